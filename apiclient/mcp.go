@@ -0,0 +1,121 @@
+package apiclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/obot-platform/obot/apiclient/types"
+)
+
+func (c *Client) GetMCPCatalogEntry(ctx context.Context, id string) (types.MCPServerCatalogEntry, error) {
+	_, resp, err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/all-mcps/entries/%s", url.PathEscape(id)), nil)
+	if err != nil {
+		return types.MCPServerCatalogEntry{}, err
+	}
+
+	var result types.MCPServerCatalogEntry
+	_, err = toObject(resp, &result)
+	return result, err
+}
+
+// GetAccessibleMCPServer returns a server the caller can connect to, whether it
+// is a personal server or one shared from a catalog or workspace.
+func (c *Client) GetAccessibleMCPServer(ctx context.Context, id string) (types.MCPServer, error) {
+	_, resp, err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/all-mcps/servers/%s", url.PathEscape(id)), nil)
+	if err != nil {
+		return types.MCPServer{}, err
+	}
+
+	var result types.MCPServer
+	_, err = toObject(resp, &result)
+	return result, err
+}
+
+// ListMCPServers lists the caller's personal MCP servers.
+func (c *Client) ListMCPServers(ctx context.Context) (types.MCPServerList, error) {
+	_, resp, err := c.doRequest(ctx, http.MethodGet, "/mcp-servers", nil)
+	if err != nil {
+		return types.MCPServerList{}, err
+	}
+
+	var result types.MCPServerList
+	_, err = toObject(resp, &result)
+	return result, err
+}
+
+func (c *Client) GetMCPServer(ctx context.Context, id string) (types.MCPServer, error) {
+	_, resp, err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/mcp-servers/%s", url.PathEscape(id)), nil)
+	if err != nil {
+		return types.MCPServer{}, err
+	}
+
+	var result types.MCPServer
+	_, err = toObject(resp, &result)
+	return result, err
+}
+
+func (c *Client) CreateMCPServer(ctx context.Context, server types.MCPServer) (types.MCPServer, error) {
+	_, resp, err := c.postJSON(ctx, "/mcp-servers", server)
+	if err != nil {
+		return types.MCPServer{}, err
+	}
+
+	var result types.MCPServer
+	_, err = toObject(resp, &result)
+	return result, err
+}
+
+// ConfigureMCPServer sets the user-supplied env and header values of a
+// personal MCP server.
+func (c *Client) ConfigureMCPServer(ctx context.Context, id string, values map[string]string) (types.MCPServer, error) {
+	_, resp, err := c.postJSON(ctx, fmt.Sprintf("/mcp-servers/%s/configure", url.PathEscape(id)), values)
+	if err != nil {
+		return types.MCPServer{}, err
+	}
+
+	var result types.MCPServer
+	_, err = toObject(resp, &result)
+	return result, err
+}
+
+func (c *Client) CreateAPIKey(ctx context.Context, req types.APIKeyCreateRequest) (types.APIKeyCreateResponse, error) {
+	_, resp, err := c.postJSON(ctx, "/api-keys", req)
+	if err != nil {
+		return types.APIKeyCreateResponse{}, err
+	}
+
+	var result types.APIKeyCreateResponse
+	_, err = toObject(resp, &result)
+	return result, err
+}
+
+func (c *Client) DeleteAPIKey(ctx context.Context, id uint) error {
+	_, resp, err := c.doRequest(ctx, http.MethodDelete, fmt.Sprintf("/api-keys/%d", id), nil)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// APIKeyCanAccessMCP reports whether key is valid for the client's server and
+// may connect to the MCP server or catalog entry identified by mcpID.
+func (c *Client) APIKeyCanAccessMCP(ctx context.Context, key, mcpID string) error {
+	keyClient := &Client{BaseURL: c.BaseURL, Token: key}
+	_, resp, err := keyClient.postJSON(ctx, "/api-keys/auth", map[string]string{"mcpId": mcpID})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var validation tokenScopeValidationResponse
+	if err := json.NewDecoder(resp.Body).Decode(&validation); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	if !validation.Allowed {
+		return fmt.Errorf("API key is not allowed to access MCP server %s", mcpID)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package apiclient
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateAPIKeySendsMCPServerScopes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "/api/api-keys", r.URL.Path)
+		require.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+
+		var req types.APIKeyCreateRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		require.Equal(t, "obot mcp install", req.Name)
+		require.Equal(t, []string{"ms1abc"}, req.MCPServerIDs)
+
+		w.WriteHeader(http.StatusCreated)
+		require.NoError(t, json.NewEncoder(w).Encode(types.APIKeyCreateResponse{
+			ID:           7,
+			Name:         req.Name,
+			MCPServerIDs: req.MCPServerIDs,
+			Key:          "ok1-7-secret",
+		}))
+	}))
+	defer server.Close()
+
+	result, err := (&Client{BaseURL: server.URL + "/api", Token: "test-token"}).CreateAPIKey(t.Context(), types.APIKeyCreateRequest{
+		Name:         "obot mcp install",
+		MCPServerIDs: []string{"ms1abc"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, uint(7), result.ID)
+	require.Equal(t, "ok1-7-secret", result.Key)
+}
+
+func TestConfigureMCPServerPostsValues(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "/api/mcp-servers/ms1abc/configure", r.URL.Path)
+
+		var values map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&values))
+		require.Equal(t, map[string]string{"API_TOKEN": "secret"}, values)
+
+		require.NoError(t, json.NewEncoder(w).Encode(types.MCPServer{
+			Metadata:   types.Metadata{ID: "ms1abc"},
+			Configured: true,
+		}))
+	}))
+	defer server.Close()
+
+	result, err := (&Client{BaseURL: server.URL + "/api"}).ConfigureMCPServer(t.Context(), "ms1abc", map[string]string{"API_TOKEN": "secret"})
+	require.NoError(t, err)
+	require.True(t, result.Configured)
+}
+
+func TestAPIKeyCanAccessMCP(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/api-keys/auth", r.URL.Path)
+
+		var body map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		if r.Header.Get("Authorization") == "Bearer revoked" {
+			// An error status fails the check whatever the body says.
+			w.WriteHeader(http.StatusUnauthorized)
+			require.NoError(t, json.NewEncoder(w).Encode(map[string]any{"allowed": true}))
+			return
+		}
+		allowed := r.Header.Get("Authorization") == "Bearer good" && body["mcpId"] == "ms1abc"
+		require.NoError(t, json.NewEncoder(w).Encode(map[string]any{"allowed": allowed}))
+	}))
+	defer server.Close()
+
+	client := &Client{BaseURL: server.URL + "/api", Token: "cli-token"}
+	require.NoError(t, client.APIKeyCanAccessMCP(t.Context(), "good", "ms1abc"))
+	require.Error(t, client.APIKeyCanAccessMCP(t.Context(), "bad", "ms1abc"))
+	require.Error(t, client.APIKeyCanAccessMCP(t.Context(), "good", "ms1other"))
+	require.Error(t, client.APIKeyCanAccessMCP(t.Context(), "revoked", "ms1abc"))
+}
@@ -0,0 +1,72 @@
+package apiclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/obot-platform/obot/apiclient/types"
+)
+
+// PublishArtifactOptions describes how an artifact ZIP is published.
+type PublishArtifactOptions struct {
+	// ArtifactType defaults to a workflow on the server.
+	ArtifactType types.PublishedArtifactType
+	// Description of the new version. Defaults to the SKILL.md description.
+	Description string
+	// Subjects who may access the new version. Defaults to those of the
+	// previous version.
+	Subjects []types.Subject
+}
+
+// PublishArtifact uploads an artifact ZIP, creating the artifact or adding a
+// new version to the caller's existing artifact of the same name and type.
+func (c *Client) PublishArtifact(ctx context.Context, data []byte, opts PublishArtifactOptions) (types.PublishedArtifact, error) {
+	values := url.Values{}
+	if opts.ArtifactType != "" {
+		values.Set("type", string(opts.ArtifactType))
+	}
+	if opts.Description != "" {
+		values.Set("description", opts.Description)
+	}
+	if len(opts.Subjects) > 0 {
+		subjects, err := json.Marshal(opts.Subjects)
+		if err != nil {
+			return types.PublishedArtifact{}, err
+		}
+		values.Set("subjects", string(subjects))
+	}
+
+	path := "/published-artifacts"
+	if encoded := values.Encode(); encoded != "" {
+		path += "?" + encoded
+	}
+
+	_, resp, err := c.doRequest(ctx, http.MethodPost, path, bytes.NewReader(data), "Content-Type", "application/zip")
+	if err != nil {
+		return types.PublishedArtifact{}, err
+	}
+
+	var result types.PublishedArtifact
+	_, err = toObject(resp, &result)
+	return result, err
+}
+
+func (c *Client) UpdatePublishedArtifact(ctx context.Context, id string, update types.PublishedArtifactUpdateRequest) (types.PublishedArtifact, error) {
+	data, err := json.Marshal(update)
+	if err != nil {
+		return types.PublishedArtifact{}, err
+	}
+
+	_, resp, err := c.doRequest(ctx, http.MethodPut, fmt.Sprintf("/published-artifacts/%s", url.PathEscape(id)), bytes.NewReader(data), "Content-Type", "application/json")
+	if err != nil {
+		return types.PublishedArtifact{}, err
+	}
+
+	var result types.PublishedArtifact
+	_, err = toObject(resp, &result)
+	return result, err
+}
@@ -74,8 +74,30 @@ func (c *Client) PreviewSkill(ctx context.Context, id string) ([]byte, error) {
 	return data, nil
 }
 
+func (c *Client) ListSkillRevisions(ctx context.Context, id string) (types.SkillRevisionList, error) {
+	_, resp, err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/skills/%s/revisions", url.PathEscape(id)), nil)
+	if err != nil {
+		return types.SkillRevisionList{}, err
+	}
+
+	var result types.SkillRevisionList
+	_, err = toObject(resp, &result)
+	return result, err
+}
+
 func (c *Client) DownloadSkill(ctx context.Context, id string) ([]byte, error) {
-	_, resp, err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/skills/%s/download", url.PathEscape(id)), nil)
+	return c.DownloadSkillRevision(ctx, id, "")
+}
+
+// DownloadSkillRevision downloads the skill content indexed at the given commit
+// SHA. An empty commit SHA downloads the latest revision.
+func (c *Client) DownloadSkillRevision(ctx context.Context, id, commitSHA string) ([]byte, error) {
+	path := fmt.Sprintf("/skills/%s/download", url.PathEscape(id))
+	if commitSHA != "" {
+		path += "?" + url.Values{"revision": []string{commitSHA}}.Encode()
+	}
+
+	_, resp, err := c.doRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -148,3 +148,34 @@ func TestSkillHelpersPropagateHTTPErrors(t *testing.T) {
 	require.ErrorAs(t, err, &httpErr)
 	require.Equal(t, http.StatusTeapot, httpErr.Code)
 }
+
+func TestListSkillRevisionsDecodesResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+		require.Equal(t, "/skills/sk1/revisions", r.URL.Path)
+		require.NoError(t, json.NewEncoder(w).Encode(types.SkillRevisionList{
+			Items: []types.SkillRevision{{CommitSHA: "abc123", InstallHash: "h1", Current: true, Installable: true}},
+		}))
+	}))
+	defer server.Close()
+
+	result, err := (&Client{BaseURL: server.URL}).ListSkillRevisions(t.Context(), "sk1")
+	require.NoError(t, err)
+	require.Len(t, result.Items, 1)
+	require.Equal(t, "abc123", result.Items[0].CommitSHA)
+	require.True(t, result.Items[0].Current)
+}
+
+func TestDownloadSkillRevisionSetsRevisionQuery(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/skills/sk1/download", r.URL.Path)
+		require.Equal(t, "abc123", r.URL.Query().Get("revision"))
+		_, err := w.Write([]byte("zip"))
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	got, err := (&Client{BaseURL: server.URL}).DownloadSkillRevision(t.Context(), "sk1", "abc123")
+	require.NoError(t, err)
+	require.Equal(t, []byte("zip"), got)
+}
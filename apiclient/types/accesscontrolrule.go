@@ -8,6 +8,13 @@ const (
 	SubjectTypeGroup    SubjectType = "group"
 	SubjectTypeUser     SubjectType = "user"
 	SubjectTypeSelector SubjectType = "selector"
+	// SubjectTypeAPIKey matches calls made with an API key. Its ID is the API
+	// key ID. Only MCP quota rules and approval policies accept it.
+	SubjectTypeAPIKey SubjectType = "apiKey"
+	// SubjectTypeHostedAgent matches calls made by a hosted agent. Its ID is
+	// the hosted agent ID. Only MCP quota rules and approval policies accept
+	// it.
+	SubjectTypeHostedAgent SubjectType = "hostedAgent"
 
 	ResourceTypeMCPServerCatalogEntry ResourceType = "mcpServerCatalogEntry"
 	ResourceTypeMCPServer             ResourceType = "mcpServer"
@@ -28,6 +35,9 @@ type AccessControlRuleManifest struct {
 	DisplayName string     `json:"displayName,omitempty"`
 	Subjects    []Subject  `json:"subjects,omitempty"`
 	Resources   []Resource `json:"resources,omitempty"`
+	// ToolConstraints restrict the arguments the rule's subjects may call the
+	// rule's resources' tools with.
+	ToolConstraints []ToolArgumentConstraint `json:"toolConstraints,omitempty"`
 }
 
 type Subject struct {
@@ -57,6 +67,11 @@ func (a AccessControlRuleManifest) Validate() error {
 			return fmt.Errorf("invalid subject: %v", err)
 		}
 	}
+	for _, constraint := range a.ToolConstraints {
+		if err := constraint.Validate(); err != nil {
+			return fmt.Errorf("invalid tool constraint: %v", err)
+		}
+	}
 	return nil
 }
 
@@ -0,0 +1,133 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// DefaultAccessRequestMaxDurationHours caps requested access when the
+	// access request setting does not set its own limit.
+	DefaultAccessRequestMaxDurationHours = 24 * 7
+)
+
+// AccessRequestResourceType is the kind of resource temporary access can be
+// requested to.
+type AccessRequestResourceType string
+
+const (
+	AccessRequestResourceTypeMCPServerCatalogEntry AccessRequestResourceType = "mcpServerCatalogEntry"
+	AccessRequestResourceTypeMCPServer             AccessRequestResourceType = "mcpServer"
+	AccessRequestResourceTypeModel                 AccessRequestResourceType = "model"
+	AccessRequestResourceTypeSkill                 AccessRequestResourceType = "skill"
+)
+
+type AccessRequestState string
+
+const (
+	AccessRequestStatePending   AccessRequestState = "pending"
+	AccessRequestStateApproved  AccessRequestState = "approved"
+	AccessRequestStateDenied    AccessRequestState = "denied"
+	AccessRequestStateCancelled AccessRequestState = "cancelled"
+	AccessRequestStateRevoked   AccessRequestState = "revoked"
+	AccessRequestStateExpired   AccessRequestState = "expired"
+)
+
+// AccessRequestManifest is what a user submits to ask for temporary access.
+type AccessRequestManifest struct {
+	ResourceType  AccessRequestResourceType `json:"resourceType"`
+	ResourceID    string                    `json:"resourceID"`
+	Justification string                    `json:"justification"`
+	// DurationHours is how long access is requested for, counted from approval.
+	DurationHours int `json:"durationHours"`
+}
+
+// AccessRequest is a user's request for temporary access to a resource. Once
+// approved, Obot grants access through a generated access control rule, model
+// access policy or skill access rule, and removes it when access expires.
+type AccessRequest struct {
+	Metadata              `json:",inline"`
+	AccessRequestManifest `json:",inline"`
+	RequesterID           string             `json:"requesterID"`
+	State                 AccessRequestState `json:"state"`
+	// DecidedBy is the user who approved or denied the request.
+	DecidedBy       string `json:"decidedBy,omitempty"`
+	DecidedAt       *Time  `json:"decidedAt,omitempty"`
+	DecisionComment string `json:"decisionComment,omitempty"`
+	ExpiresAt       *Time  `json:"expiresAt,omitempty"`
+	// GrantID is the ID of the rule or policy that grants the access while the
+	// request is approved.
+	GrantID string               `json:"grantID,omitempty"`
+	History []AccessRequestEvent `json:"history,omitempty"`
+}
+
+// AccessRequestEvent is one step in an access request's life.
+type AccessRequestEvent struct {
+	Time    Time               `json:"time"`
+	State   AccessRequestState `json:"state"`
+	ActorID string             `json:"actorID,omitempty"`
+	Comment string             `json:"comment,omitempty"`
+}
+
+type AccessRequestList List[AccessRequest]
+
+// AccessRequestDecision is the body of an approve, deny, cancel or revoke
+// request. DurationHours may shorten the access an approval grants.
+type AccessRequestDecision struct {
+	Comment       string `json:"comment,omitempty"`
+	DurationHours int    `json:"durationHours,omitempty"`
+}
+
+// AccessRequestSetting configures who can decide access requests.
+type AccessRequestSetting struct {
+	// Approvers can approve and deny access requests, in addition to owners and
+	// admins.
+	Approvers []Subject `json:"approvers,omitempty"`
+	// MaxDurationHours caps the access a request can ask for. It defaults to a
+	// week.
+	MaxDurationHours int `json:"maxDurationHours,omitempty"`
+}
+
+func (m AccessRequestManifest) Validate(maxDurationHours int) error {
+	switch m.ResourceType {
+	case AccessRequestResourceTypeMCPServerCatalogEntry, AccessRequestResourceTypeMCPServer, AccessRequestResourceTypeModel, AccessRequestResourceTypeSkill:
+	default:
+		return fmt.Errorf("invalid resource type: %s", m.ResourceType)
+	}
+	if m.ResourceID == "" {
+		return fmt.Errorf("resource ID is required")
+	}
+	if strings.TrimSpace(m.Justification) == "" {
+		return fmt.Errorf("justification is required")
+	}
+	if m.DurationHours <= 0 {
+		return fmt.Errorf("duration must be at least one hour")
+	}
+	if m.DurationHours > maxDurationHours {
+		return fmt.Errorf("duration must be at most %d hours", maxDurationHours)
+	}
+	return nil
+}
+
+func (s AccessRequestSetting) Validate() error {
+	for _, approver := range s.Approvers {
+		if approver.Type == SubjectTypeSelector {
+			return fmt.Errorf("approvers must be users or groups")
+		}
+		if err := approver.Validate(); err != nil {
+			return fmt.Errorf("invalid approver: %w", err)
+		}
+	}
+	if s.MaxDurationHours < 0 {
+		return fmt.Errorf("max duration must not be negative")
+	}
+	return nil
+}
+
+// MaxDuration returns the longest access a request can ask for, in hours.
+func (s AccessRequestSetting) MaxDuration() int {
+	if s.MaxDurationHours > 0 {
+		return s.MaxDurationHours
+	}
+	return DefaultAccessRequestMaxDurationHours
+}
@@ -0,0 +1,67 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccessRequestManifestValidate(t *testing.T) {
+	valid := AccessRequestManifest{
+		ResourceType:  AccessRequestResourceTypeMCPServerCatalogEntry,
+		ResourceID:    "entry1",
+		Justification: "on-call",
+		DurationHours: 8,
+	}
+	for _, tt := range []struct {
+		name     string
+		modify   func(*AccessRequestManifest)
+		errorMsg string
+	}{
+		{
+			name:   "valid request",
+			modify: func(*AccessRequestManifest) {},
+		},
+		{
+			name:     "unknown resource type",
+			modify:   func(m *AccessRequestManifest) { m.ResourceType = "mcpCatalog" },
+			errorMsg: "invalid resource type",
+		},
+		{
+			name:     "missing justification",
+			modify:   func(m *AccessRequestManifest) { m.Justification = "  " },
+			errorMsg: "justification is required",
+		},
+		{
+			name:     "no duration",
+			modify:   func(m *AccessRequestManifest) { m.DurationHours = 0 },
+			errorMsg: "at least one hour",
+		},
+		{
+			name:     "duration over the limit",
+			modify:   func(m *AccessRequestManifest) { m.DurationHours = 25 },
+			errorMsg: "at most 24 hours",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			manifest := valid
+			tt.modify(&manifest)
+			err := manifest.Validate(24)
+
+			if tt.errorMsg != "" {
+				require.Error(t, err)
+				assert.ErrorContains(t, err, tt.errorMsg)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestAccessRequestSettingValidate(t *testing.T) {
+	require.NoError(t, AccessRequestSetting{Approvers: []Subject{{Type: SubjectTypeGroup, ID: "security"}}}.Validate())
+	assert.ErrorContains(t, AccessRequestSetting{Approvers: []Subject{{Type: SubjectTypeSelector, ID: "*"}}}.Validate(), "users or groups")
+	assert.Equal(t, DefaultAccessRequestMaxDurationHours, AccessRequestSetting{}.MaxDuration())
+}
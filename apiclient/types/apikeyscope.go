@@ -12,3 +12,22 @@ const (
 func DefaultCLIAPIKeyScopes() []string {
 	return []string{APIKeyScopeLLM, APIKeyScopeDeviceScans, APIKeyScopeSkills}
 }
+
+// APIKeyCreateRequest creates an API key for the calling user. MCPServerIDs
+// limits the key to the listed MCP servers.
+type APIKeyCreateRequest struct {
+	Name         string   `json:"name"`
+	Description  string   `json:"description,omitempty"`
+	ExpiresAt    *Time    `json:"expiresAt,omitempty"`
+	MCPServerIDs []string `json:"mcpServerIds,omitempty"`
+}
+
+// APIKeyCreateResponse is returned once, when an API key is created. Key is
+// the only copy of the secret.
+type APIKeyCreateResponse struct {
+	ID           uint     `json:"id"`
+	Name         string   `json:"name"`
+	Description  string   `json:"description,omitempty"`
+	MCPServerIDs []string `json:"mcpServerIds,omitempty"`
+	Key          string   `json:"key"`
+}
@@ -156,6 +156,9 @@ type AuditLogDetails struct {
 	RawEvent        json.RawMessage             `json:"rawEvent,omitempty"`
 	StartedAt       *Time                       `json:"startedAt,omitempty"`
 	PayloadRedacted bool                        `json:"payloadRedacted"`
+	// ContentRedacted reports that secrets or PII were redacted from the payloads before they
+	// were stored, independent of the caller's payload access.
+	ContentRedacted bool `json:"contentRedacted,omitempty"`
 }
 
 // AuditLogTraceDetails groups identifiers that correlate an event with requests, sessions, tool
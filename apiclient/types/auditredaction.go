@@ -0,0 +1,51 @@
+package types
+
+// AuditRedactionDetector names a built-in detector that redacts matching values from stored
+// audit payloads.
+type AuditRedactionDetector string
+
+const (
+	// AuditRedactionDetectorAPIKey matches well-known API key and bearer token formats.
+	AuditRedactionDetectorAPIKey AuditRedactionDetector = "api_key"
+	// AuditRedactionDetectorJWT matches JSON Web Tokens.
+	AuditRedactionDetectorJWT AuditRedactionDetector = "jwt"
+	// AuditRedactionDetectorEmail matches email addresses.
+	AuditRedactionDetectorEmail AuditRedactionDetector = "email"
+	// AuditRedactionDetectorCreditCard matches payment card numbers that pass a Luhn check.
+	AuditRedactionDetectorCreditCard AuditRedactionDetector = "credit_card"
+	// AuditRedactionDetectorPrivateKey matches PEM-encoded private keys.
+	AuditRedactionDetectorPrivateKey AuditRedactionDetector = "private_key"
+)
+
+// AuditRedactionDetectors lists every built-in detector in the order they are applied.
+var AuditRedactionDetectors = []AuditRedactionDetector{
+	AuditRedactionDetectorPrivateKey,
+	AuditRedactionDetectorJWT,
+	AuditRedactionDetectorAPIKey,
+	AuditRedactionDetectorCreditCard,
+	AuditRedactionDetectorEmail,
+}
+
+// AuditRedactionSetting configures how LLM and MCP audit payloads are redacted before they are
+// persisted. All built-in detectors are enabled unless listed in DisabledDetectors.
+type AuditRedactionSetting struct {
+	// Disabled stores audit payloads without redaction.
+	Disabled bool `json:"disabled,omitempty"`
+	// DisabledDetectors turns off individual built-in detectors.
+	DisabledDetectors []AuditRedactionDetector `json:"disabledDetectors,omitempty"`
+	// CustomPatterns are additional regular expressions whose matches are redacted.
+	CustomPatterns []AuditRedactionPattern `json:"customPatterns,omitempty"`
+	// FieldMasks are dot-separated JSON paths whose values are always redacted. A "*" segment
+	// matches any single key or array index, and a "**" segment matches any number of segments.
+	FieldMasks []string `json:"fieldMasks,omitempty"`
+	// RetainUnredacted keeps an encrypted copy of the original payloads that only owners with the
+	// auditor role can read.
+	RetainUnredacted bool `json:"retainUnredacted,omitempty"`
+}
+
+// AuditRedactionPattern is an admin-defined regular expression. Name appears in the redaction
+// placeholder so auditors can tell which rule matched.
+type AuditRedactionPattern struct {
+	Name    string `json:"name"`
+	Pattern string `json:"pattern"`
+}
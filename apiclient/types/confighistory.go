@@ -0,0 +1,53 @@
+package types
+
+import "encoding/json"
+
+// ConfigChange is one revision in the history of a governance object, such as
+// a model access policy or an MDM configuration's enforcement allowlist.
+// Before and After hold the object's configuration on either side of the
+// change; Before is empty when it was created and After when it was deleted.
+type ConfigChange struct {
+	ID        uint               `json:"id"`
+	CreatedAt Time               `json:"createdAt"`
+	Kind      string             `json:"kind"`
+	ObjectID  string             `json:"objectID"`
+	Action    ConfigChangeAction `json:"action"`
+	ActorID   string             `json:"actorID,omitempty"`
+	ActorName string             `json:"actorName,omitempty"`
+	// RevertedFrom is the revision that a reverted change restored.
+	RevertedFrom uint               `json:"revertedFrom,omitempty"`
+	Before       json.RawMessage    `json:"before,omitempty"`
+	After        json.RawMessage    `json:"after,omitempty"`
+	Diff         []ConfigChangeDiff `json:"diff"`
+}
+
+// Kinds of governance object whose changes are recorded.
+const (
+	ConfigChangeKindModelAccessPolicy           = "ModelAccessPolicy"
+	ConfigChangeKindAccessControlRule           = "AccessControlRule"
+	ConfigChangeKindMessagePolicy               = "MessagePolicy"
+	ConfigChangeKindMDMConfigurationEnforcement = "MDMConfigurationEnforcement"
+	ConfigChangeKindMCPQuotaRule                = "MCPQuotaRule"
+	ConfigChangeKindMCPToolApprovalPolicy       = "MCPToolApprovalPolicy"
+	ConfigChangeKindMCPServerRequestPolicy      = "MCPServerRequestPolicy"
+)
+
+type ConfigChangeAction string
+
+const (
+	ConfigChangeActionCreated  ConfigChangeAction = "created"
+	ConfigChangeActionUpdated  ConfigChangeAction = "updated"
+	ConfigChangeActionDeleted  ConfigChangeAction = "deleted"
+	ConfigChangeActionReverted ConfigChangeAction = "reverted"
+)
+
+// ConfigChangeDiff is one value that a change added, removed or replaced.
+// Path is a JSON pointer into the configuration. Before is omitted for an
+// added value and After for a removed one.
+type ConfigChangeDiff struct {
+	Path   string          `json:"path"`
+	Before json.RawMessage `json:"before,omitempty"`
+	After  json.RawMessage `json:"after,omitempty"`
+}
+
+type ConfigChangeList List[ConfigChange]
@@ -0,0 +1,85 @@
+package types
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// ConfigRepository is a git repository of governance manifests, such as access
+// control rules and model access policies, that Obot keeps the storage API in
+// sync with. Objects it manages are read-only through the API.
+type ConfigRepository struct {
+	Metadata                 `json:",inline"`
+	ConfigRepositoryManifest `json:",inline"`
+	LastSyncTime             Time                     `json:"lastSyncTime,omitzero"`
+	IsSyncing                bool                     `json:"isSyncing,omitempty"`
+	SyncError                string                   `json:"syncError,omitempty"`
+	ResolvedCommitSHA        string                   `json:"resolvedCommitSHA,omitempty"`
+	Objects                  []ConfigRepositoryObject `json:"objects"`
+}
+
+type ConfigRepositoryManifest struct {
+	DisplayName string `json:"displayName,omitempty"`
+	RepoURL     string `json:"repoURL,omitempty"`
+	Ref         string `json:"ref,omitempty"`
+	// Path is the directory in the repository that manifests are read from,
+	// including its subdirectories. The repository root if empty.
+	Path            string `json:"path,omitempty"`
+	GitCredentialID string `json:"gitCredentialID,omitempty"`
+	// Prune deletes managed objects whose manifests are removed from the
+	// repository. Without it they are released, and become editable again.
+	Prune bool `json:"prune,omitempty"`
+	// Adopt lets a manifest take over an object of the same name that was
+	// created outside the repository. Without it such a manifest is an error.
+	Adopt bool `json:"adopt,omitempty"`
+}
+
+type ConfigRepositoryAction string
+
+const (
+	ConfigRepositoryActionCreated   ConfigRepositoryAction = "created"
+	ConfigRepositoryActionUpdated   ConfigRepositoryAction = "updated"
+	ConfigRepositoryActionUnchanged ConfigRepositoryAction = "unchanged"
+	ConfigRepositoryActionAdopted   ConfigRepositoryAction = "adopted"
+	ConfigRepositoryActionPruned    ConfigRepositoryAction = "pruned"
+	ConfigRepositoryActionReleased  ConfigRepositoryAction = "released"
+	ConfigRepositoryActionFailed    ConfigRepositoryAction = "failed"
+)
+
+// ConfigRepositoryObject is the outcome of the last sync for one manifest, or
+// for one managed object the repository no longer contains. Kind and Name are
+// empty for a manifest that could not be parsed.
+type ConfigRepositoryObject struct {
+	Kind   string                 `json:"kind,omitempty"`
+	Name   string                 `json:"name,omitempty"`
+	File   string                 `json:"file,omitempty"`
+	Action ConfigRepositoryAction `json:"action"`
+	Error  string                 `json:"error,omitempty"`
+}
+
+type ConfigRepositoryList List[ConfigRepository]
+
+func (m ConfigRepositoryManifest) Validate() error {
+	if strings.TrimSpace(m.DisplayName) == "" {
+		return fmt.Errorf("displayName is required")
+	}
+	if strings.TrimSpace(m.RepoURL) == "" {
+		return fmt.Errorf("repoURL is required")
+	}
+	if err := validateRepoURL("repoURL", m.RepoURL); err != nil {
+		return err
+	}
+	if err := ValidateGitRef(m.Ref); err != nil {
+		return err
+	}
+	if m.Path != "" {
+		if path.IsAbs(m.Path) || strings.Contains(m.Path, "\\") {
+			return fmt.Errorf("path must be relative to the repository root")
+		}
+		if cleaned := path.Clean(m.Path); cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+			return fmt.Errorf("path must not leave the repository")
+		}
+	}
+	return nil
+}
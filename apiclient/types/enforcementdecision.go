@@ -3,6 +3,12 @@ package types
 const (
 	EnforcementDecisionAllow = "allow"
 	EnforcementDecisionDeny  = "deny"
+
+	// EnforcementDecisionSourceDevice marks a decision requested by a device's
+	// pre-tool hook, and EnforcementDecisionSourceLLMGateway one made by the LLM
+	// gateway for a tool call in a model response.
+	EnforcementDecisionSourceDevice     = "device"
+	EnforcementDecisionSourceLLMGateway = "llm_gateway"
 )
 
 // EnforcementDecisionServer is the resolved target MCP server of a normalized tool call.
@@ -45,6 +51,8 @@ type EnforcementDecisionResponse struct {
 type EnforcementDecisionEvent struct {
 	ID                 string                     `json:"id"`
 	CreatedAt          Time                       `json:"createdAt"`
+	Source             string                     `json:"source"`
+	UserID             string                     `json:"userID,omitempty"`
 	MDMConfigurationID uint                       `json:"mdmConfigurationID"`
 	DeviceID           string                     `json:"deviceID,omitempty"`
 	ClientIP           string                     `json:"clientIP,omitempty"`
@@ -75,8 +83,8 @@ type EnforcementDecisionEventResponse struct {
 }
 
 // EnforcementDecisionAllowlistCheck is the result of replaying a recorded
-// decision against its fleet's current allowlist: would this call be allowed if
-// it were made now? The decision log is append-only evidence of what devices
+// decision against its fleet's current allowlist, or the LLM gateway's for a
+// gateway decision: would this call be allowed if it were made now? The decision log is append-only evidence of what devices
 // were told, so asking this question records nothing.
 type EnforcementDecisionAllowlistCheck struct {
 	ID                 string `json:"id"`
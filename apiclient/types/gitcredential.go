@@ -1,18 +1,39 @@
 package types
 
-// GitCredential represents an admin-managed credential for HTTPS Git repositories.
+// GitCredentialType identifies how a Git credential authenticates.
+type GitCredentialType string
+
+const (
+	// GitCredentialTypeToken authenticates HTTPS clones with an access token.
+	GitCredentialTypeToken GitCredentialType = "token"
+	// GitCredentialTypeSSHKey authenticates SSH clones with a private key,
+	// accepting only the host keys listed in the credential's known hosts.
+	GitCredentialTypeSSHKey GitCredentialType = "sshKey"
+	// GitCredentialTypeGitHubApp authenticates HTTPS clones with short-lived
+	// installation tokens minted from a GitHub App's private key.
+	GitCredentialTypeGitHubApp GitCredentialType = "githubApp"
+)
+
+// GitCredential represents an admin-managed credential for Git repositories.
 type GitCredential struct {
 	Metadata
-	DisplayName     string            `json:"displayName,omitempty"`
-	Host            string            `json:"host,omitempty"`
-	TokenConfigured bool              `json:"tokenConfigured,omitempty"`
-	Uses            GitCredentialUses `json:"uses"`
+	DisplayName string `json:"displayName,omitempty"`
+	Host        string `json:"host,omitempty"`
+	// Type is the kind of credential. Empty means GitCredentialTypeToken.
+	Type                    GitCredentialType `json:"type,omitempty"`
+	KnownHosts              string            `json:"knownHosts,omitempty"`
+	GitHubAppID             string            `json:"githubAppID,omitempty"`
+	GitHubAppInstallationID string            `json:"githubAppInstallationID,omitempty"`
+	TokenConfigured         bool              `json:"tokenConfigured,omitempty"`
+	PrivateKeyConfigured    bool              `json:"privateKeyConfigured,omitempty"`
+	Uses                    GitCredentialUses `json:"uses"`
 }
 
 type GitCredentialUses struct {
-	SkillRepositories []GitCredentialUse `json:"skillRepositories"`
-	MCPCatalogs       []GitCredentialUse `json:"mcpCatalogs"`
-	SystemMCPCatalogs []GitCredentialUse `json:"systemMcpCatalogs"`
+	SkillRepositories  []GitCredentialUse `json:"skillRepositories"`
+	MCPCatalogs        []GitCredentialUse `json:"mcpCatalogs"`
+	SystemMCPCatalogs  []GitCredentialUse `json:"systemMcpCatalogs"`
+	ConfigRepositories []GitCredentialUse `json:"configRepositories"`
 }
 
 type GitCredentialUse struct {
@@ -22,9 +43,19 @@ type GitCredentialUse struct {
 
 // GitCredentialManifest is accepted when creating or updating a Git credential.
 type GitCredentialManifest struct {
-	DisplayName string `json:"displayName,omitempty"`
-	Host        string `json:"host,omitempty"`
-	Token       string `json:"token,omitempty"`
+	DisplayName string            `json:"displayName,omitempty"`
+	Host        string            `json:"host,omitempty"`
+	Type        GitCredentialType `json:"type,omitempty"`
+	// Token is used by token credentials.
+	Token string `json:"token,omitempty"`
+	// PrivateKey is the PEM-encoded SSH key of an sshKey credential or the
+	// app private key of a githubApp credential.
+	PrivateKey string `json:"privateKey,omitempty"`
+	// KnownHosts lists the accepted SSH host keys of an sshKey credential, in
+	// known_hosts format.
+	KnownHosts              string `json:"knownHosts,omitempty"`
+	GitHubAppID             string `json:"githubAppID,omitempty"`
+	GitHubAppInstallationID string `json:"githubAppInstallationID,omitempty"`
 }
 
 type GitCredentialList List[GitCredential]
@@ -18,6 +18,10 @@ const (
 	HostedAgentStatePending HostedAgentState = "pending"
 	HostedAgentStateReady   HostedAgentState = "ready"
 	HostedAgentStateError   HostedAgentState = "error"
+	HostedAgentStateStopped HostedAgentState = "stopped"
+
+	HostedAgentDesiredStateRunning HostedAgentDesiredState = "running"
+	HostedAgentDesiredStateStopped HostedAgentDesiredState = "stopped"
 )
 
 type HostedAgent struct {
@@ -101,6 +105,11 @@ type HostedAgentManifest struct {
 	// Administrator-controlled: a terminal is direct command execution inside
 	// the sandbox, so whether an agent offers one is not the user's choice.
 	Terminal bool `json:"terminal,omitempty"`
+
+	// IdleTimeoutMinutes hibernates an instance after this long without a
+	// terminal session or HTTP request. Its workspace is kept, and the next
+	// connection starts it again. Zero falls back to the pool's timeout.
+	IdleTimeoutMinutes int `json:"idleTimeoutMinutes,omitempty"`
 }
 
 // HostedAgentQuestion defines a single value collected from the user when they
@@ -137,6 +146,10 @@ type HostedAgentEnv struct {
 // themselves are templates and carry no state; only instances run.
 type HostedAgentState string
 
+// HostedAgentDesiredState is whether the user wants an instance running. It is
+// separate from HostedAgentState, which is what the backend observed.
+type HostedAgentDesiredState string
+
 type HostedAgentList List[HostedAgent]
 
 func (m HostedAgentManifest) Validate() error {
@@ -164,6 +177,9 @@ func (m HostedAgentManifest) Validate() error {
 	if m.Port < 0 || m.Port > 65535 {
 		return fmt.Errorf("port must be between 0 and 65535")
 	}
+	if m.IdleTimeoutMinutes < 0 {
+		return fmt.Errorf("idleTimeoutMinutes must be greater than or equal to 0")
+	}
 
 	keys := make(map[string]struct{}, len(m.Env))
 	for _, env := range m.Env {
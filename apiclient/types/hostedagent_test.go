@@ -190,6 +190,13 @@ func TestHostedAgentManifestValidate(t *testing.T) {
 		assert.Contains(t, err.Error(), "maxInstancesPerUser must be greater than or equal to 0")
 	})
 
+	t.Run("negative idle timeout rejected", func(t *testing.T) {
+		m := HostedAgentManifest{Name: "a", HarnessID: "hrn1x", IdleTimeoutMinutes: -1}
+		err := m.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "idleTimeoutMinutes must be greater than or equal to 0")
+	})
+
 	t.Run("duplicate question keys", func(t *testing.T) {
 		m := HostedAgentManifest{
 			Name: "a", HarnessID: "hrn1x",
@@ -10,6 +10,7 @@ type HostedAgentInstance struct {
 	HostedAgentID               string                    `json:"hostedAgentID,omitempty"`
 	UserID                      string                    `json:"userID,omitempty"`
 	PoolID                      string                    `json:"poolID,omitempty"`
+	DesiredState                HostedAgentDesiredState   `json:"desiredState,omitempty"`
 	Status                      HostedAgentInstanceStatus `json:"status,omitempty"`
 
 	// ResolvedIcon and ResolvedIconDark are the icon to show for this instance,
@@ -68,6 +69,18 @@ type HostedAgentInstanceStatus struct {
 
 	BackendID         string `json:"backendID,omitempty"`
 	BackendGeneration int64  `json:"backendGeneration,omitempty"`
+
+	// Hibernated is set when Obot stopped the instance for being idle, as
+	// opposed to the user stopping it. A hibernated instance starts again on
+	// the next connection.
+	Hibernated       bool  `json:"hibernated,omitempty"`
+	LastActivityTime *Time `json:"lastActivityTime,omitempty"`
+
+	// Restoring is set while a snapshot is being written into the instance's
+	// workspace. The instance is stopped until it finishes. RestoreError is why
+	// the last restore failed; the workspace is then as the failure left it.
+	Restoring    bool   `json:"restoring,omitempty"`
+	RestoreError string `json:"restoreError,omitempty"`
 }
 
 type HostedAgentInstanceList List[HostedAgentInstance]
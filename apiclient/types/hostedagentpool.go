@@ -26,6 +26,14 @@ type HostedAgentPoolManifest struct {
 	// whole pool, so raising it makes every sandbox's guaranteed share smaller.
 	MaxSandboxes int  `json:"maxSandboxes,omitempty"`
 	Suspended    bool `json:"suspended,omitempty"`
+	// IdleTimeoutMinutes hibernates the pool's instances after this long
+	// without activity, unless their agent sets its own. Zero never hibernates.
+	IdleTimeoutMinutes int `json:"idleTimeoutMinutes,omitempty"`
+	// SnapshotOnDelete snapshots an instance's workspace before deleting it,
+	// so an instance deleted by mistake can be cloned back. The snapshot is
+	// kept for SnapshotRetentionDays; zero keeps it until deleted by hand.
+	SnapshotOnDelete      bool `json:"snapshotOnDelete,omitempty"`
+	SnapshotRetentionDays int  `json:"snapshotRetentionDays,omitempty"`
 }
 
 type HostedAgentPoolStatus struct {
@@ -53,9 +61,12 @@ type HostedAgentPoolDefaults struct {
 }
 
 type HostedAgentPoolDefaultsManifest struct {
-	Capacity     HostedAgentResourceQuantity `json:"capacity"`
-	MaxSandboxes int                         `json:"maxSandboxes,omitempty"`
-	Suspended    bool                        `json:"suspended,omitempty"`
+	Capacity              HostedAgentResourceQuantity `json:"capacity"`
+	MaxSandboxes          int                         `json:"maxSandboxes,omitempty"`
+	Suspended             bool                        `json:"suspended,omitempty"`
+	IdleTimeoutMinutes    int                         `json:"idleTimeoutMinutes,omitempty"`
+	SnapshotOnDelete      bool                        `json:"snapshotOnDelete,omitempty"`
+	SnapshotRetentionDays int                         `json:"snapshotRetentionDays,omitempty"`
 }
 
 type HostedAgentPoolDefaultsList List[HostedAgentPoolDefaults]
@@ -96,6 +107,12 @@ func (m HostedAgentPoolManifest) Validate() error {
 	if m.MaxSandboxes < 0 {
 		return fmt.Errorf("maxSandboxes must be greater than or equal to zero")
 	}
+	if m.IdleTimeoutMinutes < 0 {
+		return fmt.Errorf("idleTimeoutMinutes must be greater than or equal to zero")
+	}
+	if m.SnapshotRetentionDays < 0 {
+		return fmt.Errorf("snapshotRetentionDays must be greater than or equal to zero")
+	}
 	return nil
 }
 
@@ -106,6 +123,12 @@ func (m HostedAgentPoolDefaultsManifest) Validate() error {
 	if m.MaxSandboxes < 0 {
 		return fmt.Errorf("maxSandboxes must be greater than or equal to zero")
 	}
+	if m.IdleTimeoutMinutes < 0 {
+		return fmt.Errorf("idleTimeoutMinutes must be greater than or equal to zero")
+	}
+	if m.SnapshotRetentionDays < 0 {
+		return fmt.Errorf("snapshotRetentionDays must be greater than or equal to zero")
+	}
 	return nil
 }
 
@@ -69,3 +69,21 @@ func TestHostedAgentPoolAssignmentManifestValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestHostedAgentPoolManifestRejectsNegativeSnapshotRetention(t *testing.T) {
+	capacity := HostedAgentResourceQuantity{CPUVCPUs: 4, MemoryBytes: 8 << 30, StorageBytes: 100 << 30}
+
+	pool := HostedAgentPoolManifest{Capacity: capacity, SnapshotOnDelete: true, SnapshotRetentionDays: -1}
+	if err := pool.Validate(); err == nil || !strings.Contains(err.Error(), "snapshotRetentionDays") {
+		t.Fatalf("pool Validate() error = %v, want snapshotRetentionDays error", err)
+	}
+	defaults := HostedAgentPoolDefaultsManifest{Capacity: capacity, SnapshotOnDelete: true, SnapshotRetentionDays: -1}
+	if err := defaults.Validate(); err == nil || !strings.Contains(err.Error(), "snapshotRetentionDays") {
+		t.Fatalf("defaults Validate() error = %v, want snapshotRetentionDays error", err)
+	}
+
+	pool.SnapshotRetentionDays = 0
+	if err := pool.Validate(); err != nil {
+		t.Fatalf("pool Validate() error = %v, zero retention keeps snapshots indefinitely", err)
+	}
+}
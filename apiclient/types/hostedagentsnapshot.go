@@ -0,0 +1,57 @@
+package types
+
+const (
+	HostedAgentSnapshotStatePending HostedAgentSnapshotState = "pending"
+	HostedAgentSnapshotStateReady   HostedAgentSnapshotState = "ready"
+	HostedAgentSnapshotStateError   HostedAgentSnapshotState = "error"
+)
+
+type HostedAgentSnapshotState string
+
+// HostedAgentSnapshot is a copy of a hosted agent instance's workspace, kept in
+// Obot's blob store. It outlives the instance it was taken from, so it can be
+// restored into another instance of the same agent or cloned into a new one.
+type HostedAgentSnapshot struct {
+	Metadata                    `json:",inline"`
+	HostedAgentSnapshotManifest `json:",inline"`
+	HostedAgentInstanceID       string `json:"hostedAgentInstanceID,omitempty"`
+	HostedAgentID               string `json:"hostedAgentID,omitempty"`
+	// PreDelete marks the snapshot Obot took when the instance was deleted,
+	// because its pool keeps one.
+	PreDelete bool `json:"preDelete,omitempty"`
+	// ExpiresAt is when the snapshot is removed. Nil keeps it until it is
+	// deleted by hand.
+	ExpiresAt *Time                     `json:"expiresAt,omitempty"`
+	Status    HostedAgentSnapshotStatus `json:"status,omitempty"`
+}
+
+type HostedAgentSnapshotManifest struct {
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+type HostedAgentSnapshotStatus struct {
+	State         HostedAgentSnapshotState `json:"state,omitempty"`
+	SizeBytes     int64                    `json:"sizeBytes,omitempty"`
+	CompletedTime *Time                    `json:"completedTime,omitempty"`
+	Error         string                   `json:"error,omitempty"`
+}
+
+type HostedAgentSnapshotList List[HostedAgentSnapshot]
+
+// HostedAgentSnapshotRestoreRequest replaces an instance's workspace with a
+// snapshot. The instance is stopped while its workspace is written and started
+// again afterwards, unless its user had stopped it.
+type HostedAgentSnapshotRestoreRequest struct {
+	SnapshotID string `json:"snapshotID"`
+}
+
+// HostedAgentSnapshotCloneRequest creates a new instance of the snapshot's
+// agent whose workspace starts as the snapshot. The rest of the instance --
+// its answers and attached resources -- is copied from the instance the
+// snapshot was taken from. Name overrides its display name, and PoolID places
+// it in a pool other than the caller's default.
+type HostedAgentSnapshotCloneRequest struct {
+	Name   string `json:"name,omitempty"`
+	PoolID string `json:"poolID,omitempty"`
+}
@@ -0,0 +1,124 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	HostedAgentTriggerTypeSchedule HostedAgentTriggerType = "schedule"
+	HostedAgentTriggerTypeWebhook  HostedAgentTriggerType = "webhook"
+
+	// HostedAgentTriggerSource* say what caused an invocation. Manual is an
+	// owner running a trigger by hand, which is how one is tested.
+	HostedAgentTriggerSourceSchedule = "schedule"
+	HostedAgentTriggerSourceWebhook  = "webhook"
+	HostedAgentTriggerSourceManual   = "manual"
+)
+
+// HostedAgentTriggerType is what invokes a trigger: a cron schedule evaluated
+// by Obot, or an inbound webhook from another system.
+type HostedAgentTriggerType string
+
+// HostedAgentTrigger invokes a hosted agent instance from outside a user
+// session. Every invocation is delivered as an HTTP POST to the agent's port,
+// starting the instance first if it hibernated.
+type HostedAgentTrigger struct {
+	Metadata                   `json:",inline"`
+	HostedAgentTriggerManifest `json:",inline"`
+	HostedAgentInstanceID      string                   `json:"hostedAgentInstanceID,omitempty"`
+	Status                     HostedAgentTriggerStatus `json:"status,omitempty"`
+
+	// WebhookURL is where a webhook trigger receives events. It is computed on
+	// read from Obot's address, so it follows the installation if that moves.
+	WebhookURL string `json:"webhookURL,omitempty"`
+	// WebhookSecret signs requests to WebhookURL. It is returned once, when the
+	// trigger is created, and is not readable afterwards.
+	WebhookSecret string `json:"webhookSecret,omitempty"`
+}
+
+type HostedAgentTriggerManifest struct {
+	Name string `json:"name,omitempty"`
+	// TriggerType is not named Type because it is inlined alongside
+	// Metadata, whose type field it would shadow.
+	TriggerType HostedAgentTriggerType `json:"triggerType"`
+
+	// Schedule is a cron expression, required for schedule triggers.
+	// TimeZone is the IANA zone it is evaluated in; empty means UTC.
+	Schedule string `json:"schedule,omitempty"`
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// Path is where on the agent's port the payload is posted. Empty means "/".
+	Path string `json:"path,omitempty"`
+	// Payload is the JSON body a schedule delivers. A webhook delivers the body
+	// it received, so it has none of its own.
+	Payload string `json:"payload,omitempty"`
+
+	Disabled bool `json:"disabled,omitempty"`
+}
+
+type HostedAgentTriggerStatus struct {
+	// LastRunTime is when the schedule last fired. The next run is computed
+	// from it, so a trigger fires once per tick no matter how often it is
+	// reconciled.
+	LastRunTime *Time `json:"lastRunTime,omitempty"`
+	NextRunTime *Time `json:"nextRunTime,omitempty"`
+
+	LastInvocationTime *Time  `json:"lastInvocationTime,omitempty"`
+	LastStatusCode     int    `json:"lastStatusCode,omitempty"`
+	LastError          string `json:"lastError,omitempty"`
+}
+
+type HostedAgentTriggerList List[HostedAgentTrigger]
+
+// HostedAgentTriggerInvocation is one entry in a trigger's activity log.
+// StatusCode is the agent's response, and is zero when the payload never
+// reached it; Error then says why.
+type HostedAgentTriggerInvocation struct {
+	ID                    uint   `json:"id"`
+	TriggerID             string `json:"triggerID"`
+	HostedAgentInstanceID string `json:"hostedAgentInstanceID"`
+	Source                string `json:"source"`
+	CreatedAt             Time   `json:"createdAt"`
+	StatusCode            int    `json:"statusCode,omitempty"`
+	Error                 string `json:"error,omitempty"`
+	DurationMillis        int64  `json:"durationMillis"`
+}
+
+type HostedAgentTriggerInvocationList List[HostedAgentTriggerInvocation]
+
+// Validate checks everything that does not need a cron parser. The schedule
+// expression itself is parsed by the server, so this module stays dependency
+// free.
+func (m HostedAgentTriggerManifest) Validate() error {
+	switch m.TriggerType {
+	case HostedAgentTriggerTypeSchedule:
+		if m.Schedule == "" {
+			return fmt.Errorf("schedule is required for a schedule trigger")
+		}
+	case HostedAgentTriggerTypeWebhook:
+		if m.Schedule != "" || m.TimeZone != "" {
+			return fmt.Errorf("schedule and timeZone only apply to schedule triggers")
+		}
+		if m.Payload != "" {
+			return fmt.Errorf("payload only applies to schedule triggers; a webhook delivers the body it receives")
+		}
+	default:
+		return fmt.Errorf("triggerType must be %q or %q", HostedAgentTriggerTypeSchedule, HostedAgentTriggerTypeWebhook)
+	}
+
+	if m.TimeZone != "" {
+		if _, err := time.LoadLocation(m.TimeZone); err != nil {
+			return fmt.Errorf("invalid timeZone %q: %w", m.TimeZone, err)
+		}
+	}
+	if m.Payload != "" && !json.Valid([]byte(m.Payload)) {
+		return fmt.Errorf("payload must be valid JSON")
+	}
+	if m.Path != "" && !strings.HasPrefix(m.Path, "/") {
+		return fmt.Errorf("path must start with /")
+	}
+	return nil
+}
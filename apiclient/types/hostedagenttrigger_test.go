@@ -0,0 +1,72 @@
+package types
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHostedAgentTriggerManifestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest HostedAgentTriggerManifest
+		wantErr  string
+	}{
+		{
+			name:     "schedule",
+			manifest: HostedAgentTriggerManifest{TriggerType: HostedAgentTriggerTypeSchedule, Schedule: "0 9 * * 1-5", TimeZone: "America/New_York", Payload: `{"task":"standup"}`},
+		},
+		{
+			name:     "webhook",
+			manifest: HostedAgentTriggerManifest{TriggerType: HostedAgentTriggerTypeWebhook, Path: "/github"},
+		},
+		{
+			name:     "unknown type",
+			manifest: HostedAgentTriggerManifest{TriggerType: "email"},
+			wantErr:  "triggerType must be",
+		},
+		{
+			name:     "schedule without expression",
+			manifest: HostedAgentTriggerManifest{TriggerType: HostedAgentTriggerTypeSchedule},
+			wantErr:  "schedule is required",
+		},
+		{
+			name:     "webhook with schedule",
+			manifest: HostedAgentTriggerManifest{TriggerType: HostedAgentTriggerTypeWebhook, Schedule: "* * * * *"},
+			wantErr:  "only apply to schedule triggers",
+		},
+		{
+			name:     "webhook with payload",
+			manifest: HostedAgentTriggerManifest{TriggerType: HostedAgentTriggerTypeWebhook, Payload: "{}"},
+			wantErr:  "payload only applies",
+		},
+		{
+			name:     "bad time zone",
+			manifest: HostedAgentTriggerManifest{TriggerType: HostedAgentTriggerTypeSchedule, Schedule: "* * * * *", TimeZone: "Mars/Olympus"},
+			wantErr:  "invalid timeZone",
+		},
+		{
+			name:     "payload is not JSON",
+			manifest: HostedAgentTriggerManifest{TriggerType: HostedAgentTriggerTypeSchedule, Schedule: "* * * * *", Payload: "run"},
+			wantErr:  "payload must be valid JSON",
+		},
+		{
+			name:     "relative path",
+			manifest: HostedAgentTriggerManifest{TriggerType: HostedAgentTriggerTypeWebhook, Path: "hooks"},
+			wantErr:  "path must start with /",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.manifest.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
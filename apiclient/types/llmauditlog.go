@@ -35,6 +35,8 @@ type LLMAuditLog struct {
 	UserAgent                 string          `json:"userAgent"`
 	ClientSessionID           string          `json:"clientSessionID"`
 	ClientIP                  string          `json:"clientIP"`
+	// ContentRedacted reports that secrets or PII were redacted from the stored payloads.
+	ContentRedacted bool `json:"contentRedacted,omitempty"`
 }
 
 type LLMAuditLogResponse struct {
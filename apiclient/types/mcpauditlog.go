@@ -139,6 +139,9 @@ type MCPUsageStats struct {
 	TimeStart   Time               `json:"timeStart"`
 	TimeEnd     Time               `json:"timeEnd"`
 	Items       []MCPUsageStatItem `json:"items"`
+	// Quotas is the current consumption of MCP call quotas, regardless of
+	// the time range.
+	Quotas []MCPQuotaUsage `json:"quotas,omitempty"`
 }
 
 // MCPToolCallStats represents statistics for individual tool calls
@@ -26,6 +26,10 @@ type MCPCapacityInfo struct {
 	// ActiveDeployments is the number of active MCP server deployments
 	ActiveDeployments int `json:"activeDeployments"`
 
+	// ServerHealth contains the health of the servers covered by this report that
+	// have been health checked.
+	ServerHealth []MCPServerHealthSummary `json:"serverHealth,omitempty"`
+
 	// Error message if capacity info couldn't be fully retrieved
 	Error string `json:"error,omitempty"`
 }
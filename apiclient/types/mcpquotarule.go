@@ -0,0 +1,121 @@
+package types
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// validateCallerSubject validates a subject that names who makes MCP calls,
+// which may also be an API key or a hosted agent.
+func validateCallerSubject(subject Subject) error {
+	switch subject.Type {
+	case SubjectTypeAPIKey, SubjectTypeHostedAgent:
+		if subject.ID == "" {
+			return fmt.Errorf("%s ID is required", subject.Type)
+		}
+		return nil
+	}
+	return subject.Validate()
+}
+
+const (
+	MCPQuotaWindowMinute MCPQuotaWindow = "minute"
+	MCPQuotaWindowDay    MCPQuotaWindow = "day"
+)
+
+// MCPQuotaWindow is the period an MCP call quota counts calls over. Windows
+// start on the UTC minute or day.
+type MCPQuotaWindow string
+
+type MCPQuotaRule struct {
+	Metadata             `json:",inline"`
+	MCPQuotaRuleManifest `json:",inline"`
+}
+
+// MCPQuotaRuleManifest limits how often the rule's subjects may call tools on
+// the rule's resources. Each caller matched by a subject gets its own quota,
+// counted separately for each server, and for each tool when Tools is set.
+type MCPQuotaRuleManifest struct {
+	DisplayName string     `json:"displayName"`
+	Subjects    []Subject  `json:"subjects,omitempty"`
+	Resources   []Resource `json:"resources,omitempty"`
+	// Tools limits the rule to these tools, as MCP clients see them. When it
+	// is empty, calls to all tools of a server share one quota.
+	Tools []string `json:"tools,omitempty"`
+	// CallsPerMinute is the number of calls allowed per minute. Zero is no
+	// limit.
+	CallsPerMinute int `json:"callsPerMinute,omitempty"`
+	// CallsPerDay is the number of calls allowed per day. Zero is no limit.
+	CallsPerDay int `json:"callsPerDay,omitempty"`
+}
+
+type MCPQuotaRuleList List[MCPQuotaRule]
+
+// Limit returns the number of calls the rule allows in the window, or zero if
+// it does not limit it.
+func (m MCPQuotaRuleManifest) Limit(window MCPQuotaWindow) int {
+	switch window {
+	case MCPQuotaWindowMinute:
+		return m.CallsPerMinute
+	case MCPQuotaWindowDay:
+		return m.CallsPerDay
+	}
+	return 0
+}
+
+func (m MCPQuotaRuleManifest) Validate() error {
+	if m.DisplayName == "" {
+		return fmt.Errorf("displayName is required")
+	}
+	if m.CallsPerMinute < 0 || m.CallsPerDay < 0 {
+		return fmt.Errorf("callsPerMinute and callsPerDay must not be negative")
+	}
+	if m.CallsPerMinute == 0 && m.CallsPerDay == 0 {
+		return fmt.Errorf("callsPerMinute or callsPerDay is required")
+	}
+
+	if len(m.Subjects) == 0 {
+		return fmt.Errorf("at least one subject is required")
+	}
+	for _, subject := range m.Subjects {
+		if err := validateCallerSubject(subject); err != nil {
+			return fmt.Errorf("invalid subject: %v", err)
+		}
+	}
+
+	if len(m.Resources) == 0 {
+		return fmt.Errorf("at least one resource is required")
+	}
+	for _, resource := range m.Resources {
+		if resource.Type == ResourceTypeMcpCatalog {
+			return fmt.Errorf("invalid resource: resource type %s is not supported", resource.Type)
+		}
+		if err := resource.Validate(); err != nil {
+			return fmt.Errorf("invalid resource: %v", err)
+		}
+	}
+
+	if slices.ContainsFunc(m.Tools, func(tool string) bool { return strings.TrimSpace(tool) == "" }) {
+		return fmt.Errorf("tool names must not be empty")
+	}
+	return nil
+}
+
+// MCPQuotaUsage is the consumption of one MCP call quota in its current
+// window.
+type MCPQuotaUsage struct {
+	RuleID string `json:"ruleID"`
+	// Caller is who the quota is counted for: user/<user ID>,
+	// apiKey/<API key ID>, or hostedAgent/<hosted agent ID>.
+	Caller string `json:"caller"`
+	// UserID is the user the caller acts for.
+	UserID string `json:"userID,omitempty"`
+	MCPID  string `json:"mcpID"`
+	// ToolName is empty when the quota counts calls to all tools.
+	ToolName string         `json:"toolName,omitempty"`
+	Window   MCPQuotaWindow `json:"window"`
+	Used     int            `json:"used"`
+	Limit    int            `json:"limit"`
+	ResetsAt Time           `json:"resetsAt"`
+}
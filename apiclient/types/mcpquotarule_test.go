@@ -0,0 +1,63 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMCPQuotaRuleManifestValidate(t *testing.T) {
+	valid := func() MCPQuotaRuleManifest {
+		return MCPQuotaRuleManifest{
+			DisplayName:    "Search limits",
+			Subjects:       []Subject{{Type: SubjectTypeGroup, ID: "engineers"}},
+			Resources:      []Resource{{Type: ResourceTypeMCPServerCatalogEntry, ID: "search"}},
+			CallsPerMinute: 10,
+		}
+	}
+
+	for _, tt := range []struct {
+		name     string
+		modify   func(*MCPQuotaRuleManifest)
+		errorMsg string
+	}{
+		{name: "valid", modify: func(*MCPQuotaRuleManifest) {}},
+		{
+			name: "valid with API key, hosted agent, and tools",
+			modify: func(m *MCPQuotaRuleManifest) {
+				m.Subjects = []Subject{{Type: SubjectTypeAPIKey, ID: "12"}, {Type: SubjectTypeHostedAgent, ID: "ha1abc"}}
+				m.Resources = []Resource{{Type: ResourceTypeSelector, ID: "*"}}
+				m.Tools = []string{"search"}
+				m.CallsPerDay = 1000
+			},
+		},
+		{name: "missing display name", modify: func(m *MCPQuotaRuleManifest) { m.DisplayName = "" }, errorMsg: "displayName is required"},
+		{name: "no limits", modify: func(m *MCPQuotaRuleManifest) { m.CallsPerMinute = 0 }, errorMsg: "callsPerMinute or callsPerDay is required"},
+		{name: "negative limit", modify: func(m *MCPQuotaRuleManifest) { m.CallsPerDay = -1 }, errorMsg: "must not be negative"},
+		{name: "no subjects", modify: func(m *MCPQuotaRuleManifest) { m.Subjects = nil }, errorMsg: "at least one subject is required"},
+		{name: "API key without ID", modify: func(m *MCPQuotaRuleManifest) { m.Subjects = []Subject{{Type: SubjectTypeAPIKey}} }, errorMsg: "apiKey ID is required"},
+		{name: "invalid subject", modify: func(m *MCPQuotaRuleManifest) { m.Subjects = []Subject{{Type: "team", ID: "a"}} }, errorMsg: "invalid subject type"},
+		{name: "no resources", modify: func(m *MCPQuotaRuleManifest) { m.Resources = nil }, errorMsg: "at least one resource is required"},
+		{name: "catalog resource", modify: func(m *MCPQuotaRuleManifest) { m.Resources = []Resource{{Type: ResourceTypeMcpCatalog, ID: "default"}} }, errorMsg: "not supported"},
+		{name: "empty tool", modify: func(m *MCPQuotaRuleManifest) { m.Tools = []string{" "} }, errorMsg: "tool names must not be empty"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			manifest := valid()
+			tt.modify(&manifest)
+			err := manifest.Validate()
+			if tt.errorMsg == "" {
+				require.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.errorMsg)
+		})
+	}
+}
+
+func TestMCPQuotaRuleManifestLimit(t *testing.T) {
+	manifest := MCPQuotaRuleManifest{CallsPerMinute: 5, CallsPerDay: 100}
+	assert.Equal(t, 5, manifest.Limit(MCPQuotaWindowMinute))
+	assert.Equal(t, 100, manifest.Limit(MCPQuotaWindowDay))
+	assert.Zero(t, manifest.Limit("hour"))
+}
@@ -347,6 +347,9 @@ type MCPServer struct {
 	// OAuthMetadata contains discovered OAuth metadata for remote MCP servers.
 	OAuthMetadata *OAuthMetadata `json:"oauthMetadata,omitempty"`
 
+	// Health summarizes the recent health checks of the upstream server.
+	Health *MCPServerHealth `json:"health,omitempty"`
+
 	// K8sSettingsHash contains the hash of K8s settings this server was deployed with
 	K8sSettingsHash string `json:"k8sSettingsHash,omitempty"`
 
@@ -0,0 +1,46 @@
+package types
+
+const (
+	MCPServerHealthUnknown   MCPServerHealthState = "unknown"
+	MCPServerHealthHealthy   MCPServerHealthState = "healthy"
+	MCPServerHealthDegraded  MCPServerHealthState = "degraded"
+	MCPServerHealthUnhealthy MCPServerHealthState = "unhealthy"
+)
+
+// MCPServerHealthState is the health of an upstream MCP server as seen by the
+// gateway's periodic health checks.
+type MCPServerHealthState string
+
+// MCPServerHealth summarizes the recent health checks of an upstream MCP server.
+type MCPServerHealth struct {
+	State               MCPServerHealthState `json:"state"`
+	LastChecked         *Time                `json:"lastChecked,omitempty"`
+	LastSuccess         *Time                `json:"lastSuccess,omitempty"`
+	LastFailure         *Time                `json:"lastFailure,omitempty"`
+	LastError           string               `json:"lastError,omitempty"`
+	ConsecutiveFailures int                  `json:"consecutiveFailures,omitempty"`
+	// AverageLatencyMillis is the mean latency of the successful checks in History.
+	AverageLatencyMillis int64 `json:"averageLatencyMillis,omitempty"`
+	// ErrorRate is the fraction of the checks in History that failed, from 0 to 1.
+	ErrorRate float64 `json:"errorRate"`
+	// CircuitOpen indicates that the gateway is failing requests to this server
+	// fast instead of proxying them.
+	CircuitOpen bool                   `json:"circuitOpen,omitempty"`
+	History     []MCPServerHealthCheck `json:"history,omitempty"`
+}
+
+// MCPServerHealthCheck is the result of a single health check.
+type MCPServerHealthCheck struct {
+	Time          Time   `json:"time"`
+	Healthy       bool   `json:"healthy"`
+	LatencyMillis int64  `json:"latencyMillis,omitempty"`
+	Error         string `json:"error,omitempty"`
+}
+
+// MCPServerHealthSummary is the health of a single server as reported by the
+// capacity endpoints.
+type MCPServerHealthSummary struct {
+	ID     string          `json:"id"`
+	Name   string          `json:"name,omitempty"`
+	Health MCPServerHealth `json:"health"`
+}
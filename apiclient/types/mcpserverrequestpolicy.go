@@ -0,0 +1,133 @@
+package types
+
+import (
+	"fmt"
+	"time"
+)
+
+// MCPServerRequestAction is what the MCP gateway does with a request an MCP
+// server sends to the client.
+type MCPServerRequestAction string
+
+const (
+	MCPServerRequestActionAllow           MCPServerRequestAction = "allow"
+	MCPServerRequestActionDeny            MCPServerRequestAction = "deny"
+	MCPServerRequestActionRequireApproval MCPServerRequestAction = "requireApproval"
+)
+
+const (
+	MCPServerRequestMethodSampling    = "sampling/createMessage"
+	MCPServerRequestMethodElicitation = "elicitation/create"
+)
+
+func (a MCPServerRequestAction) validate() error {
+	switch a {
+	case "", MCPServerRequestActionAllow, MCPServerRequestActionDeny, MCPServerRequestActionRequireApproval:
+		return nil
+	}
+	return fmt.Errorf("%q must be %s, %s, or %s", a, MCPServerRequestActionAllow, MCPServerRequestActionDeny, MCPServerRequestActionRequireApproval)
+}
+
+type MCPServerRequestPolicy struct {
+	Metadata                       `json:",inline"`
+	MCPServerRequestPolicyManifest `json:",inline"`
+}
+
+// MCPServerRequestPolicyManifest governs the sampling and elicitation requests
+// MCP servers send back to the client. A server can use sampling to make the
+// user's LLM generate content and elicitation to ask the user for input, so
+// untrusted servers can be denied either, or have each request approved by a
+// second person first.
+type MCPServerRequestPolicyManifest struct {
+	DisplayName string `json:"displayName"`
+	// Subjects are the users, groups, API keys and hosted agents whose sessions
+	// the policy applies to.
+	Subjects []Subject `json:"subjects,omitempty"`
+	// Resources are the MCP servers the policy applies to.
+	Resources []Resource `json:"resources,omitempty"`
+	// Sampling is the action for sampling/createMessage requests. When unset,
+	// the policy does not govern sampling.
+	Sampling MCPServerRequestAction `json:"sampling,omitempty"`
+	// Elicitation is the action for elicitation/create requests. When unset,
+	// the policy does not govern elicitation.
+	Elicitation MCPServerRequestAction `json:"elicitation,omitempty"`
+	// Approvers are the users and groups who can decide requests that need
+	// approval, in addition to owners and admins.
+	Approvers []Subject `json:"approvers,omitempty"`
+	// TimeoutSeconds is how long a request waits for a decision before it is
+	// denied. It defaults to five minutes.
+	TimeoutSeconds int `json:"timeoutSeconds,omitempty"`
+}
+
+type MCPServerRequestPolicyList List[MCPServerRequestPolicy]
+
+// Action returns the action for the method, or "" if the policy does not
+// govern it.
+func (m MCPServerRequestPolicyManifest) Action(method string) MCPServerRequestAction {
+	switch method {
+	case MCPServerRequestMethodSampling:
+		return m.Sampling
+	case MCPServerRequestMethodElicitation:
+		return m.Elicitation
+	}
+	return ""
+}
+
+// Timeout returns how long a request waits for a decision.
+func (m MCPServerRequestPolicyManifest) Timeout() time.Duration {
+	if m.TimeoutSeconds > 0 {
+		return time.Duration(m.TimeoutSeconds) * time.Second
+	}
+	return DefaultMCPToolApprovalTimeoutSeconds * time.Second
+}
+
+func (m MCPServerRequestPolicyManifest) Validate() error {
+	if m.DisplayName == "" {
+		return fmt.Errorf("displayName is required")
+	}
+
+	if m.Sampling == "" && m.Elicitation == "" {
+		return fmt.Errorf("at least one of sampling or elicitation is required")
+	}
+	if err := m.Sampling.validate(); err != nil {
+		return fmt.Errorf("invalid sampling action: %v", err)
+	}
+	if err := m.Elicitation.validate(); err != nil {
+		return fmt.Errorf("invalid elicitation action: %v", err)
+	}
+
+	if len(m.Subjects) == 0 {
+		return fmt.Errorf("at least one subject is required")
+	}
+	for _, subject := range m.Subjects {
+		if err := validateCallerSubject(subject); err != nil {
+			return fmt.Errorf("invalid subject: %v", err)
+		}
+	}
+
+	if len(m.Resources) == 0 {
+		return fmt.Errorf("at least one resource is required")
+	}
+	for _, resource := range m.Resources {
+		if resource.Type == ResourceTypeMcpCatalog {
+			return fmt.Errorf("invalid resource: resource type %s is not supported", resource.Type)
+		}
+		if err := resource.Validate(); err != nil {
+			return fmt.Errorf("invalid resource: %v", err)
+		}
+	}
+
+	for _, approver := range m.Approvers {
+		if approver.Type == SubjectTypeSelector {
+			return fmt.Errorf("approvers must be users or groups")
+		}
+		if err := approver.Validate(); err != nil {
+			return fmt.Errorf("invalid approver: %v", err)
+		}
+	}
+
+	if m.TimeoutSeconds < 0 || m.TimeoutSeconds > MaxMCPToolApprovalTimeoutSeconds {
+		return fmt.Errorf("timeoutSeconds must be between 0 and %d", MaxMCPToolApprovalTimeoutSeconds)
+	}
+	return nil
+}
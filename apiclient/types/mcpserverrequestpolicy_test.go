@@ -0,0 +1,63 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMCPServerRequestPolicyManifestValidate(t *testing.T) {
+	valid := func() MCPServerRequestPolicyManifest {
+		return MCPServerRequestPolicyManifest{
+			DisplayName: "Untrusted servers",
+			Subjects:    []Subject{{Type: SubjectTypeSelector, ID: "*"}},
+			Resources:   []Resource{{Type: ResourceTypeMCPServerCatalogEntry, ID: "community"}},
+			Sampling:    MCPServerRequestActionDeny,
+			Elicitation: MCPServerRequestActionRequireApproval,
+			Approvers:   []Subject{{Type: SubjectTypeGroup, ID: "security"}},
+		}
+	}
+
+	for _, tt := range []struct {
+		name     string
+		modify   func(*MCPServerRequestPolicyManifest)
+		errorMsg string
+	}{
+		{name: "valid", modify: func(*MCPServerRequestPolicyManifest) {}},
+		{name: "valid with one action", modify: func(m *MCPServerRequestPolicyManifest) { m.Elicitation = ""; m.Approvers = nil }},
+		{name: "missing display name", modify: func(m *MCPServerRequestPolicyManifest) { m.DisplayName = "" }, errorMsg: "displayName is required"},
+		{name: "no actions", modify: func(m *MCPServerRequestPolicyManifest) { m.Sampling = ""; m.Elicitation = "" }, errorMsg: "at least one of sampling or elicitation is required"},
+		{name: "invalid sampling action", modify: func(m *MCPServerRequestPolicyManifest) { m.Sampling = "block" }, errorMsg: "invalid sampling action"},
+		{name: "invalid elicitation action", modify: func(m *MCPServerRequestPolicyManifest) { m.Elicitation = "ask" }, errorMsg: "invalid elicitation action"},
+		{name: "no subjects", modify: func(m *MCPServerRequestPolicyManifest) { m.Subjects = nil }, errorMsg: "at least one subject is required"},
+		{name: "API key subject", modify: func(m *MCPServerRequestPolicyManifest) { m.Subjects = []Subject{{Type: SubjectTypeAPIKey, ID: "7"}} }},
+		{name: "hosted agent subject without ID", modify: func(m *MCPServerRequestPolicyManifest) { m.Subjects = []Subject{{Type: SubjectTypeHostedAgent}} }, errorMsg: "hostedAgent ID is required"},
+		{name: "no resources", modify: func(m *MCPServerRequestPolicyManifest) { m.Resources = nil }, errorMsg: "at least one resource is required"},
+		{name: "catalog resource", modify: func(m *MCPServerRequestPolicyManifest) { m.Resources[0].Type = ResourceTypeMcpCatalog }, errorMsg: "not supported"},
+		{name: "selector approver", modify: func(m *MCPServerRequestPolicyManifest) { m.Approvers = []Subject{{Type: SubjectTypeSelector, ID: "*"}} }, errorMsg: "approvers must be users or groups"},
+		{name: "timeout too long", modify: func(m *MCPServerRequestPolicyManifest) { m.TimeoutSeconds = MaxMCPToolApprovalTimeoutSeconds + 1 }, errorMsg: "timeoutSeconds must be between"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			manifest := valid()
+			tt.modify(&manifest)
+			err := manifest.Validate()
+			if tt.errorMsg == "" {
+				require.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.errorMsg)
+		})
+	}
+}
+
+func TestMCPServerRequestPolicyManifestAction(t *testing.T) {
+	manifest := MCPServerRequestPolicyManifest{Sampling: MCPServerRequestActionDeny}
+	assert.Equal(t, MCPServerRequestActionDeny, manifest.Action(MCPServerRequestMethodSampling))
+	assert.Empty(t, manifest.Action(MCPServerRequestMethodElicitation))
+	assert.Empty(t, manifest.Action("roots/list"))
+
+	assert.Equal(t, 5*time.Minute, manifest.Timeout())
+	assert.Equal(t, 30*time.Second, MCPServerRequestPolicyManifest{TimeoutSeconds: 30}.Timeout())
+}
@@ -0,0 +1,147 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultMCPToolApprovalTimeoutSeconds is how long a call waits for a
+	// decision when its policy does not set a timeout.
+	DefaultMCPToolApprovalTimeoutSeconds = 5 * 60
+	// MaxMCPToolApprovalTimeoutSeconds caps how long a call can be held.
+	MaxMCPToolApprovalTimeoutSeconds = 60 * 60
+)
+
+type MCPToolCallApprovalState string
+
+const (
+	MCPToolCallApprovalStatePending   MCPToolCallApprovalState = "pending"
+	MCPToolCallApprovalStateApproved  MCPToolCallApprovalState = "approved"
+	MCPToolCallApprovalStateRejected  MCPToolCallApprovalState = "rejected"
+	MCPToolCallApprovalStateExpired   MCPToolCallApprovalState = "expired"
+	MCPToolCallApprovalStateCancelled MCPToolCallApprovalState = "cancelled"
+)
+
+type MCPToolApprovalPolicy struct {
+	Metadata                      `json:",inline"`
+	MCPToolApprovalPolicyManifest `json:",inline"`
+}
+
+// MCPToolApprovalPolicyManifest marks tools that must not run until a second
+// person approves the call. The MCP gateway holds matching tools/call requests
+// until an approver decides or the timeout passes.
+type MCPToolApprovalPolicyManifest struct {
+	DisplayName string `json:"displayName"`
+	// Subjects are the users, groups, API keys and hosted agents whose calls
+	// need approval.
+	Subjects []Subject `json:"subjects,omitempty"`
+	// Resources are the MCP servers the policy applies to.
+	Resources []Resource `json:"resources,omitempty"`
+	// Tools are the tools that need approval, as MCP clients see them.
+	Tools []string `json:"tools,omitempty"`
+	// Approvers are the users and groups who can decide, in addition to owners
+	// and admins.
+	Approvers []Subject `json:"approvers,omitempty"`
+	// TimeoutSeconds is how long a call waits for a decision before it is
+	// rejected. It defaults to five minutes.
+	TimeoutSeconds int `json:"timeoutSeconds,omitempty"`
+}
+
+type MCPToolApprovalPolicyList List[MCPToolApprovalPolicy]
+
+// MCPToolCallApproval is a held tool call waiting for, or decided by, an
+// approver.
+type MCPToolCallApproval struct {
+	Metadata `json:",inline"`
+	PolicyID string `json:"policyID"`
+	// RequesterID is the user the call was made by or for.
+	RequesterID          string `json:"requesterID"`
+	MCPID                string `json:"mcpID"`
+	MCPServerDisplayName string `json:"mcpServerDisplayName,omitempty"`
+	// Method is the held request's method: tools/call for tool calls, or
+	// sampling/createMessage or elicitation/create for requests an MCP server
+	// sent to the client.
+	Method string `json:"method,omitempty"`
+	// ToolName and Arguments are set for tool calls. For server requests,
+	// Arguments holds the request's params.
+	ToolName  string          `json:"toolName"`
+	Arguments json.RawMessage `json:"arguments,omitempty"`
+	// SessionID and RequestID identify the call in the MCP audit log.
+	SessionID       string                   `json:"sessionID,omitempty"`
+	RequestID       string                   `json:"requestID,omitempty"`
+	State           MCPToolCallApprovalState `json:"state"`
+	ExpiresAt       Time                     `json:"expiresAt"`
+	DecidedBy       string                   `json:"decidedBy,omitempty"`
+	DecidedAt       *Time                    `json:"decidedAt,omitempty"`
+	DecisionComment string                   `json:"decisionComment,omitempty"`
+	// CanDecide is whether the caller can approve or reject the call.
+	CanDecide bool `json:"canDecide,omitempty"`
+}
+
+type MCPToolCallApprovalList List[MCPToolCallApproval]
+
+// MCPToolCallApprovalDecision is the body of an approve, reject, or cancel
+// request.
+type MCPToolCallApprovalDecision struct {
+	Comment string `json:"comment,omitempty"`
+}
+
+// Timeout returns how long a call waits for a decision.
+func (m MCPToolApprovalPolicyManifest) Timeout() time.Duration {
+	if m.TimeoutSeconds > 0 {
+		return time.Duration(m.TimeoutSeconds) * time.Second
+	}
+	return DefaultMCPToolApprovalTimeoutSeconds * time.Second
+}
+
+func (m MCPToolApprovalPolicyManifest) Validate() error {
+	if m.DisplayName == "" {
+		return fmt.Errorf("displayName is required")
+	}
+
+	if len(m.Subjects) == 0 {
+		return fmt.Errorf("at least one subject is required")
+	}
+	for _, subject := range m.Subjects {
+		if err := validateCallerSubject(subject); err != nil {
+			return fmt.Errorf("invalid subject: %v", err)
+		}
+	}
+
+	if len(m.Resources) == 0 {
+		return fmt.Errorf("at least one resource is required")
+	}
+	for _, resource := range m.Resources {
+		if resource.Type == ResourceTypeMcpCatalog {
+			return fmt.Errorf("invalid resource: resource type %s is not supported", resource.Type)
+		}
+		if err := resource.Validate(); err != nil {
+			return fmt.Errorf("invalid resource: %v", err)
+		}
+	}
+
+	if len(m.Tools) == 0 {
+		return fmt.Errorf("at least one tool is required")
+	}
+	if slices.ContainsFunc(m.Tools, func(tool string) bool { return strings.TrimSpace(tool) == "" }) {
+		return fmt.Errorf("tool names must not be empty")
+	}
+
+	for _, approver := range m.Approvers {
+		if approver.Type == SubjectTypeSelector {
+			return fmt.Errorf("approvers must be users or groups")
+		}
+		if err := approver.Validate(); err != nil {
+			return fmt.Errorf("invalid approver: %v", err)
+		}
+	}
+
+	if m.TimeoutSeconds < 0 || m.TimeoutSeconds > MaxMCPToolApprovalTimeoutSeconds {
+		return fmt.Errorf("timeoutSeconds must be between 0 and %d", MaxMCPToolApprovalTimeoutSeconds)
+	}
+	return nil
+}
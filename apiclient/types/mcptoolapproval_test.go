@@ -0,0 +1,59 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMCPToolApprovalPolicyManifestValidate(t *testing.T) {
+	valid := func() MCPToolApprovalPolicyManifest {
+		return MCPToolApprovalPolicyManifest{
+			DisplayName: "Production deploys",
+			Subjects:    []Subject{{Type: SubjectTypeSelector, ID: "*"}},
+			Resources:   []Resource{{Type: ResourceTypeMCPServerCatalogEntry, ID: "deployer"}},
+			Tools:       []string{"deploy"},
+			Approvers:   []Subject{{Type: SubjectTypeGroup, ID: "sre"}},
+		}
+	}
+
+	for _, tt := range []struct {
+		name     string
+		modify   func(*MCPToolApprovalPolicyManifest)
+		errorMsg string
+	}{
+		{name: "valid", modify: func(*MCPToolApprovalPolicyManifest) {}},
+		{name: "valid without approvers", modify: func(m *MCPToolApprovalPolicyManifest) { m.Approvers = nil; m.TimeoutSeconds = 600 }},
+		{name: "missing display name", modify: func(m *MCPToolApprovalPolicyManifest) { m.DisplayName = "" }, errorMsg: "displayName is required"},
+		{name: "valid with API key and hosted agent subjects", modify: func(m *MCPToolApprovalPolicyManifest) {
+			m.Subjects = []Subject{{Type: SubjectTypeAPIKey, ID: "12"}, {Type: SubjectTypeHostedAgent, ID: "ha1abc"}}
+		}},
+		{name: "no subjects", modify: func(m *MCPToolApprovalPolicyManifest) { m.Subjects = nil }, errorMsg: "at least one subject is required"},
+		{name: "hosted agent without ID", modify: func(m *MCPToolApprovalPolicyManifest) { m.Subjects = []Subject{{Type: SubjectTypeHostedAgent}} }, errorMsg: "hostedAgent ID is required"},
+		{name: "API key approver", modify: func(m *MCPToolApprovalPolicyManifest) { m.Approvers = []Subject{{Type: SubjectTypeAPIKey, ID: "12"}} }, errorMsg: "invalid approver"},
+		{name: "no resources", modify: func(m *MCPToolApprovalPolicyManifest) { m.Resources = nil }, errorMsg: "at least one resource is required"},
+		{name: "catalog resource", modify: func(m *MCPToolApprovalPolicyManifest) { m.Resources[0].Type = ResourceTypeMcpCatalog }, errorMsg: "not supported"},
+		{name: "no tools", modify: func(m *MCPToolApprovalPolicyManifest) { m.Tools = nil }, errorMsg: "at least one tool is required"},
+		{name: "empty tool", modify: func(m *MCPToolApprovalPolicyManifest) { m.Tools = []string{""} }, errorMsg: "tool names must not be empty"},
+		{name: "selector approver", modify: func(m *MCPToolApprovalPolicyManifest) { m.Approvers = []Subject{{Type: SubjectTypeSelector, ID: "*"}} }, errorMsg: "approvers must be users or groups"},
+		{name: "timeout too long", modify: func(m *MCPToolApprovalPolicyManifest) { m.TimeoutSeconds = MaxMCPToolApprovalTimeoutSeconds + 1 }, errorMsg: "timeoutSeconds must be between"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			manifest := valid()
+			tt.modify(&manifest)
+			err := manifest.Validate()
+			if tt.errorMsg == "" {
+				require.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.errorMsg)
+		})
+	}
+}
+
+func TestMCPToolApprovalPolicyManifestTimeout(t *testing.T) {
+	assert.Equal(t, 5*time.Minute, MCPToolApprovalPolicyManifest{}.Timeout())
+	assert.Equal(t, 30*time.Second, MCPToolApprovalPolicyManifest{TimeoutSeconds: 30}.Timeout())
+}
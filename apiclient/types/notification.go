@@ -0,0 +1,259 @@
+package types
+
+import (
+	"fmt"
+	"net/mail"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// NotificationEventType identifies something Obot can notify about.
+type NotificationEventType string
+
+const (
+	// NotificationEventMessagePolicyViolation is sent when a message policy
+	// blocks a request or response.
+	NotificationEventMessagePolicyViolation NotificationEventType = "message-policy.violation"
+	// NotificationEventToolCallDenied is sent when tool call enforcement denies
+	// a tool call.
+	NotificationEventToolCallDenied NotificationEventType = "tool-call.denied"
+	// NotificationEventCatalogSyncFailed is sent when an MCP catalog source
+	// fails to sync.
+	NotificationEventCatalogSyncFailed NotificationEventType = "mcp-catalog.sync-failed"
+	// NotificationEventUnknownMCPServer is sent when a device scan reports an
+	// MCP server configuration no earlier scan has seen.
+	NotificationEventUnknownMCPServer NotificationEventType = "device-scan.unknown-mcp-server"
+	// NotificationEventTokenLimitNear is sent when a user has used most of a
+	// daily token limit.
+	NotificationEventTokenLimitNear NotificationEventType = "token-limit.near"
+	// NotificationEventToolCallApprovalRequested is sent when the MCP gateway
+	// holds a tool call until someone approves it.
+	NotificationEventToolCallApprovalRequested NotificationEventType = "tool-call.approval-requested"
+	// NotificationEventTest is sent when an admin tests a channel. Rules cannot
+	// subscribe to it.
+	NotificationEventTest NotificationEventType = "notification.test"
+)
+
+// NotificationEventTypes lists every event type a notification rule can
+// subscribe to.
+var NotificationEventTypes = []NotificationEventType{
+	NotificationEventMessagePolicyViolation,
+	NotificationEventToolCallDenied,
+	NotificationEventCatalogSyncFailed,
+	NotificationEventUnknownMCPServer,
+	NotificationEventTokenLimitNear,
+	NotificationEventToolCallApprovalRequested,
+}
+
+// NotificationEvent is the payload delivered to notification channels.
+type NotificationEvent struct {
+	ID   string                `json:"id"`
+	Type NotificationEventType `json:"type"`
+	Time Time                  `json:"time"`
+	// Summary is a one-line, human-readable description of the event.
+	Summary string `json:"summary"`
+	// UserID is the user the event concerns, if any.
+	UserID string `json:"userID,omitempty"`
+	// ResourceID is the resource the event concerns, such as a message policy,
+	// MCP catalog, or MCP server configuration hash.
+	ResourceID string            `json:"resourceID,omitempty"`
+	Details    map[string]string `json:"details,omitempty"`
+}
+
+// NotificationChannelType is how a notification channel delivers events.
+type NotificationChannelType string
+
+const (
+	// NotificationChannelTypeWebhook posts the event as JSON to an HTTPS URL,
+	// signed with the channel's secret.
+	NotificationChannelTypeWebhook NotificationChannelType = "webhook"
+	// NotificationChannelTypeSlack posts a message to a Slack-compatible
+	// incoming webhook.
+	NotificationChannelTypeSlack NotificationChannelType = "slack"
+	// NotificationChannelTypeEmail sends an email through an SMTP server.
+	NotificationChannelTypeEmail NotificationChannelType = "email"
+)
+
+// NotificationEmailConfig is the SMTP configuration of an email channel.
+type NotificationEmailConfig struct {
+	Host     string   `json:"host"`
+	Port     int      `json:"port"`
+	Username string   `json:"username,omitempty"`
+	From     string   `json:"from"`
+	To       []string `json:"to"`
+}
+
+// NotificationChannelManifest is accepted when creating or updating a
+// notification channel.
+type NotificationChannelManifest struct {
+	DisplayName string                  `json:"displayName"`
+	ChannelType NotificationChannelType `json:"channelType"`
+	// URL is the HTTPS endpoint of a webhook channel.
+	URL   string                   `json:"url,omitempty"`
+	Email *NotificationEmailConfig `json:"email,omitempty"`
+	// Secret is the signing secret of a webhook channel, the incoming webhook
+	// URL of a slack channel, or the SMTP password of an email channel. It is
+	// never returned, and may be omitted on update to keep the current one.
+	Secret string `json:"secret,omitempty"`
+}
+
+// Validate checks that the manifest sets the fields used by its channel type.
+// The secret may be omitted when one is already configured.
+func (m NotificationChannelManifest) Validate(secretConfigured bool) error {
+	if strings.TrimSpace(m.DisplayName) == "" {
+		return fmt.Errorf("displayName is required")
+	}
+
+	switch m.ChannelType {
+	case NotificationChannelTypeWebhook:
+		if m.Email != nil {
+			return fmt.Errorf("webhook channels do not accept email settings")
+		}
+		if err := validateNotificationURL(m.URL); err != nil {
+			return fmt.Errorf("invalid url: %w", err)
+		}
+		if m.Secret == "" && !secretConfigured {
+			return fmt.Errorf("secret is required to sign webhook deliveries")
+		}
+	case NotificationChannelTypeSlack:
+		if m.URL != "" || m.Email != nil {
+			return fmt.Errorf("slack channels only accept the incoming webhook URL as their secret")
+		}
+		if m.Secret == "" && !secretConfigured {
+			return fmt.Errorf("secret is required and must be the incoming webhook URL")
+		}
+		if m.Secret != "" {
+			if err := validateNotificationURL(m.Secret); err != nil {
+				return fmt.Errorf("invalid incoming webhook URL: %w", err)
+			}
+		}
+	case NotificationChannelTypeEmail:
+		if m.URL != "" {
+			return fmt.Errorf("email channels do not accept a url")
+		}
+		if m.Email == nil {
+			return fmt.Errorf("email settings are required")
+		}
+		if m.Email.Host == "" {
+			return fmt.Errorf("email host is required")
+		}
+		if m.Email.Port < 1 || m.Email.Port > 65535 {
+			return fmt.Errorf("email port must be between 1 and 65535")
+		}
+		if _, err := mail.ParseAddress(m.Email.From); err != nil {
+			return fmt.Errorf("invalid email from address %q: %w", m.Email.From, err)
+		}
+		if len(m.Email.To) == 0 {
+			return fmt.Errorf("at least one email recipient is required")
+		}
+		for _, to := range m.Email.To {
+			if _, err := mail.ParseAddress(to); err != nil {
+				return fmt.Errorf("invalid email recipient %q: %w", to, err)
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported notification channel type %q", m.ChannelType)
+	}
+	return nil
+}
+
+func validateNotificationURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "https" || u.Host == "" {
+		return fmt.Errorf("must be an https URL")
+	}
+	return nil
+}
+
+// NotificationChannel is a destination that notification rules deliver
+// events to. Its secret is never returned.
+type NotificationChannel struct {
+	Metadata                    `json:",inline"`
+	NotificationChannelManifest `json:",inline"`
+	SecretConfigured            bool `json:"secretConfigured"`
+}
+
+type NotificationChannelList List[NotificationChannel]
+
+// NotificationRuleFilter narrows the events a rule delivers. Empty fields
+// match every event.
+type NotificationRuleFilter struct {
+	UserIDs     []string `json:"userIDs,omitempty"`
+	ResourceIDs []string `json:"resourceIDs,omitempty"`
+}
+
+// NotificationRuleManifest subscribes notification channels to event types.
+type NotificationRuleManifest struct {
+	DisplayName string                  `json:"displayName"`
+	Disabled    bool                    `json:"disabled,omitempty"`
+	EventTypes  []NotificationEventType `json:"eventTypes"`
+	ChannelIDs  []string                `json:"channelIDs"`
+	Filter      NotificationRuleFilter  `json:"filter,omitzero"`
+}
+
+func (m NotificationRuleManifest) Validate() error {
+	if strings.TrimSpace(m.DisplayName) == "" {
+		return fmt.Errorf("displayName is required")
+	}
+	if len(m.EventTypes) == 0 {
+		return fmt.Errorf("at least one event type is required")
+	}
+	for _, eventType := range m.EventTypes {
+		if !slices.Contains(NotificationEventTypes, eventType) {
+			return fmt.Errorf("unsupported event type %q", eventType)
+		}
+	}
+	if len(m.ChannelIDs) == 0 {
+		return fmt.Errorf("at least one channel is required")
+	}
+	return nil
+}
+
+// Matches reports whether the rule delivers the event.
+func (m NotificationRuleManifest) Matches(event NotificationEvent) bool {
+	if m.Disabled || !slices.Contains(m.EventTypes, event.Type) {
+		return false
+	}
+	if len(m.Filter.UserIDs) > 0 && !slices.Contains(m.Filter.UserIDs, event.UserID) {
+		return false
+	}
+	if len(m.Filter.ResourceIDs) > 0 && !slices.Contains(m.Filter.ResourceIDs, event.ResourceID) {
+		return false
+	}
+	return true
+}
+
+type NotificationRule struct {
+	Metadata                 `json:",inline"`
+	NotificationRuleManifest `json:",inline"`
+}
+
+type NotificationRuleList List[NotificationRule]
+
+type NotificationDeliveryState string
+
+const (
+	NotificationDeliveryStatePending   NotificationDeliveryState = "pending"
+	NotificationDeliveryStateDelivered NotificationDeliveryState = "delivered"
+	NotificationDeliveryStateFailed    NotificationDeliveryState = "failed"
+)
+
+// NotificationDelivery records sending one event to one channel, including
+// failed attempts.
+type NotificationDelivery struct {
+	Metadata      `json:",inline"`
+	ChannelID     string                    `json:"channelID"`
+	RuleID        string                    `json:"ruleID,omitempty"`
+	Event         NotificationEvent         `json:"event"`
+	State         NotificationDeliveryState `json:"state"`
+	Attempts      int                       `json:"attempts"`
+	LastError     string                    `json:"lastError,omitempty"`
+	NextAttemptAt *Time                     `json:"nextAttemptAt,omitempty"`
+	DeliveredAt   *Time                     `json:"deliveredAt,omitempty"`
+}
+
+type NotificationDeliveryList List[NotificationDelivery]
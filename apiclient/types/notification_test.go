@@ -0,0 +1,100 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationChannelManifestValidate(t *testing.T) {
+	for _, tt := range []struct {
+		name             string
+		manifest         NotificationChannelManifest
+		secretConfigured bool
+		errorMsg         string
+	}{
+		{
+			name:     "webhook",
+			manifest: NotificationChannelManifest{DisplayName: "SIEM", ChannelType: NotificationChannelTypeWebhook, URL: "https://siem.example.com/hook", Secret: "s3cret"},
+		},
+		{
+			name:             "webhook keeps configured secret",
+			manifest:         NotificationChannelManifest{DisplayName: "SIEM", ChannelType: NotificationChannelTypeWebhook, URL: "https://siem.example.com/hook"},
+			secretConfigured: true,
+		},
+		{
+			name:     "webhook without secret",
+			manifest: NotificationChannelManifest{DisplayName: "SIEM", ChannelType: NotificationChannelTypeWebhook, URL: "https://siem.example.com/hook"},
+			errorMsg: "secret is required",
+		},
+		{
+			name:     "webhook over http",
+			manifest: NotificationChannelManifest{DisplayName: "SIEM", ChannelType: NotificationChannelTypeWebhook, URL: "http://siem.example.com/hook", Secret: "s3cret"},
+			errorMsg: "must be an https URL",
+		},
+		{
+			name:     "slack",
+			manifest: NotificationChannelManifest{DisplayName: "Security", ChannelType: NotificationChannelTypeSlack, Secret: "https://hooks.slack.com/services/T/B/X"},
+		},
+		{
+			name:     "slack with url",
+			manifest: NotificationChannelManifest{DisplayName: "Security", ChannelType: NotificationChannelTypeSlack, URL: "https://hooks.slack.com/services/T/B/X"},
+			errorMsg: "only accept the incoming webhook URL",
+		},
+		{
+			name: "email",
+			manifest: NotificationChannelManifest{DisplayName: "Admins", ChannelType: NotificationChannelTypeEmail, Email: &NotificationEmailConfig{
+				Host: "smtp.example.com", Port: 587, From: "Obot <obot@example.com>", To: []string{"admins@example.com"},
+			}},
+		},
+		{
+			name: "email with bad recipient",
+			manifest: NotificationChannelManifest{DisplayName: "Admins", ChannelType: NotificationChannelTypeEmail, Email: &NotificationEmailConfig{
+				Host: "smtp.example.com", Port: 587, From: "obot@example.com", To: []string{"admins"},
+			}},
+			errorMsg: "invalid email recipient",
+		},
+		{
+			name:     "unknown type",
+			manifest: NotificationChannelManifest{DisplayName: "Pager", ChannelType: "pager"},
+			errorMsg: "unsupported notification channel type",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.manifest.Validate(tt.secretConfigured)
+			if tt.errorMsg == "" {
+				require.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.errorMsg)
+		})
+	}
+}
+
+func TestNotificationRuleManifestMatches(t *testing.T) {
+	rule := NotificationRuleManifest{
+		DisplayName: "Policy violations",
+		EventTypes:  []NotificationEventType{NotificationEventMessagePolicyViolation},
+		ChannelIDs:  []string{"nc1slack"},
+		Filter:      NotificationRuleFilter{ResourceIDs: []string{"mp1pii"}},
+	}
+	require.NoError(t, rule.Validate())
+
+	event := NotificationEvent{Type: NotificationEventMessagePolicyViolation, UserID: "u1", ResourceID: "mp1pii"}
+	assert.True(t, rule.Matches(event))
+
+	other := event
+	other.ResourceID = "mp1other"
+	assert.False(t, rule.Matches(other))
+
+	other = event
+	other.Type = NotificationEventToolCallDenied
+	assert.False(t, rule.Matches(other))
+
+	rule.Disabled = true
+	assert.False(t, rule.Matches(event))
+
+	rule.EventTypes = []NotificationEventType{"mcp-server.created"}
+	assert.ErrorContains(t, rule.Validate(), "unsupported event type")
+}
@@ -34,6 +34,14 @@ type PublishedArtifactVersionSummary struct {
 	Subjects    []Subject `json:"subjects,omitempty"`
 }
 
+// PublishedArtifactUpdateRequest changes an artifact's description or the
+// subjects of one of its versions. Version defaults to the latest version.
+type PublishedArtifactUpdateRequest struct {
+	Description *string   `json:"description,omitempty"`
+	Version     *int      `json:"version,omitempty"`
+	Subjects    []Subject `json:"subjects,omitempty"`
+}
+
 // PublishedArtifactList is a list of published artifacts.
 type PublishedArtifactList List[PublishedArtifact]
 
@@ -3,15 +3,19 @@ package types
 type Skill struct {
 	Metadata
 	SkillManifest
-	RepoID          string `json:"repoID,omitempty"`
-	RepoURL         string `json:"repoURL,omitempty"`
-	RepoRef         string `json:"repoRef,omitempty"`
-	CommitSHA       string `json:"commitSHA,omitempty"`
-	RelativePath    string `json:"relativePath,omitempty"`
-	InstallHash     string `json:"installHash,omitempty"`
-	Valid           bool   `json:"valid,omitempty"`
-	ValidationError string `json:"validationError,omitempty"`
-	LastIndexedAt   Time   `json:"lastIndexedAt,omitzero"`
+	RepoID          string             `json:"repoID,omitempty"`
+	RepoURL         string             `json:"repoURL,omitempty"`
+	RepoRef         string             `json:"repoRef,omitempty"`
+	CommitSHA       string             `json:"commitSHA,omitempty"`
+	RelativePath    string             `json:"relativePath,omitempty"`
+	InstallHash     string             `json:"installHash,omitempty"`
+	Valid           bool               `json:"valid,omitempty"`
+	ValidationError string             `json:"validationError,omitempty"`
+	LastIndexedAt   Time               `json:"lastIndexedAt,omitzero"`
+	ScanFindings    []SkillScanFinding `json:"scanFindings,omitempty"`
+	ScanBlocked     bool               `json:"scanBlocked,omitempty"`
+	ScanOverride    *SkillScanOverride `json:"scanOverride,omitempty"`
+	Installable     bool               `json:"installable,omitempty"`
 }
 
 type SkillManifest struct {
@@ -25,3 +29,47 @@ type SkillManifest struct {
 }
 
 type SkillList List[Skill]
+
+type SkillScanSeverity string
+
+const (
+	SkillScanSeverityLow    SkillScanSeverity = "low"
+	SkillScanSeverityMedium SkillScanSeverity = "medium"
+	SkillScanSeverityHigh   SkillScanSeverity = "high"
+)
+
+// SkillScanFinding is a single issue reported by a skill security scanner.
+type SkillScanFinding struct {
+	Scanner  string            `json:"scanner"`
+	Severity SkillScanSeverity `json:"severity"`
+	Path     string            `json:"path,omitempty"`
+	Line     int               `json:"line,omitempty"`
+	Message  string            `json:"message"`
+}
+
+// SkillScanOverride records an admin decision to allow installation of a skill
+// whose scan findings would otherwise block it. The override only applies while
+// the skill's install hash matches InstallHash.
+type SkillScanOverride struct {
+	InstallHash  string `json:"installHash,omitempty"`
+	OverriddenBy string `json:"overriddenBy,omitempty"`
+	Reason       string `json:"reason,omitempty"`
+	OverriddenAt Time   `json:"overriddenAt,omitzero"`
+}
+
+// SkillRevision is a previously indexed version of a skill's content.
+type SkillRevision struct {
+	SkillManifest
+	CommitSHA   string `json:"commitSHA,omitempty"`
+	InstallHash string `json:"installHash,omitempty"`
+	IndexedAt   Time   `json:"indexedAt,omitzero"`
+	ScanBlocked bool   `json:"scanBlocked,omitempty"`
+	Installable bool   `json:"installable,omitempty"`
+	Current     bool   `json:"current,omitempty"`
+}
+
+type SkillRevisionList List[SkillRevision]
+
+type SkillScanOverrideRequest struct {
+	Reason string `json:"reason,omitempty"`
+}
@@ -0,0 +1,11 @@
+package types
+
+// ToolCallEnforcementSetting configures evaluation of the tool calls that models return through the
+// LLM gateway. It applies the same allowlist semantics as device enforcement to traffic that has
+// no device in front of it, such as hosted agents and headless CI.
+type ToolCallEnforcementSetting struct {
+	// Enabled evaluates every tool call in an LLM gateway response against Allowlist. Denied tool
+	// calls are replaced with a refusal before the response reaches the client.
+	Enabled   bool                 `json:"enabled,omitempty"`
+	Allowlist EnforcementAllowlist `json:"allowlist,omitzero"`
+}
@@ -0,0 +1,116 @@
+package types
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// ToolArgumentConstraint restricts the arguments a tool may be called with.
+// The MCP gateway checks it on every tools/call made by the subjects of the
+// access control rule it belongs to, for the rule's resources.
+type ToolArgumentConstraint struct {
+	// Tool is the tool name as MCP clients see it.
+	Tool string `json:"tool"`
+	// Schema is a JSON schema the arguments object must match.
+	Schema *ToolArgumentSchema `json:"schema,omitempty"`
+	// Arguments constrain single arguments.
+	Arguments []ToolArgumentRule `json:"arguments,omitempty"`
+}
+
+// ToolArgumentRule constrains one argument of a tool call. When the argument
+// is an array, every element must satisfy the rule.
+type ToolArgumentRule struct {
+	// Path selects the argument, with dots separating nested object fields.
+	Path string `json:"path"`
+	// Required rejects calls without the argument. Otherwise, calls without
+	// it pass.
+	Required bool `json:"required,omitempty"`
+	// Pattern is a regular expression a string argument must match. Anchor it
+	// with ^ and $ to match the whole value.
+	Pattern string `json:"pattern,omitempty"`
+	// AllowedValues lists the values the argument may have. Strings are
+	// compared as they are, other values by their JSON encoding.
+	AllowedValues []string `json:"allowedValues,omitempty"`
+}
+
+// ToolArgumentSchema is the subset of JSON schema supported in tool argument
+// constraints.
+type ToolArgumentSchema struct {
+	// Type is one of object, array, string, number, integer, boolean, or null.
+	Type       string                        `json:"type,omitempty"`
+	Properties map[string]ToolArgumentSchema `json:"properties,omitempty"`
+	Required   []string                      `json:"required,omitempty"`
+	// AdditionalProperties, when false, rejects object fields not listed in
+	// Properties.
+	AdditionalProperties *bool               `json:"additionalProperties,omitempty"`
+	Items                *ToolArgumentSchema `json:"items,omitempty"`
+	MaxItems             *int                `json:"maxItems,omitempty"`
+	// Enum lists the allowed values, compared like ToolArgumentRule.AllowedValues.
+	Enum      []string `json:"enum,omitempty"`
+	Pattern   string   `json:"pattern,omitempty"`
+	MinLength *int     `json:"minLength,omitempty"`
+	MaxLength *int     `json:"maxLength,omitempty"`
+	Minimum   *float64 `json:"minimum,omitempty"`
+	Maximum   *float64 `json:"maximum,omitempty"`
+}
+
+var toolArgumentSchemaTypes = []string{"object", "array", "string", "number", "integer", "boolean", "null"}
+
+func (c ToolArgumentConstraint) Validate() error {
+	if strings.TrimSpace(c.Tool) == "" {
+		return fmt.Errorf("tool is required")
+	}
+	if c.Schema == nil && len(c.Arguments) == 0 {
+		return fmt.Errorf("tool %q: a schema or argument rules are required", c.Tool)
+	}
+	if c.Schema != nil {
+		if err := c.Schema.Validate(); err != nil {
+			return fmt.Errorf("tool %q: invalid schema: %w", c.Tool, err)
+		}
+	}
+	for _, rule := range c.Arguments {
+		if err := rule.Validate(); err != nil {
+			return fmt.Errorf("tool %q: %w", c.Tool, err)
+		}
+	}
+	return nil
+}
+
+func (r ToolArgumentRule) Validate() error {
+	if r.Path == "" || slices.Contains(strings.Split(r.Path, "."), "") {
+		return fmt.Errorf("invalid argument path %q", r.Path)
+	}
+	if r.Pattern == "" && len(r.AllowedValues) == 0 && !r.Required {
+		return fmt.Errorf("argument %q: a pattern, allowed values, or required is needed", r.Path)
+	}
+	if r.Pattern != "" {
+		if _, err := regexp.Compile(r.Pattern); err != nil {
+			return fmt.Errorf("argument %q: invalid pattern: %w", r.Path, err)
+		}
+	}
+	return nil
+}
+
+func (s ToolArgumentSchema) Validate() error {
+	if s.Type != "" && !slices.Contains(toolArgumentSchemaTypes, s.Type) {
+		return fmt.Errorf("unsupported type %q", s.Type)
+	}
+	if s.Pattern != "" {
+		if _, err := regexp.Compile(s.Pattern); err != nil {
+			return fmt.Errorf("invalid pattern: %w", err)
+		}
+	}
+	for name, property := range s.Properties {
+		if err := property.Validate(); err != nil {
+			return fmt.Errorf("property %q: %w", name, err)
+		}
+	}
+	if s.Items != nil {
+		if err := s.Items.Validate(); err != nil {
+			return fmt.Errorf("items: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccessControlRuleManifestValidateToolConstraints(t *testing.T) {
+	manifest := AccessControlRuleManifest{
+		Subjects:  []Subject{{Type: SubjectTypeSelector, ID: "*"}},
+		Resources: []Resource{{Type: ResourceTypeMCPServerCatalogEntry, ID: "github"}},
+		ToolConstraints: []ToolArgumentConstraint{{
+			Tool:      "query_repos",
+			Arguments: []ToolArgumentRule{{Path: "repo", Pattern: "^acme/"}},
+			Schema: &ToolArgumentSchema{Type: "object", Properties: map[string]ToolArgumentSchema{
+				"limit": {Type: "integer"},
+			}},
+		}},
+	}
+	require.NoError(t, manifest.Validate())
+
+	for _, tt := range []struct {
+		name       string
+		constraint ToolArgumentConstraint
+		errorMsg   string
+	}{
+		{name: "no tool", constraint: ToolArgumentConstraint{Arguments: []ToolArgumentRule{{Path: "a", Required: true}}}, errorMsg: "tool is required"},
+		{name: "no checks", constraint: ToolArgumentConstraint{Tool: "t"}, errorMsg: "a schema or argument rules are required"},
+		{name: "empty path segment", constraint: ToolArgumentConstraint{Tool: "t", Arguments: []ToolArgumentRule{{Path: "a..b", Required: true}}}, errorMsg: "invalid argument path"},
+		{name: "rule without checks", constraint: ToolArgumentConstraint{Tool: "t", Arguments: []ToolArgumentRule{{Path: "a"}}}, errorMsg: "a pattern, allowed values, or required is needed"},
+		{name: "bad pattern", constraint: ToolArgumentConstraint{Tool: "t", Arguments: []ToolArgumentRule{{Path: "a", Pattern: "("}}}, errorMsg: "invalid pattern"},
+		{name: "bad nested schema type", constraint: ToolArgumentConstraint{Tool: "t", Schema: &ToolArgumentSchema{Properties: map[string]ToolArgumentSchema{
+			"a": {Type: "int"},
+		}}}, errorMsg: `property "a": unsupported type "int"`},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			invalid := manifest
+			invalid.ToolConstraints = []ToolArgumentConstraint{tt.constraint}
+			assert.ErrorContains(t, invalid.Validate(), tt.errorMsg)
+		})
+	}
+}
@@ -14,4 +14,7 @@ type Metadata struct {
 	Links    map[string]string `json:"links,omitempty"`
 	Metadata map[string]string `json:"metadata,omitempty"`
 	Type     string            `json:"type,omitempty"`
+	// ConfigRepositoryID is set when a config repository manages the object,
+	// which makes it read-only through the API.
+	ConfigRepositoryID string `json:"configRepositoryID,omitempty"`
 }
@@ -46,6 +46,50 @@ func (in *APIActivityList) DeepCopy() *APIActivityList {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIKeyCreateRequest) DeepCopyInto(out *APIKeyCreateRequest) {
+	*out = *in
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = (*in).DeepCopy()
+	}
+	if in.MCPServerIDs != nil {
+		in, out := &in.MCPServerIDs, &out.MCPServerIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIKeyCreateRequest.
+func (in *APIKeyCreateRequest) DeepCopy() *APIKeyCreateRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(APIKeyCreateRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIKeyCreateResponse) DeepCopyInto(out *APIKeyCreateResponse) {
+	*out = *in
+	if in.MCPServerIDs != nil {
+		in, out := &in.MCPServerIDs, &out.MCPServerIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIKeyCreateResponse.
+func (in *APIKeyCreateResponse) DeepCopy() *APIKeyCreateResponse {
+	if in == nil {
+		return nil
+	}
+	out := new(APIKeyCreateResponse)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AccessControlRule) DeepCopyInto(out *AccessControlRule) {
 	*out = *in
@@ -98,6 +142,13 @@ func (in *AccessControlRuleManifest) DeepCopyInto(out *AccessControlRuleManifest
 		*out = make([]Resource, len(*in))
 		copy(*out, *in)
 	}
+	if in.ToolConstraints != nil {
+		in, out := &in.ToolConstraints, &out.ToolConstraints
+		*out = make([]ToolArgumentConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessControlRuleManifest.
@@ -110,6 +161,126 @@ func (in *AccessControlRuleManifest) DeepCopy() *AccessControlRuleManifest {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessRequest) DeepCopyInto(out *AccessRequest) {
+	*out = *in
+	in.Metadata.DeepCopyInto(&out.Metadata)
+	out.AccessRequestManifest = in.AccessRequestManifest
+	if in.DecidedAt != nil {
+		in, out := &in.DecidedAt, &out.DecidedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = (*in).DeepCopy()
+	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]AccessRequestEvent, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessRequest.
+func (in *AccessRequest) DeepCopy() *AccessRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessRequestDecision) DeepCopyInto(out *AccessRequestDecision) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessRequestDecision.
+func (in *AccessRequestDecision) DeepCopy() *AccessRequestDecision {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessRequestDecision)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessRequestEvent) DeepCopyInto(out *AccessRequestEvent) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessRequestEvent.
+func (in *AccessRequestEvent) DeepCopy() *AccessRequestEvent {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessRequestEvent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessRequestList) DeepCopyInto(out *AccessRequestList) {
+	*out = *in
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AccessRequest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessRequestList.
+func (in *AccessRequestList) DeepCopy() *AccessRequestList {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessRequestList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessRequestManifest) DeepCopyInto(out *AccessRequestManifest) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessRequestManifest.
+func (in *AccessRequestManifest) DeepCopy() *AccessRequestManifest {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessRequestManifest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessRequestSetting) DeepCopyInto(out *AccessRequestSetting) {
+	*out = *in
+	if in.Approvers != nil {
+		in, out := &in.Approvers, &out.Approvers
+		*out = make([]Subject, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessRequestSetting.
+func (in *AccessRequestSetting) DeepCopy() *AccessRequestSetting {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessRequestSetting)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AgentCatalog) DeepCopyInto(out *AgentCatalog) {
 	*out = *in
@@ -867,6 +1038,51 @@ func (in *AuditLogTraceDetails) DeepCopy() *AuditLogTraceDetails {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditRedactionPattern) DeepCopyInto(out *AuditRedactionPattern) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditRedactionPattern.
+func (in *AuditRedactionPattern) DeepCopy() *AuditRedactionPattern {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditRedactionPattern)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditRedactionSetting) DeepCopyInto(out *AuditRedactionSetting) {
+	*out = *in
+	if in.DisabledDetectors != nil {
+		in, out := &in.DisabledDetectors, &out.DisabledDetectors
+		*out = make([]AuditRedactionDetector, len(*in))
+		copy(*out, *in)
+	}
+	if in.CustomPatterns != nil {
+		in, out := &in.CustomPatterns, &out.CustomPatterns
+		*out = make([]AuditRedactionPattern, len(*in))
+		copy(*out, *in)
+	}
+	if in.FieldMasks != nil {
+		in, out := &in.FieldMasks, &out.FieldMasks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditRedactionSetting.
+func (in *AuditRedactionSetting) DeepCopy() *AuditRedactionSetting {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditRedactionSetting)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AuthProvider) DeepCopyInto(out *AuthProvider) {
 	*out = *in
@@ -1131,160 +1347,315 @@ func (in *CompositeRuntimeConfig) DeepCopy() *CompositeRuntimeConfig {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ContainerizedRuntimeConfig) DeepCopyInto(out *ContainerizedRuntimeConfig) {
+func (in *ConfigChange) DeepCopyInto(out *ConfigChange) {
 	*out = *in
-	if in.Args != nil {
-		in, out := &in.Args, &out.Args
-		*out = make([]string, len(*in))
+	in.CreatedAt.DeepCopyInto(&out.CreatedAt)
+	if in.Before != nil {
+		in, out := &in.Before, &out.Before
+		*out = make(json.RawMessage, len(*in))
 		copy(*out, *in)
 	}
-	if in.EgressDomains != nil {
-		in, out := &in.EgressDomains, &out.EgressDomains
-		*out = make([]string, len(*in))
+	if in.After != nil {
+		in, out := &in.After, &out.After
+		*out = make(json.RawMessage, len(*in))
 		copy(*out, *in)
 	}
-	if in.DenyAllEgress != nil {
-		in, out := &in.DenyAllEgress, &out.DenyAllEgress
-		*out = new(bool)
-		**out = **in
+	if in.Diff != nil {
+		in, out := &in.Diff, &out.Diff
+		*out = make([]ConfigChangeDiff, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerizedRuntimeConfig.
-func (in *ContainerizedRuntimeConfig) DeepCopy() *ContainerizedRuntimeConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigChange.
+func (in *ConfigChange) DeepCopy() *ConfigChange {
 	if in == nil {
 		return nil
 	}
-	out := new(ContainerizedRuntimeConfig)
+	out := new(ConfigChange)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CustomS3Config) DeepCopyInto(out *CustomS3Config) {
+func (in *ConfigChangeDiff) DeepCopyInto(out *ConfigChangeDiff) {
 	*out = *in
+	if in.Before != nil {
+		in, out := &in.Before, &out.Before
+		*out = make(json.RawMessage, len(*in))
+		copy(*out, *in)
+	}
+	if in.After != nil {
+		in, out := &in.After, &out.After
+		*out = make(json.RawMessage, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomS3Config.
-func (in *CustomS3Config) DeepCopy() *CustomS3Config {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigChangeDiff.
+func (in *ConfigChangeDiff) DeepCopy() *ConfigChangeDiff {
 	if in == nil {
 		return nil
 	}
-	out := new(CustomS3Config)
+	out := new(ConfigChangeDiff)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DefaultModelAlias) DeepCopyInto(out *DefaultModelAlias) {
+func (in *ConfigChangeList) DeepCopyInto(out *ConfigChangeList) {
 	*out = *in
-	out.DefaultModelAliasManifest = in.DefaultModelAliasManifest
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ConfigChange, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DefaultModelAlias.
-func (in *DefaultModelAlias) DeepCopy() *DefaultModelAlias {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigChangeList.
+func (in *ConfigChangeList) DeepCopy() *ConfigChangeList {
 	if in == nil {
 		return nil
 	}
-	out := new(DefaultModelAlias)
+	out := new(ConfigChangeList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DefaultModelAliasList) DeepCopyInto(out *DefaultModelAliasList) {
+func (in *ConfigRepository) DeepCopyInto(out *ConfigRepository) {
 	*out = *in
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]DefaultModelAlias, len(*in))
+	in.Metadata.DeepCopyInto(&out.Metadata)
+	out.ConfigRepositoryManifest = in.ConfigRepositoryManifest
+	in.LastSyncTime.DeepCopyInto(&out.LastSyncTime)
+	if in.Objects != nil {
+		in, out := &in.Objects, &out.Objects
+		*out = make([]ConfigRepositoryObject, len(*in))
 		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DefaultModelAliasList.
-func (in *DefaultModelAliasList) DeepCopy() *DefaultModelAliasList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigRepository.
+func (in *ConfigRepository) DeepCopy() *ConfigRepository {
 	if in == nil {
 		return nil
 	}
-	out := new(DefaultModelAliasList)
+	out := new(ConfigRepository)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DefaultModelAliasManifest) DeepCopyInto(out *DefaultModelAliasManifest) {
+func (in *ConfigRepositoryList) DeepCopyInto(out *ConfigRepositoryList) {
 	*out = *in
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ConfigRepository, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DefaultModelAliasManifest.
-func (in *DefaultModelAliasManifest) DeepCopy() *DefaultModelAliasManifest {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigRepositoryList.
+func (in *ConfigRepositoryList) DeepCopy() *ConfigRepositoryList {
 	if in == nil {
 		return nil
 	}
-	out := new(DefaultModelAliasManifest)
+	out := new(ConfigRepositoryList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DeploymentCondition) DeepCopyInto(out *DeploymentCondition) {
+func (in *ConfigRepositoryManifest) DeepCopyInto(out *ConfigRepositoryManifest) {
 	*out = *in
-	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
-	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentCondition.
-func (in *DeploymentCondition) DeepCopy() *DeploymentCondition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigRepositoryManifest.
+func (in *ConfigRepositoryManifest) DeepCopy() *ConfigRepositoryManifest {
 	if in == nil {
 		return nil
 	}
-	out := new(DeploymentCondition)
+	out := new(ConfigRepositoryManifest)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Device) DeepCopyInto(out *Device) {
+func (in *ConfigRepositoryObject) DeepCopyInto(out *ConfigRepositoryObject) {
 	*out = *in
-	in.EnrolledAt.DeepCopyInto(&out.EnrolledAt)
-	if in.LastSeenAt != nil {
-		in, out := &in.LastSeenAt, &out.LastSeenAt
-		*out = (*in).DeepCopy()
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Device.
-func (in *Device) DeepCopy() *Device {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigRepositoryObject.
+func (in *ConfigRepositoryObject) DeepCopy() *ConfigRepositoryObject {
 	if in == nil {
 		return nil
 	}
-	out := new(Device)
+	out := new(ConfigRepositoryObject)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DeviceClientFleetSkill) DeepCopyInto(out *DeviceClientFleetSkill) {
+func (in *ContainerizedRuntimeConfig) DeepCopyInto(out *ContainerizedRuntimeConfig) {
 	*out = *in
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.EgressDomains != nil {
+		in, out := &in.EgressDomains, &out.EgressDomains
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DenyAllEgress != nil {
+		in, out := &in.DenyAllEgress, &out.DenyAllEgress
+		*out = new(bool)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeviceClientFleetSkill.
-func (in *DeviceClientFleetSkill) DeepCopy() *DeviceClientFleetSkill {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerizedRuntimeConfig.
+func (in *ContainerizedRuntimeConfig) DeepCopy() *ContainerizedRuntimeConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(DeviceClientFleetSkill)
+	out := new(ContainerizedRuntimeConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DeviceClientFleetSummary) DeepCopyInto(out *DeviceClientFleetSummary) {
+func (in *CustomS3Config) DeepCopyInto(out *CustomS3Config) {
 	*out = *in
-	if in.Users != nil {
-		in, out := &in.Users, &out.Users
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomS3Config.
+func (in *CustomS3Config) DeepCopy() *CustomS3Config {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomS3Config)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DefaultModelAlias) DeepCopyInto(out *DefaultModelAlias) {
+	*out = *in
+	out.DefaultModelAliasManifest = in.DefaultModelAliasManifest
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DefaultModelAlias.
+func (in *DefaultModelAlias) DeepCopy() *DefaultModelAlias {
+	if in == nil {
+		return nil
+	}
+	out := new(DefaultModelAlias)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DefaultModelAliasList) DeepCopyInto(out *DefaultModelAliasList) {
+	*out = *in
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DefaultModelAlias, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DefaultModelAliasList.
+func (in *DefaultModelAliasList) DeepCopy() *DefaultModelAliasList {
+	if in == nil {
+		return nil
+	}
+	out := new(DefaultModelAliasList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DefaultModelAliasManifest) DeepCopyInto(out *DefaultModelAliasManifest) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DefaultModelAliasManifest.
+func (in *DefaultModelAliasManifest) DeepCopy() *DefaultModelAliasManifest {
+	if in == nil {
+		return nil
+	}
+	out := new(DefaultModelAliasManifest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeploymentCondition) DeepCopyInto(out *DeploymentCondition) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentCondition.
+func (in *DeploymentCondition) DeepCopy() *DeploymentCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(DeploymentCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Device) DeepCopyInto(out *Device) {
+	*out = *in
+	in.EnrolledAt.DeepCopyInto(&out.EnrolledAt)
+	if in.LastSeenAt != nil {
+		in, out := &in.LastSeenAt, &out.LastSeenAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Device.
+func (in *Device) DeepCopy() *Device {
+	if in == nil {
+		return nil
+	}
+	out := new(Device)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeviceClientFleetSkill) DeepCopyInto(out *DeviceClientFleetSkill) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeviceClientFleetSkill.
+func (in *DeviceClientFleetSkill) DeepCopy() *DeviceClientFleetSkill {
+	if in == nil {
+		return nil
+	}
+	out := new(DeviceClientFleetSkill)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeviceClientFleetSummary) DeepCopyInto(out *DeviceClientFleetSummary) {
+	*out = *in
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 	if in.Skills != nil {
 		in, out := &in.Skills, &out.Skills
@@ -2199,6 +2570,11 @@ func (in *GitCredentialUses) DeepCopyInto(out *GitCredentialUses) {
 		*out = make([]GitCredentialUse, len(*in))
 		copy(*out, *in)
 	}
+	if in.ConfigRepositories != nil {
+		in, out := &in.ConfigRepositories, &out.ConfigRepositories
+		*out = make([]GitCredentialUse, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitCredentialUses.
@@ -2485,6 +2861,10 @@ func (in *HostedAgentInstanceStatus) DeepCopyInto(out *HostedAgentInstanceStatus
 		in, out := &in.LastObservedTime, &out.LastObservedTime
 		*out = (*in).DeepCopy()
 	}
+	if in.LastActivityTime != nil {
+		in, out := &in.LastActivityTime, &out.LastActivityTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostedAgentInstanceStatus.
@@ -2798,251 +3178,479 @@ func (in *HostedAgentResourceQuantity) DeepCopy() *HostedAgentResourceQuantity {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ImagePullSecret) DeepCopyInto(out *ImagePullSecret) {
+func (in *HostedAgentSnapshot) DeepCopyInto(out *HostedAgentSnapshot) {
 	*out = *in
 	in.Metadata.DeepCopyInto(&out.Metadata)
-	in.Manifest.DeepCopyInto(&out.Manifest)
+	out.HostedAgentSnapshotManifest = in.HostedAgentSnapshotManifest
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = (*in).DeepCopy()
+	}
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePullSecret.
-func (in *ImagePullSecret) DeepCopy() *ImagePullSecret {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostedAgentSnapshot.
+func (in *HostedAgentSnapshot) DeepCopy() *HostedAgentSnapshot {
 	if in == nil {
 		return nil
 	}
-	out := new(ImagePullSecret)
+	out := new(HostedAgentSnapshot)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ImagePullSecretCapability) DeepCopyInto(out *ImagePullSecretCapability) {
+func (in *HostedAgentSnapshotCloneRequest) DeepCopyInto(out *HostedAgentSnapshotCloneRequest) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePullSecretCapability.
-func (in *ImagePullSecretCapability) DeepCopy() *ImagePullSecretCapability {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostedAgentSnapshotCloneRequest.
+func (in *HostedAgentSnapshotCloneRequest) DeepCopy() *HostedAgentSnapshotCloneRequest {
 	if in == nil {
 		return nil
 	}
-	out := new(ImagePullSecretCapability)
+	out := new(HostedAgentSnapshotCloneRequest)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ImagePullSecretList) DeepCopyInto(out *ImagePullSecretList) {
+func (in *HostedAgentSnapshotList) DeepCopyInto(out *HostedAgentSnapshotList) {
 	*out = *in
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]ImagePullSecret, len(*in))
+		*out = make([]HostedAgentSnapshot, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePullSecretList.
-func (in *ImagePullSecretList) DeepCopy() *ImagePullSecretList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostedAgentSnapshotList.
+func (in *HostedAgentSnapshotList) DeepCopy() *HostedAgentSnapshotList {
 	if in == nil {
 		return nil
 	}
-	out := new(ImagePullSecretList)
+	out := new(HostedAgentSnapshotList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ImagePullSecretManifest) DeepCopyInto(out *ImagePullSecretManifest) {
+func (in *HostedAgentSnapshotManifest) DeepCopyInto(out *HostedAgentSnapshotManifest) {
 	*out = *in
-	if in.Basic != nil {
-		in, out := &in.Basic, &out.Basic
-		*out = new(BasicImagePullSecretConfig)
-		**out = **in
-	}
-	if in.ECR != nil {
-		in, out := &in.ECR, &out.ECR
-		*out = new(ECRImagePullSecretConfig)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePullSecretManifest.
-func (in *ImagePullSecretManifest) DeepCopy() *ImagePullSecretManifest {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostedAgentSnapshotManifest.
+func (in *HostedAgentSnapshotManifest) DeepCopy() *HostedAgentSnapshotManifest {
 	if in == nil {
 		return nil
 	}
-	out := new(ImagePullSecretManifest)
+	out := new(HostedAgentSnapshotManifest)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ImagePullSecretRefreshResponse) DeepCopyInto(out *ImagePullSecretRefreshResponse) {
+func (in *HostedAgentSnapshotRestoreRequest) DeepCopyInto(out *HostedAgentSnapshotRestoreRequest) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePullSecretRefreshResponse.
-func (in *ImagePullSecretRefreshResponse) DeepCopy() *ImagePullSecretRefreshResponse {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostedAgentSnapshotRestoreRequest.
+func (in *HostedAgentSnapshotRestoreRequest) DeepCopy() *HostedAgentSnapshotRestoreRequest {
 	if in == nil {
 		return nil
 	}
-	out := new(ImagePullSecretRefreshResponse)
+	out := new(HostedAgentSnapshotRestoreRequest)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ImagePullSecretStatus) DeepCopyInto(out *ImagePullSecretStatus) {
+func (in *HostedAgentSnapshotStatus) DeepCopyInto(out *HostedAgentSnapshotStatus) {
 	*out = *in
-	if in.LastReconciledTime != nil {
-		in, out := &in.LastReconciledTime, &out.LastReconciledTime
-		*out = (*in).DeepCopy()
-	}
-	if in.LastSuccessTime != nil {
-		in, out := &in.LastSuccessTime, &out.LastSuccessTime
-		*out = (*in).DeepCopy()
-	}
-	if in.TokenExpiresAt != nil {
-		in, out := &in.TokenExpiresAt, &out.TokenExpiresAt
+	if in.CompletedTime != nil {
+		in, out := &in.CompletedTime, &out.CompletedTime
 		*out = (*in).DeepCopy()
 	}
-	if in.RegistryEndpoints != nil {
-		in, out := &in.RegistryEndpoints, &out.RegistryEndpoints
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePullSecretStatus.
-func (in *ImagePullSecretStatus) DeepCopy() *ImagePullSecretStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostedAgentSnapshotStatus.
+func (in *HostedAgentSnapshotStatus) DeepCopy() *HostedAgentSnapshotStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ImagePullSecretStatus)
+	out := new(HostedAgentSnapshotStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ImagePullSecretTestRequest) DeepCopyInto(out *ImagePullSecretTestRequest) {
+func (in *HostedAgentTrigger) DeepCopyInto(out *HostedAgentTrigger) {
 	*out = *in
+	in.Metadata.DeepCopyInto(&out.Metadata)
+	out.HostedAgentTriggerManifest = in.HostedAgentTriggerManifest
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePullSecretTestRequest.
-func (in *ImagePullSecretTestRequest) DeepCopy() *ImagePullSecretTestRequest {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostedAgentTrigger.
+func (in *HostedAgentTrigger) DeepCopy() *HostedAgentTrigger {
 	if in == nil {
 		return nil
 	}
-	out := new(ImagePullSecretTestRequest)
+	out := new(HostedAgentTrigger)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ImagePullSecretTestResponse) DeepCopyInto(out *ImagePullSecretTestResponse) {
+func (in *HostedAgentTriggerInvocation) DeepCopyInto(out *HostedAgentTriggerInvocation) {
 	*out = *in
+	in.CreatedAt.DeepCopyInto(&out.CreatedAt)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePullSecretTestResponse.
-func (in *ImagePullSecretTestResponse) DeepCopy() *ImagePullSecretTestResponse {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostedAgentTriggerInvocation.
+func (in *HostedAgentTriggerInvocation) DeepCopy() *HostedAgentTriggerInvocation {
 	if in == nil {
 		return nil
 	}
-	out := new(ImagePullSecretTestResponse)
+	out := new(HostedAgentTriggerInvocation)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *K8sSettings) DeepCopyInto(out *K8sSettings) {
+func (in *HostedAgentTriggerInvocationList) DeepCopyInto(out *HostedAgentTriggerInvocationList) {
 	*out = *in
-	if in.PodSecurityAdmission != nil {
-		in, out := &in.PodSecurityAdmission, &out.PodSecurityAdmission
-		*out = new(PodSecurityAdmissionSettings)
-		**out = **in
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]HostedAgentTriggerInvocation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	in.Metadata.DeepCopyInto(&out.Metadata)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new K8sSettings.
-func (in *K8sSettings) DeepCopy() *K8sSettings {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostedAgentTriggerInvocationList.
+func (in *HostedAgentTriggerInvocationList) DeepCopy() *HostedAgentTriggerInvocationList {
 	if in == nil {
 		return nil
 	}
-	out := new(K8sSettings)
+	out := new(HostedAgentTriggerInvocationList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *K8sSettingsStatus) DeepCopyInto(out *K8sSettingsStatus) {
+func (in *HostedAgentTriggerList) DeepCopyInto(out *HostedAgentTriggerList) {
 	*out = *in
-	if in.CurrentSettings != nil {
-		in, out := &in.CurrentSettings, &out.CurrentSettings
-		*out = new(K8sSettings)
-		(*in).DeepCopyInto(*out)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]HostedAgentTrigger, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new K8sSettingsStatus.
-func (in *K8sSettingsStatus) DeepCopy() *K8sSettingsStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostedAgentTriggerList.
+func (in *HostedAgentTriggerList) DeepCopy() *HostedAgentTriggerList {
 	if in == nil {
 		return nil
 	}
-	out := new(K8sSettingsStatus)
+	out := new(HostedAgentTriggerList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LLMAuditLog) DeepCopyInto(out *LLMAuditLog) {
+func (in *HostedAgentTriggerManifest) DeepCopyInto(out *HostedAgentTriggerManifest) {
 	*out = *in
-	in.CreatedAt.DeepCopyInto(&out.CreatedAt)
-	if in.APIKeyID != nil {
-		in, out := &in.APIKeyID, &out.APIKeyID
-		*out = new(uint)
-		**out = **in
-	}
-	if in.RequestHeaders != nil {
-		in, out := &in.RequestHeaders, &out.RequestHeaders
-		*out = make(json.RawMessage, len(*in))
-		copy(*out, *in)
-	}
-	if in.RequestBody != nil {
-		in, out := &in.RequestBody, &out.RequestBody
-		*out = make(json.RawMessage, len(*in))
-		copy(*out, *in)
-	}
-	if in.PolicyModifiedRequestBody != nil {
-		in, out := &in.PolicyModifiedRequestBody, &out.PolicyModifiedRequestBody
-		*out = make(json.RawMessage, len(*in))
-		copy(*out, *in)
-	}
-	if in.ResponseHeaders != nil {
-		in, out := &in.ResponseHeaders, &out.ResponseHeaders
-		*out = make(json.RawMessage, len(*in))
-		copy(*out, *in)
-	}
-	if in.ResponseBody != nil {
-		in, out := &in.ResponseBody, &out.ResponseBody
-		*out = make(json.RawMessage, len(*in))
-		copy(*out, *in)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LLMAuditLog.
-func (in *LLMAuditLog) DeepCopy() *LLMAuditLog {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostedAgentTriggerManifest.
+func (in *HostedAgentTriggerManifest) DeepCopy() *HostedAgentTriggerManifest {
 	if in == nil {
 		return nil
 	}
-	out := new(LLMAuditLog)
+	out := new(HostedAgentTriggerManifest)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LLMAuditLogExportFilters) DeepCopyInto(out *LLMAuditLogExportFilters) {
+func (in *HostedAgentTriggerStatus) DeepCopyInto(out *HostedAgentTriggerStatus) {
+	*out = *in
+	if in.LastRunTime != nil {
+		in, out := &in.LastRunTime, &out.LastRunTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextRunTime != nil {
+		in, out := &in.NextRunTime, &out.NextRunTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastInvocationTime != nil {
+		in, out := &in.LastInvocationTime, &out.LastInvocationTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostedAgentTriggerStatus.
+func (in *HostedAgentTriggerStatus) DeepCopy() *HostedAgentTriggerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HostedAgentTriggerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImagePullSecret) DeepCopyInto(out *ImagePullSecret) {
+	*out = *in
+	in.Metadata.DeepCopyInto(&out.Metadata)
+	in.Manifest.DeepCopyInto(&out.Manifest)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePullSecret.
+func (in *ImagePullSecret) DeepCopy() *ImagePullSecret {
+	if in == nil {
+		return nil
+	}
+	out := new(ImagePullSecret)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImagePullSecretCapability) DeepCopyInto(out *ImagePullSecretCapability) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePullSecretCapability.
+func (in *ImagePullSecretCapability) DeepCopy() *ImagePullSecretCapability {
+	if in == nil {
+		return nil
+	}
+	out := new(ImagePullSecretCapability)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImagePullSecretList) DeepCopyInto(out *ImagePullSecretList) {
+	*out = *in
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ImagePullSecret, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePullSecretList.
+func (in *ImagePullSecretList) DeepCopy() *ImagePullSecretList {
+	if in == nil {
+		return nil
+	}
+	out := new(ImagePullSecretList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImagePullSecretManifest) DeepCopyInto(out *ImagePullSecretManifest) {
+	*out = *in
+	if in.Basic != nil {
+		in, out := &in.Basic, &out.Basic
+		*out = new(BasicImagePullSecretConfig)
+		**out = **in
+	}
+	if in.ECR != nil {
+		in, out := &in.ECR, &out.ECR
+		*out = new(ECRImagePullSecretConfig)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePullSecretManifest.
+func (in *ImagePullSecretManifest) DeepCopy() *ImagePullSecretManifest {
+	if in == nil {
+		return nil
+	}
+	out := new(ImagePullSecretManifest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImagePullSecretRefreshResponse) DeepCopyInto(out *ImagePullSecretRefreshResponse) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePullSecretRefreshResponse.
+func (in *ImagePullSecretRefreshResponse) DeepCopy() *ImagePullSecretRefreshResponse {
+	if in == nil {
+		return nil
+	}
+	out := new(ImagePullSecretRefreshResponse)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImagePullSecretStatus) DeepCopyInto(out *ImagePullSecretStatus) {
+	*out = *in
+	if in.LastReconciledTime != nil {
+		in, out := &in.LastReconciledTime, &out.LastReconciledTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastSuccessTime != nil {
+		in, out := &in.LastSuccessTime, &out.LastSuccessTime
+		*out = (*in).DeepCopy()
+	}
+	if in.TokenExpiresAt != nil {
+		in, out := &in.TokenExpiresAt, &out.TokenExpiresAt
+		*out = (*in).DeepCopy()
+	}
+	if in.RegistryEndpoints != nil {
+		in, out := &in.RegistryEndpoints, &out.RegistryEndpoints
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePullSecretStatus.
+func (in *ImagePullSecretStatus) DeepCopy() *ImagePullSecretStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ImagePullSecretStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImagePullSecretTestRequest) DeepCopyInto(out *ImagePullSecretTestRequest) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePullSecretTestRequest.
+func (in *ImagePullSecretTestRequest) DeepCopy() *ImagePullSecretTestRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(ImagePullSecretTestRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImagePullSecretTestResponse) DeepCopyInto(out *ImagePullSecretTestResponse) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePullSecretTestResponse.
+func (in *ImagePullSecretTestResponse) DeepCopy() *ImagePullSecretTestResponse {
+	if in == nil {
+		return nil
+	}
+	out := new(ImagePullSecretTestResponse)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *K8sSettings) DeepCopyInto(out *K8sSettings) {
+	*out = *in
+	if in.PodSecurityAdmission != nil {
+		in, out := &in.PodSecurityAdmission, &out.PodSecurityAdmission
+		*out = new(PodSecurityAdmissionSettings)
+		**out = **in
+	}
+	in.Metadata.DeepCopyInto(&out.Metadata)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new K8sSettings.
+func (in *K8sSettings) DeepCopy() *K8sSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(K8sSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *K8sSettingsStatus) DeepCopyInto(out *K8sSettingsStatus) {
+	*out = *in
+	if in.CurrentSettings != nil {
+		in, out := &in.CurrentSettings, &out.CurrentSettings
+		*out = new(K8sSettings)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new K8sSettingsStatus.
+func (in *K8sSettingsStatus) DeepCopy() *K8sSettingsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(K8sSettingsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LLMAuditLog) DeepCopyInto(out *LLMAuditLog) {
+	*out = *in
+	in.CreatedAt.DeepCopyInto(&out.CreatedAt)
+	if in.APIKeyID != nil {
+		in, out := &in.APIKeyID, &out.APIKeyID
+		*out = new(uint)
+		**out = **in
+	}
+	if in.RequestHeaders != nil {
+		in, out := &in.RequestHeaders, &out.RequestHeaders
+		*out = make(json.RawMessage, len(*in))
+		copy(*out, *in)
+	}
+	if in.RequestBody != nil {
+		in, out := &in.RequestBody, &out.RequestBody
+		*out = make(json.RawMessage, len(*in))
+		copy(*out, *in)
+	}
+	if in.PolicyModifiedRequestBody != nil {
+		in, out := &in.PolicyModifiedRequestBody, &out.PolicyModifiedRequestBody
+		*out = make(json.RawMessage, len(*in))
+		copy(*out, *in)
+	}
+	if in.ResponseHeaders != nil {
+		in, out := &in.ResponseHeaders, &out.ResponseHeaders
+		*out = make(json.RawMessage, len(*in))
+		copy(*out, *in)
+	}
+	if in.ResponseBody != nil {
+		in, out := &in.ResponseBody, &out.ResponseBody
+		*out = make(json.RawMessage, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LLMAuditLog.
+func (in *LLMAuditLog) DeepCopy() *LLMAuditLog {
+	if in == nil {
+		return nil
+	}
+	out := new(LLMAuditLog)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LLMAuditLogExportFilters) DeepCopyInto(out *LLMAuditLogExportFilters) {
 	*out = *in
 	if in.APIKeyIDs != nil {
 		in, out := &in.APIKeyIDs, &out.APIKeyIDs
@@ -3426,6 +4034,13 @@ func (in *MCPAllowedSecretBindingTargetList) DeepCopy() *MCPAllowedSecretBinding
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MCPCapacityInfo) DeepCopyInto(out *MCPCapacityInfo) {
 	*out = *in
+	if in.ServerHealth != nil {
+		in, out := &in.ServerHealth, &out.ServerHealth
+		*out = make([]MCPServerHealthSummary, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPCapacityInfo.
@@ -3540,52 +4155,137 @@ func (in *MCPEnv) DeepCopyInto(out *MCPEnv) {
 	in.MCPHeader.DeepCopyInto(&out.MCPHeader)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPEnv.
-func (in *MCPEnv) DeepCopy() *MCPEnv {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPEnv.
+func (in *MCPEnv) DeepCopy() *MCPEnv {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPEnv)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPHeader) DeepCopyInto(out *MCPHeader) {
+	*out = *in
+	if in.Options != nil {
+		in, out := &in.Options, &out.Options
+		*out = make([]MCPConfigurationOption, len(*in))
+		copy(*out, *in)
+	}
+	if in.SecretBinding != nil {
+		in, out := &in.SecretBinding, &out.SecretBinding
+		*out = new(MCPSecretBinding)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPHeader.
+func (in *MCPHeader) DeepCopy() *MCPHeader {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPHeader)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPPromptReadStats) DeepCopyInto(out *MCPPromptReadStats) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPPromptReadStats.
+func (in *MCPPromptReadStats) DeepCopy() *MCPPromptReadStats {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPPromptReadStats)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPQuotaRule) DeepCopyInto(out *MCPQuotaRule) {
+	*out = *in
+	in.Metadata.DeepCopyInto(&out.Metadata)
+	in.MCPQuotaRuleManifest.DeepCopyInto(&out.MCPQuotaRuleManifest)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPQuotaRule.
+func (in *MCPQuotaRule) DeepCopy() *MCPQuotaRule {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPQuotaRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPQuotaRuleList) DeepCopyInto(out *MCPQuotaRuleList) {
+	*out = *in
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]MCPQuotaRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPQuotaRuleList.
+func (in *MCPQuotaRuleList) DeepCopy() *MCPQuotaRuleList {
 	if in == nil {
 		return nil
 	}
-	out := new(MCPEnv)
+	out := new(MCPQuotaRuleList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MCPHeader) DeepCopyInto(out *MCPHeader) {
+func (in *MCPQuotaRuleManifest) DeepCopyInto(out *MCPQuotaRuleManifest) {
 	*out = *in
-	if in.Options != nil {
-		in, out := &in.Options, &out.Options
-		*out = make([]MCPConfigurationOption, len(*in))
+	if in.Subjects != nil {
+		in, out := &in.Subjects, &out.Subjects
+		*out = make([]Subject, len(*in))
 		copy(*out, *in)
 	}
-	if in.SecretBinding != nil {
-		in, out := &in.SecretBinding, &out.SecretBinding
-		*out = new(MCPSecretBinding)
-		**out = **in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]Resource, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tools != nil {
+		in, out := &in.Tools, &out.Tools
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPHeader.
-func (in *MCPHeader) DeepCopy() *MCPHeader {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPQuotaRuleManifest.
+func (in *MCPQuotaRuleManifest) DeepCopy() *MCPQuotaRuleManifest {
 	if in == nil {
 		return nil
 	}
-	out := new(MCPHeader)
+	out := new(MCPQuotaRuleManifest)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MCPPromptReadStats) DeepCopyInto(out *MCPPromptReadStats) {
+func (in *MCPQuotaUsage) DeepCopyInto(out *MCPQuotaUsage) {
 	*out = *in
+	in.ResetsAt.DeepCopyInto(&out.ResetsAt)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPPromptReadStats.
-func (in *MCPPromptReadStats) DeepCopy() *MCPPromptReadStats {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPQuotaUsage.
+func (in *MCPQuotaUsage) DeepCopy() *MCPQuotaUsage {
 	if in == nil {
 		return nil
 	}
-	out := new(MCPPromptReadStats)
+	out := new(MCPQuotaUsage)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -3740,6 +4440,11 @@ func (in *MCPServer) DeepCopyInto(out *MCPServer) {
 		*out = new(OAuthMetadata)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Health != nil {
+		in, out := &in.Health, &out.Health
+		*out = new(MCPServerHealth)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPServer.
@@ -3909,6 +4614,72 @@ func (in *MCPServerEvent) DeepCopy() *MCPServerEvent {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPServerHealth) DeepCopyInto(out *MCPServerHealth) {
+	*out = *in
+	if in.LastChecked != nil {
+		in, out := &in.LastChecked, &out.LastChecked
+		*out = (*in).DeepCopy()
+	}
+	if in.LastSuccess != nil {
+		in, out := &in.LastSuccess, &out.LastSuccess
+		*out = (*in).DeepCopy()
+	}
+	if in.LastFailure != nil {
+		in, out := &in.LastFailure, &out.LastFailure
+		*out = (*in).DeepCopy()
+	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]MCPServerHealthCheck, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPServerHealth.
+func (in *MCPServerHealth) DeepCopy() *MCPServerHealth {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPServerHealth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPServerHealthCheck) DeepCopyInto(out *MCPServerHealthCheck) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPServerHealthCheck.
+func (in *MCPServerHealthCheck) DeepCopy() *MCPServerHealthCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPServerHealthCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPServerHealthSummary) DeepCopyInto(out *MCPServerHealthSummary) {
+	*out = *in
+	in.Health.DeepCopyInto(&out.Health)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPServerHealthSummary.
+func (in *MCPServerHealthSummary) DeepCopy() *MCPServerHealthSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPServerHealthSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MCPServerInstance) DeepCopyInto(out *MCPServerInstance) {
 	*out = *in
@@ -4072,84 +4843,290 @@ func (in *MCPServerNeedingK8sUpdate) DeepCopy() *MCPServerNeedingK8sUpdate {
 	if in == nil {
 		return nil
 	}
-	out := new(MCPServerNeedingK8sUpdate)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MCPServerOAuthCredentialRequest) DeepCopyInto(out *MCPServerOAuthCredentialRequest) {
-	*out = *in
+	out := new(MCPServerNeedingK8sUpdate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPServerOAuthCredentialRequest) DeepCopyInto(out *MCPServerOAuthCredentialRequest) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPServerOAuthCredentialRequest.
+func (in *MCPServerOAuthCredentialRequest) DeepCopy() *MCPServerOAuthCredentialRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPServerOAuthCredentialRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPServerOAuthCredentialStatus) DeepCopyInto(out *MCPServerOAuthCredentialStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPServerOAuthCredentialStatus.
+func (in *MCPServerOAuthCredentialStatus) DeepCopy() *MCPServerOAuthCredentialStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPServerOAuthCredentialStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPServerRequestPolicy) DeepCopyInto(out *MCPServerRequestPolicy) {
+	*out = *in
+	in.Metadata.DeepCopyInto(&out.Metadata)
+	in.MCPServerRequestPolicyManifest.DeepCopyInto(&out.MCPServerRequestPolicyManifest)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPServerRequestPolicy.
+func (in *MCPServerRequestPolicy) DeepCopy() *MCPServerRequestPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPServerRequestPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPServerRequestPolicyList) DeepCopyInto(out *MCPServerRequestPolicyList) {
+	*out = *in
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]MCPServerRequestPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPServerRequestPolicyList.
+func (in *MCPServerRequestPolicyList) DeepCopy() *MCPServerRequestPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPServerRequestPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPServerRequestPolicyManifest) DeepCopyInto(out *MCPServerRequestPolicyManifest) {
+	*out = *in
+	if in.Subjects != nil {
+		in, out := &in.Subjects, &out.Subjects
+		*out = make([]Subject, len(*in))
+		copy(*out, *in)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]Resource, len(*in))
+		copy(*out, *in)
+	}
+	if in.Approvers != nil {
+		in, out := &in.Approvers, &out.Approvers
+		*out = make([]Subject, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPServerRequestPolicyManifest.
+func (in *MCPServerRequestPolicyManifest) DeepCopy() *MCPServerRequestPolicyManifest {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPServerRequestPolicyManifest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPServerTool) DeepCopyInto(out *MCPServerTool) {
+	*out = *in
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Credentials != nil {
+		in, out := &in.Credentials, &out.Credentials
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPServerTool.
+func (in *MCPServerTool) DeepCopy() *MCPServerTool {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPServerTool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPServersNeedingK8sUpdateList) DeepCopyInto(out *MCPServersNeedingK8sUpdateList) {
+	*out = *in
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]MCPServerNeedingK8sUpdate, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPServersNeedingK8sUpdateList.
+func (in *MCPServersNeedingK8sUpdateList) DeepCopy() *MCPServersNeedingK8sUpdateList {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPServersNeedingK8sUpdateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPToolApprovalPolicy) DeepCopyInto(out *MCPToolApprovalPolicy) {
+	*out = *in
+	in.Metadata.DeepCopyInto(&out.Metadata)
+	in.MCPToolApprovalPolicyManifest.DeepCopyInto(&out.MCPToolApprovalPolicyManifest)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPToolApprovalPolicy.
+func (in *MCPToolApprovalPolicy) DeepCopy() *MCPToolApprovalPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPToolApprovalPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPToolApprovalPolicyList) DeepCopyInto(out *MCPToolApprovalPolicyList) {
+	*out = *in
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]MCPToolApprovalPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPToolApprovalPolicyList.
+func (in *MCPToolApprovalPolicyList) DeepCopy() *MCPToolApprovalPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPToolApprovalPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPToolApprovalPolicyManifest) DeepCopyInto(out *MCPToolApprovalPolicyManifest) {
+	*out = *in
+	if in.Subjects != nil {
+		in, out := &in.Subjects, &out.Subjects
+		*out = make([]Subject, len(*in))
+		copy(*out, *in)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]Resource, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tools != nil {
+		in, out := &in.Tools, &out.Tools
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Approvers != nil {
+		in, out := &in.Approvers, &out.Approvers
+		*out = make([]Subject, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPServerOAuthCredentialRequest.
-func (in *MCPServerOAuthCredentialRequest) DeepCopy() *MCPServerOAuthCredentialRequest {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPToolApprovalPolicyManifest.
+func (in *MCPToolApprovalPolicyManifest) DeepCopy() *MCPToolApprovalPolicyManifest {
 	if in == nil {
 		return nil
 	}
-	out := new(MCPServerOAuthCredentialRequest)
+	out := new(MCPToolApprovalPolicyManifest)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MCPServerOAuthCredentialStatus) DeepCopyInto(out *MCPServerOAuthCredentialStatus) {
+func (in *MCPToolCallApproval) DeepCopyInto(out *MCPToolCallApproval) {
 	*out = *in
+	in.Metadata.DeepCopyInto(&out.Metadata)
+	if in.Arguments != nil {
+		in, out := &in.Arguments, &out.Arguments
+		*out = make(json.RawMessage, len(*in))
+		copy(*out, *in)
+	}
+	in.ExpiresAt.DeepCopyInto(&out.ExpiresAt)
+	if in.DecidedAt != nil {
+		in, out := &in.DecidedAt, &out.DecidedAt
+		*out = (*in).DeepCopy()
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPServerOAuthCredentialStatus.
-func (in *MCPServerOAuthCredentialStatus) DeepCopy() *MCPServerOAuthCredentialStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPToolCallApproval.
+func (in *MCPToolCallApproval) DeepCopy() *MCPToolCallApproval {
 	if in == nil {
 		return nil
 	}
-	out := new(MCPServerOAuthCredentialStatus)
+	out := new(MCPToolCallApproval)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MCPServerTool) DeepCopyInto(out *MCPServerTool) {
+func (in *MCPToolCallApprovalDecision) DeepCopyInto(out *MCPToolCallApprovalDecision) {
 	*out = *in
-	if in.Params != nil {
-		in, out := &in.Params, &out.Params
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.Credentials != nil {
-		in, out := &in.Credentials, &out.Credentials
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPServerTool.
-func (in *MCPServerTool) DeepCopy() *MCPServerTool {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPToolCallApprovalDecision.
+func (in *MCPToolCallApprovalDecision) DeepCopy() *MCPToolCallApprovalDecision {
 	if in == nil {
 		return nil
 	}
-	out := new(MCPServerTool)
+	out := new(MCPToolCallApprovalDecision)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MCPServersNeedingK8sUpdateList) DeepCopyInto(out *MCPServersNeedingK8sUpdateList) {
+func (in *MCPToolCallApprovalList) DeepCopyInto(out *MCPToolCallApprovalList) {
 	*out = *in
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]MCPServerNeedingK8sUpdate, len(*in))
-		copy(*out, *in)
+		*out = make([]MCPToolCallApproval, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPServersNeedingK8sUpdateList.
-func (in *MCPServersNeedingK8sUpdateList) DeepCopy() *MCPServersNeedingK8sUpdateList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPToolCallApprovalList.
+func (in *MCPToolCallApprovalList) DeepCopy() *MCPToolCallApprovalList {
 	if in == nil {
 		return nil
 	}
-	out := new(MCPServersNeedingK8sUpdateList)
+	out := new(MCPToolCallApprovalList)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -4295,6 +5272,13 @@ func (in *MCPUsageStats) DeepCopyInto(out *MCPUsageStats) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Quotas != nil {
+		in, out := &in.Quotas, &out.Quotas
+		*out = make([]MCPQuotaUsage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPUsageStats.
@@ -5267,149 +6251,388 @@ func (in *ModelProviderStatus) DeepCopy() *ModelProviderStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ModelProviderStatus)
+	out := new(ModelProviderStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelResource) DeepCopyInto(out *ModelResource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelResource.
+func (in *ModelResource) DeepCopy() *ModelResource {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelStatus) DeepCopyInto(out *ModelStatus) {
+	*out = *in
+	if in.AliasAssigned != nil {
+		in, out := &in.AliasAssigned, &out.AliasAssigned
+		*out = new(bool)
+		**out = **in
+	}
+	in.Cost.DeepCopyInto(&out.Cost)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelStatus.
+func (in *ModelStatus) DeepCopy() *ModelStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultiUserConfig) DeepCopyInto(out *MultiUserConfig) {
+	*out = *in
+	if in.UserDefinedHeaders != nil {
+		in, out := &in.UserDefinedHeaders, &out.UserDefinedHeaders
+		*out = make([]MCPHeader, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiUserConfig.
+func (in *MultiUserConfig) DeepCopy() *MultiUserConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(MultiUserConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NPXRuntimeConfig) DeepCopyInto(out *NPXRuntimeConfig) {
+	*out = *in
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.EgressDomains != nil {
+		in, out := &in.EgressDomains, &out.EgressDomains
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DenyAllEgress != nil {
+		in, out := &in.DenyAllEgress, &out.DenyAllEgress
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NPXRuntimeConfig.
+func (in *NPXRuntimeConfig) DeepCopy() *NPXRuntimeConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(NPXRuntimeConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NanobotAgent) DeepCopyInto(out *NanobotAgent) {
+	*out = *in
+	in.Metadata.DeepCopyInto(&out.Metadata)
+	out.NanobotAgentManifest = in.NanobotAgentManifest
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NanobotAgent.
+func (in *NanobotAgent) DeepCopy() *NanobotAgent {
+	if in == nil {
+		return nil
+	}
+	out := new(NanobotAgent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NanobotAgentList) DeepCopyInto(out *NanobotAgentList) {
+	*out = *in
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NanobotAgent, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NanobotAgentList.
+func (in *NanobotAgentList) DeepCopy() *NanobotAgentList {
+	if in == nil {
+		return nil
+	}
+	out := new(NanobotAgentList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NanobotAgentManifest) DeepCopyInto(out *NanobotAgentManifest) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NanobotAgentManifest.
+func (in *NanobotAgentManifest) DeepCopy() *NanobotAgentManifest {
+	if in == nil {
+		return nil
+	}
+	out := new(NanobotAgentManifest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationChannel) DeepCopyInto(out *NotificationChannel) {
+	*out = *in
+	in.Metadata.DeepCopyInto(&out.Metadata)
+	in.NotificationChannelManifest.DeepCopyInto(&out.NotificationChannelManifest)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationChannel.
+func (in *NotificationChannel) DeepCopy() *NotificationChannel {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationChannel)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationChannelList) DeepCopyInto(out *NotificationChannelList) {
+	*out = *in
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NotificationChannel, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationChannelList.
+func (in *NotificationChannelList) DeepCopy() *NotificationChannelList {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationChannelList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationChannelManifest) DeepCopyInto(out *NotificationChannelManifest) {
+	*out = *in
+	if in.Email != nil {
+		in, out := &in.Email, &out.Email
+		*out = new(NotificationEmailConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationChannelManifest.
+func (in *NotificationChannelManifest) DeepCopy() *NotificationChannelManifest {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationChannelManifest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationDelivery) DeepCopyInto(out *NotificationDelivery) {
+	*out = *in
+	in.Metadata.DeepCopyInto(&out.Metadata)
+	in.Event.DeepCopyInto(&out.Event)
+	if in.NextAttemptAt != nil {
+		in, out := &in.NextAttemptAt, &out.NextAttemptAt
+		*out = (*in).DeepCopy()
+	}
+	if in.DeliveredAt != nil {
+		in, out := &in.DeliveredAt, &out.DeliveredAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationDelivery.
+func (in *NotificationDelivery) DeepCopy() *NotificationDelivery {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationDelivery)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ModelResource) DeepCopyInto(out *ModelResource) {
+func (in *NotificationDeliveryList) DeepCopyInto(out *NotificationDeliveryList) {
 	*out = *in
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NotificationDelivery, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelResource.
-func (in *ModelResource) DeepCopy() *ModelResource {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationDeliveryList.
+func (in *NotificationDeliveryList) DeepCopy() *NotificationDeliveryList {
 	if in == nil {
 		return nil
 	}
-	out := new(ModelResource)
+	out := new(NotificationDeliveryList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ModelStatus) DeepCopyInto(out *ModelStatus) {
+func (in *NotificationEmailConfig) DeepCopyInto(out *NotificationEmailConfig) {
 	*out = *in
-	if in.AliasAssigned != nil {
-		in, out := &in.AliasAssigned, &out.AliasAssigned
-		*out = new(bool)
-		**out = **in
+	if in.To != nil {
+		in, out := &in.To, &out.To
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	in.Cost.DeepCopyInto(&out.Cost)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelStatus.
-func (in *ModelStatus) DeepCopy() *ModelStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationEmailConfig.
+func (in *NotificationEmailConfig) DeepCopy() *NotificationEmailConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(ModelStatus)
+	out := new(NotificationEmailConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MultiUserConfig) DeepCopyInto(out *MultiUserConfig) {
+func (in *NotificationEvent) DeepCopyInto(out *NotificationEvent) {
 	*out = *in
-	if in.UserDefinedHeaders != nil {
-		in, out := &in.UserDefinedHeaders, &out.UserDefinedHeaders
-		*out = make([]MCPHeader, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
+	in.Time.DeepCopyInto(&out.Time)
+	if in.Details != nil {
+		in, out := &in.Details, &out.Details
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiUserConfig.
-func (in *MultiUserConfig) DeepCopy() *MultiUserConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationEvent.
+func (in *NotificationEvent) DeepCopy() *NotificationEvent {
 	if in == nil {
 		return nil
 	}
-	out := new(MultiUserConfig)
+	out := new(NotificationEvent)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NPXRuntimeConfig) DeepCopyInto(out *NPXRuntimeConfig) {
+func (in *NotificationRule) DeepCopyInto(out *NotificationRule) {
 	*out = *in
-	if in.Args != nil {
-		in, out := &in.Args, &out.Args
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.EgressDomains != nil {
-		in, out := &in.EgressDomains, &out.EgressDomains
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.DenyAllEgress != nil {
-		in, out := &in.DenyAllEgress, &out.DenyAllEgress
-		*out = new(bool)
-		**out = **in
-	}
+	in.Metadata.DeepCopyInto(&out.Metadata)
+	in.NotificationRuleManifest.DeepCopyInto(&out.NotificationRuleManifest)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NPXRuntimeConfig.
-func (in *NPXRuntimeConfig) DeepCopy() *NPXRuntimeConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationRule.
+func (in *NotificationRule) DeepCopy() *NotificationRule {
 	if in == nil {
 		return nil
 	}
-	out := new(NPXRuntimeConfig)
+	out := new(NotificationRule)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NanobotAgent) DeepCopyInto(out *NanobotAgent) {
+func (in *NotificationRuleFilter) DeepCopyInto(out *NotificationRuleFilter) {
 	*out = *in
-	in.Metadata.DeepCopyInto(&out.Metadata)
-	out.NanobotAgentManifest = in.NanobotAgentManifest
+	if in.UserIDs != nil {
+		in, out := &in.UserIDs, &out.UserIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ResourceIDs != nil {
+		in, out := &in.ResourceIDs, &out.ResourceIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NanobotAgent.
-func (in *NanobotAgent) DeepCopy() *NanobotAgent {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationRuleFilter.
+func (in *NotificationRuleFilter) DeepCopy() *NotificationRuleFilter {
 	if in == nil {
 		return nil
 	}
-	out := new(NanobotAgent)
+	out := new(NotificationRuleFilter)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NanobotAgentList) DeepCopyInto(out *NanobotAgentList) {
+func (in *NotificationRuleList) DeepCopyInto(out *NotificationRuleList) {
 	*out = *in
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]NanobotAgent, len(*in))
+		*out = make([]NotificationRule, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NanobotAgentList.
-func (in *NanobotAgentList) DeepCopy() *NanobotAgentList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationRuleList.
+func (in *NotificationRuleList) DeepCopy() *NotificationRuleList {
 	if in == nil {
 		return nil
 	}
-	out := new(NanobotAgentList)
+	out := new(NotificationRuleList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NanobotAgentManifest) DeepCopyInto(out *NanobotAgentManifest) {
+func (in *NotificationRuleManifest) DeepCopyInto(out *NotificationRuleManifest) {
 	*out = *in
+	if in.EventTypes != nil {
+		in, out := &in.EventTypes, &out.EventTypes
+		*out = make([]NotificationEventType, len(*in))
+		copy(*out, *in)
+	}
+	if in.ChannelIDs != nil {
+		in, out := &in.ChannelIDs, &out.ChannelIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.Filter.DeepCopyInto(&out.Filter)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NanobotAgentManifest.
-func (in *NanobotAgentManifest) DeepCopy() *NanobotAgentManifest {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationRuleManifest.
+func (in *NotificationRuleManifest) DeepCopy() *NotificationRuleManifest {
 	if in == nil {
 		return nil
 	}
-	out := new(NanobotAgentManifest)
+	out := new(NotificationRuleManifest)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -5761,6 +6984,36 @@ func (in *PublishedArtifactManifest) DeepCopy() *PublishedArtifactManifest {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PublishedArtifactUpdateRequest) DeepCopyInto(out *PublishedArtifactUpdateRequest) {
+	*out = *in
+	if in.Description != nil {
+		in, out := &in.Description, &out.Description
+		*out = new(string)
+		**out = **in
+	}
+	if in.Version != nil {
+		in, out := &in.Version, &out.Version
+		*out = new(int)
+		**out = **in
+	}
+	if in.Subjects != nil {
+		in, out := &in.Subjects, &out.Subjects
+		*out = make([]Subject, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublishedArtifactUpdateRequest.
+func (in *PublishedArtifactUpdateRequest) DeepCopy() *PublishedArtifactUpdateRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(PublishedArtifactUpdateRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PublishedArtifactVersionEntry) DeepCopyInto(out *PublishedArtifactVersionEntry) {
 	*out = *in
@@ -6311,6 +7564,16 @@ func (in *Skill) DeepCopyInto(out *Skill) {
 	in.Metadata.DeepCopyInto(&out.Metadata)
 	in.SkillManifest.DeepCopyInto(&out.SkillManifest)
 	in.LastIndexedAt.DeepCopyInto(&out.LastIndexedAt)
+	if in.ScanFindings != nil {
+		in, out := &in.ScanFindings, &out.ScanFindings
+		*out = make([]SkillScanFinding, len(*in))
+		copy(*out, *in)
+	}
+	if in.ScanOverride != nil {
+		in, out := &in.ScanOverride, &out.ScanOverride
+		*out = new(SkillScanOverride)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Skill.
@@ -6508,6 +7771,91 @@ func (in *SkillResource) DeepCopy() *SkillResource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SkillRevision) DeepCopyInto(out *SkillRevision) {
+	*out = *in
+	in.SkillManifest.DeepCopyInto(&out.SkillManifest)
+	in.IndexedAt.DeepCopyInto(&out.IndexedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SkillRevision.
+func (in *SkillRevision) DeepCopy() *SkillRevision {
+	if in == nil {
+		return nil
+	}
+	out := new(SkillRevision)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SkillRevisionList) DeepCopyInto(out *SkillRevisionList) {
+	*out = *in
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SkillRevision, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SkillRevisionList.
+func (in *SkillRevisionList) DeepCopy() *SkillRevisionList {
+	if in == nil {
+		return nil
+	}
+	out := new(SkillRevisionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SkillScanFinding) DeepCopyInto(out *SkillScanFinding) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SkillScanFinding.
+func (in *SkillScanFinding) DeepCopy() *SkillScanFinding {
+	if in == nil {
+		return nil
+	}
+	out := new(SkillScanFinding)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SkillScanOverride) DeepCopyInto(out *SkillScanOverride) {
+	*out = *in
+	in.OverriddenAt.DeepCopyInto(&out.OverriddenAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SkillScanOverride.
+func (in *SkillScanOverride) DeepCopy() *SkillScanOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(SkillScanOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SkillScanOverrideRequest) DeepCopyInto(out *SkillScanOverrideRequest) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SkillScanOverrideRequest.
+func (in *SkillScanOverrideRequest) DeepCopy() *SkillScanOverrideRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(SkillScanOverrideRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StorageConfig) DeepCopyInto(out *StorageConfig) {
 	*out = *in
@@ -7028,6 +8376,136 @@ func (in *TokenUsageList) DeepCopy() *TokenUsageList {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ToolArgumentConstraint) DeepCopyInto(out *ToolArgumentConstraint) {
+	*out = *in
+	if in.Schema != nil {
+		in, out := &in.Schema, &out.Schema
+		*out = new(ToolArgumentSchema)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Arguments != nil {
+		in, out := &in.Arguments, &out.Arguments
+		*out = make([]ToolArgumentRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ToolArgumentConstraint.
+func (in *ToolArgumentConstraint) DeepCopy() *ToolArgumentConstraint {
+	if in == nil {
+		return nil
+	}
+	out := new(ToolArgumentConstraint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ToolArgumentRule) DeepCopyInto(out *ToolArgumentRule) {
+	*out = *in
+	if in.AllowedValues != nil {
+		in, out := &in.AllowedValues, &out.AllowedValues
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ToolArgumentRule.
+func (in *ToolArgumentRule) DeepCopy() *ToolArgumentRule {
+	if in == nil {
+		return nil
+	}
+	out := new(ToolArgumentRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ToolArgumentSchema) DeepCopyInto(out *ToolArgumentSchema) {
+	*out = *in
+	if in.Properties != nil {
+		in, out := &in.Properties, &out.Properties
+		*out = make(map[string]ToolArgumentSchema, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.Required != nil {
+		in, out := &in.Required, &out.Required
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AdditionalProperties != nil {
+		in, out := &in.AdditionalProperties, &out.AdditionalProperties
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = new(ToolArgumentSchema)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxItems != nil {
+		in, out := &in.MaxItems, &out.MaxItems
+		*out = new(int)
+		**out = **in
+	}
+	if in.Enum != nil {
+		in, out := &in.Enum, &out.Enum
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MinLength != nil {
+		in, out := &in.MinLength, &out.MinLength
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxLength != nil {
+		in, out := &in.MaxLength, &out.MaxLength
+		*out = new(int)
+		**out = **in
+	}
+	if in.Minimum != nil {
+		in, out := &in.Minimum, &out.Minimum
+		*out = new(float64)
+		**out = **in
+	}
+	if in.Maximum != nil {
+		in, out := &in.Maximum, &out.Maximum
+		*out = new(float64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ToolArgumentSchema.
+func (in *ToolArgumentSchema) DeepCopy() *ToolArgumentSchema {
+	if in == nil {
+		return nil
+	}
+	out := new(ToolArgumentSchema)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ToolCallEnforcementSetting) DeepCopyInto(out *ToolCallEnforcementSetting) {
+	*out = *in
+	in.Allowlist.DeepCopyInto(&out.Allowlist)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ToolCallEnforcementSetting.
+func (in *ToolCallEnforcementSetting) DeepCopy() *ToolCallEnforcementSetting {
+	if in == nil {
+		return nil
+	}
+	out := new(ToolCallEnforcementSetting)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ToolOverride) DeepCopyInto(out *ToolOverride) {
 	*out = *in
@@ -23,6 +23,9 @@ const sidebars = {
 				"functionality/mcp-servers",
 				"functionality/mcp-tunnels",
 				"functionality/mcp-access-policies",
+				"functionality/mcp-quotas",
+				"functionality/mcp-tool-approvals",
+				"functionality/mcp-server-requests",
 				"functionality/mcp-registry-api",
 				"functionality/audit-logs-and-usage",
 				"functionality/filters",
@@ -33,6 +36,8 @@ const sidebars = {
 				"functionality/message-policies",
 				"functionality/skills",
 				"functionality/skill-access-policies",
+				"functionality/access-requests",
+				"functionality/notifications",
 				"functionality/device-management",
 				"functionality/user-management",
 				"functionality/agent-auth-scopes",
@@ -69,6 +74,8 @@ const sidebars = {
 				"configuration/model-providers",
 				"configuration/user-roles",
 				"configuration/mcp-server-gitops",
+				"configuration/governance-gitops",
+				"configuration/config-change-history",
 				"configuration/mcp-deployments-in-kubernetes",
 				"configuration/image-pull-secrets",
 				"configuration/mcp-server-egress-control",
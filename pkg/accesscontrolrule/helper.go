@@ -534,6 +534,78 @@ func (h *Helper) UserHasAccessToMCPServerCatalogEntryInWorkspace(ctx context.Con
 	return false, nil
 }
 
+// ToolArgumentConstraintsForUser returns the tool argument constraints of every
+// rule that includes the user and the MCP server, either directly, through its
+// catalog entry, or through a selector. A call must satisfy all of them.
+func (h *Helper) ToolArgumentConstraintsForUser(user kuser.Info, serverName, entryName, catalogID string) ([]types.ToolArgumentConstraint, error) {
+	var (
+		forSelector = h.GetAccessControlRulesForSelectorInCatalog
+		forServer   = h.GetAccessControlRulesForMCPServerInCatalog
+		forEntry    = h.GetAccessControlRulesForMCPServerCatalogEntryInCatalog
+	)
+	if system.IsPowerUserWorkspaceID(catalogID) {
+		forSelector = h.GetAccessControlRulesForSelectorInWorkspace
+		forServer = h.GetAccessControlRulesForMCPServerInWorkspace
+		forEntry = h.GetAccessControlRulesForMCPServerCatalogEntryInWorkspace
+	}
+
+	rules, err := forSelector(system.DefaultNamespace, "*", catalogID)
+	if err != nil {
+		return nil, err
+	}
+	if serverName != "" {
+		serverRules, err := forServer(system.DefaultNamespace, serverName, catalogID)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, serverRules...)
+	}
+	if entryName != "" {
+		entryRules, err := forEntry(system.DefaultNamespace, entryName, catalogID)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, entryRules...)
+	}
+
+	var (
+		userID      = user.GetUID()
+		groups      = authGroupSet(user)
+		seen        = make(map[string]struct{}, len(rules))
+		constraints []types.ToolArgumentConstraint
+	)
+	for _, rule := range rules {
+		if _, ok := seen[rule.Name]; ok || len(rule.Spec.Manifest.ToolConstraints) == 0 {
+			continue
+		}
+		seen[rule.Name] = struct{}{}
+		if subjectsInclude(rule.Spec.Manifest.Subjects, userID, groups) {
+			constraints = append(constraints, rule.Spec.Manifest.ToolConstraints...)
+		}
+	}
+	return constraints, nil
+}
+
+func subjectsInclude(subjects []types.Subject, userID string, groups map[string]struct{}) bool {
+	for _, subject := range subjects {
+		switch subject.Type {
+		case types.SubjectTypeUser:
+			if subject.ID == userID {
+				return true
+			}
+		case types.SubjectTypeGroup:
+			if _, ok := groups[subject.ID]; ok {
+				return true
+			}
+		case types.SubjectTypeSelector:
+			if subject.ID == "*" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func authGroupSet(user kuser.Info) map[string]struct{} {
 	groups := user.GetExtra()["auth_provider_groups"]
 	set := make(map[string]struct{}, len(groups))
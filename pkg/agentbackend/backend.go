@@ -16,6 +16,9 @@ const (
 	StateReady    State = "ready"
 	StateError    State = "error"
 	StateDeleting State = "deleting"
+	// StateStopped is a sandbox scaled to zero on purpose. Its workspace is
+	// retained, so reconciling it as running again resumes where it left off.
+	StateStopped State = "stopped"
 )
 
 // Backend is the complete desired-state and observation contract required by
@@ -100,6 +103,12 @@ type DesiredInstance struct {
 	// Terminal asks the backend to keep the sandbox attachable for an
 	// interactive session.
 	Terminal bool
+	// Stopped asks the backend to release the sandbox's compute while keeping
+	// its storage and identity. A stopped sandbox holds no pool capacity.
+	//
+	// It is omitted when false so that adding it left the desired revision of
+	// every running sandbox unchanged.
+	Stopped bool `json:",omitempty"`
 }
 
 // InstanceResources is a sandbox's share of its pool, in plain units so the
@@ -52,6 +52,9 @@ type instance struct {
 	deleteAt   time.Time
 	url        string
 	urlReady   bool
+	// workspace is the archive last imported, standing in for the volume a
+	// real backend would keep. It goes when the instance does.
+	workspace []byte
 }
 
 func New(config Config) *Backend {
@@ -150,7 +153,7 @@ func (b *Backend) ReconcileInstance(_ context.Context, desired agentbackend.Desi
 	}
 
 	current, ok := b.instances[desired.Ref.ID]
-	if !ok && pool.desired.Suspended {
+	if !ok && pool.desired.Suspended && !desired.Stopped {
 		return errorInstanceObservation(desired.Ref, "PoolSuspended", "the pool does not permit new instances"), nil
 	}
 	desired.Ref.BackendID = backendID("instance", desired.Ref.ID)
@@ -160,7 +163,8 @@ func (b *Backend) ReconcileInstance(_ context.Context, desired agentbackend.Desi
 		b.instances[desired.Ref.ID] = current
 	}
 	if !ok || !reflect.DeepEqual(current.desired, desired) {
-		if pool.desired.Suspended {
+		// Stopping releases capacity, so a suspended pool still permits it.
+		if pool.desired.Suspended && !desired.Stopped {
 			if !ok || current.state != agentbackend.StateReady {
 				return errorInstanceObservation(desired.Ref, "PoolSuspended", "the pool does not permit instance starts"), nil
 			}
@@ -215,7 +219,8 @@ func (b *Backend) GetPoolUtilization(_ context.Context, ref agentbackend.PoolRef
 	var result agentbackend.UtilizationSnapshot
 	result.Timestamp = b.now()
 	for _, sandbox := range b.instances {
-		if sandbox.desired.Pool.ID != ref.ID || sandbox.state == agentbackend.StateDeleting {
+		if sandbox.desired.Pool.ID != ref.ID || sandbox.state == agentbackend.StateDeleting ||
+			sandbox.state == agentbackend.StateStopped {
 			continue
 		}
 		ordinal := float64((sandbox.generation%5)+1) / 10
@@ -258,6 +263,10 @@ func (b *Backend) progressLocked() {
 		case current.state == agentbackend.StateDeleting && !now.Before(current.deleteAt):
 			delete(b.instances, id)
 			b.emitLocked(instanceEvent(current.desired.Ref))
+		case current.state == agentbackend.StatePending && !now.Before(current.readyAt) && current.desired.Stopped:
+			current.state = agentbackend.StateStopped
+			current.urlReady = false
+			b.emitLocked(instanceEvent(current.desired.Ref))
 		case current.state == agentbackend.StatePending && !now.Before(current.readyAt):
 			current.state = agentbackend.StateReady
 			current.urlReady = true
@@ -311,7 +320,7 @@ func errorInstanceObservation(ref agentbackend.InstanceRef, reason, message stri
 }
 
 func observedRevision(state agentbackend.State, revision string) string {
-	if state == agentbackend.StateReady {
+	if state == agentbackend.StateReady || state == agentbackend.StateStopped {
 		return revision
 	}
 	return ""
@@ -1,7 +1,11 @@
 package fake
 
 import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
 	"context"
+	"strings"
 	"testing"
 	"time"
 
@@ -194,6 +198,71 @@ func TestRotatedSecretIsSeenAsAChange(t *testing.T) {
 	}
 }
 
+func TestStoppedInstanceKeepsIdentityAndReleasesCapacity(t *testing.T) {
+	now := time.Date(2026, 7, 27, 12, 0, 0, 0, time.UTC)
+	backend := New(Config{TransitionDelay: time.Second, Now: func() time.Time { return now }})
+	ctx := context.Background()
+	pool := desiredPool()
+	if _, err := backend.ReconcilePool(ctx, pool); err != nil {
+		t.Fatal(err)
+	}
+	instance := desiredInstance()
+	if _, err := backend.ReconcileInstance(ctx, instance); err != nil {
+		t.Fatal(err)
+	}
+	now = now.Add(time.Second)
+
+	// Suspending the pool must not prevent an instance from being stopped:
+	// stopping only ever gives capacity back.
+	pool.Suspended = true
+	if _, err := backend.ReconcilePool(ctx, pool); err != nil {
+		t.Fatal(err)
+	}
+	instance.Stopped = true
+	instance.Revision = "stopped-key"
+	observed, err := backend.ReconcileInstance(ctx, instance)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if observed.State != agentbackend.StatePending {
+		t.Fatalf("stop was not accepted: %#v", observed)
+	}
+	now = now.Add(time.Second)
+	observed, err = backend.ObserveInstance(ctx, instance.Ref)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if observed.State != agentbackend.StateStopped || observed.ObservedRevision != "stopped-key" ||
+		observed.URL != "" || observed.Ref.BackendID != "fake-instance-i1" {
+		t.Fatalf("instance did not stop: %#v", observed)
+	}
+	snapshot, err := backend.GetPoolUtilization(ctx, pool.Ref)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snapshot.Instances) != 0 {
+		t.Fatalf("stopped instance still reports utilization: %#v", snapshot.Instances)
+	}
+
+	pool.Suspended = false
+	if _, err := backend.ReconcilePool(ctx, pool); err != nil {
+		t.Fatal(err)
+	}
+	instance.Stopped = false
+	instance.Revision = "same-key"
+	if _, err := backend.ReconcileInstance(ctx, instance); err != nil {
+		t.Fatal(err)
+	}
+	now = now.Add(time.Second)
+	observed, err = backend.ObserveInstance(ctx, instance.Ref)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if observed.State != agentbackend.StateReady || observed.URL != "fake://hosted-agent/i1" {
+		t.Fatalf("instance did not start again: %#v", observed)
+	}
+}
+
 func desiredPool() agentbackend.DesiredPool {
 	return agentbackend.DesiredPool{
 		Ref: agentbackend.PoolRef{ID: "a1"}, Revision: "same-key",
@@ -208,3 +277,54 @@ func desiredInstance() agentbackend.DesiredInstance {
 		Revision: "same-key", Name: "agent",
 	}
 }
+
+func TestWorkspaceSurvivesExportAndImport(t *testing.T) {
+	backend := New(Config{})
+	ctx := context.Background()
+	if _, err := backend.ReconcilePool(ctx, desiredPool()); err != nil {
+		t.Fatal(err)
+	}
+	instance := desiredInstance()
+	if _, err := backend.ReconcileInstance(ctx, instance); err != nil {
+		t.Fatal(err)
+	}
+
+	var empty bytes.Buffer
+	if err := backend.ExportWorkspace(ctx, instance.Ref, &empty); err != nil {
+		t.Fatal(err)
+	}
+	if err := backend.ImportWorkspace(ctx, instance.Ref, strings.NewReader("not an archive")); err == nil {
+		t.Fatal("imported something that is not an archive")
+	}
+
+	var archive bytes.Buffer
+	gz := gzip.NewWriter(&archive)
+	tw := tar.NewWriter(gz)
+	if err := tw.WriteHeader(&tar.Header{Name: "notes.txt", Mode: 0o644, Size: 2}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tw.Write([]byte("hi")); err != nil {
+		t.Fatal(err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := backend.ImportWorkspace(ctx, instance.Ref, bytes.NewReader(archive.Bytes())); err != nil {
+		t.Fatal(err)
+	}
+
+	var exported bytes.Buffer
+	if err := backend.ExportWorkspace(ctx, instance.Ref, &exported); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(exported.Bytes(), archive.Bytes()) {
+		t.Fatal("export did not return the imported workspace")
+	}
+
+	if err := backend.ExportWorkspace(ctx, agentbackend.InstanceRef{ID: "missing"}, &exported); err == nil {
+		t.Fatal("exported a workspace for an instance that does not exist")
+	}
+}
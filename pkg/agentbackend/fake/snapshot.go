@@ -0,0 +1,86 @@
+package fake
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/obot-platform/obot/pkg/agentbackend"
+)
+
+var _ agentbackend.SnapshotBackend = (*Backend)(nil)
+
+// ExportWorkspace writes whatever was last imported, or an empty archive for a
+// workspace nothing has been written to.
+func (b *Backend) ExportWorkspace(_ context.Context, ref agentbackend.InstanceRef, w io.Writer) error {
+	b.mu.Lock()
+	current := b.instances[ref.ID]
+	var workspace []byte
+	if current != nil {
+		workspace = current.workspace
+	}
+	b.mu.Unlock()
+
+	if current == nil {
+		return fmt.Errorf("%w: instance %q does not exist", ErrInvalidDesiredState, ref.ID)
+	}
+	if workspace == nil {
+		var err error
+		if workspace, err = emptyArchive(); err != nil {
+			return err
+		}
+	}
+	_, err := w.Write(workspace)
+	return err
+}
+
+// ImportWorkspace keeps the archive after checking it is one, so a test that
+// imports garbage fails here as it would against a real volume.
+func (b *Backend) ImportWorkspace(_ context.Context, ref agentbackend.InstanceRef, r io.Reader) error {
+	workspace, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	if err := validateArchive(workspace); err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	current := b.instances[ref.ID]
+	if current == nil {
+		return fmt.Errorf("%w: instance %q does not exist", ErrInvalidDesiredState, ref.ID)
+	}
+	current.workspace = workspace
+	return nil
+}
+
+func emptyArchive() ([]byte, error) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if err := tar.NewWriter(gz).Close(); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func validateArchive(data []byte) error {
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("workspace archive is not gzip: %w", err)
+	}
+	archive := tar.NewReader(gz)
+	for {
+		if _, err := archive.Next(); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("workspace archive is not a tar: %w", err)
+		}
+	}
+}
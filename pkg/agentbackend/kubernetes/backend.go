@@ -51,6 +51,7 @@ const (
 
 	instanceLabel = "obot.ai/hosted-agent-instance"
 	poolLabel     = "obot.ai/hosted-agent-pool"
+	transferLabel = "obot.ai/hosted-agent-transfer"
 	userLabel     = "obot.ai/hosted-agent-user"
 	managedLabel  = "obot.ai/managed-by"
 	managedValue  = "obot-agent-backend"
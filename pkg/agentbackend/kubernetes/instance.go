@@ -64,7 +64,8 @@ func (b *Backend) ReconcileInstance(ctx context.Context, desired agentbackend.De
 	if !pool.Exists {
 		return agentbackend.InstanceObservation{}, fmt.Errorf("pool %s does not exist", desired.Pool.ID)
 	}
-	if !pool.Schedulable {
+	// Stopping only gives capacity back, so a suspended pool still permits it.
+	if !pool.Schedulable && !desired.Stopped {
 		return errorObservation(desired.Ref, "PoolSuspended", "the pool does not admit new sandboxes"), nil
 	}
 
@@ -323,13 +324,20 @@ func (b *Backend) instanceObjects(desired agentbackend.DesiredInstance) ([]kclie
 		podAnnotations[schedulingRevisionAnnotation] = schedulingRevision
 	}
 
+	// A stopped sandbox keeps its Deployment, Secret, and workspace directory
+	// but runs no pod, so it holds none of the pool's pod quota.
+	replicas := int32(1)
+	if desired.Stopped {
+		replicas = 0
+	}
+
 	deployment := &appsv1.Deployment{
 		Name:        name,
 		Namespace:   b.opts.Namespace,
 		Labels:      labels,
 		Annotations: annotations,
 		Spec: appsv1.DeploymentSpec{
-			Replicas: new(int32(1)),
+			Replicas: &replicas,
 			Selector: &metav1.LabelSelector{
 				MatchLabels: map[string]string{instanceLabel: sanitize(desired.Ref.ID)},
 			},
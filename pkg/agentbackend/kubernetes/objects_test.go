@@ -276,6 +276,40 @@ func TestInstanceObjectsUseRecreateAndSubPath(t *testing.T) {
 	}
 }
 
+// Stopping scales the sandbox to zero rather than deleting it, so its Secret and
+// workspace survive until it is started again.
+func TestInstanceObjectsScaleStoppedSandboxToZero(t *testing.T) {
+	backend := testBackend(t)
+
+	desired := desiredInstance()
+	desired.Stopped = true
+	objs, err := backend.instanceObjects(desired)
+	if err != nil {
+		t.Fatalf("instanceObjects: %v", err)
+	}
+	deployment := deploymentFrom(t, objs)
+	if deployment.Spec.Replicas == nil || *deployment.Spec.Replicas != 0 {
+		t.Fatalf("replicas = %v, want 0", deployment.Spec.Replicas)
+	}
+	var secrets int
+	for _, obj := range objs {
+		if _, ok := obj.(*corev1.Secret); ok {
+			secrets++
+		}
+	}
+	if secrets != 1 {
+		t.Fatalf("expected the stopped sandbox to keep its secret, got %d", secrets)
+	}
+
+	objs, err = backend.instanceObjects(desiredInstance())
+	if err != nil {
+		t.Fatalf("instanceObjects: %v", err)
+	}
+	if replicas := deploymentFrom(t, objs).Spec.Replicas; replicas == nil || *replicas != 1 {
+		t.Fatalf("replicas = %v, want 1", replicas)
+	}
+}
+
 // The revision has to reach the pod template, not just the Deployment, or a
 // configuration change would leave the running pod untouched.
 func TestInstanceObjectsPropagateRevisionToPodTemplate(t *testing.T) {
@@ -731,3 +765,31 @@ func TestInstanceObjectsIncludeServiceWithPort(t *testing.T) {
 			ports, service.Spec.Ports[0].TargetPort)
 	}
 }
+
+func TestTransferPodMountsPoolVolumeWithoutInstanceLabel(t *testing.T) {
+	backend := testBackend(t)
+
+	pod, err := backend.transferPod("inst-1", "alloc-1")
+	if err != nil {
+		t.Fatalf("transferPod: %v", err)
+	}
+
+	// A terminal selects the sandbox's pods by instanceLabel, and must not be
+	// able to land in the transfer pod.
+	if _, ok := pod.Labels[instanceLabel]; ok {
+		t.Errorf("transfer pod carries %s", instanceLabel)
+	}
+	if got := pod.Spec.PriorityClassName; got != poolName("alloc-1") {
+		t.Errorf("priorityClassName = %q, want %q", got, poolName("alloc-1"))
+	}
+	if len(pod.Spec.Volumes) != 1 || pod.Spec.Volumes[0].PersistentVolumeClaim == nil ||
+		pod.Spec.Volumes[0].PersistentVolumeClaim.ClaimName != poolName("alloc-1") {
+		t.Fatalf("volumes = %+v, want the pool claim", pod.Spec.Volumes)
+	}
+	if mounts := pod.Spec.Containers[0].VolumeMounts; len(mounts) != 1 || mounts[0].MountPath != transferMountPath {
+		t.Errorf("mounts = %+v, want the pool volume at %s", mounts, transferMountPath)
+	}
+	if _, err := backend.transferPod("../", "alloc-1"); err == nil {
+		t.Error("transferPod accepted an instance ID with no usable pool directory")
+	}
+}
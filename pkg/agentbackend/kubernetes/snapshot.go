@@ -0,0 +1,246 @@
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/obot-platform/nah/pkg/name"
+	"github.com/obot-platform/obot/pkg/agentbackend"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/remotecommand"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	_ agentbackend.SnapshotBackend = (*Backend)(nil)
+)
+
+const (
+	transferContainerName = "transfer"
+	transferMountPath     = "/pool"
+	// transferPodStartTimeout bounds the wait for the transfer pod to be
+	// scheduled. A pool at its budget cannot fit it, and that is better
+	// reported than waited on.
+	transferPodStartTimeout = 5 * time.Minute
+	// transferPodDeadline stops a transfer pod that outlived the Obot process
+	// that created it. Nothing else would remove it.
+	transferPodDeadline = int64(6 * 60 * 60)
+
+	// restoreScript replaces one sandbox's directory with the archive on
+	// stdin. It takes the directory as an argument and re-checks it for the
+	// same reason cleanupScript does: it deletes from a shared volume.
+	restoreScript = `set -eu
+dir="$1"
+case "$dir" in
+  ''|.|..|*/*|*..*)
+    echo "refusing to restore pool directory: $dir" >&2
+    exit 1
+    ;;
+esac
+mkdir -p "/pool/$dir"
+find "/pool/$dir" -mindepth 1 -maxdepth 1 -exec rm -rf {} +
+tar -xzf - -C "/pool/$dir"
+`
+)
+
+// ExportWorkspace archives the sandbox's directory on its pool volume.
+//
+// The archive is made by a separate pod rather than by exec into the sandbox,
+// for two reasons: a stopped sandbox has no pod to exec into, and the agent's
+// image is under the agent author's control and need not contain tar.
+func (b *Backend) ExportWorkspace(ctx context.Context, ref agentbackend.InstanceRef, w io.Writer) error {
+	subdir, err := sandboxSubdir(ref.ID)
+	if err != nil {
+		return err
+	}
+	return b.transferWorkspace(ctx, ref, []string{"tar", "-czf", "-", "-C", transferMountPath + "/" + subdir, "."}, nil, w)
+}
+
+// ImportWorkspace replaces the sandbox's directory on its pool volume with the
+// archive read from r.
+func (b *Backend) ImportWorkspace(ctx context.Context, ref agentbackend.InstanceRef, r io.Reader) error {
+	subdir, err := sandboxSubdir(ref.ID)
+	if err != nil {
+		return err
+	}
+	return b.transferWorkspace(ctx, ref, []string{"/bin/sh", "-c", restoreScript, "obot-restore", subdir}, r, io.Discard)
+}
+
+func (b *Backend) transferWorkspace(ctx context.Context, ref agentbackend.InstanceRef, command []string, stdin io.Reader, stdout io.Writer) error {
+	if b.attachClient == nil {
+		return fmt.Errorf("workspace snapshots require a Kubernetes connection")
+	}
+
+	// The Deployment is the only record of which pool, and so which volume,
+	// holds the sandbox. It exists while the sandbox is stopped too.
+	var deployment appsv1.Deployment
+	if err := b.client.Get(ctx, kclient.ObjectKey{Name: instanceName(ref.ID), Namespace: b.opts.Namespace}, &deployment); err != nil {
+		return fmt.Errorf("failed to read sandbox %s: %w", ref.ID, err)
+	}
+	poolID := deployment.Labels[poolLabel]
+	if poolID == "" {
+		return fmt.Errorf("sandbox %s has no pool label; cannot locate its pool volume", ref.ID)
+	}
+
+	pod, err := b.transferPod(ref.ID, poolID)
+	if err != nil {
+		return err
+	}
+	if err := b.client.Create(ctx, pod); apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("a snapshot or restore of sandbox %s is already in progress", ref.ID)
+	} else if err != nil {
+		return fmt.Errorf("failed to create transfer pod for sandbox %s: %w", ref.ID, err)
+	}
+	defer func() {
+		// The caller's context may be why this is returning, and the pod has to
+		// go regardless.
+		cleanupCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 30*time.Second)
+		defer cancel()
+		_ = b.client.Delete(cleanupCtx, pod, kclient.GracePeriodSeconds(0))
+	}()
+
+	if err := b.waitPodRunning(ctx, pod); err != nil {
+		return err
+	}
+
+	request := b.attachClient.Post().
+		Resource("pods").
+		Name(pod.Name).
+		Namespace(pod.Namespace).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: transferContainerName,
+			Command:   command,
+			Stdin:     stdin != nil,
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec)
+	executor, err := b.streamExecutor(request)
+	if err != nil {
+		return fmt.Errorf("build transfer executor: %w", err)
+	}
+
+	var stderr bytes.Buffer
+	if err := executor.StreamWithContext(ctx, remotecommand.StreamOptions{
+		Stdin:  stdin,
+		Stdout: stdout,
+		Stderr: &limitedWriter{w: &stderr, remaining: 4096},
+	}); err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return fmt.Errorf("workspace transfer for sandbox %s failed: %w: %s", ref.ID, err, message)
+		}
+		return fmt.Errorf("workspace transfer for sandbox %s failed: %w", ref.ID, err)
+	}
+	return nil
+}
+
+func (b *Backend) waitPodRunning(ctx context.Context, pod *corev1.Pod) error {
+	ctx, cancel := context.WithTimeout(ctx, transferPodStartTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		var current corev1.Pod
+		if err := b.client.Get(ctx, kclient.ObjectKeyFromObject(pod), &current); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to read transfer pod %s: %w", pod.Name, err)
+		}
+		switch current.Status.Phase {
+		case corev1.PodRunning:
+			return nil
+		case corev1.PodFailed, corev1.PodSucceeded:
+			return fmt.Errorf("transfer pod %s exited before the transfer started: %s", pod.Name, current.Status.Message)
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("transfer pod %s did not start within %s", pod.Name, transferPodStartTimeout)
+		case <-ticker.C:
+		}
+	}
+}
+
+// transferPod mounts the pool volume and waits to be exec'd into. It is
+// placed exactly as the cleanup job is, and for the same reasons.
+func (b *Backend) transferPod(instanceID, poolID string) (*corev1.Pod, error) {
+	subdir, err := sandboxSubdir(instanceID)
+	if err != nil {
+		return nil, err
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      transferPodName(instanceID),
+			Namespace: b.opts.Namespace,
+			Labels: map[string]string{
+				managedLabel: managedValue,
+				poolLabel:    sanitize(poolID),
+				// Not instanceLabel: that selects the sandbox's own pods, and a
+				// terminal must never attach to this one.
+				transferLabel: subdir,
+			},
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy:         corev1.RestartPolicyNever,
+			ActiveDeadlineSeconds: new(transferPodDeadline),
+			PriorityClassName:     poolName(poolID),
+			SecurityContext:       b.podSecurityContext(),
+			Volumes: []corev1.Volume{{
+				Name: workspaceVolumeName,
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: poolName(poolID),
+				},
+			}},
+			Containers: []corev1.Container{{
+				Name:    transferContainerName,
+				Image:   b.opts.CleanupImage,
+				Command: []string{"sleep", fmt.Sprint(transferPodDeadline)},
+				VolumeMounts: []corev1.VolumeMount{{
+					Name:      workspaceVolumeName,
+					MountPath: transferMountPath,
+				}},
+				Resources:       transferResources(),
+				SecurityContext: b.containerSecurityContext(),
+			}},
+		},
+	}
+	b.setPodScheduling(&pod.Spec)
+	return pod, nil
+}
+
+func transferPodName(instanceID string) string {
+	return name.SafeConcatName("obot-agent-transfer", sanitize(instanceID))
+}
+
+// transferResources requests as little as the cleanup job, so a full pool can
+// still be snapshotted, but may burst further: compressing a workspace is real
+// work, and at the cleanup job's limit a large one would take hours.
+func transferResources() corev1.ResourceRequirements {
+	return corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceCPU: cpuQuantity(0.01), corev1.ResourceMemory: memoryQuantity(64 << 20)},
+		Limits:   corev1.ResourceList{corev1.ResourceCPU: cpuQuantity(0.5), corev1.ResourceMemory: memoryQuantity(128 << 20)},
+	}
+}
+
+// limitedWriter keeps the start of a stream and drops the rest, so a failing
+// command's stderr can be reported without being held in full.
+type limitedWriter struct {
+	w         io.Writer
+	remaining int
+}
+
+func (l *limitedWriter) Write(p []byte) (int, error) {
+	if l.remaining > 0 {
+		n := min(len(p), l.remaining)
+		_, _ = l.w.Write(p[:n])
+		l.remaining -= n
+	}
+	return len(p), nil
+}
@@ -8,8 +8,8 @@ import (
 	corev1 "k8s.io/api/core/v1"
 )
 
-// classifyDeployment reduces Kubernetes workload status to the three states the
-// agent backend contract exposes. Everything a provider would otherwise want a
+// classifyDeployment reduces Kubernetes workload status to the states the agent
+// backend contract exposes. Everything a provider would otherwise want a
 // new state for is carried as a stable Reason plus a human-readable Message.
 //
 // "error" does not mean permanent. It means the runtime cannot currently
@@ -18,6 +18,16 @@ import (
 // its own while still being visible to the user rather than sitting silently in
 // "pending".
 func classifyDeployment(deployment *appsv1.Deployment, pods []corev1.Pod) (agentbackend.State, string, string) {
+	// A sandbox scaled to zero is stopped on purpose. It only reports stopped
+	// once its pod is gone, because until then the pod still holds the
+	// workspace and its share of the pool.
+	if deployment.Spec.Replicas != nil && *deployment.Spec.Replicas == 0 {
+		if len(pods) > 0 {
+			return agentbackend.StatePending, "Stopping", "the sandbox is stopping"
+		}
+		return agentbackend.StateStopped, "Stopped", "the sandbox is stopped"
+	}
+
 	// A quota rejection surfaces here rather than on the write that created the
 	// Deployment: the Deployment is admitted, and its ReplicaSet is what fails
 	// to create pods. Without this the sandbox would report "pending" forever
@@ -139,3 +139,21 @@ func TestClassifyDeploymentIgnoresTerminatingPods(t *testing.T) {
 		t.Fatalf("expected pending from the live pod, got %s", state)
 	}
 }
+
+// A stopped sandbox is not reported as stopped while its pod is still
+// terminating, since that pod still owns the workspace directory.
+func TestClassifyDeploymentReportsStoppedOnlyOncePodIsGone(t *testing.T) {
+	deployment := &appsv1.Deployment{}
+	deployment.Spec.Replicas = new(int32(0))
+
+	terminating := waitingPod("ContainerCreating", "starting")
+	now := metav1.Unix(400, 0)
+	terminating.DeletionTimestamp = &now
+
+	if state, reason, _ := classifyDeployment(deployment, []corev1.Pod{terminating}); state != agentbackend.StatePending || reason != "Stopping" {
+		t.Fatalf("expected pending/Stopping while the pod terminates, got %s/%s", state, reason)
+	}
+	if state, reason, _ := classifyDeployment(deployment, nil); state != agentbackend.StateStopped || reason != "Stopped" {
+		t.Fatalf("expected stopped once the pod is gone, got %s/%s", state, reason)
+	}
+}
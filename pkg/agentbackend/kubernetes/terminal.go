@@ -69,29 +69,7 @@ func (b *Backend) AttachTerminal(ctx context.Context, ref agentbackend.InstanceR
 			TTY:    true,
 		}, scheme.ParameterCodec)
 
-	// WebSocket first, SPDY second.
-	//
-	// SPDY is an HTTP/1.1 upgrade to a protocol no general-purpose proxy
-	// speaks, so an ingress, load balancer or corporate egress proxy between
-	// Obot and the API server tends to refuse the upgrade -- a terminal that
-	// fails while every ordinary API call succeeds. WebSocket traverses that
-	// same infrastructure, which is why kubectl made the same move.
-	//
-	// SPDY stays as the fallback because NewWebSocketExecutor negotiates only
-	// v5.channel.k8s.io, deliberately, and so cannot talk to an API server
-	// older than the release that added it. Keeping the second path means Obot
-	// does not impose a cluster version floor for terminals.
-	websocketExecutor, err := remotecommand.NewWebSocketExecutor(b.opts.RESTConfig, "GET", request.URL().String())
-	if err != nil {
-		return nil, fmt.Errorf("build terminal executor: %w", err)
-	}
-	spdyExecutor, err := remotecommand.NewSPDYExecutor(b.opts.RESTConfig, "POST", request.URL())
-	if err != nil {
-		return nil, fmt.Errorf("build terminal fallback executor: %w", err)
-	}
-	executor, err := remotecommand.NewFallbackExecutor(websocketExecutor, spdyExecutor, func(err error) bool {
-		return httpstream.IsUpgradeFailure(err) || httpstream.IsHTTPSProxyError(err)
-	})
+	executor, err := b.streamExecutor(request)
 	if err != nil {
 		return nil, fmt.Errorf("build terminal executor: %w", err)
 	}
@@ -113,6 +91,33 @@ func (b *Backend) AttachTerminal(ctx context.Context, ref agentbackend.InstanceR
 	return session, nil
 }
 
+// streamExecutor opens a stream to a pod subresource such as attach or exec.
+func (b *Backend) streamExecutor(request *rest.Request) (remotecommand.Executor, error) {
+	// WebSocket first, SPDY second.
+	//
+	// SPDY is an HTTP/1.1 upgrade to a protocol no general-purpose proxy
+	// speaks, so an ingress, load balancer or corporate egress proxy between
+	// Obot and the API server tends to refuse the upgrade -- a terminal that
+	// fails while every ordinary API call succeeds. WebSocket traverses that
+	// same infrastructure, which is why kubectl made the same move.
+	//
+	// SPDY stays as the fallback because NewWebSocketExecutor negotiates only
+	// v5.channel.k8s.io, deliberately, and so cannot talk to an API server
+	// older than the release that added it. Keeping the second path means Obot
+	// does not impose a cluster version floor for terminals or snapshots.
+	websocketExecutor, err := remotecommand.NewWebSocketExecutor(b.opts.RESTConfig, "GET", request.URL().String())
+	if err != nil {
+		return nil, err
+	}
+	spdyExecutor, err := remotecommand.NewSPDYExecutor(b.opts.RESTConfig, "POST", request.URL())
+	if err != nil {
+		return nil, err
+	}
+	return remotecommand.NewFallbackExecutor(websocketExecutor, spdyExecutor, func(err error) bool {
+		return httpstream.IsUpgradeFailure(err) || httpstream.IsHTTPSProxyError(err)
+	})
+}
+
 func newTerminalSession(size agentbackend.TerminalSize) *terminalSession {
 	inputReader, inputWriter := io.Pipe()
 	outputReader, outputWriter := io.Pipe()
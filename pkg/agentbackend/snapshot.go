@@ -0,0 +1,26 @@
+package agentbackend
+
+import (
+	"context"
+	"io"
+)
+
+// SnapshotBackend is an optional capability: a backend that can copy a
+// sandbox's workspace out and write one back.
+//
+// Like TerminalBackend, it is separate from Backend so that a runtime without
+// persistent storage simply does not satisfy it.
+//
+// Both directions use the same format, a gzip-compressed tar of the workspace
+// root, so whatever one backend exports another can import. Neither requires
+// the sandbox to be running; an export taken while it is running captures
+// files as they are at the moment each is read.
+type SnapshotBackend interface {
+	// ExportWorkspace streams the sandbox's workspace to w.
+	ExportWorkspace(ctx context.Context, ref InstanceRef, w io.Writer) error
+	// ImportWorkspace replaces the sandbox's workspace with the archive read
+	// from r. Anything in the workspace beforehand is removed, so the result
+	// is exactly the snapshot rather than the snapshot merged with what was
+	// there. The sandbox should be stopped while this runs.
+	ImportWorkspace(ctx context.Context, ref InstanceRef, r io.Reader) error
+}
@@ -79,6 +79,12 @@ var (
 		"/api/message-policy-violations",
 		"/api/message-policy-violations/",
 		"GET /api/message-policy-violation-stats",
+		"/api/mcp-quota-rules",
+		"/api/mcp-quota-rules/",
+		"/api/mcp-tool-approval-policies",
+		"/api/mcp-tool-approval-policies/",
+		"/api/mcp-server-request-policies",
+		"/api/mcp-server-request-policies/",
 		"/api/devices/scan-stats",
 		"/api/devices/mcp-servers/",
 		"/api/devices/skills",
@@ -89,6 +95,7 @@ var (
 		"/api/mdm/configurations/",
 		"GET /api/enforcement-decisions",
 		"GET /api/enforcement-decisions/",
+		"/api/tool-call-enforcement-settings",
 		"/api/mdm/asset-source",
 		"/api/mdm/asset-source/",
 		"GET /api/mdm/assets",
@@ -109,6 +116,7 @@ var (
 		"/api/oauth-apps",
 		"/api/oauth-apps/",
 		"/api/user-default-role-settings",
+		"/api/audit-redaction-settings",
 		"/api/setup/",
 		"/api/k8s-settings",
 		"GET /api/app-k8s-settings",
@@ -116,6 +124,12 @@ var (
 		"/api/image-pull-secrets/",
 		"/api/git-credentials",
 		"/api/git-credentials/",
+		"/api/notification-channels",
+		"/api/notification-channels/",
+		"/api/notification-rules",
+		"/api/notification-rules/",
+		"/api/notification-deliveries",
+		"/api/notification-deliveries/",
 		"/api/mcp-capacity",
 		"/api/audit-log-exports",
 		"/api/audit-log-exports/",
@@ -129,8 +143,15 @@ var (
 		"/api/skill-repositories/",
 		"/api/skill-access-rules",
 		"/api/skill-access-rules/",
+		"POST /api/skills/{id}/scan-override",
+		"DELETE /api/skills/{id}/scan-override",
 		"/api/agent-catalogs",
 		"/api/agent-catalogs/",
+		"/api/config-repositories",
+		"/api/config-repositories/",
+		"/api/config-history",
+		"/api/config-history/",
+		"/api/access-request-settings",
 		"/api/harnesses",
 		"/api/harnesses/",
 		"/api/hosted-agents",
@@ -189,7 +210,14 @@ var (
 			"GET /api/model-access-policies/",
 			"GET /api/message-policies",
 			"GET /api/message-policies/",
+			"GET /api/mcp-quota-rules",
+			"GET /api/mcp-quota-rules/",
+			"GET /api/mcp-tool-approval-policies",
+			"GET /api/mcp-tool-approval-policies/",
+			"GET /api/mcp-server-request-policies",
+			"GET /api/mcp-server-request-policies/",
 			"GET /api/user-default-role-settings",
+			"GET /api/audit-redaction-settings",
 			"GET /api/k8s-settings",
 			"GET /api/app-k8s-settings",
 			"GET /api/image-pull-secrets/capability",
@@ -197,6 +225,12 @@ var (
 			"GET /api/image-pull-secrets/",
 			"GET /api/git-credentials",
 			"GET /api/git-credentials/",
+			"GET /api/notification-channels",
+			"GET /api/notification-channels/",
+			"GET /api/notification-rules",
+			"GET /api/notification-rules/",
+			"GET /api/notification-deliveries",
+			"GET /api/notification-deliveries/",
 			"POST /api/auth-providers/",
 			"GET /api/local-auth/users",
 			"GET /api/local-auth/users/",
@@ -213,6 +247,11 @@ var (
 			"GET /api/skill-access-rules/",
 			"GET /api/agent-catalogs",
 			"GET /api/agent-catalogs/",
+			"GET /api/config-repositories",
+			"GET /api/config-repositories/",
+			"GET /api/config-history",
+			"GET /api/config-history/",
+			"GET /api/access-request-settings",
 			"GET /api/harnesses",
 			"GET /api/harnesses/",
 			"GET /api/hosted-agents",
@@ -224,6 +263,7 @@ var (
 			"GET /api/message-policy-violation-stats",
 			"GET /api/enforcement-decisions",
 			"GET /api/enforcement-decisions/",
+			"GET /api/tool-call-enforcement-settings",
 			"GET /api/devices/scan-stats",
 			"GET /api/devices/mcp-servers/",
 			"GET /api/devices/skills",
@@ -278,6 +318,9 @@ var (
 			// The auth for this is handled in the HTTP handler
 			"POST /api/mcp-audit-logs",
 
+			// Authenticated by the trigger's webhook signature in the handler
+			"POST /api/hosted-agent-triggers/{hosted_agent_trigger_id}/webhook",
+
 			// API Key authentication webhook (called by nanobot shim)
 			// This endpoint validates the API key passed in the header
 			"POST /api/api-keys/auth",
@@ -328,6 +371,25 @@ var (
 			"GET /api/license",
 			"GET /api/setup/oauth-complete",
 
+			// Any user can request temporary access. Visibility and approval
+			// rights are checked in the handler.
+			"GET /api/access-requests",
+			"POST /api/access-requests",
+			"GET /api/access-requests/{access_request_id}",
+			"POST /api/access-requests/{access_request_id}/approve",
+			"POST /api/access-requests/{access_request_id}/deny",
+			"POST /api/access-requests/{access_request_id}/revoke",
+			"POST /api/access-requests/{access_request_id}/cancel",
+
+			// Any user can see and cancel the tool calls held for their
+			// approval. Visibility and approval rights are checked in the
+			// handler.
+			"GET /api/mcp-tool-call-approvals",
+			"GET /api/mcp-tool-call-approvals/{approval_id}",
+			"POST /api/mcp-tool-call-approvals/{approval_id}/approve",
+			"POST /api/mcp-tool-call-approvals/{approval_id}/reject",
+			"POST /api/mcp-tool-call-approvals/{approval_id}/cancel",
+
 			// Users should be able to get the connected tunnel information
 			// so they can see which MCP servers that use the tunnel are available.
 			"GET /api/tunnels",
@@ -19,9 +19,21 @@ func revealsSecrets(req *http.Request) bool {
 // than managing the record of one. A terminal is a live console and
 // /agent-connect proxies straight through to whatever the agent serves; both
 // are the agent being used, not administered.
+//
+// Writing a trigger counts too: a trigger delivers whatever it is given to the
+// agent, so creating or editing one is sending the agent input by another
+// route. Reading and deleting triggers stays administration.
+//
+// So does taking, restoring or cloning a snapshot. Each copies a workspace --
+// the agent's files, and whatever the user left in them -- somewhere its owner
+// did not put it. Listing and deleting snapshots stays administration.
 func entersSandbox(req *http.Request) bool {
 	return strings.HasSuffix(req.Pattern, "/terminal") ||
-		strings.HasPrefix(req.Pattern, "/agent-connect/")
+		strings.HasPrefix(req.Pattern, "/agent-connect/") ||
+		(strings.Contains(req.Pattern, "/triggers") &&
+			(strings.HasPrefix(req.Pattern, "POST ") || strings.HasPrefix(req.Pattern, "PUT "))) ||
+		(strings.HasPrefix(req.Pattern, "POST ") &&
+			(strings.HasSuffix(req.Pattern, "/snapshots") || strings.HasSuffix(req.Pattern, "/restore") || strings.HasSuffix(req.Pattern, "/clone")))
 }
 
 func (a *Authorizer) checkHostedAgent(req *http.Request, resources *Resources, u User) (bool, error) {
@@ -79,3 +91,28 @@ func (a *Authorizer) checkHostedAgentInstance(req *http.Request, resources *Reso
 	resources.Authorizated.HostedAgentInstance = &instance
 	return true, nil
 }
+
+func (a *Authorizer) checkHostedAgentSnapshot(req *http.Request, resources *Resources, u User) (bool, error) {
+	if resources.HostedAgentSnapshotID == "" {
+		return true, nil
+	}
+
+	var snapshot v1.HostedAgentSnapshot
+	if err := a.get(req.Context(), router.Key(system.DefaultNamespace, resources.HostedAgentSnapshotID), &snapshot); err != nil {
+		return false, err
+	}
+
+	// A snapshot outlives its instance, so it carries its own owner rather than
+	// deferring to the instance's, and is judged as the instance would be.
+	if (u.IsAdmin || u.IsAuditor) && !entersSandbox(req) {
+		resources.Authorizated.HostedAgentSnapshot = &snapshot
+		return true, nil
+	}
+
+	if snapshot.Spec.UserID != u.GetUID() {
+		return false, nil
+	}
+
+	resources.Authorizated.HostedAgentSnapshot = &snapshot
+	return true, nil
+}
@@ -23,12 +23,26 @@ func TestEntersSandbox(t *testing.T) {
 		{pattern: "GET /api/hosted-agent-instances/{hosted_agent_instance_id}/terminal", want: true},
 		{pattern: "/agent-connect/{hosted_agent_instance_id}", want: true},
 		{pattern: "/agent-connect/{hosted_agent_instance_id}/{rest...}", want: true},
+		{pattern: "POST /api/hosted-agent-instances/{hosted_agent_instance_id}/triggers", want: true},
+		{pattern: "PUT /api/hosted-agent-instances/{hosted_agent_instance_id}/triggers/{hosted_agent_trigger_id}", want: true},
+		{pattern: "POST /api/hosted-agent-instances/{hosted_agent_instance_id}/triggers/{hosted_agent_trigger_id}/invoke", want: true},
+		{pattern: "POST /api/hosted-agent-instances/{hosted_agent_instance_id}/snapshots", want: true},
+		{pattern: "POST /api/hosted-agent-instances/{hosted_agent_instance_id}/restore", want: true},
+		{pattern: "POST /api/hosted-agent-snapshots/{hosted_agent_snapshot_id}/clone", want: true},
 
 		// Managing the record, which both roles keep.
 		{pattern: "GET /api/hosted-agent-instances", want: false},
 		{pattern: "GET /api/hosted-agent-instances/{hosted_agent_instance_id}", want: false},
 		{pattern: "PUT /api/hosted-agent-instances/{hosted_agent_instance_id}", want: false},
 		{pattern: "DELETE /api/hosted-agent-instances/{hosted_agent_instance_id}", want: false},
+		{pattern: "POST /api/hosted-agent-instances/{hosted_agent_instance_id}/stop", want: false},
+		{pattern: "POST /api/hosted-agent-instances/{hosted_agent_instance_id}/start", want: false},
+		{pattern: "GET /api/hosted-agent-instances/{hosted_agent_instance_id}/triggers", want: false},
+		{pattern: "GET /api/hosted-agent-instances/{hosted_agent_instance_id}/triggers/{hosted_agent_trigger_id}/invocations", want: false},
+		{pattern: "DELETE /api/hosted-agent-instances/{hosted_agent_instance_id}/triggers/{hosted_agent_trigger_id}", want: false},
+		{pattern: "GET /api/hosted-agent-instances/{hosted_agent_instance_id}/snapshots", want: false},
+		{pattern: "GET /api/hosted-agent-snapshots/{hosted_agent_snapshot_id}", want: false},
+		{pattern: "DELETE /api/hosted-agent-snapshots/{hosted_agent_snapshot_id}", want: false},
 
 		// A route that merely mentions an agent is not a way into one.
 		{pattern: "GET /api/hosted-agents/{hosted_agent_id}", want: false},
@@ -17,7 +17,23 @@ var (
 			"GET    /api/hosted-agent-instances/{hosted_agent_instance_id}",
 			"PUT    /api/hosted-agent-instances/{hosted_agent_instance_id}",
 			"DELETE /api/hosted-agent-instances/{hosted_agent_instance_id}",
+			"POST   /api/hosted-agent-instances/{hosted_agent_instance_id}/stop",
+			"POST   /api/hosted-agent-instances/{hosted_agent_instance_id}/start",
 			"GET    /api/hosted-agent-instances/{hosted_agent_instance_id}/terminal",
+			"GET    /api/hosted-agent-instances/{hosted_agent_instance_id}/triggers",
+			"POST   /api/hosted-agent-instances/{hosted_agent_instance_id}/triggers",
+			"GET    /api/hosted-agent-instances/{hosted_agent_instance_id}/triggers/{hosted_agent_trigger_id}",
+			"PUT    /api/hosted-agent-instances/{hosted_agent_instance_id}/triggers/{hosted_agent_trigger_id}",
+			"DELETE /api/hosted-agent-instances/{hosted_agent_instance_id}/triggers/{hosted_agent_trigger_id}",
+			"POST   /api/hosted-agent-instances/{hosted_agent_instance_id}/triggers/{hosted_agent_trigger_id}/invoke",
+			"GET    /api/hosted-agent-instances/{hosted_agent_instance_id}/triggers/{hosted_agent_trigger_id}/invocations",
+			"GET    /api/hosted-agent-instances/{hosted_agent_instance_id}/snapshots",
+			"POST   /api/hosted-agent-instances/{hosted_agent_instance_id}/snapshots",
+			"POST   /api/hosted-agent-instances/{hosted_agent_instance_id}/restore",
+			"GET    /api/hosted-agent-snapshots",
+			"GET    /api/hosted-agent-snapshots/{hosted_agent_snapshot_id}",
+			"DELETE /api/hosted-agent-snapshots/{hosted_agent_snapshot_id}",
+			"POST   /api/hosted-agent-snapshots/{hosted_agent_snapshot_id}/clone",
 			"GET    /api/hosted-agent-pools",
 			"GET    /api/hosted-agent-pools/{hosted_agent_pool_id}",
 			"GET    /api/hosted-agent-pools/{hosted_agent_pool_id}/utilization",
@@ -155,6 +171,7 @@ var (
 			"GET /api/skills/{skill_id}",
 			"GET /api/skills/{skill_id}/download",
 			"GET /api/skills/{skill_id}/preview",
+			"GET /api/skills/{skill_id}/revisions",
 		},
 		types.GroupPublishedArtifacts: {
 			"GET    /api/published-artifacts/{artifact_id}",
@@ -190,6 +207,7 @@ type Resources struct {
 	OAuthAuthRequestID    string
 	HostedAgentID         string
 	HostedAgentInstanceID string
+	HostedAgentSnapshotID string
 	Authorizated          ResourcesAuthorized
 }
 
@@ -204,6 +222,7 @@ type ResourcesAuthorized struct {
 	Skill                 *v1.Skill
 	HostedAgent           *v1.HostedAgent
 	HostedAgentInstance   *v1.HostedAgentInstance
+	HostedAgentSnapshot   *v1.HostedAgentSnapshot
 }
 
 func (a *Authorizer) evaluateResources(req *http.Request, vars GetVar, user User) (bool, error) {
@@ -222,6 +241,7 @@ func (a *Authorizer) evaluateResources(req *http.Request, vars GetVar, user User
 		OAuthAuthRequestID:      vars("oauth_request_id"),
 		HostedAgentID:           vars("hosted_agent_id"),
 		HostedAgentInstanceID:   vars("hosted_agent_instance_id"),
+		HostedAgentSnapshotID:   vars("hosted_agent_snapshot_id"),
 	}
 
 	if !a.checkUser(user, vars("user_id")) {
@@ -272,6 +292,10 @@ func (a *Authorizer) evaluateResources(req *http.Request, vars GetVar, user User
 		return false, err
 	}
 
+	if ok, err := a.checkHostedAgentSnapshot(req, &resources, user); !ok || err != nil {
+		return false, err
+	}
+
 	if ok, err := a.checkDeviceScan(req, &resources, user); !ok || err != nil {
 		return false, err
 	}
@@ -161,6 +161,7 @@ func (*AccessControlRuleHandler) Create(req api.Context) error {
 	if err := req.Create(&rule); err != nil {
 		return fmt.Errorf("failed to create access control rule: %w", err)
 	}
+	recordConfigChange(req, types.ConfigChangeKindAccessControlRule, rule.Name, nil, rule.Spec, 0)
 
 	// If this is a workspace-scoped rule, get the powerUserID for the response
 	var powerUserID string
@@ -213,6 +214,9 @@ func (*AccessControlRuleHandler) Update(req api.Context) error {
 	if err := req.Get(&existing, ruleID); err != nil {
 		return types.NewErrBadRequest("failed to get access control rule: %v", err)
 	}
+	if err := checkNotManagedByConfigRepository(&existing); err != nil {
+		return err
+	}
 
 	// Verify rule belongs to the requested scope
 	if catalogID != "" && existing.Spec.MCPCatalogID != catalogID {
@@ -232,10 +236,12 @@ func (*AccessControlRuleHandler) Update(req api.Context) error {
 		}
 	}
 
+	before := existing.Spec
 	existing.Spec.Manifest = manifest
 	if err := req.Update(&existing); err != nil {
 		return fmt.Errorf("failed to update access control rule: %w", err)
 	}
+	recordConfigChange(req, types.ConfigChangeKindAccessControlRule, existing.Name, before, existing.Spec, 0)
 
 	// If this is a workspace-scoped rule, get the powerUserID for the response
 	var powerUserID string
@@ -287,11 +293,30 @@ func (*AccessControlRuleHandler) Delete(req api.Context) error {
 	} else if workspaceID != "" && rule.Spec.PowerUserWorkspaceID != workspaceID {
 		return types.NewErrBadRequest("access control rule does not belong to workspace %s", workspaceID)
 	}
+	if err := checkNotManagedByConfigRepository(&rule); err != nil {
+		return err
+	}
 
-	return req.Delete(&v1.AccessControlRule{
+	if err := req.Delete(&v1.AccessControlRule{
 		Name:      ruleID,
 		Namespace: req.Namespace(),
-	})
+	}); err != nil {
+		return err
+	}
+	recordConfigChange(req, types.ConfigChangeKindAccessControlRule, rule.Name, rule.Spec, nil, 0)
+	return nil
+}
+
+// validateAccessControlRule validates a rule's manifest and that the resources
+// it references exist in the rule's catalog or workspace.
+func validateAccessControlRule(req api.Context, spec v1.AccessControlRuleSpec) error {
+	if err := spec.Manifest.Validate(); err != nil {
+		return types.NewErrBadRequest("invalid access control rule manifest: %v", err)
+	}
+	if spec.MCPCatalogID != "" {
+		return validateResourcesInCatalog(req, spec.Manifest.Resources, spec.MCPCatalogID)
+	}
+	return validateResourcesInWorkspace(req, spec.Manifest.Resources, spec.PowerUserWorkspaceID)
 }
 
 // validateResourcesInCatalog validates that referenced resources exist in the specified catalog
@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/obot-platform/obot/pkg/api"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	"github.com/obot-platform/obot/pkg/system"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestAccessControlRuleHandlerConfigRepositoryManagedRule(t *testing.T) {
+	storage := newFakeStorage(t,
+		&v1.MCPCatalog{ObjectMeta: metav1.ObjectMeta{Name: system.DefaultCatalog, Namespace: system.DefaultNamespace}},
+		&v1.AccessControlRule{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "acr1",
+				Namespace: system.DefaultNamespace,
+				Labels:    map[string]string{v1.ConfigRepositoryLabel: "cr1"},
+			},
+			Spec: v1.AccessControlRuleSpec{MCPCatalogID: system.DefaultCatalog},
+		},
+	)
+	newContext := func(method string, rec *httptest.ResponseRecorder) api.Context {
+		req := api.Context{
+			ResponseWriter: rec,
+			Request:        httptest.NewRequest(method, "/api/mcp-catalogs/"+system.DefaultCatalog+"/access-control-rules/acr1", nil),
+			Storage:        storage,
+		}
+		req.SetPathValue("catalog_id", system.DefaultCatalog)
+		req.SetPathValue("access_control_rule_id", "acr1")
+		return req
+	}
+
+	// Managed rules can still be read.
+	rec := httptest.NewRecorder()
+	require.NoError(t, new(AccessControlRuleHandler).Get(newContext(http.MethodGet, rec)))
+	assert.Contains(t, rec.Body.String(), `"id":"acr1"`)
+
+	// But not deleted.
+	err := new(AccessControlRuleHandler).Delete(newContext(http.MethodDelete, httptest.NewRecorder()))
+	var httpErr *types.ErrHTTP
+	require.True(t, errors.As(err, &httpErr), "expected *types.ErrHTTP, got %T: %v", err, err)
+	assert.Equal(t, http.StatusConflict, httpErr.Code)
+
+	var rule v1.AccessControlRule
+	assert.NoError(t, storage.Get(t.Context(), kclient.ObjectKey{Namespace: system.DefaultNamespace, Name: "acr1"}, &rule))
+}
@@ -0,0 +1,325 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/obot-platform/obot/pkg/api"
+	"github.com/obot-platform/obot/pkg/modelaccesspolicy"
+	"github.com/obot-platform/obot/pkg/publishedartifact"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	"github.com/obot-platform/obot/pkg/system"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// AccessRequestHandler lets users ask for temporary access to a resource, and
+// approvers decide. The access itself is granted and expired by the access
+// request controller.
+type AccessRequestHandler struct{}
+
+func NewAccessRequestHandler() *AccessRequestHandler {
+	return &AccessRequestHandler{}
+}
+
+// List returns access requests, newest first. Owners, admins, auditors and
+// approvers see every request; other users see their own. The state query
+// parameter filters by state, e.g. state=pending.
+func (*AccessRequestHandler) List(req api.Context) error {
+	setting, err := getAccessRequestSetting(req)
+	if err != nil {
+		return err
+	}
+
+	selector := map[string]string{}
+	if state := req.URL.Query().Get("state"); state != "" {
+		selector["spec.state"] = state
+	}
+	if !canViewAllAccessRequests(req, setting) {
+		selector["spec.requesterID"] = req.User.GetUID()
+	}
+
+	var list v1.AccessRequestList
+	if err := req.List(&list, &kclient.ListOptions{
+		Namespace:     req.Namespace(),
+		FieldSelector: fields.SelectorFromSet(selector),
+	}); err != nil {
+		return fmt.Errorf("failed to list access requests: %w", err)
+	}
+
+	slices.SortFunc(list.Items, func(a, b v1.AccessRequest) int {
+		return b.CreationTimestamp.Compare(a.CreationTimestamp.Time)
+	})
+
+	items := make([]types.AccessRequest, 0, len(list.Items))
+	for _, item := range list.Items {
+		items = append(items, convertAccessRequest(item))
+	}
+
+	return req.Write(types.AccessRequestList{Items: items})
+}
+
+func (*AccessRequestHandler) Get(req api.Context) error {
+	setting, err := getAccessRequestSetting(req)
+	if err != nil {
+		return err
+	}
+
+	request, err := getAccessRequest(req, setting)
+	if err != nil {
+		return err
+	}
+
+	return req.Write(convertAccessRequest(*request))
+}
+
+// Create submits a request for temporary access on behalf of the caller.
+func (*AccessRequestHandler) Create(req api.Context) error {
+	setting, err := getAccessRequestSetting(req)
+	if err != nil {
+		return err
+	}
+
+	var manifest types.AccessRequestManifest
+	if err := req.Read(&manifest); err != nil {
+		return types.NewErrBadRequest("failed to read access request: %v", err)
+	}
+	manifest.ResourceID = strings.TrimSpace(manifest.ResourceID)
+	manifest.Justification = strings.TrimSpace(manifest.Justification)
+
+	if err := manifest.Validate(setting.MaxDuration()); err != nil {
+		return types.NewErrBadRequest("invalid access request: %v", err)
+	}
+	if err := validateAccessRequestResource(req, manifest); err != nil {
+		return err
+	}
+
+	requesterID := req.User.GetUID()
+	var existing v1.AccessRequestList
+	if err := req.List(&existing, &kclient.ListOptions{
+		Namespace:     req.Namespace(),
+		FieldSelector: fields.OneTermEqualSelector("spec.requesterID", requesterID),
+	}); err != nil {
+		return fmt.Errorf("failed to list access requests: %w", err)
+	}
+	for _, other := range existing.Items {
+		if other.Spec.Manifest.ResourceType == manifest.ResourceType && other.Spec.Manifest.ResourceID == manifest.ResourceID &&
+			(other.Spec.State == types.AccessRequestStatePending || other.Spec.State == types.AccessRequestStateApproved) {
+			return types.NewErrHTTP(http.StatusConflict, fmt.Sprintf("access request %s for this resource is already %s", other.Name, other.Spec.State))
+		}
+	}
+
+	request := v1.AccessRequest{
+		GenerateName: system.AccessRequestPrefix,
+		Namespace:    req.Namespace(),
+		Finalizers:   []string{v1.AccessRequestFinalizer},
+		Spec: v1.AccessRequestSpec{
+			Manifest:    manifest,
+			RequesterID: requesterID,
+			State:       types.AccessRequestStatePending,
+			History: []types.AccessRequestEvent{{
+				Time:    *types.NewTime(time.Now()),
+				State:   types.AccessRequestStatePending,
+				ActorID: requesterID,
+				Comment: manifest.Justification,
+			}},
+		},
+	}
+	if err := req.Create(&request); err != nil {
+		return fmt.Errorf("failed to create access request: %w", err)
+	}
+
+	slog.Info("access requested", "accessRequest", request.Name, "requester", requesterID,
+		"resourceType", manifest.ResourceType, "resourceID", manifest.ResourceID)
+	return req.WriteCreated(convertAccessRequest(request))
+}
+
+// Approve grants the requested access. The decision may shorten, but not
+// lengthen, the requested duration. Users cannot approve their own requests.
+func (*AccessRequestHandler) Approve(req api.Context) error {
+	return decideAccessRequest(req, types.AccessRequestStatePending, types.AccessRequestStateApproved)
+}
+
+func (*AccessRequestHandler) Deny(req api.Context) error {
+	return decideAccessRequest(req, types.AccessRequestStatePending, types.AccessRequestStateDenied)
+}
+
+// Revoke ends approved access before it expires.
+func (*AccessRequestHandler) Revoke(req api.Context) error {
+	return decideAccessRequest(req, types.AccessRequestStateApproved, types.AccessRequestStateRevoked)
+}
+
+// Cancel withdraws a pending request. Only the requester can cancel it.
+func (*AccessRequestHandler) Cancel(req api.Context) error {
+	return decideAccessRequest(req, types.AccessRequestStatePending, types.AccessRequestStateCancelled)
+}
+
+func decideAccessRequest(req api.Context, from, to types.AccessRequestState) error {
+	setting, err := getAccessRequestSetting(req)
+	if err != nil {
+		return err
+	}
+
+	request, err := getAccessRequest(req, setting)
+	if err != nil {
+		return err
+	}
+
+	var decision types.AccessRequestDecision
+	if err := req.Read(&decision); err != nil && !errors.Is(err, io.EOF) {
+		return types.NewErrBadRequest("failed to read access request decision: %v", err)
+	}
+	decision.Comment = strings.TrimSpace(decision.Comment)
+
+	actorID := req.User.GetUID()
+	isRequester := request.Spec.RequesterID == actorID
+	if to == types.AccessRequestStateCancelled {
+		if !isRequester {
+			return types.NewErrForbidden("only the requester can cancel an access request")
+		}
+	} else if !canDecideAccessRequests(req, setting) {
+		return types.NewErrForbidden("only access request approvers can %s access requests", accessRequestVerb(to))
+	} else if isRequester && to == types.AccessRequestStateApproved {
+		return types.NewErrForbidden("access requests cannot be approved by their requester")
+	}
+
+	if request.Spec.State != from {
+		return types.NewErrHTTP(http.StatusConflict, fmt.Sprintf("access request %s is %s, not %s", request.Name, request.Spec.State, from))
+	}
+
+	now := time.Now()
+	if to == types.AccessRequestStateApproved {
+		hours := request.Spec.Manifest.DurationHours
+		if decision.DurationHours < 0 || decision.DurationHours > hours {
+			return types.NewErrBadRequest("approved duration must be between 1 and %d hours", hours)
+		} else if decision.DurationHours > 0 {
+			hours = decision.DurationHours
+		}
+		request.Spec.ExpiresAt = metav1.NewTime(now.Add(time.Duration(hours) * time.Hour))
+	}
+	if to == types.AccessRequestStateApproved || to == types.AccessRequestStateDenied {
+		request.Spec.DecidedBy = actorID
+		request.Spec.DecidedAt = metav1.NewTime(now)
+		request.Spec.DecisionComment = decision.Comment
+	}
+	request.Spec.State = to
+	request.Spec.History = append(request.Spec.History, types.AccessRequestEvent{
+		Time:    *types.NewTime(now),
+		State:   to,
+		ActorID: actorID,
+		Comment: decision.Comment,
+	})
+
+	if err := req.Update(request); err != nil {
+		return fmt.Errorf("failed to update access request: %w", err)
+	}
+
+	slog.Info("access request "+string(to), "accessRequest", request.Name, "requester", request.Spec.RequesterID, "actor", actorID,
+		"resourceType", request.Spec.Manifest.ResourceType, "resourceID", request.Spec.Manifest.ResourceID)
+	return req.Write(convertAccessRequest(*request))
+}
+
+func accessRequestVerb(state types.AccessRequestState) string {
+	switch state {
+	case types.AccessRequestStateApproved:
+		return "approve"
+	case types.AccessRequestStateDenied:
+		return "deny"
+	default:
+		return "revoke"
+	}
+}
+
+// validateAccessRequestResource checks that the requested resource exists and
+// can be granted: a catalog entry, an MCP server shared through a catalog or a
+// power user workspace, a specific model, or a skill.
+func validateAccessRequestResource(req api.Context, manifest types.AccessRequestManifest) error {
+	switch manifest.ResourceType {
+	case types.AccessRequestResourceTypeMCPServerCatalogEntry:
+		var entry v1.MCPServerCatalogEntry
+		if err := req.Get(&entry, manifest.ResourceID); apierrors.IsNotFound(err) {
+			return types.NewErrBadRequest("MCP server catalog entry %s not found", manifest.ResourceID)
+		} else if err != nil {
+			return err
+		}
+		if entry.Spec.MCPCatalogName == "" && entry.Spec.PowerUserWorkspaceID == "" {
+			return types.NewErrBadRequest("access can only be requested to catalog entries in a catalog or workspace")
+		}
+	case types.AccessRequestResourceTypeMCPServer:
+		var server v1.MCPServer
+		if err := req.Get(&server, manifest.ResourceID); apierrors.IsNotFound(err) {
+			return types.NewErrBadRequest("MCP server %s not found", manifest.ResourceID)
+		} else if err != nil {
+			return err
+		}
+		if server.Spec.MCPCatalogID == "" && server.Spec.PowerUserWorkspaceID == "" {
+			return types.NewErrBadRequest("access can only be requested to MCP servers in a catalog or workspace")
+		}
+	case types.AccessRequestResourceTypeModel:
+		if !system.IsModelID(manifest.ResourceID) {
+			return types.NewErrBadRequest("access can only be requested to a specific model")
+		}
+		if err := modelaccesspolicy.ValidateModelResource(req.Context(), req.Storage, req.Namespace(), types.ModelResource{ID: manifest.ResourceID}); err != nil {
+			return types.NewErrBadRequest("invalid model: %v", err)
+		}
+	case types.AccessRequestResourceTypeSkill:
+		if err := req.Get(&v1.Skill{}, manifest.ResourceID); apierrors.IsNotFound(err) {
+			return types.NewErrBadRequest("skill %s not found", manifest.ResourceID)
+		} else if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getAccessRequest returns the request in the path. Users who cannot see every
+// request get a not-found error for other users' requests.
+func getAccessRequest(req api.Context, setting types.AccessRequestSetting) (*v1.AccessRequest, error) {
+	var request v1.AccessRequest
+	if err := req.Get(&request, req.PathValue("access_request_id")); err != nil {
+		return nil, err
+	}
+	if request.Spec.RequesterID != req.User.GetUID() && !canViewAllAccessRequests(req, setting) {
+		return nil, types.NewErrNotFound("access request %s not found", request.Name)
+	}
+	return &request, nil
+}
+
+func canDecideAccessRequests(req api.Context, setting types.AccessRequestSetting) bool {
+	return req.UserIsOwner() || req.UserIsAdmin() || publishedartifact.SubjectsContainUser(setting.Approvers, req.User)
+}
+
+func canViewAllAccessRequests(req api.Context, setting types.AccessRequestSetting) bool {
+	return req.UserIsAuditor() || canDecideAccessRequests(req, setting)
+}
+
+func convertAccessRequest(request v1.AccessRequest) types.AccessRequest {
+	result := types.AccessRequest{
+		Metadata:              MetadataFrom(&request),
+		AccessRequestManifest: request.Spec.Manifest,
+		RequesterID:           request.Spec.RequesterID,
+		State:                 request.Spec.State,
+		DecidedBy:             request.Spec.DecidedBy,
+		DecisionComment:       request.Spec.DecisionComment,
+		History:               request.Spec.History,
+	}
+	if !request.Spec.DecidedAt.IsZero() {
+		result.DecidedAt = types.NewTime(request.Spec.DecidedAt.Time)
+	}
+	if !request.Spec.ExpiresAt.IsZero() {
+		result.ExpiresAt = types.NewTime(request.Spec.ExpiresAt.Time)
+	}
+	if request.Spec.State == types.AccessRequestStateApproved {
+		result.GrantID = request.GrantName()
+	}
+	return result
+}
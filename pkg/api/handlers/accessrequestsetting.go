@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/obot-platform/obot/pkg/api"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	"github.com/obot-platform/obot/pkg/system"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+type AccessRequestSettingHandler struct{}
+
+func NewAccessRequestSettingHandler() *AccessRequestSettingHandler {
+	return nil
+}
+
+func (*AccessRequestSettingHandler) Get(req api.Context) error {
+	setting, err := getAccessRequestSetting(req)
+	if err != nil {
+		return err
+	}
+	return req.Write(setting)
+}
+
+func (*AccessRequestSettingHandler) Update(req api.Context) error {
+	var input types.AccessRequestSetting
+	if err := req.Read(&input); err != nil {
+		return types.NewErrBadRequest("failed to read access request setting: %v", err)
+	}
+	if err := input.Validate(); err != nil {
+		return types.NewErrBadRequest("invalid access request setting: %v", err)
+	}
+
+	var setting v1.AccessRequestSetting
+	if err := req.Get(&setting, system.AccessRequestSettingName); apierrors.IsNotFound(err) {
+		setting = v1.AccessRequestSetting{
+			Name:      system.AccessRequestSettingName,
+			Namespace: req.Namespace(),
+			Spec: v1.AccessRequestSettingSpec{
+				Manifest: input,
+			},
+		}
+		if err := req.Create(&setting); err != nil {
+			return err
+		}
+	} else if err != nil {
+		return err
+	} else {
+		setting.Spec.Manifest = input
+		if err := req.Update(&setting); err != nil {
+			return err
+		}
+	}
+
+	return req.Write(setting.Spec.Manifest)
+}
+
+// getAccessRequestSetting returns the access request setting, or the default
+// when an admin has not configured one: no approvers beyond owners and admins.
+func getAccessRequestSetting(req api.Context) (types.AccessRequestSetting, error) {
+	var setting v1.AccessRequestSetting
+	if err := req.Get(&setting, system.AccessRequestSettingName); apierrors.IsNotFound(err) {
+		return types.AccessRequestSetting{}, nil
+	} else if err != nil {
+		return types.AccessRequestSetting{}, err
+	}
+	return setting.Spec.Manifest, nil
+}
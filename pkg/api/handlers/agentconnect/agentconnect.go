@@ -12,9 +12,11 @@ import (
 	"net/http/httputil"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/obot-platform/obot/apiclient/types"
 	"github.com/obot-platform/obot/pkg/api"
+	"github.com/obot-platform/obot/pkg/hostedagentactivity"
 	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
 )
 
@@ -28,6 +30,10 @@ type DevRouter interface {
 	DevRoute(backendID string) (string, http.RoundTripper, bool)
 }
 
+// wakeTimeout is how long a request waits for a hibernated sandbox to start.
+// Past it the caller is told to retry rather than held open indefinitely.
+const wakeTimeout = time.Minute
+
 type Handler struct {
 	transport http.RoundTripper
 	devRouter DevRouter
@@ -64,6 +70,10 @@ func (h *Handler) Proxy(req api.Context) error {
 		return err
 	}
 
+	if err := wake(req, &instance); err != nil {
+		return err
+	}
+
 	target, err := targetURL(&instance)
 	if err != nil {
 		return err
@@ -184,6 +194,37 @@ func (h *Handler) Proxy(req api.Context) error {
 	return nil
 }
 
+// wake records the request as activity and, when the sandbox is not running,
+// holds the request until it is. Recording activity is also what starts a
+// hibernated sandbox, so the first request after a quiet spell brings the agent
+// back instead of failing.
+//
+// An instance its user stopped is not woken: stopping is an explicit choice,
+// and a stray browser tab must not undo it.
+func wake(req api.Context, instance *v1.HostedAgentInstance) error {
+	if instance.Spec.DesiredState == types.HostedAgentDesiredStateStopped {
+		return types.NewErrHTTP(http.StatusServiceUnavailable,
+			fmt.Sprintf("agent %s is stopped; start it to connect", instance.Name))
+	}
+	if err := hostedagentactivity.Touch(req.Context(), req.Storage, instance); err != nil {
+		return fmt.Errorf("failed to record agent activity: %w", err)
+	}
+	if instance.Status.State == types.HostedAgentStateReady || instance.Status.State == types.HostedAgentStateError {
+		return nil
+	}
+
+	ready, err := hostedagentactivity.WaitReady(req.Context(), req.Storage, instance, wakeTimeout)
+	if err != nil {
+		return err
+	}
+	if !ready && instance.Status.State != types.HostedAgentStateError {
+		req.ResponseWriter.Header().Set("Retry-After", "5")
+		return types.NewErrHTTP(http.StatusServiceUnavailable,
+			fmt.Sprintf("agent %s is starting", instance.Name))
+	}
+	return nil
+}
+
 // targetURL is the sandbox address Obot observed, not one derived from the
 // request, so a caller cannot influence where this proxies to.
 func targetURL(instance *v1.HostedAgentInstance) (*url.URL, error) {
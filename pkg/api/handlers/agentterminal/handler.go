@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net/http"
 	"strconv"
 	"time"
 
@@ -13,6 +14,7 @@ import (
 	"github.com/obot-platform/obot/apiclient/types"
 	"github.com/obot-platform/obot/pkg/agentbackend"
 	"github.com/obot-platform/obot/pkg/api"
+	"github.com/obot-platform/obot/pkg/hostedagentactivity"
 	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
 )
 
@@ -30,6 +32,8 @@ const (
 	// readLimit caps a single inbound frame. Keystrokes and resize messages are
 	// tiny; anything approaching this is not a terminal client.
 	readLimit = 64 * 1024
+	// wakeTimeout is how long an attach waits for a hibernated sandbox to start.
+	wakeTimeout = time.Minute
 )
 
 type Handler struct {
@@ -49,6 +53,9 @@ type Handler struct {
 type pump struct {
 	conn    *websocket.Conn
 	session agentbackend.TerminalSession
+	// touch records the session as activity. An attached console keeps its
+	// sandbox from hibernating even while nothing is typed into it.
+	touch func(context.Context)
 }
 
 // New builds the terminal handler. devUIPort is the port a separate dev UI
@@ -96,6 +103,30 @@ func (h *Handler) Attach(req api.Context) error {
 		return types.NewErrBadRequest("the configured agent runtime does not support terminals")
 	}
 
+	// Attaching counts as activity, which also starts a hibernated sandbox. One
+	// its user stopped stays stopped until they start it.
+	if instance.Spec.DesiredState == types.HostedAgentDesiredStateStopped {
+		return types.NewErrBadRequest("agent %s is stopped; start it to open a terminal", instance.Name)
+	}
+	if err := hostedagentactivity.Touch(req.Context(), req.Storage, &instance); err != nil {
+		return fmt.Errorf("failed to record agent activity: %w", err)
+	}
+	if instance.Status.State != types.HostedAgentStateReady {
+		ready, err := hostedagentactivity.WaitReady(req.Context(), req.Storage, &instance, wakeTimeout)
+		if err != nil {
+			return err
+		}
+		if !ready {
+			if instance.Status.State == types.HostedAgentStateError {
+				return types.NewErrHTTP(http.StatusServiceUnavailable,
+					fmt.Sprintf("agent %s is in an error state", instance.Name))
+			}
+			req.ResponseWriter.Header().Set("Retry-After", "5")
+			return types.NewErrHTTP(http.StatusServiceUnavailable,
+				fmt.Sprintf("agent %s is starting", instance.Name))
+		}
+	}
+
 	// Accept rejects cross-origin upgrades by default, which is what this
 	// connection needs: it is authorized by the session cookie, so a page on
 	// another origin must not be able to open one. OriginPatterns adds the dev
@@ -123,7 +154,11 @@ func (h *Handler) Attach(req api.Context) error {
 	}
 	defer session.Close()
 
-	(&pump{conn: conn, session: session}).run(req.Context())
+	(&pump{conn: conn, session: session, touch: func(ctx context.Context) {
+		// Best effort: a failed write only brings hibernation forward, and is
+		// no reason to drop a working console.
+		_ = hostedagentactivity.Touch(ctx, req.Storage, &instance)
+	}}).run(req.Context())
 	return nil
 }
 
@@ -201,6 +236,9 @@ func (p *pump) writeToBrowser(ctx context.Context) {
 		case <-ctx.Done():
 			return
 		case <-ticker.C:
+			if p.touch != nil {
+				p.touch(ctx)
+			}
 			pingCtx, cancel := context.WithTimeout(ctx, pingWait)
 			err := p.conn.Ping(pingCtx)
 			cancel()
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/obot-platform/obot/pkg/api"
+	"github.com/obot-platform/obot/pkg/gateway/redact"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	"github.com/obot-platform/obot/pkg/system"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+type AuditRedactionSettingHandler struct{}
+
+func NewAuditRedactionSettingHandler() *AuditRedactionSettingHandler {
+	return nil
+}
+
+func (*AuditRedactionSettingHandler) Get(req api.Context) error {
+	var setting v1.AuditRedactionSetting
+	if err := req.Get(&setting, system.AuditRedactionSettingName); apierrors.IsNotFound(err) {
+		// The built-in detectors apply until an admin saves a setting.
+		return req.Write(types.AuditRedactionSetting{})
+	} else if err != nil {
+		return err
+	}
+	return req.Write(setting.Spec.Manifest)
+}
+
+func (*AuditRedactionSettingHandler) Update(req api.Context) error {
+	var input types.AuditRedactionSetting
+	if err := req.Read(&input); err != nil {
+		return err
+	}
+	if err := redact.Validate(input); err != nil {
+		return types.NewErrBadRequest("invalid audit redaction setting: %v", err)
+	}
+
+	var setting v1.AuditRedactionSetting
+	if err := req.Get(&setting, system.AuditRedactionSettingName); apierrors.IsNotFound(err) {
+		setting = v1.AuditRedactionSetting{
+			Name:      system.AuditRedactionSettingName,
+			Namespace: req.Namespace(),
+			Spec: v1.AuditRedactionSettingSpec{
+				Manifest: input,
+			},
+		}
+		if err := req.Create(&setting); err != nil {
+			return err
+		}
+	} else if err != nil {
+		return err
+	} else {
+		setting.Spec.Manifest = input
+		if err := req.Update(&setting); err != nil {
+			return err
+		}
+	}
+
+	return req.Write(setting.Spec.Manifest)
+}
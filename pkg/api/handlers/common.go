@@ -5,15 +5,17 @@ import (
 	"strings"
 
 	"github.com/obot-platform/obot/apiclient/types"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
 	kclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func MetadataFrom(obj kclient.Object, linkKV ...string) types.Metadata {
 	m := types.Metadata{
-		ID:      obj.GetName(),
-		Created: *types.NewTime(obj.GetCreationTimestamp().Time),
-		Links:   map[string]string{},
-		Type:    strings.ToLower(reflect.TypeOf(obj).Elem().Name()),
+		ID:                 obj.GetName(),
+		Created:            *types.NewTime(obj.GetCreationTimestamp().Time),
+		Links:              map[string]string{},
+		Type:               strings.ToLower(reflect.TypeOf(obj).Elem().Name()),
+		ConfigRepositoryID: v1.ManagedByConfigRepository(obj),
 	}
 	if delTime := obj.GetDeletionTimestamp(); delTime != nil {
 		m.Deleted = types.NewTime(delTime.Time)
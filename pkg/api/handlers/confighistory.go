@@ -0,0 +1,304 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"strconv"
+
+	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/obot-platform/obot/pkg/api"
+	"github.com/obot-platform/obot/pkg/confighistory"
+	gatewaytypes "github.com/obot-platform/obot/pkg/gateway/types"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	"gorm.io/gorm"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// configHistoryObject describes a storage kind whose changes are recorded. Its
+// history holds the object's spec, which spec returns a pointer to. validate
+// checks a reverted object the way the kind's Create and Update handlers do.
+type configHistoryObject struct {
+	newObject func() kclient.Object
+	spec      func(kclient.Object) any
+	validate  func(api.Context, kclient.Object) error
+}
+
+var configHistoryObjects = map[string]configHistoryObject{
+	types.ConfigChangeKindModelAccessPolicy: {
+		newObject: func() kclient.Object { return &v1.ModelAccessPolicy{} },
+		spec:      func(obj kclient.Object) any { return &obj.(*v1.ModelAccessPolicy).Spec },
+		validate: func(req api.Context, obj kclient.Object) error {
+			return validateModelAccessPolicyManifest(req, obj.(*v1.ModelAccessPolicy).Spec.Manifest)
+		},
+	},
+	types.ConfigChangeKindAccessControlRule: {
+		newObject: func() kclient.Object { return &v1.AccessControlRule{} },
+		spec:      func(obj kclient.Object) any { return &obj.(*v1.AccessControlRule).Spec },
+		validate: func(req api.Context, obj kclient.Object) error {
+			return validateAccessControlRule(req, obj.(*v1.AccessControlRule).Spec)
+		},
+	},
+	types.ConfigChangeKindMessagePolicy: {
+		newObject: func() kclient.Object { return &v1.MessagePolicy{} },
+		spec:      func(obj kclient.Object) any { return &obj.(*v1.MessagePolicy).Spec },
+		validate: func(_ api.Context, obj kclient.Object) error {
+			if err := obj.(*v1.MessagePolicy).Spec.Manifest.Validate(); err != nil {
+				return types.NewErrBadRequest("invalid message policy manifest: %v", err)
+			}
+			return nil
+		},
+	},
+	types.ConfigChangeKindMCPQuotaRule: {
+		newObject: func() kclient.Object { return &v1.MCPQuotaRule{} },
+		spec:      func(obj kclient.Object) any { return &obj.(*v1.MCPQuotaRule).Spec },
+		validate: func(_ api.Context, obj kclient.Object) error {
+			if err := obj.(*v1.MCPQuotaRule).Spec.Manifest.Validate(); err != nil {
+				return types.NewErrBadRequest("invalid MCP quota rule manifest: %v", err)
+			}
+			return nil
+		},
+	},
+	types.ConfigChangeKindMCPToolApprovalPolicy: {
+		newObject: func() kclient.Object { return &v1.MCPToolApprovalPolicy{} },
+		spec:      func(obj kclient.Object) any { return &obj.(*v1.MCPToolApprovalPolicy).Spec },
+		validate: func(_ api.Context, obj kclient.Object) error {
+			if err := obj.(*v1.MCPToolApprovalPolicy).Spec.Manifest.Validate(); err != nil {
+				return types.NewErrBadRequest("invalid MCP tool approval policy manifest: %v", err)
+			}
+			return nil
+		},
+	},
+	types.ConfigChangeKindMCPServerRequestPolicy: {
+		newObject: func() kclient.Object { return &v1.MCPServerRequestPolicy{} },
+		spec:      func(obj kclient.Object) any { return &obj.(*v1.MCPServerRequestPolicy).Spec },
+		validate: func(_ api.Context, obj kclient.Object) error {
+			if err := obj.(*v1.MCPServerRequestPolicy).Spec.Manifest.Validate(); err != nil {
+				return types.NewErrBadRequest("invalid MCP server request policy manifest: %v", err)
+			}
+			return nil
+		},
+	},
+}
+
+// recordConfigChange adds a revision to an object's history. A nil before
+// records a creation and a nil after a deletion; an update that changes nothing
+// is not recorded unless it is a revert. Failures are logged rather than
+// returned, so that the change itself still succeeds.
+func recordConfigChange(req api.Context, kind, objectID string, before, after any, revertedFrom uint) *gatewaytypes.ConfigChange {
+	beforeJSON, err := marshalConfigSnapshot(before)
+	if err != nil {
+		slog.Error("failed to record config change", "kind", kind, "id", objectID, "error", err)
+		return nil
+	}
+	afterJSON, err := marshalConfigSnapshot(after)
+	if err != nil {
+		slog.Error("failed to record config change", "kind", kind, "id", objectID, "error", err)
+		return nil
+	}
+
+	diff, err := confighistory.Diff(beforeJSON, afterJSON)
+	if err != nil {
+		slog.Error("failed to record config change", "kind", kind, "id", objectID, "error", err)
+		return nil
+	}
+
+	var action types.ConfigChangeAction
+	switch {
+	case revertedFrom != 0:
+		action = types.ConfigChangeActionReverted
+	case beforeJSON == nil:
+		action = types.ConfigChangeActionCreated
+	case afterJSON == nil:
+		action = types.ConfigChangeActionDeleted
+	case len(diff) == 0:
+		return nil
+	default:
+		action = types.ConfigChangeActionUpdated
+	}
+
+	diffJSON, err := json.Marshal(diff)
+	if err != nil {
+		slog.Error("failed to record config change", "kind", kind, "id", objectID, "error", err)
+		return nil
+	}
+
+	change := &gatewaytypes.ConfigChange{
+		Kind:         kind,
+		ObjectID:     objectID,
+		Action:       string(action),
+		RevertedFrom: revertedFrom,
+		Before:       string(beforeJSON),
+		After:        string(afterJSON),
+		Diff:         string(diffJSON),
+	}
+	if req.User != nil {
+		change.ActorID = req.User.GetUID()
+		change.ActorName = req.User.GetName()
+	}
+	if err := req.GatewayClient.RecordConfigChange(req.Context(), change); err != nil {
+		slog.Error("failed to record config change", "kind", kind, "id", objectID, "error", err)
+		return nil
+	}
+	return change
+}
+
+func marshalConfigSnapshot(snapshot any) ([]byte, error) {
+	if snapshot == nil || reflect.ValueOf(snapshot).Kind() == reflect.Pointer && reflect.ValueOf(snapshot).IsNil() {
+		return nil, nil
+	}
+	return json.Marshal(snapshot)
+}
+
+// ConfigHistoryHandler serves the change history of governance objects and
+// restores earlier revisions.
+type ConfigHistoryHandler struct {
+	mdmConfigurations *MDMConfigurationsHandler
+}
+
+func NewConfigHistoryHandler(mdmConfigurations *MDMConfigurationsHandler) *ConfigHistoryHandler {
+	return &ConfigHistoryHandler{mdmConfigurations: mdmConfigurations}
+}
+
+// List returns revisions, newest first, optionally for one kind or object.
+func (*ConfigHistoryHandler) List(req api.Context) error {
+	query := req.URL.Query()
+	var limit, offset int
+	if v := query.Get("limit"); v != "" {
+		if l, err := strconv.Atoi(v); err == nil && l > 0 {
+			limit = l
+		}
+	}
+	if v := query.Get("offset"); v != "" {
+		if o, err := strconv.Atoi(v); err == nil && o >= 0 {
+			offset = o
+		}
+	}
+
+	changes, total, err := req.GatewayClient.ListConfigChanges(req.Context(), query.Get("kind"), query.Get("objectID"), limit, offset)
+	if err != nil {
+		return err
+	}
+
+	items := make([]types.ConfigChange, 0, len(changes))
+	for _, change := range changes {
+		items = append(items, gatewaytypes.ConvertConfigChange(change))
+	}
+
+	req.ResponseWriter.Header().Set("X-Total-Count", strconv.FormatInt(total, 10))
+	return req.Write(types.ConfigChangeList{Items: items})
+}
+
+func (*ConfigHistoryHandler) Get(req api.Context) error {
+	change, err := getConfigChange(req)
+	if err != nil {
+		return err
+	}
+	return req.Write(gatewaytypes.ConvertConfigChange(*change))
+}
+
+// Revert restores the configuration an object had after the given revision,
+// recreating the object if it has since been deleted. The restore is itself
+// recorded as a new revision.
+func (h *ConfigHistoryHandler) Revert(req api.Context) error {
+	change, err := getConfigChange(req)
+	if err != nil {
+		return err
+	}
+	if change.After == "" {
+		return types.NewErrBadRequest("revision %d deleted %s %s; cannot revert to a deletion", change.ID, change.Kind, change.ObjectID)
+	}
+
+	var recorded *gatewaytypes.ConfigChange
+	if change.Kind == types.ConfigChangeKindMDMConfigurationEnforcement {
+		id, err := strconv.ParseUint(change.ObjectID, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid MDM configuration ID %q in revision %d: %w", change.ObjectID, change.ID, err)
+		}
+		var in types.MDMConfigurationEnforcementRequest
+		if err := json.Unmarshal([]byte(change.After), &in); err != nil {
+			return fmt.Errorf("failed to decode revision %d: %w", change.ID, err)
+		}
+		if _, recorded, err = h.mdmConfigurations.updateEnforcement(req, uint(id), in, change.ID); err != nil {
+			return err
+		}
+	} else if recorded, err = revertConfigObject(req, change); err != nil {
+		return err
+	}
+
+	if recorded == nil {
+		// The revert succeeded and recordConfigChange has logged why its
+		// revision is missing, so describe the revert without one.
+		recorded = &gatewaytypes.ConfigChange{
+			Kind:         change.Kind,
+			ObjectID:     change.ObjectID,
+			Action:       string(types.ConfigChangeActionReverted),
+			RevertedFrom: change.ID,
+			After:        change.After,
+		}
+	}
+	return req.Write(gatewaytypes.ConvertConfigChange(*recorded))
+}
+
+func revertConfigObject(req api.Context, change *gatewaytypes.ConfigChange) (*gatewaytypes.ConfigChange, error) {
+	kind, ok := configHistoryObjects[change.Kind]
+	if !ok {
+		return nil, types.NewErrBadRequest("revisions of %s cannot be reverted", change.Kind)
+	}
+
+	obj := kind.newObject()
+	exists := true
+	if err := req.Get(obj, change.ObjectID); apierrors.IsNotFound(err) {
+		exists = false
+		obj.SetName(change.ObjectID)
+		obj.SetNamespace(req.Namespace())
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get %s %s: %w", change.Kind, change.ObjectID, err)
+	} else if err := checkNotManagedByConfigRepository(obj); err != nil {
+		return nil, err
+	}
+
+	// Snapshot the current spec before it is replaced.
+	var before any
+	if exists {
+		current := reflect.ValueOf(kind.spec(obj)).Elem()
+		snapshot := reflect.New(current.Type())
+		snapshot.Elem().Set(current)
+		before = snapshot.Interface()
+	}
+
+	spec := reflect.ValueOf(kind.spec(obj)).Elem()
+	spec.SetZero()
+	if err := json.Unmarshal([]byte(change.After), spec.Addr().Interface()); err != nil {
+		return nil, fmt.Errorf("failed to decode revision %d: %w", change.ID, err)
+	}
+
+	if err := kind.validate(req, obj); err != nil {
+		return nil, err
+	}
+
+	if exists {
+		if err := req.Update(obj); err != nil {
+			return nil, fmt.Errorf("failed to update %s %s: %w", change.Kind, change.ObjectID, err)
+		}
+	} else if err := req.Create(obj); err != nil {
+		return nil, fmt.Errorf("failed to recreate %s %s: %w", change.Kind, change.ObjectID, err)
+	}
+
+	return recordConfigChange(req, change.Kind, change.ObjectID, before, kind.spec(obj), change.ID), nil
+}
+
+func getConfigChange(req api.Context) (*gatewaytypes.ConfigChange, error) {
+	id, err := parsePathUint(req, "revision_id", "revision")
+	if err != nil {
+		return nil, err
+	}
+	change, err := req.GatewayClient.GetConfigChange(req.Context(), id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, types.NewErrNotFound("revision %d not found", id)
+	}
+	return change, err
+}
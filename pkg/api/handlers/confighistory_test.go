@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/obot-platform/obot/pkg/api"
+	gatewaytypes "github.com/obot-platform/obot/pkg/gateway/types"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	"github.com/obot-platform/obot/pkg/system"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestConfigHistoryHandlerRevertValidates(t *testing.T) {
+	valid := v1.MessagePolicySpec{Manifest: types.MessagePolicyManifest{
+		DisplayName: "current",
+		Definition:  "no secrets",
+		Direction:   types.PolicyDirectionBoth,
+		Subjects:    []types.Subject{{Type: types.SubjectTypeUser, ID: "u1"}},
+	}}
+	storage := newFakeStorage(t, &v1.MessagePolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "mp1", Namespace: system.DefaultNamespace},
+		Spec:       valid,
+	})
+	gateway := newHandlerTestGateway(t)
+
+	revert := func(after any) error {
+		t.Helper()
+		afterJSON, err := json.Marshal(after)
+		require.NoError(t, err)
+		change := gatewaytypes.ConfigChange{
+			Kind:     types.ConfigChangeKindMessagePolicy,
+			ObjectID: "mp1",
+			Action:   string(types.ConfigChangeActionUpdated),
+			After:    string(afterJSON),
+		}
+		require.NoError(t, gateway.RecordConfigChange(t.Context(), &change))
+
+		req := api.Context{
+			ResponseWriter: httptest.NewRecorder(),
+			Request:        httptest.NewRequest(http.MethodPost, "/api/config-history/"+strconv.FormatUint(uint64(change.ID), 10)+"/revert", nil),
+			Storage:        storage,
+			GatewayClient:  gateway,
+		}
+		req.SetPathValue("revision_id", strconv.FormatUint(uint64(change.ID), 10))
+		return NewConfigHistoryHandler(nil).Revert(req)
+	}
+
+	// A revision that the message policy handlers would reject is not applied.
+	invalid := valid
+	invalid.Manifest.Definition = ""
+	err := revert(invalid)
+	var httpErr *types.ErrHTTP
+	require.True(t, errors.As(err, &httpErr), "expected *types.ErrHTTP, got %T: %v", err, err)
+	assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+
+	var policy v1.MessagePolicy
+	require.NoError(t, storage.Get(t.Context(), kclient.ObjectKey{Namespace: system.DefaultNamespace, Name: "mp1"}, &policy))
+	assert.Equal(t, "no secrets", policy.Spec.Manifest.Definition)
+
+	// A valid one is.
+	previous := valid
+	previous.Manifest.DisplayName = "previous"
+	require.NoError(t, revert(previous))
+	require.NoError(t, storage.Get(t.Context(), kclient.ObjectKey{Namespace: system.DefaultNamespace, Name: "mp1"}, &policy))
+	assert.Equal(t, "previous", policy.Spec.Manifest.DisplayName)
+}
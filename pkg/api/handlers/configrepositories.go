@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/obot-platform/obot/pkg/api"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	"github.com/obot-platform/obot/pkg/system"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type ConfigRepositoryHandler struct{}
+
+func NewConfigRepositoryHandler() *ConfigRepositoryHandler {
+	return &ConfigRepositoryHandler{}
+}
+
+func (*ConfigRepositoryHandler) List(req api.Context) error {
+	var list v1.ConfigRepositoryList
+	if err := req.List(&list); err != nil {
+		return fmt.Errorf("failed to list config repositories: %w", err)
+	}
+
+	items := make([]types.ConfigRepository, 0, len(list.Items))
+	for _, item := range list.Items {
+		items = append(items, convertConfigRepository(item))
+	}
+
+	return req.Write(types.ConfigRepositoryList{Items: items})
+}
+
+func (*ConfigRepositoryHandler) Get(req api.Context) error {
+	var repo v1.ConfigRepository
+	if err := req.Get(&repo, req.PathValue("config_repository_id")); err != nil {
+		return fmt.Errorf("failed to get config repository: %w", err)
+	}
+
+	return req.Write(convertConfigRepository(repo))
+}
+
+func (*ConfigRepositoryHandler) Create(req api.Context) error {
+	manifest, err := readAndValidateConfigRepositoryManifest(req)
+	if err != nil {
+		return err
+	}
+
+	repo := v1.ConfigRepository{
+		GenerateName: system.ConfigRepositoryPrefix,
+		Namespace:    req.Namespace(),
+		Spec: v1.ConfigRepositorySpec{
+			ConfigRepositoryManifest: *manifest,
+		},
+	}
+
+	if err := req.Create(&repo); err != nil {
+		return fmt.Errorf("failed to create config repository: %w", err)
+	}
+
+	return req.WriteCreated(convertConfigRepository(repo))
+}
+
+func (*ConfigRepositoryHandler) Update(req api.Context) error {
+	manifest, err := readAndValidateConfigRepositoryManifest(req)
+	if err != nil {
+		return err
+	}
+
+	var repo v1.ConfigRepository
+	if err := req.Get(&repo, req.PathValue("config_repository_id")); err != nil {
+		return fmt.Errorf("failed to get config repository: %w", err)
+	}
+
+	repo.Spec.ConfigRepositoryManifest = *manifest
+	if err := req.Update(&repo); err != nil {
+		return fmt.Errorf("failed to update config repository: %w", err)
+	}
+
+	return req.Write(convertConfigRepository(repo))
+}
+
+// Delete removes the repository. Its finalizer releases the objects it
+// managed, which are left in place and become editable again.
+func (*ConfigRepositoryHandler) Delete(req api.Context) error {
+	return req.Delete(&v1.ConfigRepository{
+		Name:      req.PathValue("config_repository_id"),
+		Namespace: req.Namespace(),
+	})
+}
+
+// Refresh asks the controller to sync now by setting the force-sync annotation,
+// rather than doing any work itself.
+func (*ConfigRepositoryHandler) Refresh(req api.Context) error {
+	var repo v1.ConfigRepository
+	if err := req.Get(&repo, req.PathValue("config_repository_id")); err != nil {
+		return fmt.Errorf("failed to get config repository: %w", err)
+	}
+
+	if repo.Annotations == nil {
+		repo.Annotations = map[string]string{}
+	}
+	repo.Annotations[v1.ConfigRepositorySyncAnnotation] = "true"
+
+	if err := req.Update(&repo); err != nil {
+		return fmt.Errorf("failed to refresh config repository: %w", err)
+	}
+
+	req.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func readAndValidateConfigRepositoryManifest(req api.Context) (*types.ConfigRepositoryManifest, error) {
+	var manifest types.ConfigRepositoryManifest
+	if err := req.Read(&manifest); err != nil {
+		return nil, types.NewErrBadRequest("failed to read config repository manifest: %v", err)
+	}
+
+	manifest.DisplayName = strings.TrimSpace(manifest.DisplayName)
+	manifest.RepoURL = strings.TrimSpace(manifest.RepoURL)
+	manifest.Ref = strings.TrimSpace(manifest.Ref)
+	manifest.Path = strings.Trim(strings.TrimSpace(manifest.Path), "/")
+
+	if err := manifest.Validate(); err != nil {
+		return nil, types.NewErrBadRequest("invalid config repository manifest: %v", err)
+	}
+	if err := validateSharedGitCredential(req, manifest.GitCredentialID, manifest.RepoURL); err != nil {
+		return nil, err
+	}
+
+	return &manifest, nil
+}
+
+// checkNotManagedByConfigRepository rejects changes to an object that a config
+// repository manages, since the next sync would revert them.
+func checkNotManagedByConfigRepository(obj kclient.Object) error {
+	if repo := v1.ManagedByConfigRepository(obj); repo != "" {
+		return types.NewErrHTTP(http.StatusConflict, fmt.Sprintf("%s is managed by config repository %s; change it in the repository instead", obj.GetName(), repo))
+	}
+	return nil
+}
+
+func convertConfigRepository(repo v1.ConfigRepository) types.ConfigRepository {
+	objects := repo.Status.Objects
+	if objects == nil {
+		objects = []types.ConfigRepositoryObject{}
+	}
+
+	return types.ConfigRepository{
+		Metadata:                 MetadataFrom(&repo),
+		ConfigRepositoryManifest: repo.Spec.ConfigRepositoryManifest,
+		LastSyncTime:             *types.NewTime(repo.Status.LastSyncTime.Time),
+		IsSyncing:                repo.Status.IsSyncing,
+		SyncError:                repo.Status.SyncError,
+		ResolvedCommitSHA:        repo.Status.ResolvedCommitSHA,
+		Objects:                  objects,
+	}
+}
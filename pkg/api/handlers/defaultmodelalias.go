@@ -5,6 +5,7 @@ import (
 	"github.com/obot-platform/obot/pkg/api"
 	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
 	"github.com/obot-platform/obot/pkg/system"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 )
 
 type DefaultModelAliasHandler struct{}
@@ -60,6 +61,9 @@ func (*DefaultModelAliasHandler) Update(req api.Context) error {
 	if err := req.Get(&dma, req.PathValue("id")); err != nil {
 		return err
 	}
+	if err := checkNotManagedByConfigRepository(&dma); err != nil {
+		return err
+	}
 
 	var manifest types.DefaultModelAliasManifest
 	if err := req.Read(&manifest); err != nil {
@@ -75,10 +79,17 @@ func (*DefaultModelAliasHandler) Update(req api.Context) error {
 }
 
 func (*DefaultModelAliasHandler) Delete(req api.Context) error {
-	return req.Delete(&v1.DefaultModelAlias{
-		Name:      req.PathValue("id"),
-		Namespace: req.Namespace(),
-	})
+	var dma v1.DefaultModelAlias
+	if err := req.Get(&dma, req.PathValue("id")); apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if err := checkNotManagedByConfigRepository(&dma); err != nil {
+		return err
+	}
+
+	return req.Delete(&dma)
 }
 
 func convertDefaultModelAlias(d v1.DefaultModelAlias) types.DefaultModelAlias {
@@ -15,8 +15,11 @@ import (
 	"github.com/obot-platform/obot/pkg/enforcement"
 	gateway "github.com/obot-platform/obot/pkg/gateway/client"
 	gtypes "github.com/obot-platform/obot/pkg/gateway/types"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	"github.com/obot-platform/obot/pkg/system"
 	"github.com/obot-platform/obot/pkg/utils"
 	"gorm.io/gorm"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 )
 
 const (
@@ -35,6 +38,8 @@ var (
 		"server":   {},
 		"decision": {},
 		"actor":    {},
+		"user":     {},
+		"source":   {},
 	}
 )
 
@@ -219,7 +224,8 @@ func (h *EnforcementHandler) GetDecision(req api.Context) error {
 
 // CheckDecisionAllowlist handles GET /api/enforcement-decisions/allowlist-check/{id}
 // (admin-only). It replays a recorded decision against its fleet's current
-// allowlist. It records nothing in the decision log.
+// allowlist, or the LLM gateway's for a gateway decision. It records nothing in
+// the decision log.
 func (h *EnforcementHandler) CheckDecisionAllowlist(req api.Context) error {
 	id, err := parseEnforcementDecisionID(req)
 	if err != nil {
@@ -233,23 +239,38 @@ func (h *EnforcementHandler) CheckDecisionAllowlist(req api.Context) error {
 		return err
 	}
 
-	policy, err := req.GatewayClient.GetMDMConfigurationEnforcement(req.Context(), log.MDMConfigurationID)
-	if errors.Is(err, gorm.ErrRecordNotFound) {
-		return types.NewErrNotFound("MDM configuration %d not found", log.MDMConfigurationID)
-	} else if err != nil {
-		return err
+	var (
+		enabled   bool
+		allowlist types.EnforcementAllowlist
+	)
+	if log.Source == types.EnforcementDecisionSourceLLMGateway {
+		// A gateway decision has no fleet; it was made against the gateway's
+		// own setting.
+		var setting v1.ToolCallEnforcementSetting
+		if err := req.Get(&setting, system.ToolCallEnforcementSettingName); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		enabled, allowlist = setting.Spec.Manifest.Enabled, setting.Spec.Manifest.Allowlist
+	} else {
+		policy, err := req.GatewayClient.GetMDMConfigurationEnforcement(req.Context(), log.MDMConfigurationID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return types.NewErrNotFound("MDM configuration %d not found", log.MDMConfigurationID)
+		} else if err != nil {
+			return err
+		}
+		enabled, allowlist = policy.Enabled, policy.Allowlist
 	}
 
 	decision := enforcement.Evaluate(
 		normalizedCallFromDecisionLog(*log, h.isObotHosted(log.ServerURL)),
-		policy.Allowlist,
+		allowlist,
 	)
 
 	return req.Write(types.EnforcementDecisionAllowlistCheck{
 		ID:                 strconv.FormatUint(uint64(log.ID), 10),
 		AllowlistDecision:  verdict(decision),
 		AllowlistReason:    decision.Reason,
-		EnforcementEnabled: policy.Enabled,
+		EnforcementEnabled: enabled,
 	})
 }
 
@@ -268,6 +289,11 @@ func (h *EnforcementHandler) ListFilterOptions(req api.Context) error {
 			"options": []string{types.EnforcementDecisionAllow, types.EnforcementDecisionDeny},
 		})
 	}
+	if filter == "source" {
+		return req.Write(map[string]any{
+			"options": []string{types.EnforcementDecisionSourceDevice, types.EnforcementDecisionSourceLLMGateway},
+		})
+	}
 
 	opts, err := parseEnforcementDecisionOptions(req.URL.Query())
 	if err != nil {
@@ -290,7 +316,9 @@ func parseEnforcementDecisionOptions(query url.Values) (gateway.EnforcementDecis
 
 	opts := gateway.EnforcementDecisionOptions{
 		MDMConfigurationID: configurationIDs,
+		Source:             parseMultiValue(query, "source"),
 		Actor:              parseMultiValue(query, "actor"),
+		User:               parseMultiValue(query, "user"),
 		Agent:              parseMultiValue(query, "agent"),
 		Server:             parseMultiValue(query, "server"),
 		Tool:               parseMultiValue(query, "tool"),
@@ -331,6 +359,8 @@ func presentEnforcementDecision(log gtypes.EnforcementDecisionLog) types.Enforce
 	event := types.EnforcementDecisionEvent{
 		ID:                 strconv.FormatUint(uint64(log.ID), 10),
 		CreatedAt:          *types.NewTime(log.CreatedAt),
+		Source:             log.Source,
+		UserID:             log.UserID,
 		MDMConfigurationID: log.MDMConfigurationID,
 		DeviceID:           log.DeviceID,
 		ClientIP:           log.ClientIP,
@@ -344,6 +374,9 @@ func presentEnforcementDecision(log gtypes.EnforcementDecisionLog) types.Enforce
 		Unresolved:         log.Unresolved,
 		UnresolvedReason:   log.UnresolvedReason,
 	}
+	if event.Source == "" {
+		event.Source = types.EnforcementDecisionSourceDevice
+	}
 	server := types.EnforcementDecisionServer{
 		URL:       log.ServerURL,
 		Command:   log.ServerCommand,
@@ -52,8 +52,11 @@ func (*GitCredentialHandler) Create(req api.Context) error {
 	if err != nil {
 		return err
 	}
-	if manifest.Token == "" {
-		return types.NewErrBadRequest("token is required")
+	if manifest.Type == "" {
+		manifest.Type = types.GitCredentialTypeToken
+	}
+	if err := validateGitCredentialManifest(manifest, manifest.Type, false); err != nil {
+		return err
 	}
 
 	credential := v1.GitCredential{
@@ -61,16 +64,20 @@ func (*GitCredentialHandler) Create(req api.Context) error {
 		Namespace:    req.Namespace(),
 		Finalizers:   []string{v1.GitCredentialFinalizer},
 		Spec: v1.GitCredentialSpec{
-			DisplayName: manifest.DisplayName,
-			Host:        host,
+			DisplayName:             manifest.DisplayName,
+			Host:                    host,
+			Type:                    manifest.Type,
+			KnownHosts:              manifest.KnownHosts,
+			GitHubAppID:             manifest.GitHubAppID,
+			GitHubAppInstallationID: manifest.GitHubAppInstallationID,
 		},
 	}
 	if err := req.Create(&credential); err != nil {
 		return fmt.Errorf("failed to create Git credential: %w", err)
 	}
-	if err := gitcredential.Store(req.Context(), req.GatewayClient, credential.Name, manifest.Token); err != nil {
+	if err := storeGitCredentialSecret(req, credential.Name, manifest); err != nil {
 		_ = req.Delete(&credential)
-		return fmt.Errorf("failed to store Git credential token: %w", err)
+		return err
 	}
 	return req.WriteCreated(convertGitCredential(credential, true))
 }
@@ -88,21 +95,30 @@ func (*GitCredentialHandler) Update(req api.Context) error {
 	if host != credential.Spec.Host {
 		return types.NewErrBadRequest("host is immutable")
 	}
+	credentialType := gitCredentialType(credential)
+	if manifest.Type == "" {
+		manifest.Type = credentialType
+	} else if manifest.Type != credentialType {
+		return types.NewErrBadRequest("type is immutable")
+	}
 	configured, err := gitcredential.Configured(req.Context(), req.GatewayClient, credential.Name)
 	if err != nil {
 		return fmt.Errorf("failed to check Git credential %q: %w", credential.Name, err)
 	}
-	if manifest.Token == "" && !configured {
-		return types.NewErrBadRequest("token is required")
+	if err := validateGitCredentialManifest(manifest, credentialType, configured); err != nil {
+		return err
 	}
 
 	credential.Spec.DisplayName = manifest.DisplayName
+	credential.Spec.KnownHosts = manifest.KnownHosts
+	credential.Spec.GitHubAppID = manifest.GitHubAppID
+	credential.Spec.GitHubAppInstallationID = manifest.GitHubAppInstallationID
 	if err := req.Update(&credential); err != nil {
 		return fmt.Errorf("failed to update Git credential: %w", err)
 	}
-	if manifest.Token != "" {
-		if err := gitcredential.Store(req.Context(), req.GatewayClient, credential.Name, manifest.Token); err != nil {
-			return fmt.Errorf("failed to store Git credential token: %w", err)
+	if manifest.Token != "" || manifest.PrivateKey != "" {
+		if err := storeGitCredentialSecret(req, credential.Name, manifest); err != nil {
+			return err
 		}
 		configured = true
 	}
@@ -135,6 +151,10 @@ func readGitCredentialManifest(req api.Context) (types.GitCredentialManifest, st
 	}
 	manifest.DisplayName = strings.TrimSpace(manifest.DisplayName)
 	manifest.Token = strings.TrimSpace(manifest.Token)
+	manifest.PrivateKey = strings.TrimSpace(manifest.PrivateKey)
+	manifest.KnownHosts = strings.TrimSpace(manifest.KnownHosts)
+	manifest.GitHubAppID = strings.TrimSpace(manifest.GitHubAppID)
+	manifest.GitHubAppInstallationID = strings.TrimSpace(manifest.GitHubAppInstallationID)
 	if manifest.DisplayName == "" {
 		return manifest, "", types.NewErrBadRequest("displayName is required")
 	}
@@ -145,6 +165,79 @@ func readGitCredentialManifest(req api.Context) (types.GitCredentialManifest, st
 	return manifest, host, nil
 }
 
+// validateGitCredentialManifest checks that the manifest sets exactly the fields
+// used by credentials of the given type. The secret may be omitted when one is
+// already configured.
+func validateGitCredentialManifest(manifest types.GitCredentialManifest, credentialType types.GitCredentialType, secretConfigured bool) error {
+	switch credentialType {
+	case types.GitCredentialTypeToken:
+		if manifest.PrivateKey != "" || manifest.KnownHosts != "" || manifest.GitHubAppID != "" || manifest.GitHubAppInstallationID != "" {
+			return types.NewErrBadRequest("token credentials only accept a token")
+		}
+		if manifest.Token == "" && !secretConfigured {
+			return types.NewErrBadRequest("token is required")
+		}
+	case types.GitCredentialTypeSSHKey:
+		if manifest.Token != "" || manifest.GitHubAppID != "" || manifest.GitHubAppInstallationID != "" {
+			return types.NewErrBadRequest("SSH key credentials only accept a private key and known hosts")
+		}
+		if manifest.PrivateKey == "" && !secretConfigured {
+			return types.NewErrBadRequest("privateKey is required")
+		}
+		if manifest.PrivateKey != "" {
+			if err := gitcredential.ValidateSSHPrivateKey(manifest.PrivateKey); err != nil {
+				return types.NewErrBadRequest("%v", err)
+			}
+		}
+		if manifest.KnownHosts == "" {
+			return types.NewErrBadRequest("knownHosts is required")
+		}
+		if err := gitcredential.ValidateKnownHosts(manifest.KnownHosts); err != nil {
+			return types.NewErrBadRequest("%v", err)
+		}
+	case types.GitCredentialTypeGitHubApp:
+		if manifest.Token != "" || manifest.KnownHosts != "" {
+			return types.NewErrBadRequest("GitHub App credentials only accept an app ID, installation ID, and private key")
+		}
+		if err := gitcredential.ValidateGitHubAppIDs(manifest.GitHubAppID, manifest.GitHubAppInstallationID); err != nil {
+			return types.NewErrBadRequest("%v", err)
+		}
+		if manifest.PrivateKey == "" && !secretConfigured {
+			return types.NewErrBadRequest("privateKey is required")
+		}
+		if manifest.PrivateKey != "" {
+			if err := gitcredential.ValidateGitHubAppPrivateKey(manifest.PrivateKey); err != nil {
+				return types.NewErrBadRequest("%v", err)
+			}
+		}
+	default:
+		return types.NewErrBadRequest("unsupported Git credential type %q", credentialType)
+	}
+	return nil
+}
+
+func storeGitCredentialSecret(req api.Context, credentialID string, manifest types.GitCredentialManifest) error {
+	if manifest.Type == types.GitCredentialTypeToken {
+		if err := gitcredential.Store(req.Context(), req.GatewayClient, credentialID, manifest.Token); err != nil {
+			return fmt.Errorf("failed to store Git credential token: %w", err)
+		}
+		return nil
+	}
+	if err := gitcredential.StorePrivateKey(req.Context(), req.GatewayClient, credentialID, manifest.PrivateKey); err != nil {
+		return fmt.Errorf("failed to store Git credential private key: %w", err)
+	}
+	return nil
+}
+
+// gitCredentialType returns the type of credential, which is a token for
+// credentials created before other types existed.
+func gitCredentialType(credential v1.GitCredential) types.GitCredentialType {
+	if credential.Spec.Type == "" {
+		return types.GitCredentialTypeToken
+	}
+	return credential.Spec.Type
+}
+
 func gitCredentialReferences(req api.Context, credentialID string) (v1.GitCredentialReferences, error) {
 	return gitcredential.References(req.Context(), req.Storage, req.Namespace(), credentialID)
 }
@@ -160,15 +253,22 @@ func convertGitCredential(credential v1.GitCredential, configured bool) types.Gi
 		}
 		return uses
 	}
+	credentialType := gitCredentialType(credential)
 	return types.GitCredential{
-		Metadata:        MetadataFrom(&credential),
-		DisplayName:     credential.Spec.DisplayName,
-		Host:            credential.Spec.Host,
-		TokenConfigured: configured,
+		Metadata:                MetadataFrom(&credential),
+		DisplayName:             credential.Spec.DisplayName,
+		Host:                    credential.Spec.Host,
+		Type:                    credentialType,
+		KnownHosts:              credential.Spec.KnownHosts,
+		GitHubAppID:             credential.Spec.GitHubAppID,
+		GitHubAppInstallationID: credential.Spec.GitHubAppInstallationID,
+		TokenConfigured:         configured && credentialType == types.GitCredentialTypeToken,
+		PrivateKeyConfigured:    configured && credentialType != types.GitCredentialTypeToken,
 		Uses: types.GitCredentialUses{
-			SkillRepositories: convertUses(credential.Status.References.SkillRepositories),
-			MCPCatalogs:       convertUses(credential.Status.References.MCPCatalogs),
-			SystemMCPCatalogs: convertUses(credential.Status.References.SystemMCPCatalogs),
+			SkillRepositories:  convertUses(credential.Status.References.SkillRepositories),
+			MCPCatalogs:        convertUses(credential.Status.References.MCPCatalogs),
+			SystemMCPCatalogs:  convertUses(credential.Status.References.SystemMCPCatalogs),
+			ConfigRepositories: convertUses(credential.Status.References.ConfigRepositories),
 		},
 	}
 }
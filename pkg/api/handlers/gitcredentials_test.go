@@ -40,21 +40,22 @@ func TestConvertGitCredentialDoesNotExposeToken(t *testing.T) {
 	assert.Equal(t, "github.com", converted.Host)
 	assert.True(t, converted.TokenConfigured)
 	assert.Equal(t, types.GitCredentialUses{
-		SkillRepositories: []types.GitCredentialUse{{ID: "skills", DisplayName: "Team Skills"}},
-		MCPCatalogs:       []types.GitCredentialUse{},
-		SystemMCPCatalogs: []types.GitCredentialUse{},
+		SkillRepositories:  []types.GitCredentialUse{{ID: "skills", DisplayName: "Team Skills"}},
+		MCPCatalogs:        []types.GitCredentialUse{},
+		SystemMCPCatalogs:  []types.GitCredentialUse{},
+		ConfigRepositories: []types.GitCredentialUse{},
 	}, converted.Uses)
 
 	response, err := json.Marshal(converted)
 	require.NoError(t, err)
 	assert.NotContains(t, string(response), `"token":`)
-	assert.Contains(t, string(response), `"uses":{"skillRepositories":[{"id":"skills","displayName":"Team Skills"}],"mcpCatalogs":[],"systemMcpCatalogs":[]}`)
+	assert.Contains(t, string(response), `"uses":{"skillRepositories":[{"id":"skills","displayName":"Team Skills"}],"mcpCatalogs":[],"systemMcpCatalogs":[],"configRepositories":[]}`)
 	assert.NotContains(t, string(response), `"inUse"`)
 
 	converted = convertGitCredential(v1.GitCredential{}, false)
 	response, err = json.Marshal(converted)
 	require.NoError(t, err)
-	assert.Contains(t, string(response), `"uses":{"skillRepositories":[],"mcpCatalogs":[],"systemMcpCatalogs":[]}`)
+	assert.Contains(t, string(response), `"uses":{"skillRepositories":[],"mcpCatalogs":[],"systemMcpCatalogs":[],"configRepositories":[]}`)
 }
 
 func TestReadGitCredentialManifestTrimsToken(t *testing.T) {
@@ -70,6 +71,59 @@ func TestReadGitCredentialManifestTrimsToken(t *testing.T) {
 	assert.Empty(t, manifest.Token)
 }
 
+func TestValidateGitCredentialManifest(t *testing.T) {
+	const knownHosts = "github.com ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIOMqqnkVzrm0SdG6UOoqKLsabgH5C9okWi0dh2l9GKJl"
+
+	tests := []struct {
+		name       string
+		manifest   types.GitCredentialManifest
+		typ        types.GitCredentialType
+		configured bool
+		wantErr    string
+	}{
+		{name: "token", manifest: types.GitCredentialManifest{Token: "t"}, typ: types.GitCredentialTypeToken},
+		{name: "token missing", typ: types.GitCredentialTypeToken, wantErr: "token is required"},
+		{name: "token kept", typ: types.GitCredentialTypeToken, configured: true},
+		{name: "token with known hosts", manifest: types.GitCredentialManifest{Token: "t", KnownHosts: knownHosts}, typ: types.GitCredentialTypeToken, wantErr: "only accept a token"},
+		{name: "ssh key kept", manifest: types.GitCredentialManifest{KnownHosts: knownHosts}, typ: types.GitCredentialTypeSSHKey, configured: true},
+		{name: "ssh key missing", manifest: types.GitCredentialManifest{KnownHosts: knownHosts}, typ: types.GitCredentialTypeSSHKey, wantErr: "privateKey is required"},
+		{name: "ssh key invalid", manifest: types.GitCredentialManifest{PrivateKey: "nope", KnownHosts: knownHosts}, typ: types.GitCredentialTypeSSHKey, wantErr: "invalid SSH private key"},
+		{name: "ssh known hosts missing", typ: types.GitCredentialTypeSSHKey, configured: true, wantErr: "knownHosts is required"},
+		{name: "ssh with token", manifest: types.GitCredentialManifest{Token: "t", KnownHosts: knownHosts}, typ: types.GitCredentialTypeSSHKey, configured: true, wantErr: "only accept a private key"},
+		{name: "github app kept", manifest: types.GitCredentialManifest{GitHubAppID: "7", GitHubAppInstallationID: "42"}, typ: types.GitCredentialTypeGitHubApp, configured: true},
+		{name: "github app bad id", manifest: types.GitCredentialManifest{GitHubAppID: "seven", GitHubAppInstallationID: "42"}, typ: types.GitCredentialTypeGitHubApp, configured: true, wantErr: "App ID"},
+		{name: "github app missing key", manifest: types.GitCredentialManifest{GitHubAppID: "7", GitHubAppInstallationID: "42"}, typ: types.GitCredentialTypeGitHubApp, wantErr: "privateKey is required"},
+		{name: "unknown type", typ: "password", wantErr: "unsupported"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateGitCredentialManifest(tt.manifest, tt.typ, tt.configured)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestConvertGitCredentialTypes(t *testing.T) {
+	legacy := convertGitCredential(v1.GitCredential{}, true)
+	assert.Equal(t, types.GitCredentialTypeToken, legacy.Type)
+	assert.True(t, legacy.TokenConfigured)
+	assert.False(t, legacy.PrivateKeyConfigured)
+
+	app := convertGitCredential(v1.GitCredential{Spec: v1.GitCredentialSpec{
+		Type:                    types.GitCredentialTypeGitHubApp,
+		GitHubAppID:             "7",
+		GitHubAppInstallationID: "42",
+	}}, true)
+	assert.False(t, app.TokenConfigured)
+	assert.True(t, app.PrivateKeyConfigured)
+	assert.Equal(t, "7", app.GitHubAppID)
+	assert.Equal(t, "42", app.GitHubAppInstallationID)
+}
+
 func TestGitCredentialReferences(t *testing.T) {
 	storage := newFakeStorage(t,
 		&v1.SkillRepository{
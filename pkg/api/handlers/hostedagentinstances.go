@@ -11,6 +11,7 @@ import (
 	"github.com/obot-platform/obot/pkg/api"
 	"github.com/obot-platform/obot/pkg/api/authz"
 	"github.com/obot-platform/obot/pkg/hostedagentaccessrule"
+	"github.com/obot-platform/obot/pkg/hostedagentactivity"
 	"github.com/obot-platform/obot/pkg/modelaccesspolicy"
 	"github.com/obot-platform/obot/pkg/skillaccessrule"
 	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
@@ -122,21 +123,33 @@ func (h *HostedAgentInstanceHandler) Create(req api.Context) error {
 		return types.NewErrBadRequest("invalid hosted agent instance request: %v", err)
 	}
 
+	instance, err := h.create(req, body.HostedAgentID, body.PoolID, body.HostedAgentInstanceManifest, nil)
+	if err != nil {
+		return err
+	}
+
+	return req.WriteCreated(convertHostedAgentInstance(instance))
+}
+
+// create is everything Create checks and does once the request is read. Clone
+// goes through it too, so an instance cloned from a snapshot is held to the
+// same access, availability and per-user limits as one launched directly.
+func (h *HostedAgentInstanceHandler) create(req api.Context, hostedAgentID, poolID string, manifest types.HostedAgentInstanceManifest, restore *v1.HostedAgentInstanceRestore) (v1.HostedAgentInstance, error) {
 	var agent v1.HostedAgent
-	if err := req.Get(&agent, body.HostedAgentID); apierrors.IsNotFound(err) {
-		return types.NewErrBadRequest("hosted agent %s not found", body.HostedAgentID)
+	if err := req.Get(&agent, hostedAgentID); apierrors.IsNotFound(err) {
+		return v1.HostedAgentInstance{}, types.NewErrBadRequest("hosted agent %s not found", hostedAgentID)
 	} else if err != nil {
-		return fmt.Errorf("failed to get hosted agent: %w", err)
+		return v1.HostedAgentInstance{}, fmt.Errorf("failed to get hosted agent: %w", err)
 	}
 
 	// This route carries no hosted agent ID in its path, so the authorizer cannot
 	// gate it. Check access here instead.
 	hasAccess, err := h.accessRuleHelper.UserHasAccessToHostedAgent(req.User, &agent)
 	if err != nil {
-		return fmt.Errorf("failed to check access to hosted agent %s: %w", agent.Name, err)
+		return v1.HostedAgentInstance{}, fmt.Errorf("failed to check access to hosted agent %s: %w", agent.Name, err)
 	}
 	if !hasAccess {
-		return types.NewErrNotFound("hosted agent %s not found", body.HostedAgentID)
+		return v1.HostedAgentInstance{}, types.NewErrNotFound("hosted agent %s not found", hostedAgentID)
 	}
 
 	// Checked here as well as reported on the agent, because the UI's decision
@@ -145,16 +158,16 @@ func (h *HostedAgentInstanceHandler) Create(req api.Context) error {
 	// request, and an error the user has no way to act on.
 	availability, err := newAgentAvailability(req.Context(), req.Storage, req.Namespace())
 	if err != nil {
-		return err
+		return v1.HostedAgentInstance{}, err
 	}
 	if reasons := availability.reasons(req.Context(), agent.Spec.Manifest); len(reasons) > 0 {
-		return types.NewErrBadRequest("%s cannot be launched here: %s",
+		return v1.HostedAgentInstance{}, types.NewErrBadRequest("%s cannot be launched here: %s",
 			agent.Spec.Manifest.Name, strings.Join(reasons, "; "))
 	}
 
-	if body.PoolID != "" {
-		if err := requirePoolAccess(req, body.PoolID); err != nil {
-			return err
+	if poolID != "" {
+		if err := requirePoolAccess(req, poolID); err != nil {
+			return v1.HostedAgentInstance{}, err
 		}
 	}
 
@@ -163,17 +176,16 @@ func (h *HostedAgentInstanceHandler) Create(req api.Context) error {
 		"spec.userID":          req.User.GetUID(),
 		"spec.hostedAgentName": agent.Name,
 	}); err != nil {
-		return fmt.Errorf("failed to list hosted agent instances: %w", err)
+		return v1.HostedAgentInstance{}, fmt.Errorf("failed to list hosted agent instances: %w", err)
 	}
 
 	if maxInstances := agent.Spec.Manifest.MaxInstancesPerUser; maxInstances > 0 && len(existing.Items) >= maxInstances {
-		return types.NewErrBadRequest("hosted agent %s allows at most %d instances per user", agent.Name, maxInstances)
+		return v1.HostedAgentInstance{}, types.NewErrBadRequest("hosted agent %s allows at most %d instances per user", agent.Name, maxInstances)
 	}
 
-	manifest := body.HostedAgentInstanceManifest
 	manifest.Answers = agent.Spec.Manifest.ApplyAnswerDefaults(manifest.Answers)
 	if err := h.validateInstanceAgainstAgent(req, manifest, agent.Spec.Manifest); err != nil {
-		return err
+		return v1.HostedAgentInstance{}, err
 	}
 
 	instance := v1.HostedAgentInstance{
@@ -182,16 +194,17 @@ func (h *HostedAgentInstanceHandler) Create(req api.Context) error {
 		Spec: v1.HostedAgentInstanceSpec{
 			UserID:          req.User.GetUID(),
 			HostedAgentName: agent.Name,
-			PoolID:          body.PoolID,
+			PoolID:          poolID,
 			Manifest:        manifest,
+			Restore:         restore,
 		},
 	}
 
 	if err := req.Create(&instance); err != nil {
-		return fmt.Errorf("failed to create hosted agent instance: %w", err)
+		return v1.HostedAgentInstance{}, fmt.Errorf("failed to create hosted agent instance: %w", err)
 	}
 
-	return req.WriteCreated(convertHostedAgentInstance(instance))
+	return instance, nil
 }
 
 func (h *HostedAgentInstanceHandler) Update(req api.Context) error {
@@ -291,7 +304,8 @@ func (h *HostedAgentInstanceHandler) checkUserMCPServerAccess(req api.Context, i
 
 // checkUserSkillAccess loads each skill so its repo ID is known. Passing an
 // empty repo ID to the helper would skip repository-granted access and wrongly
-// deny a user who was granted a whole repository.
+// deny a user who was granted a whole repository. Each skill must also be
+// installable, as it would have to be for the user to install it themselves.
 func (h *HostedAgentInstanceHandler) checkUserSkillAccess(req api.Context, ids []string) error {
 	for _, id := range ids {
 		var skill v1.Skill
@@ -308,6 +322,14 @@ func (h *HostedAgentInstanceHandler) checkUserSkillAccess(req api.Context, ids [
 		if !hasAccess {
 			return types.NewErrForbidden("you do not have access to skill %s", id)
 		}
+		// The controller would leave such a skill out of the sandbox, so refuse
+		// it here rather than attach one the agent never receives.
+		if !skill.Installable() {
+			if skill.Status.ScanBlocked {
+				return types.NewErrForbidden("skill %s is blocked by its security scan findings", id)
+			}
+			return types.NewErrBadRequest("skill %s is not a valid skill", id)
+		}
 	}
 
 	return nil
@@ -382,13 +404,62 @@ func (h *HostedAgentInstanceHandler) Delete(req api.Context) error {
 	})
 }
 
+// Stop scales the instance to zero, keeping its workspace. It stays stopped --
+// connecting to it does not start it -- until Start is called.
+func (h *HostedAgentInstanceHandler) Stop(req api.Context) error {
+	return h.setDesiredState(req, types.HostedAgentDesiredStateStopped)
+}
+
+// Start runs a stopped or hibernated instance again.
+func (h *HostedAgentInstanceHandler) Start(req api.Context) error {
+	return h.setDesiredState(req, types.HostedAgentDesiredStateRunning)
+}
+
+func (h *HostedAgentInstanceHandler) setDesiredState(req api.Context, state types.HostedAgentDesiredState) error {
+	var instance v1.HostedAgentInstance
+	if err := req.Get(&instance, req.PathValue("hosted_agent_instance_id")); err != nil {
+		return fmt.Errorf("failed to get hosted agent instance: %w", err)
+	}
+
+	if instance.Spec.DesiredState != state {
+		instance.Spec.DesiredState = state
+		if err := req.Update(&instance); err != nil {
+			return fmt.Errorf("failed to update hosted agent instance: %w", err)
+		}
+	}
+
+	if state == types.HostedAgentDesiredStateRunning {
+		// Starting is activity. Without it an instance that has been idle for
+		// longer than its timeout would hibernate again as soon as it was ready,
+		// and recording it is also what clears hibernation.
+		if err := hostedagentactivity.Touch(req.Context(), req.Storage, &instance); err != nil {
+			return fmt.Errorf("failed to record hosted agent instance activity: %w", err)
+		}
+	} else if instance.Status.Hibernated {
+		// A stop supersedes hibernation. Left set, the instance would keep
+		// reporting that it starts on the next connection, which it no longer
+		// does.
+		instance.Status.Hibernated = false
+		if err := req.Storage.Status().Update(req.Context(), &instance); err != nil {
+			return fmt.Errorf("failed to update hosted agent instance status: %w", err)
+		}
+	}
+
+	return req.Write(convertHostedAgentInstance(instance))
+}
+
 func convertHostedAgentInstance(instance v1.HostedAgentInstance) types.HostedAgentInstance {
+	desiredState := instance.Spec.DesiredState
+	if desiredState == "" {
+		desiredState = types.HostedAgentDesiredStateRunning
+	}
 	return types.HostedAgentInstance{
 		Metadata:                    MetadataFrom(&instance),
 		HostedAgentInstanceManifest: instance.Spec.Manifest,
 		HostedAgentID:               instance.Spec.HostedAgentName,
 		UserID:                      instance.Spec.UserID,
 		PoolID:                      instance.Spec.PoolID,
+		DesiredState:                desiredState,
 		Status: types.HostedAgentInstanceStatus{
 			State:             instance.Status.State,
 			URL:               instance.Status.URL,
@@ -399,6 +470,10 @@ func convertHostedAgentInstance(instance v1.HostedAgentInstance) types.HostedAge
 			LastObservedTime:  v1.NewTime(instance.Status.LastObservedTime),
 			BackendID:         instance.Status.BackendID,
 			BackendGeneration: instance.Status.BackendGeneration,
+			Hibernated:        instance.Status.Hibernated,
+			LastActivityTime:  v1.NewTime(instance.Status.LastActivityTime),
+			Restoring:         instance.RestorePending(),
+			RestoreError:      instance.Status.RestoreError,
 		},
 	}
 }
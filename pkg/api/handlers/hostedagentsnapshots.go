@@ -0,0 +1,215 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"fmt"
+
+	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/obot-platform/obot/pkg/agentbackend"
+	"github.com/obot-platform/obot/pkg/api"
+	"github.com/obot-platform/obot/pkg/hostedagentsnapshot"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type HostedAgentSnapshotHandler struct {
+	// supported is whether the backend can take snapshots at all. Checking it
+	// here turns what would be a snapshot failing in the background into an
+	// error on the request that asked for it.
+	supported bool
+	instances *HostedAgentInstanceHandler
+}
+
+func NewHostedAgentSnapshotHandler(backend agentbackend.Backend, instances *HostedAgentInstanceHandler) *HostedAgentSnapshotHandler {
+	_, supported := backend.(agentbackend.SnapshotBackend)
+	return &HostedAgentSnapshotHandler{
+		supported: supported,
+		instances: instances,
+	}
+}
+
+// List returns the caller's snapshots, including those of instances that have
+// since been deleted.
+func (h *HostedAgentSnapshotHandler) List(req api.Context) error {
+	var list v1.HostedAgentSnapshotList
+	if err := req.List(&list, kclient.MatchingFields{"spec.userID": req.User.GetUID()}); err != nil {
+		return fmt.Errorf("failed to list hosted agent snapshots: %w", err)
+	}
+	return req.Write(convertHostedAgentSnapshotList(list))
+}
+
+func (h *HostedAgentSnapshotHandler) ListForInstance(req api.Context) error {
+	var list v1.HostedAgentSnapshotList
+	if err := req.List(&list, kclient.MatchingFields{
+		"spec.hostedAgentInstanceName": req.PathValue("hosted_agent_instance_id"),
+	}); err != nil {
+		return fmt.Errorf("failed to list hosted agent snapshots: %w", err)
+	}
+	return req.Write(convertHostedAgentSnapshotList(list))
+}
+
+func (h *HostedAgentSnapshotHandler) Get(req api.Context) error {
+	var snapshot v1.HostedAgentSnapshot
+	if err := req.Get(&snapshot, req.PathValue("hosted_agent_snapshot_id")); err != nil {
+		return fmt.Errorf("failed to get hosted agent snapshot: %w", err)
+	}
+	return req.Write(convertHostedAgentSnapshot(snapshot))
+}
+
+func (h *HostedAgentSnapshotHandler) Delete(req api.Context) error {
+	return req.Delete(&v1.HostedAgentSnapshot{
+		Name:      req.PathValue("hosted_agent_snapshot_id"),
+		Namespace: req.Namespace(),
+	})
+}
+
+// Create snapshots an instance's workspace as it is now. The instance keeps
+// running; the snapshot is captured in the background and is ready once its
+// state says so.
+func (h *HostedAgentSnapshotHandler) Create(req api.Context) error {
+	if !h.supported {
+		return types.NewErrBadRequest("the hosted agent backend does not support workspace snapshots")
+	}
+
+	var manifest types.HostedAgentSnapshotManifest
+	if err := req.Read(&manifest); err != nil {
+		return types.NewErrBadRequest("failed to read hosted agent snapshot manifest: %v", err)
+	}
+
+	var instance v1.HostedAgentInstance
+	if err := req.Get(&instance, req.PathValue("hosted_agent_instance_id")); err != nil {
+		return fmt.Errorf("failed to get hosted agent instance: %w", err)
+	}
+	if instance.Status.BackendID == "" {
+		return types.NewErrBadRequest("hosted agent instance %s has not started yet and has no workspace to snapshot", instance.Name)
+	}
+	if manifest.Name == "" {
+		manifest.Name = instance.Spec.Manifest.Name
+	}
+
+	snapshot := hostedagentsnapshot.New(&instance, manifest)
+	if err := req.Create(snapshot); err != nil {
+		return fmt.Errorf("failed to create hosted agent snapshot: %w", err)
+	}
+	return req.WriteCreated(convertHostedAgentSnapshot(*snapshot))
+}
+
+// Restore replaces an instance's workspace with a snapshot. The snapshot must
+// belong to the same user and have been taken from an instance of the same
+// agent: a workspace is laid out by its agent, and another agent's would not
+// make sense to this one.
+func (h *HostedAgentSnapshotHandler) Restore(req api.Context) error {
+	if !h.supported {
+		return types.NewErrBadRequest("the hosted agent backend does not support workspace snapshots")
+	}
+
+	var body types.HostedAgentSnapshotRestoreRequest
+	if err := req.Read(&body); err != nil {
+		return types.NewErrBadRequest("failed to read restore request: %v", err)
+	}
+	if body.SnapshotID == "" {
+		return types.NewErrBadRequest("snapshotID is required")
+	}
+
+	var instance v1.HostedAgentInstance
+	if err := req.Get(&instance, req.PathValue("hosted_agent_instance_id")); err != nil {
+		return fmt.Errorf("failed to get hosted agent instance: %w", err)
+	}
+
+	snapshot, err := getRestorableSnapshot(req, body.SnapshotID, instance.Spec.UserID)
+	if err != nil {
+		return err
+	}
+	if snapshot.Spec.HostedAgentName != instance.Spec.HostedAgentName {
+		return types.NewErrBadRequest("snapshot %s was taken from a different agent and cannot be restored into this instance", snapshot.Name)
+	}
+
+	instance.Spec.Restore = &v1.HostedAgentInstanceRestore{
+		SnapshotName: snapshot.Name,
+		ID:           rand.Text(),
+	}
+	if err := req.Update(&instance); err != nil {
+		return fmt.Errorf("failed to update hosted agent instance: %w", err)
+	}
+	return req.Write(convertHostedAgentInstance(instance))
+}
+
+// Clone creates a new instance whose workspace starts as the snapshot. It is
+// created as any instance is, so it needs the caller to still have access to
+// the agent and to be within the agent's per-user limit.
+func (h *HostedAgentSnapshotHandler) Clone(req api.Context) error {
+	if !h.supported {
+		return types.NewErrBadRequest("the hosted agent backend does not support workspace snapshots")
+	}
+
+	var body types.HostedAgentSnapshotCloneRequest
+	if err := req.Read(&body); err != nil {
+		return types.NewErrBadRequest("failed to read clone request: %v", err)
+	}
+
+	snapshot, err := getRestorableSnapshot(req, req.PathValue("hosted_agent_snapshot_id"), req.User.GetUID())
+	if err != nil {
+		return err
+	}
+
+	manifest := snapshot.Spec.InstanceManifest
+	if body.Name != "" {
+		manifest.Name = body.Name
+	}
+	if err := manifest.Validate(); err != nil {
+		return types.NewErrBadRequest("invalid hosted agent instance manifest: %v", err)
+	}
+
+	instance, err := h.instances.create(req, snapshot.Spec.HostedAgentName, body.PoolID, manifest, &v1.HostedAgentInstanceRestore{
+		SnapshotName: snapshot.Name,
+		ID:           rand.Text(),
+	})
+	if err != nil {
+		return err
+	}
+	return req.WriteCreated(convertHostedAgentInstance(instance))
+}
+
+// getRestorableSnapshot returns a snapshot owned by userID that has not failed.
+// A snapshot still being captured is accepted; the restore waits for it.
+func getRestorableSnapshot(req api.Context, id, userID string) (v1.HostedAgentSnapshot, error) {
+	var snapshot v1.HostedAgentSnapshot
+	if err := req.Get(&snapshot, id); apierrors.IsNotFound(err) {
+		return snapshot, types.NewErrBadRequest("hosted agent snapshot %s not found", id)
+	} else if err != nil {
+		return snapshot, fmt.Errorf("failed to get hosted agent snapshot: %w", err)
+	}
+	if snapshot.Spec.UserID != userID {
+		return snapshot, types.NewErrBadRequest("hosted agent snapshot %s not found", id)
+	}
+	if snapshot.Status.State == types.HostedAgentSnapshotStateError {
+		return snapshot, types.NewErrBadRequest("hosted agent snapshot %s failed and cannot be restored: %s", id, snapshot.Status.Error)
+	}
+	return snapshot, nil
+}
+
+func convertHostedAgentSnapshotList(list v1.HostedAgentSnapshotList) types.HostedAgentSnapshotList {
+	items := make([]types.HostedAgentSnapshot, 0, len(list.Items))
+	for _, item := range list.Items {
+		items = append(items, convertHostedAgentSnapshot(item))
+	}
+	return types.HostedAgentSnapshotList{Items: items}
+}
+
+func convertHostedAgentSnapshot(snapshot v1.HostedAgentSnapshot) types.HostedAgentSnapshot {
+	return types.HostedAgentSnapshot{
+		Metadata:                    MetadataFrom(&snapshot),
+		HostedAgentSnapshotManifest: snapshot.Spec.Manifest,
+		HostedAgentInstanceID:       snapshot.Spec.HostedAgentInstanceName,
+		HostedAgentID:               snapshot.Spec.HostedAgentName,
+		PreDelete:                   snapshot.Spec.PreDelete,
+		ExpiresAt:                   v1.NewTime(snapshot.Spec.ExpiresAt),
+		Status: types.HostedAgentSnapshotStatus{
+			State:         snapshot.Status.State,
+			SizeBytes:     snapshot.Status.SizeBytes,
+			CompletedTime: v1.NewTime(snapshot.Status.CompletedTime),
+			Error:         snapshot.Status.Error,
+		},
+	}
+}
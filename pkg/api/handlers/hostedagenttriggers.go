@@ -0,0 +1,334 @@
+package handlers
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/adhocore/gronx"
+	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/obot-platform/obot/pkg/api"
+	gateway "github.com/obot-platform/obot/pkg/gateway/client"
+	gatewaytypes "github.com/obot-platform/obot/pkg/gateway/types"
+	"github.com/obot-platform/obot/pkg/hostedagenttrigger"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	"github.com/obot-platform/obot/pkg/system"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// maxTriggerBodySize bounds what a webhook sender can make Obot buffer. A
+// payload is handed to the agent as an event, so one this large is already
+// far more than an agent can usefully act on.
+const maxTriggerBodySize = 1 << 20
+
+type HostedAgentTriggerHandler struct {
+	serverURL string
+	deliverer *hostedagenttrigger.Deliverer
+}
+
+func NewHostedAgentTriggerHandler(serverURL string, deliverer *hostedagenttrigger.Deliverer) *HostedAgentTriggerHandler {
+	return &HostedAgentTriggerHandler{
+		serverURL: serverURL,
+		deliverer: deliverer,
+	}
+}
+
+func (h *HostedAgentTriggerHandler) List(req api.Context) error {
+	var list v1.HostedAgentTriggerList
+	if err := req.List(&list, kclient.MatchingFields{
+		"spec.hostedAgentInstanceName": req.PathValue("hosted_agent_instance_id"),
+	}); err != nil {
+		return fmt.Errorf("failed to list hosted agent triggers: %w", err)
+	}
+
+	items := make([]types.HostedAgentTrigger, 0, len(list.Items))
+	for _, item := range list.Items {
+		items = append(items, h.convert(item))
+	}
+	return req.Write(types.HostedAgentTriggerList{Items: items})
+}
+
+func (h *HostedAgentTriggerHandler) Get(req api.Context) error {
+	trigger, err := getInstanceTrigger(req)
+	if err != nil {
+		return err
+	}
+	return req.Write(h.convert(trigger))
+}
+
+func (h *HostedAgentTriggerHandler) Create(req api.Context) error {
+	manifest, err := readTriggerManifest(req)
+	if err != nil {
+		return err
+	}
+
+	// The authorizer has established the caller owns this instance; it is read
+	// again for its owner, which the trigger is recorded under.
+	var instance v1.HostedAgentInstance
+	if err := req.Get(&instance, req.PathValue("hosted_agent_instance_id")); err != nil {
+		return fmt.Errorf("failed to get hosted agent instance: %w", err)
+	}
+
+	trigger := v1.HostedAgentTrigger{
+		GenerateName: system.HostedAgentTriggerPrefix,
+		Namespace:    req.Namespace(),
+		Finalizers:   []string{v1.HostedAgentTriggerFinalizer},
+		Spec: v1.HostedAgentTriggerSpec{
+			UserID:                  instance.Spec.UserID,
+			HostedAgentInstanceName: instance.Name,
+			Manifest:                manifest,
+		},
+	}
+	if err := req.Create(&trigger); err != nil {
+		return fmt.Errorf("failed to create hosted agent trigger: %w", err)
+	}
+
+	result := h.convert(trigger)
+	if manifest.TriggerType == types.HostedAgentTriggerTypeWebhook {
+		secret, err := newWebhookSecret()
+		if err != nil {
+			return err
+		}
+		if err := req.GatewayClient.UpsertCredential(req.Context(), gatewaytypes.Credential{
+			Context: hostedagenttrigger.CredentialContext(trigger.Name),
+			Name:    trigger.Name,
+			Secrets: map[string]string{hostedagenttrigger.SecretKey: secret},
+		}); err != nil {
+			// A webhook trigger without a secret can never be invoked, so do
+			// not leave one behind.
+			_ = req.Delete(&trigger)
+			return fmt.Errorf("failed to store webhook secret: %w", err)
+		}
+		result.WebhookSecret = secret
+	}
+
+	return req.WriteCreated(result)
+}
+
+func (h *HostedAgentTriggerHandler) Update(req api.Context) error {
+	manifest, err := readTriggerManifest(req)
+	if err != nil {
+		return err
+	}
+
+	trigger, err := getInstanceTrigger(req)
+	if err != nil {
+		return err
+	}
+	// The secret is generated for the type at creation, so a trigger keeps the
+	// type it was created with.
+	if manifest.TriggerType != trigger.Spec.Manifest.TriggerType {
+		return types.NewErrBadRequest("triggerType cannot be changed; create a new trigger instead")
+	}
+
+	trigger.Spec.Manifest = manifest
+	if err := req.Update(&trigger); err != nil {
+		return fmt.Errorf("failed to update hosted agent trigger: %w", err)
+	}
+
+	return req.Write(h.convert(trigger))
+}
+
+func (h *HostedAgentTriggerHandler) Delete(req api.Context) error {
+	trigger, err := getInstanceTrigger(req)
+	if err != nil {
+		return err
+	}
+	return req.Delete(&trigger)
+}
+
+// Invoke delivers to the agent now and responds with the outcome, so an owner
+// can check a trigger works without waiting for its schedule or sender. The
+// request body, when there is one, stands in for the trigger's payload.
+func (h *HostedAgentTriggerHandler) Invoke(req api.Context) error {
+	trigger, err := getInstanceTrigger(req)
+	if err != nil {
+		return err
+	}
+
+	body, err := readTriggerBody(req)
+	if err != nil {
+		return err
+	}
+	if len(body) == 0 {
+		body = []byte(trigger.Spec.Manifest.Payload)
+	}
+
+	invocation, err := h.deliverer.Deliver(req.Context(), &trigger, types.HostedAgentTriggerSourceManual, body, req.Request.Header)
+	if err != nil {
+		return err
+	}
+	return req.Write(gatewaytypes.ConvertHostedAgentTriggerInvocation(invocation))
+}
+
+func (h *HostedAgentTriggerHandler) ListInvocations(req api.Context) error {
+	trigger, err := getInstanceTrigger(req)
+	if err != nil {
+		return err
+	}
+
+	var limit, offset int
+	query := req.URL.Query()
+	if v := query.Get("limit"); v != "" {
+		if l, err := strconv.Atoi(v); err == nil && l > 0 {
+			limit = l
+		}
+	}
+	if v := query.Get("offset"); v != "" {
+		if o, err := strconv.Atoi(v); err == nil && o >= 0 {
+			offset = o
+		}
+	}
+
+	invocations, total, err := req.GatewayClient.ListHostedAgentTriggerInvocations(req.Context(), trigger.Name, limit, offset)
+	if err != nil {
+		return err
+	}
+
+	items := make([]types.HostedAgentTriggerInvocation, 0, len(invocations))
+	for _, invocation := range invocations {
+		items = append(items, gatewaytypes.ConvertHostedAgentTriggerInvocation(invocation))
+	}
+	req.ResponseWriter.Header().Set("X-Total-Count", strconv.FormatInt(total, 10))
+	return req.Write(types.HostedAgentTriggerInvocationList{Items: items})
+}
+
+// Webhook receives an event for a webhook trigger. It is unauthenticated: the
+// sender is another system that holds the trigger's secret, not an Obot user,
+// and the signature is the authorization.
+//
+// An unsigned request is refused before anything else, so it costs neither a
+// lookup nor reading its body. Every other failure before the signature is
+// checked is the same 404, so the route does not tell a caller without the
+// secret whether a trigger exists. The event is acknowledged before delivery,
+// because the agent may first have to start and senders time out long before
+// that.
+func (h *HostedAgentTriggerHandler) Webhook(req api.Context) error {
+	if !hostedagenttrigger.Signed(req.Request.Header) {
+		return types.NewErrHTTP(http.StatusUnauthorized, "missing webhook signature")
+	}
+
+	notFound := types.NewErrNotFound("hosted agent trigger %s not found", req.PathValue("hosted_agent_trigger_id"))
+
+	var trigger v1.HostedAgentTrigger
+	if err := req.Get(&trigger, req.PathValue("hosted_agent_trigger_id")); apierrors.IsNotFound(err) {
+		return notFound
+	} else if err != nil {
+		return fmt.Errorf("failed to get hosted agent trigger: %w", err)
+	}
+	if trigger.Spec.Manifest.TriggerType != types.HostedAgentTriggerTypeWebhook || !trigger.DeletionTimestamp.IsZero() {
+		return notFound
+	}
+
+	credential, err := req.GatewayClient.RevealCredential(req.Context(), []string{hostedagenttrigger.CredentialContext(trigger.Name)}, trigger.Name)
+	if errors.As(err, &gateway.CredentialNotFoundError{}) {
+		return notFound
+	} else if err != nil {
+		return fmt.Errorf("failed to reveal webhook secret: %w", err)
+	}
+	// Anyone can sign with an empty key, so a trigger whose secret was lost
+	// accepts nothing rather than everything.
+	secret := credential.Secrets[hostedagenttrigger.SecretKey]
+	if secret == "" {
+		slog.Error("Hosted agent trigger has no webhook secret", "trigger", trigger.Name)
+		return types.NewErrHTTP(http.StatusUnauthorized, "invalid webhook signature")
+	}
+
+	body, err := readTriggerBody(req)
+	if err != nil {
+		return err
+	}
+	if !hostedagenttrigger.Verify(secret, body, req.Request.Header) {
+		return types.NewErrHTTP(http.StatusUnauthorized, "invalid webhook signature")
+	}
+	if trigger.Spec.Manifest.Disabled {
+		return types.NewErrHTTP(http.StatusConflict, fmt.Sprintf("hosted agent trigger %s is disabled", trigger.Name))
+	}
+
+	header := req.Request.Header.Clone()
+	go func() {
+		if _, err := h.deliverer.Deliver(context.WithoutCancel(req.Context()), &trigger, types.HostedAgentTriggerSourceWebhook, body, header); err != nil {
+			slog.Error("Failed to record hosted agent trigger delivery", "trigger", trigger.Name, "error", err)
+		}
+	}()
+
+	req.ResponseWriter.WriteHeader(http.StatusAccepted)
+	return nil
+}
+
+func (h *HostedAgentTriggerHandler) convert(trigger v1.HostedAgentTrigger) types.HostedAgentTrigger {
+	result := types.HostedAgentTrigger{
+		Metadata:                   MetadataFrom(&trigger),
+		HostedAgentTriggerManifest: trigger.Spec.Manifest,
+		HostedAgentInstanceID:      trigger.Spec.HostedAgentInstanceName,
+		Status: types.HostedAgentTriggerStatus{
+			LastRunTime:        v1.NewTime(trigger.Status.LastRunTime),
+			NextRunTime:        v1.NewTime(trigger.Status.NextRunTime),
+			LastInvocationTime: v1.NewTime(trigger.Status.LastInvocationTime),
+			LastStatusCode:     trigger.Status.LastStatusCode,
+			LastError:          trigger.Status.LastError,
+		},
+	}
+	if trigger.Spec.Manifest.TriggerType == types.HostedAgentTriggerTypeWebhook {
+		result.WebhookURL = fmt.Sprintf("%s/api/hosted-agent-triggers/%s/webhook", strings.TrimSuffix(h.serverURL, "/"), trigger.Name)
+	}
+	return result
+}
+
+// getInstanceTrigger reads the trigger named in the path and checks it belongs
+// to the instance named there. The authorizer checks the caller owns the
+// instance, so this is what stops one owner reaching another's trigger through
+// their own instance's path.
+func getInstanceTrigger(req api.Context) (v1.HostedAgentTrigger, error) {
+	var trigger v1.HostedAgentTrigger
+	if err := req.Get(&trigger, req.PathValue("hosted_agent_trigger_id")); err != nil {
+		return trigger, err
+	}
+	if trigger.Spec.HostedAgentInstanceName != req.PathValue("hosted_agent_instance_id") {
+		return trigger, types.NewErrNotFound("hosted agent trigger %s not found", req.PathValue("hosted_agent_trigger_id"))
+	}
+	return trigger, nil
+}
+
+// readTriggerManifest reads and validates a trigger manifest. The schedule is
+// parsed here rather than in apiclient/types so that module stays dependency
+// free.
+func readTriggerManifest(req api.Context) (types.HostedAgentTriggerManifest, error) {
+	var manifest types.HostedAgentTriggerManifest
+	if err := req.Read(&manifest); err != nil {
+		return manifest, types.NewErrBadRequest("failed to read hosted agent trigger manifest: %v", err)
+	}
+	if err := manifest.Validate(); err != nil {
+		return manifest, types.NewErrBadRequest("invalid hosted agent trigger manifest: %v", err)
+	}
+	if manifest.Schedule != "" && !gronx.IsValid(manifest.Schedule) {
+		return manifest, types.NewErrBadRequest("invalid hosted agent trigger manifest: schedule %q is not a valid cron expression", manifest.Schedule)
+	}
+	return manifest, nil
+}
+
+func readTriggerBody(req api.Context) ([]byte, error) {
+	body, err := io.ReadAll(http.MaxBytesReader(req.ResponseWriter, req.Request.Body, maxTriggerBodySize))
+	if _, tooLarge := errors.AsType[*http.MaxBytesError](err); tooLarge {
+		return nil, types.NewErrHTTP(http.StatusRequestEntityTooLarge, "request body is too large")
+	} else if err != nil {
+		return nil, types.NewErrBadRequest("failed to read request body: %v", err)
+	}
+	return body, nil
+}
+
+func newWebhookSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return hex.EncodeToString(secret), nil
+}
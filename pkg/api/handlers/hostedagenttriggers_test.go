@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/obot-platform/obot/pkg/api"
+	gatewaytypes "github.com/obot-platform/obot/pkg/gateway/types"
+	"github.com/obot-platform/obot/pkg/hostedagenttrigger"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	"github.com/obot-platform/obot/pkg/system"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// unreadBody fails the test if the handler reads the request body.
+type unreadBody struct{ t *testing.T }
+
+func (b unreadBody) Read([]byte) (int, error) {
+	b.t.Error("request body read")
+	return 0, errors.New("request body read")
+}
+
+func TestHostedAgentTriggerWebhookRejectsEmptySecret(t *testing.T) {
+	storage := newFakeStorage(t, &v1.HostedAgentTrigger{
+		ObjectMeta: metav1.ObjectMeta{Name: "hat1", Namespace: system.DefaultNamespace},
+		Spec: v1.HostedAgentTriggerSpec{
+			HostedAgentInstanceName: "hai1",
+			Manifest:                types.HostedAgentTriggerManifest{TriggerType: types.HostedAgentTriggerTypeWebhook},
+		},
+	})
+	gateway := newHandlerTestGateway(t)
+	require.NoError(t, gateway.UpsertCredential(t.Context(), gatewaytypes.Credential{
+		Context: hostedagenttrigger.CredentialContext("hat1"),
+		Name:    "hat1",
+		Secrets: map[string]string{hostedagenttrigger.SecretKey: ""},
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/api/hosted-agent-triggers/hat1/webhook", unreadBody{t: t})
+	req.SetPathValue("hosted_agent_trigger_id", "hat1")
+	req.Header.Set(hostedagenttrigger.SignatureHeader, hostedagenttrigger.Sign("", []byte(`{}`)))
+
+	err := (&HostedAgentTriggerHandler{}).Webhook(api.Context{
+		ResponseWriter: httptest.NewRecorder(),
+		Request:        req,
+		Storage:        storage,
+		GatewayClient:  gateway,
+	})
+	var httpErr *types.ErrHTTP
+	require.True(t, errors.As(err, &httpErr), "expected *types.ErrHTTP, got %T: %v", err, err)
+	assert.Equal(t, http.StatusUnauthorized, httpErr.Code)
+}
@@ -74,6 +74,25 @@ func (h *LLMAuditLogHandler) Get(req api.Context) error {
 	return req.Write(gatewaytypes.ConvertLLMAuditLog(*log))
 }
 
+// GetUnredacted returns an LLM audit log with the original payloads retained by the audit
+// redaction setting. Only owners who also hold the auditor role may read them.
+func (h *LLMAuditLogHandler) GetUnredacted(req api.Context) error {
+	if !req.UserIsOwner() || !req.UserIsAuditor() {
+		return types.NewErrForbidden("only owners with the auditor role can view unredacted audit payloads")
+	}
+
+	log, err := req.GatewayClient.GetUnredactedLLMAuditLog(req.Context(), req.PathValue("audit_log_id"))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return types.NewErrNotFound("LLM audit log not found")
+	} else if errors.Is(err, gateway.ErrUnredactedPayloadNotRetained) {
+		return types.NewErrNotFound("%v", err)
+	} else if err != nil {
+		return err
+	}
+
+	return req.Write(gatewaytypes.ConvertLLMAuditLog(*log))
+}
+
 func (h *LLMAuditLogHandler) ListFilterOptions(req api.Context) error {
 	filter := req.PathValue("filter")
 	opts := parseLLMAuditLogOpts(req.URL.Query())
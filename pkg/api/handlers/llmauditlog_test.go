@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -14,6 +15,7 @@ import (
 	"github.com/obot-platform/obot/apiclient/types"
 	"github.com/obot-platform/obot/pkg/api"
 	gatewaytypes "github.com/obot-platform/obot/pkg/gateway/types"
+	"k8s.io/apiserver/pkg/authentication/user"
 )
 
 func TestParseLLMAuditLogOptsDefaultsStartTimeToLast30Days(t *testing.T) {
@@ -148,3 +150,38 @@ func TestListLLMAuditLogAPIKeyFilterOptionsReturnsStructuredOptions(t *testing.T
 		t.Fatalf("unexpected structured options: %#v", response.Options)
 	}
 }
+
+func TestGetUnredactedLLMAuditLogRequiresOwnerAuditor(t *testing.T) {
+	gatewayClient := newEnforcementTestGatewayClient(t)
+	entry := gatewaytypes.LLMAuditLog{ID: uuid.New().String(), CreatedAt: time.Now().UTC(), UserID: "7"}
+	if err := gatewayClient.InsertLLMAuditLog(t.Context(), &entry); err != nil {
+		t.Fatalf("insert LLM audit log: %v", err)
+	}
+
+	for _, tt := range []struct {
+		name   string
+		groups []string
+		want   int
+	}{
+		{name: "admin auditor", groups: []string{types.GroupAdmin, types.GroupAuditor}, want: http.StatusForbidden},
+		{name: "owner without auditor", groups: []string{types.GroupOwner}, want: http.StatusForbidden},
+		{name: "owner auditor without retained payload", groups: []string{types.GroupOwner, types.GroupAuditor}, want: http.StatusNotFound},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "/api/llm-audit-logs/detail/"+entry.ID+"/unredacted", nil)
+			request.SetPathValue("audit_log_id", entry.ID)
+			ctx := api.Context{
+				ResponseWriter: httptest.NewRecorder(),
+				Request:        request,
+				GatewayClient:  gatewayClient,
+				User:           &user.DefaultInfo{Groups: tt.groups},
+			}
+
+			err := NewLLMAuditLogHandler().GetUnredacted(ctx)
+			var errHTTP *types.ErrHTTP
+			if !errors.As(err, &errHTTP) || errHTTP.Code != tt.want {
+				t.Fatalf("expected HTTP %d, got %v", tt.want, err)
+			}
+		})
+	}
+}
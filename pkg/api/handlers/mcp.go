@@ -24,7 +24,9 @@ import (
 	gateway "github.com/obot-platform/obot/pkg/gateway/client"
 	gatewaytypes "github.com/obot-platform/obot/pkg/gateway/types"
 	"github.com/obot-platform/obot/pkg/mcp"
+	"github.com/obot-platform/obot/pkg/mcphealth"
 	"github.com/obot-platform/obot/pkg/principal"
+	"github.com/obot-platform/obot/pkg/secretprovider"
 	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
 	"github.com/obot-platform/obot/pkg/system"
 	obottunnel "github.com/obot-platform/obot/pkg/tunnel"
@@ -52,15 +54,14 @@ type MCPOAuthChecker interface {
 }
 
 type MCPHandler struct {
-	mcpSessionManager         *mcp.SessionManager
-	mcpOAuthChecker           MCPOAuthChecker
-	acrHelper                 *accesscontrolrule.Helper
-	controllerBackend         nahbackend.Trigger
-	mcpImagePullSecrets       []string
-	mcpRuntimeBackend         string
-	serverURL                 string
-	secretBindingAllowedLabel string
-	forceDynamicClient        bool
+	mcpSessionManager   *mcp.SessionManager
+	mcpOAuthChecker     MCPOAuthChecker
+	acrHelper           *accesscontrolrule.Helper
+	controllerBackend   nahbackend.Trigger
+	mcpImagePullSecrets []string
+	mcpRuntimeBackend   string
+	serverURL           string
+	forceDynamicClient  bool
 
 	// shutdownMCPServer is only injected for testing
 	shutdownMCPServer func(string) error
@@ -88,17 +89,16 @@ type urlTemplateConfigurationError struct {
 	key string
 }
 
-func NewMCPHandler(mcpLoader *mcp.SessionManager, acrHelper *accesscontrolrule.Helper, mcpOAuthChecker MCPOAuthChecker, controllerBackend nahbackend.Trigger, mcpImagePullSecrets []string, serverURL, secretBindingAllowedLabel string, forceDynamicClient bool) *MCPHandler {
+func NewMCPHandler(mcpLoader *mcp.SessionManager, acrHelper *accesscontrolrule.Helper, mcpOAuthChecker MCPOAuthChecker, controllerBackend nahbackend.Trigger, mcpImagePullSecrets []string, serverURL string, forceDynamicClient bool) *MCPHandler {
 	return &MCPHandler{
-		mcpSessionManager:         mcpLoader,
-		mcpOAuthChecker:           mcpOAuthChecker,
-		acrHelper:                 acrHelper,
-		controllerBackend:         controllerBackend,
-		mcpImagePullSecrets:       mcpImagePullSecrets,
-		mcpRuntimeBackend:         mcpLoader.MCPRuntimeBackend(),
-		serverURL:                 serverURL,
-		secretBindingAllowedLabel: secretBindingAllowedLabel,
-		forceDynamicClient:        forceDynamicClient,
+		mcpSessionManager:   mcpLoader,
+		mcpOAuthChecker:     mcpOAuthChecker,
+		acrHelper:           acrHelper,
+		controllerBackend:   controllerBackend,
+		mcpImagePullSecrets: mcpImagePullSecrets,
+		mcpRuntimeBackend:   mcpLoader.MCPRuntimeBackend(),
+		serverURL:           serverURL,
+		forceDynamicClient:  forceDynamicClient,
 	}
 }
 
@@ -392,13 +392,13 @@ func (m *MCPHandler) ListServer(req api.Context) error {
 
 		var components []types.MCPServer
 		if server.Spec.Manifest.Runtime == types.RuntimeComposite {
-			components, err = resolveCompositeComponents(req, server, m.secretBindingAllowedLabel)
+			components, err = resolveCompositeComponents(req, server)
 			if err != nil {
 				slog.Warn("failed to resolve composite components for server", "serverName", server.Name, "error", err)
 				return err
 			}
 		}
-		mergedEnv, err := mcp.MergeBoundCreds(req.Context(), req.LocalK8sClient, req.ObotNamespace, server.Spec.Manifest.Env, server.Spec.Manifest.RemoteConfig, credMap[server.Name], m.secretBindingAllowedLabel)
+		mergedEnv, err := mcp.MergeBoundCreds(req.Context(), req.SecretProvider, server.Spec.Manifest.Env, server.Spec.Manifest.RemoteConfig, credMap[server.Name])
 		if err != nil {
 			return fmt.Errorf("failed to resolve secret bindings for server %s: %w", server.Name, err)
 		}
@@ -444,7 +444,7 @@ func (m *MCPHandler) GetServer(req api.Context) error {
 	if err != nil && !errors.As(err, &gateway.CredentialNotFoundError{}) {
 		return fmt.Errorf("failed to find credential: %w", err)
 	}
-	mergedEnv, err := mcp.MergeBoundCreds(req.Context(), req.LocalK8sClient, req.ObotNamespace, server.Spec.Manifest.Env, server.Spec.Manifest.RemoteConfig, cred.Secrets, m.secretBindingAllowedLabel)
+	mergedEnv, err := mcp.MergeBoundCreds(req.Context(), req.SecretProvider, server.Spec.Manifest.Env, server.Spec.Manifest.RemoteConfig, cred.Secrets)
 	if err != nil {
 		return fmt.Errorf("failed to resolve secret bindings: %w", err)
 	}
@@ -456,7 +456,7 @@ func (m *MCPHandler) GetServer(req api.Context) error {
 
 	var components []types.MCPServer
 	if server.Spec.Manifest.Runtime == types.RuntimeComposite {
-		components, err = resolveCompositeComponents(req, server, m.secretBindingAllowedLabel)
+		components, err = resolveCompositeComponents(req, server)
 		if err != nil {
 			slog.Warn("failed to resolve composite components for server", "serverName", server.Name, "error", err)
 			return err
@@ -1008,7 +1008,7 @@ func (m *MCPHandler) GetPrompt(req api.Context) error {
 	})
 }
 
-func mcpServerOrInstanceFromConnectURL(req api.Context, id, secretBindingAllowedLabel string, validationOptions mcp.ValidationOptions) (v1.MCPServer, v1.MCPServerInstance, error) {
+func mcpServerOrInstanceFromConnectURL(req api.Context, id string, validationOptions mcp.ValidationOptions) (v1.MCPServer, v1.MCPServerInstance, error) {
 	switch {
 	case system.IsMCPServerInstanceID(id):
 		var instance v1.MCPServerInstance
@@ -1085,7 +1085,7 @@ func mcpServerOrInstanceFromConnectURL(req api.Context, id, secretBindingAllowed
 		}
 		if len(servers.Items) == 0 {
 			// If the user has not configured an MCP server for the catalog entry, create a server for the user.
-			missingAdminConfig, err := entryMissingAdminConfig(req.Context(), req.LocalK8sClient, req.ObotNamespace, entry, secretBindingAllowedLabel)
+			missingAdminConfig, err := entryMissingAdminConfig(req.Context(), req.SecretProvider, entry)
 			if err != nil {
 				return v1.MCPServer{}, v1.MCPServerInstance{}, fmt.Errorf("failed to determine required admin configuration for catalog entry %s: %w", id, err)
 			}
@@ -1167,7 +1167,7 @@ func (m missingCatalogEntryAdminConfig) err(entryID string) error {
 	return types.NewErrBadRequest("catalog entry %s cannot be connected because %s", entryID, strings.Join(parts, "; "))
 }
 
-func entryMissingAdminConfig(ctx context.Context, client kclient.Client, obotNamespace string, entry v1.MCPServerCatalogEntry, secretBindingAllowedLabel string) (missingCatalogEntryAdminConfig, error) {
+func entryMissingAdminConfig(ctx context.Context, secretProvider secretprovider.Provider, entry v1.MCPServerCatalogEntry) (missingCatalogEntryAdminConfig, error) {
 	missing := missingCatalogEntryAdminConfig{
 		StaticOAuth: entryRequiresStaticOAuthCreds(entry),
 	}
@@ -1201,7 +1201,7 @@ func entryMissingAdminConfig(ctx context.Context, client kclient.Client, obotNam
 			remote = &types.RemoteRuntimeConfig{Headers: cm.RemoteConfig.Headers}
 		}
 
-		missingBindings, err := mcp.MissingSecretBindings(ctx, client, obotNamespace, cm.Env, remote, secretBindingAllowedLabel)
+		missingBindings, err := mcp.MissingSecretBindings(ctx, secretProvider, cm.Env, remote)
 		if err != nil {
 			return missing, err
 		}
@@ -1719,12 +1719,12 @@ func (m *MCPHandler) CreateServer(req api.Context) error {
 	if adminManagedSecretBindings {
 		markAdminAddedSecretBindings(&server.Spec.Manifest, sourceCatalogEntryManifest)
 	}
-	if err := mcp.ValidateSecretBindings(server.Spec.Manifest, gitManagedEntry, adminManagedSecretBindings, m.mcpRuntimeBackend); err != nil {
+	if err := mcp.ValidateSecretBindings(server.Spec.Manifest, gitManagedEntry, adminManagedSecretBindings, req.SecretProvider != nil); err != nil {
 		return types.NewErrBadRequest("validation failed: %v", err)
 	}
 	addExtractedEnvVars(&server)
 	if adminManagedSecretBindings && !server.Spec.IsSingleUser() {
-		if err := mcp.ValidateSecretBindingsAvailable(req.Context(), req.LocalK8sClient, req.ObotNamespace, server.Spec.Manifest.Env, server.Spec.Manifest.RemoteConfig, m.secretBindingAllowedLabel); err != nil {
+		if err := mcp.ValidateSecretBindingsAvailable(req.Context(), req.SecretProvider, server.Spec.Manifest.Env, server.Spec.Manifest.RemoteConfig); err != nil {
 			return types.NewErrBadRequest("validation failed: %v", err)
 		}
 	}
@@ -1754,7 +1754,7 @@ func (m *MCPHandler) CreateServer(req api.Context) error {
 		return fmt.Errorf("failed to find credential: %w", err)
 	}
 
-	mergedEnv, err := mcp.MergeBoundCreds(req.Context(), req.LocalK8sClient, req.ObotNamespace, server.Spec.Manifest.Env, server.Spec.Manifest.RemoteConfig, cred.Secrets, m.secretBindingAllowedLabel)
+	mergedEnv, err := mcp.MergeBoundCreds(req.Context(), req.SecretProvider, server.Spec.Manifest.Env, server.Spec.Manifest.RemoteConfig, cred.Secrets)
 	if err != nil {
 		return fmt.Errorf("failed to resolve secret bindings: %w", err)
 	}
@@ -1840,7 +1840,7 @@ func (m *MCPHandler) UpdateServer(req api.Context) error {
 		}
 		markAdminAddedSecretBindings(&updated, sourceCatalogEntryManifest)
 	}
-	if err := mcp.ValidateSecretBindings(updated, gitManagedEntry, adminManagedSecretBindings, m.mcpRuntimeBackend); err != nil {
+	if err := mcp.ValidateSecretBindings(updated, gitManagedEntry, adminManagedSecretBindings, req.SecretProvider != nil); err != nil {
 		return types.NewErrBadRequest("validation failed: %v", err)
 	}
 	if err := mcp.ValidateTemplateReferences(updated); err != nil {
@@ -1850,7 +1850,7 @@ func (m *MCPHandler) UpdateServer(req api.Context) error {
 		updatedServer := existing
 		updatedServer.Spec.Manifest = updated
 		addExtractedEnvVars(&updatedServer)
-		if err := mcp.ValidateSecretBindingsAvailable(req.Context(), req.LocalK8sClient, req.ObotNamespace, updatedServer.Spec.Manifest.Env, updatedServer.Spec.Manifest.RemoteConfig, m.secretBindingAllowedLabel); err != nil {
+		if err := mcp.ValidateSecretBindingsAvailable(req.Context(), req.SecretProvider, updatedServer.Spec.Manifest.Env, updatedServer.Spec.Manifest.RemoteConfig); err != nil {
 			return types.NewErrBadRequest("validation failed: %v", err)
 		}
 	}
@@ -1886,7 +1886,7 @@ func (m *MCPHandler) UpdateServer(req api.Context) error {
 		return fmt.Errorf("failed to generate slug: %w", err)
 	}
 
-	mergedEnv, err := mcp.MergeBoundCreds(req.Context(), req.LocalK8sClient, req.ObotNamespace, existing.Spec.Manifest.Env, existing.Spec.Manifest.RemoteConfig, cred.Secrets, m.secretBindingAllowedLabel)
+	mergedEnv, err := mcp.MergeBoundCreds(req.Context(), req.SecretProvider, existing.Spec.Manifest.Env, existing.Spec.Manifest.RemoteConfig, cred.Secrets)
 	if err != nil {
 		return fmt.Errorf("failed to resolve secret bindings: %w", err)
 	}
@@ -2052,7 +2052,7 @@ func (m *MCPHandler) ConfigureServer(req api.Context) error {
 		return fmt.Errorf("failed to generate slug: %w", err)
 	}
 
-	mergedEnv, err := mcp.MergeBoundCreds(req.Context(), req.LocalK8sClient, req.ObotNamespace, mcpServer.Spec.Manifest.Env, mcpServer.Spec.Manifest.RemoteConfig, envVars, m.secretBindingAllowedLabel)
+	mergedEnv, err := mcp.MergeBoundCreds(req.Context(), req.SecretProvider, mcpServer.Spec.Manifest.Env, mcpServer.Spec.Manifest.RemoteConfig, envVars)
 	if err != nil {
 		return fmt.Errorf("failed to resolve secret bindings: %w", err)
 	}
@@ -2261,7 +2261,7 @@ func (m *MCPHandler) configureCompositeServer(req api.Context, compositeServer v
 	}
 
 	// Re-resolve the latest components to pick up latest config
-	components, err := resolveCompositeComponents(req, compositeServer, m.secretBindingAllowedLabel)
+	components, err := resolveCompositeComponents(req, compositeServer)
 	if err != nil {
 		return fmt.Errorf("failed to resolve component servers: %w", err)
 	}
@@ -2271,7 +2271,7 @@ func (m *MCPHandler) configureCompositeServer(req api.Context, compositeServer v
 		return fmt.Errorf("failed to generate slug: %w", err)
 	}
 
-	mergedEnv, err := mcp.MergeBoundCreds(req.Context(), req.LocalK8sClient, req.ObotNamespace, compositeServer.Spec.Manifest.Env, compositeServer.Spec.Manifest.RemoteConfig, nil, m.secretBindingAllowedLabel)
+	mergedEnv, err := mcp.MergeBoundCreds(req.Context(), req.SecretProvider, compositeServer.Spec.Manifest.Env, compositeServer.Spec.Manifest.RemoteConfig, nil)
 	if err != nil {
 		return fmt.Errorf("failed to resolve secret bindings: %w", err)
 	}
@@ -2909,6 +2909,7 @@ func ConvertMCPServer(server v1.MCPServer, credEnv map[string]string, serverURL,
 		DeploymentReplicas:          server.Status.DeploymentReplicas,
 		DeploymentConditions:        conditions,
 		OAuthMetadata:               convertOAuthMetadata(server.Status.OAuthMetadata),
+		Health:                      mcphealth.ToAPI(server.Status.Health, false, time.Now()),
 		K8sSettingsHash:             server.Status.K8sSettingsHash,
 		Template:                    server.Spec.Template,
 		CompositeName:               server.Spec.CompositeName,
@@ -2955,8 +2956,8 @@ func ConvertMCPServer(server v1.MCPServer, credEnv map[string]string, serverURL,
 	return converted
 }
 
-func ConfigurationTargetForConnectID(req api.Context, id, serverURL, secretBindingAllowedLabel string, validationOptions mcp.ValidationOptions) (*types.MCPServer, *types.MCPServerInstance, error) {
-	server, instance, err := mcpServerOrInstanceFromConnectURL(req, id, secretBindingAllowedLabel, validationOptions)
+func ConfigurationTargetForConnectID(req api.Context, id, serverURL string, validationOptions mcp.ValidationOptions) (*types.MCPServer, *types.MCPServerInstance, error) {
+	server, instance, err := mcpServerOrInstanceFromConnectURL(req, id, validationOptions)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -2974,7 +2975,7 @@ func ConfigurationTargetForConnectID(req api.Context, id, serverURL, secretBindi
 		return nil, &converted, nil
 	}
 
-	credEnv, err := credentialEnvForMCPServer(req, server, secretBindingAllowedLabel)
+	credEnv, err := credentialEnvForMCPServer(req, server)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -2985,7 +2986,7 @@ func ConfigurationTargetForConnectID(req api.Context, id, serverURL, secretBindi
 
 	var components []types.MCPServer
 	if server.Spec.Manifest.Runtime == types.RuntimeComposite {
-		components, err = resolveCompositeComponents(req, server, secretBindingAllowedLabel)
+		components, err = resolveCompositeComponents(req, server)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -2995,7 +2996,7 @@ func ConfigurationTargetForConnectID(req api.Context, id, serverURL, secretBindi
 	return &converted, nil, nil
 }
 
-func credentialEnvForMCPServer(req api.Context, server v1.MCPServer, secretBindingAllowedLabel string) (map[string]string, error) {
+func credentialEnvForMCPServer(req api.Context, server v1.MCPServer) (map[string]string, error) {
 	var credCtxs []string
 	switch {
 	case server.Spec.MCPCatalogID != "":
@@ -3013,7 +3014,7 @@ func credentialEnvForMCPServer(req api.Context, server v1.MCPServer, secretBindi
 		return nil, fmt.Errorf("failed to find credential: %w", err)
 	}
 
-	mergedEnv, err := mcp.MergeBoundCreds(req.Context(), req.LocalK8sClient, req.ObotNamespace, server.Spec.Manifest.Env, server.Spec.Manifest.RemoteConfig, cred.Secrets, secretBindingAllowedLabel)
+	mergedEnv, err := mcp.MergeBoundCreds(req.Context(), req.SecretProvider, server.Spec.Manifest.Env, server.Spec.Manifest.RemoteConfig, cred.Secrets)
 	if err != nil {
 		return nil, fmt.Errorf("failed to resolve secret bindings: %w", err)
 	}
@@ -3106,7 +3107,7 @@ func SlugForMCPServer(ctx context.Context, client kclient.Client, server v1.MCPS
 
 // resolveCompositeComponents lists components of a composite MCP server, reveals their credentials, and
 // converts them to the public API type.
-func resolveCompositeComponents(req api.Context, composite v1.MCPServer, secretBindingAllowedLabel string) ([]types.MCPServer, error) {
+func resolveCompositeComponents(req api.Context, composite v1.MCPServer) ([]types.MCPServer, error) {
 	var (
 		componentServers    v1.MCPServerList
 		componentInstances  v1.MCPServerInstanceList
@@ -3134,7 +3135,7 @@ func resolveCompositeComponents(req api.Context, composite v1.MCPServer, secretB
 		}
 
 		addExtractedEnvVars(&component)
-		mergedEnv, err := mcp.MergeBoundCreds(req.Context(), req.LocalK8sClient, req.ObotNamespace, component.Spec.Manifest.Env, component.Spec.Manifest.RemoteConfig, cred.Secrets, secretBindingAllowedLabel)
+		mergedEnv, err := mcp.MergeBoundCreds(req.Context(), req.SecretProvider, component.Spec.Manifest.Env, component.Spec.Manifest.RemoteConfig, cred.Secrets)
 		if err != nil {
 			return nil, fmt.Errorf("failed to resolve secret bindings for component %s: %w", component.Name, err)
 		}
@@ -3255,13 +3256,13 @@ func (m *MCPHandler) ListServersFromAllSources(req api.Context) error {
 		// Resolve components via helper for composite servers
 		var components []types.MCPServer
 		if server.Spec.Manifest.Runtime == types.RuntimeComposite {
-			components, err = resolveCompositeComponents(req, server, m.secretBindingAllowedLabel)
+			components, err = resolveCompositeComponents(req, server)
 			if err != nil {
 				slog.Warn("failed to resolve composite components for server", "serverName", server.Name, "error", err)
 				return err
 			}
 		}
-		mergedEnv, err := mcp.MergeBoundCreds(req.Context(), req.LocalK8sClient, req.ObotNamespace, server.Spec.Manifest.Env, server.Spec.Manifest.RemoteConfig, credMap[server.Name], m.secretBindingAllowedLabel)
+		mergedEnv, err := mcp.MergeBoundCreds(req.Context(), req.SecretProvider, server.Spec.Manifest.Env, server.Spec.Manifest.RemoteConfig, credMap[server.Name])
 		if err != nil {
 			return fmt.Errorf("failed to resolve secret bindings for server %s: %w", server.Name, err)
 		}
@@ -3313,7 +3314,7 @@ func (m *MCPHandler) GetServerFromAllSources(req api.Context) error {
 		// Don't fail if catalog entry is missing, just continue without preview
 	}
 
-	mergedEnv, err := mcp.MergeBoundCreds(req.Context(), req.LocalK8sClient, req.ObotNamespace, server.Spec.Manifest.Env, server.Spec.Manifest.RemoteConfig, cred.Secrets, m.secretBindingAllowedLabel)
+	mergedEnv, err := mcp.MergeBoundCreds(req.Context(), req.SecretProvider, server.Spec.Manifest.Env, server.Spec.Manifest.RemoteConfig, cred.Secrets)
 	if err != nil {
 		return fmt.Errorf("failed to resolve secret bindings: %w", err)
 	}
@@ -3671,7 +3672,7 @@ func (m *MCPHandler) RedeployWithK8sSettings(req api.Context) error {
 		return fmt.Errorf("failed to find credential: %w", err)
 	}
 
-	mergedEnv, err := mcp.MergeBoundCreds(req.Context(), req.LocalK8sClient, req.ObotNamespace, server.Spec.Manifest.Env, server.Spec.Manifest.RemoteConfig, cred.Secrets, m.secretBindingAllowedLabel)
+	mergedEnv, err := mcp.MergeBoundCreds(req.Context(), req.SecretProvider, server.Spec.Manifest.Env, server.Spec.Manifest.RemoteConfig, cred.Secrets)
 	if err != nil {
 		return fmt.Errorf("failed to resolve secret bindings: %w", err)
 	}
@@ -4043,7 +4044,7 @@ func (m *MCPHandler) TriggerUpdate(req api.Context) error {
 	var configured map[string]string
 	if entry.Spec.Manifest.Runtime == types.RuntimeRemote && entry.Spec.Manifest.RemoteConfig != nil && entry.Spec.Manifest.RemoteConfig.URLTemplate != "" {
 		var err error
-		configured, err = credentialEnvForMCPServer(req, server, m.secretBindingAllowedLabel)
+		configured, err = credentialEnvForMCPServer(req, server)
 		if err != nil {
 			return err
 		}
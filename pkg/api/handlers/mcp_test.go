@@ -13,6 +13,7 @@ import (
 	"github.com/obot-platform/obot/apiclient/types"
 	"github.com/obot-platform/obot/pkg/api"
 	"github.com/obot-platform/obot/pkg/mcp"
+	"github.com/obot-platform/obot/pkg/secretprovider"
 	"github.com/obot-platform/obot/pkg/storage"
 	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
 	"github.com/obot-platform/obot/pkg/system"
@@ -272,7 +273,7 @@ func TestMCPServerOrInstanceFromConnectURLRejectsCatalogEntryResourcesAboveMaxim
 		Request:        httptest.NewRequest(http.MethodGet, "/mcp-connect/entry", nil),
 		Storage:        storage,
 		User:           testUser("user"),
-	}, "entry", "", mcp.ValidationOptions{
+	}, "entry", mcp.ValidationOptions{
 		ResourceMaximums: mcp.ResourceMaximums{
 			CPURequest: new(resource.MustParse("100m")),
 		},
@@ -1290,7 +1291,7 @@ func TestCreateCatalogEntryRejectsMultiUserHeaderSecretBinding(t *testing.T) {
 	req := httptest.NewRequest(http.MethodPost, "/api/mcp-catalogs/catalog-1/entries", bytes.NewReader(body))
 	req.SetPathValue("catalog_id", "catalog-1")
 
-	err = (&MCPCatalogHandler{mcpBackend: mcp.RuntimeBackendKubernetes, sessionManager: &mcp.SessionManager{}}).CreateEntry(api.Context{
+	err = (&MCPCatalogHandler{sessionManager: &mcp.SessionManager{}}).CreateEntry(api.Context{
 		ResponseWriter: httptest.NewRecorder(),
 		Request:        req,
 		Storage:        storage,
@@ -1320,7 +1321,7 @@ func TestCreateCatalogEntryAllowsConfigurationOptions(t *testing.T) {
 	req := httptest.NewRequest(http.MethodPost, "/api/mcp-catalogs/catalog-1/entries", bytes.NewReader(body))
 	req.SetPathValue("catalog_id", "catalog-1")
 
-	err = (&MCPCatalogHandler{mcpBackend: "docker", sessionManager: &mcp.SessionManager{}}).CreateEntry(api.Context{
+	err = (&MCPCatalogHandler{sessionManager: &mcp.SessionManager{}}).CreateEntry(api.Context{
 		ResponseWriter: httptest.NewRecorder(),
 		Request:        req,
 		Storage:        storage,
@@ -1336,9 +1337,8 @@ func TestCreateCatalogEntryAllowsConfigurationOptions(t *testing.T) {
 
 func newCreateServerSecretBindingTestHandler() *MCPHandler {
 	return &MCPHandler{
-		mcpSessionManager:         &mcp.SessionManager{},
-		mcpRuntimeBackend:         mcp.RuntimeBackendKubernetes,
-		secretBindingAllowedLabel: testSecretBindingAllowedLabel,
+		mcpSessionManager: &mcp.SessionManager{},
+		mcpRuntimeBackend: mcp.RuntimeBackendKubernetes,
 	}
 }
 
@@ -1377,13 +1377,17 @@ func newCreateServerSecretBindingRequest(t *testing.T, storageClient storage.Cli
 		groups = append(groups, types.GroupAdmin)
 	}
 
+	var secretProvider secretprovider.Provider
+	if localK8sClient != nil {
+		secretProvider = secretprovider.NewKubernetes(localK8sClient, system.DefaultNamespace, testSecretBindingAllowedLabel)
+	}
+
 	return api.Context{
 		ResponseWriter: httptest.NewRecorder(),
 		Request:        req,
 		Storage:        storageClient,
 		User:           testUserWithRole("user-1", groups...),
-		LocalK8sClient: localK8sClient,
-		ObotNamespace:  system.DefaultNamespace,
+		SecretProvider: secretProvider,
 	}
 }
 
@@ -1775,7 +1779,11 @@ func TestEntryMissingAdminConfig(t *testing.T) {
 				Spec:   v1.MCPServerCatalogEntrySpec{Manifest: tt.manifest},
 				Status: v1.MCPServerCatalogEntryStatus{OAuthCredentialConfigured: tt.oauthConfigured},
 			}
-			got, err := entryMissingAdminConfig(t.Context(), tt.client, ns, entry, "label")
+			var secretProvider secretprovider.Provider
+			if tt.client != nil {
+				secretProvider = secretprovider.NewKubernetes(tt.client, ns, "label")
+			}
+			got, err := entryMissingAdminConfig(t.Context(), secretProvider, entry)
 			require.NoError(t, err)
 			assert.Equal(t, tt.wantFields, got.SecretBoundFields)
 			assert.Equal(t, tt.wantOAuth, got.StaticOAuth)
@@ -2,10 +2,14 @@ package handlers
 
 import (
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/obot-platform/obot/apiclient/types"
 	"github.com/obot-platform/obot/pkg/api"
 	"github.com/obot-platform/obot/pkg/mcp"
+	"github.com/obot-platform/obot/pkg/mcphealth"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
 )
 
 type MCPCapacityHandler struct {
@@ -30,5 +34,38 @@ func (h *MCPCapacityHandler) GetCapacity(req api.Context) error {
 		return err
 	}
 
+	var servers v1.MCPServerList
+	if err := req.List(&servers); err != nil {
+		return fmt.Errorf("failed to list servers: %w", err)
+	}
+	info.ServerHealth = mcpServerHealthSummaries(servers.Items, h.mcpSessionManager.HealthTracker())
+
 	return req.Write(info)
 }
+
+// mcpServerHealthSummaries returns the health of the servers that have been health checked.
+func mcpServerHealthSummaries(servers []v1.MCPServer, tracker *mcphealth.Tracker) []types.MCPServerHealthSummary {
+	var (
+		now       = time.Now()
+		summaries []types.MCPServerHealthSummary
+	)
+	for _, server := range servers {
+		if server.Spec.Template {
+			continue
+		}
+		health := mcphealth.ToAPI(server.Status.Health, tracker.Open(server.Name), now)
+		if health == nil {
+			continue
+		}
+		name := server.Spec.Alias
+		if name == "" {
+			name = server.Spec.Manifest.Name
+		}
+		summaries = append(summaries, types.MCPServerHealthSummary{
+			ID:     server.Name,
+			Name:   name,
+			Health: *health,
+		})
+	}
+	return summaries
+}
@@ -19,6 +19,7 @@ import (
 	gclient "github.com/obot-platform/obot/pkg/gateway/client"
 	gatewaytypes "github.com/obot-platform/obot/pkg/gateway/types"
 	"github.com/obot-platform/obot/pkg/mcp"
+	"github.com/obot-platform/obot/pkg/secretprovider"
 	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
 	"github.com/obot-platform/obot/pkg/system"
 	"github.com/obot-platform/obot/pkg/tunnel"
@@ -34,32 +35,28 @@ var (
 )
 
 type MCPCatalogHandler struct {
-	defaultCatalogPath        string
-	serverURL                 string
-	mcpBackend                string
-	sessionManager            *mcp.SessionManager
-	capacityInfoProvider      capacityInfoProvider
-	oauthChecker              MCPOAuthChecker
-	gatewayClient             *gclient.Client
-	acrHelper                 *accesscontrolrule.Helper
-	secretBindingAllowedLabel string
+	defaultCatalogPath   string
+	serverURL            string
+	sessionManager       *mcp.SessionManager
+	capacityInfoProvider capacityInfoProvider
+	oauthChecker         MCPOAuthChecker
+	gatewayClient        *gclient.Client
+	acrHelper            *accesscontrolrule.Helper
 }
 
 type capacityInfoProvider interface {
 	GetCapacityInfoForServers(context.Context, []string) (types.MCPCapacityInfo, error)
 }
 
-func NewMCPCatalogHandler(defaultCatalogPath string, serverURL string, mcpBackend string, sessionManager *mcp.SessionManager, oauthChecker MCPOAuthChecker, gatewayClient *gclient.Client, acrHelper *accesscontrolrule.Helper, secretBindingAllowedLabel string) *MCPCatalogHandler {
+func NewMCPCatalogHandler(defaultCatalogPath string, serverURL string, sessionManager *mcp.SessionManager, oauthChecker MCPOAuthChecker, gatewayClient *gclient.Client, acrHelper *accesscontrolrule.Helper) *MCPCatalogHandler {
 	return &MCPCatalogHandler{
-		defaultCatalogPath:        defaultCatalogPath,
-		serverURL:                 serverURL,
-		mcpBackend:                mcpBackend,
-		sessionManager:            sessionManager,
-		capacityInfoProvider:      sessionManager,
-		oauthChecker:              oauthChecker,
-		gatewayClient:             gatewayClient,
-		acrHelper:                 acrHelper,
-		secretBindingAllowedLabel: secretBindingAllowedLabel,
+		defaultCatalogPath:   defaultCatalogPath,
+		serverURL:            serverURL,
+		sessionManager:       sessionManager,
+		capacityInfoProvider: sessionManager,
+		oauthChecker:         oauthChecker,
+		gatewayClient:        gatewayClient,
+		acrHelper:            acrHelper,
 	}
 }
 
@@ -343,7 +340,7 @@ func (h *MCPCatalogHandler) CreateEntry(req api.Context) error {
 	}
 	// UI-created catalog entries are never git-managed, but multi-user catalog
 	// entries may still define secretBinding as part of their shared template.
-	if err := mcp.ValidateSecretBindingsCatalogEntry(manifest, false, req.UserIsAdmin(), h.mcpBackend); err != nil {
+	if err := mcp.ValidateSecretBindingsCatalogEntry(manifest, false, req.UserIsAdmin(), req.SecretProvider != nil); err != nil {
 		return types.NewErrBadRequest("failed to validate entry manifest: %v", err)
 	}
 	if err := mcp.ValidateTemplateReferencesCatalogEntry(manifest); err != nil {
@@ -425,7 +422,7 @@ func (h *MCPCatalogHandler) UpdateEntry(req api.Context) error {
 	// git-sync controller reconciles git-managed entries through a separate path.
 	// Multi-user catalog entries may still define secretBinding as part of their
 	// shared template.
-	if err := mcp.ValidateSecretBindingsCatalogEntry(manifest, false, req.UserIsAdmin(), h.mcpBackend); err != nil {
+	if err := mcp.ValidateSecretBindingsCatalogEntry(manifest, false, req.UserIsAdmin(), req.SecretProvider != nil); err != nil {
 		return types.NewErrBadRequest("failed to validate entry manifest: %v", err)
 	}
 	if err := mcp.ValidateTemplateReferencesCatalogEntry(manifest); err != nil {
@@ -562,7 +559,7 @@ func (h *MCPCatalogHandler) AdminListServersForEntryInCatalog(req api.Context) e
 			return fmt.Errorf("failed to find credential: %w", err)
 		}
 
-		mergedEnv, err := mcp.MergeBoundCreds(req.Context(), req.LocalK8sClient, req.ObotNamespace, server.Spec.Manifest.Env, server.Spec.Manifest.RemoteConfig, cred.Secrets, h.secretBindingAllowedLabel)
+		mergedEnv, err := mcp.MergeBoundCreds(req.Context(), req.SecretProvider, server.Spec.Manifest.Env, server.Spec.Manifest.RemoteConfig, cred.Secrets)
 		if err != nil {
 			return fmt.Errorf("failed to resolve secret bindings: %w", err)
 		}
@@ -574,7 +571,7 @@ func (h *MCPCatalogHandler) AdminListServersForEntryInCatalog(req api.Context) e
 
 		var components []types.MCPServer
 		if server.Spec.Manifest.Runtime == types.RuntimeComposite {
-			components, err = resolveCompositeComponents(req, server, h.secretBindingAllowedLabel)
+			components, err = resolveCompositeComponents(req, server)
 			if err != nil {
 				return err
 			}
@@ -629,6 +626,7 @@ func (h *MCPCatalogHandler) GetEntryCapacity(req api.Context) error {
 		}
 		return err
 	}
+	info.ServerHealth = mcpServerHealthSummaries(list.Items, nil)
 
 	return req.Write(info)
 }
@@ -695,7 +693,7 @@ func (h *MCPCatalogHandler) AdminListServersForAllEntriesInCatalog(req api.Conte
 			return fmt.Errorf("failed to find credential: %w", err)
 		}
 
-		mergedEnv, err := mcp.MergeBoundCreds(req.Context(), req.LocalK8sClient, req.ObotNamespace, server.Spec.Manifest.Env, server.Spec.Manifest.RemoteConfig, cred.Secrets, h.secretBindingAllowedLabel)
+		mergedEnv, err := mcp.MergeBoundCreds(req.Context(), req.SecretProvider, server.Spec.Manifest.Env, server.Spec.Manifest.RemoteConfig, cred.Secrets)
 		if err != nil {
 			return fmt.Errorf("failed to resolve secret bindings: %w", err)
 		}
@@ -707,7 +705,7 @@ func (h *MCPCatalogHandler) AdminListServersForAllEntriesInCatalog(req api.Conte
 
 		var components []types.MCPServer
 		if server.Spec.Manifest.Runtime == types.RuntimeComposite {
-			components, err = resolveCompositeComponents(req, server, h.secretBindingAllowedLabel)
+			components, err = resolveCompositeComponents(req, server)
 			if err != nil {
 				return err
 			}
@@ -773,7 +771,7 @@ func (h *MCPCatalogHandler) ListServersForEntry(req api.Context) error {
 			return fmt.Errorf("failed to find credential: %w", err)
 		}
 
-		mergedEnv, err := mcp.MergeBoundCreds(req.Context(), req.LocalK8sClient, req.ObotNamespace, server.Spec.Manifest.Env, server.Spec.Manifest.RemoteConfig, cred.Secrets, h.secretBindingAllowedLabel)
+		mergedEnv, err := mcp.MergeBoundCreds(req.Context(), req.SecretProvider, server.Spec.Manifest.Env, server.Spec.Manifest.RemoteConfig, cred.Secrets)
 		if err != nil {
 			return fmt.Errorf("failed to resolve secret bindings: %w", err)
 		}
@@ -785,7 +783,7 @@ func (h *MCPCatalogHandler) ListServersForEntry(req api.Context) error {
 
 		var components []types.MCPServer
 		if server.Spec.Manifest.Runtime == types.RuntimeComposite {
-			components, err = resolveCompositeComponents(req, server, h.secretBindingAllowedLabel)
+			components, err = resolveCompositeComponents(req, server)
 			if err != nil {
 				return fmt.Errorf("failed to resolve composite components: %w", err)
 			}
@@ -842,7 +840,7 @@ func (h *MCPCatalogHandler) GetServerFromEntry(req api.Context) error {
 		return fmt.Errorf("failed to find credential: %w", err)
 	}
 
-	mergedEnv, err := mcp.MergeBoundCreds(req.Context(), req.LocalK8sClient, req.ObotNamespace, server.Spec.Manifest.Env, server.Spec.Manifest.RemoteConfig, cred.Secrets, h.secretBindingAllowedLabel)
+	mergedEnv, err := mcp.MergeBoundCreds(req.Context(), req.SecretProvider, server.Spec.Manifest.Env, server.Spec.Manifest.RemoteConfig, cred.Secrets)
 	if err != nil {
 		return fmt.Errorf("failed to resolve secret bindings: %w", err)
 	}
@@ -854,7 +852,7 @@ func (h *MCPCatalogHandler) GetServerFromEntry(req api.Context) error {
 
 	var components []types.MCPServer
 	if server.Spec.Manifest.Runtime == types.RuntimeComposite {
-		components, err = resolveCompositeComponents(req, server, h.secretBindingAllowedLabel)
+		components, err = resolveCompositeComponents(req, server)
 		if err != nil {
 			slog.Warn("failed to resolve composite components for catalog server", "serverName", server.Name, "error", err)
 			return err
@@ -927,9 +925,7 @@ func (h *MCPCatalogHandler) GenerateToolPreviews(req api.Context) error {
 		req.Context(),
 		req.GatewayClient,
 		req.Storage,
-		req.LocalK8sClient,
-		req.ObotNamespace,
-		h.secretBindingAllowedLabel,
+		req.SecretProvider,
 		entry.Namespace,
 		entry.Name,
 		catalogName,
@@ -1053,9 +1049,7 @@ func (h *MCPCatalogHandler) generateCompositeToolPreviews(req api.Context, entry
 			req.Context(),
 			req.GatewayClient,
 			req.Storage,
-			req.LocalK8sClient,
-			req.ObotNamespace,
-			h.secretBindingAllowedLabel,
+			req.SecretProvider,
 			entry.Namespace,
 			componentEntry.CatalogEntryID,
 			catalogName,
@@ -1187,7 +1181,7 @@ func (h *MCPCatalogHandler) GenerateToolPreviewsOAuthURL(req api.Context) error
 	if err != nil {
 		return err
 	}
-	server, serverConfig, err := tempServerAndConfig(req.Context(), req.GatewayClient, req.Storage, req.LocalK8sClient, req.ObotNamespace, h.secretBindingAllowedLabel, entry.Namespace, entry.Name, catalogName, entry.Spec.Manifest, configRequest.Config, configRequest.URL, h.serverURL, validationOptions)
+	server, serverConfig, err := tempServerAndConfig(req.Context(), req.GatewayClient, req.Storage, req.SecretProvider, entry.Namespace, entry.Name, catalogName, entry.Spec.Manifest, configRequest.Config, configRequest.URL, h.serverURL, validationOptions)
 	if err != nil {
 		return types.NewErrBadRequest("failed to create temporary server and config: %v", err)
 	}
@@ -1280,9 +1274,7 @@ func (h *MCPCatalogHandler) GenerateComponentToolPreviews(req api.Context) error
 		req.Context(),
 		req.GatewayClient,
 		req.Storage,
-		req.LocalK8sClient,
-		req.ObotNamespace,
-		h.secretBindingAllowedLabel,
+		req.SecretProvider,
 		composite.Namespace,
 		component.CatalogEntryID,
 		catalogName,
@@ -1409,9 +1401,7 @@ func (h *MCPCatalogHandler) GenerateComponentToolPreviewsOAuthURL(req api.Contex
 		req.Context(),
 		req.GatewayClient,
 		req.Storage,
-		req.LocalK8sClient,
-		req.ObotNamespace,
-		h.secretBindingAllowedLabel,
+		req.SecretProvider,
 		composite.Namespace,
 		component.CatalogEntryID,
 		catalogName,
@@ -1499,9 +1489,7 @@ func (h *MCPCatalogHandler) generateCompositeOAuthURLs(req api.Context, entry v1
 			req.Context(),
 			req.GatewayClient,
 			req.Storage,
-			req.LocalK8sClient,
-			req.ObotNamespace,
-			h.secretBindingAllowedLabel,
+			req.SecretProvider,
 			entry.Namespace,
 			componentEntry.CatalogEntryID,
 			catalogName,
@@ -1532,14 +1520,14 @@ func (h *MCPCatalogHandler) generateCompositeOAuthURLs(req api.Context, entry v1
 	return req.Write(oauthURLs)
 }
 
-func tempServerAndConfig(ctx context.Context, gatewayClient *gclient.Client, client kclient.Client, localK8sClient kclient.Client, obotNamespace, secretBindingAllowedLabel, namespace, entryName, catalogName string, entryManifest types.MCPServerCatalogEntryManifest, config map[string]string, url, baseURL string, validationOptions mcp.ValidationOptions) (v1.MCPServer, mcp.ServerConfig, error) {
+func tempServerAndConfig(ctx context.Context, gatewayClient *gclient.Client, client kclient.Client, secretProvider secretprovider.Provider, namespace, entryName, catalogName string, entryManifest types.MCPServerCatalogEntryManifest, config map[string]string, url, baseURL string, validationOptions mcp.ValidationOptions) (v1.MCPServer, mcp.ServerConfig, error) {
 	// Convert catalog entry to server manifest
 	serverManifest, err := types.MapCatalogEntryToServer(entryManifest, url, false)
 	if err != nil {
 		return v1.MCPServer{}, mcp.ServerConfig{}, fmt.Errorf("failed to convert catalog entry to server config: %w", err)
 	}
 
-	config, err = prepareTempServerConfig(ctx, localK8sClient, obotNamespace, secretBindingAllowedLabel, &serverManifest, config, !entryManifest.ServerUserType.IsSingleUser(), validationOptions)
+	config, err = prepareTempServerConfig(ctx, secretProvider, &serverManifest, config, !entryManifest.ServerUserType.IsSingleUser(), validationOptions)
 	if err != nil {
 		return v1.MCPServer{}, mcp.ServerConfig{}, err
 	}
@@ -1611,7 +1599,7 @@ func tempServerAndConfig(ctx context.Context, gatewayClient *gclient.Client, cli
 	return tempMCPServer, serverConfig, nil
 }
 
-func prepareTempServerConfig(ctx context.Context, localK8sClient kclient.Client, obotNamespace, secretBindingAllowedLabel string, serverManifest *types.MCPServerManifest, config map[string]string, isMultiUser bool, validationOptions mcp.ValidationOptions) (map[string]string, error) {
+func prepareTempServerConfig(ctx context.Context, secretProvider secretprovider.Provider, serverManifest *types.MCPServerManifest, config map[string]string, isMultiUser bool, validationOptions mcp.ValidationOptions) (map[string]string, error) {
 	if err := validateConfiguredOptions(serverManifest.Env, serverManifest.RemoteConfig, config); err != nil {
 		return nil, types.NewErrBadRequest("invalid configuration: %v", err)
 	}
@@ -1624,7 +1612,7 @@ func prepareTempServerConfig(ctx context.Context, localK8sClient kclient.Client,
 		return nil, err
 	}
 
-	mergedConfig, err := mcp.MergeBoundCreds(ctx, localK8sClient, obotNamespace, serverManifest.Env, serverManifest.RemoteConfig, config, secretBindingAllowedLabel)
+	mergedConfig, err := mcp.MergeBoundCreds(ctx, secretProvider, serverManifest.Env, serverManifest.RemoteConfig, config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to resolve secret bindings: %w", err)
 	}
@@ -1842,7 +1830,7 @@ func (h *MCPCatalogHandler) RefreshCompositeComponents(req api.Context) error {
 		return types.NewErrBadRequest("failed to validate entry manifest: %v", err)
 	}
 	// Preserve the git-managed status of the original entry when re-validating.
-	if err := mcp.ValidateSecretBindingsCatalogEntry(entry.Spec.Manifest, entryGitManaged, req.UserIsAdmin(), h.mcpBackend); err != nil {
+	if err := mcp.ValidateSecretBindingsCatalogEntry(entry.Spec.Manifest, entryGitManaged, req.UserIsAdmin(), req.SecretProvider != nil); err != nil {
 		return types.NewErrBadRequest("failed to validate entry manifest: %v", err)
 	}
 	if err := mcp.ValidateTemplateReferencesCatalogEntry(entry.Spec.Manifest); err != nil {
@@ -11,6 +11,7 @@ import (
 	"github.com/obot-platform/obot/apiclient/types"
 	"github.com/obot-platform/obot/pkg/api"
 	"github.com/obot-platform/obot/pkg/mcp"
+	"github.com/obot-platform/obot/pkg/secretprovider"
 	"github.com/obot-platform/obot/pkg/storage"
 	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
 	storagescheme "github.com/obot-platform/obot/pkg/storage/scheme"
@@ -470,7 +471,7 @@ func TestPrepareTempServerConfigDoesNotUseBoundSecretInURL(t *testing.T) {
 		AllowLinkLocalMCP: true,
 	}}
 
-	merged, err := prepareTempServerConfig(t.Context(), localK8sClient, namespace, label, &manifest, input, false, options)
+	merged, err := prepareTempServerConfig(t.Context(), secretprovider.NewKubernetes(localK8sClient, namespace, label), &manifest, input, false, options)
 	require.NoError(t, err)
 	require.Equal(t, "https://example.com/mcp/user-value", manifest.RemoteConfig.URL)
 	require.NotContains(t, manifest.RemoteConfig.URL, "secret-value")
@@ -486,7 +487,7 @@ func TestPrepareTempServerConfigRejectsUnknownOption(t *testing.T) {
 			Options: []types.MCPConfigurationOption{{Name: "US", Value: "us"}}}},
 		RemoteConfig: &types.RemoteRuntimeConfig{URL: "https://example.com/mcp"},
 	}
-	_, err := prepareTempServerConfig(t.Context(), fake.NewClientBuilder().Build(), "obot-ns", "allowed", &manifest, map[string]string{"REGION": "forged"}, false, mcp.ValidationOptions{})
+	_, err := prepareTempServerConfig(t.Context(), nil, &manifest, map[string]string{"REGION": "forged"}, false, mcp.ValidationOptions{})
 	require.Error(t, err)
 	var httpErr *types.ErrHTTP
 	require.ErrorAs(t, err, &httpErr)
@@ -556,6 +556,31 @@ func (h *AuditLogHandler) GetAuditLog(req api.Context) error {
 	return req.Write(result)
 }
 
+// GetUnredactedAuditLog handles GET /api/mcp-audit-logs/detail/{audit_log_id}/unredacted. It
+// returns the original payloads retained by the audit redaction setting, which only owners who
+// also hold the auditor role may read.
+func (h *AuditLogHandler) GetUnredactedAuditLog(req api.Context) error {
+	if !req.UserIsOwner() || !req.UserIsAuditor() {
+		return types.NewErrForbidden("only owners with the auditor role can view unredacted audit payloads")
+	}
+
+	var auditLogID uint64
+	if _, err := fmt.Sscanf(req.PathValue("audit_log_id"), "%d", &auditLogID); err != nil {
+		return types.NewErrBadRequest("invalid audit log id: %v", err)
+	}
+
+	log, err := req.GatewayClient.GetUnredactedMCPAuditLog(req.Context(), uint(auditLogID))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return types.NewErrNotFound("audit log not found")
+	} else if errors.Is(err, gateway.ErrUnredactedPayloadNotRetained) {
+		return types.NewErrNotFound("%v", err)
+	} else if err != nil {
+		return err
+	}
+
+	return req.Write(auditlog.Present(*log, auditlog.PresentOptions{IncludeDetails: true}))
+}
+
 func (h *AuditLogHandler) ListAuditLogFilterOptions(req api.Context) error {
 	filter := req.PathValue("filter")
 	if filter == "" {
@@ -749,12 +774,18 @@ func (h *AuditLogHandler) GetUsageStats(req api.Context) error {
 		result = append(result, gatewaytypes.ConvertMCPUsageStats(stat))
 	}
 
+	quotas := make([]types.MCPQuotaUsage, 0, len(stats.Quotas))
+	for _, quota := range stats.Quotas {
+		quotas = append(quotas, gatewaytypes.ConvertMCPQuotaUsage(quota))
+	}
+
 	return req.Write(types.MCPUsageStats{
 		TimeStart:   *types.NewTime(stats.TimeStart),
 		TimeEnd:     *types.NewTime(stats.TimeEnd),
 		TotalCalls:  stats.TotalCalls,
 		UniqueUsers: stats.UniqueUsers,
 		Items:       result,
+		Quotas:      quotas,
 	})
 }
 
@@ -19,11 +19,15 @@ import (
 	"github.com/obot-platform/nanobot/pkg/session"
 	ntypes "github.com/obot-platform/nanobot/pkg/types"
 	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/obot-platform/obot/pkg/accesscontrolrule"
 	"github.com/obot-platform/obot/pkg/api"
 	"github.com/obot-platform/obot/pkg/controller/handlers/systemmcpserver"
 	gateway "github.com/obot-platform/obot/pkg/gateway/client"
 	"github.com/obot-platform/obot/pkg/jwt/persistent"
 	"github.com/obot-platform/obot/pkg/mcp"
+	"github.com/obot-platform/obot/pkg/mcpapproval"
+	"github.com/obot-platform/obot/pkg/mcpquota"
+	"github.com/obot-platform/obot/pkg/mcpserverrequest"
 	"github.com/obot-platform/obot/pkg/principal"
 	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
 	"github.com/obot-platform/obot/pkg/system"
@@ -40,15 +44,18 @@ var (
 )
 
 type Handler struct {
-	mcpSessionManager         *mcp.SessionManager
-	globalTokenStore          mcp.GlobalTokenStore
-	tokenService              *persistent.TokenService
-	auditLogCollector         proxyAuditCollector
-	nanobot                   http.Handler
-	hookRunner                mcp.HookRunner
-	tunnelManager             *tunnel.Manager
-	secretBindingAllowedLabel string
-	serverURL                 string
+	mcpSessionManager   *mcp.SessionManager
+	acrHelper           *accesscontrolrule.Helper
+	quotaHelper         *mcpquota.Helper
+	approvalHelper      *mcpapproval.Helper
+	serverRequestHelper *mcpserverrequest.Helper
+	globalTokenStore    mcp.GlobalTokenStore
+	tokenService        *persistent.TokenService
+	auditLogCollector   proxyAuditCollector
+	nanobot             http.Handler
+	hookRunner          mcp.HookRunner
+	tunnelManager       *tunnel.Manager
+	serverURL           string
 }
 
 func auditLogMetadataForPrincipal(metadata map[string]string, user user.Info) map[string]string {
@@ -70,7 +77,7 @@ func compositeLoopbackURLs(serverURL, mcpServerName string, transform func(strin
 	return audienceURL, transform(audienceURL)
 }
 
-func NewHandler(ctx context.Context, mcpSessionManager *mcp.SessionManager, globalTokenStore mcp.GlobalTokenStore, tokenService *persistent.TokenService, auditLogCollector proxyAuditCollector, serverURL, dsn, secretBindingAllowedLabel string, tunnelManager *tunnel.Manager) (*Handler, error) {
+func NewHandler(ctx context.Context, mcpSessionManager *mcp.SessionManager, globalTokenStore mcp.GlobalTokenStore, tokenService *persistent.TokenService, auditLogCollector proxyAuditCollector, serverURL, dsn string, tunnelManager *tunnel.Manager, acrHelper *accesscontrolrule.Helper, quotaHelper *mcpquota.Helper, approvalHelper *mcpapproval.Helper, serverRequestHelper *mcpserverrequest.Helper) (*Handler, error) {
 	sessionStore, err := session.NewStoreFromDSN(dsn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create session store: %w", err)
@@ -119,15 +126,18 @@ func NewHandler(ctx context.Context, mcpSessionManager *mcp.SessionManager, glob
 	}
 
 	return &Handler{
-		mcpSessionManager:         mcpSessionManager,
-		globalTokenStore:          globalTokenStore,
-		tokenService:              tokenService,
-		auditLogCollector:         auditLogCollector,
-		nanobot:                   nanobotHTTPServer,
-		hookRunner:                mcp.NewHookRunner(mcpSessionManager),
-		tunnelManager:             tunnelManager,
-		secretBindingAllowedLabel: secretBindingAllowedLabel,
-		serverURL:                 serverURL,
+		mcpSessionManager:   mcpSessionManager,
+		acrHelper:           acrHelper,
+		quotaHelper:         quotaHelper,
+		approvalHelper:      approvalHelper,
+		serverRequestHelper: serverRequestHelper,
+		globalTokenStore:    globalTokenStore,
+		tokenService:        tokenService,
+		auditLogCollector:   auditLogCollector,
+		nanobot:             nanobotHTTPServer,
+		hookRunner:          mcp.NewHookRunner(mcpSessionManager),
+		tunnelManager:       tunnelManager,
+		serverURL:           serverURL,
 	}, nil
 }
 
@@ -142,6 +152,9 @@ func (h *Handler) Proxy(req api.Context) error {
 		if errors.Is(err, errMCPServerRequiresConfiguration) {
 			return nil
 		}
+		if unavailable, ok := errors.AsType[*mcpServerUnavailableError](err); ok {
+			return h.writeMCPServerUnavailable(req, serverConfig, unavailable)
+		}
 		return fmt.Errorf("failed to ensure server is deployed: %v", err)
 	}
 
@@ -214,7 +227,8 @@ func (h *Handler) Proxy(req api.Context) error {
 			return fmt.Errorf("failed to prepare MCP request audit log: %w", err)
 		}
 
-		hooks, err := newHookProcessor(req.Request, h.hookRunner, hookConfig, hookServers, audit, newHookCorrelationStore(req.Storage, serverConfig.AuditLogMetadata))
+		hooks, err := newHookProcessor(req.Request, h.hookRunner, hookConfig, hookServers, audit, newHookCorrelationStore(req.Storage, serverConfig.AuditLogMetadata),
+			h.serverRequestGovernor(req, serverConfig, client, u), h.toolCallGuards(req, serverConfig)...)
 		if err != nil {
 			return fmt.Errorf("failed to prepare MCP request hooks: %w", err)
 		}
@@ -235,6 +249,7 @@ func (h *Handler) Proxy(req api.Context) error {
 				rewriteProxyRequest(r, u)
 			},
 			ModifyResponse: func(resp *http.Response) error {
+				h.recordUpstreamStatus(serverConfig, resp.StatusCode)
 				if err := hooks.filterResponse(resp); err != nil {
 					return err
 				}
@@ -242,6 +257,9 @@ func (h *Handler) Proxy(req api.Context) error {
 			},
 			ErrorHandler: func(w http.ResponseWriter, _ *http.Request, err error) {
 				audit.recordTransportError(err, http.StatusBadGateway)
+				if !errors.Is(err, context.Canceled) {
+					h.recordUpstreamStatus(serverConfig, http.StatusBadGateway)
+				}
 				if serverConfig.NanobotAgentName != "" {
 					http.Error(w, fmt.Sprintf("failed to proxy request to Nanobot agent %s: %v", serverConfig.NanobotAgentName, err), http.StatusBadGateway)
 				} else {
@@ -353,6 +371,12 @@ func (h *Handler) ensureServerIsDeployed(req api.Context) (mcp.ServerConfig, err
 		}
 	}
 
+	if mcpServerConfig.Runtime != types.RuntimeComposite {
+		if allowed, retryAfter := h.mcpSessionManager.HealthTracker().Allow(mcpServer.Name, mcpServer.Status.Health); !allowed {
+			return mcpServerConfig, &mcpServerUnavailableError{retryAfter: retryAfter}
+		}
+	}
+
 	mcpServerConfig, err = h.mcpSessionManager.LaunchServer(req.Context(), mcpServerConfig)
 	if err != nil {
 		return mcp.ServerConfig{}, fmt.Errorf("failed to launch mcp server: %w", err)
@@ -428,7 +452,7 @@ func (h *Handler) ensureSystemServerIsDeployed(req api.Context, mcpID string) (m
 		secretsCred = tokenExchangeCred.Secrets
 	}
 
-	credEnv, err = mcp.MergeBoundCreds(req.Context(), req.LocalK8sClient, req.ObotNamespace, systemServer.Spec.Manifest.Env, systemServer.Spec.Manifest.RemoteConfig, credEnv, h.secretBindingAllowedLabel)
+	credEnv, err = mcp.MergeBoundCreds(req.Context(), req.SecretProvider, systemServer.Spec.Manifest.Env, systemServer.Spec.Manifest.RemoteConfig, credEnv)
 	if err != nil {
 		return mcp.ServerConfig{}, fmt.Errorf("failed to resolve secret bindings: %w", err)
 	}
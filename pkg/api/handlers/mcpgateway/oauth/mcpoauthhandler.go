@@ -19,13 +19,12 @@ import (
 )
 
 type MCPOAuthHandlerFactory struct {
-	baseURL                   string
-	mcpSessionManager         *mcp.SessionManager
-	client                    kclient.Client
-	stateMgr                  *stateManager
-	tokenStore                mcp.GlobalTokenStore
-	secretBindingAllowedLabel string
-	cimdDocumentURL           string
+	baseURL           string
+	mcpSessionManager *mcp.SessionManager
+	client            kclient.Client
+	stateMgr          *stateManager
+	tokenStore        mcp.GlobalTokenStore
+	cimdDocumentURL   string
 }
 
 type mcpOAuthHandler struct {
@@ -41,14 +40,13 @@ type mcpOAuthHandler struct {
 	catalogEntryName string
 }
 
-func NewMCPOAuthHandlerFactory(baseURL string, sessionManager *mcp.SessionManager, client kclient.Client, gatewayClient *client.Client, globalTokenStore mcp.GlobalTokenStore, secretBindingAllowedLabel string, forceDynamicClient bool) *MCPOAuthHandlerFactory {
+func NewMCPOAuthHandlerFactory(baseURL string, sessionManager *mcp.SessionManager, client kclient.Client, gatewayClient *client.Client, globalTokenStore mcp.GlobalTokenStore, forceDynamicClient bool) *MCPOAuthHandlerFactory {
 	f := &MCPOAuthHandlerFactory{
-		baseURL:                   baseURL,
-		mcpSessionManager:         sessionManager,
-		client:                    client,
-		stateMgr:                  newStateManager(gatewayClient),
-		tokenStore:                globalTokenStore,
-		secretBindingAllowedLabel: secretBindingAllowedLabel,
+		baseURL:           baseURL,
+		mcpSessionManager: sessionManager,
+		client:            client,
+		stateMgr:          newStateManager(gatewayClient),
+		tokenStore:        globalTokenStore,
 	}
 
 	if !forceDynamicClient && strings.HasPrefix(baseURL, "https://") {
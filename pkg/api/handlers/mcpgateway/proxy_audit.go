@@ -516,6 +516,29 @@ func (a *proxyAudit) recordSSEEvent(event string, data []byte, statusCode int) {
 	}
 }
 
+// recordRejectedServerRequest records a request the server sent on a stream
+// that the gateway answered itself instead of passing it on to the client.
+func (a *proxyAudit) recordRejectedServerRequest(body, response []byte, statuses []hookStatus, err error) {
+	if a == nil {
+		return
+	}
+
+	entry := a.entry
+	entry.CreatedAt = time.Now()
+	entry.RequestBody = jsonBody(body)
+	populateMCPMessageFields(&entry, body)
+	entry.RequestHeaders = entry.ResponseHeaders
+	entry.ResponseHeaders = nil
+	entry.ResponseBody = jsonBody(response)
+	entry.ResponseStatus = http.StatusOK
+	entry.WebhookStatuses = append(entry.WebhookStatuses, auditHookStatuses(statuses)...)
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	entry.SetProcessingTime()
+	a.submit(entry, true)
+}
+
 func (a *proxyAudit) newResponseEntry(requestID string) auditlogs.MCPAuditLog {
 	if a == nil {
 		return auditlogs.MCPAuditLog{}
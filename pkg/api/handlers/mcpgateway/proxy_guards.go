@@ -0,0 +1,175 @@
+package mcpgateway
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/obot-platform/obot/pkg/api"
+	gateway "github.com/obot-platform/obot/pkg/gateway/client"
+	"github.com/obot-platform/obot/pkg/mcp"
+	"github.com/obot-platform/obot/pkg/mcpapproval"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	"github.com/obot-platform/obot/pkg/toolconstraint"
+)
+
+// toolCall is the part of tools/call params that guards check.
+type toolCall struct {
+	Name      string          `json:"name"`
+	Arguments json.RawMessage `json:"arguments,omitempty"`
+
+	sessionID, requestID string
+}
+
+// toolCallGuard checks a tools/call request from the client before hooks run
+// and before it reaches the upstream MCP server. Returning a
+// *toolCallRejection rejects the call with that JSON-RPC error; any other error
+// rejects it as a failed check. A guard that lets the call through can return a
+// note, which is recorded in the audit log.
+type toolCallGuard struct {
+	name  string
+	check func(call toolCall) (string, error)
+}
+
+type toolCallRejection struct {
+	rpcError *mcp.RPCError
+}
+
+func (r *toolCallRejection) Error() string {
+	return r.rpcError.Message
+}
+
+// guardToolCall runs the guards on a tools/call request, and records and
+// returns the error response if one rejects it.
+func (h *hookProcessor) guardToolCall(message mcp.Message) ([]byte, error) {
+	if len(h.guards) == 0 || message.Method != "tools/call" {
+		return nil, nil
+	}
+
+	var call toolCall
+	if err := json.Unmarshal(message.Params, &call); err != nil {
+		return mcpErrorResponse(message, mcp.ErrRPCInvalidParams.WithMessage("%v", err)), err
+	}
+	call.sessionID = h.sessionID
+	call.requestID = mcp.MessageIDString(message.ID)
+
+	for _, guard := range h.guards {
+		note, err := guard.check(call)
+		if err == nil {
+			if note != "" {
+				h.audit.recordRequestHooks(hookResult{statuses: []hookStatus{{typeName: "request", method: message.Method, name: guard.name, tool: call.Name, status: "ok", message: note}}})
+			}
+			continue
+		}
+
+		status := hookStatus{typeName: "request", method: message.Method, name: guard.name, tool: call.Name, status: "rejected", message: err.Error()}
+		rpcError := mcp.ErrRPCUnknown.WithMessage("failed to check tool call: %v", err)
+		if rejection, ok := errors.AsType[*toolCallRejection](err); ok {
+			rpcError = rejection.rpcError
+		} else {
+			status.status = "error"
+		}
+		h.audit.recordRequestHooks(hookResult{statuses: []hookStatus{status}})
+		return mcpErrorResponse(message, rpcError), fmt.Errorf("tool call rejected by %s: %w", guard.name, err)
+	}
+	return nil, nil
+}
+
+// toolCallGuards returns the guards for calls to the server by the requesting
+// user. Tool argument constraints are checked before quotas, so that calls
+// rejected for their arguments are not counted, and approval is asked for last,
+// so that nobody is asked to approve a call that would be rejected anyway.
+func (h *Handler) toolCallGuards(req api.Context, serverConfig mcp.ServerConfig) []toolCallGuard {
+	if serverConfig.SystemMCPServer {
+		return nil
+	}
+
+	var guards []toolCallGuard
+	if h.acrHelper != nil && serverConfig.MCPCatalogName != "" {
+		guards = append(guards, toolCallGuard{
+			name: "tool-argument-constraints",
+			check: func(call toolCall) (string, error) {
+				constraints, err := h.acrHelper.ToolArgumentConstraintsForUser(req.User, serverConfig.MCPServerName, serverConfig.MCPCatalogEntryName, serverConfig.MCPCatalogName)
+				if err != nil {
+					return "", fmt.Errorf("failed to get tool argument constraints: %w", err)
+				}
+				if err := toolconstraint.Check(constraints, call.Name, call.Arguments); err != nil {
+					return "", &toolCallRejection{rpcError: mcp.ErrRPCInvalidParams.WithMessage("%v", err)}
+				}
+				return "", nil
+			},
+		})
+	}
+	if h.quotaHelper != nil {
+		guards = append(guards, toolCallGuard{
+			name: "mcp-quota",
+			check: func(call toolCall) (string, error) {
+				err := h.quotaHelper.Take(req.Context(), req.User, serverConfig.MCPServerName, serverConfig.MCPCatalogEntryName, call.Name)
+				if exceeded, ok := errors.AsType[*gateway.MCPQuotaExceededError](err); ok {
+					return "", &toolCallRejection{rpcError: quotaExceededError(exceeded, time.Now())}
+				}
+				return "", err
+			},
+		})
+	}
+	if h.approvalHelper != nil {
+		guards = append(guards, toolCallGuard{
+			name: "tool-call-approval",
+			check: func(call toolCall) (string, error) {
+				approval, err := h.approvalHelper.Hold(req.Context(), req.User, mcpapproval.Call{
+					MCPID:                serverConfig.MCPServerName,
+					CatalogEntryName:     serverConfig.MCPCatalogEntryName,
+					MCPServerDisplayName: serverConfig.MCPServerDisplayName,
+					ToolName:             call.Name,
+					Arguments:            call.Arguments,
+					SessionID:            call.sessionID,
+					RequestID:            call.requestID,
+				})
+				if err != nil || approval == nil {
+					return "", err
+				}
+				if approval.Spec.State != types.MCPToolCallApprovalStateApproved {
+					return "", &toolCallRejection{rpcError: toolCallNotApprovedError(approval)}
+				}
+				return fmt.Sprintf("approval %s approved by %s", approval.Name, approval.Spec.DecidedBy), nil
+			},
+		})
+	}
+	return guards
+}
+
+// toolCallNotApprovedError tells the client why a held call was not released.
+func toolCallNotApprovedError(approval *v1.MCPToolCallApproval) *mcp.RPCError {
+	message := fmt.Sprintf("tool call approval %s was %s", approval.Name, approval.Spec.State)
+	if approval.Spec.DecisionComment != "" {
+		message += ": " + approval.Spec.DecisionComment
+	}
+	return mcp.ErrRPCToolCallNotApproved.WithMessage("%s", message).WithData(mcp.ToolCallNotApprovedData{
+		ApprovalID: approval.Name,
+		State:      string(approval.Spec.State),
+	})
+}
+
+// quotaExceededError tells the client which quota it exceeded and when it can
+// retry, both in the message and in the error data.
+func quotaExceededError(exceeded *gateway.MCPQuotaExceededError, now time.Time) *mcp.RPCError {
+	retryAfter := int(math.Ceil(exceeded.ResetsAt.Sub(now).Seconds()))
+	if retryAfter < 1 {
+		retryAfter = 1
+	}
+
+	limit := fmt.Sprintf("%d calls per %s", exceeded.Counter.Limit, exceeded.Counter.Window)
+	if exceeded.Counter.ToolName != "" {
+		limit += fmt.Sprintf(" to tool %q", exceeded.Counter.ToolName)
+	}
+	return mcp.ErrRPCQuotaExceeded.WithMessage("%s; retry after %d seconds", limit, retryAfter).WithData(mcp.QuotaExceededData{
+		RetryAfterSeconds: retryAfter,
+		RetryAt:           exceeded.ResetsAt.UTC().Format(time.RFC3339),
+		Window:            string(exceeded.Counter.Window),
+		Limit:             exceeded.Counter.Limit,
+		Tool:              exceeded.Counter.ToolName,
+	})
+}
@@ -0,0 +1,61 @@
+package mcpgateway
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/obot-platform/obot/apiclient/types"
+	gateway "github.com/obot-platform/obot/pkg/gateway/client"
+	"github.com/obot-platform/obot/pkg/mcp"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+)
+
+func TestQuotaExceededError(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 30, 15, 500_000_000, time.UTC)
+	rpcError := quotaExceededError(&gateway.MCPQuotaExceededError{
+		Counter:  gateway.MCPQuotaCounter{ToolName: "search", Window: types.MCPQuotaWindowMinute, Limit: 10},
+		ResetsAt: time.Date(2026, 10, 19, 12, 31, 0, 0, time.UTC),
+	}, now)
+
+	if rpcError.Code != mcp.ErrRPCQuotaExceeded.Code {
+		t.Fatalf("unexpected code %d", rpcError.Code)
+	}
+	if !strings.Contains(rpcError.Message, `10 calls per minute to tool "search"; retry after 45 seconds`) {
+		t.Fatalf("unexpected message %q", rpcError.Message)
+	}
+
+	var data mcp.QuotaExceededData
+	if err := json.Unmarshal(rpcError.Data, &data); err != nil {
+		t.Fatal(err)
+	}
+	if data != (mcp.QuotaExceededData{RetryAfterSeconds: 45, RetryAt: "2026-10-19T12:31:00Z", Window: "minute", Limit: 10, Tool: "search"}) {
+		t.Fatalf("unexpected data %+v", data)
+	}
+}
+
+func TestToolCallNotApprovedError(t *testing.T) {
+	rpcError := toolCallNotApprovedError(&v1.MCPToolCallApproval{
+		Name: "mtca1abc",
+		Spec: v1.MCPToolCallApprovalSpec{
+			State:           types.MCPToolCallApprovalStateRejected,
+			DecisionComment: "not during the freeze",
+		},
+	})
+
+	if rpcError.Code != mcp.ErrRPCToolCallNotApproved.Code {
+		t.Fatalf("unexpected code %d", rpcError.Code)
+	}
+	if rpcError.Message != "MCP tool call not approved: tool call approval mtca1abc was rejected: not during the freeze" {
+		t.Fatalf("unexpected message %q", rpcError.Message)
+	}
+
+	var data mcp.ToolCallNotApprovedData
+	if err := json.Unmarshal(rpcError.Data, &data); err != nil {
+		t.Fatal(err)
+	}
+	if data != (mcp.ToolCallNotApprovedData{ApprovalID: "mtca1abc", State: "rejected"}) {
+		t.Fatalf("unexpected data %+v", data)
+	}
+}
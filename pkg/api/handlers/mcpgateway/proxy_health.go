@@ -0,0 +1,86 @@
+package mcpgateway
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/obot-platform/obot/pkg/api"
+	"github.com/obot-platform/obot/pkg/mcp"
+)
+
+// mcpServerUnavailableError is returned when the circuit for an MCP server is
+// open and the request is rejected without contacting the server.
+type mcpServerUnavailableError struct {
+	retryAfter time.Duration
+}
+
+func (e *mcpServerUnavailableError) Error() string {
+	return fmt.Sprintf("MCP server is unavailable: recent requests and health checks have failed, retry in %s", e.retryAfterSeconds())
+}
+
+func (e *mcpServerUnavailableError) retryAfterSeconds() string {
+	return strconv.Itoa(max(1, int(math.Ceil(e.retryAfter.Seconds()))))
+}
+
+// writeMCPServerUnavailable fails the request fast with a 503 and a Retry-After
+// hint. The body is a JSON-RPC error so that MCP clients can surface it.
+func (h *Handler) writeMCPServerUnavailable(req api.Context, serverConfig mcp.ServerConfig, unavailable *mcpServerUnavailableError) error {
+	audit, err := newProxyAudit(req.Request, serverConfig.AuditLogMetadata, h.auditLogCollector, req.Storage)
+	if err != nil {
+		return fmt.Errorf("failed to prepare MCP request audit log: %w", err)
+	}
+	audit.recordRequest()
+
+	var request mcp.Message
+	if req.Method == http.MethodPost && req.Request.Body != nil {
+		body, err := io.ReadAll(io.LimitReader(req.Request.Body, maxMCPProxyHookBodySize))
+		if err == nil {
+			_ = decodeMCPHookMessage(body, &request)
+		}
+	}
+
+	mcpServerName := serverConfig.MCPServerDisplayName
+	if mcpServerName == "" {
+		mcpServerName = serverConfig.MCPServerName
+	}
+	unavailableErr := fmt.Errorf("%s: %w", mcpServerName, unavailable)
+	audit.recordTransportError(unavailableErr, http.StatusServiceUnavailable)
+
+	body, err := json.Marshal(mcp.Message{
+		JSONRPC: "2.0",
+		ID:      request.ID,
+		Error:   mcp.NewRPCError(mcp.ErrRPCUnknown.Code, unavailableErr.Error()),
+	})
+	if err != nil {
+		return err
+	}
+
+	req.ResponseWriter.Header().Set("Content-Type", "application/json")
+	req.ResponseWriter.Header().Set("Retry-After", unavailable.retryAfterSeconds())
+	req.WriteHeader(http.StatusServiceUnavailable)
+	_, _ = req.ResponseWriter.Write(body)
+	return nil
+}
+
+// recordUpstreamStatus feeds the circuit breaker with the outcome of a proxied
+// request. Only responses that indicate the server itself is unreachable or
+// overloaded count as failures.
+func (h *Handler) recordUpstreamStatus(serverConfig mcp.ServerConfig, statusCode int) {
+	if serverConfig.Runtime == types.RuntimeComposite {
+		return
+	}
+
+	tracker := h.mcpSessionManager.HealthTracker()
+	switch statusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		tracker.RecordFailure(serverConfig.MCPServerName)
+	default:
+		tracker.RecordSuccess(serverConfig.MCPServerName)
+	}
+}
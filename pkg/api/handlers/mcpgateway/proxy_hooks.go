@@ -15,8 +15,10 @@ import (
 	"slices"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/obot-platform/obot/pkg/mcp"
+	"github.com/obot-platform/obot/pkg/mcpserverrequest"
 )
 
 const (
@@ -33,16 +35,19 @@ type pendingRequest struct {
 
 // hookProcessor filters MCP messages for one proxied HTTP exchange.
 type hookProcessor struct {
-	ctx       context.Context
-	runner    mcp.HookRunner
-	hooks     mcp.Hooks
-	servers   mcp.HookServerConfigs
-	audit     *proxyAudit
-	store     *hookCorrelationStore
-	sessionID string
-	request   *pendingRequest
-	requestID string
-	disabled  bool
+	ctx     context.Context
+	runner  mcp.HookRunner
+	hooks   mcp.Hooks
+	servers mcp.HookServerConfigs
+	audit   *proxyAudit
+	store   *hookCorrelationStore
+	guards  []toolCallGuard
+	// serverRequests governs requests the server sends on response streams.
+	serverRequests *serverRequestGovernor
+	sessionID      string
+	request        *pendingRequest
+	requestID      string
+	disabled       bool
 
 	requestError    error
 	requestResponse []byte
@@ -91,24 +96,53 @@ type hookSSEBody struct {
 	hooks       *hookProcessor
 	output      []byte
 	terminalErr error
+
+	// With a server request governor, events are read on their own goroutine
+	// and the governor's checks, which can wait for an approval, run on
+	// theirs. Only the request being checked is held back; the rest of the
+	// stream keeps flowing. Everything else happens on the goroutine that
+	// calls Read.
+	events    chan hookSSEEvent
+	checked   chan checkedServerRequest
+	held      int
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+type hookSSEEvent struct {
+	raw   []byte
+	lines []hookSSELine
+	err   error
+}
+
+type checkedServerRequest struct {
+	event   hookSSEEvent
+	body    []byte
+	message mcp.Message
+	result  mcpserverrequest.Result
+	err     error
 }
 
 type hookSSELine struct {
 	value, ending string
 }
 
-func newHookProcessor(req *http.Request, runner mcp.HookRunner, hooks mcp.Hooks, servers mcp.HookServerConfigs, audit *proxyAudit, store *hookCorrelationStore) (*hookProcessor, error) {
+func newHookProcessor(req *http.Request, runner mcp.HookRunner, hooks mcp.Hooks, servers mcp.HookServerConfigs, audit *proxyAudit, store *hookCorrelationStore, serverRequests *serverRequestGovernor, guards ...toolCallGuard) (*hookProcessor, error) {
 	processor := &hookProcessor{
-		ctx:       req.Context(),
-		runner:    runner,
-		hooks:     hooks,
-		servers:   servers,
-		audit:     audit,
-		store:     store,
-		sessionID: mcpSessionID(req.Header, req.URL),
-		disabled:  runner == nil || len(hooks) == 0,
-	}
-	if processor.disabled || req.Method != http.MethodPost || req.Body == nil {
+		ctx:            req.Context(),
+		runner:         runner,
+		hooks:          hooks,
+		servers:        servers,
+		audit:          audit,
+		store:          store,
+		guards:         guards,
+		serverRequests: serverRequests,
+		sessionID:      mcpSessionID(req.Header, req.URL),
+		disabled:       runner == nil || len(hooks) == 0,
+	}
+	// Without hooks or guards, the request body is only needed to audit a
+	// client's response to a server request.
+	if (processor.disabled && len(guards) == 0 && audit == nil) || req.Method != http.MethodPost || req.Body == nil {
 		return processor, nil
 	}
 
@@ -130,18 +164,37 @@ func newHookProcessor(req *http.Request, runner mcp.HookRunner, hooks mcp.Hooks,
 	setMCPRequestBody(req, body)
 
 	var message mcp.Message
-	if decodeMCPHookMessage(body, &message) != nil {
+	if err := decodeMCPHookMessage(body, &message); err != nil {
+		if len(guards) == 0 {
+			return processor, nil
+		}
+		// Guards check one message at a time. A batch, or anything else they
+		// cannot read, would reach the server unchecked, so it is refused.
+		processor.requestError = fmt.Errorf("MCP request rejected by tool call guards: %w", err)
+		processor.requestResponse = mcpErrorResponse(mcp.Message{}, mcp.ErrRPCInvalidRequest.WithMessage("expected a single JSON-RPC message"))
 		return processor, nil
 	}
 
+	// Guards run whether or not the server has hooks.
+	if response, err := processor.guardToolCall(message); err != nil {
+		processor.requestError = err
+		processor.requestResponse = response
+		return processor, nil
+	}
 	if message.Method == "" {
 		// If there is no method on this message, it's a protocol response
-		body = processor.filterResponseMessage(body, hookOriginServer)
-		clearMCPHookRequestHeaders(req)
-		setMCPRequestBody(req, body)
+		// to a request the server sent, which is audited with or without hooks.
+		if !processor.disabled {
+			body = processor.filterResponseMessage(body, hookOriginServer)
+			clearMCPHookRequestHeaders(req)
+			setMCPRequestBody(req, body)
+		}
 		processor.audit.recordResponse(body, http.StatusOK, nil, "")
 		return processor, nil
 	}
+	if processor.disabled {
+		return processor, nil
+	}
 
 	filtered, err := processor.filterRequest(body, message, hookOriginClient)
 	filtered.hooks.captureBody(body)
@@ -240,7 +293,7 @@ func (h *hookProcessor) blockedRequest() ([]byte, bool, error) {
 }
 
 func (h *hookProcessor) filterResponse(resp *http.Response) error {
-	if h == nil || h.disabled {
+	if h == nil || (h.disabled && h.serverRequests == nil) {
 		return nil
 	}
 	if resp.Body != nil {
@@ -272,7 +325,9 @@ func (h *hookProcessor) filterResponse(resp *http.Response) error {
 		clearMCPHookResponseHeaders(resp.Header)
 		return nil
 	}
-	if resp.Body == nil {
+	// Server requests only arrive on streams, so without hooks there is nothing
+	// else to filter.
+	if resp.Body == nil || h.disabled {
 		return nil
 	}
 
@@ -365,10 +420,20 @@ func (h *hookProcessor) filterResponseMessage(body []byte, origin hookOrigin) []
 	return filtered
 }
 
-func (h *hookProcessor) filterRequestMessage(body []byte, wireMessage mcp.Message) []byte {
+// filterRequestMessage runs hooks on a request the server sent on a response
+// stream. governed is what the server request governor did to it first.
+func (h *hookProcessor) filterRequestMessage(body []byte, wireMessage mcp.Message, governed hookResult) []byte {
+	auditID := mcp.MessageIDString(wireMessage.ID)
+	if h.disabled {
+		if len(governed.statuses) > 0 {
+			h.audit.recordStreamRequestHooks(auditID, governed)
+		}
+		return body
+	}
+
 	filtered, err := h.filterRequest(body, wireMessage, hookOriginServer)
 	filtered.hooks.captureBody(body)
-	auditID := mcp.MessageIDString(wireMessage.ID)
+	filtered.hooks.follow(governed)
 
 	if filtered.hooks.err != nil {
 		filtered.hooks.err = fmt.Errorf("failed to call request hooks: %w", filtered.hooks.err)
@@ -614,14 +679,18 @@ func addMCPHookMutationsMeta(message *mcp.Message) error {
 }
 
 func mcpHookErrorResponse(request mcp.Message, direction string, hookErr error) []byte {
-	jsonRPC := request.JSONRPC
-	if jsonRPC == "" {
-		jsonRPC = "2.0"
-	}
 	rpcError := mcp.ErrRPCUnknown.WithMessage("failed to call %q hooks: %v", direction, hookErr)
 	if blockedErr, ok := errors.AsType[*hookBlockedError](hookErr); ok {
 		rpcError = mcp.NewRPCError(mcp.ErrRPCUnknown.Code, blockedErr.Error())
 	}
+	return mcpErrorResponse(request, rpcError)
+}
+
+func mcpErrorResponse(request mcp.Message, rpcError *mcp.RPCError) []byte {
+	jsonRPC := request.JSONRPC
+	if jsonRPC == "" {
+		jsonRPC = "2.0"
+	}
 	data, err := json.Marshal(mcp.Message{
 		JSONRPC: jsonRPC,
 		ID:      request.ID,
@@ -634,21 +703,22 @@ func mcpHookErrorResponse(request mcp.Message, direction string, hookErr error)
 }
 
 func newHookSSEBody(source io.ReadCloser, hooks *hookProcessor) *hookSSEBody {
-	return &hookSSEBody{source: source, reader: bufio.NewReader(source), hooks: hooks}
+	body := &hookSSEBody{source: source, reader: bufio.NewReader(source), hooks: hooks}
+	if hooks.serverRequests != nil {
+		body.events = make(chan hookSSEEvent)
+		body.checked = make(chan checkedServerRequest)
+		body.done = make(chan struct{})
+		go body.readEvents()
+	}
+	return body
 }
 
 func (b *hookSSEBody) Read(p []byte) (int, error) {
 	for len(b.output) == 0 {
-		if b.terminalErr != nil {
+		if b.terminalErr != nil && b.held == 0 {
 			return 0, b.terminalErr
 		}
-		rawEvent, lines, err := readMCPHookSSEEvent(b.reader)
-		if len(rawEvent) > 0 {
-			b.output = b.transformEvent(rawEvent, lines)
-		}
-		if err != nil {
-			b.terminalErr = err
-		}
+		b.output = b.next()
 	}
 
 	n := copy(p, b.output)
@@ -656,14 +726,61 @@ func (b *hookSSEBody) Read(p []byte) (int, error) {
 	return n, nil
 }
 
+// next returns the output for the next event from the server or the next
+// server request the governor is done with.
+func (b *hookSSEBody) next() []byte {
+	var event hookSSEEvent
+	if b.events == nil {
+		event.raw, event.lines, event.err = readMCPHookSSEEvent(b.reader)
+	} else {
+		events := b.events
+		if b.terminalErr != nil {
+			// The server is done; only held requests are left.
+			events = nil
+		}
+		select {
+		case event = <-events:
+		case checked := <-b.checked:
+			b.held--
+			return b.finishServerRequest(checked)
+		}
+	}
+
+	if event.err != nil {
+		b.terminalErr = event.err
+	}
+	if len(event.raw) == 0 {
+		return nil
+	}
+	return b.transformEvent(event)
+}
+
+func (b *hookSSEBody) readEvents() {
+	for {
+		var event hookSSEEvent
+		event.raw, event.lines, event.err = readMCPHookSSEEvent(b.reader)
+		select {
+		case b.events <- event:
+		case <-b.done:
+			return
+		}
+		if event.err != nil {
+			return
+		}
+	}
+}
+
 func (b *hookSSEBody) Close() error {
+	if b.done != nil {
+		b.closeOnce.Do(func() { close(b.done) })
+	}
 	return b.source.Close()
 }
 
-func (b *hookSSEBody) transformEvent(rawEvent []byte, lines []hookSSELine) []byte {
+func (b *hookSSEBody) transformEvent(rawEvent hookSSEEvent) []byte {
 	var event string
 	var data []string
-	for _, line := range lines {
+	for _, line := range rawEvent.lines {
 		if value, ok := strings.CutPrefix(line.value, "event:"); ok {
 			event = strings.TrimSpace(value)
 		} else if value, ok := strings.CutPrefix(line.value, "data:"); ok {
@@ -671,7 +788,7 @@ func (b *hookSSEBody) transformEvent(rawEvent []byte, lines []hookSSELine) []byt
 		}
 	}
 	if len(data) == 0 {
-		return rawEvent
+		return rawEvent.raw
 	}
 
 	joined := []byte(strings.Join(data, "\n"))
@@ -680,27 +797,70 @@ func (b *hookSSEBody) transformEvent(rawEvent []byte, lines []hookSSELine) []byt
 			if sessionID := mcpSessionID(nil, endpoint); sessionID != "" {
 				b.hooks.sessionID = sessionID
 			}
+			if b.hooks.serverRequests != nil {
+				b.hooks.serverRequests.endpoint = endpoint
+			}
 		}
-		return rawEvent
+		return rawEvent.raw
 	}
 
 	var message mcp.Message
-	if decodeMCPHookMessage(joined, &message) != nil {
-		return rawEvent
+	if err := decodeMCPHookMessage(joined, &message); err != nil {
+		if b.hooks.serverRequests == nil {
+			return rawEvent.raw
+		}
+		// The governor checks one message at a time. A batch, or anything
+		// else it cannot read, could carry server requests past it, so it
+		// is dropped.
+		b.hooks.rejectUngovernableEvent(joined, err)
+		return nil
 	}
-	var filtered []byte
+	filtered := joined
 	if message.Method != "" {
-		filtered = b.hooks.filterRequestMessage(joined, message)
-	} else {
+		if b.hooks.serverRequests != nil && mcp.MessageIDString(message.ID) != "" {
+			b.holdServerRequest(rawEvent, joined, message)
+			return nil
+		}
+		filtered = b.hooks.filterRequestMessage(joined, message, hookResult{})
+	} else if !b.hooks.disabled {
 		filtered = b.hooks.filterResponseMessage(joined, hookOriginClient)
 	}
-	if bytes.Equal(filtered, joined) {
-		return rawEvent
+	return replaceSSEEventData(rawEvent, joined, filtered)
+}
+
+// holdServerRequest checks a server request without holding up the events
+// that follow it.
+func (b *hookSSEBody) holdServerRequest(event hookSSEEvent, body []byte, message mcp.Message) {
+	b.held++
+	sessionID := b.hooks.sessionID
+	go func() {
+		result, err := b.hooks.checkServerRequest(message, sessionID)
+		select {
+		case b.checked <- checkedServerRequest{event: event, body: body, message: message, result: result, err: err}:
+		case <-b.done:
+		}
+	}()
+}
+
+func (b *hookSSEBody) finishServerRequest(checked checkedServerRequest) []byte {
+	body, message, governed, ok := b.hooks.governServerRequest(checked.body, checked.message, checked.result, checked.err)
+	if !ok {
+		// The governor answered the server, so the client never sees it.
+		return nil
+	}
+	return replaceSSEEventData(checked.event, checked.body, b.hooks.filterRequestMessage(body, message, governed))
+}
+
+// replaceSSEEventData returns event with its data replaced by filtered, or the
+// event as it was if filtering did not change data.
+func replaceSSEEventData(event hookSSEEvent, data, filtered []byte) []byte {
+	if bytes.Equal(filtered, data) {
+		return event.raw
 	}
 
 	var output bytes.Buffer
 	wroteData := false
-	for _, line := range lines {
+	for _, line := range event.lines {
 		if _, ok := strings.CutPrefix(line.value, "data:"); ok {
 			if !wroteData {
 				output.WriteString("data: ")
@@ -70,7 +70,7 @@ func TestMCPProxyHooksScopeByMethodNameAndDirection(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			before := runner.callCount()
-			if _, err := newHookProcessor(mustMCPHookRequest(t, tt.request), runner, hooks, nil, nil, nil); err != nil {
+			if _, err := newHookProcessor(mustMCPHookRequest(t, tt.request), runner, hooks, nil, nil, nil, nil); err != nil {
 				t.Fatal(err)
 			}
 			if got := runner.callCount() - before; got != tt.wantCalls {
@@ -80,7 +80,7 @@ func TestMCPProxyHooksScopeByMethodNameAndDirection(t *testing.T) {
 	}
 
 	request := mustMCPHookRequest(t, `{"jsonrpc":"2.0","id":5,"method":"tools/call","params":{"name":"echo"}}`)
-	hookProcessor, err := newHookProcessor(request, runner, hooks, nil, nil, nil)
+	hookProcessor, err := newHookProcessor(request, runner, hooks, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -112,7 +112,7 @@ func TestMCPProxyHooksChainAndContinueAfterErrors(t *testing.T) {
 		hooks := mcp.Hooks{{Name: "tools/call", Targets: []mcp.HookTarget{{Target: "policy/first"}, {Target: "policy/second"}}}}
 		request := mustMCPHookRequest(t, `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"echo","arguments":{"value":"original"}}}`)
 
-		if _, err := newHookProcessor(request, runner, hooks, nil, nil, nil); err != nil {
+		if _, err := newHookProcessor(request, runner, hooks, nil, nil, nil, nil); err != nil {
 			t.Fatal(err)
 		}
 		body, err := io.ReadAll(request.Body)
@@ -132,7 +132,7 @@ func TestMCPProxyHooksChainAndContinueAfterErrors(t *testing.T) {
 			return mcp.SessionMessageHook{Accept: true, Message: input.Message}, true, nil
 		}}
 		hooks := mcp.Hooks{{Name: "tools/call", Targets: []mcp.HookTarget{{Target: "policy/fail"}, {Target: "policy/pass"}}}}
-		hookProcessor, err := newHookProcessor(mustMCPHookRequest(t, `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"echo"}}`), runner, hooks, nil, nil, nil)
+		hookProcessor, err := newHookProcessor(mustMCPHookRequest(t, `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"echo"}}`), runner, hooks, nil, nil, nil, nil)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -162,7 +162,7 @@ func TestMCPProxyHooksMutateRequestAndResponseWithAudit(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	hookProcessor, err := newHookProcessor(request, runner, hooks, nil, auditor, nil)
+	hookProcessor, err := newHookProcessor(request, runner, hooks, nil, auditor, nil, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -249,7 +249,7 @@ func TestMCPProxyHookAuditSavesEachMutationCombination(t *testing.T) {
 			if err != nil {
 				t.Fatal(err)
 			}
-			hookProcessor, err := newHookProcessor(request, runner, hooks, nil, auditor, nil)
+			hookProcessor, err := newHookProcessor(request, runner, hooks, nil, auditor, nil, nil)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -312,7 +312,7 @@ func TestMCPProxyHookAuditKeepsRequestMutationOnTransportError(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	_, err = newHookProcessor(request, runner, mcp.Hooks{{Name: "tools/call", Targets: []mcp.HookTarget{{Target: "policy/modify"}}}}, nil, auditor, nil)
+	_, err = newHookProcessor(request, runner, mcp.Hooks{{Name: "tools/call", Targets: []mcp.HookTarget{{Target: "policy/modify"}}}}, nil, auditor, nil, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -342,7 +342,7 @@ func TestMCPProxyHooksRejectRequestAndDisallowedMutation(t *testing.T) {
 				return result, true, nil
 			}}
 			hooks := mcp.Hooks{{Name: "tools/call", Targets: []mcp.HookTarget{{Target: "policy/check", MutateDisallowed: tt.mutateDisallowed}}}}
-			hookProcessor, err := newHookProcessor(mustMCPHookRequest(t, `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"echo"}}`), runner, hooks, nil, nil, nil)
+			hookProcessor, err := newHookProcessor(mustMCPHookRequest(t, `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"echo"}}`), runner, hooks, nil, nil, nil, nil)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -371,7 +371,7 @@ func TestMCPProxyHooksRejectResponse(t *testing.T) {
 		}
 		return mcp.SessionMessageHook{Accept: false, Message: input.Message, Reason: "response blocked"}, true, nil
 	}}
-	hookProcessor, err := newHookProcessor(mustMCPHookRequest(t, `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"echo"}}`), runner, mcp.Hooks{{Name: "tools/call", Targets: []mcp.HookTarget{{Target: "policy/check"}}}}, nil, nil, nil)
+	hookProcessor, err := newHookProcessor(mustMCPHookRequest(t, `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"echo"}}`), runner, mcp.Hooks{{Name: "tools/call", Targets: []mcp.HookTarget{{Target: "policy/check"}}}}, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -400,7 +400,7 @@ func TestMCPProxyHookBlockWithoutReasonStillExplainsFailure(t *testing.T) {
 		return mcp.SessionMessageHook{Accept: false, Message: input.Message}, true, nil
 	}}
 	hooks := mcp.Hooks{{Name: "tools/call", Targets: []mcp.HookTarget{{Target: "policy/check"}}}}
-	hookProcessor, err := newHookProcessor(mustMCPHookRequest(t, `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"echo"}}`), runner, hooks, nil, nil, nil)
+	hookProcessor, err := newHookProcessor(mustMCPHookRequest(t, `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"echo"}}`), runner, hooks, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -438,7 +438,7 @@ func TestMCPProxyHooksFilterLegacySSEAcrossProcessors(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	postHookProcessor, err := newHookProcessor(post, runner, hooks, nil, postAuditor, store)
+	postHookProcessor, err := newHookProcessor(post, runner, hooks, nil, postAuditor, store, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -468,7 +468,7 @@ func TestMCPProxyHooksFilterLegacySSEAcrossProcessors(t *testing.T) {
 	}
 	// This is deliberately a separate processor with no state from the POST,
 	// matching the behavior when the two requests land on different replicas.
-	getHookProcessor, err := newHookProcessor(get, runner, hooks, nil, getAuditor, store)
+	getHookProcessor, err := newHookProcessor(get, runner, hooks, nil, getAuditor, store, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -539,7 +539,7 @@ func TestMCPProxyHooksFilterServerRequestResponseAcrossProcessors(t *testing.T)
 	if err != nil {
 		t.Fatal(err)
 	}
-	getProcessor, err := newHookProcessor(get, runner, hooks, nil, getAuditor, store)
+	getProcessor, err := newHookProcessor(get, runner, hooks, nil, getAuditor, store, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -567,7 +567,7 @@ func TestMCPProxyHooksFilterServerRequestResponseAcrossProcessors(t *testing.T)
 	if err != nil {
 		t.Fatal(err)
 	}
-	if _, err := newHookProcessor(post, runner, hooks, nil, postAuditor, store); err != nil {
+	if _, err := newHookProcessor(post, runner, hooks, nil, postAuditor, store, nil); err != nil {
 		t.Fatal(err)
 	}
 	responseBody, err := io.ReadAll(post.Body)
@@ -602,7 +602,7 @@ func TestMCPProxyHooksFilterStreamableHTTPSSE(t *testing.T) {
 	}}
 	hooks := mcp.Hooks{{Name: "resources/read", Params: map[string]string{"name": "file:///readme"}, Targets: []mcp.HookTarget{{Target: "policy/redact"}}}}
 	request := mustMCPHookRequest(t, `{"jsonrpc":"2.0","id":"request-1","method":"resources/read","params":{"uri":"file:///readme"}}`)
-	hookProcessor, err := newHookProcessor(request, runner, hooks, nil, nil, nil)
+	hookProcessor, err := newHookProcessor(request, runner, hooks, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -638,6 +638,7 @@ func TestMCPProxyHooksInspectEncodedResponse(t *testing.T) {
 		nil,
 		nil,
 		nil,
+		nil,
 	)
 	if err != nil {
 		t.Fatal(err)
@@ -688,3 +689,141 @@ func mcpHookResponse(body string) *http.Response {
 		Body:       io.NopCloser(strings.NewReader(body)),
 	}
 }
+
+func TestMCPProxyToolCallGuardRejectsWithoutHooks(t *testing.T) {
+	guard := toolCallGuard{name: "tool-argument-constraints", check: func(call toolCall) (string, error) {
+		if call.Name == "sql_query" && !strings.Contains(string(call.Arguments), "SELECT") {
+			return "", &toolCallRejection{rpcError: mcp.ErrRPCInvalidParams.WithMessage("only SELECT is allowed")}
+		}
+		return "", nil
+	}}
+	collector := new(recordingProxyAuditCollector)
+	metadata := map[string]string{"mcpID": "mcp-1", "userID": "user-1"}
+
+	allowed := mustMCPHookRequest(t, `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"sql_query","arguments":{"query":"SELECT 1"}}}`)
+	processor, err := newHookProcessor(allowed, nil, nil, nil, nil, nil, nil, guard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, blocked, _ := processor.blockedRequest(); blocked {
+		t.Fatal("allowed tool call was blocked")
+	}
+
+	request := mustMCPHookRequest(t, `{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"sql_query","arguments":{"query":"DROP TABLE users"}}}`)
+	auditor, err := newProxyAudit(request, metadata, collector, newMCPProxyTestStorage())
+	if err != nil {
+		t.Fatal(err)
+	}
+	processor, err = newHookProcessor(request, nil, nil, nil, auditor, nil, nil, guard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	auditor.recordRequest()
+	body, blocked, guardErr := processor.blockedRequest()
+	if !blocked || guardErr == nil {
+		t.Fatal("rejected tool call was not blocked")
+	}
+	auditor.recordBlockedRequest(body, guardErr)
+
+	var response mcp.Message
+	if err := json.Unmarshal(body, &response); err != nil {
+		t.Fatal(err)
+	}
+	if response.Error == nil || response.Error.Code != mcp.ErrRPCInvalidParams.Code || !strings.Contains(response.Error.Message, "only SELECT is allowed") {
+		t.Fatalf("unexpected response: %s", body)
+	}
+	if mcp.MessageIDString(response.ID) != "2" {
+		t.Fatalf("response ID = %v, want 2", response.ID)
+	}
+
+	if len(collector.entries) != 2 {
+		t.Fatalf("got %d audit entries, want request and response", len(collector.entries))
+	}
+	requestEntry, responseEntry := collector.entries[0], collector.entries[1]
+	if len(requestEntry.WebhookStatuses) != 1 || requestEntry.WebhookStatuses[0].Status != "rejected" || requestEntry.WebhookStatuses[0].Tool != "sql_query" {
+		t.Fatalf("unexpected audit statuses: %+v", requestEntry.WebhookStatuses)
+	}
+	if !strings.Contains(responseEntry.Error, "tool-argument-constraints") {
+		t.Fatalf("audit error = %q, want the guard name", responseEntry.Error)
+	}
+}
+
+func TestMCPProxyToolCallGuardRejectsBatches(t *testing.T) {
+	var checked int
+	guard := toolCallGuard{name: "mcp-quota", check: func(toolCall) (string, error) {
+		checked++
+		return "", nil
+	}}
+
+	for name, body := range map[string]string{
+		"batch":           `[{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"deploy","arguments":{}}},{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"deploy","arguments":{}}}]`,
+		"multiple values": `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"deploy","arguments":{}}}{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"deploy","arguments":{}}}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			processor, err := newHookProcessor(mustMCPHookRequest(t, body), nil, nil, nil, nil, nil, nil, guard)
+			if err != nil {
+				t.Fatal(err)
+			}
+			response, blocked, guardErr := processor.blockedRequest()
+			if !blocked || guardErr == nil {
+				t.Fatal("request the guards could not check was not blocked")
+			}
+
+			var message mcp.Message
+			if err := json.Unmarshal(response, &message); err != nil {
+				t.Fatal(err)
+			}
+			if message.Error == nil || message.Error.Code != mcp.ErrRPCInvalidRequest.Code {
+				t.Fatalf("unexpected response: %s", response)
+			}
+		})
+	}
+	if checked != 0 {
+		t.Fatalf("guard checked %d calls, want none", checked)
+	}
+
+	// Without guards there is nothing to bypass, and the body is passed on.
+	processor, err := newHookProcessor(mustMCPHookRequest(t, `[{"jsonrpc":"2.0","id":1,"method":"ping"}]`), nil, nil, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, blocked, _ := processor.blockedRequest(); blocked {
+		t.Fatal("batch was blocked without guards")
+	}
+}
+
+func TestMCPProxyToolCallGuardRecordsNote(t *testing.T) {
+	var checked toolCall
+	guard := toolCallGuard{name: "tool-call-approval", check: func(call toolCall) (string, error) {
+		checked = call
+		return "approval mtca1abc approved by 2", nil
+	}}
+	collector := new(recordingProxyAuditCollector)
+	metadata := map[string]string{"mcpID": "mcp-1", "userID": "user-1"}
+
+	request := mustMCPHookRequest(t, `{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"deploy","arguments":{}}}`)
+	request.Header.Set(mcpSessionHeader, "session-1")
+	auditor, err := newProxyAudit(request, metadata, collector, newMCPProxyTestStorage())
+	if err != nil {
+		t.Fatal(err)
+	}
+	processor, err := newHookProcessor(request, nil, nil, nil, auditor, nil, nil, guard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, blocked, _ := processor.blockedRequest(); blocked {
+		t.Fatal("approved tool call was blocked")
+	}
+	if checked.sessionID != "session-1" || checked.requestID != "3" {
+		t.Fatalf("guard got session %q and request %q, want session-1 and 3", checked.sessionID, checked.requestID)
+	}
+
+	auditor.recordRequest()
+	if len(collector.entries) != 1 {
+		t.Fatalf("got %d audit entries, want the request", len(collector.entries))
+	}
+	statuses := collector.entries[0].WebhookStatuses
+	if len(statuses) != 1 || statuses[0].Status != "ok" || statuses[0].Message != "approval mtca1abc approved by 2" {
+		t.Fatalf("unexpected audit statuses: %+v", statuses)
+	}
+}
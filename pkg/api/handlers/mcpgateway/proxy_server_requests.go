@@ -0,0 +1,196 @@
+package mcpgateway
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/obot-platform/obot/pkg/api"
+	"github.com/obot-platform/obot/pkg/mcp"
+	"github.com/obot-platform/obot/pkg/mcpserverrequest"
+)
+
+const (
+	serverRequestGovernorName = "mcp-server-request-policy"
+	serverRequestReplyTimeout = 30 * time.Second
+)
+
+// serverRequestGovernor checks the requests an MCP server sends to the client
+// on a response stream, before hooks run and before they reach the client. A
+// request it rejects is dropped from the stream and answered with an error on
+// the client's behalf, so the server does not wait for a response that will
+// never come.
+type serverRequestGovernor struct {
+	check func(method string, params json.RawMessage, sessionID, requestID string) (mcpserverrequest.Result, error)
+	reply func(endpoint *url.URL, sessionID string, body []byte) error
+
+	// endpoint is where a server using the SSE transport takes client
+	// messages. Streamable HTTP servers take them at the MCP URL.
+	endpoint *url.URL
+}
+
+// serverRequestGovernor returns the governor for requests from the server to
+// the requesting user, or nil if they are not governed.
+func (h *Handler) serverRequestGovernor(req api.Context, serverConfig mcp.ServerConfig, client *http.Client, upstream *url.URL) *serverRequestGovernor {
+	if h.serverRequestHelper == nil || serverConfig.SystemMCPServer {
+		return nil
+	}
+
+	protocolVersion := req.Request.Header.Get("Mcp-Protocol-Version")
+	return &serverRequestGovernor{
+		check: func(method string, params json.RawMessage, sessionID, requestID string) (mcpserverrequest.Result, error) {
+			return h.serverRequestHelper.Check(req.Context(), req.User, mcpserverrequest.Request{
+				MCPID:                serverConfig.MCPServerName,
+				CatalogEntryName:     serverConfig.MCPCatalogEntryName,
+				MCPServerDisplayName: serverConfig.MCPServerDisplayName,
+				Method:               method,
+				Params:               params,
+				SessionID:            sessionID,
+				RequestID:            requestID,
+			})
+		},
+		reply: func(endpoint *url.URL, sessionID string, body []byte) error {
+			target := upstream
+			if endpoint != nil {
+				target = upstream.ResolveReference(endpoint)
+			}
+
+			// The stream may already be closing, but the server is still
+			// owed an answer.
+			ctx, cancel := context.WithTimeout(context.WithoutCancel(req.Context()), serverRequestReplyTimeout)
+			defer cancel()
+
+			reply, err := http.NewRequestWithContext(ctx, http.MethodPost, target.String(), bytes.NewReader(body))
+			if err != nil {
+				return err
+			}
+			reply.Header.Set("Content-Type", "application/json")
+			reply.Header.Set("Accept", "application/json, text/event-stream")
+			if sessionID != "" {
+				reply.Header.Set(mcpSessionHeader, sessionID)
+			}
+			if protocolVersion != "" {
+				reply.Header.Set("Mcp-Protocol-Version", protocolVersion)
+			}
+
+			resp, err := client.Do(reply)
+			if err != nil {
+				return err
+			}
+			defer resp.Body.Close()
+			_, _ = io.Copy(io.Discard, resp.Body)
+			if resp.StatusCode >= http.StatusBadRequest {
+				return fmt.Errorf("MCP server answered with status %d", resp.StatusCode)
+			}
+			return nil
+		},
+	}
+}
+
+// checkServerRequest asks the governor about a request the server sent on a
+// response stream. It may wait for an approval, so the stream calls it off the
+// goroutine that reads events.
+func (h *hookProcessor) checkServerRequest(message mcp.Message, sessionID string) (mcpserverrequest.Result, error) {
+	return h.serverRequests.check(message.Method, message.Params, sessionID, mcp.MessageIDString(message.ID))
+}
+
+// governServerRequest applies the governor's outcome to a request the server
+// sent on a response stream. It returns the request to pass on, possibly with
+// rewritten params, and the governor's outcome for the audit log. If the
+// request is rejected, it is answered and recorded here, and ok is false.
+func (h *hookProcessor) governServerRequest(body []byte, message mcp.Message, result mcpserverrequest.Result, err error) (_ []byte, _ mcp.Message, _ hookResult, ok bool) {
+	if err != nil {
+		status := hookStatus{typeName: "request", method: message.Method, name: serverRequestGovernorName, status: "rejected", message: err.Error()}
+		rpcError := mcp.ErrRPCUnknown.WithMessage("failed to check server request: %v", err)
+		if rejection, ok := errors.AsType[*mcpserverrequest.Rejection](err); ok {
+			rpcError = serverRequestDeniedError(rejection)
+		} else {
+			status.status = "error"
+		}
+
+		h.rejectServerRequest(body, message, rpcError, status, fmt.Errorf("server request rejected by %s: %w", serverRequestGovernorName, err))
+		return nil, message, hookResult{}, false
+	}
+
+	governed := hookResult{originalBody: jsonBody(body)}
+	if result.Params != nil {
+		message.Params = result.Params
+		mutated, err := json.Marshal(message)
+		if err != nil {
+			// The rewrite only narrows what the client is offered, so the
+			// request is passed on as the server sent it rather than dropped.
+			return body, message, hookResult{}, true
+		}
+		body = mutated
+		governed.mutated = true
+		governed.mutatedBody = jsonBody(body)
+	}
+	if result.Note != "" || governed.mutated {
+		status := "ok"
+		if governed.mutated {
+			status = "mutated"
+		}
+		governed.statuses = []hookStatus{{typeName: "request", method: message.Method, name: serverRequestGovernorName, status: status, message: result.Note}}
+	}
+	return body, message, governed, true
+}
+
+// rejectUngovernableEvent handles a stream event the governor cannot read,
+// such as a batch. It never reaches the client, and any requests in a batch
+// are answered so that the server does not wait on them.
+func (h *hookProcessor) rejectUngovernableEvent(body []byte, decodeErr error) {
+	var batch []json.RawMessage
+	if json.Unmarshal(body, &batch) != nil {
+		return
+	}
+
+	rpcError := mcp.ErrRPCInvalidRequest.WithMessage("expected a single JSON-RPC message")
+	for _, raw := range batch {
+		var message mcp.Message
+		if decodeMCPHookMessage(raw, &message) != nil || message.Method == "" || mcp.MessageIDString(message.ID) == "" {
+			continue
+		}
+		status := hookStatus{typeName: "request", method: message.Method, name: serverRequestGovernorName, status: "rejected", message: rpcError.Message}
+		h.rejectServerRequest(raw, message, rpcError, status, fmt.Errorf("server request rejected by %s: %w", serverRequestGovernorName, decodeErr))
+	}
+}
+
+// rejectServerRequest answers a server request on the client's behalf and
+// records it.
+func (h *hookProcessor) rejectServerRequest(body []byte, message mcp.Message, rpcError *mcp.RPCError, status hookStatus, err error) {
+	response := mcpErrorResponse(message, rpcError)
+	if replyErr := h.serverRequests.reply(h.serverRequests.endpoint, h.sessionID, response); replyErr != nil {
+		err = errors.Join(err, fmt.Errorf("failed to send rejection to MCP server: %w", replyErr))
+	}
+	h.audit.recordRejectedServerRequest(body, response, []hookStatus{status}, err)
+}
+
+// serverRequestDeniedError tells the server why its request did not reach the
+// client.
+func serverRequestDeniedError(rejection *mcpserverrequest.Rejection) *mcp.RPCError {
+	return mcp.ErrRPCServerRequestDenied.WithMessage("%s", rejection.Message).WithData(mcp.ServerRequestDeniedData{
+		Reason:     rejection.Reason,
+		ApprovalID: rejection.ApprovalID,
+		State:      string(rejection.State),
+	})
+}
+
+// follow records that the checks in before ran ahead of the ones in r: their
+// statuses come first, and the body they were given is the original one.
+func (r *hookResult) follow(before hookResult) {
+	if len(before.statuses) == 0 && !before.mutated {
+		return
+	}
+	r.statuses = append(before.statuses, r.statuses...)
+	r.originalBody = before.originalBody
+	if before.mutated && !r.mutated {
+		r.mutated = true
+		r.mutatedBody = before.mutatedBody
+	}
+}
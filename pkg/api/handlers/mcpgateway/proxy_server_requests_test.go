@@ -0,0 +1,320 @@
+package mcpgateway
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/obot-platform/nanobot/pkg/mcp/auditlogs"
+	"github.com/obot-platform/obot/pkg/mcp"
+	"github.com/obot-platform/obot/pkg/mcpserverrequest"
+)
+
+func TestMCPProxyServerRequestRejectedWithoutHooks(t *testing.T) {
+	var (
+		replyEndpoint *url.URL
+		replySession  string
+		replyBody     []byte
+	)
+	governor := &serverRequestGovernor{
+		check: func(method string, _ json.RawMessage, _, _ string) (mcpserverrequest.Result, error) {
+			if method == "sampling/createMessage" {
+				return mcpserverrequest.Result{}, &mcpserverrequest.Rejection{Reason: mcpserverrequest.ReasonDenied, Message: "sampling is denied"}
+			}
+			return mcpserverrequest.Result{}, nil
+		},
+		reply: func(endpoint *url.URL, sessionID string, body []byte) error {
+			replyEndpoint, replySession, replyBody = endpoint, sessionID, body
+			return nil
+		},
+	}
+	collector := new(recordingProxyAuditCollector)
+	metadata := map[string]string{"mcpID": "mcp-1", "userID": "user-1"}
+
+	get, err := http.NewRequest(http.MethodGet, "http://obot.example/sse", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	auditor, err := newProxyAudit(get, metadata, collector, newMCPProxyTestStorage())
+	if err != nil {
+		t.Fatal(err)
+	}
+	processor, err := newHookProcessor(get, nil, nil, nil, auditor, nil, governor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stream := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"text/event-stream"}},
+		Body: io.NopCloser(strings.NewReader("event: endpoint\ndata: /messages?sessionId=session-1\n\n" +
+			"event: message\ndata: {\"jsonrpc\":\"2.0\",\"id\":4,\"method\":\"sampling/createMessage\",\"params\":{\"messages\":[]}}\n\n" +
+			"event: message\ndata: {\"jsonrpc\":\"2.0\",\"id\":5,\"method\":\"roots/list\"}\n\n")),
+	}
+	if err := processor.filterResponse(stream); err != nil {
+		t.Fatal(err)
+	}
+	if err := auditor.wrapResponse(stream); err != nil {
+		t.Fatal(err)
+	}
+	output, err := io.ReadAll(stream.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(string(output), "sampling/createMessage") || !strings.Contains(string(output), "roots/list") {
+		t.Fatalf("unexpected client stream: %s", output)
+	}
+	if replyEndpoint == nil || replyEndpoint.String() != "/messages?sessionId=session-1" || replySession != "session-1" {
+		t.Fatalf("reply sent to %v for session %q, want the SSE endpoint for session-1", replyEndpoint, replySession)
+	}
+
+	var reply struct {
+		ID    any `json:"id"`
+		Error struct {
+			Code    int                         `json:"code"`
+			Message string                      `json:"message"`
+			Data    mcp.ServerRequestDeniedData `json:"data"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(replyBody, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if mcp.MessageIDString(reply.ID) != "4" || reply.Error.Code != mcp.ErrRPCServerRequestDenied.Code ||
+		!strings.Contains(reply.Error.Message, "sampling is denied") || reply.Error.Data.Reason != mcpserverrequest.ReasonDenied {
+		t.Fatalf("unexpected reply: %s", replyBody)
+	}
+
+	if len(collector.entries) != 2 {
+		t.Fatalf("got %d audit entries, want the rejected request and roots/list", len(collector.entries))
+	}
+	// Server requests are checked concurrently, so the entries may be in
+	// either order.
+	i := slices.IndexFunc(collector.entries, func(entry auditlogs.MCPAuditLog) bool { return entry.CallType == "sampling/createMessage" })
+	if i < 0 {
+		t.Fatalf("no audit entry for the rejected request: %+v", collector.entries)
+	}
+	rejected := collector.entries[i]
+	if !collector.received[i] || !strings.Contains(string(rejected.ResponseBody), "sampling is denied") {
+		t.Fatalf("unexpected rejected request entry: %+v", rejected)
+	}
+	if len(rejected.WebhookStatuses) != 1 || rejected.WebhookStatuses[0].Status != "rejected" || rejected.WebhookStatuses[0].Name != serverRequestGovernorName {
+		t.Fatalf("unexpected audit statuses: %+v", rejected.WebhookStatuses)
+	}
+	if !strings.Contains(rejected.Error, serverRequestGovernorName) {
+		t.Fatalf("audit error = %q, want the governor name", rejected.Error)
+	}
+}
+
+func TestMCPProxyServerRequestRewritten(t *testing.T) {
+	governor := &serverRequestGovernor{
+		check: func(method string, _ json.RawMessage, sessionID, requestID string) (mcpserverrequest.Result, error) {
+			if sessionID != "session-1" || requestID != "4" {
+				t.Errorf("governor got session %q and request %q, want session-1 and 4", sessionID, requestID)
+			}
+			return mcpserverrequest.Result{
+				Params: json.RawMessage(`{"messages":[],"modelPreferences":{"hints":[{"name":"gpt-5"}]}}`),
+				Note:   "replaced model hints with the models the user may use",
+			}, nil
+		},
+		reply: func(*url.URL, string, []byte) error {
+			t.Error("allowed request was answered by the gateway")
+			return nil
+		},
+	}
+	collector := new(recordingProxyAuditCollector)
+	metadata := map[string]string{"mcpID": "mcp-1", "userID": "user-1"}
+
+	get, err := http.NewRequest(http.MethodGet, "http://obot.example/mcp", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	get.Header.Set(mcpSessionHeader, "session-1")
+	auditor, err := newProxyAudit(get, metadata, collector, newMCPProxyTestStorage())
+	if err != nil {
+		t.Fatal(err)
+	}
+	processor, err := newHookProcessor(get, nil, nil, nil, auditor, nil, governor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stream := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"text/event-stream"}},
+		Body:       io.NopCloser(strings.NewReader("event: message\ndata: {\"jsonrpc\":\"2.0\",\"id\":4,\"method\":\"sampling/createMessage\",\"params\":{\"messages\":[],\"modelPreferences\":{\"hints\":[{\"name\":\"internal-model\"}]}}}\n\n")),
+	}
+	if err := processor.filterResponse(stream); err != nil {
+		t.Fatal(err)
+	}
+	if err := auditor.wrapResponse(stream); err != nil {
+		t.Fatal(err)
+	}
+	output, err := io.ReadAll(stream.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(string(output), "internal-model") || !strings.Contains(string(output), "gpt-5") {
+		t.Fatalf("request was not rewritten: %s", output)
+	}
+	if len(collector.entries) != 1 || collector.received[0] {
+		t.Fatalf("unexpected audit entries: count=%d received=%v", len(collector.entries), collector.received)
+	}
+	entry := collector.entries[0]
+	if !strings.Contains(string(entry.RequestBody), "internal-model") || !strings.Contains(string(entry.MutatedRequestBody), "gpt-5") {
+		t.Fatalf("audit entry does not hold both bodies: request=%s mutated=%s", entry.RequestBody, entry.MutatedRequestBody)
+	}
+	if len(entry.WebhookStatuses) != 1 || entry.WebhookStatuses[0].Status != "mutated" || !strings.Contains(entry.WebhookStatuses[0].Message, "model hints") {
+		t.Fatalf("unexpected audit statuses: %+v", entry.WebhookStatuses)
+	}
+}
+
+func TestMCPProxyAuditsServerRequestResponseWithoutHooks(t *testing.T) {
+	collector := new(recordingProxyAuditCollector)
+	metadata := map[string]string{"mcpID": "mcp-1", "userID": "user-1"}
+
+	post := mustMCPHookRequest(t, `{"jsonrpc":"2.0","id":4,"result":{"action":"accept","content":{"name":"Ada"}}}`)
+	post.Header.Set(mcpSessionHeader, "session-1")
+	auditor, err := newProxyAudit(post, metadata, collector, newMCPProxyTestStorage())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := newHookProcessor(post, nil, nil, nil, auditor, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	auditor.recordRequest()
+
+	if len(collector.entries) != 1 || !collector.received[0] {
+		t.Fatalf("unexpected audit entries: count=%d received=%v", len(collector.entries), collector.received)
+	}
+	entry := collector.entries[0]
+	if entry.CallType != "response" || entry.RequestID != "4" || !strings.Contains(string(entry.ResponseBody), "Ada") {
+		t.Fatalf("unexpected response entry: %+v", entry)
+	}
+}
+
+func TestMCPProxyServerRequestBatchRejected(t *testing.T) {
+	var replyBody []byte
+	governor := &serverRequestGovernor{
+		check: func(string, json.RawMessage, string, string) (mcpserverrequest.Result, error) {
+			t.Error("the governor was asked about a request in a batch")
+			return mcpserverrequest.Result{}, nil
+		},
+		reply: func(_ *url.URL, _ string, body []byte) error {
+			replyBody = body
+			return nil
+		},
+	}
+
+	get, err := http.NewRequest(http.MethodGet, "http://obot.example/mcp", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	get.Header.Set(mcpSessionHeader, "session-1")
+	processor, err := newHookProcessor(get, nil, nil, nil, nil, nil, governor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stream := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"text/event-stream"}},
+		Body: io.NopCloser(strings.NewReader("event: message\ndata: [{\"jsonrpc\":\"2.0\",\"id\":4,\"method\":\"sampling/createMessage\",\"params\":{\"messages\":[]}}]\n\n" +
+			"event: message\ndata: {\"jsonrpc\":\"2.0\",\"method\":\"notifications/progress\"}\n\n")),
+	}
+	if err := processor.filterResponse(stream); err != nil {
+		t.Fatal(err)
+	}
+	output, err := io.ReadAll(stream.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(string(output), "sampling/createMessage") || !strings.Contains(string(output), "notifications/progress") {
+		t.Fatalf("unexpected client stream: %s", output)
+	}
+	var reply struct {
+		ID    any          `json:"id"`
+		Error mcp.RPCError `json:"error"`
+	}
+	if err := json.Unmarshal(replyBody, &reply); err != nil {
+		t.Fatalf("batched request was not answered: %v", err)
+	}
+	if mcp.MessageIDString(reply.ID) != "4" || reply.Error.Code != mcp.ErrRPCInvalidRequest.Code {
+		t.Fatalf("unexpected reply: %s", replyBody)
+	}
+}
+
+func TestMCPProxyServerRequestHeldWithoutBlockingStream(t *testing.T) {
+	release := make(chan struct{})
+	governor := &serverRequestGovernor{
+		check: func(method string, _ json.RawMessage, _, _ string) (mcpserverrequest.Result, error) {
+			if method == "elicitation/create" {
+				<-release
+			}
+			return mcpserverrequest.Result{}, nil
+		},
+		reply: func(*url.URL, string, []byte) error {
+			t.Error("allowed request was answered by the gateway")
+			return nil
+		},
+	}
+
+	get, err := http.NewRequest(http.MethodGet, "http://obot.example/mcp", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	get.Header.Set(mcpSessionHeader, "session-1")
+	processor, err := newHookProcessor(get, nil, nil, nil, nil, nil, governor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stream := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"text/event-stream"}},
+		Body: io.NopCloser(strings.NewReader("event: message\ndata: {\"jsonrpc\":\"2.0\",\"id\":4,\"method\":\"elicitation/create\",\"params\":{}}\n\n" +
+			"event: message\ndata: {\"jsonrpc\":\"2.0\",\"id\":1,\"result\":{}}\n\n")),
+	}
+	if err := processor.filterResponse(stream); err != nil {
+		t.Fatal(err)
+	}
+	defer stream.Body.Close()
+
+	// The response that follows the held request reaches the client first.
+	lines := make(chan string)
+	go func() {
+		defer close(lines)
+		scanner := bufio.NewScanner(stream.Body)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+	}()
+	next := func() string {
+		t.Helper()
+		for {
+			select {
+			case line, ok := <-lines:
+				if !ok {
+					t.Fatal("stream ended early")
+				}
+				if data, ok := strings.CutPrefix(line, "data: "); ok {
+					return data
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("timed out waiting for the stream")
+			}
+		}
+	}
+
+	if data := next(); !strings.Contains(data, `"result"`) {
+		t.Fatalf("first event = %s, want the response", data)
+	}
+	close(release)
+	if data := next(); !strings.Contains(data, "elicitation/create") {
+		t.Fatalf("second event = %s, want the held request", data)
+	}
+}
@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"fmt"
+
+	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/obot-platform/obot/pkg/api"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	"github.com/obot-platform/obot/pkg/system"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+type MCPQuotaRuleHandler struct{}
+
+func NewMCPQuotaRuleHandler() *MCPQuotaRuleHandler {
+	return nil
+}
+
+// List returns all MCP quota rules.
+func (*MCPQuotaRuleHandler) List(req api.Context) error {
+	var list v1.MCPQuotaRuleList
+	if err := req.List(&list); err != nil {
+		return fmt.Errorf("failed to list MCP quota rules: %w", err)
+	}
+
+	items := make([]types.MCPQuotaRule, 0, len(list.Items))
+	for _, item := range list.Items {
+		items = append(items, convertMCPQuotaRule(item))
+	}
+
+	return req.Write(types.MCPQuotaRuleList{
+		Items: items,
+	})
+}
+
+// Get returns a specific MCP quota rule by ID.
+func (*MCPQuotaRuleHandler) Get(req api.Context) error {
+	var rule v1.MCPQuotaRule
+	if err := req.Get(&rule, req.PathValue("id")); err != nil {
+		return fmt.Errorf("failed to get MCP quota rule: %w", err)
+	}
+
+	return req.Write(convertMCPQuotaRule(rule))
+}
+
+// Create creates a new MCP quota rule.
+func (*MCPQuotaRuleHandler) Create(req api.Context) error {
+	var manifest types.MCPQuotaRuleManifest
+	if err := req.Read(&manifest); err != nil {
+		return types.NewErrBadRequest("failed to read MCP quota rule manifest: %v", err)
+	}
+
+	if err := manifest.Validate(); err != nil {
+		return types.NewErrBadRequest("invalid MCP quota rule manifest: %v", err)
+	}
+
+	rule := v1.MCPQuotaRule{
+		GenerateName: system.MCPQuotaRulePrefix,
+		Namespace:    req.Namespace(),
+		Spec: v1.MCPQuotaRuleSpec{
+			Manifest: manifest,
+		},
+	}
+
+	if err := req.Create(&rule); err != nil {
+		return fmt.Errorf("failed to create MCP quota rule: %w", err)
+	}
+	recordConfigChange(req, types.ConfigChangeKindMCPQuotaRule, rule.Name, nil, rule.Spec, 0)
+
+	return req.Write(convertMCPQuotaRule(rule))
+}
+
+// Update updates an existing MCP quota rule. Calls already counted against
+// the rule's quotas in the current windows still count against the new limits.
+func (*MCPQuotaRuleHandler) Update(req api.Context) error {
+	var manifest types.MCPQuotaRuleManifest
+	if err := req.Read(&manifest); err != nil {
+		return types.NewErrBadRequest("failed to read MCP quota rule manifest: %v", err)
+	}
+
+	if err := manifest.Validate(); err != nil {
+		return types.NewErrBadRequest("invalid MCP quota rule manifest: %v", err)
+	}
+
+	var existing v1.MCPQuotaRule
+	if err := req.Get(&existing, req.PathValue("id")); err != nil {
+		return fmt.Errorf("failed to get MCP quota rule: %w", err)
+	}
+
+	before := existing.Spec
+	existing.Spec.Manifest = manifest
+	if err := req.Update(&existing); err != nil {
+		return fmt.Errorf("failed to update MCP quota rule: %w", err)
+	}
+	recordConfigChange(req, types.ConfigChangeKindMCPQuotaRule, existing.Name, before, existing.Spec, 0)
+
+	return req.Write(convertMCPQuotaRule(existing))
+}
+
+// Delete deletes an MCP quota rule.
+func (*MCPQuotaRuleHandler) Delete(req api.Context) error {
+	var existing v1.MCPQuotaRule
+	if err := req.Get(&existing, req.PathValue("id")); apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to get MCP quota rule: %w", err)
+	}
+
+	if err := req.Delete(&existing); err != nil {
+		return err
+	}
+	recordConfigChange(req, types.ConfigChangeKindMCPQuotaRule, existing.Name, existing.Spec, nil, 0)
+	return nil
+}
+
+func convertMCPQuotaRule(rule v1.MCPQuotaRule) types.MCPQuotaRule {
+	return types.MCPQuotaRule{
+		Metadata:             MetadataFrom(&rule),
+		MCPQuotaRuleManifest: rule.Spec.Manifest,
+	}
+}
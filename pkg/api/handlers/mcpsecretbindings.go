@@ -4,34 +4,23 @@ import (
 	"github.com/obot-platform/obot/apiclient/types"
 	"github.com/obot-platform/obot/pkg/api"
 	"github.com/obot-platform/obot/pkg/mcp"
-	kclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-// MCPSecretBindingHandler serves admin lookup APIs for Kubernetes Secrets allowed for MCP secret bindings.
-type MCPSecretBindingHandler struct {
-	allowedLabel string
-	backend      string
-	k8sClient    kclient.Client
-	namespace    string
-}
+// MCPSecretBindingHandler serves admin lookup APIs for secrets allowed for MCP secret bindings.
+type MCPSecretBindingHandler struct{}
 
 // NewMCPSecretBindingHandler creates an MCP secret-binding lookup handler.
-func NewMCPSecretBindingHandler(backend string, k8sClient kclient.Client, namespace, allowedLabel string) *MCPSecretBindingHandler {
-	return &MCPSecretBindingHandler{
-		allowedLabel: allowedLabel,
-		backend:      backend,
-		k8sClient:    k8sClient,
-		namespace:    namespace,
-	}
+func NewMCPSecretBindingHandler() *MCPSecretBindingHandler {
+	return &MCPSecretBindingHandler{}
 }
 
-// ListAllowedSecrets lists bindable Kubernetes Secrets without exposing secret values.
+// ListAllowedSecrets lists bindable secrets from the configured secret provider without exposing secret values.
 func (h *MCPSecretBindingHandler) ListAllowedSecrets(req api.Context) error {
-	if !mcp.IsKubernetesBackend(h.backend) || h.k8sClient == nil {
+	if req.SecretProvider == nil {
 		return req.Write(types.MCPAllowedSecretBindingTargetList{Items: []types.MCPAllowedSecretBindingTarget{}})
 	}
 
-	targets, err := mcp.ListAllowedSecretBindingTargets(req.Context(), h.k8sClient, h.namespace, h.allowedLabel)
+	targets, err := mcp.ListAllowedSecretBindingTargets(req.Context(), req.SecretProvider)
 	if err != nil {
 		return err
 	}
@@ -8,7 +8,7 @@ import (
 
 	"github.com/obot-platform/obot/apiclient/types"
 	"github.com/obot-platform/obot/pkg/api"
-	"github.com/obot-platform/obot/pkg/mcp"
+	"github.com/obot-platform/obot/pkg/secretprovider"
 	"github.com/obot-platform/obot/pkg/system"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -20,8 +20,7 @@ const (
 )
 
 func TestListAllowedSecrets(t *testing.T) {
-	handler := NewMCPSecretBindingHandler(
-		mcp.RuntimeBackendKubernetes,
+	secretProvider := secretprovider.NewKubernetes(
 		newCreateServerSecretBindingK8sClient(t, &corev1.Secret{
 			Name:      "source-secret",
 			Namespace: system.DefaultNamespace,
@@ -43,9 +42,10 @@ func TestListAllowedSecrets(t *testing.T) {
 	req := httptest.NewRequest(http.MethodGet, "/api/mcp-server-binding-secrets", nil)
 	rec := httptest.NewRecorder()
 
-	err := handler.ListAllowedSecrets(api.Context{
+	err := NewMCPSecretBindingHandler().ListAllowedSecrets(api.Context{
 		ResponseWriter: rec,
 		Request:        req,
+		SecretProvider: secretProvider,
 	})
 
 	require.NoError(t, err)
@@ -56,22 +56,11 @@ func TestListAllowedSecrets(t *testing.T) {
 	assert.Equal(t, []types.MCPAllowedSecretBindingTarget{{Name: "source-secret", Keys: []string{"token"}}}, result.Items)
 }
 
-func TestListAllowedSecretsReturnsEmptyForNonKubernetesBackend(t *testing.T) {
-	handler := NewMCPSecretBindingHandler(
-		"docker",
-		newCreateServerSecretBindingK8sClient(t, &corev1.Secret{
-			Name:      "source-secret",
-			Namespace: system.DefaultNamespace,
-			Labels:    map[string]string{testSecretBindingAllowedLabel: "true"},
-			Data:      map[string][]byte{"token": []byte("secret-token")},
-		}),
-		system.DefaultNamespace,
-		testSecretBindingAllowedLabel,
-	)
+func TestListAllowedSecretsReturnsEmptyWithoutSecretProvider(t *testing.T) {
 	req := httptest.NewRequest(http.MethodGet, "/api/mcp-server-binding-secrets", nil)
 	rec := httptest.NewRecorder()
 
-	err := handler.ListAllowedSecrets(api.Context{
+	err := NewMCPSecretBindingHandler().ListAllowedSecrets(api.Context{
 		ResponseWriter: rec,
 		Request:        req,
 	})
@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"fmt"
+
+	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/obot-platform/obot/pkg/api"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	"github.com/obot-platform/obot/pkg/system"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+type MCPServerRequestPolicyHandler struct{}
+
+func NewMCPServerRequestPolicyHandler() *MCPServerRequestPolicyHandler {
+	return nil
+}
+
+// List returns all MCP server request policies.
+func (*MCPServerRequestPolicyHandler) List(req api.Context) error {
+	var list v1.MCPServerRequestPolicyList
+	if err := req.List(&list); err != nil {
+		return fmt.Errorf("failed to list MCP server request policies: %w", err)
+	}
+
+	items := make([]types.MCPServerRequestPolicy, 0, len(list.Items))
+	for _, item := range list.Items {
+		items = append(items, convertMCPServerRequestPolicy(item))
+	}
+
+	return req.Write(types.MCPServerRequestPolicyList{
+		Items: items,
+	})
+}
+
+// Get returns a specific MCP server request policy by ID.
+func (*MCPServerRequestPolicyHandler) Get(req api.Context) error {
+	var policy v1.MCPServerRequestPolicy
+	if err := req.Get(&policy, req.PathValue("id")); err != nil {
+		return fmt.Errorf("failed to get MCP server request policy: %w", err)
+	}
+
+	return req.Write(convertMCPServerRequestPolicy(policy))
+}
+
+// Create creates a new MCP server request policy.
+func (*MCPServerRequestPolicyHandler) Create(req api.Context) error {
+	var manifest types.MCPServerRequestPolicyManifest
+	if err := req.Read(&manifest); err != nil {
+		return types.NewErrBadRequest("failed to read MCP server request policy manifest: %v", err)
+	}
+
+	if err := manifest.Validate(); err != nil {
+		return types.NewErrBadRequest("invalid MCP server request policy manifest: %v", err)
+	}
+
+	policy := v1.MCPServerRequestPolicy{
+		GenerateName: system.MCPServerRequestPolicyPrefix,
+		Namespace:    req.Namespace(),
+		Spec: v1.MCPServerRequestPolicySpec{
+			Manifest: manifest,
+		},
+	}
+
+	if err := req.Create(&policy); err != nil {
+		return fmt.Errorf("failed to create MCP server request policy: %w", err)
+	}
+	recordConfigChange(req, types.ConfigChangeKindMCPServerRequestPolicy, policy.Name, nil, policy.Spec, 0)
+
+	return req.Write(convertMCPServerRequestPolicy(policy))
+}
+
+// Update updates an existing MCP server request policy. Requests already held
+// for approval keep the approvers and timeout they were held with.
+func (*MCPServerRequestPolicyHandler) Update(req api.Context) error {
+	var manifest types.MCPServerRequestPolicyManifest
+	if err := req.Read(&manifest); err != nil {
+		return types.NewErrBadRequest("failed to read MCP server request policy manifest: %v", err)
+	}
+
+	if err := manifest.Validate(); err != nil {
+		return types.NewErrBadRequest("invalid MCP server request policy manifest: %v", err)
+	}
+
+	var existing v1.MCPServerRequestPolicy
+	if err := req.Get(&existing, req.PathValue("id")); err != nil {
+		return fmt.Errorf("failed to get MCP server request policy: %w", err)
+	}
+
+	before := existing.Spec
+	existing.Spec.Manifest = manifest
+	if err := req.Update(&existing); err != nil {
+		return fmt.Errorf("failed to update MCP server request policy: %w", err)
+	}
+	recordConfigChange(req, types.ConfigChangeKindMCPServerRequestPolicy, existing.Name, before, existing.Spec, 0)
+
+	return req.Write(convertMCPServerRequestPolicy(existing))
+}
+
+// Delete deletes an MCP server request policy.
+func (*MCPServerRequestPolicyHandler) Delete(req api.Context) error {
+	var existing v1.MCPServerRequestPolicy
+	if err := req.Get(&existing, req.PathValue("id")); apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to get MCP server request policy: %w", err)
+	}
+
+	if err := req.Delete(&existing); err != nil {
+		return err
+	}
+	recordConfigChange(req, types.ConfigChangeKindMCPServerRequestPolicy, existing.Name, existing.Spec, nil, 0)
+	return nil
+}
+
+func convertMCPServerRequestPolicy(policy v1.MCPServerRequestPolicy) types.MCPServerRequestPolicy {
+	return types.MCPServerRequestPolicy{
+		Metadata:                       MetadataFrom(&policy),
+		MCPServerRequestPolicyManifest: policy.Spec.Manifest,
+	}
+}
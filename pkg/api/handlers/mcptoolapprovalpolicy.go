@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"fmt"
+
+	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/obot-platform/obot/pkg/api"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	"github.com/obot-platform/obot/pkg/system"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+type MCPToolApprovalPolicyHandler struct{}
+
+func NewMCPToolApprovalPolicyHandler() *MCPToolApprovalPolicyHandler {
+	return nil
+}
+
+// List returns all MCP tool approval policies.
+func (*MCPToolApprovalPolicyHandler) List(req api.Context) error {
+	var list v1.MCPToolApprovalPolicyList
+	if err := req.List(&list); err != nil {
+		return fmt.Errorf("failed to list MCP tool approval policies: %w", err)
+	}
+
+	items := make([]types.MCPToolApprovalPolicy, 0, len(list.Items))
+	for _, item := range list.Items {
+		items = append(items, convertMCPToolApprovalPolicy(item))
+	}
+
+	return req.Write(types.MCPToolApprovalPolicyList{
+		Items: items,
+	})
+}
+
+// Get returns a specific MCP tool approval policy by ID.
+func (*MCPToolApprovalPolicyHandler) Get(req api.Context) error {
+	var policy v1.MCPToolApprovalPolicy
+	if err := req.Get(&policy, req.PathValue("id")); err != nil {
+		return fmt.Errorf("failed to get MCP tool approval policy: %w", err)
+	}
+
+	return req.Write(convertMCPToolApprovalPolicy(policy))
+}
+
+// Create creates a new MCP tool approval policy.
+func (*MCPToolApprovalPolicyHandler) Create(req api.Context) error {
+	var manifest types.MCPToolApprovalPolicyManifest
+	if err := req.Read(&manifest); err != nil {
+		return types.NewErrBadRequest("failed to read MCP tool approval policy manifest: %v", err)
+	}
+
+	if err := manifest.Validate(); err != nil {
+		return types.NewErrBadRequest("invalid MCP tool approval policy manifest: %v", err)
+	}
+
+	policy := v1.MCPToolApprovalPolicy{
+		GenerateName: system.MCPToolApprovalPolicyPrefix,
+		Namespace:    req.Namespace(),
+		Spec: v1.MCPToolApprovalPolicySpec{
+			Manifest: manifest,
+		},
+	}
+
+	if err := req.Create(&policy); err != nil {
+		return fmt.Errorf("failed to create MCP tool approval policy: %w", err)
+	}
+	recordConfigChange(req, types.ConfigChangeKindMCPToolApprovalPolicy, policy.Name, nil, policy.Spec, 0)
+
+	return req.Write(convertMCPToolApprovalPolicy(policy))
+}
+
+// Update updates an existing MCP tool approval policy. Calls already held for
+// approval keep the approvers and timeout they were held with.
+func (*MCPToolApprovalPolicyHandler) Update(req api.Context) error {
+	var manifest types.MCPToolApprovalPolicyManifest
+	if err := req.Read(&manifest); err != nil {
+		return types.NewErrBadRequest("failed to read MCP tool approval policy manifest: %v", err)
+	}
+
+	if err := manifest.Validate(); err != nil {
+		return types.NewErrBadRequest("invalid MCP tool approval policy manifest: %v", err)
+	}
+
+	var existing v1.MCPToolApprovalPolicy
+	if err := req.Get(&existing, req.PathValue("id")); err != nil {
+		return fmt.Errorf("failed to get MCP tool approval policy: %w", err)
+	}
+
+	before := existing.Spec
+	existing.Spec.Manifest = manifest
+	if err := req.Update(&existing); err != nil {
+		return fmt.Errorf("failed to update MCP tool approval policy: %w", err)
+	}
+	recordConfigChange(req, types.ConfigChangeKindMCPToolApprovalPolicy, existing.Name, before, existing.Spec, 0)
+
+	return req.Write(convertMCPToolApprovalPolicy(existing))
+}
+
+// Delete deletes an MCP tool approval policy.
+func (*MCPToolApprovalPolicyHandler) Delete(req api.Context) error {
+	var existing v1.MCPToolApprovalPolicy
+	if err := req.Get(&existing, req.PathValue("id")); apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to get MCP tool approval policy: %w", err)
+	}
+
+	if err := req.Delete(&existing); err != nil {
+		return err
+	}
+	recordConfigChange(req, types.ConfigChangeKindMCPToolApprovalPolicy, existing.Name, existing.Spec, nil, 0)
+	return nil
+}
+
+func convertMCPToolApprovalPolicy(policy v1.MCPToolApprovalPolicy) types.MCPToolApprovalPolicy {
+	return types.MCPToolApprovalPolicy{
+		Metadata:                      MetadataFrom(&policy),
+		MCPToolApprovalPolicyManifest: policy.Spec.Manifest,
+	}
+}
@@ -0,0 +1,178 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/obot-platform/obot/pkg/api"
+	"github.com/obot-platform/obot/pkg/publishedartifact"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// MCPToolCallApprovalHandler lets approvers decide on tool calls the MCP
+// gateway is holding because an MCP tool approval policy requires it. The
+// gateway releases or rejects the call once the approval is decided.
+type MCPToolCallApprovalHandler struct{}
+
+func NewMCPToolCallApprovalHandler() *MCPToolCallApprovalHandler {
+	return &MCPToolCallApprovalHandler{}
+}
+
+// List returns tool call approvals, newest first. Owners, admins and auditors
+// see every approval; other users see the calls they made and the calls they
+// can decide. The state query parameter filters by state, e.g. state=pending.
+func (*MCPToolCallApprovalHandler) List(req api.Context) error {
+	selector := map[string]string{}
+	if state := req.URL.Query().Get("state"); state != "" {
+		selector["spec.state"] = state
+	}
+
+	var list v1.MCPToolCallApprovalList
+	if err := req.List(&list, &kclient.ListOptions{
+		Namespace:     req.Namespace(),
+		FieldSelector: fields.SelectorFromSet(selector),
+	}); err != nil {
+		return fmt.Errorf("failed to list tool call approvals: %w", err)
+	}
+
+	slices.SortFunc(list.Items, func(a, b v1.MCPToolCallApproval) int {
+		return b.CreationTimestamp.Compare(a.CreationTimestamp.Time)
+	})
+
+	items := make([]types.MCPToolCallApproval, 0, len(list.Items))
+	for _, item := range list.Items {
+		if canViewMCPToolCallApproval(req, item) {
+			items = append(items, convertMCPToolCallApproval(req, item))
+		}
+	}
+
+	return req.Write(types.MCPToolCallApprovalList{Items: items})
+}
+
+func (*MCPToolCallApprovalHandler) Get(req api.Context) error {
+	approval, err := getMCPToolCallApproval(req)
+	if err != nil {
+		return err
+	}
+
+	return req.Write(convertMCPToolCallApproval(req, *approval))
+}
+
+// Approve releases the held call to the MCP server. Users cannot approve their
+// own calls.
+func (*MCPToolCallApprovalHandler) Approve(req api.Context) error {
+	return decideMCPToolCallApproval(req, types.MCPToolCallApprovalStateApproved)
+}
+
+// Reject returns an error to the client instead of calling the tool.
+func (*MCPToolCallApprovalHandler) Reject(req api.Context) error {
+	return decideMCPToolCallApproval(req, types.MCPToolCallApprovalStateRejected)
+}
+
+// Cancel withdraws a held call. Only the requester can cancel it.
+func (*MCPToolCallApprovalHandler) Cancel(req api.Context) error {
+	return decideMCPToolCallApproval(req, types.MCPToolCallApprovalStateCancelled)
+}
+
+func decideMCPToolCallApproval(req api.Context, to types.MCPToolCallApprovalState) error {
+	approval, err := getMCPToolCallApproval(req)
+	if err != nil {
+		return err
+	}
+
+	var decision types.MCPToolCallApprovalDecision
+	if err := req.Read(&decision); err != nil && !errors.Is(err, io.EOF) {
+		return types.NewErrBadRequest("failed to read tool call approval decision: %v", err)
+	}
+	decision.Comment = strings.TrimSpace(decision.Comment)
+
+	actorID := req.User.GetUID()
+	isRequester := approval.Spec.RequesterID == actorID
+	if to == types.MCPToolCallApprovalStateCancelled {
+		if !isRequester {
+			return types.NewErrForbidden("only the requester can cancel a tool call approval")
+		}
+	} else if !canDecideMCPToolCallApproval(req, *approval) {
+		return types.NewErrForbidden("only approvers can decide tool call approvals")
+	} else if isRequester && to == types.MCPToolCallApprovalStateApproved {
+		return types.NewErrForbidden("tool calls cannot be approved by their requester")
+	}
+
+	now := time.Now()
+	if approval.Spec.State != types.MCPToolCallApprovalStatePending {
+		return types.NewErrHTTP(http.StatusConflict, fmt.Sprintf("tool call approval %s is %s, not %s", approval.Name, approval.Spec.State, types.MCPToolCallApprovalStatePending))
+	}
+	if !now.Before(approval.Spec.ExpiresAt.Time) {
+		return types.NewErrHTTP(http.StatusConflict, fmt.Sprintf("tool call approval %s has expired", approval.Name))
+	}
+
+	approval.Spec.State = to
+	approval.Spec.DecidedBy = actorID
+	approval.Spec.DecidedAt = metav1.NewTime(now)
+	approval.Spec.DecisionComment = decision.Comment
+	if err := req.Update(approval); err != nil {
+		return fmt.Errorf("failed to update tool call approval: %w", err)
+	}
+
+	slog.Info("tool call approval "+string(to), "approval", approval.Name, "requester", approval.Spec.RequesterID, "actor", actorID,
+		"mcpID", approval.Spec.MCPID, "method", approval.Spec.Method, "tool", approval.Spec.ToolName)
+	return req.Write(convertMCPToolCallApproval(req, *approval))
+}
+
+// getMCPToolCallApproval returns the approval in the path. Users who cannot see
+// it get a not-found error.
+func getMCPToolCallApproval(req api.Context) (*v1.MCPToolCallApproval, error) {
+	var approval v1.MCPToolCallApproval
+	if err := req.Get(&approval, req.PathValue("approval_id")); err != nil {
+		return nil, err
+	}
+	if !canViewMCPToolCallApproval(req, approval) {
+		return nil, types.NewErrNotFound("tool call approval %s not found", approval.Name)
+	}
+	return &approval, nil
+}
+
+// canDecideMCPToolCallApproval reports whether the user is an owner, an admin,
+// or one of the approvers of the policy that held the call.
+func canDecideMCPToolCallApproval(req api.Context, approval v1.MCPToolCallApproval) bool {
+	return req.UserIsOwner() || req.UserIsAdmin() || publishedartifact.SubjectsContainUser(approval.Spec.Approvers, req.User)
+}
+
+func canViewMCPToolCallApproval(req api.Context, approval v1.MCPToolCallApproval) bool {
+	return approval.Spec.RequesterID == req.User.GetUID() || req.UserIsAuditor() || canDecideMCPToolCallApproval(req, approval)
+}
+
+func convertMCPToolCallApproval(req api.Context, approval v1.MCPToolCallApproval) types.MCPToolCallApproval {
+	result := types.MCPToolCallApproval{
+		Metadata:             MetadataFrom(&approval),
+		PolicyID:             approval.Spec.PolicyName,
+		RequesterID:          approval.Spec.RequesterID,
+		MCPID:                approval.Spec.MCPID,
+		MCPServerDisplayName: approval.Spec.MCPServerDisplayName,
+		Method:               approval.Spec.Method,
+		ToolName:             approval.Spec.ToolName,
+		Arguments:            approval.Spec.Arguments,
+		SessionID:            approval.Spec.SessionID,
+		RequestID:            approval.Spec.RequestID,
+		State:                approval.Spec.State,
+		ExpiresAt:            *types.NewTime(approval.Spec.ExpiresAt.Time),
+		DecidedBy:            approval.Spec.DecidedBy,
+		DecisionComment:      approval.Spec.DecisionComment,
+		CanDecide: approval.Spec.State == types.MCPToolCallApprovalStatePending &&
+			approval.Spec.RequesterID != req.User.GetUID() && canDecideMCPToolCallApproval(req, approval),
+	}
+	if !approval.Spec.DecidedAt.IsZero() {
+		result.DecidedAt = types.NewTime(approval.Spec.DecidedAt.Time)
+	}
+	return result
+}
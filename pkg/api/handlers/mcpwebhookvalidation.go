@@ -19,6 +19,7 @@ import (
 	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
 	"github.com/obot-platform/obot/pkg/system"
 	"github.com/obot-platform/obot/pkg/wait"
+	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -121,6 +122,16 @@ func (m *MCPWebhookValidationHandler) Update(req api.Context) error {
 		return types.NewErrBadRequest("failed to read manifest: %v", err)
 	}
 
+	// Config repositories never hold the secret, so it is the one thing that
+	// can still be changed here for a webhook validation that one manages.
+	if v1.ManagedByConfigRepository(&webhookValidation) != "" {
+		withoutSecret := manifest
+		withoutSecret.Secret = ""
+		if !equality.Semantic.DeepEqual(withoutSecret, webhookValidation.Spec.Manifest) {
+			return checkNotManagedByConfigRepository(&webhookValidation)
+		}
+	}
+
 	if err := m.resolveManifestFromCatalogEntry(req, &manifest); err != nil {
 		return err
 	}
@@ -169,6 +180,9 @@ func (m *MCPWebhookValidationHandler) Delete(req api.Context) error {
 	if err := req.Get(&webhookValidation, req.PathValue("mcp_webhook_validation_id")); err != nil {
 		return err
 	}
+	if err := checkNotManagedByConfigRepository(&webhookValidation); err != nil {
+		return err
+	}
 
 	if _, err := req.GatewayClient.DeleteCredential(req.Context(), system.MCPWebhookValidationCredentialContext, webhookValidation.Name); err != nil {
 		return fmt.Errorf("failed to delete credential: %w", err)
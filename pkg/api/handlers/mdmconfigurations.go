@@ -72,6 +72,8 @@ func (h *MDMConfigurationsHandler) Create(req api.Context) error {
 	if err != nil {
 		return err
 	}
+	recordConfigChange(req, types.ConfigChangeKindMDMConfigurationEnforcement, strconv.FormatUint(uint64(created.ID), 10),
+		nil, mdmEnforcementSnapshot(created), 0)
 	result, err := convertMDMConfiguration(*created)
 	if err != nil {
 		return err
@@ -166,17 +168,32 @@ func (h *MDMConfigurationsHandler) UpdateEnforcement(req api.Context) error {
 	if err != nil {
 		return err
 	}
-	current, err := getMDMConfiguration(req, id)
+	var in types.MDMConfigurationEnforcementRequest
+	if err := req.Read(&in); err != nil {
+		return err
+	}
+	updated, _, err := h.updateEnforcement(req, id, in, 0)
 	if err != nil {
 		return err
 	}
-	var in types.MDMConfigurationEnforcementRequest
-	if err := req.Read(&in); err != nil {
+	result, err := convertMDMConfiguration(*updated)
+	if err != nil {
 		return err
 	}
+	return req.Write(result)
+}
+
+// updateEnforcement saves a configuration's enforcement policy and records the
+// change in its history, returning the updated configuration and the recorded
+// revision. revertedFrom is the revision being restored, if any.
+func (h *MDMConfigurationsHandler) updateEnforcement(req api.Context, id uint, in types.MDMConfigurationEnforcementRequest, revertedFrom uint) (*gtypes.MDMConfiguration, *gtypes.ConfigChange, error) {
+	current, err := getMDMConfiguration(req, id)
+	if err != nil {
+		return nil, nil, err
+	}
 	allowlist, err := enforcementAllowlistForSave(in.EnforcementEnabled, in.EnforcementAllowlist, current)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
 	// Re-render the configuration's artifacts from its own pinned bundle and
@@ -185,24 +202,35 @@ func (h *MDMConfigurationsHandler) UpdateEnforcement(req api.Context) error {
 	var artifacts []gtypes.MDMConfigurationArtifact
 	if current.AssetDigest != "" {
 		if artifacts, err = h.renderEnforcementArtifacts(req, current, in.EnforcementEnabled); err != nil {
-			return err
+			return nil, nil, err
 		}
 	}
 
 	if err := req.GatewayClient.UpdateMDMConfigurationEnforcement(req.Context(), id, in.EnforcementEnabled, allowlist, artifacts); errors.Is(err, gorm.ErrRecordNotFound) {
-		return types.NewErrNotFound("MDM configuration %d not found", id)
+		return nil, nil, types.NewErrNotFound("MDM configuration %d not found", id)
 	} else if err != nil {
-		return err
+		return nil, nil, err
 	}
 	updated, err := getMDMConfiguration(req, id)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
-	result, err := convertMDMConfiguration(*updated)
-	if err != nil {
-		return err
+
+	change := recordConfigChange(req, types.ConfigChangeKindMDMConfigurationEnforcement, strconv.FormatUint(uint64(id), 10),
+		mdmEnforcementSnapshot(current), mdmEnforcementSnapshot(updated), revertedFrom)
+	return updated, change, nil
+}
+
+// mdmEnforcementSnapshot is the part of a configuration that its change
+// history records.
+func mdmEnforcementSnapshot(configuration *gtypes.MDMConfiguration) *types.MDMConfigurationEnforcementRequest {
+	if configuration == nil {
+		return nil
+	}
+	return &types.MDMConfigurationEnforcementRequest{
+		EnforcementEnabled:   configuration.EnforcementEnabled,
+		EnforcementAllowlist: configuration.EnforcementAllowlist,
 	}
-	return req.Write(result)
 }
 
 // renderEnforcementArtifacts re-renders a configuration's device artifacts from
@@ -241,9 +269,17 @@ func (*MDMConfigurationsHandler) Delete(req api.Context) error {
 	if err != nil {
 		return err
 	}
+	current, err := req.GatewayClient.GetMDMConfiguration(req.Context(), id)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
 	if err := req.GatewayClient.DeleteMDMConfiguration(req.Context(), id); err != nil {
 		return err
 	}
+	if current != nil {
+		recordConfigChange(req, types.ConfigChangeKindMDMConfigurationEnforcement, strconv.FormatUint(uint64(id), 10),
+			mdmEnforcementSnapshot(current), nil, 0)
+	}
 	req.WriteHeader(http.StatusNoContent)
 	return nil
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/obot-platform/obot/pkg/api"
 	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
 	"github.com/obot-platform/obot/pkg/system"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 )
 
 type MessagePolicyHandler struct{}
@@ -66,6 +67,7 @@ func (*MessagePolicyHandler) Create(req api.Context) error {
 	if err := req.Create(&policy); err != nil {
 		return fmt.Errorf("failed to create message policy: %w", err)
 	}
+	recordConfigChange(req, types.ConfigChangeKindMessagePolicy, policy.Name, nil, policy.Spec, 0)
 
 	return req.Write(convertMessagePolicy(policy))
 }
@@ -87,11 +89,16 @@ func (*MessagePolicyHandler) Update(req api.Context) error {
 	if err := req.Get(&existing, policyID); err != nil {
 		return types.NewErrBadRequest("failed to get message policy: %v", err)
 	}
+	if err := checkNotManagedByConfigRepository(&existing); err != nil {
+		return err
+	}
 
+	before := existing.Spec
 	existing.Spec.Manifest = manifest
 	if err := req.Update(&existing); err != nil {
 		return fmt.Errorf("failed to update message policy: %w", err)
 	}
+	recordConfigChange(req, types.ConfigChangeKindMessagePolicy, existing.Name, before, existing.Spec, 0)
 
 	return req.Write(convertMessagePolicy(existing))
 }
@@ -100,10 +107,21 @@ func (*MessagePolicyHandler) Update(req api.Context) error {
 func (*MessagePolicyHandler) Delete(req api.Context) error {
 	policyID := req.PathValue("id")
 
-	return req.Delete(&v1.MessagePolicy{
-		Name:      policyID,
-		Namespace: req.Namespace(),
-	})
+	var existing v1.MessagePolicy
+	if err := req.Get(&existing, policyID); apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to get message policy: %w", err)
+	}
+	if err := checkNotManagedByConfigRepository(&existing); err != nil {
+		return err
+	}
+
+	if err := req.Delete(&existing); err != nil {
+		return err
+	}
+	recordConfigChange(req, types.ConfigChangeKindMessagePolicy, existing.Name, existing.Spec, nil, 0)
+	return nil
 }
 
 func convertMessagePolicy(policy v1.MessagePolicy) types.MessagePolicy {
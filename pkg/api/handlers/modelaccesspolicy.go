@@ -8,6 +8,7 @@ import (
 	"github.com/obot-platform/obot/pkg/modelaccesspolicy"
 	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
 	"github.com/obot-platform/obot/pkg/system"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 )
 
 type ModelAccessPolicyHandler struct{}
@@ -63,6 +64,7 @@ func (*ModelAccessPolicyHandler) Create(req api.Context) error {
 	if err := req.Create(&policy); err != nil {
 		return fmt.Errorf("failed to create model access policy: %w", err)
 	}
+	recordConfigChange(req, types.ConfigChangeKindModelAccessPolicy, policy.Name, nil, policy.Spec, 0)
 
 	return req.Write(convertModelAccessPolicy(policy))
 }
@@ -80,11 +82,16 @@ func (*ModelAccessPolicyHandler) Update(req api.Context) error {
 	if err := req.Get(&existing, policyID); err != nil {
 		return types.NewErrBadRequest("failed to get model access policy: %v", err)
 	}
+	if err := checkNotManagedByConfigRepository(&existing); err != nil {
+		return err
+	}
 
+	before := existing.Spec
 	existing.Spec.Manifest = manifest
 	if err := req.Update(&existing); err != nil {
 		return fmt.Errorf("failed to update model access policy: %w", err)
 	}
+	recordConfigChange(req, types.ConfigChangeKindModelAccessPolicy, existing.Name, before, existing.Spec, 0)
 
 	return req.Write(convertModelAccessPolicy(existing))
 }
@@ -93,10 +100,21 @@ func (*ModelAccessPolicyHandler) Update(req api.Context) error {
 func (*ModelAccessPolicyHandler) Delete(req api.Context) error {
 	policyID := req.PathValue("id")
 
-	return req.Delete(&v1.ModelAccessPolicy{
-		Name:      policyID,
-		Namespace: req.Namespace(),
-	})
+	var existing v1.ModelAccessPolicy
+	if err := req.Get(&existing, policyID); apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to get model access policy: %w", err)
+	}
+	if err := checkNotManagedByConfigRepository(&existing); err != nil {
+		return err
+	}
+
+	if err := req.Delete(&existing); err != nil {
+		return err
+	}
+	recordConfigChange(req, types.ConfigChangeKindModelAccessPolicy, existing.Name, existing.Spec, nil, 0)
+	return nil
 }
 
 func readAndValidateModelAccessPolicyManifest(req api.Context) (types.ModelAccessPolicyManifest, error) {
@@ -104,8 +122,12 @@ func readAndValidateModelAccessPolicyManifest(req api.Context) (types.ModelAcces
 	if err := req.Read(&manifest); err != nil {
 		return manifest, types.NewErrBadRequest("failed to read model access policy manifest: %v", err)
 	}
+	return manifest, validateModelAccessPolicyManifest(req, manifest)
+}
+
+func validateModelAccessPolicyManifest(req api.Context, manifest types.ModelAccessPolicyManifest) error {
 	if err := manifest.Validate(); err != nil {
-		return manifest, types.NewErrBadRequest("invalid model access policy manifest: %v", err)
+		return types.NewErrBadRequest("invalid model access policy manifest: %v", err)
 	}
 	if err := modelaccesspolicy.ValidateModelResources(
 		req.Context(),
@@ -113,10 +135,9 @@ func readAndValidateModelAccessPolicyManifest(req api.Context) (types.ModelAcces
 		req.Namespace(),
 		manifest.Models,
 	); err != nil {
-		return manifest, types.NewErrBadRequest("invalid model access policy manifest: %v", err)
+		return types.NewErrBadRequest("invalid model access policy manifest: %v", err)
 	}
-
-	return manifest, nil
+	return nil
 }
 
 func convertModelAccessPolicy(policy v1.ModelAccessPolicy) types.ModelAccessPolicy {
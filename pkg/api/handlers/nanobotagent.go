@@ -20,18 +20,16 @@ import (
 )
 
 type NanobotAgentHandler struct {
-	sessionManager            *mcp.SessionManager
-	serverURL                 string
-	agentsEnabled             bool
-	secretBindingAllowedLabel string
+	sessionManager *mcp.SessionManager
+	serverURL      string
+	agentsEnabled  bool
 }
 
-func NewNanobotAgentHandler(sessionManager *mcp.SessionManager, serverURL string, agentsEnabled bool, secretBindingAllowedLabel string) *NanobotAgentHandler {
+func NewNanobotAgentHandler(sessionManager *mcp.SessionManager, serverURL string, agentsEnabled bool) *NanobotAgentHandler {
 	return &NanobotAgentHandler{
-		sessionManager:            sessionManager,
-		serverURL:                 serverURL,
-		agentsEnabled:             agentsEnabled,
-		secretBindingAllowedLabel: secretBindingAllowedLabel,
+		sessionManager: sessionManager,
+		serverURL:      serverURL,
+		agentsEnabled:  agentsEnabled,
 	}
 }
 
@@ -0,0 +1,424 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/obot-platform/obot/pkg/api"
+	gclient "github.com/obot-platform/obot/pkg/gateway/client"
+	gatewaytypes "github.com/obot-platform/obot/pkg/gateway/types"
+	"github.com/obot-platform/obot/pkg/notification"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	"github.com/obot-platform/obot/pkg/system"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// NotificationChannelHandler manages the destinations notifications are sent
+// to. Channel secrets are kept in the credential store.
+type NotificationChannelHandler struct{}
+
+func NewNotificationChannelHandler() *NotificationChannelHandler {
+	return &NotificationChannelHandler{}
+}
+
+func (*NotificationChannelHandler) List(req api.Context) error {
+	var list v1.NotificationChannelList
+	if err := req.List(&list); err != nil {
+		return fmt.Errorf("failed to list notification channels: %w", err)
+	}
+
+	items := make([]types.NotificationChannel, 0, len(list.Items))
+	for _, channel := range list.Items {
+		secret, err := notificationChannelSecret(req, channel.Name)
+		if err != nil {
+			return err
+		}
+		items = append(items, convertNotificationChannel(channel, secret != ""))
+	}
+	return req.Write(types.NotificationChannelList{Items: items})
+}
+
+func (*NotificationChannelHandler) Get(req api.Context) error {
+	var channel v1.NotificationChannel
+	if err := req.Get(&channel, req.PathValue("id")); err != nil {
+		return fmt.Errorf("failed to get notification channel: %w", err)
+	}
+	secret, err := notificationChannelSecret(req, channel.Name)
+	if err != nil {
+		return err
+	}
+	return req.Write(convertNotificationChannel(channel, secret != ""))
+}
+
+func (*NotificationChannelHandler) Create(req api.Context) error {
+	manifest, err := readNotificationChannelManifest(req)
+	if err != nil {
+		return err
+	}
+	if err := manifest.Validate(false); err != nil {
+		return types.NewErrBadRequest("invalid notification channel: %v", err)
+	}
+
+	secret := manifest.Secret
+	manifest.Secret = ""
+	channel := v1.NotificationChannel{
+		GenerateName: system.NotificationChannelPrefix,
+		Namespace:    req.Namespace(),
+		Finalizers:   []string{v1.NotificationChannelFinalizer},
+		Spec:         v1.NotificationChannelSpec{Manifest: manifest},
+	}
+	if err := req.Create(&channel); err != nil {
+		return fmt.Errorf("failed to create notification channel: %w", err)
+	}
+	if err := storeNotificationChannelSecret(req, channel.Name, secret); err != nil {
+		_ = req.Delete(&channel)
+		return err
+	}
+	return req.WriteCreated(convertNotificationChannel(channel, true))
+}
+
+func (*NotificationChannelHandler) Update(req api.Context) error {
+	manifest, err := readNotificationChannelManifest(req)
+	if err != nil {
+		return err
+	}
+
+	var channel v1.NotificationChannel
+	if err := req.Get(&channel, req.PathValue("id")); err != nil {
+		return fmt.Errorf("failed to get notification channel: %w", err)
+	}
+	if manifest.ChannelType == "" {
+		manifest.ChannelType = channel.Spec.Manifest.ChannelType
+	} else if manifest.ChannelType != channel.Spec.Manifest.ChannelType {
+		return types.NewErrBadRequest("channelType is immutable")
+	}
+	current, err := notificationChannelSecret(req, channel.Name)
+	if err != nil {
+		return err
+	}
+	if err := manifest.Validate(current != ""); err != nil {
+		return types.NewErrBadRequest("invalid notification channel: %v", err)
+	}
+
+	secret := manifest.Secret
+	manifest.Secret = ""
+	channel.Spec.Manifest = manifest
+	if err := req.Update(&channel); err != nil {
+		return fmt.Errorf("failed to update notification channel: %w", err)
+	}
+	if secret != "" {
+		if err := storeNotificationChannelSecret(req, channel.Name, secret); err != nil {
+			return err
+		}
+		current = secret
+	}
+	return req.Write(convertNotificationChannel(channel, current != ""))
+}
+
+// Delete removes a channel that no notification rule uses. Its secret is
+// removed by the controller.
+func (*NotificationChannelHandler) Delete(req api.Context) error {
+	var channel v1.NotificationChannel
+	if err := req.Get(&channel, req.PathValue("id")); err != nil {
+		return fmt.Errorf("failed to get notification channel: %w", err)
+	}
+
+	var rules v1.NotificationRuleList
+	if err := req.List(&rules); err != nil {
+		return fmt.Errorf("failed to list notification rules: %w", err)
+	}
+	var users []string
+	for _, rule := range rules.Items {
+		if slices.Contains(rule.Spec.Manifest.ChannelIDs, channel.Name) {
+			users = append(users, rule.Name)
+		}
+	}
+	if len(users) > 0 {
+		return types.NewErrHTTP(http.StatusConflict, fmt.Sprintf("notification channel is still used by rules %s", strings.Join(users, ", ")))
+	}
+
+	if err := req.Delete(&channel); err != nil {
+		return fmt.Errorf("failed to delete notification channel: %w", err)
+	}
+	req.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+// Test sends a test event to the channel and reports any error, without
+// recording a delivery.
+func (*NotificationChannelHandler) Test(req api.Context) error {
+	var channel v1.NotificationChannel
+	if err := req.Get(&channel, req.PathValue("id")); err != nil {
+		return fmt.Errorf("failed to get notification channel: %w", err)
+	}
+	secret, err := notificationChannelSecret(req, channel.Name)
+	if err != nil {
+		return err
+	}
+
+	event := types.NotificationEvent{
+		ID:         system.NotificationDeliveryPrefix + "test",
+		Type:       types.NotificationEventTest,
+		Time:       *types.NewTime(metav1.Now().Time),
+		Summary:    fmt.Sprintf("Test notification for channel %s", channel.Spec.Manifest.DisplayName),
+		UserID:     req.User.GetUID(),
+		ResourceID: channel.Name,
+	}
+	if err := notification.Send(req.Context(), channel.Spec.Manifest, secret, event); err != nil {
+		return types.NewErrHTTP(http.StatusBadGateway, fmt.Sprintf("failed to send test notification: %v", err))
+	}
+	req.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func readNotificationChannelManifest(req api.Context) (types.NotificationChannelManifest, error) {
+	var manifest types.NotificationChannelManifest
+	if err := req.Read(&manifest); err != nil {
+		return manifest, types.NewErrBadRequest("failed to read notification channel: %v", err)
+	}
+	manifest.DisplayName = strings.TrimSpace(manifest.DisplayName)
+	manifest.URL = strings.TrimSpace(manifest.URL)
+	manifest.Secret = strings.TrimSpace(manifest.Secret)
+	if manifest.Email != nil {
+		manifest.Email.Host = strings.TrimSpace(manifest.Email.Host)
+		manifest.Email.Username = strings.TrimSpace(manifest.Email.Username)
+		manifest.Email.From = strings.TrimSpace(manifest.Email.From)
+	}
+	return manifest, nil
+}
+
+func notificationChannelSecret(req api.Context, channelName string) (string, error) {
+	credential, err := req.GatewayClient.RevealCredential(req.Context(), []string{notification.CredentialContext}, channelName)
+	if errors.As(err, &gclient.CredentialNotFoundError{}) {
+		return "", nil
+	} else if err != nil {
+		return "", fmt.Errorf("failed to get secret of notification channel %q: %w", channelName, err)
+	}
+	return credential.Secrets[notification.SecretKey], nil
+}
+
+func storeNotificationChannelSecret(req api.Context, channelName, secret string) error {
+	if err := req.GatewayClient.UpsertCredential(req.Context(), gatewaytypes.Credential{
+		Context: notification.CredentialContext,
+		Name:    channelName,
+		Secrets: map[string]string{notification.SecretKey: secret},
+	}); err != nil {
+		return fmt.Errorf("failed to store notification channel secret: %w", err)
+	}
+	return nil
+}
+
+func convertNotificationChannel(channel v1.NotificationChannel, secretConfigured bool) types.NotificationChannel {
+	return types.NotificationChannel{
+		Metadata:                    MetadataFrom(&channel),
+		NotificationChannelManifest: channel.Spec.Manifest,
+		SecretConfigured:            secretConfigured,
+	}
+}
+
+// NotificationRuleHandler manages the rules that subscribe notification
+// channels to events.
+type NotificationRuleHandler struct{}
+
+func NewNotificationRuleHandler() *NotificationRuleHandler {
+	return &NotificationRuleHandler{}
+}
+
+func (*NotificationRuleHandler) List(req api.Context) error {
+	var list v1.NotificationRuleList
+	if err := req.List(&list); err != nil {
+		return fmt.Errorf("failed to list notification rules: %w", err)
+	}
+
+	items := make([]types.NotificationRule, 0, len(list.Items))
+	for _, rule := range list.Items {
+		items = append(items, convertNotificationRule(rule))
+	}
+	return req.Write(types.NotificationRuleList{Items: items})
+}
+
+func (*NotificationRuleHandler) Get(req api.Context) error {
+	var rule v1.NotificationRule
+	if err := req.Get(&rule, req.PathValue("id")); err != nil {
+		return fmt.Errorf("failed to get notification rule: %w", err)
+	}
+	return req.Write(convertNotificationRule(rule))
+}
+
+func (*NotificationRuleHandler) Create(req api.Context) error {
+	manifest, err := readNotificationRuleManifest(req)
+	if err != nil {
+		return err
+	}
+
+	rule := v1.NotificationRule{
+		GenerateName: system.NotificationRulePrefix,
+		Namespace:    req.Namespace(),
+		Spec:         v1.NotificationRuleSpec{Manifest: manifest},
+	}
+	if err := req.Create(&rule); err != nil {
+		return fmt.Errorf("failed to create notification rule: %w", err)
+	}
+	return req.WriteCreated(convertNotificationRule(rule))
+}
+
+func (*NotificationRuleHandler) Update(req api.Context) error {
+	manifest, err := readNotificationRuleManifest(req)
+	if err != nil {
+		return err
+	}
+
+	var rule v1.NotificationRule
+	if err := req.Get(&rule, req.PathValue("id")); err != nil {
+		return fmt.Errorf("failed to get notification rule: %w", err)
+	}
+	rule.Spec.Manifest = manifest
+	if err := req.Update(&rule); err != nil {
+		return fmt.Errorf("failed to update notification rule: %w", err)
+	}
+	return req.Write(convertNotificationRule(rule))
+}
+
+func (*NotificationRuleHandler) Delete(req api.Context) error {
+	if err := req.Delete(&v1.NotificationRule{
+		Name:      req.PathValue("id"),
+		Namespace: req.Namespace(),
+	}); err != nil {
+		return fmt.Errorf("failed to delete notification rule: %w", err)
+	}
+	req.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+// readNotificationRuleManifest reads and validates a rule, including that its
+// channels exist.
+func readNotificationRuleManifest(req api.Context) (types.NotificationRuleManifest, error) {
+	var manifest types.NotificationRuleManifest
+	if err := req.Read(&manifest); err != nil {
+		return manifest, types.NewErrBadRequest("failed to read notification rule: %v", err)
+	}
+	manifest.DisplayName = strings.TrimSpace(manifest.DisplayName)
+	slices.Sort(manifest.ChannelIDs)
+	manifest.ChannelIDs = slices.Compact(manifest.ChannelIDs)
+	if err := manifest.Validate(); err != nil {
+		return manifest, types.NewErrBadRequest("invalid notification rule: %v", err)
+	}
+
+	for _, channelID := range manifest.ChannelIDs {
+		if err := req.Get(&v1.NotificationChannel{}, channelID); apierrors.IsNotFound(err) {
+			return manifest, types.NewErrBadRequest("notification channel %q not found", channelID)
+		} else if err != nil {
+			return manifest, fmt.Errorf("failed to get notification channel %q: %w", channelID, err)
+		}
+	}
+	return manifest, nil
+}
+
+func convertNotificationRule(rule v1.NotificationRule) types.NotificationRule {
+	return types.NotificationRule{
+		Metadata:                 MetadataFrom(&rule),
+		NotificationRuleManifest: rule.Spec.Manifest,
+	}
+}
+
+// NotificationDeliveryHandler serves the delivery log.
+type NotificationDeliveryHandler struct{}
+
+func NewNotificationDeliveryHandler() *NotificationDeliveryHandler {
+	return &NotificationDeliveryHandler{}
+}
+
+// List returns deliveries, newest first. The channel, rule, type and state
+// query parameters filter the result.
+func (*NotificationDeliveryHandler) List(req api.Context) error {
+	query := req.URL.Query()
+	selector := map[string]string{}
+	if channel := query.Get("channel"); channel != "" {
+		selector["spec.channelName"] = channel
+	}
+	if rule := query.Get("rule"); rule != "" {
+		selector["spec.ruleName"] = rule
+	}
+	if eventType := query.Get("type"); eventType != "" {
+		selector["spec.event.type"] = eventType
+	}
+
+	var list v1.NotificationDeliveryList
+	if err := req.List(&list, &kclient.ListOptions{
+		Namespace:     req.Namespace(),
+		FieldSelector: fields.SelectorFromSet(selector),
+	}); err != nil {
+		return fmt.Errorf("failed to list notification deliveries: %w", err)
+	}
+
+	slices.SortFunc(list.Items, func(a, b v1.NotificationDelivery) int {
+		return b.CreationTimestamp.Compare(a.CreationTimestamp.Time)
+	})
+
+	state := types.NotificationDeliveryState(query.Get("state"))
+	items := make([]types.NotificationDelivery, 0, len(list.Items))
+	for _, item := range list.Items {
+		delivery := convertNotificationDelivery(item)
+		if state == "" || delivery.State == state {
+			items = append(items, delivery)
+		}
+	}
+	return req.Write(types.NotificationDeliveryList{Items: items})
+}
+
+func (*NotificationDeliveryHandler) Get(req api.Context) error {
+	var delivery v1.NotificationDelivery
+	if err := req.Get(&delivery, req.PathValue("id")); err != nil {
+		return fmt.Errorf("failed to get notification delivery: %w", err)
+	}
+	return req.Write(convertNotificationDelivery(delivery))
+}
+
+// Retry sends a failed delivery again, with a fresh set of attempts.
+func (*NotificationDeliveryHandler) Retry(req api.Context) error {
+	var delivery v1.NotificationDelivery
+	if err := req.Get(&delivery, req.PathValue("id")); err != nil {
+		return fmt.Errorf("failed to get notification delivery: %w", err)
+	}
+	if delivery.Status.State != types.NotificationDeliveryStateFailed {
+		return types.NewErrHTTP(http.StatusConflict, "only failed deliveries can be retried")
+	}
+
+	delivery.Status.State = types.NotificationDeliveryStatePending
+	delivery.Status.Attempts = 0
+	delivery.Status.NextAttemptAt = metav1.Time{}
+	if err := req.Storage.Status().Update(req.Context(), &delivery); err != nil {
+		return fmt.Errorf("failed to retry notification delivery: %w", err)
+	}
+	return req.Write(convertNotificationDelivery(delivery))
+}
+
+func convertNotificationDelivery(delivery v1.NotificationDelivery) types.NotificationDelivery {
+	state := delivery.Status.State
+	if state == "" {
+		state = types.NotificationDeliveryStatePending
+	}
+	result := types.NotificationDelivery{
+		Metadata:  MetadataFrom(&delivery),
+		ChannelID: delivery.Spec.ChannelName,
+		RuleID:    delivery.Spec.RuleName,
+		Event:     delivery.Spec.Event,
+		State:     state,
+		Attempts:  delivery.Status.Attempts,
+		LastError: delivery.Status.LastError,
+	}
+	if !delivery.Status.NextAttemptAt.IsZero() {
+		result.NextAttemptAt = types.NewTime(delivery.Status.NextAttemptAt.Time)
+	}
+	if !delivery.Status.DeliveredAt.IsZero() {
+		result.DeliveredAt = types.NewTime(delivery.Status.DeliveredAt.Time)
+	}
+	return result
+}
@@ -15,16 +15,14 @@ import (
 )
 
 type PowerUserWorkspaceHandler struct {
-	serverURL                 string
-	acrHelper                 *accesscontrolrule.Helper
-	secretBindingAllowedLabel string
+	serverURL string
+	acrHelper *accesscontrolrule.Helper
 }
 
-func NewPowerUserWorkspaceHandler(serverURL string, acrHelper *accesscontrolrule.Helper, secretBindingAllowedLabel string) *PowerUserWorkspaceHandler {
+func NewPowerUserWorkspaceHandler(serverURL string, acrHelper *accesscontrolrule.Helper) *PowerUserWorkspaceHandler {
 	return &PowerUserWorkspaceHandler{
-		serverURL:                 serverURL,
-		acrHelper:                 acrHelper,
-		secretBindingAllowedLabel: secretBindingAllowedLabel,
+		serverURL: serverURL,
+		acrHelper: acrHelper,
 	}
 }
 
@@ -143,7 +141,7 @@ func (p *PowerUserWorkspaceHandler) ListAllServers(req api.Context) error {
 			return fmt.Errorf("failed to determine slug: %w", err)
 		}
 
-		mergedEnv, err := mcp.MergeBoundCreds(req.Context(), req.LocalK8sClient, req.ObotNamespace, server.Spec.Manifest.Env, server.Spec.Manifest.RemoteConfig, credMap[server.Name], p.secretBindingAllowedLabel)
+		mergedEnv, err := mcp.MergeBoundCreds(req.Context(), req.SecretProvider, server.Spec.Manifest.Env, server.Spec.Manifest.RemoteConfig, credMap[server.Name])
 		if err != nil {
 			return fmt.Errorf("failed to resolve secret bindings for server %s: %w", server.Name, err)
 		}
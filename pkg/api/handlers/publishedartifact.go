@@ -5,15 +5,13 @@ import (
 	"bytes"
 	"crypto/rand"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"log/slog"
 	"maps"
 	"net/http"
-	"os"
-	"path"
-	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
@@ -34,8 +32,6 @@ import (
 
 const (
 	maxArtifactUploadBytes    = 100 * 1024 * 1024
-	maxZIPFiles               = 50
-	maxZIPUncompressedBytes   = 100 * 1024 * 1024
 	maxSkillMDBytes           = 1024 * 1024
 	maxArtifactDescriptionLen = 1024
 	maxPublishRetries         = 3
@@ -47,12 +43,6 @@ var (
 	errConcurrentCreate = fmt.Errorf("concurrent create detected")
 )
 
-type publishedArtifactUpdateRequest struct {
-	Description *string         `json:"description,omitempty"`
-	Version     *int            `json:"version,omitempty"`
-	Subjects    []types.Subject `json:"subjects,omitempty"`
-}
-
 type PublishedArtifactHandler struct {
 	blobStore blob.BlobStore
 	bucket    string
@@ -96,11 +86,41 @@ func (h *PublishedArtifactHandler) Create(req api.Context) error {
 
 	slog.Debug("Artifact author", "id", authorID, "email", authorEmail)
 
+	artifactType := types.PublishedArtifactTypeWorkflow
+	switch t := types.PublishedArtifactType(req.URL.Query().Get("type")); t {
+	case "", types.PublishedArtifactTypeWorkflow:
+	case types.PublishedArtifactTypeSkill:
+		artifactType = t
+	default:
+		return types.NewErrBadRequest("unsupported artifact type %q", t)
+	}
+
+	// The version description defaults to the SKILL.md description.
+	description := fm.Description
+	if override := strings.TrimSpace(req.URL.Query().Get("description")); override != "" {
+		if len(override) > maxArtifactDescriptionLen {
+			return types.NewErrBadRequest("description must be %d characters or fewer", maxArtifactDescriptionLen)
+		}
+		description = override
+	}
+
+	// Subjects, when given, grant access to the new version instead of those
+	// of the previous version.
+	var subjects []types.Subject
+	if raw := req.URL.Query().Get("subjects"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &subjects); err != nil {
+			return types.NewErrBadRequest("invalid subjects: %v", err)
+		}
+		if err := validatePublishedArtifactSubjects(subjects); err != nil {
+			return types.NewErrBadRequest("invalid subjects: %v", err)
+		}
+	}
+
 	// Build the manifest for DB storage from SKILL.md frontmatter.
 	manifest := types.PublishedArtifactManifest{
 		Name:         fm.Name,
-		Description:  fm.Description,
-		ArtifactType: types.PublishedArtifactTypeWorkflow,
+		Description:  description,
+		ArtifactType: artifactType,
 		AuthorEmail:  authorEmail,
 	}
 
@@ -117,7 +137,7 @@ func (h *PublishedArtifactHandler) Create(req api.Context) error {
 
 		if apierrors.IsNotFound(err) {
 			// No existing artifact — try to create a new one.
-			if err := h.createNewArtifact(req, data, fm, body, manifest, authorID, authorEmail, artifactName); errors.Is(err, errConcurrentCreate) {
+			if err := h.createNewArtifact(req, data, fm, body, manifest, subjects, authorID, authorEmail, artifactName); errors.Is(err, errConcurrentCreate) {
 				slog.Debug("Concurrent create for artifact, retrying", "artifact", artifactName, "attempt", attempt+1, "maxAttempts", maxPublishRetries)
 				continue
 			} else if err != nil {
@@ -127,7 +147,10 @@ func (h *PublishedArtifactHandler) Create(req api.Context) error {
 		}
 
 		// Update existing artifact with a new version.
-		previousVersionSubjects := publishedartifact.VersionSubjects(&existing, existing.Spec.LatestVersion)
+		versionSubjects := subjects
+		if versionSubjects == nil {
+			versionSubjects = publishedartifact.VersionSubjects(&existing, existing.Spec.LatestVersion)
+		}
 		version := existing.Spec.LatestVersion + 1
 
 		// Stamp publish metadata into SKILL.md before uploading the next version.
@@ -158,7 +181,7 @@ func (h *PublishedArtifactHandler) Create(req api.Context) error {
 			BlobKey:     blobKey,
 			Description: manifest.Description,
 			CreatedAt:   *types.NewTime(time.Now()),
-			Subjects:    previousVersionSubjects,
+			Subjects:    versionSubjects,
 		})
 
 		if err := req.Update(&existing); apierrors.IsConflict(err) {
@@ -182,7 +205,7 @@ func (h *PublishedArtifactHandler) Create(req api.Context) error {
 	return types.NewErrHTTP(http.StatusConflict, fmt.Sprintf("failed to publish artifact after %d attempts due to concurrent updates, please retry", maxPublishRetries))
 }
 
-func (h *PublishedArtifactHandler) createNewArtifact(req api.Context, data []byte, fm skillformat.Frontmatter, body string, manifest types.PublishedArtifactManifest, authorID, authorEmail, artifactName string) error {
+func (h *PublishedArtifactHandler) createNewArtifact(req api.Context, data []byte, fm skillformat.Frontmatter, body string, manifest types.PublishedArtifactManifest, subjects []types.Subject, authorID, authorEmail, artifactName string) error {
 	// Stamp publish metadata into SKILL.md before uploading the first version.
 	fm = withArtifactMetadata(fm, artifactName, authorEmail, 1)
 	data, err := rewriteSkillFrontmatterInZIP(data, fm, body)
@@ -218,6 +241,7 @@ func (h *PublishedArtifactHandler) createNewArtifact(req api.Context, data []byt
 					BlobKey:     blobKey,
 					Description: manifest.Description,
 					CreatedAt:   *types.NewTime(time.Now()),
+					Subjects:    subjects,
 				},
 			},
 		},
@@ -445,7 +469,7 @@ func (h *PublishedArtifactHandler) Update(req api.Context) error {
 		return err
 	}
 
-	var update publishedArtifactUpdateRequest
+	var update types.PublishedArtifactUpdateRequest
 	if err := req.Read(&update); err != nil {
 		return err
 	}
@@ -536,68 +560,6 @@ func validatePublishedArtifactSubjects(subjects []types.Subject) error {
 	return nil
 }
 
-// validateZIP checks the ZIP archive for file count limits, total declared uncompressed size,
-// suspicious entry names (path traversal, absolute paths, symlinks), and Windows-style paths.
-// Note: declared sizes from headers are attacker-controlled; callers that decompress content
-// must also enforce limits on actual bytes read (see rewriteSkillFrontmatterInZIP).
-func validateZIP(r *zip.Reader) error {
-	if len(r.File) > maxZIPFiles {
-		return fmt.Errorf("ZIP contains too many files (%d, max %d)", len(r.File), maxZIPFiles)
-	}
-
-	var totalUncompressed uint64
-	for _, f := range r.File {
-		if err := validateZIPEntryName(f.Name); err != nil {
-			return err
-		}
-
-		if f.Mode()&os.ModeSymlink != 0 {
-			return fmt.Errorf("ZIP entry %q is a symbolic link", f.Name)
-		}
-		if mode := f.Mode(); !mode.IsDir() && !mode.IsRegular() && mode&os.ModeType != 0 {
-			return fmt.Errorf("ZIP entry %q has unsupported file type", f.Name)
-		}
-
-		if f.UncompressedSize64 > uint64(maxZIPUncompressedBytes) {
-			return fmt.Errorf("ZIP entry %q uncompressed size exceeds limit (%d bytes)", f.Name, maxZIPUncompressedBytes)
-		}
-		if totalUncompressed > uint64(maxZIPUncompressedBytes)-f.UncompressedSize64 {
-			return fmt.Errorf("ZIP total uncompressed size exceeds limit (%d bytes)", maxZIPUncompressedBytes)
-		}
-		totalUncompressed += f.UncompressedSize64
-	}
-
-	return nil
-}
-
-// validateZIPEntryName checks a single ZIP entry name for path traversal, absolute paths,
-// and Windows drive-letter paths.
-func validateZIPEntryName(name string) error {
-	// Normalize backslashes to forward slashes before cleaning.
-	normalized := strings.ReplaceAll(filepath.ToSlash(name), "\\", "/")
-	cleaned := path.Clean(strings.TrimPrefix(normalized, "./"))
-
-	// Reject entries that are empty or resolve to the current directory.
-	if cleaned == "." || cleaned == "" {
-		return fmt.Errorf("ZIP entry %q is empty or refers to the current directory", name)
-	}
-	if strings.HasPrefix(cleaned, "/") {
-		return fmt.Errorf("ZIP entry %q is an absolute path", name)
-	}
-	if cleaned == ".." || strings.HasPrefix(cleaned, "../") {
-		return fmt.Errorf("ZIP entry %q contains path traversal", name)
-	}
-	// Reject Windows drive-letter paths (e.g. "C:\..." or "C:/...")
-	if len(cleaned) >= 2 && cleaned[1] == ':' &&
-		((cleaned[0] >= 'a' && cleaned[0] <= 'z') || (cleaned[0] >= 'A' && cleaned[0] <= 'Z')) {
-		return fmt.Errorf("ZIP entry %q is an absolute path", name)
-	}
-	if volume := filepath.VolumeName(filepath.FromSlash(cleaned)); volume != "" {
-		return fmt.Errorf("ZIP entry %q is an absolute path", name)
-	}
-	return nil
-}
-
 // readSkillFrontmatterFromZIP finds SKILL.md in the ZIP, parses its frontmatter,
 // and returns the frontmatter and body separately.
 func readSkillFrontmatterFromZIP(data []byte) (skillformat.Frontmatter, string, error) {
@@ -606,7 +568,7 @@ func readSkillFrontmatterFromZIP(data []byte) (skillformat.Frontmatter, string,
 		return skillformat.Frontmatter{}, "", fmt.Errorf("invalid ZIP archive: %w", err)
 	}
 
-	if err := validateZIP(r); err != nil {
+	if err := skillformat.ValidateZIP(r); err != nil {
 		return skillformat.Frontmatter{}, "", err
 	}
 
@@ -645,7 +607,7 @@ func rewriteSkillFrontmatterInZIP(data []byte, fm skillformat.Frontmatter, body
 		return nil, fmt.Errorf("invalid ZIP archive: %w", err)
 	}
 
-	if err := validateZIP(r); err != nil {
+	if err := skillformat.ValidateZIP(r); err != nil {
 		return nil, err
 	}
 
@@ -673,8 +635,8 @@ func rewriteSkillFrontmatterInZIP(data []byte, fm skillformat.Frontmatter, body
 				return nil, fmt.Errorf("failed to write %s: %w", skillformat.SkillMainFile, err)
 			}
 			totalWritten += uint64(n)
-			if totalWritten > uint64(maxZIPUncompressedBytes) {
-				return nil, fmt.Errorf("ZIP total uncompressed size exceeds limit (%d bytes)", maxZIPUncompressedBytes)
+			if totalWritten > uint64(skillformat.MaxZIPUncompressedBytes) {
+				return nil, fmt.Errorf("ZIP total uncompressed size exceeds limit (%d bytes)", skillformat.MaxZIPUncompressedBytes)
 			}
 			continue
 		}
@@ -689,15 +651,15 @@ func rewriteSkillFrontmatterInZIP(data []byte, fm skillformat.Frontmatter, body
 		if err != nil {
 			return nil, fmt.Errorf("failed to open entry %s: %w", f.Name, err)
 		}
-		remaining := uint64(maxZIPUncompressedBytes) - totalWritten
+		remaining := uint64(skillformat.MaxZIPUncompressedBytes) - totalWritten
 		n, err := io.Copy(fw, io.LimitReader(rc, int64(remaining)+1))
 		rc.Close()
 		if err != nil {
 			return nil, fmt.Errorf("failed to copy entry %s: %w", f.Name, err)
 		}
 		totalWritten += uint64(n)
-		if totalWritten > uint64(maxZIPUncompressedBytes) {
-			return nil, fmt.Errorf("ZIP total uncompressed size exceeds limit (%d bytes)", maxZIPUncompressedBytes)
+		if totalWritten > uint64(skillformat.MaxZIPUncompressedBytes) {
+			return nil, fmt.Errorf("ZIP total uncompressed size exceeds limit (%d bytes)", skillformat.MaxZIPUncompressedBytes)
 		}
 	}
 
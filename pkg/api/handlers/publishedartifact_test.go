@@ -4,11 +4,11 @@ import (
 	"archive/zip"
 	"bytes"
 	"encoding/json"
-	"fmt"
 	"io"
 	"maps"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strings"
 	"testing"
 
@@ -669,99 +669,101 @@ func TestPublishedArtifactCreate_InheritsSubjectsFromPreviousVersion(t *testing.
 	}
 }
 
-func TestValidateZIP_PathTraversal(t *testing.T) {
-	zipData := createArtifactTestZIP(t, map[string][]byte{
-		"../etc/passwd": []byte("bad"),
-	})
-	r, err := zip.NewReader(bytes.NewReader(zipData), int64(len(zipData)))
+func TestPublishedArtifactCreate_SkillWithDescription(t *testing.T) {
+	storage := newPublishedArtifactTestStorage(t)
+	blobStore, err := blobpkg.NewDirectoryStore(t.TempDir())
 	if err != nil {
-		t.Fatalf("failed to create zip reader: %v", err)
-	}
-	if err := validateZIP(r); err == nil {
-		t.Fatal("expected error for path traversal entry")
-	} else if !strings.Contains(err.Error(), "path traversal") {
-		t.Errorf("error = %q, want containing 'path traversal'", err.Error())
+		t.Fatalf("failed to create directory blob store: %v", err)
 	}
-}
-
-func TestValidateZIP_AbsolutePath(t *testing.T) {
-	zipData := createArtifactTestZIP(t, map[string][]byte{
-		"/etc/passwd": []byte("bad"),
+	handler := NewPublishedArtifactHandler(blobStore, "test-bucket")
+	user := &kuser.DefaultInfo{Name: "owner", UID: "owner", Groups: []string{types.GroupAuthenticated}}
+	reqBody := createArtifactTestZIP(t, map[string][]byte{
+		skillformat.SkillMainFile: createSkillMDContent(t, "skill-a", "From SKILL.md", nil),
 	})
-	r, err := zip.NewReader(bytes.NewReader(zipData), int64(len(zipData)))
-	if err != nil {
-		t.Fatalf("failed to create zip reader: %v", err)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/published-artifacts?type=skill&description=First+release", bytes.NewReader(reqBody))
+	rec := httptest.NewRecorder()
+	if err := handler.Create(api.Context{ResponseWriter: rec, Request: req, Storage: storage, User: user}); err != nil {
+		t.Fatalf("Create() error = %v", err)
 	}
-	if err := validateZIP(r); err == nil {
-		t.Fatal("expected error for absolute path entry")
-	} else if !strings.Contains(err.Error(), "absolute path") {
-		t.Errorf("error = %q, want containing 'absolute path'", err.Error())
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("status code = %d, want %d", rec.Code, http.StatusCreated)
 	}
-}
 
-func TestValidateZIP_TooManyFiles(t *testing.T) {
-	files := make(map[string][]byte)
-	for i := range maxZIPFiles + 1 {
-		files[fmt.Sprintf("file%d.txt", i)] = []byte("x")
+	var response types.PublishedArtifact
+	if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
 	}
-	zipData := createArtifactTestZIP(t, files)
-	r, err := zip.NewReader(bytes.NewReader(zipData), int64(len(zipData)))
-	if err != nil {
-		t.Fatalf("failed to create zip reader: %v", err)
+	if response.ArtifactType != types.PublishedArtifactTypeSkill {
+		t.Errorf("ArtifactType = %q, want %q", response.ArtifactType, types.PublishedArtifactTypeSkill)
 	}
-	if err := validateZIP(r); err == nil {
-		t.Fatal("expected error for too many files")
-	} else if !strings.Contains(err.Error(), "too many files") {
-		t.Errorf("error = %q, want containing 'too many files'", err.Error())
+	if response.Description != "First release" {
+		t.Errorf("Description = %q, want %q", response.Description, "First release")
+	}
+	if len(response.Versions) != 1 || response.Versions[0].Description != "First release" {
+		t.Errorf("Versions = %+v, want one version described as %q", response.Versions, "First release")
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/api/published-artifacts?type=agent", bytes.NewReader(reqBody))
+	err = handler.Create(api.Context{ResponseWriter: httptest.NewRecorder(), Request: req, Storage: storage, User: user})
+	if err == nil || !strings.Contains(err.Error(), "unsupported artifact type") {
+		t.Errorf("Create() error = %v, want unsupported artifact type", err)
 	}
 }
 
-func TestValidateZIP_Valid(t *testing.T) {
-	zipData := createArtifactTestZIP(t, map[string][]byte{
-		skillformat.SkillMainFile: createSkillMDContent(t, "test", "A test.", nil),
-		"scripts/run.sh":          []byte("#!/bin/bash"),
+func TestPublishedArtifactCreate_WithSubjects(t *testing.T) {
+	storage := newPublishedArtifactTestStorage(t)
+	blobStore, err := blobpkg.NewDirectoryStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create directory blob store: %v", err)
+	}
+	handler := NewPublishedArtifactHandler(blobStore, "test-bucket")
+	user := &kuser.DefaultInfo{Name: "owner", UID: "owner", Groups: []string{types.GroupAuthenticated}}
+	reqBody := createArtifactTestZIP(t, map[string][]byte{
+		skillformat.SkillMainFile: createSkillMDContent(t, "skill-a", "From SKILL.md", nil),
 	})
-	r, err := zip.NewReader(bytes.NewReader(zipData), int64(len(zipData)))
+
+	subjects := []types.Subject{{Type: types.SubjectTypeUser, ID: "user-a"}}
+	encoded, err := json.Marshal(subjects)
 	if err != nil {
-		t.Fatalf("failed to create zip reader: %v", err)
+		t.Fatal(err)
 	}
-	if err := validateZIP(r); err != nil {
-		t.Fatalf("validateZIP() unexpected error: %v", err)
+	req := httptest.NewRequest(http.MethodPost, "/api/published-artifacts?type=skill&subjects="+url.QueryEscape(string(encoded)), bytes.NewReader(reqBody))
+	rec := httptest.NewRecorder()
+	if err := handler.Create(api.Context{ResponseWriter: rec, Request: req, Storage: storage, User: user}); err != nil {
+		t.Fatalf("Create() error = %v", err)
 	}
-}
 
-func TestValidateZIPEntryName(t *testing.T) {
-	tests := []struct {
-		name    string
-		entry   string
-		wantErr string
-	}{
-		{name: "valid simple", entry: "SKILL.md"},
-		{name: "valid nested", entry: "scripts/run.sh"},
-		{name: "absolute unix", entry: "/etc/passwd", wantErr: "absolute path"},
-		{name: "traversal unix", entry: "../etc/passwd", wantErr: "path traversal"},
-		{name: "traversal nested", entry: "foo/../../etc/passwd", wantErr: "path traversal"},
-		{name: "windows backslash traversal", entry: `..\..\evil.sh`, wantErr: "path traversal"},
-		{name: "windows drive letter", entry: `C:\tmp\evil.sh`, wantErr: "absolute path"},
-		{name: "windows drive slash", entry: "C:/tmp/evil.sh", wantErr: "absolute path"},
+	var response types.PublishedArtifact
+	if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(response.Versions) != 1 || len(response.Versions[0].Subjects) != 1 || response.Versions[0].Subjects[0] != subjects[0] {
+		t.Fatalf("Versions = %+v, want one version shared with %+v", response.Versions, subjects)
 	}
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			err := validateZIPEntryName(tt.entry)
-			if tt.wantErr == "" {
-				if err != nil {
-					t.Fatalf("unexpected error: %v", err)
-				}
-				return
-			}
-			if err == nil {
-				t.Fatal("expected error, got nil")
-			}
-			if !strings.Contains(err.Error(), tt.wantErr) {
-				t.Errorf("error = %q, want containing %q", err.Error(), tt.wantErr)
-			}
-		})
+	// A later version replaces the previous version's subjects.
+	subjects = []types.Subject{{Type: types.SubjectTypeGroup, ID: "group-b"}}
+	if encoded, err = json.Marshal(subjects); err != nil {
+		t.Fatal(err)
+	}
+	req = httptest.NewRequest(http.MethodPost, "/api/published-artifacts?type=skill&subjects="+url.QueryEscape(string(encoded)), bytes.NewReader(reqBody))
+	rec = httptest.NewRecorder()
+	if err := handler.Create(api.Context{ResponseWriter: rec, Request: req, Storage: storage, User: user}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	response = types.PublishedArtifact{}
+	if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(response.Versions) != 2 || len(response.Versions[1].Subjects) != 1 || response.Versions[1].Subjects[0] != subjects[0] {
+		t.Fatalf("Versions = %+v, want version 2 shared with %+v", response.Versions, subjects)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/api/published-artifacts?type=skill&subjects="+url.QueryEscape(`[{"type":"user","id":"*"}]`), bytes.NewReader(reqBody))
+	err = handler.Create(api.Context{ResponseWriter: httptest.NewRecorder(), Request: req, Storage: storage, User: user})
+	if err == nil || !strings.Contains(err.Error(), "invalid subjects") {
+		t.Errorf("Create() error = %v, want invalid subjects", err)
 	}
 }
 
@@ -789,7 +791,7 @@ func TestRewriteSkillFrontmatterInZIP_EnforcesActualSize(t *testing.T) {
 		t.Fatal(err)
 	}
 	chunk := make([]byte, 1024*1024) // 1 MB
-	for written := 0; written <= maxZIPUncompressedBytes; written += len(chunk) {
+	for written := 0; written <= skillformat.MaxZIPUncompressedBytes; written += len(chunk) {
 		if _, err := bw.Write(chunk); err != nil {
 			t.Fatal(err)
 		}
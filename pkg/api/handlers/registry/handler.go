@@ -21,20 +21,18 @@ import (
 )
 
 type Handler struct {
-	acrHelper                 *accesscontrolrule.Helper
-	serverURL                 string
-	registryNoAuth            bool
-	mimeFetcher               *mimeFetcher
-	secretBindingAllowedLabel string
+	acrHelper      *accesscontrolrule.Helper
+	serverURL      string
+	registryNoAuth bool
+	mimeFetcher    *mimeFetcher
 }
 
-func NewHandler(acrHelper *accesscontrolrule.Helper, serverURL string, registryNoAuth bool, secretBindingAllowedLabel string) *Handler {
+func NewHandler(acrHelper *accesscontrolrule.Helper, serverURL string, registryNoAuth bool) *Handler {
 	return &Handler{
-		acrHelper:                 acrHelper,
-		serverURL:                 serverURL,
-		registryNoAuth:            registryNoAuth,
-		mimeFetcher:               newMimeFetcher(),
-		secretBindingAllowedLabel: secretBindingAllowedLabel,
+		acrHelper:      acrHelper,
+		serverURL:      serverURL,
+		registryNoAuth: registryNoAuth,
+		mimeFetcher:    newMimeFetcher(),
 	}
 }
 
@@ -97,7 +95,7 @@ func (h *Handler) collectAccessibleServers(req api.Context, reverseDNS string) (
 			continue
 		}
 
-		mergedCredEnv, err := mcp.MergeBoundCreds(req.Context(), req.LocalK8sClient, req.ObotNamespace, server.Spec.Manifest.Env, server.Spec.Manifest.RemoteConfig, credMap[server.Name], h.secretBindingAllowedLabel)
+		mergedCredEnv, err := mcp.MergeBoundCreds(req.Context(), req.SecretProvider, server.Spec.Manifest.Env, server.Spec.Manifest.RemoteConfig, credMap[server.Name])
 		if err != nil {
 			continue
 		}
@@ -144,7 +142,7 @@ func (h *Handler) collectAccessibleServers(req api.Context, reverseDNS string) (
 			continue
 		}
 
-		mergedCredEnv, err := mcp.MergeBoundCreds(req.Context(), req.LocalK8sClient, req.ObotNamespace, server.Spec.Manifest.Env, server.Spec.Manifest.RemoteConfig, credMap[server.Name], h.secretBindingAllowedLabel)
+		mergedCredEnv, err := mcp.MergeBoundCreds(req.Context(), req.SecretProvider, server.Spec.Manifest.Env, server.Spec.Manifest.RemoteConfig, credMap[server.Name])
 		if err != nil {
 			continue
 		}
@@ -186,7 +184,7 @@ func (h *Handler) collectAccessibleServers(req api.Context, reverseDNS string) (
 			continue
 		}
 
-		mergedCredEnv, err := mcp.MergeBoundCreds(req.Context(), req.LocalK8sClient, req.ObotNamespace, server.Spec.Manifest.Env, server.Spec.Manifest.RemoteConfig, credMap[server.Name], h.secretBindingAllowedLabel)
+		mergedCredEnv, err := mcp.MergeBoundCreds(req.Context(), req.SecretProvider, server.Spec.Manifest.Env, server.Spec.Manifest.RemoteConfig, credMap[server.Name])
 		if err != nil {
 			continue
 		}
@@ -275,7 +273,7 @@ func (h *Handler) collectAccessibleServersNoAuth(req api.Context, reverseDNS str
 		// Get credentials
 		credEnv, _ := h.getCredentialsForServer(req, server, "", system.DefaultCatalog, "")
 
-		mergedCredEnv, err := mcp.MergeBoundCreds(req.Context(), req.LocalK8sClient, req.ObotNamespace, server.Spec.Manifest.Env, server.Spec.Manifest.RemoteConfig, credEnv, h.secretBindingAllowedLabel)
+		mergedCredEnv, err := mcp.MergeBoundCreds(req.Context(), req.SecretProvider, server.Spec.Manifest.Env, server.Spec.Manifest.RemoteConfig, credEnv)
 		if err != nil {
 			continue
 		}
@@ -823,7 +821,7 @@ func (h *Handler) findMCPServer(req api.Context, serverName, reverseDNS string)
 		return types.RegistryServerResponse{}, fmt.Errorf("server not found")
 	}
 
-	credEnv, err = mcp.MergeBoundCreds(req.Context(), req.LocalK8sClient, req.ObotNamespace, server.Spec.Manifest.Env, server.Spec.Manifest.RemoteConfig, credEnv, h.secretBindingAllowedLabel)
+	credEnv, err = mcp.MergeBoundCreds(req.Context(), req.SecretProvider, server.Spec.Manifest.Env, server.Spec.Manifest.RemoteConfig, credEnv)
 	if err != nil {
 		return types.RegistryServerResponse{}, fmt.Errorf("failed to resolve secret bindings: %w", err)
 	}
@@ -70,6 +70,9 @@ func (*SkillAccessRuleHandler) Update(req api.Context) error {
 	if err := req.Get(&rule, req.PathValue("skill_access_rule_id")); err != nil {
 		return fmt.Errorf("failed to get skill access rule: %w", err)
 	}
+	if err := checkNotManagedByConfigRepository(&rule); err != nil {
+		return err
+	}
 
 	rule.Spec.Manifest = *manifest
 	if err := req.Update(&rule); err != nil {
@@ -80,10 +83,17 @@ func (*SkillAccessRuleHandler) Update(req api.Context) error {
 }
 
 func (*SkillAccessRuleHandler) Delete(req api.Context) error {
-	return req.Delete(&v1.SkillAccessRule{
-		Name:      req.PathValue("skill_access_rule_id"),
-		Namespace: req.Namespace(),
-	})
+	var rule v1.SkillAccessRule
+	if err := req.Get(&rule, req.PathValue("skill_access_rule_id")); apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to get skill access rule: %w", err)
+	}
+	if err := checkNotManagedByConfigRepository(&rule); err != nil {
+		return err
+	}
+
+	return req.Delete(&rule)
 }
 
 func readAndValidateManifest(req api.Context) (*types.SkillAccessRuleManifest, error) {
@@ -20,11 +20,13 @@ import (
 	"github.com/obot-platform/obot/pkg/controller/handlers/skillrepository"
 	gclient "github.com/obot-platform/obot/pkg/gateway/client"
 	gatewaytypes "github.com/obot-platform/obot/pkg/gateway/types"
+	gitpkg "github.com/obot-platform/obot/pkg/git"
 	"github.com/obot-platform/obot/pkg/gitcredential"
 	"github.com/obot-platform/obot/pkg/skillaccessrule"
 	"github.com/obot-platform/obot/pkg/skillformat"
 	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -35,7 +37,7 @@ const (
 
 type SkillHandler struct {
 	skillAccessRuleHelper  *skillaccessrule.Helper
-	materializeSkillSource func(ctx context.Context, skill *v1.Skill, token string) (func(), string, error)
+	materializeSkillSource func(ctx context.Context, skill *v1.Skill, auth gitpkg.Auth) (func(), string, error)
 }
 
 type skillRepositoryCredentialRevealer interface {
@@ -64,20 +66,20 @@ func revealSkillRepositoryToken(ctx context.Context, client skillRepositoryCrede
 }
 
 func (h *SkillHandler) materialize(req api.Context, skill *v1.Skill) (func(), string, error) {
-	var token string
+	var auth gitpkg.Auth
 	var repository v1.SkillRepository
 	err := req.Get(&repository, skill.Spec.RepoID)
 	if err == nil && repository.Spec.GitCredentialID != "" {
-		token, err = gitcredential.Resolve(req.Context(), req.Storage, req.GatewayClient, req.Namespace(), repository.Spec.GitCredentialID, skill.Spec.RepoURL)
+		auth, err = gitcredential.Resolve(req.Context(), req.Storage, req.GatewayClient, req.Namespace(), repository.Spec.GitCredentialID, skill.Spec.RepoURL)
 	} else if err == nil || apierrors.IsNotFound(err) {
-		token, err = revealSkillRepositoryToken(req.Context(), req.GatewayClient, skill)
+		auth.Token, err = revealSkillRepositoryToken(req.Context(), req.GatewayClient, skill)
 	} else {
 		err = fmt.Errorf("failed to get skill repository %s: %w", skill.Spec.RepoID, err)
 	}
 	if err != nil {
 		return nil, "", err
 	}
-	return h.materializeSkillSource(req.Context(), skill, token)
+	return h.materializeSkillSource(req.Context(), skill, auth)
 }
 
 func (h *SkillHandler) List(req api.Context) error {
@@ -95,7 +97,7 @@ func (h *SkillHandler) List(req api.Context) error {
 	query := strings.ToLower(strings.TrimSpace(req.URL.Query().Get("q")))
 	filtered := make([]types.Skill, 0, len(items))
 	for _, item := range items {
-		if !item.Status.Valid && !includeInvalid {
+		if !item.Installable() && !includeInvalid {
 			continue
 		}
 		if query != "" && !matchesSkillQuery(item, query) {
@@ -121,7 +123,7 @@ func (h *SkillHandler) List(req api.Context) error {
 }
 
 func (h *SkillHandler) Get(req api.Context) error {
-	skill, err := h.getAccessibleSkill(req, req.PathValue("id"))
+	skill, err := h.getSkillWithRevisions(req, req.PathValue("id"))
 	if err != nil {
 		return err
 	}
@@ -166,11 +168,37 @@ func (h *SkillHandler) Preview(req api.Context) error {
 }
 
 func (h *SkillHandler) Download(req api.Context) error {
-	skill, err := h.getAccessibleSkill(req, req.PathValue("id"))
+	revision := req.URL.Query().Get("revision")
+	getSkill := h.getAccessibleSkill
+	if revision != "" {
+		getSkill = h.getSkillWithRevisions
+	}
+	skill, err := getSkill(req, req.PathValue("id"))
 	if err != nil {
 		return err
 	}
 
+	if revision != "" {
+		rev, ok := skill.Revision(revision)
+		if !ok {
+			return types.NewErrNotFound("revision %s not found for skill %s", revision, skill.Name)
+		}
+		if !skill.RevisionInstallable(rev) {
+			if rev.ScanBlocked {
+				return types.NewErrForbidden("revision %s of skill %s is blocked by its security scan findings", revision, skill.Name)
+			}
+			return types.NewErrForbidden("revision %s of skill %s is not a valid skill", revision, skill.Name)
+		}
+
+		// Materialize the pinned commit instead of the latest indexed one.
+		skill = skill.DeepCopy()
+		skill.Spec.CommitSHA = rev.CommitSHA
+		skill.Spec.InstallHash = rev.InstallHash
+		skill.Status.LastIndexedAt = rev.IndexedAt
+	} else if !skill.Installable() {
+		return types.NewErrForbidden("skill %s is blocked by its security scan findings", skill.Name)
+	}
+
 	cleanup, skillDir, err := h.materialize(req, skill)
 	if err != nil {
 		return fmt.Errorf("failed to materialize skill source: %w", err)
@@ -193,6 +221,70 @@ func (h *SkillHandler) Download(req api.Context) error {
 	return zipSkillDirectory(skillDir, req.ResponseWriter)
 }
 
+// Revisions lists the indexed revisions of a skill, newest first.
+func (h *SkillHandler) Revisions(req api.Context) error {
+	skill, err := h.getSkillWithRevisions(req, req.PathValue("id"))
+	if err != nil {
+		return err
+	}
+
+	items := make([]types.SkillRevision, 0, len(skill.Status.Revisions))
+	for _, rev := range skill.Status.Revisions {
+		items = append(items, convertSkillRevision(skill, rev))
+	}
+
+	return req.Write(types.SkillRevisionList{Items: items})
+}
+
+// SetScanOverride allows installation of a skill that was blocked by its
+// security scan. The override is tied to the skill's current install hash.
+func (*SkillHandler) SetScanOverride(req api.Context) error {
+	var overrideReq types.SkillScanOverrideRequest
+	if err := req.Read(&overrideReq); err != nil && !errors.Is(err, io.EOF) {
+		return types.NewErrBadRequest("failed to read scan override request: %v", err)
+	}
+
+	var skill v1.Skill
+	if err := req.Get(&skill, req.PathValue("id")); err != nil {
+		return err
+	}
+	if !skill.Status.Valid || skill.Spec.InstallHash == "" {
+		return types.NewErrBadRequest("skill %s is not valid and cannot be overridden", skill.Name)
+	}
+	if !skill.Status.ScanBlocked {
+		return types.NewErrHTTP(http.StatusConflict, fmt.Sprintf("skill %s is not blocked by its security scan and has nothing to override", skill.Name))
+	}
+
+	skill.Spec.ScanOverride = &v1.SkillScanOverride{
+		InstallHash:  skill.Spec.InstallHash,
+		OverriddenBy: req.User.GetName(),
+		Reason:       strings.TrimSpace(overrideReq.Reason),
+		OverriddenAt: metav1.Now(),
+	}
+	if err := req.Update(&skill); err != nil {
+		return fmt.Errorf("failed to set scan override for skill %s: %w", skill.Name, err)
+	}
+
+	return req.Write(convertSkill(skill))
+}
+
+// DeleteScanOverride removes an admin scan override from a skill.
+func (*SkillHandler) DeleteScanOverride(req api.Context) error {
+	var skill v1.Skill
+	if err := req.Get(&skill, req.PathValue("id")); err != nil {
+		return err
+	}
+
+	if skill.Spec.ScanOverride != nil {
+		skill.Spec.ScanOverride = nil
+		if err := req.Update(&skill); err != nil {
+			return fmt.Errorf("failed to remove scan override for skill %s: %w", skill.Name, err)
+		}
+	}
+
+	return req.Write(convertSkill(skill))
+}
+
 func (h *SkillHandler) getAccessibleSkill(req api.Context, id string) (*v1.Skill, error) {
 	var skill v1.Skill
 	if err := req.Get(&skill, id); err != nil {
@@ -201,10 +293,31 @@ func (h *SkillHandler) getAccessibleSkill(req api.Context, id string) (*v1.Skill
 	if !skill.Status.Valid {
 		return nil, types.NewErrNotFound("skill %s not found", id)
 	}
+	// Admins and auditors can still inspect skills blocked by the security scan
+	// so that they can review the findings before overriding them.
+	if !skill.Installable() && !req.UserIsAdmin() && !req.UserIsAuditor() {
+		return nil, types.NewErrNotFound("skill %s not found", id)
+	}
 
 	return &skill, nil
 }
 
+// getSkillWithRevisions returns a skill for requests that can target any of its
+// indexed revisions. Unlike getAccessibleSkill it does not require the current
+// revision to be valid or installable, so that users can still roll back to an
+// earlier revision that is.
+func (h *SkillHandler) getSkillWithRevisions(req api.Context, id string) (*v1.Skill, error) {
+	var skill v1.Skill
+	if err := req.Get(&skill, id); err != nil {
+		return nil, err
+	}
+	if req.UserIsAdmin() || req.UserIsAuditor() || skill.Installable() || slices.ContainsFunc(skill.Status.Revisions, skill.RevisionInstallable) {
+		return &skill, nil
+	}
+
+	return nil, types.NewErrNotFound("skill %s not found", id)
+}
+
 func (h *SkillHandler) listAccessibleSkills(req api.Context, repoID string) ([]v1.Skill, error) {
 	allowAll, repoIDs, skillIDs, err := h.skillAccessRuleHelper.GetUserSkillAccessScope(req.User)
 	if err != nil {
@@ -345,10 +458,11 @@ func injectObotMetadata(skillDir string, skill *v1.Skill) error {
 	if fm.Metadata == nil {
 		fm.Metadata = make(map[string]string)
 	}
-	fm.Metadata["obot-skill-id"] = skill.Name
-	fm.Metadata["obot-skill-repository-id"] = skill.Spec.RepoID
-	fm.Metadata["obot-commit-sha"] = skill.Spec.CommitSHA
-	fm.Metadata["obot-indexed-at"] = skill.Status.LastIndexedAt.UTC().Format("2006-01-02T15:04:05Z")
+	fm.Metadata[skillformat.MetadataSkillID] = skill.Name
+	fm.Metadata[skillformat.MetadataRepositoryID] = skill.Spec.RepoID
+	fm.Metadata[skillformat.MetadataCommitSHA] = skill.Spec.CommitSHA
+	fm.Metadata[skillformat.MetadataInstallHash] = skill.Spec.InstallHash
+	fm.Metadata[skillformat.MetadataIndexedAt] = skill.Status.LastIndexedAt.UTC().Format("2006-01-02T15:04:05Z")
 
 	output, err := skillformat.FormatSkillMD(fm, body)
 	if err != nil {
@@ -469,5 +583,33 @@ func convertSkill(skill v1.Skill) types.Skill {
 		Valid:           skill.Status.Valid,
 		ValidationError: skill.Status.ValidationError,
 		LastIndexedAt:   *types.NewTime(skill.Status.LastIndexedAt.Time),
+		ScanFindings:    skill.Status.ScanFindings,
+		ScanBlocked:     skill.Status.ScanBlocked,
+		ScanOverride:    convertSkillScanOverride(skill.Spec.ScanOverride),
+		Installable:     skill.Installable(),
+	}
+}
+
+func convertSkillRevision(skill *v1.Skill, rev v1.SkillRevision) types.SkillRevision {
+	return types.SkillRevision{
+		SkillManifest: rev.Manifest,
+		CommitSHA:     rev.CommitSHA,
+		InstallHash:   rev.InstallHash,
+		IndexedAt:     *types.NewTime(rev.IndexedAt.Time),
+		ScanBlocked:   rev.ScanBlocked,
+		Installable:   skill.RevisionInstallable(rev),
+		Current:       rev.InstallHash == skill.Spec.InstallHash,
+	}
+}
+
+func convertSkillScanOverride(override *v1.SkillScanOverride) *types.SkillScanOverride {
+	if override == nil {
+		return nil
+	}
+	return &types.SkillScanOverride{
+		InstallHash:  override.InstallHash,
+		OverriddenBy: override.OverriddenBy,
+		Reason:       override.Reason,
+		OverriddenAt: *types.NewTime(override.OverriddenAt.Time),
 	}
 }
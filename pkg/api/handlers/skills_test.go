@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -20,6 +21,7 @@ import (
 	gclient "github.com/obot-platform/obot/pkg/gateway/client"
 	gatewaydb "github.com/obot-platform/obot/pkg/gateway/db"
 	gatewaytypes "github.com/obot-platform/obot/pkg/gateway/types"
+	gitpkg "github.com/obot-platform/obot/pkg/git"
 	"github.com/obot-platform/obot/pkg/skillaccessrule"
 	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
 	storagescheme "github.com/obot-platform/obot/pkg/storage/scheme"
@@ -486,10 +488,10 @@ func TestSkillHandlerDownloadPackagesMaterializedSkill(t *testing.T) {
 	handler := NewSkillHandler(newSkillAccessRuleHelper(t,
 		newSkillRule("rule1", []types.Subject{{Type: types.SubjectTypeUser, ID: "user1"}}, []types.SkillResource{{Type: types.SkillResourceTypeSkillRepository, ID: "repo-1"}}),
 	))
-	handler.materializeSkillSource = func(_ context.Context, got *v1.Skill, token string) (func(), string, error) {
+	handler.materializeSkillSource = func(_ context.Context, got *v1.Skill, auth gitpkg.Auth) (func(), string, error) {
 		assert.Equal(t, "abc123", got.Spec.CommitSHA)
 		assert.Equal(t, "skills/postgres-helper", got.Spec.RelativePath)
-		assert.Equal(t, "private-token", token)
+		assert.Equal(t, gitpkg.Auth{Token: "private-token"}, auth)
 		return func() {}, tempDir, nil
 	}
 
@@ -520,6 +522,242 @@ func TestSkillHandlerDownloadPackagesMaterializedSkill(t *testing.T) {
 	assert.Contains(t, names, "scripts/run.sh")
 }
 
+func TestSkillHandlerScanBlockedSkillAndOverride(t *testing.T) {
+	skill := &v1.Skill{
+		Name: "sk1", Namespace: system.DefaultNamespace,
+		Spec: v1.SkillSpec{
+			SkillManifest: types.SkillManifest{Name: "risky-helper"},
+			RepoID:        "repo-1",
+			InstallHash:   "hash1",
+		},
+		Status: v1.SkillStatus{
+			Valid:       true,
+			ScanBlocked: true,
+			ScanFindings: []types.SkillScanFinding{{
+				Scanner:  "remote-execution",
+				Severity: types.SkillScanSeverityHigh,
+				Path:     "install.sh",
+				Line:     1,
+				Message:  "remote script piped to a shell",
+			}},
+		},
+	}
+	storage := newFakeStorage(t, skill)
+	handler := NewSkillHandler(newSkillAccessRuleHelper(t,
+		newSkillRule("rule1", []types.Subject{{Type: types.SubjectTypeUser, ID: "user1"}}, []types.SkillResource{{Type: types.SkillResourceTypeSkillRepository, ID: "repo-1"}}),
+	))
+
+	get := func(user kuser.Info) error {
+		req := httptest.NewRequest(http.MethodGet, "/api/skills/sk1", nil)
+		req.SetPathValue("id", "sk1")
+		return handler.Get(api.Context{
+			ResponseWriter: httptest.NewRecorder(),
+			Request:        req,
+			Storage:        storage,
+			User:           user,
+		})
+	}
+
+	err := get(testUser("user1"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+	require.NoError(t, get(testUserWithRole("admin1", types.GroupAdmin)))
+
+	downloadReq := httptest.NewRequest(http.MethodGet, "/api/skills/sk1/download", nil)
+	downloadReq.SetPathValue("id", "sk1")
+	err = handler.Download(api.Context{
+		ResponseWriter: httptest.NewRecorder(),
+		Request:        downloadReq,
+		Storage:        storage,
+		User:           testUserWithRole("admin1", types.GroupAdmin),
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "blocked by its security scan")
+
+	overrideReq := httptest.NewRequest(http.MethodPost, "/api/skills/sk1/scan-override", strings.NewReader(`{"reason":" reviewed installer "}`))
+	overrideReq.SetPathValue("id", "sk1")
+	rec := httptest.NewRecorder()
+	require.NoError(t, handler.SetScanOverride(api.Context{
+		ResponseWriter: rec,
+		Request:        overrideReq,
+		Storage:        storage,
+		User:           testUserWithRole("admin1", types.GroupAdmin),
+	}))
+
+	var resp types.Skill
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.True(t, resp.Installable)
+	require.NotNil(t, resp.ScanOverride)
+	assert.Equal(t, "hash1", resp.ScanOverride.InstallHash)
+	assert.Equal(t, "admin1", resp.ScanOverride.OverriddenBy)
+	assert.Equal(t, "reviewed installer", resp.ScanOverride.Reason)
+
+	require.NoError(t, get(testUser("user1")))
+
+	deleteReq := httptest.NewRequest(http.MethodDelete, "/api/skills/sk1/scan-override", nil)
+	deleteReq.SetPathValue("id", "sk1")
+	require.NoError(t, handler.DeleteScanOverride(api.Context{
+		ResponseWriter: httptest.NewRecorder(),
+		Request:        deleteReq,
+		Storage:        storage,
+		User:           testUserWithRole("admin1", types.GroupAdmin),
+	}))
+	require.Error(t, get(testUser("user1")))
+}
+
+func TestSkillHandlerScanOverrideRequiresScanBlock(t *testing.T) {
+	storage := newFakeStorage(t, &v1.Skill{
+		Name: "sk1", Namespace: system.DefaultNamespace,
+		Spec: v1.SkillSpec{
+			SkillManifest: types.SkillManifest{Name: "safe-helper"},
+			RepoID:        "repo-1",
+			InstallHash:   "hash1",
+		},
+		Status: v1.SkillStatus{Valid: true},
+	})
+	handler := NewSkillHandler(newSkillAccessRuleHelper(t))
+
+	req := httptest.NewRequest(http.MethodPost, "/api/skills/sk1/scan-override", strings.NewReader(`{"reason":"just in case"}`))
+	req.SetPathValue("id", "sk1")
+	err := handler.SetScanOverride(api.Context{
+		ResponseWriter: httptest.NewRecorder(),
+		Request:        req,
+		Storage:        storage,
+		User:           testUserWithRole("admin1", types.GroupAdmin),
+	})
+	var httpErr *types.ErrHTTP
+	require.True(t, errors.As(err, &httpErr), "expected *types.ErrHTTP, got %T: %v", err, err)
+	assert.Equal(t, http.StatusConflict, httpErr.Code)
+
+	var stored v1.Skill
+	require.NoError(t, storage.Get(t.Context(), kclient.ObjectKey{Namespace: system.DefaultNamespace, Name: "sk1"}, &stored))
+	assert.Nil(t, stored.Spec.ScanOverride)
+}
+
+func TestSkillHandlerRevisionsOfInvalidSkill(t *testing.T) {
+	// The latest commit no longer has a valid skill, but an earlier revision
+	// was indexed and can still be installed.
+	skill := &v1.Skill{
+		Name: "sk1", Namespace: system.DefaultNamespace,
+		Spec: v1.SkillSpec{
+			SkillManifest: types.SkillManifest{Name: "postgres-helper"},
+			RepoID:        "repo-1",
+			CommitSHA:     "bbb222",
+			RelativePath:  "skills/postgres-helper",
+		},
+		Status: v1.SkillStatus{
+			Revisions: []v1.SkillRevision{
+				{CommitSHA: "bbb222"},
+				{CommitSHA: "aaa111", InstallHash: "hash1"},
+			},
+		},
+	}
+	storage := newFakeStorage(t, skill)
+	gatewayClient := newHandlerTestGateway(t)
+
+	tempDir := t.TempDir()
+	require.NoError(t, os.WriteFile(tempDir+"/SKILL.md", []byte("---\nname: postgres-helper\ndescription: Test\n---\n"), 0o644))
+
+	handler := NewSkillHandler(newSkillAccessRuleHelper(t,
+		newSkillRule("rule1", []types.Subject{{Type: types.SubjectTypeUser, ID: "user1"}}, []types.SkillResource{{Type: types.SkillResourceTypeSkillRepository, ID: "repo-1"}}),
+	))
+	handler.materializeSkillSource = func(_ context.Context, got *v1.Skill, _ gitpkg.Auth) (func(), string, error) {
+		assert.Equal(t, "aaa111", got.Spec.CommitSHA)
+		return func() {}, tempDir, nil
+	}
+
+	newContext := func(path string, rec *httptest.ResponseRecorder) api.Context {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.SetPathValue("id", "sk1")
+		return api.Context{
+			ResponseWriter: rec,
+			Request:        req,
+			Storage:        storage,
+			GatewayClient:  gatewayClient,
+			User:           testUser("user1"),
+		}
+	}
+
+	err := handler.Download(newContext("/api/skills/sk1/download", httptest.NewRecorder()))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+
+	rec := httptest.NewRecorder()
+	require.NoError(t, handler.Revisions(newContext("/api/skills/sk1/revisions", rec)))
+	var revisions types.SkillRevisionList
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &revisions))
+	require.Len(t, revisions.Items, 2)
+	assert.False(t, revisions.Items[0].Installable, "a revision without an install hash is not installable")
+	assert.True(t, revisions.Items[1].Installable)
+
+	err = handler.Download(newContext("/api/skills/sk1/download?revision=bbb222", httptest.NewRecorder()))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not a valid skill")
+
+	rec = httptest.NewRecorder()
+	require.NoError(t, handler.Download(newContext("/api/skills/sk1/download?revision=aaa111", rec)))
+	assert.Equal(t, "application/zip", rec.Header().Get("Content-Type"))
+}
+
+func TestSkillHandlerRevisionsOfScanBlockedSkill(t *testing.T) {
+	skill := &v1.Skill{
+		Name: "sk1", Namespace: system.DefaultNamespace,
+		Spec: v1.SkillSpec{
+			SkillManifest: types.SkillManifest{Name: "risky-helper"},
+			RepoID:        "repo-1",
+			CommitSHA:     "bbb222",
+			InstallHash:   "hash2",
+		},
+		Status: v1.SkillStatus{
+			Valid:       true,
+			ScanBlocked: true,
+			Revisions: []v1.SkillRevision{
+				{CommitSHA: "bbb222", InstallHash: "hash2", ScanBlocked: true},
+				{CommitSHA: "aaa111", InstallHash: "hash1", ScanBlocked: true},
+			},
+		},
+	}
+	storage := newFakeStorage(t, skill)
+	handler := NewSkillHandler(newSkillAccessRuleHelper(t,
+		newSkillRule("rule1", []types.Subject{{Type: types.SubjectTypeUser, ID: "user1"}}, []types.SkillResource{{Type: types.SkillResourceTypeSkillRepository, ID: "repo-1"}}),
+	))
+
+	revisions := func() error {
+		req := httptest.NewRequest(http.MethodGet, "/api/skills/sk1/revisions", nil)
+		req.SetPathValue("id", "sk1")
+		return handler.Revisions(api.Context{
+			ResponseWriter: httptest.NewRecorder(),
+			Request:        req,
+			Storage:        storage,
+			User:           testUser("user1"),
+		})
+	}
+
+	// Every revision is blocked, so users cannot see the skill at all.
+	err := revisions()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+
+	// An override for an earlier revision lets users roll back to it, even
+	// though the current revision is still blocked.
+	var stored v1.Skill
+	require.NoError(t, storage.Get(t.Context(), kclient.ObjectKeyFromObject(skill), &stored))
+	stored.Spec.ScanOverride = &v1.SkillScanOverride{InstallHash: "hash1"}
+	require.NoError(t, storage.Update(t.Context(), &stored))
+	require.NoError(t, revisions())
+
+	downloadReq := httptest.NewRequest(http.MethodGet, "/api/skills/sk1/download?revision=bbb222", nil)
+	downloadReq.SetPathValue("id", "sk1")
+	err = handler.Download(api.Context{
+		ResponseWriter: httptest.NewRecorder(),
+		Request:        downloadReq,
+		Storage:        storage,
+		User:           testUser("user1"),
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "blocked by its security scan")
+}
+
 func TestSkillHandlerPreviewReturnsSkillMD(t *testing.T) {
 	want := []byte("---\nname: postgres-helper\ndescription: Preview me\n---\n\n# Instructions\n")
 	skill := &v1.Skill{
@@ -541,9 +779,9 @@ func TestSkillHandlerPreviewReturnsSkillMD(t *testing.T) {
 	handler := NewSkillHandler(newSkillAccessRuleHelper(t,
 		newSkillRule("rule1", []types.Subject{{Type: types.SubjectTypeUser, ID: "user1"}}, []types.SkillResource{{Type: types.SkillResourceTypeSkillRepository, ID: "repo-1"}}),
 	))
-	handler.materializeSkillSource = func(_ context.Context, got *v1.Skill, token string) (func(), string, error) {
+	handler.materializeSkillSource = func(_ context.Context, got *v1.Skill, auth gitpkg.Auth) (func(), string, error) {
 		assert.Equal(t, "abc123", got.Spec.CommitSHA)
-		assert.Empty(t, token)
+		assert.Empty(t, auth)
 		return func() {}, tempDir, nil
 	}
 
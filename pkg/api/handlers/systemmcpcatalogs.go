@@ -13,6 +13,7 @@ import (
 	mcpcataloghandler "github.com/obot-platform/obot/pkg/controller/handlers/mcpcatalog"
 	gateway "github.com/obot-platform/obot/pkg/gateway/client"
 	gatewaytypes "github.com/obot-platform/obot/pkg/gateway/types"
+	gitpkg "github.com/obot-platform/obot/pkg/git"
 	"github.com/obot-platform/obot/pkg/mcp"
 	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
 	"github.com/obot-platform/obot/pkg/system"
@@ -283,6 +284,17 @@ func normalizeAndValidateCatalogSourceURLs(sourceURLs []string, localPath string
 		if urlStr == "" || urlStr == localPath {
 			continue
 		}
+		if gitpkg.IsSSHURL(urlStr) {
+			normalized, err := gitpkg.NormalizeSSHURL(urlStr)
+			if err != nil {
+				return types.NewErrBadRequest("invalid URL: %v", err)
+			}
+			if !gitpkg.IsGitRepoURL(normalized) {
+				return types.NewErrBadRequest("SSH URLs must point to a git repository")
+			}
+			sourceURLs[i] = normalized
+			continue
+		}
 		if !strings.Contains(urlStr, "://") {
 			urlStr = "https://" + urlStr
 			sourceURLs[i] = urlStr
@@ -292,7 +304,7 @@ func normalizeAndValidateCatalogSourceURLs(sourceURLs []string, localPath string
 			return types.NewErrBadRequest("invalid URL: %v", err)
 		}
 		if u.Scheme != "https" {
-			return types.NewErrBadRequest("only HTTPS URLs are supported")
+			return types.NewErrBadRequest("only HTTPS and SSH URLs are supported")
 		}
 	}
 
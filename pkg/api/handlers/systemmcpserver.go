@@ -25,14 +25,12 @@ import (
 )
 
 type SystemMCPServerHandler struct {
-	mcpSessionManager         *mcp.SessionManager
-	secretBindingAllowedLabel string
+	mcpSessionManager *mcp.SessionManager
 }
 
-func NewSystemMCPServerHandler(mcpLoader *mcp.SessionManager, secretBindingAllowedLabel string) *SystemMCPServerHandler {
+func NewSystemMCPServerHandler(mcpLoader *mcp.SessionManager) *SystemMCPServerHandler {
 	return &SystemMCPServerHandler{
-		mcpSessionManager:         mcpLoader,
-		secretBindingAllowedLabel: secretBindingAllowedLabel,
+		mcpSessionManager: mcpLoader,
 	}
 }
 
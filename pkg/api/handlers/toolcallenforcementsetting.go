@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/obot-platform/obot/pkg/api"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	"github.com/obot-platform/obot/pkg/system"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+type ToolCallEnforcementSettingHandler struct{}
+
+func NewToolCallEnforcementSettingHandler() *ToolCallEnforcementSettingHandler {
+	return nil
+}
+
+func (*ToolCallEnforcementSettingHandler) Get(req api.Context) error {
+	var setting v1.ToolCallEnforcementSetting
+	if err := req.Get(&setting, system.ToolCallEnforcementSettingName); apierrors.IsNotFound(err) {
+		// The LLM gateway does not evaluate tool calls until an admin enables it.
+		return req.Write(types.ToolCallEnforcementSetting{})
+	} else if err != nil {
+		return err
+	}
+	return req.Write(setting.Spec.Manifest)
+}
+
+func (*ToolCallEnforcementSettingHandler) Update(req api.Context) error {
+	var input types.ToolCallEnforcementSetting
+	if err := req.Read(&input); err != nil {
+		return err
+	}
+
+	allowlist, err := normalizeEnforcementAllowlist(input.Allowlist)
+	if err != nil {
+		return err
+	}
+	if err := validateEnforcementAllowlist(allowlist); err != nil {
+		return err
+	}
+	input.Allowlist = allowlist
+
+	var setting v1.ToolCallEnforcementSetting
+	if err := req.Get(&setting, system.ToolCallEnforcementSettingName); apierrors.IsNotFound(err) {
+		// Match MDM configurations: enabling enforcement for the first time
+		// without an allowlist starts from the default rather than denying
+		// every tool call.
+		if input.Enabled && enforcementAllowlistIsEmpty(input.Allowlist) {
+			input.Allowlist = defaultEnforcementAllowlist()
+		}
+		setting = v1.ToolCallEnforcementSetting{
+			Name:      system.ToolCallEnforcementSettingName,
+			Namespace: req.Namespace(),
+			Spec: v1.ToolCallEnforcementSettingSpec{
+				Manifest: input,
+			},
+		}
+		if err := req.Create(&setting); err != nil {
+			return err
+		}
+	} else if err != nil {
+		return err
+	} else if err := checkNotManagedByConfigRepository(&setting); err != nil {
+		return err
+	} else {
+		setting.Spec.Manifest = input
+		if err := req.Update(&setting); err != nil {
+			return err
+		}
+	}
+
+	return req.Write(setting.Spec.Manifest)
+}
@@ -44,6 +44,7 @@ type VersionHandlerOptions struct {
 	MessagePoliciesEnabled  bool
 	AgentsEnabled           bool
 	HideK8sDetails          bool
+	SecretBindingsEnabled   bool
 }
 
 type VersionHandler struct {
@@ -150,6 +151,7 @@ func (v *VersionHandler) getVersionResponse(ctx context.Context) (map[string]any
 		"messagePoliciesEnabled":       v.MessagePoliciesEnabled,
 		"agentsEnabled":                v.AgentsEnabled,
 		"hideK8sDetails":               v.HideK8sDetails,
+		"secretBindingsEnabled":        v.SecretBindingsEnabled,
 		"licenseEntitlementViolations": violations,
 		"missingLicenseEntitlements":   missingEntitlements(violations),
 	}
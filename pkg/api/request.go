@@ -14,6 +14,7 @@ import (
 	"github.com/klauspost/compress/zstd"
 	"github.com/obot-platform/obot/apiclient/types"
 	gclient "github.com/obot-platform/obot/pkg/gateway/client"
+	"github.com/obot-platform/obot/pkg/secretprovider"
 	"github.com/obot-platform/obot/pkg/storage"
 	"github.com/obot-platform/obot/pkg/system"
 	"github.com/obot-platform/obot/pkg/utils"
@@ -40,15 +41,11 @@ type (
 		User          user.Info
 		APIBaseURL    string
 
-		// LocalK8sClient is a kclient for the local Kubernetes cluster — the
-		// cluster the obot pod runs in, where source Secrets for
-		// secretBindings live. Nil on the docker backend
-		LocalK8sClient kclient.Client
-
-		// ObotNamespace is the Kubernetes namespace in which the obot server
-		// runs; mcp.MergeBoundCreds reads source Secrets from here. Empty
-		// when LocalK8sClient is nil.
-		ObotNamespace string
+		// SecretProvider resolves the secrets that MCP secret bindings
+		// reference; mcp.MergeBoundCreds reads them through it. Nil when no
+		// provider is configured, such as the default Kubernetes provider on
+		// the docker backend.
+		SecretProvider secretprovider.Provider
 	}
 
 	HandlerFunc func(Context) error
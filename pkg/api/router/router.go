@@ -13,6 +13,7 @@ import (
 	"github.com/obot-platform/obot/pkg/api/handlers/registry"
 	"github.com/obot-platform/obot/pkg/api/handlers/setup"
 	"github.com/obot-platform/obot/pkg/api/handlers/wellknown"
+	"github.com/obot-platform/obot/pkg/hostedagenttrigger"
 	"github.com/obot-platform/obot/pkg/services"
 	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
 	"github.com/obot-platform/obot/pkg/upgrade"
@@ -65,6 +66,7 @@ func Router(ctx context.Context, services *services.Services) (http.Handler, err
 		MessagePoliciesEnabled:  services.MessagePoliciesEnabled,
 		AgentsEnabled:           agentsEnabled,
 		HideK8sDetails:          services.HideK8sDetails,
+		SecretBindingsEnabled:   services.MCPSessionManager.SecretProvider() != nil,
 	})
 	if err != nil {
 		return nil, err
@@ -78,8 +80,11 @@ func Router(ctx context.Context, services *services.Services) (http.Handler, err
 		mcpgateway.NewAuditLogHandler(services.GatewayClient),
 		services.ServerURL,
 		services.DSN,
-		services.MCPSecretBindingAllowedLabel,
 		services.TunnelManager,
+		services.AccessControlRuleHelper,
+		services.MCPQuotaHelper,
+		services.MCPApprovalHelper,
+		services.MCPServerRequestHelper,
 	)
 	if err != nil {
 		return nil, err
@@ -89,10 +94,10 @@ func Router(ctx context.Context, services *services.Services) (http.Handler, err
 	agentConnect := agentconnect.New(http.DefaultTransport, services.AgentDevRouter)
 	agentTerminal := agentterminal.New(services.AgentBackend, services.DevUIPort)
 
-	oauthChecker := oauth.NewMCPOAuthHandlerFactory(services.ServerURL, services.MCPSessionManager, services.StorageClient, services.GatewayClient, services.MCPOAuthTokenStorage, services.MCPSecretBindingAllowedLabel, services.ForceDynamicClient)
+	oauthChecker := oauth.NewMCPOAuthHandlerFactory(services.ServerURL, services.MCPSessionManager, services.StorageClient, services.GatewayClient, services.MCPOAuthTokenStorage, services.ForceDynamicClient)
 
 	models := handlers.NewModelHandler(services.ModelAccessPolicyHelper)
-	mcpCatalogs := handlers.NewMCPCatalogHandler(services.DefaultMCPCatalogPath, services.ServerURL, services.MCPRuntimeBackend, services.MCPSessionManager, oauthChecker, services.GatewayClient, services.AccessControlRuleHelper, services.MCPSecretBindingAllowedLabel)
+	mcpCatalogs := handlers.NewMCPCatalogHandler(services.DefaultMCPCatalogPath, services.ServerURL, services.MCPSessionManager, oauthChecker, services.GatewayClient, services.AccessControlRuleHelper)
 	modelInfoSources := handlers.NewModelInfoSourceHandler()
 	systemMCPCatalogs := handlers.NewSystemMCPCatalogHandler(services.DefaultSystemMCPCatalogPath, services.MCPSessionManager)
 	accessControlRules := handlers.NewAccessControlRuleHandler()
@@ -100,6 +105,7 @@ func Router(ctx context.Context, services *services.Services) (http.Handler, err
 	gitCredentials := handlers.NewGitCredentialHandler()
 	skillAccessRules := handlers.NewSkillAccessRuleHandler()
 	agentCatalogs := handlers.NewAgentCatalogHandler(services.DevMode)
+	configRepositories := handlers.NewConfigRepositoryHandler()
 	harnesses := handlers.NewHarnessHandler()
 	hostedAgents := handlers.NewHostedAgentHandler(services.HostedAgentAccessRuleHelper)
 	hostedAgentInstances := handlers.NewHostedAgentInstanceHandler(
@@ -108,38 +114,50 @@ func Router(ctx context.Context, services *services.Services) (http.Handler, err
 		services.SkillAccessRuleHelper,
 		services.ModelAccessPolicyHelper,
 	)
+	hostedAgentSnapshots := handlers.NewHostedAgentSnapshotHandler(services.AgentBackend, hostedAgentInstances)
+	hostedAgentTriggers := handlers.NewHostedAgentTriggerHandler(services.ServerURL, hostedagenttrigger.NewDeliverer(services.StorageClient, services.GatewayClient, http.DefaultTransport, services.AgentDevRouter))
 	hostedAgentPools := handlers.NewHostedAgentPoolHandler(services.AgentBackend)
 	hostedAgentPoolDefaults := handlers.NewHostedAgentPoolDefaultsHandler()
 	hostedAgentPoolAssignments := handlers.NewHostedAgentPoolAssignmentHandler()
 	hostedAgentAccessRules := handlers.NewHostedAgentAccessRuleHandler()
 	skills := handlers.NewSkillHandler(services.SkillAccessRuleHelper)
-	powerUserWorkspaces := handlers.NewPowerUserWorkspaceHandler(services.ServerURL, services.AccessControlRuleHelper, services.MCPSecretBindingAllowedLabel)
+	powerUserWorkspaces := handlers.NewPowerUserWorkspaceHandler(services.ServerURL, services.AccessControlRuleHelper)
 	mcpWebhookValidations := handlers.NewMCPWebhookValidationHandler(services.MCPSessionManager)
 	availableModels := handlers.NewAvailableModelsHandler(services.ProviderDispatcher, services.LicenseProvider)
 	modelProviders := handlers.NewModelProviderHandler(services.ProviderDispatcher, services.LicenseProvider)
 	modelAccessPolicies := handlers.NewModelAccessPolicyHandler()
 	messagePolicies := handlers.NewMessagePolicyHandler()
+	mcpQuotaRules := handlers.NewMCPQuotaRuleHandler()
+	mcpToolApprovalPolicies := handlers.NewMCPToolApprovalPolicyHandler()
+	mcpToolCallApprovals := handlers.NewMCPToolCallApprovalHandler()
+	mcpServerRequestPolicies := handlers.NewMCPServerRequestPolicyHandler()
 	policyViolations := handlers.NewMessagePolicyViolationHandler()
 	deviceScans := handlers.NewDeviceScansHandler()
 	mdmAssetSources := handlers.NewMDMAssetSourceHandler()
 	mdmAssets := handlers.NewMDMAssetHandler()
 	mdmConfigurations := handlers.NewMDMConfigurationsHandler(services.ServerURL)
+	configHistory := handlers.NewConfigHistoryHandler(mdmConfigurations)
+	accessRequests := handlers.NewAccessRequestHandler()
+	accessRequestSetting := handlers.NewAccessRequestSettingHandler()
+	notificationChannels := handlers.NewNotificationChannelHandler()
+	notificationRules := handlers.NewNotificationRuleHandler()
+	notificationDeliveries := handlers.NewNotificationDeliveryHandler()
 	deviceEnroll := handlers.NewDeviceEnrollHandler(services.LicenseProvider)
 	authProviders := handlers.NewAuthProviderHandler(services.ProviderDispatcher, services.PostgresDSN, services.LicenseProvider)
 	localAuth := handlers.NewLocalAuthHandler(services.LocalAuthProvider)
 	defaultModelAliases := handlers.NewDefaultModelAliasHandler()
 	images := handlers.NewImageHandler()
-	mcp := handlers.NewMCPHandler(services.MCPSessionManager, services.AccessControlRuleHelper, oauthChecker, services.Router.Backend(), services.MCPImagePullSecrets, services.ServerURL, services.MCPSecretBindingAllowedLabel, services.ForceDynamicClient)
-	mcpSecretBindings := handlers.NewMCPSecretBindingHandler(services.MCPRuntimeBackend, services.LocalK8sClient, services.ObotNamespace, services.MCPSecretBindingAllowedLabel)
+	mcp := handlers.NewMCPHandler(services.MCPSessionManager, services.AccessControlRuleHelper, oauthChecker, services.Router.Backend(), services.MCPImagePullSecrets, services.ServerURL, services.ForceDynamicClient)
+	mcpSecretBindings := handlers.NewMCPSecretBindingHandler()
 	mcpAuditLogs := mcpgateway.NewAuditLogHandler(services.GatewayClient)
 	localAgentAuditLogs := mcpgateway.NewLocalAgentAuditLogHandler()
 	llmAuditLogs := handlers.NewLLMAuditLogHandler()
 	auditLogExports := handlers.NewAuditLogExportHandler(services.GatewayClient)
 	serverInstances := handlers.NewServerInstancesHandler(services.AccessControlRuleHelper, services.ServerURL)
-	systemMCPServers := handlers.NewSystemMCPServerHandler(services.MCPSessionManager, services.MCPSecretBindingAllowedLabel)
+	systemMCPServers := handlers.NewSystemMCPServerHandler(services.MCPSessionManager)
 	userDefaultRoleSettings := handlers.NewUserDefaultRoleSettingHandler()
 	setupHandler := setup.NewHandler(services.ServerURL, services.Bootstrapper)
-	registryHandler := registry.NewHandler(services.AccessControlRuleHelper, services.ServerURL, services.RegistryNoAuth, services.MCPSecretBindingAllowedLabel)
+	registryHandler := registry.NewHandler(services.AccessControlRuleHelper, services.ServerURL, services.RegistryNoAuth)
 	oauthClients := handlers.NewOAuthClientsHandler(services.OAuthServerConfig, services.ServerURL)
 	publishedArtifacts := handlers.NewPublishedArtifactHandler(services.ArtifactBlobStore, services.ArtifactBlobBucket)
 	imagePullSecretsHandler := handlers.NewImagePullSecretHandler(services.MCPRuntimeBackend, services.MCPImagePullSecrets, services.MCPServerNamespace, services.ServiceNamespace, services.ServiceAccountName, services.LocalK8sClient, services.ServiceAccountIssuerURL, services.ServiceAccountIssuerError)
@@ -421,6 +439,7 @@ func Router(ctx context.Context, services *services.Services) (http.Handler, err
 	mux.HandleFunc("POST /api/mcp-audit-logs", mcpAuditLogs.SubmitAuditLogs)
 	mux.HandleFunc("GET /api/mcp-audit-logs/filter-options/{filter}", mcpAuditLogs.ListAuditLogFilterOptions)
 	mux.HandleFunc("GET /api/mcp-audit-logs/detail/{audit_log_id}", mcpAuditLogs.GetAuditLog)
+	mux.HandleFunc("GET /api/mcp-audit-logs/detail/{audit_log_id}/unredacted", mcpAuditLogs.GetUnredactedAuditLog)
 	mux.HandleFunc("GET /api/mcp-audit-logs/{mcp_id}", mcpAuditLogs.ListAuditLogs)
 	mux.HandleFunc("GET /api/mcp-stats", mcpAuditLogs.GetUsageStats)
 	mux.HandleFunc("GET /api/mcp-stats/{mcp_id}", mcpAuditLogs.GetUsageStats)
@@ -433,10 +452,16 @@ func Router(ctx context.Context, services *services.Services) (http.Handler, err
 	mux.HandleFunc("GET /api/enforcement-decisions/allowlist-check/{id}", enforcement.CheckDecisionAllowlist)
 	mux.HandleFunc("GET /api/enforcement-decisions/{id}", enforcement.GetDecision)
 
+	// LLM gateway tool-call enforcement
+	toolCallEnforcementSettings := handlers.NewToolCallEnforcementSettingHandler()
+	mux.HandleFunc("GET /api/tool-call-enforcement-settings", toolCallEnforcementSettings.Get)
+	mux.HandleFunc("PUT /api/tool-call-enforcement-settings", toolCallEnforcementSettings.Update)
+
 	// LLM Audit Logs
 	mux.HandleFunc("GET /api/llm-audit-logs", llmAuditLogs.List)
 	mux.HandleFunc("GET /api/llm-audit-logs/filter-options/{filter}", llmAuditLogs.ListFilterOptions)
 	mux.HandleFunc("GET /api/llm-audit-logs/detail/{audit_log_id}", llmAuditLogs.Get)
+	mux.HandleFunc("GET /api/llm-audit-logs/detail/{audit_log_id}/unredacted", llmAuditLogs.GetUnredacted)
 
 	// Audit Log Exports
 	mux.HandleFunc("POST /api/audit-log-exports", auditLogExports.CreateAuditLogExport)
@@ -471,6 +496,9 @@ func Router(ctx context.Context, services *services.Services) (http.Handler, err
 	mux.HandleFunc("GET /api/skills/{id}", skills.Get)
 	mux.HandleFunc("GET /api/skills/{id}/download", skills.Download)
 	mux.HandleFunc("GET /api/skills/{id}/preview", skills.Preview)
+	mux.HandleFunc("GET /api/skills/{id}/revisions", skills.Revisions)
+	mux.HandleFunc("POST /api/skills/{id}/scan-override", skills.SetScanOverride)
+	mux.HandleFunc("DELETE /api/skills/{id}/scan-override", skills.DeleteScanOverride)
 
 	// Skill repositories (admin only)
 	mux.HandleFunc("GET /api/skill-repositories", skillRepositories.List)
@@ -495,6 +523,30 @@ func Router(ctx context.Context, services *services.Services) (http.Handler, err
 	mux.HandleFunc("DELETE /api/agent-catalogs/{agent_catalog_id}", agentCatalogs.Delete)
 	mux.HandleFunc("POST /api/agent-catalogs/{agent_catalog_id}/refresh", agentCatalogs.Refresh)
 
+	// Config repositories (admin only)
+	mux.HandleFunc("GET /api/config-repositories", configRepositories.List)
+	mux.HandleFunc("POST /api/config-repositories", configRepositories.Create)
+	mux.HandleFunc("GET /api/config-repositories/{config_repository_id}", configRepositories.Get)
+	mux.HandleFunc("PUT /api/config-repositories/{config_repository_id}", configRepositories.Update)
+	mux.HandleFunc("DELETE /api/config-repositories/{config_repository_id}", configRepositories.Delete)
+	mux.HandleFunc("POST /api/config-repositories/{config_repository_id}/refresh", configRepositories.Refresh)
+
+	// Config change history (admin only)
+	mux.HandleFunc("GET /api/config-history", configHistory.List)
+	mux.HandleFunc("GET /api/config-history/{revision_id}", configHistory.Get)
+	mux.HandleFunc("POST /api/config-history/{revision_id}/revert", configHistory.Revert)
+
+	// Access requests. Any user can request access; approvers are checked in the handler.
+	mux.HandleFunc("GET /api/access-requests", accessRequests.List)
+	mux.HandleFunc("POST /api/access-requests", accessRequests.Create)
+	mux.HandleFunc("GET /api/access-requests/{access_request_id}", accessRequests.Get)
+	mux.HandleFunc("POST /api/access-requests/{access_request_id}/approve", accessRequests.Approve)
+	mux.HandleFunc("POST /api/access-requests/{access_request_id}/deny", accessRequests.Deny)
+	mux.HandleFunc("POST /api/access-requests/{access_request_id}/revoke", accessRequests.Revoke)
+	mux.HandleFunc("POST /api/access-requests/{access_request_id}/cancel", accessRequests.Cancel)
+	mux.HandleFunc("GET /api/access-request-settings", accessRequestSetting.Get)
+	mux.HandleFunc("PUT /api/access-request-settings", accessRequestSetting.Update)
+
 	// Harnesses (admin only) — the runtimes hosted agents are built on
 	mux.HandleFunc("GET /api/harnesses", harnesses.List)
 	mux.HandleFunc("POST /api/harnesses", harnesses.Create)
@@ -516,8 +568,30 @@ func Router(ctx context.Context, services *services.Services) (http.Handler, err
 	mux.HandleFunc("GET /api/hosted-agent-instances/{hosted_agent_instance_id}", hostedAgentInstances.Get)
 	mux.HandleFunc("PUT /api/hosted-agent-instances/{hosted_agent_instance_id}", hostedAgentInstances.Update)
 	mux.HandleFunc("DELETE /api/hosted-agent-instances/{hosted_agent_instance_id}", hostedAgentInstances.Delete)
+	mux.HandleFunc("POST /api/hosted-agent-instances/{hosted_agent_instance_id}/stop", hostedAgentInstances.Stop)
+	mux.HandleFunc("POST /api/hosted-agent-instances/{hosted_agent_instance_id}/start", hostedAgentInstances.Start)
 	mux.HandleFunc("GET /api/hosted-agent-instances/{hosted_agent_instance_id}/terminal", agentTerminal.Attach)
 
+	// Hosted agent triggers (owners manage their instance's triggers)
+	mux.HandleFunc("GET /api/hosted-agent-instances/{hosted_agent_instance_id}/triggers", hostedAgentTriggers.List)
+	mux.HandleFunc("POST /api/hosted-agent-instances/{hosted_agent_instance_id}/triggers", hostedAgentTriggers.Create)
+	mux.HandleFunc("GET /api/hosted-agent-instances/{hosted_agent_instance_id}/triggers/{hosted_agent_trigger_id}", hostedAgentTriggers.Get)
+	mux.HandleFunc("PUT /api/hosted-agent-instances/{hosted_agent_instance_id}/triggers/{hosted_agent_trigger_id}", hostedAgentTriggers.Update)
+	mux.HandleFunc("DELETE /api/hosted-agent-instances/{hosted_agent_instance_id}/triggers/{hosted_agent_trigger_id}", hostedAgentTriggers.Delete)
+	mux.HandleFunc("POST /api/hosted-agent-instances/{hosted_agent_instance_id}/triggers/{hosted_agent_trigger_id}/invoke", hostedAgentTriggers.Invoke)
+	mux.HandleFunc("GET /api/hosted-agent-instances/{hosted_agent_instance_id}/triggers/{hosted_agent_trigger_id}/invocations", hostedAgentTriggers.ListInvocations)
+	// Authenticated by the trigger's webhook signature rather than a user.
+	mux.HandleFunc("POST /api/hosted-agent-triggers/{hosted_agent_trigger_id}/webhook", hostedAgentTriggers.Webhook)
+
+	// Hosted agent snapshots
+	mux.HandleFunc("GET /api/hosted-agent-instances/{hosted_agent_instance_id}/snapshots", hostedAgentSnapshots.ListForInstance)
+	mux.HandleFunc("POST /api/hosted-agent-instances/{hosted_agent_instance_id}/snapshots", hostedAgentSnapshots.Create)
+	mux.HandleFunc("POST /api/hosted-agent-instances/{hosted_agent_instance_id}/restore", hostedAgentSnapshots.Restore)
+	mux.HandleFunc("GET /api/hosted-agent-snapshots", hostedAgentSnapshots.List)
+	mux.HandleFunc("GET /api/hosted-agent-snapshots/{hosted_agent_snapshot_id}", hostedAgentSnapshots.Get)
+	mux.HandleFunc("DELETE /api/hosted-agent-snapshots/{hosted_agent_snapshot_id}", hostedAgentSnapshots.Delete)
+	mux.HandleFunc("POST /api/hosted-agent-snapshots/{hosted_agent_snapshot_id}/clone", hostedAgentSnapshots.Clone)
+
 	// Hosted agent pools (users have assigned read-only access; admins manage)
 	mux.HandleFunc("GET /api/hosted-agent-pools", hostedAgentPools.List)
 	mux.HandleFunc("POST /api/hosted-agent-pools", hostedAgentPools.Create)
@@ -579,6 +653,22 @@ func Router(ctx context.Context, services *services.Services) (http.Handler, err
 	mux.HandleFunc("PATCH /api/git-credentials/{id}", gitCredentials.Update)
 	mux.HandleFunc("DELETE /api/git-credentials/{id}", gitCredentials.Delete)
 
+	// Notification channels, rules, and the delivery log
+	mux.HandleFunc("GET /api/notification-channels", notificationChannels.List)
+	mux.HandleFunc("POST /api/notification-channels", notificationChannels.Create)
+	mux.HandleFunc("GET /api/notification-channels/{id}", notificationChannels.Get)
+	mux.HandleFunc("PUT /api/notification-channels/{id}", notificationChannels.Update)
+	mux.HandleFunc("DELETE /api/notification-channels/{id}", notificationChannels.Delete)
+	mux.HandleFunc("POST /api/notification-channels/{id}/test", notificationChannels.Test)
+	mux.HandleFunc("GET /api/notification-rules", notificationRules.List)
+	mux.HandleFunc("POST /api/notification-rules", notificationRules.Create)
+	mux.HandleFunc("GET /api/notification-rules/{id}", notificationRules.Get)
+	mux.HandleFunc("PUT /api/notification-rules/{id}", notificationRules.Update)
+	mux.HandleFunc("DELETE /api/notification-rules/{id}", notificationRules.Delete)
+	mux.HandleFunc("GET /api/notification-deliveries", notificationDeliveries.List)
+	mux.HandleFunc("GET /api/notification-deliveries/{id}", notificationDeliveries.Get)
+	mux.HandleFunc("POST /api/notification-deliveries/{id}/retry", notificationDeliveries.Retry)
+
 	// MCP Capacity (admin only)
 	mcpCapacityHandler := handlers.NewMCPCapacityHandler(services.MCPSessionManager)
 	mux.HandleFunc("GET /api/mcp-capacity", mcpCapacityHandler.GetCapacity)
@@ -588,6 +678,11 @@ func Router(ctx context.Context, services *services.Services) (http.Handler, err
 	mux.HandleFunc("GET /api/eula", eulaHandler.Get)
 	mux.HandleFunc("PUT /api/eula", eulaHandler.Update)
 
+	// Audit Redaction Settings
+	auditRedactionSettings := handlers.NewAuditRedactionSettingHandler()
+	mux.HandleFunc("GET /api/audit-redaction-settings", auditRedactionSettings.Get)
+	mux.HandleFunc("PUT /api/audit-redaction-settings", auditRedactionSettings.Update)
+
 	// App Preferences
 	appPrefsHandler := handlers.NewAppPreferencesHandler()
 	mux.HandleFunc("GET /api/app-preferences", appPrefsHandler.Get)
@@ -678,6 +773,34 @@ func Router(ctx context.Context, services *services.Services) (http.Handler, err
 		mux.HandleFunc("GET /api/message-policy-violation-stats", policyViolations.GetStats)
 	}
 
+	// MCP Quota Rules
+	mux.HandleFunc("GET /api/mcp-quota-rules", mcpQuotaRules.List)
+	mux.HandleFunc("GET /api/mcp-quota-rules/{id}", mcpQuotaRules.Get)
+	mux.HandleFunc("POST /api/mcp-quota-rules", mcpQuotaRules.Create)
+	mux.HandleFunc("PUT /api/mcp-quota-rules/{id}", mcpQuotaRules.Update)
+	mux.HandleFunc("DELETE /api/mcp-quota-rules/{id}", mcpQuotaRules.Delete)
+
+	// MCP Tool Approval Policies
+	mux.HandleFunc("GET /api/mcp-tool-approval-policies", mcpToolApprovalPolicies.List)
+	mux.HandleFunc("GET /api/mcp-tool-approval-policies/{id}", mcpToolApprovalPolicies.Get)
+	mux.HandleFunc("POST /api/mcp-tool-approval-policies", mcpToolApprovalPolicies.Create)
+	mux.HandleFunc("PUT /api/mcp-tool-approval-policies/{id}", mcpToolApprovalPolicies.Update)
+	mux.HandleFunc("DELETE /api/mcp-tool-approval-policies/{id}", mcpToolApprovalPolicies.Delete)
+
+	// MCP tool call approvals. Any user can see the calls they made; approvers are checked in the handler.
+	mux.HandleFunc("GET /api/mcp-tool-call-approvals", mcpToolCallApprovals.List)
+	mux.HandleFunc("GET /api/mcp-tool-call-approvals/{approval_id}", mcpToolCallApprovals.Get)
+	mux.HandleFunc("POST /api/mcp-tool-call-approvals/{approval_id}/approve", mcpToolCallApprovals.Approve)
+	mux.HandleFunc("POST /api/mcp-tool-call-approvals/{approval_id}/reject", mcpToolCallApprovals.Reject)
+	mux.HandleFunc("POST /api/mcp-tool-call-approvals/{approval_id}/cancel", mcpToolCallApprovals.Cancel)
+
+	// MCP Server Request Policies
+	mux.HandleFunc("GET /api/mcp-server-request-policies", mcpServerRequestPolicies.List)
+	mux.HandleFunc("GET /api/mcp-server-request-policies/{id}", mcpServerRequestPolicies.Get)
+	mux.HandleFunc("POST /api/mcp-server-request-policies", mcpServerRequestPolicies.Create)
+	mux.HandleFunc("PUT /api/mcp-server-request-policies/{id}", mcpServerRequestPolicies.Update)
+	mux.HandleFunc("DELETE /api/mcp-server-request-policies/{id}", mcpServerRequestPolicies.Delete)
+
 	// Device Scans
 	mux.HandleFunc("POST /api/devices/scans", deviceScans.Submit)
 	mux.HandleFunc("GET /api/devices/scans", deviceScans.List)
@@ -738,7 +861,7 @@ func Router(ctx context.Context, services *services.Services) (http.Handler, err
 	mux.HandleFunc("DELETE /api/projects/{project_id}", projects.Delete)
 
 	// NanobotAgents
-	nanobotAgents := handlers.NewNanobotAgentHandler(services.MCPSessionManager, services.ServerURL, agentsEnabled, services.MCPSecretBindingAllowedLabel)
+	nanobotAgents := handlers.NewNanobotAgentHandler(services.MCPSessionManager, services.ServerURL, agentsEnabled)
 	mux.HandleFunc("GET /api/nanobot-agents", nanobotAgents.ListAll)
 	mux.HandleFunc("POST /api/projects/{project_id}/agents", nanobotAgents.Create)
 	mux.HandleFunc("GET /api/projects/{project_id}/agents", nanobotAgents.List)
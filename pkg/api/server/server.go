@@ -23,9 +23,9 @@ import (
 	gclient "github.com/obot-platform/obot/pkg/gateway/client"
 	"github.com/obot-platform/obot/pkg/license"
 	"github.com/obot-platform/obot/pkg/proxy"
+	"github.com/obot-platform/obot/pkg/secretprovider"
 	"github.com/obot-platform/obot/pkg/storage"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
-	kclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 )
@@ -33,8 +33,7 @@ import (
 type Server struct {
 	storageClient           storage.Client
 	gatewayClient           *gclient.Client
-	localK8sClient          kclient.Client
-	obotNamespace           string
+	secretProvider          secretprovider.Provider
 	authenticator           *authn.Authenticator
 	authorizer              *authz.Authorizer
 	proxyManager            *proxy.Manager
@@ -60,7 +59,7 @@ type responseWriter struct {
 	auditLogger audit.Logger
 }
 
-func NewServer(storageClient storage.Client, gatewayClient *gclient.Client, localK8sClient kclient.Client, obotNamespace string, authn *authn.Authenticator, authz *authz.Authorizer, proxyManager *proxy.Manager, auditLogger audit.Logger, rateLimiter *ratelimiter.RateLimiter, baseURL string, oauthScopesSupported []string, registryNoAuth bool, licenseProvider *license.Provider) *Server {
+func NewServer(storageClient storage.Client, gatewayClient *gclient.Client, secretProvider secretprovider.Provider, authn *authn.Authenticator, authz *authz.Authorizer, proxyManager *proxy.Manager, auditLogger audit.Logger, rateLimiter *ratelimiter.RateLimiter, baseURL string, oauthScopesSupported []string, registryNoAuth bool, licenseProvider *license.Provider) *Server {
 	var scope string
 	if len(oauthScopesSupported) > 0 {
 		scope = fmt.Sprintf(", scope=\"%s\"", strings.Join(oauthScopesSupported, " "))
@@ -68,8 +67,7 @@ func NewServer(storageClient storage.Client, gatewayClient *gclient.Client, loca
 	s := &Server{
 		storageClient:           storageClient,
 		gatewayClient:           gatewayClient,
-		localK8sClient:          localK8sClient,
-		obotNamespace:           obotNamespace,
+		secretProvider:          secretProvider,
 		authenticator:           authn,
 		authorizer:              authz,
 		proxyManager:            proxyManager,
@@ -233,8 +231,7 @@ func (s *Server) Wrap(f api.HandlerFunc) http.HandlerFunc {
 				GatewayClient:  s.gatewayClient,
 				User:           user,
 				APIBaseURL:     s.baseURL,
-				LocalK8sClient: s.localK8sClient,
-				ObotNamespace:  s.obotNamespace,
+				SecretProvider: s.secretProvider,
 			})
 		}
 		if errHTTP := (*types.ErrHTTP)(nil); errors.As(err, &errHTTP) {
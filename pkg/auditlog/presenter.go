@@ -130,6 +130,7 @@ func presentMCP(event *api.AuditLogEvent, log gatewaytypes.MCPAuditLog, opts Pre
 		},
 		WebhookStatuses: webhooks,
 		PayloadRedacted: opts.PayloadRedacted,
+		ContentRedacted: log.ContentRedacted,
 	}
 }
 
@@ -279,5 +280,6 @@ func presentLocalAgent(event *api.AuditLogEvent, log gatewaytypes.MCPAuditLog, o
 		RawEvent:        local.RawEvent,
 		StartedAt:       startedAt,
 		PayloadRedacted: opts.PayloadRedacted,
+		ContentRedacted: log.ContentRedacted,
 	}
 }
@@ -0,0 +1,422 @@
+// Package backup writes Obot's state to an encrypted archive and restores it.
+//
+// Obot keeps its state in two stores: the storage API, which holds every
+// obot.obot.ai/v1 object, and the gateway database, which holds users, API
+// keys, credentials, and audit logs. An archive holds both, so an instance can
+// be recovered from it or moved to a new database, including from SQLite to
+// PostgreSQL.
+//
+// The archive is a gzip-compressed tar, encrypted with a key derived from a
+// passphrase:
+//
+//	manifest.json                  what the archive holds, see Manifest
+//	gateway/<table>/<part>.gob     gateway rows, in parts of partRows
+//	storage/<Kind>.json            storage objects, one list per kind
+//
+// Gateway rows are gob-encoded from the models in pkg/gateway/types rather than
+// copied as columns, so that a restore decodes them into its own version of the
+// models: fields added since the backup was taken start out zero, and fields
+// since removed are dropped.
+//
+// Credentials in the gateway database stay encrypted with the instance's
+// encryption provider, so the instance they are restored into must use the same
+// one.
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"database/sql"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	gatewaydb "github.com/obot-platform/obot/pkg/gateway/db"
+	"github.com/obot-platform/obot/pkg/gateway/types"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	storagescheme "github.com/obot-platform/obot/pkg/storage/scheme"
+	"github.com/obot-platform/obot/pkg/version"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// FormatVersion is the version of the archive format this package writes. It
+// reads archives of this version and older.
+const FormatVersion = 1
+
+const (
+	manifestFile = "manifest.json"
+	gatewayDir   = "gateway"
+	storageDir   = "storage"
+
+	// partRows is how many gateway rows go in one archive entry. Entries are
+	// built in memory, so this bounds how much a large audit table costs.
+	partRows = 5000
+)
+
+// Scope selects what an archive holds.
+type Scope string
+
+const (
+	// ScopeConfig is everything needed to run the instance: every storage
+	// object and the gateway's users, groups, keys, and credentials.
+	ScopeConfig Scope = "config"
+	// ScopeAudit is ScopeConfig plus the audit logs and other records of what
+	// has happened on the instance.
+	ScopeAudit Scope = "audit"
+)
+
+func (s Scope) Validate() error {
+	switch s {
+	case ScopeConfig, ScopeAudit:
+		return nil
+	default:
+		return fmt.Errorf("invalid backup scope %q: must be %q or %q", s, ScopeConfig, ScopeAudit)
+	}
+}
+
+// Includes reports whether an archive of scope s holds everything in other.
+func (s Scope) Includes(other Scope) bool {
+	return s == other || s == ScopeAudit
+}
+
+// Manifest describes an archive. It is the archive's first entry, so a restore
+// knows what it is restoring before it writes anything.
+type Manifest struct {
+	FormatVersion int       `json:"formatVersion"`
+	CreatedAt     time.Time `json:"createdAt"`
+	ObotVersion   string    `json:"obotVersion"`
+	Scope         Scope     `json:"scope"`
+	// Dialect is the gateway database the archive was taken from, such as
+	// sqlite or postgres.
+	Dialect string `json:"dialect"`
+	// Migrations are the gateway data migrations that had been applied. A
+	// restore applies those the archive predates.
+	Migrations []string `json:"migrations"`
+	// Gateway is how many rows the archive holds per gateway table.
+	Gateway map[string]int `json:"gateway"`
+	// Storage is how many objects the archive holds per storage kind.
+	Storage map[string]int `json:"storage"`
+}
+
+// Stores are the two halves of Obot's state.
+type Stores struct {
+	Storage kclient.Client
+	Gateway *gatewaydb.DB
+}
+
+type Options struct {
+	Passphrase string
+	Scope      Scope
+}
+
+// auditModels are the gateway tables that only a ScopeAudit archive holds.
+var auditModels = []any{
+	types.LLMAuditLog{},
+	types.APIActivity{},
+	types.RunTokenActivity{},
+	types.MCPAuditLog{},
+	types.MessagePolicyViolation{},
+	types.DeviceScan{},
+	types.DeviceScanMCPServer{},
+	types.DeviceScanSkill{},
+	types.DeviceScanPlugin{},
+	types.DeviceScanFile{},
+	types.DeviceScanClient{},
+	types.EnforcementDecisionLog{},
+	types.HostedAgentTriggerInvocation{},
+	types.ConfigChange{},
+	types.MCPQuotaUsage{},
+}
+
+// transientModels are never backed up. They hold sign-ins and OAuth flows that
+// are in progress, which would have expired by the time an archive is restored.
+var transientModels = []any{
+	types.TokenRequest{},
+	types.MCPOAuthPendingState{},
+}
+
+var schemaCache sync.Map
+
+// tableName is the name a model's rows have in an archive. It uses gorm's
+// default naming rather than the database's, so an archive's names do not
+// depend on where it was taken.
+func tableName(model any) string {
+	s, err := schema.Parse(model, &schemaCache, schema.NamingStrategy{})
+	if err != nil {
+		// Every model is parsed by AutoMigrate at startup, so this cannot fail
+		// for one that is in use.
+		panic(fmt.Sprintf("failed to parse gateway model %T: %v", model, err))
+	}
+	return s.Table
+}
+
+// gatewayModels returns the gateway models an archive of the scope holds, by
+// table name.
+func gatewayModels(scope Scope) map[string]any {
+	skip := map[string]bool{}
+	for _, model := range transientModels {
+		skip[tableName(model)] = true
+	}
+	if !scope.Includes(ScopeAudit) {
+		for _, model := range auditModels {
+			skip[tableName(model)] = true
+		}
+	}
+
+	models := map[string]any{}
+	for _, model := range gatewaydb.Models() {
+		if name := tableName(model); !skip[name] {
+			models[name] = model
+		}
+	}
+	return models
+}
+
+// storageKinds returns every kind in the storage API that has a list type, in
+// order.
+func storageKinds() []string {
+	var kinds []string
+	for kind := range storagescheme.Scheme.KnownTypes(v1.SchemeGroupVersion) {
+		if !strings.HasSuffix(kind, "List") {
+			continue
+		}
+		if _, err := newList(kind); err == nil {
+			kinds = append(kinds, strings.TrimSuffix(kind, "List"))
+		}
+	}
+	slices.Sort(kinds)
+	return kinds
+}
+
+func newList(kind string) (kclient.ObjectList, error) {
+	obj, err := storagescheme.Scheme.New(v1.SchemeGroupVersion.WithKind(strings.TrimSuffix(kind, "List") + "List"))
+	if err != nil {
+		return nil, err
+	}
+	list, ok := obj.(kclient.ObjectList)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a list", kind)
+	}
+	return list, nil
+}
+
+// Write writes an archive of the stores to w. The gateway database is read from
+// a single snapshot, but storage objects are listed kind by kind, and nothing
+// makes the two stores consistent with each other: a backup of a running
+// instance can catch a change that has reached one store and not the other.
+// Controllers reconcile such differences after a restore, the same as they do
+// after a crash.
+func Write(ctx context.Context, w io.Writer, stores Stores, opts Options) (Manifest, error) {
+	if err := opts.Scope.Validate(); err != nil {
+		return Manifest{}, err
+	}
+
+	manifest := Manifest{
+		FormatVersion: FormatVersion,
+		CreatedAt:     time.Now().UTC(),
+		ObotVersion:   version.Get().String(),
+		Scope:         opts.Scope,
+		Gateway:       map[string]int{},
+		Storage:       map[string]int{},
+	}
+
+	lists, err := readStorage(ctx, stores.Storage, manifest.Storage)
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	encrypted, err := newEncryptWriter(w, opts.Passphrase)
+	if err != nil {
+		return Manifest{}, err
+	}
+	gz := gzip.NewWriter(encrypted)
+	tw := tar.NewWriter(gz)
+
+	db := stores.Gateway.WithContext(ctx)
+	manifest.Dialect = db.Name()
+	txOptions := &sql.TxOptions{ReadOnly: true}
+	if manifest.Dialect == "postgres" {
+		txOptions.Isolation = sql.LevelRepeatableRead
+	}
+	tx := db.Begin(txOptions)
+	if tx.Error != nil {
+		return Manifest{}, fmt.Errorf("failed to start reading the gateway database: %w", tx.Error)
+	}
+	// The transaction only reads; rolling it back just ends it.
+	defer tx.Rollback()
+
+	models := gatewayModels(opts.Scope)
+	tables := sortedKeys(models)
+	for _, table := range tables {
+		var count int64
+		if err := tx.Model(models[table]).Unscoped().Count(&count).Error; err != nil {
+			return Manifest{}, fmt.Errorf("failed to count %s: %w", table, err)
+		}
+		manifest.Gateway[table] = int(count)
+	}
+	if err := tx.Model(&types.Migration{}).Order("name").Pluck("name", &manifest.Migrations).Error; err != nil {
+		return Manifest{}, fmt.Errorf("failed to read applied migrations: %w", err)
+	}
+
+	manifestData, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return Manifest{}, err
+	}
+	if err := writeEntry(tw, manifestFile, manifestData); err != nil {
+		return Manifest{}, err
+	}
+
+	for _, table := range tables {
+		written, err := writeTable(tw, tx, table, models[table])
+		if err != nil {
+			return Manifest{}, fmt.Errorf("failed to back up %s: %w", table, err)
+		}
+		if written != manifest.Gateway[table] {
+			return Manifest{}, fmt.Errorf("failed to back up %s: counted %d rows but read %d", table, manifest.Gateway[table], written)
+		}
+	}
+
+	for _, kind := range sortedKeys(lists) {
+		if err := writeEntry(tw, path.Join(storageDir, kind+".json"), lists[kind]); err != nil {
+			return Manifest{}, err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return Manifest{}, err
+	}
+	if err := gz.Close(); err != nil {
+		return Manifest{}, err
+	}
+	if err := encrypted.Close(); err != nil {
+		return Manifest{}, err
+	}
+	return manifest, nil
+}
+
+// readStorage lists every storage object, encoded per kind, and records how
+// many there are of each. Objects that are being deleted are left out.
+func readStorage(ctx context.Context, c kclient.Client, counts map[string]int) (map[string][]byte, error) {
+	lists := map[string][]byte{}
+	for _, kind := range storageKinds() {
+		list, err := newList(kind)
+		if err != nil {
+			return nil, err
+		}
+		if err := c.List(ctx, list); err != nil {
+			return nil, fmt.Errorf("failed to list %s: %w", kind, err)
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return nil, err
+		}
+		items = slices.DeleteFunc(items, func(item runtime.Object) bool {
+			obj, ok := item.(kclient.Object)
+			return !ok || !obj.GetDeletionTimestamp().IsZero()
+		})
+		if len(items) == 0 {
+			continue
+		}
+		if err := meta.SetList(list, items); err != nil {
+			return nil, err
+		}
+
+		data, err := json.Marshal(list)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode %s: %w", kind, err)
+		}
+		lists[kind] = data
+		counts[kind] = len(items)
+	}
+	return lists, nil
+}
+
+// writeTable writes every row of the table, in parts of partRows, and returns
+// how many it wrote.
+func writeTable(tw *tar.Writer, tx *gorm.DB, table string, model any) (int, error) {
+	rows, err := tx.Model(model).Unscoped().Rows()
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	var (
+		typ     = reflect.TypeOf(model)
+		part    bytes.Buffer
+		enc     = gob.NewEncoder(&part)
+		inPart  int
+		parts   int
+		written int
+	)
+	flush := func() error {
+		if inPart == 0 {
+			return nil
+		}
+		parts++
+		if err := writeEntry(tw, path.Join(gatewayDir, table, fmt.Sprintf("%05d.gob", parts)), part.Bytes()); err != nil {
+			return err
+		}
+		part.Reset()
+		// Every part is decoded on its own, so each needs its own type
+		// definitions.
+		enc = gob.NewEncoder(&part)
+		inPart = 0
+		return nil
+	}
+
+	for rows.Next() {
+		row := reflect.New(typ)
+		if err := tx.ScanRows(rows, row.Interface()); err != nil {
+			return 0, err
+		}
+		if err := enc.Encode(row.Interface()); err != nil {
+			return 0, err
+		}
+		inPart++
+		written++
+		if inPart == partRows {
+			if err := flush(); err != nil {
+				return 0, err
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+	return written, flush()
+}
+
+func writeEntry(tw *tar.Writer, name string, data []byte) error {
+	if err := tw.WriteHeader(&tar.Header{
+		Name:     name,
+		Mode:     0o600,
+		Size:     int64(len(data)),
+		Typeflag: tar.TypeReg,
+	}); err != nil {
+		return err
+	}
+	_, err := tw.Write(data)
+	return err
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
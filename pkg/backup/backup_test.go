@@ -0,0 +1,324 @@
+package backup
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"github.com/obot-platform/obot/apiclient/types"
+	gatewaydb "github.com/obot-platform/obot/pkg/gateway/db"
+	gatewaytypes "github.com/obot-platform/obot/pkg/gateway/types"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	storagescheme "github.com/obot-platform/obot/pkg/storage/scheme"
+	"gorm.io/gorm"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const passphrase = "correct horse battery staple"
+
+func newStores(t *testing.T, objs ...kclient.Object) Stores {
+	t.Helper()
+	g, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, err := g.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+	db, err := gatewaydb.New(g, sqlDB, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.AutoMigrate(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	return Stores{
+		Storage: fake.NewClientBuilder().
+			WithScheme(storagescheme.Scheme).
+			WithStatusSubresource(&v1.HostedAgentSnapshot{}).
+			WithObjects(objs...).
+			Build(),
+		Gateway: db,
+	}
+}
+
+// populatedStores returns stores with a little of everything: config and audit
+// rows, an object with a status, and an object owned by another.
+func populatedStores(t *testing.T) Stores {
+	t.Helper()
+	instance := &v1.HostedAgentInstance{
+		ObjectMeta: metav1.ObjectMeta{Name: "hai1abc", Namespace: "default", UID: "instance-uid"},
+		Spec:       v1.HostedAgentInstanceSpec{UserID: "1", HostedAgentName: "ha1abc"},
+	}
+	snapshot := &v1.HostedAgentSnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "has1abc",
+			Namespace: "default",
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: v1.SchemeGroupVersion.String(),
+				Kind:       "HostedAgentInstance",
+				Name:       instance.Name,
+				UID:        instance.UID,
+			}},
+		},
+		Spec:   v1.HostedAgentSnapshotSpec{UserID: "1", HostedAgentInstanceName: instance.Name},
+		Status: v1.HostedAgentSnapshotStatus{State: types.HostedAgentSnapshotStateReady, SizeBytes: 42},
+	}
+	stores := newStores(t, instance, snapshot)
+
+	db := stores.Gateway.WithContext(t.Context())
+	for _, row := range []any{
+		&gatewaytypes.User{ID: 7, Username: "alice", HashedUsername: "h-alice", Email: "alice@example.com"},
+		&gatewaytypes.Image{Data: []byte("png"), MIMEType: "image/png"},
+		&gatewaytypes.MCPAuditLog{UserID: "7", ClientIP: "10.0.0.1"},
+	} {
+		if err := db.Create(row).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	return stores
+}
+
+func writeArchive(t *testing.T, stores Stores, scope Scope) []byte {
+	t.Helper()
+	var archive bytes.Buffer
+	if _, err := Write(t.Context(), &archive, stores, Options{Passphrase: passphrase, Scope: scope}); err != nil {
+		t.Fatal(err)
+	}
+	return archive.Bytes()
+}
+
+func count(t *testing.T, stores Stores, model any) int64 {
+	t.Helper()
+	var n int64
+	if err := stores.Gateway.WithContext(t.Context()).Model(model).Count(&n).Error; err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+func TestRoundTrip(t *testing.T) {
+	ctx := t.Context()
+	source := populatedStores(t)
+	var image gatewaytypes.Image
+	if err := source.Gateway.WithContext(ctx).First(&image).Error; err != nil {
+		t.Fatal(err)
+	}
+	archive := writeArchive(t, source, ScopeAudit)
+
+	target := newStores(t)
+	result, err := Restore(ctx, bytes.NewReader(archive), target, RestoreOptions{Passphrase: passphrase})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Gateway["users"] != 1 || result.Gateway["mcp_audit_logs"] != 1 || result.Storage["HostedAgentSnapshot"] != 1 {
+		t.Fatalf("unexpected restore counts: gateway %v, storage %v", result.Gateway, result.Storage)
+	}
+
+	var user gatewaytypes.User
+	if err := target.Gateway.WithContext(ctx).First(&user, 7).Error; err != nil {
+		t.Fatal(err)
+	}
+	if user.Email != "alice@example.com" {
+		t.Fatalf("restored user = %#v", user)
+	}
+	// The restored image keeps its ID, which the create hook would replace.
+	var restoredImage gatewaytypes.Image
+	if err := target.Gateway.WithContext(ctx).First(&restoredImage, "id = ?", image.ID).Error; err != nil {
+		t.Fatalf("image was not restored with its ID: %v", err)
+	}
+	if n := count(t, target, &gatewaytypes.MCPAuditLog{}); n != 1 {
+		t.Fatalf("restored %d audit logs, want 1", n)
+	}
+
+	var instance v1.HostedAgentInstance
+	if err := target.Storage.Get(ctx, kclient.ObjectKey{Namespace: "default", Name: "hai1abc"}, &instance); err != nil {
+		t.Fatal(err)
+	}
+	var snapshot v1.HostedAgentSnapshot
+	if err := target.Storage.Get(ctx, kclient.ObjectKey{Namespace: "default", Name: "has1abc"}, &snapshot); err != nil {
+		t.Fatal(err)
+	}
+	if snapshot.Status.State != types.HostedAgentSnapshotStateReady || snapshot.Status.SizeBytes != 42 {
+		t.Fatalf("snapshot status was not restored: %#v", snapshot.Status)
+	}
+	if refs := snapshot.OwnerReferences; len(refs) != 1 || refs[0].UID != instance.UID {
+		t.Fatalf("owner reference %v does not point at the restored owner %s", refs, instance.UID)
+	}
+}
+
+func TestConfigScopeLeavesOutAuditLogs(t *testing.T) {
+	source := populatedStores(t)
+	archive := writeArchive(t, source, ScopeConfig)
+
+	target := newStores(t)
+	result, err := Restore(t.Context(), bytes.NewReader(archive), target, RestoreOptions{Passphrase: passphrase})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, model := range auditModels {
+		if _, ok := result.Manifest.Gateway[tableName(model)]; ok {
+			t.Fatalf("config backup holds %s", tableName(model))
+		}
+	}
+	if n := count(t, target, &gatewaytypes.MCPAuditLog{}); n != 0 {
+		t.Fatalf("restored %d audit logs from a config backup", n)
+	}
+	if n := count(t, target, &gatewaytypes.User{}); n != 1 {
+		t.Fatalf("restored %d users, want 1", n)
+	}
+
+	// An audit restore cannot come from a config backup.
+	if _, err := Restore(t.Context(), bytes.NewReader(archive), newStores(t), RestoreOptions{Passphrase: passphrase, Scope: ScopeAudit}); err == nil {
+		t.Fatal("restored audit scope from a config backup")
+	}
+}
+
+func TestDryRunWritesNothing(t *testing.T) {
+	archive := writeArchive(t, populatedStores(t), ScopeAudit)
+
+	// Without stores, a dry run only checks the archive.
+	result, err := Restore(t.Context(), bytes.NewReader(archive), Stores{}, RestoreOptions{Passphrase: passphrase, DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.DryRun || result.Gateway["users"] != 1 || result.Storage["HostedAgentInstance"] != 1 {
+		t.Fatalf("unexpected dry run result: %#v", result)
+	}
+
+	target := newStores(t)
+	if _, err := Restore(t.Context(), bytes.NewReader(archive), target, RestoreOptions{Passphrase: passphrase, DryRun: true}); err != nil {
+		t.Fatal(err)
+	}
+	if n := count(t, target, &gatewaytypes.User{}); n != 0 {
+		t.Fatalf("dry run restored %d users", n)
+	}
+	var instances v1.HostedAgentInstanceList
+	if err := target.Storage.List(t.Context(), &instances); err != nil {
+		t.Fatal(err)
+	}
+	if len(instances.Items) != 0 {
+		t.Fatalf("dry run restored %d instances", len(instances.Items))
+	}
+}
+
+func TestRestoreIntoUsedStores(t *testing.T) {
+	archive := writeArchive(t, populatedStores(t), ScopeAudit)
+	target := populatedStores(t)
+
+	_, err := Restore(t.Context(), bytes.NewReader(archive), target, RestoreOptions{Passphrase: passphrase})
+	if err == nil || !strings.Contains(err.Error(), "gateway table users") {
+		t.Fatalf("Restore() error = %v, want a conflict on users", err)
+	}
+	// A dry run reports the same conflict.
+	if _, err := Restore(t.Context(), bytes.NewReader(archive), target, RestoreOptions{Passphrase: passphrase, DryRun: true}); err == nil {
+		t.Fatal("dry run did not report the conflict")
+	}
+
+	if _, err := Restore(t.Context(), bytes.NewReader(archive), target, RestoreOptions{Passphrase: passphrase, Overwrite: true}); err != nil {
+		t.Fatal(err)
+	}
+	if n := count(t, target, &gatewaytypes.User{}); n != 1 {
+		t.Fatalf("overwritten database has %d users, want 1", n)
+	}
+}
+
+func TestRestoreAppliesMissingMigrations(t *testing.T) {
+	source := populatedStores(t)
+	// The backup predates a data migration.
+	if err := source.Gateway.WithContext(t.Context()).Delete(&gatewaytypes.Migration{Name: "auditor_user_role"}).Error; err != nil {
+		t.Fatal(err)
+	}
+	archive := writeArchive(t, source, ScopeConfig)
+
+	target := newStores(t)
+	result, err := Restore(t.Context(), bytes.NewReader(archive), target, RestoreOptions{Passphrase: passphrase})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Migrations) != 1 || result.Migrations[0] != "auditor_user_role" {
+		t.Fatalf("applied migrations = %v, want [auditor_user_role]", result.Migrations)
+	}
+	if n := count(t, target, &gatewaytypes.Migration{}); n != count(t, newStores(t), &gatewaytypes.Migration{}) {
+		t.Fatalf("restored database records %d migrations, not all of them", n)
+	}
+
+	// A backup from a newer version, with a migration this one lacks, is
+	// refused.
+	source = newStores(t)
+	if err := source.Gateway.WithContext(t.Context()).Create(&gatewaytypes.Migration{Name: "from_the_future"}).Error; err != nil {
+		t.Fatal(err)
+	}
+	archive = writeArchive(t, source, ScopeConfig)
+	if _, err := Restore(t.Context(), bytes.NewReader(archive), newStores(t), RestoreOptions{Passphrase: passphrase}); err == nil || !strings.Contains(err.Error(), "newer version") {
+		t.Fatalf("Restore() error = %v, want a newer-version error", err)
+	}
+}
+
+func TestRestoreRejectsBadArchives(t *testing.T) {
+	archive := writeArchive(t, populatedStores(t), ScopeConfig)
+	dryRun := func(data []byte, passphrase string) error {
+		_, err := Restore(t.Context(), bytes.NewReader(data), Stores{}, RestoreOptions{Passphrase: passphrase, DryRun: true})
+		return err
+	}
+
+	if err := dryRun(archive, "wrong"); !errors.Is(err, ErrDecrypt) {
+		t.Errorf("wrong passphrase: error = %v, want ErrDecrypt", err)
+	}
+
+	tampered := bytes.Clone(archive)
+	tampered[len(tampered)/2] ^= 0xff
+	if err := dryRun(tampered, passphrase); !errors.Is(err, ErrDecrypt) {
+		t.Errorf("tampered archive: error = %v, want ErrDecrypt", err)
+	}
+
+	if err := dryRun(archive[:len(archive)-1], passphrase); err == nil {
+		t.Error("restored a truncated archive")
+	}
+
+	if err := dryRun([]byte("not a backup at all"), passphrase); err == nil {
+		t.Error("restored something that is not an archive")
+	}
+}
+
+func TestTruncatedAtChunkBoundary(t *testing.T) {
+	var archive bytes.Buffer
+	w, err := newEncryptWriter(&archive, passphrase)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write(bytes.Repeat([]byte("x"), 2*chunkSize+10)); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Cutting the archive after a whole chunk leaves every chunk that is left
+	// intact, so only the missing final chunk gives it away.
+	cut := archive.Bytes()[:len(magic)+1+saltSize+4+chunkSize+16]
+	r, err := newDecryptReader(bytes.NewReader(cut), passphrase)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := io.ReadAll(r); !errors.Is(err, errTruncated) {
+		t.Fatalf("ReadAll() error = %v, want errTruncated", err)
+	}
+
+	r, err = newDecryptReader(bytes.NewReader(archive.Bytes()), passphrase)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data, err := io.ReadAll(r); err != nil || len(data) != 2*chunkSize+10 {
+		t.Fatalf("ReadAll() = %d bytes, %v", len(data), err)
+	}
+}
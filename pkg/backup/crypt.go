@@ -0,0 +1,221 @@
+package backup
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// An archive starts with a fixed header, followed by the tar stream encrypted
+// in chunks with AES-256-GCM:
+//
+//	magic (8) | format version (1) | scrypt salt (16)
+//	chunk length (4, big endian) | sealed chunk
+//	...
+//
+// Each chunk's nonce is its sequence number with a flag on the last one, and
+// the header is authenticated with every chunk, so chunks cannot be reordered,
+// dropped, or moved between archives, and a truncated archive is detected.
+const (
+	magic = "OBOTBKUP"
+
+	saltSize  = 16
+	chunkSize = 64 * 1024
+
+	scryptN = 1 << 15
+	scryptR = 8
+	scryptP = 1
+)
+
+var (
+	// ErrDecrypt is returned when an archive cannot be decrypted: the
+	// passphrase is wrong or the archive has been altered.
+	ErrDecrypt = errors.New("failed to decrypt backup archive: wrong passphrase or corrupted archive")
+
+	errTruncated = errors.New("backup archive is truncated")
+)
+
+func newAEAD(passphrase string, salt []byte) (cipher.AEAD, error) {
+	if passphrase == "" {
+		return nil, errors.New("a backup passphrase is required")
+	}
+	key, err := scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, 32)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func chunkNonce(aead cipher.AEAD, seq uint64, final bool) []byte {
+	nonce := make([]byte, aead.NonceSize())
+	binary.BigEndian.PutUint64(nonce, seq)
+	if final {
+		nonce[len(nonce)-1] = 1
+	}
+	return nonce
+}
+
+type encryptWriter struct {
+	w      io.Writer
+	aead   cipher.AEAD
+	header []byte
+	buf    []byte
+	seq    uint64
+	closed bool
+}
+
+// newEncryptWriter writes the archive header to w and returns a writer that
+// encrypts everything written to it. Close must be called to write the final
+// chunk; it does not close w.
+func newEncryptWriter(w io.Writer, passphrase string) (*encryptWriter, error) {
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	header := append(append([]byte(magic), FormatVersion), salt...)
+	if _, err := w.Write(header); err != nil {
+		return nil, err
+	}
+	return &encryptWriter{
+		w:      w,
+		aead:   aead,
+		header: header,
+		buf:    make([]byte, 0, chunkSize),
+	}, nil
+}
+
+func (e *encryptWriter) Write(p []byte) (int, error) {
+	if e.closed {
+		return 0, errors.New("write to closed backup archive")
+	}
+	written := len(p)
+	for len(p) > 0 {
+		n := min(chunkSize-len(e.buf), len(p))
+		e.buf = append(e.buf, p[:n]...)
+		p = p[n:]
+		if len(e.buf) == chunkSize {
+			if err := e.flush(false); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return written, nil
+}
+
+func (e *encryptWriter) flush(final bool) error {
+	sealed := e.aead.Seal(nil, chunkNonce(e.aead, e.seq, final), e.buf, e.header)
+	e.seq++
+	e.buf = e.buf[:0]
+
+	var length [4]byte
+	binary.BigEndian.PutUint32(length[:], uint32(len(sealed)))
+	if _, err := e.w.Write(length[:]); err != nil {
+		return err
+	}
+	_, err := e.w.Write(sealed)
+	return err
+}
+
+func (e *encryptWriter) Close() error {
+	if e.closed {
+		return nil
+	}
+	e.closed = true
+	return e.flush(true)
+}
+
+type decryptReader struct {
+	r      io.Reader
+	aead   cipher.AEAD
+	header []byte
+	buf    []byte
+	seq    uint64
+	final  bool
+}
+
+// newDecryptReader reads the archive header from r and returns a reader of the
+// decrypted stream. Reading past the end of an archive that stops before its
+// final chunk fails rather than returning io.EOF.
+func newDecryptReader(r io.Reader, passphrase string) (*decryptReader, error) {
+	header := make([]byte, len(magic)+1+saltSize)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, fmt.Errorf("failed to read backup archive header: %w", err)
+	}
+	if !bytes.Equal(header[:len(magic)], []byte(magic)) {
+		return nil, errors.New("not an Obot backup archive")
+	}
+	if version := header[len(magic)]; version > FormatVersion {
+		return nil, fmt.Errorf("backup archive format version %d is newer than this version of Obot supports (%d)", version, FormatVersion)
+	}
+	aead, err := newAEAD(passphrase, header[len(magic)+1:])
+	if err != nil {
+		return nil, err
+	}
+	return &decryptReader{
+		r:      r,
+		aead:   aead,
+		header: header,
+	}, nil
+}
+
+func (d *decryptReader) Read(p []byte) (int, error) {
+	for len(d.buf) == 0 {
+		if d.final {
+			return 0, io.EOF
+		}
+		if err := d.next(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, d.buf)
+	d.buf = d.buf[n:]
+	return n, nil
+}
+
+func (d *decryptReader) next() error {
+	var length [4]byte
+	if _, err := io.ReadFull(d.r, length[:]); errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return errTruncated
+	} else if err != nil {
+		return err
+	}
+
+	size := binary.BigEndian.Uint32(length[:])
+	if size > chunkSize+uint32(d.aead.Overhead()) {
+		return ErrDecrypt
+	}
+	sealed := make([]byte, size)
+	if _, err := io.ReadFull(d.r, sealed); errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return errTruncated
+	} else if err != nil {
+		return err
+	}
+
+	plain, err := d.aead.Open(nil, chunkNonce(d.aead, d.seq, false), sealed, d.header)
+	if err != nil {
+		plain, err = d.aead.Open(nil, chunkNonce(d.aead, d.seq, true), sealed, d.header)
+		if err != nil {
+			return ErrDecrypt
+		}
+		d.final = true
+	}
+	d.seq++
+	d.buf = plain
+	return nil
+}
@@ -0,0 +1,561 @@
+package backup
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/obot-platform/obot/pkg/gateway/types"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type RestoreOptions struct {
+	Passphrase string
+	// Scope limits what is restored. Empty restores everything in the archive;
+	// ScopeConfig restores an archive without its audit logs.
+	Scope Scope
+	// DryRun reads, decrypts, and decodes the whole archive and checks it
+	// against its manifest without writing anything. The stores are optional;
+	// when given, they are checked for data the restore would conflict with.
+	DryRun bool
+	// Overwrite deletes what is already in the stores before restoring. Without
+	// it, a restore only goes into stores with nothing of what it restores.
+	Overwrite bool
+}
+
+// Result is what a restore found in an archive and, unless it was a dry run,
+// wrote.
+type Result struct {
+	Manifest Manifest       `json:"manifest"`
+	DryRun   bool           `json:"dryRun"`
+	Gateway  map[string]int `json:"gateway"`
+	Storage  map[string]int `json:"storage"`
+	// Migrations are the gateway data migrations the archive predates, which
+	// were applied to the restored data.
+	Migrations []string `json:"migrations,omitempty"`
+}
+
+// Restore restores an archive into the stores. Obot must not be running
+// against them: its controllers would act on objects half restored.
+//
+// The gateway database is migrated to the current schema first, and the
+// archive's rows are decoded into the current models and inserted in one
+// transaction, with the archive's record of applied data migrations. Migrating
+// again afterwards applies the data migrations the archive predates. An archive
+// taken by a newer version of Obot, which has tables, kinds, or migrations this
+// version does not know, is refused rather than restored with parts missing.
+//
+// Storage objects are created after the gateway is committed. They get new
+// UIDs, and owner references between them are rewritten to match.
+func Restore(ctx context.Context, r io.Reader, stores Stores, opts RestoreOptions) (Result, error) {
+	if opts.Scope != "" {
+		if err := opts.Scope.Validate(); err != nil {
+			return Result{}, err
+		}
+	}
+
+	decrypted, err := newDecryptReader(r, opts.Passphrase)
+	if err != nil {
+		return Result{}, err
+	}
+	gz, err := gzip.NewReader(decrypted)
+	if err != nil {
+		return Result{}, wrapDecryptErr(err)
+	}
+	tr := tar.NewReader(gz)
+
+	manifest, err := readManifest(tr)
+	if err != nil {
+		return Result{}, err
+	}
+	scope := opts.Scope
+	if scope == "" {
+		scope = manifest.Scope
+	} else if !manifest.Scope.Includes(scope) {
+		return Result{}, fmt.Errorf("cannot restore scope %q from a backup of scope %q", scope, manifest.Scope)
+	}
+
+	models := gatewayModels(ScopeAudit)
+	for table := range manifest.Gateway {
+		if _, ok := models[table]; !ok {
+			return Result{}, fmt.Errorf("backup has gateway table %s, which this version of Obot does not know: it was taken by a newer version", table)
+		}
+	}
+	models = gatewayModels(scope)
+	kinds := storageKinds()
+	for kind := range manifest.Storage {
+		if !slices.Contains(kinds, kind) {
+			return Result{}, fmt.Errorf("backup has storage kind %s, which this version of Obot does not know: it was taken by a newer version", kind)
+		}
+	}
+
+	result := Result{
+		Manifest: manifest,
+		DryRun:   opts.DryRun,
+		Gateway:  map[string]int{},
+		Storage:  map[string]int{},
+	}
+
+	var target *restorer
+	if opts.DryRun {
+		if stores.Gateway != nil && stores.Storage != nil && !opts.Overwrite {
+			if err := checkEmpty(ctx, stores, manifest, models); err != nil {
+				return result, err
+			}
+		}
+	} else {
+		target, err = newRestorer(ctx, stores, manifest, models, opts.Overwrite)
+		if err != nil {
+			return result, err
+		}
+		defer target.rollback()
+	}
+
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return result, wrapDecryptErr(err)
+		}
+
+		dir, name := path.Split(hdr.Name)
+		switch {
+		case strings.HasPrefix(dir, gatewayDir+"/"):
+			table := strings.TrimSuffix(strings.TrimPrefix(dir, gatewayDir+"/"), "/")
+			model, ok := models[table]
+			if !ok {
+				if _, known := manifest.Gateway[table]; !known {
+					return result, fmt.Errorf("backup has gateway table %s, which is not in its manifest", table)
+				}
+				// Audit tables when restoring only ScopeConfig.
+				continue
+			}
+			rows, err := decodeRows(tr, model)
+			if err != nil {
+				return result, fmt.Errorf("failed to decode %s: %w", hdr.Name, wrapDecryptErr(err))
+			}
+			result.Gateway[table] += reflect.ValueOf(rows).Elem().Len()
+			if target != nil {
+				if err := target.insert(table, model, rows); err != nil {
+					return result, err
+				}
+			}
+		case dir == storageDir+"/":
+			kind := strings.TrimSuffix(name, ".json")
+			if _, ok := manifest.Storage[kind]; !ok {
+				return result, fmt.Errorf("backup has storage kind %s, which is not in its manifest", kind)
+			}
+			// Gateway rows all come before storage objects, so the gateway is
+			// complete by the time the first kind is reached.
+			if err := verifyCounts("gateway table", manifest.Gateway, result.Gateway, models); err != nil {
+				return result, err
+			}
+			if target != nil {
+				if result.Migrations, err = target.commitGateway(); err != nil {
+					return result, err
+				}
+			}
+			objects, err := decodeObjects(tr, kind)
+			if err != nil {
+				return result, fmt.Errorf("failed to decode %s: %w", hdr.Name, wrapDecryptErr(err))
+			}
+			result.Storage[kind] += len(objects)
+			if target != nil {
+				if err := target.create(objects); err != nil {
+					return result, fmt.Errorf("failed to restore %s: %w", kind, err)
+				}
+			}
+		default:
+			return result, fmt.Errorf("backup has unexpected entry %s", hdr.Name)
+		}
+	}
+
+	// Reading to the end checks the compressed stream's checksum and that the
+	// archive was not cut short after its last entry.
+	if _, err := io.Copy(io.Discard, gz); err != nil {
+		return result, wrapDecryptErr(err)
+	}
+
+	if err := verifyCounts("gateway table", manifest.Gateway, result.Gateway, models); err != nil {
+		return result, err
+	}
+	if target != nil {
+		if result.Migrations, err = target.commitGateway(); err != nil {
+			return result, err
+		}
+	}
+	if err := verifyCounts("storage kind", manifest.Storage, result.Storage, nil); err != nil {
+		return result, err
+	}
+	if target != nil {
+		if err := target.fixOwnerReferences(); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+func readManifest(tr *tar.Reader) (Manifest, error) {
+	hdr, err := tr.Next()
+	if err != nil {
+		return Manifest{}, wrapDecryptErr(err)
+	}
+	if hdr.Name != manifestFile {
+		return Manifest{}, fmt.Errorf("backup archive does not start with its manifest")
+	}
+	var manifest Manifest
+	if err := json.NewDecoder(tr).Decode(&manifest); err != nil {
+		return Manifest{}, fmt.Errorf("failed to read backup manifest: %w", wrapDecryptErr(err))
+	}
+	if manifest.FormatVersion > FormatVersion {
+		return Manifest{}, fmt.Errorf("backup format version %d is newer than this version of Obot supports (%d)", manifest.FormatVersion, FormatVersion)
+	}
+	if err := manifest.Scope.Validate(); err != nil {
+		return Manifest{}, err
+	}
+	return manifest, nil
+}
+
+// wrapDecryptErr keeps a decryption failure recognizable through the layers
+// that read from it.
+func wrapDecryptErr(err error) error {
+	if errors.Is(err, ErrDecrypt) || errors.Is(err, errTruncated) {
+		return err
+	}
+	if errors.Is(err, gzip.ErrChecksum) || errors.Is(err, gzip.ErrHeader) || errors.Is(err, tar.ErrHeader) {
+		return fmt.Errorf("backup archive is corrupted: %w", err)
+	}
+	return err
+}
+
+// verifyCounts checks that an archive held as many of everything restored as
+// its manifest says. Only the names in restored are checked when it is given.
+func verifyCounts(what string, want, got map[string]int, restored map[string]any) error {
+	for _, name := range sortedKeys(want) {
+		if restored != nil {
+			if _, ok := restored[name]; !ok {
+				continue
+			}
+		}
+		if got[name] != want[name] {
+			return fmt.Errorf("backup is incomplete: its manifest counts %d in %s %s, but the archive has %d", want[name], what, name, got[name])
+		}
+	}
+	return nil
+}
+
+// decodeRows decodes a part of a gateway table into a pointer to a slice of
+// the model.
+func decodeRows(r io.Reader, model any) (any, error) {
+	typ := reflect.TypeOf(model)
+	rows := reflect.New(reflect.SliceOf(typ))
+	dec := gob.NewDecoder(r)
+	for {
+		row := reflect.New(typ)
+		if err := dec.Decode(row.Interface()); errors.Is(err, io.EOF) {
+			return rows.Interface(), nil
+		} else if err != nil {
+			return nil, err
+		}
+		rows.Elem().Set(reflect.Append(rows.Elem(), row.Elem()))
+	}
+}
+
+func decodeObjects(r io.Reader, kind string) ([]kclient.Object, error) {
+	list, err := newList(kind)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.NewDecoder(r).Decode(list); err != nil {
+		return nil, err
+	}
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return nil, err
+	}
+	objects := make([]kclient.Object, 0, len(items))
+	for _, item := range items {
+		obj, ok := item.(kclient.Object)
+		if !ok {
+			return nil, fmt.Errorf("%s list holds a %T", kind, item)
+		}
+		objects = append(objects, obj)
+	}
+	return objects, nil
+}
+
+// checkEmpty returns an error naming what in the stores a restore of the
+// archive would conflict with. Tables that do not exist yet are empty.
+func checkEmpty(ctx context.Context, stores Stores, manifest Manifest, models map[string]any) error {
+	db := stores.Gateway.WithContext(ctx)
+	var conflicts []string
+	for _, table := range sortedKeys(models) {
+		if !db.Migrator().HasTable(models[table]) {
+			continue
+		}
+		var count int64
+		if err := db.Model(models[table]).Unscoped().Count(&count).Error; err != nil {
+			return fmt.Errorf("failed to count %s: %w", table, err)
+		}
+		if count > 0 {
+			conflicts = append(conflicts, "gateway table "+table)
+		}
+	}
+	for _, kind := range sortedKeys(manifest.Storage) {
+		list, err := newList(kind)
+		if err != nil {
+			return err
+		}
+		if err := stores.Storage.List(ctx, list); err != nil {
+			return fmt.Errorf("failed to list %s: %w", kind, err)
+		}
+		if meta.LenList(list) > 0 {
+			conflicts = append(conflicts, "storage kind "+kind)
+		}
+	}
+	if len(conflicts) > 0 {
+		return fmt.Errorf("cannot restore into stores that already have data (%s); restore with overwrite to replace it", strings.Join(conflicts, ", "))
+	}
+	return nil
+}
+
+// restorer writes an archive into the stores as it is read.
+type restorer struct {
+	ctx     context.Context
+	stores  Stores
+	models  map[string]any
+	tx      *gorm.DB
+	applied []string
+
+	// uids maps the UIDs storage objects had when they were backed up to the
+	// ones they were restored with.
+	uids map[ktypes.UID]ktypes.UID
+	// owned are the restored objects with owner references to rewrite.
+	owned []kclient.Object
+}
+
+func newRestorer(ctx context.Context, stores Stores, manifest Manifest, models map[string]any, overwrite bool) (*restorer, error) {
+	if err := stores.Gateway.AutoMigrate(); err != nil {
+		return nil, fmt.Errorf("failed to migrate the gateway database: %w", err)
+	}
+
+	db := stores.Gateway.WithContext(ctx)
+	// Migrating an up-to-date database records every migration this version
+	// has, so any other in the archive was applied by a newer version.
+	var known []string
+	if err := db.Model(&types.Migration{}).Pluck("name", &known).Error; err != nil {
+		return nil, fmt.Errorf("failed to read applied migrations: %w", err)
+	}
+	for _, name := range manifest.Migrations {
+		if !slices.Contains(known, name) {
+			return nil, fmt.Errorf("backup has gateway migration %s, which this version of Obot does not know: it was taken by a newer version", name)
+		}
+	}
+
+	if overwrite {
+		if err := clearStorage(ctx, stores.Storage, sortedKeys(manifest.Storage)); err != nil {
+			return nil, err
+		}
+	} else if err := checkEmpty(ctx, stores, manifest, models); err != nil {
+		return nil, err
+	}
+
+	tx := db.Begin()
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+	r := &restorer{
+		ctx:    ctx,
+		stores: stores,
+		models: models,
+		tx:     tx,
+		uids:   map[ktypes.UID]ktypes.UID{},
+	}
+	if overwrite {
+		for _, table := range sortedKeys(models) {
+			if err := tx.Session(&gorm.Session{AllowGlobalUpdate: true}).Unscoped().Delete(models[table]).Error; err != nil {
+				r.rollback()
+				return nil, fmt.Errorf("failed to clear %s: %w", table, err)
+			}
+		}
+	}
+
+	// The archive's record of applied migrations replaces the one migrating
+	// just wrote, so that those the archive predates run again on its data.
+	if err := tx.Session(&gorm.Session{AllowGlobalUpdate: true}).Delete(&types.Migration{}).Error; err != nil {
+		r.rollback()
+		return nil, fmt.Errorf("failed to reset applied migrations: %w", err)
+	}
+	for _, name := range manifest.Migrations {
+		if err := tx.Create(&types.Migration{Name: name}).Error; err != nil {
+			r.rollback()
+			return nil, fmt.Errorf("failed to record applied migration %s: %w", name, err)
+		}
+	}
+	for _, name := range known {
+		if !slices.Contains(manifest.Migrations, name) {
+			r.applied = append(r.applied, name)
+		}
+	}
+	slices.Sort(r.applied)
+	return r, nil
+}
+
+func (r *restorer) insert(table string, model any, rows any) error {
+	// Hooks would change the rows, such as by giving them new IDs, and
+	// associations are restored from their own tables.
+	if err := r.tx.Session(&gorm.Session{SkipHooks: true}).Omit(clause.Associations).CreateInBatches(rows, 100).Error; err != nil {
+		return fmt.Errorf("failed to restore %s: %w", table, err)
+	}
+	return nil
+}
+
+// commitGateway commits the gateway rows and applies the data migrations the
+// archive predates, returning them. It does nothing after the first call.
+func (r *restorer) commitGateway() ([]string, error) {
+	if r.tx == nil {
+		return r.applied, nil
+	}
+	if r.tx.Name() == "postgres" {
+		if err := resetSequences(r.tx, r.models); err != nil {
+			return nil, err
+		}
+	}
+	err := r.tx.Commit().Error
+	r.tx = nil
+	if err != nil {
+		return nil, fmt.Errorf("failed to commit the gateway database: %w", err)
+	}
+	if err := r.stores.Gateway.AutoMigrate(); err != nil {
+		return nil, fmt.Errorf("failed to migrate the restored gateway database: %w", err)
+	}
+	return r.applied, nil
+}
+
+func (r *restorer) rollback() {
+	if r.tx != nil {
+		r.tx.Rollback()
+		r.tx = nil
+	}
+}
+
+// resetSequences moves PostgreSQL's sequences for auto-increment keys past the
+// restored rows, which were inserted with their keys and so did not use them.
+func resetSequences(tx *gorm.DB, models map[string]any) error {
+	for _, table := range sortedKeys(models) {
+		stmt := &gorm.Statement{DB: tx}
+		if err := stmt.Parse(models[table]); err != nil {
+			return err
+		}
+		field := stmt.Schema.PrioritizedPrimaryField
+		if field == nil || !field.AutoIncrement || field.DataType == schema.String {
+			continue
+		}
+		if err := tx.Exec("SELECT setval(pg_get_serial_sequence(?, ?), COALESCE(MAX(?), 0) + 1, false) FROM ?",
+			stmt.Schema.Table, field.DBName, clause.Column{Name: field.DBName}, clause.Table{Name: stmt.Schema.Table}).Error; err != nil {
+			return fmt.Errorf("failed to reset the key sequence of %s: %w", table, err)
+		}
+	}
+	return nil
+}
+
+// create creates storage objects as they were backed up, status included.
+func (r *restorer) create(objects []kclient.Object) error {
+	for _, obj := range objects {
+		backedUp := obj.DeepCopyObject().(kclient.Object)
+		oldUID := obj.GetUID()
+
+		obj.SetResourceVersion("")
+		obj.SetUID("")
+		obj.SetGeneration(0)
+		obj.SetManagedFields(nil)
+		if err := r.stores.Storage.Create(r.ctx, obj); err != nil {
+			return fmt.Errorf("failed to create %s: %w", obj.GetName(), err)
+		}
+		r.uids[oldUID] = obj.GetUID()
+
+		// Creating drops the status of kinds that keep it in a subresource.
+		backedUp.SetResourceVersion(obj.GetResourceVersion())
+		backedUp.SetUID(obj.GetUID())
+		backedUp.SetGeneration(obj.GetGeneration())
+		backedUp.SetManagedFields(nil)
+		backedUp.SetCreationTimestamp(obj.GetCreationTimestamp())
+		if err := r.stores.Storage.Status().Update(r.ctx, backedUp); err == nil {
+			obj = backedUp
+		} else if !apierrors.IsNotFound(err) && !apierrors.IsMethodNotSupported(err) {
+			return fmt.Errorf("failed to restore the status of %s: %w", obj.GetName(), err)
+		}
+
+		if len(obj.GetOwnerReferences()) > 0 {
+			r.owned = append(r.owned, obj)
+		}
+	}
+	return nil
+}
+
+// fixOwnerReferences points restored objects' owner references at their
+// owners' new UIDs. References to owners that were not restored are left.
+func (r *restorer) fixOwnerReferences() error {
+	for _, obj := range r.owned {
+		refs := obj.GetOwnerReferences()
+		var changed bool
+		for i, ref := range refs {
+			if uid, ok := r.uids[ref.UID]; ok && uid != ref.UID {
+				refs[i].UID = uid
+				changed = true
+			}
+		}
+		if !changed {
+			continue
+		}
+		obj.SetOwnerReferences(refs)
+		if err := r.stores.Storage.Update(r.ctx, obj); err != nil {
+			return fmt.Errorf("failed to update the owner references of %s: %w", obj.GetName(), err)
+		}
+	}
+	return nil
+}
+
+// clearStorage deletes every object of the kinds, finalizers and all: nothing
+// is running to finalize them.
+func clearStorage(ctx context.Context, c kclient.Client, kinds []string) error {
+	for _, kind := range kinds {
+		list, err := newList(kind)
+		if err != nil {
+			return err
+		}
+		if err := c.List(ctx, list); err != nil {
+			return fmt.Errorf("failed to list %s: %w", kind, err)
+		}
+		if err := meta.EachListItem(list, func(item runtime.Object) error {
+			obj := item.(kclient.Object)
+			if len(obj.GetFinalizers()) > 0 {
+				obj.SetFinalizers(nil)
+				if err := c.Update(ctx, obj); err != nil {
+					return kclient.IgnoreNotFound(err)
+				}
+			}
+			return kclient.IgnoreNotFound(c.Delete(ctx, obj))
+		}); err != nil {
+			return fmt.Errorf("failed to clear %s: %w", kind, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package localconfig
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/adrg/xdg"
+)
+
+const (
+	skillLockRelPath = "obot/skills-lock.json"
+	skillLockVersion = 1
+)
+
+// SkillLock records the skills the CLI has installed into local agent
+// directories so they can be listed, updated and removed later.
+type SkillLock struct {
+	Version int           `json:"version"`
+	Skills  []LockedSkill `json:"skills,omitempty"`
+}
+
+// LockedSkill is a single installed skill tree.
+type LockedSkill struct {
+	SkillID string `json:"skillID"`
+	Name    string `json:"name"`
+	// Agent is the local agent ID that owns the skills root, or empty when
+	// the skill was installed into a custom directory.
+	Agent string `json:"agent,omitempty"`
+	// Root is the skills directory and Path is the installed skill directory
+	// beneath it.
+	Root        string `json:"root"`
+	Path        string `json:"path"`
+	CommitSHA   string `json:"commitSHA,omitempty"`
+	InstallHash string `json:"installHash,omitempty"`
+	// ContentHash is the hash of the files as written to disk. It is used to
+	// detect local modifications before the CLI replaces or removes them.
+	ContentHash string    `json:"contentHash"`
+	InstalledAt time.Time `json:"installedAt"`
+}
+
+// LoadSkillLock reads the skill lockfile from XDG config storage. A missing
+// lockfile is returned as an empty lock.
+func LoadSkillLock() (SkillLock, error) {
+	path := filepath.Join(xdg.ConfigHome, skillLockRelPath)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return SkillLock{Version: skillLockVersion}, nil
+	}
+	if err != nil {
+		return SkillLock{}, fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	var lock SkillLock
+	if err := json.Unmarshal(data, &lock); err != nil {
+		return SkillLock{}, fmt.Errorf("reading %s: %w", path, err)
+	}
+	if lock.Version > skillLockVersion {
+		return SkillLock{}, fmt.Errorf("reading %s: unsupported lockfile version %d", path, lock.Version)
+	}
+	lock.Version = skillLockVersion
+	return lock, nil
+}
+
+// SaveSkillLock writes the skill lockfile to XDG config storage.
+func SaveSkillLock(lock SkillLock) error {
+	path, err := xdg.ConfigFile(skillLockRelPath)
+	if err != nil {
+		return err
+	}
+
+	lock.Version = skillLockVersion
+	sort.Slice(lock.Skills, func(i, j int) bool {
+		if lock.Skills[i].Root != lock.Skills[j].Root {
+			return lock.Skills[i].Root < lock.Skills[j].Root
+		}
+		return lock.Skills[i].Name < lock.Skills[j].Name
+	})
+
+	data, err := json.MarshalIndent(lock, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("creating %s: %w", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	return nil
+}
+
+// Find returns the entry installed at path.
+func (l *SkillLock) Find(path string) (LockedSkill, bool) {
+	path = filepath.Clean(path)
+	for _, skill := range l.Skills {
+		if skill.Path == path {
+			return skill, true
+		}
+	}
+	return LockedSkill{}, false
+}
+
+// Put adds an entry, replacing any existing entry for the same path.
+func (l *SkillLock) Put(skill LockedSkill) {
+	skill.Root = filepath.Clean(skill.Root)
+	skill.Path = filepath.Clean(skill.Path)
+	for i := range l.Skills {
+		if l.Skills[i].Path == skill.Path {
+			l.Skills[i] = skill
+			return
+		}
+	}
+	l.Skills = append(l.Skills, skill)
+}
+
+// Remove deletes the entry installed at path and reports whether one existed.
+func (l *SkillLock) Remove(path string) bool {
+	path = filepath.Clean(path)
+	for i := range l.Skills {
+		if l.Skills[i].Path == path {
+			l.Skills = append(l.Skills[:i], l.Skills[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,74 @@
+package localconfig
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadMissingSkillLock(t *testing.T) {
+	useTestXDGConfigHome(t)
+
+	lock, err := LoadSkillLock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lock.Version != skillLockVersion || len(lock.Skills) != 0 {
+		t.Fatalf("expected empty lock, got %#v", lock)
+	}
+}
+
+func TestSaveLoadSkillLock(t *testing.T) {
+	configHome := useTestXDGConfigHome(t)
+
+	var lock SkillLock
+	lock.Put(LockedSkill{SkillID: "sk2", Name: "zeta", Root: "/skills", Path: "/skills/zeta/", ContentHash: "c2"})
+	lock.Put(LockedSkill{SkillID: "sk1", Name: "alpha", Root: "/skills", Path: "/skills/alpha", ContentHash: "c1"})
+	lock.Put(LockedSkill{SkillID: "sk1", Name: "alpha", Root: "/skills", Path: "/skills/alpha", ContentHash: "c1-updated"})
+	if err := SaveSkillLock(lock); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := os.Stat(filepath.Join(configHome, "obot", "skills-lock.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Fatalf("lockfile mode = %v, want 0600", info.Mode().Perm())
+	}
+
+	loaded, err := LoadSkillLock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded.Skills) != 2 || loaded.Skills[0].Name != "alpha" || loaded.Skills[1].Name != "zeta" {
+		t.Fatalf("unexpected lock contents: %#v", loaded.Skills)
+	}
+	if loaded.Skills[0].ContentHash != "c1-updated" {
+		t.Fatalf("expected replaced entry, got %#v", loaded.Skills[0])
+	}
+
+	entry, ok := loaded.Find("/skills/zeta")
+	if !ok || entry.SkillID != "sk2" {
+		t.Fatalf("Find(/skills/zeta) = %#v, %v", entry, ok)
+	}
+	if !loaded.Remove("/skills/zeta") || loaded.Remove("/skills/zeta") {
+		t.Fatal("expected Remove to delete the entry exactly once")
+	}
+}
+
+func TestLoadSkillLockRejectsNewerVersion(t *testing.T) {
+	configHome := useTestXDGConfigHome(t)
+
+	path := filepath.Join(configHome, "obot", "skills-lock.json")
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(`{"version": 99}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := LoadSkillLock(); err == nil {
+		t.Fatal("expected error")
+	}
+}
@@ -0,0 +1,74 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/obot-platform/obot/pkg/cli/internal/credentials"
+	"github.com/obot-platform/obot/pkg/cli/internal/localconfig"
+)
+
+// MCPAPIKey is an API key the CLI created for a single MCP server and wrote
+// into local client configuration.
+type MCPAPIKey struct {
+	ID  uint   `json:"id"`
+	Key string `json:"key"`
+}
+
+// StoredMCPAPIKey returns the API key stored for mcpID on appURL. The boolean
+// is false when no key is stored.
+func StoredMCPAPIKey(appURL, mcpID string) (MCPAPIKey, bool, error) {
+	account, err := mcpKeyAccount(appURL, mcpID)
+	if err != nil {
+		return MCPAPIKey{}, false, err
+	}
+
+	raw, err := credentialStore.Get(account)
+	if credentials.IsNotFound(err) {
+		return MCPAPIKey{}, false, nil
+	}
+	if err != nil {
+		return MCPAPIKey{}, false, err
+	}
+
+	var key MCPAPIKey
+	if err := json.Unmarshal([]byte(raw), &key); err != nil || key.Key == "" {
+		// An unreadable entry is treated as missing so a new key replaces it.
+		return MCPAPIKey{}, false, nil
+	}
+	return key, true, nil
+}
+
+// StoreMCPAPIKey stores key for mcpID on appURL in the OS keyring.
+func StoreMCPAPIKey(appURL, mcpID string, key MCPAPIKey) error {
+	account, err := mcpKeyAccount(appURL, mcpID)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(key)
+	if err != nil {
+		return err
+	}
+	return credentialStore.Set(account, string(data))
+}
+
+// DeleteMCPAPIKey removes the key stored for mcpID on appURL.
+func DeleteMCPAPIKey(appURL, mcpID string) error {
+	account, err := mcpKeyAccount(appURL, mcpID)
+	if err != nil {
+		return err
+	}
+	return credentialStore.Delete(account)
+}
+
+func mcpKeyAccount(appURL, mcpID string) (string, error) {
+	appURL, err := localconfig.NormalizeAppURL(appURL)
+	if err != nil {
+		return "", err
+	}
+	if strings.TrimSpace(mcpID) == "" {
+		return "", fmt.Errorf("MCP server ID is required")
+	}
+	return appURL + "/mcp-connect/" + mcpID, nil
+}
@@ -0,0 +1,35 @@
+package internal
+
+import "testing"
+
+func TestMCPAPIKeyRoundTripDoesNotTouchLoginToken(t *testing.T) {
+	store := newFakeCredentialStore()
+	store.tokens["https://obot.example.com"] = "login-token"
+	restore := useCredentialStore(t, store)
+	defer restore()
+
+	if _, ok, err := StoredMCPAPIKey("https://obot.example.com/", "ms1abc"); err != nil || ok {
+		t.Fatalf("StoredMCPAPIKey() before store = %v, %v", ok, err)
+	}
+
+	if err := StoreMCPAPIKey("https://obot.example.com", "ms1abc", MCPAPIKey{ID: 7, Key: "ok1-7-secret"}); err != nil {
+		t.Fatal(err)
+	}
+	key, ok, err := StoredMCPAPIKey("https://obot.example.com", "ms1abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok || key.ID != 7 || key.Key != "ok1-7-secret" {
+		t.Fatalf("StoredMCPAPIKey() = %+v, %v", key, ok)
+	}
+
+	if err := DeleteMCPAPIKey("https://obot.example.com", "ms1abc"); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok, err := StoredMCPAPIKey("https://obot.example.com", "ms1abc"); err != nil || ok {
+		t.Fatalf("StoredMCPAPIKey() after delete = %v, %v", ok, err)
+	}
+	if got := store.tokens["https://obot.example.com"]; got != "login-token" {
+		t.Fatalf("login token = %q, want unchanged", got)
+	}
+}
@@ -63,6 +63,8 @@ func (m *MCP) Customize(c *cobra.Command) {
 	c.Short = "Manage MCP servers"
 	c.Args = cobra.NoArgs
 	c.AddCommand(cmd.Command(&MCPSearch{root: m.root}))
+	c.AddCommand(cmd.Command(&MCPInstall{root: m.root}))
+	c.AddCommand(cmd.Command(&MCPUninstall{root: m.root}))
 	c.AddCommand(cmd.Command(&MCPValidateCatalogYAML{}))
 	c.AddCommand(cmd.Command(&MCPValidateSystemCatalogYAML{}))
 }
@@ -168,8 +170,8 @@ func validateMCPCatalogFile(ctx context.Context, path string, requireEntryKey bo
 	}
 
 	validationOptions := mcpcatalog.ValidationOptions{
-		GitManaged: true,
-		MCPBackend: mcp.RuntimeBackendKubernetes,
+		GitManaged:               true,
+		SecretProviderConfigured: true,
 		MCP: mcp.ValidationOptions{
 			RemoteMCPURLValidationConfig: mcp.RemoteMCPURLValidationConfig{
 				AllowLocalhostMCP: true,
@@ -0,0 +1,761 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/obot-platform/obot/apiclient"
+	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/obot-platform/obot/pkg/cli/internal"
+	"github.com/obot-platform/obot/pkg/localagents"
+	"github.com/obot-platform/obot/pkg/system"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// mcpConfigURLKey is the configure key the API uses for the user-supplied URL
+// of a remote server constrained to a hostname.
+const mcpConfigURLKey = "__url"
+
+type MCPInstall struct {
+	PromptConfig
+
+	Clients string   `usage:"Comma-separated target clients: claude-code or cursor; defaults to every detected client"`
+	Name    string   `usage:"Name of the entry written to client configuration; defaults to a name derived from the server title"`
+	Config  []string `usage:"Configuration value as KEY=VALUE; may be repeated"`
+	JSON    bool     `usage:"Print results as JSON"`
+
+	root *Obot
+}
+
+type MCPUninstall struct {
+	PromptConfig
+
+	Clients string `usage:"Comma-separated target clients: claude-code or cursor; defaults to every supported client"`
+	JSON    bool   `usage:"Print results as JSON"`
+
+	root *Obot
+}
+
+type mcpInstallOutput struct {
+	Name    string            `json:"name"`
+	Server  string            `json:"server"`
+	MCPID   string            `json:"mcpID"`
+	URL     string            `json:"url"`
+	Clients []mcpClientResult `json:"clients"`
+}
+
+type mcpUninstallOutput struct {
+	Name       string            `json:"name"`
+	Clients    []mcpClientResult `json:"clients"`
+	KeyRevoked bool              `json:"keyRevoked"`
+}
+
+type mcpClientResult struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"displayName"`
+	Path        string `json:"path,omitempty"`
+}
+
+// mcpConfigField is a value the user supplies when configuring a server.
+type mcpConfigField struct {
+	Key         string
+	Name        string
+	Description string
+	Sensitive   bool
+	Required    bool
+	Prefix      string
+	Options     []types.MCPConfigurationOption
+}
+
+func (m *MCPInstall) Customize(cmd *cobra.Command) {
+	cmd.Use = "install <server>"
+	cmd.Short = "Add an Obot MCP server to local MCP clients"
+	cmd.Long = `Add an Obot MCP server to the MCP configuration of local clients.
+
+The server may be given by registry name, ID, or title as shown by
+"obot mcp search". Servers that require configuration are configured
+from --config values, prompting for any required value that is missing.
+A dedicated API key limited to the server is created, stored in the OS
+keyring, and written into each client's configuration.`
+	cmd.Args = cobra.ExactArgs(1)
+}
+
+func (m *MCPInstall) Run(cmd *cobra.Command, args []string) error {
+	if m.root == nil || m.root.Client == nil {
+		return fmt.Errorf("mcp install: no API client configured")
+	}
+
+	targets, err := selectMCPConfigTargets(cmd.Context(), m.Clients, true)
+	if err != nil {
+		return err
+	}
+	values, err := parseMCPConfigValues(m.Config)
+	if err != nil {
+		return err
+	}
+
+	client, err := mcpAPIClient(cmd.Context(), m.root.Client)
+	if err != nil {
+		return err
+	}
+	appURL, err := internal.AppURLForAPIBaseURL(client.BaseURL)
+	if err != nil {
+		return err
+	}
+
+	registryServer, err := resolveRegistryServer(cmd.Context(), client, strings.TrimSpace(args[0]))
+	if err != nil {
+		return registrySearchError(err)
+	}
+
+	server, headers, err := m.prepareServer(cmd, client, appURL, registryServer, values)
+	if err != nil {
+		return err
+	}
+
+	connectURL := server.ConnectURL
+	if connectURL == "" {
+		connectURL = firstRegistryRemoteURL(registryServer.Server)
+	}
+	if connectURL == "" {
+		connectURL = strings.TrimRight(appURL, "/") + "/mcp-connect/" + url.PathEscape(server.ID)
+	}
+	mcpID := mcpConnectID(connectURL)
+	if mcpID == "" {
+		mcpID = server.ID
+	}
+
+	name := m.Name
+	if name == "" {
+		name = mcpEntryName(registryServer.Server)
+	}
+
+	key, err := ensureMCPAPIKey(cmd.Context(), client, appURL, mcpID, server.ID, name)
+	if err != nil {
+		return err
+	}
+	headers["Authorization"] = "Bearer " + key
+
+	entry := localagents.MCPServerEntry{
+		Name:    name,
+		URL:     connectURL,
+		Headers: headers,
+	}
+	output := mcpInstallOutput{
+		Name:   name,
+		Server: registryServer.Server.Name,
+		MCPID:  mcpID,
+		URL:    connectURL,
+	}
+	for _, target := range targets {
+		result, err := target.InstallMCPServer(cmd.Context(), "", entry)
+		if err != nil {
+			return fmt.Errorf("failed to add MCP server to %s: %w", target.DisplayName(), err)
+		}
+		output.Clients = append(output.Clients, mcpClientResult{
+			ID:          result.AgentID,
+			DisplayName: result.DisplayName,
+			Path:        strings.Join(result.Installed, ", "),
+		})
+	}
+
+	if m.JSON {
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		return enc.Encode(output)
+	}
+	for _, result := range output.Clients {
+		fmt.Fprintf(cmd.OutOrStdout(), "Added %s to %s (%s)\n", name, result.DisplayName, result.Path)
+	}
+	return nil
+}
+
+// prepareServer returns the server the client connects through, configuring
+// it first when the registry reports that configuration is required, along
+// with any user-defined headers the client must send.
+func (m *MCPInstall) prepareServer(cmd *cobra.Command, client *apiclient.Client, appURL string, registryServer types.RegistryServerResponse, values map[string]string) (types.MCPServer, map[string]string, error) {
+	ctx := cmd.Context()
+	id := registryServerID(registryServer.Server.Name)
+	configurationRequired := registryServer.Meta.Obot != nil && registryServer.Meta.Obot.ConfigurationRequired
+	headers := map[string]string{}
+
+	if system.IsMCPServerID(id) {
+		server, err := client.GetAccessibleMCPServer(ctx, id)
+		if err != nil {
+			return types.MCPServer{}, nil, err
+		}
+		if server.MCPServerManifest.Runtime == types.RuntimeComposite && configurationRequired {
+			return types.MCPServer{}, nil, compositeConfigurationError(appURL, registryServer.Server.Name)
+		}
+
+		var userHeaders []types.MCPHeader
+		if server.MCPServerManifest.MultiUserConfig != nil {
+			userHeaders = server.MCPServerManifest.MultiUserConfig.UserDefinedHeaders
+		}
+		fields := mcpHeaderFields(userHeaders)
+		if configurationRequired && len(fields) == 0 {
+			fields = mcpServerConfigFields(server.MCPServerManifest.Env, nil, false)
+			configured, err := m.configureServer(cmd, client, server.ID, fields, values)
+			return configured, headers, err
+		}
+
+		collected, err := m.collectValues(cmd, fields, values)
+		if err != nil {
+			return types.MCPServer{}, nil, err
+		}
+		for _, field := range fields {
+			if value, ok := collected[field.Key]; ok {
+				headers[field.Key] = field.Prefix + value
+			}
+		}
+		return server, headers, nil
+	}
+
+	entry, err := client.GetMCPCatalogEntry(ctx, id)
+	if err != nil {
+		return types.MCPServer{}, nil, err
+	}
+	if entry.Manifest.Runtime == types.RuntimeComposite && configurationRequired {
+		return types.MCPServer{}, nil, compositeConfigurationError(appURL, registryServer.Server.Name)
+	}
+
+	// API keys are scoped to MCP servers, so a personal server is needed even
+	// when the catalog entry has nothing to configure.
+	server, err := personalServerForEntry(ctx, client, entry.ID)
+	if err != nil {
+		return types.MCPServer{}, nil, err
+	}
+	if !configurationRequired && server.Configured {
+		return server, headers, nil
+	}
+
+	var remoteHeaders []types.MCPHeader
+	needsURL := false
+	if rc := entry.Manifest.RemoteConfig; rc != nil {
+		remoteHeaders = rc.Headers
+		needsURL = rc.FixedURL == "" && rc.Hostname != ""
+	}
+	fields := mcpServerConfigFields(entry.Manifest.Env, remoteHeaders, needsURL)
+	configured, err := m.configureServer(cmd, client, server.ID, fields, values)
+	return configured, headers, err
+}
+
+func (m *MCPInstall) configureServer(cmd *cobra.Command, client *apiclient.Client, serverID string, fields []mcpConfigField, values map[string]string) (types.MCPServer, error) {
+	collected, err := m.collectValues(cmd, fields, values)
+	if err != nil {
+		return types.MCPServer{}, err
+	}
+	server, err := client.ConfigureMCPServer(cmd.Context(), serverID, collected)
+	if err != nil {
+		return types.MCPServer{}, fmt.Errorf("failed to configure MCP server: %w", err)
+	}
+	if missing := slices.Concat(server.MissingRequiredEnvVars, server.MissingRequiredHeaders); len(missing) > 0 {
+		return types.MCPServer{}, fmt.Errorf("MCP server is still missing required configuration: %s", strings.Join(missing, ", "))
+	}
+	return server, nil
+}
+
+// collectValues resolves every field from --config values, prompting for
+// required fields that were not supplied.
+func (m *MCPInstall) collectValues(cmd *cobra.Command, fields []mcpConfigField, values map[string]string) (map[string]string, error) {
+	known := map[string]bool{}
+	for _, field := range fields {
+		known[field.Key] = true
+	}
+	var unknown []string
+	for key := range values {
+		if !known[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("unknown --config keys for this server: %s", strings.Join(unknown, ", "))
+	}
+
+	result := map[string]string{}
+	var missing []string
+	for _, field := range fields {
+		value, ok := values[field.Key]
+		if !ok && field.Required {
+			if m.NonInteractive {
+				missing = append(missing, field.Key)
+				continue
+			}
+			var err error
+			if value, err = promptMCPConfigField(cmd, field); err != nil {
+				return nil, err
+			}
+			ok = true
+		}
+		if !ok {
+			continue
+		}
+		if err := validateMCPConfigValue(field, value); err != nil {
+			return nil, err
+		}
+		result[field.Key] = value
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("missing required configuration; pass --config for: %s", strings.Join(missing, ", "))
+	}
+	return result, nil
+}
+
+func (m *MCPUninstall) Customize(cmd *cobra.Command) {
+	cmd.Use = "uninstall <name>"
+	cmd.Short = "Remove an Obot MCP server from local MCP clients"
+	cmd.Long = `Remove an MCP server entry from the MCP configuration of local clients.
+
+When the entry is removed from every client, the API key created for it
+by "obot mcp install" is revoked and removed from the OS keyring.`
+	cmd.Args = cobra.ExactArgs(1)
+}
+
+func (m *MCPUninstall) Run(cmd *cobra.Command, args []string) error {
+	if m.root == nil || m.root.Client == nil {
+		return fmt.Errorf("mcp uninstall: no API client configured")
+	}
+
+	name := strings.TrimSpace(args[0])
+	targets, err := selectMCPConfigTargets(cmd.Context(), m.Clients, false)
+	if err != nil {
+		return err
+	}
+
+	output := mcpUninstallOutput{Name: name}
+	var mcpID string
+	for _, target := range targets {
+		entry, ok, err := target.GetMCPServer(cmd.Context(), "", name)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		if id := mcpConnectID(entry.URL); id != "" {
+			mcpID = id
+		}
+		if _, err := target.UninstallMCPServer(cmd.Context(), "", name); err != nil {
+			return fmt.Errorf("failed to remove MCP server from %s: %w", target.DisplayName(), err)
+		}
+		output.Clients = append(output.Clients, mcpClientResult{ID: target.ID(), DisplayName: target.DisplayName()})
+	}
+	if len(output.Clients) == 0 {
+		return fmt.Errorf("no MCP client has an entry named %q", name)
+	}
+
+	if mcpID != "" {
+		inUse, err := mcpKeyInUse(cmd.Context(), mcpID)
+		if err != nil {
+			return err
+		}
+		if !inUse {
+			if output.KeyRevoked, err = revokeMCPAPIKey(cmd.Context(), m.root.Client, mcpID); err != nil {
+				return err
+			}
+		}
+	}
+
+	if m.JSON {
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		return enc.Encode(output)
+	}
+	for _, result := range output.Clients {
+		fmt.Fprintf(cmd.OutOrStdout(), "Removed %s from %s\n", name, result.DisplayName)
+	}
+	if output.KeyRevoked {
+		fmt.Fprintln(cmd.OutOrStdout(), "Revoked the API key created for", name)
+	}
+	return nil
+}
+
+// selectMCPConfigTargets parses --clients. Without it, install writes to every
+// detected client and uninstall considers every supported client.
+func selectMCPConfigTargets(ctx context.Context, raw string, detectedOnly bool) ([]localagents.MCPConfigInstaller, error) {
+	all := localagents.MCPConfigTargets()
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		if !detectedOnly {
+			return all, nil
+		}
+		var targets []localagents.MCPConfigInstaller
+		for _, target := range all {
+			if target.Detect(ctx).State == localagents.DetectionPresent {
+				targets = append(targets, target)
+			}
+		}
+		if len(targets) == 0 {
+			return nil, fmt.Errorf("no supported MCP clients were detected; pass --clients with %s", mcpConfigTargetIDs(all))
+		}
+		return targets, nil
+	}
+
+	var targets []localagents.MCPConfigInstaller
+	for part := range strings.SplitSeq(raw, ",") {
+		value := strings.TrimSpace(part)
+		if value == "" {
+			continue
+		}
+		i := slices.IndexFunc(all, func(target localagents.MCPConfigInstaller) bool {
+			return target.ID() == value
+		})
+		if i < 0 {
+			return nil, fmt.Errorf("unsupported --clients value %q; supported values are %s", value, mcpConfigTargetIDs(all))
+		}
+		if !slices.Contains(targets, all[i]) {
+			targets = append(targets, all[i])
+		}
+	}
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("--clients must include %s", mcpConfigTargetIDs(all))
+	}
+	return targets, nil
+}
+
+func mcpConfigTargetIDs(targets []localagents.MCPConfigInstaller) string {
+	ids := make([]string, 0, len(targets))
+	for _, target := range targets {
+		ids = append(ids, target.ID())
+	}
+	return strings.Join(ids, " or ")
+}
+
+func parseMCPConfigValues(raw []string) (map[string]string, error) {
+	values := map[string]string{}
+	for _, pair := range raw {
+		key, value, ok := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid --config value %q; expected KEY=VALUE", pair)
+		}
+		values[key] = value
+	}
+	return values, nil
+}
+
+// mcpAPIClient returns a client whose token can call the Obot API. The
+// default CLI token does not carry the api scope.
+func mcpAPIClient(ctx context.Context, client *apiclient.Client) (*apiclient.Client, error) {
+	token, err := client.GetToken(ctx, apiclient.TokenFetchOptions{
+		Scopes: append(types.DefaultCLIAPIKeyScopes(), types.APIKeyScopeAPI),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get an API token: %w", err)
+	}
+	return client.WithToken(token), nil
+}
+
+// resolveRegistryServer finds the registry server matching a full registry
+// name, the ID after the registry namespace, or a title.
+func resolveRegistryServer(ctx context.Context, client *apiclient.Client, query string) (types.RegistryServerResponse, error) {
+	if query == "" {
+		return types.RegistryServerResponse{}, fmt.Errorf("server is required")
+	}
+
+	search := query
+	if _, id, ok := strings.Cut(query, "/"); ok {
+		search = id
+	}
+
+	var (
+		cursor  string
+		matches []types.RegistryServerResponse
+	)
+	for {
+		page, err := client.ListRegistryServers(ctx, apiclient.ListRegistryServersOptions{
+			Search: search,
+			Cursor: cursor,
+			Limit:  mcpSearchPageLimit,
+		})
+		if err != nil {
+			return types.RegistryServerResponse{}, err
+		}
+		for _, server := range page.Servers {
+			if server.Server.Name == query {
+				return server, nil
+			}
+			if registryServerID(server.Server.Name) == query || strings.EqualFold(server.Server.Title, query) {
+				matches = append(matches, server)
+			}
+		}
+		if page.Metadata == nil || page.Metadata.NextCursor == "" {
+			break
+		}
+		cursor = page.Metadata.NextCursor
+	}
+
+	switch len(matches) {
+	case 0:
+		return types.RegistryServerResponse{}, fmt.Errorf("no MCP server matches %q; run \"obot mcp search\" to find one", query)
+	case 1:
+		return matches[0], nil
+	}
+	names := make([]string, 0, len(matches))
+	for _, match := range matches {
+		names = append(names, match.Server.Name)
+	}
+	return types.RegistryServerResponse{}, fmt.Errorf("%q matches more than one MCP server; use one of: %s", query, strings.Join(names, ", "))
+}
+
+func registryServerID(registryName string) string {
+	if _, id, ok := strings.Cut(registryName, "/"); ok {
+		return id
+	}
+	return registryName
+}
+
+// mcpConnectID returns the MCP ID in a /mcp-connect/{id} URL.
+func mcpConnectID(connectURL string) string {
+	u, err := url.Parse(connectURL)
+	if err != nil {
+		return ""
+	}
+	_, id, ok := strings.Cut(u.Path, "/mcp-connect/")
+	if !ok {
+		return ""
+	}
+	id, _, _ = strings.Cut(id, "/")
+	id, _ = url.PathUnescape(id)
+	return id
+}
+
+func mcpEntryName(server types.RegistryServerDetail) string {
+	name := server.Title
+	if name == "" {
+		name = registryServerID(server.Name)
+	}
+
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '_', r == '-':
+			b.WriteRune(r)
+		default:
+			b.WriteByte('-')
+		}
+	}
+	result := strings.Trim(b.String(), "-")
+	for strings.Contains(result, "--") {
+		result = strings.ReplaceAll(result, "--", "-")
+	}
+	if result == "" {
+		return registryServerID(server.Name)
+	}
+	return result
+}
+
+func compositeConfigurationError(appURL, registryName string) error {
+	configURL := registryServerConfigurationURL(appURL, registryName)
+	if configURL == "" {
+		return fmt.Errorf("composite MCP servers must be configured in the Obot UI")
+	}
+	return fmt.Errorf("composite MCP servers must be configured in the Obot UI: %s", configURL)
+}
+
+func personalServerForEntry(ctx context.Context, client *apiclient.Client, entryID string) (types.MCPServer, error) {
+	servers, err := client.ListMCPServers(ctx)
+	if err != nil {
+		return types.MCPServer{}, err
+	}
+	for _, server := range servers.Items {
+		if server.CatalogEntryID == entryID {
+			return server, nil
+		}
+	}
+
+	server, err := client.CreateMCPServer(ctx, types.MCPServer{CatalogEntryID: entryID})
+	if err != nil {
+		return types.MCPServer{}, fmt.Errorf("failed to create MCP server: %w", err)
+	}
+	return server, nil
+}
+
+func mcpServerConfigFields(env []types.MCPEnv, headers []types.MCPHeader, needsURL bool) []mcpConfigField {
+	var fields []mcpConfigField
+	if needsURL {
+		fields = append(fields, mcpConfigField{
+			Key:         mcpConfigURLKey,
+			Name:        "URL",
+			Description: "URL of the remote MCP server",
+			Required:    true,
+		})
+	}
+	for _, e := range env {
+		if e.SecretBinding != nil {
+			continue
+		}
+		fields = append(fields, mcpConfigField{
+			Key:         e.Key,
+			Name:        e.Name,
+			Description: e.Description,
+			Sensitive:   e.Sensitive,
+			Required:    e.Required,
+			Options:     e.Options,
+		})
+	}
+	return append(fields, mcpHeaderFields(headers)...)
+}
+
+// mcpHeaderFields returns the user-supplied headers; static headers are
+// skipped.
+func mcpHeaderFields(headers []types.MCPHeader) []mcpConfigField {
+	var fields []mcpConfigField
+	for _, h := range headers {
+		if h.Value != "" || h.SecretBinding != nil {
+			continue
+		}
+		fields = append(fields, mcpConfigField{
+			Key:         h.Key,
+			Name:        h.Name,
+			Description: h.Description,
+			Sensitive:   h.Sensitive,
+			Required:    h.Required,
+			Prefix:      h.Prefix,
+			Options:     h.Options,
+		})
+	}
+	return fields
+}
+
+func promptMCPConfigField(cmd *cobra.Command, field mcpConfigField) (string, error) {
+	label := field.Name
+	if label == "" {
+		label = field.Key
+	}
+	if field.Description != "" {
+		fmt.Fprintf(cmd.OutOrStdout(), "%s: %s\n", label, field.Description)
+	}
+	for _, option := range field.Options {
+		if option.Description != "" {
+			fmt.Fprintf(cmd.OutOrStdout(), "  %s  %s\n", option.Value, option.Description)
+		} else {
+			fmt.Fprintf(cmd.OutOrStdout(), "  %s\n", option.Value)
+		}
+	}
+
+	prompt := fmt.Sprintf("%s (%s): ", label, field.Key)
+	if field.Sensitive {
+		if in, ok := cmd.InOrStdin().(interface {
+			io.Reader
+			Fd() uintptr
+		}); ok && term.IsTerminal(int(in.Fd())) {
+			fmt.Fprint(cmd.OutOrStdout(), prompt)
+			value, err := term.ReadPassword(int(in.Fd()))
+			fmt.Fprintln(cmd.OutOrStdout())
+			if err != nil {
+				return "", err
+			}
+			return strings.TrimSpace(string(value)), nil
+		}
+	}
+
+	value, err := promptLine(cmd, prompt)
+	if errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("missing required configuration %s; pass --config %s=VALUE", field.Key, field.Key)
+	}
+	return value, err
+}
+
+func validateMCPConfigValue(field mcpConfigField, value string) error {
+	if field.Required && strings.TrimSpace(value) == "" {
+		return fmt.Errorf("%s is required", field.Key)
+	}
+	if len(field.Options) == 0 || value == "" {
+		return nil
+	}
+	for _, option := range field.Options {
+		if option.Value == value {
+			return nil
+		}
+	}
+	values := make([]string, 0, len(field.Options))
+	for _, option := range field.Options {
+		values = append(values, option.Value)
+	}
+	return fmt.Errorf("invalid value for %s; expected one of: %s", field.Key, strings.Join(values, ", "))
+}
+
+// ensureMCPAPIKey returns the stored key for mcpID when it still grants
+// access, and otherwise creates and stores a new key limited to serverID.
+func ensureMCPAPIKey(ctx context.Context, client *apiclient.Client, appURL, mcpID, serverID, name string) (string, error) {
+	stored, ok, err := internal.StoredMCPAPIKey(appURL, mcpID)
+	if err != nil {
+		return "", err
+	}
+	if ok {
+		if err := client.APIKeyCanAccessMCP(ctx, stored.Key, mcpID); err == nil {
+			return stored.Key, nil
+		}
+	}
+
+	created, err := client.CreateAPIKey(ctx, types.APIKeyCreateRequest{
+		Name:         "obot mcp install: " + name,
+		Description:  "Created by obot mcp install for local MCP clients",
+		MCPServerIDs: []string{serverID},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to create API key: %w", err)
+	}
+	if err := internal.StoreMCPAPIKey(appURL, mcpID, internal.MCPAPIKey{ID: created.ID, Key: created.Key}); err != nil {
+		return "", fmt.Errorf("failed to store API key: %w", err)
+	}
+	if ok && stored.ID != created.ID {
+		// The stored key no longer works; remove it so it doesn't linger.
+		_ = client.DeleteAPIKey(ctx, stored.ID)
+	}
+	return created.Key, nil
+}
+
+// mcpKeyInUse reports whether any MCP client config still has an entry that
+// connects to mcpID. The API key stored for mcpID is shared by all of them.
+func mcpKeyInUse(ctx context.Context, mcpID string) (bool, error) {
+	for _, target := range localagents.MCPConfigTargets() {
+		entries, err := target.ListMCPServers(ctx, "")
+		if err != nil {
+			return false, err
+		}
+		for _, entry := range entries {
+			if mcpConnectID(entry.URL) == mcpID {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+func revokeMCPAPIKey(ctx context.Context, client *apiclient.Client, mcpID string) (bool, error) {
+	appURL, err := internal.AppURLForAPIBaseURL(client.BaseURL)
+	if err != nil {
+		return false, err
+	}
+	stored, ok, err := internal.StoredMCPAPIKey(appURL, mcpID)
+	if err != nil || !ok {
+		return false, err
+	}
+
+	client, err = mcpAPIClient(ctx, client)
+	if err != nil {
+		return false, err
+	}
+	if err := client.DeleteAPIKey(ctx, stored.ID); err != nil {
+		var httpErr *types.ErrHTTP
+		if !errors.As(err, &httpErr) || httpErr.Code != http.StatusNotFound {
+			return false, fmt.Errorf("failed to revoke API key: %w", err)
+		}
+	}
+	if err := internal.DeleteMCPAPIKey(appURL, mcpID); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,239 @@
+package cli
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/obot-platform/obot/apiclient/types"
+	keyring "github.com/zalando/go-keyring"
+)
+
+type mcpInstallTestAPI struct {
+	t *testing.T
+
+	mu          sync.Mutex
+	registry    []types.RegistryServerResponse
+	entry       types.MCPServerCatalogEntry
+	servers     []types.MCPServer
+	configured  map[string]string
+	keysCreated int
+	keyScopes   [][]string
+	deletedKeys []string
+	validKeys   map[string]bool
+}
+
+func newMCPInstallTestAPI(t *testing.T) (*mcpInstallTestAPI, *httptest.Server) {
+	t.Helper()
+	api := &mcpInstallTestAPI{t: t, validKeys: map[string]bool{}}
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+	return api, server
+}
+
+func (a *mcpInstallTestAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/api/api-keys/auth":
+		var body struct {
+			ValidateOnly bool   `json:"validateOnly"`
+			MCPID        string `json:"mcpId"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if body.ValidateOnly {
+			_, _ = w.Write([]byte(`{"allowed":true,"scopes":{"canAccessAPI":true,"canAccessDeviceScans":true}}`))
+			return
+		}
+		key := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		_ = json.NewEncoder(w).Encode(map[string]bool{"allowed": a.validKeys[key]})
+	case r.Method == http.MethodGet && r.URL.Path == "/v0.1/servers":
+		_ = json.NewEncoder(w).Encode(types.RegistryServerList{Servers: a.registry})
+	case r.Method == http.MethodGet && r.URL.Path == "/api/all-mcps/entries/"+a.entry.ID:
+		_ = json.NewEncoder(w).Encode(a.entry)
+	case r.Method == http.MethodGet && r.URL.Path == "/api/mcp-servers":
+		_ = json.NewEncoder(w).Encode(types.MCPServerList{Items: a.servers})
+	case r.Method == http.MethodPost && r.URL.Path == "/api/mcp-servers":
+		var server types.MCPServer
+		_ = json.NewDecoder(r.Body).Decode(&server)
+		server.ID = "ms1created"
+		server.ConnectURL = "http://" + r.Host + "/mcp-connect/ms1created"
+		a.servers = append(a.servers, server)
+		_ = json.NewEncoder(w).Encode(server)
+	case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/configure"):
+		_ = json.NewDecoder(r.Body).Decode(&a.configured)
+		server := a.servers[0]
+		server.Configured = true
+		a.servers[0] = server
+		_ = json.NewEncoder(w).Encode(server)
+	case r.Method == http.MethodPost && r.URL.Path == "/api/api-keys":
+		var req types.APIKeyCreateRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		a.keysCreated++
+		a.keyScopes = append(a.keyScopes, req.MCPServerIDs)
+		key := "ok1-secret-" + strings.Repeat("x", a.keysCreated)
+		a.validKeys[key] = true
+		_ = json.NewEncoder(w).Encode(types.APIKeyCreateResponse{ID: uint(a.keysCreated), Key: key})
+	case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/api/api-keys/"):
+		a.deletedKeys = append(a.deletedKeys, strings.TrimPrefix(r.URL.Path, "/api/api-keys/"))
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		a.t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		http.NotFound(w, r)
+	}
+}
+
+func githubCatalogEntry() types.MCPServerCatalogEntry {
+	return types.MCPServerCatalogEntry{
+		Metadata: types.Metadata{ID: "github"},
+		Manifest: types.MCPServerCatalogEntryManifest{
+			Name:    "GitHub",
+			Runtime: types.RuntimeRemote,
+			RemoteConfig: &types.RemoteCatalogConfig{
+				FixedURL: "https://api.githubcopilot.com/mcp",
+				Headers: []types.MCPHeader{
+					{Name: "Token", Key: "GITHUB_TOKEN", Sensitive: true, Required: true},
+					{Name: "Static", Key: "X-Static", Value: "fixed"},
+				},
+			},
+		},
+	}
+}
+
+func TestMCPInstallConfiguresServerAndWritesClientConfig(t *testing.T) {
+	home := useSetupTestHome(t)
+	keyring.MockInit()
+
+	api, server := newMCPInstallTestAPI(t)
+	api.entry = githubCatalogEntry()
+	api.registry = []types.RegistryServerResponse{
+		registryTestServer("io.example/github", "GitHub", "GitHub tools", "", true),
+	}
+
+	stdout, err := executeMCPTestCommand(t, mcpTestRoot(server.URL), "install", "GitHub",
+		"--clients", "cursor", "--config", "GITHUB_TOKEN=ghp_test", "--non-interactive")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(stdout, "Added github to Cursor") {
+		t.Fatalf("unexpected output:\n%s", stdout)
+	}
+	if api.configured["GITHUB_TOKEN"] != "ghp_test" {
+		t.Fatalf("configured values = %v", api.configured)
+	}
+	if _, ok := api.configured["X-Static"]; ok {
+		t.Fatalf("static header should not be configured: %v", api.configured)
+	}
+	if len(api.keyScopes) != 1 || len(api.keyScopes[0]) != 1 || api.keyScopes[0][0] != "ms1created" {
+		t.Fatalf("API key scopes = %v, want [[ms1created]]", api.keyScopes)
+	}
+
+	var config struct {
+		MCPServers map[string]struct {
+			URL     string            `json:"url"`
+			Headers map[string]string `json:"headers"`
+		} `json:"mcpServers"`
+	}
+	data, err := os.ReadFile(filepath.Join(home, ".cursor", "mcp.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		t.Fatal(err)
+	}
+	entry := config.MCPServers["github"]
+	if entry.URL != server.URL+"/mcp-connect/ms1created" {
+		t.Fatalf("url = %q", entry.URL)
+	}
+	if entry.Headers["Authorization"] != "Bearer ok1-secret-x" {
+		t.Fatalf("authorization = %q", entry.Headers["Authorization"])
+	}
+	if strings.Contains(stdout, "ok1-secret") {
+		t.Fatalf("output should not include the API key:\n%s", stdout)
+	}
+
+	// Installing again reuses the stored key and the existing server.
+	if _, err := executeMCPTestCommand(t, mcpTestRoot(server.URL), "install", "io.example/github",
+		"--clients", "claude-code", "--config", "GITHUB_TOKEN=ghp_test", "--non-interactive"); err != nil {
+		t.Fatal(err)
+	}
+	if api.keysCreated != 1 {
+		t.Fatalf("keys created = %d, want stored key reused", api.keysCreated)
+	}
+	if len(api.servers) != 1 {
+		t.Fatalf("servers = %d, want existing server reused", len(api.servers))
+	}
+
+	// Removing the entry from one client keeps the key the other still uses.
+	stdout, err = executeMCPTestCommand(t, mcpTestRoot(server.URL), "uninstall", "github", "--clients", "cursor")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(stdout, "Revoked") || len(api.deletedKeys) != 0 {
+		t.Fatalf("key revoked while still in use; output:\n%s", stdout)
+	}
+
+	stdout, err = executeMCPTestCommand(t, mcpTestRoot(server.URL), "uninstall", "github")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(stdout, "Removed github from Claude Code") || !strings.Contains(stdout, "Revoked") {
+		t.Fatalf("unexpected output:\n%s", stdout)
+	}
+	if len(api.deletedKeys) != 1 || api.deletedKeys[0] != "1" {
+		t.Fatalf("deleted keys = %v, want [1]", api.deletedKeys)
+	}
+}
+
+func TestMCPInstallNonInteractiveRequiresConfig(t *testing.T) {
+	useSetupTestHome(t)
+	keyring.MockInit()
+
+	api, server := newMCPInstallTestAPI(t)
+	api.entry = githubCatalogEntry()
+	api.registry = []types.RegistryServerResponse{
+		registryTestServer("io.example/github", "GitHub", "GitHub tools", "", true),
+	}
+
+	_, err := executeMCPTestCommand(t, mcpTestRoot(server.URL), "install", "github", "--clients", "cursor", "--non-interactive")
+	if err == nil || !strings.Contains(err.Error(), "GITHUB_TOKEN") {
+		t.Fatalf("expected missing GITHUB_TOKEN error, got %v", err)
+	}
+	if api.keysCreated != 0 {
+		t.Fatalf("keys created = %d, want none", api.keysCreated)
+	}
+}
+
+func TestMCPInstallRejectsAmbiguousServer(t *testing.T) {
+	useSetupTestHome(t)
+	keyring.MockInit()
+
+	api, server := newMCPInstallTestAPI(t)
+	api.registry = []types.RegistryServerResponse{
+		registryTestServer("io.example/github", "GitHub", "", "", false),
+		registryTestServer("io.other/github", "GitHub", "", "", false),
+	}
+
+	_, err := executeMCPTestCommand(t, mcpTestRoot(server.URL), "install", "github", "--clients", "cursor")
+	if err == nil || !strings.Contains(err.Error(), "io.other/github") {
+		t.Fatalf("expected ambiguity error, got %v", err)
+	}
+}
+
+func TestMCPConnectID(t *testing.T) {
+	for in, want := range map[string]string{
+		"https://obot.example.com/mcp-connect/ms1abc":      "ms1abc",
+		"https://obot.example.com/mcp-connect/github/tool": "github",
+		"https://example.com/mcp":                          "",
+	} {
+		if got := mcpConnectID(in); got != want {
+			t.Fatalf("mcpConnectID(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
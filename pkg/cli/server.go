@@ -5,6 +5,7 @@ import (
 	"os/signal"
 	"syscall"
 
+	obotcmd "github.com/obot-platform/cmd"
 	"github.com/obot-platform/obot/logger"
 	"github.com/obot-platform/obot/pkg/server"
 	"github.com/obot-platform/obot/pkg/services"
@@ -17,6 +18,8 @@ type Server struct {
 
 func (s *Server) Customize(cmd *cobra.Command) {
 	cmd.Hidden = true
+	cmd.AddCommand(obotcmd.Command(&ServerBackup{}))
+	cmd.AddCommand(obotcmd.Command(&ServerRestore{}))
 }
 
 func (s *Server) Run(cmd *cobra.Command, _ []string) error {
@@ -0,0 +1,188 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+
+	"github.com/obot-platform/obot/pkg/backup"
+	"github.com/obot-platform/obot/pkg/gateway/db"
+	"github.com/obot-platform/obot/pkg/storage"
+	storageservices "github.com/obot-platform/obot/pkg/storage/services"
+	"github.com/spf13/cobra"
+)
+
+// ServerBackup writes an archive of an Obot instance's database. The instance
+// may be running, but a backup of a stopped one is consistent across the
+// storage API and the gateway.
+type ServerBackup struct {
+	DSN        string `usage:"Database dsn in driver://connection_string format" default:"sqlite://file:obot.db?_journal=WAL&cache=shared&_busy_timeout=30000" env:"OBOT_SERVER_DSN"`
+	Output     string `usage:"File to write the backup to, or - for standard output" short:"o" default:"obot-backup.obotbak"`
+	Scope      string `usage:"What to back up: config, or audit for config plus audit logs" default:"config"`
+	Passphrase string `usage:"Passphrase to encrypt the backup with" env:"OBOT_SERVER_BACKUP_PASSPHRASE"`
+}
+
+// ServerRestore restores an archive into an Obot instance's database. The
+// instance must not be running.
+type ServerRestore struct {
+	DSN        string `usage:"Database dsn in driver://connection_string format" default:"sqlite://file:obot.db?_journal=WAL&cache=shared&_busy_timeout=30000" env:"OBOT_SERVER_DSN"`
+	Scope      string `usage:"Restore only part of the backup: config leaves out audit logs (default: everything in the backup)"`
+	Passphrase string `usage:"Passphrase the backup was encrypted with" env:"OBOT_SERVER_BACKUP_PASSPHRASE"`
+	DryRun     bool   `usage:"Decrypt and verify the backup, and check the database for conflicts, without restoring anything"`
+	Overwrite  bool   `usage:"Delete what is in the database before restoring"`
+	JSON       bool   `usage:"Print the result as JSON"`
+}
+
+func (s *ServerBackup) Customize(cmd *cobra.Command) {
+	cmd.Use = "backup"
+	cmd.Short = "Back up Obot's database to an encrypted archive"
+	cmd.Args = cobra.NoArgs
+}
+
+func (s *ServerBackup) Run(cmd *cobra.Command, _ []string) error {
+	ctx, cancel := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	stores, err := openStores(ctx, s.DSN)
+	if err != nil {
+		return err
+	}
+	defer stores.Gateway.Close()
+
+	opts := backup.Options{Passphrase: s.Passphrase, Scope: backup.Scope(s.Scope)}
+	var manifest backup.Manifest
+	if s.Output == "-" {
+		manifest, err = backup.Write(ctx, cmd.OutOrStdout(), stores, opts)
+	} else {
+		manifest, err = writeBackupFile(ctx, s.Output, stores, opts)
+	}
+	if err != nil {
+		return err
+	}
+
+	var objects, rows int
+	for _, n := range manifest.Storage {
+		objects += n
+	}
+	for _, n := range manifest.Gateway {
+		rows += n
+	}
+	fmt.Fprintf(cmd.ErrOrStderr(), "Backed up %d storage objects and %d gateway rows (scope %s)\n", objects, rows, manifest.Scope)
+	return nil
+}
+
+// writeBackupFile writes the archive next to path and moves it into place once
+// it is complete, so a failed backup never leaves a partial archive behind.
+func writeBackupFile(ctx context.Context, path string, stores backup.Stores, opts backup.Options) (backup.Manifest, error) {
+	f, err := os.CreateTemp(filepath.Dir(path), ".obot-backup-*")
+	if err != nil {
+		return backup.Manifest{}, err
+	}
+	defer os.Remove(f.Name())
+
+	manifest, err := backup.Write(ctx, f, stores, opts)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return backup.Manifest{}, err
+	}
+	return manifest, os.Rename(f.Name(), path)
+}
+
+func (s *ServerRestore) Customize(cmd *cobra.Command) {
+	cmd.Use = "restore <file>"
+	cmd.Short = "Restore Obot's database from an encrypted archive"
+	cmd.Long = "Restore Obot's database from an archive written by 'obot server backup'. Use - to read the archive from standard input. " +
+		"The database may use a different driver than the one the backup was taken from, such as to move from SQLite to PostgreSQL."
+	cmd.Args = cobra.ExactArgs(1)
+}
+
+func (s *ServerRestore) Run(cmd *cobra.Command, args []string) error {
+	ctx, cancel := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	var in io.Reader = cmd.InOrStdin()
+	if args[0] != "-" {
+		f, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+
+	stores, err := openStores(ctx, s.DSN)
+	if err != nil {
+		return err
+	}
+	defer stores.Gateway.Close()
+
+	result, err := backup.Restore(ctx, in, stores, backup.RestoreOptions{
+		Passphrase: s.Passphrase,
+		Scope:      backup.Scope(s.Scope),
+		DryRun:     s.DryRun,
+		Overwrite:  s.Overwrite,
+	})
+	if err != nil {
+		if errors.Is(err, backup.ErrDecrypt) && s.Passphrase == "" {
+			return fmt.Errorf("%w (set --passphrase or OBOT_SERVER_BACKUP_PASSPHRASE)", err)
+		}
+		return err
+	}
+
+	if s.JSON {
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	}
+
+	out := cmd.OutOrStdout()
+	verb := "Restored"
+	if result.DryRun {
+		verb = "Verified"
+	}
+	fmt.Fprintf(out, "%s backup taken %s by Obot %s from %s (scope %s)\n", verb,
+		result.Manifest.CreatedAt.Format("2006-01-02 15:04:05 MST"), result.Manifest.ObotVersion, result.Manifest.Dialect, result.Manifest.Scope)
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "STORE\tNAME\tCOUNT")
+	for _, name := range slices.Sorted(maps.Keys(result.Storage)) {
+		fmt.Fprintf(w, "storage\t%s\t%d\n", name, result.Storage[name])
+	}
+	for _, name := range slices.Sorted(maps.Keys(result.Gateway)) {
+		fmt.Fprintf(w, "gateway\t%s\t%d\n", name, result.Gateway[name])
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if len(result.Migrations) > 0 {
+		fmt.Fprintf(out, "Applied migrations the backup predates: %s\n", strings.Join(result.Migrations, ", "))
+	}
+	return nil
+}
+
+// openStores opens the storage API and gateway database the way the server
+// does, without starting anything else.
+func openStores(ctx context.Context, dsn string) (backup.Stores, error) {
+	dsn = strings.Replace(dsn, "postgresql://", "postgres://", 1)
+	storageClient, _, dbAccess, _, err := storage.Start(ctx, storageservices.Config{DSN: dsn})
+	if err != nil {
+		return backup.Stores{}, fmt.Errorf("failed to open the database: %w", err)
+	}
+	gatewayDB, err := db.New(dbAccess.DB, dbAccess.SQLDB, true)
+	if err != nil {
+		return backup.Stores{}, err
+	}
+	return backup.Stores{Storage: storageClient, Gateway: gatewayDB}, nil
+}
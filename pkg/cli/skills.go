@@ -12,6 +12,7 @@ import (
 
 	"github.com/obot-platform/cmd"
 	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/obot-platform/obot/pkg/cli/internal/localconfig"
 	"github.com/obot-platform/obot/pkg/localagents"
 	"github.com/spf13/cobra"
 )
@@ -33,11 +34,53 @@ type SkillsInstall struct {
 	PromptConfig
 
 	Destination string `usage:"Target skills directory, such as ~/.claude/skills or ~/.agents/skills"`
+	Revision    string `usage:"Install the skill as indexed at this commit SHA or unique prefix (see 'obot skills revisions')"`
+	Force       bool   `usage:"Overwrite an existing copy that was modified locally or not installed by obot"`
 	JSON        bool   `usage:"Print results as JSON"`
 
 	root *Obot
 }
 
+type SkillsRevisions struct {
+	PromptConfig
+
+	JSON bool `usage:"Print results as JSON"`
+
+	root *Obot
+}
+
+type SkillsOutdated struct {
+	PromptConfig
+
+	Destination string `usage:"Skills directory to check, such as ~/.claude/skills or ~/.agents/skills"`
+	JSON        bool   `usage:"Print results as JSON"`
+
+	root *Obot
+}
+
+type skillsOutdatedOutput struct {
+	Results []skillsOutdatedResult `json:"results"`
+}
+
+type skillsOutdatedResult struct {
+	Name            string `json:"name"`
+	Path            string `json:"path"`
+	SkillID         string `json:"skillID,omitempty"`
+	InstalledCommit string `json:"installedCommit,omitempty"`
+	InstalledHash   string `json:"installedHash,omitempty"`
+	LatestCommit    string `json:"latestCommit,omitempty"`
+	LatestHash      string `json:"latestHash,omitempty"`
+	Status          string `json:"status"`
+}
+
+const (
+	skillStatusUpToDate   = "up-to-date"
+	skillStatusOutdated   = "outdated"
+	skillStatusUnknown    = "unknown"
+	skillStatusNotFound   = "not-found"
+	skillStatusNotManaged = "not-managed"
+)
+
 type skillsInstallOutput struct {
 	Results []skillsInstallResult `json:"results"`
 }
@@ -55,6 +98,12 @@ func (s *Skills) Customize(c *cobra.Command) {
 	c.Args = cobra.NoArgs
 	c.AddCommand(cmd.Command(&SkillsSearch{root: s.root}))
 	c.AddCommand(cmd.Command(&SkillsInstall{root: s.root}))
+	c.AddCommand(cmd.Command(&SkillsRevisions{root: s.root}))
+	c.AddCommand(cmd.Command(&SkillsOutdated{root: s.root}))
+	c.AddCommand(cmd.Command(&SkillsList{root: s.root}))
+	c.AddCommand(cmd.Command(&SkillsUpdate{root: s.root}))
+	c.AddCommand(cmd.Command(&SkillsUninstall{root: s.root}))
+	c.AddCommand(cmd.Command(&SkillsPublish{root: s.root}))
 }
 
 func (s *Skills) Run(cmd *cobra.Command, _ []string) error {
@@ -161,28 +210,162 @@ func (s *SkillsInstall) Run(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("skill %q has no ID", skillID)
 	}
 
-	data, err := s.root.Client.DownloadSkill(cmd.Context(), skill.ID)
+	var commitSHA string
+	if revision := strings.TrimSpace(s.Revision); revision != "" {
+		rev, err := resolveSkillRevision(cmd, s.root, skill.ID, revision)
+		if err != nil {
+			return err
+		}
+		commitSHA = rev.CommitSHA
+	}
+
+	lock, err := localconfig.LoadSkillLock()
 	if err != nil {
 		return err
 	}
-	archive, err := localagents.ParseSkillArchive(data, fallbackSkillName(skill))
+	name, installed, err := installTrackedSkill(cmd, s.root, &lock, destination, skill, commitSHA, s.Force)
 	if err != nil {
 		return err
 	}
+	if err := localconfig.SaveSkillLock(lock); err != nil {
+		return err
+	}
 
 	output := skillsInstallOutput{
-		Results: make([]skillsInstallResult, 0, 1),
+		Results: []skillsInstallResult{{
+			Destination: destination,
+			Mode:        "direct",
+			Installed:   installed,
+			Message:     fmt.Sprintf("Installed %s to %s", name, destination),
+		}},
+	}
+
+	if s.JSON {
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		return enc.Encode(output)
+	}
+
+	for _, result := range output.Results {
+		fmt.Fprintln(cmd.OutOrStdout(), result.Message)
+	}
+	return nil
+}
+
+// resolveSkillRevision finds the revision whose commit SHA equals or uniquely
+// starts with the requested value.
+func resolveSkillRevision(cmd *cobra.Command, root *Obot, skillID, revision string) (types.SkillRevision, error) {
+	revisions, err := root.Client.ListSkillRevisions(cmd.Context(), skillID)
+	if err != nil {
+		return types.SkillRevision{}, err
+	}
+
+	var matches []types.SkillRevision
+	for _, rev := range revisions.Items {
+		if rev.CommitSHA == revision {
+			matches = []types.SkillRevision{rev}
+			break
+		}
+		if strings.HasPrefix(rev.CommitSHA, revision) {
+			matches = append(matches, rev)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return types.SkillRevision{}, fmt.Errorf("revision %q not found for skill %q", revision, skillID)
+	case 1:
+	default:
+		return types.SkillRevision{}, fmt.Errorf("revision %q is ambiguous for skill %q", revision, skillID)
+	}
+	if !matches[0].Installable {
+		return types.SkillRevision{}, fmt.Errorf("revision %s of skill %q is blocked by its security scan findings", shortSHA(matches[0].CommitSHA), skillID)
+	}
+	return matches[0], nil
+}
+
+func (s *SkillsRevisions) Customize(cmd *cobra.Command) {
+	cmd.Use = "revisions <skill-id>"
+	cmd.Short = "List the indexed revisions of an Obot skill"
+	cmd.Args = cobra.ExactArgs(1)
+}
+
+func (s *SkillsRevisions) Run(cmd *cobra.Command, args []string) error {
+	if s.root == nil || s.root.Client == nil {
+		return fmt.Errorf("skills revisions: no API client configured")
+	}
+
+	skillID := strings.TrimSpace(args[0])
+	result, err := s.root.Client.ListSkillRevisions(cmd.Context(), skillID)
+	if err != nil {
+		if isHTTPNotFound(err) {
+			return fmt.Errorf("skill %q not found", skillID)
+		}
+		return err
+	}
+
+	if s.JSON {
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	}
+
+	if len(result.Items) == 0 {
+		fmt.Fprintln(cmd.OutOrStdout(), "No revisions found")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "COMMIT\tINSTALL HASH\tINDEXED\tSTATUS")
+	for _, rev := range result.Items {
+		status := ""
+		switch {
+		case rev.Current:
+			status = "current"
+		case !rev.Installable:
+			status = "blocked"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
+			shortSHA(rev.CommitSHA),
+			shortSHA(rev.InstallHash),
+			rev.IndexedAt.GetTime().UTC().Format("2006-01-02 15:04"),
+			status,
+		)
 	}
-	name, installed, err := localagents.InstallSkillToRoot(cmd.Context(), destination, archive)
+	return w.Flush()
+}
+
+func (s *SkillsOutdated) Customize(cmd *cobra.Command) {
+	cmd.Use = "outdated"
+	cmd.Short = "Report installed Obot skills that differ from the latest version on the server"
+	cmd.Args = cobra.NoArgs
+}
+
+func (s *SkillsOutdated) Run(cmd *cobra.Command, _ []string) error {
+	if s.root == nil || s.root.Client == nil {
+		return fmt.Errorf("skills outdated: no API client configured")
+	}
+
+	destination, err := resolveSkillsDestination(strings.TrimSpace(s.Destination))
 	if err != nil {
 		return err
 	}
-	output.Results = append(output.Results, skillsInstallResult{
-		Destination: destination,
-		Mode:        "direct",
-		Installed:   installed,
-		Message:     fmt.Sprintf("Installed %s to %s", name, destination),
-	})
+
+	installed, err := localagents.ReadInstalledSkills(destination)
+	if err != nil {
+		return err
+	}
+
+	output := skillsOutdatedOutput{
+		Results: make([]skillsOutdatedResult, 0, len(installed)),
+	}
+	for _, local := range installed {
+		result, err := compareInstalledSkill(cmd, s.root, local)
+		if err != nil {
+			return err
+		}
+		output.Results = append(output.Results, result)
+	}
 
 	if s.JSON {
 		enc := json.NewEncoder(cmd.OutOrStdout())
@@ -190,10 +373,67 @@ func (s *SkillsInstall) Run(cmd *cobra.Command, args []string) error {
 		return enc.Encode(output)
 	}
 
+	if len(output.Results) == 0 {
+		fmt.Fprintf(cmd.OutOrStdout(), "No skills installed in %s\n", destination)
+		return nil
+	}
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tSKILL ID\tINSTALLED\tLATEST\tSTATUS")
 	for _, result := range output.Results {
-		fmt.Fprintln(cmd.OutOrStdout(), result.Message)
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+			tableCell(result.Name),
+			tableCell(result.SkillID),
+			shortSHA(result.InstalledCommit),
+			shortSHA(result.LatestCommit),
+			result.Status,
+		)
 	}
-	return nil
+	return w.Flush()
+}
+
+// compareInstalledSkill compares a local skill with the server's latest indexed
+// version using the install hash recorded at download time.
+func compareInstalledSkill(cmd *cobra.Command, root *Obot, local localagents.InstalledSkill) (skillsOutdatedResult, error) {
+	result := skillsOutdatedResult{
+		Name:            local.Name,
+		Path:            local.Path,
+		SkillID:         local.SkillID,
+		InstalledCommit: local.CommitSHA,
+		InstalledHash:   local.InstallHash,
+	}
+	if local.SkillID == "" {
+		result.Status = skillStatusNotManaged
+		return result, nil
+	}
+
+	latest, err := root.Client.GetSkill(cmd.Context(), local.SkillID)
+	if err != nil {
+		if isHTTPNotFound(err) {
+			result.Status = skillStatusNotFound
+			return result, nil
+		}
+		return result, err
+	}
+
+	result.LatestCommit = latest.CommitSHA
+	result.LatestHash = latest.InstallHash
+	switch {
+	case local.InstallHash == "" || latest.InstallHash == "":
+		result.Status = skillStatusUnknown
+	case local.InstallHash == latest.InstallHash:
+		result.Status = skillStatusUpToDate
+	default:
+		result.Status = skillStatusOutdated
+	}
+	return result, nil
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > 12 {
+		return sha[:12]
+	}
+	return sha
 }
 
 func resolveSkillsDestination(destination string) (string, error) {
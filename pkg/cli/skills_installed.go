@@ -0,0 +1,443 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/obot-platform/obot/pkg/cli/internal/localconfig"
+	"github.com/obot-platform/obot/pkg/localagents"
+	"github.com/spf13/cobra"
+)
+
+const (
+	skillStatusModified = "modified"
+	skillStatusMissing  = "missing"
+)
+
+type SkillsList struct {
+	PromptConfig
+
+	Destination string `usage:"Only list skills installed in this skills directory"`
+	JSON        bool   `usage:"Print results as JSON"`
+
+	root *Obot
+}
+
+type SkillsUpdate struct {
+	PromptConfig
+
+	Destination string `usage:"Only update skills installed in this skills directory"`
+	Force       bool   `usage:"Overwrite skills that were modified locally"`
+	JSON        bool   `usage:"Print results as JSON"`
+
+	root *Obot
+}
+
+type SkillsUninstall struct {
+	PromptConfig
+
+	Destination string `usage:"Only uninstall the copy in this skills directory"`
+	Force       bool   `usage:"Remove the skill even if it was modified locally"`
+	JSON        bool   `usage:"Print results as JSON"`
+
+	root *Obot
+}
+
+type skillsInstalledOutput struct {
+	Results []skillsInstalledResult `json:"results"`
+}
+
+type skillsInstalledResult struct {
+	Name            string `json:"name"`
+	SkillID         string `json:"skillID"`
+	Agent           string `json:"agent,omitempty"`
+	Path            string `json:"path"`
+	InstalledCommit string `json:"installedCommit,omitempty"`
+	LatestCommit    string `json:"latestCommit,omitempty"`
+	Status          string `json:"status"`
+	Message         string `json:"message,omitempty"`
+}
+
+func (s *SkillsList) Customize(cmd *cobra.Command) {
+	cmd.Use = "list"
+	cmd.Short = "List Obot skills installed by this CLI and whether they are current"
+	cmd.Args = cobra.NoArgs
+}
+
+func (s *SkillsList) Run(cmd *cobra.Command, _ []string) error {
+	if s.root == nil || s.root.Client == nil {
+		return fmt.Errorf("skills list: no API client configured")
+	}
+
+	lock, err := localconfig.LoadSkillLock()
+	if err != nil {
+		return err
+	}
+	entries, err := filterLockedSkills(lock, s.Destination, nil)
+	if err != nil {
+		return err
+	}
+
+	output := skillsInstalledOutput{
+		Results: make([]skillsInstalledResult, 0, len(entries)),
+	}
+	for _, entry := range entries {
+		result, _, err := lockedSkillStatus(cmd, s.root, entry)
+		if err != nil {
+			return err
+		}
+		output.Results = append(output.Results, result)
+	}
+
+	if s.JSON {
+		return writeSkillsInstalledJSON(cmd, output)
+	}
+	if len(output.Results) == 0 {
+		fmt.Fprintln(cmd.OutOrStdout(), "No skills installed")
+		return nil
+	}
+	return writeSkillsInstalledTable(cmd, output)
+}
+
+func (s *SkillsUpdate) Customize(cmd *cobra.Command) {
+	cmd.Use = "update [skill-id|name...]"
+	cmd.Short = "Update installed Obot skills to the latest version on the server"
+}
+
+func (s *SkillsUpdate) Run(cmd *cobra.Command, args []string) error {
+	if s.root == nil || s.root.Client == nil {
+		return fmt.Errorf("skills update: no API client configured")
+	}
+
+	lock, err := localconfig.LoadSkillLock()
+	if err != nil {
+		return err
+	}
+	entries, err := filterLockedSkills(lock, s.Destination, args)
+	if err != nil {
+		return err
+	}
+	if len(args) > 0 && len(entries) == 0 {
+		return fmt.Errorf("no installed skills match %s", strings.Join(args, ", "))
+	}
+
+	var (
+		output  = skillsInstalledOutput{Results: make([]skillsInstalledResult, 0, len(entries))}
+		skipped int
+	)
+	for _, entry := range entries {
+		result, latest, err := lockedSkillStatus(cmd, s.root, entry)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case result.Status == skillStatusModified && !s.Force:
+			skipped++
+			result.Message = fmt.Sprintf("Skipped %s: modified locally", entry.Name)
+		case result.Status == skillStatusNotFound:
+			result.Message = fmt.Sprintf("Skipped %s: no longer available on the server", entry.Name)
+		case result.Status == skillStatusUpToDate:
+			result.Message = fmt.Sprintf("%s is up to date", entry.Name)
+		default:
+			// Outdated, missing, or modified with --force.
+			if _, _, err := installTrackedSkill(cmd, s.root, &lock, entry.Root, latest, "", s.Force); err != nil {
+				return fmt.Errorf("update %s: %w", entry.Name, err)
+			}
+			// Save as each skill is rewritten, so a later failure does not
+			// leave this one recorded with its old hash.
+			if err := localconfig.SaveSkillLock(lock); err != nil {
+				return err
+			}
+			result.Status = skillStatusUpToDate
+			result.InstalledCommit = latest.CommitSHA
+			result.Message = fmt.Sprintf("Updated %s in %s", entry.Name, entry.Root)
+		}
+		output.Results = append(output.Results, result)
+	}
+
+	if s.JSON {
+		if err := writeSkillsInstalledJSON(cmd, output); err != nil {
+			return err
+		}
+	} else {
+		if len(output.Results) == 0 {
+			fmt.Fprintln(cmd.OutOrStdout(), "No skills installed")
+		}
+		for _, result := range output.Results {
+			fmt.Fprintln(cmd.OutOrStdout(), result.Message)
+		}
+	}
+
+	if skipped > 0 {
+		return fmt.Errorf("%d skill(s) were modified locally and were not updated; rerun with --force to overwrite local changes", skipped)
+	}
+	return nil
+}
+
+func (s *SkillsUninstall) Customize(cmd *cobra.Command) {
+	cmd.Use = "uninstall <skill-id|name>"
+	cmd.Short = "Remove an Obot skill installed by this CLI"
+	cmd.Args = cobra.ExactArgs(1)
+}
+
+func (s *SkillsUninstall) Run(cmd *cobra.Command, args []string) error {
+	lock, err := localconfig.LoadSkillLock()
+	if err != nil {
+		return err
+	}
+	entries, err := filterLockedSkills(lock, s.Destination, args)
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		return fmt.Errorf("skill %q is not installed", strings.TrimSpace(args[0]))
+	}
+
+	// Check every copy before removing any so a refusal leaves nothing half done.
+	for _, entry := range entries {
+		status, err := lockedSkillLocalStatus(entry)
+		if err != nil {
+			return err
+		}
+		if status == skillStatusModified && !s.Force {
+			return fmt.Errorf("%s was modified locally; rerun with --force to remove it anyway", entry.Path)
+		}
+	}
+
+	output := skillsInstalledOutput{
+		Results: make([]skillsInstalledResult, 0, len(entries)),
+	}
+	for _, entry := range entries {
+		if filepath.Dir(entry.Path) != entry.Root {
+			return fmt.Errorf("refusing to remove %s: not directly under %s", entry.Path, entry.Root)
+		}
+		if err := os.RemoveAll(entry.Path); err != nil {
+			return fmt.Errorf("remove %s: %w", entry.Path, err)
+		}
+		lock.Remove(entry.Path)
+
+		result := lockedSkillResult(entry)
+		result.Message = fmt.Sprintf("Uninstalled %s from %s", entry.Name, entry.Root)
+		output.Results = append(output.Results, result)
+	}
+
+	if err := localconfig.SaveSkillLock(lock); err != nil {
+		return err
+	}
+
+	if s.JSON {
+		return writeSkillsInstalledJSON(cmd, output)
+	}
+	for _, result := range output.Results {
+		fmt.Fprintln(cmd.OutOrStdout(), result.Message)
+	}
+	return nil
+}
+
+// installTrackedSkill downloads a skill into skillsRoot and records it in the
+// lock. An existing tracked copy that was modified locally, or an existing
+// directory that the lock does not track, is only replaced when force is set.
+func installTrackedSkill(cmd *cobra.Command, root *Obot, lock *localconfig.SkillLock, skillsRoot string, skill types.Skill, commitSHA string, force bool) (string, []string, error) {
+	data, err := root.Client.DownloadSkillRevision(cmd.Context(), skill.ID, commitSHA)
+	if err != nil {
+		return "", nil, err
+	}
+	archive, err := localagents.ParseSkillArchive(data, fallbackSkillName(skill))
+	if err != nil {
+		return "", nil, err
+	}
+
+	name, err := archive.InstallName()
+	if err != nil {
+		return "", nil, err
+	}
+	if existing, ok := lock.Find(filepath.Join(skillsRoot, name)); ok && !force {
+		status, err := lockedSkillLocalStatus(existing)
+		if err != nil {
+			return "", nil, err
+		}
+		if status == skillStatusModified {
+			return "", nil, fmt.Errorf("%s was modified locally; rerun with --force to overwrite it", existing.Path)
+		}
+	} else if !ok && !force {
+		target := filepath.Join(skillsRoot, name)
+		if _, err := os.Stat(target); err == nil {
+			return "", nil, fmt.Errorf("%s already exists and was not installed by obot; rerun with --force to overwrite it", target)
+		} else if !os.IsNotExist(err) {
+			return "", nil, err
+		}
+	}
+
+	name, installed, err := localagents.InstallSkillToRoot(cmd.Context(), skillsRoot, archive)
+	if err != nil {
+		return "", nil, err
+	}
+
+	target := filepath.Join(skillsRoot, name)
+	contentHash, err := localagents.HashSkillDir(target)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if commitSHA == "" {
+		commitSHA = skill.CommitSHA
+	}
+	installHash := skill.InstallHash
+	if local, err := localagents.ReadInstalledSkills(skillsRoot); err == nil {
+		// Prefer the values injected into SKILL.md, which reflect the revision
+		// that was actually downloaded.
+		for _, installedSkill := range local {
+			if installedSkill.Path == target && installedSkill.InstallHash != "" {
+				commitSHA = installedSkill.CommitSHA
+				installHash = installedSkill.InstallHash
+			}
+		}
+	}
+
+	var agent string
+	if home, err := os.UserHomeDir(); err == nil && home != "" {
+		agent = localagents.SkillsRootAgentID(home, skillsRoot)
+	}
+
+	lock.Put(localconfig.LockedSkill{
+		SkillID:     skill.ID,
+		Name:        name,
+		Agent:       agent,
+		Root:        skillsRoot,
+		Path:        target,
+		CommitSHA:   commitSHA,
+		InstallHash: installHash,
+		ContentHash: contentHash,
+		InstalledAt: time.Now().UTC(),
+	})
+	return name, installed, nil
+}
+
+// filterLockedSkills returns the lock entries under destination (if set) whose
+// skill ID or name matches one of selectors (if any).
+func filterLockedSkills(lock localconfig.SkillLock, destination string, selectors []string) ([]localconfig.LockedSkill, error) {
+	var root string
+	if destination = strings.TrimSpace(destination); destination != "" {
+		var err error
+		if root, err = resolveSkillsDestination(destination); err != nil {
+			return nil, err
+		}
+	}
+
+	var result []localconfig.LockedSkill
+	for _, entry := range lock.Skills {
+		if root != "" && entry.Root != root {
+			continue
+		}
+		if len(selectors) > 0 && !matchesLockedSkill(entry, selectors) {
+			continue
+		}
+		result = append(result, entry)
+	}
+	return result, nil
+}
+
+func matchesLockedSkill(entry localconfig.LockedSkill, selectors []string) bool {
+	for _, selector := range selectors {
+		selector = strings.TrimSpace(selector)
+		if selector == entry.SkillID || selector == entry.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// lockedSkillLocalStatus compares the files on disk with the lock entry. It
+// returns skillStatusMissing, skillStatusModified, or an empty string when the
+// files are unchanged.
+func lockedSkillLocalStatus(entry localconfig.LockedSkill) (string, error) {
+	if _, err := os.Stat(entry.Path); errors.Is(err, fs.ErrNotExist) {
+		return skillStatusMissing, nil
+	} else if err != nil {
+		return "", err
+	}
+
+	hash, err := localagents.HashSkillDir(entry.Path)
+	if err != nil {
+		return "", err
+	}
+	if hash != entry.ContentHash {
+		return skillStatusModified, nil
+	}
+	return "", nil
+}
+
+// lockedSkillStatus combines the local state of an installed skill with the
+// latest version on the server. The returned skill is the server's copy.
+func lockedSkillStatus(cmd *cobra.Command, root *Obot, entry localconfig.LockedSkill) (skillsInstalledResult, types.Skill, error) {
+	result := lockedSkillResult(entry)
+
+	localStatus, err := lockedSkillLocalStatus(entry)
+	if err != nil {
+		return result, types.Skill{}, err
+	}
+
+	latest, err := root.Client.GetSkill(cmd.Context(), entry.SkillID)
+	if err != nil {
+		if isHTTPNotFound(err) {
+			result.Status = skillStatusNotFound
+			return result, types.Skill{}, nil
+		}
+		return result, types.Skill{}, err
+	}
+	result.LatestCommit = latest.CommitSHA
+
+	switch {
+	case localStatus != "":
+		result.Status = localStatus
+	case entry.InstallHash == "" || latest.InstallHash == "":
+		result.Status = skillStatusUnknown
+	case entry.InstallHash == latest.InstallHash:
+		result.Status = skillStatusUpToDate
+	default:
+		result.Status = skillStatusOutdated
+	}
+	return result, latest, nil
+}
+
+func lockedSkillResult(entry localconfig.LockedSkill) skillsInstalledResult {
+	return skillsInstalledResult{
+		Name:            entry.Name,
+		SkillID:         entry.SkillID,
+		Agent:           entry.Agent,
+		Path:            entry.Path,
+		InstalledCommit: entry.CommitSHA,
+	}
+}
+
+func writeSkillsInstalledJSON(cmd *cobra.Command, output skillsInstalledOutput) error {
+	enc := json.NewEncoder(cmd.OutOrStdout())
+	enc.SetIndent("", "  ")
+	return enc.Encode(output)
+}
+
+func writeSkillsInstalledTable(cmd *cobra.Command, output skillsInstalledOutput) error {
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tSKILL ID\tAGENT\tINSTALLED\tLATEST\tSTATUS\tPATH")
+	for _, result := range output.Results {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			tableCell(result.Name),
+			tableCell(result.SkillID),
+			tableCell(result.Agent),
+			shortSHA(result.InstalledCommit),
+			shortSHA(result.LatestCommit),
+			result.Status,
+			result.Path,
+		)
+	}
+	return w.Flush()
+}
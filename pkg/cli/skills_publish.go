@@ -0,0 +1,175 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/obot-platform/obot/apiclient"
+	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/obot-platform/obot/pkg/skillformat"
+	"github.com/spf13/cobra"
+)
+
+type SkillsPublish struct {
+	PromptConfig
+
+	Description string   `usage:"Description of the published version; defaults to the SKILL.md description"`
+	User        []string `usage:"User ID allowed to install this version; may be repeated"`
+	Group       []string `usage:"Group ID allowed to install this version; may be repeated"`
+	DryRun      bool     `usage:"Validate and package the skill without publishing it"`
+	JSON        bool     `usage:"Print results as JSON"`
+
+	root *Obot
+}
+
+type skillsPublishOutput struct {
+	Name        string                   `json:"name"`
+	Path        string                   `json:"path"`
+	Size        int                      `json:"size"`
+	DryRun      bool                     `json:"dryRun,omitempty"`
+	Subjects    []types.Subject          `json:"subjects,omitempty"`
+	Artifact    *types.PublishedArtifact `json:"artifact,omitempty"`
+	Version     int                      `json:"version,omitempty"`
+	Description string                   `json:"description,omitempty"`
+}
+
+func (s *SkillsPublish) Customize(cmd *cobra.Command) {
+	cmd.Use = "publish <dir>"
+	cmd.Short = "Publish a local skill directory to Obot"
+	cmd.Long = `Validate a local skill directory, package it, and publish it to Obot.
+
+Publishing a skill that you have already published adds a new version. Use
+--user and --group to limit which users and groups can install the new version.`
+	cmd.Args = cobra.ExactArgs(1)
+}
+
+func (s *SkillsPublish) Run(cmd *cobra.Command, args []string) error {
+	dir, err := filepath.Abs(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", args[0], err)
+	}
+	if err := skillformat.ValidateSkillDirectory(dir); err != nil {
+		return fmt.Errorf("invalid skill directory %s: %w", dir, err)
+	}
+	content, err := os.ReadFile(filepath.Join(dir, skillformat.SkillMainFile))
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", skillformat.SkillMainFile, err)
+	}
+	frontmatter, _, err := skillformat.ParseFrontmatter(string(content))
+	if err != nil {
+		return fmt.Errorf("invalid %s: %w", skillformat.SkillMainFile, err)
+	}
+
+	description := strings.TrimSpace(s.Description)
+	if description != "" {
+		if err := skillformat.ValidateDescription(description); err != nil {
+			return err
+		}
+	}
+
+	subjects, err := skillPublishSubjects(s.User, s.Group)
+	if err != nil {
+		return err
+	}
+
+	data, err := skillformat.ZIPDirectory(dir)
+	if err != nil {
+		return fmt.Errorf("failed to package %s: %w", dir, err)
+	}
+
+	output := skillsPublishOutput{
+		Name:        frontmatter.Name,
+		Path:        dir,
+		Size:        len(data),
+		DryRun:      s.DryRun,
+		Subjects:    subjects,
+		Description: description,
+	}
+
+	if !s.DryRun {
+		if s.root == nil || s.root.Client == nil {
+			return fmt.Errorf("skills publish: no API client configured")
+		}
+
+		artifact, err := s.publish(cmd.Context(), data, description, subjects)
+		if err != nil {
+			return err
+		}
+		output.Artifact = &artifact
+		output.Version = artifact.LatestVersion
+	}
+
+	if s.JSON {
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		return enc.Encode(output)
+	}
+
+	out := cmd.OutOrStdout()
+	if s.DryRun {
+		fmt.Fprintf(out, "Validated skill %s (%d bytes packaged); not published (dry run)\n", output.Name, output.Size)
+	} else {
+		fmt.Fprintf(out, "Published skill %s version %d (%s)\n", output.Name, output.Version, output.Artifact.ID)
+	}
+	if len(subjects) > 0 {
+		names := make([]string, 0, len(subjects))
+		for _, subject := range subjects {
+			names = append(names, string(subject.Type)+":"+subject.ID)
+		}
+		fmt.Fprintf(out, "Access: %s\n", strings.Join(names, ", "))
+	}
+	return nil
+}
+
+func (s *SkillsPublish) publish(ctx context.Context, data []byte, description string, subjects []types.Subject) (types.PublishedArtifact, error) {
+	client, err := publishedArtifactsAPIClient(ctx, s.root.Client)
+	if err != nil {
+		return types.PublishedArtifact{}, err
+	}
+
+	artifact, err := client.PublishArtifact(ctx, data, apiclient.PublishArtifactOptions{
+		ArtifactType: types.PublishedArtifactTypeSkill,
+		Description:  description,
+		Subjects:     subjects,
+	})
+	if err != nil {
+		return types.PublishedArtifact{}, fmt.Errorf("failed to publish skill: %w", err)
+	}
+	return artifact, nil
+}
+
+// publishedArtifactsAPIClient returns a client whose token can manage
+// published artifacts, upgrading the stored CLI token if necessary.
+func publishedArtifactsAPIClient(ctx context.Context, client *apiclient.Client) (*apiclient.Client, error) {
+	token, err := client.GetToken(ctx, apiclient.TokenFetchOptions{
+		Scopes: append(types.DefaultCLIAPIKeyScopes(), types.APIKeyScopePublishedArtifacts),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get an API token: %w", err)
+	}
+	return client.WithToken(token), nil
+}
+
+func skillPublishSubjects(users, groups []string) ([]types.Subject, error) {
+	var subjects []types.Subject
+	for _, ids := range []struct {
+		subjectType types.SubjectType
+		values      []string
+	}{
+		{types.SubjectTypeUser, users},
+		{types.SubjectTypeGroup, groups},
+	} {
+		for _, id := range ids.values {
+			id = strings.TrimSpace(id)
+			if id == "" {
+				return nil, fmt.Errorf("%s ID must not be empty", ids.subjectType)
+			}
+			subjects = append(subjects, types.Subject{Type: ids.subjectType, ID: id})
+		}
+	}
+	return subjects, nil
+}
@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -12,9 +13,11 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/adrg/xdg"
 	"github.com/obot-platform/cmd"
 	"github.com/obot-platform/obot/apiclient"
 	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/obot-platform/obot/pkg/cli/internal/localconfig"
 	"github.com/obot-platform/obot/pkg/skillformat"
 )
 
@@ -23,7 +26,12 @@ type skillInstallTestResponse struct {
 	Name        string
 	DisplayName string
 	Description string
+	CommitSHA   string
+	InstallHash string
 	Download    []byte
+	Revisions   []types.SkillRevision
+	// RevisionDownloads holds archives served for ?revision=<commitSHA>.
+	RevisionDownloads map[string][]byte
 }
 
 func TestSkillsSearchCallsAPIWithQueryAndLimit(t *testing.T) {
@@ -108,7 +116,7 @@ func TestSkillsSearchJSONMode(t *testing.T) {
 }
 
 func TestSkillsInstallExactIDInstallsClaudeCode(t *testing.T) {
-	home := useSetupTestHome(t)
+	home := useSkillsTestHome(t)
 	server := skillInstallTestServer(t, []skillInstallTestResponse{{
 		ID:       "sk1",
 		Name:     "github-review",
@@ -128,7 +136,7 @@ func TestSkillsInstallExactIDInstallsClaudeCode(t *testing.T) {
 }
 
 func TestSkillsInstallExactIDInstallsSharedAgents(t *testing.T) {
-	home := useSetupTestHome(t)
+	home := useSkillsTestHome(t)
 	server := skillInstallTestServer(t, []skillInstallTestResponse{{
 		ID:       "sk1",
 		Name:     "github-review",
@@ -161,7 +169,7 @@ func TestSkillsInstallNoMatches(t *testing.T) {
 }
 
 func TestSkillsInstallRequiresDestination(t *testing.T) {
-	useSetupTestHome(t)
+	useSkillsTestHome(t)
 	server := skillInstallTestServer(t, []skillInstallTestResponse{{
 		ID:       "sk1",
 		Name:     "github-review",
@@ -179,7 +187,7 @@ func TestSkillsInstallRequiresDestination(t *testing.T) {
 }
 
 func TestSkillsInstallJSONMode(t *testing.T) {
-	home := useSetupTestHome(t)
+	home := useSkillsTestHome(t)
 	server := skillInstallTestServer(t, []skillInstallTestResponse{{
 		ID:       "sk1",
 		Name:     "github-review",
@@ -218,6 +226,533 @@ func TestNewIncludesSkillsCommands(t *testing.T) {
 	if _, _, err := root.Find([]string{"skills", "install"}); err != nil {
 		t.Fatalf("skills install command was not registered: %v", err)
 	}
+	if _, _, err := root.Find([]string{"skills", "revisions"}); err != nil {
+		t.Fatalf("skills revisions command was not registered: %v", err)
+	}
+	if _, _, err := root.Find([]string{"skills", "outdated"}); err != nil {
+		t.Fatalf("skills outdated command was not registered: %v", err)
+	}
+	for _, name := range []string{"list", "update", "uninstall", "publish"} {
+		if _, _, err := root.Find([]string{"skills", name}); err != nil {
+			t.Fatalf("skills %s command was not registered: %v", name, err)
+		}
+	}
+}
+
+func TestSkillsInstallRevisionPrefix(t *testing.T) {
+	home := useSkillsTestHome(t)
+	server := skillInstallTestServer(t, []skillInstallTestResponse{{
+		ID:       "sk1",
+		Name:     "github-review",
+		Download: skillTestZip(t, "github-review", "Latest description."),
+		Revisions: []types.SkillRevision{
+			{CommitSHA: "bbbb2222", Installable: true, Current: true},
+			{CommitSHA: "aaaa1111", Installable: true},
+		},
+		RevisionDownloads: map[string][]byte{
+			"aaaa1111": skillTestZip(t, "github-review", "Pinned description."),
+		},
+	}})
+	defer server.Close()
+
+	if _, err := executeSkillsTestCommand(t, skillsTestRoot(server.URL), "install", "sk1", "--destination", "~/.claude/skills", "--revision", "aaaa"); err != nil {
+		t.Fatal(err)
+	}
+
+	assertFileContains(t, filepath.Join(home, ".claude", "skills", "github-review", skillformat.SkillMainFile), "Pinned description.")
+}
+
+func TestSkillsInstallRevisionErrors(t *testing.T) {
+	useSkillsTestHome(t)
+	server := skillInstallTestServer(t, []skillInstallTestResponse{{
+		ID:       "sk1",
+		Name:     "github-review",
+		Download: skillTestZip(t, "github-review", "Latest description."),
+		Revisions: []types.SkillRevision{
+			{CommitSHA: "abcd2222", Installable: true, Current: true},
+			{CommitSHA: "abcd1111", Installable: false},
+		},
+	}})
+	defer server.Close()
+
+	tests := []struct {
+		revision string
+		want     string
+	}{
+		{"ffff", `revision "ffff" not found`},
+		{"abcd", `revision "abcd" is ambiguous`},
+		{"abcd1", "blocked by its security scan findings"},
+	}
+	for _, tt := range tests {
+		_, err := executeSkillsTestCommand(t, skillsTestRoot(server.URL), "install", "sk1", "--destination", "~/.claude/skills", "--revision", tt.revision)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Fatalf("revision %q: error = %v, want %q", tt.revision, err, tt.want)
+		}
+	}
+}
+
+func TestSkillsRevisionsTable(t *testing.T) {
+	server := skillInstallTestServer(t, []skillInstallTestResponse{{
+		ID:   "sk1",
+		Name: "github-review",
+		Revisions: []types.SkillRevision{
+			{CommitSHA: "bbbb2222bbbb2222", InstallHash: "hash2", Installable: true, Current: true},
+			{CommitSHA: "aaaa1111aaaa1111", InstallHash: "hash1", Installable: false},
+		},
+	}})
+	defer server.Close()
+
+	stdout, err := executeSkillsTestCommand(t, skillsTestRoot(server.URL), "revisions", "sk1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"COMMIT", "bbbb2222bbbb", "current", "aaaa1111aaaa", "blocked"} {
+		if !strings.Contains(stdout, want) {
+			t.Fatalf("expected %q in output:\n%s", want, stdout)
+		}
+	}
+}
+
+func TestSkillsOutdated(t *testing.T) {
+	home := useSkillsTestHome(t)
+	skillsDir := filepath.Join(home, ".claude", "skills")
+	writeInstalledTestSkill(t, skillsDir, "current", "sk1", "hash1")
+	writeInstalledTestSkill(t, skillsDir, "stale", "sk2", "old-hash")
+	writeInstalledTestSkill(t, skillsDir, "removed", "sk3", "hash3")
+	writeInstalledTestSkill(t, skillsDir, "local", "", "")
+
+	server := skillInstallTestServer(t, []skillInstallTestResponse{
+		{ID: "sk1", Name: "current", CommitSHA: "c1", InstallHash: "hash1"},
+		{ID: "sk2", Name: "stale", CommitSHA: "c2", InstallHash: "new-hash"},
+	})
+	defer server.Close()
+
+	stdout, err := executeSkillsTestCommand(t, skillsTestRoot(server.URL), "outdated", "--destination", "~/.claude/skills", "--json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var output skillsOutdatedOutput
+	if err := json.Unmarshal([]byte(stdout), &output); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, stdout)
+	}
+	got := map[string]string{}
+	for _, result := range output.Results {
+		got[result.Name] = result.Status
+	}
+	want := map[string]string{
+		"current": skillStatusUpToDate,
+		"stale":   skillStatusOutdated,
+		"removed": skillStatusNotFound,
+		"local":   skillStatusNotManaged,
+	}
+	for name, status := range want {
+		if got[name] != status {
+			t.Fatalf("status for %s = %q, want %q (all: %#v)", name, got[name], status, got)
+		}
+	}
+}
+
+func TestSkillsInstallRecordsLockAndListShowsStatus(t *testing.T) {
+	home := useSkillsTestHome(t)
+	skills := []skillInstallTestResponse{{
+		ID:          "sk1",
+		Name:        "github-review",
+		CommitSHA:   "c1",
+		InstallHash: "h1",
+		Download:    skillTestZip(t, "github-review", "Review GitHub pull requests."),
+	}}
+	server := skillInstallTestServer(t, skills)
+	defer server.Close()
+	root := skillsTestRoot(server.URL)
+
+	if _, err := executeSkillsTestCommand(t, root, "install", "sk1", "--destination", "~/.claude/skills"); err != nil {
+		t.Fatal(err)
+	}
+
+	lock, err := localconfig.LoadSkillLock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	target := filepath.Join(home, ".claude", "skills", "github-review")
+	entry, ok := lock.Find(target)
+	if !ok {
+		t.Fatalf("expected lock entry for %s, got %#v", target, lock.Skills)
+	}
+	if entry.SkillID != "sk1" || entry.Agent != "claude-code" || entry.InstallHash != "h1" || entry.ContentHash == "" {
+		t.Fatalf("unexpected lock entry: %#v", entry)
+	}
+
+	if status := listSkillsTestStatus(t, root, "sk1"); status != skillStatusUpToDate {
+		t.Fatalf("status = %q, want %q", status, skillStatusUpToDate)
+	}
+
+	skills[0].InstallHash = "h2"
+	if status := listSkillsTestStatus(t, root, "sk1"); status != skillStatusOutdated {
+		t.Fatalf("status = %q, want %q", status, skillStatusOutdated)
+	}
+
+	if err := os.WriteFile(filepath.Join(target, "notes.md"), []byte("mine"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if status := listSkillsTestStatus(t, root, "sk1"); status != skillStatusModified {
+		t.Fatalf("status = %q, want %q", status, skillStatusModified)
+	}
+}
+
+func TestSkillsUpdateReplacesOutdatedSkill(t *testing.T) {
+	home := useSkillsTestHome(t)
+	skills := []skillInstallTestResponse{{
+		ID:          "sk1",
+		Name:        "github-review",
+		CommitSHA:   "c1",
+		InstallHash: "h1",
+		Download:    skillTestZip(t, "github-review", "Old description."),
+	}}
+	server := skillInstallTestServer(t, skills)
+	defer server.Close()
+	root := skillsTestRoot(server.URL)
+
+	if _, err := executeSkillsTestCommand(t, root, "install", "sk1", "--destination", "~/.claude/skills"); err != nil {
+		t.Fatal(err)
+	}
+
+	skills[0].CommitSHA = "c2"
+	skills[0].InstallHash = "h2"
+	skills[0].Download = skillTestZip(t, "github-review", "New description.")
+
+	stdout, err := executeSkillsTestCommand(t, root, "update")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(stdout, "Updated github-review") {
+		t.Fatalf("expected update message, got:\n%s", stdout)
+	}
+	skillMD := filepath.Join(home, ".claude", "skills", "github-review", skillformat.SkillMainFile)
+	assertFileContains(t, skillMD, "New description.")
+
+	if status := listSkillsTestStatus(t, root, "sk1"); status != skillStatusUpToDate {
+		t.Fatalf("status after update = %q, want %q", status, skillStatusUpToDate)
+	}
+}
+
+func TestSkillsUpdateRefusesLocallyModifiedSkill(t *testing.T) {
+	home := useSkillsTestHome(t)
+	skills := []skillInstallTestResponse{{
+		ID:          "sk1",
+		Name:        "github-review",
+		InstallHash: "h1",
+		Download:    skillTestZip(t, "github-review", "Old description."),
+	}}
+	server := skillInstallTestServer(t, skills)
+	defer server.Close()
+	root := skillsTestRoot(server.URL)
+
+	if _, err := executeSkillsTestCommand(t, root, "install", "sk1", "--destination", "~/.claude/skills"); err != nil {
+		t.Fatal(err)
+	}
+
+	skillMD := filepath.Join(home, ".claude", "skills", "github-review", skillformat.SkillMainFile)
+	if err := os.WriteFile(skillMD, []byte("---\nname: github-review\ndescription: Mine.\n---\nLocal edits\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	skills[0].InstallHash = "h2"
+	skills[0].Download = skillTestZip(t, "github-review", "New description.")
+
+	_, err := executeSkillsTestCommand(t, root, "update", "github-review")
+	if err == nil || !strings.Contains(err.Error(), "modified locally") {
+		t.Fatalf("expected modified error, got %v", err)
+	}
+	assertFileContains(t, skillMD, "Local edits")
+
+	_, err = executeSkillsTestCommand(t, root, "install", "sk1", "--destination", "~/.claude/skills")
+	if err == nil || !strings.Contains(err.Error(), "--force") {
+		t.Fatalf("expected install to refuse overwrite, got %v", err)
+	}
+	assertFileContains(t, skillMD, "Local edits")
+
+	if _, err := executeSkillsTestCommand(t, root, "update", "--force"); err != nil {
+		t.Fatal(err)
+	}
+	assertFileContains(t, skillMD, "New description.")
+}
+
+func TestSkillsUpdateRefusesUntrackedDirectory(t *testing.T) {
+	home := useSkillsTestHome(t)
+	skills := []skillInstallTestResponse{{
+		ID:          "sk1",
+		Name:        "github-review",
+		InstallHash: "h1",
+		Download:    skillTestZip(t, "github-review", "Old description."),
+	}}
+	server := skillInstallTestServer(t, skills)
+	defer server.Close()
+	root := skillsTestRoot(server.URL)
+
+	if _, err := executeSkillsTestCommand(t, root, "install", "sk1", "--destination", "~/.claude/skills"); err != nil {
+		t.Fatal(err)
+	}
+
+	// The skill was renamed upstream to a directory the user already has.
+	target := filepath.Join(home, ".claude", "skills", "gh-review")
+	if err := os.MkdirAll(target, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	skillMD := filepath.Join(target, skillformat.SkillMainFile)
+	if err := os.WriteFile(skillMD, []byte("---\nname: gh-review\ndescription: Mine.\n---\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	skills[0].InstallHash = "h2"
+	skills[0].Download = skillTestZip(t, "gh-review", "New description.")
+
+	_, err := executeSkillsTestCommand(t, root, "update")
+	if err == nil || !strings.Contains(err.Error(), "--force") {
+		t.Fatalf("expected update to refuse overwriting %s, got %v", target, err)
+	}
+	assertFileContains(t, skillMD, "Mine.")
+}
+
+func TestSkillsUpdateSavesLockBeforeFailure(t *testing.T) {
+	useSkillsTestHome(t)
+	skills := []skillInstallTestResponse{
+		{ID: "sk1", Name: "github-review", InstallHash: "h1", Download: skillTestZip(t, "github-review", "Old description.")},
+		{ID: "sk2", Name: "gitlab-review", InstallHash: "h1", Download: skillTestZip(t, "gitlab-review", "Old description.")},
+	}
+	server := skillInstallTestServer(t, skills)
+	defer server.Close()
+	root := skillsTestRoot(server.URL)
+
+	for _, id := range []string{"sk1", "sk2"} {
+		if _, err := executeSkillsTestCommand(t, root, "install", id, "--destination", "~/.claude/skills"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	skills[0].InstallHash = "h2"
+	skills[0].Download = skillTestZip(t, "github-review", "New description.")
+	skills[1].InstallHash = "h2"
+	skills[1].Download = []byte("not a zip")
+
+	if _, err := executeSkillsTestCommand(t, root, "update"); err == nil || !strings.Contains(err.Error(), "gitlab-review") {
+		t.Fatalf("expected gitlab-review to fail, got %v", err)
+	}
+	if status := listSkillsTestStatus(t, root, "sk1"); status != skillStatusUpToDate {
+		t.Fatalf("status of the updated skill = %q, want %q", status, skillStatusUpToDate)
+	}
+}
+
+func TestSkillsInstallRefusesUntrackedDirectory(t *testing.T) {
+	home := useSkillsTestHome(t)
+	server := skillInstallTestServer(t, []skillInstallTestResponse{{
+		ID:       "sk1",
+		Name:     "github-review",
+		Download: skillTestZip(t, "github-review", "Review GitHub pull requests."),
+	}})
+	defer server.Close()
+	root := skillsTestRoot(server.URL)
+
+	target := filepath.Join(home, ".claude", "skills", "github-review")
+	if err := os.MkdirAll(target, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	skillMD := filepath.Join(target, skillformat.SkillMainFile)
+	if err := os.WriteFile(skillMD, []byte("---\nname: github-review\ndescription: Mine.\n---\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := executeSkillsTestCommand(t, root, "install", "sk1", "--destination", "~/.claude/skills")
+	if err == nil || !strings.Contains(err.Error(), target) || !strings.Contains(err.Error(), "--force") {
+		t.Fatalf("expected install to refuse overwriting %s, got %v", target, err)
+	}
+	assertFileContains(t, skillMD, "Mine.")
+
+	if _, err := executeSkillsTestCommand(t, root, "install", "sk1", "--destination", "~/.claude/skills", "--force"); err != nil {
+		t.Fatal(err)
+	}
+	assertFileContains(t, skillMD, "Review GitHub pull requests.")
+}
+
+func TestSkillsUninstall(t *testing.T) {
+	home := useSkillsTestHome(t)
+	server := skillInstallTestServer(t, []skillInstallTestResponse{{
+		ID:       "sk1",
+		Name:     "github-review",
+		Download: skillTestZip(t, "github-review", "Review GitHub pull requests."),
+	}})
+	defer server.Close()
+	root := skillsTestRoot(server.URL)
+
+	for _, destination := range []string{"~/.claude/skills", "~/.agents/skills"} {
+		if _, err := executeSkillsTestCommand(t, root, "install", "sk1", "--destination", destination); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	claudeTarget := filepath.Join(home, ".claude", "skills", "github-review")
+	agentsTarget := filepath.Join(home, ".agents", "skills", "github-review")
+	if err := os.WriteFile(filepath.Join(agentsTarget, "notes.md"), []byte("mine"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := executeSkillsTestCommand(t, root, "uninstall", "sk1")
+	if err == nil || !strings.Contains(err.Error(), "modified locally") {
+		t.Fatalf("expected modified error, got %v", err)
+	}
+	if _, err := os.Stat(claudeTarget); err != nil {
+		t.Fatalf("expected refusal to leave every copy in place: %v", err)
+	}
+
+	if _, err := executeSkillsTestCommand(t, root, "uninstall", "github-review", "--destination", "~/.claude/skills"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(claudeTarget); !os.IsNotExist(err) {
+		t.Fatalf("expected %s to be removed, stat err = %v", claudeTarget, err)
+	}
+
+	lock, err := localconfig.LoadSkillLock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lock.Skills) != 1 || lock.Skills[0].Path != agentsTarget {
+		t.Fatalf("unexpected lock after uninstall: %#v", lock.Skills)
+	}
+
+	_, err = executeSkillsTestCommand(t, root, "uninstall", "missing")
+	if err == nil || !strings.Contains(err.Error(), `skill "missing" is not installed`) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestSkillsPublishDryRunDoesNotContactServer(t *testing.T) {
+	skillsDir := t.TempDir()
+	writeInstalledTestSkill(t, skillsDir, "review-helper", "", "")
+
+	stdout, err := executeSkillsTestCommand(t, nil, "publish", filepath.Join(skillsDir, "review-helper"), "--dry-run", "--json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var output skillsPublishOutput
+	if err := json.Unmarshal([]byte(stdout), &output); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, stdout)
+	}
+	if output.Name != "review-helper" || !output.DryRun || output.Size == 0 || output.Artifact != nil {
+		t.Fatalf("unexpected dry run output: %+v", output)
+	}
+}
+
+func TestSkillsPublishRejectsInvalidDirectory(t *testing.T) {
+	skillsDir := t.TempDir()
+	writeInstalledTestSkill(t, skillsDir, "review-helper", "", "")
+	renamed := filepath.Join(skillsDir, "other-name")
+	if err := os.Rename(filepath.Join(skillsDir, "review-helper"), renamed); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := executeSkillsTestCommand(t, nil, "publish", renamed, "--dry-run"); err == nil || !strings.Contains(err.Error(), "invalid skill directory") {
+		t.Fatalf("expected invalid skill directory error, got %v", err)
+	}
+}
+
+func TestSkillsPublishUploadsWithSubjects(t *testing.T) {
+	skillsDir := t.TempDir()
+	writeInstalledTestSkill(t, skillsDir, "review-helper", "", "")
+
+	var subjects []types.Subject
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer test-token" {
+			t.Fatalf("authorization = %q, want bearer token", got)
+		}
+
+		artifact := types.PublishedArtifact{
+			Metadata:      types.Metadata{ID: "pa1"},
+			LatestVersion: 2,
+		}
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api-keys/auth":
+			_, _ = w.Write([]byte(`{"allowed":true,"scopes":{"canAccessSkills":true,"canAccessLLMProxy":true,"canAccessDeviceScans":true,"canAccessPublishedArtifacts":true}}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/published-artifacts":
+			if got := r.URL.Query().Get("type"); got != string(types.PublishedArtifactTypeSkill) {
+				t.Fatalf("type = %q, want skill", got)
+			}
+			if got := r.URL.Query().Get("description"); got != "Second release" {
+				t.Fatalf("description = %q, want Second release", got)
+			}
+			if err := json.Unmarshal([]byte(r.URL.Query().Get("subjects")), &subjects); err != nil {
+				t.Fatalf("invalid subjects: %v", err)
+			}
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			reader, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+			if err != nil {
+				t.Fatalf("request body is not a ZIP: %v", err)
+			}
+			if len(reader.File) != 1 || reader.File[0].Name != skillformat.SkillMainFile {
+				t.Fatalf("unexpected ZIP entries: %v", reader.File)
+			}
+			_ = json.NewEncoder(w).Encode(artifact)
+		default:
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	stdout, err := executeSkillsTestCommand(t, skillsTestRoot(server.URL), "publish", filepath.Join(skillsDir, "review-helper"),
+		"--description", "Second release", "--user", "u1", "--group", "g1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(stdout, "Published skill review-helper version 2 (pa1)") || !strings.Contains(stdout, "user:u1, group:g1") {
+		t.Fatalf("unexpected output:\n%s", stdout)
+	}
+	want := []types.Subject{{Type: types.SubjectTypeUser, ID: "u1"}, {Type: types.SubjectTypeGroup, ID: "g1"}}
+	if len(subjects) != len(want) || subjects[0] != want[0] || subjects[1] != want[1] {
+		t.Fatalf("subjects = %+v, want %+v", subjects, want)
+	}
+}
+
+func listSkillsTestStatus(t *testing.T, root *Obot, skillID string) string {
+	t.Helper()
+
+	stdout, err := executeSkillsTestCommand(t, root, "list", "--json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var output skillsInstalledOutput
+	if err := json.Unmarshal([]byte(stdout), &output); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, stdout)
+	}
+	for _, result := range output.Results {
+		if result.SkillID == skillID {
+			return result.Status
+		}
+	}
+	t.Fatalf("skill %s not listed: %s", skillID, stdout)
+	return ""
+}
+
+// useSkillsTestHome isolates both the home directory and the XDG config
+// directory that holds the skills lockfile.
+func useSkillsTestHome(t *testing.T) string {
+	t.Helper()
+
+	home := useSetupTestHome(t)
+	oldConfigHome, hadConfigHome := os.LookupEnv("XDG_CONFIG_HOME")
+	if err := os.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config")); err != nil {
+		t.Fatal(err)
+	}
+	xdg.Reload()
+	t.Cleanup(func() {
+		if hadConfigHome {
+			_ = os.Setenv("XDG_CONFIG_HOME", oldConfigHome)
+		} else {
+			_ = os.Unsetenv("XDG_CONFIG_HOME")
+		}
+		xdg.Reload()
+	})
+	return home
 }
 
 func skillsTestRoot(baseURL string) *Obot {
@@ -264,8 +799,25 @@ func skillInstallTestServer(t *testing.T, skills []skillInstallTestResponse) *ht
 			id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/skills/"), "/download")
 			for _, skill := range skills {
 				if skill.ID == id {
+					download := skill.Download
+					if revision := r.URL.Query().Get("revision"); revision != "" {
+						var ok bool
+						if download, ok = skill.RevisionDownloads[revision]; !ok {
+							http.NotFound(w, r)
+							return
+						}
+					}
 					w.Header().Set("Content-Type", "application/zip")
-					_, _ = w.Write(skill.Download)
+					_, _ = w.Write(download)
+					return
+				}
+			}
+			http.NotFound(w, r)
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/revisions"):
+			id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/skills/"), "/revisions")
+			for _, skill := range skills {
+				if skill.ID == id {
+					_ = json.NewEncoder(w).Encode(types.SkillRevisionList{Items: skill.Revisions})
 					return
 				}
 			}
@@ -292,6 +844,8 @@ func skillTestType(skill skillInstallTestResponse) types.Skill {
 		DisplayName: skill.DisplayName,
 		Description: skill.Description,
 		RepoID:      "default/test",
+		CommitSHA:   skill.CommitSHA,
+		InstallHash: skill.InstallHash,
 	}
 }
 
@@ -316,6 +870,23 @@ func skillTestZip(t *testing.T, name, description string) []byte {
 	return buf.Bytes()
 }
 
+func writeInstalledTestSkill(t *testing.T, skillsDir, name, skillID, installHash string) {
+	t.Helper()
+
+	dir := filepath.Join(skillsDir, name)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	content := fmt.Sprintf("---\nname: %s\ndescription: Test skill.\n", name)
+	if skillID != "" {
+		content += fmt.Sprintf("metadata:\n  %s: %s\n  %s: %s\n", skillformat.MetadataSkillID, skillID, skillformat.MetadataInstallHash, installHash)
+	}
+	content += "---\nBody\n"
+	if err := os.WriteFile(filepath.Join(dir, skillformat.SkillMainFile), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func assertFileContains(t *testing.T, path, substr string) {
 	t.Helper()
 
@@ -14,6 +14,7 @@ import (
 type Tunnel struct {
 	Token       string `usage:"Token used to authenticate with the Obot instance"`
 	ObotBaseURL string `usage:"Base URL of the Obot instance" default:"http://localhost:8080/api" env:"OBOT_BASE_URL"`
+	StdioConfig string `usage:"Path to a YAML file of local stdio MCP servers to serve through the tunnel" env:"OBOT_TUNNEL_STDIO_CONFIG"`
 }
 
 func (t *Tunnel) Customize(cmd *cobra.Command) {
@@ -26,7 +27,16 @@ func (t *Tunnel) Run(cmd *cobra.Command, _ []string) error {
 	ctx, cancel := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
 	defer cancel()
 
-	err := obottunnel.Run(ctx, t.ObotBaseURL, t.Token)
+	var opts obottunnel.Options
+	if t.StdioConfig != "" {
+		servers, err := obottunnel.LoadStdioConfig(t.StdioConfig)
+		if err != nil {
+			return err
+		}
+		opts.StdioServers = servers
+	}
+
+	err := obottunnel.RunWithOptions(ctx, t.ObotBaseURL, t.Token, opts)
 	if ctx.Err() != nil {
 		return nil
 	}
@@ -0,0 +1,98 @@
+// Package confighistory computes the differences recorded in the change
+// history of governance objects.
+package confighistory
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/obot-platform/obot/apiclient/types"
+)
+
+// Diff returns the values that differ between two JSON documents, either of
+// which may be nil for an object that did not exist. Objects are compared key
+// by key, so a change to one field of a large policy is reported as just that
+// field. Arrays and other values are compared whole.
+func Diff(before, after []byte) ([]types.ConfigChangeDiff, error) {
+	beforeValue, beforeOK, err := decode(before)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode previous configuration: %w", err)
+	}
+	afterValue, afterOK, err := decode(after)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode new configuration: %w", err)
+	}
+
+	diff := []types.ConfigChangeDiff{}
+	if err := compare(&diff, "", beforeValue, beforeOK, afterValue, afterOK); err != nil {
+		return nil, err
+	}
+	return diff, nil
+}
+
+func decode(data []byte) (any, bool, error) {
+	if len(data) == 0 {
+		return nil, false, nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	// Keep numbers as written, so that large integers are compared and
+	// reported exactly.
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, false, err
+	}
+	return value, true, nil
+}
+
+func compare(diff *[]types.ConfigChangeDiff, path string, before any, beforeOK bool, after any, afterOK bool) error {
+	beforeObject, beforeIsObject := before.(map[string]any)
+	afterObject, afterIsObject := after.(map[string]any)
+	if (beforeIsObject || !beforeOK) && (afterIsObject || !afterOK) {
+		keys := slices.Collect(maps.Keys(beforeObject))
+		for key := range afterObject {
+			if _, ok := beforeObject[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		slices.Sort(keys)
+		for _, key := range keys {
+			beforeValue, beforeHas := beforeObject[key]
+			afterValue, afterHas := afterObject[key]
+			if err := compare(diff, path+"/"+escapePointer(key), beforeValue, beforeHas, afterValue, afterHas); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if beforeOK && afterOK && reflect.DeepEqual(before, after) {
+		return nil
+	}
+
+	entry := types.ConfigChangeDiff{Path: path}
+	var err error
+	if beforeOK {
+		if entry.Before, err = json.Marshal(before); err != nil {
+			return err
+		}
+	}
+	if afterOK {
+		if entry.After, err = json.Marshal(after); err != nil {
+			return err
+		}
+	}
+	*diff = append(*diff, entry)
+	return nil
+}
+
+// escapePointer escapes a key for use as a JSON pointer reference token, as
+// RFC 6901 specifies.
+func escapePointer(key string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1")
+}
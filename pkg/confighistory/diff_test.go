@@ -0,0 +1,71 @@
+package confighistory
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	for name, test := range map[string]struct {
+		before, after string
+		want          []types.ConfigChangeDiff
+	}{
+		"unchanged": {
+			before: `{"manifest":{"displayName":"a","subjects":[{"id":"1"}]}}`,
+			after:  `{"manifest":{"subjects":[{"id":"1"}],"displayName":"a"}}`,
+			want:   []types.ConfigChangeDiff{},
+		},
+		"created": {
+			after: `{"manifest":{"displayName":"a","models":["m1"]}}`,
+			want: []types.ConfigChangeDiff{
+				{Path: "/manifest/displayName", After: json.RawMessage(`"a"`)},
+				{Path: "/manifest/models", After: json.RawMessage(`["m1"]`)},
+			},
+		},
+		"deleted": {
+			before: `{"enabled":true}`,
+			want: []types.ConfigChangeDiff{
+				{Path: "/enabled", Before: json.RawMessage(`true`)},
+			},
+		},
+		"updated": {
+			before: `{"manifest":{"displayName":"a","models":["m1"],"a/b~c":1,"gone":null}}`,
+			after:  `{"manifest":{"displayName":"b","models":["m1","m2"],"a/b~c":1,"added":12345678901234567890}}`,
+			want: []types.ConfigChangeDiff{
+				{Path: "/manifest/added", After: json.RawMessage(`12345678901234567890`)},
+				{Path: "/manifest/displayName", Before: json.RawMessage(`"a"`), After: json.RawMessage(`"b"`)},
+				{Path: "/manifest/gone", Before: json.RawMessage(`null`)},
+				{Path: "/manifest/models", Before: json.RawMessage(`["m1"]`), After: json.RawMessage(`["m1","m2"]`)},
+			},
+		},
+		"replaced type": {
+			before: `{"allowlist":{"hosts":["a"]}}`,
+			after:  `{"allowlist":null}`,
+			want: []types.ConfigChangeDiff{
+				{Path: "/allowlist", Before: json.RawMessage(`{"hosts":["a"]}`), After: json.RawMessage(`null`)},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var before, after []byte
+			if test.before != "" {
+				before = []byte(test.before)
+			}
+			if test.after != "" {
+				after = []byte(test.after)
+			}
+			diff, err := Diff(before, after)
+			require.NoError(t, err)
+			assert.Equal(t, test.want, diff)
+		})
+	}
+}
+
+func TestDiffRejectsInvalidJSON(t *testing.T) {
+	_, err := Diff([]byte(`{`), nil)
+	assert.Error(t, err)
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/obot-platform/obot/pkg/backup"
 	"github.com/obot-platform/obot/pkg/controller/data"
 	"github.com/obot-platform/obot/pkg/controller/handlers/adminworkspace"
 	"github.com/obot-platform/obot/pkg/controller/handlers/deployment"
@@ -14,8 +15,10 @@ import (
 	"github.com/obot-platform/obot/pkg/controller/handlers/mdmassetsource"
 	"github.com/obot-platform/obot/pkg/controller/handlers/modelinfosource"
 	"github.com/obot-platform/obot/pkg/controller/handlers/provider"
+	"github.com/obot-platform/obot/pkg/controller/handlers/scheduledbackup"
 	"github.com/obot-platform/obot/pkg/controller/handlers/secret"
 	"github.com/obot-platform/obot/pkg/controller/handlers/tunnelpeer"
+	"github.com/obot-platform/obot/pkg/gateway/genericchat"
 	"github.com/obot-platform/obot/pkg/localauth"
 	"github.com/obot-platform/obot/pkg/mcp"
 	"github.com/obot-platform/obot/pkg/serviceaccounts"
@@ -287,6 +290,15 @@ func (c *Controller) PostStart(ctx context.Context, client kclient.Client) {
 	go c.retriggerCatalogEntries(ctx, client)
 
 	go c.runServiceAccountKeyRotation(ctx)
+
+	go scheduledbackup.New(
+		backup.Stores{Storage: c.services.StorageClient, Gateway: c.services.GatewayDB},
+		c.services.ArtifactBlobStore,
+		c.services.ArtifactBlobBucket,
+		c.services.BackupSchedule,
+		c.services.BackupOptions,
+		c.services.BackupRetention,
+	).Run(ctx)
 }
 
 // retriggerCatalogEntries touches all MCPServerCatalogEntries to trigger their handlers,
@@ -705,10 +717,34 @@ func (c *Controller) ensureLocalAuthProvider(ctx context.Context) error {
 	return c.services.StorageClient.Update(ctx, &existing)
 }
 
+// ensureGenericChatCompletionsModelProvider creates or updates the ModelProvider resource for the
+// built-in Generic Chat Completions provider. Like the local auth provider, it runs inside this
+// process, so it doesn't come from the provider registry.
+func (c *Controller) ensureGenericChatCompletionsModelProvider(ctx context.Context) error {
+	modelProvider := genericchat.ModelProvider()
+
+	var existing v1.ModelProvider
+	if err := c.services.StorageClient.Get(ctx, kclient.ObjectKeyFromObject(modelProvider), &existing); apierrors.IsNotFound(err) {
+		return c.services.StorageClient.Create(ctx, modelProvider)
+	} else if err != nil {
+		return fmt.Errorf("failed to get generic chat completions model provider: %w", err)
+	}
+
+	if equality.Semantic.DeepEqual(existing.Spec, modelProvider.Spec) {
+		return nil
+	}
+
+	existing.Spec = modelProvider.Spec
+	return c.services.StorageClient.Update(ctx, &existing)
+}
+
 func (c *Controller) ensureAuthProvidersAndModelProviders(ctx context.Context) error {
 	if err := c.ensureLocalAuthProvider(ctx); err != nil {
 		return fmt.Errorf("failed to ensure local auth provider: %w", err)
 	}
+	if err := c.ensureGenericChatCompletionsModelProvider(ctx); err != nil {
+		return fmt.Errorf("failed to ensure generic chat completions model provider: %w", err)
+	}
 
 	var authProviders v1.AuthProviderList
 	if err := c.services.StorageClient.List(ctx, &authProviders); err != nil {
@@ -0,0 +1,181 @@
+package accessrequest
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/obot-platform/nah/pkg/router"
+	"github.com/obot-platform/obot/apiclient/types"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type Handler struct {
+	now func() time.Time
+}
+
+func New() *Handler {
+	return &Handler{now: time.Now}
+}
+
+// Reconcile keeps the grant for an approved request in place until the request
+// expires, then removes it and marks the request expired. Requests in any other
+// state have no grant.
+func (h *Handler) Reconcile(req router.Request, resp router.Response) error {
+	request := req.Object.(*v1.AccessRequest)
+	if !request.DeletionTimestamp.IsZero() {
+		// Cleanup removes the grant; recreating it here would undo that.
+		return nil
+	}
+	if request.Spec.State != types.AccessRequestStateApproved {
+		return deleteGrant(req, request)
+	}
+
+	now := h.now()
+	if remaining := request.Spec.ExpiresAt.Sub(now); remaining > 0 {
+		if err := ensureGrant(req, request); err != nil {
+			return err
+		}
+		resp.RetryAfter(remaining)
+		return nil
+	}
+
+	if err := deleteGrant(req, request); err != nil {
+		return err
+	}
+
+	request.Spec.State = types.AccessRequestStateExpired
+	request.Spec.History = append(request.Spec.History, types.AccessRequestEvent{
+		Time:  *types.NewTime(now),
+		State: types.AccessRequestStateExpired,
+	})
+	slog.Info("access request expired", "accessRequest", request.Name, "requester", request.Spec.RequesterID,
+		"resourceType", request.Spec.Manifest.ResourceType, "resourceID", request.Spec.Manifest.ResourceID)
+	return req.Client.Update(req.Ctx, request)
+}
+
+// Cleanup removes the grant of a request that is being deleted, so that
+// deleting a request revokes the access it gave.
+func (h *Handler) Cleanup(req router.Request, _ router.Response) error {
+	return deleteGrant(req, req.Object.(*v1.AccessRequest))
+}
+
+func ensureGrant(req router.Request, request *v1.AccessRequest) error {
+	grant := newGrant(request, grantScope{})
+	if err := req.Get(grant, request.Namespace, grant.GetName()); err == nil {
+		return nil
+	} else if !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to get grant for access request %s: %w", request.Name, err)
+	}
+
+	scope, err := resourceScope(req, request)
+	if apierrors.IsNotFound(err) {
+		// The resource is gone, so there is nothing left to grant access to.
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if err := req.Client.Create(req.Ctx, newGrant(request, scope)); err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create grant for access request %s: %w", request.Name, err)
+	}
+	return nil
+}
+
+func deleteGrant(req router.Request, request *v1.AccessRequest) error {
+	if err := req.Client.Delete(req.Ctx, newGrant(request, grantScope{})); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete grant for access request %s: %w", request.Name, err)
+	}
+	return nil
+}
+
+// grantScope is where an access control rule lives: the catalog or the power
+// user workspace of the MCP server or catalog entry it grants access to.
+type grantScope struct {
+	catalogID   string
+	workspaceID string
+}
+
+// resourceScope reads the requested MCP server or catalog entry to find where
+// a rule granting access to it belongs. Models and skills are not scoped.
+func resourceScope(req router.Request, request *v1.AccessRequest) (grantScope, error) {
+	var scope grantScope
+	switch request.Spec.Manifest.ResourceType {
+	case types.AccessRequestResourceTypeMCPServer:
+		var server v1.MCPServer
+		if err := req.Get(&server, request.Namespace, request.Spec.Manifest.ResourceID); err != nil {
+			return scope, fmt.Errorf("failed to get MCP server for access request %s: %w", request.Name, err)
+		}
+		scope = grantScope{catalogID: server.Spec.MCPCatalogID, workspaceID: server.Spec.PowerUserWorkspaceID}
+	case types.AccessRequestResourceTypeMCPServerCatalogEntry:
+		var entry v1.MCPServerCatalogEntry
+		if err := req.Get(&entry, request.Namespace, request.Spec.Manifest.ResourceID); err != nil {
+			return scope, fmt.Errorf("failed to get MCP server catalog entry for access request %s: %w", request.Name, err)
+		}
+		scope = grantScope{catalogID: entry.Spec.MCPCatalogName, workspaceID: entry.Spec.PowerUserWorkspaceID}
+	}
+	return scope, nil
+}
+
+// newGrant returns the rule or policy that gives the requester the access
+// they asked for.
+func newGrant(request *v1.AccessRequest, scope grantScope) kclient.Object {
+	var (
+		displayName = fmt.Sprintf("Temporary access (%s)", request.Name)
+		subjects    = []types.Subject{{Type: types.SubjectTypeUser, ID: request.Spec.RequesterID}}
+		labels      = map[string]string{v1.AccessRequestLabel: request.Name}
+		manifest    = request.Spec.Manifest
+	)
+
+	switch manifest.ResourceType {
+	case types.AccessRequestResourceTypeModel:
+		return &v1.ModelAccessPolicy{
+			Name:      request.GrantName(),
+			Namespace: request.Namespace,
+			Labels:    labels,
+			Spec: v1.ModelAccessPolicySpec{
+				Manifest: types.ModelAccessPolicyManifest{
+					DisplayName: displayName,
+					Subjects:    subjects,
+					Models:      []types.ModelResource{{ID: manifest.ResourceID}},
+				},
+			},
+		}
+	case types.AccessRequestResourceTypeSkill:
+		return &v1.SkillAccessRule{
+			Name:      request.GrantName(),
+			Namespace: request.Namespace,
+			Labels:    labels,
+			Spec: v1.SkillAccessRuleSpec{
+				Manifest: types.SkillAccessRuleManifest{
+					DisplayName: displayName,
+					Subjects:    subjects,
+					Resources:   []types.SkillResource{{Type: types.SkillResourceTypeSkill, ID: manifest.ResourceID}},
+				},
+			},
+		}
+	default:
+		resourceType := types.ResourceTypeMCPServerCatalogEntry
+		if manifest.ResourceType == types.AccessRequestResourceTypeMCPServer {
+			resourceType = types.ResourceTypeMCPServer
+		}
+		return &v1.AccessControlRule{
+			Name:       request.GrantName(),
+			Namespace:  request.Namespace,
+			Labels:     labels,
+			Finalizers: []string{v1.AccessControlRuleFinalizer},
+			Spec: v1.AccessControlRuleSpec{
+				MCPCatalogID:         scope.catalogID,
+				PowerUserWorkspaceID: scope.workspaceID,
+				Generated:            true,
+				Manifest: types.AccessControlRuleManifest{
+					DisplayName: displayName,
+					Subjects:    subjects,
+					Resources:   []types.Resource{{Type: resourceType, ID: manifest.ResourceID}},
+				},
+			},
+		}
+	}
+}
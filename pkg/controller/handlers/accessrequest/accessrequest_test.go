@@ -0,0 +1,145 @@
+package accessrequest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/obot-platform/nah/pkg/router"
+	"github.com/obot-platform/obot/apiclient/types"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	storagescheme "github.com/obot-platform/obot/pkg/storage/scheme"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newApprovedRequest(resourceType types.AccessRequestResourceType, resourceID string, expiresAt time.Time) *v1.AccessRequest {
+	return &v1.AccessRequest{
+		Name:      "ar1abcde",
+		Namespace: "default",
+		Spec: v1.AccessRequestSpec{
+			Manifest: types.AccessRequestManifest{
+				ResourceType:  resourceType,
+				ResourceID:    resourceID,
+				Justification: "incident response",
+				DurationHours: 1,
+			},
+			RequesterID: "42",
+			State:       types.AccessRequestStateApproved,
+			ExpiresAt:   metav1.NewTime(expiresAt),
+		},
+	}
+}
+
+func reconcile(t *testing.T, h *Handler, c kclient.WithWatch, request *v1.AccessRequest) (*v1.AccessRequest, *router.ResponseWrapper) {
+	t.Helper()
+	var current v1.AccessRequest
+	require.NoError(t, c.Get(t.Context(), router.Key(request.Namespace, request.Name), &current))
+	resp := &router.ResponseWrapper{}
+	require.NoError(t, h.Reconcile(router.Request{
+		Client:    c,
+		Ctx:       t.Context(),
+		Object:    &current,
+		Namespace: request.Namespace,
+		Name:      request.Name,
+	}, resp))
+	require.NoError(t, c.Get(t.Context(), router.Key(request.Namespace, request.Name), &current))
+	return &current, resp
+}
+
+func TestReconcileGrantsUntilExpiry(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	request := newApprovedRequest(types.AccessRequestResourceTypeMCPServerCatalogEntry, "entry1", now.Add(time.Hour))
+	entry := &v1.MCPServerCatalogEntry{
+		ObjectMeta: metav1.ObjectMeta{Name: "entry1", Namespace: "default"},
+		Spec:       v1.MCPServerCatalogEntrySpec{MCPCatalogName: "default"},
+	}
+	c := fake.NewClientBuilder().WithScheme(storagescheme.Scheme).WithObjects(request, entry).Build()
+	h := &Handler{now: func() time.Time { return now }}
+
+	current, resp := reconcile(t, h, c, request)
+	assert.Equal(t, types.AccessRequestStateApproved, current.Spec.State)
+	assert.Equal(t, time.Hour, resp.Delay)
+
+	var rule v1.AccessControlRule
+	require.NoError(t, c.Get(t.Context(), router.Key("default", "acr1abcde"), &rule))
+	assert.Equal(t, "ar1abcde", rule.Labels[v1.AccessRequestLabel])
+	assert.Equal(t, "default", rule.Spec.MCPCatalogID)
+	assert.Equal(t, []types.Subject{{Type: types.SubjectTypeUser, ID: "42"}}, rule.Spec.Manifest.Subjects)
+	assert.Equal(t, []types.Resource{{Type: types.ResourceTypeMCPServerCatalogEntry, ID: "entry1"}}, rule.Spec.Manifest.Resources)
+
+	now = now.Add(time.Hour)
+	current, _ = reconcile(t, h, c, request)
+	assert.Equal(t, types.AccessRequestStateExpired, current.Spec.State)
+	require.Len(t, current.Spec.History, 1)
+	assert.Equal(t, types.AccessRequestStateExpired, current.Spec.History[0].State)
+	// The rule's finalizer holds it until the access control rule controller
+	// has cleaned up after it.
+	require.NoError(t, c.Get(t.Context(), router.Key("default", "acr1abcde"), &rule))
+	assert.NotNil(t, rule.DeletionTimestamp, "grant was not deleted after expiry")
+}
+
+func TestReconcileRemovesRevokedGrant(t *testing.T) {
+	request := newApprovedRequest(types.AccessRequestResourceTypeModel, "m1model", time.Now().Add(time.Hour))
+	c := fake.NewClientBuilder().WithScheme(storagescheme.Scheme).WithObjects(request).Build()
+	h := New()
+
+	reconcile(t, h, c, request)
+	var policy v1.ModelAccessPolicy
+	require.NoError(t, c.Get(t.Context(), router.Key("default", "map1abcde"), &policy))
+	assert.Equal(t, []types.ModelResource{{ID: "m1model"}}, policy.Spec.Manifest.Models)
+
+	var current v1.AccessRequest
+	require.NoError(t, c.Get(t.Context(), router.Key("default", request.Name), &current))
+	current.Spec.State = types.AccessRequestStateRevoked
+	require.NoError(t, c.Update(t.Context(), &current))
+
+	revoked, _ := reconcile(t, h, c, request)
+	assert.Equal(t, types.AccessRequestStateRevoked, revoked.Spec.State)
+	err := c.Get(t.Context(), router.Key("default", "map1abcde"), &policy)
+	assert.True(t, apierrors.IsNotFound(err), "grant still exists after revocation: %v", err)
+}
+
+func TestReconcileScopesGrantToResourceWorkspace(t *testing.T) {
+	request := newApprovedRequest(types.AccessRequestResourceTypeMCPServer, "ms1server", time.Now().Add(time.Hour))
+	server := &v1.MCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "ms1server", Namespace: "default"},
+		Spec:       v1.MCPServerSpec{PowerUserWorkspaceID: "puw1"},
+	}
+	c := fake.NewClientBuilder().WithScheme(storagescheme.Scheme).WithObjects(request, server).Build()
+
+	reconcile(t, New(), c, request)
+	var rule v1.AccessControlRule
+	require.NoError(t, c.Get(t.Context(), router.Key("default", "acr1abcde"), &rule))
+	assert.Equal(t, "puw1", rule.Spec.PowerUserWorkspaceID)
+	assert.Empty(t, rule.Spec.MCPCatalogID)
+	assert.Equal(t, []types.Resource{{Type: types.ResourceTypeMCPServer, ID: "ms1server"}}, rule.Spec.Manifest.Resources)
+}
+
+func TestCleanupRemovesGrantOfDeletedRequest(t *testing.T) {
+	request := newApprovedRequest(types.AccessRequestResourceTypeSkill, "sk1skill", time.Now().Add(time.Hour))
+	request.Finalizers = []string{v1.AccessRequestFinalizer}
+	c := fake.NewClientBuilder().WithScheme(storagescheme.Scheme).WithObjects(request).Build()
+	h := New()
+
+	reconcile(t, h, c, request)
+	var rule v1.SkillAccessRule
+	require.NoError(t, c.Get(t.Context(), router.Key("default", request.GrantName()), &rule))
+
+	require.NoError(t, c.Delete(t.Context(), request))
+	var deleting v1.AccessRequest
+	require.NoError(t, c.Get(t.Context(), router.Key("default", request.Name), &deleting))
+	require.NoError(t, h.Cleanup(router.Request{
+		Client:    c,
+		Ctx:       t.Context(),
+		Object:    &deleting,
+		Namespace: request.Namespace,
+		Name:      request.Name,
+	}, &router.ResponseWrapper{}))
+
+	err := c.Get(t.Context(), router.Key("default", request.GrantName()), &rule)
+	assert.True(t, apierrors.IsNotFound(err), "grant still exists after its request was deleted: %v", err)
+}
@@ -85,7 +85,7 @@ func (gitSourceFetcher) Fetch(ctx context.Context, repoURL, ref string) (*fetche
 		return fetchLocalRepository(localPath, ref)
 	}
 
-	dir, commitSHA, cleanup, err := gitpkg.Clone(ctx, repoURL, "", ref)
+	dir, commitSHA, cleanup, err := gitpkg.Clone(ctx, repoURL, gitpkg.Auth{}, ref)
 	if err != nil {
 		return nil, err
 	}
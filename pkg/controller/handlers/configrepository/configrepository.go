@@ -0,0 +1,382 @@
+// Package configrepository keeps governance objects, such as access control
+// rules and model access policies, in sync with YAML manifests in a git
+// repository.
+//
+// Each manifest is an object as the storage API would return it, less what
+// Obot sets: apiVersion, kind, metadata.name and spec. An object the
+// repository creates, or adopts, is labeled with the repository's name. The
+// label is what makes it read-only through the API, and what a sync compares
+// against to find objects whose manifests were removed. Those are deleted when
+// the repository prunes and released otherwise. Neither happens while any
+// manifest fails to parse, since the objects such a manifest describes would
+// look removed.
+package configrepository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/obot-platform/nah/pkg/router"
+	"github.com/obot-platform/obot/apiclient/types"
+	gclient "github.com/obot-platform/obot/pkg/gateway/client"
+	gitpkg "github.com/obot-platform/obot/pkg/git"
+	"github.com/obot-platform/obot/pkg/gitcredential"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const syncInterval = 15 * time.Minute
+
+type fetchedRepository struct {
+	RepoRoot  string
+	CommitSHA string
+	Cleanup   func()
+}
+
+type repositoryFetcher interface {
+	Fetch(ctx context.Context, repoURL string, auth gitpkg.Auth, ref string) (*fetchedRepository, error)
+}
+
+type gitRepositoryFetcher struct{}
+
+func (gitRepositoryFetcher) Fetch(ctx context.Context, repoURL string, auth gitpkg.Auth, ref string) (*fetchedRepository, error) {
+	dir, commitSHA, cleanup, err := gitpkg.Clone(ctx, repoURL, auth, ref)
+	if err != nil {
+		return nil, err
+	}
+	return &fetchedRepository{
+		RepoRoot:  dir,
+		CommitSHA: commitSHA,
+		Cleanup:   cleanup,
+	}, nil
+}
+
+type Handler struct {
+	fetcher       repositoryFetcher
+	gatewayClient *gclient.Client
+	now           func() time.Time
+}
+
+func New(gatewayClient *gclient.Client) *Handler {
+	return &Handler{
+		fetcher:       gitRepositoryFetcher{},
+		gatewayClient: gatewayClient,
+		now:           time.Now,
+	}
+}
+
+func (h *Handler) Sync(req router.Request, resp router.Response) error {
+	repo := req.Object.(*v1.ConfigRepository)
+	namespace := repo.Namespace
+
+	forceSync := repo.Annotations[v1.ConfigRepositorySyncAnnotation] == "true"
+	if !forceSync && !repo.Status.LastSyncTime.IsZero() {
+		timeSinceLastSync := h.now().Sub(repo.Status.LastSyncTime.Time)
+		if timeSinceLastSync < syncInterval {
+			resp.RetryAfter(syncInterval - timeSinceLastSync)
+			return nil
+		}
+	}
+
+	repo.Status.IsSyncing = true
+	if err := req.Client.Status().Update(req.Ctx, repo); err != nil {
+		return fmt.Errorf("failed to mark config repository syncing: %w", err)
+	}
+
+	defer h.clearIsSyncing(req.Ctx, req.Client, namespace, repo.Name)
+
+	commitSHA, objects, err := h.sync(req.Ctx, req.Client, repo)
+	if err != nil {
+		if statusErr := h.recordFailure(req.Ctx, req.Client, namespace, repo.Name, err); statusErr != nil {
+			return statusErr
+		}
+	} else if err := h.recordResult(req.Ctx, req.Client, namespace, repo.Name, commitSHA, objects); err != nil {
+		return err
+	}
+
+	if forceSync {
+		if err := clearSyncAnnotation(req.Ctx, req.Client, namespace, repo.Name); err != nil {
+			return err
+		}
+	}
+
+	resp.RetryAfter(syncInterval)
+	return nil
+}
+
+// sync applies the repository's manifests and returns the commit they were
+// read at and the outcome for each. An error means nothing was applied.
+func (h *Handler) sync(ctx context.Context, c kclient.Client, repo *v1.ConfigRepository) (string, []types.ConfigRepositoryObject, error) {
+	auth, err := gitcredential.Resolve(ctx, c, h.gatewayClient, repo.Namespace, repo.Spec.GitCredentialID, repo.Spec.RepoURL)
+	if err != nil {
+		return "", nil, err
+	}
+	fetched, err := h.fetcher.Fetch(ctx, repo.Spec.RepoURL, auth, repo.Spec.Ref)
+	if err != nil {
+		return "", nil, err
+	}
+	defer fetched.Cleanup()
+
+	// The path is looked up through an os.Root so a symlink in the
+	// repository cannot point it outside the checkout.
+	root, err := os.OpenRoot(fetched.RepoRoot)
+	if err != nil {
+		return "", nil, err
+	}
+	defer root.Close()
+	relDir := filepath.FromSlash(repo.Spec.Path)
+	if relDir == "" {
+		relDir = "."
+	}
+	info, err := root.Stat(relDir)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read path %q: %w", repo.Spec.Path, err)
+	}
+	if !info.IsDir() {
+		return "", nil, fmt.Errorf("path %q is not a directory", repo.Spec.Path)
+	}
+	dir := filepath.Join(fetched.RepoRoot, relDir)
+
+	manifests, failed, err := readManifests(fetched.RepoRoot, dir)
+	if err != nil {
+		return "", nil, err
+	}
+
+	objects, err := apply(ctx, c, repo, manifests, len(failed) == 0)
+	if err != nil {
+		return "", nil, err
+	}
+	return fetched.CommitSHA, append(failed, objects...), nil
+}
+
+// apply creates, updates and adopts the objects the manifests describe. When
+// removeStale is set, managed objects the manifests no longer describe are
+// pruned or released.
+func apply(ctx context.Context, c kclient.Client, repo *v1.ConfigRepository, manifests []manifest, removeStale bool) ([]types.ConfigRepositoryObject, error) {
+	results := make([]types.ConfigRepositoryObject, 0, len(manifests))
+	desired := make(map[string]string, len(manifests))
+	for _, m := range manifests {
+		result := types.ConfigRepositoryObject{
+			Kind: m.kind,
+			Name: m.object.GetName(),
+			File: m.file,
+		}
+		if file, ok := desired[m.key()]; ok {
+			result.Action = types.ConfigRepositoryActionFailed
+			result.Error = fmt.Sprintf("%s %s is also defined in %s", m.kind, m.object.GetName(), file)
+			results = append(results, result)
+			continue
+		}
+		desired[m.key()] = m.file
+
+		action, err := applyManifest(ctx, c, repo, m)
+		if err != nil {
+			action = types.ConfigRepositoryActionFailed
+			result.Error = err.Error()
+		}
+		result.Action = action
+		results = append(results, result)
+	}
+
+	if !removeStale {
+		return results, nil
+	}
+
+	for _, kindName := range kindNames() {
+		list := kinds[kindName].newList()
+		if err := c.List(ctx, list, kclient.InNamespace(repo.Namespace)); err != nil {
+			return nil, fmt.Errorf("failed to list %s objects: %w", kindName, err)
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			obj := item.(kclient.Object)
+			if v1.ManagedByConfigRepository(obj) != repo.Name {
+				continue
+			}
+			if _, ok := desired[kindName+"/"+obj.GetName()]; ok {
+				continue
+			}
+
+			result := types.ConfigRepositoryObject{Kind: kindName, Name: obj.GetName()}
+			if repo.Spec.Prune {
+				result.Action = types.ConfigRepositoryActionPruned
+				err = c.Delete(ctx, obj)
+				if apierrors.IsNotFound(err) {
+					err = nil
+				}
+			} else {
+				result.Action = types.ConfigRepositoryActionReleased
+				err = release(ctx, c, obj)
+			}
+			if err != nil {
+				result.Action = types.ConfigRepositoryActionFailed
+				result.Error = err.Error()
+			}
+			results = append(results, result)
+		}
+	}
+
+	return results, nil
+}
+
+func applyManifest(ctx context.Context, c kclient.Client, repo *v1.ConfigRepository, m manifest) (types.ConfigRepositoryAction, error) {
+	existing := kinds[m.kind].newObject()
+	if err := c.Get(ctx, router.Key(repo.Namespace, m.object.GetName()), existing); apierrors.IsNotFound(err) {
+		m.object.SetNamespace(repo.Namespace)
+		m.object.SetLabels(map[string]string{v1.ConfigRepositoryLabel: repo.Name})
+		if err := c.Create(ctx, m.object); err != nil {
+			return "", fmt.Errorf("failed to create: %w", err)
+		}
+		return types.ConfigRepositoryActionCreated, nil
+	} else if err != nil {
+		return "", fmt.Errorf("failed to get: %w", err)
+	}
+
+	action := types.ConfigRepositoryActionUpdated
+	switch owner := v1.ManagedByConfigRepository(existing); {
+	case owner == repo.Name:
+	case owner != "":
+		return "", fmt.Errorf("already managed by config repository %s", owner)
+	case !repo.Spec.Adopt:
+		return "", fmt.Errorf("already exists and is not managed by a config repository; enable adopt to manage it from this one")
+	default:
+		action = types.ConfigRepositoryActionAdopted
+	}
+	if !existing.GetDeletionTimestamp().IsZero() {
+		return "", fmt.Errorf("is being deleted")
+	}
+
+	current, desired := spec(existing), spec(m.object)
+	if action == types.ConfigRepositoryActionUpdated && equality.Semantic.DeepEqual(current.Interface(), desired.Interface()) {
+		return types.ConfigRepositoryActionUnchanged, nil
+	}
+
+	current.Set(desired)
+	labels := existing.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels[v1.ConfigRepositoryLabel] = repo.Name
+	existing.SetLabels(labels)
+	if err := c.Update(ctx, existing); err != nil {
+		return "", fmt.Errorf("failed to update: %w", err)
+	}
+	return action, nil
+}
+
+// Release lets go of the objects a deleted config repository managed. They are
+// left in place and become editable through the API again.
+func (*Handler) Release(req router.Request, _ router.Response) error {
+	repo := req.Object.(*v1.ConfigRepository)
+
+	var errs []error
+	for _, kindName := range kindNames() {
+		list := kinds[kindName].newList()
+		if err := req.Client.List(req.Ctx, list, kclient.InNamespace(repo.Namespace)); err != nil {
+			return fmt.Errorf("failed to list %s objects: %w", kindName, err)
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return err
+		}
+		for _, item := range items {
+			obj := item.(kclient.Object)
+			if v1.ManagedByConfigRepository(obj) != repo.Name {
+				continue
+			}
+			if err := release(req.Ctx, req.Client, obj); err != nil {
+				errs = append(errs, fmt.Errorf("failed to release %s %s: %w", kindName, obj.GetName(), err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func release(ctx context.Context, c kclient.Client, obj kclient.Object) error {
+	labels := obj.GetLabels()
+	delete(labels, v1.ConfigRepositoryLabel)
+	obj.SetLabels(labels)
+	if err := c.Update(ctx, obj); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+func (h *Handler) recordFailure(ctx context.Context, c kclient.Client, namespace, name string, syncErr error) error {
+	var repo v1.ConfigRepository
+	if err := c.Get(ctx, router.Key(namespace, name), &repo); err != nil {
+		return fmt.Errorf("failed to reload config repository: %w", err)
+	}
+
+	repo.Status.LastSyncTime = metav1.NewTime(h.now())
+	repo.Status.SyncError = syncErr.Error()
+	return c.Status().Update(ctx, &repo)
+}
+
+func (h *Handler) recordResult(ctx context.Context, c kclient.Client, namespace, name, commitSHA string, objects []types.ConfigRepositoryObject) error {
+	var repo v1.ConfigRepository
+	if err := c.Get(ctx, router.Key(namespace, name), &repo); err != nil {
+		return fmt.Errorf("failed to reload config repository: %w", err)
+	}
+
+	var failed int
+	for _, object := range objects {
+		if object.Action == types.ConfigRepositoryActionFailed {
+			failed++
+		}
+	}
+
+	repo.Status.LastSyncTime = metav1.NewTime(h.now())
+	repo.Status.SyncError = ""
+	if failed > 0 {
+		repo.Status.SyncError = fmt.Sprintf("%d of %d objects failed to sync", failed, len(objects))
+	}
+	repo.Status.ResolvedCommitSHA = commitSHA
+	repo.Status.Objects = objects
+	return c.Status().Update(ctx, &repo)
+}
+
+func (h *Handler) clearIsSyncing(ctx context.Context, c kclient.Client, namespace, name string) {
+	var repo v1.ConfigRepository
+	if err := c.Get(ctx, router.Key(namespace, name), &repo); err != nil {
+		if !apierrors.IsNotFound(err) {
+			slog.Error("failed to reload config repository to clear syncing bit", "repository", name, "error", err)
+		}
+		return
+	}
+
+	if !repo.Status.IsSyncing {
+		return
+	}
+
+	repo.Status.IsSyncing = false
+	if err := c.Status().Update(ctx, &repo); err != nil && !apierrors.IsNotFound(err) {
+		slog.Error("failed to clear syncing bit for config repository", "repository", name, "error", err)
+	}
+}
+
+func clearSyncAnnotation(ctx context.Context, c kclient.Client, namespace, name string) error {
+	var repo v1.ConfigRepository
+	if err := c.Get(ctx, router.Key(namespace, name), &repo); err != nil {
+		return fmt.Errorf("failed to reload config repository for annotation cleanup: %w", err)
+	}
+
+	if _, ok := repo.Annotations[v1.ConfigRepositorySyncAnnotation]; !ok {
+		return nil
+	}
+
+	delete(repo.Annotations, v1.ConfigRepositorySyncAnnotation)
+	return c.Update(ctx, &repo)
+}
@@ -0,0 +1,255 @@
+package configrepository
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/obot-platform/nah/pkg/router"
+	"github.com/obot-platform/obot/apiclient/types"
+	gitpkg "github.com/obot-platform/obot/pkg/git"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	storagescheme "github.com/obot-platform/obot/pkg/storage/scheme"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const messagePolicy = `apiVersion: obot.obot.ai/v1
+kind: MessagePolicy
+metadata:
+  name: no-secrets
+spec:
+  manifest:
+    displayName: No secrets
+    definition: Do not share credentials.
+    direction: both
+    subjects:
+      - type: selector
+        id: "*"
+`
+
+const defaultModelAlias = `# Aliases are shared by every project.
+---
+apiVersion: obot.obot.ai/v1
+kind: DefaultModelAlias
+metadata:
+  name: llm
+spec:
+  manifest:
+    alias: llm
+    model: m1-gpt
+`
+
+type dirFetcher string
+
+func (d dirFetcher) Fetch(context.Context, string, gitpkg.Auth, string) (*fetchedRepository, error) {
+	return &fetchedRepository{RepoRoot: string(d), CommitSHA: "abc123", Cleanup: func() {}}, nil
+}
+
+func writeRepo(t *testing.T, files map[string]string) string {
+	t.Helper()
+	root := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+	return root
+}
+
+func newRepository(prune, adopt bool) *v1.ConfigRepository {
+	return &v1.ConfigRepository{
+		Name:      "cfr1governance",
+		Namespace: "default",
+		Spec: v1.ConfigRepositorySpec{ConfigRepositoryManifest: types.ConfigRepositoryManifest{
+			DisplayName: "Governance",
+			RepoURL:     "https://github.com/example/governance",
+			Path:        "obot",
+			Prune:       prune,
+			Adopt:       adopt,
+		}},
+	}
+}
+
+func runSync(t *testing.T, c kclient.WithWatch, repoDir string, repo *v1.ConfigRepository) *v1.ConfigRepository {
+	t.Helper()
+	h := &Handler{fetcher: dirFetcher(repoDir), now: time.Now}
+	var current v1.ConfigRepository
+	require.NoError(t, c.Get(t.Context(), router.Key(repo.Namespace, repo.Name), &current))
+	// Every call syncs, however recent the last one.
+	current.Status.LastSyncTime = metav1.Time{}
+	require.NoError(t, h.Sync(router.Request{
+		Client:    c,
+		Ctx:       t.Context(),
+		Object:    &current,
+		Namespace: repo.Namespace,
+		Name:      repo.Name,
+	}, &router.ResponseWrapper{}))
+	require.NoError(t, c.Get(t.Context(), router.Key(repo.Namespace, repo.Name), &current))
+	return &current
+}
+
+func newClient(objects ...kclient.Object) kclient.WithWatch {
+	return fake.NewClientBuilder().
+		WithScheme(storagescheme.Scheme).
+		WithStatusSubresource(&v1.ConfigRepository{}).
+		WithObjects(objects...).
+		Build()
+}
+
+func TestSyncCreatesAndUpdates(t *testing.T) {
+	repo := newRepository(false, false)
+	c := newClient(repo)
+	dir := writeRepo(t, map[string]string{
+		"obot/policies/message.yaml": messagePolicy,
+		"obot/aliases.yml":           defaultModelAlias,
+		"obot/README.md":             "not a manifest",
+		"obot/.github/ci.yaml":       "on: push",
+		"other/ignored.yaml":         "outside the configured path",
+	})
+
+	synced := runSync(t, c, dir, repo)
+	assert.Empty(t, synced.Status.SyncError)
+	assert.Equal(t, "abc123", synced.Status.ResolvedCommitSHA)
+	assert.Equal(t, []types.ConfigRepositoryObject{
+		{Kind: "DefaultModelAlias", Name: "llm", File: "obot/aliases.yml", Action: types.ConfigRepositoryActionCreated},
+		{Kind: "MessagePolicy", Name: "no-secrets", File: "obot/policies/message.yaml", Action: types.ConfigRepositoryActionCreated},
+	}, synced.Status.Objects)
+
+	var policy v1.MessagePolicy
+	require.NoError(t, c.Get(t.Context(), router.Key("default", "no-secrets"), &policy))
+	assert.Equal(t, "No secrets", policy.Spec.Manifest.DisplayName)
+	assert.Equal(t, repo.Name, v1.ManagedByConfigRepository(&policy))
+
+	// Drift is reverted on the next sync, and what matches is left alone.
+	policy.Spec.Manifest.DisplayName = "Edited"
+	require.NoError(t, c.Update(t.Context(), &policy))
+
+	synced = runSync(t, c, dir, repo)
+	assert.Equal(t, types.ConfigRepositoryActionUnchanged, synced.Status.Objects[0].Action)
+	assert.Equal(t, types.ConfigRepositoryActionUpdated, synced.Status.Objects[1].Action)
+	require.NoError(t, c.Get(t.Context(), router.Key("default", "no-secrets"), &policy))
+	assert.Equal(t, "No secrets", policy.Spec.Manifest.DisplayName)
+}
+
+func TestSyncAdoptsOnlyWhenAllowed(t *testing.T) {
+	existing := &v1.DefaultModelAlias{
+		Name:      "llm",
+		Namespace: "default",
+		Spec: v1.DefaultModelAliasSpec{Manifest: types.DefaultModelAliasManifest{
+			Alias: "llm",
+			Model: "m1-other",
+		}},
+	}
+	owned := &v1.MessagePolicy{
+		Name:      "no-secrets",
+		Namespace: "default",
+		Labels:    map[string]string{v1.ConfigRepositoryLabel: "cfr1other"},
+	}
+	repo := newRepository(false, false)
+	c := newClient(repo, existing, owned)
+	dir := writeRepo(t, map[string]string{
+		"obot/aliases.yaml": defaultModelAlias,
+		"obot/message.yaml": messagePolicy,
+	})
+
+	synced := runSync(t, c, dir, repo)
+	assert.Equal(t, "2 of 2 objects failed to sync", synced.Status.SyncError)
+	assert.Contains(t, synced.Status.Objects[0].Error, "enable adopt")
+	assert.Contains(t, synced.Status.Objects[1].Error, "already managed by config repository cfr1other")
+
+	synced.Spec.Adopt = true
+	require.NoError(t, c.Update(t.Context(), synced))
+	synced = runSync(t, c, dir, repo)
+	assert.Equal(t, types.ConfigRepositoryActionAdopted, synced.Status.Objects[0].Action)
+	// Adopting never takes an object from another repository.
+	assert.Equal(t, types.ConfigRepositoryActionFailed, synced.Status.Objects[1].Action)
+
+	var alias v1.DefaultModelAlias
+	require.NoError(t, c.Get(t.Context(), router.Key("default", "llm"), &alias))
+	assert.Equal(t, "m1-gpt", alias.Spec.Manifest.Model)
+	assert.Equal(t, repo.Name, v1.ManagedByConfigRepository(&alias))
+}
+
+func TestSyncRemovesStaleObjects(t *testing.T) {
+	for _, prune := range []bool{true, false} {
+		t.Run(map[bool]string{true: "prune", false: "release"}[prune], func(t *testing.T) {
+			repo := newRepository(prune, false)
+			c := newClient(repo)
+			dir := writeRepo(t, map[string]string{
+				"obot/aliases.yaml": defaultModelAlias,
+				"obot/message.yaml": messagePolicy,
+			})
+			runSync(t, c, dir, repo)
+
+			// A manifest that fails to parse holds back removal: the objects it
+			// described would otherwise look removed.
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "obot", "message.yaml"), []byte("kind: [broken"), 0o600))
+			synced := runSync(t, c, dir, repo)
+			assert.Equal(t, "1 of 2 objects failed to sync", synced.Status.SyncError)
+			var policy v1.MessagePolicy
+			require.NoError(t, c.Get(t.Context(), router.Key("default", "no-secrets"), &policy))
+			assert.Equal(t, repo.Name, v1.ManagedByConfigRepository(&policy))
+
+			require.NoError(t, os.Remove(filepath.Join(dir, "obot", "message.yaml")))
+			synced = runSync(t, c, dir, repo)
+			assert.Empty(t, synced.Status.SyncError)
+			require.Len(t, synced.Status.Objects, 2)
+			removed := synced.Status.Objects[1]
+			assert.Equal(t, "MessagePolicy", removed.Kind)
+			assert.Equal(t, "no-secrets", removed.Name)
+
+			err := c.Get(t.Context(), router.Key("default", "no-secrets"), &policy)
+			if prune {
+				assert.Equal(t, types.ConfigRepositoryActionPruned, removed.Action)
+				assert.True(t, apierrors.IsNotFound(err), "pruned policy still exists: %v", err)
+			} else {
+				assert.Equal(t, types.ConfigRepositoryActionReleased, removed.Action)
+				require.NoError(t, err)
+				assert.Empty(t, v1.ManagedByConfigRepository(&policy))
+			}
+		})
+	}
+}
+
+func TestDecodeDocumentRejects(t *testing.T) {
+	for name, doc := range map[string]string{
+		"status":      "apiVersion: obot.obot.ai/v1\nkind: DefaultModelAlias\nmetadata: {name: llm}\nspec: {manifest: {alias: llm, model: m1}}\nstatus: {setAliasName: llm}\n",
+		"unknown":     "apiVersion: obot.obot.ai/v1\nkind: DefaultModelAlias\nmetadata: {name: llm}\nspec: {manifest: {alias: llm, model: m1, extra: true}}\n",
+		"version":     "apiVersion: v1\nkind: DefaultModelAlias\nmetadata: {name: llm}\n",
+		"kind":        "apiVersion: obot.obot.ai/v1\nkind: MCPServer\nmetadata: {name: server}\n",
+		"name":        "apiVersion: obot.obot.ai/v1\nkind: DefaultModelAlias\nmetadata: {name: Not_Valid}\nspec: {manifest: {alias: llm, model: m1}}\n",
+		"invalid":     "apiVersion: obot.obot.ai/v1\nkind: DefaultModelAlias\nmetadata: {name: llm}\nspec: {manifest: {alias: llm}}\n",
+		"secret":      "apiVersion: obot.obot.ai/v1\nkind: MCPWebhookValidation\nmetadata: {name: hook}\nspec: {manifest: {secret: hunter2}}\n",
+		"generated":   "apiVersion: obot.obot.ai/v1\nkind: AccessControlRule\nmetadata: {name: rule}\nspec: {generated: true}\n",
+		"enforcement": "apiVersion: obot.obot.ai/v1\nkind: ToolCallEnforcementSetting\nmetadata: {name: other}\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := decodeDocument([]byte(doc))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestRelease(t *testing.T) {
+	repo := newRepository(true, false)
+	c := newClient(repo)
+	dir := writeRepo(t, map[string]string{"obot/aliases.yaml": defaultModelAlias})
+	runSync(t, c, dir, repo)
+
+	require.NoError(t, (&Handler{}).Release(router.Request{
+		Client: c,
+		Ctx:    t.Context(),
+		Object: repo,
+	}, &router.ResponseWrapper{}))
+
+	var alias v1.DefaultModelAlias
+	require.NoError(t, c.Get(t.Context(), router.Key("default", "llm"), &alias))
+	assert.Empty(t, v1.ManagedByConfigRepository(&alias))
+}
@@ -0,0 +1,293 @@
+package configrepository
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/obot-platform/obot/apiclient/types"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	"github.com/obot-platform/obot/pkg/system"
+	"k8s.io/apimachinery/pkg/util/validation"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	maxManifestFileBytes = 1024 * 1024
+	maxManifests         = 1000
+)
+
+// kind is a type of governance object a config repository can manage. Every
+// one of them keeps its configuration in a Spec field, which is all a manifest
+// sets.
+type kind struct {
+	newObject func() kclient.Object
+	newList   func() kclient.ObjectList
+	// validate checks a manifest's object before it is applied, filling in
+	// anything the API would have defaulted.
+	validate func(kclient.Object) error
+}
+
+var kinds = map[string]kind{
+	"AccessControlRule": {
+		newObject: func() kclient.Object { return &v1.AccessControlRule{} },
+		newList:   func() kclient.ObjectList { return &v1.AccessControlRuleList{} },
+		validate: func(obj kclient.Object) error {
+			rule := obj.(*v1.AccessControlRule)
+			if rule.Spec.Generated {
+				return fmt.Errorf("generated access control rules are managed by Obot")
+			}
+			if rule.Spec.PowerUserWorkspaceID != "" {
+				return fmt.Errorf("workspace access control rules are managed by their workspace")
+			}
+			if rule.Spec.MCPCatalogID == "" {
+				rule.Spec.MCPCatalogID = system.DefaultCatalog
+			}
+			return rule.Spec.Manifest.Validate()
+		},
+	},
+	"ModelAccessPolicy": {
+		newObject: func() kclient.Object { return &v1.ModelAccessPolicy{} },
+		newList:   func() kclient.ObjectList { return &v1.ModelAccessPolicyList{} },
+		validate: func(obj kclient.Object) error {
+			return obj.(*v1.ModelAccessPolicy).Spec.Manifest.Validate()
+		},
+	},
+	"MessagePolicy": {
+		newObject: func() kclient.Object { return &v1.MessagePolicy{} },
+		newList:   func() kclient.ObjectList { return &v1.MessagePolicyList{} },
+		validate: func(obj kclient.Object) error {
+			return obj.(*v1.MessagePolicy).Spec.Manifest.Validate()
+		},
+	},
+	"ToolCallEnforcementSetting": {
+		newObject: func() kclient.Object { return &v1.ToolCallEnforcementSetting{} },
+		newList:   func() kclient.ObjectList { return &v1.ToolCallEnforcementSettingList{} },
+		validate: func(obj kclient.Object) error {
+			if obj.GetName() != system.ToolCallEnforcementSettingName {
+				return fmt.Errorf("the tool call enforcement setting must be named %s", system.ToolCallEnforcementSettingName)
+			}
+			return nil
+		},
+	},
+	"MCPWebhookValidation": {
+		newObject: func() kclient.Object { return &v1.MCPWebhookValidation{} },
+		newList:   func() kclient.ObjectList { return &v1.MCPWebhookValidationList{} },
+		validate: func(obj kclient.Object) error {
+			if obj.(*v1.MCPWebhookValidation).Spec.Manifest.Secret != "" {
+				return fmt.Errorf("webhook secrets must not be stored in source control; configure the secret in Obot")
+			}
+			return nil
+		},
+	},
+	"DefaultModelAlias": {
+		newObject: func() kclient.Object { return &v1.DefaultModelAlias{} },
+		newList:   func() kclient.ObjectList { return &v1.DefaultModelAliasList{} },
+		validate: func(obj kclient.Object) error {
+			manifest := obj.(*v1.DefaultModelAlias).Spec.Manifest
+			if manifest.Alias == "" || manifest.Model == "" {
+				return fmt.Errorf("alias and model are required")
+			}
+			return nil
+		},
+	},
+	"SkillAccessRule": {
+		newObject: func() kclient.Object { return &v1.SkillAccessRule{} },
+		newList:   func() kclient.ObjectList { return &v1.SkillAccessRuleList{} },
+		validate: func(obj kclient.Object) error {
+			return obj.(*v1.SkillAccessRule).Spec.Manifest.Validate()
+		},
+	},
+}
+
+// kindNames returns the managed kinds in a stable order.
+func kindNames() []string {
+	return slices.Sorted(maps.Keys(kinds))
+}
+
+// spec returns the Spec field of an object of one of the kinds.
+func spec(obj kclient.Object) reflect.Value {
+	return reflect.ValueOf(obj).Elem().FieldByName("Spec")
+}
+
+// document is one YAML document in a config repository. It has the shape of
+// the object as the storage API returns it, less everything Obot sets.
+type document struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Metadata   struct {
+		Name string `json:"name"`
+	} `json:"metadata"`
+	Spec json.RawMessage `json:"spec"`
+}
+
+// manifest is a document decoded into the object it describes.
+type manifest struct {
+	file   string
+	kind   string
+	object kclient.Object
+}
+
+func (m manifest) key() string {
+	return m.kind + "/" + m.object.GetName()
+}
+
+// readManifests decodes every YAML document under dir. Documents that cannot be
+// decoded are returned as failed results rather than stopping the others.
+// Files are named relative to repoRoot.
+func readManifests(repoRoot, dir string) ([]manifest, []types.ConfigRepositoryObject, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(currentPath string, entry fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		// Hidden directories hold tooling, such as .git and .github, rather
+		// than configuration.
+		if entry.IsDir() && currentPath != dir && strings.HasPrefix(entry.Name(), ".") {
+			return filepath.SkipDir
+		}
+		if entry.Type()&os.ModeSymlink != 0 || entry.IsDir() {
+			return nil
+		}
+		if ext := filepath.Ext(entry.Name()); ext == ".yaml" || ext == ".yml" {
+			files = append(files, currentPath)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find manifests: %w", err)
+	}
+	slices.Sort(files)
+
+	var (
+		manifests []manifest
+		failed    []types.ConfigRepositoryObject
+		count     int
+	)
+	for _, file := range files {
+		relPath, err := filepath.Rel(repoRoot, file)
+		if err != nil {
+			return nil, nil, err
+		}
+		relPath = filepath.ToSlash(relPath)
+
+		docs, err := readDocuments(file)
+		if err != nil {
+			failed = append(failed, types.ConfigRepositoryObject{
+				File:   relPath,
+				Action: types.ConfigRepositoryActionFailed,
+				Error:  err.Error(),
+			})
+			continue
+		}
+		for i, doc := range docs {
+			if count++; count > maxManifests {
+				return nil, nil, fmt.Errorf("too many manifests (limit: %d)", maxManifests)
+			}
+			m, err := decodeDocument(doc)
+			if err != nil {
+				if len(docs) > 1 {
+					err = fmt.Errorf("document %d: %w", i+1, err)
+				}
+				failed = append(failed, types.ConfigRepositoryObject{
+					File:   relPath,
+					Action: types.ConfigRepositoryActionFailed,
+					Error:  err.Error(),
+				})
+				continue
+			}
+			m.file = relPath
+			manifests = append(manifests, m)
+		}
+	}
+
+	return manifests, failed, nil
+}
+
+func readDocuments(file string) ([][]byte, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	content, err := io.ReadAll(io.LimitReader(f, maxManifestFileBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(content) > maxManifestFileBytes {
+		return nil, fmt.Errorf("file exceeds maximum size of %d bytes", maxManifestFileBytes)
+	}
+
+	var (
+		docs   [][]byte
+		reader = utilyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(content)))
+	)
+	for {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return docs, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to split YAML documents: %w", err)
+		}
+		if len(bytes.TrimSpace(doc)) == 0 || isComment(doc) {
+			continue
+		}
+		docs = append(docs, doc)
+	}
+}
+
+// isComment reports whether a document is nothing but comments, as a file
+// that starts with a license header before its first "---" would be.
+func isComment(doc []byte) bool {
+	for line := range bytes.Lines(doc) {
+		if line = bytes.TrimSpace(line); len(line) > 0 && line[0] != '#' {
+			return false
+		}
+	}
+	return true
+}
+
+func decodeDocument(doc []byte) (manifest, error) {
+	var d document
+	if err := yaml.UnmarshalStrict(doc, &d); err != nil {
+		return manifest{}, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+	if d.APIVersion != v1.SchemeGroupVersion.String() {
+		return manifest{}, fmt.Errorf("apiVersion must be %s", v1.SchemeGroupVersion.String())
+	}
+	k, ok := kinds[d.Kind]
+	if !ok {
+		return manifest{}, fmt.Errorf("kind %q cannot be managed by a config repository; supported kinds are %s", d.Kind, strings.Join(kindNames(), ", "))
+	}
+	if errs := validation.IsDNS1123Subdomain(d.Metadata.Name); len(errs) > 0 {
+		return manifest{}, fmt.Errorf("invalid metadata.name %q: %s", d.Metadata.Name, strings.Join(errs, "; "))
+	}
+
+	obj := k.newObject()
+	obj.SetName(d.Metadata.Name)
+	if len(d.Spec) > 0 {
+		dec := json.NewDecoder(bytes.NewReader(d.Spec))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(spec(obj).Addr().Interface()); err != nil {
+			return manifest{}, fmt.Errorf("invalid spec: %w", err)
+		}
+	}
+	if err := k.validate(obj); err != nil {
+		return manifest{}, fmt.Errorf("invalid %s %s: %w", d.Kind, d.Metadata.Name, err)
+	}
+
+	return manifest{kind: d.Kind, object: obj}, nil
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/obot-platform/nah/pkg/router"
+	"github.com/obot-platform/obot/apiclient/types"
 	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
 	storagescheme "github.com/obot-platform/obot/pkg/storage/scheme"
 	"github.com/stretchr/testify/assert"
@@ -35,6 +36,13 @@ func TestSyncReferences(t *testing.T) {
 				"https://github.com/obot-platform/system-catalog": credential.Name,
 			}},
 		},
+		&v1.ConfigRepository{
+			Name: "config", Namespace: "default",
+			Spec: v1.ConfigRepositorySpec{ConfigRepositoryManifest: types.ConfigRepositoryManifest{
+				DisplayName:     "Governance",
+				GitCredentialID: credential.Name,
+			}},
+		},
 	).Build()
 
 	err := (&Handler{}).SyncReferences(router.Request{
@@ -55,6 +63,7 @@ func TestSyncReferences(t *testing.T) {
 			ID:          "system-catalog",
 			DisplayName: "https://github.com/obot-platform/system-catalog",
 		}},
+		ConfigRepositories: []v1.GitCredentialReference{{ID: "config", DisplayName: "Governance"}},
 	}, credential.Status.References)
 }
 
@@ -17,7 +17,9 @@ import (
 	"github.com/obot-platform/obot/apiclient/types"
 	"github.com/obot-platform/obot/pkg/agentbackend"
 	"github.com/obot-platform/obot/pkg/hash"
+	"github.com/obot-platform/obot/pkg/hostedagentactivity"
 	"github.com/obot-platform/obot/pkg/hostedagentrefs"
+	"github.com/obot-platform/obot/pkg/hostedagentsnapshot"
 	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -167,6 +169,11 @@ func (h *Handler) EnsurePool(req router.Request, _ router.Response) error {
 				Capacity:     defaults.Spec.Manifest.Capacity,
 				MaxSandboxes: defaults.Spec.Manifest.MaxSandboxes,
 				Suspended:    defaults.Spec.Manifest.Suspended,
+
+				IdleTimeoutMinutes: defaults.Spec.Manifest.IdleTimeoutMinutes,
+
+				SnapshotOnDelete:      defaults.Spec.Manifest.SnapshotOnDelete,
+				SnapshotRetentionDays: defaults.Spec.Manifest.SnapshotRetentionDays,
 			},
 		},
 	}
@@ -275,6 +282,18 @@ func (h *Handler) OrchestrateInstance(req router.Request, resp router.Response)
 		return err
 	}
 
+	var pool v1.HostedAgentPool
+	if err := req.Get(&pool, req.Namespace, instance.Spec.PoolID); err != nil {
+		return err
+	}
+
+	// Hibernation is decided before the desired state is built, so the pass
+	// that notices an idle sandbox is the one that stops it.
+	idleTimeout := hostedagentactivity.IdleTimeout(agent.Spec.Manifest, pool.Spec.Manifest)
+	if hibernationDue(instance, idleTimeout, h.now()) {
+		instance.Status.Hibernated = true
+	}
+
 	// The credential is resolved before the config rather than attached after
 	// it, because the config embeds it: every MCP header and model key in there
 	// is this credential, so it has to exist before the config is rendered.
@@ -302,10 +321,6 @@ func (h *Handler) OrchestrateInstance(req router.Request, resp router.Response)
 	// Carrying the result in desired state means resizing a pool changes every
 	// sandbox's revision and restarts it, which is the only way a running
 	// sandbox picks up new limits.
-	var pool v1.HostedAgentPool
-	if err := req.Get(&pool, req.Namespace, instance.Spec.PoolID); err != nil {
-		return err
-	}
 	desired.Requests, desired.Limits, _ = agentbackend.SandboxShare(agentbackend.ResourceQuantity{
 		CPUVCPUs:     pool.Spec.Manifest.Capacity.CPUVCPUs,
 		MemoryBytes:  pool.Spec.Manifest.Capacity.MemoryBytes,
@@ -324,7 +339,7 @@ func (h *Handler) OrchestrateInstance(req router.Request, resp router.Response)
 	previous := instance.Status
 	h.applyObservation(instance, desired.Revision, observation)
 	interval := pollInterval(instance.Status.State)
-	resp.RetryAfter(interval)
+	resp.RetryAfter(min(interval, untilHibernation(instance, idleTimeout, h.now())))
 
 	// A status write emits a change event, which reconciles this instance again
 	// straight away. Writing unconditionally therefore means the controller
@@ -350,7 +365,28 @@ func statusUnchanged(a, b v1.HostedAgentInstanceStatus) bool {
 		a.Message == b.Message &&
 		a.ObservedRevision == b.ObservedRevision &&
 		a.BackendID == b.BackendID &&
-		a.BackendGeneration == b.BackendGeneration
+		a.BackendGeneration == b.BackendGeneration &&
+		a.Hibernated == b.Hibernated
+}
+
+// hibernationDue reports whether a running instance has gone unused for longer
+// than its idle timeout. Only a ready instance hibernates: one still starting
+// has not had the chance to be used.
+func hibernationDue(instance *v1.HostedAgentInstance, timeout time.Duration, now time.Time) bool {
+	return timeout > 0 &&
+		!instance.Stopped() &&
+		instance.Status.State == types.HostedAgentStateReady &&
+		!now.Before(hostedagentactivity.IdleSince(instance).Add(timeout))
+}
+
+// untilHibernation is how long until the instance is due to hibernate, so the
+// controller looks again then rather than at the next ready poll. It is never
+// shorter than a second, and unbounded when the instance cannot hibernate.
+func untilHibernation(instance *v1.HostedAgentInstance, timeout time.Duration, now time.Time) time.Duration {
+	if timeout <= 0 || instance.Stopped() || instance.Status.State != types.HostedAgentStateReady {
+		return readyPollInterval
+	}
+	return max(hostedagentactivity.IdleSince(instance).Add(timeout).Sub(now), time.Second)
 }
 
 func heartbeatDue(last *metav1.Time, now time.Time, interval time.Duration) bool {
@@ -364,6 +400,13 @@ func (h *Handler) RemoveInstance(req router.Request, resp router.Response) error
 	if h.backend == nil {
 		return fmt.Errorf("hosted agent backend is not configured")
 	}
+	if done, err := h.finalSnapshot(req, instance); err != nil {
+		return err
+	} else if !done {
+		resp.RetryAfter(transitionalPollInterval)
+		return nil
+	}
+
 	ref := instanceRef(instance)
 	result, err := h.backend.DeleteInstance(req.Ctx, ref)
 	if err != nil {
@@ -387,6 +430,22 @@ func (h *Handler) RemoveInstance(req router.Request, resp router.Response) error
 	return nil
 }
 
+// finalSnapshot takes the pre-delete snapshot when the instance's pool keeps
+// one and the backend can take it. It reports whether deletion may proceed.
+func (h *Handler) finalSnapshot(req router.Request, instance *v1.HostedAgentInstance) (bool, error) {
+	if _, ok := h.backend.(agentbackend.SnapshotBackend); !ok || instance.Spec.PoolID == "" {
+		return true, nil
+	}
+	var pool v1.HostedAgentPool
+	if err := req.Get(&pool, req.Namespace, instance.Spec.PoolID); apierrors.IsNotFound(err) {
+		// The pool's volume goes with it, so there is nothing left to keep.
+		return true, nil
+	} else if err != nil {
+		return false, err
+	}
+	return hostedagentsnapshot.EnsureFinal(req.Ctx, req.Client, instance, pool.Spec.Manifest, h.now())
+}
+
 // instanceCredential returns the sandbox's own credential and an opaque version
 // that changes when it is rotated.
 func (h *Handler) instanceCredential(ctx context.Context, instance *v1.HostedAgentInstance) (string, string, error) {
@@ -441,6 +500,18 @@ func (h *Handler) applyObservation(instance *v1.HostedAgentInstance, desiredRevi
 	case observation.State == agentbackend.StateError:
 		instance.Status.State = types.HostedAgentStateError
 		instance.Status.Error = observation.Message
+	case observation.State == agentbackend.StateStopped:
+		instance.Status.State = types.HostedAgentStateStopped
+		// The backend cannot tell a hibernated sandbox from a stopped one, and
+		// the difference is what the user needs to know: one comes back on its
+		// own, the other has to be started.
+		if instance.RestorePending() {
+			instance.Status.Reason = "Restoring"
+			instance.Status.Message = "the agent's workspace is being restored from a snapshot and it starts again afterwards"
+		} else if instance.Status.Hibernated {
+			instance.Status.Reason = "Hibernated"
+			instance.Status.Message = "the agent was stopped for being idle and starts again on the next connection"
+		}
 	case observation.Exists &&
 		observation.State == agentbackend.StateReady &&
 		observation.ObservedRevision == desiredRevision:
@@ -467,7 +538,7 @@ func instanceRef(instance *v1.HostedAgentInstance) agentbackend.InstanceRef {
 }
 
 func pollInterval(state types.HostedAgentState) time.Duration {
-	if state == types.HostedAgentStateReady {
+	if state == types.HostedAgentStateReady || state == types.HostedAgentStateStopped {
 		return readyPollInterval
 	}
 	return transitionalPollInterval
@@ -602,6 +673,7 @@ func (b defaultDesiredBuilder) Build(ctx context.Context, in BuildInput) (agentb
 		Source:   source,
 		Port:     manifest.Port,
 		Terminal: manifest.Terminal,
+		Stopped:  instance.Stopped(),
 		Env:      env,
 		// The configuration itself carries no credential, so it is an ordinary
 		// world-readable file: it can be inspected, logged or copied without
@@ -230,3 +230,79 @@ func TestHeartbeatDue(t *testing.T) {
 		t.Error("an instance that has never been observed is always due")
 	}
 }
+
+func TestHibernationDue(t *testing.T) {
+	created := time.Unix(1000, 0)
+	instance := &v1.HostedAgentInstance{}
+	instance.CreationTimestamp = metav1.NewTime(created)
+	instance.Status.State = types2.HostedAgentStateReady
+
+	if hibernationDue(instance, 0, created.Add(24*time.Hour)) {
+		t.Error("a zero timeout must never hibernate")
+	}
+	if hibernationDue(instance, time.Hour, created.Add(59*time.Minute)) {
+		t.Error("an instance must not hibernate before its timeout")
+	}
+	if !hibernationDue(instance, time.Hour, created.Add(time.Hour)) {
+		t.Error("an instance never used must hibernate an idle timeout after creation")
+	}
+
+	active := metav1.NewTime(created.Add(30 * time.Minute))
+	instance.Status.LastActivityTime = &active
+	if hibernationDue(instance, time.Hour, created.Add(time.Hour)) {
+		t.Error("recorded activity must push the deadline out")
+	}
+	if got := untilHibernation(instance, time.Hour, created.Add(time.Hour)); got != 30*time.Minute {
+		t.Errorf("untilHibernation = %s, want 30m", got)
+	}
+
+	instance.Status.State = types2.HostedAgentStatePending
+	if hibernationDue(instance, time.Hour, created.Add(24*time.Hour)) {
+		t.Error("an instance that is not ready must not hibernate")
+	}
+
+	instance.Status.State = types2.HostedAgentStateReady
+	instance.Spec.DesiredState = types2.HostedAgentDesiredStateStopped
+	if hibernationDue(instance, time.Hour, created.Add(24*time.Hour)) {
+		t.Error("a stopped instance must not hibernate")
+	}
+}
+
+func TestApplyObservationDistinguishesHibernatedFromStopped(t *testing.T) {
+	handler := &Handler{now: time.Now}
+	observation := agentbackend.InstanceObservation{
+		Exists:           true,
+		State:            agentbackend.StateStopped,
+		ObservedRevision: "wanted",
+		Reason:           "Stopped",
+		Message:          "the sandbox is stopped",
+	}
+
+	stopped := &v1.HostedAgentInstance{}
+	handler.applyObservation(stopped, "wanted", observation)
+	if stopped.Status.State != types2.HostedAgentStateStopped || stopped.Status.Reason != "Stopped" {
+		t.Fatalf("unexpected stopped status: %#v", stopped.Status)
+	}
+
+	hibernated := &v1.HostedAgentInstance{}
+	hibernated.Status.Hibernated = true
+	handler.applyObservation(hibernated, "wanted", observation)
+	if hibernated.Status.State != types2.HostedAgentStateStopped || hibernated.Status.Reason != "Hibernated" {
+		t.Fatalf("unexpected hibernated status: %#v", hibernated.Status)
+	}
+
+	restoring := &v1.HostedAgentInstance{}
+	restoring.Spec.Restore = &v1.HostedAgentInstanceRestore{SnapshotName: "has1abc", ID: "r1"}
+	if !restoring.Stopped() {
+		t.Fatal("an instance with a pending restore must be stopped")
+	}
+	handler.applyObservation(restoring, "wanted", observation)
+	if restoring.Status.Reason != "Restoring" {
+		t.Fatalf("unexpected restoring status: %#v", restoring.Status)
+	}
+
+	restoring.Status.RestoredID = "r1"
+	if restoring.Stopped() {
+		t.Fatal("a finished restore must not keep the instance stopped")
+	}
+}
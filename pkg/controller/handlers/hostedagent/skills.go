@@ -42,7 +42,7 @@ type skillFetcher struct {
 	cache map[string][]agentbackend.File
 
 	// clone is injectable so tests do not need a git server.
-	clone func(ctx context.Context, repoURL, token, ref string) (string, string, func(), error)
+	clone func(ctx context.Context, repoURL string, auth gitpkg.Auth, ref string) (string, string, func(), error)
 }
 
 func newSkillFetcher() *skillFetcher {
@@ -57,7 +57,8 @@ func newSkillFetcher() *skillFetcher {
 //
 // A skill that cannot be fetched is skipped rather than failing the sandbox: an
 // agent usually has several, and one unreachable repository should not stop it
-// from starting.
+// from starting. So is one that could not be installed any other way, because
+// it is invalid or its security scan blocked it and no admin overrode that.
 func (f *skillFetcher) skillFiles(ctx context.Context, client kclient.Client, namespace string, ids []string) ([]agentbackend.File, []agentConfigSkill) {
 	var (
 		files   []agentbackend.File
@@ -79,6 +80,10 @@ func (f *skillFetcher) skillFiles(ctx context.Context, client kclient.Client, na
 			slog.Warn("Skipping hosted agent skill", "skill", id, "error", err)
 			continue
 		}
+		if !skill.Installable() {
+			slog.Warn("Skipping hosted agent skill that is not installable", "skill", id, "valid", skill.Status.Valid, "scanBlocked", skill.Status.ScanBlocked)
+			continue
+		}
 
 		name := skillDirName(&skill)
 		mount := path.Join(skillsMountRoot, name)
@@ -156,7 +161,7 @@ func (f *skillFetcher) fetch(ctx context.Context, skill *v1.Skill, mount string)
 	if ref == "" {
 		ref = skill.Spec.RepoRef
 	}
-	dir, _, cleanup, err := f.clone(ctx, skill.Spec.RepoURL, "", ref)
+	dir, _, cleanup, err := f.clone(ctx, skill.Spec.RepoURL, gitpkg.Auth{}, ref)
 	if err != nil {
 		return nil, fmt.Errorf("clone %s: %w", skill.Spec.RepoURL, err)
 	}
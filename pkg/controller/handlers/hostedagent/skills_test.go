@@ -9,13 +9,14 @@ import (
 
 	"github.com/obot-platform/obot/apiclient/types"
 	"github.com/obot-platform/obot/pkg/agentbackend"
+	gitpkg "github.com/obot-platform/obot/pkg/git"
 	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
 	storagescheme "github.com/obot-platform/obot/pkg/storage/scheme"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 // fakeRepo writes a skill directory and returns a clone function serving it.
-func fakeRepo(t *testing.T, files map[string]string) (func(context.Context, string, string, string) (string, string, func(), error), *int) {
+func fakeRepo(t *testing.T, files map[string]string) (func(context.Context, string, gitpkg.Auth, string) (string, string, func(), error), *int) {
 	t.Helper()
 	dir := t.TempDir()
 	for name, content := range files {
@@ -28,7 +29,7 @@ func fakeRepo(t *testing.T, files map[string]string) (func(context.Context, stri
 		}
 	}
 	calls := 0
-	return func(context.Context, string, string, string) (string, string, func(), error) {
+	return func(context.Context, string, gitpkg.Auth, string) (string, string, func(), error) {
 		calls++
 		return dir, "sha", func() {}, nil
 	}, &calls
@@ -60,6 +61,7 @@ func TestSkillFilesArePlacedInTheSandbox(t *testing.T) {
 			RepoURL:       "https://example.com/skills.git",
 			CommitSHA:     "abc123",
 		},
+		Status: v1.SkillStatus{Valid: true},
 	}).Build()
 
 	files, entries := fetcher.skillFiles(context.Background(), client, "obot", []string{"sk1pdf"})
@@ -122,7 +124,8 @@ func TestUnfetchableSkillIsSkipped(t *testing.T) {
 	fetcher := &skillFetcher{cache: map[string][]agentbackend.File{}}
 	client := skillClient(&v1.Skill{
 		Name: "sk1broken", Namespace: "obot",
-		Spec: v1.SkillSpec{SkillManifest: types.SkillManifest{Name: "broken"}},
+		Spec:   v1.SkillSpec{SkillManifest: types.SkillManifest{Name: "broken"}},
+		Status: v1.SkillStatus{Valid: true},
 	}).Build()
 
 	files, entries := fetcher.skillFiles(context.Background(), client, "obot", []string{"sk1broken", "sk1missing"})
@@ -130,3 +133,39 @@ func TestUnfetchableSkillIsSkipped(t *testing.T) {
 		t.Fatalf("expected nothing for unfetchable skills: %v %v", files, entries)
 	}
 }
+
+// A skill its security scan blocked is delivered only once an admin overrides
+// the block for its current content, as for any other install.
+func TestScanBlockedSkillIsNotDelivered(t *testing.T) {
+	clone, calls := fakeRepo(t, map[string]string{"SKILL.md": "# Risky skill"})
+	fetcher := &skillFetcher{cache: map[string][]agentbackend.File{}, clone: clone}
+	blocked := &v1.Skill{
+		Name: "sk1risky", Namespace: "obot",
+		Spec: v1.SkillSpec{
+			SkillManifest: types.SkillManifest{Name: "risky"},
+			RepoURL:       "https://example.com/skills.git",
+			CommitSHA:     "abc123",
+			InstallHash:   "hash1",
+		},
+		Status: v1.SkillStatus{Valid: true, ScanBlocked: true},
+	}
+	invalid := &v1.Skill{
+		Name: "sk1invalid", Namespace: "obot",
+		Spec: v1.SkillSpec{
+			SkillManifest: types.SkillManifest{Name: "invalid"},
+			RepoURL:       "https://example.com/skills.git",
+			CommitSHA:     "abc123",
+		},
+	}
+
+	files, entries := fetcher.skillFiles(context.Background(), skillClient(blocked, invalid).Build(), "obot", []string{"sk1risky", "sk1invalid"})
+	if len(files) != 0 || len(entries) != 0 || *calls != 0 {
+		t.Fatalf("uninstallable skills were delivered: %v %v (clones: %d)", files, entries, *calls)
+	}
+
+	blocked.Spec.ScanOverride = &v1.SkillScanOverride{InstallHash: "hash1"}
+	_, entries = fetcher.skillFiles(context.Background(), skillClient(blocked).Build(), "obot", []string{"sk1risky"})
+	if len(entries) != 1 || entries[0].ID != "sk1risky" {
+		t.Fatalf("overridden skill was not delivered: %v", entries)
+	}
+}
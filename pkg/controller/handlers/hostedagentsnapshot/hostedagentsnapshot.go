@@ -0,0 +1,259 @@
+// Package hostedagentsnapshot captures hosted agent workspace snapshots into
+// the blob store, restores them into instances, and removes them when they
+// expire or are deleted.
+package hostedagentsnapshot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"time"
+
+	"github.com/obot-platform/nah/pkg/router"
+	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/obot-platform/obot/pkg/agentbackend"
+	"github.com/obot-platform/obot/pkg/hostedagentsnapshot"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	"github.com/obot-platform/obot/pkg/storage/blob"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// pendingSnapshotPollInterval is how often a restore waiting on a snapshot
+	// that is still being captured looks again.
+	pendingSnapshotPollInterval = 5 * time.Second
+
+	// A capture that fails while streaming, such as when the sandbox's pod is
+	// being rescheduled or the blob store is briefly unreachable, is retried
+	// with exponential backoff before the snapshot is marked failed.
+	maxCaptureAttempts  = 5
+	captureRetryBackoff = 15 * time.Second
+
+	errUnsupported = "the hosted agent backend does not support workspace snapshots"
+)
+
+type Handler struct {
+	backend agentbackend.SnapshotBackend
+	store   blob.BlobStore
+	bucket  string
+	now     func() time.Time
+}
+
+// New returns a handler for the given backend. The backend may not support
+// snapshots, in which case every snapshot fails with a clear error rather than
+// waiting forever.
+func New(backend agentbackend.InstanceBackend, store blob.BlobStore, bucket string) *Handler {
+	snapshots, _ := backend.(agentbackend.SnapshotBackend)
+	return &Handler{
+		backend: snapshots,
+		store:   store,
+		bucket:  bucket,
+		now:     time.Now,
+	}
+}
+
+// Capture streams a new snapshot's workspace into the blob store. A snapshot is
+// captured once: one that is ready or failed is left alone, and a failed one is
+// retaken by creating another. Failures that a retry may fix are retried with
+// backoff, the others fail the snapshot straight away.
+func (h *Handler) Capture(req router.Request, resp router.Response) error {
+	snapshot := req.Object.(*v1.HostedAgentSnapshot)
+	if hostedagentsnapshot.Finished(snapshot) || !snapshot.DeletionTimestamp.IsZero() {
+		return nil
+	}
+
+	now := h.now()
+	if next := snapshot.Status.NextAttemptAt; next != nil {
+		if wait := next.Sub(now); wait > 0 {
+			resp.RetryAfter(wait)
+			return nil
+		}
+	}
+
+	if h.backend == nil {
+		return h.fail(req, snapshot, errors.New(errUnsupported))
+	}
+	var instance v1.HostedAgentInstance
+	if err := req.Get(&instance, snapshot.Namespace, snapshot.Spec.HostedAgentInstanceName); apierrors.IsNotFound(err) {
+		return h.fail(req, snapshot, fmt.Errorf("instance %s was deleted before its workspace could be captured", snapshot.Spec.HostedAgentInstanceName))
+	} else if err != nil {
+		return err
+	}
+	if instance.Status.BackendID == "" {
+		return h.fail(req, snapshot, fmt.Errorf("instance %s has not been started yet and has no workspace", instance.Name))
+	}
+
+	size, err := h.capture(req.Ctx, &instance, snapshot)
+	snapshot.Status.Attempts++
+	snapshot.Status.NextAttemptAt = nil
+	switch {
+	case err == nil:
+		snapshot.Status.State = types.HostedAgentSnapshotStateReady
+		snapshot.Status.SizeBytes = size
+		snapshot.Status.Error = ""
+		snapshot.Status.CompletedTime = &metav1.Time{Time: h.now()}
+	case snapshot.Status.Attempts >= maxCaptureAttempts:
+		return h.fail(req, snapshot, err)
+	default:
+		backoff := captureRetryBackoff << (snapshot.Status.Attempts - 1)
+		snapshot.Status.State = types.HostedAgentSnapshotStatePending
+		snapshot.Status.Error = err.Error()
+		snapshot.Status.NextAttemptAt = &metav1.Time{Time: now.Add(backoff)}
+		resp.RetryAfter(backoff)
+	}
+	return req.Client.Status().Update(req.Ctx, snapshot)
+}
+
+// fail records that the snapshot could not be captured.
+func (h *Handler) fail(req router.Request, snapshot *v1.HostedAgentSnapshot, err error) error {
+	snapshot.Status.State = types.HostedAgentSnapshotStateError
+	snapshot.Status.Error = err.Error()
+	snapshot.Status.NextAttemptAt = nil
+	snapshot.Status.CompletedTime = &metav1.Time{Time: h.now()}
+	return req.Client.Status().Update(req.Ctx, snapshot)
+}
+
+func (h *Handler) capture(ctx context.Context, instance *v1.HostedAgentInstance, snapshot *v1.HostedAgentSnapshot) (int64, error) {
+	// The archive is streamed straight into the store, so a large workspace is
+	// never held in memory or on Obot's own disk.
+	reader, writer := io.Pipe()
+	counter := &countingReader{r: reader}
+	exported := make(chan error, 1)
+	go func() {
+		err := h.backend.ExportWorkspace(ctx, instanceRef(instance), writer)
+		_ = writer.CloseWithError(err)
+		exported <- err
+	}()
+
+	key := hostedagentsnapshot.BlobKey(snapshot.Name)
+	uploadErr := h.store.Upload(ctx, h.bucket, key, counter)
+	// Unblock the exporter if the upload gave up before reading everything.
+	_ = reader.CloseWithError(io.ErrClosedPipe)
+	exportErr := <-exported
+	if err := errors.Join(exportErr, uploadErr); err != nil {
+		// Whatever made it to the store is an incomplete archive that nothing
+		// will ever read.
+		_ = h.store.Delete(context.WithoutCancel(ctx), h.bucket, key)
+		if exportErr != nil {
+			return 0, fmt.Errorf("failed to export workspace: %w", exportErr)
+		}
+		return 0, fmt.Errorf("failed to store snapshot: %w", uploadErr)
+	}
+	return counter.n, nil
+}
+
+// Expire deletes a snapshot when its retention runs out.
+func (h *Handler) Expire(req router.Request, resp router.Response) error {
+	snapshot := req.Object.(*v1.HostedAgentSnapshot)
+	if snapshot.Spec.ExpiresAt == nil {
+		return nil
+	}
+	if until := snapshot.Spec.ExpiresAt.Sub(h.now()); until > 0 {
+		if until < 10*time.Hour {
+			resp.RetryAfter(until)
+		}
+		return nil
+	}
+	return kclient.IgnoreNotFound(req.Client.Delete(req.Ctx, snapshot))
+}
+
+// Remove deletes a snapshot's archive from the blob store.
+func (h *Handler) Remove(req router.Request, _ router.Response) error {
+	snapshot := req.Object.(*v1.HostedAgentSnapshot)
+	if snapshot.Status.State != types.HostedAgentSnapshotStateReady {
+		// Only a ready snapshot has an archive; a failed capture removed its own.
+		return nil
+	}
+	if err := h.store.Delete(req.Ctx, h.bucket, hostedagentsnapshot.BlobKey(snapshot.Name)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to delete snapshot archive: %w", err)
+	}
+	return nil
+}
+
+// Restore writes the requested snapshot into an instance's workspace.
+//
+// It waits for the instance to stop first: a pending restore is part of what
+// stops it, and writing under a running agent would leave it with a workspace
+// that is neither the old one nor the snapshot. Once the restore is recorded,
+// the instance is no longer held stopped and starts again by itself.
+func (h *Handler) Restore(req router.Request, resp router.Response) error {
+	instance := req.Object.(*v1.HostedAgentInstance)
+	if !instance.RestorePending() || !instance.DeletionTimestamp.IsZero() {
+		return nil
+	}
+	if instance.Status.State != types.HostedAgentStateStopped {
+		// The instance controller polls a stopping instance and writes its
+		// status when it stops, which brings the instance back here.
+		return nil
+	}
+
+	var snapshot v1.HostedAgentSnapshot
+	if err := req.Get(&snapshot, instance.Namespace, instance.Spec.Restore.SnapshotName); apierrors.IsNotFound(err) {
+		return h.recordRestore(req, instance, fmt.Errorf("snapshot %s no longer exists", instance.Spec.Restore.SnapshotName))
+	} else if err != nil {
+		return err
+	}
+	if !hostedagentsnapshot.Finished(&snapshot) {
+		resp.RetryAfter(pendingSnapshotPollInterval)
+		return nil
+	}
+
+	return h.recordRestore(req, instance, h.restore(req.Ctx, instance, &snapshot))
+}
+
+func (h *Handler) restore(ctx context.Context, instance *v1.HostedAgentInstance, snapshot *v1.HostedAgentSnapshot) error {
+	if h.backend == nil {
+		return errors.New(errUnsupported)
+	}
+	if snapshot.Status.State != types.HostedAgentSnapshotStateReady {
+		return fmt.Errorf("snapshot %s failed and cannot be restored: %s", snapshot.Name, snapshot.Status.Error)
+	}
+
+	archive, err := h.store.Download(ctx, h.bucket, hostedagentsnapshot.BlobKey(snapshot.Name))
+	if err != nil {
+		return fmt.Errorf("failed to read snapshot %s: %w", snapshot.Name, err)
+	}
+	defer archive.Close()
+
+	if err := h.backend.ImportWorkspace(ctx, instanceRef(instance), archive); err != nil {
+		return fmt.Errorf("failed to restore snapshot %s: %w", snapshot.Name, err)
+	}
+	return nil
+}
+
+func (h *Handler) recordRestore(req router.Request, instance *v1.HostedAgentInstance, restoreErr error) error {
+	instance.Status.RestoredID = instance.Spec.Restore.ID
+	instance.Status.RestoreError = ""
+	if restoreErr != nil {
+		instance.Status.RestoreError = restoreErr.Error()
+	}
+	return req.Client.Status().Update(req.Ctx, instance)
+}
+
+func instanceRef(instance *v1.HostedAgentInstance) agentbackend.InstanceRef {
+	id := string(instance.UID)
+	if id == "" {
+		id = instance.Name
+	}
+	return agentbackend.InstanceRef{
+		ID:        id,
+		Namespace: instance.Namespace,
+		UserID:    instance.Spec.UserID,
+		BackendID: instance.Status.BackendID,
+	}
+}
+
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
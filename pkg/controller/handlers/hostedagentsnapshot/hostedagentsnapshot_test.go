@@ -0,0 +1,273 @@
+package hostedagentsnapshot
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/obot-platform/nah/pkg/router"
+	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/obot-platform/obot/pkg/agentbackend"
+	fakebackend "github.com/obot-platform/obot/pkg/agentbackend/fake"
+	"github.com/obot-platform/obot/pkg/hostedagentsnapshot"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	"github.com/obot-platform/obot/pkg/storage/blob"
+	storagescheme "github.com/obot-platform/obot/pkg/storage/scheme"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type response struct {
+	retryAfter time.Duration
+}
+
+func (*response) Attributes() map[string]any { return map[string]any{} }
+
+func (r *response) RetryAfter(delay time.Duration) { r.retryAfter = delay }
+
+func newHandler(t *testing.T) (*Handler, *fakebackend.Backend) {
+	t.Helper()
+	store, err := blob.NewDirectoryStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	backend := fakebackend.New(fakebackend.Config{})
+	return New(backend, store, "default"), backend
+}
+
+func newClient(objs ...kclient.Object) kclient.WithWatch {
+	return fake.NewClientBuilder().
+		WithScheme(storagescheme.Scheme).
+		WithStatusSubresource(&v1.HostedAgentSnapshot{}, &v1.HostedAgentInstance{}).
+		WithObjects(objs...).
+		Build()
+}
+
+func runningInstance(t *testing.T, backend *fakebackend.Backend, name string) *v1.HostedAgentInstance {
+	t.Helper()
+	ctx := context.Background()
+	if _, err := backend.ReconcilePool(ctx, agentbackend.DesiredPool{
+		Ref: agentbackend.PoolRef{ID: "pool-1"}, Revision: "r",
+		Capacity: agentbackend.ResourceQuantity{CPUVCPUs: 2, MemoryBytes: 1 << 30, StorageBytes: 1 << 30},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	observation, err := backend.ReconcileInstance(ctx, agentbackend.DesiredInstance{
+		Ref:      agentbackend.InstanceRef{ID: name},
+		Pool:     agentbackend.PoolRef{ID: "pool-1"},
+		Revision: "r",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &v1.HostedAgentInstance{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec:       v1.HostedAgentInstanceSpec{UserID: "user-1", HostedAgentName: "ha1", PoolID: "pool-1"},
+		Status:     v1.HostedAgentInstanceStatus{BackendID: observation.Ref.BackendID},
+	}
+}
+
+func workspaceArchive(t *testing.T, file, content string) []byte {
+	t.Helper()
+	var archive bytes.Buffer
+	gz := gzip.NewWriter(&archive)
+	tw := tar.NewWriter(gz)
+	if err := tw.WriteHeader(&tar.Header{Name: file, Mode: 0o644, Size: int64(len(content))}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tw.Write([]byte(content)); err != nil {
+		t.Fatal(err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return archive.Bytes()
+}
+
+func TestCaptureAndRestore(t *testing.T) {
+	ctx := t.Context()
+	h, backend := newHandler(t)
+	source := runningInstance(t, backend, "hai1source")
+	saved := workspaceArchive(t, "notes.txt", "remember this")
+	if err := backend.ImportWorkspace(ctx, instanceRef(source), bytes.NewReader(saved)); err != nil {
+		t.Fatal(err)
+	}
+
+	snapshot := hostedagentsnapshot.New(source, types.HostedAgentSnapshotManifest{Name: "before upgrade"})
+	snapshot.Name = "has1abc"
+	target := runningInstance(t, backend, "hai1target")
+	target.Spec.Restore = &v1.HostedAgentInstanceRestore{SnapshotName: snapshot.Name, ID: "r1"}
+	target.Status.State = types.HostedAgentStateReady
+	c := newClient(source, target, snapshot)
+
+	if err := h.Capture(router.Request{Ctx: ctx, Client: c, Object: snapshot}, &response{}); err != nil {
+		t.Fatal(err)
+	}
+	if snapshot.Status.State != types.HostedAgentSnapshotStateReady || snapshot.Status.SizeBytes != int64(len(saved)) {
+		t.Fatalf("unexpected snapshot status: %#v", snapshot.Status)
+	}
+
+	// A restore waits until the instance has stopped.
+	if err := h.Restore(router.Request{Ctx: ctx, Client: c, Object: target}, &response{}); err != nil {
+		t.Fatal(err)
+	}
+	if target.Status.RestoredID != "" {
+		t.Fatal("restored into an instance that was still running")
+	}
+
+	target.Status.State = types.HostedAgentStateStopped
+	if err := h.Restore(router.Request{Ctx: ctx, Client: c, Object: target}, &response{}); err != nil {
+		t.Fatal(err)
+	}
+	if target.Status.RestoredID != "r1" || target.Status.RestoreError != "" || target.RestorePending() {
+		t.Fatalf("unexpected restore status: %#v", target.Status)
+	}
+	var restored bytes.Buffer
+	if err := backend.ExportWorkspace(ctx, instanceRef(target), &restored); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(restored.Bytes(), saved) {
+		t.Fatal("restored workspace does not match the snapshot")
+	}
+
+	if err := h.Remove(router.Request{Ctx: ctx, Client: c, Object: snapshot}, &response{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := h.store.Download(ctx, h.bucket, hostedagentsnapshot.BlobKey(snapshot.Name)); err == nil {
+		t.Fatal("snapshot archive outlived the snapshot")
+	}
+	// A second removal, as after a failed finalizer update, still succeeds.
+	if err := h.Remove(router.Request{Ctx: ctx, Client: c, Object: snapshot}, &response{}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCaptureOfDeletedInstanceFails(t *testing.T) {
+	snapshot := &v1.HostedAgentSnapshot{
+		ObjectMeta: metav1.ObjectMeta{Name: "has1abc", Namespace: "default"},
+		Spec:       v1.HostedAgentSnapshotSpec{HostedAgentInstanceName: "hai1gone"},
+	}
+	h, _ := newHandler(t)
+	c := newClient(snapshot)
+
+	if err := h.Capture(router.Request{Ctx: t.Context(), Client: c, Object: snapshot}, &response{}); err != nil {
+		t.Fatal(err)
+	}
+	if snapshot.Status.State != types.HostedAgentSnapshotStateError || snapshot.Status.Error == "" {
+		t.Fatalf("unexpected snapshot status: %#v", snapshot.Status)
+	}
+}
+
+func TestCaptureRetriesFailedExport(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	// The backend has no such sandbox, so every export fails.
+	instance := &v1.HostedAgentInstance{
+		ObjectMeta: metav1.ObjectMeta{Name: "hai1", Namespace: "default"},
+		Status:     v1.HostedAgentInstanceStatus{BackendID: "missing"},
+	}
+	snapshot := &v1.HostedAgentSnapshot{
+		ObjectMeta: metav1.ObjectMeta{Name: "has1abc", Namespace: "default"},
+		Spec:       v1.HostedAgentSnapshotSpec{HostedAgentInstanceName: "hai1"},
+	}
+	h, _ := newHandler(t)
+	h.now = func() time.Time { return now }
+	c := newClient(instance, snapshot)
+
+	resp := &response{}
+	if err := h.Capture(router.Request{Ctx: t.Context(), Client: c, Object: snapshot}, resp); err != nil {
+		t.Fatal(err)
+	}
+	if snapshot.Status.State != types.HostedAgentSnapshotStatePending || snapshot.Status.Attempts != 1 || snapshot.Status.Error == "" {
+		t.Fatalf("unexpected snapshot status: %#v", snapshot.Status)
+	}
+	if resp.retryAfter != captureRetryBackoff {
+		t.Fatalf("retryAfter = %s, want %s", resp.retryAfter, captureRetryBackoff)
+	}
+
+	// Nothing is tried again before the backoff has passed.
+	resp = &response{}
+	h.now = func() time.Time { return now.Add(captureRetryBackoff / 2) }
+	if err := h.Capture(router.Request{Ctx: t.Context(), Client: c, Object: snapshot}, resp); err != nil {
+		t.Fatal(err)
+	}
+	if snapshot.Status.Attempts != 1 || resp.retryAfter != captureRetryBackoff/2 {
+		t.Fatalf("retried early: status %#v, retry %s", snapshot.Status, resp.retryAfter)
+	}
+
+	for snapshot.Status.State == types.HostedAgentSnapshotStatePending {
+		now = snapshot.Status.NextAttemptAt.Time
+		h.now = func() time.Time { return now }
+		if err := h.Capture(router.Request{Ctx: t.Context(), Client: c, Object: snapshot}, &response{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if snapshot.Status.State != types.HostedAgentSnapshotStateError || snapshot.Status.Attempts != maxCaptureAttempts {
+		t.Fatalf("unexpected snapshot status: %#v", snapshot.Status)
+	}
+}
+
+func TestRestoreWaitsForPendingSnapshot(t *testing.T) {
+	snapshot := &v1.HostedAgentSnapshot{ObjectMeta: metav1.ObjectMeta{Name: "has1abc", Namespace: "default"}}
+	instance := &v1.HostedAgentInstance{
+		ObjectMeta: metav1.ObjectMeta{Name: "hai1", Namespace: "default"},
+		Spec:       v1.HostedAgentInstanceSpec{Restore: &v1.HostedAgentInstanceRestore{SnapshotName: "has1abc", ID: "r1"}},
+		Status:     v1.HostedAgentInstanceStatus{State: types.HostedAgentStateStopped},
+	}
+	h, _ := newHandler(t)
+	c := newClient(snapshot, instance)
+
+	resp := &response{}
+	if err := h.Restore(router.Request{Ctx: t.Context(), Client: c, Object: instance}, resp); err != nil {
+		t.Fatal(err)
+	}
+	if instance.Status.RestoredID != "" || resp.retryAfter != pendingSnapshotPollInterval {
+		t.Fatalf("restore did not wait for the snapshot: status %#v, retry %s", instance.Status, resp.retryAfter)
+	}
+
+	// A snapshot deleted in the meantime fails the restore rather than
+	// holding the instance stopped for good.
+	if err := c.Delete(t.Context(), snapshot); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.Restore(router.Request{Ctx: t.Context(), Client: c, Object: instance}, &response{}); err != nil {
+		t.Fatal(err)
+	}
+	if instance.Status.RestoredID != "r1" || instance.Status.RestoreError == "" {
+		t.Fatalf("unexpected restore status: %#v", instance.Status)
+	}
+}
+
+func TestExpire(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	snapshot := &v1.HostedAgentSnapshot{
+		ObjectMeta: metav1.ObjectMeta{Name: "has1abc", Namespace: "default"},
+		Spec:       v1.HostedAgentSnapshotSpec{ExpiresAt: &metav1.Time{Time: now.Add(time.Hour)}},
+	}
+	h, _ := newHandler(t)
+	c := newClient(snapshot)
+	h.now = func() time.Time { return now }
+
+	resp := &response{}
+	if err := h.Expire(router.Request{Ctx: t.Context(), Client: c, Object: snapshot}, resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.retryAfter != time.Hour {
+		t.Fatalf("retryAfter = %s, want 1h", resp.retryAfter)
+	}
+
+	h.now = func() time.Time { return now.Add(2 * time.Hour) }
+	if err := h.Expire(router.Request{Ctx: t.Context(), Client: c, Object: snapshot}, &response{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Get(t.Context(), kclient.ObjectKeyFromObject(snapshot), &v1.HostedAgentSnapshot{}); !apierrors.IsNotFound(err) {
+		t.Fatalf("expired snapshot was not deleted: %v", err)
+	}
+}
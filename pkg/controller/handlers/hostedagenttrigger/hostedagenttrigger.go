@@ -0,0 +1,100 @@
+// Package hostedagenttrigger fires scheduled hosted agent triggers and cleans
+// up after deleted ones.
+package hostedagenttrigger
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/obot-platform/nah/pkg/router"
+	"github.com/obot-platform/obot/apiclient/types"
+	gateway "github.com/obot-platform/obot/pkg/gateway/client"
+	"github.com/obot-platform/obot/pkg/hostedagenttrigger"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type Handler struct {
+	gatewayClient *gateway.Client
+	deliverer     *hostedagenttrigger.Deliverer
+	now           func() time.Time
+}
+
+func New(gatewayClient *gateway.Client, deliverer *hostedagenttrigger.Deliverer) *Handler {
+	return &Handler{
+		gatewayClient: gatewayClient,
+		deliverer:     deliverer,
+		now:           time.Now,
+	}
+}
+
+// Schedule fires a schedule trigger when its next tick arrives.
+//
+// LastRunTime moves to now rather than to the tick that fired, so a trigger
+// that missed several ticks while Obot was down fires once on recovery instead
+// of once per missed tick.
+func (h *Handler) Schedule(req router.Request, resp router.Response) error {
+	trigger := req.Object.(*v1.HostedAgentTrigger)
+	if trigger.Spec.Manifest.TriggerType != types.HostedAgentTriggerTypeSchedule || trigger.Spec.Manifest.Disabled {
+		if trigger.Status.NextRunTime != nil {
+			trigger.Status.NextRunTime = nil
+			return req.Client.Status().Update(req.Ctx, trigger)
+		}
+		return nil
+	}
+
+	lastRun := trigger.CreationTimestamp.Time
+	if trigger.Status.LastRunTime != nil {
+		lastRun = trigger.Status.LastRunTime.Time
+	}
+	next, err := hostedagenttrigger.NextRun(trigger.Spec.Manifest, lastRun)
+	if err != nil {
+		return fmt.Errorf("failed to calculate next run time: %w", err)
+	}
+
+	now := h.now()
+	if until := next.Sub(now); until > 0 {
+		if until < 10*time.Hour {
+			resp.RetryAfter(until)
+		}
+		if trigger.Status.NextRunTime == nil || !trigger.Status.NextRunTime.Time.Equal(next) {
+			trigger.Status.NextRunTime = &metav1.Time{Time: next}
+			return req.Client.Status().Update(req.Ctx, trigger)
+		}
+		return nil
+	}
+
+	// Record the run before delivering it. If the write fails the tick is
+	// retried; if it succeeded and delivery then fails, the failure is in the
+	// trigger's log, and the tick is not delivered twice.
+	trigger.Status.LastRunTime = &metav1.Time{Time: now}
+	trigger.Status.NextRunTime = nil
+	if next, err := hostedagenttrigger.NextRun(trigger.Spec.Manifest, now); err == nil {
+		trigger.Status.NextRunTime = &metav1.Time{Time: next}
+	}
+	if err := req.Client.Status().Update(req.Ctx, trigger); err != nil {
+		return err
+	}
+
+	// Delivery may wait minutes for a hibernated instance to start, which is
+	// far too long to hold a reconcile, and must outlive this one.
+	trigger = trigger.DeepCopy()
+	go func() {
+		ctx := context.WithoutCancel(req.Ctx)
+		if _, err := h.deliverer.Deliver(ctx, trigger, types.HostedAgentTriggerSourceSchedule, []byte(trigger.Spec.Manifest.Payload), nil); err != nil {
+			slog.Error("Failed to record hosted agent trigger delivery", "trigger", trigger.Name, "error", err)
+		}
+	}()
+	return nil
+}
+
+// Remove deletes a trigger's webhook secret and activity log.
+func (h *Handler) Remove(req router.Request, _ router.Response) error {
+	trigger := req.Object.(*v1.HostedAgentTrigger)
+	if _, err := h.gatewayClient.DeleteCredential(req.Ctx, hostedagenttrigger.CredentialContext(trigger.Name), trigger.Name); err != nil {
+		return fmt.Errorf("failed to delete webhook secret: %w", err)
+	}
+	return h.gatewayClient.DeleteHostedAgentTriggerInvocations(req.Ctx, trigger.Name)
+}
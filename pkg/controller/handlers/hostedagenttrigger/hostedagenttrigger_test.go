@@ -0,0 +1,86 @@
+package hostedagenttrigger
+
+import (
+	"testing"
+	"time"
+
+	"github.com/obot-platform/nah/pkg/router"
+	"github.com/obot-platform/obot/apiclient/types"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	storagescheme "github.com/obot-platform/obot/pkg/storage/scheme"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type response struct {
+	retryAfter time.Duration
+}
+
+func (*response) Attributes() map[string]any { return map[string]any{} }
+
+func (r *response) RetryAfter(delay time.Duration) { r.retryAfter = delay }
+
+func TestScheduleWaitsForNextTick(t *testing.T) {
+	created := time.Date(2026, 1, 1, 8, 30, 0, 0, time.UTC)
+	trigger := &v1.HostedAgentTrigger{
+		ObjectMeta: metav1.ObjectMeta{Name: "hat1", Namespace: "default", CreationTimestamp: metav1.NewTime(created)},
+		Spec: v1.HostedAgentTriggerSpec{
+			Manifest: types.HostedAgentTriggerManifest{TriggerType: types.HostedAgentTriggerTypeSchedule, Schedule: "0 9 * * *"},
+		},
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(storagescheme.Scheme).
+		WithStatusSubresource(&v1.HostedAgentTrigger{}).
+		WithObjects(trigger).
+		Build()
+
+	h := &Handler{now: func() time.Time { return created.Add(10 * time.Minute) }}
+	resp := &response{}
+	if err := h.Schedule(router.Request{Ctx: t.Context(), Client: c, Object: trigger}, resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.retryAfter != 20*time.Minute {
+		t.Fatalf("retry after = %s, want 20m", resp.retryAfter)
+	}
+
+	var stored v1.HostedAgentTrigger
+	if err := c.Get(t.Context(), kclient.ObjectKeyFromObject(trigger), &stored); err != nil {
+		t.Fatal(err)
+	}
+	if stored.Status.LastRunTime != nil {
+		t.Fatal("trigger fired before its tick")
+	}
+	if want := created.Add(30 * time.Minute); stored.Status.NextRunTime == nil || !stored.Status.NextRunTime.Time.Equal(want) {
+		t.Fatalf("next run = %v, want %s", stored.Status.NextRunTime, want)
+	}
+}
+
+func TestScheduleIgnoresDisabledTrigger(t *testing.T) {
+	next := metav1.NewTime(time.Now())
+	trigger := &v1.HostedAgentTrigger{
+		ObjectMeta: metav1.ObjectMeta{Name: "hat1", Namespace: "default"},
+		Spec: v1.HostedAgentTriggerSpec{
+			Manifest: types.HostedAgentTriggerManifest{TriggerType: types.HostedAgentTriggerTypeSchedule, Schedule: "* * * * *", Disabled: true},
+		},
+		Status: v1.HostedAgentTriggerStatus{NextRunTime: &next},
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(storagescheme.Scheme).
+		WithStatusSubresource(&v1.HostedAgentTrigger{}).
+		WithObjects(trigger).
+		Build()
+
+	// A nil deliverer would panic if the disabled trigger were fired.
+	h := &Handler{now: time.Now}
+	if err := h.Schedule(router.Request{Ctx: t.Context(), Client: c, Object: trigger}, &response{}); err != nil {
+		t.Fatal(err)
+	}
+	var stored v1.HostedAgentTrigger
+	if err := c.Get(t.Context(), kclient.ObjectKeyFromObject(trigger), &stored); err != nil {
+		t.Fatal(err)
+	}
+	if stored.Status.NextRunTime != nil || stored.Status.LastRunTime != nil {
+		t.Fatalf("disabled trigger status = %#v", stored.Status)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/obot-platform/obot/pkg/git"
 	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
 	storagescheme "github.com/obot-platform/obot/pkg/storage/scheme"
 	"github.com/stretchr/testify/assert"
@@ -69,7 +70,7 @@ compositeConfig:
 `, sourceRef(dir, "tool")), 0o600))
 
 	h := &Handler{}
-	objs, err := h.readMCPCatalog(t.Context(), "default", dir, "")
+	objs, err := h.readMCPCatalog(t.Context(), "default", dir, git.Auth{})
 	assert.NoError(t, err)
 
 	objs, errsBySourceURL := h.resolveCompositeSourceRefs(t.Context(), nil, "", "", objs)
@@ -115,7 +116,7 @@ compositeConfig:
 `), 0o600))
 
 	h := &Handler{}
-	objs, err := h.readMCPCatalog(t.Context(), "default", dir, "")
+	objs, err := h.readMCPCatalog(t.Context(), "default", dir, git.Auth{})
 	assert.NoError(t, err)
 
 	objs, errsBySourceURL := h.resolveCompositeSourceRefs(t.Context(), nil, "", "", objs)
@@ -312,9 +313,9 @@ compositeConfig:
 `, sourceRef(first, "tool")), 0o600))
 
 	h := &Handler{}
-	firstObjs, err := h.readMCPCatalog(t.Context(), "default", first, "")
+	firstObjs, err := h.readMCPCatalog(t.Context(), "default", first, git.Auth{})
 	assert.NoError(t, err)
-	secondObjs, err := h.readMCPCatalog(t.Context(), "default", second, "")
+	secondObjs, err := h.readMCPCatalog(t.Context(), "default", second, git.Auth{})
 	assert.NoError(t, err)
 
 	objs, errsBySourceURL := h.resolveCompositeSourceRefs(t.Context(), nil, "", "", append(firstObjs, secondObjs...))
@@ -9,13 +9,13 @@ import (
 	"github.com/obot-platform/obot/pkg/git"
 )
 
-func readGitCatalogEntries[T any](ctx context.Context, catalogURL, token string) ([]T, error) {
-	return readGitCatalogEntriesFromSubdir(ctx, catalogURL, token, "", readCatalogDirectory[T])
+func readGitCatalogEntries[T any](ctx context.Context, catalogURL string, auth git.Auth) ([]T, error) {
+	return readGitCatalogEntriesFromSubdir(ctx, catalogURL, auth, "", readCatalogDirectory[T])
 }
 
-func readGitCatalogEntriesFromSubdir[T any](ctx context.Context, catalogURL, token, subdir string, readDir func(string) ([]T, error)) ([]T, error) {
+func readGitCatalogEntriesFromSubdir[T any](ctx context.Context, catalogURL string, auth git.Auth, subdir string, readDir func(string) ([]T, error)) ([]T, error) {
 	if strings.HasPrefix(catalogURL, "http://") {
-		return nil, fmt.Errorf("only HTTPS is supported for git catalogs")
+		return nil, fmt.Errorf("only HTTPS and SSH are supported for git catalogs")
 	}
 
 	if subdir != "" {
@@ -26,7 +26,7 @@ func readGitCatalogEntriesFromSubdir[T any](ctx context.Context, catalogURL, tok
 		subdir = cleanSubdir
 	}
 
-	dir, _, cleanup, err := git.Clone(ctx, catalogURL, token, "")
+	dir, _, cleanup, err := git.Clone(ctx, catalogURL, auth, "")
 	if err != nil {
 		return nil, err
 	}
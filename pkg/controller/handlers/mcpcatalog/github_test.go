@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/obot-platform/obot/pkg/git"
 	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
 	"github.com/stretchr/testify/assert"
 )
@@ -23,7 +24,7 @@ npxConfig:
 `), 0o600))
 
 	h := &Handler{}
-	objs, err := h.readMCPCatalog(t.Context(), "default", dir, "")
+	objs, err := h.readMCPCatalog(t.Context(), "default", dir, git.Auth{})
 	assert.NoError(t, err)
 	assert.Len(t, objs, 1)
 
@@ -80,7 +81,7 @@ func TestReadGitCatalog(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			entries, err := readGitCatalogEntries[types.MCPServerCatalogEntryManifest](t.Context(), tt.catalog, "")
+			entries, err := readGitCatalogEntries[types.MCPServerCatalogEntryManifest](t.Context(), tt.catalog, git.Auth{})
 			if tt.wantErr {
 				assert.Error(t, err)
 				return
@@ -23,6 +23,7 @@ import (
 	"github.com/obot-platform/obot/pkg/gitcredential"
 	"github.com/obot-platform/obot/pkg/mcp"
 	catalogvalidation "github.com/obot-platform/obot/pkg/mcpcatalog"
+	"github.com/obot-platform/obot/pkg/notification"
 	"github.com/obot-platform/obot/pkg/safehttp"
 	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
 	"github.com/obot-platform/obot/pkg/system"
@@ -57,7 +58,7 @@ type Handler struct {
 	gatewayClient             *gclient.Client
 	accessControlRuleHelper   *accesscontrolrule.Helper
 	remoteURLValidationConfig mcp.ValidationOptions
-	mcpBackend                string
+	secretProviderConfigured  bool
 	mcpSessionManager         *mcp.SessionManager
 }
 
@@ -84,7 +85,7 @@ func New(defaultCatalogPath, defaultSystemCatalogPath string, gatewayClient *gcl
 		}),
 		accessControlRuleHelper:   accessControlRuleHelper,
 		remoteURLValidationConfig: validationOptions,
-		mcpBackend:                mcpSessionManager.MCPRuntimeBackend(),
+		secretProviderConfigured:  mcpSessionManager.SecretProvider() != nil,
 		mcpSessionManager:         mcpSessionManager,
 	}
 }
@@ -131,7 +132,7 @@ func (h *Handler) Sync(req router.Request, resp router.Response) error {
 
 	for _, sourceURL := range mcpCatalog.Spec.SourceURLs {
 		credentialID := mcpCatalog.Spec.SourceURLGitCredentialIDs[sourceURL]
-		token, err := gitcredential.ResolveOrReveal(req.Ctx, req.Client, h.gatewayClient, mcpCatalog.Namespace, credentialID, sourceURL, mcpCatalog.Name, CatalogCredentialToolName)
+		auth, err := gitcredential.ResolveOrReveal(req.Ctx, req.Client, h.gatewayClient, mcpCatalog.Namespace, credentialID, sourceURL, mcpCatalog.Name, CatalogCredentialToolName)
 		if errors.Is(err, gitcredential.ErrLegacyCredential) {
 			slog.Error("failed to retrieve legacy credential for catalog source, continuing without authentication", "catalog", mcpCatalog.Name, "source", sourceURL, "error", err)
 			err = nil
@@ -140,7 +141,7 @@ func (h *Handler) Sync(req router.Request, resp router.Response) error {
 			mcpCatalog.Status.SyncErrors[sourceURL] = err.Error()
 			continue
 		}
-		objs, err := h.readMCPCatalog(req.Ctx, mcpCatalog.Name, sourceURL, token, validationOptions)
+		objs, err := h.readMCPCatalog(req.Ctx, mcpCatalog.Name, sourceURL, auth, validationOptions)
 		if err != nil {
 			slog.Error("failed to read catalog source", "source", sourceURL, "error", err)
 			mcpCatalog.Status.SyncErrors[sourceURL] = err.Error()
@@ -165,6 +166,7 @@ func (h *Handler) Sync(req router.Request, resp router.Response) error {
 		addSyncError(mcpCatalog.Status.SyncErrors, sourceURL, errMsg)
 	}
 
+	notifySyncErrors(req.Client, mcpCatalog.Name, mcpCatalog.Status.SyncErrors)
 	mcpCatalog.Status.LastSyncTime = metav1.Now()
 	if err := req.Client.Status().Update(req.Ctx, mcpCatalog); err != nil {
 		return fmt.Errorf("failed to update catalog status: %w", err)
@@ -212,6 +214,23 @@ func addSyncError(syncErrors map[string]string, sourceURL, errMsg string) {
 	}
 }
 
+// notifySyncErrors reports each failing source of a catalog, at most once a day
+// while it keeps failing.
+func notifySyncErrors(c kclient.Client, catalogName string, syncErrors map[string]string) {
+	day := time.Now().UTC().Format(time.DateOnly)
+	for sourceURL, errMsg := range syncErrors {
+		notification.EmitAsync(c, catalogName+"/"+sourceURL+"/"+day, types.NotificationEvent{
+			Type:       types.NotificationEventCatalogSyncFailed,
+			Summary:    fmt.Sprintf("MCP catalog %s failed to sync a source", catalogName),
+			ResourceID: catalogName,
+			Details: map[string]string{
+				"source": sourceURL,
+				"error":  errMsg,
+			},
+		})
+	}
+}
+
 func filterConflictingCatalogEntries(ctx context.Context, c kclient.Client, namespace string, objs []kclient.Object) ([]kclient.Object, map[string]string, error) {
 	result := make([]kclient.Object, 0, len(objs))
 	errsBySourceURL := make(map[string]string)
@@ -456,9 +475,9 @@ func (h *Handler) resolveCompositeSourceRefs(ctx context.Context, c kclient.Clie
 
 		if changed {
 			if err := catalogvalidation.ValidateManifest(ctx, entry.Spec.Manifest, catalogvalidation.ValidationOptions{
-				MCP:        validationOptions,
-				MCPBackend: h.mcpBackend,
-				GitManaged: entry.IsGitManaged(),
+				MCP:                      validationOptions,
+				SecretProviderConfigured: h.secretProviderConfigured,
+				GitManaged:               entry.IsGitManaged(),
 			}); err != nil {
 				addSyncError(errsBySourceURL, entry.Spec.SourceURL, fmt.Sprintf("failed to validate resolved composite catalog entry %q: %v", entry.Name, err))
 				continue
@@ -543,7 +562,7 @@ func (h *Handler) SyncSystem(req router.Request, resp router.Response) error {
 
 	for _, sourceURL := range systemCatalog.Spec.SourceURLs {
 		credentialID := systemCatalog.Spec.SourceURLGitCredentialIDs[sourceURL]
-		token, err := gitcredential.ResolveOrReveal(req.Ctx, req.Client, h.gatewayClient, systemCatalog.Namespace, credentialID, sourceURL, systemCatalog.Name, CatalogCredentialToolName)
+		auth, err := gitcredential.ResolveOrReveal(req.Ctx, req.Client, h.gatewayClient, systemCatalog.Namespace, credentialID, sourceURL, systemCatalog.Name, CatalogCredentialToolName)
 		if errors.Is(err, gitcredential.ErrLegacyCredential) {
 			slog.Error("failed to retrieve legacy credential for system catalog source, continuing without authentication", "catalog", systemCatalog.Name, "source", sourceURL, "error", err)
 			err = nil
@@ -552,7 +571,7 @@ func (h *Handler) SyncSystem(req router.Request, resp router.Response) error {
 			systemCatalog.Status.SyncErrors[sourceURL] = err.Error()
 			continue
 		}
-		objs, err := h.readSystemMCPCatalog(req.Ctx, systemCatalog.Name, sourceURL, token)
+		objs, err := h.readSystemMCPCatalog(req.Ctx, systemCatalog.Name, sourceURL, auth)
 		if err != nil {
 			slog.Error("failed to read system catalog source", "source", sourceURL, "error", err)
 			systemCatalog.Status.SyncErrors[sourceURL] = err.Error()
@@ -564,6 +583,7 @@ func (h *Handler) SyncSystem(req router.Request, resp router.Response) error {
 		toAdd = append(toAdd, objs...)
 	}
 
+	notifySyncErrors(req.Client, systemCatalog.Name, systemCatalog.Status.SyncErrors)
 	systemCatalog.Status.LastSyncTime = metav1.Now()
 	if err := req.Client.Status().Update(req.Ctx, systemCatalog); err != nil {
 		return fmt.Errorf("failed to update system catalog status: %w", err)
@@ -593,8 +613,8 @@ func (h *Handler) SyncSystem(req router.Request, resp router.Response) error {
 	return app.Apply(req.Ctx, systemCatalog, toAdd...)
 }
 
-func (h *Handler) readSystemMCPCatalog(ctx context.Context, catalogName, sourceURL, token string) ([]kclient.Object, error) {
-	entries, err := readCatalogManifests[types.SystemMCPServerCatalogEntryManifest](ctx, h.httpClient, sourceURL, token)
+func (h *Handler) readSystemMCPCatalog(ctx context.Context, catalogName, sourceURL string, auth git.Auth) ([]kclient.Object, error) {
+	entries, err := readCatalogManifests[types.SystemMCPServerCatalogEntryManifest](ctx, h.httpClient, sourceURL, auth)
 	if err != nil {
 		return nil, err
 	}
@@ -634,12 +654,12 @@ func (h *Handler) readSystemMCPCatalog(ctx context.Context, catalogName, sourceU
 	return systemObjs, errors.Join(errs...)
 }
 
-func (h *Handler) readMCPCatalog(ctx context.Context, catalogName, sourceURL, token string, options ...mcp.ValidationOptions) ([]kclient.Object, error) {
+func (h *Handler) readMCPCatalog(ctx context.Context, catalogName, sourceURL string, auth git.Auth, options ...mcp.ValidationOptions) ([]kclient.Object, error) {
 	validationOptions := h.remoteURLValidationConfig
 	if len(options) > 0 {
 		validationOptions = options[0]
 	}
-	entries, err := readCatalogManifests[types.MCPServerCatalogEntryManifest](ctx, h.httpClient, sourceURL, token)
+	entries, err := readCatalogManifests[types.MCPServerCatalogEntryManifest](ctx, h.httpClient, sourceURL, auth)
 	if err != nil {
 		return nil, err
 	}
@@ -685,9 +705,9 @@ func (h *Handler) readMCPCatalog(ctx context.Context, catalogName, sourceURL, to
 
 		catalogvalidation.NormalizeManifest(&entry)
 		if err := catalogvalidation.ValidateManifest(ctx, entry, catalogvalidation.ValidationOptions{
-			MCP:        validationOptions,
-			MCPBackend: h.mcpBackend,
-			GitManaged: catalogEntry.IsGitManaged(),
+			MCP:                      validationOptions,
+			SecretProviderConfigured: h.secretProviderConfigured,
+			GitManaged:               catalogEntry.IsGitManaged(),
 		}); err != nil {
 			errs = append(errs, fmt.Errorf("failed to validate catalog entry %s: %w", entry.Name, err))
 			continue
@@ -700,10 +720,17 @@ func (h *Handler) readMCPCatalog(ctx context.Context, catalogName, sourceURL, to
 	return objs, errors.Join(errs...)
 }
 
-func readCatalogManifests[T any](ctx context.Context, httpClient *http.Client, sourceURL, token string) ([]T, error) {
+func readCatalogManifests[T any](ctx context.Context, httpClient *http.Client, sourceURL string, auth git.Auth) ([]T, error) {
+	if git.IsSSHURL(sourceURL) {
+		entries, err := readGitCatalogEntries[T](ctx, sourceURL, auth)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read git catalog %s: %w", sourceURL, err)
+		}
+		return entries, nil
+	}
 	if strings.HasPrefix(sourceURL, "http://") || strings.HasPrefix(sourceURL, "https://") {
 		if git.IsGitRepoURL(sourceURL) {
-			entries, err := readGitCatalogEntries[T](ctx, sourceURL, token)
+			entries, err := readGitCatalogEntries[T](ctx, sourceURL, auth)
 			if err != nil {
 				return nil, fmt.Errorf("failed to read git catalog %s: %w", sourceURL, err)
 			}
@@ -714,8 +741,8 @@ func readCatalogManifests[T any](ctx context.Context, httpClient *http.Client, s
 		if err != nil {
 			return nil, fmt.Errorf("failed to create request for catalog %s: %w", sourceURL, err)
 		}
-		if token != "" {
-			req.Header.Set("Authorization", "Bearer "+token)
+		if auth.Token != "" {
+			req.Header.Set("Authorization", "Bearer "+auth.Token)
 		}
 		resp, err := httpClient.Do(req)
 		if err != nil {
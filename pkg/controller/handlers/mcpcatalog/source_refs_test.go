@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/obot-platform/obot/pkg/git"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -30,7 +31,7 @@ npxConfig:
 `), 0o600))
 
 	h := &Handler{}
-	objs, err := h.readMCPCatalog(t.Context(), "default", dir, "")
+	objs, err := h.readMCPCatalog(t.Context(), "default", dir, git.Auth{})
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), `duplicate source entry key "shared"`)
@@ -50,7 +51,7 @@ npxConfig:
 `), 0o600))
 
 	h := &Handler{}
-	objs, err := h.readMCPCatalog(t.Context(), "default", dir, "")
+	objs, err := h.readMCPCatalog(t.Context(), "default", dir, git.Auth{})
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), `source entry key "Bad_Key" must be DNS-friendly`)
@@ -69,7 +70,7 @@ remoteConfig:
 `), 0o600))
 
 	h := &Handler{}
-	objs, err := h.readMCPCatalog(t.Context(), "default", dir, "")
+	objs, err := h.readMCPCatalog(t.Context(), "default", dir, git.Auth{})
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "remoteConfig.tunnelName: cannot be set on catalog-synced entries")
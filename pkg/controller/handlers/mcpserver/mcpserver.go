@@ -19,6 +19,7 @@ import (
 	gateway "github.com/obot-platform/obot/pkg/gateway/client"
 	gatewaytypes "github.com/obot-platform/obot/pkg/gateway/types"
 	"github.com/obot-platform/obot/pkg/mcp"
+	"github.com/obot-platform/obot/pkg/mcphealth"
 	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
 	"github.com/obot-platform/obot/pkg/system"
 	"github.com/obot-platform/obot/pkg/utils"
@@ -34,6 +35,10 @@ import (
 
 const (
 	oauthMetadataSyncInterval = time.Hour
+
+	// healthStatusRefreshChecks is how many health check intervals may pass
+	// before an unchanged health status is written again.
+	healthStatusRefreshChecks = 10
 )
 
 type Handler struct {
@@ -1075,6 +1080,35 @@ func (h *Handler) SyncOAuthMetadata(req router.Request, _ router.Response) error
 		return nil
 	}
 
+	serverConfig, missingConfig, err := h.serverConfig(req.Ctx, server)
+	if err != nil {
+		return err
+	} else if len(missingConfig) > 0 {
+		return nil
+	}
+
+	metadata, err := h.mcpSessionManager.GetOAuthMetadata(req.Ctx, serverConfig,
+		"Obot Test MCP OAuth Client", system.MCPOAuthCallbackURL(h.baseURL), mcp.RequiresStaticOAuth(*server))
+	if err != nil {
+		return fmt.Errorf("failed to get OAuth metadata: %w", err)
+	}
+
+	statusMetadata := &v1.OAuthMetadata{
+		ProtectedResourceURL:              metadata.ProtectedResourceMetadataURL,
+		AuthorizationServerURL:            metadata.AuthorizationServerMetadataURL,
+		ProtectedResourceMetadata:         runtime.RawExtension{Raw: metadata.ProtectedResourceMetadata},
+		AuthorizationServerMetadata:       runtime.RawExtension{Raw: metadata.AuthorizationServerMetadata},
+		ClientRegistration:                runtime.RawExtension{Raw: metadata.ClientRegistration},
+		DynamicClientRegistration:         metadata.DynamicClientRegistration,
+		ClientIDMetadataDocumentSupported: metadata.ClientIDMetadataDocumentSupported,
+	}
+
+	syncTime := metav1.Now()
+	return setOAuthMetadata(req, server, statusMetadata, &syncTime)
+}
+
+// serverConfig reveals the credentials of the server and converts it to the config used to connect to it.
+func (h *Handler) serverConfig(ctx context.Context, server *v1.MCPServer) (mcp.ServerConfig, []string, error) {
 	var credCtxs []string
 	if server.Spec.IsCatalogServer() {
 		credCtxs = []string{fmt.Sprintf("%s-%s", server.Spec.MCPCatalogID, server.Name)}
@@ -1083,44 +1117,108 @@ func (h *Handler) SyncOAuthMetadata(req router.Request, _ router.Response) error
 	} else {
 		credCtxs = []string{fmt.Sprintf("%s-%s", server.Spec.UserID, server.Name)}
 	}
-	cred, err := h.gatewayClient.RevealCredential(req.Ctx, credCtxs, server.Name)
+	cred, err := h.gatewayClient.RevealCredential(ctx, credCtxs, server.Name)
 	if err != nil && !errors.As(err, &gateway.CredentialNotFoundError{}) {
-		return fmt.Errorf("failed to reveal credential: %w", err)
+		return mcp.ServerConfig{}, nil, fmt.Errorf("failed to reveal credential: %w", err)
 	}
 
 	var staticOAuthCred gatewaytypes.Credential
 	if server.Spec.MCPServerCatalogEntryName != "" {
-		staticOAuthCred, err = h.gatewayClient.RevealCredential(req.Ctx, []string{system.MCPOAuthCredentialName(server.Spec.MCPServerCatalogEntryName)}, system.StaticOAuthCredentialName)
+		staticOAuthCred, err = h.gatewayClient.RevealCredential(ctx, []string{system.MCPOAuthCredentialName(server.Spec.MCPServerCatalogEntryName)}, system.StaticOAuthCredentialName)
 		if err != nil && !errors.As(err, &gateway.CredentialNotFoundError{}) {
-			return fmt.Errorf("failed to reveal credential: %w", err)
+			return mcp.ServerConfig{}, nil, fmt.Errorf("failed to reveal credential: %w", err)
 		}
 	}
 
 	serverConfig, missingConfig, err := mcp.ServerToServerConfig(*server, server.ValidConnectURLs(h.baseURL), server.Spec.UserID, server.Name, server.Status.MCPCatalogID, cred.Secrets, nil, staticOAuthCred.Secrets)
 	if err != nil {
-		return fmt.Errorf("failed to convert MCP server to server config: %w", err)
-	} else if len(missingConfig) > 0 {
+		return mcp.ServerConfig{}, nil, fmt.Errorf("failed to convert MCP server to server config: %w", err)
+	}
+	return serverConfig, missingConfig, nil
+}
+
+// CheckHealth periodically probes running servers, records the result on the
+// server's status and feeds the gateway's circuit breaker.
+func (h *Handler) CheckHealth(req router.Request, resp router.Response) error {
+	server := req.Object.(*v1.MCPServer)
+	interval := h.mcpSessionManager.HealthCheckInterval()
+	if interval <= 0 || !shouldCheckHealth(server) {
 		return nil
 	}
 
-	metadata, err := h.mcpSessionManager.GetOAuthMetadata(req.Ctx, serverConfig,
-		"Obot Test MCP OAuth Client", system.MCPOAuthCallbackURL(h.baseURL), mcp.RequiresStaticOAuth(*server))
+	tracker := h.mcpSessionManager.HealthTracker()
+	lastChecked := tracker.LastProbed(server.Name)
+	if server.Status.Health != nil && server.Status.Health.LastChecked.After(lastChecked) {
+		lastChecked = server.Status.Health.LastChecked.Time
+	}
+	if next := lastChecked.Add(interval); time.Now().Before(next) {
+		resp.RetryAfter(time.Until(next))
+		return nil
+	}
+
+	serverConfig, missingConfig, err := h.serverConfig(req.Ctx, server)
 	if err != nil {
-		return fmt.Errorf("failed to get OAuth metadata: %w", err)
+		return err
+	} else if len(missingConfig) > 0 {
+		return nil
 	}
 
-	statusMetadata := &v1.OAuthMetadata{
-		ProtectedResourceURL:              metadata.ProtectedResourceMetadataURL,
-		AuthorizationServerURL:            metadata.AuthorizationServerMetadataURL,
-		ProtectedResourceMetadata:         runtime.RawExtension{Raw: metadata.ProtectedResourceMetadata},
-		AuthorizationServerMetadata:       runtime.RawExtension{Raw: metadata.AuthorizationServerMetadata},
-		ClientRegistration:                runtime.RawExtension{Raw: metadata.ClientRegistration},
-		DynamicClientRegistration:         metadata.DynamicClientRegistration,
-		ClientIDMetadataDocumentSupported: metadata.ClientIDMetadataDocumentSupported,
+	start := time.Now()
+	checkErr := h.mcpSessionManager.CheckServerHealth(req.Ctx, serverConfig)
+	check := v1.MCPServerHealthCheck{
+		Time:          metav1.NewTime(start),
+		Healthy:       checkErr == nil,
+		LatencyMillis: time.Since(start).Milliseconds(),
 	}
 
-	syncTime := metav1.Now()
-	return setOAuthMetadata(req, server, statusMetadata, &syncTime)
+	tracker.RecordProbe(server.Name, start)
+	if checkErr != nil {
+		check.Error = checkErr.Error()
+		tracker.RecordFailure(server.Name)
+		slog.Debug("MCP server health check failed", "server", server.Name, "error", checkErr)
+	} else {
+		tracker.RecordSuccess(server.Name)
+	}
+
+	health := server.Status.Health.DeepCopy()
+	if health == nil {
+		health = new(v1.MCPServerHealth)
+	}
+	mcphealth.RecordCheck(health, check, tracker.FailureThreshold(), interval)
+	if mcphealth.NeedsUpdate(server.Status.Health, health, healthStatusRefreshChecks*interval) {
+		server.Status.Health = health
+		if err := req.Client.Status().Update(req.Ctx, server); err != nil {
+			return err
+		}
+	}
+
+	resp.RetryAfter(interval)
+	return nil
+}
+
+// ForgetHealth drops the in-memory health state of deleted servers.
+func (h *Handler) ForgetHealth(req router.Request, _ router.Response) error {
+	if req.Object == nil || !req.Object.GetDeletionTimestamp().IsZero() {
+		h.mcpSessionManager.HealthTracker().Forget(req.Name)
+	}
+	return nil
+}
+
+func shouldCheckHealth(server *v1.MCPServer) bool {
+	if server.Spec.Template || server.Spec.NeedsURL || server.Status.Idle || !server.DeletionTimestamp.IsZero() {
+		return false
+	}
+
+	switch server.Spec.Manifest.Runtime {
+	case types.RuntimeComposite:
+		// Components are checked individually.
+		return false
+	case types.RuntimeRemote:
+		return server.Spec.Manifest.RemoteConfig != nil
+	default:
+		// Only check hosted servers that are running so the check never deploys one.
+		return server.Status.DeploymentStatus == "Available"
+	}
 }
 
 func (h *Handler) SyncThirdPartyAuthStatus(req router.Request, _ router.Response) error {
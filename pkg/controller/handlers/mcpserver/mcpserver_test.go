@@ -1560,6 +1560,75 @@ func TestShouldSyncOAuthMetadata(t *testing.T) {
 	}
 }
 
+func TestShouldCheckHealth(t *testing.T) {
+	tests := []struct {
+		name     string
+		mutate   func(*v1.MCPServer)
+		expected bool
+	}{
+		{
+			name: "checks remote server",
+			mutate: func(server *v1.MCPServer) {
+				server.Spec.Manifest.Runtime = types.RuntimeRemote
+				server.Spec.Manifest.RemoteConfig = &types.RemoteRuntimeConfig{URL: "https://example.com/mcp"}
+			},
+			expected: true,
+		},
+		{
+			name: "skips remote server without config",
+			mutate: func(server *v1.MCPServer) {
+				server.Spec.Manifest.Runtime = types.RuntimeRemote
+			},
+		},
+		{
+			name: "checks available hosted server",
+			mutate: func(server *v1.MCPServer) {
+				server.Spec.Manifest.Runtime = types.RuntimeUVX
+				server.Status.DeploymentStatus = "Available"
+			},
+			expected: true,
+		},
+		{
+			name: "skips hosted server that is not running",
+			mutate: func(server *v1.MCPServer) {
+				server.Spec.Manifest.Runtime = types.RuntimeUVX
+				server.Status.DeploymentStatus = "Progressing"
+			},
+		},
+		{
+			name: "skips idle server",
+			mutate: func(server *v1.MCPServer) {
+				server.Spec.Manifest.Runtime = types.RuntimeUVX
+				server.Status.DeploymentStatus = "Available"
+				server.Status.Idle = true
+			},
+		},
+		{
+			name: "skips template server",
+			mutate: func(server *v1.MCPServer) {
+				server.Spec.Manifest.Runtime = types.RuntimeRemote
+				server.Spec.Manifest.RemoteConfig = &types.RemoteRuntimeConfig{URL: "https://example.com/mcp"}
+				server.Spec.Template = true
+			},
+		},
+		{
+			name: "skips composite server",
+			mutate: func(server *v1.MCPServer) {
+				server.Spec.Manifest.Runtime = types.RuntimeComposite
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newMCPServer("test-server")
+			tt.mutate(server)
+
+			assert.Equal(t, tt.expected, shouldCheckHealth(server))
+		})
+	}
+}
+
 func TestShutdownIdleServersSetsLastRequestTimeForOlderServers(t *testing.T) {
 	server := newMCPServer("older-server")
 	server.CreationTimestamp = metav1.NewTime(time.Now().Add(-2 * time.Hour))
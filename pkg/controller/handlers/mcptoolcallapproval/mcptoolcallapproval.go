@@ -0,0 +1,58 @@
+package mcptoolcallapproval
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/obot-platform/nah/pkg/router"
+	"github.com/obot-platform/obot/apiclient/types"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// expiryGracePeriod gives the gateway holding a call time to expire its
+	// approval itself before the controller assumes the gateway is gone.
+	expiryGracePeriod = time.Minute
+	// retention is how long finished approvals are kept for review.
+	retention = 7 * 24 * time.Hour
+)
+
+type Handler struct {
+	now func() time.Time
+}
+
+func New() *Handler {
+	return &Handler{now: time.Now}
+}
+
+// Reconcile expires approvals that are still pending after the call waiting on
+// them would have timed out, which happens when the gateway holding the call
+// stops, and deletes finished approvals once they are past retention.
+func (h *Handler) Reconcile(req router.Request, resp router.Response) error {
+	approval := req.Object.(*v1.MCPToolCallApproval)
+	now := h.now()
+
+	if approval.Spec.State == types.MCPToolCallApprovalStatePending {
+		if remaining := approval.Spec.ExpiresAt.Add(expiryGracePeriod).Sub(now); remaining > 0 {
+			resp.RetryAfter(remaining)
+			return nil
+		}
+
+		approval.Spec.State = types.MCPToolCallApprovalStateExpired
+		approval.Spec.DecidedAt = metav1.NewTime(now)
+		slog.Info("tool call approval expired", "approval", approval.Name, "requester", approval.Spec.RequesterID,
+			"mcpID", approval.Spec.MCPID, "tool", approval.Spec.ToolName)
+		return req.Client.Update(req.Ctx, approval)
+	}
+
+	finishedAt := approval.Spec.DecidedAt.Time
+	if finishedAt.IsZero() {
+		finishedAt = approval.Spec.ExpiresAt.Time
+	}
+	if remaining := finishedAt.Add(retention).Sub(now); remaining > 0 {
+		resp.RetryAfter(remaining)
+		return nil
+	}
+	return req.Delete(approval)
+}
@@ -0,0 +1,103 @@
+package mcptoolcallapproval
+
+import (
+	"testing"
+	"time"
+
+	"github.com/obot-platform/nah/pkg/router"
+	"github.com/obot-platform/obot/apiclient/types"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	storagescheme "github.com/obot-platform/obot/pkg/storage/scheme"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReconcile(t *testing.T) {
+	now := time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC)
+	for _, tt := range []struct {
+		name      string
+		state     types.MCPToolCallApprovalState
+		expiresAt time.Time
+		decidedAt time.Time
+		wantState types.MCPToolCallApprovalState
+		deleted   bool
+		delay     time.Duration
+	}{
+		{
+			name:      "waits for pending approval",
+			state:     types.MCPToolCallApprovalStatePending,
+			expiresAt: now.Add(5 * time.Minute),
+			wantState: types.MCPToolCallApprovalStatePending,
+			delay:     6 * time.Minute,
+		},
+		{
+			name:      "leaves a just expired approval to the gateway",
+			state:     types.MCPToolCallApprovalStatePending,
+			expiresAt: now.Add(-30 * time.Second),
+			wantState: types.MCPToolCallApprovalStatePending,
+			delay:     30 * time.Second,
+		},
+		{
+			name:      "expires abandoned approval",
+			state:     types.MCPToolCallApprovalStatePending,
+			expiresAt: now.Add(-2 * time.Minute),
+			wantState: types.MCPToolCallApprovalStateExpired,
+		},
+		{
+			name:      "keeps decided approval",
+			state:     types.MCPToolCallApprovalStateApproved,
+			expiresAt: now.Add(-time.Hour),
+			decidedAt: now.Add(-2 * time.Hour),
+			wantState: types.MCPToolCallApprovalStateApproved,
+			delay:     retention - 2*time.Hour,
+		},
+		{
+			name:      "deletes old approval",
+			state:     types.MCPToolCallApprovalStateRejected,
+			expiresAt: now.Add(-retention),
+			decidedAt: now.Add(-retention - time.Minute),
+			deleted:   true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			approval := &v1.MCPToolCallApproval{
+				Name:      "mtca1abc",
+				Namespace: "default",
+				Spec: v1.MCPToolCallApprovalSpec{
+					RequesterID: "1",
+					MCPID:       "ms1deployer",
+					ToolName:    "deploy",
+					State:       tt.state,
+					ExpiresAt:   metav1.NewTime(tt.expiresAt),
+					DecidedAt:   metav1.NewTime(tt.decidedAt),
+				},
+			}
+			c := fake.NewClientBuilder().WithScheme(storagescheme.Scheme).WithObjects(approval).Build()
+			h := &Handler{now: func() time.Time { return now }}
+
+			var current v1.MCPToolCallApproval
+			require.NoError(t, c.Get(t.Context(), kclient.ObjectKeyFromObject(approval), &current))
+			resp := &router.ResponseWrapper{}
+			require.NoError(t, h.Reconcile(router.Request{
+				Client:    c,
+				Ctx:       t.Context(),
+				Object:    &current,
+				Namespace: approval.Namespace,
+				Name:      approval.Name,
+			}, resp))
+			assert.Equal(t, tt.delay, resp.Delay)
+
+			err := c.Get(t.Context(), kclient.ObjectKeyFromObject(approval), &current)
+			if tt.deleted {
+				assert.True(t, apierrors.IsNotFound(err), "expected approval to be deleted, got %v", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantState, current.Spec.State)
+		})
+	}
+}
@@ -413,6 +413,8 @@ func (h *Handler) parseModelProvider(model resolvedLLMModel) (nanobotLLMProvider
 			dialect = nanobottypes.DialectAnthropicMessages
 		case system.OpenAIModelProvider:
 			dialect = nanobottypes.DialectOpenAIResponses
+		case system.GenericChatCompletionsModelProvider:
+			dialect = nanobottypes.DialectOpenAIChatCompletions
 		default:
 			dialect = nanobottypes.DialectOpenResponses
 		}
@@ -426,6 +428,8 @@ func (h *Handler) parseModelProvider(model resolvedLLMModel) (nanobotLLMProvider
 		baseURL += "/anthropic/v1"
 	case system.GenericResponsesModelProvider:
 		baseURL += "/generic-responses/v1"
+	case system.GenericChatCompletionsModelProvider:
+		baseURL += "/generic-chat-completions/v1"
 	case system.AmazonBedrockModelProvider:
 		baseURL += "/aws-bedrock/v1"
 	case system.AmazonBedrockAPIKeyModelProvider:
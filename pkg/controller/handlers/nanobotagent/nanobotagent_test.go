@@ -121,6 +121,7 @@ func TestNanobotParseModelProviderNamedRoutes(t *testing.T) {
 		{system.OpenAIModelProvider, nanobottypes.DialectOpenAIResponses, "https://obot.example.com/api/llm-proxy/openai/v1"},
 		{system.OpenAIModelProvider, nanobottypes.DialectOpenAIChatCompletions, "https://obot.example.com/api/llm-proxy/openai/v1"},
 		{system.GenericResponsesModelProvider, nanobottypes.DialectOpenResponses, "https://obot.example.com/api/llm-proxy/generic-responses/v1"},
+		{system.GenericChatCompletionsModelProvider, nanobottypes.DialectOpenAIChatCompletions, "https://obot.example.com/api/llm-proxy/generic-chat-completions/v1"},
 	} {
 		model := resolvedLLMModel{
 			Name:            "some-model",
@@ -151,6 +152,7 @@ func TestNanobotParseModelProviderBuiltinFallbacks(t *testing.T) {
 		{system.OpenAIModelProvider, nanobottypes.DialectOpenAIResponses, "https://obot.example.com/api/llm-proxy/openai/v1"},
 		{system.AnthropicModelProvider, nanobottypes.DialectAnthropicMessages, "https://obot.example.com/api/llm-proxy/anthropic/v1"},
 		{system.GenericResponsesModelProvider, nanobottypes.DialectOpenResponses, "https://obot.example.com/api/llm-proxy/generic-responses/v1"},
+		{system.GenericChatCompletionsModelProvider, nanobottypes.DialectOpenAIChatCompletions, "https://obot.example.com/api/llm-proxy/generic-chat-completions/v1"},
 	} {
 		model := resolvedLLMModel{Name: "my-model", ModelProvider: tc.modelProvider}
 		p, qualifiedName, err := h.parseModelProvider(model)
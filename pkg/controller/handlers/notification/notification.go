@@ -0,0 +1,120 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/obot-platform/nah/pkg/router"
+	"github.com/obot-platform/obot/apiclient/types"
+	gclient "github.com/obot-platform/obot/pkg/gateway/client"
+	notifier "github.com/obot-platform/obot/pkg/notification"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// maxAttempts is how many times a delivery is tried before it fails.
+	maxAttempts = 5
+	// retryBackoff is the wait before the first retry, doubling on each retry.
+	retryBackoff = time.Minute
+	// deliveryRetention is how long deliveries are kept in the delivery log.
+	// It is also how long an event key suppresses repeated deliveries.
+	deliveryRetention = 7 * 24 * time.Hour
+)
+
+type Handler struct {
+	gatewayClient *gclient.Client
+	now           func() time.Time
+	revealSecret  func(ctx context.Context, channelName string) (string, error)
+	send          func(ctx context.Context, channel types.NotificationChannelManifest, secret string, event types.NotificationEvent) error
+}
+
+func New(gatewayClient *gclient.Client) *Handler {
+	h := &Handler{
+		gatewayClient: gatewayClient,
+		now:           time.Now,
+		send:          notifier.Send,
+	}
+	h.revealSecret = h.revealChannelSecret
+	return h
+}
+
+// Deliver sends a pending delivery to its channel, retrying with backoff until
+// it succeeds or runs out of attempts, and deletes finished deliveries once
+// they are older than the retention period. The router saves the status.
+func (h *Handler) Deliver(req router.Request, resp router.Response) error {
+	delivery := req.Object.(*v1.NotificationDelivery)
+	now := h.now()
+
+	if delivery.Status.State == types.NotificationDeliveryStateDelivered || delivery.Status.State == types.NotificationDeliveryStateFailed {
+		if remaining := delivery.CreationTimestamp.Add(deliveryRetention).Sub(now); remaining > 0 {
+			resp.RetryAfter(remaining)
+			return nil
+		}
+		return kclient.IgnoreNotFound(req.Client.Delete(req.Ctx, delivery))
+	}
+
+	if wait := delivery.Status.NextAttemptAt.Sub(now); !delivery.Status.NextAttemptAt.IsZero() && wait > 0 {
+		resp.RetryAfter(wait)
+		return nil
+	}
+
+	err := h.deliver(req, delivery)
+	delivery.Status.Attempts++
+	delivery.Status.LastAttemptAt = metav1.NewTime(now)
+	delivery.Status.NextAttemptAt = metav1.Time{}
+	switch {
+	case err == nil:
+		delivery.Status.State = types.NotificationDeliveryStateDelivered
+		delivery.Status.LastError = ""
+		delivery.Status.DeliveredAt = metav1.NewTime(now)
+	case delivery.Status.Attempts >= maxAttempts:
+		delivery.Status.State = types.NotificationDeliveryStateFailed
+		delivery.Status.LastError = err.Error()
+		slog.Warn("Notification delivery failed", "delivery", delivery.Name, "channel", delivery.Spec.ChannelName,
+			"event", delivery.Spec.Event.Type, "attempts", delivery.Status.Attempts, "error", err)
+	default:
+		backoff := retryBackoff << (delivery.Status.Attempts - 1)
+		delivery.Status.State = types.NotificationDeliveryStatePending
+		delivery.Status.LastError = err.Error()
+		delivery.Status.NextAttemptAt = metav1.NewTime(now.Add(backoff))
+		resp.RetryAfter(backoff)
+	}
+	return nil
+}
+
+func (h *Handler) deliver(req router.Request, delivery *v1.NotificationDelivery) error {
+	var channel v1.NotificationChannel
+	if err := req.Get(&channel, delivery.Namespace, delivery.Spec.ChannelName); apierrors.IsNotFound(err) {
+		return fmt.Errorf("notification channel %s no longer exists", delivery.Spec.ChannelName)
+	} else if err != nil {
+		return err
+	}
+
+	secret, err := h.revealSecret(req.Ctx, channel.Name)
+	if err != nil {
+		return fmt.Errorf("failed to get channel secret: %w", err)
+	}
+	return h.send(req.Ctx, channel.Spec.Manifest, secret, delivery.Spec.Event)
+}
+
+func (h *Handler) revealChannelSecret(ctx context.Context, channelName string) (string, error) {
+	credential, err := h.gatewayClient.RevealCredential(ctx, []string{notifier.CredentialContext}, channelName)
+	if errors.As(err, &gclient.CredentialNotFoundError{}) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+	return credential.Secrets[notifier.SecretKey], nil
+}
+
+// CleanupChannel removes a deleted channel's secret.
+func (h *Handler) CleanupChannel(req router.Request, _ router.Response) error {
+	_, err := h.gatewayClient.DeleteCredential(req.Ctx, notifier.CredentialContext, req.Object.GetName())
+	return err
+}
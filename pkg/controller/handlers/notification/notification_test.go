@@ -0,0 +1,127 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/obot-platform/nah/pkg/router"
+	"github.com/obot-platform/obot/apiclient/types"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	storagescheme "github.com/obot-platform/obot/pkg/storage/scheme"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newTestDelivery(created time.Time) *v1.NotificationDelivery {
+	return &v1.NotificationDelivery{
+		Name:              "nd1test",
+		Namespace:         "default",
+		CreationTimestamp: metav1.NewTime(created),
+		Spec: v1.NotificationDeliverySpec{
+			ChannelName: "nc1webhook",
+			RuleName:    "nr1violations",
+			Event:       types.NotificationEvent{ID: "ev1", Type: types.NotificationEventMessagePolicyViolation, Summary: "blocked"},
+		},
+	}
+}
+
+func newTestChannel() *v1.NotificationChannel {
+	return &v1.NotificationChannel{
+		Name:      "nc1webhook",
+		Namespace: "default",
+		Spec: v1.NotificationChannelSpec{Manifest: types.NotificationChannelManifest{
+			DisplayName: "SIEM",
+			ChannelType: types.NotificationChannelTypeWebhook,
+			URL:         "https://siem.example.com/hook",
+		}},
+	}
+}
+
+func deliver(t *testing.T, h *Handler, c kclient.WithWatch, delivery *v1.NotificationDelivery) *router.ResponseWrapper {
+	t.Helper()
+	resp := &router.ResponseWrapper{}
+	require.NoError(t, h.Deliver(router.Request{
+		Client:    c,
+		Ctx:       t.Context(),
+		Object:    delivery,
+		Namespace: delivery.Namespace,
+		Name:      delivery.Name,
+	}, resp))
+	return resp
+}
+
+func TestDeliverRetriesThenFails(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	delivery := newTestDelivery(now)
+	c := fake.NewClientBuilder().WithScheme(storagescheme.Scheme).WithObjects(newTestChannel(), delivery).Build()
+
+	var sent []string
+	h := &Handler{
+		now:          func() time.Time { return now },
+		revealSecret: func(context.Context, string) (string, error) { return "s3cret", nil },
+		send: func(_ context.Context, channel types.NotificationChannelManifest, secret string, event types.NotificationEvent) error {
+			sent = append(sent, channel.URL+" "+secret+" "+event.ID)
+			return errors.New("unexpected status 503")
+		},
+	}
+
+	resp := deliver(t, h, c, delivery)
+	assert.Equal(t, types.NotificationDeliveryStatePending, delivery.Status.State)
+	assert.Equal(t, 1, delivery.Status.Attempts)
+	assert.Equal(t, "unexpected status 503", delivery.Status.LastError)
+	assert.Equal(t, time.Minute, resp.Delay)
+	assert.Equal(t, []string{"https://siem.example.com/hook s3cret ev1"}, sent)
+
+	// Not sent again before the next attempt is due.
+	resp = deliver(t, h, c, delivery)
+	assert.Equal(t, 1, delivery.Status.Attempts)
+	assert.Equal(t, time.Minute, resp.Delay)
+
+	for attempt := 2; attempt <= maxAttempts; attempt++ {
+		now = delivery.Status.NextAttemptAt.Time
+		deliver(t, h, c, delivery)
+		assert.Equal(t, attempt, delivery.Status.Attempts)
+	}
+	assert.Equal(t, types.NotificationDeliveryStateFailed, delivery.Status.State)
+	assert.Len(t, sent, maxAttempts)
+}
+
+func TestDeliverSucceedsAndExpires(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	delivery := newTestDelivery(now)
+	c := fake.NewClientBuilder().WithScheme(storagescheme.Scheme).WithObjects(newTestChannel(), delivery).Build()
+	h := &Handler{
+		now:          func() time.Time { return now },
+		revealSecret: func(context.Context, string) (string, error) { return "", nil },
+		send: func(context.Context, types.NotificationChannelManifest, string, types.NotificationEvent) error {
+			return nil
+		},
+	}
+
+	deliver(t, h, c, delivery)
+	assert.Equal(t, types.NotificationDeliveryStateDelivered, delivery.Status.State)
+	assert.Equal(t, now, delivery.Status.DeliveredAt.Time)
+
+	resp := deliver(t, h, c, delivery)
+	assert.Equal(t, deliveryRetention, resp.Delay)
+
+	now = now.Add(deliveryRetention)
+	deliver(t, h, c, delivery)
+	err := c.Get(t.Context(), router.Key(delivery.Namespace, delivery.Name), &v1.NotificationDelivery{})
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+func TestDeliverMissingChannel(t *testing.T) {
+	delivery := newTestDelivery(time.Now())
+	c := fake.NewClientBuilder().WithScheme(storagescheme.Scheme).WithObjects(delivery).Build()
+	h := &Handler{now: time.Now}
+
+	deliver(t, h, c, delivery)
+	assert.Equal(t, "notification channel nc1webhook no longer exists", delivery.Status.LastError)
+}
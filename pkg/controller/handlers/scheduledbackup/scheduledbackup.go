@@ -0,0 +1,203 @@
+// Package scheduledbackup backs Obot's state up to the artifact blob store on a
+// cron schedule and prunes old backups.
+package scheduledbackup
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"time"
+
+	"github.com/adhocore/gronx"
+	"github.com/obot-platform/obot/pkg/backup"
+	"github.com/obot-platform/obot/pkg/storage/blob"
+)
+
+// indexKey is where the list of scheduled backups is kept. The blob store
+// cannot list its objects, so the index is what retention prunes by.
+const indexKey = "backups/index.json"
+
+// Index records the scheduled backups that are kept, oldest first.
+type Index struct {
+	// LastRun is when the last backup was started, whether or not it
+	// succeeded. The next one is scheduled from it, so a backup missed while
+	// Obot was down is taken when it starts again.
+	LastRun   time.Time `json:"lastRun"`
+	LastError string    `json:"lastError,omitempty"`
+	Backups   []Entry   `json:"backups"`
+}
+
+type Entry struct {
+	Key       string          `json:"key"`
+	CreatedAt time.Time       `json:"createdAt"`
+	Manifest  backup.Manifest `json:"manifest"`
+}
+
+type Handler struct {
+	stores    backup.Stores
+	store     blob.BlobStore
+	bucket    string
+	schedule  string
+	opts      backup.Options
+	retention int
+	now       func() time.Time
+}
+
+// New returns a handler that backs the stores up on the cron schedule, keeping
+// the newest retention backups, or all of them if retention is zero.
+func New(stores backup.Stores, store blob.BlobStore, bucket, schedule string, opts backup.Options, retention int) *Handler {
+	return &Handler{
+		stores:    stores,
+		store:     store,
+		bucket:    bucket,
+		schedule:  schedule,
+		opts:      opts,
+		retention: retention,
+		now:       time.Now,
+	}
+}
+
+// BlobKey is where the backup taken at the given time is kept.
+func BlobKey(taken time.Time) string {
+	return "backups/obot-" + taken.UTC().Format("20060102T150405Z") + ".obotbak"
+}
+
+// Run takes backups on the schedule until ctx is done. It runs only on the
+// leader, so two replicas never back up at once.
+func (h *Handler) Run(ctx context.Context) {
+	if h.schedule == "" {
+		return
+	}
+
+	for {
+		index, err := h.readIndex(ctx)
+		if err != nil {
+			slog.Error("Failed to read the backup index", "error", err)
+		}
+		after := index.LastRun
+		if after.IsZero() {
+			after = h.now()
+		}
+		next, err := gronx.NextTickAfter(h.schedule, after, false)
+		if err != nil {
+			slog.Error("Invalid backup schedule", "schedule", h.schedule, "error", err)
+			return
+		}
+
+		timer := time.NewTimer(max(next.Sub(h.now()), 0))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		if err := h.Backup(ctx); err != nil && !errors.Is(err, context.Canceled) {
+			slog.Error("Scheduled backup failed", "error", err)
+		}
+	}
+}
+
+// Backup takes a backup now, records it in the index, and prunes backups past
+// the retention.
+func (h *Handler) Backup(ctx context.Context) error {
+	index, err := h.readIndex(ctx)
+	if err != nil {
+		return err
+	}
+
+	started := h.now()
+	index.LastRun = started
+	key := BlobKey(started)
+	manifest, backupErr := h.write(ctx, key)
+	if backupErr != nil {
+		index.LastError = backupErr.Error()
+	} else {
+		index.LastError = ""
+		index.Backups = append(index.Backups, Entry{
+			Key:       key,
+			CreatedAt: started,
+			Manifest:  manifest,
+		})
+		slog.Info("Backed up Obot", "bucket", h.bucket, "key", key, "scope", manifest.Scope)
+	}
+
+	var pruned []Entry
+	if h.retention > 0 && len(index.Backups) > h.retention {
+		pruned = index.Backups[:len(index.Backups)-h.retention]
+		index.Backups = index.Backups[len(index.Backups)-h.retention:]
+	}
+	// The index is written before the pruned backups are deleted: a backup
+	// left behind by a failed delete is only wasted space, but an index entry
+	// for a deleted one would be a backup that cannot be restored.
+	if err := h.writeIndex(ctx, index); err != nil {
+		return errors.Join(backupErr, err)
+	}
+	for _, entry := range pruned {
+		if err := h.store.Delete(ctx, h.bucket, entry.Key); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			slog.Warn("Failed to delete an old backup", "key", entry.Key, "error", err)
+		}
+	}
+	return backupErr
+}
+
+func (h *Handler) write(ctx context.Context, key string) (backup.Manifest, error) {
+	// The archive is streamed straight into the store, so it is never held in
+	// memory or on Obot's own disk.
+	reader, writer := io.Pipe()
+	type written struct {
+		manifest backup.Manifest
+		err      error
+	}
+	done := make(chan written, 1)
+	go func() {
+		manifest, err := backup.Write(ctx, writer, h.stores, h.opts)
+		_ = writer.CloseWithError(err)
+		done <- written{manifest: manifest, err: err}
+	}()
+
+	uploadErr := h.store.Upload(ctx, h.bucket, key, reader)
+	// Unblock the writer if the upload gave up before reading everything.
+	_ = reader.CloseWithError(io.ErrClosedPipe)
+	result := <-done
+	if err := errors.Join(result.err, uploadErr); err != nil {
+		// Whatever made it to the store is an incomplete archive.
+		_ = h.store.Delete(context.WithoutCancel(ctx), h.bucket, key)
+		if result.err != nil {
+			return backup.Manifest{}, fmt.Errorf("failed to write backup: %w", result.err)
+		}
+		return backup.Manifest{}, fmt.Errorf("failed to store backup: %w", uploadErr)
+	}
+	return result.manifest, nil
+}
+
+func (h *Handler) readIndex(ctx context.Context) (Index, error) {
+	var index Index
+	r, err := h.store.Download(ctx, h.bucket, indexKey)
+	if errors.Is(err, fs.ErrNotExist) {
+		return index, nil
+	} else if err != nil {
+		return index, fmt.Errorf("failed to read backup index: %w", err)
+	}
+	defer r.Close()
+	if err := json.NewDecoder(r).Decode(&index); err != nil {
+		return index, fmt.Errorf("failed to decode backup index: %w", err)
+	}
+	return index, nil
+}
+
+func (h *Handler) writeIndex(ctx context.Context, index Index) error {
+	reader, writer := io.Pipe()
+	go func() {
+		_ = writer.CloseWithError(json.NewEncoder(writer).Encode(index))
+	}()
+	if err := h.store.Upload(ctx, h.bucket, indexKey, reader); err != nil {
+		_ = reader.CloseWithError(err)
+		return fmt.Errorf("failed to write backup index: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package scheduledbackup
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/fs"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"github.com/obot-platform/obot/pkg/backup"
+	gatewaydb "github.com/obot-platform/obot/pkg/gateway/db"
+	"github.com/obot-platform/obot/pkg/storage/blob"
+	storagescheme "github.com/obot-platform/obot/pkg/storage/scheme"
+	"gorm.io/gorm"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newHandler(t *testing.T, passphrase string, retention int) *Handler {
+	t.Helper()
+	g, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, err := g.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+	db, err := gatewaydb.New(g, sqlDB, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.AutoMigrate(); err != nil {
+		t.Fatal(err)
+	}
+	store, err := blob.NewDirectoryStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stores := backup.Stores{
+		Storage: fake.NewClientBuilder().WithScheme(storagescheme.Scheme).Build(),
+		Gateway: db,
+	}
+	return New(stores, store, "default", "0 3 * * *", backup.Options{Passphrase: passphrase, Scope: backup.ScopeConfig}, retention)
+}
+
+func TestBackupPrunesPastRetention(t *testing.T) {
+	ctx := t.Context()
+	h := newHandler(t, "secret", 2)
+	start := time.Date(2026, 1, 1, 3, 0, 0, 0, time.UTC)
+
+	for day := range 3 {
+		h.now = func() time.Time { return start.AddDate(0, 0, day) }
+		if err := h.Backup(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	index, err := h.readIndex(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(index.Backups) != 2 || index.Backups[0].Key != BlobKey(start.AddDate(0, 0, 1)) {
+		t.Fatalf("unexpected backups kept: %#v", index.Backups)
+	}
+	if !index.LastRun.Equal(start.AddDate(0, 0, 2)) || index.LastError != "" {
+		t.Fatalf("unexpected last run: %s, %q", index.LastRun, index.LastError)
+	}
+	if _, err := h.store.Download(ctx, h.bucket, BlobKey(start)); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("pruned backup is still stored: %v", err)
+	}
+
+	// What is kept can be restored.
+	r, err := h.store.Download(ctx, h.bucket, index.Backups[1].Key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	archive, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := backup.Restore(ctx, bytes.NewReader(archive), backup.Stores{}, backup.RestoreOptions{Passphrase: "secret", DryRun: true}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFailedBackupIsRecorded(t *testing.T) {
+	ctx := t.Context()
+	// Without a passphrase nothing can be written.
+	h := newHandler(t, "", 2)
+	now := time.Date(2026, 1, 1, 3, 0, 0, 0, time.UTC)
+	h.now = func() time.Time { return now }
+
+	if err := h.Backup(ctx); err == nil {
+		t.Fatal("backup without a passphrase succeeded")
+	}
+	index, err := h.readIndex(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !index.LastRun.Equal(now) || index.LastError == "" || len(index.Backups) != 0 {
+		t.Fatalf("unexpected index after a failed backup: %#v", index)
+	}
+	if _, err := h.store.Download(ctx, h.bucket, BlobKey(now)); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("failed backup left an archive behind: %v", err)
+	}
+}
@@ -12,8 +12,8 @@ func newGitRepositoryFetcher() *gitRepositoryFetcher {
 	return &gitRepositoryFetcher{}
 }
 
-func (f *gitRepositoryFetcher) Fetch(ctx context.Context, repoURL, token, ref string) (*fetchedRepository, error) {
-	dir, commitSHA, cleanup, err := gitpkg.Clone(ctx, repoURL, token, ref)
+func (f *gitRepositoryFetcher) Fetch(ctx context.Context, repoURL string, auth gitpkg.Auth, ref string) (*fetchedRepository, error) {
+	dir, commitSHA, cleanup, err := gitpkg.Clone(ctx, repoURL, auth, ref)
 	if err != nil {
 		return nil, err
 	}
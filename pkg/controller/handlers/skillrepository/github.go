@@ -23,8 +23,19 @@ func (f *fetchedRepository) Cleanup() {
 }
 
 // NormalizeRepositoryURL validates a git repository URL and adds the HTTPS scheme when omitted.
+// SSH addresses are returned as ssh:// URLs.
 func NormalizeRepositoryURL(repoURL string) (string, error) {
 	repoURL = strings.TrimSpace(repoURL)
+	if gitpkg.IsSSHURL(repoURL) {
+		normalized, err := gitpkg.NormalizeSSHURL(repoURL)
+		if err != nil {
+			return "", err
+		}
+		if !gitpkg.IsGitRepoURL(normalized) {
+			return "", fmt.Errorf("repository URL does not appear to be a git repository")
+		}
+		return normalized, nil
+	}
 	if repoURL != "" && !strings.Contains(repoURL, "://") {
 		repoURL = "https://" + repoURL
 	}
@@ -33,7 +44,7 @@ func NormalizeRepositoryURL(repoURL string) (string, error) {
 		return "", fmt.Errorf("invalid repository URL: %w", err)
 	}
 	if u.Scheme != "https" {
-		return "", fmt.Errorf("repository URL must use HTTPS")
+		return "", fmt.Errorf("repository URL must use HTTPS or SSH")
 	}
 	if u.User != nil {
 		return "", fmt.Errorf("repository URL must not include credentials")
@@ -57,7 +68,7 @@ func NormalizeRepositoryURL(repoURL string) (string, error) {
 	return repoURL, nil
 }
 
-// ValidateRepositoryURL returns an error if repoURL is not a valid HTTPS git repository URL.
+// ValidateRepositoryURL returns an error if repoURL is not a valid HTTPS or SSH git repository URL.
 func ValidateRepositoryURL(repoURL string) error {
 	_, err := NormalizeRepositoryURL(repoURL)
 	return err
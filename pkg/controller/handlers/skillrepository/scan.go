@@ -15,6 +15,7 @@ import (
 	"github.com/obot-platform/nah/pkg/name"
 	"github.com/obot-platform/obot/apiclient/types"
 	"github.com/obot-platform/obot/pkg/skillformat"
+	"github.com/obot-platform/obot/pkg/skillscan"
 	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
 	"github.com/obot-platform/obot/pkg/system"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -24,7 +25,7 @@ const (
 	maxSkillMDBytes = 1024 * 1024
 )
 
-func buildSkillsFromRepository(repoRoot string, repo *v1.SkillRepository, commitSHA string, indexedAt metav1.Time) ([]*v1.Skill, error) {
+func buildSkillsFromRepository(repoRoot string, repo *v1.SkillRepository, commitSHA string, indexedAt metav1.Time, scanners []skillscan.Scanner) ([]*v1.Skill, error) {
 	directories, err := discoverSkillDirectories(repoRoot)
 	if err != nil {
 		return nil, err
@@ -38,7 +39,7 @@ func buildSkillsFromRepository(repoRoot string, repo *v1.SkillRepository, commit
 		}
 		relPath = filepath.ToSlash(relPath)
 
-		skill, err := buildSkill(dir, relPath, repo, commitSHA, indexedAt)
+		skill, err := buildSkill(dir, relPath, repo, commitSHA, indexedAt, scanners)
 		if err != nil {
 			return nil, err
 		}
@@ -94,7 +95,7 @@ func discoverSkillDirectories(repoRoot string) ([]string, error) {
 	return directories, nil
 }
 
-func buildSkill(dirPath, relPath string, repo *v1.SkillRepository, commitSHA string, indexedAt metav1.Time) (*v1.Skill, error) {
+func buildSkill(dirPath, relPath string, repo *v1.SkillRepository, commitSHA string, indexedAt metav1.Time, scanners []skillscan.Scanner) (*v1.Skill, error) {
 	skillPath := filepath.Join(dirPath, skillformat.SkillMainFile)
 	f, err := os.Open(skillPath)
 	if err != nil {
@@ -178,6 +179,16 @@ func buildSkill(dirPath, relPath string, repo *v1.SkillRepository, commitSHA str
 	}
 
 	skill.Status.Valid = true
+
+	if len(scanners) > 0 {
+		input, err := skillscan.LoadInput(dirPath, fm)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan skill %s: %w", relPath, err)
+		}
+		skill.Status.ScanFindings = skillscan.Run(scanners, input)
+		skill.Status.ScanBlocked = skillscan.Blocking(skill.Status.ScanFindings)
+	}
+
 	return skill, nil
 }
 
@@ -10,7 +10,9 @@ import (
 
 	"github.com/obot-platform/nah/pkg/router"
 	gclient "github.com/obot-platform/obot/pkg/gateway/client"
+	gitpkg "github.com/obot-platform/obot/pkg/git"
 	"github.com/obot-platform/obot/pkg/gitcredential"
+	"github.com/obot-platform/obot/pkg/skillscan"
 	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -19,15 +21,17 @@ import (
 
 const (
 	syncInterval                      = time.Hour
+	maxSkillRevisions                 = 20
 	SkillRepositoryCredentialToolName = "skill-repository-source-token"
 )
 
 type repositoryFetcher interface {
-	Fetch(ctx context.Context, repoURL, token, ref string) (*fetchedRepository, error)
+	Fetch(ctx context.Context, repoURL string, auth gitpkg.Auth, ref string) (*fetchedRepository, error)
 }
 
 type Handler struct {
 	fetcher       repositoryFetcher
+	scanners      []skillscan.Scanner
 	now           func() time.Time
 	gatewayClient *gclient.Client
 }
@@ -35,6 +39,7 @@ type Handler struct {
 func New(gatewayClient *gclient.Client) *Handler {
 	return &Handler{
 		fetcher:       newGitRepositoryFetcher(),
+		scanners:      skillscan.DefaultScanners(),
 		now:           time.Now,
 		gatewayClient: gatewayClient,
 	}
@@ -65,7 +70,7 @@ func (h *Handler) Sync(req router.Request, resp router.Response) error {
 		}
 	}
 
-	token, err := gitcredential.ResolveOrReveal(req.Ctx, req.Client, h.gatewayClient, repo.Namespace, repo.Spec.GitCredentialID, repo.Spec.RepoURL, repo.Name, SkillRepositoryCredentialToolName)
+	auth, err := gitcredential.ResolveOrReveal(req.Ctx, req.Client, h.gatewayClient, repo.Namespace, repo.Spec.GitCredentialID, repo.Spec.RepoURL, repo.Name, SkillRepositoryCredentialToolName)
 	if errors.Is(err, gitcredential.ErrLegacyCredential) {
 		slog.Error("failed to retrieve legacy credential for repository, continuing without authentication", "repository", repo.Name, "source", repo.Spec.RepoURL, "error", err)
 	} else if err != nil {
@@ -75,7 +80,7 @@ func (h *Handler) Sync(req router.Request, resp router.Response) error {
 		resp.RetryAfter(syncInterval)
 		return nil
 	}
-	fetched, err := h.fetcher.Fetch(req.Ctx, repo.Spec.RepoURL, token, repo.Spec.Ref)
+	fetched, err := h.fetcher.Fetch(req.Ctx, repo.Spec.RepoURL, auth, repo.Spec.Ref)
 	if err != nil {
 		if statusErr := h.recordFailure(req.Ctx, req.Client, namespace, repo.Name, err); statusErr != nil {
 			return statusErr
@@ -86,7 +91,7 @@ func (h *Handler) Sync(req router.Request, resp router.Response) error {
 	defer fetched.Cleanup()
 
 	now := metav1.NewTime(h.now())
-	skills, err := buildSkillsFromRepository(fetched.RepoRoot, repo, fetched.CommitSHA, now)
+	skills, err := buildSkillsFromRepository(fetched.RepoRoot, repo, fetched.CommitSHA, now, h.scanners)
 	if err != nil {
 		if statusErr := h.recordFailure(req.Ctx, req.Client, namespace, repo.Name, err); statusErr != nil {
 			return statusErr
@@ -125,17 +130,22 @@ func upsertSkills(ctx context.Context, c kclient.Client, namespace, repoID strin
 	for _, skill := range skills {
 		existing, ok := existingSkills[skill.Name]
 		if !ok {
+			skill.Status.Revisions = recordSkillRevision(nil, skill)
 			if err := c.Create(ctx, skill); err != nil {
 				return fmt.Errorf("failed to create skill %s: %w", skill.Name, err)
 			}
 			continue
 		}
 
+		// Admin scan overrides are not part of the indexed content, so keep them
+		// across syncs. They stop applying once the install hash changes.
+		skill.Spec.ScanOverride = existing.Spec.ScanOverride
 		existing.Spec = skill.Spec
 		if err := c.Update(ctx, existing); err != nil {
 			return fmt.Errorf("failed to update skill %s: %w", skill.Name, err)
 		}
 
+		skill.Status.Revisions = recordSkillRevision(existing.Status.Revisions, skill)
 		existing.Status = skill.Status
 		if err := c.Status().Update(ctx, existing); err != nil {
 			return fmt.Errorf("failed to update skill status %s: %w", skill.Name, err)
@@ -154,6 +164,34 @@ func upsertSkills(ctx context.Context, c kclient.Client, namespace, repoID strin
 	return nil
 }
 
+// recordSkillRevision prepends the skill's current content to its revision
+// history when it differs from the most recent revision. Only valid skills are
+// recorded, and the history is capped at maxSkillRevisions entries.
+func recordSkillRevision(history []v1.SkillRevision, skill *v1.Skill) []v1.SkillRevision {
+	if !skill.Status.Valid || skill.Spec.InstallHash == "" {
+		return history
+	}
+	if len(history) > 0 && history[0].InstallHash == skill.Spec.InstallHash {
+		return history
+	}
+
+	revisions := make([]v1.SkillRevision, 0, min(len(history)+1, maxSkillRevisions))
+	revisions = append(revisions, v1.SkillRevision{
+		Manifest:    skill.Spec.SkillManifest,
+		CommitSHA:   skill.Spec.CommitSHA,
+		InstallHash: skill.Spec.InstallHash,
+		IndexedAt:   skill.Status.LastIndexedAt,
+		ScanBlocked: skill.Status.ScanBlocked,
+	})
+	for _, rev := range history {
+		if len(revisions) == maxSkillRevisions {
+			break
+		}
+		revisions = append(revisions, rev)
+	}
+	return revisions
+}
+
 func listSkillsForRepo(ctx context.Context, c kclient.Client, namespace, repoID string) (map[string]*v1.Skill, error) {
 	var list v1.SkillList
 	if err := c.List(ctx, &list, kclient.InNamespace(namespace), kclient.MatchingFields{"spec.repoID": repoID}); err != nil {
@@ -227,7 +265,7 @@ func clearSyncAnnotation(ctx context.Context, c kclient.Client, namespace, name
 	return c.Update(ctx, &repo)
 }
 
-func materializeSkillSource(ctx context.Context, fetcher repositoryFetcher, skill *v1.Skill, token string) (*fetchedRepository, string, error) {
+func materializeSkillSource(ctx context.Context, fetcher repositoryFetcher, skill *v1.Skill, auth gitpkg.Auth) (*fetchedRepository, string, error) {
 	if skill.Spec.RepoURL == "" {
 		return nil, "", fmt.Errorf("skill %s is missing repoURL", skill.Name)
 	}
@@ -238,7 +276,7 @@ func materializeSkillSource(ctx context.Context, fetcher repositoryFetcher, skil
 		return nil, "", fmt.Errorf("skill %s is missing relativePath", skill.Name)
 	}
 
-	fetched, err := fetcher.Fetch(ctx, skill.Spec.RepoURL, token, skill.Spec.CommitSHA)
+	fetched, err := fetcher.Fetch(ctx, skill.Spec.RepoURL, auth, skill.Spec.CommitSHA)
 	if err != nil {
 		return nil, "", err
 	}
@@ -266,8 +304,8 @@ func materializeSkillSource(ctx context.Context, fetcher repositoryFetcher, skil
 	return fetched, skillDir, nil
 }
 
-func MaterializeSkillSource(ctx context.Context, skill *v1.Skill, token string) (func(), string, error) {
-	fetched, skillDir, err := materializeSkillSource(ctx, newGitRepositoryFetcher(), skill, token)
+func MaterializeSkillSource(ctx context.Context, skill *v1.Skill, auth gitpkg.Auth) (func(), string, error) {
+	fetched, skillDir, err := materializeSkillSource(ctx, newGitRepositoryFetcher(), skill, auth)
 	if err != nil {
 		return nil, "", err
 	}
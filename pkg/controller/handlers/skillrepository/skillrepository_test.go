@@ -12,6 +12,7 @@ import (
 	"github.com/obot-platform/obot/apiclient/types"
 	gatewayclient "github.com/obot-platform/obot/pkg/gateway/client"
 	gatewaydb "github.com/obot-platform/obot/pkg/gateway/db"
+	gitpkg "github.com/obot-platform/obot/pkg/git"
 	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
 	storagescheme "github.com/obot-platform/obot/pkg/storage/scheme"
 	storageservices "github.com/obot-platform/obot/pkg/storage/services"
@@ -24,12 +25,12 @@ import (
 
 // mockFetcher implements the repositoryFetcher interface for testing.
 type mockFetcher struct {
-	fetchFn func(ctx context.Context, repoURL, token, ref string) (*fetchedRepository, error)
+	fetchFn func(ctx context.Context, repoURL string, auth gitpkg.Auth, ref string) (*fetchedRepository, error)
 }
 
-func (m *mockFetcher) Fetch(ctx context.Context, repoURL, token, ref string) (*fetchedRepository, error) {
+func (m *mockFetcher) Fetch(ctx context.Context, repoURL string, auth gitpkg.Auth, ref string) (*fetchedRepository, error) {
 	if m.fetchFn != nil {
-		return m.fetchFn(ctx, repoURL, token, ref)
+		return m.fetchFn(ctx, repoURL, auth, ref)
 	}
 	return nil, fmt.Errorf("fetchFn not set")
 }
@@ -136,6 +137,43 @@ func TestUpsertSkills(t *testing.T) {
 		assert.Equal(t, "New description", got.Spec.Description)
 	})
 
+	t.Run("update appends revision history", func(t *testing.T) {
+		existing := newSkill("skill-a", "default", "repo1", "Old description")
+		existing.Spec.CommitSHA = "c1"
+		existing.Spec.InstallHash = "h1"
+		existing.Status.Revisions = []v1.SkillRevision{{CommitSHA: "c1", InstallHash: "h1"}}
+		c := newFakeClient(t, existing)
+
+		updated := newSkill("skill-a", "default", "repo1", "New description")
+		updated.Spec.CommitSHA = "c2"
+		updated.Spec.InstallHash = "h2"
+		require.NoError(t, upsertSkills(ctx, c, "default", "repo1", []*v1.Skill{updated}))
+
+		var got v1.Skill
+		require.NoError(t, c.Get(ctx, kclient.ObjectKey{Namespace: "default", Name: "skill-a"}, &got))
+		require.Len(t, got.Status.Revisions, 2)
+		assert.Equal(t, "c2", got.Status.Revisions[0].CommitSHA)
+		assert.Equal(t, "New description", got.Status.Revisions[0].Manifest.Description)
+		assert.Equal(t, "c1", got.Status.Revisions[1].CommitSHA)
+	})
+
+	t.Run("update preserves scan override", func(t *testing.T) {
+		existing := newSkill("skill-a", "default", "repo1", "Old description")
+		existing.Spec.ScanOverride = &v1.SkillScanOverride{InstallHash: "hash1", OverriddenBy: "admin"}
+		c := newFakeClient(t, existing)
+
+		updated := newSkill("skill-a", "default", "repo1", "New description")
+		updated.Spec.InstallHash = "hash2"
+		err := upsertSkills(ctx, c, "default", "repo1", []*v1.Skill{updated})
+		require.NoError(t, err)
+
+		var got v1.Skill
+		require.NoError(t, c.Get(ctx, kclient.ObjectKey{Namespace: "default", Name: "skill-a"}, &got))
+		require.NotNil(t, got.Spec.ScanOverride)
+		assert.Equal(t, "hash1", got.Spec.ScanOverride.InstallHash)
+		assert.False(t, got.ScanOverridden(), "override must not apply to changed content")
+	})
+
 	t.Run("prune removed", func(t *testing.T) {
 		existing1 := newSkill("skill-a", "default", "repo1", "A")
 		existing2 := newSkill("skill-b", "default", "repo1", "B")
@@ -213,6 +251,55 @@ func TestUpsertSkills(t *testing.T) {
 	})
 }
 
+func TestRecordSkillRevision(t *testing.T) {
+	skillWithHash := func(commitSHA, installHash string) *v1.Skill {
+		skill := newSkill("skill-a", "default", "repo1", "A")
+		skill.Spec.CommitSHA = commitSHA
+		skill.Spec.InstallHash = installHash
+		return skill
+	}
+
+	t.Run("records first revision", func(t *testing.T) {
+		revisions := recordSkillRevision(nil, skillWithHash("c1", "h1"))
+		require.Len(t, revisions, 1)
+		assert.Equal(t, "c1", revisions[0].CommitSHA)
+		assert.Equal(t, "h1", revisions[0].InstallHash)
+		assert.Equal(t, "skill-a", revisions[0].Manifest.Name)
+	})
+
+	t.Run("unchanged content keeps history", func(t *testing.T) {
+		history := []v1.SkillRevision{{CommitSHA: "c1", InstallHash: "h1"}}
+		revisions := recordSkillRevision(history, skillWithHash("c2", "h1"))
+		require.Len(t, revisions, 1)
+		assert.Equal(t, "c1", revisions[0].CommitSHA)
+	})
+
+	t.Run("changed content prepends", func(t *testing.T) {
+		history := []v1.SkillRevision{{CommitSHA: "c1", InstallHash: "h1"}}
+		revisions := recordSkillRevision(history, skillWithHash("c2", "h2"))
+		require.Len(t, revisions, 2)
+		assert.Equal(t, "c2", revisions[0].CommitSHA)
+		assert.Equal(t, "c1", revisions[1].CommitSHA)
+	})
+
+	t.Run("invalid skill is not recorded", func(t *testing.T) {
+		skill := skillWithHash("c2", "h2")
+		skill.Status.Valid = false
+		assert.Empty(t, recordSkillRevision(nil, skill))
+	})
+
+	t.Run("history is bounded", func(t *testing.T) {
+		var history []v1.SkillRevision
+		for i := range maxSkillRevisions {
+			history = append(history, v1.SkillRevision{CommitSHA: fmt.Sprintf("old%d", i), InstallHash: fmt.Sprintf("old%d", i)})
+		}
+		revisions := recordSkillRevision(history, skillWithHash("new", "new"))
+		require.Len(t, revisions, maxSkillRevisions)
+		assert.Equal(t, "new", revisions[0].CommitSHA)
+		assert.Equal(t, fmt.Sprintf("old%d", maxSkillRevisions-2), revisions[maxSkillRevisions-1].CommitSHA)
+	})
+}
+
 func TestListSkillsForRepo(t *testing.T) {
 	ctx := t.Context()
 
@@ -264,7 +351,7 @@ func TestSync(t *testing.T) {
 		h := &Handler{
 			gatewayClient: gatewayClient,
 			fetcher: &mockFetcher{
-				fetchFn: func(_ context.Context, _, _, _ string) (*fetchedRepository, error) {
+				fetchFn: func(context.Context, string, gitpkg.Auth, string) (*fetchedRepository, error) {
 					return fetched, nil
 				},
 			},
@@ -310,7 +397,7 @@ func TestSync(t *testing.T) {
 		h := &Handler{
 			gatewayClient: gatewayClient,
 			fetcher: &mockFetcher{
-				fetchFn: func(_ context.Context, _, _, _ string) (*fetchedRepository, error) {
+				fetchFn: func(context.Context, string, gitpkg.Auth, string) (*fetchedRepository, error) {
 					fetchCalled = true
 					return nil, fmt.Errorf("should not be called")
 				},
@@ -351,7 +438,7 @@ func TestSync(t *testing.T) {
 		h := &Handler{
 			gatewayClient: gatewayClient,
 			fetcher: &mockFetcher{
-				fetchFn: func(_ context.Context, _, _, _ string) (*fetchedRepository, error) {
+				fetchFn: func(context.Context, string, gitpkg.Auth, string) (*fetchedRepository, error) {
 					fetchCalled = true
 					return fetched, nil
 				},
@@ -387,7 +474,7 @@ func TestSync(t *testing.T) {
 		h := &Handler{
 			gatewayClient: gatewayClient,
 			fetcher: &mockFetcher{
-				fetchFn: func(_ context.Context, _, _, _ string) (*fetchedRepository, error) {
+				fetchFn: func(context.Context, string, gitpkg.Auth, string) (*fetchedRepository, error) {
 					return nil, fmt.Errorf("network timeout")
 				},
 			},
@@ -425,7 +512,7 @@ func TestSync(t *testing.T) {
 		h := &Handler{
 			gatewayClient: gatewayClient,
 			fetcher: &mockFetcher{
-				fetchFn: func(_ context.Context, _, _, _ string) (*fetchedRepository, error) {
+				fetchFn: func(context.Context, string, gitpkg.Auth, string) (*fetchedRepository, error) {
 					fetchCalls++
 					return nil, fmt.Errorf("bad credentials")
 				},
@@ -476,9 +563,9 @@ func TestSync(t *testing.T) {
 		fetchCalled := false
 		h := &Handler{
 			gatewayClient: failedGatewayClient,
-			fetcher: &mockFetcher{fetchFn: func(_ context.Context, _ string, token string, _ string) (*fetchedRepository, error) {
+			fetcher: &mockFetcher{fetchFn: func(_ context.Context, _ string, auth gitpkg.Auth, _ string) (*fetchedRepository, error) {
 				fetchCalled = true
-				assert.Empty(t, token)
+				assert.Empty(t, auth)
 				return fetched, nil
 			}},
 			now: func() time.Time { return fixedTime },
@@ -515,7 +602,7 @@ func TestSync(t *testing.T) {
 		h := &Handler{
 			gatewayClient: gatewayClient,
 			fetcher: &mockFetcher{
-				fetchFn: func(_ context.Context, _, _, _ string) (*fetchedRepository, error) {
+				fetchFn: func(context.Context, string, gitpkg.Auth, string) (*fetchedRepository, error) {
 					return &fetchedRepository{
 						RepoRoot:  root,
 						CommitSHA: "abc123",
@@ -690,7 +777,7 @@ func TestMaterializeSkillSource(t *testing.T) {
 				RelativePath: "my-skill",
 			},
 		}
-		_, _, err := materializeSkillSource(ctx, &mockFetcher{}, skill, "")
+		_, _, err := materializeSkillSource(ctx, &mockFetcher{}, skill, gitpkg.Auth{})
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "missing repoURL")
 	})
@@ -703,7 +790,7 @@ func TestMaterializeSkillSource(t *testing.T) {
 				RelativePath: "my-skill",
 			},
 		}
-		_, _, err := materializeSkillSource(ctx, &mockFetcher{}, skill, "")
+		_, _, err := materializeSkillSource(ctx, &mockFetcher{}, skill, gitpkg.Auth{})
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "missing commitSHA")
 	})
@@ -716,7 +803,7 @@ func TestMaterializeSkillSource(t *testing.T) {
 				CommitSHA: "abc123",
 			},
 		}
-		_, _, err := materializeSkillSource(ctx, &mockFetcher{}, skill, "")
+		_, _, err := materializeSkillSource(ctx, &mockFetcher{}, skill, gitpkg.Auth{})
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "missing relativePath")
 	})
@@ -727,10 +814,10 @@ func TestMaterializeSkillSource(t *testing.T) {
 		require.NoError(t, os.MkdirAll(skillDir, 0o755))
 
 		var gotRef string
-		var gotToken string
+		var gotAuth gitpkg.Auth
 		fetcher := &mockFetcher{
-			fetchFn: func(_ context.Context, _, token, ref string) (*fetchedRepository, error) {
-				gotToken = token
+			fetchFn: func(_ context.Context, _ string, auth gitpkg.Auth, ref string) (*fetchedRepository, error) {
+				gotAuth = auth
 				gotRef = ref
 				return &fetchedRepository{
 					RepoRoot:  root,
@@ -750,14 +837,14 @@ func TestMaterializeSkillSource(t *testing.T) {
 			},
 		}
 
-		fetched, path, err := materializeSkillSource(ctx, fetcher, skill, "private-token")
+		fetched, path, err := materializeSkillSource(ctx, fetcher, skill, gitpkg.Auth{Token: "private-token"})
 		require.NoError(t, err)
 		defer fetched.Cleanup()
 		assert.DirExists(t, path)
 		absSkillDir, _ := filepath.Abs(skillDir)
 		assert.Equal(t, absSkillDir, path)
 		assert.Equal(t, "abc123", gotRef)
-		assert.Equal(t, "private-token", gotToken)
+		assert.Equal(t, gitpkg.Auth{Token: "private-token"}, gotAuth)
 	})
 
 	t.Run("relativePath is file not dir", func(t *testing.T) {
@@ -765,7 +852,7 @@ func TestMaterializeSkillSource(t *testing.T) {
 		require.NoError(t, os.WriteFile(filepath.Join(root, "not-a-dir"), []byte("file"), 0o644))
 
 		fetcher := &mockFetcher{
-			fetchFn: func(_ context.Context, _, _, _ string) (*fetchedRepository, error) {
+			fetchFn: func(context.Context, string, gitpkg.Auth, string) (*fetchedRepository, error) {
 				return &fetchedRepository{
 					RepoRoot:  root,
 					CommitSHA: "abc123",
@@ -783,7 +870,7 @@ func TestMaterializeSkillSource(t *testing.T) {
 			},
 		}
 
-		_, _, err := materializeSkillSource(ctx, fetcher, skill, "")
+		_, _, err := materializeSkillSource(ctx, fetcher, skill, gitpkg.Auth{})
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "not a directory")
 	})
@@ -792,7 +879,7 @@ func TestMaterializeSkillSource(t *testing.T) {
 		root := t.TempDir()
 
 		fetcher := &mockFetcher{
-			fetchFn: func(_ context.Context, _, _, _ string) (*fetchedRepository, error) {
+			fetchFn: func(context.Context, string, gitpkg.Auth, string) (*fetchedRepository, error) {
 				return &fetchedRepository{
 					RepoRoot:  root,
 					CommitSHA: "abc123",
@@ -810,7 +897,7 @@ func TestMaterializeSkillSource(t *testing.T) {
 			},
 		}
 
-		_, _, err := materializeSkillSource(ctx, fetcher, skill, "")
+		_, _, err := materializeSkillSource(ctx, fetcher, skill, gitpkg.Auth{})
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "escapes")
 	})
@@ -825,7 +912,7 @@ func TestMaterializeSkillSource(t *testing.T) {
 		}
 
 		fetcher := &mockFetcher{
-			fetchFn: func(_ context.Context, _, _, _ string) (*fetchedRepository, error) {
+			fetchFn: func(context.Context, string, gitpkg.Auth, string) (*fetchedRepository, error) {
 				return &fetchedRepository{
 					RepoRoot:  root,
 					CommitSHA: "abc123",
@@ -847,7 +934,7 @@ func TestMaterializeSkillSource(t *testing.T) {
 		// absolute but does not resolve symlinks. materializeSkillSource calls
 		// os.Lstat on the joined path, so the symlink itself is inspected and
 		// should be reported with ModeSymlink, causing this test to fail as expected.
-		_, _, err := materializeSkillSource(ctx, fetcher, skill, "")
+		_, _, err := materializeSkillSource(ctx, fetcher, skill, gitpkg.Auth{})
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "symbolic link")
 	})
@@ -1,18 +1,24 @@
 package controller
 
 import (
+	"net/http"
+
 	"github.com/obot-platform/nah/pkg/router"
 	"github.com/obot-platform/obot/pkg/controller/generationed"
 	"github.com/obot-platform/obot/pkg/controller/handlers/accesscontrolrule"
+	"github.com/obot-platform/obot/pkg/controller/handlers/accessrequest"
 	"github.com/obot-platform/obot/pkg/controller/handlers/adminworkspace"
 	"github.com/obot-platform/obot/pkg/controller/handlers/agentcatalog"
 	"github.com/obot-platform/obot/pkg/controller/handlers/alias"
 	"github.com/obot-platform/obot/pkg/controller/handlers/auditlogexport"
 	"github.com/obot-platform/obot/pkg/controller/handlers/cleanup"
+	"github.com/obot-platform/obot/pkg/controller/handlers/configrepository"
 	gitcredentialhandler "github.com/obot-platform/obot/pkg/controller/handlers/gitcredential"
 	"github.com/obot-platform/obot/pkg/controller/handlers/hostedagent"
 	hostedagentcreds "github.com/obot-platform/obot/pkg/controller/handlers/hostedagent/credentials"
 	"github.com/obot-platform/obot/pkg/controller/handlers/hostedagentpool"
+	hostedagentsnapshothandler "github.com/obot-platform/obot/pkg/controller/handlers/hostedagentsnapshot"
+	hostedagenttriggerhandler "github.com/obot-platform/obot/pkg/controller/handlers/hostedagenttrigger"
 	"github.com/obot-platform/obot/pkg/controller/handlers/imagepullsecret"
 	"github.com/obot-platform/obot/pkg/controller/handlers/mcpcatalog"
 	"github.com/obot-platform/obot/pkg/controller/handlers/mcpclientsession"
@@ -20,12 +26,14 @@ import (
 	"github.com/obot-platform/obot/pkg/controller/handlers/mcpserver"
 	"github.com/obot-platform/obot/pkg/controller/handlers/mcpservercatalogentry"
 	"github.com/obot-platform/obot/pkg/controller/handlers/mcpserverinstance"
+	"github.com/obot-platform/obot/pkg/controller/handlers/mcptoolcallapproval"
 	"github.com/obot-platform/obot/pkg/controller/handlers/mcpwebhookvalidation"
 	"github.com/obot-platform/obot/pkg/controller/handlers/mdmassetsource"
 	"github.com/obot-platform/obot/pkg/controller/handlers/model"
 	"github.com/obot-platform/obot/pkg/controller/handlers/modelaccesspolicy"
 	"github.com/obot-platform/obot/pkg/controller/handlers/modelinfosource"
 	"github.com/obot-platform/obot/pkg/controller/handlers/nanobotagent"
+	"github.com/obot-platform/obot/pkg/controller/handlers/notification"
 	"github.com/obot-platform/obot/pkg/controller/handlers/oauthclients"
 	"github.com/obot-platform/obot/pkg/controller/handlers/oktagroupmigration"
 	"github.com/obot-platform/obot/pkg/controller/handlers/poweruserworkspace"
@@ -34,6 +42,7 @@ import (
 	"github.com/obot-platform/obot/pkg/controller/handlers/scheduledauditlogexport"
 	"github.com/obot-platform/obot/pkg/controller/handlers/skillrepository"
 	"github.com/obot-platform/obot/pkg/controller/handlers/systemmcpserver"
+	"github.com/obot-platform/obot/pkg/hostedagenttrigger"
 	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
 	"github.com/obot-platform/obot/pkg/system"
 )
@@ -48,6 +57,10 @@ func (c *Controller) setupRoutes() {
 	modelInfoSource := modelinfosource.New(c.services.ModelInfoSourceURL, c.services.MCPSessionManager.RemoteMCPURLValidationConfig())
 	mdmAssetSource := mdmassetsource.New(c.services.MDMAssetSource, c.services.ServerURL, c.services.GatewayClient)
 	skillRepository := skillrepository.New(c.services.GatewayClient)
+	configRepository := configrepository.New(c.services.GatewayClient)
+	accessRequest := accessrequest.New()
+	mcpToolCallApproval := mcptoolcallapproval.New()
+	notificationHandler := notification.New(c.services.GatewayClient)
 	mcpserver := mcpserver.New(c.services.GatewayClient, c.services.MCPSessionManager, c.services.MCPOAuthTokenStorage, c.services.MCPNetworkPolicyEnabled, c.services.MCPDefaultDenyAllEgress, c.services.SingleUserIdleServerShutdownInterval, c.services.MultiUserIdleServerShutdownInterval, c.services.AgentIdleServerShutdownInterval, c.services.ServerURL, c.services.MCPRuntimeBackend, c.services.MCPImagePullSecrets)
 	mcpserverinstance := mcpserverinstance.New(c.services.GatewayClient)
 	accesscontrolrule := accesscontrolrule.New(c.services.AccessControlRuleHelper)
@@ -63,6 +76,8 @@ func (c *Controller) setupRoutes() {
 	agentCatalogHandler := agentcatalog.New()
 	hostedAgentHandler := hostedagent.New(c.services.AgentBackend, hostedagentcreds.New(c.services.GatewayClient), c.services.ServerURL, c.services.AgentServerURL)
 	hostedAgentPoolHandler := hostedagentpool.New(c.services.AgentBackend)
+	hostedAgentSnapshotHandler := hostedagentsnapshothandler.New(c.services.AgentBackend, c.services.ArtifactBlobStore, c.services.ArtifactBlobBucket)
+	hostedAgentTriggerHandler := hostedagenttriggerhandler.New(c.services.GatewayClient, hostedagenttrigger.NewDeliverer(c.services.StorageClient, c.services.GatewayClient, http.DefaultTransport, c.services.AgentDevRouter))
 	oktaGroupMigrationHandler := oktagroupmigration.New()
 	projectHandler := project.New(c.services.GatewayClient)
 	imagePullSecretHandler := imagepullsecret.New(c.services.GatewayClient, c.services.LocalK8sClient, c.services.MCPRuntimeBackend, c.services.MCPServerNamespace, c.services.ServiceNamespace, c.services.ServiceAccountName, c.services.MCPImagePullSecrets, c.services.ServiceAccountIssuerURL)
@@ -114,6 +129,21 @@ func (c *Controller) setupRoutes() {
 	// SkillRepository
 	root.Type(&v1.SkillRepository{}).HandlerFunc(skillRepository.Sync)
 
+	// ConfigRepository
+	root.Type(&v1.ConfigRepository{}).HandlerFunc(configRepository.Sync)
+	root.Type(&v1.ConfigRepository{}).FinalizeFunc(v1.ConfigRepositoryFinalizer, configRepository.Release)
+
+	// AccessRequest
+	root.Type(&v1.AccessRequest{}).HandlerFunc(accessRequest.Reconcile)
+	root.Type(&v1.AccessRequest{}).FinalizeFunc(v1.AccessRequestFinalizer, accessRequest.Cleanup)
+
+	// MCPToolCallApproval
+	root.Type(&v1.MCPToolCallApproval{}).HandlerFunc(mcpToolCallApproval.Reconcile)
+
+	// Notifications
+	root.Type(&v1.NotificationChannel{}).FinalizeFunc(v1.NotificationChannelFinalizer, notificationHandler.CleanupChannel)
+	root.Type(&v1.NotificationDelivery{}).HandlerFunc(notificationHandler.Deliver)
+
 	// Skill
 	root.Type(&v1.Skill{}).HandlerFunc(cleanup.Cleanup)
 
@@ -130,6 +160,17 @@ func (c *Controller) setupRoutes() {
 	root.Type(&v1.HostedAgentInstance{}).HandlerFunc(hostedAgentHandler.EnsurePool)
 	root.Type(&v1.HostedAgentInstance{}).FinalizeFunc(v1.HostedAgentInstanceFinalizer, hostedAgentHandler.RemoveInstance)
 	root.Type(&v1.HostedAgentInstance{}).HandlerFunc(hostedAgentHandler.OrchestrateInstance)
+	root.Type(&v1.HostedAgentInstance{}).HandlerFunc(hostedAgentSnapshotHandler.Restore)
+
+	// HostedAgentSnapshot
+	root.Type(&v1.HostedAgentSnapshot{}).FinalizeFunc(v1.HostedAgentSnapshotFinalizer, hostedAgentSnapshotHandler.Remove)
+	root.Type(&v1.HostedAgentSnapshot{}).HandlerFunc(hostedAgentSnapshotHandler.Expire)
+	root.Type(&v1.HostedAgentSnapshot{}).HandlerFunc(hostedAgentSnapshotHandler.Capture)
+
+	// HostedAgentTrigger
+	root.Type(&v1.HostedAgentTrigger{}).HandlerFunc(cleanup.Cleanup)
+	root.Type(&v1.HostedAgentTrigger{}).FinalizeFunc(v1.HostedAgentTriggerFinalizer, hostedAgentTriggerHandler.Remove)
+	root.Type(&v1.HostedAgentTrigger{}).HandlerFunc(hostedAgentTriggerHandler.Schedule)
 
 	// HostedAgentPool
 	root.Type(&v1.HostedAgentPool{}).FinalizeFunc(v1.HostedAgentPoolFinalizer, hostedAgentPoolHandler.Remove)
@@ -173,6 +214,8 @@ func (c *Controller) setupRoutes() {
 	root.Type(&v1.MCPServer{}).HandlerFunc(mcpserver.SyncOAuthCredentialStatus)
 	root.Type(&v1.MCPServer{}).HandlerFunc(mcpserver.SyncOAuthMetadata)
 	root.Type(&v1.MCPServer{}).HandlerFunc(mcpserver.SyncThirdPartyAuthStatus)
+	root.Type(&v1.MCPServer{}).HandlerFunc(mcpserver.CheckHealth)
+	root.Type(&v1.MCPServer{}).IncludeRemoved().HandlerFunc(mcpserver.ForgetHealth)
 	root.Type(&v1.MCPServer{}).HandlerFunc(mcpserver.EnsureMCPServerSecretInfo)
 	root.Type(&v1.MCPServer{}).HandlerFunc(mcpserver.EnsureCompositeComponents)
 	root.Type(&v1.MCPServer{}).HandlerFunc(mcpserver.ShutdownIdleServers)
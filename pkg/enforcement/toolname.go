@@ -0,0 +1,66 @@
+package enforcement
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	mcpToolNamePrefix    = "mcp__"
+	mcpToolNameSeparator = "__"
+)
+
+// MCPServerResolver resolves the server hint of an mcp__<server>__<tool> name
+// to the server it targets. ok is false when the hint names no server the
+// caller is known to have configured.
+type MCPServerResolver func(serverName string) (server ServerIdentity, obotHosted, ok bool)
+
+// SplitMCPToolName splits a runtime tool name that follows the
+// mcp__<server>__<tool> convention into its server hint and tool. The server
+// hint ends at the first separator, so a tool name may itself contain one.
+func SplitMCPToolName(name string) (serverName, tool string, ok bool) {
+	rest, ok := strings.CutPrefix(name, mcpToolNamePrefix)
+	if !ok {
+		return "", "", false
+	}
+	serverName, tool, ok = strings.Cut(rest, mcpToolNameSeparator)
+	if !ok || serverName == "" || tool == "" {
+		return "", "", false
+	}
+	return serverName, tool, true
+}
+
+// CallFromToolName builds the NormalizedCall for a tool name as a model emits
+// it, for callers that see the tool call rather than the agent's configuration.
+//
+// A name outside the MCP convention is a tool of the agent itself. Nothing more
+// specific than KindGeneric can be said about it from the name alone, so only
+// the built-in agent tools toggle can allow it. An MCP name whose server the
+// resolver does not know is unresolved, exactly as on a device that finds the
+// server in no config file, unless it names one of the agent's built-in
+// servers, which are matched by name and need no resolution.
+func CallFromToolName(agent, name string, resolve MCPServerResolver) NormalizedCall {
+	serverName, tool, ok := SplitMCPToolName(name)
+	if !ok {
+		return NormalizedCall{Agent: agent, Tool: name, Kind: KindGeneric}
+	}
+
+	call := NormalizedCall{
+		Agent:      agent,
+		Tool:       tool,
+		Kind:       KindMCP,
+		ServerName: serverName,
+	}
+	if resolve != nil {
+		if server, obotHosted, ok := resolve(serverName); ok {
+			call.Server = server
+			call.ObotHosted = obotHosted
+			return call
+		}
+	}
+	if !isBuiltinAgentMCP(agent, serverName) {
+		call.Unresolved = true
+		call.UnresolvedReason = fmt.Sprintf("MCP server %q is not one of the caller's configured MCP servers", serverName)
+	}
+	return call
+}
@@ -0,0 +1,68 @@
+package enforcement
+
+import (
+	"testing"
+
+	"github.com/obot-platform/obot/apiclient/types"
+)
+
+func TestSplitMCPToolName(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		in         string
+		wantServer string
+		wantTool   string
+		wantOK     bool
+	}{
+		{"mcp tool", "mcp__github__create_issue", "github", "create_issue", true},
+		{"server with single underscores", "mcp__my_server__search", "my_server", "search", true},
+		{"tool with separator", "mcp__docs__read__all", "docs", "read__all", true},
+		{"not an mcp tool", "Bash", "", "", false},
+		{"missing tool", "mcp__github__", "", "", false},
+		{"missing server", "mcp____search", "", "", false},
+		{"prefix only", "mcp__github", "", "", false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			server, tool, ok := SplitMCPToolName(tt.in)
+			if server != tt.wantServer || tool != tt.wantTool || ok != tt.wantOK {
+				t.Fatalf("SplitMCPToolName(%q) = %q, %q, %v, want %q, %q, %v", tt.in, server, tool, ok, tt.wantServer, tt.wantTool, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestCallFromToolName(t *testing.T) {
+	resolve := func(serverName string) (ServerIdentity, bool, bool) {
+		if serverName != "ms1docs" {
+			return ServerIdentity{}, false, false
+		}
+		return ServerIdentity{URL: "https://obot.example.com/mcp-connect/ms1docs"}, true, true
+	}
+
+	call := CallFromToolName(AgentClaudeCode, "mcp__ms1docs__search", resolve)
+	if call.Kind != KindMCP || call.Tool != "search" || call.ServerName != "ms1docs" || !call.ObotHosted || call.Unresolved {
+		t.Fatalf("unexpected call for a configured server: %#v", call)
+	}
+	if call.Server.URL != "https://obot.example.com/mcp-connect/ms1docs" {
+		t.Fatalf("server URL = %q", call.Server.URL)
+	}
+
+	call = CallFromToolName(AgentClaudeCode, "mcp__unknown__search", resolve)
+	if !call.Unresolved || call.UnresolvedReason == "" {
+		t.Fatalf("expected an unknown server to be unresolved, got %#v", call)
+	}
+
+	// Built-in agent servers are matched by name and never need resolving.
+	call = CallFromToolName(AgentClaudeCode, "mcp__claude-in-chrome__navigate", resolve)
+	if call.Unresolved {
+		t.Fatalf("expected a built-in server to be resolved, got %#v", call)
+	}
+	if !Evaluate(call, types.EnforcementAllowlist{AllowAllBuiltinAgentMCP: true}).Allow {
+		t.Fatal("expected the built-in server toggle to allow the call")
+	}
+
+	call = CallFromToolName(AgentClaudeCode, "Bash", resolve)
+	if call.Kind != KindGeneric || call.Tool != "Bash" || call.Unresolved {
+		t.Fatalf("unexpected call for an agent tool: %#v", call)
+	}
+}
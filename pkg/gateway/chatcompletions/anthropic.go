@@ -0,0 +1,378 @@
+package chatcompletions
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tidwall/gjson"
+)
+
+// defaultAnthropicMaxTokens is used when the client does not set a limit,
+// because Anthropic Messages requires one.
+const defaultAnthropicMaxTokens = 4096
+
+type anthropicRequest struct {
+	Model         string               `json:"model"`
+	MaxTokens     int                  `json:"max_tokens"`
+	System        string               `json:"system,omitempty"`
+	Messages      []anthropicMessage   `json:"messages"`
+	Stream        bool                 `json:"stream,omitempty"`
+	Temperature   *float64             `json:"temperature,omitempty"`
+	TopP          *float64             `json:"top_p,omitempty"`
+	StopSequences []string             `json:"stop_sequences,omitempty"`
+	Tools         []anthropicTool      `json:"tools,omitempty"`
+	ToolChoice    *anthropicToolChoice `json:"tool_choice,omitempty"`
+	Metadata      *anthropicMetadata   `json:"metadata,omitempty"`
+}
+
+type anthropicMessage struct {
+	Role    string             `json:"role"`
+	Content []anthropicContent `json:"content"`
+}
+
+type anthropicContent struct {
+	Type      string                `json:"type"`
+	Text      string                `json:"text,omitempty"`
+	Source    *anthropicImageSource `json:"source,omitempty"`
+	ID        string                `json:"id,omitempty"`
+	Name      string                `json:"name,omitempty"`
+	Input     json.RawMessage       `json:"input,omitempty"`
+	ToolUseID string                `json:"tool_use_id,omitempty"`
+	Content   string                `json:"content,omitempty"`
+}
+
+type anthropicImageSource struct {
+	Type      string `json:"type"`
+	MediaType string `json:"media_type,omitempty"`
+	Data      string `json:"data,omitempty"`
+	URL       string `json:"url,omitempty"`
+}
+
+type anthropicTool struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	InputSchema json.RawMessage `json:"input_schema"`
+}
+
+type anthropicToolChoice struct {
+	Type                   string `json:"type"`
+	Name                   string `json:"name,omitempty"`
+	DisableParallelToolUse bool   `json:"disable_parallel_tool_use,omitempty"`
+}
+
+type anthropicMetadata struct {
+	UserID string `json:"user_id,omitempty"`
+}
+
+// ToAnthropicMessages converts a Chat Completions request to an Anthropic
+// Messages request for model. System and developer messages become the system
+// prompt, tool results become tool_result blocks on user turns, and
+// consecutive messages with the same role are merged as Anthropic requires.
+func ToAnthropicMessages(req Request, model string) ([]byte, error) {
+	out := anthropicRequest{
+		Model:         model,
+		MaxTokens:     defaultAnthropicMaxTokens,
+		Stream:        req.Stream,
+		Temperature:   req.Temperature,
+		TopP:          req.TopP,
+		StopSequences: stopSequences(req.Stop),
+	}
+	if req.MaxCompletionTokens != nil {
+		out.MaxTokens = *req.MaxCompletionTokens
+	} else if req.MaxTokens != nil {
+		out.MaxTokens = *req.MaxTokens
+	}
+	if req.User != "" {
+		out.Metadata = &anthropicMetadata{UserID: req.User}
+	}
+
+	var system []string
+	for _, msg := range req.Messages {
+		var (
+			role    string
+			content []anthropicContent
+		)
+		switch msg.Role {
+		case "system", "developer":
+			if text := contentText(msg.Content); text != "" {
+				system = append(system, text)
+			}
+			continue
+		case "user":
+			role = "user"
+			for _, part := range contentParts(msg.Content) {
+				if block, ok := anthropicContentFromPart(part); ok {
+					content = append(content, block)
+				}
+			}
+		case "assistant":
+			role = "assistant"
+			if text := contentText(msg.Content); text != "" {
+				content = append(content, anthropicContent{Type: "text", Text: text})
+			}
+			for _, call := range msg.ToolCalls {
+				content = append(content, anthropicContent{
+					Type:  "tool_use",
+					ID:    call.ID,
+					Name:  call.Function.Name,
+					Input: jsonObject(call.Function.Arguments),
+				})
+			}
+		case "tool":
+			role = "user"
+			content = append(content, anthropicContent{
+				Type:      "tool_result",
+				ToolUseID: msg.ToolCallID,
+				Content:   contentText(msg.Content),
+			})
+		default:
+			return nil, fmt.Errorf("unsupported message role %q", msg.Role)
+		}
+
+		if len(content) == 0 {
+			continue
+		}
+		if last := len(out.Messages) - 1; last >= 0 && out.Messages[last].Role == role {
+			out.Messages[last].Content = append(out.Messages[last].Content, content...)
+		} else {
+			out.Messages = append(out.Messages, anthropicMessage{Role: role, Content: content})
+		}
+	}
+	out.System = strings.Join(system, "\n\n")
+
+	for _, tool := range req.Tools {
+		if tool.Type != "" && tool.Type != "function" {
+			continue
+		}
+		schema := tool.Function.Parameters
+		if len(schema) == 0 {
+			schema = json.RawMessage(`{"type":"object","properties":{}}`)
+		}
+		out.Tools = append(out.Tools, anthropicTool{
+			Name:        tool.Function.Name,
+			Description: tool.Function.Description,
+			InputSchema: schema,
+		})
+	}
+
+	mode, function := toolChoice(req.ToolChoice)
+	switch {
+	case function != "":
+		out.ToolChoice = &anthropicToolChoice{Type: "tool", Name: function}
+	case mode == "required":
+		out.ToolChoice = &anthropicToolChoice{Type: "any"}
+	case mode == "none":
+		out.ToolChoice = &anthropicToolChoice{Type: "none"}
+	case mode == "auto":
+		out.ToolChoice = &anthropicToolChoice{Type: "auto"}
+	}
+	if req.ParallelToolCalls != nil && !*req.ParallelToolCalls && len(out.Tools) > 0 {
+		if out.ToolChoice == nil {
+			out.ToolChoice = &anthropicToolChoice{Type: "auto"}
+		}
+		if out.ToolChoice.Type != "none" {
+			out.ToolChoice.DisableParallelToolUse = true
+		}
+	}
+
+	return json.Marshal(out)
+}
+
+func anthropicContentFromPart(part ContentPart) (anthropicContent, bool) {
+	switch part.Type {
+	case "text":
+		if part.Text == "" {
+			return anthropicContent{}, false
+		}
+		return anthropicContent{Type: "text", Text: part.Text}, true
+	case "image_url":
+		if part.ImageURL == nil || part.ImageURL.URL == "" {
+			return anthropicContent{}, false
+		}
+		if mediaType, data, ok := parseDataURL(part.ImageURL.URL); ok {
+			return anthropicContent{Type: "image", Source: &anthropicImageSource{Type: "base64", MediaType: mediaType, Data: data}}, true
+		}
+		return anthropicContent{Type: "image", Source: &anthropicImageSource{Type: "url", URL: part.ImageURL.URL}}, true
+	default:
+		return anthropicContent{}, false
+	}
+}
+
+// parseDataURL splits a base64 data URL into its media type and payload.
+func parseDataURL(u string) (mediaType, data string, ok bool) {
+	rest, ok := strings.CutPrefix(u, "data:")
+	if !ok {
+		return "", "", false
+	}
+	meta, data, ok := strings.Cut(rest, ",")
+	if !ok {
+		return "", "", false
+	}
+	mediaType, ok = strings.CutSuffix(meta, ";base64")
+	return mediaType, data, ok
+}
+
+// jsonObject returns arguments as a JSON object, falling back to an empty
+// object when they are missing or not valid JSON.
+func jsonObject(arguments string) json.RawMessage {
+	if trimmed := strings.TrimSpace(arguments); trimmed != "" && gjson.Valid(trimmed) && gjson.Parse(trimmed).IsObject() {
+		return json.RawMessage(trimmed)
+	}
+	return json.RawMessage("{}")
+}
+
+// FromAnthropicMessages converts a non-streamed Anthropic Messages response to
+// a Chat Completions response.
+func FromAnthropicMessages(body []byte) ([]byte, error) {
+	if !gjson.ValidBytes(body) {
+		return nil, fmt.Errorf("invalid Anthropic Messages response")
+	}
+	result := gjson.ParseBytes(body)
+
+	var (
+		texts   []string
+		message = ResponseMessage{Role: "assistant"}
+	)
+	result.Get("content").ForEach(func(_, block gjson.Result) bool {
+		switch block.Get("type").String() {
+		case "text":
+			texts = append(texts, block.Get("text").String())
+		case "tool_use":
+			input := block.Get("input").Raw
+			if input == "" {
+				input = "{}"
+			}
+			message.ToolCalls = append(message.ToolCalls, ToolCall{
+				ID:       block.Get("id").String(),
+				Type:     "function",
+				Function: FunctionCall{Name: block.Get("name").String(), Arguments: input},
+			})
+		}
+		return true
+	})
+	if len(texts) > 0 || len(message.ToolCalls) == 0 {
+		message.Content = ptr(strings.Join(texts, ""))
+	}
+
+	return json.Marshal(Completion{
+		ID:              result.Get("id").String(),
+		Object:          objectCompletion,
+		Created:         time.Now().Unix(),
+		Model:           result.Get("model").String(),
+		Choices:         []Choice{{Message: message, FinishReason: anthropicFinishReason(result.Get("stop_reason").String())}},
+		Usage:           anthropicUsage(result.Get("usage"), nil),
+		PolicyViolation: result.Get("obot_tool_call_policy_violation").String(),
+	})
+}
+
+func anthropicFinishReason(stopReason string) string {
+	switch stopReason {
+	case "max_tokens", "model_context_window_exceeded":
+		return finishLength
+	case "tool_use":
+		return finishToolCalls
+	case "refusal":
+		return finishContentFilter
+	default:
+		return finishStop
+	}
+}
+
+// anthropicUsage converts Anthropic usage, merged over previous when the
+// usage is reported in parts across stream events. Anthropic reports uncached
+// input separately from cache reads and writes, while prompt_tokens includes
+// all of them.
+func anthropicUsage(u gjson.Result, previous *Usage) *Usage {
+	if !u.Exists() {
+		return previous
+	}
+
+	usage := Usage{}
+	if previous != nil {
+		usage = *previous
+	}
+	if input := u.Get("input_tokens"); input.Exists() {
+		cacheRead := int(u.Get("cache_read_input_tokens").Int())
+		usage.PromptTokens = max(usage.PromptTokens, int(input.Int())+cacheRead+int(u.Get("cache_creation_input_tokens").Int()))
+		if cacheRead > 0 {
+			usage.PromptTokensDetails = &PromptTokensDetails{CachedTokens: cacheRead}
+		}
+	}
+	usage.CompletionTokens = max(usage.CompletionTokens, int(u.Get("output_tokens").Int()))
+	usage.TotalTokens = usage.PromptTokens + usage.CompletionTokens
+	return &usage
+}
+
+// AnthropicStream translates the events of a streamed Anthropic Messages
+// response to Chat Completions chunks.
+type AnthropicStream struct {
+	stream
+	blockToTool map[int]int
+}
+
+func NewAnthropicStream(includeUsage bool) *AnthropicStream {
+	return &AnthropicStream{
+		stream:      newStream(includeUsage),
+		blockToTool: map[int]int{},
+	}
+}
+
+func (s *AnthropicStream) Translate(data []byte) []byte {
+	if !gjson.ValidBytes(data) {
+		return nil
+	}
+	event := gjson.ParseBytes(data)
+	if violation := event.Get("obot_tool_call_policy_violation"); violation.Exists() {
+		return s.policyViolation(violation.String())
+	}
+
+	switch event.Get("type").String() {
+	case "message_start":
+		s.id = event.Get("message.id").String()
+		s.model = event.Get("message.model").String()
+		s.usage = anthropicUsage(event.Get("message.usage"), s.usage)
+		return s.start()
+	case "content_block_start":
+		block := event.Get("content_block")
+		switch block.Get("type").String() {
+		case "text":
+			if text := block.Get("text").String(); text != "" {
+				return s.delta(Delta{Content: ptr(text)})
+			}
+		case "tool_use":
+			index := len(s.blockToTool)
+			s.blockToTool[int(event.Get("index").Int())] = index
+			return s.delta(Delta{ToolCalls: []ToolCall{{
+				Index:    ptr(index),
+				ID:       block.Get("id").String(),
+				Type:     "function",
+				Function: FunctionCall{Name: block.Get("name").String()},
+			}}})
+		}
+	case "content_block_delta":
+		delta := event.Get("delta")
+		switch delta.Get("type").String() {
+		case "text_delta":
+			return s.delta(Delta{Content: ptr(delta.Get("text").String())})
+		case "input_json_delta":
+			index, ok := s.blockToTool[int(event.Get("index").Int())]
+			if !ok {
+				return nil
+			}
+			return s.delta(Delta{ToolCalls: []ToolCall{{
+				Index:    ptr(index),
+				Function: FunctionCall{Arguments: delta.Get("partial_json").String()},
+			}}})
+		}
+	case "message_delta":
+		s.usage = anthropicUsage(event.Get("usage"), s.usage)
+		if stopReason := event.Get("delta.stop_reason").String(); stopReason != "" {
+			return s.finish(anthropicFinishReason(stopReason))
+		}
+	case "error":
+		return s.errorEvent(event.Get("error.message").String(), event.Get("error.type").String())
+	}
+	return nil
+}
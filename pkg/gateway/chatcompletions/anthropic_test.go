@@ -0,0 +1,232 @@
+package chatcompletions
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/tidwall/gjson"
+)
+
+func TestToAnthropicMessages(t *testing.T) {
+	var req Request
+	if err := json.Unmarshal([]byte(`{
+		"model": "anthropic/claude-sonnet-4-5",
+		"stream": true,
+		"max_completion_tokens": 1024,
+		"stop": "END",
+		"parallel_tool_calls": false,
+		"user": "user-1",
+		"messages": [
+			{"role": "system", "content": "Be brief."},
+			{"role": "developer", "content": [{"type": "text", "text": "Use tools."}]},
+			{"role": "user", "content": [
+				{"type": "text", "text": "What is in this image?"},
+				{"type": "image_url", "image_url": {"url": "data:image/png;base64,aGVsbG8="}}
+			]},
+			{"role": "assistant", "content": null, "tool_calls": [
+				{"id": "call_1", "type": "function", "function": {"name": "lookup", "arguments": "{\"q\":\"cat\"}"}},
+				{"id": "call_2", "type": "function", "function": {"name": "lookup", "arguments": "not json"}}
+			]},
+			{"role": "tool", "tool_call_id": "call_1", "content": "a cat"},
+			{"role": "tool", "tool_call_id": "call_2", "content": "nothing"},
+			{"role": "user", "content": "Thanks"}
+		],
+		"tools": [{"type": "function", "function": {"name": "lookup", "description": "Look things up"}}],
+		"tool_choice": {"type": "function", "function": {"name": "lookup"}}
+	}`), &req); err != nil {
+		t.Fatal(err)
+	}
+
+	body, err := ToAnthropicMessages(req, "claude-model")
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := gjson.ParseBytes(body)
+
+	for path, want := range map[string]string{
+		"model":                                  "claude-model",
+		"stream":                                 "true",
+		"max_tokens":                             "1024",
+		"system":                                 "Be brief.\n\nUse tools.",
+		"stop_sequences.0":                       "END",
+		"metadata.user_id":                       "user-1",
+		"messages.#":                             "3",
+		"messages.0.role":                        "user",
+		"messages.0.content.1.type":              "image",
+		"messages.0.content.1.source.type":       "base64",
+		"messages.0.content.1.source.media_type": "image/png",
+		"messages.0.content.1.source.data":       "aGVsbG8=",
+		"messages.1.role":                        "assistant",
+		"messages.1.content.0.type":              "tool_use",
+		"messages.1.content.0.input.q":           "cat",
+		"messages.1.content.1.input":             "{}",
+		"messages.2.role":                        "user",
+		"messages.2.content.#":                   "3",
+		"messages.2.content.0.type":              "tool_result",
+		"messages.2.content.0.tool_use_id":       "call_1",
+		"messages.2.content.0.content":           "a cat",
+		"messages.2.content.2.text":              "Thanks",
+		"tools.0.input_schema.type":              "object",
+		"tool_choice.type":                       "tool",
+		"tool_choice.name":                       "lookup",
+		"tool_choice.disable_parallel_tool_use":  "true",
+	} {
+		if got := result.Get(path).String(); got != want {
+			t.Errorf("%s = %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestToAnthropicMessagesRejectsUnknownRole(t *testing.T) {
+	_, err := ToAnthropicMessages(Request{Messages: []Message{{Role: "function"}}}, "model")
+	if err == nil {
+		t.Fatal("expected an error for an unsupported role")
+	}
+}
+
+func TestFromAnthropicMessages(t *testing.T) {
+	body, err := FromAnthropicMessages([]byte(`{
+		"id": "msg_1",
+		"model": "claude-model",
+		"content": [
+			{"type": "text", "text": "Looking it up."},
+			{"type": "tool_use", "id": "toolu_1", "name": "lookup", "input": {"q":"cat"}}
+		],
+		"stop_reason": "tool_use",
+		"usage": {"input_tokens": 10, "cache_read_input_tokens": 5, "output_tokens": 7}
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var completion Completion
+	if err := json.Unmarshal(body, &completion); err != nil {
+		t.Fatal(err)
+	}
+	if completion.Object != objectCompletion || completion.ID != "msg_1" {
+		t.Fatalf("unexpected completion: %s", body)
+	}
+	choice := completion.Choices[0]
+	if choice.FinishReason != finishToolCalls {
+		t.Errorf("finish reason = %q, want %q", choice.FinishReason, finishToolCalls)
+	}
+	if choice.Message.Content == nil || *choice.Message.Content != "Looking it up." {
+		t.Errorf("unexpected content: %v", choice.Message.Content)
+	}
+	if len(choice.Message.ToolCalls) != 1 || choice.Message.ToolCalls[0].Function.Arguments != `{"q":"cat"}` {
+		t.Errorf("unexpected tool calls: %+v", choice.Message.ToolCalls)
+	}
+	if u := completion.Usage; u == nil || u.PromptTokens != 15 || u.CompletionTokens != 7 || u.TotalTokens != 22 || u.PromptTokensDetails.CachedTokens != 5 {
+		t.Errorf("unexpected usage: %+v", completion.Usage)
+	}
+}
+
+func TestAnthropicStream(t *testing.T) {
+	s := NewAnthropicStream(true)
+
+	var out []byte
+	for _, event := range []string{
+		`{"type":"message_start","message":{"id":"msg_1","model":"claude-model","usage":{"input_tokens":12,"output_tokens":1}}}`,
+		`{"type":"content_block_start","index":0,"content_block":{"type":"text","text":""}}`,
+		`{"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"Hi"}}`,
+		`{"type":"content_block_start","index":1,"content_block":{"type":"tool_use","id":"toolu_1","name":"lookup","input":{}}}`,
+		`{"type":"content_block_delta","index":1,"delta":{"type":"input_json_delta","partial_json":"{\"q\":"}}`,
+		`{"type":"content_block_delta","index":1,"delta":{"type":"input_json_delta","partial_json":"\"cat\"}"}}`,
+		`{"type":"message_delta","delta":{"stop_reason":"tool_use"},"usage":{"output_tokens":9}}`,
+		`{"type":"message_stop"}`,
+	} {
+		out = append(out, s.Translate([]byte(event))...)
+	}
+	out = append(out, s.Finish()...)
+
+	chunks, done := parseEvents(t, out)
+	if !done {
+		t.Fatal("stream did not end with [DONE]")
+	}
+
+	var (
+		text, arguments string
+		finishReason    string
+		usage           *Usage
+	)
+	for _, chunk := range chunks {
+		if chunk.ID != "msg_1" || chunk.Model != "claude-model" {
+			t.Errorf("unexpected chunk identity: %+v", chunk)
+		}
+		if chunk.Usage != nil {
+			usage = chunk.Usage
+		}
+		for _, choice := range chunk.Choices {
+			if choice.Delta.Content != nil {
+				text += *choice.Delta.Content
+			}
+			for _, call := range choice.Delta.ToolCalls {
+				if call.Index == nil || *call.Index != 0 {
+					t.Errorf("unexpected tool call index: %v", call.Index)
+				}
+				arguments += call.Function.Arguments
+			}
+			if choice.FinishReason != nil {
+				finishReason = *choice.FinishReason
+			}
+		}
+	}
+	if text != "Hi" {
+		t.Errorf("text = %q, want %q", text, "Hi")
+	}
+	if arguments != `{"q":"cat"}` {
+		t.Errorf("arguments = %q", arguments)
+	}
+	if finishReason != finishToolCalls {
+		t.Errorf("finish reason = %q, want %q", finishReason, finishToolCalls)
+	}
+	if usage == nil || usage.PromptTokens != 12 || usage.CompletionTokens != 9 {
+		t.Errorf("unexpected usage: %+v", usage)
+	}
+	if more := s.Finish(); more != nil {
+		t.Errorf("Finish returned %q when called again", more)
+	}
+}
+
+func TestAnthropicStreamPolicyViolation(t *testing.T) {
+	s := NewAnthropicStream(false)
+	out := s.Translate([]byte(`{"obot_tool_call_policy_violation":"blocked"}`))
+	if got := gjson.GetBytes(trimData(out), "obot_tool_call_policy_violation").String(); got != "blocked" {
+		t.Fatalf("policy violation marker was not passed through: %s", out)
+	}
+}
+
+// parseEvents decodes the chunks of an encoded event stream, reporting whether
+// it was terminated.
+func parseEvents(t *testing.T, out []byte) ([]Chunk, bool) {
+	t.Helper()
+
+	var (
+		chunks []Chunk
+		done   bool
+	)
+	for _, event := range strings.Split(strings.TrimSpace(string(out)), "\n\n") {
+		data, ok := strings.CutPrefix(event, "data: ")
+		if !ok {
+			t.Fatalf("malformed event %q", event)
+		}
+		if data == "[DONE]" {
+			done = true
+			continue
+		}
+		var chunk Chunk
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			t.Fatalf("invalid chunk %q: %v", data, err)
+		}
+		if chunk.Object != objectChunk {
+			t.Errorf("object = %q, want %q", chunk.Object, objectChunk)
+		}
+		chunks = append(chunks, chunk)
+	}
+	return chunks, done
+}
+
+func trimData(event []byte) []byte {
+	return []byte(strings.TrimSpace(strings.TrimPrefix(string(event), "data: ")))
+}
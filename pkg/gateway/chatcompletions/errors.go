@@ -0,0 +1,54 @@
+package chatcompletions
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/tidwall/gjson"
+)
+
+// ErrorBody returns an OpenAI error envelope for statusCode. The message is
+// taken from the error envelopes of Anthropic and OpenAI when body is one, and
+// from the raw body otherwise.
+func ErrorBody(statusCode int, body []byte) []byte {
+	detail := ErrorDetail{Type: errorType(statusCode)}
+	if gjson.ValidBytes(body) {
+		result := gjson.ParseBytes(body)
+		if message := result.Get("error.message"); message.Exists() {
+			detail.Message = message.String()
+			if errType := result.Get("error.type").String(); errType != "" {
+				detail.Type = errType
+			}
+			if code := result.Get("error.code"); code.Type == gjson.String {
+				detail.Code = ptr(code.String())
+			}
+		} else if message := result.Get("message"); message.Exists() {
+			detail.Message = message.String()
+		}
+	}
+	if detail.Message == "" {
+		detail.Message = strings.TrimSpace(string(body))
+	}
+	if detail.Message == "" {
+		detail.Message = http.StatusText(statusCode)
+	}
+
+	data, _ := json.Marshal(ErrorResponse{Error: detail})
+	return data
+}
+
+func errorType(statusCode int) string {
+	switch statusCode {
+	case http.StatusBadRequest, http.StatusNotFound, http.StatusUnprocessableEntity:
+		return "invalid_request_error"
+	case http.StatusUnauthorized:
+		return "authentication_error"
+	case http.StatusForbidden:
+		return "permission_error"
+	case http.StatusTooManyRequests:
+		return "rate_limit_error"
+	default:
+		return "api_error"
+	}
+}
@@ -0,0 +1,335 @@
+package chatcompletions
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tidwall/gjson"
+)
+
+type responsesRequest struct {
+	Model             string               `json:"model"`
+	Instructions      string               `json:"instructions,omitempty"`
+	Input             []responsesInputItem `json:"input"`
+	Stream            bool                 `json:"stream,omitempty"`
+	Store             bool                 `json:"store"`
+	MaxOutputTokens   *int                 `json:"max_output_tokens,omitempty"`
+	Temperature       *float64             `json:"temperature,omitempty"`
+	TopP              *float64             `json:"top_p,omitempty"`
+	Tools             []responsesTool      `json:"tools,omitempty"`
+	ToolChoice        any                  `json:"tool_choice,omitempty"`
+	ParallelToolCalls *bool                `json:"parallel_tool_calls,omitempty"`
+	Reasoning         *responsesReasoning  `json:"reasoning,omitempty"`
+	Text              *responsesText       `json:"text,omitempty"`
+}
+
+type responsesInputItem struct {
+	Type      string             `json:"type"`
+	Role      string             `json:"role,omitempty"`
+	Content   []responsesContent `json:"content,omitempty"`
+	CallID    string             `json:"call_id,omitempty"`
+	Name      string             `json:"name,omitempty"`
+	Arguments string             `json:"arguments,omitempty"`
+	Output    *string            `json:"output,omitempty"`
+}
+
+type responsesContent struct {
+	Type     string `json:"type"`
+	Text     string `json:"text,omitempty"`
+	ImageURL string `json:"image_url,omitempty"`
+	Detail   string `json:"detail,omitempty"`
+}
+
+type responsesTool struct {
+	Type        string          `json:"type"`
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	Parameters  json.RawMessage `json:"parameters,omitempty"`
+	Strict      *bool           `json:"strict,omitempty"`
+}
+
+type responsesNamedToolChoice struct {
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+type responsesReasoning struct {
+	Effort string `json:"effort"`
+}
+
+type responsesText struct {
+	Format responsesTextFormat `json:"format"`
+}
+
+type responsesTextFormat struct {
+	Type        string          `json:"type"`
+	Name        string          `json:"name,omitempty"`
+	Description string          `json:"description,omitempty"`
+	Schema      json.RawMessage `json:"schema,omitempty"`
+	Strict      *bool           `json:"strict,omitempty"`
+}
+
+// ToResponses converts a Chat Completions request to a Responses API request
+// for model. System and developer messages become the instructions, and tool
+// calls and results become function_call and function_call_output items. The
+// response is not stored upstream, since the client resends the conversation.
+func ToResponses(req Request, model string) ([]byte, error) {
+	out := responsesRequest{
+		Model:             model,
+		Stream:            req.Stream,
+		Temperature:       req.Temperature,
+		TopP:              req.TopP,
+		ParallelToolCalls: req.ParallelToolCalls,
+		Input:             []responsesInputItem{},
+	}
+	if req.MaxCompletionTokens != nil {
+		out.MaxOutputTokens = req.MaxCompletionTokens
+	} else if req.MaxTokens != nil {
+		out.MaxOutputTokens = req.MaxTokens
+	}
+	if req.ReasoningEffort != "" {
+		out.Reasoning = &responsesReasoning{Effort: req.ReasoningEffort}
+	}
+
+	var instructions []string
+	for _, msg := range req.Messages {
+		switch msg.Role {
+		case "system", "developer":
+			if text := contentText(msg.Content); text != "" {
+				instructions = append(instructions, text)
+			}
+		case "user":
+			var content []responsesContent
+			for _, part := range contentParts(msg.Content) {
+				switch {
+				case part.Type == "text" && part.Text != "":
+					content = append(content, responsesContent{Type: "input_text", Text: part.Text})
+				case part.Type == "image_url" && part.ImageURL != nil && part.ImageURL.URL != "":
+					content = append(content, responsesContent{Type: "input_image", ImageURL: part.ImageURL.URL, Detail: cmp.Or(part.ImageURL.Detail, "auto")})
+				}
+			}
+			if len(content) > 0 {
+				out.Input = append(out.Input, responsesInputItem{Type: "message", Role: "user", Content: content})
+			}
+		case "assistant":
+			if text := contentText(msg.Content); text != "" {
+				out.Input = append(out.Input, responsesInputItem{
+					Type:    "message",
+					Role:    "assistant",
+					Content: []responsesContent{{Type: "output_text", Text: text}},
+				})
+			}
+			for _, call := range msg.ToolCalls {
+				out.Input = append(out.Input, responsesInputItem{
+					Type:      "function_call",
+					CallID:    call.ID,
+					Name:      call.Function.Name,
+					Arguments: string(jsonObject(call.Function.Arguments)),
+				})
+			}
+		case "tool":
+			out.Input = append(out.Input, responsesInputItem{
+				Type:   "function_call_output",
+				CallID: msg.ToolCallID,
+				Output: ptr(contentText(msg.Content)),
+			})
+		default:
+			return nil, fmt.Errorf("unsupported message role %q", msg.Role)
+		}
+	}
+	out.Instructions = strings.Join(instructions, "\n\n")
+
+	for _, tool := range req.Tools {
+		if tool.Type != "" && tool.Type != "function" {
+			continue
+		}
+		out.Tools = append(out.Tools, responsesTool{
+			Type:        "function",
+			Name:        tool.Function.Name,
+			Description: tool.Function.Description,
+			Parameters:  tool.Function.Parameters,
+			Strict:      tool.Function.Strict,
+		})
+	}
+
+	if mode, function := toolChoice(req.ToolChoice); function != "" {
+		out.ToolChoice = responsesNamedToolChoice{Type: "function", Name: function}
+	} else if mode != "" {
+		out.ToolChoice = mode
+	}
+
+	if format := req.ResponseFormat; format != nil {
+		switch format.Type {
+		case "json_schema":
+			if format.JSONSchema != nil {
+				out.Text = &responsesText{Format: responsesTextFormat{
+					Type:        "json_schema",
+					Name:        format.JSONSchema.Name,
+					Description: format.JSONSchema.Description,
+					Schema:      format.JSONSchema.Schema,
+					Strict:      format.JSONSchema.Strict,
+				}}
+			}
+		case "json_object":
+			out.Text = &responsesText{Format: responsesTextFormat{Type: "json_object"}}
+		}
+	}
+
+	return json.Marshal(out)
+}
+
+// FromResponses converts a non-streamed Responses API response to a Chat
+// Completions response.
+func FromResponses(body []byte) ([]byte, error) {
+	if !gjson.ValidBytes(body) {
+		return nil, fmt.Errorf("invalid Responses API response")
+	}
+	result := gjson.ParseBytes(body)
+
+	var (
+		texts   []string
+		message = ResponseMessage{Role: "assistant"}
+	)
+	result.Get("output").ForEach(func(_, item gjson.Result) bool {
+		switch item.Get("type").String() {
+		case "message":
+			item.Get("content").ForEach(func(_, part gjson.Result) bool {
+				if part.Get("type").String() == "output_text" {
+					texts = append(texts, part.Get("text").String())
+				}
+				return true
+			})
+		case "function_call":
+			message.ToolCalls = append(message.ToolCalls, ToolCall{
+				ID:       item.Get("call_id").String(),
+				Type:     "function",
+				Function: FunctionCall{Name: item.Get("name").String(), Arguments: item.Get("arguments").String()},
+			})
+		}
+		return true
+	})
+	if len(texts) > 0 || len(message.ToolCalls) == 0 {
+		message.Content = ptr(strings.Join(texts, ""))
+	}
+
+	created := result.Get("created_at").Int()
+	if created == 0 {
+		created = time.Now().Unix()
+	}
+
+	return json.Marshal(Completion{
+		ID:              result.Get("id").String(),
+		Object:          objectCompletion,
+		Created:         created,
+		Model:           result.Get("model").String(),
+		Choices:         []Choice{{Message: message, FinishReason: responsesFinishReason(result, len(message.ToolCalls) > 0)}},
+		Usage:           responsesUsage(result.Get("usage")),
+		PolicyViolation: result.Get("obot_tool_call_policy_violation").String(),
+	})
+}
+
+func responsesFinishReason(response gjson.Result, toolCalls bool) string {
+	if response.Get("status").String() == "incomplete" {
+		switch response.Get("incomplete_details.reason").String() {
+		case "max_output_tokens":
+			return finishLength
+		case "content_filter":
+			return finishContentFilter
+		}
+	}
+	if toolCalls {
+		return finishToolCalls
+	}
+	return finishStop
+}
+
+// responsesUsage converts Responses API usage, whose input_tokens already
+// include cached tokens.
+func responsesUsage(u gjson.Result) *Usage {
+	if !u.Exists() {
+		return nil
+	}
+
+	usage := &Usage{
+		PromptTokens:     int(u.Get("input_tokens").Int()),
+		CompletionTokens: int(u.Get("output_tokens").Int()),
+	}
+	usage.TotalTokens = usage.PromptTokens + usage.CompletionTokens
+	if cached := u.Get("input_tokens_details.cached_tokens"); cached.Exists() {
+		usage.PromptTokensDetails = &PromptTokensDetails{CachedTokens: int(cached.Int())}
+	}
+	if reasoning := u.Get("output_tokens_details.reasoning_tokens"); reasoning.Exists() {
+		usage.CompletionTokensDetails = &CompletionTokensDetails{ReasoningTokens: int(reasoning.Int())}
+	}
+	return usage
+}
+
+// ResponsesStream translates the events of a streamed Responses API response
+// to Chat Completions chunks.
+type ResponsesStream struct {
+	stream
+	itemToTool map[int]int
+}
+
+func NewResponsesStream(includeUsage bool) *ResponsesStream {
+	return &ResponsesStream{
+		stream:     newStream(includeUsage),
+		itemToTool: map[int]int{},
+	}
+}
+
+func (s *ResponsesStream) Translate(data []byte) []byte {
+	if !gjson.ValidBytes(data) {
+		return nil
+	}
+	event := gjson.ParseBytes(data)
+	if violation := event.Get("obot_tool_call_policy_violation"); violation.Exists() {
+		return s.policyViolation(violation.String())
+	}
+
+	switch event.Get("type").String() {
+	case "response.created":
+		s.id = event.Get("response.id").String()
+		s.model = event.Get("response.model").String()
+		if created := event.Get("response.created_at").Int(); created != 0 {
+			s.created = created
+		}
+		return s.start()
+	case "response.output_text.delta":
+		return s.delta(Delta{Content: ptr(event.Get("delta").String())})
+	case "response.output_item.added":
+		item := event.Get("item")
+		if item.Get("type").String() != "function_call" {
+			return nil
+		}
+		index := len(s.itemToTool)
+		s.itemToTool[int(event.Get("output_index").Int())] = index
+		return s.delta(Delta{ToolCalls: []ToolCall{{
+			Index:    ptr(index),
+			ID:       item.Get("call_id").String(),
+			Type:     "function",
+			Function: FunctionCall{Name: item.Get("name").String(), Arguments: item.Get("arguments").String()},
+		}}})
+	case "response.function_call_arguments.delta":
+		index, ok := s.itemToTool[int(event.Get("output_index").Int())]
+		if !ok {
+			return nil
+		}
+		return s.delta(Delta{ToolCalls: []ToolCall{{
+			Index:    ptr(index),
+			Function: FunctionCall{Arguments: event.Get("delta").String()},
+		}}})
+	case "response.completed", "response.incomplete":
+		response := event.Get("response")
+		s.usage = responsesUsage(response.Get("usage"))
+		return s.finish(responsesFinishReason(response, len(s.itemToTool) > 0))
+	case "response.failed":
+		return s.errorEvent(event.Get("response.error.message").String(), event.Get("response.error.code").String())
+	case "error":
+		return s.errorEvent(event.Get("message").String(), event.Get("code").String())
+	}
+	return nil
+}
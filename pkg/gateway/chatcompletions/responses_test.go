@@ -0,0 +1,223 @@
+package chatcompletions
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/tidwall/gjson"
+)
+
+func TestToResponses(t *testing.T) {
+	var req Request
+	if err := json.Unmarshal([]byte(`{
+		"model": "openai/gpt-5",
+		"max_tokens": 256,
+		"reasoning_effort": "low",
+		"messages": [
+			{"role": "system", "content": "Be brief."},
+			{"role": "user", "content": [
+				{"type": "text", "text": "Describe"},
+				{"type": "image_url", "image_url": {"url": "https://example.com/cat.png"}}
+			]},
+			{"role": "assistant", "content": "Checking.", "tool_calls": [
+				{"id": "call_1", "type": "function", "function": {"name": "lookup", "arguments": "{\"q\":\"cat\"}"}}
+			]},
+			{"role": "tool", "tool_call_id": "call_1", "content": ""}
+		],
+		"tools": [{"type": "function", "function": {"name": "lookup", "parameters": {"type": "object"}}}],
+		"tool_choice": "required",
+		"response_format": {"type": "json_schema", "json_schema": {"name": "answer", "schema": {"type": "object"}}}
+	}`), &req); err != nil {
+		t.Fatal(err)
+	}
+
+	body, err := ToResponses(req, "gpt-model")
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := gjson.ParseBytes(body)
+
+	for path, want := range map[string]string{
+		"model":                    "gpt-model",
+		"store":                    "false",
+		"instructions":             "Be brief.",
+		"max_output_tokens":        "256",
+		"reasoning.effort":         "low",
+		"input.#":                  "4",
+		"input.0.content.0.type":   "input_text",
+		"input.0.content.1.type":   "input_image",
+		"input.0.content.1.detail": "auto",
+		"input.1.role":             "assistant",
+		"input.1.content.0.type":   "output_text",
+		"input.2.type":             "function_call",
+		"input.2.call_id":          "call_1",
+		"input.2.arguments":        `{"q":"cat"}`,
+		"input.3.type":             "function_call_output",
+		"input.3.output":           "",
+		"tools.0.name":             "lookup",
+		"tools.0.parameters.type":  "object",
+		"tool_choice":              "required",
+		"text.format.type":         "json_schema",
+		"text.format.name":         "answer",
+		"text.format.schema.type":  "object",
+	} {
+		if got := result.Get(path).String(); got != want {
+			t.Errorf("%s = %q, want %q", path, got, want)
+		}
+	}
+	if !result.Get("input.3.output").Exists() {
+		t.Error("empty tool output was omitted")
+	}
+}
+
+func TestFromResponses(t *testing.T) {
+	body, err := FromResponses([]byte(`{
+		"id": "resp_1",
+		"model": "gpt-model",
+		"created_at": 1700000000,
+		"status": "incomplete",
+		"incomplete_details": {"reason": "max_output_tokens"},
+		"output": [
+			{"type": "reasoning", "summary": []},
+			{"type": "message", "content": [{"type": "output_text", "text": "Partial"}]}
+		],
+		"usage": {"input_tokens": 20, "input_tokens_details": {"cached_tokens": 4}, "output_tokens": 8, "output_tokens_details": {"reasoning_tokens": 3}}
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var completion Completion
+	if err := json.Unmarshal(body, &completion); err != nil {
+		t.Fatal(err)
+	}
+	if completion.Created != 1700000000 {
+		t.Errorf("created = %d", completion.Created)
+	}
+	choice := completion.Choices[0]
+	if choice.FinishReason != finishLength {
+		t.Errorf("finish reason = %q, want %q", choice.FinishReason, finishLength)
+	}
+	if choice.Message.Content == nil || *choice.Message.Content != "Partial" {
+		t.Errorf("unexpected content: %v", choice.Message.Content)
+	}
+	if u := completion.Usage; u == nil || u.PromptTokens != 20 || u.TotalTokens != 28 ||
+		u.PromptTokensDetails.CachedTokens != 4 || u.CompletionTokensDetails.ReasoningTokens != 3 {
+		t.Errorf("unexpected usage: %+v", completion.Usage)
+	}
+}
+
+func TestResponsesStream(t *testing.T) {
+	s := NewResponsesStream(false)
+
+	var out []byte
+	for _, event := range []string{
+		`{"type":"response.created","response":{"id":"resp_1","model":"gpt-model","created_at":1700000000}}`,
+		`{"type":"response.output_item.added","output_index":0,"item":{"type":"reasoning"}}`,
+		`{"type":"response.output_item.added","output_index":1,"item":{"type":"function_call","call_id":"call_1","name":"lookup","arguments":""}}`,
+		`{"type":"response.function_call_arguments.delta","output_index":1,"delta":"{\"q\":\"cat\"}"}`,
+		`{"type":"response.completed","response":{"status":"completed","usage":{"input_tokens":5,"output_tokens":2}}}`,
+	} {
+		out = append(out, s.Translate([]byte(event))...)
+	}
+	out = append(out, s.Finish()...)
+
+	chunks, done := parseEvents(t, out)
+	if !done {
+		t.Fatal("stream did not end with [DONE]")
+	}
+
+	var (
+		calls        []ToolCall
+		finishReason string
+	)
+	for _, chunk := range chunks {
+		if chunk.Usage != nil {
+			t.Error("usage was sent although the client did not ask for it")
+		}
+		if chunk.Created != 1700000000 {
+			t.Errorf("created = %d", chunk.Created)
+		}
+		for _, choice := range chunk.Choices {
+			calls = append(calls, choice.Delta.ToolCalls...)
+			if choice.FinishReason != nil {
+				finishReason = *choice.FinishReason
+			}
+		}
+	}
+	if len(calls) != 2 || calls[0].ID != "call_1" || calls[0].Function.Name != "lookup" || calls[1].Function.Arguments != `{"q":"cat"}` {
+		t.Errorf("unexpected tool call deltas: %+v", calls)
+	}
+	if finishReason != finishToolCalls {
+		t.Errorf("finish reason = %q, want %q", finishReason, finishToolCalls)
+	}
+}
+
+func TestResponsesStreamFailure(t *testing.T) {
+	s := NewResponsesStream(false)
+	out := s.Translate([]byte(`{"type":"response.failed","response":{"error":{"code":"server_error","message":"upstream failed"}}}`))
+
+	result := gjson.ParseBytes(trimData(out))
+	if result.Get("error.message").String() != "upstream failed" || result.Get("error.type").String() != "server_error" {
+		t.Fatalf("unexpected error event: %s", out)
+	}
+}
+
+func TestErrorBody(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		body        string
+		wantMessage string
+		wantType    string
+		wantCode    string
+	}{
+		{
+			name:        "anthropic error",
+			status:      http.StatusBadRequest,
+			body:        `{"type":"error","error":{"type":"invalid_request_error","message":"max_tokens too large"}}`,
+			wantMessage: "max_tokens too large",
+			wantType:    "invalid_request_error",
+		},
+		{
+			name:        "openai error",
+			status:      http.StatusTooManyRequests,
+			body:        `{"error":{"message":"slow down","type":"","code":"rate_limit_exceeded"}}`,
+			wantMessage: "slow down",
+			wantType:    "rate_limit_error",
+			wantCode:    "rate_limit_exceeded",
+		},
+		{
+			name:        "plain text",
+			status:      http.StatusForbidden,
+			body:        "user does not have permission to use model \"gpt\"\n",
+			wantMessage: "user does not have permission to use model \"gpt\"",
+			wantType:    "permission_error",
+		},
+		{
+			name:        "empty",
+			status:      http.StatusBadGateway,
+			wantMessage: "Bad Gateway",
+			wantType:    "api_error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resp ErrorResponse
+			if err := json.Unmarshal(ErrorBody(tt.status, []byte(tt.body)), &resp); err != nil {
+				t.Fatal(err)
+			}
+			if resp.Error.Message != tt.wantMessage || resp.Error.Type != tt.wantType {
+				t.Errorf("got %+v", resp.Error)
+			}
+			var code string
+			if resp.Error.Code != nil {
+				code = *resp.Error.Code
+			}
+			if code != tt.wantCode {
+				t.Errorf("code = %q, want %q", code, tt.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package chatcompletions
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+)
+
+// StreamTranslator converts a streamed upstream response to Chat Completions
+// server-sent events.
+type StreamTranslator interface {
+	// Translate converts the data payload of one upstream event. It returns the
+	// encoded Chat Completions events to send, which may be none.
+	Translate(data []byte) []byte
+	// Finish returns the events that end the stream: the usage chunk when the
+	// client asked for one, and the [DONE] terminator. It returns nothing when
+	// called again.
+	Finish() []byte
+}
+
+// stream holds the state shared by the dialect-specific stream translators.
+type stream struct {
+	id, model    string
+	created      int64
+	includeUsage bool
+	usage        *Usage
+	started      bool
+	finished     bool
+	done         bool
+}
+
+func newStream(includeUsage bool) stream {
+	return stream{
+		created:      time.Now().Unix(),
+		includeUsage: includeUsage,
+	}
+}
+
+func (s *stream) start() []byte {
+	if s.started {
+		return nil
+	}
+	s.started = true
+	return s.chunk(Delta{Role: "assistant", Content: ptr("")}, nil)
+}
+
+func (s *stream) delta(delta Delta) []byte {
+	return append(s.start(), s.chunk(delta, nil)...)
+}
+
+func (s *stream) finish(reason string) []byte {
+	if s.finished {
+		return nil
+	}
+	s.finished = true
+	return append(s.start(), s.chunk(Delta{}, &reason)...)
+}
+
+func (s *stream) policyViolation(notification string) []byte {
+	return s.event(Chunk{
+		ID:              s.id,
+		Object:          objectChunk,
+		Created:         s.created,
+		Model:           s.model,
+		Choices:         []ChunkChoice{},
+		PolicyViolation: notification,
+	})
+}
+
+func (s *stream) errorEvent(message, errType string) []byte {
+	if errType == "" {
+		errType = "api_error"
+	}
+	return s.event(ErrorResponse{Error: ErrorDetail{Message: message, Type: errType}})
+}
+
+func (s *stream) Finish() []byte {
+	if s.done {
+		return nil
+	}
+	s.done = true
+
+	var out []byte
+	if s.includeUsage && s.usage != nil {
+		out = s.event(Chunk{
+			ID:      s.id,
+			Object:  objectChunk,
+			Created: s.created,
+			Model:   s.model,
+			Choices: []ChunkChoice{},
+			Usage:   s.usage,
+		})
+	}
+	return append(out, "data: [DONE]\n\n"...)
+}
+
+func (s *stream) chunk(delta Delta, finishReason *string) []byte {
+	return s.event(Chunk{
+		ID:      s.id,
+		Object:  objectChunk,
+		Created: s.created,
+		Model:   s.model,
+		Choices: []ChunkChoice{{Delta: delta, FinishReason: finishReason}},
+	})
+}
+
+func (s *stream) event(v any) []byte {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	buf.Grow(len(data) + 8)
+	buf.WriteString("data: ")
+	buf.Write(data)
+	buf.WriteString("\n\n")
+	return buf.Bytes()
+}
@@ -0,0 +1,256 @@
+// Package chatcompletions translates OpenAI Chat Completions requests and
+// responses to and from the dialects spoken by the LLM gateway's providers:
+// Anthropic Messages and the OpenAI Responses API.
+package chatcompletions
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Request is the subset of a Chat Completions request that can be translated.
+type Request struct {
+	Model               string          `json:"model"`
+	Messages            []Message       `json:"messages"`
+	Stream              bool            `json:"stream,omitempty"`
+	StreamOptions       *StreamOptions  `json:"stream_options,omitempty"`
+	MaxTokens           *int            `json:"max_tokens,omitempty"`
+	MaxCompletionTokens *int            `json:"max_completion_tokens,omitempty"`
+	Temperature         *float64        `json:"temperature,omitempty"`
+	TopP                *float64        `json:"top_p,omitempty"`
+	Stop                json.RawMessage `json:"stop,omitempty"`
+	Tools               []Tool          `json:"tools,omitempty"`
+	ToolChoice          json.RawMessage `json:"tool_choice,omitempty"`
+	ParallelToolCalls   *bool           `json:"parallel_tool_calls,omitempty"`
+	ReasoningEffort     string          `json:"reasoning_effort,omitempty"`
+	ResponseFormat      *ResponseFormat `json:"response_format,omitempty"`
+	User                string          `json:"user,omitempty"`
+}
+
+type StreamOptions struct {
+	IncludeUsage bool `json:"include_usage,omitempty"`
+}
+
+// IncludeUsage reports whether the client asked for a final usage chunk on a
+// streamed response.
+func (r Request) IncludeUsage() bool {
+	return r.StreamOptions != nil && r.StreamOptions.IncludeUsage
+}
+
+// Message is a Chat Completions message. Content is either a string or an
+// array of content parts.
+type Message struct {
+	Role       string          `json:"role"`
+	Content    json.RawMessage `json:"content,omitempty"`
+	Name       string          `json:"name,omitempty"`
+	ToolCalls  []ToolCall      `json:"tool_calls,omitempty"`
+	ToolCallID string          `json:"tool_call_id,omitempty"`
+}
+
+type ContentPart struct {
+	Type     string    `json:"type"`
+	Text     string    `json:"text,omitempty"`
+	ImageURL *ImageURL `json:"image_url,omitempty"`
+}
+
+type ImageURL struct {
+	URL    string `json:"url"`
+	Detail string `json:"detail,omitempty"`
+}
+
+type ToolCall struct {
+	Index    *int         `json:"index,omitempty"`
+	ID       string       `json:"id,omitempty"`
+	Type     string       `json:"type,omitempty"`
+	Function FunctionCall `json:"function"`
+}
+
+type FunctionCall struct {
+	Name      string `json:"name,omitempty"`
+	Arguments string `json:"arguments"`
+}
+
+type Tool struct {
+	Type     string             `json:"type"`
+	Function FunctionDefinition `json:"function"`
+}
+
+type FunctionDefinition struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	Parameters  json.RawMessage `json:"parameters,omitempty"`
+	Strict      *bool           `json:"strict,omitempty"`
+}
+
+type ResponseFormat struct {
+	Type       string      `json:"type"`
+	JSONSchema *JSONSchema `json:"json_schema,omitempty"`
+}
+
+type JSONSchema struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	Schema      json.RawMessage `json:"schema,omitempty"`
+	Strict      *bool           `json:"strict,omitempty"`
+}
+
+// Completion is a non-streamed Chat Completions response.
+type Completion struct {
+	ID      string   `json:"id"`
+	Object  string   `json:"object"`
+	Created int64    `json:"created"`
+	Model   string   `json:"model"`
+	Choices []Choice `json:"choices"`
+	Usage   *Usage   `json:"usage,omitempty"`
+	// PolicyViolation carries the gateway's tool call policy violation marker
+	// through the translation unchanged.
+	PolicyViolation string `json:"obot_tool_call_policy_violation,omitempty"`
+}
+
+type Choice struct {
+	Index        int             `json:"index"`
+	Message      ResponseMessage `json:"message"`
+	FinishReason string          `json:"finish_reason"`
+}
+
+type ResponseMessage struct {
+	Role      string     `json:"role"`
+	Content   *string    `json:"content"`
+	ToolCalls []ToolCall `json:"tool_calls,omitempty"`
+}
+
+// Chunk is one event of a streamed Chat Completions response.
+type Chunk struct {
+	ID              string        `json:"id"`
+	Object          string        `json:"object"`
+	Created         int64         `json:"created"`
+	Model           string        `json:"model"`
+	Choices         []ChunkChoice `json:"choices"`
+	Usage           *Usage        `json:"usage,omitempty"`
+	PolicyViolation string        `json:"obot_tool_call_policy_violation,omitempty"`
+}
+
+type ChunkChoice struct {
+	Index        int     `json:"index"`
+	Delta        Delta   `json:"delta"`
+	FinishReason *string `json:"finish_reason"`
+}
+
+type Delta struct {
+	Role      string     `json:"role,omitempty"`
+	Content   *string    `json:"content,omitempty"`
+	ToolCalls []ToolCall `json:"tool_calls,omitempty"`
+}
+
+type Usage struct {
+	PromptTokens            int                      `json:"prompt_tokens"`
+	CompletionTokens        int                      `json:"completion_tokens"`
+	TotalTokens             int                      `json:"total_tokens"`
+	PromptTokensDetails     *PromptTokensDetails     `json:"prompt_tokens_details,omitempty"`
+	CompletionTokensDetails *CompletionTokensDetails `json:"completion_tokens_details,omitempty"`
+}
+
+type PromptTokensDetails struct {
+	CachedTokens int `json:"cached_tokens"`
+}
+
+type CompletionTokensDetails struct {
+	ReasoningTokens int `json:"reasoning_tokens"`
+}
+
+// ErrorResponse is the error envelope OpenAI clients expect.
+type ErrorResponse struct {
+	Error ErrorDetail `json:"error"`
+}
+
+type ErrorDetail struct {
+	Message string  `json:"message"`
+	Type    string  `json:"type"`
+	Code    *string `json:"code"`
+}
+
+const (
+	objectCompletion = "chat.completion"
+	objectChunk      = "chat.completion.chunk"
+
+	finishStop          = "stop"
+	finishLength        = "length"
+	finishToolCalls     = "tool_calls"
+	finishContentFilter = "content_filter"
+)
+
+// contentParts normalizes message content to a list of parts. Plain string
+// content becomes a single text part.
+func contentParts(content json.RawMessage) []ContentPart {
+	if len(content) == 0 || string(content) == "null" {
+		return nil
+	}
+
+	var text string
+	if err := json.Unmarshal(content, &text); err == nil {
+		if text == "" {
+			return nil
+		}
+		return []ContentPart{{Type: "text", Text: text}}
+	}
+
+	var parts []ContentPart
+	_ = json.Unmarshal(content, &parts)
+	return parts
+}
+
+// contentText joins the text parts of message content.
+func contentText(content json.RawMessage) string {
+	var texts []string
+	for _, part := range contentParts(content) {
+		if part.Type == "text" && part.Text != "" {
+			texts = append(texts, part.Text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
+
+// stopSequences accepts the string or array forms of the stop parameter.
+func stopSequences(stop json.RawMessage) []string {
+	if len(stop) == 0 {
+		return nil
+	}
+
+	var single string
+	if err := json.Unmarshal(stop, &single); err == nil {
+		if single == "" {
+			return nil
+		}
+		return []string{single}
+	}
+
+	var multiple []string
+	_ = json.Unmarshal(stop, &multiple)
+	return multiple
+}
+
+// toolChoice decodes the tool_choice parameter into either a mode ("auto",
+// "none" or "required") or the name of a function that must be called.
+func toolChoice(raw json.RawMessage) (mode, function string) {
+	if len(raw) == 0 {
+		return "", ""
+	}
+
+	if err := json.Unmarshal(raw, &mode); err == nil {
+		return mode, ""
+	}
+
+	var named struct {
+		Function struct {
+			Name string `json:"name"`
+		} `json:"function"`
+	}
+	if err := json.Unmarshal(raw, &named); err == nil && named.Function.Name != "" {
+		return "", named.Function.Name
+	}
+	return "", ""
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -9,7 +9,7 @@ import (
 )
 
 func (c *Client) LogMCPAuditEntry(entry types.MCPAuditLog) {
-	// Encrypt the audit entry before adding to buffer
+	// Redact and encrypt the audit entry before adding to buffer
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
@@ -22,6 +22,7 @@ func (c *Client) LogMCPAuditEntry(entry types.MCPAuditLog) {
 	mcp.RequestMutated = len(mcp.MutatedRequestBody) > 0
 	mcp.ResponseMutated = len(mcp.OriginalResponseBody) > 0
 
+	c.redactMCPAuditLog(ctx, &entry)
 	if err := c.encryptMCPAuditLog(ctx, &entry); err != nil {
 		slog.Error("Failed to encrypt MCP audit log", "error", err)
 	}
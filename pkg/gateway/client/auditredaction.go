@@ -0,0 +1,273 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	types2 "github.com/obot-platform/obot/apiclient/types"
+	"github.com/obot-platform/obot/pkg/gateway/redact"
+	"github.com/obot-platform/obot/pkg/gateway/types"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	"github.com/obot-platform/obot/pkg/system"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const auditRedactorCacheTTL = 30 * time.Second
+
+// ErrUnredactedPayloadNotRetained is returned when an audit log has no retained original payload.
+var ErrUnredactedPayloadNotRetained = errors.New("unredacted payload was not retained for this audit log")
+
+// auditPayloadField is a redactable payload column. Name keys the field's original value in the
+// retained unredacted payload.
+type auditPayloadField struct {
+	name  string
+	value *json.RawMessage
+}
+
+// auditRedactor returns the redactor for the current audit redaction setting. The setting is
+// cached briefly because it is consulted for every audit entry.
+func (c *Client) auditRedactor(ctx context.Context) *redact.Redactor {
+	c.auditRedactorLock.Lock()
+	defer c.auditRedactorLock.Unlock()
+
+	if c.auditRedactorLoaded && time.Now().Before(c.auditRedactorExpiresAt) {
+		return c.auditRedactorCache
+	}
+
+	r, err := c.loadAuditRedactor(ctx)
+	if err != nil {
+		slog.Error("Failed to load audit redaction setting, using the last known setting", "error", err)
+		if !c.auditRedactorLoaded {
+			// Fail toward redaction: use the built-in detectors until the setting can be read.
+			r, _ = redact.New(types2.AuditRedactionSetting{})
+		} else {
+			r = c.auditRedactorCache
+		}
+	}
+
+	c.auditRedactorCache = r
+	c.auditRedactorLoaded = true
+	c.auditRedactorExpiresAt = time.Now().Add(auditRedactorCacheTTL)
+	return r
+}
+
+func (c *Client) loadAuditRedactor(ctx context.Context) (*redact.Redactor, error) {
+	var setting types2.AuditRedactionSetting
+	if c.storageClient != nil {
+		var obj v1.AuditRedactionSetting
+		if err := c.storageClient.Get(ctx, kclient.ObjectKey{Namespace: system.DefaultNamespace, Name: system.AuditRedactionSettingName}, &obj); err == nil {
+			setting = obj.Spec.Manifest
+		} else if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+	}
+	return redact.New(setting)
+}
+
+// redactAuditPayloads redacts fields in place. It reports whether anything was redacted and, when
+// the redactor retains originals, returns them encoded for storage.
+func redactAuditPayloads(r *redact.Redactor, fields ...auditPayloadField) (bool, json.RawMessage) {
+	var (
+		redacted  bool
+		originals map[string]string
+	)
+	for _, field := range fields {
+		out, changed := r.Redact(*field.value)
+		if !changed {
+			continue
+		}
+		redacted = true
+		if r.RetainUnredacted() {
+			if originals == nil {
+				originals = map[string]string{}
+			}
+			originals[field.name] = string(*field.value)
+		}
+		*field.value = out
+	}
+	if len(originals) == 0 {
+		return redacted, nil
+	}
+	b, err := json.Marshal(originals)
+	if err != nil {
+		slog.Error("Failed to encode unredacted audit payload", "error", err)
+		return redacted, nil
+	}
+	return redacted, b
+}
+
+// restoreAuditPayloads replaces fields with the originals retained by redactAuditPayloads.
+func restoreAuditPayloads(unredacted json.RawMessage, fields ...auditPayloadField) error {
+	if len(unredacted) == 0 {
+		return nil
+	}
+	var originals map[string]string
+	if err := json.Unmarshal(unredacted, &originals); err != nil {
+		return fmt.Errorf("failed to decode unredacted audit payload: %w", err)
+	}
+	for _, field := range fields {
+		if original, ok := originals[field.name]; ok {
+			*field.value = json.RawMessage(original)
+		}
+	}
+	return nil
+}
+
+func llmAuditRequestPayloadFields(log *types.LLMAuditLog) []auditPayloadField {
+	return []auditPayloadField{
+		{name: "requestBody", value: &log.RequestBody},
+		{name: "policyModifiedRequestBody", value: &log.PolicyModifiedRequestBody},
+	}
+}
+
+func llmAuditResponsePayloadFields(log *types.LLMAuditLog) []auditPayloadField {
+	return []auditPayloadField{
+		{name: "responseBody", value: &log.ResponseBody},
+	}
+}
+
+// mcpAuditRequestPayloadFields and mcpAuditResponsePayloadFields follow the split between the
+// request-phase and response-phase entries that insertMCPAuditLogs merges into one row.
+func mcpAuditRequestPayloadFields(log *types.MCPAuditLog) []auditPayloadField {
+	if log.SourceType == types2.AuditLogSourceTypeLocalAgentToolCall {
+		local := log.LocalAgentToolCallFields
+		if local == nil {
+			return nil
+		}
+		return []auditPayloadField{
+			{name: "requestBody", value: &local.RequestBody},
+			{name: "rawEvent", value: &local.RawEvent},
+		}
+	}
+	if mcp := log.MCP(); mcp != nil {
+		return []auditPayloadField{
+			{name: "requestBody", value: &mcp.RequestBody},
+		}
+	}
+	return nil
+}
+
+func mcpAuditResponsePayloadFields(log *types.MCPAuditLog) []auditPayloadField {
+	if log.SourceType == types2.AuditLogSourceTypeLocalAgentToolCall {
+		local := log.LocalAgentToolCallFields
+		if local == nil {
+			return nil
+		}
+		return []auditPayloadField{
+			{name: "responseBody", value: &local.ResponseBody},
+		}
+	}
+	if mcp := log.MCP(); mcp != nil {
+		return []auditPayloadField{
+			{name: "mutatedRequestBody", value: &mcp.MutatedRequestBody},
+			{name: "responseBody", value: &mcp.ResponseBody},
+			{name: "originalResponseBody", value: &mcp.OriginalResponseBody},
+		}
+	}
+	return nil
+}
+
+func (c *Client) redactLLMAuditLog(ctx context.Context, log *types.LLMAuditLog) {
+	r := c.auditRedactor(ctx)
+	if r == nil {
+		return
+	}
+	requestRedacted, unredactedRequest := redactAuditPayloads(r, llmAuditRequestPayloadFields(log)...)
+	responseRedacted, unredactedResponse := redactAuditPayloads(r, llmAuditResponsePayloadFields(log)...)
+	log.ContentRedacted = requestRedacted || responseRedacted
+	log.UnredactedRequestPayload = unredactedRequest
+	log.UnredactedResponsePayload = unredactedResponse
+}
+
+func (c *Client) redactMCPAuditLog(ctx context.Context, log *types.MCPAuditLog) {
+	log.ContentRedacted = false
+	log.UnredactedRequestPayload = nil
+	log.UnredactedResponsePayload = nil
+
+	r := c.auditRedactor(ctx)
+	if r == nil {
+		return
+	}
+	requestRedacted, unredactedRequest := redactAuditPayloads(r, mcpAuditRequestPayloadFields(log)...)
+	responseRedacted, unredactedResponse := redactAuditPayloads(r, mcpAuditResponsePayloadFields(log)...)
+	log.ContentRedacted = requestRedacted || responseRedacted
+	log.UnredactedRequestPayload = unredactedRequest
+	log.UnredactedResponsePayload = unredactedResponse
+}
+
+// GetUnredactedLLMAuditLog returns an LLM audit log with its retained original payloads restored.
+// Callers must restrict it to the privileged role allowed to read unredacted payloads.
+func (c *Client) GetUnredactedLLMAuditLog(ctx context.Context, id string) (*types.LLMAuditLog, error) {
+	log, err := c.GetLLMAuditLog(ctx, id, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.loadUnredactedPayloads(ctx, &types.LLMAuditLog{}, id, &log.UnredactedRequestPayload, &log.UnredactedResponsePayload); err != nil {
+		return nil, err
+	}
+	if len(log.UnredactedRequestPayload) == 0 && len(log.UnredactedResponsePayload) == 0 {
+		return nil, ErrUnredactedPayloadNotRetained
+	}
+	if err := c.decryptLLMAuditLogUnredactedPayloads(ctx, log); err != nil {
+		return nil, fmt.Errorf("failed to decrypt LLM audit log: %w", err)
+	}
+
+	if err := restoreAuditPayloads(log.UnredactedRequestPayload, llmAuditRequestPayloadFields(log)...); err != nil {
+		return nil, err
+	}
+	if err := restoreAuditPayloads(log.UnredactedResponsePayload, llmAuditResponsePayloadFields(log)...); err != nil {
+		return nil, err
+	}
+	log.UnredactedRequestPayload, log.UnredactedResponsePayload = nil, nil
+	return log, nil
+}
+
+// GetUnredactedMCPAuditLog returns an MCP or local-agent audit log with its retained original
+// payloads restored. Callers must restrict it to the privileged role allowed to read unredacted
+// payloads.
+func (c *Client) GetUnredactedMCPAuditLog(ctx context.Context, id uint) (*types.MCPAuditLog, error) {
+	log, err := c.GetMCPAuditLog(ctx, id, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.loadUnredactedPayloads(ctx, &types.MCPAuditLog{}, id, &log.UnredactedRequestPayload, &log.UnredactedResponsePayload); err != nil {
+		return nil, err
+	}
+	if len(log.UnredactedRequestPayload) == 0 && len(log.UnredactedResponsePayload) == 0 {
+		return nil, ErrUnredactedPayloadNotRetained
+	}
+	if err := c.decryptMCPAuditLogUnredactedPayloads(ctx, log); err != nil {
+		return nil, fmt.Errorf("failed to decrypt MCP audit log: %w", err)
+	}
+
+	if err := restoreAuditPayloads(log.UnredactedRequestPayload, mcpAuditRequestPayloadFields(log)...); err != nil {
+		return nil, err
+	}
+	if err := restoreAuditPayloads(log.UnredactedResponsePayload, mcpAuditResponsePayloadFields(log)...); err != nil {
+		return nil, err
+	}
+	log.UnredactedRequestPayload, log.UnredactedResponsePayload = nil, nil
+	return log, nil
+}
+
+// loadUnredactedPayloads reads the write-only unredacted payload columns, which GORM skips when
+// loading models.
+func (c *Client) loadUnredactedPayloads(ctx context.Context, model any, id any, request, response *json.RawMessage) error {
+	var requestPayload, responsePayload []byte
+	if err := c.db.WithContext(ctx).Model(model).
+		Select("unredacted_request_payload", "unredacted_response_payload").
+		Where("id = ?", id).
+		Row().
+		Scan(&requestPayload, &responsePayload); err != nil {
+		return err
+	}
+	*request, *response = requestPayload, responsePayload
+	return nil
+}
@@ -0,0 +1,137 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+	"uuid"
+
+	types2 "github.com/obot-platform/obot/apiclient/types"
+	"github.com/obot-platform/obot/pkg/gateway/redact"
+	"github.com/obot-platform/obot/pkg/gateway/types"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/server/options/encryptionconfig"
+	"k8s.io/apiserver/pkg/storage/value"
+)
+
+func setTestAuditRedactor(t *testing.T, c *Client, setting types2.AuditRedactionSetting) {
+	t.Helper()
+	r, err := redact.New(setting)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.auditRedactorCache = r
+	c.auditRedactorLoaded = true
+	c.auditRedactorExpiresAt = time.Now().Add(time.Hour)
+}
+
+func TestPersistLLMAuditLogsRedactsPayloads(t *testing.T) {
+	c := newTestClient(t)
+	c.encryptionConfig = &encryptionconfig.EncryptionConfiguration{
+		Transformers: map[schema.GroupResource]value.Transformer{
+			llmAuditLogGroupResource: testTransformer{},
+		},
+	}
+	setTestAuditRedactor(t, c, types2.AuditRedactionSetting{RetainUnredacted: true})
+
+	redactedID, cleanID := uuid.New().String(), uuid.New().String()
+	originalRequest := `{"input":"my key is sk-proj-abcdefghijklmnopqrstuvwx"}`
+	if err := c.persistLLMAuditLogs([]llmAuditEntry{
+		{log: types.LLMAuditLog{ID: redactedID, CreatedAt: time.Now(), UserID: "user-1", RequestBody: json.RawMessage(originalRequest)}},
+		{log: types.LLMAuditLog{ID: cleanID, CreatedAt: time.Now(), UserID: "user-1", RequestBody: json.RawMessage(`{"input":"hello"}`)}},
+	}); err != nil {
+		t.Fatalf("failed to persist LLM audit logs: %v", err)
+	}
+
+	got, err := c.GetLLMAuditLog(t.Context(), redactedID, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.ContentRedacted || strings.Contains(string(got.RequestBody), "sk-proj") || !strings.Contains(string(got.RequestBody), "[REDACTED:api_key]") {
+		t.Fatalf("expected a redacted request body, got redacted=%v body=%s", got.ContentRedacted, got.RequestBody)
+	}
+	if len(got.UnredactedRequestPayload) != 0 {
+		t.Fatal("unredacted payload was loaded by a normal query")
+	}
+
+	unredacted, err := c.GetUnredactedLLMAuditLog(t.Context(), redactedID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(unredacted.RequestBody) != originalRequest {
+		t.Fatalf("unredacted request body = %s, want %s", unredacted.RequestBody, originalRequest)
+	}
+
+	clean, err := c.GetLLMAuditLog(t.Context(), cleanID, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if clean.ContentRedacted {
+		t.Fatal("expected a clean log not to be flagged")
+	}
+	if _, err := c.GetUnredactedLLMAuditLog(t.Context(), cleanID); !errors.Is(err, ErrUnredactedPayloadNotRetained) {
+		t.Fatalf("expected ErrUnredactedPayloadNotRetained, got %v", err)
+	}
+}
+
+func TestInsertMCPAuditLogsMergesRedactedResponse(t *testing.T) {
+	c := newTestClient(t)
+	ctx := t.Context()
+	setTestAuditRedactor(t, c, types2.AuditRedactionSetting{RetainUnredacted: true, FieldMasks: []string{"params.arguments.token"}})
+
+	now := time.Now().UTC()
+	request := types.MCPAuditLog{
+		CreatedAt:  now,
+		SourceType: types2.AuditLogSourceTypeMCP,
+		UserID:     "user-1",
+		MCPFields: &types.MCPAuditLogFields{
+			MCPID:       "mcp-1",
+			CallType:    "tools/call",
+			RequestID:   "1",
+			RequestBody: json.RawMessage(`{"params":{"arguments":{"token":"abc"}}}`),
+		},
+	}
+	response := types.MCPAuditLog{
+		CreatedAt:  now.Add(time.Second),
+		SourceType: types2.AuditLogSourceTypeMCP,
+		UserID:     "user-1",
+		MCPFields: &types.MCPAuditLogFields{
+			MCPID:            "mcp-1",
+			CallType:         "tools/call",
+			RequestID:        "1",
+			ResponseReceived: true,
+			ResponseStatus:   200,
+			ResponseBody:     json.RawMessage(`{"result":{"content":[{"text":"mail bob@example.com"}]}}`),
+		},
+	}
+	for _, log := range []*types.MCPAuditLog{&request, &response} {
+		c.redactMCPAuditLog(ctx, log)
+	}
+	if err := c.insertMCPAuditLogs(ctx, []types.MCPAuditLog{request, response}); err != nil {
+		t.Fatalf("failed to insert audit logs: %v", err)
+	}
+
+	var stored types.MCPAuditLog
+	if err := c.db.WithContext(ctx).First(&stored).Error; err != nil {
+		t.Fatal(err)
+	}
+	if !stored.ContentRedacted {
+		t.Fatal("expected the merged row to be flagged as redacted")
+	}
+	if body := string(stored.MCP().RequestBody); body != `{"params":{"arguments":{"token":"[REDACTED]"}}}` {
+		t.Fatalf("unexpected request body %s", body)
+	}
+
+	unredacted, err := c.GetUnredactedMCPAuditLog(ctx, stored.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if body := string(unredacted.MCP().RequestBody); !strings.Contains(body, `"token":"abc"`) {
+		t.Fatalf("unredacted request body = %s", body)
+	}
+	if body := string(unredacted.MCP().ResponseBody); !strings.Contains(body, "bob@example.com") {
+		t.Fatalf("unredacted response body = %s", body)
+	}
+}
@@ -11,6 +11,7 @@ import (
 
 	types2 "github.com/obot-platform/obot/apiclient/types"
 	"github.com/obot-platform/obot/pkg/gateway/db"
+	"github.com/obot-platform/obot/pkg/gateway/redact"
 	"github.com/obot-platform/obot/pkg/gateway/types"
 	"k8s.io/apiserver/pkg/server/options/encryptionconfig"
 	kclient "sigs.k8s.io/controller-runtime/pkg/client"
@@ -60,6 +61,10 @@ type Client struct {
 	oktaGroupMigrationMu      sync.Mutex
 	oktaGroupMigrationDone    bool
 	mcpOAuthTokenTrigger      func(context.Context, string) error
+	auditRedactorLock         sync.Mutex
+	auditRedactorCache        *redact.Redactor
+	auditRedactorLoaded       bool
+	auditRedactorExpiresAt    time.Time
 }
 
 func New(ctx context.Context, db *db.DB, storageClient kclient.Client, encryptionConfig *encryptionconfig.EncryptionConfiguration, mcpOAuthTokenTrigger func(context.Context, string) error, ownerEmails, adminEmails []string, auditLogPersistenceInterval time.Duration, auditLogBatchSize, auditLogRetentionDays, llmAuditLogRetentionDays, deviceScanRetentionDays int, llmAuditEnabled bool) *Client {
@@ -102,6 +107,7 @@ func New(ctx context.Context, db *db.DB, storageClient kclient.Client, encryptio
 	go c.runAPIKeyCacheCleanup(ctx)
 	go c.runRetentionCleanup(ctx, auditLogRetentionDays, llmAuditLogRetentionDays)
 	go c.runDeviceScanCleanup(ctx, deviceScanRetentionDays)
+	go c.runMCPQuotaUsageCleanup(ctx)
 	return c
 }
 
@@ -0,0 +1,60 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/obot-platform/obot/pkg/gateway/types"
+	"gorm.io/gorm"
+)
+
+// RecordConfigChange adds a revision to a governance object's history.
+func (c *Client) RecordConfigChange(ctx context.Context, change *types.ConfigChange) error {
+	if err := c.db.WithContext(ctx).Create(change).Error; err != nil {
+		return fmt.Errorf("failed to record config change: %w", err)
+	}
+	return nil
+}
+
+// ListConfigChanges returns recorded revisions, newest first, along with the
+// total number that match. An empty kind or objectID matches any.
+func (c *Client) ListConfigChanges(ctx context.Context, kind, objectID string, limit, offset int) ([]types.ConfigChange, int64, error) {
+	db := c.db.WithContext(ctx).Model(&types.ConfigChange{})
+	if kind != "" {
+		db = db.Where("kind = ?", kind)
+	}
+	if objectID != "" {
+		db = db.Where("object_id = ?", objectID)
+	}
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count config changes: %w", err)
+	}
+
+	if limit > 0 {
+		db = db.Limit(limit)
+	}
+	if offset > 0 {
+		db = db.Offset(offset)
+	}
+
+	var changes []types.ConfigChange
+	if err := db.Order("id DESC").Find(&changes).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to list config changes: %w", err)
+	}
+	return changes, total, nil
+}
+
+// GetConfigChange returns one revision. It returns gorm.ErrRecordNotFound if
+// there is no such revision.
+func (c *Client) GetConfigChange(ctx context.Context, id uint) (*types.ConfigChange, error) {
+	var change types.ConfigChange
+	if err := c.db.WithContext(ctx).Where("id = ?", id).First(&change).Error; errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get config change: %w", err)
+	}
+	return &change, nil
+}
@@ -0,0 +1,47 @@
+package client
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/obot-platform/obot/pkg/gateway/types"
+	"gorm.io/gorm"
+)
+
+func TestListConfigChangesFiltersNewestFirst(t *testing.T) {
+	client := newTestClient(t)
+	for _, change := range []types.ConfigChange{
+		{Kind: "MessagePolicy", ObjectID: "mp1", Action: "created", After: `{"manifest":{}}`},
+		{Kind: "MessagePolicy", ObjectID: "mp2", Action: "created", After: `{"manifest":{}}`},
+		{Kind: "ModelAccessPolicy", ObjectID: "map1", Action: "created", After: `{"manifest":{}}`},
+		{Kind: "MessagePolicy", ObjectID: "mp1", Action: "deleted", Before: `{"manifest":{}}`},
+	} {
+		if err := client.RecordConfigChange(t.Context(), &change); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	changes, total, err := client.ListConfigChanges(t.Context(), "MessagePolicy", "mp1", 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 2 || len(changes) != 2 || changes[0].Action != "deleted" || changes[1].Action != "created" {
+		t.Fatalf("changes = %#v, total = %d", changes, total)
+	}
+
+	changes, total, err = client.ListConfigChanges(t.Context(), "MessagePolicy", "", 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 3 || len(changes) != 1 || changes[0].ObjectID != "mp2" {
+		t.Fatalf("second page = %#v, total = %d", changes, total)
+	}
+
+	change, err := client.GetConfigChange(t.Context(), changes[0].ID)
+	if err != nil || change.ObjectID != "mp2" {
+		t.Fatalf("GetConfigChange() = %#v, %v", change, err)
+	}
+	if _, err := client.GetConfigChange(t.Context(), 1000); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("GetConfigChange() for a missing revision error = %v", err)
+	}
+}
@@ -95,9 +95,20 @@ func (c *Client) InsertDeviceScan(ctx context.Context, scan *types.DeviceScan) e
 	if scan == nil {
 		return errors.New("nil device scan")
 	}
+
+	hashes := make([]string, 0, len(scan.MCPServers))
+	for _, server := range scan.MCPServers {
+		hashes = append(hashes, server.ConfigHash)
+	}
+	known, err := c.knownMCPServerConfigHashes(ctx, hashes)
+	if err != nil {
+		return fmt.Errorf("failed to look up known MCP servers: %w", err)
+	}
+
 	if err := c.db.WithContext(ctx).Create(scan).Error; err != nil {
 		return fmt.Errorf("failed to insert device scan: %w", err)
 	}
+	c.notifyUnknownMCPServers(scan, known)
 	return nil
 }
 
@@ -386,3 +386,24 @@ func TestDeviceClientFleetSummaries(t *testing.T) {
 		t.Errorf("sort mcp_server_count desc: want claude-code first, got %+v", byMCPServers)
 	}
 }
+
+func TestKnownMCPServerConfigHashes(t *testing.T) {
+	c := newTestClient(t)
+
+	if err := c.InsertDeviceScan(t.Context(), &types.DeviceScan{
+		SubmittedBy: "user-a", DeviceID: "device-a", ScannedAt: time.Now().UTC(),
+		MCPServers: []types.DeviceScanMCPServer{
+			{Client: "claude-code", Name: "seen", Transport: "stdio", ConfigHash: "hash-seen"},
+		},
+	}); err != nil {
+		t.Fatalf("InsertDeviceScan: %v", err)
+	}
+
+	known, err := c.knownMCPServerConfigHashes(t.Context(), []string{"hash-seen", "hash-new"})
+	if err != nil {
+		t.Fatalf("knownMCPServerConfigHashes: %v", err)
+	}
+	if !known["hash-seen"] || known["hash-new"] {
+		t.Fatalf("known = %v, want only hash-seen", known)
+	}
+}
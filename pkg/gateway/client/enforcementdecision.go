@@ -3,9 +3,11 @@ package client
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
+	apitypes "github.com/obot-platform/obot/apiclient/types"
 	"github.com/obot-platform/obot/pkg/gateway/types"
 	"gorm.io/gorm"
 )
@@ -23,6 +25,8 @@ var (
 		"decision":   "decision",
 		"device_id":  "device_id",
 		"client_ip":  "client_ip",
+		"source":     "source",
+		"user_id":    "user_id",
 	}
 
 	// enforcementDecisionFilterColumns maps a UI filter key to its
@@ -34,10 +38,11 @@ var (
 		"server":   "server_name",
 		"decision": "decision",
 		"actor":    "device_id",
+		"user":     "user_id",
 	}
 
 	enforcementDecisionQueryColumns = []string{
-		"agent", "tool", "kind", "server_name", "decision", "reason", "device_id", "client_ip",
+		"agent", "tool", "kind", "server_name", "decision", "reason", "device_id", "user_id", "client_ip",
 		"server_url", "server_hostname", "server_command", "server_package_source",
 		"server_package_name", "server_package_version", "server_connector",
 		"unresolved_reason",
@@ -46,7 +51,9 @@ var (
 
 type EnforcementDecisionOptions struct {
 	MDMConfigurationID []uint
+	Source             []string // device | llm_gateway
 	Actor              []string // device_id
+	User               []string // user_id
 	Agent              []string
 	Server             []string // server_name
 	Tool               []string
@@ -90,11 +97,19 @@ func (c *Client) applyEnforcementDecisionFilters(db *gorm.DB, opts EnforcementDe
 	if len(opts.MDMConfigurationID) > 0 {
 		db = db.Where("mdm_configuration_id IN ?", opts.MDMConfigurationID)
 	}
+	if len(opts.Source) > 0 {
+		// Rows recorded before decisions had a source are device decisions.
+		if slices.Contains(opts.Source, apitypes.EnforcementDecisionSourceDevice) {
+			db = db.Where("(source IN ? OR source = ?)", opts.Source, "")
+		} else {
+			db = db.Where("source IN ?", opts.Source)
+		}
+	}
 	for _, filter := range []struct {
 		column string
 		values []string
 	}{
-		{"device_id", opts.Actor}, {"agent", opts.Agent}, {"server_name", opts.Server},
+		{"device_id", opts.Actor}, {"user_id", opts.User}, {"agent", opts.Agent}, {"server_name", opts.Server},
 		{"tool", opts.Tool}, {"kind", opts.Kind}, {"decision", opts.Decision},
 	} {
 		if len(filter.values) > 0 {
@@ -135,3 +135,46 @@ func TestEnforcementDecisionFilteredRead(t *testing.T) {
 		t.Fatal("expected invalid sort key to be rejected")
 	}
 }
+
+func TestEnforcementDecisionSourceFilter(t *testing.T) {
+	c := newTestClient(t)
+
+	c.LogEnforcementDecision(sampleEnforcementDecision(apitypes.EnforcementDecisionAllow, "claude_code"))
+	gateway := sampleEnforcementDecision(apitypes.EnforcementDecisionDeny, "claude_code")
+	gateway.Source = apitypes.EnforcementDecisionSourceLLMGateway
+	gateway.MDMConfigurationID = 0
+	gateway.DeviceID = ""
+	gateway.UserID = "hosted-agent:hai1abc"
+	c.LogEnforcementDecision(gateway)
+	if err := c.persistEnforcementDecisions(); err != nil {
+		t.Fatalf("persist enforcement decisions: %v", err)
+	}
+	// A row recorded before decisions had a source counts as a device decision.
+	if err := c.db.WithContext(t.Context()).Create(&types.EnforcementDecisionLog{
+		CreatedAt: time.Now().UTC(),
+		Decision:  apitypes.EnforcementDecisionAllow,
+	}).Error; err != nil {
+		t.Fatalf("create legacy row: %v", err)
+	}
+
+	devices, total, err := c.GetEnforcementDecisions(t.Context(), EnforcementDecisionOptions{
+		Source: []string{apitypes.EnforcementDecisionSourceDevice},
+	})
+	if err != nil {
+		t.Fatalf("filter by device source: %v", err)
+	}
+	if total != 2 || len(devices) != 2 {
+		t.Fatalf("expected two device rows, got total=%d %#v", total, devices)
+	}
+
+	gateways, total, err := c.GetEnforcementDecisions(t.Context(), EnforcementDecisionOptions{
+		Source: []string{apitypes.EnforcementDecisionSourceLLMGateway},
+		User:   []string{"hosted-agent:hai1abc"},
+	})
+	if err != nil {
+		t.Fatalf("filter by gateway source: %v", err)
+	}
+	if total != 1 || len(gateways) != 1 || gateways[0].Decision != apitypes.EnforcementDecisionDeny {
+		t.Fatalf("expected the gateway deny row, got total=%d %#v", total, gateways)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"log/slog"
 	"time"
 
+	apitypes "github.com/obot-platform/obot/apiclient/types"
 	"github.com/obot-platform/obot/pkg/gateway/types"
 )
 
@@ -17,6 +18,12 @@ func (c *Client) LogEnforcementDecision(entry types.EnforcementDecisionLog) {
 	if entry.CreatedAt.IsZero() {
 		entry.CreatedAt = time.Now().UTC()
 	}
+	if entry.Source == "" {
+		entry.Source = apitypes.EnforcementDecisionSourceDevice
+	}
+	if entry.Decision == apitypes.EnforcementDecisionDeny {
+		c.notifyToolCallDenied(entry)
+	}
 
 	c.enforcementLock.Lock()
 	defer c.enforcementLock.Unlock()
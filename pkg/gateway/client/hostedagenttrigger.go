@@ -0,0 +1,76 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/obot-platform/obot/pkg/gateway/types"
+	"gorm.io/gorm"
+)
+
+// hostedAgentTriggerInvocationsKept bounds each trigger's activity log. A
+// trigger on a one-minute schedule would otherwise add half a million rows a
+// year, and nobody reads that far back.
+const hostedAgentTriggerInvocationsKept = 1000
+
+// RecordHostedAgentTriggerInvocation appends to a trigger's activity log and
+// drops whatever falls beyond the retained window.
+func (c *Client) RecordHostedAgentTriggerInvocation(ctx context.Context, invocation *types.HostedAgentTriggerInvocation) error {
+	return c.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(invocation).Error; err != nil {
+			return fmt.Errorf("failed to record hosted agent trigger invocation: %w", err)
+		}
+
+		var oldest types.HostedAgentTriggerInvocation
+		err := tx.Where("trigger_id = ?", invocation.TriggerID).
+			Order("id DESC").
+			Offset(hostedAgentTriggerInvocationsKept).
+			Limit(1).
+			Take(&oldest).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("failed to find expired hosted agent trigger invocations: %w", err)
+		}
+		if err := tx.Where("trigger_id = ? AND id <= ?", invocation.TriggerID, oldest.ID).
+			Delete(&types.HostedAgentTriggerInvocation{}).Error; err != nil {
+			return fmt.Errorf("failed to delete expired hosted agent trigger invocations: %w", err)
+		}
+		return nil
+	})
+}
+
+// ListHostedAgentTriggerInvocations returns a trigger's activity log, newest
+// first, along with the total number of entries.
+func (c *Client) ListHostedAgentTriggerInvocations(ctx context.Context, triggerID string, limit, offset int) ([]types.HostedAgentTriggerInvocation, int64, error) {
+	db := c.db.WithContext(ctx).Model(&types.HostedAgentTriggerInvocation{}).Where("trigger_id = ?", triggerID)
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count hosted agent trigger invocations: %w", err)
+	}
+
+	if limit > 0 {
+		db = db.Limit(limit)
+	}
+	if offset > 0 {
+		db = db.Offset(offset)
+	}
+
+	var invocations []types.HostedAgentTriggerInvocation
+	if err := db.Order("id DESC").Find(&invocations).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to list hosted agent trigger invocations: %w", err)
+	}
+	return invocations, total, nil
+}
+
+// DeleteHostedAgentTriggerInvocations removes a trigger's activity log when the
+// trigger itself is deleted.
+func (c *Client) DeleteHostedAgentTriggerInvocations(ctx context.Context, triggerID string) error {
+	if err := c.db.WithContext(ctx).Where("trigger_id = ?", triggerID).
+		Delete(&types.HostedAgentTriggerInvocation{}).Error; err != nil {
+		return fmt.Errorf("failed to delete hosted agent trigger invocations: %w", err)
+	}
+	return nil
+}
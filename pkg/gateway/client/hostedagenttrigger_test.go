@@ -0,0 +1,45 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/obot-platform/obot/pkg/gateway/types"
+)
+
+func TestRecordHostedAgentTriggerInvocationKeepsNewest(t *testing.T) {
+	client := newTestClient(t)
+	for i := range hostedAgentTriggerInvocationsKept + 5 {
+		if err := client.RecordHostedAgentTriggerInvocation(t.Context(), &types.HostedAgentTriggerInvocation{
+			TriggerID:  "hat1a",
+			StatusCode: 200 + i,
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := client.RecordHostedAgentTriggerInvocation(t.Context(), &types.HostedAgentTriggerInvocation{
+		TriggerID: "hat1b",
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	invocations, total, err := client.ListHostedAgentTriggerInvocations(t.Context(), "hat1a", 1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != hostedAgentTriggerInvocationsKept {
+		t.Fatalf("total = %d, want %d", total, hostedAgentTriggerInvocationsKept)
+	}
+	if len(invocations) != 1 || invocations[0].StatusCode != 200+hostedAgentTriggerInvocationsKept+4 {
+		t.Fatalf("newest invocation = %#v", invocations)
+	}
+
+	if err := client.DeleteHostedAgentTriggerInvocations(t.Context(), "hat1a"); err != nil {
+		t.Fatal(err)
+	}
+	if _, total, err = client.ListHostedAgentTriggerInvocations(t.Context(), "hat1a", 0, 0); err != nil || total != 0 {
+		t.Fatalf("after delete total = %d, err = %v", total, err)
+	}
+	if _, total, err = client.ListHostedAgentTriggerInvocations(t.Context(), "hat1b", 0, 0); err != nil || total != 1 {
+		t.Fatalf("other trigger total = %d, err = %v", total, err)
+	}
+}
@@ -357,6 +357,7 @@ func (c *Client) persistLLMAuditLogs(entries []llmAuditEntry) error {
 		logs[i] = entry.log
 		logs[i].CreatedAt = logs[i].CreatedAt.UTC()
 		aggregateLLMAuditResponse(&logs[i], entry.responseStream)
+		c.redactLLMAuditLog(ctx, &logs[i])
 	}
 	return c.insertLLMAuditLogs(ctx, logs)
 }
@@ -429,6 +430,8 @@ func (c *Client) encryptLLMAuditLog(ctx context.Context, log *types.LLMAuditLog)
 		encryptRawMessageField(ctx, transformer, dataCtx, &log.PolicyModifiedRequestBody),
 		encryptRawMessageField(ctx, transformer, dataCtx, &log.ResponseHeaders),
 		encryptRawMessageField(ctx, transformer, dataCtx, &log.ResponseBody),
+		encryptRawMessageField(ctx, transformer, dataCtx, &log.UnredactedRequestPayload),
+		encryptRawMessageField(ctx, transformer, dataCtx, &log.UnredactedResponsePayload),
 	}
 	log.Encrypted = true
 
@@ -457,6 +460,23 @@ func (c *Client) decryptLLMAuditLog(ctx context.Context, log *types.LLMAuditLog)
 	return errors.Join(errs...)
 }
 
+func (c *Client) decryptLLMAuditLogUnredactedPayloads(ctx context.Context, log *types.LLMAuditLog) error {
+	if !log.Encrypted || c.encryptionConfig == nil {
+		return nil
+	}
+
+	transformer := c.encryptionConfig.Transformers[llmAuditLogGroupResource]
+	if transformer == nil {
+		return nil
+	}
+
+	dataCtx := llmAuditLogDataCtx(log)
+	return errors.Join(
+		decryptRawMessageField(ctx, transformer, dataCtx, &log.UnredactedRequestPayload),
+		decryptRawMessageField(ctx, transformer, dataCtx, &log.UnredactedResponsePayload),
+	)
+}
+
 func llmAuditLogDataCtx(log *types.LLMAuditLog) value.Context {
 	return value.DefaultContext(fmt.Sprintf("%s/%s/%s", llmAuditLogGroupResource.String(), log.ID, log.UserID))
 }
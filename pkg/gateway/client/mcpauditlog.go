@@ -72,6 +72,9 @@ var (
 		"local_agent_error", "hostname", "local_username", "reported_user_email", "cwd",
 		"git_root", "git_remotes", "git_branch", "transcript_path",
 		"local_agent_request_body", "local_agent_response_body", "local_agent_raw_event",
+		// Retained unredacted payloads. GORM never reads these, but listing them keeps the omitted
+		// set explicit.
+		"unredacted_request_payload", "unredacted_response_payload",
 	}
 
 	// mcpAuditLogHeaderColumns hold MCP request/response headers. The list view drops them, but the
@@ -263,6 +266,12 @@ func (c *Client) insertMCPAuditLogs(ctx context.Context, logs []types.MCPAuditLo
 				if responseMCP.ResponseStatus != 0 {
 					updates["response_status"] = responseMCP.ResponseStatus
 				}
+				if responseLog.ContentRedacted {
+					updates["content_redacted"] = true
+				}
+				if len(responseLog.UnredactedResponsePayload) > 0 {
+					updates["unredacted_response_payload"] = responseLog.UnredactedResponsePayload
+				}
 				if responseMCP.Error != "" {
 					updates["error"] = responseMCP.Error
 				}
@@ -324,6 +333,7 @@ func (c *Client) InsertLocalAgentAuditLogs(ctx context.Context, logs []types.MCP
 		if err := log.ValidateSourceFields(); err != nil {
 			return fmt.Errorf("invalid local agent audit log source fields: %w", err)
 		}
+		c.redactMCPAuditLog(ctx, &log)
 		if err := c.encryptMCPAuditLog(ctx, &log); err != nil {
 			return fmt.Errorf("failed to encrypt local agent audit log: %w", err)
 		}
@@ -1128,12 +1138,31 @@ func (c *Client) GetMCPUsageStats(ctx context.Context, opts MCPUsageStatsOptions
 		return types.MCPUsageStatsList{}, err
 	}
 
+	// Quotas are reported for their current windows, whatever the time range.
+	quotaOpts := MCPQuotaUsageOptions{UserIDs: opts.UserIDs}
+	if len(opts.PowerUserWorkspaceID) > 0 || len(opts.OwnServerMCPIDs) > 0 {
+		quotaOpts.MCPIDs = opts.OwnServerMCPIDs
+		quotaOpts.ScopeToMCPIDs = true
+	}
+	if opts.MCPID != "" {
+		if quotaOpts.ScopeToMCPIDs && !slices.Contains(quotaOpts.MCPIDs, opts.MCPID) {
+			quotaOpts.MCPIDs = nil
+		} else {
+			quotaOpts.MCPIDs = []string{opts.MCPID}
+		}
+	}
+	quotas, err := c.GetMCPQuotaUsage(ctx, quotaOpts, time.Now())
+	if err != nil {
+		return types.MCPUsageStatsList{}, err
+	}
+
 	return types.MCPUsageStatsList{
 		TimeStart:   opts.StartTime,
 		TimeEnd:     opts.EndTime,
 		TotalCalls:  callsAndUsers.TotalCalls,
 		UniqueUsers: callsAndUsers.UniqueUsers,
 		Items:       stats,
+		Quotas:      quotas,
 	}, nil
 }
 
@@ -1180,6 +1209,11 @@ func (c *Client) encryptMCPAuditLog(ctx context.Context, log *types.MCPAuditLog)
 		)
 	}
 
+	errs = append(errs,
+		encryptRawMessageField(ctx, transformer, dataCtx, &log.UnredactedRequestPayload),
+		encryptRawMessageField(ctx, transformer, dataCtx, &log.UnredactedResponsePayload),
+	)
+
 	log.Encrypted = true
 
 	return errors.Join(errs...)
@@ -1230,6 +1264,22 @@ func (c *Client) decryptMCPAuditLog(ctx context.Context, log *types.MCPAuditLog)
 	return errors.Join(errs...)
 }
 
+func (c *Client) decryptMCPAuditLogUnredactedPayloads(ctx context.Context, log *types.MCPAuditLog) error {
+	if !log.Encrypted || c.encryptionConfig == nil {
+		return nil
+	}
+	transformer := c.encryptionConfig.Transformers[mcpAuditLogGroupResource]
+	if transformer == nil {
+		return nil
+	}
+
+	dataCtx := mcpAuditLogDataCtx(log)
+	return errors.Join(
+		decryptRawMessageField(ctx, transformer, dataCtx, &log.UnredactedRequestPayload),
+		decryptRawMessageField(ctx, transformer, dataCtx, &log.UnredactedResponsePayload),
+	)
+}
+
 func encryptRawMessageField(ctx context.Context, transformer value.Transformer, dataCtx value.Context, field *json.RawMessage) error {
 	if len(*field) == 0 {
 		return nil
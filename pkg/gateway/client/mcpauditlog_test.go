@@ -1191,6 +1191,7 @@ func TestOmitMCPAuditLogSensitiveFieldsExcludesExactlyThePayloadColumns(t *testi
 		"local_agent_error", "hostname", "local_username", "reported_user_email",
 		"cwd", "git_root", "git_remotes", "git_branch", "transcript_path",
 		"local_agent_request_body", "local_agent_response_body", "local_agent_raw_event",
+		"unredacted_request_payload", "unredacted_response_payload",
 	}
 	headers := []string{"request_headers", "response_headers"}
 
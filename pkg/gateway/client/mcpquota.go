@@ -0,0 +1,175 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	types2 "github.com/obot-platform/obot/apiclient/types"
+	"github.com/obot-platform/obot/pkg/gateway/types"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	mcpQuotaUsageCleanupInterval = time.Hour
+	// mcpQuotaUsageRetention is how long counters are kept after their window
+	// starts. Only the current windows are read, so this just has to outlast a
+	// day window.
+	mcpQuotaUsageRetention = 48 * time.Hour
+)
+
+// MCPQuotaCounter identifies one quota that an MCP call counts against.
+type MCPQuotaCounter struct {
+	RuleID   string
+	Caller   string
+	UserID   string
+	MCPID    string
+	ToolName string
+	Window   types2.MCPQuotaWindow
+	Limit    int
+}
+
+// MCPQuotaExceededError is returned when a call would exceed a quota.
+type MCPQuotaExceededError struct {
+	Counter  MCPQuotaCounter
+	ResetsAt time.Time
+}
+
+func (e *MCPQuotaExceededError) Error() string {
+	return fmt.Sprintf("MCP call quota of %d calls per %s exceeded", e.Counter.Limit, e.Counter.Window)
+}
+
+// TakeMCPQuota counts one call against each of the counters. If the call would
+// exceed any of them, none are counted and an *MCPQuotaExceededError is
+// returned for the quota that resets last.
+func (c *Client) TakeMCPQuota(ctx context.Context, counters []MCPQuotaCounter, now time.Time) error {
+	if len(counters) == 0 {
+		return nil
+	}
+
+	var exceeded *MCPQuotaExceededError
+	err := c.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, counter := range counters {
+			ok, err := takeMCPQuota(tx, counter, now)
+			if err != nil {
+				return err
+			}
+			if ok {
+				continue
+			}
+			resetsAt := types.MCPQuotaWindowEnd(counter.Window, now)
+			if exceeded == nil || resetsAt.After(exceeded.ResetsAt) {
+				exceeded = &MCPQuotaExceededError{Counter: counter, ResetsAt: resetsAt}
+			}
+		}
+		if exceeded != nil {
+			// Roll back the counters this call was added to.
+			return exceeded
+		}
+		return nil
+	})
+	if exceeded != nil && errors.Is(err, exceeded) {
+		return exceeded
+	}
+	if err != nil {
+		return fmt.Errorf("failed to take MCP quota: %w", err)
+	}
+	return nil
+}
+
+// takeMCPQuota adds one to the counter, and returns false if that would exceed
+// its limit.
+func takeMCPQuota(tx *gorm.DB, counter MCPQuotaCounter, now time.Time) (bool, error) {
+	windowStart := types.MCPQuotaWindowStart(counter.Window, now)
+	increment := func() (bool, error) {
+		result := tx.Model(&types.MCPQuotaUsage{}).
+			Where("rule_id = ? AND caller = ? AND mcp_id = ? AND tool_name = ? AND quota_window = ? AND window_start = ?",
+				counter.RuleID, counter.Caller, counter.MCPID, counter.ToolName, string(counter.Window), windowStart).
+			Where("count < ?", counter.Limit).
+			Updates(map[string]any{
+				"count":      gorm.Expr("count + 1"),
+				"call_limit": counter.Limit,
+				"updated_at": now,
+			})
+		return result.RowsAffected > 0, result.Error
+	}
+
+	if ok, err := increment(); ok || err != nil {
+		return ok, err
+	}
+	if counter.Limit < 1 {
+		return false, nil
+	}
+
+	result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&types.MCPQuotaUsage{
+		UpdatedAt:   now,
+		RuleID:      counter.RuleID,
+		Caller:      counter.Caller,
+		MCPID:       counter.MCPID,
+		ToolName:    counter.ToolName,
+		Window:      string(counter.Window),
+		WindowStart: windowStart,
+		UserID:      counter.UserID,
+		Count:       1,
+		Limit:       counter.Limit,
+	})
+	if result.Error != nil || result.RowsAffected > 0 {
+		return result.Error == nil, result.Error
+	}
+
+	// The counter exists, either because it is at its limit or because a
+	// concurrent call created it first.
+	return increment()
+}
+
+// MCPQuotaUsageOptions filters the quotas returned by GetMCPQuotaUsage.
+type MCPQuotaUsageOptions struct {
+	MCPIDs  []string
+	UserIDs []string
+	// ScopeToMCPIDs limits the results to MCPIDs, even if it is empty.
+	ScopeToMCPIDs bool
+}
+
+// GetMCPQuotaUsage returns the consumption of quotas in their current windows.
+func (c *Client) GetMCPQuotaUsage(ctx context.Context, opts MCPQuotaUsageOptions, now time.Time) ([]types.MCPQuotaUsage, error) {
+	if opts.ScopeToMCPIDs && len(opts.MCPIDs) == 0 {
+		return nil, nil
+	}
+
+	db := c.db.WithContext(ctx).
+		Where("(quota_window = ? AND window_start = ?) OR (quota_window = ? AND window_start = ?)",
+			string(types2.MCPQuotaWindowMinute), types.MCPQuotaWindowStart(types2.MCPQuotaWindowMinute, now),
+			string(types2.MCPQuotaWindowDay), types.MCPQuotaWindowStart(types2.MCPQuotaWindowDay, now))
+	if len(opts.MCPIDs) > 0 {
+		db = db.Where("mcp_id IN (?)", opts.MCPIDs)
+	}
+	if len(opts.UserIDs) > 0 {
+		db = db.Where("user_id IN (?)", opts.UserIDs)
+	}
+
+	var usage []types.MCPQuotaUsage
+	return usage, db.Order("mcp_id, rule_id, caller, tool_name, quota_window").Find(&usage).Error
+}
+
+func (c *Client) runMCPQuotaUsageCleanup(ctx context.Context) {
+	ticker := time.NewTicker(mcpQuotaUsageCleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := c.deleteOldMCPQuotaUsage(ctx, now.UTC()); err != nil && !errors.Is(err, context.Canceled) {
+				slog.Error("Failed to delete old MCP quota usage", "error", err)
+			}
+		}
+	}
+}
+
+func (c *Client) deleteOldMCPQuotaUsage(ctx context.Context, now time.Time) error {
+	return c.db.WithContext(ctx).Where("window_start < ?", now.Add(-mcpQuotaUsageRetention)).Delete(new(types.MCPQuotaUsage)).Error
+}
@@ -0,0 +1,64 @@
+package client
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	types2 "github.com/obot-platform/obot/apiclient/types"
+)
+
+func TestTakeMCPQuota(t *testing.T) {
+	client := newTestClient(t)
+	now := time.Date(2026, 10, 19, 12, 30, 15, 0, time.UTC)
+
+	minute := MCPQuotaCounter{RuleID: "mqr1a", Caller: "user/1", UserID: "1", MCPID: "ms1abc", Window: types2.MCPQuotaWindowMinute, Limit: 2}
+	day := minute
+	day.Window = types2.MCPQuotaWindowDay
+	day.Limit = 3
+	counters := []MCPQuotaCounter{minute, day}
+
+	for i := range 2 {
+		if err := client.TakeMCPQuota(t.Context(), counters, now); err != nil {
+			t.Fatalf("call %d: unexpected error: %v", i, err)
+		}
+	}
+
+	err := client.TakeMCPQuota(t.Context(), counters, now)
+	exceeded, ok := errors.AsType[*MCPQuotaExceededError](err)
+	if !ok {
+		t.Fatalf("expected a quota exceeded error, got %v", err)
+	}
+	if exceeded.Counter.Window != types2.MCPQuotaWindowMinute || !exceeded.ResetsAt.Equal(time.Date(2026, 10, 19, 12, 31, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected exceeded quota: %+v", exceeded)
+	}
+
+	// The rejected call is not counted against the day quota, so one more call
+	// fits in the next minute.
+	if err := client.TakeMCPQuota(t.Context(), counters, now.Add(time.Minute)); err != nil {
+		t.Fatalf("unexpected error in the next minute: %v", err)
+	}
+	err = client.TakeMCPQuota(t.Context(), counters, now.Add(2*time.Minute))
+	if exceeded, ok = errors.AsType[*MCPQuotaExceededError](err); !ok || exceeded.Counter.Window != types2.MCPQuotaWindowDay {
+		t.Fatalf("expected the day quota to be exceeded, got %v", err)
+	}
+
+	usage, err := client.GetMCPQuotaUsage(t.Context(), MCPQuotaUsageOptions{MCPIDs: []string{"ms1abc"}}, now.Add(2*time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(usage) != 1 || usage[0].Window != string(types2.MCPQuotaWindowDay) || usage[0].Count != 3 || usage[0].Limit != 3 {
+		t.Fatalf("unexpected quota usage: %+v", usage)
+	}
+
+	if err := client.deleteOldMCPQuotaUsage(t.Context(), now.Add(72*time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	usage, err = client.GetMCPQuotaUsage(t.Context(), MCPQuotaUsageOptions{}, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(usage) != 0 {
+		t.Fatalf("expected old quota usage to be deleted, got %+v", usage)
+	}
+}
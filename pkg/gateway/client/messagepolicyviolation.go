@@ -6,9 +6,11 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/obot-platform/obot/pkg/gateway/types"
+	"github.com/obot-platform/obot/pkg/notification"
 	"gorm.io/gorm"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apiserver/pkg/storage/value"
@@ -80,6 +82,7 @@ func (c *Client) LogMessagePolicyViolation(ctx context.Context, v *types.Message
 		return fmt.Errorf("failed to insert policy violation: %w", err)
 	}
 
+	notification.EmitAsync(c.storageClient, strconv.FormatUint(uint64(v.ID), 10), messagePolicyViolationEvent(v))
 	return nil
 }
 
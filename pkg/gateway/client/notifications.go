@@ -0,0 +1,129 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	apitypes "github.com/obot-platform/obot/apiclient/types"
+	"github.com/obot-platform/obot/pkg/gateway/types"
+	"github.com/obot-platform/obot/pkg/notification"
+)
+
+// tokenLimitNearFraction is the fraction of a daily token limit remaining at
+// which the user is reported as nearing the limit.
+const tokenLimitNearFraction = 0.2
+
+// messagePolicyViolationEvent identifies the violation without copying any of
+// its content. Notifications are delivered in plaintext, so subscribers look
+// the violation up by ID to see the explanation.
+func messagePolicyViolationEvent(v *types.MessagePolicyViolation) apitypes.NotificationEvent {
+	return apitypes.NotificationEvent{
+		Type:       apitypes.NotificationEventMessagePolicyViolation,
+		Time:       *apitypes.NewTime(v.CreatedAt),
+		Summary:    fmt.Sprintf("Message policy %q blocked a %s message", v.PolicyName, v.Direction),
+		UserID:     v.UserID,
+		ResourceID: v.PolicyID,
+		Details: map[string]string{
+			"violationID": strconv.FormatUint(uint64(v.ID), 10),
+			"policyName":  v.PolicyName,
+		},
+	}
+}
+
+func (c *Client) notifyToolCallDenied(entry types.EnforcementDecisionLog) {
+	details := map[string]string{
+		"source": entry.Source,
+		"agent":  entry.Agent,
+		"tool":   entry.Tool,
+		"server": entry.ServerName,
+		"reason": entry.Reason,
+	}
+	if entry.DeviceID != "" {
+		details["deviceID"] = entry.DeviceID
+	}
+	if entry.ServerURL != "" {
+		details["serverURL"] = entry.ServerURL
+	}
+	if entry.Unresolved {
+		details["unresolvedReason"] = entry.UnresolvedReason
+	}
+
+	notification.EmitAsync(c.storageClient, "", apitypes.NotificationEvent{
+		Type:       apitypes.NotificationEventToolCallDenied,
+		Time:       *apitypes.NewTime(entry.CreatedAt),
+		Summary:    fmt.Sprintf("Tool call %s on %s was denied", entry.Tool, entry.ServerName),
+		UserID:     entry.UserID,
+		ResourceID: entry.ServerName,
+		Details:    details,
+	})
+}
+
+// knownMCPServerConfigHashes returns which of the hashes an earlier device scan
+// has reported.
+func (c *Client) knownMCPServerConfigHashes(ctx context.Context, hashes []string) (map[string]bool, error) {
+	known := make(map[string]bool, len(hashes))
+	if len(hashes) == 0 {
+		return known, nil
+	}
+
+	var found []string
+	if err := c.db.WithContext(ctx).Model(&types.DeviceScanMCPServer{}).
+		Where("config_hash IN ?", hashes).
+		Distinct().
+		Pluck("config_hash", &found).Error; err != nil {
+		return nil, err
+	}
+	for _, hash := range found {
+		known[hash] = true
+	}
+	return known, nil
+}
+
+func (c *Client) notifyUnknownMCPServers(scan *types.DeviceScan, known map[string]bool) {
+	for _, server := range scan.MCPServers {
+		if server.ConfigHash == "" || known[server.ConfigHash] {
+			continue
+		}
+		// Only report a configuration once, even if a scan lists it twice.
+		known[server.ConfigHash] = true
+
+		notification.EmitAsync(c.storageClient, server.ConfigHash, apitypes.NotificationEvent{
+			Type:       apitypes.NotificationEventUnknownMCPServer,
+			Time:       *apitypes.NewTime(scan.CreatedAt),
+			Summary:    fmt.Sprintf("Device %s reported an unknown MCP server %q", scan.Hostname, server.Name),
+			UserID:     scan.SubmittedBy,
+			ResourceID: server.ConfigHash,
+			Details: map[string]string{
+				"deviceID":  scan.DeviceID,
+				"client":    server.Client,
+				"name":      server.Name,
+				"transport": server.Transport,
+				"command":   server.Command,
+				"url":       server.URL,
+			},
+		})
+	}
+}
+
+// notifyTokenLimitNear reports a user with little of a daily token limit left,
+// at most once per user, dimension, and day.
+func (c *Client) notifyTokenLimitNear(userID, dimension string, limit, remaining int) {
+	if limit <= 0 || float64(remaining) > float64(limit)*tokenLimitNearFraction {
+		return
+	}
+
+	day := time.Now().UTC().Format(time.DateOnly)
+	notification.EmitAsync(c.storageClient, userID+"/"+dimension+"/"+day, apitypes.NotificationEvent{
+		Type:       apitypes.NotificationEventTokenLimitNear,
+		Summary:    fmt.Sprintf("User has %d of their %d daily %s tokens left", max(remaining, 0), limit, dimension),
+		UserID:     userID,
+		ResourceID: userID,
+		Details: map[string]string{
+			"dimension": dimension,
+			"limit":     strconv.Itoa(limit),
+			"remaining": strconv.Itoa(remaining),
+		},
+	})
+}
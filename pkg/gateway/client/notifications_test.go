@@ -0,0 +1,40 @@
+package client
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/obot-platform/obot/pkg/gateway/types"
+)
+
+func TestMessagePolicyViolationEventOmitsContent(t *testing.T) {
+	t.Parallel()
+
+	event := messagePolicyViolationEvent(&types.MessagePolicyViolation{
+		ID:                   42,
+		CreatedAt:            time.Now(),
+		UserID:               "7",
+		PolicyID:             "mp1",
+		PolicyName:           "no-secrets",
+		Direction:            "user-message",
+		ViolationExplanation: "the message contained the password hunter2",
+		BlockedContent:       json.RawMessage(`"hunter2"`),
+	})
+
+	if got := event.Details["violationID"]; got != "42" {
+		t.Fatalf("violationID = %q, want 42", got)
+	}
+	if got := event.Details["policyName"]; got != "no-secrets" {
+		t.Fatalf("policyName = %q, want no-secrets", got)
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "hunter2") {
+		t.Fatalf("event leaks violation content: %s", data)
+	}
+}
@@ -89,6 +89,12 @@ func (c *Client) RemainingTokenUsageForUser(ctx context.Context, userID string,
 	// (only meaningful for limited dimensions; ignore it when the Unlimited flag is set).
 	r.InputTokens = inputLimit - activity[0].Usage.InputTokens
 	r.OutputTokens = outputLimit - activity[0].Usage.OutputTokens
+	if !inputUnlimited {
+		c.notifyTokenLimitNear(userID, "input", inputLimit, r.InputTokens)
+	}
+	if !outputUnlimited {
+		c.notifyTokenLimitNear(userID, "output", outputLimit, r.OutputTokens)
+	}
 
 	return r, nil
 }
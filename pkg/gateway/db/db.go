@@ -23,6 +23,50 @@ func New(db *gorm.DB, sqlDB *sql.DB, autoMigrate bool) (*DB, error) {
 	}, nil
 }
 
+// Models returns the gateway's tables, one value of each model type, in the
+// order they are migrated.
+func Models() []any {
+	return []any{
+		types.AuthToken{},
+		types.TokenRequest{},
+		types.LLMAuditLog{},
+		types.User{},
+		types.Identity{},
+		types.Group{},
+		types.GroupMemberships{},
+		types.GroupRoleAssignment{},
+		types.APIActivity{},
+		types.Image{},
+		types.RunTokenActivity{},
+		types.MCPOAuthToken{},
+		types.MCPOAuthPendingState{},
+		types.MCPAuditLog{},
+		types.TempSetupUser{},
+		types.Property{},
+		types.APIKey{},
+		types.ServiceAccountAPIKey{},
+		types.MessagePolicyViolation{},
+		types.DeviceScan{},
+		types.DeviceScanMCPServer{},
+		types.DeviceScanSkill{},
+		types.DeviceScanPlugin{},
+		types.DeviceScanFile{},
+		types.DeviceScanClient{},
+		types.MDMAssetBundle{},
+		types.MDMConfiguration{},
+		types.MDMConfigurationArtifact{},
+		types.DeviceEnrollmentKey{},
+		types.Device{},
+		types.Credential{},
+		types.LocalAuthUser{},
+		types.LocalAuthSession{},
+		types.EnforcementDecisionLog{},
+		types.HostedAgentTriggerInvocation{},
+		types.ConfigChange{},
+		types.MCPQuotaUsage{},
+	}
+}
+
 func (db *DB) AutoMigrate() (err error) {
 	if !db.autoMigrate {
 		return nil
@@ -100,42 +144,7 @@ func (db *DB) AutoMigrate() (err error) {
 		return fmt.Errorf("failed to migrate API key skills access: %w", err)
 	}
 
-	if err := tx.AutoMigrate(
-		types.AuthToken{},
-		types.TokenRequest{},
-		types.LLMAuditLog{},
-		types.User{},
-		types.Identity{},
-		types.Group{},
-		types.GroupMemberships{},
-		types.GroupRoleAssignment{},
-		types.APIActivity{},
-		types.Image{},
-		types.RunTokenActivity{},
-		types.MCPOAuthToken{},
-		types.MCPOAuthPendingState{},
-		types.MCPAuditLog{},
-		types.TempSetupUser{},
-		types.Property{},
-		types.APIKey{},
-		types.ServiceAccountAPIKey{},
-		types.MessagePolicyViolation{},
-		types.DeviceScan{},
-		types.DeviceScanMCPServer{},
-		types.DeviceScanSkill{},
-		types.DeviceScanPlugin{},
-		types.DeviceScanFile{},
-		types.DeviceScanClient{},
-		types.MDMAssetBundle{},
-		types.MDMConfiguration{},
-		types.MDMConfigurationArtifact{},
-		types.DeviceEnrollmentKey{},
-		types.Device{},
-		types.Credential{},
-		types.LocalAuthUser{},
-		types.LocalAuthSession{},
-		types.EnforcementDecisionLog{},
-	); err != nil {
+	if err := tx.AutoMigrate(Models()...); err != nil {
 		return fmt.Errorf("failed to auto migrate gateway types: %w", err)
 	}
 
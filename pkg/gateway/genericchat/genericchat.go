@@ -0,0 +1,220 @@
+// Package genericchat implements the built-in model provider for self-hosted
+// services that speak the OpenAI Chat Completions API, such as vLLM, Ollama and
+// llama.cpp. Unlike the providers from the provider registry, it has no daemon:
+// models are discovered and configuration is validated from within the Obot
+// process by calling the service's models endpoint directly.
+package genericchat
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	nanobottypes "github.com/obot-platform/nanobot/pkg/types"
+	"github.com/obot-platform/obot/apiclient/types"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	"github.com/obot-platform/obot/pkg/system"
+)
+
+const (
+	BaseURLEnv = "OBOT_GENERIC_CHAT_COMPLETIONS_MODEL_PROVIDER_BASE_URL"
+	APIKeyEnv  = "OBOT_GENERIC_CHAT_COMPLETIONS_MODEL_PROVIDER_API_KEY"
+	HeadersEnv = "OBOT_GENERIC_CHAT_COMPLETIONS_MODEL_PROVIDER_HEADERS"
+
+	discoveryTimeout = 30 * time.Second
+)
+
+// Model is an entry discovered from the upstream models endpoint, in the shape
+// model provider daemons report models in.
+type Model struct {
+	ID       string            `json:"id"`
+	Object   string            `json:"object"`
+	OwnedBy  string            `json:"owned_by,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+type transport struct {
+	key     string
+	headers http.Header
+	next    http.RoundTripper
+}
+
+func IsProvider(providerName string) bool {
+	return providerName == system.GenericChatCompletionsModelProvider
+}
+
+// ModelProvider returns the ModelProvider resource for the built-in provider.
+// It has no Command: the dispatcher serves it from within the Obot process.
+func ModelProvider() *v1.ModelProvider {
+	return &v1.ModelProvider{
+		Name:      system.GenericChatCompletionsModelProvider,
+		Namespace: system.DefaultNamespace,
+		Spec: v1.ModelProviderSpec{
+			ModelProviderManifest: types.ModelProviderManifest{
+				CommonProviderMetadata: types.CommonProviderMetadata{
+					Name:        "Generic Chat Completions Compatible",
+					Description: "Connect any service that implements the OpenAI Chat Completions API, such as vLLM, Ollama or llama.cpp.",
+					RequiredConfigurationParameters: []types.ProviderConfigurationParameter{
+						{
+							Name:         BaseURLEnv,
+							FriendlyName: "Base URL",
+							Description:  "Base URL of the Chat Completions API, for example http://vllm.internal:8000/v1. Models are discovered from its /models endpoint.",
+						},
+					},
+					OptionalConfigurationParameters: []types.ProviderConfigurationParameter{
+						{
+							Name:         APIKeyEnv,
+							FriendlyName: "API Key",
+							Description:  "Sent as a bearer token. Leave empty for services that do not require authentication.",
+							Sensitive:    true,
+						},
+						{
+							Name:         HeadersEnv,
+							FriendlyName: "Custom Headers",
+							Description:  "Additional headers to send upstream, one \"Name: value\" pair per line.",
+							Sensitive:    true,
+							Multiline:    true,
+						},
+					},
+				},
+				Dialect: string(nanobottypes.DialectOpenAIChatCompletions),
+			},
+		},
+	}
+}
+
+// BaseURL returns the configured upstream base URL without a trailing slash.
+func BaseURL(credentials map[string]string) (url.URL, error) {
+	rawURL := strings.TrimSpace(credentials[BaseURLEnv])
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return url.URL{}, fmt.Errorf("failed to parse Generic Chat Completions base URL: %w", err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return url.URL{}, fmt.Errorf("generic Chat Completions base URL must be an absolute HTTP or HTTPS URL")
+	}
+	u.Path = strings.TrimRight(u.Path, "/")
+	return *u, nil
+}
+
+// Headers parses the configured custom headers. Blank lines and lines starting
+// with # are ignored.
+func Headers(credentials map[string]string) (http.Header, error) {
+	headers := http.Header{}
+	for i, line := range strings.Split(credentials[HeadersEnv], "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		name, value, ok := strings.Cut(line, ":")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" || strings.ContainsAny(name, " \t") {
+			return nil, fmt.Errorf("invalid custom header on line %d: expected \"Name: value\"", i+1)
+		}
+		headers.Add(name, strings.TrimSpace(value))
+	}
+	return headers, nil
+}
+
+// Transport authenticates requests to the upstream service. Any credentials
+// the client sent to Obot are removed first.
+func Transport(credentials map[string]string, next http.RoundTripper) (http.RoundTripper, error) {
+	headers, err := Headers(credentials)
+	if err != nil {
+		return nil, err
+	}
+	return transport{
+		key:     strings.TrimSpace(credentials[APIKeyEnv]),
+		headers: headers,
+		next:    next,
+	}, nil
+}
+
+func (t transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req.Header.Del("Authorization")
+	req.Header.Del("X-Api-Key")
+	for name, values := range t.headers {
+		req.Header[name] = values
+	}
+	if t.key != "" {
+		req.Header.Set("Authorization", "Bearer "+t.key)
+	}
+	return t.next.RoundTrip(req)
+}
+
+// ListModels discovers the models served by the upstream service.
+func ListModels(ctx context.Context, credentials map[string]string) ([]Model, error) {
+	u, err := BaseURL(credentials)
+	if err != nil {
+		return nil, err
+	}
+	if u.Path == "" {
+		u.Path = "/v1"
+	}
+
+	rt, err := Transport(credentials, http.DefaultTransport)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, discoveryTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.JoinPath("models").String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create models request: %w", err)
+	}
+
+	resp, err := (&http.Client{Transport: rt}).Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list models: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return nil, fmt.Errorf("failed to list models: %s: %s", resp.Status, strings.TrimSpace(string(message)))
+	}
+
+	var list struct {
+		Data []struct {
+			ID      string `json:"id"`
+			OwnedBy string `json:"owned_by"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		return nil, fmt.Errorf("failed to decode model list: %w", err)
+	}
+
+	models := make([]Model, 0, len(list.Data))
+	for _, m := range list.Data {
+		if m.ID == "" {
+			continue
+		}
+		models = append(models, Model{
+			ID:      m.ID,
+			Object:  "model",
+			OwnedBy: m.OwnedBy,
+			Metadata: map[string]string{
+				"usage":   string(usage(m.ID)),
+				"dialect": string(nanobottypes.DialectOpenAIChatCompletions),
+			},
+		})
+	}
+	return models, nil
+}
+
+// usage guesses a model's usage from its ID, since the Chat Completions models
+// endpoint doesn't report it. Servers like vLLM and Ollama serve embedding
+// models from the same endpoint, and those must not be offered as LLMs.
+func usage(id string) types.ModelUsage {
+	if strings.Contains(strings.ToLower(id), "embed") {
+		return types.ModelUsageEmbedding
+	}
+	return types.ModelUsageLLM
+}
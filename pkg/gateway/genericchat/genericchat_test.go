@@ -0,0 +1,131 @@
+package genericchat
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/obot-platform/obot/apiclient/types"
+)
+
+func TestBaseURL(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		baseURL string
+		want    string
+		wantErr bool
+	}{
+		{name: "configured", baseURL: "http://vllm.internal:8000/v1/", want: "http://vllm.internal:8000/v1"},
+		{name: "no path", baseURL: " http://localhost:11434 ", want: "http://localhost:11434"},
+		{name: "missing", wantErr: true},
+		{name: "relative", baseURL: "localhost:11434/v1", wantErr: true},
+		{name: "unsupported scheme", baseURL: "ftp://models.example/v1", wantErr: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := BaseURL(map[string]string{BaseURLEnv: tt.baseURL})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("BaseURL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && u.String() != tt.want {
+				t.Errorf("BaseURL() = %q, want %q", u.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestHeaders(t *testing.T) {
+	headers, err := Headers(map[string]string{HeadersEnv: "X-Tenant: team-a\n\n# comment\nX-Route:  gpu-pool \n"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if headers.Get("X-Tenant") != "team-a" || headers.Get("X-Route") != "gpu-pool" || len(headers) != 2 {
+		t.Errorf("unexpected headers: %v", headers)
+	}
+
+	for _, invalid := range []string{"X-Tenant", ": value", "X Tenant: value"} {
+		if _, err := Headers(map[string]string{HeadersEnv: invalid}); err == nil {
+			t.Errorf("expected an error for %q", invalid)
+		}
+	}
+}
+
+func TestListModels(t *testing.T) {
+	var got *http.Request
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		if r.URL.Path != "/v1/models" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`{"object":"list","data":[
+			{"id":"meta-llama/Llama-3.1-8B-Instruct","object":"model","owned_by":"vllm"},
+			{"id":"nomic-embed-text","object":"model"},
+			{"id":""}
+		]}`))
+	}))
+	defer srv.Close()
+
+	models, err := ListModels(context.Background(), map[string]string{
+		BaseURLEnv: srv.URL,
+		APIKeyEnv:  "secret",
+		HeadersEnv: "X-Tenant: team-a",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if auth := got.Header.Get("Authorization"); auth != "Bearer secret" {
+		t.Errorf("Authorization = %q", auth)
+	}
+	if tenant := got.Header.Get("X-Tenant"); tenant != "team-a" {
+		t.Errorf("X-Tenant = %q", tenant)
+	}
+	if len(models) != 2 {
+		t.Fatalf("expected 2 models, got %+v", models)
+	}
+	if models[0].Metadata["usage"] != string(types.ModelUsageLLM) || models[0].Metadata["dialect"] != "OpenAIChatCompletions" {
+		t.Errorf("unexpected metadata for %s: %v", models[0].ID, models[0].Metadata)
+	}
+	if models[1].Metadata["usage"] != string(types.ModelUsageEmbedding) {
+		t.Errorf("embedding model usage = %q", models[1].Metadata["usage"])
+	}
+}
+
+func TestListModelsUpstreamError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "invalid api key", http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	if _, err := ListModels(context.Background(), map[string]string{BaseURLEnv: srv.URL + "/v1"}); err == nil {
+		t.Fatal("expected an error for an unauthorized models request")
+	}
+}
+
+func TestTransportReplacesClientCredentials(t *testing.T) {
+	var got *http.Request
+	rt, err := Transport(map[string]string{}, roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		got = req
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "http://localhost:11434/v1/chat/completions", nil)
+	req.Header.Set("Authorization", "Bearer obot-key")
+	req.Header.Set("X-Api-Key", "obot-key")
+	if _, err := rt.RoundTrip(req); err != nil {
+		t.Fatal(err)
+	}
+	if got.Header.Get("Authorization") != "" || got.Header.Get("X-Api-Key") != "" {
+		t.Errorf("client credentials were forwarded: %v", got.Header)
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
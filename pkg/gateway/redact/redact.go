@@ -0,0 +1,289 @@
+// Package redact removes secrets and PII from audit payloads before they are persisted.
+package redact
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	types "github.com/obot-platform/obot/apiclient/types"
+)
+
+// FieldMaskPlaceholder replaces values selected by a field mask.
+const FieldMaskPlaceholder = "[REDACTED]"
+
+var (
+	customPatternNameRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,62}$`)
+
+	builtinPatterns = map[types.AuditRedactionDetector]*regexp.Regexp{
+		types.AuditRedactionDetectorPrivateKey: regexp.MustCompile(`-----BEGIN [A-Z0-9 ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z0-9 ]*PRIVATE KEY-----`),
+		types.AuditRedactionDetectorJWT:        regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{5,}\.eyJ[A-Za-z0-9_-]{5,}\.[A-Za-z0-9_-]{10,}`),
+		types.AuditRedactionDetectorAPIKey: regexp.MustCompile(strings.Join([]string{
+			`\bsk-[A-Za-z0-9_-]{20,}`,
+			`\bok1-[A-Za-z0-9_-]{20,}`,
+			`\bgh[pousr]_[A-Za-z0-9]{30,}`,
+			`\bgithub_pat_[A-Za-z0-9_]{22,}`,
+			`\bgl(?:pat|dt)-[A-Za-z0-9_-]{20,}`,
+			`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`,
+			`\bAIza[0-9A-Za-z_-]{35}`,
+			`\bxox[abposr]-[A-Za-z0-9-]{10,}`,
+			`(?i)\bbearer\s+[A-Za-z0-9._~+/-]{20,}=*`,
+		}, "|")),
+		types.AuditRedactionDetectorCreditCard: regexp.MustCompile(`\b(?:4\d|5[1-5]|2[2-7]|3[47]|6[05])\d{2}(?:[ -]?\d){9,15}\b`),
+		types.AuditRedactionDetectorEmail:      regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9-]+(?:\.[A-Za-z0-9-]+)*\.[A-Za-z]{2,}`),
+	}
+)
+
+type pattern struct {
+	name  string
+	regex *regexp.Regexp
+	// check further filters regex matches, such as the Luhn check for card numbers.
+	check func(string) bool
+}
+
+// Redactor applies a configured set of detectors, custom patterns, and field masks. A nil
+// Redactor leaves payloads unchanged.
+type Redactor struct {
+	patterns         []pattern
+	fieldMasks       [][]string
+	retainUnredacted bool
+}
+
+// Validate reports configuration errors without building a Redactor.
+func Validate(setting types.AuditRedactionSetting) error {
+	_, err := New(setting)
+	return err
+}
+
+// New builds a Redactor from setting. It returns nil when redaction is disabled.
+func New(setting types.AuditRedactionSetting) (*Redactor, error) {
+	for _, detector := range setting.DisabledDetectors {
+		if _, ok := builtinPatterns[detector]; !ok {
+			return nil, fmt.Errorf("unknown redaction detector %q", detector)
+		}
+	}
+	if setting.Disabled {
+		return nil, nil
+	}
+
+	r := &Redactor{
+		retainUnredacted: setting.RetainUnredacted,
+	}
+	for _, detector := range types.AuditRedactionDetectors {
+		if slices.Contains(setting.DisabledDetectors, detector) {
+			continue
+		}
+		p := pattern{name: string(detector), regex: builtinPatterns[detector]}
+		if detector == types.AuditRedactionDetectorCreditCard {
+			p.check = luhnValid
+		}
+		r.patterns = append(r.patterns, p)
+	}
+
+	names := map[string]struct{}{}
+	for _, custom := range setting.CustomPatterns {
+		if !customPatternNameRegex.MatchString(custom.Name) {
+			return nil, fmt.Errorf("invalid custom pattern name %q: must be lowercase letters, digits, '-' or '_'", custom.Name)
+		}
+		if _, ok := names[custom.Name]; ok {
+			return nil, fmt.Errorf("duplicate custom pattern name %q", custom.Name)
+		}
+		names[custom.Name] = struct{}{}
+
+		regex, err := regexp.Compile(custom.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern for %q: %w", custom.Name, err)
+		}
+		if regex.MatchString("") {
+			return nil, fmt.Errorf("pattern for %q must not match an empty string", custom.Name)
+		}
+		r.patterns = append(r.patterns, pattern{name: custom.Name, regex: regex})
+	}
+
+	for _, mask := range setting.FieldMasks {
+		segments := strings.Split(mask, ".")
+		if slices.Contains(segments, "") {
+			return nil, fmt.Errorf("invalid field mask %q", mask)
+		}
+		r.fieldMasks = append(r.fieldMasks, segments)
+	}
+
+	return r, nil
+}
+
+// RetainUnredacted reports whether the original payloads should be kept for privileged readers.
+func (r *Redactor) RetainUnredacted() bool {
+	return r != nil && r.retainUnredacted
+}
+
+// Redact returns payload with sensitive values replaced and whether anything was redacted. JSON
+// payloads are redacted value by value, including JSON documents encoded in string values, and
+// keep their structure. Other payloads are redacted as plain text and field masks do not apply.
+func (r *Redactor) Redact(payload json.RawMessage) (json.RawMessage, bool) {
+	if r == nil || len(payload) == 0 {
+		return payload, false
+	}
+
+	value, ok := decodeJSON(payload)
+	if !ok {
+		text, changed := r.redactText(string(payload))
+		if !changed {
+			return payload, false
+		}
+		return json.RawMessage(text), true
+	}
+
+	value, changed := r.redactValue(value, nil)
+	if !changed {
+		return payload, false
+	}
+	out, err := encodeJSON(value)
+	if err != nil {
+		// Never persist the original when the redacted document can't be encoded.
+		return json.RawMessage(`"` + FieldMaskPlaceholder + `"`), true
+	}
+	return out, true
+}
+
+func (r *Redactor) redactValue(value any, path []string) (any, bool) {
+	if len(path) > 0 && r.masked(path) {
+		return FieldMaskPlaceholder, true
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		var changed bool
+		for key, child := range v {
+			redacted, childChanged := r.redactValue(child, append(path, key))
+			if childChanged {
+				v[key] = redacted
+				changed = true
+			}
+		}
+		return v, changed
+	case []any:
+		var changed bool
+		for i, child := range v {
+			redacted, childChanged := r.redactValue(child, append(path, fmt.Sprint(i)))
+			if childChanged {
+				v[i] = redacted
+				changed = true
+			}
+		}
+		return v, changed
+	case string:
+		return r.redactString(v, path)
+	default:
+		return value, false
+	}
+}
+
+// redactString handles tool arguments and other JSON documents that are encoded as strings, so
+// field masks still apply inside them.
+func (r *Redactor) redactString(s string, path []string) (any, bool) {
+	if trimmed := strings.TrimSpace(s); strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[") {
+		if nested, ok := decodeJSON([]byte(trimmed)); ok {
+			nested, changed := r.redactValue(nested, path)
+			if !changed {
+				return s, false
+			}
+			if out, err := encodeJSON(nested); err == nil {
+				return string(out), true
+			}
+		}
+	}
+	return r.redactText(s)
+}
+
+func (r *Redactor) redactText(s string) (string, bool) {
+	var changed bool
+	for _, p := range r.patterns {
+		placeholder := "[REDACTED:" + p.name + "]"
+		s = p.regex.ReplaceAllStringFunc(s, func(match string) string {
+			if p.check != nil && !p.check(match) {
+				return match
+			}
+			changed = true
+			return placeholder
+		})
+	}
+	return s, changed
+}
+
+func (r *Redactor) masked(path []string) bool {
+	for _, mask := range r.fieldMasks {
+		if matchPath(mask, path) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchPath(mask, path []string) bool {
+	if len(mask) == 0 {
+		return len(path) == 0
+	}
+	if mask[0] == "**" {
+		for i := 0; i <= len(path); i++ {
+			if matchPath(mask[1:], path[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(path) == 0 || (mask[0] != "*" && mask[0] != path[0]) {
+		return false
+	}
+	return matchPath(mask[1:], path[1:])
+}
+
+func decodeJSON(data []byte) (any, bool) {
+	if !json.Valid(data) {
+		return nil, false
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var value any
+	if err := dec.Decode(&value); err != nil {
+		return nil, false
+	}
+	return value, true
+}
+
+func encodeJSON(value any) (json.RawMessage, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(value); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+func luhnValid(s string) bool {
+	var (
+		sum    int
+		digits int
+		double bool
+	)
+	for i := len(s) - 1; i >= 0; i-- {
+		c := s[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+		d := int(c - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		digits++
+		double = !double
+	}
+	return digits >= 13 && digits <= 19 && sum%10 == 0
+}
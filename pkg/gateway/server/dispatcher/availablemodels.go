@@ -8,10 +8,15 @@ import (
 	"net/http"
 
 	openai "github.com/obot-platform/chat-completion-client"
+	"github.com/obot-platform/obot/pkg/gateway/genericchat"
 	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
 )
 
 func (d *Dispatcher) ModelsForProvider(ctx context.Context, modelProvider v1.ModelProvider) (*openai.ModelsList, error) {
+	if genericchat.IsProvider(modelProvider.Name) {
+		return d.builtinModelsForProvider(ctx, modelProvider)
+	}
+
 	u, err := d.urlForModelProvider(ctx, providerKeyForModelProvider(modelProvider.Namespace, modelProvider.Name), modelProvider)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get URL for model provider %q: %w", modelProvider.Name, err)
@@ -43,3 +48,31 @@ func (d *Dispatcher) ModelsForProvider(ctx context.Context, modelProvider v1.Mod
 
 	return &oModels, nil
 }
+
+// builtinModelsForProvider discovers models for a model provider that runs
+// inside the Obot process rather than as a daemon.
+func (d *Dispatcher) builtinModelsForProvider(ctx context.Context, modelProvider v1.ModelProvider) (*openai.ModelsList, error) {
+	credEnv, err := d.builtinModelProviderEnv(ctx, modelProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	models, err := genericchat.ListModels(ctx, credEnv)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get model list from model provider %q: %w", modelProvider.Name, err)
+	}
+
+	// Round-trip through JSON so the result is decoded exactly as a daemon's
+	// response would be.
+	data, err := json.Marshal(map[string]any{"object": "list", "data": models})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode model list from model provider %q: %w", modelProvider.Name, err)
+	}
+
+	var oModels openai.ModelsList
+	if err = json.Unmarshal(data, &oModels); err != nil {
+		return nil, fmt.Errorf("failed to decode model list from model provider %q: %w", modelProvider.Name, err)
+	}
+
+	return &oModels, nil
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/obot-platform/obot/logger"
 	"github.com/obot-platform/obot/pkg/gateway/client"
+	"github.com/obot-platform/obot/pkg/gateway/genericchat"
 	"github.com/obot-platform/obot/pkg/license"
 	"github.com/obot-platform/obot/pkg/mcp"
 	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
@@ -150,10 +151,20 @@ func (d *Dispatcher) ValidateModelProvider(ctx context.Context, namespace, model
 		return fmt.Errorf("failed to get provider: %w", err)
 	}
 
+	if genericchat.IsProvider(modelProvider.Name) {
+		if _, err := genericchat.ListModels(ctx, env); err != nil {
+			return err
+		}
+		return nil
+	}
+
 	return d.runCommand(ctx, env, modelProvider.Spec.Command, modelProvider.Spec.ValidateArgs...)
 }
 
 func (d *Dispatcher) urlForModelProvider(ctx context.Context, key string, modelProvider v1.ModelProvider) (url.URL, error) {
+	if modelProvider.Spec.Command == "" {
+		return url.URL{}, fmt.Errorf("model provider %q runs inside Obot and does not have a daemon", modelProvider.Name)
+	}
 	if len(modelProvider.Status.MissingConfigurationParameters) > 0 {
 		return url.URL{}, fmt.Errorf("provider %q is not configured, missing configuration parameters: %s", modelProvider.Name, strings.Join(modelProvider.Status.MissingConfigurationParameters, ", "))
 	}
@@ -247,6 +258,20 @@ func CredentialEnvForAuthProvider(ctx context.Context, gatewayClient *client.Cli
 	return credentialEnvForProvider(ctx, gatewayClient, &authProvider, system.GenericAuthProviderCredentialContext)
 }
 
+// builtinModelProviderEnv returns the configuration of a model provider that
+// runs inside the Obot process.
+func (d *Dispatcher) builtinModelProviderEnv(ctx context.Context, modelProvider v1.ModelProvider) (map[string]string, error) {
+	if len(modelProvider.Status.MissingConfigurationParameters) > 0 {
+		return nil, fmt.Errorf("provider %q is not configured, missing configuration parameters: %s", modelProvider.Name, strings.Join(modelProvider.Status.MissingConfigurationParameters, ", "))
+	}
+
+	credEnv, err := CredentialEnvForModelProvider(ctx, d.gatewayClient, modelProvider)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reveal provider credential: %w", err)
+	}
+	return credEnv, nil
+}
+
 func CredentialEnvForModelProvider(ctx context.Context, gatewayClient *client.Client, modelProvider v1.ModelProvider) (map[string]string, error) {
 	return credentialEnvForProvider(ctx, gatewayClient, &modelProvider, system.GenericModelProviderCredentialContext)
 }
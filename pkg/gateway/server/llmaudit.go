@@ -284,6 +284,8 @@ func extractLLMReasoningEffort(modelProvider string, body []byte) string {
 		return gjson.GetBytes(body, "reasoning.effort").String()
 	case system.AnthropicModelProvider:
 		return gjson.GetBytes(body, "output_config.effort").String()
+	case system.GenericChatCompletionsModelProvider:
+		return gjson.GetBytes(body, "reasoning_effort").String()
 	default:
 		return ""
 	}
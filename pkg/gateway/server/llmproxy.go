@@ -70,18 +70,27 @@ type responseModifier struct {
 	// Input policy violation: replacement text to send back via response header.
 	inputPolicyReplacement string
 
+	// dropStreamUsage removes the usage chunk from a Chat Completions stream when
+	// the gateway, not the client, asked for it.
+	dropStreamUsage bool
+
 	// Output (tool-call) policy evaluation fields.
 	messagePolicyHelper *messagepolicy.Helper
 	outputPolicies      []messagepolicy.ApplicablePolicy
 	conversationHistory []messagepolicy.ConversationMessage
 	pipeReader          *io.PipeReader // set when output policies are active; Read() reads from this
 	audit               *llmAuditRecorder
+
+	// toolCallEnforcer replaces tool calls the tool-call enforcement allowlist
+	// denies. It is nil unless enforcement is enabled.
+	toolCallEnforcer *toolCallEnforcer
 }
 
 type preparedLLMProxyRequest struct {
 	body              []byte
 	model             string
 	tokenUsageTracker *threadSafeTokenUsageTracker
+	dropStreamUsage   bool
 }
 
 type llmProviderProxyBackend interface {
@@ -97,6 +106,7 @@ type llmProviderProxy struct {
 	modelProvider             *v1.ModelProvider
 	mapHelper                 *modelaccesspolicy.Helper
 	messagePolicyHelper       *messagepolicy.Helper
+	toolCallEnforcement       *toolCallEnforcement
 	lock                      sync.RWMutex
 }
 
@@ -205,9 +215,14 @@ func (r *responseModifier) modifyResponse(resp *http.Response) error {
 	r.stream = strings.Contains(resp.Header.Get("Content-Type"), "text/event-stream")
 	resp.Body = r
 
-	// When tool-call output policies are active, launch a goroutine that streams text
-	// through immediately while buffering tool call chunks for policy evaluation.
-	if len(r.outputPolicies) > 0 {
+	// When tool-call output policies or enforcement are active, launch a goroutine that
+	// streams text through immediately while buffering tool call chunks for evaluation.
+	if len(r.outputPolicies) > 0 || r.toolCallEnforcer != nil {
+		if !r.stream {
+			// The body may be rewritten, so the upstream length no longer holds.
+			resp.ContentLength = -1
+			resp.Header.Del("Content-Length")
+		}
 		pr, pw := io.Pipe()
 		r.pipeReader = pr
 		go r.streamAndEvaluateToolCalls(resp.Request.Context(), pw)
@@ -220,12 +235,12 @@ func shouldWrapLLMResponse(resp *http.Response) bool {
 	if resp.StatusCode != http.StatusOK {
 		return false
 	}
-	switch resp.Request.URL.Path {
-	case "/v1/messages", "/anthropic/v1/messages", "/v1/responses", "/openai/v1/responses":
-		return true
-	default:
-		return false
-	}
+	// Upstream base URLs may carry a path prefix, such as /openai/v1 or a
+	// self-hosted server's /api/v1, so match on the endpoint suffix.
+	p := resp.Request.URL.Path
+	return strings.HasSuffix(p, "/v1/messages") ||
+		strings.HasSuffix(p, "/v1/responses") ||
+		strings.HasSuffix(p, "/v1/chat/completions")
 }
 
 // filterModelListResponse rewrites a provider models-list response, dropping any
@@ -377,6 +392,9 @@ func (r *responseModifier) Read(p []byte) (int, error) {
 	}
 
 	r.tokenUsageTracker.addTokenUsage(line)
+	if r.dropStreamUsage && isChatCompletionsUsageChunk(line) {
+		return r.Read(p)
+	}
 
 	n := copy(p, prefix)
 	if n < len(prefix) {
@@ -418,17 +436,20 @@ func (r *responseModifier) streamAndEvaluateToolCalls(ctx context.Context, pw *i
 
 // streamAndEvaluateToolCallsSSE handles streaming (SSE) responses.
 // Text delta chunks are forwarded immediately; once the first tool_call chunk appears,
-// all remaining lines are buffered until the stream ends. After policy evaluation,
+// all remaining lines are buffered until the stream ends. Tool calls denied by tool-call
+// enforcement are then replaced with refusals. After policy evaluation,
 // the buffered lines (including tool calls) are forwarded unmodified, and a violation
 // marker is injected if a policy was violated. The downstream client (nanobot) detects
 // this marker and returns error tool_results instead of executing the tools.
 func (r *responseModifier) streamAndEvaluateToolCallsSSE(ctx context.Context, pw *io.PipeWriter) {
 	var (
 		buffered             [][]byte // all lines from the first tool_call onward, in original order
+		pending              [][]byte // event lines held back until their data line is seen
 		toolCalls            []messagepolicy.ToolCallInfo
 		seenToolCalls        bool
 		anthropicBlockToTool map[int]int // maps Anthropic content block index → toolCalls slice index
 		responsesItemToTool  map[int]int // maps Responses API output_index → toolCalls slice index
+		chatIndexToTool      map[int]int // maps Chat Completions tool_calls index → toolCalls slice index
 	)
 
 	for {
@@ -440,17 +461,24 @@ func (r *responseModifier) streamAndEvaluateToolCallsSSE(ctx context.Context, pw
 		// Once we see the first tool_call chunk, buffer everything remaining
 		// so we can evaluate policies before deciding what to send.
 		if seenToolCalls {
-			buffered = append(buffered, slices.Clone(line))
-
 			rest, isData := bytes.CutPrefix(line, []byte("data: "))
 			if isData {
 				r.tokenUsageTracker.addTokenUsage(rest)
+				if r.dropStreamUsage && isChatCompletionsUsageChunk(rest) {
+					if err != nil {
+						break
+					}
+					continue
+				}
 
 				// Anthropic format: content_block_start/content_block_delta
 				accumulateAnthropicToolCallInfo(rest, &toolCalls, anthropicBlockToTool)
 				// OpenAI Responses API format: response.output_item.added / response.function_call_arguments.delta
 				accumulateResponsesAPIToolCallInfo(rest, &toolCalls, responsesItemToTool)
+				// Chat Completions format: choices[].delta.tool_calls
+				accumulateChatCompletionsToolCallInfo(rest, &toolCalls, chatIndexToTool)
 			}
+			buffered = append(buffered, slices.Clone(line))
 
 			if err != nil {
 				break
@@ -460,7 +488,15 @@ func (r *responseModifier) streamAndEvaluateToolCallsSSE(ctx context.Context, pw
 
 		rest, isData := bytes.CutPrefix(line, []byte("data: "))
 		if !isData {
-			_, _ = pw.Write(line)
+			// Enforcement may rewrite the first tool call event, so its event
+			// line is held back until it is known which event it belongs to.
+			if r.toolCallEnforcer != nil && len(bytes.TrimSpace(line)) > 0 {
+				pending = append(pending, slices.Clone(line))
+			} else {
+				writeLines(pw, pending)
+				pending = nil
+				_, _ = pw.Write(line)
+			}
 			if err != nil {
 				break
 			}
@@ -468,6 +504,19 @@ func (r *responseModifier) streamAndEvaluateToolCallsSSE(ctx context.Context, pw
 		}
 
 		r.tokenUsageTracker.addTokenUsage(rest)
+		if r.dropStreamUsage && isChatCompletionsUsageChunk(rest) {
+			if err != nil {
+				break
+			}
+			continue
+		}
+
+		if isAnthropicToolCallEvent(rest) || isResponsesAPIToolCallEvent(rest) || isChatCompletionsToolCallEvent(rest) {
+			buffered = append(buffered, pending...)
+		} else {
+			writeLines(pw, pending)
+		}
+		pending = nil
 
 		if isAnthropicToolCallEvent(rest) {
 			// Anthropic-format tool calls (content_block_start with type "tool_use").
@@ -481,6 +530,12 @@ func (r *responseModifier) streamAndEvaluateToolCallsSSE(ctx context.Context, pw
 			responsesItemToTool = make(map[int]int)
 			buffered = append(buffered, slices.Clone(line))
 			accumulateResponsesAPIToolCallInfo(rest, &toolCalls, responsesItemToTool)
+		} else if isChatCompletionsToolCallEvent(rest) {
+			// OpenAI Chat Completions tool calls (choices[].delta.tool_calls).
+			seenToolCalls = true
+			chatIndexToTool = make(map[int]int)
+			buffered = append(buffered, slices.Clone(line))
+			accumulateChatCompletionsToolCallInfo(rest, &toolCalls, chatIndexToTool)
 		} else {
 			_, _ = pw.Write(line)
 		}
@@ -489,11 +544,15 @@ func (r *responseModifier) streamAndEvaluateToolCallsSSE(ctx context.Context, pw
 			break
 		}
 	}
+	writeLines(pw, pending)
 
-	if len(toolCalls) == 0 {
-		for _, line := range buffered {
-			_, _ = pw.Write(line)
-		}
+	if r.toolCallEnforcer != nil && len(toolCalls) > 0 {
+		buffered = r.toolCallEnforcer.enforceSSE(buffered)
+		toolCalls = accumulateToolCallInfo(buffered)
+	}
+
+	if len(toolCalls) == 0 || len(r.outputPolicies) == 0 {
+		writeLines(pw, buffered)
 		return
 	}
 
@@ -531,7 +590,8 @@ func (r *responseModifier) streamAndEvaluateToolCallsSSE(ctx context.Context, pw
 	violationLine := fmt.Sprintf("data: %s\n\n", violationJSON)
 
 	// Flush buffered lines, injecting the violation marker before the stream terminator.
-	// OpenAI Responses API ends with response.completed; Anthropic ends with content_block_stop.
+	// OpenAI Responses API ends with response.completed; Anthropic ends with content_block_stop;
+	// Chat Completions ends with [DONE].
 	injected := false
 	for _, line := range buffered {
 		if !injected {
@@ -541,7 +601,8 @@ func (r *responseModifier) streamAndEvaluateToolCallsSSE(ctx context.Context, pw
 				eventType := gjson.GetBytes(trimmed, "type").String()
 				isResponsesCompleted := eventType == "response.completed"
 				isAnthropicStop := eventType == "content_block_stop"
-				if isResponsesCompleted || isAnthropicStop {
+				isChatCompletionsDone := bytes.Equal(trimmed, []byte("[DONE]"))
+				if isResponsesCompleted || isAnthropicStop || isChatCompletionsDone {
 					_, _ = pw.Write([]byte(violationLine))
 					injected = true
 				}
@@ -562,10 +623,16 @@ func (r *responseModifier) streamAndEvaluateToolCallsJSON(ctx context.Context, p
 
 	r.tokenUsageTracker.addTokenUsage(body)
 
+	if r.toolCallEnforcer != nil {
+		body = r.toolCallEnforcer.enforceJSON(body)
+	}
+
 	// Anthropic format: content array with type "tool_use"
 	anthropicContent := gjson.GetBytes(body, "content")
 	// OpenAI Responses API format: output array with type "function_call"
 	responsesOutput := gjson.GetBytes(body, "output")
+	// OpenAI Chat Completions format: choices array with message.tool_calls
+	chatChoices := gjson.GetBytes(body, "choices")
 
 	var toolCalls []messagepolicy.ToolCallInfo
 	if responsesOutput.Exists() {
@@ -592,9 +659,20 @@ func (r *responseModifier) streamAndEvaluateToolCallsJSON(ctx context.Context, p
 			}
 			return true
 		})
+	} else if chatChoices.Exists() {
+		chatChoices.ForEach(func(_, choice gjson.Result) bool {
+			choice.Get("message.tool_calls").ForEach(func(_, call gjson.Result) bool {
+				toolCalls = append(toolCalls, messagepolicy.ToolCallInfo{
+					Name:      call.Get("function.name").String(),
+					Arguments: call.Get("function.arguments").String(),
+				})
+				return true
+			})
+			return true
+		})
 	}
 
-	if len(toolCalls) == 0 {
+	if len(toolCalls) == 0 || len(r.outputPolicies) == 0 {
 		_, _ = pw.Write(body)
 		return
 	}
@@ -637,6 +715,34 @@ func (r *responseModifier) streamAndEvaluateToolCallsJSON(ctx context.Context, p
 	_, _ = pw.Write(append(modified, '\n'))
 }
 
+// writeLines writes buffered SSE lines through in order.
+func writeLines(w io.Writer, lines [][]byte) {
+	for _, line := range lines {
+		_, _ = w.Write(line)
+	}
+}
+
+// accumulateToolCallInfo extracts the tool calls from buffered SSE lines, in
+// whichever format they are in.
+func accumulateToolCallInfo(lines [][]byte) []messagepolicy.ToolCallInfo {
+	var (
+		toolCalls            []messagepolicy.ToolCallInfo
+		anthropicBlockToTool = map[int]int{}
+		responsesItemToTool  = map[int]int{}
+		chatIndexToTool      = map[int]int{}
+	)
+	for _, line := range lines {
+		rest, isData := bytes.CutPrefix(line, []byte("data: "))
+		if !isData {
+			continue
+		}
+		accumulateAnthropicToolCallInfo(rest, &toolCalls, anthropicBlockToTool)
+		accumulateResponsesAPIToolCallInfo(rest, &toolCalls, responsesItemToTool)
+		accumulateChatCompletionsToolCallInfo(rest, &toolCalls, chatIndexToTool)
+	}
+	return toolCalls
+}
+
 // isAnthropicToolCallEvent checks if an SSE data payload is an Anthropic tool-call-related event
 // (content_block_start with type "tool_use", or content_block_delta with type "input_json_delta").
 func isAnthropicToolCallEvent(data []byte) bool {
@@ -698,6 +804,41 @@ func accumulateResponsesAPIToolCallInfo(data []byte, toolCalls *[]messagepolicy.
 	}
 }
 
+// isChatCompletionsToolCallEvent checks if an SSE data payload is an OpenAI Chat Completions chunk
+// carrying tool call deltas.
+func isChatCompletionsToolCallEvent(data []byte) bool {
+	for _, choice := range gjson.GetBytes(data, "choices").Array() {
+		if len(choice.Get("delta.tool_calls").Array()) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// accumulateChatCompletionsToolCallInfo extracts tool call name/arguments from OpenAI Chat Completions
+// SSE chunks. The first delta for a tool_calls index creates a new entry; later deltas for the same
+// index append partial arguments.
+func accumulateChatCompletionsToolCallInfo(data []byte, toolCalls *[]messagepolicy.ToolCallInfo, indexToTool map[int]int) {
+	if indexToTool == nil {
+		return
+	}
+	for _, choice := range gjson.GetBytes(data, "choices").Array() {
+		for _, call := range choice.Get("delta.tool_calls").Array() {
+			callIdx := int(call.Get("index").Int())
+			toolIdx, ok := indexToTool[callIdx]
+			if !ok {
+				toolIdx = len(*toolCalls)
+				indexToTool[callIdx] = toolIdx
+				*toolCalls = append(*toolCalls, messagepolicy.ToolCallInfo{})
+			}
+			if name := call.Get("function.name").String(); name != "" {
+				(*toolCalls)[toolIdx].Name = name
+			}
+			(*toolCalls)[toolIdx].Arguments += call.Get("function.arguments").String()
+		}
+	}
+}
+
 // buildToolCallTargetMessage formats tool calls into the target message string for the policy judge.
 // logViolation persists a policy violation record. Failures are logged but non-fatal.
 func logViolation(ctx context.Context, c *client.Client, v messagepolicy.MessagePolicyViolation, userID, direction string, blockedContent json.RawMessage, projectID, threadID string) {
@@ -880,6 +1021,20 @@ func parseMessagesFromBody(rawMessages []any) ([]messagepolicy.ConversationMessa
 			Content: content,
 		}
 
+		// OpenAI Chat Completions: assistant tool_calls and tool results.
+		if toolCalls, ok := msg["tool_calls"].([]any); ok {
+			for _, rawCall := range toolCalls {
+				call, _ := rawCall.(map[string]any)
+				function, _ := call["function"].(map[string]any)
+				name, _ := function["name"].(string)
+				arguments, _ := function["arguments"].(string)
+				cm.ToolCalls = append(cm.ToolCalls, messagepolicy.ToolCallInfo{Name: name, Arguments: arguments})
+			}
+		}
+		if role == "tool" {
+			cm.ToolCallID, _ = msg["tool_call_id"].(string)
+		}
+
 		history = append(history, cm)
 
 		if role == "user" && content != "" {
@@ -939,6 +1094,7 @@ func (s *Server) newLLMProviderProxy(u *url.URL, modelProviderName string) *llmP
 		backend:                   apiKeyLLMProviderBackend{u: *u, providerName: modelProviderName},
 		mapHelper:                 s.mapHelper,
 		messagePolicyHelper:       s.messagePolicyHelper,
+		toolCallEnforcement:       s.toolCallEnforcement,
 	}
 }
 
@@ -1027,6 +1183,9 @@ func (l *llmProviderProxy) proxy(req api.Context) (retErr error) {
 		if err != nil {
 			return fmt.Errorf("failed to rewrite model in request body: %w", err)
 		}
+		if routeDialect == nanobottypes.DialectOpenAIChatCompletions {
+			prepared.body, prepared.dropStreamUsage = requestChatCompletionsStreamUsage(prepared.body)
+		}
 		audit.setRequestBody(prepared.body)
 	}
 
@@ -1084,6 +1243,11 @@ func (l *llmProviderProxy) proxy(req api.Context) (retErr error) {
 		return err
 	}
 
+	enforcer, err := newToolCallEnforcer(req, l.toolCallEnforcement)
+	if err != nil {
+		return err
+	}
+
 	modifier := &responseModifier{
 		user:                   req.User,
 		model:                  prepared.model,
@@ -1093,9 +1257,11 @@ func (l *llmProviderProxy) proxy(req api.Context) (retErr error) {
 		tokenUsageTracker:      prepared.tokenUsageTracker,
 		mapHelper:              l.mapHelper,
 		inputPolicyReplacement: inputPolicyReplacement,
+		dropStreamUsage:        prepared.dropStreamUsage,
 		messagePolicyHelper:    messagePolicyHelper,
 		outputPolicies:         outputPolicies,
 		conversationHistory:    conversationHistory,
+		toolCallEnforcer:       enforcer,
 		audit:                  audit,
 	}
 
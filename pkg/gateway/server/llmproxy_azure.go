@@ -24,6 +24,7 @@ func (s *Server) newAzureLLMProviderProxy(providerName string) *llmProviderProxy
 		backend:                   &azureProviderBackend{providerName: providerName},
 		mapHelper:                 s.mapHelper,
 		messagePolicyHelper:       s.messagePolicyHelper,
+		toolCallEnforcement:       s.toolCallEnforcement,
 	}
 }
 
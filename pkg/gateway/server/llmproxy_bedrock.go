@@ -25,6 +25,7 @@ func (s *Server) newAWSBedrockLLMProviderProxy() *llmProviderProxy {
 		backend:                   bedrockMantleProviderBackend{providerName: system.AmazonBedrockModelProvider},
 		mapHelper:                 s.mapHelper,
 		messagePolicyHelper:       s.messagePolicyHelper,
+		toolCallEnforcement:       s.toolCallEnforcement,
 	}
 }
 
@@ -35,6 +36,7 @@ func (s *Server) newAWSBedrockAPIKeyLLMProviderProxy() *llmProviderProxy {
 		backend:                   bedrockMantleProviderBackend{providerName: system.AmazonBedrockAPIKeyModelProvider, apiKey: true},
 		mapHelper:                 s.mapHelper,
 		messagePolicyHelper:       s.messagePolicyHelper,
+		toolCallEnforcement:       s.toolCallEnforcement,
 	}
 }
 
@@ -0,0 +1,396 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+
+	nanobottypes "github.com/obot-platform/nanobot/pkg/types"
+	types2 "github.com/obot-platform/obot/apiclient/types"
+	"github.com/obot-platform/obot/pkg/api"
+	"github.com/obot-platform/obot/pkg/gateway/chatcompletions"
+	"github.com/obot-platform/obot/pkg/modelaccesspolicy"
+	"github.com/obot-platform/obot/pkg/principal"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	"github.com/obot-platform/obot/pkg/system"
+	"github.com/tidwall/sjson"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const anthropicVersion = "2023-06-01"
+
+// chatCompletionsProxy serves an OpenAI Chat Completions endpoint in front of
+// every provider passthrough. Requests are translated to the dialect of the
+// requested model and sent through that provider's proxy, so model access,
+// message policies, token accounting and audit logging apply exactly as they
+// do on the native routes. Responses are translated back on the way out.
+type chatCompletionsProxy struct {
+	mapHelper *modelaccesspolicy.Helper
+	proxies   map[string]*llmProviderProxy
+}
+
+// chatCompletionsTranslation describes how requests for models of one dialect
+// are translated. Models that already speak Chat Completions are passed
+// through with only the model rewritten.
+type chatCompletionsTranslation struct {
+	path         string
+	passthrough  bool
+	toUpstream   func(chatcompletions.Request, string) ([]byte, error)
+	fromUpstream func([]byte) ([]byte, error)
+	newStream    func(includeUsage bool) chatcompletions.StreamTranslator
+}
+
+type chatCompletionsModel struct {
+	ID      string `json:"id"`
+	Object  string `json:"object"`
+	Created int64  `json:"created"`
+	OwnedBy string `json:"owned_by"`
+}
+
+type chatCompletionsModelList struct {
+	Object string                 `json:"object"`
+	Data   []chatCompletionsModel `json:"data"`
+}
+
+func (s *Server) newChatCompletionsProxy(proxies ...*llmProviderProxy) *chatCompletionsProxy {
+	byProvider := make(map[string]*llmProviderProxy, len(proxies))
+	for _, proxy := range proxies {
+		byProvider[proxy.backend.modelProviderName()] = proxy
+	}
+	return &chatCompletionsProxy{
+		mapHelper: s.mapHelper,
+		proxies:   byProvider,
+	}
+}
+
+func chatCompletionsTranslationFor(dialect nanobottypes.Dialect) (chatCompletionsTranslation, bool) {
+	switch dialect {
+	case nanobottypes.DialectAnthropicMessages:
+		return chatCompletionsTranslation{
+			path:         "v1/messages",
+			toUpstream:   chatcompletions.ToAnthropicMessages,
+			fromUpstream: chatcompletions.FromAnthropicMessages,
+			newStream: func(includeUsage bool) chatcompletions.StreamTranslator {
+				return chatcompletions.NewAnthropicStream(includeUsage)
+			},
+		}, true
+	case nanobottypes.DialectOpenAIResponses, nanobottypes.DialectOpenResponses:
+		return chatCompletionsTranslation{
+			path:         "v1/responses",
+			toUpstream:   chatcompletions.ToResponses,
+			fromUpstream: chatcompletions.FromResponses,
+			newStream: func(includeUsage bool) chatcompletions.StreamTranslator {
+				return chatcompletions.NewResponsesStream(includeUsage)
+			},
+		}, true
+	case nanobottypes.DialectOpenAIChatCompletions:
+		return chatCompletionsTranslation{
+			path:        "v1/chat/completions",
+			passthrough: true,
+		}, true
+	default:
+		return chatCompletionsTranslation{}, false
+	}
+}
+
+// chatCompletionsTranslationForModel is the translation for the model, or a
+// bad request error saying why the model cannot be served as chat completions.
+// Bedrock is reached through Mantle, which only serves the Anthropic Messages
+// and OpenAI Responses APIs, so its models cannot be passed through.
+func chatCompletionsTranslationForModel(manifest types2.ModelManifest) (chatCompletionsTranslation, error) {
+	translation, ok := chatCompletionsTranslationFor(nanobottypes.Dialect(manifest.Dialect))
+	if !ok {
+		return chatCompletionsTranslation{}, types2.NewErrBadRequest("model %q uses dialect %q, which cannot be served as chat completions", manifest.TargetModel, manifest.Dialect)
+	}
+	if translation.passthrough && (manifest.ModelProvider == system.AmazonBedrockModelProvider || manifest.ModelProvider == system.AmazonBedrockAPIKeyModelProvider) {
+		return chatCompletionsTranslation{}, types2.NewErrBadRequest("model %q uses dialect %q, which Amazon Bedrock cannot serve as chat completions", manifest.TargetModel, manifest.Dialect)
+	}
+	return translation, nil
+}
+
+func (c *chatCompletionsProxy) chatCompletions(req api.Context) error {
+	body, err := copyBody(&req.Request.Body)
+	if err != nil {
+		return fmt.Errorf("failed to copy body: %w", err)
+	}
+
+	var request chatcompletions.Request
+	if err := json.Unmarshal(body, &request); err != nil {
+		return writeChatCompletionsError(req, types2.NewErrBadRequest("invalid request body: %v", err))
+	}
+
+	model, proxy, err := c.resolveModel(req, request.Model)
+	if err != nil {
+		return writeChatCompletionsError(req, err)
+	}
+
+	translation, err := chatCompletionsTranslationForModel(model.Spec.Manifest)
+	if err != nil {
+		return writeChatCompletionsError(req, err)
+	}
+
+	// The provider proxy resolves the model again from the translated body, so
+	// refer to it by resource name to get the same model back.
+	var upstreamBody []byte
+	if translation.passthrough {
+		upstreamBody, err = sjson.SetBytes(body, "model", model.Name)
+	} else {
+		upstreamBody, err = translation.toUpstream(request, model.Name)
+	}
+	if err != nil {
+		return writeChatCompletionsError(req, types2.NewErrBadRequest("failed to translate request: %v", err))
+	}
+
+	upstreamReq := req.Request.Clone(req.Context())
+	upstreamReq.SetPathValue("path", translation.path)
+	upstreamReq.Body = io.NopCloser(bytes.NewReader(upstreamBody))
+	upstreamReq.ContentLength = int64(len(upstreamBody))
+	upstreamReq.Header.Del("Content-Length")
+	upstreamReq.Header.Set("Content-Type", "application/json")
+	if translation.path == "v1/messages" && upstreamReq.Header.Get("Anthropic-Version") == "" {
+		upstreamReq.Header.Set("Anthropic-Version", anthropicVersion)
+	}
+
+	upstream := req
+	upstream.Request = upstreamReq
+	if translation.passthrough {
+		// The upstream response, errors included, is already in the format
+		// Chat Completions clients expect.
+		if err := proxy.proxy(upstream); err != nil {
+			return writeChatCompletionsError(req, err)
+		}
+		return nil
+	}
+
+	w := &chatCompletionsResponseWriter{
+		w:            req.ResponseWriter,
+		header:       http.Header{},
+		fromUpstream: translation.fromUpstream,
+		stream:       translation.newStream(request.IncludeUsage()),
+	}
+	upstream.ResponseWriter = w
+	if err := proxy.proxy(upstream); err != nil && w.status == 0 {
+		return writeChatCompletionsError(req, err)
+	}
+	w.finish()
+
+	return nil
+}
+
+// resolveModel finds the model a chat completions request refers to, along
+// with the proxy for its provider. Models can be referred to by the ids listed
+// by the models endpoint ("<provider>/<target model>"), by default model alias
+// or by resource name.
+func (c *chatCompletionsProxy) resolveModel(req api.Context, reference string) (*v1.Model, *llmProviderProxy, error) {
+	if reference == "" {
+		return nil, nil, types2.NewErrBadRequest("model is required")
+	}
+
+	var provider, target = "", reference
+	if p, t, ok := strings.Cut(reference, "/"); ok && c.proxies[p] != nil {
+		provider, target = p, t
+	}
+
+	model, err := c.mapHelper.ResolveModelReference(req.Context(), req.Storage, system.DefaultNamespace, provider, target)
+	if apierrors.IsNotFound(err) {
+		return nil, nil, types2.NewErrNotFound("model %q not found", reference)
+	} else if err != nil {
+		return nil, nil, types2.NewErrBadRequest("failed to resolve model %q: %v", reference, err)
+	}
+
+	proxy := c.proxies[model.Spec.Manifest.ModelProvider]
+	if proxy == nil {
+		return nil, nil, types2.NewErrBadRequest("model %q is served by model provider %q, which is not supported by this endpoint", reference, model.Spec.Manifest.ModelProvider)
+	}
+
+	return model, proxy, nil
+}
+
+// models lists the models the caller may use through the chat completions
+// endpoint, using the same authority the inference path applies.
+func (c *chatCompletionsProxy) models(req api.Context) error {
+	allowed := func(string) bool { return true }
+	if agentModels, isAgent := principal.AuthorizedModelIDs(req.User); isAgent {
+		allowed = func(modelID string) bool { return modelAllowedForAgent(agentModels, modelID) }
+	} else {
+		allowedModels, allowAll, err := c.mapHelper.GetUserAllowedModels(req.User)
+		if err != nil {
+			return fmt.Errorf("failed to determine accessible models: %w", err)
+		}
+		if !allowAll {
+			allowed = func(modelID string) bool { return allowedModels[modelID] }
+		}
+	}
+
+	var models v1.ModelList
+	if err := req.Storage.List(req.Context(), &models, kclient.InNamespace(system.DefaultNamespace)); err != nil {
+		return fmt.Errorf("failed to list models: %w", err)
+	}
+
+	list := chatCompletionsModelList{
+		Object: "list",
+		Data:   make([]chatCompletionsModel, 0, len(models.Items)),
+	}
+	for _, model := range models.Items {
+		manifest := model.Spec.Manifest
+		if !model.DeletionTimestamp.IsZero() || !manifest.Active || manifest.TargetModel == "" ||
+			!modelaccesspolicy.IsAllowedModelUsage(manifest.Usage) || c.proxies[manifest.ModelProvider] == nil {
+			continue
+		}
+		if _, err := chatCompletionsTranslationForModel(manifest); err != nil {
+			continue
+		}
+		if !allowed(model.Name) {
+			continue
+		}
+		list.Data = append(list.Data, chatCompletionsModel{
+			ID:      manifest.ModelProvider + "/" + manifest.TargetModel,
+			Object:  "model",
+			Created: model.CreationTimestamp.Unix(),
+			OwnedBy: manifest.ModelProvider,
+		})
+	}
+	slices.SortFunc(list.Data, func(a, b chatCompletionsModel) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	return req.Write(list)
+}
+
+// writeChatCompletionsError writes err in the error format OpenAI clients
+// expect. Errors that are not HTTP errors are returned to be handled as usual.
+func writeChatCompletionsError(req api.Context, err error) error {
+	var errHTTP *types2.ErrHTTP
+	if !errors.As(err, &errHTTP) {
+		return err
+	}
+	req.ResponseWriter.Header().Set("Content-Type", "application/json")
+	req.WriteHeader(errHTTP.Code)
+	_, _ = req.ResponseWriter.Write(chatcompletions.ErrorBody(errHTTP.Code, []byte(errHTTP.Message)))
+	return nil
+}
+
+// chatCompletionsResponseWriter translates the response the provider proxy
+// writes. Successful streams are translated event by event as they arrive;
+// other responses are buffered and translated once complete.
+type chatCompletionsResponseWriter struct {
+	w            http.ResponseWriter
+	header       http.Header
+	fromUpstream func([]byte) ([]byte, error)
+	stream       chatcompletions.StreamTranslator
+
+	status    int
+	streaming bool
+	pending   []byte
+	body      bytes.Buffer
+}
+
+func (c *chatCompletionsResponseWriter) Header() http.Header {
+	return c.header
+}
+
+func (c *chatCompletionsResponseWriter) WriteHeader(status int) {
+	if c.status != 0 {
+		return
+	}
+	c.status = status
+
+	for _, name := range []string{"X-Obot-Message-Policy-Replacement", "Retry-After", "Request-Id", "X-Request-Id"} {
+		if value := c.header.Get(name); value != "" {
+			c.w.Header().Set(name, value)
+		}
+	}
+
+	c.streaming = status == http.StatusOK && strings.Contains(c.header.Get("Content-Type"), "text/event-stream")
+	if c.streaming {
+		c.w.Header().Set("Content-Type", "text/event-stream")
+		c.w.Header().Set("Cache-Control", "no-cache")
+		c.w.WriteHeader(status)
+	}
+}
+
+func (c *chatCompletionsResponseWriter) Write(p []byte) (int, error) {
+	if c.status == 0 {
+		c.WriteHeader(http.StatusOK)
+	}
+	if !c.streaming {
+		return c.body.Write(p)
+	}
+
+	c.pending = append(c.pending, p...)
+	for {
+		i := bytes.IndexByte(c.pending, '\n')
+		if i < 0 {
+			break
+		}
+		line := c.pending[:i+1]
+		c.pending = c.pending[i+1:]
+		if err := c.translateLine(line); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+func (c *chatCompletionsResponseWriter) Flush() {
+	if f, ok := c.w.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (c *chatCompletionsResponseWriter) translateLine(line []byte) error {
+	data, ok := bytes.CutPrefix(bytes.TrimRight(line, "\r\n"), []byte("data:"))
+	if !ok {
+		// Event names, comments and blank separators are replaced by the
+		// translated events' own framing.
+		return nil
+	}
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || bytes.Equal(data, []byte("[DONE]")) {
+		return nil
+	}
+	if out := c.stream.Translate(data); len(out) > 0 {
+		_, err := c.w.Write(out)
+		return err
+	}
+	return nil
+}
+
+// finish translates a buffered response, or ends a stream.
+func (c *chatCompletionsResponseWriter) finish() {
+	if c.status == 0 {
+		return
+	}
+
+	if c.streaming {
+		if len(c.pending) > 0 {
+			_ = c.translateLine(c.pending)
+			c.pending = nil
+		}
+		_, _ = c.w.Write(c.stream.Finish())
+		c.Flush()
+		return
+	}
+
+	body := c.body.Bytes()
+	if c.status == http.StatusOK {
+		if translated, err := c.fromUpstream(body); err == nil {
+			body = translated
+		} else {
+			body = chatcompletions.ErrorBody(http.StatusBadGateway, []byte(err.Error()))
+			c.status = http.StatusBadGateway
+		}
+	} else {
+		body = chatcompletions.ErrorBody(c.status, body)
+	}
+
+	c.w.Header().Set("Content-Type", "application/json")
+	c.w.WriteHeader(c.status)
+	_, _ = c.w.Write(body)
+}
@@ -0,0 +1,107 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	nanobottypes "github.com/obot-platform/nanobot/pkg/types"
+	types2 "github.com/obot-platform/obot/apiclient/types"
+	"github.com/obot-platform/obot/pkg/system"
+	"github.com/tidwall/gjson"
+)
+
+func TestChatCompletionsResponseWriterStream(t *testing.T) {
+	translation, ok := chatCompletionsTranslationFor(nanobottypes.DialectAnthropicMessages)
+	if !ok {
+		t.Fatal("expected Anthropic Messages to be translatable")
+	}
+
+	rec := httptest.NewRecorder()
+	w := &chatCompletionsResponseWriter{
+		w:            rec,
+		header:       http.Header{},
+		fromUpstream: translation.fromUpstream,
+		stream:       translation.newStream(false),
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Content-Length", "1000")
+	w.WriteHeader(http.StatusOK)
+
+	// Events arrive split across writes and must be reassembled by line.
+	for _, part := range []string{
+		"event: message_start\ndata: {\"type\":\"message_start\",\"message\":{\"id\":\"msg_1\",\"model\":\"m\"}}\n\n",
+		"event: content_block_delta\ndata: {\"type\":\"content_block_delta\",\"index\":0,",
+		"\"delta\":{\"type\":\"text_delta\",\"text\":\"Hello\"}}\n\n",
+		"event: message_delta\ndata: {\"type\":\"message_delta\",\"delta\":{\"stop_reason\":\"end_turn\"}}\n\n",
+	} {
+		if _, err := w.Write([]byte(part)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	w.finish()
+
+	if got := rec.Header().Get("Content-Length"); got != "" {
+		t.Errorf("upstream Content-Length was forwarded: %s", got)
+	}
+	body := rec.Body.String()
+	if strings.Contains(body, "event:") {
+		t.Errorf("upstream event names were forwarded: %s", body)
+	}
+	if !strings.Contains(body, `"content":"Hello"`) || !strings.Contains(body, `"finish_reason":"stop"`) {
+		t.Errorf("unexpected translated stream: %s", body)
+	}
+	if !strings.HasSuffix(body, "data: [DONE]\n\n") {
+		t.Errorf("stream was not terminated: %s", body)
+	}
+}
+
+func TestChatCompletionsResponseWriterError(t *testing.T) {
+	translation, _ := chatCompletionsTranslationFor(nanobottypes.DialectOpenAIResponses)
+
+	rec := httptest.NewRecorder()
+	w := &chatCompletionsResponseWriter{
+		w:            rec,
+		header:       http.Header{},
+		fromUpstream: translation.fromUpstream,
+		stream:       translation.newStream(false),
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusTooManyRequests)
+	_, _ = w.Write([]byte(`{"error":{"message":"slow down","code":"rate_limit_exceeded"}}`))
+	w.finish()
+
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusTooManyRequests)
+	}
+	if got := gjson.Get(rec.Body.String(), "error.type").String(); got != "rate_limit_error" {
+		t.Errorf("error type = %q, want rate_limit_error", got)
+	}
+}
+
+func TestChatCompletionsTranslationForBedrockModel(t *testing.T) {
+	for _, provider := range []string{system.AmazonBedrockModelProvider, system.AmazonBedrockAPIKeyModelProvider} {
+		manifest := types2.ModelManifest{
+			TargetModel:   "anthropic.claude",
+			ModelProvider: provider,
+			Dialect:       string(nanobottypes.DialectAnthropicMessages),
+		}
+		if translation, err := chatCompletionsTranslationForModel(manifest); err != nil || translation.path != "v1/messages" {
+			t.Fatalf("%s: Anthropic Messages model not translated: %v", provider, err)
+		}
+
+		manifest.Dialect = string(nanobottypes.DialectOpenAIChatCompletions)
+		_, err := chatCompletionsTranslationForModel(manifest)
+		var httpErr *types2.ErrHTTP
+		if !errors.As(err, &httpErr) || httpErr.Code != http.StatusBadRequest {
+			t.Fatalf("%s: chat completions model passed through to Bedrock: %v", provider, err)
+		}
+	}
+
+	manifest := types2.ModelManifest{ModelProvider: "openai-model-provider", Dialect: string(nanobottypes.DialectOpenAIChatCompletions)}
+	if translation, err := chatCompletionsTranslationForModel(manifest); err != nil || !translation.passthrough {
+		t.Fatalf("chat completions model not passed through: %v", err)
+	}
+}
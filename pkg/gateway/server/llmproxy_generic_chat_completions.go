@@ -0,0 +1,63 @@
+package server
+
+import (
+	"net/http"
+	"net/url"
+
+	nanobottypes "github.com/obot-platform/nanobot/pkg/types"
+	"github.com/obot-platform/obot/pkg/gateway/genericchat"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	"github.com/obot-platform/obot/pkg/system"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+)
+
+type genericChatCompletionsProviderBackend struct{}
+
+func (s *Server) newGenericChatCompletionsLLMProviderProxy() *llmProviderProxy {
+	return &llmProviderProxy{
+		dailyUserInputTokenLimit:  s.dailyUserInputTokenLimit,
+		dailyUserOutputTokenLimit: s.dailyUserOutputTokenLimit,
+		backend:                   genericChatCompletionsProviderBackend{},
+		mapHelper:                 s.mapHelper,
+		messagePolicyHelper:       s.messagePolicyHelper,
+		toolCallEnforcement:       s.toolCallEnforcement,
+	}
+}
+
+func (genericChatCompletionsProviderBackend) modelProviderName() string {
+	return system.GenericChatCompletionsModelProvider
+}
+
+func (genericChatCompletionsProviderBackend) upstreamURL(_ *http.Request, credEnv map[string]string) (url.URL, nanobottypes.Dialect, error) {
+	u, err := genericchat.BaseURL(credEnv)
+	return u, nanobottypes.DialectOpenAIChatCompletions, err
+}
+
+func (genericChatCompletionsProviderBackend) transport(_ v1.ModelProvider, credEnv map[string]string) (http.RoundTripper, error) {
+	return genericchat.Transport(credEnv, http.DefaultTransport)
+}
+
+// requestChatCompletionsStreamUsage asks the upstream to end a streamed Chat
+// Completions response with a usage chunk, which is the only way a stream
+// reports token usage. It reports whether the client did not ask for that
+// chunk itself, in which case it is removed from the response again.
+func requestChatCompletionsStreamUsage(body []byte) ([]byte, bool) {
+	if !gjson.GetBytes(body, "stream").Bool() || gjson.GetBytes(body, "stream_options.include_usage").Bool() {
+		return body, false
+	}
+
+	modified, err := sjson.SetBytes(body, "stream_options.include_usage", true)
+	if err != nil {
+		return body, false
+	}
+	return modified, true
+}
+
+// isChatCompletionsUsageChunk reports whether an SSE data payload is the usage
+// chunk that ends a Chat Completions stream. It carries no choices.
+func isChatCompletionsUsageChunk(data []byte) bool {
+	return gjson.GetBytes(data, "object").String() == "chat.completion.chunk" &&
+		gjson.GetBytes(data, "usage").IsObject() &&
+		len(gjson.GetBytes(data, "choices").Array()) == 0
+}
@@ -0,0 +1,134 @@
+package server
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/obot-platform/obot/pkg/messagepolicy"
+	"github.com/tidwall/gjson"
+)
+
+func TestRequestChatCompletionsStreamUsage(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		body     string
+		wantDrop bool
+		wantSet  bool
+	}{
+		{name: "stream without usage", body: `{"model":"m","stream":true}`, wantDrop: true, wantSet: true},
+		{name: "client asked for usage", body: `{"model":"m","stream":true,"stream_options":{"include_usage":true}}`, wantSet: true},
+		{name: "keeps other stream options", body: `{"model":"m","stream":true,"stream_options":{"include_obfuscation":false}}`, wantDrop: true, wantSet: true},
+		{name: "not streaming", body: `{"model":"m"}`},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			body, drop := requestChatCompletionsStreamUsage([]byte(tt.body))
+			if drop != tt.wantDrop {
+				t.Errorf("drop = %v, want %v", drop, tt.wantDrop)
+			}
+			if got := gjson.GetBytes(body, "stream_options.include_usage").Bool(); got != tt.wantSet {
+				t.Errorf("include_usage = %v, want %v: %s", got, tt.wantSet, body)
+			}
+			if strings.Contains(tt.body, "include_obfuscation") && !gjson.GetBytes(body, "stream_options.include_obfuscation").Exists() {
+				t.Errorf("existing stream options were dropped: %s", body)
+			}
+		})
+	}
+}
+
+func TestResponseModifier_ChatCompletionsDropsRequestedUsage(t *testing.T) {
+	stream := "data: {\"object\":\"chat.completion.chunk\",\"choices\":[{\"index\":0,\"delta\":{\"content\":\"Hi\"}}],\"usage\":null}\n\n" +
+		"data: {\"object\":\"chat.completion.chunk\",\"choices\":[],\"usage\":{\"prompt_tokens\":12,\"completion_tokens\":3}}\n\n" +
+		"data: [DONE]\n\n"
+
+	for _, drop := range []bool{true, false} {
+		r := &responseModifier{
+			stream:            true,
+			dropStreamUsage:   drop,
+			tokenUsageTracker: &threadSafeTokenUsageTracker{inner: &chatCompletionsTokenUsageTracker{}},
+			b:                 bufio.NewReader(strings.NewReader(stream)),
+			c:                 io.NopCloser(strings.NewReader("")),
+		}
+
+		out, err := io.ReadAll(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := strings.Contains(string(out), "prompt_tokens"); got == drop {
+			t.Errorf("drop = %v: usage chunk forwarded = %v: %s", drop, got, out)
+		}
+		if !strings.Contains(string(out), "data: [DONE]") {
+			t.Errorf("stream terminator was not forwarded: %s", out)
+		}
+
+		got := r.tokenUsageTracker.getTokenUsage()
+		if got.InputTokens != 12 || got.OutputTokens != 3 {
+			t.Errorf("drop = %v: usage = %+v, want 12 input and 3 output tokens", drop, got)
+		}
+	}
+}
+
+func TestAccumulateChatCompletionsToolCallInfo(t *testing.T) {
+	var (
+		toolCalls   []messagepolicy.ToolCallInfo
+		indexToTool = map[int]int{}
+	)
+	for _, chunk := range []string{
+		`{"choices":[{"index":0,"delta":{"tool_calls":[{"index":0,"id":"call_1","type":"function","function":{"name":"get_weather","arguments":""}}]}}]}`,
+		`{"choices":[{"index":0,"delta":{"tool_calls":[{"index":0,"function":{"arguments":"{\"city\":"}}]}}]}`,
+		`{"choices":[{"index":0,"delta":{"tool_calls":[{"index":1,"id":"call_2","type":"function","function":{"name":"get_time","arguments":"{}"}}]}}]}`,
+		`{"choices":[{"index":0,"delta":{"tool_calls":[{"index":0,"function":{"arguments":"\"NYC\"}"}}]}}]}`,
+	} {
+		if !isChatCompletionsToolCallEvent([]byte(chunk)) {
+			t.Errorf("expected %s to be a tool call event", chunk)
+		}
+		accumulateChatCompletionsToolCallInfo([]byte(chunk), &toolCalls, indexToTool)
+	}
+
+	if len(toolCalls) != 2 {
+		t.Fatalf("expected 2 tool calls, got %+v", toolCalls)
+	}
+	if toolCalls[0].Name != "get_weather" || toolCalls[0].Arguments != `{"city":"NYC"}` {
+		t.Errorf("toolCalls[0] = %+v", toolCalls[0])
+	}
+	if toolCalls[1].Name != "get_time" || toolCalls[1].Arguments != "{}" {
+		t.Errorf("toolCalls[1] = %+v", toolCalls[1])
+	}
+	if isChatCompletionsToolCallEvent([]byte(`{"choices":[{"index":0,"delta":{"content":"Hi"}}]}`)) {
+		t.Error("content chunk was treated as a tool call event")
+	}
+}
+
+func TestParseMessagesFromBody_ChatCompletionsFormat(t *testing.T) {
+	raw := []any{
+		map[string]any{"role": "user", "content": "What's the weather in NYC?"},
+		map[string]any{
+			"role":    "assistant",
+			"content": nil,
+			"tool_calls": []any{
+				map[string]any{
+					"id":       "call_abc",
+					"type":     "function",
+					"function": map[string]any{"name": "get_weather", "arguments": `{"city":"NYC"}`},
+				},
+			},
+		},
+		map[string]any{"role": "tool", "tool_call_id": "call_abc", "content": `{"temp":72}`},
+	}
+
+	history, lastUserMsg, _ := parseMessagesFromBody(raw)
+
+	if len(history) != 3 {
+		t.Fatalf("expected 3 history entries, got %d", len(history))
+	}
+	if lastUserMsg != "What's the weather in NYC?" {
+		t.Errorf("lastUserMsg = %q", lastUserMsg)
+	}
+	if len(history[1].ToolCalls) != 1 || history[1].ToolCalls[0].Name != "get_weather" || history[1].ToolCalls[0].Arguments != `{"city":"NYC"}` {
+		t.Errorf("history[1] = %+v, want assistant with get_weather tool call", history[1])
+	}
+	if history[2].Role != "tool" || history[2].ToolCallID != "call_abc" || history[2].Content != `{"temp":72}` {
+		t.Errorf("history[2] = %+v, want tool result for call_abc", history[2])
+	}
+}
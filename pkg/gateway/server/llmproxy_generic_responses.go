@@ -30,6 +30,7 @@ func (s *Server) newGenericResponsesLLMProviderProxy() *llmProviderProxy {
 		backend:                   genericResponsesProviderBackend{},
 		mapHelper:                 s.mapHelper,
 		messagePolicyHelper:       s.messagePolicyHelper,
+		toolCallEnforcement:       s.toolCallEnforcement,
 	}
 }
 
@@ -116,6 +116,10 @@ func TestModifyResponse_WrapGate(t *testing.T) {
 	}{
 		{"anthropic messages", "/v1/messages", http.StatusOK, true},
 		{"openai responses", "/v1/responses", http.StatusOK, true},
+		{"chat completions", "/v1/chat/completions", http.StatusOK, true},
+		{"prefixed anthropic messages", "/anthropic/v1/messages", http.StatusOK, true},
+		{"prefixed chat completions", "/openai/v1/chat/completions", http.StatusOK, true},
+		{"prefixed unknown path", "/openai/v1/embeddings", http.StatusOK, false},
 		{"unknown path", "/v1/embeddings", http.StatusOK, false},
 		{"non-200 status", "/v1/messages", http.StatusBadRequest, false},
 	}
@@ -0,0 +1,699 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	types2 "github.com/obot-platform/obot/apiclient/types"
+	"github.com/obot-platform/obot/pkg/api"
+	"github.com/obot-platform/obot/pkg/api/server/requestinfo"
+	"github.com/obot-platform/obot/pkg/enforcement"
+	"github.com/obot-platform/obot/pkg/gateway/types"
+	"github.com/obot-platform/obot/pkg/principal"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	"github.com/obot-platform/obot/pkg/system"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// toolCallEnforcementSettingTTL is how long the tool-call enforcement setting
+// is reused before it is read again. It is consulted on every LLM request.
+const toolCallEnforcementSettingTTL = 10 * time.Second
+
+// toolCallEnforcement is shared by the LLM proxies. It caches the enforcement
+// setting so that a request does not have to read it from storage.
+type toolCallEnforcement struct {
+	// serverURL is Obot's base URL, which the caller's MCP servers are reached
+	// through at /mcp-connect/<id>.
+	serverURL string
+
+	lock      sync.Mutex
+	setting   *types2.ToolCallEnforcementSetting
+	expiresAt time.Time
+}
+
+func newToolCallEnforcement(serverURL string) *toolCallEnforcement {
+	return &toolCallEnforcement{serverURL: strings.TrimSuffix(serverURL, "/")}
+}
+
+// getSetting returns the enforcement setting, or nil if there is none.
+func (t *toolCallEnforcement) getSetting(req api.Context) (*types2.ToolCallEnforcementSetting, error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if now := time.Now(); now.Before(t.expiresAt) {
+		return t.setting, nil
+	}
+
+	var setting v1.ToolCallEnforcementSetting
+	if err := req.Get(&setting, system.ToolCallEnforcementSettingName); apierrors.IsNotFound(err) {
+		t.setting = nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get tool call enforcement setting: %w", err)
+	} else {
+		t.setting = &setting.Spec.Manifest
+	}
+	t.expiresAt = time.Now().Add(toolCallEnforcementSettingTTL)
+	return t.setting, nil
+}
+
+// toolCallEnforcer applies the gateway's tool-call enforcement setting to the
+// tool calls in one model response. Devices ask for a decision before running a
+// tool; traffic from hosted agents and headless CI has no device in front of
+// it, so the gateway decides instead, as the response passes through.
+//
+// A denied call is replaced with a refusal rather than flagged. Removing it
+// keeps the conversation valid for every client: there is no tool_use left
+// waiting for a result, and the model reads on the next turn why it did not
+// run.
+type toolCallEnforcer struct {
+	allowlist types2.EnforcementAllowlist
+	agent     string
+	// serverURL is Obot's base URL, which the caller's MCP servers are reached
+	// through at /mcp-connect/<id>.
+	serverURL string
+	// mcpIDs are the MCP servers the caller's credential is scoped to. It is
+	// empty, or holds "*", when the caller may use every server it can reach.
+	mcpIDs []string
+	// servers lists the Obot MCP servers the caller can name. Clients name a
+	// server after its display name rather than its ID, so a tool name prefix
+	// is looked up here. It is only called once, the first time a tool call
+	// needs it.
+	servers      func() ([]toolCallServer, error)
+	serversCache []toolCallServer
+	serversRead  bool
+	// entry is the decision-log row every decision starts from.
+	entry types.EnforcementDecisionLog
+	log   func(types.EnforcementDecisionLog)
+}
+
+// newToolCallEnforcer returns nil unless tool-call enforcement is enabled.
+func newToolCallEnforcer(req api.Context, enforcement *toolCallEnforcement) (*toolCallEnforcer, error) {
+	setting, err := enforcement.getSetting(req)
+	if err != nil || setting == nil || !setting.Enabled {
+		return nil, err
+	}
+
+	ownerID := principal.ResourceOwnerID(req.User)
+	return &toolCallEnforcer{
+		allowlist: setting.Allowlist,
+		agent:     toolCallAgent(req.Request.UserAgent()),
+		serverURL: enforcement.serverURL,
+		mcpIDs:    req.User.GetExtra()["authorized_mcp_ids"],
+		servers: func() ([]toolCallServer, error) {
+			return listToolCallServers(req.Context(), req.Storage, ownerID)
+		},
+		entry: types.EnforcementDecisionLog{
+			Source:   types2.EnforcementDecisionSourceLLMGateway,
+			UserID:   req.User.GetUID(),
+			ClientIP: requestinfo.GetSourceIP(req.Request),
+		},
+		log: req.GatewayClient.LogEnforcementDecision,
+	}, nil
+}
+
+// toolCallAgent identifies the coding agent from its User-Agent, which is the
+// only evidence the gateway has of it. An unrecognized agent is left empty, and
+// matches no agent's built-in MCP servers.
+func toolCallAgent(userAgent string) string {
+	userAgent = strings.ToLower(userAgent)
+	switch {
+	case strings.Contains(userAgent, "claude-cli"), strings.Contains(userAgent, "claude-code"):
+		return enforcement.AgentClaudeCode
+	case strings.Contains(userAgent, "codex"):
+		return enforcement.AgentCodex
+	case strings.Contains(userAgent, "cursor"):
+		return enforcement.AgentCursor
+	case strings.Contains(userAgent, "vscode"):
+		return enforcement.AgentVSCode
+	default:
+		return ""
+	}
+}
+
+// toolCallServer is an Obot MCP server a tool name prefix can refer to.
+type toolCallServer struct {
+	// id is what the server is reached through at /mcp-connect/<id>.
+	id string
+	// names are the names a client may have given the server, normalized with
+	// toolCallServerName.
+	names []string
+	// scopeIDs are the IDs that grant access to the server when a credential
+	// is scoped to a list of servers.
+	scopeIDs []string
+}
+
+// listToolCallServers returns the MCP servers ownerID can name: their own
+// servers and server instances first, then shared servers, then catalog
+// entries, so that a name is resolved to the server closest to the caller.
+func listToolCallServers(ctx context.Context, client kclient.Client, ownerID string) ([]toolCallServer, error) {
+	var mcpServers v1.MCPServerList
+	if err := client.List(ctx, &mcpServers, kclient.InNamespace(system.DefaultNamespace)); err != nil {
+		return nil, fmt.Errorf("failed to list MCP servers: %w", err)
+	}
+	var instances v1.MCPServerInstanceList
+	if err := client.List(ctx, &instances, kclient.InNamespace(system.DefaultNamespace)); err != nil {
+		return nil, fmt.Errorf("failed to list MCP server instances: %w", err)
+	}
+	var entries v1.MCPServerCatalogEntryList
+	if err := client.List(ctx, &entries, kclient.InNamespace(system.DefaultNamespace)); err != nil {
+		return nil, fmt.Errorf("failed to list MCP server catalog entries: %w", err)
+	}
+
+	var (
+		owned, shared, catalog []toolCallServer
+		byName                 = make(map[string]v1.MCPServer, len(mcpServers.Items))
+	)
+	for _, server := range mcpServers.Items {
+		byName[server.Name] = server
+		if server.Spec.Template || server.DeletionTimestamp != nil {
+			continue
+		}
+		candidate := toolCallServer{
+			id:       server.Name,
+			names:    toolCallServerNames(server.Name, server.Spec.Alias, server.Spec.Manifest.Name, server.Spec.MCPServerCatalogEntryName),
+			scopeIDs: nonEmpty(server.Name, server.Spec.CompositeName),
+		}
+		switch {
+		case server.Spec.UserID == ownerID:
+			owned = append(owned, candidate)
+		case server.Spec.MCPCatalogID != "" || server.Spec.PowerUserWorkspaceID != "":
+			shared = append(shared, candidate)
+		}
+	}
+	for _, instance := range instances.Items {
+		if instance.Spec.UserID != ownerID || instance.Spec.Template || instance.DeletionTimestamp != nil {
+			continue
+		}
+		server := byName[instance.Spec.MCPServerName]
+		owned = append(owned, toolCallServer{
+			id:       instance.Name,
+			names:    toolCallServerNames(instance.Name, server.Spec.Alias, server.Spec.Manifest.Name, instance.Spec.MCPServerCatalogEntryName),
+			scopeIDs: nonEmpty(instance.Name, instance.Spec.CompositeName, instance.Spec.MCPServerName),
+		})
+	}
+	for _, entry := range entries.Items {
+		catalog = append(catalog, toolCallServer{
+			id:       entry.Name,
+			names:    toolCallServerNames(entry.Name, entry.Spec.Manifest.Name),
+			scopeIDs: []string{entry.Name},
+		})
+	}
+
+	return slices.Concat(owned, shared, catalog), nil
+}
+
+func toolCallServerNames(names ...string) []string {
+	result := make([]string, 0, len(names))
+	for _, name := range names {
+		if name = toolCallServerName(name); name != "" && !slices.Contains(result, name) {
+			result = append(result, name)
+		}
+	}
+	return result
+}
+
+// toolCallServerName normalizes a server name the way clients do when they
+// build tool names from it: lowercased, with anything but letters, digits, '_'
+// and '-' replaced by '-'.
+func toolCallServerName(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(strings.TrimSpace(name)) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '_', r == '-':
+			b.WriteRune(r)
+		default:
+			b.WriteByte('-')
+		}
+	}
+	result := strings.Trim(b.String(), "-")
+	for strings.Contains(result, "--") {
+		result = strings.ReplaceAll(result, "--", "-")
+	}
+	return result
+}
+
+func nonEmpty(values ...string) []string {
+	return slices.DeleteFunc(values, func(value string) bool { return value == "" })
+}
+
+// scoped reports whether the caller's credential is limited to a list of MCP
+// servers, rather than every server it can reach.
+func (e *toolCallEnforcer) scoped() bool {
+	return len(e.mcpIDs) > 0 && !slices.Contains(e.mcpIDs, "*")
+}
+
+func (e *toolCallEnforcer) resolveServer(serverName string) (enforcement.ServerIdentity, bool, bool) {
+	if id, ok := e.resolveServerID(serverName); ok {
+		serverURL := fmt.Sprintf("%s/mcp-connect/%s", e.serverURL, id)
+		var hostname string
+		if u, err := url.Parse(serverURL); err == nil {
+			hostname = u.Hostname()
+		}
+		return enforcement.ServerIdentity{URL: serverURL, Hostname: hostname}, true, true
+	}
+	return enforcement.ServerIdentity{}, false, false
+}
+
+// resolveServerID finds the Obot MCP server serverName refers to: one of the
+// servers the caller's credential is scoped to, by ID, or a server the caller
+// can reach, by name.
+func (e *toolCallEnforcer) resolveServerID(serverName string) (string, bool) {
+	for _, id := range e.mcpIDs {
+		if id != "" && id != "*" && strings.EqualFold(id, serverName) {
+			return id, true
+		}
+	}
+
+	name := toolCallServerName(serverName)
+	if name == "" || e.servers == nil {
+		return "", false
+	}
+	if !e.serversRead {
+		e.serversRead = true
+		servers, err := e.servers()
+		if err != nil {
+			slog.Error("failed to list MCP servers for tool call enforcement", "error", err)
+		}
+		e.serversCache = servers
+	}
+	for _, server := range e.serversCache {
+		if !slices.Contains(server.names, name) {
+			continue
+		}
+		if e.scoped() && !slices.ContainsFunc(server.scopeIDs, func(id string) bool { return slices.Contains(e.mcpIDs, id) }) {
+			continue
+		}
+		return server.id, true
+	}
+	return "", false
+}
+
+// decide evaluates one tool call and records the decision.
+func (e *toolCallEnforcer) decide(name string) enforcement.Decision {
+	call := enforcement.CallFromToolName(e.agent, name, e.resolveServer)
+	decision := enforcement.Evaluate(call, e.allowlist)
+
+	if e.log != nil {
+		entry := e.entry
+		entry.CreatedAt = time.Now().UTC()
+		entry.Agent = call.Agent
+		entry.Tool = call.Tool
+		entry.Kind = call.Kind
+		entry.ServerName = call.ServerName
+		entry.ObotHosted = call.ObotHosted
+		entry.ServerURL = call.Server.URL
+		entry.ServerHostname = call.Server.Hostname
+		entry.Unresolved = call.Unresolved
+		entry.UnresolvedReason = call.UnresolvedReason
+		entry.Decision = verdictForDecision(decision)
+		entry.Reason = decision.Reason
+		e.log(entry)
+	}
+
+	return decision
+}
+
+func verdictForDecision(decision enforcement.Decision) string {
+	if decision.Allow {
+		return types2.EnforcementDecisionAllow
+	}
+	return types2.EnforcementDecisionDeny
+}
+
+// refusal is what the model and the user see in place of a denied tool call.
+func toolCallRefusal(name string, decision enforcement.Decision) string {
+	return fmt.Sprintf("The %q tool call was blocked by Obot tool call enforcement (%s). Tell the user this action is not permitted instead of retrying it.", name, decision.Reason)
+}
+
+// enforceJSON rewrites a non-streaming response, replacing denied tool calls.
+func (e *toolCallEnforcer) enforceJSON(body []byte) []byte {
+	switch {
+	case gjson.GetBytes(body, "output").Exists():
+		return e.enforceResponsesJSON(body)
+	case gjson.GetBytes(body, "content").Exists():
+		return e.enforceAnthropicJSON(body)
+	case gjson.GetBytes(body, "choices").Exists():
+		return e.enforceChatCompletionsJSON(body)
+	default:
+		return body
+	}
+}
+
+func (e *toolCallEnforcer) enforceAnthropicJSON(body []byte) []byte {
+	var denied, allowed int
+	for i, block := range gjson.GetBytes(body, "content").Array() {
+		if block.Get("type").String() != "tool_use" {
+			continue
+		}
+		name := block.Get("name").String()
+		decision := e.decide(name)
+		if decision.Allow {
+			allowed++
+			continue
+		}
+		denied++
+		body = setRawJSON(body, fmt.Sprintf("content.%d", i), anthropicTextBlock(toolCallRefusal(name, decision)))
+	}
+	if denied > 0 && allowed == 0 && gjson.GetBytes(body, "stop_reason").String() == "tool_use" {
+		body = setJSON(body, "stop_reason", "end_turn")
+	}
+	return body
+}
+
+func (e *toolCallEnforcer) enforceResponsesJSON(body []byte) []byte {
+	for i, item := range gjson.GetBytes(body, "output").Array() {
+		if item.Get("type").String() != "function_call" {
+			continue
+		}
+		name := item.Get("name").String()
+		if decision := e.decide(name); !decision.Allow {
+			body = setRawJSON(body, fmt.Sprintf("output.%d", i), responsesMessageItem(item, toolCallRefusal(name, decision)))
+		}
+	}
+	return body
+}
+
+func (e *toolCallEnforcer) enforceChatCompletionsJSON(body []byte) []byte {
+	for i, choice := range gjson.GetBytes(body, "choices").Array() {
+		calls := choice.Get("message.tool_calls").Array()
+		if len(calls) == 0 {
+			continue
+		}
+
+		var (
+			kept      []string
+			refusals  []string
+			path      = fmt.Sprintf("choices.%d.message", i)
+			finishKey = fmt.Sprintf("choices.%d.finish_reason", i)
+		)
+		for _, call := range calls {
+			name := call.Get("function.name").String()
+			if decision := e.decide(name); decision.Allow {
+				kept = append(kept, call.Raw)
+			} else {
+				refusals = append(refusals, toolCallRefusal(name, decision))
+			}
+		}
+		if len(refusals) == 0 {
+			continue
+		}
+
+		content := choice.Get("message.content").String()
+		if content != "" {
+			content += "\n\n"
+		}
+		body = setJSON(body, path+".content", content+strings.Join(refusals, "\n"))
+		if len(kept) > 0 {
+			body = setRawJSON(body, path+".tool_calls", []byte("["+strings.Join(kept, ",")+"]"))
+		} else {
+			body = deleteJSON(body, path+".tool_calls")
+			if gjson.GetBytes(body, finishKey).String() == "tool_calls" {
+				body = setJSON(body, finishKey, "stop")
+			}
+		}
+	}
+	return body
+}
+
+// sseToolCallState tracks the tool calls of one streamed response across
+// events, since only the first event of a call carries its name.
+type sseToolCallState struct {
+	// Anthropic content block index and Responses API output index of each
+	// denied call, mapped to its refusal.
+	anthropicDenied map[int64]string
+	responsesDenied map[int64]string
+	anthropicKept   int
+	// chatIndexes maps a Chat Completions choice and original tool_calls
+	// index to the index the call is renumbered to, or -1 when it is denied.
+	chatIndexes map[[2]int64]int64
+	chatKept    map[int64]int64
+	chatDenied  map[int64]bool
+}
+
+// enforceSSE rewrites buffered SSE lines, from the first tool call event to the
+// end of the stream, replacing denied tool calls.
+func (e *toolCallEnforcer) enforceSSE(lines [][]byte) [][]byte {
+	state := sseToolCallState{
+		anthropicDenied: map[int64]string{},
+		responsesDenied: map[int64]string{},
+		chatIndexes:     map[[2]int64]int64{},
+		chatKept:        map[int64]int64{},
+		chatDenied:      map[int64]bool{},
+	}
+
+	var (
+		out   = make([][]byte, 0, len(lines))
+		event [][]byte
+	)
+	flush := func() {
+		if len(event) > 0 {
+			out = append(out, e.enforceSSEEvent(&state, event)...)
+			event = nil
+		}
+	}
+	for _, line := range lines {
+		event = append(event, line)
+		if len(bytes.TrimSpace(line)) == 0 {
+			flush()
+		}
+	}
+	flush()
+	return out
+}
+
+// enforceSSEEvent rewrites one event: its lines up to and including the blank
+// line that ends it. It returns the lines to send in its place.
+func (e *toolCallEnforcer) enforceSSEEvent(state *sseToolCallState, event [][]byte) [][]byte {
+	dataIdx := -1
+	for i, line := range event {
+		if bytes.HasPrefix(line, []byte("data: ")) {
+			dataIdx = i
+			break
+		}
+	}
+	if dataIdx < 0 {
+		return event
+	}
+	data := bytes.TrimSpace(bytes.TrimPrefix(event[dataIdx], []byte("data: ")))
+	if !gjson.ValidBytes(data) {
+		return event
+	}
+
+	eventType := gjson.GetBytes(data, "type").String()
+	switch {
+	case eventType == "content_block_start" && gjson.GetBytes(data, "content_block.type").String() == "tool_use":
+		name := gjson.GetBytes(data, "content_block.name").String()
+		decision := e.decide(name)
+		if decision.Allow {
+			state.anthropicKept++
+			return event
+		}
+		index := gjson.GetBytes(data, "index").Int()
+		refusal := toolCallRefusal(name, decision)
+		state.anthropicDenied[index] = refusal
+		start := setRawJSON(data, "content_block", []byte(`{"type":"text","text":""}`))
+		delta := setJSON([]byte(`{"type":"content_block_delta","delta":{"type":"text_delta"}}`), "index", index)
+		delta = setJSON(delta, "delta.text", refusal)
+		return slices.Concat(
+			replaceSSEData(event, dataIdx, start),
+			newSSEEvent(event, dataIdx, "content_block_delta", delta),
+		)
+	case eventType == "content_block_delta" && gjson.GetBytes(data, "delta.type").String() == "input_json_delta":
+		if _, denied := state.anthropicDenied[gjson.GetBytes(data, "index").Int()]; denied {
+			return nil
+		}
+	case eventType == "message_delta" && gjson.GetBytes(data, "delta.stop_reason").String() == "tool_use":
+		if len(state.anthropicDenied) > 0 && state.anthropicKept == 0 {
+			return replaceSSEData(event, dataIdx, setJSON(data, "delta.stop_reason", "end_turn"))
+		}
+
+	case eventType == "response.output_item.added" && gjson.GetBytes(data, "item.type").String() == "function_call":
+		item := gjson.GetBytes(data, "item")
+		name := item.Get("name").String()
+		decision := e.decide(name)
+		if decision.Allow {
+			return event
+		}
+		refusal := toolCallRefusal(name, decision)
+		state.responsesDenied[gjson.GetBytes(data, "output_index").Int()] = refusal
+		return replaceSSEData(event, dataIdx, setRawJSON(data, "item", responsesMessageItem(item, refusal)))
+	case eventType == "response.function_call_arguments.delta", eventType == "response.function_call_arguments.done":
+		if _, denied := state.responsesDenied[gjson.GetBytes(data, "output_index").Int()]; denied {
+			return nil
+		}
+	case eventType == "response.output_item.done":
+		if refusal, denied := state.responsesDenied[gjson.GetBytes(data, "output_index").Int()]; denied {
+			return replaceSSEData(event, dataIdx, setRawJSON(data, "item", responsesMessageItem(gjson.GetBytes(data, "item"), refusal)))
+		}
+	case eventType == "response.completed", eventType == "response.incomplete":
+		if len(state.responsesDenied) == 0 {
+			return event
+		}
+		for i, item := range gjson.GetBytes(data, "response.output").Array() {
+			if refusal, denied := state.responsesDenied[int64(i)]; denied && item.Get("type").String() == "function_call" {
+				data = setRawJSON(data, fmt.Sprintf("response.output.%d", i), responsesMessageItem(item, refusal))
+			}
+		}
+		return replaceSSEData(event, dataIdx, data)
+
+	case gjson.GetBytes(data, "choices").IsArray():
+		if rewritten, changed := e.enforceChatCompletionsChunk(state, data); changed {
+			return replaceSSEData(event, dataIdx, rewritten)
+		}
+	}
+	return event
+}
+
+// enforceChatCompletionsChunk removes denied calls from a Chat Completions
+// chunk, renumbering the calls that remain so their indexes stay contiguous,
+// and streams each refusal as content in its place.
+func (e *toolCallEnforcer) enforceChatCompletionsChunk(state *sseToolCallState, data []byte) ([]byte, bool) {
+	var changed bool
+	for i, choice := range gjson.GetBytes(data, "choices").Array() {
+		choiceIdx := choice.Get("index").Int()
+		path := fmt.Sprintf("choices.%d", i)
+
+		calls := choice.Get("delta.tool_calls").Array()
+		if len(calls) > 0 {
+			var (
+				kept     []string
+				refusals []string
+			)
+			for _, call := range calls {
+				key := [2]int64{choiceIdx, call.Get("index").Int()}
+				newIdx, seen := state.chatIndexes[key]
+				if !seen {
+					name := call.Get("function.name").String()
+					if decision := e.decide(name); decision.Allow {
+						newIdx = state.chatKept[choiceIdx]
+						state.chatKept[choiceIdx]++
+					} else {
+						newIdx = -1
+						state.chatDenied[choiceIdx] = true
+						refusals = append(refusals, toolCallRefusal(name, decision))
+					}
+					state.chatIndexes[key] = newIdx
+				}
+				if newIdx < 0 {
+					continue
+				}
+				raw := call.Raw
+				if call.Get("index").Int() != newIdx {
+					raw = string(setJSON([]byte(raw), "index", newIdx))
+				}
+				kept = append(kept, raw)
+			}
+			if len(kept) != len(calls) || toolCallsRewritten(kept, calls) {
+				changed = true
+				if len(kept) > 0 {
+					data = setRawJSON(data, path+".delta.tool_calls", []byte("["+strings.Join(kept, ",")+"]"))
+				} else {
+					data = deleteJSON(data, path+".delta.tool_calls")
+				}
+			}
+			if len(refusals) > 0 {
+				changed = true
+				content := choice.Get("delta.content").String()
+				data = setJSON(data, path+".delta.content", content+strings.Join(refusals, "\n"))
+			}
+		}
+
+		if choice.Get("finish_reason").String() == "tool_calls" && state.chatDenied[choiceIdx] && state.chatKept[choiceIdx] == 0 {
+			changed = true
+			data = setJSON(data, path+".finish_reason", "stop")
+		}
+	}
+	return data, changed
+}
+
+// toolCallsRewritten reports whether any kept tool call's JSON differs from the
+// call it came from, such as by being renumbered. The two must be the same
+// length.
+func toolCallsRewritten(kept []string, calls []gjson.Result) bool {
+	for i := range kept {
+		if kept[i] != calls[i].Raw {
+			return true
+		}
+	}
+	return false
+}
+
+// replaceSSEData returns event with its data line replaced.
+func replaceSSEData(event [][]byte, dataIdx int, data []byte) [][]byte {
+	out := make([][]byte, len(event))
+	copy(out, event)
+	out[dataIdx] = fmt.Appendf(nil, "data: %s\n", data)
+	return out
+}
+
+// newSSEEvent builds an event shaped like template: with an event line naming
+// eventType if template has one, and terminated by a blank line.
+func newSSEEvent(template [][]byte, dataIdx int, eventType string, data []byte) [][]byte {
+	var out [][]byte
+	for _, line := range template[:dataIdx] {
+		if bytes.HasPrefix(line, []byte("event:")) {
+			out = append(out, fmt.Appendf(nil, "event: %s\n", eventType))
+		}
+	}
+	return append(out, fmt.Appendf(nil, "data: %s\n", data), []byte("\n"))
+}
+
+func anthropicTextBlock(text string) []byte {
+	b, _ := json.Marshal(map[string]string{"type": "text", "text": text})
+	return b
+}
+
+// responsesMessageItem builds the assistant message that replaces a denied
+// Responses API function_call item.
+func responsesMessageItem(item gjson.Result, text string) []byte {
+	id := "msg_" + item.Get("call_id").String()
+	if id == "msg_" {
+		id = "msg_" + item.Get("id").String()
+	}
+	b, _ := json.Marshal(map[string]any{
+		"type":   "message",
+		"id":     id,
+		"status": "completed",
+		"role":   "assistant",
+		"content": []map[string]any{{
+			"type":        "output_text",
+			"text":        text,
+			"annotations": []any{},
+		}},
+	})
+	return b
+}
+
+// setJSON, setRawJSON and deleteJSON edit a document in place, leaving it
+// unchanged if the edit fails, so enforcement can never corrupt a response.
+func setJSON(body []byte, path string, value any) []byte {
+	if out, err := sjson.SetBytes(body, path, value); err == nil {
+		return out
+	}
+	return body
+}
+
+func setRawJSON(body []byte, path string, value []byte) []byte {
+	if out, err := sjson.SetRawBytes(body, path, value); err == nil {
+		return out
+	}
+	return body
+}
+
+func deleteJSON(body []byte, path string) []byte {
+	if out, err := sjson.DeleteBytes(body, path); err == nil {
+		return out
+	}
+	return body
+}
@@ -0,0 +1,367 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	types2 "github.com/obot-platform/obot/apiclient/types"
+	"github.com/obot-platform/obot/pkg/enforcement"
+	"github.com/obot-platform/obot/pkg/gateway/types"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	storagescheme "github.com/obot-platform/obot/pkg/storage/scheme"
+	"github.com/obot-platform/obot/pkg/system"
+	"github.com/tidwall/gjson"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// newTestToolCallEnforcer allows the caller's configured "docs" server and
+// records every decision.
+func newTestToolCallEnforcer(logged *[]types.EnforcementDecisionLog) *toolCallEnforcer {
+	return &toolCallEnforcer{
+		allowlist: types2.EnforcementAllowlist{AllowAllObotHostedMCP: true},
+		agent:     enforcement.AgentClaudeCode,
+		serverURL: "https://obot.example.com",
+		mcpIDs:    []string{"docs"},
+		entry: types.EnforcementDecisionLog{
+			Source: types2.EnforcementDecisionSourceLLMGateway,
+			UserID: "user-1",
+		},
+		log: func(entry types.EnforcementDecisionLog) {
+			*logged = append(*logged, entry)
+		},
+	}
+}
+
+func splitSSELines(stream string) [][]byte {
+	var lines [][]byte
+	for _, line := range strings.SplitAfter(stream, "\n") {
+		if line != "" {
+			lines = append(lines, []byte(line))
+		}
+	}
+	return lines
+}
+
+func sseDataLines(lines [][]byte) []string {
+	var data []string
+	for _, line := range lines {
+		if rest, ok := bytes.CutPrefix(line, []byte("data: ")); ok {
+			data = append(data, strings.TrimSpace(string(rest)))
+		}
+	}
+	return data
+}
+
+func TestToolCallAgent(t *testing.T) {
+	for userAgent, want := range map[string]string{
+		"claude-cli/2.1.3 (external, sdk-cli)": enforcement.AgentClaudeCode,
+		"codex_cli_rs/0.40.0":                  enforcement.AgentCodex,
+		"Cursor/1.7":                           enforcement.AgentCursor,
+		"vscode/1.104 copilot":                 enforcement.AgentVSCode,
+		"python-requests/2.32":                 "",
+	} {
+		if got := toolCallAgent(userAgent); got != want {
+			t.Errorf("toolCallAgent(%q) = %q, want %q", userAgent, got, want)
+		}
+	}
+}
+
+func TestToolCallEnforcerDecide(t *testing.T) {
+	var logged []types.EnforcementDecisionLog
+	e := newTestToolCallEnforcer(&logged)
+
+	if decision := e.decide("mcp__docs__search"); !decision.Allow {
+		t.Fatalf("expected a configured Obot-hosted server to be allowed, got %#v", decision)
+	}
+	if decision := e.decide("mcp__other__search"); decision.Allow {
+		t.Fatal("expected an unconfigured server to be denied")
+	}
+	if decision := e.decide("Bash"); decision.Allow {
+		t.Fatal("expected an agent tool to be denied without the built-in tools toggle")
+	}
+
+	if len(logged) != 3 {
+		t.Fatalf("expected 3 logged decisions, got %d", len(logged))
+	}
+	allowed := logged[0]
+	if allowed.Source != types2.EnforcementDecisionSourceLLMGateway || allowed.UserID != "user-1" {
+		t.Errorf("expected the gateway source and caller, got %q, %q", allowed.Source, allowed.UserID)
+	}
+	if allowed.Decision != types2.EnforcementDecisionAllow || !allowed.ObotHosted || allowed.ServerURL != "https://obot.example.com/mcp-connect/docs" || allowed.ServerHostname != "obot.example.com" {
+		t.Errorf("unexpected allowed entry: %#v", allowed)
+	}
+	if unresolved := logged[1]; unresolved.Decision != types2.EnforcementDecisionDeny || !unresolved.Unresolved {
+		t.Errorf("unexpected unresolved entry: %#v", unresolved)
+	}
+}
+
+// Hosted agents are given every server ("*") and people signed in to the
+// gateway have no server scope at all, so their tool names can only be
+// resolved by the names their clients gave the servers.
+func TestToolCallEnforcerResolvesServersByName(t *testing.T) {
+	client := fake.NewClientBuilder().WithScheme(storagescheme.Scheme).WithObjects(
+		&v1.MCPServer{
+			ObjectMeta: metav1.ObjectMeta{Name: "ms1github", Namespace: system.DefaultNamespace},
+			Spec: v1.MCPServerSpec{
+				Manifest: types2.MCPServerManifest{Name: "GitHub"},
+				UserID:   "owner-1",
+			},
+		},
+		&v1.MCPServer{
+			ObjectMeta: metav1.ObjectMeta{Name: "ms1jira", Namespace: system.DefaultNamespace},
+			Spec: v1.MCPServerSpec{
+				Manifest:     types2.MCPServerManifest{Name: "Jira Cloud"},
+				MCPCatalogID: "default",
+			},
+		},
+		&v1.MCPServer{
+			ObjectMeta: metav1.ObjectMeta{Name: "ms1private", Namespace: system.DefaultNamespace},
+			Spec: v1.MCPServerSpec{
+				Manifest: types2.MCPServerManifest{Name: "Private"},
+				UserID:   "someone-else",
+			},
+		},
+	).Build()
+
+	for _, tc := range []struct {
+		name    string
+		mcpIDs  []string
+		tool    string
+		allowed bool
+		url     string
+	}{
+		{name: "agent with every server", mcpIDs: []string{"*"}, tool: "mcp__github__create_issue", allowed: true, url: "https://obot.example.com/mcp-connect/ms1github"},
+		{name: "user without a scope", tool: "mcp__jira-cloud__search", allowed: true, url: "https://obot.example.com/mcp-connect/ms1jira"},
+		{name: "user without a scope, by ID", tool: "mcp__ms1github__create_issue", allowed: true, url: "https://obot.example.com/mcp-connect/ms1github"},
+		{name: "another user's server", tool: "mcp__private__read", allowed: false},
+		{name: "scoped to another server", mcpIDs: []string{"ms1jira"}, tool: "mcp__github__create_issue", allowed: false},
+		{name: "scoped to the server", mcpIDs: []string{"ms1github"}, tool: "mcp__github__create_issue", allowed: true, url: "https://obot.example.com/mcp-connect/ms1github"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var logged []types.EnforcementDecisionLog
+			e := newTestToolCallEnforcer(&logged)
+			e.mcpIDs = tc.mcpIDs
+			e.servers = func() ([]toolCallServer, error) {
+				return listToolCallServers(t.Context(), client, "owner-1")
+			}
+
+			if decision := e.decide(tc.tool); decision.Allow != tc.allowed {
+				t.Fatalf("decide(%q) = %#v, want allowed %v", tc.tool, decision, tc.allowed)
+			}
+			if got := logged[0].ServerURL; got != tc.url {
+				t.Errorf("server URL = %q, want %q", got, tc.url)
+			}
+		})
+	}
+}
+
+func TestToolCallEnforcerJSON_Anthropic(t *testing.T) {
+	var logged []types.EnforcementDecisionLog
+	e := newTestToolCallEnforcer(&logged)
+
+	body := `{"id":"msg_1","content":[{"type":"text","text":"Running it."},{"type":"tool_use","id":"toolu_1","name":"Bash","input":{"command":"ls"}}],"stop_reason":"tool_use"}`
+	out := e.enforceJSON([]byte(body))
+
+	blocks := gjson.GetBytes(out, "content").Array()
+	if len(blocks) != 2 || blocks[1].Get("type").String() != "text" || !strings.Contains(blocks[1].Get("text").String(), `"Bash"`) {
+		t.Fatalf("expected the tool_use block to be replaced with a refusal, got %s", out)
+	}
+	if got := gjson.GetBytes(out, "stop_reason").String(); got != "end_turn" {
+		t.Errorf("stop_reason = %q, want end_turn", got)
+	}
+}
+
+func TestToolCallEnforcerJSON_AnthropicKeepsAllowedCalls(t *testing.T) {
+	var logged []types.EnforcementDecisionLog
+	e := newTestToolCallEnforcer(&logged)
+
+	body := `{"content":[{"type":"tool_use","id":"toolu_1","name":"mcp__docs__search","input":{}},{"type":"tool_use","id":"toolu_2","name":"Bash","input":{}}],"stop_reason":"tool_use"}`
+	out := e.enforceJSON([]byte(body))
+
+	if got := gjson.GetBytes(out, "content.0.name").String(); got != "mcp__docs__search" {
+		t.Errorf("expected the allowed call to be kept, got %s", out)
+	}
+	if got := gjson.GetBytes(out, "content.1.type").String(); got != "text" {
+		t.Errorf("expected the denied call to be replaced, got %s", out)
+	}
+	if got := gjson.GetBytes(out, "stop_reason").String(); got != "tool_use" {
+		t.Errorf("stop_reason = %q, want tool_use while a call remains", got)
+	}
+}
+
+func TestToolCallEnforcerJSON_ResponsesAPI(t *testing.T) {
+	var logged []types.EnforcementDecisionLog
+	e := newTestToolCallEnforcer(&logged)
+
+	body := `{"id":"resp_1","output":[{"type":"function_call","id":"fc_1","call_id":"call_1","name":"shell","arguments":"{}"}],"status":"completed"}`
+	out := e.enforceJSON([]byte(body))
+
+	item := gjson.GetBytes(out, "output.0")
+	if item.Get("type").String() != "message" || item.Get("content.0.type").String() != "output_text" || !strings.Contains(item.Get("content.0.text").String(), `"shell"`) {
+		t.Fatalf("expected the function_call to be replaced with a message, got %s", out)
+	}
+}
+
+func TestToolCallEnforcerJSON_ChatCompletions(t *testing.T) {
+	var logged []types.EnforcementDecisionLog
+	e := newTestToolCallEnforcer(&logged)
+
+	body := `{"choices":[{"index":0,"message":{"role":"assistant","content":null,"tool_calls":[{"id":"call_1","type":"function","function":{"name":"Bash","arguments":"{}"}}]},"finish_reason":"tool_calls"}]}`
+	out := e.enforceJSON([]byte(body))
+
+	if gjson.GetBytes(out, "choices.0.message.tool_calls").Exists() {
+		t.Errorf("expected the denied tool_calls to be removed, got %s", out)
+	}
+	if !strings.Contains(gjson.GetBytes(out, "choices.0.message.content").String(), `"Bash"`) {
+		t.Errorf("expected the refusal as content, got %s", out)
+	}
+	if got := gjson.GetBytes(out, "choices.0.finish_reason").String(); got != "stop" {
+		t.Errorf("finish_reason = %q, want stop", got)
+	}
+}
+
+func TestToolCallEnforcerSSE_Anthropic(t *testing.T) {
+	var logged []types.EnforcementDecisionLog
+	e := newTestToolCallEnforcer(&logged)
+
+	stream := "event: content_block_start\n" +
+		"data: {\"type\":\"content_block_start\",\"index\":1,\"content_block\":{\"type\":\"tool_use\",\"id\":\"toolu_1\",\"name\":\"Bash\",\"input\":{}}}\n\n" +
+		"event: content_block_delta\n" +
+		"data: {\"type\":\"content_block_delta\",\"index\":1,\"delta\":{\"type\":\"input_json_delta\",\"partial_json\":\"{}\"}}\n\n" +
+		"event: content_block_stop\n" +
+		"data: {\"type\":\"content_block_stop\",\"index\":1}\n\n" +
+		"event: message_delta\n" +
+		"data: {\"type\":\"message_delta\",\"delta\":{\"stop_reason\":\"tool_use\"}}\n\n" +
+		"event: message_stop\n" +
+		"data: {\"type\":\"message_stop\"}\n\n"
+
+	out := e.enforceSSE(splitSSELines(stream))
+	got := string(bytes.Join(out, nil))
+
+	if strings.Contains(got, `"type":"tool_use"`) {
+		t.Errorf("expected the tool_use block to be removed, got %q", got)
+	}
+	if strings.Contains(got, "input_json_delta") {
+		t.Errorf("expected the denied call's arguments to be dropped, got %q", got)
+	}
+	if !strings.Contains(got, "event: content_block_delta\ndata: {\"type\":\"content_block_delta\",\"delta\":{\"type\":\"text_delta\"") {
+		t.Errorf("expected the refusal as a text delta event, got %q", got)
+	}
+	if !strings.Contains(got, "\"stop_reason\":\"end_turn\"") {
+		t.Errorf("expected stop_reason end_turn, got %q", got)
+	}
+
+	data := sseDataLines(out)
+	if len(data) != 5 || gjson.Get(data[0], "content_block.type").String() != "text" {
+		t.Errorf("unexpected events: %q", data)
+	}
+}
+
+func TestToolCallEnforcerSSE_ResponsesAPI(t *testing.T) {
+	var logged []types.EnforcementDecisionLog
+	e := newTestToolCallEnforcer(&logged)
+
+	stream := "event: response.output_item.added\n" +
+		"data: {\"type\":\"response.output_item.added\",\"output_index\":0,\"item\":{\"type\":\"function_call\",\"id\":\"fc_1\",\"call_id\":\"call_1\",\"name\":\"shell\",\"arguments\":\"\"}}\n\n" +
+		"event: response.function_call_arguments.delta\n" +
+		"data: {\"type\":\"response.function_call_arguments.delta\",\"output_index\":0,\"delta\":\"{}\"}\n\n" +
+		"event: response.function_call_arguments.done\n" +
+		"data: {\"type\":\"response.function_call_arguments.done\",\"output_index\":0,\"arguments\":\"{}\"}\n\n" +
+		"event: response.output_item.done\n" +
+		"data: {\"type\":\"response.output_item.done\",\"output_index\":0,\"item\":{\"type\":\"function_call\",\"id\":\"fc_1\",\"call_id\":\"call_1\",\"name\":\"shell\",\"arguments\":\"{}\"}}\n\n" +
+		"event: response.completed\n" +
+		"data: {\"type\":\"response.completed\",\"response\":{\"output\":[{\"type\":\"function_call\",\"id\":\"fc_1\",\"call_id\":\"call_1\",\"name\":\"shell\",\"arguments\":\"{}\"}]}}\n\n"
+
+	data := sseDataLines(e.enforceSSE(splitSSELines(stream)))
+	if len(data) != 3 {
+		t.Fatalf("expected the argument events to be dropped, got %q", data)
+	}
+	if gjson.Get(data[0], "item.type").String() != "message" || gjson.Get(data[1], "item.type").String() != "message" {
+		t.Errorf("expected the items to be replaced with messages, got %q", data)
+	}
+	if gjson.Get(data[2], "response.output.0.type").String() != "message" {
+		t.Errorf("expected the completed response to carry the message, got %q", data[2])
+	}
+	if len(logged) != 1 {
+		t.Errorf("expected one decision per call, got %d", len(logged))
+	}
+}
+
+func TestToolCallEnforcerSSE_ChatCompletionsRenumbersAllowedCalls(t *testing.T) {
+	var logged []types.EnforcementDecisionLog
+	e := newTestToolCallEnforcer(&logged)
+
+	stream := "data: {\"choices\":[{\"index\":0,\"delta\":{\"tool_calls\":[{\"index\":0,\"id\":\"call_1\",\"type\":\"function\",\"function\":{\"name\":\"Bash\",\"arguments\":\"\"}}]}}]}\n\n" +
+		"data: {\"choices\":[{\"index\":0,\"delta\":{\"tool_calls\":[{\"index\":0,\"function\":{\"arguments\":\"{}\"}}]}}]}\n\n" +
+		"data: {\"choices\":[{\"index\":0,\"delta\":{\"tool_calls\":[{\"index\":1,\"id\":\"call_2\",\"type\":\"function\",\"function\":{\"name\":\"mcp__docs__search\",\"arguments\":\"\"}}]}}]}\n\n" +
+		"data: {\"choices\":[{\"index\":0,\"delta\":{},\"finish_reason\":\"tool_calls\"}]}\n\n" +
+		"data: [DONE]\n\n"
+
+	data := sseDataLines(e.enforceSSE(splitSSELines(stream)))
+	if len(data) != 5 {
+		t.Fatalf("unexpected events: %q", data)
+	}
+	if gjson.Get(data[0], "choices.0.delta.tool_calls").Exists() || !strings.Contains(gjson.Get(data[0], "choices.0.delta.content").String(), `"Bash"`) {
+		t.Errorf("expected the denied call to become content, got %s", data[0])
+	}
+	if gjson.Get(data[1], "choices.0.delta.tool_calls").Exists() {
+		t.Errorf("expected the denied call's arguments to be dropped, got %s", data[1])
+	}
+	if got := gjson.Get(data[2], "choices.0.delta.tool_calls.0.index").Int(); got != 0 {
+		t.Errorf("expected the allowed call to be renumbered to 0, got %d", got)
+	}
+	if got := gjson.Get(data[3], "choices.0.finish_reason").String(); got != "tool_calls" {
+		t.Errorf("finish_reason = %q, want tool_calls while a call remains", got)
+	}
+	if data[4] != "[DONE]" {
+		t.Errorf("expected the stream terminator to pass through, got %q", data[4])
+	}
+}
+
+func TestStreamAndEvaluateToolCallsSSE_EnforcementWithoutPolicies(t *testing.T) {
+	stream := "event: content_block_start\n" +
+		"data: {\"type\":\"content_block_start\",\"index\":0,\"content_block\":{\"type\":\"text\",\"text\":\"\"}}\n\n" +
+		"event: content_block_stop\n" +
+		"data: {\"type\":\"content_block_stop\",\"index\":0}\n\n" +
+		"event: content_block_start\n" +
+		"data: {\"type\":\"content_block_start\",\"index\":1,\"content_block\":{\"type\":\"tool_use\",\"id\":\"toolu_1\",\"name\":\"Bash\",\"input\":{}}}\n\n" +
+		"event: content_block_stop\n" +
+		"data: {\"type\":\"content_block_stop\",\"index\":1}\n\n" +
+		"event: message_delta\n" +
+		"data: {\"type\":\"message_delta\",\"delta\":{\"stop_reason\":\"tool_use\"}}\n\n"
+
+	var logged []types.EnforcementDecisionLog
+	pr, pw := io.Pipe()
+	r := &responseModifier{
+		stream:           true,
+		b:                bufio.NewReader(strings.NewReader(stream)),
+		c:                io.NopCloser(strings.NewReader("")),
+		toolCallEnforcer: newTestToolCallEnforcer(&logged),
+	}
+
+	go r.streamAndEvaluateToolCalls(t.Context(), pw)
+
+	result, err := io.ReadAll(pr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := string(result)
+	if strings.Contains(got, "\"tool_use\"") {
+		t.Errorf("expected the denied tool_use block to be replaced, got %q", got)
+	}
+	// The held-back event line of the rewritten event is sent exactly once.
+	if n := strings.Count(got, "event: content_block_start\n"); n != 2 {
+		t.Errorf("expected 2 content_block_start event lines, got %d in %q", n, got)
+	}
+	if strings.Contains(got, "obot_tool_call_policy_violation") {
+		t.Errorf("expected no policy violation marker without policies, got %q", got)
+	}
+	if len(logged) != 1 {
+		t.Errorf("expected one logged decision, got %d", len(logged))
+	}
+}
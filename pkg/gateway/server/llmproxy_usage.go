@@ -37,6 +37,14 @@ type responseTokenUsageTracker struct {
 	inputTokens, cachedTokens, output, reasoning int
 }
 
+// chatCompletionsTokenUsageTracker tracks OpenAI Chat Completions usage.
+//
+// Usage buckets match Responses, so only parsing differs. Streaming responses
+// carry usage on a final chunk that the proxy requests via stream_options.
+type chatCompletionsTokenUsageTracker struct {
+	responseTokenUsageTracker
+}
+
 // newTokenUsageTracker chooses a parser that matches the upstream usage shape.
 func newTokenUsageTracker(model v1.Model) *threadSafeTokenUsageTracker {
 	var (
@@ -49,6 +57,8 @@ func newTokenUsageTracker(model v1.Model) *threadSafeTokenUsageTracker {
 		inner = &messageTokenUsageTracker{cost: cost}
 	case nanobottypes.DialectOpenAIResponses, nanobottypes.DialectOpenResponses:
 		inner = &responseTokenUsageTracker{cost: cost}
+	case nanobottypes.DialectOpenAIChatCompletions:
+		inner = &chatCompletionsTokenUsageTracker{responseTokenUsageTracker{cost: cost}}
 	default:
 		return nil
 	}
@@ -172,6 +182,18 @@ func (t *responseTokenUsageTracker) getTokenUsage() types.TokenUsage {
 	return u
 }
 
+func (t *chatCompletionsTokenUsageTracker) addTokenUsage(line []byte) {
+	u := gjson.GetBytes(line, "usage")
+	if !u.IsObject() {
+		return
+	}
+	// prompt_tokens is cache-inclusive, like Responses input_tokens.
+	t.inputTokens = max(t.inputTokens, int(u.Get("prompt_tokens").Int()))
+	t.cachedTokens = max(t.cachedTokens, int(u.Get("prompt_tokens_details.cached_tokens").Int()))
+	t.output = max(t.output, int(u.Get("completion_tokens").Int()))
+	t.reasoning = max(t.reasoning, int(u.Get("completion_tokens_details.reasoning_tokens").Int()))
+}
+
 // costForTier selects the largest applicable context-tier rate.
 func costForTier(cost types2.ModelCost, contextSize int) types2.TokenUsageCost {
 	result := cost.TokenUsageCost
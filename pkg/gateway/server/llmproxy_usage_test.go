@@ -120,6 +120,46 @@ func TestResponseTokenUsageTracker(t *testing.T) {
 	}
 }
 
+func TestChatCompletionsTokenUsageTracker(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines []string
+		want  types.TokenUsage
+	}{
+		{
+			name: "stream usage chunk with cached + reasoning",
+			lines: []string{
+				`{"object":"chat.completion.chunk","choices":[{"index":0,"delta":{"content":"Hi"}}]}`,
+				`{"object":"chat.completion.chunk","choices":[],"usage":{"prompt_tokens":2006,"prompt_tokens_details":{"cached_tokens":1920},"completion_tokens":300,"completion_tokens_details":{"reasoning_tokens":120},"total_tokens":2306}}`,
+			},
+			want: types.TokenUsage{InputTokens: 2006, CacheReadTokens: 1920, OutputTokens: 300, ThinkingTokens: 120, TotalTokens: 2306},
+		},
+		{
+			name: "non-streaming response",
+			lines: []string{
+				`{"object":"chat.completion","usage":{"prompt_tokens":5,"completion_tokens":10,"total_tokens":15}}`,
+			},
+			want: types.TokenUsage{InputTokens: 5, OutputTokens: 10, TotalTokens: 15},
+		},
+		{
+			name: "null usage on content chunks",
+			lines: []string{
+				`{"object":"chat.completion.chunk","choices":[{"index":0,"delta":{"content":"Hi"}}],"usage":null}`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := observeAll(&chatCompletionsTokenUsageTracker{}, tt.lines...)
+			if got != tt.want {
+				t.Errorf("getTokenUsage() = %+v, want %+v", got, tt.want)
+			}
+			assertInvariants(t, got, true)
+		})
+	}
+}
+
 // TestTokenUsageTrackerCosts_WorkedExamples verifies tracker cost math.
 func TestTokenUsageTrackerCosts_WorkedExamples(t *testing.T) {
 	t.Run("anthropic cached + thinking (Opus 4.8)", func(t *testing.T) {
@@ -66,13 +66,28 @@ func (s *Server) AddRoutes(mux *server.Server, tunnelBridge http.Handler) {
 	mux.HandleFunc("/api/oauth/redirect/{namespace}/{name}", wrap(s.redirect))
 
 	// LLM proxy
-	mux.HandleFunc("/api/llm-proxy/openai/{path...}", s.newLLMProviderProxy(mustParseURL(openAIBaseURL), system.OpenAIModelProvider).proxy)
-	mux.HandleFunc("/api/llm-proxy/anthropic/{path...}", s.newLLMProviderProxy(mustParseURL(anthropicBaseURL), system.AnthropicModelProvider).proxy)
-	mux.HandleFunc("/api/llm-proxy/generic-responses/{path...}", s.newGenericResponsesLLMProviderProxy().proxy)
-	mux.HandleFunc("/api/llm-proxy/aws-bedrock/{path...}", s.newAWSBedrockLLMProviderProxy().proxy)
-	mux.HandleFunc("/api/llm-proxy/aws-bedrock-api-key/{path...}", s.newAWSBedrockAPIKeyLLMProviderProxy().proxy)
-	mux.HandleFunc("/api/llm-proxy/azure/{path...}", s.newAzureLLMProviderProxy(system.AzureModelProvider).proxy)
-	mux.HandleFunc("/api/llm-proxy/azure-entra/{path...}", s.newAzureLLMProviderProxy(system.AzureEntraModelProvider).proxy)
+	var (
+		openAIProxy                 = s.newLLMProviderProxy(mustParseURL(openAIBaseURL), system.OpenAIModelProvider)
+		anthropicProxy              = s.newLLMProviderProxy(mustParseURL(anthropicBaseURL), system.AnthropicModelProvider)
+		genericResponsesProxy       = s.newGenericResponsesLLMProviderProxy()
+		genericChatCompletionsProxy = s.newGenericChatCompletionsLLMProviderProxy()
+		awsBedrockProxy             = s.newAWSBedrockLLMProviderProxy()
+		awsBedrockAPIKeyProxy       = s.newAWSBedrockAPIKeyLLMProviderProxy()
+		azureProxy                  = s.newAzureLLMProviderProxy(system.AzureModelProvider)
+		azureEntraProxy             = s.newAzureLLMProviderProxy(system.AzureEntraModelProvider)
+		chatCompletionsProxy        = s.newChatCompletionsProxy(openAIProxy, anthropicProxy, genericResponsesProxy, genericChatCompletionsProxy, awsBedrockProxy, awsBedrockAPIKeyProxy, azureProxy, azureEntraProxy)
+	)
+	mux.HandleFunc("/api/llm-proxy/openai/{path...}", openAIProxy.proxy)
+	mux.HandleFunc("/api/llm-proxy/anthropic/{path...}", anthropicProxy.proxy)
+	mux.HandleFunc("/api/llm-proxy/generic-responses/{path...}", genericResponsesProxy.proxy)
+	mux.HandleFunc("/api/llm-proxy/generic-chat-completions/{path...}", genericChatCompletionsProxy.proxy)
+	mux.HandleFunc("/api/llm-proxy/aws-bedrock/{path...}", awsBedrockProxy.proxy)
+	mux.HandleFunc("/api/llm-proxy/aws-bedrock-api-key/{path...}", awsBedrockAPIKeyProxy.proxy)
+	mux.HandleFunc("/api/llm-proxy/azure/{path...}", azureProxy.proxy)
+	mux.HandleFunc("/api/llm-proxy/azure-entra/{path...}", azureEntraProxy.proxy)
+	// OpenAI Chat Completions in front of every provider above.
+	mux.HandleFunc("POST /api/llm-proxy/v1/chat/completions", chatCompletionsProxy.chatCompletions)
+	mux.HandleFunc("GET /api/llm-proxy/v1/models", chatCompletionsProxy.models)
 
 	// API Keys for MCP server access - user's own keys
 	mux.HandleFunc("POST /api/api-keys", wrap(s.createAPIKey))
@@ -27,6 +27,7 @@ type Server struct {
 	messagePolicyHelper       *messagepolicy.Helper
 	dailyUserInputTokenLimit  int
 	dailyUserOutputTokenLimit int
+	toolCallEnforcement       *toolCallEnforcement
 }
 
 func New(db *db.DB, tokenService *persistent.TokenService, modelProviderDispatcher *dispatcher.Dispatcher, acrHelper *accesscontrolrule.Helper, mapHelper *modelaccesspolicy.Helper, messagePolicyHelper *messagepolicy.Helper, opts Options) (*Server, error) {
@@ -41,6 +42,7 @@ func New(db *db.DB, tokenService *persistent.TokenService, modelProviderDispatch
 		messagePolicyHelper:       messagePolicyHelper,
 		dailyUserInputTokenLimit:  opts.DailyUserInputTokenLimit,
 		dailyUserOutputTokenLimit: opts.DailyUserOutputTokenLimit,
+		toolCallEnforcement:       newToolCallEnforcement(opts.Hostname),
 	}
 
 	return s, nil
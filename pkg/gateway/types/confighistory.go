@@ -0,0 +1,50 @@
+//nolint:revive
+package types
+
+import (
+	"encoding/json"
+	"time"
+
+	types2 "github.com/obot-platform/obot/apiclient/types"
+)
+
+// ConfigChange is one revision in a governance object's change history. The
+// configuration on either side of the change is kept whole, so that any
+// revision can be restored, alongside the diff between them.
+type ConfigChange struct {
+	ID           uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	CreatedAt    time.Time `json:"createdAt" gorm:"index"`
+	Kind         string    `json:"kind" gorm:"index:idx_config_changes_object,priority:1"`
+	ObjectID     string    `json:"objectID" gorm:"index:idx_config_changes_object,priority:2"`
+	Action       string    `json:"action"`
+	ActorID      string    `json:"actorID" gorm:"index"`
+	ActorName    string    `json:"actorName"`
+	RevertedFrom uint      `json:"revertedFrom"`
+	Before       string    `json:"before" gorm:"type:text"`
+	After        string    `json:"after" gorm:"type:text"`
+	Diff         string    `json:"diff" gorm:"type:text"`
+}
+
+func ConvertConfigChange(c ConfigChange) types2.ConfigChange {
+	change := types2.ConfigChange{
+		ID:           c.ID,
+		CreatedAt:    *types2.NewTime(c.CreatedAt),
+		Kind:         c.Kind,
+		ObjectID:     c.ObjectID,
+		Action:       types2.ConfigChangeAction(c.Action),
+		ActorID:      c.ActorID,
+		ActorName:    c.ActorName,
+		RevertedFrom: c.RevertedFrom,
+		Diff:         []types2.ConfigChangeDiff{},
+	}
+	if c.Before != "" {
+		change.Before = json.RawMessage(c.Before)
+	}
+	if c.After != "" {
+		change.After = json.RawMessage(c.After)
+	}
+	if c.Diff != "" {
+		_ = json.Unmarshal([]byte(c.Diff), &change.Diff)
+	}
+	return change
+}
@@ -6,11 +6,20 @@ import (
 )
 
 // EnforcementDecisionLog is one recorded allow/deny decision made by the
-// enforcement decision endpoint for a device's tool call.
+// enforcement decision endpoint for a device's tool call, or by the LLM gateway
+// for a tool call in a model response.
 type EnforcementDecisionLog struct {
 	ID        uint      `json:"id" gorm:"primaryKey;autoIncrement"`
 	CreatedAt time.Time `json:"createdAt" gorm:"index"`
 
+	// Source is where the decision was made: types.EnforcementDecisionSourceDevice
+	// or types.EnforcementDecisionSourceLLMGateway. Rows recorded before the
+	// gateway made decisions have no source and are device decisions.
+	Source string `json:"source,omitempty" gorm:"index"`
+	// UserID is the caller the LLM gateway evaluated the call for. Device
+	// decisions are attributed to the device instead.
+	UserID string `json:"userID,omitempty" gorm:"index"`
+
 	// Fields describing the device:
 	MDMConfigurationID uint   `json:"mdmConfigurationID" gorm:"index"`
 	DeviceID           string `json:"deviceID,omitempty" gorm:"index"`
@@ -0,0 +1,35 @@
+//nolint:revive
+package types
+
+import (
+	"time"
+
+	types2 "github.com/obot-platform/obot/apiclient/types"
+)
+
+// HostedAgentTriggerInvocation is one delivery of a hosted agent trigger, kept
+// as the trigger's activity log.
+type HostedAgentTriggerInvocation struct {
+	ID                    uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	CreatedAt             time.Time `json:"createdAt" gorm:"index"`
+	TriggerID             string    `json:"triggerID" gorm:"index"`
+	HostedAgentInstanceID string    `json:"hostedAgentInstanceID" gorm:"index"`
+	UserID                string    `json:"userID" gorm:"index"`
+	Source                string    `json:"source"`
+	StatusCode            int       `json:"statusCode"`
+	Error                 string    `json:"error"`
+	DurationMillis        int64     `json:"durationMillis"`
+}
+
+func ConvertHostedAgentTriggerInvocation(i HostedAgentTriggerInvocation) types2.HostedAgentTriggerInvocation {
+	return types2.HostedAgentTriggerInvocation{
+		ID:                    i.ID,
+		TriggerID:             i.TriggerID,
+		HostedAgentInstanceID: i.HostedAgentInstanceID,
+		Source:                i.Source,
+		CreatedAt:             *types2.NewTime(i.CreatedAt),
+		StatusCode:            i.StatusCode,
+		Error:                 i.Error,
+		DurationMillis:        i.DurationMillis,
+	}
+}
@@ -45,6 +45,13 @@ type LLMAuditLog struct {
 	ClientSessionID           string `gorm:"type:text;index:idx_llm_audit_client_session_created,priority:1"`
 	ClientIP                  string `gorm:"type:text"`
 	Encrypted                 bool
+	// ContentRedacted is set when secrets or PII were redacted from the payloads.
+	ContentRedacted bool `gorm:"not null;default:false"`
+	// UnredactedRequestPayload and UnredactedResponsePayload hold the original values of the
+	// redacted payload fields when the redaction setting retains them. GORM never reads them, so
+	// they can only be loaded explicitly by privileged callers.
+	UnredactedRequestPayload  json.RawMessage `gorm:"->:false;<-"`
+	UnredactedResponsePayload json.RawMessage `gorm:"->:false;<-"`
 }
 
 func (LLMAuditLog) TableName() string {
@@ -81,5 +88,6 @@ func ConvertLLMAuditLog(a LLMAuditLog) types2.LLMAuditLog {
 		UserAgent:                 a.UserAgent,
 		ClientSessionID:           a.ClientSessionID,
 		ClientIP:                  a.ClientIP,
+		ContentRedacted:           a.ContentRedacted,
 	}
 }
@@ -34,6 +34,13 @@ type MCPAuditLog struct {
 	MCPFields                *MCPAuditLogFields                `json:"mcpFields,omitempty" gorm:"embedded"`
 	LocalAgentToolCallFields *LocalAgentToolCallAuditLogFields `json:"localAgentToolCallFields,omitempty" gorm:"embedded"`
 	Encrypted                bool                              `json:"encrypted"`
+	// ContentRedacted is set when secrets or PII were redacted from the payloads.
+	ContentRedacted bool `json:"contentRedacted" gorm:"not null;default:false"`
+	// UnredactedRequestPayload and UnredactedResponsePayload hold the original values of the
+	// redacted payload fields when the redaction setting retains them. GORM never reads them, so
+	// they can only be loaded explicitly by privileged callers.
+	UnredactedRequestPayload  json.RawMessage `json:"-" gorm:"->:false;<-"`
+	UnredactedResponsePayload json.RawMessage `json:"-" gorm:"->:false;<-"`
 }
 
 type MCPAuditLogFields struct {
@@ -156,6 +163,7 @@ type MCPUsageStatsList struct {
 	TimeStart   time.Time          `json:"timeStart"`
 	TimeEnd     time.Time          `json:"timeEnd"`
 	Items       []MCPUsageStatItem `json:"items"`
+	Quotas      []MCPQuotaUsage    `json:"quotas"`
 }
 
 type MCPToolCallStatsItem struct {
@@ -0,0 +1,57 @@
+//nolint:revive
+package types
+
+import (
+	"time"
+
+	types2 "github.com/obot-platform/obot/apiclient/types"
+)
+
+// MCPQuotaUsage counts the calls made against one MCP call quota in one
+// window. A quota is identified by its rule, caller, server, tool, and window;
+// each window start gets its own row.
+type MCPQuotaUsage struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+	RuleID      string    `json:"ruleID" gorm:"uniqueIndex:idx_mcp_quota_usage_counter,priority:1"`
+	Caller      string    `json:"caller" gorm:"uniqueIndex:idx_mcp_quota_usage_counter,priority:2"`
+	MCPID       string    `json:"mcpID" gorm:"uniqueIndex:idx_mcp_quota_usage_counter,priority:3"`
+	ToolName    string    `json:"toolName" gorm:"uniqueIndex:idx_mcp_quota_usage_counter,priority:4"`
+	Window      string    `json:"window" gorm:"column:quota_window;uniqueIndex:idx_mcp_quota_usage_counter,priority:5"`
+	WindowStart time.Time `json:"windowStart" gorm:"uniqueIndex:idx_mcp_quota_usage_counter,priority:6;index"`
+	UserID      string    `json:"userID" gorm:"index"`
+	Count       int       `json:"count"`
+	Limit       int       `json:"limit" gorm:"column:call_limit"`
+}
+
+// MCPQuotaWindowStart returns the start of the window that contains now.
+func MCPQuotaWindowStart(window types2.MCPQuotaWindow, now time.Time) time.Time {
+	now = now.UTC()
+	if window == types2.MCPQuotaWindowDay {
+		return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	}
+	return now.Truncate(time.Minute)
+}
+
+// MCPQuotaWindowEnd returns when the window that contains now ends.
+func MCPQuotaWindowEnd(window types2.MCPQuotaWindow, now time.Time) time.Time {
+	start := MCPQuotaWindowStart(window, now)
+	if window == types2.MCPQuotaWindowDay {
+		return start.AddDate(0, 0, 1)
+	}
+	return start.Add(time.Minute)
+}
+
+func ConvertMCPQuotaUsage(u MCPQuotaUsage) types2.MCPQuotaUsage {
+	return types2.MCPQuotaUsage{
+		RuleID:   u.RuleID,
+		Caller:   u.Caller,
+		UserID:   u.UserID,
+		MCPID:    u.MCPID,
+		ToolName: u.ToolName,
+		Window:   types2.MCPQuotaWindow(u.Window),
+		Used:     u.Count,
+		Limit:    u.Limit,
+		ResetsAt: *types2.NewTime(MCPQuotaWindowEnd(types2.MCPQuotaWindow(u.Window), u.WindowStart)),
+	}
+}
@@ -18,6 +18,7 @@ import (
 	gogit "github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/cache"
+	"github.com/go-git/go-git/v5/plumbing/transport"
 	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
 	gitfs "github.com/go-git/go-git/v5/storage/filesystem"
 )
@@ -30,6 +31,16 @@ var (
 	errRepoTooLarge = errors.New("repository too large")
 )
 
+// Auth holds the credentials used to clone a repository. Token authenticates
+// HTTPS clones; SSHPrivateKey and SSHKnownHosts authenticate SSH clones.
+type Auth struct {
+	Token string
+	// SSHPrivateKey is a PEM-encoded private key without a passphrase.
+	SSHPrivateKey string
+	// SSHKnownHosts lists the accepted host keys in known_hosts format.
+	SSHKnownHosts string
+}
+
 type cloneAuthAttempt struct {
 	name  string
 	token string
@@ -85,19 +96,26 @@ func ResolveToken(token string) string {
 	return os.Getenv("GITHUB_AUTH_TOKEN")
 }
 
-// Clone clones a git repository over HTTPS into a temporary directory.
+// Clone clones a git repository over HTTPS or SSH into a temporary directory.
 // Returns the directory path, resolved HEAD commit SHA, a cleanup function, and any error.
 //
 // The repoURL may embed a branch via the path (e.g. github.com/org/repo.git/mybranch).
 // If ref is non-empty it overrides any branch embedded in the URL.
-// If token is empty, Clone tries anonymous clone before retrying with GITHUB_AUTH_TOKEN.
-func Clone(ctx context.Context, repoURL, token, ref string) (dir string, commitSHA string, cleanup func(), err error) {
-	if strings.HasPrefix(repoURL, "http://") {
-		return "", "", nil, fmt.Errorf("only HTTPS is supported for git repositories")
-	}
-	if !strings.HasPrefix(repoURL, "https://") {
+// SSH repositories (ssh:// URLs or scp-like git@host:org/repo.git addresses)
+// require auth.SSHPrivateKey and auth.SSHKnownHosts. For HTTPS repositories, if
+// auth.Token is empty, Clone tries anonymous clone before retrying with GITHUB_AUTH_TOKEN.
+func Clone(ctx context.Context, repoURL string, auth Auth, ref string) (dir string, commitSHA string, cleanup func(), err error) {
+	sshRepo := IsSSHURL(repoURL)
+	if sshRepo {
+		if repoURL, err = NormalizeSSHURL(repoURL); err != nil {
+			return "", "", nil, err
+		}
+	} else if strings.HasPrefix(repoURL, "http://") {
+		return "", "", nil, fmt.Errorf("only HTTPS and SSH are supported for git repositories")
+	} else if !strings.HasPrefix(repoURL, "https://") {
 		repoURL = "https://" + repoURL
 	}
+	token := auth.Token
 
 	u, err := url.Parse(repoURL)
 	if err != nil {
@@ -126,8 +144,10 @@ func Clone(ctx context.Context, repoURL, token, ref string) (dir string, commitS
 	// for the clone to start. The sizeLimitedFS below acts as a hard fallback.
 	repoPath := strings.TrimPrefix(cloneURL, "https://"+u.Host+"/")
 	repoPath = strings.TrimSuffix(repoPath, ".git")
-	switch u.Host {
-	case "github.com":
+	switch {
+	case sshRepo:
+		// Platform APIs are not reachable with SSH credentials.
+	case u.Host == "github.com":
 		parts := strings.SplitN(repoPath, "/", 2)
 		if len(parts) == 2 {
 			if err := checkGitHubRepoSize(ctx, parts[0], parts[1], maxRepoSizeMB, apiToken); err != nil {
@@ -137,7 +157,7 @@ func Clone(ctx context.Context, repoURL, token, ref string) (dir string, commitS
 				slog.Warn("GitHub repository size check failed; continuing with clone-time size limit", "repo", repoPath, "error", err)
 			}
 		}
-	case "gitlab.com":
+	case u.Host == "gitlab.com":
 		if err := checkGitLabRepoSize(ctx, u.Host, repoPath, maxRepoSizeMB, token); err != nil {
 			if errors.Is(err, errRepoTooLarge) || isContextError(err) {
 				return "", "", nil, fmt.Errorf("repository size check failed: %w", err)
@@ -153,6 +173,15 @@ func Clone(ctx context.Context, repoURL, token, ref string) (dir string, commitS
 	cleanupFn := func() { _ = os.RemoveAll(parentDir) }
 
 	attempts := cloneAuthAttempts(token, fallbackToken)
+	var sshAuth transport.AuthMethod
+	if sshRepo {
+		sshAuth, err = sshAuthMethod(u, auth, parentDir)
+		if err != nil {
+			cleanupFn()
+			return "", "", nil, err
+		}
+		attempts = []cloneAuthAttempt{{name: "ssh key"}}
+	}
 	refAttempts := cloneRefAttempts(resolvedRef, ref != "")
 	attemptErrs := make([]error, 0, len(attempts)*len(refAttempts))
 	for _, attempt := range attempts {
@@ -169,7 +198,9 @@ func Clone(ctx context.Context, repoURL, token, ref string) (dir string, commitS
 				ReferenceName: refAttempt.referenceName,
 			}
 
-			if attempt.token != "" {
+			if sshAuth != nil {
+				cloneOptions.Auth = sshAuth
+			} else if attempt.token != "" {
 				cloneOptions.Auth = &githttp.BasicAuth{
 					Username: "x-access-token", // Accepted as a dummy username by GitHub and GitLab.
 					Password: attempt.token,
@@ -282,7 +313,14 @@ func parseGitURL(repoURL string) (string, string, error) {
 		branch = "main"
 	}
 
-	return fmt.Sprintf("https://%s/%s", u.Host, repoPath), branch, nil
+	cloneURL := url.URL{Scheme: u.Scheme, Host: u.Host, Path: "/" + repoPath}
+	// An SSH URL's user is the account to connect as, not a secret. HTTPS
+	// credentials come from Auth, and are kept out of a URL that ends up in logs
+	// and errors.
+	if u.Scheme == "ssh" {
+		cloneURL.User = u.User
+	}
+	return cloneURL.String(), branch, nil
 }
 
 func validateBranchName(branch string) error {
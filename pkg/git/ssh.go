@@ -0,0 +1,90 @@
+package git
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	gitssh "github.com/go-git/go-git/v5/plumbing/transport/ssh"
+)
+
+const defaultSSHUser = "git"
+
+// scpLikeURL matches the scp-like syntax git accepts for SSH remotes, e.g.
+// git@github.com:org/repo.git. The user is required so that host:port
+// shorthands for HTTPS URLs are not mistaken for SSH addresses.
+var scpLikeURL = regexp.MustCompile(`^([A-Za-z0-9._-]+)@([A-Za-z0-9.-]+):([^/].*)$`)
+
+// IsSSHURL returns true if repoURL is an ssh:// URL or an scp-like SSH address.
+func IsSSHURL(repoURL string) bool {
+	repoURL = strings.TrimSpace(repoURL)
+	return strings.HasPrefix(repoURL, "ssh://") || scpLikeURL.MatchString(repoURL)
+}
+
+// NormalizeSSHURL validates an SSH repository address and returns it as an
+// ssh:// URL. scp-like addresses (git@host:org/repo.git) are converted to
+// ssh://git@host/org/repo.git.
+func NormalizeSSHURL(repoURL string) (string, error) {
+	repoURL = strings.TrimSpace(repoURL)
+	if m := scpLikeURL.FindStringSubmatch(repoURL); m != nil {
+		repoURL = "ssh://" + m[1] + "@" + m[2] + "/" + m[3]
+	}
+
+	u, err := url.Parse(repoURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid SSH repository URL: %w", err)
+	}
+	if u.Scheme != "ssh" || u.Hostname() == "" {
+		return "", fmt.Errorf("invalid SSH repository URL %q", repoURL)
+	}
+	if _, ok := u.User.Password(); ok {
+		return "", fmt.Errorf("SSH repository URL must not include a password")
+	}
+	if u.RawQuery != "" || u.Fragment != "" {
+		return "", fmt.Errorf("SSH repository URL must not include a query or fragment")
+	}
+	return u.String(), nil
+}
+
+// sshAuthMethod authenticates as the user of u with the private key in auth,
+// accepting only the host keys listed in auth.SSHKnownHosts. The known hosts
+// are written to a file in dir, which the caller removes.
+func sshAuthMethod(u *url.URL, auth Auth, dir string) (transport.AuthMethod, error) {
+	if auth.SSHPrivateKey == "" {
+		return nil, fmt.Errorf("an SSH private key is required to clone %s", u.Host)
+	}
+	if strings.TrimSpace(auth.SSHKnownHosts) == "" {
+		return nil, fmt.Errorf("known hosts are required to clone %s over SSH", u.Host)
+	}
+
+	user := u.User.Username()
+	if user == "" {
+		user = defaultSSHUser
+	}
+	publicKeys, err := gitssh.NewPublicKeys(user, []byte(auth.SSHPrivateKey), "")
+	if err != nil {
+		return nil, fmt.Errorf("invalid SSH private key: %w", err)
+	}
+
+	knownHostsFile := filepath.Join(dir, "known_hosts")
+	if err := os.WriteFile(knownHostsFile, []byte(auth.SSHKnownHosts), 0o600); err != nil {
+		return nil, fmt.Errorf("failed to write known hosts: %w", err)
+	}
+	db, err := gitssh.NewKnownHostsDb(knownHostsFile)
+	if err != nil {
+		return nil, fmt.Errorf("invalid known hosts: %w", err)
+	}
+
+	port := u.Port()
+	if port == "" {
+		port = "22"
+	}
+	publicKeys.HostKeyCallback = db.HostKeyCallback()
+	publicKeys.HostKeyAlgorithms = db.HostKeyAlgorithms(net.JoinHostPort(u.Hostname(), port))
+	return publicKeys, nil
+}
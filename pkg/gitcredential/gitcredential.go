@@ -4,15 +4,18 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"net/url"
 	"sort"
 	"strconv"
 	"strings"
 
+	"github.com/obot-platform/obot/apiclient/types"
 	gclient "github.com/obot-platform/obot/pkg/gateway/client"
 	gatewaytypes "github.com/obot-platform/obot/pkg/gateway/types"
 	obotgit "github.com/obot-platform/obot/pkg/git"
 	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	"golang.org/x/crypto/ssh"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	kclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -20,6 +23,7 @@ import (
 const (
 	credentialContext = "git-credentials"
 	tokenKey          = "token"
+	privateKeyKey     = "privateKey"
 )
 
 var (
@@ -50,7 +54,54 @@ func NormalizeHost(value string) (string, error) {
 	return strings.ToLower(u.Hostname()), nil
 }
 
-func validateSourceURL(sourceURL, host string) error {
+// ValidateSSHPrivateKey returns an error if privateKey is not an unencrypted PEM-encoded SSH key.
+func ValidateSSHPrivateKey(privateKey string) error {
+	_, err := ssh.ParsePrivateKey([]byte(privateKey))
+	if _, ok := err.(*ssh.PassphraseMissingError); ok {
+		return fmt.Errorf("passphrase-protected SSH private keys are not supported")
+	}
+	if err != nil {
+		return fmt.Errorf("invalid SSH private key: %w", err)
+	}
+	return nil
+}
+
+// ValidateKnownHosts returns an error if knownHosts is not in known_hosts format
+// or lists no host keys.
+func ValidateKnownHosts(knownHosts string) error {
+	rest := []byte(knownHosts)
+	entries := 0
+	for {
+		_, _, _, _, remaining, err := ssh.ParseKnownHosts(rest)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("invalid known hosts: %w", err)
+		}
+		entries++
+		rest = remaining
+	}
+	if entries == 0 {
+		return fmt.Errorf("known hosts must list at least one host key")
+	}
+	return nil
+}
+
+func validateSourceURL(sourceURL, host string, credentialType types.GitCredentialType) error {
+	sshURL := obotgit.IsSSHURL(sourceURL)
+	if credentialType == types.GitCredentialTypeSSHKey {
+		if !sshURL {
+			return fmt.Errorf("source URL %q must use SSH to be used with an SSH key credential", sourceURL)
+		}
+		normalized, err := obotgit.NormalizeSSHURL(sourceURL)
+		if err != nil {
+			return err
+		}
+		sourceURL = normalized
+	} else if sshURL {
+		return fmt.Errorf("source URL %q must use HTTPS", sourceURL)
+	}
 	if !obotgit.IsGitRepoURL(sourceURL) {
 		return fmt.Errorf("source URL %q is not a Git repository URL", sourceURL)
 	}
@@ -58,7 +109,7 @@ func validateSourceURL(sourceURL, host string) error {
 	if err != nil {
 		return fmt.Errorf("invalid source URL %q: %w", sourceURL, err)
 	}
-	if u.Scheme != "https" {
+	if credentialType != types.GitCredentialTypeSSHKey && u.Scheme != "https" {
 		return fmt.Errorf("source URL %q must use HTTPS", sourceURL)
 	}
 	normalizedSourceHost, err := NormalizeHost(u.Host)
@@ -83,7 +134,19 @@ func Store(ctx context.Context, gatewayClient *gclient.Client, credentialID, tok
 	})
 }
 
-// Configured reports whether the identified Git credential has a stored token.
+// StorePrivateKey saves the private key of the identified SSH key or GitHub App credential.
+func StorePrivateKey(ctx context.Context, gatewayClient *gclient.Client, credentialID, privateKey string) error {
+	if privateKey == "" {
+		return fmt.Errorf("private key is required")
+	}
+	return gatewayClient.UpsertCredential(ctx, gatewaytypes.Credential{
+		Context: credentialContext,
+		Name:    credentialID,
+		Secrets: map[string]string{privateKeyKey: privateKey},
+	})
+}
+
+// Configured reports whether the identified Git credential has a stored token or private key.
 func Configured(ctx context.Context, gatewayClient *gclient.Client, credentialID string) (bool, error) {
 	credential, err := gatewayClient.RevealCredential(ctx, []string{credentialContext}, credentialID)
 	if errors.As(err, &gclient.CredentialNotFoundError{}) {
@@ -92,58 +155,80 @@ func Configured(ctx context.Context, gatewayClient *gclient.Client, credentialID
 	if err != nil {
 		return false, err
 	}
-	return credential.Secrets[tokenKey] != "", nil
+	return credential.Secrets[tokenKey] != "" || credential.Secrets[privateKeyKey] != "", nil
 }
 
 // ResolveOrReveal resolves a shared Git credential or falls back to a legacy source-specific token.
-func ResolveOrReveal(ctx context.Context, storageClient kclient.Client, gatewayClient *gclient.Client, namespace, credentialID, sourceURL, legacyCredentialContext, legacyToolName string) (string, error) {
+func ResolveOrReveal(ctx context.Context, storageClient kclient.Client, gatewayClient *gclient.Client, namespace, credentialID, sourceURL, legacyCredentialContext, legacyToolName string) (obotgit.Auth, error) {
 	if credentialID != "" {
 		return Resolve(ctx, storageClient, gatewayClient, namespace, credentialID, sourceURL)
 	}
 	credential, err := gatewayClient.RevealCredential(ctx, []string{legacyCredentialContext}, legacyToolName)
 	if errors.As(err, &gclient.CredentialNotFoundError{}) {
-		return "", nil
+		return obotgit.Auth{}, nil
 	}
 	if err != nil {
-		return "", fmt.Errorf("%w: %w", ErrLegacyCredential, err)
+		return obotgit.Auth{}, fmt.Errorf("%w: %w", ErrLegacyCredential, err)
 	}
-	return credential.Secrets[sourceURL], nil
+	return obotgit.Auth{Token: credential.Secrets[sourceURL]}, nil
 }
 
-// Delete removes the stored token for the identified Git credential.
+// Delete removes the stored secrets of the identified Git credential.
 func Delete(ctx context.Context, gatewayClient *gclient.Client, credentialID string) error {
 	_, err := gatewayClient.DeleteCredential(ctx, credentialContext, credentialID)
 	return err
 }
 
-// Resolve validates a Git credential for a source URL and returns its stored token.
-func Resolve(ctx context.Context, storageClient kclient.Client, gatewayClient *gclient.Client, namespace, credentialID, sourceURL string) (string, error) {
+// Resolve validates a Git credential for a source URL and returns the
+// authentication it provides. GitHub App credentials resolve to a freshly
+// minted installation token.
+func Resolve(ctx context.Context, storageClient kclient.Client, gatewayClient *gclient.Client, namespace, credentialID, sourceURL string) (obotgit.Auth, error) {
 	if credentialID == "" {
-		return "", nil
+		return obotgit.Auth{}, nil
 	}
 
 	var credential v1.GitCredential
 	if err := storageClient.Get(ctx, kclient.ObjectKey{Namespace: namespace, Name: credentialID}, &credential); err != nil {
 		if apierrors.IsNotFound(err) {
-			return "", fmt.Errorf("git credential %q does not exist", credentialID)
+			return obotgit.Auth{}, fmt.Errorf("git credential %q does not exist", credentialID)
 		}
-		return "", fmt.Errorf("failed to get Git credential %q: %w", credentialID, err)
+		return obotgit.Auth{}, fmt.Errorf("failed to get Git credential %q: %w", credentialID, err)
 	}
 	if !credential.DeletionTimestamp.IsZero() {
-		return "", fmt.Errorf("git credential %q is being deleted", credentialID)
+		return obotgit.Auth{}, fmt.Errorf("git credential %q is being deleted", credentialID)
 	}
-	if err := validateSourceURL(sourceURL, credential.Spec.Host); err != nil {
-		return "", err
+	if err := validateSourceURL(sourceURL, credential.Spec.Host, credential.Spec.Type); err != nil {
+		return obotgit.Auth{}, err
 	}
 	storedCredential, err := gatewayClient.RevealCredential(ctx, []string{credentialContext}, credentialID)
 	if err != nil {
-		return "", fmt.Errorf("failed to reveal Git credential %q: %w", credentialID, err)
+		return obotgit.Auth{}, fmt.Errorf("failed to reveal Git credential %q: %w", credentialID, err)
 	}
-	token := storedCredential.Secrets[tokenKey]
-	if token == "" {
-		return "", fmt.Errorf("git credential %q has no token configured", credentialID)
+
+	switch credential.Spec.Type {
+	case types.GitCredentialTypeSSHKey:
+		privateKey := storedCredential.Secrets[privateKeyKey]
+		if privateKey == "" {
+			return obotgit.Auth{}, fmt.Errorf("git credential %q has no private key configured", credentialID)
+		}
+		return obotgit.Auth{SSHPrivateKey: privateKey, SSHKnownHosts: credential.Spec.KnownHosts}, nil
+	case types.GitCredentialTypeGitHubApp:
+		privateKey := storedCredential.Secrets[privateKeyKey]
+		if privateKey == "" {
+			return obotgit.Auth{}, fmt.Errorf("git credential %q has no private key configured", credentialID)
+		}
+		token, err := githubAppInstallationToken(ctx, credential.Spec.Host, credential.Spec.GitHubAppID, credential.Spec.GitHubAppInstallationID, privateKey)
+		if err != nil {
+			return obotgit.Auth{}, fmt.Errorf("failed to get installation token for Git credential %q: %w", credentialID, err)
+		}
+		return obotgit.Auth{Token: token}, nil
+	default:
+		token := storedCredential.Secrets[tokenKey]
+		if token == "" {
+			return obotgit.Auth{}, fmt.Errorf("git credential %q has no token configured", credentialID)
+		}
+		return obotgit.Auth{Token: token}, nil
 	}
-	return token, nil
 }
 
 // References lists resources in the namespace that use the identified Git credential.
@@ -191,6 +276,18 @@ func References(ctx context.Context, storageClient kclient.Client, namespace, cr
 			}
 		}
 	}
+	var configRepositories v1.ConfigRepositoryList
+	if err := storageClient.List(ctx, &configRepositories, kclient.InNamespace(namespace)); err != nil {
+		return references, fmt.Errorf("failed to list config repositories: %w", err)
+	}
+	for _, repository := range configRepositories.Items {
+		if repository.Spec.GitCredentialID == credentialID {
+			references.ConfigRepositories = append(references.ConfigRepositories, v1.GitCredentialReference{
+				ID:          repository.Name,
+				DisplayName: repository.Spec.DisplayName,
+			})
+		}
+	}
 	// Catalog references come from maps, so sort every group to keep status updates deterministic.
 	sortReferences := func(references []v1.GitCredentialReference) {
 		sort.Slice(references, func(i, j int) bool {
@@ -203,5 +300,6 @@ func References(ctx context.Context, storageClient kclient.Client, namespace, cr
 	sortReferences(references.SkillRepositories)
 	sortReferences(references.MCPCatalogs)
 	sortReferences(references.SystemMCPCatalogs)
+	sortReferences(references.ConfigRepositories)
 	return references, nil
 }
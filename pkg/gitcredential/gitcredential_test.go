@@ -2,18 +2,24 @@ package gitcredential
 
 import (
 	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
 	"testing"
 	"time"
 
+	"github.com/obot-platform/obot/apiclient/types"
 	gatewayclient "github.com/obot-platform/obot/pkg/gateway/client"
 	gatewaydb "github.com/obot-platform/obot/pkg/gateway/db"
 	gatewaytypes "github.com/obot-platform/obot/pkg/gateway/types"
+	obotgit "github.com/obot-platform/obot/pkg/git"
 	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
 	storagescheme "github.com/obot-platform/obot/pkg/storage/scheme"
 	sservices "github.com/obot-platform/obot/pkg/storage/services"
 	"github.com/obot-platform/obot/pkg/system"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
@@ -45,11 +51,18 @@ func TestNormalizeHost(t *testing.T) {
 }
 
 func TestValidateSourceURL(t *testing.T) {
-	require.NoError(t, validateSourceURL("https://github.com/obot-platform/obot", "github.com"))
-	require.NoError(t, validateSourceURL("https://git.example.com:8443/team/repo.git", "git.example.com:8443"))
-	assert.ErrorContains(t, validateSourceURL("https://gitlab.com/team/repo", "github.com"), "cannot be used")
-	assert.ErrorContains(t, validateSourceURL("http://github.com/obot-platform/obot", "github.com"), "must use HTTPS")
-	assert.ErrorContains(t, validateSourceURL("https://example.com/catalog.yaml", "example.com"), "not a Git repository")
+	require.NoError(t, validateSourceURL("https://github.com/obot-platform/obot", "github.com", types.GitCredentialTypeToken))
+	require.NoError(t, validateSourceURL("https://git.example.com:8443/team/repo.git", "git.example.com:8443", ""))
+	require.NoError(t, validateSourceURL("https://github.com/obot-platform/obot", "github.com", types.GitCredentialTypeGitHubApp))
+	assert.ErrorContains(t, validateSourceURL("https://gitlab.com/team/repo", "github.com", types.GitCredentialTypeToken), "cannot be used")
+	assert.ErrorContains(t, validateSourceURL("http://github.com/obot-platform/obot", "github.com", types.GitCredentialTypeToken), "must use HTTPS")
+	assert.ErrorContains(t, validateSourceURL("https://example.com/catalog.yaml", "example.com", types.GitCredentialTypeToken), "not a Git repository")
+	assert.ErrorContains(t, validateSourceURL("git@github.com:obot-platform/obot.git", "github.com", types.GitCredentialTypeToken), "must use HTTPS")
+
+	require.NoError(t, validateSourceURL("git@github.com:obot-platform/obot.git", "github.com", types.GitCredentialTypeSSHKey))
+	require.NoError(t, validateSourceURL("ssh://git@git.example.com:2222/team/repo.git", "git.example.com:2222", types.GitCredentialTypeSSHKey))
+	assert.ErrorContains(t, validateSourceURL("ssh://git@git.example.com:2222/team/repo.git", "git.example.com", types.GitCredentialTypeSSHKey), "cannot be used")
+	assert.ErrorContains(t, validateSourceURL("https://github.com/obot-platform/obot", "github.com", types.GitCredentialTypeSSHKey), "must use SSH")
 }
 
 func TestConfigured(t *testing.T) {
@@ -93,9 +106,9 @@ func TestResolveSharedCredential(t *testing.T) {
 		"https://github.com/obot-platform/skills",
 		"https://github.com/obot-platform/catalog.git",
 	} {
-		token, err := Resolve(ctx, repositoryClient, gatewayClient, system.DefaultNamespace, "gc1-test", sourceURL)
+		auth, err := Resolve(ctx, repositoryClient, gatewayClient, system.DefaultNamespace, "gc1-test", sourceURL)
 		require.NoError(t, err)
-		assert.Equal(t, "shared-token", token)
+		assert.Equal(t, obotgit.Auth{Token: "shared-token"}, auth)
 	}
 
 	_, err := Resolve(ctx, repositoryClient, gatewayClient, system.DefaultNamespace, "gc1-test", "https://gitlab.com/obot-platform/catalog")
@@ -118,6 +131,52 @@ func TestResolveRejectsCredentialWithoutToken(t *testing.T) {
 	require.ErrorContains(t, err, "no token configured")
 }
 
+func TestResolveSSHKeyCredential(t *testing.T) {
+	ctx := context.Background()
+	gatewayClient := newTestGatewayClient(t)
+	const knownHosts = "github.com ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIOMqqnkVzrm0SdG6UOoqKLsabgH5C9okWi0dh2l9GKJl\n"
+	repositoryClient := fake.NewClientBuilder().WithScheme(storagescheme.Scheme).WithObjects(&v1.GitCredential{
+		Name: "gc1-ssh", Namespace: system.DefaultNamespace,
+		Spec: v1.GitCredentialSpec{DisplayName: "Deploy key", Host: "github.com", Type: types.GitCredentialTypeSSHKey, KnownHosts: knownHosts},
+	}).Build()
+
+	_, err := Resolve(ctx, repositoryClient, gatewayClient, system.DefaultNamespace, "gc1-ssh", "git@github.com:obot-platform/skills.git")
+	require.Error(t, err)
+
+	require.NoError(t, StorePrivateKey(ctx, gatewayClient, "gc1-ssh", "private-key"))
+	configured, err := Configured(ctx, gatewayClient, "gc1-ssh")
+	require.NoError(t, err)
+	assert.True(t, configured)
+
+	auth, err := Resolve(ctx, repositoryClient, gatewayClient, system.DefaultNamespace, "gc1-ssh", "git@github.com:obot-platform/skills.git")
+	require.NoError(t, err)
+	assert.Equal(t, obotgit.Auth{SSHPrivateKey: "private-key", SSHKnownHosts: knownHosts}, auth)
+
+	_, err = Resolve(ctx, repositoryClient, gatewayClient, system.DefaultNamespace, "gc1-ssh", "https://github.com/obot-platform/skills")
+	assert.ErrorContains(t, err, "must use SSH")
+}
+
+func TestValidateSSHPrivateKey(t *testing.T) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	block, err := ssh.MarshalPrivateKey(key, "")
+	require.NoError(t, err)
+	require.NoError(t, ValidateSSHPrivateKey(string(pem.EncodeToMemory(block))))
+
+	block, err = ssh.MarshalPrivateKeyWithPassphrase(key, "", []byte("secret"))
+	require.NoError(t, err)
+	assert.ErrorContains(t, ValidateSSHPrivateKey(string(pem.EncodeToMemory(block))), "passphrase")
+
+	assert.ErrorContains(t, ValidateSSHPrivateKey("not a key"), "invalid SSH private key")
+}
+
+func TestValidateKnownHosts(t *testing.T) {
+	require.NoError(t, ValidateKnownHosts("# GitHub\ngithub.com ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIOMqqnkVzrm0SdG6UOoqKLsabgH5C9okWi0dh2l9GKJl\n"))
+	assert.ErrorContains(t, ValidateKnownHosts("# nothing here\n"), "at least one host key")
+	assert.ErrorContains(t, ValidateKnownHosts("github.com not-a-key"), "invalid known hosts")
+}
+
 func TestResolveOrReveal(t *testing.T) {
 	ctx := t.Context()
 	storageClient := fake.NewClientBuilder().WithScheme(storagescheme.Scheme).WithObjects(&v1.GitCredential{
@@ -134,24 +193,24 @@ func TestResolveOrReveal(t *testing.T) {
 			Secrets: map[string]string{sourceURL: "legacy-token"},
 		}))
 
-		token, err := ResolveOrReveal(ctx, storageClient, gatewayClient, system.DefaultNamespace, "", sourceURL, "repo1", "legacy-tool")
+		auth, err := ResolveOrReveal(ctx, storageClient, gatewayClient, system.DefaultNamespace, "", sourceURL, "repo1", "legacy-tool")
 		require.NoError(t, err)
-		assert.Equal(t, "legacy-token", token)
+		assert.Equal(t, obotgit.Auth{Token: "legacy-token"}, auth)
 	})
 
 	t.Run("missing legacy token falls back without authentication", func(t *testing.T) {
-		token, err := ResolveOrReveal(ctx, storageClient, newTestGatewayClient(t), system.DefaultNamespace, "", sourceURL, "repo1", "legacy-tool")
+		auth, err := ResolveOrReveal(ctx, storageClient, newTestGatewayClient(t), system.DefaultNamespace, "", sourceURL, "repo1", "legacy-tool")
 		require.NoError(t, err)
-		assert.Empty(t, token)
+		assert.Empty(t, auth)
 	})
 
 	t.Run("legacy backend failure returns matchable error", func(t *testing.T) {
 		gatewayClient := newTestGatewayClient(t)
 		require.NoError(t, gatewayClient.Close())
 
-		token, err := ResolveOrReveal(ctx, storageClient, gatewayClient, system.DefaultNamespace, "", sourceURL, "repo1", "legacy-tool")
+		auth, err := ResolveOrReveal(ctx, storageClient, gatewayClient, system.DefaultNamespace, "", sourceURL, "repo1", "legacy-tool")
 		require.ErrorIs(t, err, ErrLegacyCredential)
-		assert.Empty(t, token)
+		assert.Empty(t, auth)
 	})
 
 	t.Run("shared credential backend failure remains fatal", func(t *testing.T) {
@@ -0,0 +1,123 @@
+package gitcredential
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// installationTokenRenewMargin is how long before expiry a cached installation
+// token is replaced, so that a clone never starts with a token about to lapse.
+const installationTokenRenewMargin = 5 * time.Minute
+
+var (
+	githubHTTPClient = &http.Client{Timeout: 10 * time.Second}
+
+	installationTokensLock sync.Mutex
+	installationTokens     = map[string]installationToken{}
+)
+
+type installationToken struct {
+	token     string
+	expiresAt time.Time
+}
+
+// githubAPIURL returns the REST API root of the GitHub instance at host.
+func githubAPIURL(host string) string {
+	if host == "github.com" {
+		return "https://api.github.com"
+	}
+	return "https://" + host + "/api/v3"
+}
+
+// ValidateGitHubAppPrivateKey returns an error if privateKey is not a PEM-encoded RSA key.
+func ValidateGitHubAppPrivateKey(privateKey string) error {
+	if _, err := jwt.ParseRSAPrivateKeyFromPEM([]byte(privateKey)); err != nil {
+		return fmt.Errorf("invalid GitHub App private key: %w", err)
+	}
+	return nil
+}
+
+// ValidateGitHubAppIDs returns an error if the app or installation ID is not a positive number.
+func ValidateGitHubAppIDs(appID, installationID string) error {
+	if id, err := strconv.ParseInt(appID, 10, 64); err != nil || id <= 0 {
+		return fmt.Errorf("GitHub App ID must be a positive number")
+	}
+	if id, err := strconv.ParseInt(installationID, 10, 64); err != nil || id <= 0 {
+		return fmt.Errorf("GitHub App installation ID must be a positive number")
+	}
+	return nil
+}
+
+// githubAppInstallationToken returns an installation access token for the
+// app installation, minting a new one when no cached token is fresh.
+func githubAppInstallationToken(ctx context.Context, host, appID, installationID, privateKey string) (string, error) {
+	keyHash := sha256.Sum256([]byte(privateKey))
+	cacheKey := host + "/" + appID + "/" + installationID + "/" + hex.EncodeToString(keyHash[:])
+
+	installationTokensLock.Lock()
+	cached, ok := installationTokens[cacheKey]
+	installationTokensLock.Unlock()
+	if ok && time.Now().Add(installationTokenRenewMargin).Before(cached.expiresAt) {
+		return cached.token, nil
+	}
+
+	key, err := jwt.ParseRSAPrivateKeyFromPEM([]byte(privateKey))
+	if err != nil {
+		return "", fmt.Errorf("invalid GitHub App private key: %w", err)
+	}
+	// GitHub rejects app JWTs valid for more than ten minutes. Backdate the
+	// issue time to allow for clock drift.
+	now := time.Now()
+	appJWT, err := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.RegisteredClaims{
+		Issuer:    appID,
+		IssuedAt:  jwt.NewNumericDate(now.Add(-time.Minute)),
+		ExpiresAt: jwt.NewNumericDate(now.Add(9 * time.Minute)),
+	}).SignedString(key)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign GitHub App JWT: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/app/installations/%s/access_tokens", githubAPIURL(host), installationID), http.NoBody)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+appJWT)
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := githubHTTPClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to request GitHub App installation token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return "", fmt.Errorf("GitHub returned status %d for installation %s of app %s: %s", resp.StatusCode, installationID, appID, body)
+	}
+
+	var result struct {
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to parse GitHub App installation token: %w", err)
+	}
+	if result.Token == "" {
+		return "", fmt.Errorf("GitHub returned no installation token for installation %s of app %s", installationID, appID)
+	}
+
+	installationTokensLock.Lock()
+	installationTokens[cacheKey] = installationToken{token: result.Token, expiresAt: result.ExpiresAt}
+	installationTokensLock.Unlock()
+	return result.Token, nil
+}
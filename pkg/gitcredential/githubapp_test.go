@@ -0,0 +1,76 @@
+package gitcredential
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitHubAppInstallationToken(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	privateKey := string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}))
+	require.NoError(t, ValidateGitHubAppPrivateKey(privateKey))
+
+	var requests int
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v3/app/installations/42/access_tokens" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		appJWT, err := jwt.Parse(strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "), func(*jwt.Token) (any, error) {
+			return &key.PublicKey, nil
+		}, jwt.WithValidMethods([]string{"RS256"}))
+		if !assert.NoError(t, err) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		issuer, _ := appJWT.Claims.GetIssuer()
+		assert.Equal(t, "7", issuer)
+
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"token":      "ghs_installation",
+			"expires_at": time.Now().Add(time.Hour),
+		})
+	}))
+	defer server.Close()
+
+	originalClient := githubHTTPClient
+	githubHTTPClient = server.Client()
+	t.Cleanup(func() { githubHTTPClient = originalClient })
+	host := strings.TrimPrefix(server.URL, "https://")
+
+	token, err := githubAppInstallationToken(t.Context(), host, "7", "42", privateKey)
+	require.NoError(t, err)
+	assert.Equal(t, "ghs_installation", token)
+
+	// The token is reused until shortly before it expires.
+	token, err = githubAppInstallationToken(t.Context(), host, "7", "42", privateKey)
+	require.NoError(t, err)
+	assert.Equal(t, "ghs_installation", token)
+	assert.Equal(t, 1, requests)
+
+	_, err = githubAppInstallationToken(t.Context(), host, "7", "43", privateKey)
+	assert.ErrorContains(t, err, "status 404")
+}
+
+func TestValidateGitHubAppIDs(t *testing.T) {
+	require.NoError(t, ValidateGitHubAppIDs("7", "42"))
+	assert.ErrorContains(t, ValidateGitHubAppIDs("app", "42"), "App ID")
+	assert.ErrorContains(t, ValidateGitHubAppIDs("7", "0"), "installation ID")
+	assert.ErrorContains(t, ValidateGitHubAppPrivateKey("not a key"), "invalid GitHub App private key")
+}
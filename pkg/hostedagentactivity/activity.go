@@ -0,0 +1,113 @@
+// Package hostedagentactivity records when a hosted agent instance was last
+// used, and starts one again that hibernated for going unused.
+//
+// Activity is a terminal session or an HTTP request through agent-connect.
+// The controller compares it against the idle timeout to decide when to
+// hibernate; anything that uses an instance records activity here, so a
+// hibernated instance wakes the moment it is needed again.
+package hostedagentactivity
+
+import (
+	"context"
+	"time"
+
+	"github.com/obot-platform/obot/apiclient/types"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// Resolution is how stale a recorded activity time may be. A busy agent
+	// serves many requests a second, and writing status for each would turn
+	// every one into a storage write.
+	Resolution = time.Minute
+
+	wakePollInterval = time.Second
+)
+
+// IdleTimeout is how long an instance may go unused before it hibernates. An
+// agent's own timeout wins over its pool's, because the agent author knows
+// whether it does background work. Zero means never.
+func IdleTimeout(agent types.HostedAgentManifest, pool types.HostedAgentPoolManifest) time.Duration {
+	if agent.IdleTimeoutMinutes > 0 {
+		return time.Duration(agent.IdleTimeoutMinutes) * time.Minute
+	}
+	return time.Duration(pool.IdleTimeoutMinutes) * time.Minute
+}
+
+// IdleSince is when the instance was last used. An instance nobody has used
+// yet counts from its creation, so one that is launched and forgotten still
+// hibernates.
+func IdleSince(instance *v1.HostedAgentInstance) time.Time {
+	if last := instance.Status.LastActivityTime; last != nil && last.After(instance.CreationTimestamp.Time) {
+		return last.Time
+	}
+	return instance.CreationTimestamp.Time
+}
+
+// Touch records activity on the instance. Activity on a hibernated instance
+// also wakes it, since activity is exactly what it hibernated for lacking.
+//
+// It writes at most once per Resolution, and only reads storage when it does.
+// On a write the instance is updated in place.
+func Touch(ctx context.Context, c kclient.Client, instance *v1.HostedAgentInstance) error {
+	now := time.Now()
+	if !instance.Status.Hibernated && instance.Status.LastActivityTime != nil &&
+		now.Sub(instance.Status.LastActivityTime.Time) < Resolution {
+		return nil
+	}
+
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		var current v1.HostedAgentInstance
+		if err := c.Get(ctx, kclient.ObjectKeyFromObject(instance), &current); err != nil {
+			return err
+		}
+		current.Status.Hibernated = false
+		current.Status.LastActivityTime = &metav1.Time{Time: now}
+		if err := c.Status().Update(ctx, &current); err != nil {
+			return err
+		}
+		*instance = current
+		return nil
+	})
+}
+
+// WaitReady polls the instance until it is ready to serve or the timeout
+// elapses, and reports which. The instance is updated in place with the last
+// observation either way.
+//
+// An instance in error is not waited on: it will not become ready by itself
+// within any timeout a caller is willing to hold a request for.
+func WaitReady(ctx context.Context, c kclient.Client, instance *v1.HostedAgentInstance, timeout time.Duration) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(wakePollInterval)
+	defer ticker.Stop()
+	for {
+		switch {
+		case instance.Status.State == types.HostedAgentStateReady && instance.Status.URL != "":
+			return true, nil
+		case instance.Status.State == types.HostedAgentStateError:
+			return false, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			if ctx.Err() == context.DeadlineExceeded {
+				return false, nil
+			}
+			return false, ctx.Err()
+		case <-ticker.C:
+		}
+
+		if err := c.Get(ctx, kclient.ObjectKeyFromObject(instance), instance); err != nil {
+			if ctx.Err() == context.DeadlineExceeded {
+				return false, nil
+			}
+			return false, err
+		}
+	}
+}
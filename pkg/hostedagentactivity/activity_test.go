@@ -0,0 +1,103 @@
+package hostedagentactivity
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/obot-platform/obot/apiclient/types"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	storagescheme "github.com/obot-platform/obot/pkg/storage/scheme"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func clientWith(objs ...kclient.Object) kclient.Client {
+	return fakeclient.NewClientBuilder().
+		WithScheme(storagescheme.Scheme).
+		WithStatusSubresource(&v1.HostedAgentInstance{}).
+		WithObjects(objs...).
+		Build()
+}
+
+func TestIdleTimeoutPrefersAgent(t *testing.T) {
+	pool := types.HostedAgentPoolManifest{IdleTimeoutMinutes: 30}
+	if got := IdleTimeout(types.HostedAgentManifest{}, pool); got != 30*time.Minute {
+		t.Errorf("pool timeout = %s, want 30m", got)
+	}
+	if got := IdleTimeout(types.HostedAgentManifest{IdleTimeoutMinutes: 5}, pool); got != 5*time.Minute {
+		t.Errorf("agent timeout = %s, want 5m", got)
+	}
+	if got := IdleTimeout(types.HostedAgentManifest{}, types.HostedAgentPoolManifest{}); got != 0 {
+		t.Errorf("unset timeout = %s, want 0", got)
+	}
+}
+
+func TestTouchWakesAndThrottles(t *testing.T) {
+	ctx := context.Background()
+	instance := &v1.HostedAgentInstance{}
+	instance.Name = "hai1"
+	instance.Namespace = "default"
+	instance.Status.Hibernated = true
+	c := clientWith(instance)
+
+	if err := Touch(ctx, c, instance); err != nil {
+		t.Fatal(err)
+	}
+	var stored v1.HostedAgentInstance
+	if err := c.Get(ctx, kclient.ObjectKeyFromObject(instance), &stored); err != nil {
+		t.Fatal(err)
+	}
+	if stored.Status.Hibernated || stored.Status.LastActivityTime == nil {
+		t.Fatalf("touch did not wake the instance: %#v", stored.Status)
+	}
+	if instance.ResourceVersion != stored.ResourceVersion {
+		t.Fatal("touch did not update the caller's copy")
+	}
+
+	// A second touch within the resolution must not write again.
+	if err := Touch(ctx, c, instance); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Get(ctx, kclient.ObjectKeyFromObject(instance), &stored); err != nil {
+		t.Fatal(err)
+	}
+	if stored.ResourceVersion != instance.ResourceVersion {
+		t.Fatal("touch wrote again within the resolution")
+	}
+}
+
+func TestWaitReadyStopsOnError(t *testing.T) {
+	instance := &v1.HostedAgentInstance{}
+	instance.Name = "hai1"
+	instance.Namespace = "default"
+	instance.Status.State = types.HostedAgentStateError
+	c := clientWith(instance)
+
+	ready, err := WaitReady(context.Background(), c, instance, time.Minute)
+	if err != nil || ready {
+		t.Fatalf("WaitReady = %v, %v; want false, nil", ready, err)
+	}
+
+	instance.Status.State = types.HostedAgentStateReady
+	instance.Status.URL = "http://sandbox"
+	ready, err = WaitReady(context.Background(), c, instance, time.Minute)
+	if err != nil || !ready {
+		t.Fatalf("WaitReady = %v, %v; want true, nil", ready, err)
+	}
+}
+
+func TestIdleSinceFallsBackToCreation(t *testing.T) {
+	created := time.Unix(1000, 0)
+	instance := &v1.HostedAgentInstance{}
+	instance.CreationTimestamp = metav1.NewTime(created)
+	if got := IdleSince(instance); !got.Equal(created) {
+		t.Errorf("IdleSince = %s, want creation", got)
+	}
+	active := metav1.NewTime(created.Add(time.Hour))
+	instance.Status.LastActivityTime = &active
+	if got := IdleSince(instance); !got.Equal(active.Time) {
+		t.Errorf("IdleSince = %s, want last activity", got)
+	}
+}
@@ -231,6 +231,8 @@ func APIsFor(provider, dialect string) []string {
 		system.AzureModelProvider,
 		system.AzureEntraModelProvider:
 		return []string{APIOpenAIResponses, APIOpenAIChatCompletions}
+	case system.GenericChatCompletionsModelProvider:
+		return []string{APIOpenAIChatCompletions}
 	default:
 		return nil
 	}
@@ -245,6 +247,8 @@ func ProxyPath(provider string) (string, bool) {
 		return "openai", true
 	case system.GenericResponsesModelProvider:
 		return "generic-responses", true
+	case system.GenericChatCompletionsModelProvider:
+		return "generic-chat-completions", true
 	case system.AmazonBedrockModelProvider:
 		return "aws-bedrock", true
 	case system.AmazonBedrockAPIKeyModelProvider:
@@ -0,0 +1,99 @@
+// Package hostedagentsnapshot creates snapshots of hosted agent workspaces and
+// says where they are kept.
+//
+// A snapshot is a gzip-compressed tar of an instance's workspace, stored in
+// Obot's artifact blob store under BlobKey. The controller captures it; this
+// package only describes it, so the API and the instance controller agree on
+// what a snapshot is without depending on each other.
+package hostedagentsnapshot
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/obot-platform/obot/apiclient/types"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	"github.com/obot-platform/obot/pkg/system"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// BlobKey is where a snapshot's archive is kept in the blob store.
+func BlobKey(snapshotName string) string {
+	return "hosted-agent-snapshots/" + snapshotName + ".tar.gz"
+}
+
+// New describes a snapshot of the instance. It is captured once it is created.
+func New(instance *v1.HostedAgentInstance, manifest types.HostedAgentSnapshotManifest) *v1.HostedAgentSnapshot {
+	return &v1.HostedAgentSnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: system.HostedAgentSnapshotPrefix,
+			Namespace:    instance.Namespace,
+			Finalizers:   []string{v1.HostedAgentSnapshotFinalizer},
+		},
+		Spec: v1.HostedAgentSnapshotSpec{
+			UserID:                  instance.Spec.UserID,
+			HostedAgentInstanceName: instance.Name,
+			HostedAgentName:         instance.Spec.HostedAgentName,
+			PoolID:                  instance.Spec.PoolID,
+			Manifest:                manifest,
+			InstanceManifest:        instance.Spec.Manifest,
+		},
+	}
+}
+
+// FinalName is the name of the snapshot taken when the instance is deleted. It
+// is fixed so that every retry of the deletion finds the snapshot the first one
+// started, rather than starting another.
+func FinalName(instanceName string) string {
+	return system.HostedAgentSnapshotPrefix + instanceName
+}
+
+// EnsureFinal starts the instance's pre-delete snapshot if its pool keeps one,
+// and reports whether deletion may go ahead: the pool keeps none, or the
+// snapshot has finished. A snapshot that failed does not hold deletion up; it
+// is kept with its error, so the owner can see what was lost.
+func EnsureFinal(ctx context.Context, c kclient.Client, instance *v1.HostedAgentInstance, pool types.HostedAgentPoolManifest, now time.Time) (bool, error) {
+	// An instance that never reached the backend has no workspace to keep.
+	if !pool.SnapshotOnDelete || instance.Status.BackendID == "" {
+		return true, nil
+	}
+
+	var snapshot v1.HostedAgentSnapshot
+	err := c.Get(ctx, kclient.ObjectKey{Namespace: instance.Namespace, Name: FinalName(instance.Name)}, &snapshot)
+	if apierrors.IsNotFound(err) {
+		snapshot := New(instance, types.HostedAgentSnapshotManifest{
+			Name:        instance.Spec.Manifest.Name,
+			Description: "Taken automatically when the instance was deleted",
+		})
+		snapshot.GenerateName = ""
+		snapshot.Name = FinalName(instance.Name)
+		snapshot.Spec.PreDelete = true
+		snapshot.Spec.ExpiresAt = Expiry(now, pool.SnapshotRetentionDays)
+		if err := c.Create(ctx, snapshot); err != nil && !apierrors.IsAlreadyExists(err) {
+			return false, fmt.Errorf("failed to create pre-delete snapshot: %w", err)
+		}
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("failed to get pre-delete snapshot: %w", err)
+	}
+
+	return Finished(&snapshot), nil
+}
+
+// Finished reports whether the snapshot has been captured or has failed to be.
+func Finished(snapshot *v1.HostedAgentSnapshot) bool {
+	return snapshot.Status.State == types.HostedAgentSnapshotStateReady ||
+		snapshot.Status.State == types.HostedAgentSnapshotStateError
+}
+
+// Expiry is when a snapshot taken at the given time is removed under the
+// retention. Zero days keeps it until it is deleted by hand.
+func Expiry(taken time.Time, retentionDays int) *metav1.Time {
+	if retentionDays <= 0 {
+		return nil
+	}
+	return &metav1.Time{Time: taken.AddDate(0, 0, retentionDays)}
+}
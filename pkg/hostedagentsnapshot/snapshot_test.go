@@ -0,0 +1,98 @@
+package hostedagentsnapshot
+
+import (
+	"testing"
+	"time"
+
+	"github.com/obot-platform/obot/apiclient/types"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	storagescheme "github.com/obot-platform/obot/pkg/storage/scheme"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestEnsureFinalWaitsForSnapshot(t *testing.T) {
+	ctx := t.Context()
+	instance := &v1.HostedAgentInstance{}
+	instance.Name = "hai1abc"
+	instance.Namespace = "default"
+	instance.Spec.UserID = "user-1"
+	instance.Spec.HostedAgentName = "ha1abc"
+	instance.Spec.Manifest.Name = "My agent"
+	instance.Status.BackendID = "backend-1"
+	c := fakeclient.NewClientBuilder().
+		WithScheme(storagescheme.Scheme).
+		WithStatusSubresource(&v1.HostedAgentSnapshot{}).
+		Build()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	pool := types.HostedAgentPoolManifest{SnapshotOnDelete: true, SnapshotRetentionDays: 7}
+
+	done, err := EnsureFinal(ctx, c, instance, pool, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if done {
+		t.Fatal("deletion may not proceed before the snapshot is taken")
+	}
+
+	var snapshot v1.HostedAgentSnapshot
+	if err := c.Get(ctx, kclient.ObjectKey{Namespace: "default", Name: FinalName(instance.Name)}, &snapshot); err != nil {
+		t.Fatal(err)
+	}
+	if !snapshot.Spec.PreDelete || snapshot.Spec.UserID != "user-1" || snapshot.Spec.InstanceManifest.Name != "My agent" {
+		t.Fatalf("unexpected snapshot spec: %#v", snapshot.Spec)
+	}
+	if snapshot.Spec.ExpiresAt == nil || !snapshot.Spec.ExpiresAt.Time.Equal(now.AddDate(0, 0, 7)) {
+		t.Fatalf("expiresAt = %v, want %v", snapshot.Spec.ExpiresAt, now.AddDate(0, 0, 7))
+	}
+
+	// A retry before the capture finishes finds the same snapshot.
+	if done, err := EnsureFinal(ctx, c, instance, pool, now); err != nil || done {
+		t.Fatalf("EnsureFinal() = %v, %v; want false, nil", done, err)
+	}
+
+	snapshot.Status.State = types.HostedAgentSnapshotStateError
+	if err := c.Status().Update(ctx, &snapshot); err != nil {
+		t.Fatal(err)
+	}
+	if done, err := EnsureFinal(ctx, c, instance, pool, now); err != nil || !done {
+		t.Fatalf("EnsureFinal() = %v, %v; a failed snapshot must not block deletion", done, err)
+	}
+}
+
+func TestEnsureFinalSkipsWhenNotKept(t *testing.T) {
+	c := fakeclient.NewClientBuilder().WithScheme(storagescheme.Scheme).Build()
+	instance := &v1.HostedAgentInstance{}
+	instance.Name = "hai1abc"
+	instance.Namespace = "default"
+
+	// Never reached the backend: nothing to keep even when the pool asks.
+	done, err := EnsureFinal(t.Context(), c, instance, types.HostedAgentPoolManifest{SnapshotOnDelete: true}, time.Now())
+	if err != nil || !done {
+		t.Fatalf("EnsureFinal() = %v, %v; want true, nil", done, err)
+	}
+
+	instance.Status.BackendID = "backend-1"
+	done, err = EnsureFinal(t.Context(), c, instance, types.HostedAgentPoolManifest{}, time.Now())
+	if err != nil || !done {
+		t.Fatalf("EnsureFinal() = %v, %v; want true, nil", done, err)
+	}
+
+	var snapshots v1.HostedAgentSnapshotList
+	if err := c.List(t.Context(), &snapshots); err != nil {
+		t.Fatal(err)
+	}
+	if len(snapshots.Items) != 0 {
+		t.Fatalf("created %d snapshots, want none", len(snapshots.Items))
+	}
+}
+
+func TestExpiry(t *testing.T) {
+	taken := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	if got := Expiry(taken, 0); got != nil {
+		t.Errorf("Expiry(0) = %v, want nil", got)
+	}
+	if got := Expiry(taken, 30); got == nil || !got.Time.Equal(taken.AddDate(0, 0, 30)) {
+		t.Errorf("Expiry(30) = %v, want %v", got, taken.AddDate(0, 0, 30))
+	}
+}
@@ -0,0 +1,293 @@
+// Package hostedagenttrigger delivers events to hosted agent instances from
+// outside a user session: on a cron schedule, from a signed inbound webhook, or
+// by hand when an owner tests a trigger.
+//
+// Every invocation is an HTTP POST to the instance's agent port. A stopped or
+// hibernated instance is started first, so a trigger on an idle agent pays for
+// one cold start rather than failing. Each delivery is recorded in the
+// trigger's status and in its activity log.
+package hostedagenttrigger
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/adhocore/gronx"
+	"github.com/obot-platform/obot/apiclient/types"
+	gateway "github.com/obot-platform/obot/pkg/gateway/client"
+	gatewaytypes "github.com/obot-platform/obot/pkg/gateway/types"
+	"github.com/obot-platform/obot/pkg/hostedagentactivity"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// SecretKey is where a webhook trigger's signing secret is kept in its
+	// credential.
+	SecretKey = "webhookSecret"
+
+	// SignatureHeader carries the HMAC of a webhook body. It is the header
+	// GitHub sends, so a GitHub webhook can point at a trigger unchanged.
+	SignatureHeader = "X-Hub-Signature-256"
+	// legacySignatureHeader is accepted when it carries a SHA-256 signature,
+	// as some senders use it without the suffix.
+	legacySignatureHeader = "X-Hub-Signature"
+	signaturePrefix       = "sha256="
+
+	// TriggerIDHeader and SourceHeader tell the agent what invoked it, so one
+	// endpoint can serve several triggers.
+	TriggerIDHeader = "X-Obot-Trigger-ID"
+	SourceHeader    = "X-Obot-Trigger-Source"
+
+	// wakeTimeout is longer than agent-connect's because nobody is waiting on
+	// a scheduled delivery, and dropping it is worse than delivering it late.
+	wakeTimeout     = 2 * time.Minute
+	deliveryTimeout = time.Minute
+)
+
+// CredentialContext is the credential context holding a trigger's webhook
+// secret.
+func CredentialContext(triggerName string) string {
+	return fmt.Sprintf("hosted-agent-trigger-%s", triggerName)
+}
+
+// Sign returns the signature header value for body.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Signed reports whether the request headers carry a SHA-256 signature at all,
+// so that an unsigned request can be turned away before its body is read.
+func Signed(header http.Header) bool {
+	return strings.HasPrefix(signature(header), signaturePrefix)
+}
+
+// Verify reports whether the request headers carry a valid signature for body.
+// Nothing verifies under an empty secret.
+func Verify(secret string, body []byte, header http.Header) bool {
+	if secret == "" || !Signed(header) {
+		return false
+	}
+	return hmac.Equal([]byte(signature(header)), []byte(Sign(secret, body)))
+}
+
+func signature(header http.Header) string {
+	if signature := header.Get(SignatureHeader); signature != "" {
+		return signature
+	}
+	return header.Get(legacySignatureHeader)
+}
+
+// NextRun is the first tick of the manifest's schedule after the given time, in
+// the manifest's time zone.
+func NextRun(manifest types.HostedAgentTriggerManifest, after time.Time) (time.Time, error) {
+	if manifest.TimeZone != "" {
+		location, err := time.LoadLocation(manifest.TimeZone)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid time zone %q: %w", manifest.TimeZone, err)
+		}
+		after = after.In(location)
+	}
+	next, err := gronx.NextTickAfter(manifest.Schedule, after, false)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse schedule: %w", err)
+	}
+	return next, nil
+}
+
+// DevRouter reaches a sandbox from outside the cluster. It has the same shape
+// as agent-connect's, and is set from the same backend.
+type DevRouter interface {
+	DevRoute(backendID string) (string, http.RoundTripper, bool)
+}
+
+type Deliverer struct {
+	client    kclient.Client
+	gateway   *gateway.Client
+	transport http.RoundTripper
+	devRouter DevRouter
+}
+
+func NewDeliverer(client kclient.Client, gatewayClient *gateway.Client, transport http.RoundTripper, devRouter DevRouter) *Deliverer {
+	return &Deliverer{
+		client:    client,
+		gateway:   gatewayClient,
+		transport: transport,
+		devRouter: devRouter,
+	}
+}
+
+// Deliver posts body to the trigger's instance and records the outcome. A
+// failure to reach the agent is recorded rather than returned; the returned
+// error is only for failing to record it.
+func (d *Deliverer) Deliver(ctx context.Context, trigger *v1.HostedAgentTrigger, source string, body []byte, header http.Header) (gatewaytypes.HostedAgentTriggerInvocation, error) {
+	start := time.Now()
+	statusCode, deliverErr := d.deliver(ctx, trigger, source, body, header)
+
+	invocation := gatewaytypes.HostedAgentTriggerInvocation{
+		TriggerID:             trigger.Name,
+		HostedAgentInstanceID: trigger.Spec.HostedAgentInstanceName,
+		UserID:                trigger.Spec.UserID,
+		Source:                source,
+		StatusCode:            statusCode,
+		DurationMillis:        time.Since(start).Milliseconds(),
+	}
+	if deliverErr != nil {
+		invocation.Error = deliverErr.Error()
+	} else if statusCode >= http.StatusBadRequest {
+		invocation.Error = fmt.Sprintf("agent responded %d", statusCode)
+	}
+
+	var errs []error
+	if err := d.gateway.RecordHostedAgentTriggerInvocation(ctx, &invocation); err != nil {
+		errs = append(errs, err)
+	}
+	if err := d.recordStatus(ctx, trigger, invocation); err != nil {
+		errs = append(errs, fmt.Errorf("failed to update trigger status: %w", err))
+	}
+	if invocation.Error != "" {
+		slog.Info("Hosted agent trigger delivery failed", "trigger", trigger.Name, "instance", trigger.Spec.HostedAgentInstanceName, "source", source, "error", invocation.Error)
+	}
+	return invocation, errors.Join(errs...)
+}
+
+func (d *Deliverer) deliver(ctx context.Context, trigger *v1.HostedAgentTrigger, source string, body []byte, header http.Header) (int, error) {
+	var instance v1.HostedAgentInstance
+	if err := d.client.Get(ctx, kclient.ObjectKey{Namespace: trigger.Namespace, Name: trigger.Spec.HostedAgentInstanceName}, &instance); err != nil {
+		return 0, fmt.Errorf("failed to get instance: %w", err)
+	}
+
+	// A trigger exists to run its agent while nobody is watching, so a stopped
+	// instance is started for it, as if its user had started it.
+	if instance.Spec.DesiredState == types.HostedAgentDesiredStateStopped {
+		if err := d.start(ctx, &instance); err != nil {
+			return 0, fmt.Errorf("failed to start instance: %w", err)
+		}
+	}
+	if err := hostedagentactivity.Touch(ctx, d.client, &instance); err != nil {
+		return 0, fmt.Errorf("failed to record agent activity: %w", err)
+	}
+	if ready, err := hostedagentactivity.WaitReady(ctx, d.client, &instance, wakeTimeout); err != nil {
+		return 0, err
+	} else if !ready {
+		if instance.Status.Error != "" {
+			return 0, fmt.Errorf("instance %s is not running: %s", instance.Name, instance.Status.Error)
+		}
+		return 0, fmt.Errorf("instance %s did not start within %s", instance.Name, wakeTimeout)
+	}
+
+	target, transport, err := d.target(&instance, trigger.Spec.Manifest.Path)
+	if err != nil {
+		return 0, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deliveryTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to build request: %w", err)
+	}
+	copyHeaders(req.Header, header)
+	if req.Header.Get("Content-Type") == "" && len(body) > 0 {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set(TriggerIDHeader, trigger.Name)
+	req.Header.Set(SourceHeader, source)
+
+	resp, err := (&http.Client{Transport: transport}).Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to reach agent: %w", err)
+	}
+	defer resp.Body.Close()
+	// The response is not kept, but draining it lets the connection be reused.
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<20))
+	return resp.StatusCode, nil
+}
+
+// start sets the instance running. The instance is updated in place.
+func (d *Deliverer) start(ctx context.Context, instance *v1.HostedAgentInstance) error {
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		var current v1.HostedAgentInstance
+		if err := d.client.Get(ctx, kclient.ObjectKeyFromObject(instance), &current); err != nil {
+			return err
+		}
+		if current.Spec.DesiredState != types.HostedAgentDesiredStateRunning {
+			current.Spec.DesiredState = types.HostedAgentDesiredStateRunning
+			if err := d.client.Update(ctx, &current); err != nil {
+				return err
+			}
+		}
+		*instance = current
+		return nil
+	})
+}
+
+// target mirrors agent-connect: the observed sandbox address, or the
+// development route to it when Obot runs outside the cluster.
+func (d *Deliverer) target(instance *v1.HostedAgentInstance, path string) (string, http.RoundTripper, error) {
+	if instance.Status.URL == "" {
+		return "", nil, fmt.Errorf("agent %s does not expose a port", instance.Name)
+	}
+	base, transport := instance.Status.URL, d.transport
+	if d.devRouter != nil {
+		if devURL, devTransport, ok := d.devRouter.DevRoute(instance.Status.BackendID); ok {
+			base, transport = devURL, devTransport
+		}
+	}
+
+	target, err := url.Parse(base)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to parse agent address: %w", err)
+	}
+	target.Path = strings.TrimSuffix(target.Path, "/") + "/" + strings.TrimPrefix(path, "/")
+	return target.String(), transport, nil
+}
+
+// copyHeaders forwards what a webhook sender says about its event -- its
+// content type and its own X- headers, such as GitHub's X-GitHub-Event -- and
+// nothing about how it reached Obot. The signature is dropped because it was
+// checked here and would only tempt an agent to re-check it with a secret it
+// does not have.
+func copyHeaders(dst, src http.Header) {
+	for name, values := range src {
+		canonical := http.CanonicalHeaderKey(name)
+		switch {
+		case canonical == "Content-Type":
+		case canonical == SignatureHeader, canonical == legacySignatureHeader:
+			continue
+		case strings.HasPrefix(canonical, "X-Forwarded-"), strings.HasPrefix(canonical, "X-Obot-"):
+			continue
+		case !strings.HasPrefix(canonical, "X-"):
+			continue
+		}
+		dst[canonical] = append([]string(nil), values...)
+	}
+}
+
+func (d *Deliverer) recordStatus(ctx context.Context, trigger *v1.HostedAgentTrigger, invocation gatewaytypes.HostedAgentTriggerInvocation) error {
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		var current v1.HostedAgentTrigger
+		if err := d.client.Get(ctx, kclient.ObjectKeyFromObject(trigger), &current); err != nil {
+			return kclient.IgnoreNotFound(err)
+		}
+		current.Status.LastInvocationTime = &metav1.Time{Time: time.Now()}
+		current.Status.LastStatusCode = invocation.StatusCode
+		current.Status.LastError = invocation.Error
+		return d.client.Status().Update(ctx, &current)
+	})
+}
@@ -0,0 +1,159 @@
+package hostedagenttrigger
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/obot-platform/obot/apiclient/types"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	storagescheme "github.com/obot-platform/obot/pkg/storage/scheme"
+	"github.com/obot-platform/obot/pkg/system"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestVerify(t *testing.T) {
+	body := []byte(`{"action":"opened"}`)
+	signed := http.Header{}
+	signed.Set(SignatureHeader, Sign("secret", body))
+	if !Verify("secret", body, signed) {
+		t.Fatal("valid signature rejected")
+	}
+	if Verify("other", body, signed) {
+		t.Fatal("signature accepted under the wrong secret")
+	}
+	if Verify("secret", []byte(`{"action":"closed"}`), signed) {
+		t.Fatal("signature accepted for a different body")
+	}
+
+	legacy := http.Header{}
+	legacy.Set(legacySignatureHeader, Sign("secret", body))
+	if !Verify("secret", body, legacy) {
+		t.Fatal("SHA-256 signature in the legacy header rejected")
+	}
+	legacy.Set(legacySignatureHeader, "sha1=abc")
+	if Verify("secret", body, legacy) {
+		t.Fatal("SHA-1 signature accepted")
+	}
+	if Verify("secret", body, http.Header{}) || Signed(http.Header{}) {
+		t.Fatal("unsigned request accepted")
+	}
+	if !Signed(signed) {
+		t.Fatal("signed request reported as unsigned")
+	}
+
+	empty := http.Header{}
+	empty.Set(SignatureHeader, Sign("", body))
+	if Verify("", body, empty) {
+		t.Fatal("signature accepted under an empty secret")
+	}
+}
+
+func TestDeliverStartsStoppedInstance(t *testing.T) {
+	var received []byte
+	agent := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer agent.Close()
+
+	instance := &v1.HostedAgentInstance{
+		ObjectMeta: metav1.ObjectMeta{Name: "hai1", Namespace: system.DefaultNamespace},
+		Spec:       v1.HostedAgentInstanceSpec{DesiredState: types.HostedAgentDesiredStateStopped},
+		Status: v1.HostedAgentInstanceStatus{
+			State: types.HostedAgentStateReady,
+			URL:   agent.URL,
+		},
+	}
+	client := fake.NewClientBuilder().
+		WithScheme(storagescheme.Scheme).
+		WithObjects(instance).
+		WithStatusSubresource(instance).
+		Build()
+	trigger := &v1.HostedAgentTrigger{
+		ObjectMeta: metav1.ObjectMeta{Name: "hat1", Namespace: system.DefaultNamespace},
+		Spec:       v1.HostedAgentTriggerSpec{HostedAgentInstanceName: instance.Name},
+	}
+
+	d := &Deliverer{client: client, transport: http.DefaultTransport}
+	statusCode, err := d.deliver(t.Context(), trigger, types.HostedAgentTriggerSourceManual, []byte(`{"event":"tick"}`), http.Header{})
+	if err != nil {
+		t.Fatalf("deliver: %v", err)
+	}
+	if statusCode != http.StatusNoContent || string(received) != `{"event":"tick"}` {
+		t.Fatalf("delivered %q, agent responded %d", received, statusCode)
+	}
+
+	var current v1.HostedAgentInstance
+	if err := client.Get(t.Context(), kclient.ObjectKeyFromObject(instance), &current); err != nil {
+		t.Fatal(err)
+	}
+	if current.Spec.DesiredState != types.HostedAgentDesiredStateRunning {
+		t.Fatalf("desired state = %q, want running", current.Spec.DesiredState)
+	}
+}
+
+func TestNextRunUsesTimeZone(t *testing.T) {
+	after := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	next, err := NextRun(types.HostedAgentTriggerManifest{Schedule: "0 9 * * *", TimeZone: "America/New_York"}, after)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 9:00 in New York on January 1st is 14:00 UTC.
+	if want := time.Date(2026, 1, 1, 14, 0, 0, 0, time.UTC); !next.Equal(want) {
+		t.Fatalf("next = %s, want %s", next.UTC(), want)
+	}
+
+	if _, err := NextRun(types.HostedAgentTriggerManifest{Schedule: "not a schedule"}, after); err == nil {
+		t.Fatal("invalid schedule accepted")
+	}
+}
+
+func TestCopyHeadersKeepsOnlyEventHeaders(t *testing.T) {
+	src := http.Header{}
+	src.Set("Content-Type", "application/json")
+	src.Set("X-GitHub-Event", "push")
+	src.Set(SignatureHeader, "sha256=abc")
+	src.Set("X-Forwarded-For", "10.0.0.1")
+	src.Set(SourceHeader, "spoofed")
+	src.Set("Authorization", "Bearer token")
+	src.Set("Cookie", "session=1")
+
+	dst := http.Header{}
+	copyHeaders(dst, src)
+	if len(dst) != 2 || dst.Get("Content-Type") != "application/json" || dst.Get("X-GitHub-Event") != "push" {
+		t.Fatalf("forwarded headers = %v", dst)
+	}
+}
+
+type devRouter string
+
+func (r devRouter) DevRoute(string) (string, http.RoundTripper, bool) {
+	return string(r), http.DefaultTransport, true
+}
+
+func TestTargetAppendsPath(t *testing.T) {
+	instance := &v1.HostedAgentInstance{}
+	instance.Status.URL = "http://sandbox:8080"
+
+	d := &Deliverer{transport: http.DefaultTransport}
+	if got, _, err := d.target(instance, ""); err != nil || got != "http://sandbox:8080/" {
+		t.Fatalf("target = %q, %v", got, err)
+	}
+	if got, _, err := d.target(instance, "/hooks/github"); err != nil || got != "http://sandbox:8080/hooks/github" {
+		t.Fatalf("target = %q, %v", got, err)
+	}
+
+	d.devRouter = devRouter("http://localhost:8080/api/v1/proxy/")
+	if got, _, err := d.target(instance, "/hooks"); err != nil || got != "http://localhost:8080/api/v1/proxy/hooks" {
+		t.Fatalf("development target = %q, %v", got, err)
+	}
+
+	if _, _, err := d.target(&v1.HostedAgentInstance{}, "/"); err == nil {
+		t.Fatal("instance without a port accepted")
+	}
+}
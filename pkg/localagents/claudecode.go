@@ -116,3 +116,77 @@ func (c ClaudeCode) InstallSkill(ctx context.Context, home string, skill SkillAr
 func claudeCodeSkillsRoot(home string) string {
 	return filepath.Join(home, ".claude", "skills")
 }
+
+func (c ClaudeCode) InstallMCPServer(ctx context.Context, home string, entry MCPServerEntry) (InstallResult, error) {
+	if err := ctx.Err(); err != nil {
+		return InstallResult{}, err
+	}
+	home, err := resolveHome(home, c.home)
+	if err != nil {
+		return InstallResult{}, err
+	}
+
+	config := claudeCodeMCPConfig(home)
+	if err := config.install(entry); err != nil {
+		return InstallResult{}, err
+	}
+	return InstallResult{
+		AgentID:     c.ID(),
+		DisplayName: c.DisplayName(),
+		Installed:   []string{config.path},
+		Message:     fmt.Sprintf("Added MCP server %s to Claude Code", entry.Name),
+	}, nil
+}
+
+func (c ClaudeCode) UninstallMCPServer(ctx context.Context, home, name string) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	home, err := resolveHome(home, c.home)
+	if err != nil {
+		return false, err
+	}
+	return claudeCodeMCPConfig(home).uninstall(name)
+}
+
+func (c ClaudeCode) GetMCPServer(ctx context.Context, home, name string) (MCPServerEntry, bool, error) {
+	if err := ctx.Err(); err != nil {
+		return MCPServerEntry{}, false, err
+	}
+	home, err := resolveHome(home, c.home)
+	if err != nil {
+		return MCPServerEntry{}, false, err
+	}
+	return claudeCodeMCPConfig(home).get(name)
+}
+
+func (c ClaudeCode) ListMCPServers(ctx context.Context, home string) ([]MCPServerEntry, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	home, err := resolveHome(home, c.home)
+	if err != nil {
+		return nil, err
+	}
+	return claudeCodeMCPConfig(home).list()
+}
+
+// claudeCodeMCPConfig is the user-scoped MCP configuration, which Claude Code
+// keeps in ~/.claude.json alongside its other settings.
+func claudeCodeMCPConfig(home string) jsonMCPConfig {
+	return jsonMCPConfig{
+		path: filepath.Join(home, ".claude.json"),
+		entry: func(entry MCPServerEntry) any {
+			return struct {
+				Type string `json:"type"`
+				jsonMCPServerValue
+			}{
+				Type: "http",
+				jsonMCPServerValue: jsonMCPServerValue{
+					URL:     entry.URL,
+					Headers: entry.Headers,
+				},
+			}
+		},
+	}
+}
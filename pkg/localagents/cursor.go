@@ -0,0 +1,130 @@
+package localagents
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+const (
+	CursorAgentID     = "cursor"
+	cursorDisplayName = "Cursor"
+)
+
+type Cursor struct {
+	home string
+}
+
+func NewCursor() Cursor {
+	return Cursor{}
+}
+
+func (c Cursor) ID() string {
+	return CursorAgentID
+}
+
+func (c Cursor) DisplayName() string {
+	return cursorDisplayName
+}
+
+func (c Cursor) Detect(ctx context.Context) DetectionResult {
+	result := DetectionResult{
+		AgentID:     c.ID(),
+		DisplayName: c.DisplayName(),
+		State:       DetectionMissing,
+	}
+	if err := ctx.Err(); err != nil {
+		result.Reason = err.Error()
+		return result
+	}
+
+	home, err := resolveHome("", c.home)
+	if err != nil {
+		result.Reason = err.Error()
+		return result
+	}
+
+	if binary, err := exec.LookPath("cursor"); err == nil && binary != "" {
+		result.State = DetectionPresent
+		result.Reason = "found cursor binary at " + binary
+		return result
+	}
+
+	configPath := filepath.Join(home, ".cursor")
+	if fi, err := os.Stat(configPath); err == nil && fi.IsDir() {
+		result.State = DetectionPresent
+		result.Reason = "found Cursor config at " + configPath
+		return result
+	}
+
+	result.Reason = "Cursor was not detected"
+	return result
+}
+
+func (c Cursor) InstallMCPServer(ctx context.Context, home string, entry MCPServerEntry) (InstallResult, error) {
+	if err := ctx.Err(); err != nil {
+		return InstallResult{}, err
+	}
+	home, err := resolveHome(home, c.home)
+	if err != nil {
+		return InstallResult{}, err
+	}
+
+	config := cursorMCPConfig(home)
+	if err := config.install(entry); err != nil {
+		return InstallResult{}, err
+	}
+	return InstallResult{
+		AgentID:     c.ID(),
+		DisplayName: c.DisplayName(),
+		Installed:   []string{config.path},
+		Message:     fmt.Sprintf("Added MCP server %s to Cursor", entry.Name),
+	}, nil
+}
+
+func (c Cursor) UninstallMCPServer(ctx context.Context, home, name string) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	home, err := resolveHome(home, c.home)
+	if err != nil {
+		return false, err
+	}
+	return cursorMCPConfig(home).uninstall(name)
+}
+
+func (c Cursor) GetMCPServer(ctx context.Context, home, name string) (MCPServerEntry, bool, error) {
+	if err := ctx.Err(); err != nil {
+		return MCPServerEntry{}, false, err
+	}
+	home, err := resolveHome(home, c.home)
+	if err != nil {
+		return MCPServerEntry{}, false, err
+	}
+	return cursorMCPConfig(home).get(name)
+}
+
+func (c Cursor) ListMCPServers(ctx context.Context, home string) ([]MCPServerEntry, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	home, err := resolveHome(home, c.home)
+	if err != nil {
+		return nil, err
+	}
+	return cursorMCPConfig(home).list()
+}
+
+func cursorMCPConfig(home string) jsonMCPConfig {
+	return jsonMCPConfig{
+		path: filepath.Join(home, ".cursor", "mcp.json"),
+		entry: func(entry MCPServerEntry) any {
+			return jsonMCPServerValue{
+				URL:     entry.URL,
+				Headers: entry.Headers,
+			}
+		},
+	}
+}
@@ -71,7 +71,7 @@ func InstallSkillToRoot(ctx context.Context, skillsRoot string, skill SkillArchi
 }
 
 func installSkillArchiveToRoot(skillsRoot string, skill SkillArchive) (string, []string, error) {
-	name, err := skill.installName()
+	name, err := skill.InstallName()
 	if err != nil {
 		return "", nil, err
 	}
@@ -0,0 +1,161 @@
+package localagents
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/obot-platform/obot/pkg/skillformat"
+)
+
+const maxInstalledSkillMDBytes = 1024 * 1024
+
+// InstalledSkill describes a skill directory found under a skills root. The
+// Obot fields are read from the metadata injected into SKILL.md at download
+// time and are empty for skills that were not installed from Obot.
+type InstalledSkill struct {
+	Name        string
+	Path        string
+	SkillID     string
+	CommitSHA   string
+	InstallHash string
+}
+
+// ReadInstalledSkills lists the skill directories directly under skillsRoot.
+// A missing root is treated as having no installed skills.
+func ReadInstalledSkills(skillsRoot string) ([]InstalledSkill, error) {
+	entries, err := os.ReadDir(skillsRoot)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read skills directory %s: %w", skillsRoot, err)
+	}
+
+	var skills []InstalledSkill
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		dir := filepath.Join(skillsRoot, entry.Name())
+		fm, ok, err := readInstalledFrontmatter(filepath.Join(dir, skillformat.SkillMainFile))
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+
+		name := fm.Name
+		if name == "" {
+			name = entry.Name()
+		}
+		skills = append(skills, InstalledSkill{
+			Name:        name,
+			Path:        dir,
+			SkillID:     fm.Metadata[skillformat.MetadataSkillID],
+			CommitSHA:   fm.Metadata[skillformat.MetadataCommitSHA],
+			InstallHash: fm.Metadata[skillformat.MetadataInstallHash],
+		})
+	}
+
+	sort.Slice(skills, func(i, j int) bool {
+		return skills[i].Name < skills[j].Name
+	})
+	return skills, nil
+}
+
+func readInstalledFrontmatter(path string) (skillformat.Frontmatter, bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return skillformat.Frontmatter{}, false, nil
+		}
+		return skillformat.Frontmatter{}, false, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	content, err := io.ReadAll(io.LimitReader(f, maxInstalledSkillMDBytes))
+	if err != nil {
+		return skillformat.Frontmatter{}, false, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	fm, _, err := skillformat.ParseFrontmatter(string(content))
+	if err != nil {
+		// A hand-edited SKILL.md with broken frontmatter is still an installed
+		// skill; it just can't be traced back to Obot.
+		return skillformat.Frontmatter{}, true, nil
+	}
+	return fm, true, nil
+}
+
+// SkillsRootAgentID returns the ID of the local agent that reads skills from
+// skillsRoot, or an empty string for a custom directory.
+func SkillsRootAgentID(home, skillsRoot string) string {
+	switch filepath.Clean(skillsRoot) {
+	case claudeCodeSkillsRoot(home):
+		return ClaudeCodeAgentID
+	case sharedAgentsSkillsRoot(home):
+		return SharedAgentsID
+	}
+	return ""
+}
+
+// HashSkillDir returns a digest of the regular files under dir, their
+// relative paths and whether they are executable. Any edit, addition or
+// removal of a file changes the digest.
+func HashSkillDir(dir string) (string, error) {
+	h := sha256.New()
+	err := filepath.WalkDir(dir, func(currentPath string, entry fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if entry.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, currentPath)
+		if err != nil {
+			return err
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(h, "%s\x00%s\x00", filepath.ToSlash(rel), conservativeFileMode(info.Mode()))
+		if entry.Type()&fs.ModeSymlink != 0 {
+			target, err := os.Readlink(currentPath)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(h, "link:%s\x00", target)
+			return nil
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+
+		f, err := os.Open(currentPath)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		n, err := io.Copy(h, f)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(h, "\x00%d\x00", n)
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to hash skill directory %s: %w", dir, err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
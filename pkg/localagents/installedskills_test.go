@@ -0,0 +1,115 @@
+package localagents
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/obot-platform/obot/pkg/skillformat"
+)
+
+func TestReadInstalledSkills(t *testing.T) {
+	root := t.TempDir()
+	writeInstalledSkill(t, root, "github-review", "---\nname: github-review\ndescription: Review.\nmetadata:\n  obot-skill-id: sk1\n  obot-commit-sha: abc123\n  obot-install-hash: h1\n---\nBody\n")
+	writeInstalledSkill(t, root, "local-only", "---\nname: local-only\ndescription: Local.\n---\nBody\n")
+	writeInstalledSkill(t, root, "broken", "---\nname: [unterminated\n")
+	if err := os.MkdirAll(filepath.Join(root, "not-a-skill"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	skills, err := ReadInstalledSkills(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(skills) != 3 {
+		t.Fatalf("len(skills) = %d, want 3: %#v", len(skills), skills)
+	}
+
+	if skills[0].Name != "broken" || skills[0].SkillID != "" {
+		t.Fatalf("unexpected broken skill: %#v", skills[0])
+	}
+	if skills[1].Name != "github-review" || skills[1].SkillID != "sk1" || skills[1].CommitSHA != "abc123" || skills[1].InstallHash != "h1" {
+		t.Fatalf("unexpected obot skill: %#v", skills[1])
+	}
+	if skills[1].Path != filepath.Join(root, "github-review") {
+		t.Fatalf("Path = %q, want %q", skills[1].Path, filepath.Join(root, "github-review"))
+	}
+	if skills[2].Name != "local-only" || skills[2].SkillID != "" {
+		t.Fatalf("unexpected local skill: %#v", skills[2])
+	}
+}
+
+func TestReadInstalledSkillsMissingRoot(t *testing.T) {
+	skills, err := ReadInstalledSkills(filepath.Join(t.TempDir(), "missing"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(skills) != 0 {
+		t.Fatalf("expected no skills, got %#v", skills)
+	}
+}
+
+func writeInstalledSkill(t *testing.T, root, dir, content string) {
+	t.Helper()
+
+	path := filepath.Join(root, dir, skillformat.SkillMainFile)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestHashSkillDirDetectsChanges(t *testing.T) {
+	root := t.TempDir()
+	writeInstalledSkill(t, root, "demo", "---\nname: demo\n---\nBody\n")
+	dir := filepath.Join(root, "demo")
+
+	original, err := HashSkillDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := HashSkillDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if original != again {
+		t.Fatalf("hash is not stable: %s != %s", original, again)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "notes.md"), []byte("extra"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	added, err := HashSkillDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if added == original {
+		t.Fatal("expected hash to change when a file is added")
+	}
+
+	if err := os.Chmod(filepath.Join(dir, "notes.md"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	chmodded, err := HashSkillDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if chmodded == added {
+		t.Fatal("expected hash to change when a file becomes executable")
+	}
+}
+
+func TestSkillsRootAgentID(t *testing.T) {
+	home := "/home/test"
+	if got := SkillsRootAgentID(home, "/home/test/.claude/skills/"); got != ClaudeCodeAgentID {
+		t.Fatalf("claude code root = %q", got)
+	}
+	if got := SkillsRootAgentID(home, "/home/test/.agents/skills"); got != SharedAgentsID {
+		t.Fatalf("shared agents root = %q", got)
+	}
+	if got := SkillsRootAgentID(home, "/tmp/skills"); got != "" {
+		t.Fatalf("custom root = %q", got)
+	}
+}
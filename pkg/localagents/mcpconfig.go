@@ -0,0 +1,192 @@
+package localagents
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+// MCPServerEntry is a remote MCP server written into a local agent's MCP
+// configuration.
+type MCPServerEntry struct {
+	Name    string
+	URL     string
+	Headers map[string]string
+}
+
+// MCPConfigTargets returns the local agents whose MCP configuration the CLI
+// can manage.
+func MCPConfigTargets() []MCPConfigInstaller {
+	return []MCPConfigInstaller{
+		NewClaudeCode(),
+		NewCursor(),
+	}
+}
+
+// jsonMCPConfig edits the "mcpServers" object of a JSON config file while
+// preserving every other key in the file.
+type jsonMCPConfig struct {
+	path string
+	// entry renders a server into the agent-specific JSON shape.
+	entry func(MCPServerEntry) any
+}
+
+type jsonMCPServerValue struct {
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+func (c jsonMCPConfig) read() (map[string]json.RawMessage, map[string]json.RawMessage, error) {
+	root := map[string]json.RawMessage{}
+	servers := map[string]json.RawMessage{}
+
+	data, err := os.ReadFile(c.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return root, servers, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read %s: %w", c.path, err)
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return root, servers, nil
+	}
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse %s: %w", c.path, err)
+	}
+	if raw, ok := root["mcpServers"]; ok && !bytes.Equal(bytes.TrimSpace(raw), []byte("null")) {
+		if err := json.Unmarshal(raw, &servers); err != nil {
+			return nil, nil, fmt.Errorf("failed to parse mcpServers in %s: %w", c.path, err)
+		}
+	}
+	return root, servers, nil
+}
+
+func (c jsonMCPConfig) write(root, servers map[string]json.RawMessage) error {
+	rawServers, err := json.Marshal(servers)
+	if err != nil {
+		return err
+	}
+	root["mcpServers"] = rawServers
+
+	data, err := json.MarshalIndent(root, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	// Entries carry API keys, so new files are private to the user. Existing
+	// files keep their mode.
+	mode := os.FileMode(0600)
+	if fi, err := os.Stat(c.path); err == nil {
+		mode = fi.Mode().Perm()
+	}
+
+	dir := filepath.Dir(c.path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(c.path)+".tmp-")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary config file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write %s: %w", c.path, err)
+	}
+	if err := tmp.Chmod(mode); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to set permissions on %s: %w", c.path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", c.path, err)
+	}
+	if err := os.Rename(tmp.Name(), c.path); err != nil {
+		return fmt.Errorf("failed to replace %s: %w", c.path, err)
+	}
+	return nil
+}
+
+func (c jsonMCPConfig) install(entry MCPServerEntry) error {
+	if entry.Name == "" {
+		return fmt.Errorf("MCP server entry name is required")
+	}
+
+	root, servers, err := c.read()
+	if err != nil {
+		return err
+	}
+	raw, err := json.Marshal(c.entry(entry))
+	if err != nil {
+		return err
+	}
+	servers[entry.Name] = raw
+	return c.write(root, servers)
+}
+
+func (c jsonMCPConfig) uninstall(name string) (bool, error) {
+	root, servers, err := c.read()
+	if err != nil {
+		return false, err
+	}
+	if _, ok := servers[name]; !ok {
+		return false, nil
+	}
+	delete(servers, name)
+	return true, c.write(root, servers)
+}
+
+func (c jsonMCPConfig) get(name string) (MCPServerEntry, bool, error) {
+	_, servers, err := c.read()
+	if err != nil {
+		return MCPServerEntry{}, false, err
+	}
+	raw, ok := servers[name]
+	if !ok {
+		return MCPServerEntry{}, false, nil
+	}
+
+	entry, err := parseJSONMCPServer(name, raw)
+	if err != nil {
+		return MCPServerEntry{}, false, fmt.Errorf("failed to parse MCP server %s in %s: %w", name, c.path, err)
+	}
+	return entry, true, nil
+}
+
+// list returns the remote MCP server entries in the config, sorted by name.
+// Entries in a shape this package does not write are skipped.
+func (c jsonMCPConfig) list() ([]MCPServerEntry, error) {
+	_, servers, err := c.read()
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]MCPServerEntry, 0, len(servers))
+	for _, name := range slices.Sorted(maps.Keys(servers)) {
+		entry, err := parseJSONMCPServer(name, servers[name])
+		if err != nil || entry.URL == "" {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func parseJSONMCPServer(name string, raw json.RawMessage) (MCPServerEntry, error) {
+	var value jsonMCPServerValue
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return MCPServerEntry{}, err
+	}
+	return MCPServerEntry{
+		Name:    name,
+		URL:     value.URL,
+		Headers: value.Headers,
+	}, nil
+}
@@ -0,0 +1,112 @@
+package localagents
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestClaudeCodeMCPServerRoundTripPreservesConfig(t *testing.T) {
+	home := t.TempDir()
+	configPath := filepath.Join(home, ".claude.json")
+	if err := os.WriteFile(configPath, []byte(`{"theme":"dark","mcpServers":{"other":{"command":"npx"}}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	entry := MCPServerEntry{
+		Name:    "obot-github",
+		URL:     "https://obot.example.com/mcp-connect/ms1abc",
+		Headers: map[string]string{"Authorization": "Bearer ok1-key"},
+	}
+	if _, err := NewClaudeCode().InstallMCPServer(t.Context(), home, entry); err != nil {
+		t.Fatal(err)
+	}
+
+	var config struct {
+		Theme      string                     `json:"theme"`
+		MCPServers map[string]json.RawMessage `json:"mcpServers"`
+	}
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		t.Fatal(err)
+	}
+	if config.Theme != "dark" {
+		t.Fatalf("theme = %q, want preserved value", config.Theme)
+	}
+	if _, ok := config.MCPServers["other"]; !ok {
+		t.Fatal("existing MCP server entry was removed")
+	}
+	var installed map[string]any
+	if err := json.Unmarshal(config.MCPServers["obot-github"], &installed); err != nil {
+		t.Fatal(err)
+	}
+	if installed["type"] != "http" || installed["url"] != entry.URL {
+		t.Fatalf("installed entry = %v", installed)
+	}
+	if fi, err := os.Stat(configPath); err != nil {
+		t.Fatal(err)
+	} else if fi.Mode().Perm() != 0644 {
+		t.Fatalf("mode = %v, want existing mode 0644", fi.Mode().Perm())
+	}
+
+	got, ok, err := NewClaudeCode().GetMCPServer(t.Context(), home, "obot-github")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok || got.URL != entry.URL || got.Headers["Authorization"] != "Bearer ok1-key" {
+		t.Fatalf("GetMCPServer() = %+v, %v", got, ok)
+	}
+
+	// The stdio server is not a remote entry and is left out.
+	listed, err := NewClaudeCode().ListMCPServers(t.Context(), home)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(listed) != 1 || listed[0].Name != "obot-github" || listed[0].URL != entry.URL {
+		t.Fatalf("ListMCPServers() = %+v", listed)
+	}
+
+	removed, err := NewClaudeCode().UninstallMCPServer(t.Context(), home, "obot-github")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !removed {
+		t.Fatal("UninstallMCPServer() = false, want true")
+	}
+	if _, ok, err := NewClaudeCode().GetMCPServer(t.Context(), home, "obot-github"); err != nil || ok {
+		t.Fatalf("GetMCPServer() after uninstall = %v, %v", ok, err)
+	}
+	if _, ok, err := NewClaudeCode().GetMCPServer(t.Context(), home, "other"); err != nil || !ok {
+		t.Fatalf("other server after uninstall = %v, %v", ok, err)
+	}
+}
+
+func TestCursorMCPServerCreatesPrivateConfig(t *testing.T) {
+	home := t.TempDir()
+
+	entry := MCPServerEntry{Name: "obot-github", URL: "https://obot.example.com/mcp-connect/ms1abc"}
+	if _, err := NewCursor().InstallMCPServer(t.Context(), home, entry); err != nil {
+		t.Fatal(err)
+	}
+
+	configPath := filepath.Join(home, ".cursor", "mcp.json")
+	fi, err := os.Stat(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fi.Mode().Perm() != 0600 {
+		t.Fatalf("mode = %v, want 0600", fi.Mode().Perm())
+	}
+
+	removed, err := NewCursor().UninstallMCPServer(t.Context(), home, "missing")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if removed {
+		t.Fatal("UninstallMCPServer() for a missing entry = true, want false")
+	}
+}
@@ -121,7 +121,7 @@ func ParseSkillArchive(data []byte, fallbackName string) (SkillArchive, error) {
 	if err := archive.validateFiles(); err != nil {
 		return SkillArchive{}, err
 	}
-	if _, err := archive.installName(); err != nil {
+	if _, err := archive.InstallName(); err != nil {
 		return SkillArchive{}, err
 	}
 
@@ -149,7 +149,8 @@ func (s SkillArchive) ExtractTo(target string) error {
 	return replaceDir(target, files)
 }
 
-func (s SkillArchive) installName() (string, error) {
+// InstallName returns the directory name the skill is installed under.
+func (s SkillArchive) InstallName() (string, error) {
 	name := s.frontmatterName()
 	if name == "" {
 		name = sanitizeSkillName(s.Name)
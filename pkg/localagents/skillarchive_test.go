@@ -43,7 +43,7 @@ func TestParseSkillArchiveValidRootZip(t *testing.T) {
 		t.Fatalf("Files count = %d, want 2", len(archive.Files))
 	}
 
-	name, err := archive.installName()
+	name, err := archive.InstallName()
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -179,7 +179,7 @@ func TestParseSkillArchiveFallsBackToSanitizedName(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	name, err := archive.installName()
+	name, err := archive.InstallName()
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -37,6 +37,17 @@ type DirectInstaller interface {
 	InstallSkill(ctx context.Context, home string, skill SkillArchive) (InstallResult, error)
 }
 
+// MCPConfigInstaller writes remote MCP server entries into an agent's MCP
+// configuration.
+type MCPConfigInstaller interface {
+	Agent
+	InstallMCPServer(ctx context.Context, home string, entry MCPServerEntry) (InstallResult, error)
+	// UninstallMCPServer removes the named entry and reports whether it existed.
+	UninstallMCPServer(ctx context.Context, home, name string) (bool, error)
+	GetMCPServer(ctx context.Context, home, name string) (MCPServerEntry, bool, error)
+	ListMCPServers(ctx context.Context, home string) ([]MCPServerEntry, error)
+}
+
 type SetupTarget interface {
 	ID() string
 	DisplayName() string
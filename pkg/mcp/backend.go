@@ -47,6 +47,9 @@ type backend interface {
 	deployServer(ctx context.Context, server ServerConfig) error
 	streamServerLogs(ctx context.Context, id string) (io.ReadCloser, error)
 	getServerDetails(ctx context.Context, id string) (types.MCPServerDetails, error)
+	// runningServerURL returns the URL of a deployed server without deploying or changing it. It returns
+	// ErrServerNotRunning if the server is not deployed.
+	runningServerURL(ctx context.Context, server ServerConfig) (string, error)
 	restartServer(ctx context.Context, server ServerConfig) error
 	shutdownServer(ctx context.Context, id string, hardShutdown bool) error
 	transformObotHostname(url string) string
@@ -341,11 +341,7 @@ func (d *dockerBackend) ensureDeployment(ctx context.Context, server ServerConfi
 				return ServerConfig{}, fmt.Errorf("failed syncing container files: %w", err)
 			}
 
-			containerPort := defaultContainerPort
-			if server.Runtime == otypes.RuntimeContainerized && server.ContainerPort != 0 {
-				containerPort = server.ContainerPort
-			}
-
+			containerPort := serverContainerPort(server)
 			if err = d.ensureServerReady(ctx, existing, server, containerPort); err != nil {
 				return ServerConfig{}, fmt.Errorf("server running, but readiness check failed: %w", err)
 			}
@@ -398,6 +394,33 @@ func (d *dockerBackend) streamServerLogs(ctx context.Context, id string) (io.Rea
 	return logs, nil
 }
 
+func (d *dockerBackend) runningServerURL(ctx context.Context, server ServerConfig) (string, error) {
+	c, err := d.getContainer(ctx, server.MCPServerName)
+	if err != nil {
+		return "", fmt.Errorf("failed to get container: %w", err)
+	}
+	if c == nil {
+		return "", ErrServerNotRunning
+	}
+	if c.State != container.StateRunning {
+		return "", fmt.Errorf("container %s is %s", server.MCPServerName, c.State)
+	}
+
+	baseURL, err := d.containerURL(c, server, serverContainerPort(server))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s/%s", baseURL, strings.TrimPrefix(server.ContainerPath, "/")), nil
+}
+
+// serverContainerPort returns the port a server listens on in its container.
+func serverContainerPort(server ServerConfig) int {
+	if server.Runtime == otypes.RuntimeContainerized && server.ContainerPort != 0 {
+		return server.ContainerPort
+	}
+	return defaultContainerPort
+}
+
 func (d *dockerBackend) getServerDetails(ctx context.Context, id string) (otypes.MCPServerDetails, error) {
 	container, err := d.getContainer(ctx, id)
 	if err != nil {
@@ -1136,36 +1159,38 @@ func (d *dockerBackend) waitForContainer(ctx context.Context, containerID string
 }
 
 func (d *dockerBackend) ensureServerReady(ctx context.Context, c *container.Summary, server ServerConfig, containerPort int) error {
-	var (
-		host string
-		err  error
-		port = containerPort
-	)
+	url, err := d.containerURL(c, server, containerPort)
+	if err != nil {
+		return err
+	}
+
+	if err = ensureServerReady(ctx, url, server); err != nil {
+		return fmt.Errorf("server readiness check failed: %w", err)
+	}
+
+	return nil
+}
+
+// containerURL returns the base URL the server in the container is reached at.
+func (d *dockerBackend) containerURL(c *container.Summary, server ServerConfig, containerPort int) (string, error) {
 	if d.containerEnv {
 		if c == nil || c.NetworkSettings == nil {
-			return fmt.Errorf("container %s not found or has no network settings", server.MCPServerName)
+			return "", fmt.Errorf("container %s not found or has no network settings", server.MCPServerName)
 		}
 
 		n, ok := c.NetworkSettings.Networks[d.network]
 		if !ok || n.IPAddress == "" {
-			return fmt.Errorf("container %s is not connected to %s network", server.MCPServerName, d.network)
-		}
-
-		host = n.IPAddress
-	} else {
-		port, err = d.getHostPort(c, containerPort)
-		if err != nil {
-			return fmt.Errorf("failed to get host port: %w", err)
+			return "", fmt.Errorf("container %s is not connected to %s network", server.MCPServerName, d.network)
 		}
 
-		host = "localhost"
+		return fmt.Sprintf("http://%s:%d", n.IPAddress, containerPort), nil
 	}
 
-	if err = ensureServerReady(ctx, fmt.Sprintf("http://%s:%d", host, port), server); err != nil {
-		return fmt.Errorf("server readiness check failed: %w", err)
+	port, err := d.getHostPort(c, containerPort)
+	if err != nil {
+		return "", fmt.Errorf("failed to get host port: %w", err)
 	}
-
-	return nil
+	return fmt.Sprintf("http://localhost:%d", port), nil
 }
 
 // prepareContainerFiles creates a volume for server.Files and returns volume name and env vars
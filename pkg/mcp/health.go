@@ -0,0 +1,93 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/obot-platform/obot/pkg/mcphealth"
+)
+
+const healthCheckTimeout = 10 * time.Second
+
+// HealthTracker returns the circuit breaker shared by the MCP gateway and the
+// periodic health checks.
+func (sm *SessionManager) HealthTracker() *mcphealth.Tracker {
+	return sm.health
+}
+
+// HealthCheckInterval returns the interval between health checks of running
+// servers, or zero if health checks are disabled.
+func (sm *SessionManager) HealthCheckInterval() time.Duration {
+	return sm.healthCheckInterval
+}
+
+// CheckServerHealth probes a running MCP server with an initialize request;
+// any response other than a 5xx means the server is reachable. Remote and
+// tunneled servers are probed over their configured network path. Hosted
+// servers are looked up in the runtime backend without deploying or changing
+// them, and are unhealthy if they are not running.
+func (sm *SessionManager) CheckServerHealth(ctx context.Context, serverConfig ServerConfig) error {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	if serverConfig.Runtime != types.RuntimeRemote {
+		url, err := sm.backend.runningServerURL(ctx, serverConfig)
+		if err != nil {
+			return err
+		}
+		return probeMCPServer(ctx, &http.Client{Timeout: healthCheckTimeout}, url, serverConfig)
+	}
+
+	if serverConfig.URL == "" {
+		return fmt.Errorf("MCP server %s has no URL", serverConfig.MCPServerName)
+	}
+
+	client, err := sm.HTTPClientForServer(serverConfig, HTTPClientOptions{
+		Timeout:       healthCheckTimeout,
+		DirectConnect: true,
+	})
+	if err != nil {
+		return err
+	}
+	return probeMCPServer(ctx, client, serverConfig.URL, serverConfig)
+}
+
+// probeMCPServer sends an initialize request to the server at url, and closes
+// the session it opens.
+func probeMCPServer(ctx context.Context, client *http.Client, url string, serverConfig ServerConfig) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, strings.NewReader(streamableHTTPHealthcheckBody))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json,text/event-stream")
+	req.Header.Set("Content-Type", "application/json")
+	copyHeaders(req.Header, serverConfig.PassthroughHeaderNames, serverConfig.PassthroughHeaderValues)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	_ = resp.Body.Close()
+
+	if sessionID := resp.Header.Get("Mcp-Session-Id"); sessionID != "" {
+		// Close the session the check opened. Errors don't matter here.
+		if req, err := http.NewRequestWithContext(ctx, http.MethodDelete, url, nil); err == nil {
+			req.Header.Set("Mcp-Session-Id", sessionID)
+			copyHeaders(req.Header, serverConfig.PassthroughHeaderNames, serverConfig.PassthroughHeaderValues)
+			if resp, err := client.Do(req); err == nil {
+				_ = resp.Body.Close()
+			}
+		}
+	}
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("unexpected status code [%d]: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
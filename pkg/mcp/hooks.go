@@ -14,9 +14,45 @@ const (
 )
 
 var (
-	ErrRPCUnknown = NewRPCError(-32001, "JSON RPC unknown error")
+	ErrRPCUnknown        = NewRPCError(-32001, "JSON RPC unknown error")
+	ErrRPCInvalidRequest = NewRPCError(-32600, "JSON RPC invalid request")
+	ErrRPCInvalidParams  = NewRPCError(-32602, "JSON RPC invalid params")
+	// ErrRPCQuotaExceeded rejects a call that would exceed an MCP call quota.
+	// Its data holds a QuotaExceededData.
+	ErrRPCQuotaExceeded = NewRPCError(-32029, "MCP call quota exceeded")
+	// ErrRPCToolCallNotApproved rejects a call held for approval that was not
+	// approved. Its data holds a ToolCallNotApprovedData.
+	ErrRPCToolCallNotApproved = NewRPCError(-32030, "MCP tool call not approved")
+	// ErrRPCServerRequestDenied answers a sampling or elicitation request from
+	// an MCP server that the gateway did not pass on to the client. Its data
+	// holds a ServerRequestDeniedData.
+	ErrRPCServerRequestDenied = NewRPCError(-32031, "MCP server request denied")
 )
 
+// QuotaExceededData tells a client rejected by a quota when to retry.
+type QuotaExceededData struct {
+	RetryAfterSeconds int    `json:"retryAfterSeconds"`
+	RetryAt           string `json:"retryAt"`
+	Window            string `json:"window"`
+	Limit             int    `json:"limit"`
+	Tool              string `json:"tool,omitempty"`
+}
+
+// ToolCallNotApprovedData tells a client which approval its call waited on and
+// how it ended.
+type ToolCallNotApprovedData struct {
+	ApprovalID string `json:"approvalID"`
+	State      string `json:"state"`
+}
+
+// ServerRequestDeniedData tells an MCP server why its request did not reach
+// the client, and which approval it waited on if it needed one.
+type ServerRequestDeniedData struct {
+	Reason     string `json:"reason"`
+	ApprovalID string `json:"approvalID,omitempty"`
+	State      string `json:"state,omitempty"`
+}
+
 // HookRunner executes one configured hook target.
 type HookRunner interface {
 	RunHook(ctx context.Context, servers HookServerConfigs, input SessionMessageHook, target string) (*SessionMessageHook, error)
@@ -87,6 +123,13 @@ func (e *RPCError) WithMessage(format string, args ...any) *RPCError {
 	return &result
 }
 
+// WithData returns a copy of the error with data encoded as its data.
+func (e *RPCError) WithData(data any) *RPCError {
+	result := *e
+	result.Data, _ = json.Marshal(data)
+	return &result
+}
+
 func MessageIDString(id any) string {
 	switch value := id.(type) {
 	case nil:
@@ -136,6 +136,10 @@ func (k *kubernetesBackend) deployServerObjects(ctx context.Context, server Serv
 		return fmt.Errorf("failed to cleanup old MCP deployment %s: %w", server.MCPServerName, err)
 	}
 
+	return k.applyServerObjects(ctx, server, objs)
+}
+
+func (k *kubernetesBackend) applyServerObjects(ctx context.Context, server ServerConfig, objs []kclient.Object) error {
 	if err := apply.New(k.client).WithNamespace(k.mcpNamespace).WithOwnerSubContext(server.MCPServerName).WithPruneTypes(
 		new(corev1.Secret), new(appsv1.Deployment), new(corev1.Service), new(corev1.PersistentVolumeClaim),
 	).Apply(ctx, nil, objs...); err != nil {
@@ -145,7 +149,9 @@ func (k *kubernetesBackend) deployServerObjects(ctx context.Context, server Serv
 	return nil
 }
 
-func (k *kubernetesBackend) ensureServerDeployment(ctx context.Context, server ServerConfig) (ServerConfig, error) {
+// transformServerURLs points the server's component and webhook URLs at Obot's
+// in-cluster address.
+func (k *kubernetesBackend) transformServerURLs(server ServerConfig) {
 	for i, component := range server.Components {
 		component.URL = k.transformObotHostname(component.URL)
 		server.Components[i] = component
@@ -155,6 +161,10 @@ func (k *kubernetesBackend) ensureServerDeployment(ctx context.Context, server S
 		webhook.URL = k.transformObotHostname(webhook.URL)
 		server.Webhooks[i] = webhook
 	}
+}
+
+func (k *kubernetesBackend) ensureServerDeployment(ctx context.Context, server ServerConfig) (ServerConfig, error) {
+	k.transformServerURLs(server)
 
 	if server.Runtime == types.RuntimeRemote || server.Runtime == types.RuntimeComposite {
 		// Remove any existing deployment for remote and composite servers
@@ -245,6 +255,20 @@ func (k *kubernetesBackend) ensureServerDeployment(ctx context.Context, server S
 	}, nil
 }
 
+func (k *kubernetesBackend) runningServerURL(ctx context.Context, server ServerConfig) (string, error) {
+	var deployment appsv1.Deployment
+	if err := k.cachedClient.Get(ctx, kclient.ObjectKey{Name: server.MCPServerName, Namespace: k.mcpNamespace}, &deployment); apierrors.IsNotFound(err) {
+		return "", ErrServerNotRunning
+	} else if err != nil {
+		return "", fmt.Errorf("failed to get deployment %s: %w", server.MCPServerName, err)
+	}
+	if deployment.Status.ReadyReplicas == 0 {
+		return "", fmt.Errorf("deployment %s has no ready replicas", server.MCPServerName)
+	}
+
+	return fmt.Sprintf("http://%s.%s.svc.%s/%s", server.MCPServerName, k.mcpNamespace, k.mcpClusterDomain, strings.TrimPrefix(server.ContainerPath, "/")), nil
+}
+
 func (k *kubernetesBackend) getServerDetails(ctx context.Context, id string) (types.MCPServerDetails, error) {
 	var deployment appsv1.Deployment
 	if err := k.cachedClient.Get(ctx, kclient.ObjectKey{Name: id, Namespace: k.mcpNamespace}, &deployment); err != nil {
@@ -1090,6 +1114,27 @@ func (k *kubernetesBackend) restartServer(ctx context.Context, server ServerConf
 	if id == "" {
 		return fmt.Errorf("MCPServerName is required to restart server")
 	}
+
+	// Apply the server's current objects first, so that a restart also picks up
+	// configuration that changed while the server ran, such as the rotated value
+	// of a bound secret. Changed configuration rolls the pods by itself.
+	var existing appsv1.Deployment
+	if err := k.client.Get(ctx, kclient.ObjectKey{Name: id, Namespace: k.mcpNamespace}, &existing); apierrors.IsNotFound(err) {
+		// If the deployment isn't found, then just return and it will be created when needed.
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to get deployment %s: %w", id, err)
+	}
+	k.transformServerURLs(server)
+	objs, err := k.k8sObjects(ctx, server)
+	if err != nil {
+		return fmt.Errorf("failed to generate kubernetes objects for server %s: %w", id, err)
+	}
+	if err := k.applyServerObjects(ctx, server, objs); err != nil {
+		return err
+	}
+	k.deleteDeploymentCache(id)
+
 	// Fetch K8s settings once at the start
 	k8sSettings := k.getK8sSettings(ctx)
 
@@ -14,6 +14,8 @@ import (
 	"github.com/obot-platform/obot/apiclient/types"
 	gateway "github.com/obot-platform/obot/pkg/gateway/client"
 	"github.com/obot-platform/obot/pkg/jwt/persistent"
+	"github.com/obot-platform/obot/pkg/mcphealth"
+	"github.com/obot-platform/obot/pkg/secretprovider"
 	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
 	"github.com/obot-platform/obot/pkg/system"
 	"github.com/obot-platform/obot/pkg/tunnel"
@@ -51,10 +53,23 @@ type Options struct {
 	DisallowLinkLocalMCP              bool     `usage:"Disallow MCP containers from connecting to link-local addresses" default:"true"`
 	MCPRuntimeBackend                 string   `usage:"The runtime backend to use for running MCP servers: docker, kubernetes, or k8s. Defaults to docker" default:"docker"`
 	MCPSecretBindingAllowedLabel      string   `usage:"Kubernetes Secret label key required for admin UI secret-binding lookup and save-time validation" default:"obot.obot.ai/allow-secret-binding"`
+	MCPSecretProvider                 string   `usage:"Where MCP secret bindings are resolved from: kubernetes, vault, or file" default:"kubernetes"`
+	MCPSecretProviderCacheTTLSeconds  int      `usage:"How long resolved secret binding values are cached, and how often they are checked for rotation, set to 0 to disable caching and rotation restarts" default:"60"`
+	MCPSecretVaultAddress             string   `usage:"The address of the Vault server MCP secret bindings are read from"`
+	MCPSecretVaultNamespace           string   `usage:"The Vault Enterprise namespace MCP secret bindings are read from"`
+	MCPSecretVaultMount               string   `usage:"The mount path of the Vault KV v2 secrets engine MCP secret bindings are read from" default:"secret"`
+	MCPSecretVaultPathPrefix          string   `usage:"The path within the Vault KV v2 mount under which bindable secrets live"`
+	MCPSecretVaultToken               string   `usage:"The token used to authenticate to Vault"`
+	MCPSecretVaultAppRoleMount        string   `usage:"The mount path of the Vault AppRole auth method" default:"approle"`
+	MCPSecretVaultAppRoleID           string   `usage:"The AppRole role ID used to authenticate to Vault"`
+	MCPSecretVaultAppRoleSecretID     string   `usage:"The AppRole secret ID used to authenticate to Vault"`
+	MCPSecretFileDirectory            string   `usage:"The directory MCP secret bindings are read from, with one subdirectory per secret and one file per key"`
 	MCPImagePullSecrets               []string `usage:"The name of the image pull secret to use for pulling MCP images"`
 	SingleUserIdleServerShutdownHours int      `usage:"The interval in hours to check for idle MCP servers designated to a single user and shut them down, set to -1 to disable shutdown" default:"24"`
 	MultiUserIdleServerShutdownHours  int      `usage:"The interval in hours to check for idle multi-user MCP servers and shut them down, set to -1 to disable" default:"168"`
 	IdleAgentShutdownHours            int      `usage:"The interval in hours to check for idle agents and shut them down, set to -1 to disable" default:"72"`
+	MCPHealthCheckIntervalSeconds     int      `usage:"The interval in seconds between health checks of running MCP servers, set to 0 to disable" default:"60"`
+	MCPCircuitBreakerFailureThreshold int      `usage:"The number of consecutive failed requests or health checks after which the gateway fails requests to an MCP server fast" default:"3"`
 
 	// Kubernetes settings from Helm
 	MCPK8sSettingsAffinity              string `usage:"Affinity rules for MCP server pods (JSON)"`
@@ -106,10 +121,10 @@ type SessionManager struct {
 	resourceMaximums          ResourceMaximums
 	storageClient             kclient.WithWatch
 	gatewayClient             *gateway.Client
-	localK8sClient            kclient.Client
-	obotNamespace             string
-	secretBindingAllowedLabel string
+	secretProvider            secretprovider.Provider
 	tunnelManager             *tunnel.Manager
+	health                    *mcphealth.Tracker
+	healthCheckInterval       time.Duration
 
 	webhookHelper *WebhookHelper
 }
@@ -179,27 +194,66 @@ func NewSessionManager(ctx context.Context, authEnabled bool, globalTokenStore G
 		return nil, fmt.Errorf("unknown runtime backend: %s", opts.MCPRuntimeBackend)
 	}
 
-	return &SessionManager{
-		webhookHelper:             webhookHelper,
-		tokenService:              tokenService,
-		globalTokenStore:          globalTokenStore,
-		backend:                   backend,
-		runtimeBackend:            opts.MCPRuntimeBackend,
-		baseURL:                   baseURL,
-		httpListenPort:            httpListenPort,
-		resourceMaximums:          resourceMaximums,
-		storageClient:             obotStorageClient,
-		gatewayClient:             gatewayClient,
-		localK8sClient:            client,
-		obotNamespace:             obotNamespace,
-		secretBindingAllowedLabel: strings.TrimSpace(opts.MCPSecretBindingAllowedLabel),
-		tunnelManager:             tunnelManager,
+	secretProvider, err := secretprovider.New(secretprovider.Config{
+		Kind:             opts.MCPSecretProvider,
+		CacheTTL:         time.Duration(opts.MCPSecretProviderCacheTTLSeconds) * time.Second,
+		KubernetesClient: client,
+		Namespace:        obotNamespace,
+		AllowedLabel:     strings.TrimSpace(opts.MCPSecretBindingAllowedLabel),
+		Vault: secretprovider.VaultConfig{
+			Address:         opts.MCPSecretVaultAddress,
+			Namespace:       opts.MCPSecretVaultNamespace,
+			Mount:           opts.MCPSecretVaultMount,
+			PathPrefix:      opts.MCPSecretVaultPathPrefix,
+			Token:           opts.MCPSecretVaultToken,
+			AppRoleMount:    opts.MCPSecretVaultAppRoleMount,
+			AppRoleID:       opts.MCPSecretVaultAppRoleID,
+			AppRoleSecretID: opts.MCPSecretVaultAppRoleSecretID,
+		},
+		Directory: opts.MCPSecretFileDirectory,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize MCP secret provider: %w", err)
+	}
+
+	sm := &SessionManager{
+		webhookHelper:    webhookHelper,
+		tokenService:     tokenService,
+		globalTokenStore: globalTokenStore,
+		backend:          backend,
+		runtimeBackend:   opts.MCPRuntimeBackend,
+		baseURL:          baseURL,
+		httpListenPort:   httpListenPort,
+		resourceMaximums: resourceMaximums,
+		storageClient:    obotStorageClient,
+		gatewayClient:    gatewayClient,
+		secretProvider:   secretProvider,
+		tunnelManager:    tunnelManager,
+		health: mcphealth.NewTracker(mcphealth.Options{
+			FailureThreshold: opts.MCPCircuitBreakerFailureThreshold,
+		}),
+		healthCheckInterval: time.Duration(opts.MCPHealthCheckIntervalSeconds) * time.Second,
 		remoteURLValidationConfig: RemoteMCPURLValidationConfig{
 			AllowLocalhostMCP: !opts.DisallowLocalhostMCP,
 			AllowPrivateIPMCP: !opts.DisallowPrivateIPMCP,
 			AllowLinkLocalMCP: !opts.DisallowLinkLocalMCP,
 		},
-	}, nil
+	}
+
+	if cache, ok := secretProvider.(*secretprovider.Cache); ok {
+		go cache.Run(ctx, sm.restartServersBoundToSecret)
+	}
+
+	return sm, nil
+}
+
+// SecretProvider returns the provider secret bindings are resolved from, or
+// nil if none is configured.
+func (sm *SessionManager) SecretProvider() secretprovider.Provider {
+	if sm == nil {
+		return nil
+	}
+	return sm.secretProvider
 }
 
 func (sm *SessionManager) MCPRuntimeBackend() string {
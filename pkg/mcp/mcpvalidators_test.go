@@ -2478,12 +2478,12 @@ func TestValidateSecretBindings(t *testing.T) {
 	binding := &types.MCPSecretBinding{Name: "datadog-prod", Key: "api-key"}
 
 	tests := []struct {
-		name         string
-		manifest     types.MCPServerManifest
-		gitManaged   bool
-		adminManaged bool
-		backend      string
-		wantErr      string // substring; "" = expect no error
+		name           string
+		manifest       types.MCPServerManifest
+		gitManaged     bool
+		adminManaged   bool
+		secretProvider bool
+		wantErr        string // substring; "" = expect no error
 	}{
 		{
 			name: "no bindings is allowed regardless",
@@ -2493,8 +2493,8 @@ func TestValidateSecretBindings(t *testing.T) {
 					Headers: []types.MCPHeader{{Key: "X-Foo", Value: "bar"}},
 				},
 			},
-			gitManaged: false,
-			backend:    "docker",
+			gitManaged:     false,
+			secretProvider: false,
 		},
 		{
 			name: "bound header requires git-managed",
@@ -2504,9 +2504,9 @@ func TestValidateSecretBindings(t *testing.T) {
 					Headers: []types.MCPHeader{{Key: "DD-API-KEY", SecretBinding: binding}},
 				},
 			},
-			gitManaged: false,
-			backend:    "kubernetes",
-			wantErr:    "git-synced catalog entries",
+			gitManaged:     false,
+			secretProvider: true,
+			wantErr:        "git-synced catalog entries",
 		},
 		{
 			name: "bound header accepted for git-managed remote",
@@ -2516,8 +2516,8 @@ func TestValidateSecretBindings(t *testing.T) {
 					Headers: []types.MCPHeader{{Key: "DD-API-KEY", SecretBinding: binding}},
 				},
 			},
-			gitManaged: true,
-			backend:    "kubernetes",
+			gitManaged:     true,
+			secretProvider: true,
 		},
 		{
 			name: "bound env accepted for admin-managed multi-user server",
@@ -2525,8 +2525,8 @@ func TestValidateSecretBindings(t *testing.T) {
 				Runtime: types.RuntimeContainerized,
 				Env:     []types.MCPEnv{{Key: "DD_API_KEY", SecretBinding: binding}},
 			},
-			adminManaged: true,
-			backend:      "kubernetes",
+			adminManaged:   true,
+			secretProvider: true,
 		},
 		{
 			name: "bound multi-user header is rejected",
@@ -2536,21 +2536,21 @@ func TestValidateSecretBindings(t *testing.T) {
 					Key: "X-API-Key", SecretBinding: binding,
 				}}},
 			},
-			adminManaged: true,
-			backend:      "kubernetes",
-			wantErr:      "secretBinding is not supported for user-defined headers",
+			adminManaged:   true,
+			secretProvider: true,
+			wantErr:        "secretBinding is not supported for user-defined headers",
 		},
 		{
-			name: "bound header rejected on non-kubernetes backend",
+			name: "bound header rejected without a secret provider",
 			manifest: types.MCPServerManifest{
 				Runtime: types.RuntimeRemote,
 				RemoteConfig: &types.RemoteRuntimeConfig{
 					Headers: []types.MCPHeader{{Key: "DD-API-KEY", SecretBinding: binding}},
 				},
 			},
-			gitManaged: true,
-			backend:    "docker",
-			wantErr:    "requires the kubernetes MCP runtime backend",
+			gitManaged:     true,
+			secretProvider: false,
+			wantErr:        "requires a configured secret provider",
 		},
 		{
 			name: "binding and static value are mutually exclusive",
@@ -2560,9 +2560,9 @@ func TestValidateSecretBindings(t *testing.T) {
 					Headers: []types.MCPHeader{{Key: "DD-API-KEY", Value: "literal", SecretBinding: binding}},
 				},
 			},
-			gitManaged: true,
-			backend:    "kubernetes",
-			wantErr:    "mutually exclusive",
+			gitManaged:     true,
+			secretProvider: true,
+			wantErr:        "mutually exclusive",
 		},
 		{
 			name: "binding requires non-empty name/key",
@@ -2572,9 +2572,9 @@ func TestValidateSecretBindings(t *testing.T) {
 					Headers: []types.MCPHeader{{Key: "DD-API-KEY", SecretBinding: &types.MCPSecretBinding{Name: "datadog-prod"}}},
 				},
 			},
-			gitManaged: true,
-			backend:    "kubernetes",
-			wantErr:    "requires both name and key",
+			gitManaged:     true,
+			secretProvider: true,
+			wantErr:        "requires both name and key",
 		},
 		{
 			name: "bound env under remote runtime is rejected",
@@ -2583,9 +2583,9 @@ func TestValidateSecretBindings(t *testing.T) {
 				Env:          []types.MCPEnv{{Key: "DD_API_KEY", SecretBinding: binding}},
 				RemoteConfig: &types.RemoteRuntimeConfig{},
 			},
-			gitManaged: true,
-			backend:    "kubernetes",
-			wantErr:    "not supported for remote runtime",
+			gitManaged:     true,
+			secretProvider: true,
+			wantErr:        "not supported for remote runtime",
 		},
 		{
 			name: "file-backed env with secret binding is accepted",
@@ -2593,8 +2593,8 @@ func TestValidateSecretBindings(t *testing.T) {
 				Runtime: types.RuntimeContainerized,
 				Env:     []types.MCPEnv{{Key: "DD_API_KEY", SecretBinding: binding, File: true}},
 			},
-			gitManaged: true,
-			backend:    "kubernetes",
+			gitManaged:     true,
+			secretProvider: true,
 		},
 		{
 			name: "bound env accepted for git-managed containerized",
@@ -2602,8 +2602,8 @@ func TestValidateSecretBindings(t *testing.T) {
 				Runtime: types.RuntimeContainerized,
 				Env:     []types.MCPEnv{{Key: "DD_API_KEY", SecretBinding: binding}},
 			},
-			gitManaged: true,
-			backend:    "kubernetes",
+			gitManaged:     true,
+			secretProvider: true,
 		},
 		{
 			name: "dynamicFile without file is accepted and ignored",
@@ -2614,8 +2614,8 @@ func TestValidateSecretBindings(t *testing.T) {
 					DynamicFile: true,
 				}},
 			},
-			gitManaged: true,
-			backend:    "kubernetes",
+			gitManaged:     true,
+			secretProvider: true,
 		},
 		{
 			name: "file and dynamicFile together are accepted",
@@ -2627,14 +2627,14 @@ func TestValidateSecretBindings(t *testing.T) {
 					DynamicFile: true,
 				}},
 			},
-			gitManaged: true,
-			backend:    "kubernetes",
+			gitManaged:     true,
+			secretProvider: true,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := ValidateSecretBindings(tt.manifest, tt.gitManaged, tt.adminManaged, tt.backend)
+			err := ValidateSecretBindings(tt.manifest, tt.gitManaged, tt.adminManaged, tt.secretProvider)
 			if tt.wantErr == "" {
 				require.NoError(t, err)
 				return
@@ -2688,7 +2688,7 @@ func TestValidateSecretBindingsCatalogEntry_URLTemplate(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := ValidateSecretBindingsCatalogEntry(tt.manifest, true, false, "kubernetes")
+			err := ValidateSecretBindingsCatalogEntry(tt.manifest, true, false, true)
 			if tt.wantErr == "" {
 				require.NoError(t, err)
 				return
@@ -2754,7 +2754,7 @@ func TestValidateSecretBindingsCatalogEntryMultiUser(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := ValidateSecretBindingsCatalogEntry(tt.manifest, false, tt.adminManaged, "kubernetes")
+			err := ValidateSecretBindingsCatalogEntry(tt.manifest, false, tt.adminManaged, true)
 			if tt.wantErr == "" {
 				require.NoError(t, err)
 				return
@@ -2810,7 +2810,7 @@ func TestValidateSecretBindingsCatalogEntryRejectsAdminAdded(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := ValidateSecretBindingsCatalogEntry(tt.manifest, true, false, "kubernetes")
+			err := ValidateSecretBindingsCatalogEntry(tt.manifest, true, false, true)
 			require.Error(t, err)
 			require.Contains(t, err.Error(), tt.wantErr)
 		})
@@ -2828,7 +2828,7 @@ func TestValidateSecretBindingsCatalogEntryRejectsMultiUserHeaderBinding(t *test
 		}}},
 	}
 
-	err := ValidateSecretBindingsCatalogEntry(manifest, true, false, "kubernetes")
+	err := ValidateSecretBindingsCatalogEntry(manifest, true, false, true)
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "secretBinding is not supported for user-defined headers")
 }
@@ -4,15 +4,10 @@ import (
 	"context"
 	"fmt"
 	"maps"
-	"sort"
 	"strings"
 
 	"github.com/obot-platform/obot/apiclient/types"
-	corev1 "k8s.io/api/core/v1"
-	apierrors "k8s.io/apimachinery/pkg/api/errors"
-	"k8s.io/apimachinery/pkg/labels"
-	"k8s.io/apimachinery/pkg/selection"
-	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"github.com/obot-platform/obot/pkg/secretprovider"
 )
 
 type MissingSecretBinding struct {
@@ -22,7 +17,7 @@ type MissingSecretBinding struct {
 }
 
 // MergeBoundCreds resolves every secretBinding referenced by envs and (for
-// remote runtime) remoteConfig.Headers from the obot namespace and returns a
+// remote runtime) remoteConfig.Headers from the secret provider and returns a
 // NEW map containing credEnv merged with the resolved values:
 //
 //   - env bindings → out[env.Key] = <secret value>
@@ -44,24 +39,21 @@ type MissingSecretBinding struct {
 // ServerToServerConfig / ConvertMCPServer only.
 //
 // If there are no secretBindings, MergeBoundCreds returns credEnv unchanged
-// (no pool). If c is nil (docker backend), bindings cannot be resolved
-// and the returned map omits them — the downstream missing-required gate
-// then fires for required bindings.
+// (no pool). If p is nil (no secret provider is configured), bindings cannot
+// be resolved and the returned map omits them — the downstream
+// missing-required gate then fires for required bindings.
 //
-// Secrets must have the configured secret-binding allow label. A Secret without
-// that label is treated as unavailable, the same as a missing Secret/key.
+// A secret the provider reports as unavailable is treated the same as a
+// missing key.
 //
-// Lookups are cached per-call by Secret name so a manifest with N bindings
-// against the same Secret performs one Get. Reads hit nah's watch cache, so
-// calling this from API request paths is cheap.
+// Lookups are cached per-call by secret name so a manifest with N bindings
+// against the same secret performs one Get.
 func MergeBoundCreds(
 	ctx context.Context,
-	c kclient.Client,
-	obotNamespace string,
+	p secretprovider.Provider,
 	envs []types.MCPEnv,
 	remoteConfig *types.RemoteRuntimeConfig,
 	credEnv map[string]string,
-	allowedLabel string,
 ) (map[string]string, error) {
 	// Fast path: no bindings → nothing to merge, return credEnv as-is.
 	if !hasAnyBinding(envs, remoteConfig) {
@@ -72,11 +64,10 @@ func MergeBoundCreds(
 	merged := make(map[string]string, len(credEnv)+8)
 	maps.Copy(merged, credEnv)
 
-	if c == nil {
-		// No kclient (docker backend) → strip any stale credEnv values for
-		// bound keys so the downstream missing-required gate fires
-		// uniformly. The API validator rejects bindings on the docker
-		// backend, but be defensive.
+	if p == nil {
+		// No secret provider → strip any stale credEnv values for bound keys
+		// so the downstream missing-required gate fires uniformly. The API
+		// validator rejects bindings without a provider, but be defensive.
 		for _, e := range envs {
 			if e.SecretBinding != nil {
 				delete(merged, e.Key)
@@ -92,7 +83,7 @@ func MergeBoundCreds(
 		return merged, nil
 	}
 
-	// secretCache[name] is nil when the Secret was confirmed unavailable,
+	// secretCache[name] is nil when the secret was confirmed unavailable,
 	// non-nil (possibly empty) when it exists and is allowed.
 	secretCache := map[string]map[string][]byte{}
 
@@ -102,21 +93,11 @@ func MergeBoundCreds(
 		}
 		data, cached := secretCache[b.Name]
 		if !cached {
-			var s corev1.Secret
-			getErr := c.Get(ctx, kclient.ObjectKey{Namespace: obotNamespace, Name: b.Name}, &s)
-			switch {
-			case apierrors.IsNotFound(getErr):
-				secretCache[b.Name] = nil
-				return "", false, nil
-			case getErr != nil:
-				return "", false, fmt.Errorf("get secret %s/%s: %w", obotNamespace, b.Name, getErr)
+			var err error
+			if data, err = p.Get(ctx, b.Name); err != nil {
+				return "", false, err
 			}
-			if _, ok := s.Labels[allowedLabel]; !ok {
-				secretCache[b.Name] = nil
-				return "", false, nil
-			}
-			secretCache[b.Name] = s.Data
-			data = s.Data
+			secretCache[b.Name] = data
 		}
 		if data == nil {
 			return "", false, nil
@@ -133,7 +114,7 @@ func MergeBoundCreds(
 			continue
 		}
 		// Strip any stale credEnv value before resolving. Bound source of
-		// truth is the Secret; user-supplied values for bound keys are
+		// truth is the secret; user-supplied values for bound keys are
 		// rejected by the validator.
 		delete(merged, env.Key)
 
@@ -170,7 +151,7 @@ func MergeBoundCreds(
 	return merged, nil
 }
 
-func MissingSecretBindings(ctx context.Context, c kclient.Client, obotNamespace string, envs []types.MCPEnv, remoteConfig *types.RemoteRuntimeConfig, allowedLabel string) ([]MissingSecretBinding, error) {
+func MissingSecretBindings(ctx context.Context, p secretprovider.Provider, envs []types.MCPEnv, remoteConfig *types.RemoteRuntimeConfig) ([]MissingSecretBinding, error) {
 	hasBinding := false
 	for _, env := range envs {
 		if env.SecretBinding != nil {
@@ -189,11 +170,11 @@ func MissingSecretBindings(ctx context.Context, c kclient.Client, obotNamespace
 	if !hasBinding {
 		return nil, nil
 	}
-	if c == nil {
-		return nil, fmt.Errorf("secret bindings require a Kubernetes client")
+	if p == nil {
+		return nil, fmt.Errorf("secret bindings require a secret provider")
 	}
 
-	resolved, err := MergeBoundCreds(ctx, c, obotNamespace, envs, remoteConfig, nil, allowedLabel)
+	resolved, err := MergeBoundCreds(ctx, p, envs, remoteConfig, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -222,17 +203,17 @@ func MissingSecretBindings(ctx context.Context, c kclient.Client, obotNamespace
 
 // ValidateSecretBindingsAvailable verifies secret-bound config can be resolved
 // before creating/updating/launching a server that users cannot fix.
-func ValidateSecretBindingsAvailable(ctx context.Context, c kclient.Client, obotNamespace string, envs []types.MCPEnv, remoteConfig *types.RemoteRuntimeConfig, allowedLabel string) error {
-	missing, err := MissingSecretBindings(ctx, c, obotNamespace, envs, remoteConfig, allowedLabel)
+func ValidateSecretBindingsAvailable(ctx context.Context, p secretprovider.Provider, envs []types.MCPEnv, remoteConfig *types.RemoteRuntimeConfig) error {
+	missing, err := MissingSecretBindings(ctx, p, envs, remoteConfig)
 	if err != nil {
 		return err
 	}
 	if len(missing) > 0 {
 		fields := make([]string, 0, len(missing))
 		for _, field := range missing {
-			fields = append(fields, fmt.Sprintf("%s %q references %s/%s", field.Kind, field.Header.Key, obotNamespace, field.Binding.Name))
+			fields = append(fields, fmt.Sprintf("%s %q references %s", field.Kind, field.Header.Key, p.Location(field.Binding.Name)))
 		}
-		return fmt.Errorf("secret bindings reference unavailable Kubernetes Secrets: %s", strings.Join(fields, ", "))
+		return fmt.Errorf("secret bindings reference unavailable %s: %s", p.Description(), strings.Join(fields, ", "))
 	}
 	return nil
 }
@@ -253,40 +234,10 @@ func hasAnyBinding(envs []types.MCPEnv, remoteConfig *types.RemoteRuntimeConfig)
 	return false
 }
 
-// ListAllowedSecretBindingTargets returns labeled Kubernetes Secrets and data keys that admins may select for MCP secret bindings.
-func ListAllowedSecretBindingTargets(ctx context.Context, c kclient.Client, obotNamespace, allowedLabel string) ([]types.MCPAllowedSecretBindingTarget, error) {
-	if c == nil {
+// ListAllowedSecretBindingTargets returns the secrets and data keys that admins may select for MCP secret bindings.
+func ListAllowedSecretBindingTargets(ctx context.Context, p secretprovider.Provider) ([]types.MCPAllowedSecretBindingTarget, error) {
+	if p == nil {
 		return nil, nil
 	}
-
-	requirement, err := labels.NewRequirement(allowedLabel, selection.Exists, nil)
-	if err != nil || requirement == nil {
-		return nil, fmt.Errorf("create allowed secret binding label selector: %w", err)
-	}
-	selector := labels.NewSelector().Add(*requirement)
-	var secrets corev1.SecretList
-	if err := c.List(ctx, &secrets, kclient.InNamespace(obotNamespace), kclient.MatchingLabelsSelector{Selector: selector}); err != nil {
-		return nil, fmt.Errorf("list allowed secret bindings: %w", err)
-	}
-
-	targets := make([]types.MCPAllowedSecretBindingTarget, 0, len(secrets.Items))
-	for _, secret := range secrets.Items {
-		keys := make([]string, 0, len(secret.Data))
-		for key := range secret.Data {
-			keys = append(keys, key)
-		}
-		if len(keys) == 0 {
-			continue
-		}
-		sort.Strings(keys)
-		targets = append(targets, types.MCPAllowedSecretBindingTarget{
-			Name: secret.Name,
-			Keys: keys,
-		})
-	}
-
-	sort.Slice(targets, func(i, j int) bool {
-		return targets[i].Name < targets[j].Name
-	})
-	return targets, nil
+	return p.List(ctx)
 }
@@ -1,9 +1,12 @@
 package mcp
 
 import (
+	"fmt"
+	"path/filepath"
 	"testing"
 
 	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/obot-platform/obot/pkg/secretprovider"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
@@ -20,11 +23,11 @@ func TestMergeBoundCreds(t *testing.T) {
 	const ns = "obot-ns"
 	const label = "test-secret-binding-label"
 
-	newClient := func(t *testing.T, objects ...kclient.Object) kclient.Client {
+	newProvider := func(t *testing.T, objects ...kclient.Object) secretprovider.Provider {
 		t.Helper()
 		scheme := runtime.NewScheme()
 		require.NoError(t, corev1.AddToScheme(scheme))
-		return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+		return secretprovider.NewKubernetes(fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build(), ns, label)
 	}
 
 	t.Run("does not mutate input cred map and overrides stale values", func(t *testing.T) {
@@ -32,12 +35,12 @@ func TestMergeBoundCreds(t *testing.T) {
 		input := map[string]string{"API_KEY": "stale", "UNCHANGED": "keep"}
 		inputBefore := map[string]string{"API_KEY": "stale", "UNCHANGED": "keep"}
 
-		c := newClient(t, &corev1.Secret{
+		p := newProvider(t, &corev1.Secret{
 			Data: map[string][]byte{"api_key": []byte("fresh")},
 			Name: "bound-secret", Namespace: ns, Labels: map[string]string{label: "true"},
 		})
 
-		out, err := MergeBoundCreds(t.Context(), c, ns, manifestEnv, nil, input, label)
+		out, err := MergeBoundCreds(t.Context(), p, manifestEnv, nil, input)
 		require.NoError(t, err)
 		assert.Equal(t, inputBefore, input)
 		assert.Equal(t, "fresh", out["API_KEY"])
@@ -48,39 +51,39 @@ func TestMergeBoundCreds(t *testing.T) {
 		manifestEnv := []types.MCPEnv{{Key: "API_KEY", SecretBinding: binding("missing-secret", "api_key")}}
 		input := map[string]string{"API_KEY": "stale", "OTHER": "ok"}
 
-		out, err := MergeBoundCreds(t.Context(), newClient(t), ns, manifestEnv, nil, input, label)
+		out, err := MergeBoundCreds(t.Context(), newProvider(t), manifestEnv, nil, input)
 		require.NoError(t, err)
 		assert.NotContains(t, out, "API_KEY")
 		assert.Equal(t, "ok", out["OTHER"])
 
 		manifestEnv[0].SecretBinding = binding("present-secret", "missing-key")
-		c := newClient(t, &corev1.Secret{
+		p := newProvider(t, &corev1.Secret{
 			Data: map[string][]byte{"other": []byte("x")},
 			Name: "present-secret", Namespace: ns, Labels: map[string]string{label: "true"},
 		})
-		out, err = MergeBoundCreds(t.Context(), c, ns, manifestEnv, nil, input, label)
+		out, err = MergeBoundCreds(t.Context(), p, manifestEnv, nil, input)
 		require.NoError(t, err)
 		assert.NotContains(t, out, "API_KEY")
 	})
 
 	t.Run("merges remote header bindings", func(t *testing.T) {
 		remote := &types.RemoteRuntimeConfig{Headers: []types.MCPHeader{{Key: "Authorization", SecretBinding: binding("auth-secret", "token")}}}
-		c := newClient(t, &corev1.Secret{
+		p := newProvider(t, &corev1.Secret{
 			Data: map[string][]byte{"token": []byte("Bearer abc")},
 			Name: "auth-secret", Namespace: ns, Labels: map[string]string{label: "true"},
 		})
 
-		out, err := MergeBoundCreds(t.Context(), c, ns, nil, remote, map[string]string{"Authorization": "stale"}, label)
+		out, err := MergeBoundCreds(t.Context(), p, nil, remote, map[string]string{"Authorization": "stale"})
 		require.NoError(t, err)
 		assert.Equal(t, "Bearer abc", out["Authorization"])
 	})
 
-	t.Run("nil client strips bound keys and keeps others", func(t *testing.T) {
+	t.Run("nil provider strips bound keys and keeps others", func(t *testing.T) {
 		manifestEnv := []types.MCPEnv{{Key: "API_KEY", SecretBinding: binding("s", "k")}}
 		remote := &types.RemoteRuntimeConfig{Headers: []types.MCPHeader{{Key: "Authorization", SecretBinding: binding("s", "token")}}}
 		in := map[string]string{"API_KEY": "x", "Authorization": "y", "OTHER": "ok"}
 
-		out, err := MergeBoundCreds(t.Context(), nil, ns, manifestEnv, remote, in, label)
+		out, err := MergeBoundCreds(t.Context(), nil, manifestEnv, remote, in)
 		require.NoError(t, err)
 		assert.NotContains(t, out, "API_KEY")
 		assert.NotContains(t, out, "Authorization")
@@ -90,12 +93,12 @@ func TestMergeBoundCreds(t *testing.T) {
 	t.Run("empty secret value is treated as missing", func(t *testing.T) {
 		manifestEnv := []types.MCPEnv{{Key: "API_KEY", SecretBinding: binding("bound-secret", "api_key")}}
 		in := map[string]string{"API_KEY": "stale"}
-		c := newClient(t, &corev1.Secret{
+		p := newProvider(t, &corev1.Secret{
 			Data: map[string][]byte{"api_key": []byte("")},
 			Name: "bound-secret", Namespace: ns, Labels: map[string]string{label: "true"},
 		})
 
-		out, err := MergeBoundCreds(t.Context(), c, ns, manifestEnv, nil, in, label)
+		out, err := MergeBoundCreds(t.Context(), p, manifestEnv, nil, in)
 		require.NoError(t, err)
 		assert.NotContains(t, out, "API_KEY")
 	})
@@ -103,12 +106,12 @@ func TestMergeBoundCreds(t *testing.T) {
 	t.Run("unlabeled secret is treated as missing", func(t *testing.T) {
 		manifestEnv := []types.MCPEnv{{Key: "API_KEY", SecretBinding: binding("bound-secret", "api_key")}}
 		in := map[string]string{"API_KEY": "stale", "OTHER": "ok"}
-		c := newClient(t, &corev1.Secret{
+		p := newProvider(t, &corev1.Secret{
 			Data: map[string][]byte{"api_key": []byte("fresh")},
 			Name: "bound-secret", Namespace: ns,
 		})
 
-		out, err := MergeBoundCreds(t.Context(), c, ns, manifestEnv, nil, in, label)
+		out, err := MergeBoundCreds(t.Context(), p, manifestEnv, nil, in)
 		require.NoError(t, err)
 		assert.NotContains(t, out, "API_KEY")
 		assert.Equal(t, "ok", out["OTHER"])
@@ -119,59 +122,59 @@ func TestValidateSecretBindingsAvailable(t *testing.T) {
 	const ns = "obot-ns"
 	const label = "test-secret-binding-label"
 
-	newClient := func(t *testing.T, objects ...kclient.Object) kclient.Client {
+	newProvider := func(t *testing.T, objects ...kclient.Object) secretprovider.Provider {
 		t.Helper()
 		scheme := runtime.NewScheme()
 		require.NoError(t, corev1.AddToScheme(scheme))
-		return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+		return secretprovider.NewKubernetes(fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build(), ns, label)
 	}
 
 	requiredEnv := []types.MCPEnv{{Key: "API_KEY", Required: true, SecretBinding: binding("bound-secret", "api_key")}}
 
 	t.Run("valid secret", func(t *testing.T) {
-		c := newClient(t, &corev1.Secret{
+		p := newProvider(t, &corev1.Secret{
 			Data: map[string][]byte{"api_key": []byte("fresh")},
 			Name: "bound-secret", Namespace: ns, Labels: map[string]string{label: "true"},
 		})
 
-		require.NoError(t, ValidateSecretBindingsAvailable(t.Context(), c, ns, requiredEnv, nil, label))
+		require.NoError(t, ValidateSecretBindingsAvailable(t.Context(), p, requiredEnv, nil))
 	})
 
 	t.Run("missing secret", func(t *testing.T) {
-		err := ValidateSecretBindingsAvailable(t.Context(), newClient(t), ns, requiredEnv, nil, label)
+		err := ValidateSecretBindingsAvailable(t.Context(), newProvider(t), requiredEnv, nil)
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "unavailable Kubernetes Secret")
 	})
 
 	t.Run("missing key", func(t *testing.T) {
-		c := newClient(t, &corev1.Secret{
+		p := newProvider(t, &corev1.Secret{
 			Data: map[string][]byte{"other": []byte("fresh")},
 			Name: "bound-secret", Namespace: ns, Labels: map[string]string{label: "true"},
 		})
 
-		err := ValidateSecretBindingsAvailable(t.Context(), c, ns, requiredEnv, nil, label)
+		err := ValidateSecretBindingsAvailable(t.Context(), p, requiredEnv, nil)
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "unavailable Kubernetes Secret")
 	})
 
 	t.Run("empty value is treated as unavailable", func(t *testing.T) {
-		c := newClient(t, &corev1.Secret{
+		p := newProvider(t, &corev1.Secret{
 			Data: map[string][]byte{"api_key": []byte("")},
 			Name: "bound-secret", Namespace: ns, Labels: map[string]string{label: "true"},
 		})
 
-		err := ValidateSecretBindingsAvailable(t.Context(), c, ns, requiredEnv, nil, label)
+		err := ValidateSecretBindingsAvailable(t.Context(), p, requiredEnv, nil)
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "unavailable Kubernetes Secret")
 	})
 
 	t.Run("unlabeled secret", func(t *testing.T) {
-		c := newClient(t, &corev1.Secret{
+		p := newProvider(t, &corev1.Secret{
 			Data: map[string][]byte{"api_key": []byte("fresh")},
 			Name: "bound-secret", Namespace: ns,
 		})
 
-		err := ValidateSecretBindingsAvailable(t.Context(), c, ns, requiredEnv, nil, label)
+		err := ValidateSecretBindingsAvailable(t.Context(), p, requiredEnv, nil)
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "unavailable Kubernetes Secret")
 	})
@@ -179,28 +182,44 @@ func TestValidateSecretBindingsAvailable(t *testing.T) {
 	t.Run("binding is checked even when optional", func(t *testing.T) {
 		env := []types.MCPEnv{{Key: "API_KEY", SecretBinding: binding("missing", "api_key")}}
 
-		err := ValidateSecretBindingsAvailable(t.Context(), newClient(t), ns, env, nil, label)
+		err := ValidateSecretBindingsAvailable(t.Context(), newProvider(t), env, nil)
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "unavailable Kubernetes Secret")
 	})
 
 	t.Run("remote header", func(t *testing.T) {
 		remote := &types.RemoteRuntimeConfig{Headers: []types.MCPHeader{{Key: "Authorization", Required: true, SecretBinding: binding("auth-secret", "token")}}}
-		c := newClient(t, &corev1.Secret{
+		p := newProvider(t, &corev1.Secret{
 			Data: map[string][]byte{"token": []byte("Bearer abc")},
 			Name: "auth-secret", Namespace: ns, Labels: map[string]string{label: "true"},
 		})
 
-		require.NoError(t, ValidateSecretBindingsAvailable(t.Context(), c, ns, nil, remote, label))
+		require.NoError(t, ValidateSecretBindingsAvailable(t.Context(), p, nil, remote))
 	})
 
 	t.Run("reports all missing bindings", func(t *testing.T) {
 		remote := &types.RemoteRuntimeConfig{Headers: []types.MCPHeader{{Key: "Authorization", Required: true, SecretBinding: binding("auth-secret", "token")}}}
 
-		err := ValidateSecretBindingsAvailable(t.Context(), newClient(t), ns, requiredEnv, remote, label)
+		err := ValidateSecretBindingsAvailable(t.Context(), newProvider(t), requiredEnv, remote)
 		require.Error(t, err)
 		assert.Equal(t, err.Error(), `secret bindings reference unavailable Kubernetes Secrets: env "API_KEY" references obot-ns/bound-secret, header "Authorization" references obot-ns/auth-secret`)
 	})
+
+	t.Run("reports where other providers look", func(t *testing.T) {
+		dir := t.TempDir()
+		p, err := secretprovider.NewFile(dir)
+		require.NoError(t, err)
+
+		err = ValidateSecretBindingsAvailable(t.Context(), p, requiredEnv, nil)
+		require.Error(t, err)
+		assert.Equal(t, err.Error(), fmt.Sprintf(`secret bindings reference unavailable secret files: env "API_KEY" references %s`, filepath.Join(dir, "bound-secret")))
+	})
+
+	t.Run("no provider", func(t *testing.T) {
+		err := ValidateSecretBindingsAvailable(t.Context(), nil, requiredEnv, nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "secret bindings require a secret provider")
+	})
 }
 
 func TestMissingSecretBindings(t *testing.T) {
@@ -214,10 +233,9 @@ func TestMissingSecretBindings(t *testing.T) {
 		Name: "bound-secret", Namespace: ns, Labels: map[string]string{label: "true"},
 	}).Build()
 
-	missing, err := MissingSecretBindings(t.Context(), c, ns,
+	missing, err := MissingSecretBindings(t.Context(), secretprovider.NewKubernetes(c, ns, label),
 		[]types.MCPEnv{{Key: "ENV_KEY", SecretBinding: binding("bound-secret", "env_key")}},
 		&types.RemoteRuntimeConfig{Headers: []types.MCPHeader{{Key: "Authorization", SecretBinding: binding("missing-secret", "token")}}},
-		label,
 	)
 	require.NoError(t, err)
 	require.Len(t, missing, 1)
@@ -250,7 +268,7 @@ func TestListAllowedSecretBindingTargets(t *testing.T) {
 		},
 	).Build()
 
-	targets, err := ListAllowedSecretBindingTargets(t.Context(), c, ns, label)
+	targets, err := ListAllowedSecretBindingTargets(t.Context(), secretprovider.NewKubernetes(c, ns, label))
 	require.NoError(t, err)
 	assert.Equal(t, []types.MCPAllowedSecretBindingTarget{
 		{Name: "a-secret", Keys: []string{"token"}},
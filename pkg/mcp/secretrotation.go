@@ -0,0 +1,70 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/obot-platform/obot/apiclient/types"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	"github.com/obot-platform/obot/pkg/system"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// restartServersBoundToSecret restarts the deployed servers whose
+// configuration binds the named secret, so that they pick up its rotated
+// value. Remote servers have nothing to restart; their sessions are closed so
+// that the next request sends the new header values.
+func (sm *SessionManager) restartServersBoundToSecret(ctx context.Context, secretName string) {
+	var servers v1.MCPServerList
+	if err := sm.storageClient.List(ctx, &servers, kclient.InNamespace(system.DefaultNamespace)); err != nil {
+		slog.Error("failed to list MCP servers to restart for rotated secret", "secret", secretName, "error", err)
+		return
+	}
+
+	for _, server := range servers.Items {
+		if !server.DeletionTimestamp.IsZero() || !bindsSecret(server.Spec.Manifest, secretName) {
+			continue
+		}
+
+		switch server.Spec.Manifest.Runtime {
+		case types.RuntimeComposite:
+			// Components are servers of their own and are restarted individually.
+			continue
+		case types.RuntimeRemote:
+			slog.Info("Closing sessions of MCP server after bound secret rotated", "mcpServerName", server.Name, "secret", secretName)
+			sm.closeClients(server.Name)
+			continue
+		}
+
+		serverConfig, _, err := sm.serverConfigForAction(ctx, server, server.Spec.UserID, false)
+		if err != nil {
+			slog.Error("failed to build config to restart MCP server for rotated secret", "mcpServerName", server.Name, "secret", secretName, "error", err)
+			continue
+		}
+
+		slog.Info("Restarting MCP server after bound secret rotated", "mcpServerName", server.Name, "secret", secretName)
+		if err := sm.RestartServerDeployment(ctx, serverConfig); err != nil {
+			if nse := (*ErrNotSupportedByBackend)(nil); errors.As(err, &nse) {
+				continue
+			}
+			slog.Error("failed to restart MCP server for rotated secret", "mcpServerName", server.Name, "secret", secretName, "error", err)
+		}
+	}
+}
+
+func bindsSecret(manifest types.MCPServerManifest, secretName string) bool {
+	for _, env := range manifest.Env {
+		if env.SecretBinding != nil && env.SecretBinding.Name == secretName {
+			return true
+		}
+	}
+	if manifest.RemoteConfig != nil {
+		for _, header := range manifest.RemoteConfig.Headers {
+			if header.SecretBinding != nil && header.SecretBinding.Name == secretName {
+				return true
+			}
+		}
+	}
+	return false
+}
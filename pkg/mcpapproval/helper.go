@@ -0,0 +1,293 @@
+// Package mcpapproval holds MCP tool calls, and requests MCP servers send to
+// clients, that a policy says need a second person to approve them.
+package mcpapproval
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/obot-platform/nah/pkg/backend"
+	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/obot-platform/obot/pkg/notification"
+	"github.com/obot-platform/obot/pkg/principal"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	"github.com/obot-platform/obot/pkg/system"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kuser "k8s.io/apiserver/pkg/authentication/user"
+	gocache "k8s.io/client-go/tools/cache"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	resourceIndex = "resource"
+
+	defaultPollInterval = time.Second
+)
+
+type Helper struct {
+	indexer      gocache.Indexer
+	client       kclient.Client
+	pollInterval time.Duration
+	now          func() time.Time
+}
+
+func NewHelper(ctx context.Context, backend backend.Backend, client kclient.Client) (*Helper, error) {
+	gvk, err := backend.GroupVersionKindFor(&v1.MCPToolApprovalPolicy{})
+	if err != nil {
+		return nil, err
+	}
+
+	informer, err := backend.GetInformerForKind(ctx, gvk)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := informer.AddIndexers(gocache.Indexers{
+		resourceIndex: resourceIndexFunc,
+	}); err != nil {
+		return nil, err
+	}
+
+	return &Helper{
+		indexer:      informer.GetIndexer(),
+		client:       client,
+		pollInterval: defaultPollInterval,
+		now:          time.Now,
+	}, nil
+}
+
+// Call is a request the gateway is about to pass on: a tools/call request for
+// the MCP server, or a request the server sent for the client.
+type Call struct {
+	MCPID                string
+	CatalogEntryName     string
+	MCPServerDisplayName string
+	// Method defaults to tools/call.
+	Method    string
+	ToolName  string
+	Arguments json.RawMessage
+	// SessionID and RequestID identify the call in the MCP audit log.
+	SessionID string
+	RequestID string
+}
+
+// Requirement is what a policy asks of a call that needs approval.
+type Requirement struct {
+	PolicyName string
+	Approvers  []types.Subject
+	Timeout    time.Duration
+}
+
+// Hold waits for the call to be approved if a tool approval policy requires
+// it. It returns nil if no policy applies; otherwise it returns the approval
+// once it is no longer pending, and the call may only proceed if it was
+// approved. If ctx is done first, the approval is cancelled and ctx's error is
+// returned.
+func (h *Helper) Hold(ctx context.Context, user kuser.Info, call Call) (*v1.MCPToolCallApproval, error) {
+	policy, err := h.Policy(user, call.MCPID, call.CatalogEntryName, call.ToolName)
+	if err != nil || policy == nil {
+		return nil, err
+	}
+
+	return h.Request(ctx, user, call, Requirement{
+		PolicyName: policy.Name,
+		Approvers:  policy.Spec.Manifest.Approvers,
+		Timeout:    policy.Spec.Manifest.Timeout(),
+	})
+}
+
+// Request creates a pending approval for the call and waits for it like Hold,
+// for callers that found the requirement in a policy of their own.
+func (h *Helper) Request(ctx context.Context, user kuser.Info, call Call, requirement Requirement) (*v1.MCPToolCallApproval, error) {
+	if call.Method == "" {
+		call.Method = "tools/call"
+	}
+
+	now := h.now()
+	approval := &v1.MCPToolCallApproval{
+		GenerateName: system.MCPToolCallApprovalPrefix,
+		Namespace:    system.DefaultNamespace,
+		Spec: v1.MCPToolCallApprovalSpec{
+			PolicyName:           requirement.PolicyName,
+			RequesterID:          principal.ResourceOwnerID(user),
+			MCPID:                call.MCPID,
+			MCPServerDisplayName: call.MCPServerDisplayName,
+			Method:               call.Method,
+			ToolName:             call.ToolName,
+			Arguments:            call.Arguments,
+			SessionID:            call.SessionID,
+			RequestID:            call.RequestID,
+			Approvers:            requirement.Approvers,
+			State:                types.MCPToolCallApprovalStatePending,
+			ExpiresAt:            metav1.NewTime(now.Add(requirement.Timeout)),
+		},
+	}
+	if err := h.client.Create(ctx, approval); err != nil {
+		return nil, fmt.Errorf("failed to create tool call approval: %w", err)
+	}
+
+	slog.Info("holding MCP request for approval", "approval", approval.Name, "policy", requirement.PolicyName,
+		"requester", approval.Spec.RequesterID, "mcpID", call.MCPID, "method", call.Method, "tool", call.ToolName)
+	notification.EmitAsync(h.client, approval.Name, types.NotificationEvent{
+		Type:       types.NotificationEventToolCallApprovalRequested,
+		Summary:    summary(call),
+		UserID:     approval.Spec.RequesterID,
+		ResourceID: approval.Name,
+		Details: map[string]string{
+			"policyID":  requirement.PolicyName,
+			"mcpID":     call.MCPID,
+			"method":    call.Method,
+			"toolName":  call.ToolName,
+			"expiresAt": approval.Spec.ExpiresAt.UTC().Format(time.RFC3339),
+		},
+	})
+
+	return h.wait(ctx, approval)
+}
+
+// wait polls the approval until it is decided or expires.
+func (h *Helper) wait(ctx context.Context, approval *v1.MCPToolCallApproval) (*v1.MCPToolCallApproval, error) {
+	ticker := time.NewTicker(h.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := h.client.Get(ctx, kclient.ObjectKeyFromObject(approval), approval); err != nil && ctx.Err() == nil {
+			return nil, fmt.Errorf("failed to get tool call approval %s: %w", approval.Name, err)
+		}
+		if approval.Spec.State != types.MCPToolCallApprovalStatePending {
+			return approval, nil
+		}
+		if !h.now().Before(approval.Spec.ExpiresAt.Time) {
+			return h.finish(context.Background(), approval, types.MCPToolCallApprovalStateExpired)
+		}
+
+		select {
+		case <-ctx.Done():
+			// The client went away, so nobody is waiting for a decision anymore.
+			if _, err := h.finish(context.Background(), approval, types.MCPToolCallApprovalStateCancelled); err != nil {
+				slog.Error("failed to cancel tool call approval", "approval", approval.Name, "error", err)
+			}
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// finish moves a pending approval to the given state. If someone decided it in
+// the meantime, the decision stands and is returned instead.
+func (h *Helper) finish(ctx context.Context, approval *v1.MCPToolCallApproval, state types.MCPToolCallApprovalState) (*v1.MCPToolCallApproval, error) {
+	for {
+		if approval.Spec.State != types.MCPToolCallApprovalStatePending {
+			return approval, nil
+		}
+
+		approval.Spec.State = state
+		approval.Spec.DecidedAt = metav1.NewTime(h.now())
+		err := h.client.Update(ctx, approval)
+		if err == nil {
+			slog.Info("tool call approval finished", "approval", approval.Name, "state", state)
+			return approval, nil
+		}
+		if !apierrors.IsConflict(err) {
+			return nil, fmt.Errorf("failed to mark tool call approval %s %s: %w", approval.Name, state, err)
+		}
+		if err := h.client.Get(ctx, kclient.ObjectKeyFromObject(approval), approval); err != nil {
+			return nil, fmt.Errorf("failed to get tool call approval %s: %w", approval.Name, err)
+		}
+	}
+}
+
+// Policy returns the policy that requires approval of calls to the tool by the
+// user, or nil if there is none. If several apply, the first by name is used,
+// so that the same call always gets the same approvers and timeout.
+func (h *Helper) Policy(user kuser.Info, serverName, catalogEntryName, tool string) (*v1.MCPToolApprovalPolicy, error) {
+	keys := []string{resourceKey(types.ResourceTypeSelector, "*"), resourceKey(types.ResourceTypeMCPServer, serverName)}
+	if catalogEntryName != "" {
+		keys = append(keys, resourceKey(types.ResourceTypeMCPServerCatalogEntry, catalogEntryName))
+	}
+
+	var match *v1.MCPToolApprovalPolicy
+	for _, key := range keys {
+		objs, err := h.indexer.ByIndex(resourceIndex, key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get MCP tool approval policies for resource %s: %w", key, err)
+		}
+		for _, obj := range objs {
+			policy, ok := obj.(*v1.MCPToolApprovalPolicy)
+			if !ok || match != nil && match.Name <= policy.Name {
+				continue
+			}
+			if slices.Contains(policy.Spec.Manifest.Tools, tool) && matchesSubjects(policy.Spec.Manifest.Subjects, user) {
+				match = policy
+			}
+		}
+	}
+	return match, nil
+}
+
+// matchesSubjects reports whether the subjects name the hosted agent or API key
+// making the call, the user, or the user a hosted agent or API key acts for.
+func matchesSubjects(subjects []types.Subject, user kuser.Info) bool {
+	ownerID := principal.ResourceOwnerID(user)
+	groups := user.GetExtra()["auth_provider_groups"]
+	var apiKeyID string
+	if attribution, ok := principal.APIKeyAttributionFromUser(user); ok {
+		apiKeyID = strconv.FormatUint(uint64(attribution.ID), 10)
+	}
+	return slices.ContainsFunc(subjects, func(subject types.Subject) bool {
+		switch subject.Type {
+		case types.SubjectTypeHostedAgent:
+			return principal.IsHostedAgent(user) && subject.ID == user.GetUID()
+		case types.SubjectTypeAPIKey:
+			return apiKeyID != "" && subject.ID == apiKeyID
+		case types.SubjectTypeUser:
+			return subject.ID == ownerID
+		case types.SubjectTypeGroup:
+			return slices.Contains(groups, subject.ID)
+		case types.SubjectTypeSelector:
+			return subject.ID == "*"
+		}
+		return false
+	})
+}
+
+func summary(call Call) string {
+	switch call.Method {
+	case types.MCPServerRequestMethodSampling:
+		return fmt.Sprintf("MCP server %s is asking to use the LLM and needs approval", serverName(call))
+	case types.MCPServerRequestMethodElicitation:
+		return fmt.Sprintf("MCP server %s is asking the user for input and needs approval", serverName(call))
+	}
+	return fmt.Sprintf("Tool %s on MCP server %s needs approval", call.ToolName, serverName(call))
+}
+
+func serverName(call Call) string {
+	if strings.TrimSpace(call.MCPServerDisplayName) != "" {
+		return call.MCPServerDisplayName
+	}
+	return call.MCPID
+}
+
+func resourceIndexFunc(obj any) ([]string, error) {
+	policy := obj.(*v1.MCPToolApprovalPolicy)
+	if !policy.DeletionTimestamp.IsZero() {
+		return nil, nil
+	}
+
+	keys := make([]string, 0, len(policy.Spec.Manifest.Resources))
+	for _, resource := range policy.Spec.Manifest.Resources {
+		keys = append(keys, resourceKey(resource.Type, resource.ID))
+	}
+	return keys, nil
+}
+
+func resourceKey(resourceType types.ResourceType, id string) string {
+	return string(resourceType) + "/" + id
+}
@@ -0,0 +1,237 @@
+package mcpapproval
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/obot-platform/obot/pkg/principal"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	storagescheme "github.com/obot-platform/obot/pkg/storage/scheme"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	kuser "k8s.io/apiserver/pkg/authentication/user"
+	gocache "k8s.io/client-go/tools/cache"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestPolicyMatchesResourcesToolsAndSubjects(t *testing.T) {
+	helper := newTestHelper(t, nil,
+		newPolicy("b-deploys", types.MCPToolApprovalPolicyManifest{
+			Subjects:  []types.Subject{{Type: types.SubjectTypeSelector, ID: "*"}},
+			Resources: []types.Resource{{Type: types.ResourceTypeMCPServerCatalogEntry, ID: "deployer"}},
+			Tools:     []string{"deploy"},
+		}),
+		newPolicy("a-contractors", types.MCPToolApprovalPolicyManifest{
+			Subjects:  []types.Subject{{Type: types.SubjectTypeGroup, ID: "contractors"}},
+			Resources: []types.Resource{{Type: types.ResourceTypeSelector, ID: "*"}},
+			Tools:     []string{"deploy", "send_email"},
+		}),
+	)
+
+	policy, err := helper.Policy(testUser("1"), "ms1deployer", "deployer", "deploy")
+	require.NoError(t, err)
+	require.NotNil(t, policy)
+	assert.Equal(t, "b-deploys", policy.Name)
+
+	// When several policies apply, the first by name is used.
+	policy, err = helper.Policy(testUser("1", "contractors"), "ms1deployer", "deployer", "deploy")
+	require.NoError(t, err)
+	require.NotNil(t, policy)
+	assert.Equal(t, "a-contractors", policy.Name)
+
+	policy, err = helper.Policy(testUser("1"), "ms1deployer", "deployer", "status")
+	require.NoError(t, err)
+	assert.Nil(t, policy)
+
+	policy, err = helper.Policy(testUser("1"), "ms1mailer", "mailer", "send_email")
+	require.NoError(t, err)
+	assert.Nil(t, policy)
+}
+
+func TestMatchesSubjects(t *testing.T) {
+	apiKeyUser := &kuser.DefaultInfo{UID: "1", Extra: map[string][]string{principal.APIKeyIDExtra: {"7"}}}
+	agent := &kuser.DefaultInfo{UID: "ha1agent", Extra: map[string][]string{principal.HostedAgentOwnerExtra: {"1"}}}
+
+	for _, tt := range []struct {
+		name     string
+		subjects []types.Subject
+		user     kuser.Info
+		matches  bool
+	}{
+		{name: "user", subjects: []types.Subject{{Type: types.SubjectTypeUser, ID: "1"}}, user: testUser("1"), matches: true},
+		{name: "group", subjects: []types.Subject{{Type: types.SubjectTypeGroup, ID: "sre"}}, user: testUser("1", "sre"), matches: true},
+		{name: "agent's owner", subjects: []types.Subject{{Type: types.SubjectTypeUser, ID: "1"}}, user: agent, matches: true},
+		{name: "API key", subjects: []types.Subject{{Type: types.SubjectTypeAPIKey, ID: "7"}}, user: apiKeyUser, matches: true},
+		{name: "other API key", subjects: []types.Subject{{Type: types.SubjectTypeAPIKey, ID: "8"}}, user: apiKeyUser},
+		{name: "API key subject without a key", subjects: []types.Subject{{Type: types.SubjectTypeAPIKey, ID: "7"}}, user: testUser("1")},
+		{name: "hosted agent", subjects: []types.Subject{{Type: types.SubjectTypeHostedAgent, ID: "ha1agent"}}, user: agent, matches: true},
+		{name: "hosted agent subject for a user", subjects: []types.Subject{{Type: types.SubjectTypeHostedAgent, ID: "1"}}, user: testUser("1")},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.matches, matchesSubjects(tt.subjects, tt.user))
+		})
+	}
+}
+
+func TestHoldWithoutPolicy(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(storagescheme.Scheme).Build()
+	helper := newTestHelper(t, c)
+
+	approval, err := helper.Hold(t.Context(), testUser("1"), Call{MCPID: "ms1deployer", ToolName: "deploy"})
+	require.NoError(t, err)
+	assert.Nil(t, approval)
+
+	var approvals v1.MCPToolCallApprovalList
+	require.NoError(t, c.List(t.Context(), &approvals))
+	assert.Empty(t, approvals.Items)
+}
+
+func TestHoldReleasesOnDecision(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(storagescheme.Scheme).Build()
+	helper := newTestHelper(t, c, deployPolicy(60))
+
+	// Approve the approval as soon as the helper creates it.
+	go func() {
+		for {
+			var approvals v1.MCPToolCallApprovalList
+			if err := c.List(context.Background(), &approvals); err == nil && len(approvals.Items) == 1 {
+				approval := approvals.Items[0]
+				approval.Spec.State = types.MCPToolCallApprovalStateApproved
+				approval.Spec.DecidedBy = "2"
+				if c.Update(context.Background(), &approval) == nil {
+					return
+				}
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}()
+
+	approval, err := helper.Hold(t.Context(), testUser("1"), Call{
+		MCPID:     "ms1deployer",
+		ToolName:  "deploy",
+		Arguments: json.RawMessage(`{"env":"production"}`),
+		SessionID: "session-1",
+		RequestID: "7",
+	})
+	require.NoError(t, err)
+	require.NotNil(t, approval)
+	assert.Equal(t, types.MCPToolCallApprovalStateApproved, approval.Spec.State)
+	assert.Equal(t, "2", approval.Spec.DecidedBy)
+	assert.Equal(t, "deploys", approval.Spec.PolicyName)
+	assert.Equal(t, "tools/call", approval.Spec.Method)
+	assert.Equal(t, "1", approval.Spec.RequesterID)
+	assert.Equal(t, "session-1", approval.Spec.SessionID)
+	assert.Equal(t, "7", approval.Spec.RequestID)
+	assert.JSONEq(t, `{"env":"production"}`, string(approval.Spec.Arguments))
+}
+
+func TestHoldExpires(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(storagescheme.Scheme).Build()
+	helper := newTestHelper(t, c, deployPolicy(1))
+	start := time.Now()
+	helper.now = func() time.Time {
+		// Each poll moves the clock past the timeout.
+		start = start.Add(2 * time.Second)
+		return start
+	}
+
+	approval, err := helper.Hold(t.Context(), testUser("1"), Call{MCPID: "ms1deployer", ToolName: "deploy"})
+	require.NoError(t, err)
+	require.NotNil(t, approval)
+	assert.Equal(t, types.MCPToolCallApprovalStateExpired, approval.Spec.State)
+
+	var stored v1.MCPToolCallApproval
+	require.NoError(t, c.Get(t.Context(), kclient.ObjectKeyFromObject(approval), &stored))
+	assert.Equal(t, types.MCPToolCallApprovalStateExpired, stored.Spec.State)
+}
+
+func TestHoldCancelledWhenCallerLeaves(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(storagescheme.Scheme).Build()
+	helper := newTestHelper(t, c, deployPolicy(60))
+
+	ctx, cancel := context.WithTimeout(t.Context(), 20*time.Millisecond)
+	defer cancel()
+	approval, err := helper.Hold(ctx, testUser("1"), Call{MCPID: "ms1deployer", ToolName: "deploy"})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Nil(t, approval)
+
+	var approvals v1.MCPToolCallApprovalList
+	require.NoError(t, c.List(t.Context(), &approvals))
+	require.Len(t, approvals.Items, 1)
+	assert.Equal(t, types.MCPToolCallApprovalStateCancelled, approvals.Items[0].Spec.State)
+}
+
+func TestFinishKeepsDecision(t *testing.T) {
+	approval := &v1.MCPToolCallApproval{
+		Name:      "mtca1abc",
+		Namespace: "default",
+		Spec: v1.MCPToolCallApprovalSpec{
+			State: types.MCPToolCallApprovalStatePending,
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(storagescheme.Scheme).WithObjects(approval).Build()
+	helper := newTestHelper(t, c)
+
+	var stale v1.MCPToolCallApproval
+	require.NoError(t, c.Get(t.Context(), kclient.ObjectKeyFromObject(approval), &stale))
+
+	var decided v1.MCPToolCallApproval
+	require.NoError(t, c.Get(t.Context(), kclient.ObjectKeyFromObject(approval), &decided))
+	decided.Spec.State = types.MCPToolCallApprovalStateRejected
+	require.NoError(t, c.Update(t.Context(), &decided))
+
+	finished, err := helper.finish(t.Context(), &stale, types.MCPToolCallApprovalStateExpired)
+	require.NoError(t, err)
+	assert.Equal(t, types.MCPToolCallApprovalStateRejected, finished.Spec.State)
+}
+
+func newTestHelper(t *testing.T, c kclient.Client, policies ...*v1.MCPToolApprovalPolicy) *Helper {
+	t.Helper()
+
+	indexer := gocache.NewIndexer(gocache.MetaNamespaceKeyFunc, gocache.Indexers{
+		resourceIndex: resourceIndexFunc,
+	})
+	for _, policy := range policies {
+		require.NoError(t, indexer.Add(policy))
+	}
+
+	return &Helper{
+		indexer:      indexer,
+		client:       c,
+		pollInterval: time.Millisecond,
+		now:          time.Now,
+	}
+}
+
+func deployPolicy(timeoutSeconds int) *v1.MCPToolApprovalPolicy {
+	return newPolicy("deploys", types.MCPToolApprovalPolicyManifest{
+		Subjects:       []types.Subject{{Type: types.SubjectTypeSelector, ID: "*"}},
+		Resources:      []types.Resource{{Type: types.ResourceTypeMCPServer, ID: "ms1deployer"}},
+		Tools:          []string{"deploy"},
+		TimeoutSeconds: timeoutSeconds,
+	})
+}
+
+func newPolicy(name string, manifest types.MCPToolApprovalPolicyManifest) *v1.MCPToolApprovalPolicy {
+	manifest.DisplayName = name
+	return &v1.MCPToolApprovalPolicy{
+		Name:      name,
+		Namespace: "default",
+		Spec: v1.MCPToolApprovalPolicySpec{
+			Manifest: manifest,
+		},
+	}
+}
+
+func testUser(userID string, groups ...string) kuser.Info {
+	return &kuser.DefaultInfo{
+		UID: userID,
+		Extra: map[string][]string{
+			"auth_provider_groups": groups,
+		},
+	}
+}
@@ -19,9 +19,11 @@ var (
 )
 
 type ValidationOptions struct {
-	MCP        mcp.ValidationOptions
-	MCPBackend string
-	GitManaged bool
+	MCP mcp.ValidationOptions
+	// SecretProviderConfigured allows secret bindings, which need a secret
+	// provider to resolve them.
+	SecretProviderConfigured bool
+	GitManaged               bool
 }
 
 // SanitizeName converts a catalog entry name to the RFC 1123-compatible form
@@ -97,7 +99,7 @@ func ValidateSourceFields(entry types.MCPServerCatalogEntryManifest) error {
 func ValidateManifest(ctx context.Context, entry types.MCPServerCatalogEntryManifest, options ValidationOptions) error {
 	return errors.Join(
 		mcp.ValidateCatalogEntryManifest(ctx, entry, options.GitManaged, options.MCP),
-		mcp.ValidateSecretBindingsCatalogEntry(entry, options.GitManaged, false, options.MCPBackend),
+		mcp.ValidateSecretBindingsCatalogEntry(entry, options.GitManaged, false, options.SecretProviderConfigured),
 		mcp.ValidateTemplateReferencesCatalogEntry(entry),
 	)
 }
@@ -0,0 +1,149 @@
+package mcphealth
+
+import (
+	"time"
+
+	"github.com/obot-platform/obot/apiclient/types"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// MaxHistory is the number of checks kept on a server's status.
+	MaxHistory = 20
+
+	// degradedErrorRate is the error rate over the history at which a server
+	// that is currently passing checks is reported as degraded.
+	degradedErrorRate = 0.25
+
+	maxErrorLength = 512
+)
+
+// RecordCheck adds check to health and updates the derived fields. A server is
+// unhealthy after threshold consecutive failures, in which case the circuit is
+// held open for openFor so that replicas that do not run the probes fail fast
+// until the next check.
+func RecordCheck(health *v1.MCPServerHealth, check v1.MCPServerHealthCheck, threshold int, openFor time.Duration) {
+	if len(check.Error) > maxErrorLength {
+		check.Error = check.Error[:maxErrorLength]
+	}
+
+	health.History = append(health.History, check)
+	if extra := len(health.History) - MaxHistory; extra > 0 {
+		health.History = append([]v1.MCPServerHealthCheck(nil), health.History[extra:]...)
+	}
+
+	health.LastChecked = check.Time
+	if check.Healthy {
+		health.LastSuccess = check.Time
+		health.ConsecutiveFailures = 0
+	} else {
+		health.LastFailure = check.Time
+		health.LastError = check.Error
+		health.ConsecutiveFailures++
+	}
+
+	switch {
+	case threshold > 0 && health.ConsecutiveFailures >= threshold:
+		health.State = types.MCPServerHealthUnhealthy
+	case health.ConsecutiveFailures > 0 || errorRate(health.History) >= degradedErrorRate:
+		health.State = types.MCPServerHealthDegraded
+	default:
+		health.State = types.MCPServerHealthHealthy
+	}
+
+	health.CircuitOpenUntil = metav1.Time{}
+	if health.State == types.MCPServerHealthUnhealthy {
+		health.CircuitOpenUntil = metav1.NewTime(check.Time.Add(openFor))
+	}
+}
+
+// NeedsUpdate reports whether after, the result of recording a check on a copy
+// of before, should be written. Checks that leave the state, failure count,
+// error and circuit unchanged are only written once refresh has passed since
+// the last written check, so the history of a steady server is sampled rather
+// than complete.
+func NeedsUpdate(before, after *v1.MCPServerHealth, refresh time.Duration) bool {
+	if before == nil {
+		return after != nil
+	}
+	if after == nil {
+		return true
+	}
+
+	return before.State != after.State ||
+		before.ConsecutiveFailures != after.ConsecutiveFailures ||
+		before.LastError != after.LastError ||
+		!before.CircuitOpenUntil.Equal(&after.CircuitOpenUntil) ||
+		after.LastChecked.Sub(before.LastChecked.Time) >= refresh
+}
+
+// ToAPI converts the stored health of a server for API responses. circuitOpen
+// is this replica's view of the circuit, which is combined with the stored one.
+func ToAPI(health *v1.MCPServerHealth, circuitOpen bool, now time.Time) *types.MCPServerHealth {
+	if health == nil {
+		if !circuitOpen {
+			return nil
+		}
+		return &types.MCPServerHealth{State: types.MCPServerHealthUnhealthy, CircuitOpen: true}
+	}
+
+	result := &types.MCPServerHealth{
+		State:                health.State,
+		LastChecked:          optionalTime(health.LastChecked),
+		LastSuccess:          optionalTime(health.LastSuccess),
+		LastFailure:          optionalTime(health.LastFailure),
+		LastError:            health.LastError,
+		ConsecutiveFailures:  health.ConsecutiveFailures,
+		AverageLatencyMillis: averageLatency(health.History),
+		ErrorRate:            errorRate(health.History),
+		CircuitOpen:          circuitOpen || health.CircuitOpenUntil.After(now),
+		History:              make([]types.MCPServerHealthCheck, 0, len(health.History)),
+	}
+	if result.State == "" {
+		result.State = types.MCPServerHealthUnknown
+	}
+	for _, check := range health.History {
+		result.History = append(result.History, types.MCPServerHealthCheck{
+			Time:          types.Time{Time: check.Time.Time},
+			Healthy:       check.Healthy,
+			LatencyMillis: check.LatencyMillis,
+			Error:         check.Error,
+		})
+	}
+	return result
+}
+
+func optionalTime(t metav1.Time) *types.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return types.NewTime(t.Time)
+}
+
+func errorRate(history []v1.MCPServerHealthCheck) float64 {
+	if len(history) == 0 {
+		return 0
+	}
+	var failures int
+	for _, check := range history {
+		if !check.Healthy {
+			failures++
+		}
+	}
+	return float64(failures) / float64(len(history))
+}
+
+func averageLatency(history []v1.MCPServerHealthCheck) int64 {
+	var total, count int64
+	for _, check := range history {
+		if check.Healthy {
+			total += check.LatencyMillis
+			count++
+		}
+	}
+	if count == 0 {
+		return 0
+	}
+	return total / count
+}
@@ -0,0 +1,100 @@
+package mcphealth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/obot-platform/obot/apiclient/types"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRecordCheckStates(t *testing.T) {
+	start := time.Unix(1000, 0)
+	health := &v1.MCPServerHealth{}
+	check := func(i int, healthy bool) {
+		c := v1.MCPServerHealthCheck{Time: metav1.NewTime(start.Add(time.Duration(i) * time.Minute)), Healthy: healthy, LatencyMillis: 100}
+		if !healthy {
+			c.Error = "connection refused"
+			c.LatencyMillis = 0
+		}
+		RecordCheck(health, c, 2, time.Minute)
+	}
+
+	check(0, true)
+	assert.Equal(t, types.MCPServerHealthHealthy, health.State)
+
+	check(1, false)
+	assert.Equal(t, types.MCPServerHealthDegraded, health.State)
+	assert.True(t, health.CircuitOpenUntil.IsZero())
+
+	check(2, false)
+	assert.Equal(t, types.MCPServerHealthUnhealthy, health.State)
+	assert.Equal(t, 2, health.ConsecutiveFailures)
+	assert.Equal(t, "connection refused", health.LastError)
+	assert.Equal(t, start.Add(3*time.Minute), health.CircuitOpenUntil.Time)
+
+	// Passing again, but two of the four recent checks failed.
+	check(3, true)
+	assert.Equal(t, types.MCPServerHealthDegraded, health.State)
+	assert.Zero(t, health.ConsecutiveFailures)
+	assert.True(t, health.CircuitOpenUntil.IsZero())
+
+	for i := 4; i < 4+MaxHistory; i++ {
+		check(i, true)
+	}
+	assert.Len(t, health.History, MaxHistory)
+	assert.Equal(t, types.MCPServerHealthHealthy, health.State)
+}
+
+func TestNeedsUpdate(t *testing.T) {
+	start := time.Unix(1000, 0)
+	record := func(before *v1.MCPServerHealth, i int, healthy bool) *v1.MCPServerHealth {
+		after := before.DeepCopy()
+		c := v1.MCPServerHealthCheck{Time: metav1.NewTime(start.Add(time.Duration(i) * time.Minute)), Healthy: healthy}
+		if !healthy {
+			c.Error = "connection refused"
+		}
+		RecordCheck(after, c, 2, time.Minute)
+		return after
+	}
+
+	healthy := record(&v1.MCPServerHealth{}, 0, true)
+	assert.True(t, NeedsUpdate(nil, healthy, 10*time.Minute))
+	assert.True(t, NeedsUpdate(&v1.MCPServerHealth{}, healthy, 10*time.Minute))
+
+	// Another passing check changes nothing but the history.
+	assert.False(t, NeedsUpdate(healthy, record(healthy, 1, true), 10*time.Minute))
+	assert.True(t, NeedsUpdate(healthy, record(healthy, 10, true), 10*time.Minute))
+
+	failing := record(healthy, 1, false)
+	assert.True(t, NeedsUpdate(healthy, failing, 10*time.Minute))
+	assert.True(t, NeedsUpdate(failing, record(failing, 2, false), 10*time.Minute))
+}
+
+func TestToAPI(t *testing.T) {
+	now := time.Unix(1000, 0)
+	assert.Nil(t, ToAPI(nil, false, now))
+	assert.Equal(t, types.MCPServerHealthUnhealthy, ToAPI(nil, true, now).State)
+
+	health := &v1.MCPServerHealth{
+		State:            types.MCPServerHealthUnhealthy,
+		LastChecked:      metav1.NewTime(now),
+		CircuitOpenUntil: metav1.NewTime(now.Add(time.Minute)),
+		History: []v1.MCPServerHealthCheck{
+			{Time: metav1.NewTime(now.Add(-2 * time.Minute)), Healthy: true, LatencyMillis: 100},
+			{Time: metav1.NewTime(now.Add(-time.Minute)), Healthy: true, LatencyMillis: 300},
+			{Time: metav1.NewTime(now), Error: "timeout"},
+		},
+	}
+
+	result := ToAPI(health, false, now)
+	require.NotNil(t, result)
+	assert.True(t, result.CircuitOpen)
+	assert.Equal(t, int64(200), result.AverageLatencyMillis)
+	assert.InDelta(t, 1.0/3, result.ErrorRate, 0.001)
+	assert.Nil(t, result.LastSuccess)
+	assert.Len(t, result.History, 3)
+}
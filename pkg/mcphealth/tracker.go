@@ -0,0 +1,201 @@
+// Package mcphealth tracks the health of upstream MCP servers and decides when
+// the gateway should stop proxying requests to them.
+package mcphealth
+
+import (
+	"sync"
+	"time"
+
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+)
+
+const (
+	defaultFailureThreshold = 3
+	defaultOpenDuration     = 30 * time.Second
+	defaultMaxOpenDuration  = 5 * time.Minute
+)
+
+type Options struct {
+	// FailureThreshold is the number of consecutive failures that opens the
+	// circuit for a server.
+	FailureThreshold int
+	// OpenDuration is how long the circuit stays open before a single trial
+	// request is let through. It doubles after each failed trial, up to
+	// MaxOpenDuration.
+	OpenDuration    time.Duration
+	MaxOpenDuration time.Duration
+}
+
+func (o Options) complete() Options {
+	if o.FailureThreshold <= 0 {
+		o.FailureThreshold = defaultFailureThreshold
+	}
+	if o.OpenDuration <= 0 {
+		o.OpenDuration = defaultOpenDuration
+	}
+	if o.MaxOpenDuration < o.OpenDuration {
+		o.MaxOpenDuration = max(defaultMaxOpenDuration, o.OpenDuration)
+	}
+	return o
+}
+
+// Tracker is an in-memory circuit breaker per MCP server. It is fed by both
+// the gateway's observations of proxied requests and the periodic probes.
+type Tracker struct {
+	opts Options
+	now  func() time.Time
+
+	lock     sync.Mutex
+	breakers map[string]*breaker
+	probed   map[string]time.Time
+}
+
+type breaker struct {
+	consecutiveFailures int
+	lastObserved        time.Time
+	openUntil           time.Time
+	openDuration        time.Duration
+	trialStarted        time.Time
+}
+
+func NewTracker(opts Options) *Tracker {
+	return &Tracker{
+		opts:     opts.complete(),
+		now:      time.Now,
+		breakers: map[string]*breaker{},
+		probed:   map[string]time.Time{},
+	}
+}
+
+func (t *Tracker) FailureThreshold() int {
+	return t.opts.FailureThreshold
+}
+
+// Allow reports whether a request to the named server should be proxied. When
+// it returns false, the duration is a hint for when to retry.
+//
+// persisted is the health recorded on the server's status, which may be nil.
+// It is only consulted when this tracker has not observed the server more
+// recently, so that replicas that do not run the probes still fail fast.
+func (t *Tracker) Allow(name string, persisted *v1.MCPServerHealth) (bool, time.Duration) {
+	if t == nil {
+		return true, 0
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	now := t.now()
+	b := t.breakers[name]
+	if persisted != nil && (b == nil || b.lastObserved.Before(persisted.LastChecked.Time)) {
+		if until := persisted.CircuitOpenUntil.Time; until.After(now) {
+			return false, until.Sub(now)
+		}
+	}
+	if b == nil || b.openUntil.IsZero() {
+		return true, 0
+	}
+	if now.Before(b.openUntil) {
+		return false, b.openUntil.Sub(now)
+	}
+
+	// The circuit is half-open: let a single trial request through. If the
+	// trial never reports back, allow another once the open period has passed.
+	if b.trialStarted.IsZero() || now.Sub(b.trialStarted) >= b.openDuration {
+		b.trialStarted = now
+		return true, 0
+	}
+	return false, b.trialStarted.Add(b.openDuration).Sub(now)
+}
+
+// RecordSuccess closes the circuit for the named server.
+func (t *Tracker) RecordSuccess(name string) {
+	if t == nil {
+		return
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.breakers[name] = &breaker{lastObserved: t.now()}
+}
+
+// RecordFailure counts a failure for the named server, opening the circuit
+// once the failure threshold is reached.
+func (t *Tracker) RecordFailure(name string) {
+	if t == nil {
+		return
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	now := t.now()
+	b := t.breakers[name]
+	if b == nil {
+		b = &breaker{}
+		t.breakers[name] = b
+	}
+	b.consecutiveFailures++
+	b.lastObserved = now
+
+	switch {
+	case !b.openUntil.IsZero():
+		// A trial request failed while half-open, or a request that started
+		// before the circuit opened failed. Back off further.
+		b.openDuration = min(2*b.openDuration, t.opts.MaxOpenDuration)
+		b.openUntil = now.Add(b.openDuration)
+		b.trialStarted = time.Time{}
+	case b.consecutiveFailures >= t.opts.FailureThreshold:
+		b.openDuration = t.opts.OpenDuration
+		b.openUntil = now.Add(b.openDuration)
+	}
+}
+
+// Open reports whether the circuit for the named server is currently open.
+func (t *Tracker) Open(name string) bool {
+	if t == nil {
+		return false
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	b := t.breakers[name]
+	return b != nil && t.now().Before(b.openUntil)
+}
+
+// Forget drops all state for the named server.
+func (t *Tracker) Forget(name string) {
+	if t == nil {
+		return
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	delete(t.breakers, name)
+	delete(t.probed, name)
+}
+
+// RecordProbe notes when the named server was last probed by this replica.
+// The stored status is not rewritten after every probe, so this is what
+// schedules the next one.
+func (t *Tracker) RecordProbe(name string, at time.Time) {
+	if t == nil {
+		return
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.probed[name] = at
+}
+
+// LastProbed returns when the named server was last probed by this replica, or
+// the zero time if it has not been.
+func (t *Tracker) LastProbed(name string) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.probed[name]
+}
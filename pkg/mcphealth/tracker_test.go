@@ -0,0 +1,99 @@
+package mcphealth
+
+import (
+	"testing"
+	"time"
+
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newTestTracker(now *time.Time) *Tracker {
+	t := NewTracker(Options{FailureThreshold: 2, OpenDuration: 10 * time.Second, MaxOpenDuration: 30 * time.Second})
+	t.now = func() time.Time { return *now }
+	return t
+}
+
+func TestTrackerOpensAfterThresholdAndRecovers(t *testing.T) {
+	now := time.Unix(1000, 0)
+	tracker := newTestTracker(&now)
+
+	tracker.RecordFailure("ms1")
+	allowed, _ := tracker.Allow("ms1", nil)
+	assert.True(t, allowed, "one failure should not open the circuit")
+
+	tracker.RecordFailure("ms1")
+	allowed, retry := tracker.Allow("ms1", nil)
+	assert.False(t, allowed)
+	assert.Equal(t, 10*time.Second, retry)
+	assert.True(t, tracker.Open("ms1"))
+
+	// Other servers are unaffected.
+	allowed, _ = tracker.Allow("ms2", nil)
+	assert.True(t, allowed)
+
+	// Half-open: exactly one trial goes through.
+	now = now.Add(10 * time.Second)
+	allowed, _ = tracker.Allow("ms1", nil)
+	assert.True(t, allowed)
+	allowed, _ = tracker.Allow("ms1", nil)
+	assert.False(t, allowed)
+
+	tracker.RecordSuccess("ms1")
+	allowed, _ = tracker.Allow("ms1", nil)
+	assert.True(t, allowed)
+	assert.False(t, tracker.Open("ms1"))
+}
+
+func TestTrackerBacksOffAfterFailedTrial(t *testing.T) {
+	now := time.Unix(1000, 0)
+	tracker := newTestTracker(&now)
+
+	tracker.RecordFailure("ms1")
+	tracker.RecordFailure("ms1")
+
+	for _, want := range []time.Duration{20 * time.Second, 30 * time.Second, 30 * time.Second} {
+		now = now.Add(time.Minute)
+		allowed, _ := tracker.Allow("ms1", nil)
+		assert.True(t, allowed, "trial request should be allowed")
+
+		tracker.RecordFailure("ms1")
+		allowed, retry := tracker.Allow("ms1", nil)
+		assert.False(t, allowed)
+		assert.Equal(t, want, retry)
+	}
+}
+
+func TestTrackerUsesPersistedHealthUntilObservedLocally(t *testing.T) {
+	now := time.Unix(1000, 0)
+	tracker := newTestTracker(&now)
+	persisted := &v1.MCPServerHealth{
+		LastChecked:      metav1.NewTime(now.Add(-time.Second)),
+		CircuitOpenUntil: metav1.NewTime(now.Add(time.Minute)),
+	}
+
+	allowed, retry := tracker.Allow("ms1", persisted)
+	assert.False(t, allowed)
+	assert.Equal(t, time.Minute, retry)
+
+	// A newer local success wins over the older persisted result.
+	tracker.RecordSuccess("ms1")
+	allowed, _ = tracker.Allow("ms1", persisted)
+	assert.True(t, allowed)
+}
+
+func TestTrackerForget(t *testing.T) {
+	now := time.Unix(1000, 0)
+	tracker := newTestTracker(&now)
+
+	tracker.RecordFailure("ms1")
+	tracker.RecordFailure("ms1")
+	tracker.RecordProbe("ms1", now)
+	assert.True(t, tracker.Open("ms1"))
+	assert.Equal(t, now, tracker.LastProbed("ms1"))
+
+	tracker.Forget("ms1")
+	assert.False(t, tracker.Open("ms1"))
+	assert.True(t, tracker.LastProbed("ms1").IsZero())
+}
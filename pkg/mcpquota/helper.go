@@ -0,0 +1,184 @@
+// Package mcpquota matches MCP tool calls to the MCP quota rules that limit
+// them.
+package mcpquota
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/obot-platform/nah/pkg/backend"
+	"github.com/obot-platform/obot/apiclient/types"
+	gateway "github.com/obot-platform/obot/pkg/gateway/client"
+	"github.com/obot-platform/obot/pkg/principal"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	kuser "k8s.io/apiserver/pkg/authentication/user"
+	gocache "k8s.io/client-go/tools/cache"
+)
+
+const resourceIndex = "resource"
+
+type Helper struct {
+	indexer       gocache.Indexer
+	gatewayClient *gateway.Client
+}
+
+func NewHelper(ctx context.Context, backend backend.Backend, gatewayClient *gateway.Client) (*Helper, error) {
+	gvk, err := backend.GroupVersionKindFor(&v1.MCPQuotaRule{})
+	if err != nil {
+		return nil, err
+	}
+
+	informer, err := backend.GetInformerForKind(ctx, gvk)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := informer.AddIndexers(gocache.Indexers{
+		resourceIndex: resourceIndexFunc,
+	}); err != nil {
+		return nil, err
+	}
+
+	return &Helper{
+		indexer:       informer.GetIndexer(),
+		gatewayClient: gatewayClient,
+	}, nil
+}
+
+// Take counts a call to the tool against the quotas that apply to the user
+// and server. It returns a *gateway.MCPQuotaExceededError, without counting
+// the call, if that would exceed any of them.
+func (h *Helper) Take(ctx context.Context, user kuser.Info, serverName, catalogEntryName, tool string) error {
+	counters, err := h.Counters(user, serverName, catalogEntryName, tool)
+	if err != nil || len(counters) == 0 {
+		return err
+	}
+	return h.gatewayClient.TakeMCPQuota(ctx, counters, time.Now())
+}
+
+// Counters returns the quotas a call to the tool by the user counts against.
+func (h *Helper) Counters(user kuser.Info, serverName, catalogEntryName, tool string) ([]gateway.MCPQuotaCounter, error) {
+	keys := []string{resourceKey(types.ResourceTypeSelector, "*"), resourceKey(types.ResourceTypeMCPServer, serverName)}
+	if catalogEntryName != "" {
+		keys = append(keys, resourceKey(types.ResourceTypeMCPServerCatalogEntry, catalogEntryName))
+	}
+
+	var (
+		seen     = make(map[string]struct{})
+		counters []gateway.MCPQuotaCounter
+	)
+	for _, key := range keys {
+		objs, err := h.indexer.ByIndex(resourceIndex, key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get MCP quota rules for resource %s: %w", key, err)
+		}
+		for _, obj := range objs {
+			rule, ok := obj.(*v1.MCPQuotaRule)
+			if !ok {
+				continue
+			}
+			if _, ok := seen[rule.Name]; ok {
+				continue
+			}
+			seen[rule.Name] = struct{}{}
+			counters = append(counters, ruleCounters(rule, user, serverName, tool)...)
+		}
+	}
+	return counters, nil
+}
+
+func ruleCounters(rule *v1.MCPQuotaRule, user kuser.Info, serverName, tool string) []gateway.MCPQuotaCounter {
+	manifest := rule.Spec.Manifest
+	var toolName string
+	if len(manifest.Tools) > 0 {
+		if !slices.Contains(manifest.Tools, tool) {
+			return nil
+		}
+		toolName = tool
+	}
+
+	caller, ok := matchCaller(manifest.Subjects, user)
+	if !ok {
+		return nil
+	}
+
+	var counters []gateway.MCPQuotaCounter
+	for _, window := range []types.MCPQuotaWindow{types.MCPQuotaWindowMinute, types.MCPQuotaWindowDay} {
+		if limit := manifest.Limit(window); limit > 0 {
+			counters = append(counters, gateway.MCPQuotaCounter{
+				RuleID:   rule.Name,
+				Caller:   caller,
+				UserID:   principal.ResourceOwnerID(user),
+				MCPID:    serverName,
+				ToolName: toolName,
+				Window:   window,
+				Limit:    limit,
+			})
+		}
+	}
+	return counters
+}
+
+// matchCaller returns who a quota is counted for if one of the subjects
+// matches the user. A hosted agent or API key named by a subject gets its own
+// quota; matching by user, group, or selector counts the calls of everything
+// acting for the user together.
+func matchCaller(subjects []types.Subject, user kuser.Info) (string, bool) {
+	var (
+		apiKeyID       string
+		isHostedAgent  = principal.IsHostedAgent(user)
+		ownerID        = principal.ResourceOwnerID(user)
+		groups         = user.GetExtra()["auth_provider_groups"]
+		matchesOwner   bool
+		matchesAPIKey  bool
+		matchesAgentID bool
+	)
+	if attribution, ok := principal.APIKeyAttributionFromUser(user); ok {
+		apiKeyID = strconv.FormatUint(uint64(attribution.ID), 10)
+	}
+
+	for _, subject := range subjects {
+		switch subject.Type {
+		case types.SubjectTypeHostedAgent:
+			matchesAgentID = matchesAgentID || isHostedAgent && subject.ID == user.GetUID()
+		case types.SubjectTypeAPIKey:
+			matchesAPIKey = matchesAPIKey || apiKeyID != "" && subject.ID == apiKeyID
+		case types.SubjectTypeUser:
+			matchesOwner = matchesOwner || subject.ID == ownerID
+		case types.SubjectTypeGroup:
+			matchesOwner = matchesOwner || slices.Contains(groups, subject.ID)
+		case types.SubjectTypeSelector:
+			matchesOwner = matchesOwner || subject.ID == "*"
+		}
+	}
+
+	switch {
+	case matchesAgentID:
+		return "hostedAgent/" + user.GetUID(), true
+	case matchesAPIKey:
+		return "apiKey/" + apiKeyID, true
+	case matchesOwner:
+		return "user/" + ownerID, true
+	}
+	return "", false
+}
+
+func resourceIndexFunc(obj any) ([]string, error) {
+	rule := obj.(*v1.MCPQuotaRule)
+	if !rule.DeletionTimestamp.IsZero() {
+		return nil, nil
+	}
+
+	keys := make([]string, 0, len(rule.Spec.Manifest.Resources))
+	for _, resource := range rule.Spec.Manifest.Resources {
+		keys = append(keys, resourceKey(resource.Type, resource.ID))
+	}
+	return keys, nil
+}
+
+func resourceKey(resourceType types.ResourceType, id string) string {
+	return string(resourceType) + "/" + id
+}
@@ -0,0 +1,133 @@
+package mcpquota
+
+import (
+	"testing"
+	"time"
+
+	"github.com/obot-platform/obot/apiclient/types"
+	gateway "github.com/obot-platform/obot/pkg/gateway/client"
+	"github.com/obot-platform/obot/pkg/principal"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kuser "k8s.io/apiserver/pkg/authentication/user"
+	gocache "k8s.io/client-go/tools/cache"
+)
+
+func TestCountersMatchResourcesAndTools(t *testing.T) {
+	helper := newTestHelper(t,
+		newRule("all-servers", types.MCPQuotaRuleManifest{
+			Subjects:    []types.Subject{{Type: types.SubjectTypeSelector, ID: "*"}},
+			Resources:   []types.Resource{{Type: types.ResourceTypeSelector, ID: "*"}},
+			CallsPerDay: 1000,
+		}),
+		newRule("search-tool", types.MCPQuotaRuleManifest{
+			Subjects:       []types.Subject{{Type: types.SubjectTypeGroup, ID: "eng"}},
+			Resources:      []types.Resource{{Type: types.ResourceTypeMCPServerCatalogEntry, ID: "search"}},
+			Tools:          []string{"search"},
+			CallsPerMinute: 10,
+			CallsPerDay:    100,
+		}),
+		newRule("other-server", types.MCPQuotaRuleManifest{
+			Subjects:       []types.Subject{{Type: types.SubjectTypeSelector, ID: "*"}},
+			Resources:      []types.Resource{{Type: types.ResourceTypeMCPServer, ID: "ms1other"}},
+			CallsPerMinute: 1,
+		}),
+	)
+
+	counters, err := helper.Counters(testUser("1", "eng"), "ms1search", "search", "search")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []gateway.MCPQuotaCounter{
+		{RuleID: "all-servers", Caller: "user/1", UserID: "1", MCPID: "ms1search", Window: types.MCPQuotaWindowDay, Limit: 1000},
+		{RuleID: "search-tool", Caller: "user/1", UserID: "1", MCPID: "ms1search", ToolName: "search", Window: types.MCPQuotaWindowMinute, Limit: 10},
+		{RuleID: "search-tool", Caller: "user/1", UserID: "1", MCPID: "ms1search", ToolName: "search", Window: types.MCPQuotaWindowDay, Limit: 100},
+	}, counters)
+
+	// Other tools and users outside the group only count against the rule for
+	// all servers.
+	counters, err = helper.Counters(testUser("1", "eng"), "ms1search", "search", "fetch")
+	require.NoError(t, err)
+	require.Len(t, counters, 1)
+	assert.Equal(t, "all-servers", counters[0].RuleID)
+
+	counters, err = helper.Counters(testUser("2"), "ms1search", "search", "search")
+	require.NoError(t, err)
+	require.Len(t, counters, 1)
+	assert.Equal(t, "all-servers", counters[0].RuleID)
+}
+
+func TestCountersIgnoreDeletedRules(t *testing.T) {
+	rule := newRule("deleted", types.MCPQuotaRuleManifest{
+		Subjects:       []types.Subject{{Type: types.SubjectTypeSelector, ID: "*"}},
+		Resources:      []types.Resource{{Type: types.ResourceTypeSelector, ID: "*"}},
+		CallsPerMinute: 1,
+	})
+	rule.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+	helper := newTestHelper(t, rule)
+
+	counters, err := helper.Counters(testUser("1"), "ms1search", "search", "search")
+	require.NoError(t, err)
+	assert.Empty(t, counters)
+}
+
+func TestMatchCaller(t *testing.T) {
+	apiKeyUser := &kuser.DefaultInfo{UID: "1", Extra: map[string][]string{principal.APIKeyIDExtra: {"7"}}}
+	agent := &kuser.DefaultInfo{UID: "ha1agent", Extra: map[string][]string{principal.HostedAgentOwnerExtra: {"1"}}}
+
+	for _, tt := range []struct {
+		name     string
+		subjects []types.Subject
+		user     kuser.Info
+		caller   string
+	}{
+		{name: "user", subjects: []types.Subject{{Type: types.SubjectTypeUser, ID: "1"}}, user: testUser("1"), caller: "user/1"},
+		{name: "other user", subjects: []types.Subject{{Type: types.SubjectTypeUser, ID: "2"}}, user: testUser("1")},
+		{name: "group", subjects: []types.Subject{{Type: types.SubjectTypeGroup, ID: "eng"}}, user: testUser("1", "eng"), caller: "user/1"},
+		{name: "API key", subjects: []types.Subject{{Type: types.SubjectTypeAPIKey, ID: "7"}}, user: apiKeyUser, caller: "apiKey/7"},
+		{name: "API key before user", subjects: []types.Subject{{Type: types.SubjectTypeSelector, ID: "*"}, {Type: types.SubjectTypeAPIKey, ID: "7"}}, user: apiKeyUser, caller: "apiKey/7"},
+		{name: "API key subject without a key", subjects: []types.Subject{{Type: types.SubjectTypeAPIKey, ID: "7"}}, user: testUser("1")},
+		{name: "hosted agent", subjects: []types.Subject{{Type: types.SubjectTypeHostedAgent, ID: "ha1agent"}}, user: agent, caller: "hostedAgent/ha1agent"},
+		{name: "hosted agent by its owner", subjects: []types.Subject{{Type: types.SubjectTypeUser, ID: "1"}}, user: agent, caller: "user/1"},
+		{name: "person is not a hosted agent", subjects: []types.Subject{{Type: types.SubjectTypeHostedAgent, ID: "1"}}, user: testUser("1")},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			caller, ok := matchCaller(tt.subjects, tt.user)
+			assert.Equal(t, tt.caller != "", ok)
+			assert.Equal(t, tt.caller, caller)
+		})
+	}
+}
+
+func newTestHelper(t *testing.T, rules ...*v1.MCPQuotaRule) *Helper {
+	t.Helper()
+
+	indexer := gocache.NewIndexer(gocache.MetaNamespaceKeyFunc, gocache.Indexers{
+		resourceIndex: resourceIndexFunc,
+	})
+	for _, rule := range rules {
+		require.NoError(t, indexer.Add(rule))
+	}
+
+	return &Helper{indexer: indexer}
+}
+
+func newRule(name string, manifest types.MCPQuotaRuleManifest) *v1.MCPQuotaRule {
+	manifest.DisplayName = name
+	return &v1.MCPQuotaRule{
+		Name:      name,
+		Namespace: "default",
+		Spec: v1.MCPQuotaRuleSpec{
+			Manifest: manifest,
+		},
+	}
+}
+
+func testUser(userID string, groups ...string) kuser.Info {
+	return &kuser.DefaultInfo{
+		UID: userID,
+		Extra: map[string][]string{
+			"auth_provider_groups": groups,
+		},
+	}
+}
@@ -0,0 +1,392 @@
+// Package mcpserverrequest governs the requests MCP servers send back to
+// clients through the MCP gateway: sampling, which asks the user's LLM to
+// generate a message, and elicitation, which asks the user for input.
+package mcpserverrequest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/obot-platform/nah/pkg/backend"
+	"github.com/obot-platform/obot/apiclient/types"
+	gatewaytypes "github.com/obot-platform/obot/pkg/gateway/types"
+	"github.com/obot-platform/obot/pkg/mcpapproval"
+	"github.com/obot-platform/obot/pkg/messagepolicy"
+	"github.com/obot-platform/obot/pkg/modelaccesspolicy"
+	"github.com/obot-platform/obot/pkg/principal"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	"github.com/obot-platform/obot/pkg/system"
+	kuser "k8s.io/apiserver/pkg/authentication/user"
+	gocache "k8s.io/client-go/tools/cache"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const resourceIndex = "resource"
+
+// Reasons a request is rejected, as reported to the MCP server and recorded in
+// the audit log.
+const (
+	ReasonDenied        = "denied"
+	ReasonModelAccess   = "model-access"
+	ReasonMessagePolicy = "message-policy"
+	ReasonApproval      = "approval"
+)
+
+type approvalRequester interface {
+	Request(ctx context.Context, user kuser.Info, call mcpapproval.Call, requirement mcpapproval.Requirement) (*v1.MCPToolCallApproval, error)
+}
+
+type allowedModelsGetter interface {
+	GetUserAllowedModels(user kuser.Info) (map[string]bool, bool, error)
+}
+
+type messageEvaluator interface {
+	GetApplicablePolicies(user kuser.Info, direction types.PolicyDirection) ([]messagepolicy.ApplicablePolicy, error)
+	EvaluateMessage(ctx context.Context, policies []messagepolicy.ApplicablePolicy, conversationHistory []messagepolicy.ConversationMessage, targetMessage string, direction types.PolicyDirection) []messagepolicy.MessagePolicyViolation
+}
+
+type violationLogger interface {
+	LogMessagePolicyViolation(ctx context.Context, v *gatewaytypes.MessagePolicyViolation) error
+}
+
+type Helper struct {
+	indexer         gocache.Indexer
+	client          kclient.Client
+	approvals       approvalRequester
+	modelAccess     allowedModelsGetter
+	messagePolicies messageEvaluator
+	violations      violationLogger
+}
+
+// NewHelper returns a helper that applies MCP server request policies. Sampling
+// requests are also checked against model access policies and, when
+// messagePolicies is not nil, against the message policies that apply to the
+// user's own messages; violations are logged with violations.
+func NewHelper(ctx context.Context, backend backend.Backend, client kclient.Client, approvals *mcpapproval.Helper, modelAccess *modelaccesspolicy.Helper, messagePolicies *messagepolicy.Helper, violations violationLogger) (*Helper, error) {
+	gvk, err := backend.GroupVersionKindFor(&v1.MCPServerRequestPolicy{})
+	if err != nil {
+		return nil, err
+	}
+
+	informer, err := backend.GetInformerForKind(ctx, gvk)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := informer.AddIndexers(gocache.Indexers{
+		resourceIndex: resourceIndexFunc,
+	}); err != nil {
+		return nil, err
+	}
+
+	h := &Helper{
+		indexer:     informer.GetIndexer(),
+		client:      client,
+		approvals:   approvals,
+		modelAccess: modelAccess,
+		violations:  violations,
+	}
+	if messagePolicies != nil {
+		h.messagePolicies = messagePolicies
+	}
+	return h, nil
+}
+
+// Request is a request an MCP server sent for the client.
+type Request struct {
+	MCPID                string
+	CatalogEntryName     string
+	MCPServerDisplayName string
+	Method               string
+	Params               json.RawMessage
+	// SessionID and RequestID identify the request in the MCP audit log.
+	SessionID string
+	RequestID string
+}
+
+// Result is what Check decided for a request it let through.
+type Result struct {
+	// Params replaces the request's params when it is not nil.
+	Params json.RawMessage
+	// Note explains the decision for the audit log, if there is anything to
+	// explain.
+	Note string
+}
+
+// Rejection is the error Check returns when a request must not reach the
+// client.
+type Rejection struct {
+	Reason     string
+	Message    string
+	ApprovalID string
+	State      types.MCPToolCallApprovalState
+}
+
+func (r *Rejection) Error() string {
+	return r.Message
+}
+
+// Check applies the policies for the request. Requests for methods it does not
+// govern are let through unchanged. A request that must not reach the client
+// is rejected with a *Rejection; any other error means the request could not be
+// checked.
+func (h *Helper) Check(ctx context.Context, user kuser.Info, req Request) (Result, error) {
+	if req.Method != types.MCPServerRequestMethodSampling && req.Method != types.MCPServerRequestMethodElicitation {
+		return Result{}, nil
+	}
+
+	policy, err := h.Policy(user, req.MCPID, req.CatalogEntryName, req.Method)
+	if err != nil {
+		return Result{}, err
+	}
+
+	action := types.MCPServerRequestActionAllow
+	if policy != nil {
+		action = policy.Spec.Manifest.Action(req.Method)
+	}
+	if action == types.MCPServerRequestActionDeny {
+		return Result{}, &Rejection{
+			Reason:  ReasonDenied,
+			Message: fmt.Sprintf("%s requests from this MCP server are denied by policy %s", req.Method, policy.Name),
+		}
+	}
+
+	var (
+		result Result
+		notes  []string
+	)
+	if req.Method == types.MCPServerRequestMethodSampling {
+		params, note, err := h.checkSampling(ctx, user, req.Params)
+		if err != nil {
+			return Result{}, err
+		}
+		if params != nil {
+			result.Params = params
+			req.Params = params
+		}
+		if note != "" {
+			notes = append(notes, note)
+		}
+	}
+
+	if action == types.MCPServerRequestActionRequireApproval {
+		approval, err := h.approvals.Request(ctx, user, mcpapproval.Call{
+			MCPID:                req.MCPID,
+			CatalogEntryName:     req.CatalogEntryName,
+			MCPServerDisplayName: req.MCPServerDisplayName,
+			Method:               req.Method,
+			Arguments:            req.Params,
+			SessionID:            req.SessionID,
+			RequestID:            req.RequestID,
+		}, mcpapproval.Requirement{
+			PolicyName: policy.Name,
+			Approvers:  policy.Spec.Manifest.Approvers,
+			Timeout:    policy.Spec.Manifest.Timeout(),
+		})
+		if err != nil {
+			return Result{}, err
+		}
+		if approval.Spec.State != types.MCPToolCallApprovalStateApproved {
+			message := fmt.Sprintf("approval %s for the %s request was %s", approval.Name, req.Method, approval.Spec.State)
+			if approval.Spec.DecisionComment != "" {
+				message += ": " + approval.Spec.DecisionComment
+			}
+			return Result{}, &Rejection{
+				Reason:     ReasonApproval,
+				Message:    message,
+				ApprovalID: approval.Name,
+				State:      approval.Spec.State,
+			}
+		}
+		notes = append(notes, fmt.Sprintf("approval %s approved by %s", approval.Name, approval.Spec.DecidedBy))
+	} else if policy != nil {
+		notes = append(notes, "allowed by policy "+policy.Name)
+	}
+
+	result.Note = strings.Join(notes, "; ")
+	return result, nil
+}
+
+// checkSampling applies model access and message policies to a sampling
+// request. The client runs the request on the user's LLM, so it is held to the
+// rules the LLM gateway applies to the user's own requests. It returns the
+// rewritten params, or nil if they are unchanged.
+func (h *Helper) checkSampling(ctx context.Context, user kuser.Info, params json.RawMessage) (json.RawMessage, string, error) {
+	var request samplingParams
+	if err := json.Unmarshal(params, &request); err != nil {
+		return nil, "", &Rejection{Reason: ReasonDenied, Message: fmt.Sprintf("invalid sampling request: %v", err)}
+	}
+
+	models, err := h.allowedModels(ctx, user)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(models) == 0 {
+		return nil, "", &Rejection{Reason: ReasonModelAccess, Message: "sampling is denied because the user has no access to any models"}
+	}
+
+	if err := h.checkMessagePolicies(ctx, user, request, params); err != nil {
+		return nil, "", err
+	}
+
+	return limitModelHints(params, models)
+}
+
+func (h *Helper) checkMessagePolicies(ctx context.Context, user kuser.Info, request samplingParams, params json.RawMessage) error {
+	if h.messagePolicies == nil {
+		return nil
+	}
+
+	policies, err := h.messagePolicies.GetApplicablePolicies(user, types.PolicyDirectionUserMessage)
+	if err != nil {
+		return fmt.Errorf("failed to get message policies: %w", err)
+	}
+	if len(policies) == 0 {
+		return nil
+	}
+
+	// The server wrote every message in the request, so all of it is checked
+	// as if the user had sent it.
+	violations := h.messagePolicies.EvaluateMessage(ctx, policies, nil, request.text(), types.PolicyDirectionUserMessage)
+	if len(violations) == 0 {
+		return nil
+	}
+
+	for _, v := range violations {
+		h.logViolation(ctx, user, v, params)
+	}
+	return &Rejection{
+		Reason:  ReasonMessagePolicy,
+		Message: fmt.Sprintf("sampling request violates message policy %s: %s", violations[0].PolicyName, violations[0].Explanation),
+	}
+}
+
+func (h *Helper) logViolation(ctx context.Context, user kuser.Info, v messagepolicy.MessagePolicyViolation, params json.RawMessage) {
+	if h.violations == nil {
+		return
+	}
+	if err := h.violations.LogMessagePolicyViolation(ctx, &gatewaytypes.MessagePolicyViolation{
+		CreatedAt:            time.Now(),
+		UserID:               principal.ResourceOwnerID(user),
+		PolicyID:             v.PolicyID,
+		PolicyName:           v.PolicyName,
+		PolicyDefinition:     v.PolicyDefinition,
+		Direction:            string(types.PolicyDirectionUserMessage),
+		ViolationExplanation: v.Explanation,
+		BlockedContent:       params,
+	}); err != nil {
+		slog.Warn("failed to log policy violation", "policyID", v.PolicyID, "error", err)
+	}
+}
+
+// allowedModels returns the active models the user may use, with the same
+// authority the LLM gateway applies to the user's requests.
+func (h *Helper) allowedModels(ctx context.Context, user kuser.Info) ([]v1.Model, error) {
+	allowed := func(string) bool { return true }
+	if agentModels, isAgent := principal.AuthorizedModelIDs(user); isAgent {
+		allowed = func(modelID string) bool {
+			return slices.Contains(agentModels, "*") || slices.Contains(agentModels, modelID)
+		}
+	} else {
+		allowedModels, allowAll, err := h.modelAccess.GetUserAllowedModels(user)
+		if err != nil {
+			return nil, fmt.Errorf("failed to determine accessible models: %w", err)
+		}
+		if !allowAll {
+			allowed = func(modelID string) bool { return allowedModels[modelID] }
+		}
+	}
+
+	var models v1.ModelList
+	if err := h.client.List(ctx, &models, kclient.InNamespace(system.DefaultNamespace)); err != nil {
+		return nil, fmt.Errorf("failed to list models: %w", err)
+	}
+
+	result := make([]v1.Model, 0, len(models.Items))
+	for _, model := range models.Items {
+		manifest := model.Spec.Manifest
+		if !model.DeletionTimestamp.IsZero() || !manifest.Active || manifest.TargetModel == "" ||
+			!modelaccesspolicy.IsAllowedModelUsage(manifest.Usage) || !allowed(model.Name) {
+			continue
+		}
+		result = append(result, model)
+	}
+	return result, nil
+}
+
+// Policy returns the policy that governs the method for requests from the
+// server to the user, or nil if there is none. If several apply, the first by
+// name is used.
+func (h *Helper) Policy(user kuser.Info, serverName, catalogEntryName, method string) (*v1.MCPServerRequestPolicy, error) {
+	keys := []string{resourceKey(types.ResourceTypeSelector, "*"), resourceKey(types.ResourceTypeMCPServer, serverName)}
+	if catalogEntryName != "" {
+		keys = append(keys, resourceKey(types.ResourceTypeMCPServerCatalogEntry, catalogEntryName))
+	}
+
+	var match *v1.MCPServerRequestPolicy
+	for _, key := range keys {
+		objs, err := h.indexer.ByIndex(resourceIndex, key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get MCP server request policies for resource %s: %w", key, err)
+		}
+		for _, obj := range objs {
+			policy, ok := obj.(*v1.MCPServerRequestPolicy)
+			if !ok || match != nil && match.Name <= policy.Name {
+				continue
+			}
+			if policy.Spec.Manifest.Action(method) != "" && matchesSubjects(policy.Spec.Manifest.Subjects, user) {
+				match = policy
+			}
+		}
+	}
+	return match, nil
+}
+
+// matchesSubjects reports whether the subjects name the user, or the user a
+// hosted agent or API key acts for.
+func matchesSubjects(subjects []types.Subject, user kuser.Info) bool {
+	ownerID := principal.ResourceOwnerID(user)
+	groups := user.GetExtra()["auth_provider_groups"]
+	var apiKeyID string
+	if attribution, ok := principal.APIKeyAttributionFromUser(user); ok {
+		apiKeyID = strconv.FormatUint(uint64(attribution.ID), 10)
+	}
+	return slices.ContainsFunc(subjects, func(subject types.Subject) bool {
+		switch subject.Type {
+		case types.SubjectTypeHostedAgent:
+			return principal.IsHostedAgent(user) && subject.ID == user.GetUID()
+		case types.SubjectTypeAPIKey:
+			return apiKeyID != "" && subject.ID == apiKeyID
+		case types.SubjectTypeUser:
+			return subject.ID == ownerID
+		case types.SubjectTypeGroup:
+			return slices.Contains(groups, subject.ID)
+		case types.SubjectTypeSelector:
+			return subject.ID == "*"
+		}
+		return false
+	})
+}
+
+func resourceIndexFunc(obj any) ([]string, error) {
+	policy := obj.(*v1.MCPServerRequestPolicy)
+	if !policy.DeletionTimestamp.IsZero() {
+		return nil, nil
+	}
+
+	keys := make([]string, 0, len(policy.Spec.Manifest.Resources))
+	for _, resource := range policy.Spec.Manifest.Resources {
+		keys = append(keys, resourceKey(resource.Type, resource.ID))
+	}
+	return keys, nil
+}
+
+func resourceKey(resourceType types.ResourceType, id string) string {
+	return string(resourceType) + "/" + id
+}
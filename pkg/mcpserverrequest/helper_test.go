@@ -0,0 +1,308 @@
+package mcpserverrequest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/obot-platform/obot/apiclient/types"
+	gatewaytypes "github.com/obot-platform/obot/pkg/gateway/types"
+	"github.com/obot-platform/obot/pkg/mcpapproval"
+	"github.com/obot-platform/obot/pkg/messagepolicy"
+	"github.com/obot-platform/obot/pkg/principal"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	storagescheme "github.com/obot-platform/obot/pkg/storage/scheme"
+	"github.com/obot-platform/obot/pkg/system"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	kuser "k8s.io/apiserver/pkg/authentication/user"
+	gocache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const testSamplingParams = `{"messages":[{"role":"user","content":{"type":"text","text":"Summarize the open tickets"}}],` +
+	`"systemPrompt":"You are terse.","modelPreferences":{"hints":[{"name":"claude"},{"name":"internal-finetune"}]},"maxTokens":100}`
+
+func TestPolicyMatchesResourcesMethodsAndSubjects(t *testing.T) {
+	helper := newTestHelper(t,
+		newPolicy("b-community", types.MCPServerRequestPolicyManifest{
+			Subjects:  []types.Subject{{Type: types.SubjectTypeSelector, ID: "*"}},
+			Resources: []types.Resource{{Type: types.ResourceTypeMCPServerCatalogEntry, ID: "community"}},
+			Sampling:  types.MCPServerRequestActionDeny,
+		}),
+		newPolicy("a-contractors", types.MCPServerRequestPolicyManifest{
+			Subjects:    []types.Subject{{Type: types.SubjectTypeGroup, ID: "contractors"}},
+			Resources:   []types.Resource{{Type: types.ResourceTypeSelector, ID: "*"}},
+			Elicitation: types.MCPServerRequestActionRequireApproval,
+		}),
+	)
+
+	policy, err := helper.Policy(testUser("1"), "ms1community", "community", types.MCPServerRequestMethodSampling)
+	require.NoError(t, err)
+	require.NotNil(t, policy)
+	assert.Equal(t, "b-community", policy.Name)
+
+	// A policy that does not govern the method does not apply to it.
+	policy, err = helper.Policy(testUser("1"), "ms1community", "community", types.MCPServerRequestMethodElicitation)
+	require.NoError(t, err)
+	assert.Nil(t, policy)
+
+	policy, err = helper.Policy(testUser("1", "contractors"), "ms1community", "community", types.MCPServerRequestMethodElicitation)
+	require.NoError(t, err)
+	require.NotNil(t, policy)
+	assert.Equal(t, "a-contractors", policy.Name)
+
+	policy, err = helper.Policy(testUser("1"), "ms1other", "other", types.MCPServerRequestMethodSampling)
+	require.NoError(t, err)
+	assert.Nil(t, policy)
+}
+
+func TestMatchesSubjects(t *testing.T) {
+	apiKeyUser := &kuser.DefaultInfo{UID: "1", Extra: map[string][]string{principal.APIKeyIDExtra: {"7"}}}
+	agent := &kuser.DefaultInfo{UID: "ha1agent", Extra: map[string][]string{principal.HostedAgentOwnerExtra: {"1"}}}
+
+	for _, tt := range []struct {
+		name     string
+		subjects []types.Subject
+		user     kuser.Info
+		matches  bool
+	}{
+		{name: "agent's owner", subjects: []types.Subject{{Type: types.SubjectTypeUser, ID: "1"}}, user: agent, matches: true},
+		{name: "API key", subjects: []types.Subject{{Type: types.SubjectTypeAPIKey, ID: "7"}}, user: apiKeyUser, matches: true},
+		{name: "other API key", subjects: []types.Subject{{Type: types.SubjectTypeAPIKey, ID: "8"}}, user: apiKeyUser},
+		{name: "hosted agent", subjects: []types.Subject{{Type: types.SubjectTypeHostedAgent, ID: "ha1agent"}}, user: agent, matches: true},
+		{name: "hosted agent subject for a user", subjects: []types.Subject{{Type: types.SubjectTypeHostedAgent, ID: "1"}}, user: testUser("1")},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.matches, matchesSubjects(tt.subjects, tt.user))
+		})
+	}
+}
+
+func TestCheckAllowsWithoutPolicy(t *testing.T) {
+	helper := newTestHelper(t)
+
+	result, err := helper.Check(t.Context(), testUser("1"), Request{MCPID: "ms1community", Method: types.MCPServerRequestMethodElicitation, Params: json.RawMessage(`{}`)})
+	require.NoError(t, err)
+	assert.Equal(t, Result{}, result)
+
+	result, err = helper.Check(t.Context(), testUser("1"), Request{MCPID: "ms1community", Method: "roots/list"})
+	require.NoError(t, err)
+	assert.Equal(t, Result{}, result)
+}
+
+func TestCheckDenies(t *testing.T) {
+	helper := newTestHelper(t, communityPolicy(types.MCPServerRequestActionDeny))
+
+	_, err := helper.Check(t.Context(), testUser("1"), samplingRequest())
+	rejection, ok := errors.AsType[*Rejection](err)
+	require.True(t, ok, "want a rejection, got %v", err)
+	assert.Equal(t, ReasonDenied, rejection.Reason)
+	assert.Contains(t, rejection.Message, "community")
+}
+
+func TestCheckSamplingRequiresModelAccess(t *testing.T) {
+	helper := newTestHelper(t)
+	helper.modelAccess = allowedModels{}
+
+	_, err := helper.Check(t.Context(), testUser("1"), samplingRequest())
+	rejection, ok := errors.AsType[*Rejection](err)
+	require.True(t, ok, "want a rejection, got %v", err)
+	assert.Equal(t, ReasonModelAccess, rejection.Reason)
+}
+
+func TestCheckSamplingLimitsModelHints(t *testing.T) {
+	helper := newTestHelper(t)
+
+	result, err := helper.Check(t.Context(), testUser("1"), samplingRequest())
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"messages":[{"role":"user","content":{"type":"text","text":"Summarize the open tickets"}}],`+
+		`"systemPrompt":"You are terse.","modelPreferences":{"hints":[{"name":"claude"}]},"maxTokens":100}`, string(result.Params))
+	assert.Equal(t, "removed 1 of 2 model hints naming models the user may not use", result.Note)
+
+	// When no hint names a model the user may use, the hints name the models
+	// the user may use instead.
+	request := samplingRequest()
+	request.Params = json.RawMessage(`{"messages":[],"modelPreferences":{"hints":[{"name":"internal-finetune"}]}}`)
+	result, err = helper.Check(t.Context(), testUser("1"), request)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"messages":[],"modelPreferences":{"hints":[{"name":"claude-sonnet-4-5"}]}}`, string(result.Params))
+}
+
+func TestCheckSamplingUsesAgentModels(t *testing.T) {
+	helper := newTestHelper(t)
+	agent := &kuser.DefaultInfo{UID: "agent-1", Extra: map[string][]string{principal.AuthorizedModelIDsExtra: {"m1internal"}}}
+
+	result, err := helper.Check(t.Context(), agent, samplingRequest())
+	require.NoError(t, err)
+	assert.Contains(t, string(result.Params), `"internal-finetune"`)
+	assert.NotContains(t, string(result.Params), `"claude"`)
+}
+
+func TestCheckSamplingAppliesMessagePolicies(t *testing.T) {
+	helper := newTestHelper(t)
+	evaluator := &messagePolicies{violation: "Asks for customer data"}
+	logger := new(violations)
+	helper.messagePolicies = evaluator
+	helper.violations = logger
+
+	_, err := helper.Check(t.Context(), testUser("1"), samplingRequest())
+	rejection, ok := errors.AsType[*Rejection](err)
+	require.True(t, ok, "want a rejection, got %v", err)
+	assert.Equal(t, ReasonMessagePolicy, rejection.Reason)
+	assert.Contains(t, rejection.Message, "Asks for customer data")
+	assert.Equal(t, "system: You are terse.\nuser: Summarize the open tickets", evaluator.target)
+
+	require.Len(t, logger.logged, 1)
+	assert.Equal(t, "1", logger.logged[0].UserID)
+	assert.Equal(t, string(types.PolicyDirectionUserMessage), logger.logged[0].Direction)
+	assert.JSONEq(t, testSamplingParams, string(logger.logged[0].BlockedContent))
+}
+
+func TestCheckRequiresApproval(t *testing.T) {
+	helper := newTestHelper(t, communityPolicy(types.MCPServerRequestActionRequireApproval))
+	approvals := &approvals{state: types.MCPToolCallApprovalStateApproved}
+	helper.approvals = approvals
+
+	result, err := helper.Check(t.Context(), testUser("1"), samplingRequest())
+	require.NoError(t, err)
+	assert.Equal(t, "removed 1 of 2 model hints naming models the user may not use; approval mtca1abc approved by 2", result.Note)
+	assert.Equal(t, types.MCPServerRequestMethodSampling, approvals.call.Method)
+	assert.Equal(t, "community", approvals.requirement.PolicyName)
+	// Approvers see the request as it will reach the client.
+	assert.JSONEq(t, string(result.Params), string(approvals.call.Arguments))
+
+	approvals.state = types.MCPToolCallApprovalStateRejected
+	_, err = helper.Check(t.Context(), testUser("1"), samplingRequest())
+	rejection, ok := errors.AsType[*Rejection](err)
+	require.True(t, ok, "want a rejection, got %v", err)
+	assert.Equal(t, ReasonApproval, rejection.Reason)
+	assert.Equal(t, "mtca1abc", rejection.ApprovalID)
+	assert.Equal(t, types.MCPToolCallApprovalStateRejected, rejection.State)
+}
+
+func newTestHelper(t *testing.T, policies ...*v1.MCPServerRequestPolicy) *Helper {
+	t.Helper()
+
+	indexer := gocache.NewIndexer(gocache.MetaNamespaceKeyFunc, gocache.Indexers{
+		resourceIndex: resourceIndexFunc,
+	})
+	for _, policy := range policies {
+		require.NoError(t, indexer.Add(policy))
+	}
+
+	c := fake.NewClientBuilder().WithScheme(storagescheme.Scheme).WithObjects(
+		newModel("m1claude", "claude-sonnet-4-5", true),
+		newModel("m1internal", "internal-finetune", true),
+		newModel("m1retired", "claude-2", false),
+	).Build()
+
+	return &Helper{
+		indexer:     indexer,
+		client:      c,
+		approvals:   new(approvals),
+		modelAccess: allowedModels{"m1claude": true, "m1retired": true},
+	}
+}
+
+func samplingRequest() Request {
+	return Request{
+		MCPID:            "ms1community",
+		CatalogEntryName: "community",
+		Method:           types.MCPServerRequestMethodSampling,
+		Params:           json.RawMessage(testSamplingParams),
+		SessionID:        "session-1",
+		RequestID:        "4",
+	}
+}
+
+func communityPolicy(sampling types.MCPServerRequestAction) *v1.MCPServerRequestPolicy {
+	return newPolicy("community", types.MCPServerRequestPolicyManifest{
+		Subjects:  []types.Subject{{Type: types.SubjectTypeSelector, ID: "*"}},
+		Resources: []types.Resource{{Type: types.ResourceTypeMCPServerCatalogEntry, ID: "community"}},
+		Sampling:  sampling,
+		Approvers: []types.Subject{{Type: types.SubjectTypeGroup, ID: "security"}},
+	})
+}
+
+func newPolicy(name string, manifest types.MCPServerRequestPolicyManifest) *v1.MCPServerRequestPolicy {
+	manifest.DisplayName = name
+	return &v1.MCPServerRequestPolicy{
+		Name:      name,
+		Namespace: "default",
+		Spec: v1.MCPServerRequestPolicySpec{
+			Manifest: manifest,
+		},
+	}
+}
+
+func newModel(name, targetModel string, active bool) *v1.Model {
+	return &v1.Model{
+		Name:      name,
+		Namespace: system.DefaultNamespace,
+		Spec: v1.ModelSpec{
+			Manifest: types.ModelManifest{
+				Name:        targetModel,
+				TargetModel: targetModel,
+				Active:      active,
+				Usage:       types.ModelUsageLLM,
+			},
+		},
+	}
+}
+
+func testUser(userID string, groups ...string) kuser.Info {
+	return &kuser.DefaultInfo{
+		UID: userID,
+		Extra: map[string][]string{
+			"auth_provider_groups": groups,
+		},
+	}
+}
+
+type allowedModels map[string]bool
+
+func (a allowedModels) GetUserAllowedModels(kuser.Info) (map[string]bool, bool, error) {
+	return a, false, nil
+}
+
+type approvals struct {
+	state       types.MCPToolCallApprovalState
+	call        mcpapproval.Call
+	requirement mcpapproval.Requirement
+}
+
+func (a *approvals) Request(_ context.Context, _ kuser.Info, call mcpapproval.Call, requirement mcpapproval.Requirement) (*v1.MCPToolCallApproval, error) {
+	a.call, a.requirement = call, requirement
+	return &v1.MCPToolCallApproval{
+		Name: "mtca1abc",
+		Spec: v1.MCPToolCallApprovalSpec{
+			State:     a.state,
+			DecidedBy: "2",
+		},
+	}, nil
+}
+
+type messagePolicies struct {
+	violation string
+	target    string
+}
+
+func (m *messagePolicies) GetApplicablePolicies(kuser.Info, types.PolicyDirection) ([]messagepolicy.ApplicablePolicy, error) {
+	return []messagepolicy.ApplicablePolicy{{ID: "mp1abc", Manifest: types.MessagePolicyManifest{DisplayName: "No customer data"}}}, nil
+}
+
+func (m *messagePolicies) EvaluateMessage(_ context.Context, policies []messagepolicy.ApplicablePolicy, _ []messagepolicy.ConversationMessage, target string, _ types.PolicyDirection) []messagepolicy.MessagePolicyViolation {
+	m.target = target
+	return []messagepolicy.MessagePolicyViolation{{PolicyID: policies[0].ID, PolicyName: policies[0].Manifest.DisplayName, Explanation: m.violation}}
+}
+
+type violations struct {
+	logged []*gatewaytypes.MessagePolicyViolation
+}
+
+func (v *violations) LogMessagePolicyViolation(_ context.Context, violation *gatewaytypes.MessagePolicyViolation) error {
+	v.logged = append(v.logged, violation)
+	return nil
+}
@@ -0,0 +1,124 @@
+package mcpserverrequest
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+)
+
+// samplingParams is the part of sampling/createMessage params the gateway
+// inspects.
+type samplingParams struct {
+	Messages []struct {
+		Role    string          `json:"role"`
+		Content json.RawMessage `json:"content"`
+	} `json:"messages"`
+	SystemPrompt string `json:"systemPrompt,omitempty"`
+}
+
+type samplingContent struct {
+	Type string `json:"type"`
+	Text string `json:"text,omitempty"`
+}
+
+// text returns the system prompt and the text of every message, one per line.
+// Message content is a single content block or a list of them; only text
+// blocks are included.
+func (p samplingParams) text() string {
+	var lines []string
+	if p.SystemPrompt != "" {
+		lines = append(lines, "system: "+p.SystemPrompt)
+	}
+	for _, message := range p.Messages {
+		var blocks []samplingContent
+		if err := json.Unmarshal(message.Content, &blocks); err != nil {
+			var block samplingContent
+			if json.Unmarshal(message.Content, &block) != nil {
+				continue
+			}
+			blocks = []samplingContent{block}
+		}
+		for _, block := range blocks {
+			if block.Type == "text" && block.Text != "" {
+				lines = append(lines, message.Role+": "+block.Text)
+			}
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// limitModelHints drops model hints that name no model the user may use, so
+// that the client does not pick a model for the server that the user could not
+// pick for themselves. Clients treat hint names as substrings of model names.
+// If no hint is left, the hints are replaced with the models the user may use.
+// It returns nil params if the request has no hints or all of them are kept.
+// Hints are advisory: the client runs the model outside the LLM gateway and
+// may still pick one the user has no access to.
+func limitModelHints(params json.RawMessage, models []v1.Model) (json.RawMessage, string, error) {
+	var request map[string]json.RawMessage
+	if err := json.Unmarshal(params, &request); err != nil {
+		return nil, "", fmt.Errorf("failed to decode sampling request: %w", err)
+	}
+	var preferences map[string]json.RawMessage
+	if raw, ok := request["modelPreferences"]; !ok || json.Unmarshal(raw, &preferences) != nil {
+		return nil, "", nil
+	}
+	var hints []json.RawMessage
+	if raw, ok := preferences["hints"]; !ok || json.Unmarshal(raw, &hints) != nil || len(hints) == 0 {
+		return nil, "", nil
+	}
+
+	kept := make([]json.RawMessage, 0, len(hints))
+	for _, hint := range hints {
+		var parsed struct {
+			Name string `json:"name"`
+		}
+		if json.Unmarshal(hint, &parsed) == nil && matchesModel(parsed.Name, models) {
+			kept = append(kept, hint)
+		}
+	}
+	if len(kept) == len(hints) {
+		return nil, "", nil
+	}
+
+	note := fmt.Sprintf("removed %d of %d model hints naming models the user may not use", len(hints)-len(kept), len(hints))
+	if len(kept) == 0 {
+		for _, model := range models {
+			hint, err := json.Marshal(map[string]string{"name": model.Spec.Manifest.TargetModel})
+			if err != nil {
+				return nil, "", err
+			}
+			kept = append(kept, hint)
+		}
+		note = "replaced model hints with the models the user may use"
+	}
+
+	var err error
+	if preferences["hints"], err = json.Marshal(kept); err != nil {
+		return nil, "", err
+	}
+	if request["modelPreferences"], err = json.Marshal(preferences); err != nil {
+		return nil, "", err
+	}
+	result, err := json.Marshal(request)
+	if err != nil {
+		return nil, "", err
+	}
+	return result, note, nil
+}
+
+func matchesModel(hint string, models []v1.Model) bool {
+	hint = strings.ToLower(strings.TrimSpace(hint))
+	if hint == "" {
+		return false
+	}
+	for _, model := range models {
+		if strings.Contains(strings.ToLower(model.Spec.Manifest.TargetModel), hint) ||
+			strings.Contains(strings.ToLower(model.Spec.Manifest.Name), hint) {
+			return true
+		}
+	}
+	return false
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/obot-platform/obot/pkg/alias"
 	"github.com/obot-platform/obot/pkg/gateway/azure"
 	"github.com/obot-platform/obot/pkg/gateway/bedrock"
+	"github.com/obot-platform/obot/pkg/gateway/genericchat"
 	"github.com/obot-platform/obot/pkg/gateway/server/dispatcher"
 	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
 	"github.com/obot-platform/obot/pkg/system"
@@ -250,6 +251,20 @@ func (h *Helper) resolveModelByAlias(ctx context.Context, aliasType types.Defaul
 		}
 		providerURL = u.String()
 		httpClient = &http.Client{Transport: transport}
+	} else if genericchat.IsProvider(modelProvider.Name) {
+		u, err := genericchat.BaseURL(credEnv)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get Generic Chat Completions model provider URL: %w", err)
+		}
+		transport, err := genericchat.Transport(credEnv, http.DefaultTransport)
+		if err != nil {
+			return nil, fmt.Errorf("failed to configure Generic Chat Completions model provider transport: %w", err)
+		}
+		if u.Path == "" {
+			u.Path = "/v1"
+		}
+		providerURL = u.String()
+		httpClient = &http.Client{Transport: transport}
 	} else {
 		u, err := h.dispatcher.URLForModelProvider(ctx, system.DefaultNamespace, model.Spec.Manifest.ModelProvider)
 		if err != nil {
@@ -405,7 +420,11 @@ func (h *Helper) callLLMChatCompletions(ctx context.Context, resolved *resolvedM
 		httpReq.Header.Set(k, v)
 	}
 
-	resp, err := http.DefaultClient.Do(httpReq)
+	client := resolved.httpClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(httpReq)
 	if err != nil {
 		slog.Error("LLM call failed", "model", resolved.targetModel, "error", err)
 		return "", fmt.Errorf("LLM call failed: %w", err)
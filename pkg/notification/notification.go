@@ -0,0 +1,92 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/obot-platform/obot/pkg/hash"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	"github.com/obot-platform/obot/pkg/system"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// CredentialContext is the credential context holding channel secrets,
+	// stored under the channel's name.
+	CredentialContext = "notification-channel"
+	// SecretKey is where a channel's secret is kept in its credential.
+	SecretKey = "secret"
+
+	emitTimeout = 30 * time.Second
+)
+
+// Emit records a pending delivery of the event for every channel of every rule
+// that matches it. The controller sends the deliveries.
+//
+// A non-empty key deduplicates: an event with the same key is delivered at most
+// once per rule and channel for as long as the delivery log keeps the first
+// one. Callers reporting an ongoing condition include the period they want to
+// be reminded at, such as the date, in the key.
+func Emit(ctx context.Context, c kclient.Client, key string, event types.NotificationEvent) error {
+	if event.ID == "" {
+		event.ID = uuid.New().String()
+	}
+	if event.Time.IsZero() {
+		event.Time = *types.NewTime(time.Now())
+	}
+	if key == "" {
+		key = event.ID
+	}
+
+	var rules v1.NotificationRuleList
+	if err := c.List(ctx, &rules, kclient.InNamespace(system.DefaultNamespace)); err != nil {
+		return fmt.Errorf("failed to list notification rules: %w", err)
+	}
+
+	for _, rule := range rules.Items {
+		if !rule.Spec.Manifest.Matches(event) {
+			continue
+		}
+		for _, channelName := range rule.Spec.Manifest.ChannelIDs {
+			delivery := v1.NotificationDelivery{
+				Name:      DeliveryName(rule.Name, channelName, string(event.Type), key),
+				Namespace: system.DefaultNamespace,
+				Spec: v1.NotificationDeliverySpec{
+					ChannelName: channelName,
+					RuleName:    rule.Name,
+					Event:       event,
+				},
+			}
+			if err := c.Create(ctx, &delivery); err != nil && !apierrors.IsAlreadyExists(err) {
+				return fmt.Errorf("failed to record notification delivery for channel %s: %w", channelName, err)
+			}
+		}
+	}
+	return nil
+}
+
+// EmitAsync emits the event in the background, logging failures, so that the
+// callers reporting events never wait on or fail because of notifications.
+func EmitAsync(c kclient.Client, key string, event types.NotificationEvent) {
+	if c == nil {
+		return
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), emitTimeout)
+		defer cancel()
+		if err := Emit(ctx, c, key, event); err != nil {
+			slog.Error("Failed to emit notification", "type", event.Type, "error", err)
+		}
+	}()
+}
+
+// DeliveryName is the name of the delivery of an event with the given key to a
+// channel through a rule.
+func DeliveryName(ruleName, channelName, eventType, key string) string {
+	return system.NotificationDeliveryPrefix + hash.String(ruleName + "\x00" + channelName + "\x00" + eventType + "\x00" + key)[:16]
+}
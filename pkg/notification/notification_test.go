@@ -0,0 +1,71 @@
+package notification
+
+import (
+	"testing"
+
+	"github.com/obot-platform/obot/apiclient/types"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	storagescheme "github.com/obot-platform/obot/pkg/storage/scheme"
+	"github.com/obot-platform/obot/pkg/system"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestEmit(t *testing.T) {
+	storage := fake.NewClientBuilder().WithScheme(storagescheme.Scheme).WithObjects(
+		&v1.NotificationRule{
+			Name: "nr1violations", Namespace: system.DefaultNamespace,
+			Spec: v1.NotificationRuleSpec{Manifest: types.NotificationRuleManifest{
+				DisplayName: "Violations",
+				EventTypes:  []types.NotificationEventType{types.NotificationEventMessagePolicyViolation},
+				ChannelIDs:  []string{"nc1slack", "nc1webhook"},
+			}},
+		},
+		&v1.NotificationRule{
+			Name: "nr1disabled", Namespace: system.DefaultNamespace,
+			Spec: v1.NotificationRuleSpec{Manifest: types.NotificationRuleManifest{
+				DisplayName: "Disabled",
+				Disabled:    true,
+				EventTypes:  []types.NotificationEventType{types.NotificationEventMessagePolicyViolation},
+				ChannelIDs:  []string{"nc1email"},
+			}},
+		},
+		&v1.NotificationRule{
+			Name: "nr1denials", Namespace: system.DefaultNamespace,
+			Spec: v1.NotificationRuleSpec{Manifest: types.NotificationRuleManifest{
+				DisplayName: "Denials",
+				EventTypes:  []types.NotificationEventType{types.NotificationEventToolCallDenied},
+				ChannelIDs:  []string{"nc1email"},
+			}},
+		},
+	).Build()
+
+	event := types.NotificationEvent{
+		Type:       types.NotificationEventMessagePolicyViolation,
+		Summary:    "Message policy PII blocked a user message",
+		UserID:     "u1",
+		ResourceID: "mp1pii",
+	}
+	require.NoError(t, Emit(t.Context(), storage, "", event))
+
+	var deliveries v1.NotificationDeliveryList
+	require.NoError(t, storage.List(t.Context(), &deliveries, kclient.InNamespace(system.DefaultNamespace)))
+	require.Len(t, deliveries.Items, 2)
+	channels := []string{deliveries.Items[0].Spec.ChannelName, deliveries.Items[1].Spec.ChannelName}
+	assert.ElementsMatch(t, []string{"nc1slack", "nc1webhook"}, channels)
+	for _, delivery := range deliveries.Items {
+		assert.Equal(t, "nr1violations", delivery.Spec.RuleName)
+		assert.NotEmpty(t, delivery.Spec.Event.ID)
+		assert.False(t, delivery.Spec.Event.Time.IsZero())
+		assert.Equal(t, event.Summary, delivery.Spec.Event.Summary)
+	}
+
+	// Events without a key are always delivered; events with the same key only once.
+	require.NoError(t, Emit(t.Context(), storage, "", event))
+	require.NoError(t, Emit(t.Context(), storage, "u1/2026-10-19", event))
+	require.NoError(t, Emit(t.Context(), storage, "u1/2026-10-19", event))
+	require.NoError(t, storage.List(t.Context(), &deliveries, kclient.InNamespace(system.DefaultNamespace)))
+	assert.Len(t, deliveries.Items, 6)
+}
@@ -0,0 +1,235 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"mime"
+	"net"
+	"net/http"
+	"net/mail"
+	"net/smtp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/obot-platform/obot/apiclient/types"
+)
+
+const (
+	// SignatureHeader carries the HMAC-SHA256 of a webhook delivery's body,
+	// keyed with the channel's secret.
+	SignatureHeader = "X-Obot-Signature-256"
+	EventTypeHeader = "X-Obot-Event"
+	EventIDHeader   = "X-Obot-Event-ID"
+
+	signaturePrefix = "sha256="
+	sendTimeout     = 30 * time.Second
+)
+
+var httpClient = &http.Client{Timeout: sendTimeout}
+
+// Send delivers the event to a channel with the channel's secret.
+func Send(ctx context.Context, channel types.NotificationChannelManifest, secret string, event types.NotificationEvent) error {
+	ctx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+
+	switch channel.ChannelType {
+	case types.NotificationChannelTypeWebhook:
+		return sendWebhook(ctx, channel.URL, secret, event)
+	case types.NotificationChannelTypeSlack:
+		return sendSlack(ctx, secret, event)
+	case types.NotificationChannelTypeEmail:
+		if channel.Email == nil {
+			return fmt.Errorf("email channel has no email settings")
+		}
+		return sendEmail(ctx, *channel.Email, secret, event)
+	default:
+		return fmt.Errorf("unsupported notification channel type %q", channel.ChannelType)
+	}
+}
+
+// Sign returns the signature header value of a webhook body.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+func sendWebhook(ctx context.Context, url, secret string, event types.NotificationEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+	return post(ctx, url, body, map[string]string{
+		SignatureHeader: Sign(secret, body),
+		EventTypeHeader: string(event.Type),
+		EventIDHeader:   event.ID,
+	})
+}
+
+func sendSlack(ctx context.Context, url string, event types.NotificationEvent) error {
+	body, err := json.Marshal(map[string]string{"text": slackText(event)})
+	if err != nil {
+		return fmt.Errorf("failed to marshal Slack message: %w", err)
+	}
+	return post(ctx, url, body, nil)
+}
+
+func post(ctx context.Context, url string, body []byte, headers map[string]string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+	return nil
+}
+
+// slackText formats the event as Slack mrkdwn.
+func slackText(event types.NotificationEvent) string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "*%s*\n%s", event.Type, slackEscape(event.Summary))
+	for _, line := range detailLines(event) {
+		fmt.Fprintf(&text, "\n• %s", slackEscape(line))
+	}
+	return text.String()
+}
+
+func slackEscape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
+
+// detailLines lists the event's user, resource, and details as "key: value"
+// lines in a stable order.
+func detailLines(event types.NotificationEvent) []string {
+	var lines []string
+	if event.UserID != "" {
+		lines = append(lines, "User: "+event.UserID)
+	}
+	if event.ResourceID != "" {
+		lines = append(lines, "Resource: "+event.ResourceID)
+	}
+	for _, key := range slices.Sorted(maps.Keys(event.Details)) {
+		lines = append(lines, key+": "+event.Details[key])
+	}
+	return lines
+}
+
+func sendEmail(ctx context.Context, config types.NotificationEmailConfig, password string, event types.NotificationEvent) error {
+	from, err := mail.ParseAddress(config.From)
+	if err != nil {
+		return fmt.Errorf("invalid from address: %w", err)
+	}
+	to := make([]string, 0, len(config.To))
+	for _, recipient := range config.To {
+		address, err := mail.ParseAddress(recipient)
+		if err != nil {
+			return fmt.Errorf("invalid recipient %q: %w", recipient, err)
+		}
+		to = append(to, address.Address)
+	}
+
+	addr := net.JoinHostPort(config.Host, strconv.Itoa(config.Port))
+	tlsConfig := &tls.Config{ServerName: config.Host, MinVersion: tls.VersionTLS12}
+
+	var conn net.Conn
+	if config.Port == 465 {
+		conn, err = (&tls.Dialer{Config: tlsConfig}).DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = (&net.Dialer{}).DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to connect to SMTP server: %w", err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, config.Host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to start SMTP session: %w", err)
+	}
+	defer client.Close()
+
+	if config.Port != 465 {
+		if ok, _ := client.Extension("STARTTLS"); ok {
+			if err := client.StartTLS(tlsConfig); err != nil {
+				return fmt.Errorf("failed to start TLS: %w", err)
+			}
+		}
+	}
+	if config.Username != "" {
+		// PlainAuth refuses to send credentials over an unencrypted connection
+		// to anything but localhost.
+		if err := client.Auth(smtp.PlainAuth("", config.Username, password, config.Host)); err != nil {
+			return fmt.Errorf("failed to authenticate: %w", err)
+		}
+	}
+
+	if err := client.Mail(from.Address); err != nil {
+		return fmt.Errorf("failed to set sender: %w", err)
+	}
+	for _, recipient := range to {
+		if err := client.Rcpt(recipient); err != nil {
+			return fmt.Errorf("failed to add recipient %s: %w", recipient, err)
+		}
+	}
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("failed to start message: %w", err)
+	}
+	if _, err := w.Write(emailMessage(from.String(), config.To, event)); err != nil {
+		return fmt.Errorf("failed to write message: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to send message: %w", err)
+	}
+	return client.Quit()
+}
+
+func emailMessage(from string, to []string, event types.NotificationEvent) []byte {
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", from)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", headerValue("[Obot] "+event.Summary))
+	fmt.Fprintf(&msg, "Date: %s\r\n", event.Time.GetTime().Format(time.RFC1123Z))
+	fmt.Fprintf(&msg, "Message-ID: <%s@obot>\r\n", event.ID)
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	msg.WriteString("\r\n")
+	fmt.Fprintf(&msg, "%s\r\n\r\n", event.Summary)
+	fmt.Fprintf(&msg, "Event: %s\r\n", event.Type)
+	for _, line := range detailLines(event) {
+		fmt.Fprintf(&msg, "%s\r\n", line)
+	}
+	return msg.Bytes()
+}
+
+// headerValue keeps event text from adding header lines, and encodes it if it
+// is not ASCII.
+func headerValue(s string) string {
+	return mime.QEncoding.Encode("utf-8", strings.Join(strings.Fields(s), " "))
+}
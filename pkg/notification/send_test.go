@@ -0,0 +1,85 @@
+package notification
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testEvent = types.NotificationEvent{
+	ID:         "ev1",
+	Type:       types.NotificationEventToolCallDenied,
+	Time:       *types.NewTime(time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)),
+	Summary:    "Tool call github_delete_repo was denied",
+	UserID:     "u1",
+	ResourceID: "github",
+	Details:    map[string]string{"reason": "not <allowlisted>", "agent": "claude-code"},
+}
+
+func TestSendWebhook(t *testing.T) {
+	var received types.NotificationEvent
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.Equal(t, Sign("s3cret", body), r.Header.Get(SignatureHeader))
+		assert.Equal(t, string(types.NotificationEventToolCallDenied), r.Header.Get(EventTypeHeader))
+		assert.Equal(t, "ev1", r.Header.Get(EventIDHeader))
+		require.NoError(t, json.Unmarshal(body, &received))
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+	useTestHTTPClient(t, server)
+
+	channel := types.NotificationChannelManifest{ChannelType: types.NotificationChannelTypeWebhook, URL: server.URL + "/hook"}
+	require.NoError(t, Send(t.Context(), channel, "s3cret", testEvent))
+	assert.Equal(t, testEvent.Summary, received.Summary)
+	assert.Equal(t, testEvent.Details, received.Details)
+}
+
+func TestSendSlack(t *testing.T) {
+	var message map[string]string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/services/T/B/X" {
+			http.Error(w, "no_service", http.StatusNotFound)
+			return
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&message))
+	}))
+	defer server.Close()
+	useTestHTTPClient(t, server)
+
+	channel := types.NotificationChannelManifest{ChannelType: types.NotificationChannelTypeSlack}
+	require.NoError(t, Send(t.Context(), channel, server.URL+"/services/T/B/X", testEvent))
+	assert.Equal(t, "*tool-call.denied*\nTool call github_delete_repo was denied\n• User: u1\n• Resource: github\n• agent: claude-code\n• reason: not &lt;allowlisted&gt;", message["text"])
+
+	err := Send(t.Context(), channel, server.URL+"/services/T/B/Y", testEvent)
+	assert.ErrorContains(t, err, "unexpected status 404: no_service")
+}
+
+func TestEmailMessage(t *testing.T) {
+	event := testEvent
+	event.Summary = "Catalog sync failed\r\nBcc: attacker@example.com"
+	msg := string(emailMessage("Obot <obot@example.com>", []string{"admins@example.com"}, event))
+
+	headers, body, ok := strings.Cut(msg, "\r\n\r\n")
+	require.True(t, ok)
+	assert.Contains(t, headers, "Subject: [Obot] Catalog sync failed Bcc: attacker@example.com\r\n")
+	assert.NotContains(t, headers, "\r\nBcc:")
+	assert.Contains(t, headers, "To: admins@example.com\r\n")
+	assert.Contains(t, body, "Event: tool-call.denied\r\n")
+	assert.Contains(t, body, "reason: not <allowlisted>\r\n")
+}
+
+func useTestHTTPClient(t *testing.T, server *httptest.Server) {
+	original := httpClient
+	httpClient = server.Client()
+	t.Cleanup(func() { httpClient = original })
+}
@@ -0,0 +1,131 @@
+package secretprovider
+
+import (
+	"context"
+	"log/slog"
+	"maps"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/obot-platform/obot/apiclient/types"
+)
+
+// Cache wraps a Provider, keeping each secret it reads for a TTL. Run keeps the
+// cached secrets fresh and reports those whose values changed, so that servers
+// using a rotated secret can be restarted with the new value.
+type Cache struct {
+	provider Provider
+	ttl      time.Duration
+	now      func() time.Time
+
+	lock    sync.Mutex
+	entries map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	// data is nil when the secret was unavailable.
+	data    map[string][]byte
+	fetched time.Time
+}
+
+func NewCache(p Provider, ttl time.Duration) *Cache {
+	return &Cache{
+		provider: p,
+		ttl:      ttl,
+		now:      time.Now,
+		entries:  map[string]cacheEntry{},
+	}
+}
+
+func (c *Cache) Get(ctx context.Context, name string) (map[string][]byte, error) {
+	c.lock.Lock()
+	entry, ok := c.entries[name]
+	c.lock.Unlock()
+	if ok && c.now().Before(entry.fetched.Add(c.ttl)) {
+		return entry.data, nil
+	}
+
+	data, err := c.provider.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	c.lock.Lock()
+	c.entries[name] = cacheEntry{data: data, fetched: c.now()}
+	c.lock.Unlock()
+	return data, nil
+}
+
+// List is not cached: it serves the admin UI, which should show what the store
+// holds now.
+func (c *Cache) List(ctx context.Context) ([]types.MCPAllowedSecretBindingTarget, error) {
+	return c.provider.List(ctx)
+}
+
+func (c *Cache) Description() string {
+	return c.provider.Description()
+}
+
+func (c *Cache) Location(name string) string {
+	return c.provider.Location(name)
+}
+
+// Refresh reads every cached secret again and returns, sorted, the names of
+// those whose data changed, including secrets that appeared or disappeared.
+// A secret that fails to read keeps its cached value.
+func (c *Cache) Refresh(ctx context.Context) []string {
+	c.lock.Lock()
+	names := make([]string, 0, len(c.entries))
+	for name := range c.entries {
+		names = append(names, name)
+	}
+	c.lock.Unlock()
+	sort.Strings(names)
+
+	var changed []string
+	for _, name := range names {
+		data, err := c.provider.Get(ctx, name)
+		if err != nil {
+			slog.Warn("failed to refresh bound secret", "secret", c.provider.Location(name), "error", err)
+			continue
+		}
+
+		c.lock.Lock()
+		previous, ok := c.entries[name]
+		c.entries[name] = cacheEntry{data: data, fetched: c.now()}
+		c.lock.Unlock()
+
+		if ok && !secretDataEqual(previous.data, data) {
+			changed = append(changed, name)
+		}
+	}
+	return changed
+}
+
+// Run refreshes the cache every TTL until ctx is done, calling onRotate for
+// each secret whose data changed.
+func (c *Cache) Run(ctx context.Context, onRotate func(ctx context.Context, name string)) {
+	ticker := time.NewTicker(c.ttl)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for _, name := range c.Refresh(ctx) {
+				onRotate(ctx, name)
+			}
+		}
+	}
+}
+
+func secretDataEqual(a, b map[string][]byte) bool {
+	if (a == nil) != (b == nil) {
+		return false
+	}
+	return maps.EqualFunc(a, b, func(x, y []byte) bool {
+		return string(x) == string(y)
+	})
+}
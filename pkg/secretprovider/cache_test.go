@@ -0,0 +1,108 @@
+package secretprovider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type countingProvider struct {
+	secrets map[string]map[string][]byte
+	gets    int
+}
+
+func (p *countingProvider) Get(_ context.Context, name string) (map[string][]byte, error) {
+	p.gets++
+	return p.secrets[name], nil
+}
+
+func (p *countingProvider) List(context.Context) ([]types.MCPAllowedSecretBindingTarget, error) {
+	return bindingTargets(p.secrets), nil
+}
+
+func (*countingProvider) Description() string {
+	return "test secrets"
+}
+
+func (*countingProvider) Location(name string) string {
+	return "test/" + name
+}
+
+func TestCacheGet(t *testing.T) {
+	p := &countingProvider{secrets: map[string]map[string][]byte{"github": {"token": []byte("a")}}}
+	c := NewCache(p, time.Minute)
+	now := time.Now()
+	c.now = func() time.Time { return now }
+
+	data, err := c.Get(t.Context(), "github")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("a"), data["token"])
+
+	p.secrets["github"] = map[string][]byte{"token": []byte("b")}
+	data, err = c.Get(t.Context(), "github")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("a"), data["token"], "cached until the TTL passes")
+	assert.Equal(t, 1, p.gets)
+
+	// Unavailable secrets are cached too.
+	data, err = c.Get(t.Context(), "missing")
+	require.NoError(t, err)
+	assert.Nil(t, data)
+	_, _ = c.Get(t.Context(), "missing")
+	assert.Equal(t, 2, p.gets)
+
+	now = now.Add(time.Minute)
+	data, err = c.Get(t.Context(), "github")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("b"), data["token"])
+	assert.Equal(t, 3, p.gets)
+
+	assert.Equal(t, "test secrets", c.Description())
+	assert.Equal(t, "test/github", c.Location("github"))
+}
+
+func TestCacheRefreshReportsRotatedSecrets(t *testing.T) {
+	p := &countingProvider{secrets: map[string]map[string][]byte{
+		"github": {"token": []byte("a")},
+		"db":     {"password": []byte("x")},
+		"gone":   {"key": []byte("v")},
+	}}
+	c := NewCache(p, time.Minute)
+	for _, name := range []string{"github", "db", "gone", "later"} {
+		_, err := c.Get(t.Context(), name)
+		require.NoError(t, err)
+	}
+
+	assert.Empty(t, c.Refresh(t.Context()))
+
+	p.secrets["github"] = map[string][]byte{"token": []byte("b")}
+	delete(p.secrets, "gone")
+	p.secrets["later"] = map[string][]byte{"key": []byte("v")}
+	assert.Equal(t, []string{"github", "gone", "later"}, c.Refresh(t.Context()))
+
+	data, err := c.Get(t.Context(), "github")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("b"), data["token"], "refresh updates the cached value")
+	assert.Empty(t, c.Refresh(t.Context()))
+}
+
+func TestNew(t *testing.T) {
+	p, err := New(Config{})
+	require.NoError(t, err)
+	assert.Nil(t, p, "kubernetes without a client has no provider")
+
+	p, err = New(Config{Kind: KindFile, Directory: t.TempDir(), CacheTTL: time.Minute})
+	require.NoError(t, err)
+	assert.IsType(t, &Cache{}, p)
+
+	p, err = New(Config{Kind: KindFile, Directory: t.TempDir()})
+	require.NoError(t, err)
+	assert.IsType(t, &File{}, p)
+
+	_, err = New(Config{Kind: "aws"})
+	require.Error(t, err)
+}
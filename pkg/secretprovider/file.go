@@ -0,0 +1,115 @@
+package secretprovider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/obot-platform/obot/apiclient/types"
+)
+
+// File reads secrets from a directory with one subdirectory per secret and one
+// file per key, the layout of a Kubernetes Secret volume mount. Entries whose
+// names start with a dot are ignored, which skips the bookkeeping links that
+// Secret and ConfigMap volumes contain.
+type File struct {
+	dir string
+}
+
+func NewFile(dir string) (*File, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("file secret provider requires a directory")
+	}
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("file secret provider directory: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("file secret provider directory %s is not a directory", dir)
+	}
+	return &File{dir: dir}, nil
+}
+
+func (f *File) Get(_ context.Context, name string) (map[string][]byte, error) {
+	if err := validateSecretName(name); err != nil {
+		return nil, nil
+	}
+
+	secretDir := filepath.Join(f.dir, name)
+	entries, err := os.ReadDir(secretDir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		if info, statErr := os.Stat(secretDir); statErr == nil && !info.IsDir() {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read secret directory %s: %w", secretDir, err)
+	}
+
+	data := make(map[string][]byte, len(entries))
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		keyPath := filepath.Join(secretDir, entry.Name())
+		// Stat follows symlinks, which Secret volume keys are.
+		info, err := os.Stat(keyPath)
+		if err != nil {
+			return nil, fmt.Errorf("read secret file %s: %w", keyPath, err)
+		}
+		if !info.Mode().IsRegular() {
+			continue
+		}
+		value, err := os.ReadFile(keyPath)
+		if err != nil {
+			return nil, fmt.Errorf("read secret file %s: %w", keyPath, err)
+		}
+		data[entry.Name()] = value
+	}
+	return data, nil
+}
+
+func (f *File) List(ctx context.Context) ([]types.MCPAllowedSecretBindingTarget, error) {
+	entries, err := os.ReadDir(f.dir)
+	if err != nil {
+		return nil, fmt.Errorf("list secret directory %s: %w", f.dir, err)
+	}
+
+	secrets := make(map[string]map[string][]byte, len(entries))
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		info, err := os.Stat(filepath.Join(f.dir, entry.Name()))
+		if err != nil || !info.IsDir() {
+			continue
+		}
+		data, err := f.Get(ctx, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		secrets[entry.Name()] = data
+	}
+	return bindingTargets(secrets), nil
+}
+
+func (*File) Description() string {
+	return "secret files"
+}
+
+func (f *File) Location(name string) string {
+	return filepath.Join(f.dir, name)
+}
+
+// validateSecretName rejects names that would escape the directory or prefix
+// that secrets are read from.
+func validateSecretName(name string) error {
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) || strings.HasPrefix(name, ".") {
+		return fmt.Errorf("invalid secret name %q", name)
+	}
+	return nil
+}
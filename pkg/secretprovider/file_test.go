@@ -0,0 +1,59 @@
+package secretprovider
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFile(t *testing.T) {
+	dir := t.TempDir()
+	// Lay out a secret the way a Kubernetes Secret volume does: keys are links
+	// into a hidden, timestamped data directory.
+	github := filepath.Join(dir, "github")
+	require.NoError(t, os.MkdirAll(filepath.Join(github, "..2026_01_01"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(github, "..2026_01_01", "token"), []byte("ghp_abc"), 0o600))
+	require.NoError(t, os.Symlink("..2026_01_01", filepath.Join(github, "..data")))
+	require.NoError(t, os.Symlink(filepath.Join("..data", "token"), filepath.Join(github, "token")))
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "db"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "db", "password"), []byte("hunter2"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "db", "user"), []byte("obot"), 0o600))
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "empty"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "not-a-secret"), []byte("x"), 0o600))
+
+	f, err := NewFile(dir)
+	require.NoError(t, err)
+
+	data, err := f.Get(t.Context(), "github")
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"token": []byte("ghp_abc")}, data)
+
+	for _, name := range []string{"missing", "not-a-secret", "..", "../etc", "db/password", ".hidden"} {
+		data, err = f.Get(t.Context(), name)
+		require.NoError(t, err, name)
+		assert.Nil(t, data, name)
+	}
+
+	targets, err := f.List(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, []types.MCPAllowedSecretBindingTarget{
+		{Name: "db", Keys: []string{"password", "user"}},
+		{Name: "github", Keys: []string{"token"}},
+	}, targets)
+
+	assert.Equal(t, filepath.Join(dir, "db"), f.Location("db"))
+}
+
+func TestNewFileRequiresDirectory(t *testing.T) {
+	_, err := NewFile("")
+	require.Error(t, err)
+
+	_, err = NewFile(filepath.Join(t.TempDir(), "missing"))
+	require.Error(t, err)
+}
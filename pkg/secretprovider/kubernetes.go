@@ -0,0 +1,74 @@
+package secretprovider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/obot-platform/obot/apiclient/types"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Kubernetes reads Secrets from one namespace of the cluster Obot runs in.
+// Only Secrets with the allowed label may be bound; any other Secret is
+// treated as unavailable, the same as a missing one.
+type Kubernetes struct {
+	client       kclient.Client
+	namespace    string
+	allowedLabel string
+}
+
+func NewKubernetes(c kclient.Client, namespace, allowedLabel string) *Kubernetes {
+	return &Kubernetes{
+		client:       c,
+		namespace:    namespace,
+		allowedLabel: allowedLabel,
+	}
+}
+
+// Get reads the Secret through the client, which for the API server is backed
+// by a watch cache, so calling it from request paths is cheap.
+func (k *Kubernetes) Get(ctx context.Context, name string) (map[string][]byte, error) {
+	var s corev1.Secret
+	if err := k.client.Get(ctx, kclient.ObjectKey{Namespace: k.namespace, Name: name}, &s); apierrors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("get secret %s/%s: %w", k.namespace, name, err)
+	}
+	if _, ok := s.Labels[k.allowedLabel]; !ok {
+		return nil, nil
+	}
+	if s.Data == nil {
+		return map[string][]byte{}, nil
+	}
+	return s.Data, nil
+}
+
+func (k *Kubernetes) List(ctx context.Context) ([]types.MCPAllowedSecretBindingTarget, error) {
+	requirement, err := labels.NewRequirement(k.allowedLabel, selection.Exists, nil)
+	if err != nil || requirement == nil {
+		return nil, fmt.Errorf("create allowed secret binding label selector: %w", err)
+	}
+	selector := labels.NewSelector().Add(*requirement)
+	var secrets corev1.SecretList
+	if err := k.client.List(ctx, &secrets, kclient.InNamespace(k.namespace), kclient.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, fmt.Errorf("list allowed secret bindings: %w", err)
+	}
+
+	data := make(map[string]map[string][]byte, len(secrets.Items))
+	for _, secret := range secrets.Items {
+		data[secret.Name] = secret.Data
+	}
+	return bindingTargets(data), nil
+}
+
+func (*Kubernetes) Description() string {
+	return "Kubernetes Secrets"
+}
+
+func (k *Kubernetes) Location(name string) string {
+	return k.namespace + "/" + name
+}
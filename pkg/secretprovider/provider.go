@@ -0,0 +1,115 @@
+// Package secretprovider resolves the secrets that MCP server secret bindings
+// reference. A binding names a secret and one of its keys; a Provider looks
+// the secret up in a Kubernetes namespace, a Vault KV v2 mount, or a directory.
+package secretprovider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/obot-platform/obot/apiclient/types"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	KindKubernetes = "kubernetes"
+	KindVault      = "vault"
+	KindFile       = "file"
+)
+
+// Provider reads secrets that MCP secret bindings may reference.
+type Provider interface {
+	// Get returns the data of the named secret. It returns nil data and no
+	// error when the secret does not exist or may not be bound, so that the
+	// binding is reported as missing rather than failing the caller.
+	Get(ctx context.Context, name string) (map[string][]byte, error)
+	// List returns the secrets that may be bound and their keys, sorted by
+	// name. Values are never returned.
+	List(ctx context.Context) ([]types.MCPAllowedSecretBindingTarget, error)
+	// Description names the kind of secret the provider reads, for example
+	// "Kubernetes Secrets".
+	Description() string
+	// Location describes where the named secret is read from, for diagnostics.
+	Location(name string) string
+}
+
+// Config selects and configures a Provider.
+type Config struct {
+	// Kind is one of KindKubernetes, KindVault or KindFile. Empty means
+	// KindKubernetes.
+	Kind string
+	// CacheTTL is how long values are cached and how often a Cache checks
+	// them for rotation. Zero disables caching.
+	CacheTTL time.Duration
+
+	// KubernetesClient and Namespace locate Secrets for KindKubernetes.
+	// Secrets must carry AllowedLabel to be bound.
+	KubernetesClient kclient.Client
+	Namespace        string
+	AllowedLabel     string
+
+	Vault VaultConfig
+
+	// Directory holds one subdirectory per secret for KindFile.
+	Directory string
+}
+
+// New returns the Provider cfg describes, wrapped in a Cache when cfg.CacheTTL
+// is set. It returns a nil Provider for KindKubernetes without a client, which
+// is the case on the docker MCP runtime backend; secret bindings are then
+// unavailable.
+func New(cfg Config) (Provider, error) {
+	var (
+		p   Provider
+		err error
+	)
+	switch strings.ToLower(cfg.Kind) {
+	case "", KindKubernetes:
+		if cfg.KubernetesClient == nil {
+			return nil, nil
+		}
+		p = NewKubernetes(cfg.KubernetesClient, cfg.Namespace, cfg.AllowedLabel)
+	case KindVault:
+		p, err = NewVault(cfg.Vault)
+	case KindFile:
+		p, err = NewFile(cfg.Directory)
+	default:
+		return nil, fmt.Errorf("unknown secret provider %q: must be %s, %s or %s", cfg.Kind, KindKubernetes, KindVault, KindFile)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.CacheTTL > 0 {
+		p = NewCache(p, cfg.CacheTTL)
+	}
+	return p, nil
+}
+
+// bindingTargets converts secret data to sorted binding targets, dropping
+// secrets without keys.
+func bindingTargets(secrets map[string]map[string][]byte) []types.MCPAllowedSecretBindingTarget {
+	targets := make([]types.MCPAllowedSecretBindingTarget, 0, len(secrets))
+	for name, data := range secrets {
+		keys := make([]string, 0, len(data))
+		for key := range data {
+			keys = append(keys, key)
+		}
+		if len(keys) == 0 {
+			continue
+		}
+		sort.Strings(keys)
+		targets = append(targets, types.MCPAllowedSecretBindingTarget{
+			Name: name,
+			Keys: keys,
+		})
+	}
+
+	sort.Slice(targets, func(i, j int) bool {
+		return targets[i].Name < targets[j].Name
+	})
+	return targets
+}
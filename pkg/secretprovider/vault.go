@@ -0,0 +1,301 @@
+package secretprovider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/obot-platform/obot/apiclient/types"
+)
+
+// VaultConfig configures a Vault KV v2 Provider. Either Token or both
+// AppRoleID and AppRoleSecretID must be set.
+type VaultConfig struct {
+	// Address is the Vault server's URL, such as https://vault.example.com:8200.
+	Address string
+	// Namespace is sent as X-Vault-Namespace, for Vault Enterprise namespaces.
+	Namespace string
+	// Mount is the path the KV v2 secrets engine is mounted at. It defaults to
+	// "secret".
+	Mount string
+	// PathPrefix is prepended to secret names, so that a binding to "github"
+	// with prefix "obot" reads secret/data/obot/github.
+	PathPrefix string
+
+	Token string
+
+	// AppRoleMount is the path the AppRole auth method is mounted at. It
+	// defaults to "approle".
+	AppRoleMount    string
+	AppRoleID       string
+	AppRoleSecretID string
+
+	// HTTPClient is used for requests to Vault. It defaults to a client with a
+	// 30 second timeout.
+	HTTPClient *http.Client
+}
+
+// tokenRenewMargin is how long before an AppRole token's lease ends that a new
+// token is requested.
+const tokenRenewMargin = 30 * time.Second
+
+// Vault reads secrets from a Vault KV v2 secrets engine. Each secret's data
+// holds the keys a binding can reference.
+type Vault struct {
+	config VaultConfig
+	client *http.Client
+	now    func() time.Time
+
+	lock        sync.Mutex
+	token       string
+	tokenExpiry time.Time
+}
+
+func NewVault(cfg VaultConfig) (*Vault, error) {
+	if cfg.Address == "" {
+		return nil, fmt.Errorf("vault secret provider requires an address")
+	}
+	if _, err := url.Parse(cfg.Address); err != nil {
+		return nil, fmt.Errorf("invalid vault address %q: %w", cfg.Address, err)
+	}
+	useAppRole := cfg.AppRoleID != "" || cfg.AppRoleSecretID != ""
+	switch {
+	case cfg.Token != "" && useAppRole:
+		return nil, fmt.Errorf("vault secret provider accepts a token or AppRole credentials, not both")
+	case useAppRole && (cfg.AppRoleID == "" || cfg.AppRoleSecretID == ""):
+		return nil, fmt.Errorf("vault AppRole authentication requires both a role ID and a secret ID")
+	case cfg.Token == "" && !useAppRole:
+		return nil, fmt.Errorf("vault secret provider requires a token or AppRole credentials")
+	}
+
+	cfg.Address = strings.TrimSuffix(cfg.Address, "/")
+	cfg.Mount = strings.Trim(cfg.Mount, "/")
+	if cfg.Mount == "" {
+		cfg.Mount = "secret"
+	}
+	cfg.PathPrefix = strings.Trim(cfg.PathPrefix, "/")
+	cfg.AppRoleMount = strings.Trim(cfg.AppRoleMount, "/")
+	if cfg.AppRoleMount == "" {
+		cfg.AppRoleMount = "approle"
+	}
+
+	client := cfg.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+
+	return &Vault{
+		config: cfg,
+		client: client,
+		now:    time.Now,
+		token:  cfg.Token,
+	}, nil
+}
+
+func (v *Vault) Get(ctx context.Context, name string) (map[string][]byte, error) {
+	if err := validateSecretName(name); err != nil {
+		return nil, nil
+	}
+
+	var resp struct {
+		Data struct {
+			Data map[string]any `json:"data"`
+		} `json:"data"`
+	}
+	found, err := v.do(ctx, http.MethodGet, v.apiPath("data", name), nil, &resp)
+	if err != nil {
+		return nil, fmt.Errorf("read vault secret %s: %w", v.Location(name), err)
+	}
+	// A deleted or destroyed version has no data.
+	if !found || resp.Data.Data == nil {
+		return nil, nil
+	}
+
+	data := make(map[string][]byte, len(resp.Data.Data))
+	for key, value := range resp.Data.Data {
+		switch value := value.(type) {
+		case string:
+			data[key] = []byte(value)
+		case nil:
+		default:
+			// KV v2 values may be any JSON; non-string values are bound as
+			// their JSON encoding.
+			encoded, err := json.Marshal(value)
+			if err != nil {
+				return nil, fmt.Errorf("read vault secret %s: encode key %s: %w", v.Location(name), key, err)
+			}
+			data[key] = encoded
+		}
+	}
+	return data, nil
+}
+
+// List lists the secrets directly under the path prefix; secrets in nested
+// folders cannot be bound.
+func (v *Vault) List(ctx context.Context) ([]types.MCPAllowedSecretBindingTarget, error) {
+	var resp struct {
+		Data struct {
+			Keys []string `json:"keys"`
+		} `json:"data"`
+	}
+	found, err := v.do(ctx, http.MethodGet, v.apiPath("metadata", "")+"?list=true", nil, &resp)
+	if err != nil {
+		return nil, fmt.Errorf("list vault secrets: %w", err)
+	}
+	if !found {
+		return []types.MCPAllowedSecretBindingTarget{}, nil
+	}
+
+	secrets := make(map[string]map[string][]byte, len(resp.Data.Keys))
+	for _, name := range resp.Data.Keys {
+		if strings.HasSuffix(name, "/") {
+			continue
+		}
+		data, err := v.Get(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		secrets[name] = data
+	}
+	return bindingTargets(secrets), nil
+}
+
+func (*Vault) Description() string {
+	return "Vault secrets"
+}
+
+func (v *Vault) Location(name string) string {
+	return path.Join(v.config.Mount, v.config.PathPrefix, name)
+}
+
+func (v *Vault) apiPath(kind, name string) string {
+	return "/v1/" + path.Join(v.config.Mount, kind, v.config.PathPrefix, name)
+}
+
+// do sends a request to Vault and decodes the response into out. It reports
+// false without an error when Vault responds 404. A request rejected with 403
+// while using AppRole is retried once with a new token, in case the token was
+// revoked before its lease ended.
+func (v *Vault) do(ctx context.Context, method, apiPath string, body, out any) (bool, error) {
+	for attempt := 0; ; attempt++ {
+		token, err := v.currentToken(ctx)
+		if err != nil {
+			return false, err
+		}
+
+		status, err := v.send(ctx, method, apiPath, token, body, out)
+		if err != nil {
+			return false, err
+		}
+		switch {
+		case status == http.StatusNotFound:
+			return false, nil
+		case status == http.StatusForbidden && v.config.Token == "" && attempt == 0:
+			v.invalidateToken(token)
+			continue
+		case status >= 300:
+			return false, fmt.Errorf("vault responded %d", status)
+		}
+		return true, nil
+	}
+}
+
+func (v *Vault) send(ctx context.Context, method, apiPath, token string, body, out any) (int, error) {
+	var reader io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return 0, err
+		}
+		reader = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, v.config.Address+apiPath, reader)
+	if err != nil {
+		return 0, err
+	}
+	if token != "" {
+		req.Header.Set("X-Vault-Token", token)
+	}
+	if v.config.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", v.config.Namespace)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return resp.StatusCode, nil
+	}
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return 0, fmt.Errorf("decode vault response: %w", err)
+		}
+	}
+	return resp.StatusCode, nil
+}
+
+// currentToken returns the static token, or an AppRole token, logging in when
+// there is none or its lease is about to end.
+func (v *Vault) currentToken(ctx context.Context) (string, error) {
+	if v.config.Token != "" {
+		return v.config.Token, nil
+	}
+
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	if v.token != "" && (v.tokenExpiry.IsZero() || v.now().Before(v.tokenExpiry.Add(-tokenRenewMargin))) {
+		return v.token, nil
+	}
+
+	var resp struct {
+		Auth struct {
+			ClientToken   string `json:"client_token"`
+			LeaseDuration int    `json:"lease_duration"`
+		} `json:"auth"`
+	}
+	status, err := v.send(ctx, http.MethodPost, "/v1/"+path.Join("auth", v.config.AppRoleMount, "login"), "", map[string]string{
+		"role_id":   v.config.AppRoleID,
+		"secret_id": v.config.AppRoleSecretID,
+	}, &resp)
+	if err != nil {
+		return "", fmt.Errorf("vault AppRole login: %w", err)
+	}
+	if status >= 300 {
+		return "", fmt.Errorf("vault AppRole login: vault responded %d", status)
+	}
+	if resp.Auth.ClientToken == "" {
+		return "", fmt.Errorf("vault AppRole login: response has no client token")
+	}
+
+	v.token = resp.Auth.ClientToken
+	v.tokenExpiry = time.Time{}
+	if resp.Auth.LeaseDuration > 0 {
+		v.tokenExpiry = v.now().Add(time.Duration(resp.Auth.LeaseDuration) * time.Second)
+	}
+	return v.token, nil
+}
+
+func (v *Vault) invalidateToken(token string) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	if v.token == token {
+		v.token = ""
+	}
+}
@@ -0,0 +1,164 @@
+package secretprovider
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeVault struct {
+	t       *testing.T
+	token   string
+	secrets map[string]map[string]any
+	logins  int
+	// revoked tokens are rejected with 403.
+	revoked map[string]bool
+}
+
+func (f *fakeVault) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/v1/auth/approle/login" {
+		var body map[string]string
+		require.NoError(f.t, json.NewDecoder(r.Body).Decode(&body))
+		if body["role_id"] != "role" || body["secret_id"] != "secret" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f.logins++
+		f.token = "approle-token-" + strconv.Itoa(f.logins)
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"auth": map[string]any{"client_token": f.token, "lease_duration": 3600},
+		})
+		return
+	}
+
+	if token := r.Header.Get("X-Vault-Token"); token != f.token || f.revoked[token] {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	switch {
+	case r.URL.Path == "/v1/kv/metadata/obot" && r.URL.Query().Get("list") == "true":
+		keys := []string{"nested/"}
+		for name := range f.secrets {
+			keys = append(keys, name)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"keys": keys}})
+	case len(r.URL.Path) > len("/v1/kv/data/obot/"):
+		data, ok := f.secrets[r.URL.Path[len("/v1/kv/data/obot/"):]]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"data": data}})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestVault(t *testing.T) {
+	fake := &fakeVault{
+		t:     t,
+		token: "static-token",
+		secrets: map[string]map[string]any{
+			"github": {"token": "ghp_abc", "port": 8080},
+			"empty":  nil,
+		},
+	}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	v, err := NewVault(VaultConfig{Address: server.URL + "/", Mount: "kv", PathPrefix: "/obot/", Token: "static-token"})
+	require.NoError(t, err)
+
+	data, err := v.Get(t.Context(), "github")
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"token": []byte("ghp_abc"), "port": []byte("8080")}, data)
+
+	data, err = v.Get(t.Context(), "missing")
+	require.NoError(t, err)
+	assert.Nil(t, data)
+
+	data, err = v.Get(t.Context(), "empty")
+	require.NoError(t, err)
+	assert.Nil(t, data, "a deleted version is unavailable")
+
+	data, err = v.Get(t.Context(), "../escape")
+	require.NoError(t, err)
+	assert.Nil(t, data)
+
+	targets, err := v.List(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, []types.MCPAllowedSecretBindingTarget{{Name: "github", Keys: []string{"port", "token"}}}, targets)
+
+	assert.Equal(t, "Vault secrets", v.Description())
+	assert.Equal(t, "kv/obot/github", v.Location("github"))
+}
+
+func TestVaultRejectsBadToken(t *testing.T) {
+	server := httptest.NewServer(&fakeVault{t: t, token: "right"})
+	defer server.Close()
+
+	v, err := NewVault(VaultConfig{Address: server.URL, Mount: "kv", PathPrefix: "obot", Token: "wrong"})
+	require.NoError(t, err)
+
+	_, err = v.Get(t.Context(), "github")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "vault responded 403")
+}
+
+func TestVaultAppRole(t *testing.T) {
+	fake := &fakeVault{
+		t:       t,
+		secrets: map[string]map[string]any{"github": {"token": "ghp_abc"}},
+		revoked: map[string]bool{},
+	}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	v, err := NewVault(VaultConfig{Address: server.URL, Mount: "kv", PathPrefix: "obot", AppRoleID: "role", AppRoleSecretID: "secret"})
+	require.NoError(t, err)
+	now := time.Now()
+	v.now = func() time.Time { return now }
+
+	data, err := v.Get(t.Context(), "github")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("ghp_abc"), data["token"])
+	assert.Equal(t, 1, fake.logins)
+
+	// The token is reused while its lease lasts.
+	_, err = v.Get(t.Context(), "github")
+	require.NoError(t, err)
+	assert.Equal(t, 1, fake.logins)
+
+	// A new token is requested shortly before the lease ends.
+	now = now.Add(time.Hour - tokenRenewMargin)
+	_, err = v.Get(t.Context(), "github")
+	require.NoError(t, err)
+	assert.Equal(t, 2, fake.logins)
+
+	// A revoked token is replaced and the request retried.
+	fake.revoked[fake.token] = true
+	data, err = v.Get(t.Context(), "github")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("ghp_abc"), data["token"])
+	assert.Equal(t, 3, fake.logins)
+}
+
+func TestNewVaultValidatesAuth(t *testing.T) {
+	for _, cfg := range []VaultConfig{
+		{Token: "t"},
+		{Address: "http://vault"},
+		{Address: "http://vault", Token: "t", AppRoleID: "role", AppRoleSecretID: "secret"},
+		{Address: "http://vault", AppRoleID: "role"},
+	} {
+		_, err := NewVault(cfg)
+		assert.Error(t, err, "%+v", cfg)
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/adhocore/gronx"
 	"github.com/adrg/xdg"
 	"github.com/glebarez/sqlite"
 	"github.com/obot-platform/nah"
@@ -30,6 +31,7 @@ import (
 	"github.com/obot-platform/obot/pkg/api/server"
 	"github.com/obot-platform/obot/pkg/api/server/audit"
 	"github.com/obot-platform/obot/pkg/api/server/ratelimiter"
+	"github.com/obot-platform/obot/pkg/backup"
 	"github.com/obot-platform/obot/pkg/bootstrap"
 	"github.com/obot-platform/obot/pkg/encryption"
 	"github.com/obot-platform/obot/pkg/gateway/client"
@@ -46,6 +48,9 @@ import (
 	"github.com/obot-platform/obot/pkg/localauth"
 	"github.com/obot-platform/obot/pkg/logutil"
 	"github.com/obot-platform/obot/pkg/mcp"
+	"github.com/obot-platform/obot/pkg/mcpapproval"
+	"github.com/obot-platform/obot/pkg/mcpquota"
+	"github.com/obot-platform/obot/pkg/mcpserverrequest"
 	"github.com/obot-platform/obot/pkg/messagepolicy"
 	"github.com/obot-platform/obot/pkg/modelaccesspolicy"
 	"github.com/obot-platform/obot/pkg/otel"
@@ -158,6 +163,12 @@ type Config struct {
 	ArtifactAzureClientID         string `usage:"Azure client ID for artifact storage" name:"artifact-azure-client-id" env:"OBOT_ARTIFACT_AZURE_CLIENT_ID"`
 	ArtifactAzureClientSecret     string `usage:"Azure client secret for artifact storage" name:"artifact-azure-client-secret" env:"OBOT_ARTIFACT_AZURE_CLIENT_SECRET"`
 
+	// Scheduled backups
+	BackupSchedule   string `usage:"Cron schedule for backing up Obot's database to the artifact storage (empty disables scheduled backups)"`
+	BackupScope      string `usage:"What scheduled backups hold: config, or audit for config plus audit logs" default:"config"`
+	BackupPassphrase string `usage:"Passphrase scheduled backups are encrypted with" env:"OBOT_SERVER_BACKUP_PASSPHRASE"`
+	BackupRetention  int    `usage:"Number of scheduled backups to keep (0 keeps all of them)" default:"7"`
+
 	GatewayConfig
 	EncryptionConfig
 	AuditConfig
@@ -188,9 +199,8 @@ type Services struct {
 	OAuthServerConfig handlers.OAuthAuthorizationServerConfig
 
 	// Global token storage client for MCP OAuth
-	MCPOAuthTokenStorage         mcp.GlobalTokenStore
-	MCPSecretBindingAllowedLabel string
-	RegistryNoAuth               bool
+	MCPOAuthTokenStorage mcp.GlobalTokenStore
+	RegistryNoAuth       bool
 
 	PostgresDSN                   string
 	ProviderRegistryPaths         []string
@@ -222,12 +232,20 @@ type Services struct {
 	// Used for indexed lookups of hosted agent access rules.
 	HostedAgentAccessRuleHelper *hostedagentaccessrule.Helper
 
+	// Used to match MCP tool calls to quota rules and count them.
+	MCPQuotaHelper *mcpquota.Helper
+
+	// Used to hold MCP tool calls that need approval.
+	MCPApprovalHelper *mcpapproval.Helper
+
+	// Used to govern sampling and elicitation requests from MCP servers.
+	MCPServerRequestHelper *mcpserverrequest.Helper
+
 	MCPOAuthClientSecretExpiration time.Duration
 	ForceDynamicClient             bool
 
 	// LocalK8sClient is a kclient for the local Kubernetes cluster — the
-	// cluster the obot pod runs in, where source Secrets for
-	// secretBindings live. Nil on the docker backend.
+	// cluster the obot pod runs in. Nil on the docker backend.
 	LocalK8sClient            kclient.Client
 	LocalRouter               *router.Router
 	EveryReplicaRouter        *router.Router
@@ -240,10 +258,6 @@ type Services struct {
 	ServiceAccountName        string
 	StorageListenPort         int
 
-	// ObotNamespace is the Kubernetes namespace in which the obot server
-	// runs; mcp.MergeBoundCreds reads source Secrets from here.
-	ObotNamespace string
-
 	// Parsed settings from Helm for k8s to pass to controller
 	// PodSchedulingSettingsFromHelm contains affinity, tolerations, resources, runtimeClassName
 	// when explicitly set via Helm. If non-nil, SetViaHelm=true and UI cannot modify these.
@@ -282,6 +296,12 @@ type Services struct {
 	ArtifactBlobStore  blob.BlobStore
 	ArtifactBlobBucket string
 
+	// Scheduled backups, written to the artifact blob storage
+	GatewayDB       *db.DB
+	BackupSchedule  string
+	BackupOptions   backup.Options
+	BackupRetention int
+
 	// License provider
 	LicenseProvider *license.Provider
 }
@@ -1022,6 +1042,16 @@ func New(ctx context.Context, config Config) (*Services, error) {
 		return nil, err
 	}
 
+	mcpQuotaHelper, err := mcpquota.NewHelper(ctx, r.Backend(), gatewayClient)
+	if err != nil {
+		return nil, err
+	}
+
+	mcpApprovalHelper, err := mcpapproval.NewHelper(ctx, r.Backend(), storageClient)
+	if err != nil {
+		return nil, err
+	}
+
 	licenseProvider, err := license.NewProvider(ctx, gatewayClient, license.Config(config.LicenseConfig))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create license provider: %w", err)
@@ -1037,6 +1067,11 @@ func New(ctx context.Context, config Config) (*Services, error) {
 		}
 	}
 
+	mcpServerRequestHelper, err := mcpserverrequest.NewHelper(ctx, r.Backend(), storageClient, mcpApprovalHelper, mapHelper, msgPolicyHelper, gatewayClient)
+	if err != nil {
+		return nil, err
+	}
+
 	apply.AddValidOwnerChange("otto-controller", "obot-controller")
 	apply.AddValidOwnerChange("mcpcatalogentries", "catalog-default")
 
@@ -1257,8 +1292,7 @@ func New(ctx context.Context, config Config) (*Services, error) {
 		APIServer: server.NewServer(
 			storageClient,
 			gatewayClient,
-			apiLocalK8sClient,
-			config.ServiceNamespace,
+			mcpSessionManager.SecretProvider(),
 			authn.NewAuthenticator(authenticators),
 			authorizer,
 			proxyManager,
@@ -1269,28 +1303,26 @@ func New(ctx context.Context, config Config) (*Services, error) {
 			registryNoAuth,
 			licenseProvider,
 		),
-		GatewayClient:                gatewayClient,
-		ProxyManager:                 proxyManager,
-		ProviderDispatcher:           providerDispatcher,
-		Otel:                         otel,
-		AuditLogger:                  auditLogger,
-		MCPSessionManager:            mcpSessionManager,
-		TunnelManager:                tunnelManager,
-		OAuthServerConfig:            oauthServerConfig,
-		MCPOAuthTokenStorage:         mcpOAuthTokenStorage,
-		MCPSecretBindingAllowedLabel: secretBindingAllowedLabel,
-		RegistryNoAuth:               registryNoAuth,
-		DSN:                          config.DSN,
-		PostgresDSN:                  postgresDSN,
-		ObotNamespace:                config.ServiceNamespace,
-		DevUIPort:                    devPort,
-		DevMode:                      config.DevMode,
-		UserUIPort:                   config.UserUIPort,
-		ProviderRegistryPaths:        config.ProviderRegistries,
-		GatewayServer:                gatewayServer,
-		AuthEnabled:                  config.EnableAuthentication,
-		Bootstrapper:                 bootstrapper,
-		LocalAuthProvider:            localAuthProvider,
+		GatewayClient:         gatewayClient,
+		ProxyManager:          proxyManager,
+		ProviderDispatcher:    providerDispatcher,
+		Otel:                  otel,
+		AuditLogger:           auditLogger,
+		MCPSessionManager:     mcpSessionManager,
+		TunnelManager:         tunnelManager,
+		OAuthServerConfig:     oauthServerConfig,
+		MCPOAuthTokenStorage:  mcpOAuthTokenStorage,
+		RegistryNoAuth:        registryNoAuth,
+		DSN:                   config.DSN,
+		PostgresDSN:           postgresDSN,
+		DevUIPort:             devPort,
+		DevMode:               config.DevMode,
+		UserUIPort:            config.UserUIPort,
+		ProviderRegistryPaths: config.ProviderRegistries,
+		GatewayServer:         gatewayServer,
+		AuthEnabled:           config.EnableAuthentication,
+		Bootstrapper:          bootstrapper,
+		LocalAuthProvider:     localAuthProvider,
 
 		DefaultMCPCatalogPath:          config.DefaultMCPCatalogPath,
 		MDMAssetSource:                 config.MDMAssetSource,
@@ -1307,6 +1339,9 @@ func New(ctx context.Context, config Config) (*Services, error) {
 
 		SkillAccessRuleHelper:                skillAccessRuleHelper,
 		HostedAgentAccessRuleHelper:          hostedAgentAccessRuleHelper,
+		MCPQuotaHelper:                       mcpQuotaHelper,
+		MCPApprovalHelper:                    mcpApprovalHelper,
+		MCPServerRequestHelper:               mcpServerRequestHelper,
 		LocalK8sClient:                       mcpLocalK8sClient,
 		LocalRouter:                          localRouter,
 		EveryReplicaRouter:                   tunnelPeerRouter,
@@ -1344,6 +1379,10 @@ func New(ctx context.Context, config Config) (*Services, error) {
 		MCPNetworkPolicyProviderChartPath:    config.MCPNetworkPolicyProviderChartPath,
 		MCPNetworkPolicyProviderValues:       config.MCPNetworkPolicyProviderValues,
 		ArtifactBlobBucket:                   config.ArtifactStorageBucket,
+		GatewayDB:                            gatewayDB,
+		BackupSchedule:                       config.BackupSchedule,
+		BackupOptions:                        backup.Options{Passphrase: config.BackupPassphrase, Scope: backup.Scope(config.BackupScope)},
+		BackupRetention:                      config.BackupRetention,
 		LicenseProvider:                      licenseProvider,
 	}
 
@@ -1372,6 +1411,18 @@ func New(ctx context.Context, config Config) (*Services, error) {
 		svcs.ArtifactBlobBucket = "default"
 	}
 
+	if config.BackupSchedule != "" {
+		if !gronx.IsValid(config.BackupSchedule) {
+			return nil, fmt.Errorf("invalid backup schedule %q: must be a cron expression", config.BackupSchedule)
+		}
+		if config.BackupPassphrase == "" {
+			return nil, fmt.Errorf("OBOT_SERVER_BACKUP_PASSPHRASE must be set to schedule backups")
+		}
+		if err := svcs.BackupOptions.Scope.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
 	return svcs, nil
 }
 
@@ -16,6 +16,16 @@ const (
 	SkillMainFile = "SKILL.md"
 )
 
+// Metadata keys that Obot injects into SKILL.md when a skill is downloaded, so
+// that installed copies can be traced back to the indexed skill.
+const (
+	MetadataSkillID      = "obot-skill-id"
+	MetadataRepositoryID = "obot-skill-repository-id"
+	MetadataCommitSHA    = "obot-commit-sha"
+	MetadataInstallHash  = "obot-install-hash"
+	MetadataIndexedAt    = "obot-indexed-at"
+)
+
 var (
 	nameRegexp = regexp.MustCompile(`^[a-z0-9-]+$`)
 )
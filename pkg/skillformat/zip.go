@@ -0,0 +1,170 @@
+package skillformat
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Limits applied to skill ZIP archives.
+const (
+	MaxZIPFiles             = 50
+	MaxZIPUncompressedBytes = 100 * 1024 * 1024
+)
+
+// ValidateZIP checks the ZIP archive for file count limits, total declared uncompressed size,
+// suspicious entry names (path traversal, absolute paths, symlinks), and Windows-style paths.
+// Note: declared sizes from headers are attacker-controlled; callers that decompress content
+// must also enforce limits on actual bytes read.
+func ValidateZIP(r *zip.Reader) error {
+	if len(r.File) > MaxZIPFiles {
+		return fmt.Errorf("ZIP contains too many files (%d, max %d)", len(r.File), MaxZIPFiles)
+	}
+
+	var totalUncompressed uint64
+	for _, f := range r.File {
+		if err := ValidateZIPEntryName(f.Name); err != nil {
+			return err
+		}
+
+		if f.Mode()&os.ModeSymlink != 0 {
+			return fmt.Errorf("ZIP entry %q is a symbolic link", f.Name)
+		}
+		if mode := f.Mode(); !mode.IsDir() && !mode.IsRegular() && mode&os.ModeType != 0 {
+			return fmt.Errorf("ZIP entry %q has unsupported file type", f.Name)
+		}
+
+		if f.UncompressedSize64 > uint64(MaxZIPUncompressedBytes) {
+			return fmt.Errorf("ZIP entry %q uncompressed size exceeds limit (%d bytes)", f.Name, MaxZIPUncompressedBytes)
+		}
+		if totalUncompressed > uint64(MaxZIPUncompressedBytes)-f.UncompressedSize64 {
+			return fmt.Errorf("ZIP total uncompressed size exceeds limit (%d bytes)", MaxZIPUncompressedBytes)
+		}
+		totalUncompressed += f.UncompressedSize64
+	}
+
+	return nil
+}
+
+// ValidateZIPEntryName checks a single ZIP entry name for path traversal, absolute paths,
+// and Windows drive-letter paths.
+func ValidateZIPEntryName(name string) error {
+	// Normalize backslashes to forward slashes before cleaning.
+	normalized := strings.ReplaceAll(filepath.ToSlash(name), "\\", "/")
+	cleaned := path.Clean(strings.TrimPrefix(normalized, "./"))
+
+	// Reject entries that are empty or resolve to the current directory.
+	if cleaned == "." || cleaned == "" {
+		return fmt.Errorf("ZIP entry %q is empty or refers to the current directory", name)
+	}
+	if strings.HasPrefix(cleaned, "/") {
+		return fmt.Errorf("ZIP entry %q is an absolute path", name)
+	}
+	if cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return fmt.Errorf("ZIP entry %q contains path traversal", name)
+	}
+	// Reject Windows drive-letter paths (e.g. "C:\..." or "C:/...")
+	if len(cleaned) >= 2 && cleaned[1] == ':' &&
+		((cleaned[0] >= 'a' && cleaned[0] <= 'z') || (cleaned[0] >= 'A' && cleaned[0] <= 'Z')) {
+		return fmt.Errorf("ZIP entry %q is an absolute path", name)
+	}
+	if volume := filepath.VolumeName(filepath.FromSlash(cleaned)); volume != "" {
+		return fmt.Errorf("ZIP entry %q is an absolute path", name)
+	}
+	return nil
+}
+
+// ZIPDirectory archives the regular files of a skill directory so that the
+// result passes ValidateZIP. Version control directories are skipped and
+// symbolic links are rejected.
+func ZIPDirectory(dirPath string) ([]byte, error) {
+	var (
+		buf   bytes.Buffer
+		files int
+		total int64
+	)
+	w := zip.NewWriter(&buf)
+
+	err := filepath.WalkDir(dirPath, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if p != dirPath && (d.Name() == ".git" || d.Name() == ".svn" || d.Name() == ".hg") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		rel, err := filepath.Rel(dirPath, p)
+		if err != nil {
+			return err
+		}
+		name := filepath.ToSlash(rel)
+		if d.Type()&fs.ModeSymlink != 0 {
+			return fmt.Errorf("%s is a symbolic link", name)
+		}
+		if !d.Type().IsRegular() {
+			return fmt.Errorf("%s has unsupported file type", name)
+		}
+		if err := ValidateZIPEntryName(name); err != nil {
+			return err
+		}
+
+		files++
+		if files > MaxZIPFiles {
+			return fmt.Errorf("skill directory contains too many files (max %d)", MaxZIPFiles)
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		total += info.Size()
+		if total > MaxZIPUncompressedBytes {
+			return fmt.Errorf("skill directory exceeds the size limit (%d bytes)", MaxZIPUncompressedBytes)
+		}
+
+		header, err := zip.FileInfoHeader(info)
+		if err != nil {
+			return err
+		}
+		header.Name = name
+		header.Method = zip.Deflate
+		fw, err := w.CreateHeader(header)
+		if err != nil {
+			return fmt.Errorf("failed to create ZIP entry %s: %w", name, err)
+		}
+		f, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		if _, err := io.Copy(fw, f); err != nil {
+			return fmt.Errorf("failed to write ZIP entry %s: %w", name, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close ZIP writer: %w", err)
+	}
+
+	data := buf.Bytes()
+	r, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, err
+	}
+	if err := ValidateZIP(r); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
@@ -0,0 +1,180 @@
+package skillformat
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func createTestZIP(t *testing.T, files map[string][]byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for name, content := range files {
+		fw, err := w.Create(name)
+		if err != nil {
+			t.Fatalf("failed to create ZIP entry %s: %v", name, err)
+		}
+		if _, err := fw.Write(content); err != nil {
+			t.Fatalf("failed to write ZIP entry %s: %v", name, err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("failed to close ZIP: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestValidateZIP_PathTraversal(t *testing.T) {
+	zipData := createTestZIP(t, map[string][]byte{
+		"../etc/passwd": []byte("bad"),
+	})
+	r, err := zip.NewReader(bytes.NewReader(zipData), int64(len(zipData)))
+	if err != nil {
+		t.Fatalf("failed to create zip reader: %v", err)
+	}
+	if err := ValidateZIP(r); err == nil {
+		t.Fatal("expected error for path traversal entry")
+	} else if !strings.Contains(err.Error(), "path traversal") {
+		t.Errorf("error = %q, want containing 'path traversal'", err.Error())
+	}
+}
+
+func TestValidateZIP_AbsolutePath(t *testing.T) {
+	zipData := createTestZIP(t, map[string][]byte{
+		"/etc/passwd": []byte("bad"),
+	})
+	r, err := zip.NewReader(bytes.NewReader(zipData), int64(len(zipData)))
+	if err != nil {
+		t.Fatalf("failed to create zip reader: %v", err)
+	}
+	if err := ValidateZIP(r); err == nil {
+		t.Fatal("expected error for absolute path entry")
+	} else if !strings.Contains(err.Error(), "absolute path") {
+		t.Errorf("error = %q, want containing 'absolute path'", err.Error())
+	}
+}
+
+func TestValidateZIP_TooManyFiles(t *testing.T) {
+	files := make(map[string][]byte)
+	for i := range MaxZIPFiles + 1 {
+		files[fmt.Sprintf("file%d.txt", i)] = []byte("x")
+	}
+	zipData := createTestZIP(t, files)
+	r, err := zip.NewReader(bytes.NewReader(zipData), int64(len(zipData)))
+	if err != nil {
+		t.Fatalf("failed to create zip reader: %v", err)
+	}
+	if err := ValidateZIP(r); err == nil {
+		t.Fatal("expected error for too many files")
+	} else if !strings.Contains(err.Error(), "too many files") {
+		t.Errorf("error = %q, want containing 'too many files'", err.Error())
+	}
+}
+
+func TestValidateZIP_Valid(t *testing.T) {
+	zipData := createTestZIP(t, map[string][]byte{
+		SkillMainFile:    []byte("---\nname: test\ndescription: A test.\n---\n"),
+		"scripts/run.sh": []byte("#!/bin/bash"),
+	})
+	r, err := zip.NewReader(bytes.NewReader(zipData), int64(len(zipData)))
+	if err != nil {
+		t.Fatalf("failed to create zip reader: %v", err)
+	}
+	if err := ValidateZIP(r); err != nil {
+		t.Fatalf("ValidateZIP() unexpected error: %v", err)
+	}
+}
+
+func TestValidateZIPEntryName(t *testing.T) {
+	tests := []struct {
+		name    string
+		entry   string
+		wantErr string
+	}{
+		{name: "valid simple", entry: "SKILL.md"},
+		{name: "valid nested", entry: "scripts/run.sh"},
+		{name: "absolute unix", entry: "/etc/passwd", wantErr: "absolute path"},
+		{name: "traversal unix", entry: "../etc/passwd", wantErr: "path traversal"},
+		{name: "traversal nested", entry: "foo/../../etc/passwd", wantErr: "path traversal"},
+		{name: "windows backslash traversal", entry: `..\..\evil.sh`, wantErr: "path traversal"},
+		{name: "windows drive letter", entry: `C:\tmp\evil.sh`, wantErr: "absolute path"},
+		{name: "windows drive slash", entry: "C:/tmp/evil.sh", wantErr: "absolute path"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateZIPEntryName(tt.entry)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %q, want containing %q", err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestZIPDirectory(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "my-skill")
+	for name, content := range map[string]string{
+		SkillMainFile:      "---\nname: my-skill\ndescription: A test.\n---\n",
+		"scripts/run.sh":   "#!/bin/bash\n",
+		".git/config":      "[core]\n",
+		"docs/nested/a.md": "a",
+	} {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	data, err := ZIPDirectory(dir)
+	if err != nil {
+		t.Fatalf("ZIPDirectory() unexpected error: %v", err)
+	}
+	r, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("failed to create zip reader: %v", err)
+	}
+	var names []string
+	for _, f := range r.File {
+		names = append(names, f.Name)
+	}
+	want := []string{SkillMainFile, "docs/nested/a.md", "scripts/run.sh"}
+	if strings.Join(names, ",") != strings.Join(want, ",") {
+		t.Errorf("entries = %v, want %v", names, want)
+	}
+
+	if err := os.Symlink("/etc/passwd", filepath.Join(dir, "link")); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+	if _, err := ZIPDirectory(dir); err == nil || !strings.Contains(err.Error(), "symbolic link") {
+		t.Errorf("ZIPDirectory() error = %v, want symbolic link error", err)
+	}
+}
+
+func TestZIPDirectory_TooManyFiles(t *testing.T) {
+	dir := t.TempDir()
+	for i := range MaxZIPFiles + 1 {
+		if err := os.WriteFile(filepath.Join(dir, fmt.Sprintf("file%d.txt", i)), []byte("x"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := ZIPDirectory(dir); err == nil || !strings.Contains(err.Error(), "too many files") {
+		t.Errorf("ZIPDirectory() error = %v, want too many files error", err)
+	}
+}
@@ -0,0 +1,245 @@
+package skillscan
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/obot-platform/obot/pkg/skillformat"
+)
+
+type linePattern struct {
+	re       *regexp.Regexp
+	severity types.SkillScanSeverity
+	message  string
+}
+
+// matchLines reports a finding for the first pattern that matches each line of
+// the file. Matched text is never echoed in the message so that secrets do not
+// end up in the skill status.
+func matchLines(f File, patterns []linePattern) []types.SkillScanFinding {
+	var findings []types.SkillScanFinding
+
+	scanner := bufio.NewScanner(bytes.NewReader(f.Content))
+	scanner.Buffer(make([]byte, 0, 64*1024), maxScannedFileBytes+1)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		for _, p := range patterns {
+			if p.re.MatchString(text) {
+				findings = append(findings, types.SkillScanFinding{
+					Severity: p.severity,
+					Path:     f.Path,
+					Line:     line,
+					Message:  p.message,
+				})
+				break
+			}
+		}
+	}
+
+	return findings
+}
+
+var secretPatterns = []linePattern{
+	{regexp.MustCompile(`-----BEGIN ([A-Z]+ )*PRIVATE KEY( BLOCK)?-----`), types.SkillScanSeverityHigh, "embedded private key"},
+	{regexp.MustCompile(`\b(AKIA|ASIA)[0-9A-Z]{16}\b`), types.SkillScanSeverityHigh, "embedded AWS access key ID"},
+	{regexp.MustCompile(`\b(gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{22,})\b`), types.SkillScanSeverityHigh, "embedded GitHub token"},
+	{regexp.MustCompile(`\bxox[abposr]-[A-Za-z0-9-]{10,}`), types.SkillScanSeverityHigh, "embedded Slack token"},
+	{regexp.MustCompile(`\bsk-(ant-|proj-)?[A-Za-z0-9_-]{32,}`), types.SkillScanSeverityHigh, "embedded LLM provider API key"},
+	{regexp.MustCompile(`\bAIza[0-9A-Za-z_-]{35}\b`), types.SkillScanSeverityHigh, "embedded Google API key"},
+	{regexp.MustCompile(`\b[sr]k_live_[0-9A-Za-z]{24,}\b`), types.SkillScanSeverityHigh, "embedded Stripe live key"},
+	{regexp.MustCompile(`(?i)\b(api[_-]?key|secret|token|passw(or)?d)\b["']?\s*[:=]\s*["'][A-Za-z0-9/+_.\-]{16,}["']`), types.SkillScanSeverityMedium, "possible hard-coded credential"},
+}
+
+// secretScanner looks for credentials committed into the skill tree.
+type secretScanner struct{}
+
+func (secretScanner) Name() string {
+	return "secrets"
+}
+
+func (secretScanner) Scan(in *Input) []types.SkillScanFinding {
+	var findings []types.SkillScanFinding
+	for _, f := range in.Files {
+		findings = append(findings, matchLines(f, secretPatterns)...)
+	}
+	return findings
+}
+
+var encodedPayloadPatterns = []linePattern{
+	{regexp.MustCompile(`base64\s+(-d|-D|--decode)\b[^|]*\|\s*(sudo\s+)?(ba|z|da)?sh\b`), types.SkillScanSeverityHigh, "decoded payload piped to a shell"},
+	{regexp.MustCompile(`\beval\s*\(?\s*["'$(]*\s*(atob|base64_decode|Buffer\.from|echo\s+\S+\s*\|\s*base64)`), types.SkillScanSeverityHigh, "evaluation of a decoded payload"},
+	{regexp.MustCompile(`\bexec\s*\(\s*(base64\.b64decode|zlib\.decompress|codecs\.decode|bytes\.fromhex|marshal\.loads)`), types.SkillScanSeverityHigh, "execution of a decoded payload"},
+	{regexp.MustCompile(`[A-Za-z0-9+/]{200,}={0,2}`), types.SkillScanSeverityMedium, "large base64-encoded blob"},
+	{regexp.MustCompile(`(\\x[0-9a-fA-F]{2}){20,}`), types.SkillScanSeverityMedium, "long hex-escaped string"},
+}
+
+// encodedPayloadScanner looks for obfuscated content, which is rarely needed in
+// a skill and commonly used to hide what a script does.
+type encodedPayloadScanner struct{}
+
+func (encodedPayloadScanner) Name() string {
+	return "encoded-payload"
+}
+
+func (encodedPayloadScanner) Scan(in *Input) []types.SkillScanFinding {
+	var findings []types.SkillScanFinding
+	for _, f := range in.Files {
+		findings = append(findings, matchLines(f, encodedPayloadPatterns)...)
+	}
+	return findings
+}
+
+var remoteExecutionPatterns = []linePattern{
+	{regexp.MustCompile(`\b(curl|wget)\b[^|\n]*\|\s*(sudo\s+)?(-\S+\s+)*(ba|z|da|k)?sh\b`), types.SkillScanSeverityHigh, "remote script piped to a shell"},
+	{regexp.MustCompile(`\b(curl|wget)\b[^|\n]*\|\s*(sudo\s+)?(python[0-9.]*|perl|ruby|node)\b`), types.SkillScanSeverityHigh, "remote script piped to an interpreter"},
+	{regexp.MustCompile(`\b(ba|z)?sh\s+(-c\s+)?["']?[<$]\(\s*(curl|wget)\b`), types.SkillScanSeverityHigh, "remote script executed by a shell"},
+	{regexp.MustCompile(`(?i)\b(iwr|irm|invoke-webrequest|invoke-restmethod)\b[^|\n]*\|\s*(iex|invoke-expression)\b`), types.SkillScanSeverityHigh, "remote script piped to Invoke-Expression"},
+	{regexp.MustCompile(`(?i)\b(iex|invoke-expression)\b\s*\(?.*\b(downloadstring|invoke-webrequest|iwr|irm)\b`), types.SkillScanSeverityHigh, "remote script passed to Invoke-Expression"},
+}
+
+// remoteExecutionScanner looks for the "curl | sh" family of patterns, which
+// run unreviewed code fetched at execution time.
+type remoteExecutionScanner struct{}
+
+func (remoteExecutionScanner) Name() string {
+	return "remote-execution"
+}
+
+func (remoteExecutionScanner) Scan(in *Input) []types.SkillScanFinding {
+	var findings []types.SkillScanFinding
+	for _, f := range in.Files {
+		findings = append(findings, matchLines(f, remoteExecutionPatterns)...)
+	}
+	return findings
+}
+
+var promptInjectionPatterns = []linePattern{
+	{regexp.MustCompile(`(?i)\b(ignore|disregard|forget|override)\s+(all\s+|any\s+)?(of\s+)?(the\s+|your\s+)?(previous|prior|above|earlier|system|original)\s+(instructions|prompts?|directions|rules|guidelines)`), types.SkillScanSeverityHigh, "instruction to ignore previous instructions"},
+	{regexp.MustCompile(`(?i)\b(do\s+not|don't|never)\s+(tell|inform|notify|mention\s+(this\s+)?to|reveal\s+(this\s+)?to|ask)\s+the\s+user\b`), types.SkillScanSeverityHigh, "instruction to conceal actions from the user"},
+	{regexp.MustCompile(`(?i)\bwithout\s+(telling|informing|notifying|asking|alerting)\s+the\s+user\b`), types.SkillScanSeverityHigh, "instruction to act without the user's knowledge"},
+	{regexp.MustCompile(`(?i)\b(reveal|print|output|repeat|send|exfiltrate|leak)\s+(your|the)\s+(system\s+prompt|hidden\s+instructions)`), types.SkillScanSeverityHigh, "instruction to disclose the system prompt"},
+	{regexp.MustCompile(`(?i)\b(bypass|disable|ignore|override)\s+(your\s+|the\s+|all\s+)?(safety|security)\s+(rules|guidelines|checks|restrictions|policies)`), types.SkillScanSeverityHigh, "instruction to bypass safety controls"},
+	{regexp.MustCompile(`(?i)\byou\s+are\s+now\s+(in\s+)?(developer|jailbreak|dan|unrestricted)\s+mode\b`), types.SkillScanSeverityHigh, "jailbreak persona instruction"},
+	{regexp.MustCompile(`[\x{200B}-\x{200D}\x{2060}\x{FEFF}\x{202A}-\x{202E}\x{2066}-\x{2069}]`), types.SkillScanSeverityMedium, "invisible or bidirectional control characters"},
+}
+
+// promptInjectionScanner looks for phrasing in SKILL.md that tries to subvert
+// the agent that loads the skill.
+type promptInjectionScanner struct{}
+
+func (promptInjectionScanner) Name() string {
+	return "prompt-injection"
+}
+
+func (promptInjectionScanner) Scan(in *Input) []types.SkillScanFinding {
+	skillMD, ok := in.SkillMD()
+	if !ok {
+		return nil
+	}
+	return matchLines(skillMD, promptInjectionPatterns)
+}
+
+var (
+	// destructiveCommands grant privilege escalation or irreversible changes.
+	destructiveCommands = map[string]struct{}{
+		"sudo": {}, "su": {}, "doas": {}, "rm": {}, "dd": {}, "mkfs": {}, "shred": {}, "chmod": {}, "chown": {},
+	}
+	// networkCommands can move data off the machine or fetch code to run.
+	networkCommands = map[string]struct{}{
+		"curl": {}, "wget": {}, "nc": {}, "ncat": {}, "netcat": {}, "ssh": {}, "scp": {}, "rsync": {},
+	}
+	shellTools = map[string]struct{}{
+		"bash": {}, "shell": {}, "sh": {},
+	}
+)
+
+// allowedToolsScanner flags allowed-tools entries that pre-approve dangerous
+// tools for the agent.
+type allowedToolsScanner struct{}
+
+func (allowedToolsScanner) Name() string {
+	return "allowed-tools"
+}
+
+func (allowedToolsScanner) Scan(in *Input) []types.SkillScanFinding {
+	var findings []types.SkillScanFinding
+	for _, tool := range ParseAllowedTools(in.Frontmatter.AllowedTools) {
+		severity, message := classifyAllowedTool(tool)
+		if severity == "" {
+			continue
+		}
+		findings = append(findings, types.SkillScanFinding{
+			Severity: severity,
+			Path:     skillformat.SkillMainFile,
+			Message:  message,
+		})
+	}
+	return findings
+}
+
+func classifyAllowedTool(tool string) (types.SkillScanSeverity, string) {
+	if tool == "*" {
+		return types.SkillScanSeverityHigh, "allowed-tools grants every tool"
+	}
+
+	name, pattern, scoped := strings.Cut(tool, "(")
+	if _, ok := shellTools[strings.ToLower(name)]; !ok {
+		return "", ""
+	}
+
+	pattern = strings.TrimSpace(strings.TrimSuffix(pattern, ")"))
+	if !scoped || pattern == "" || pattern == "*" || pattern == ":*" {
+		return types.SkillScanSeverityMedium, fmt.Sprintf("allowed-tools grants unrestricted shell access (%s)", tool)
+	}
+
+	command, _, _ := strings.Cut(pattern, ":")
+	fields := strings.Fields(command)
+	if len(fields) == 0 {
+		return types.SkillScanSeverityMedium, fmt.Sprintf("allowed-tools grants unrestricted shell access (%s)", tool)
+	}
+	if _, ok := destructiveCommands[fields[0]]; ok {
+		return types.SkillScanSeverityHigh, fmt.Sprintf("allowed-tools pre-approves a privileged or destructive command (%s)", tool)
+	}
+	if _, ok := networkCommands[fields[0]]; ok {
+		return types.SkillScanSeverityMedium, fmt.Sprintf("allowed-tools pre-approves a network command (%s)", tool)
+	}
+	return "", ""
+}
+
+// ParseAllowedTools splits an allowed-tools value into individual entries.
+// Entries are separated by whitespace or commas; separators inside parentheses
+// are part of the entry, as in "Bash(git add:*)".
+func ParseAllowedTools(value string) []string {
+	var (
+		tools   []string
+		current strings.Builder
+		depth   int
+	)
+
+	flush := func() {
+		if current.Len() > 0 {
+			tools = append(tools, current.String())
+			current.Reset()
+		}
+	}
+
+	for _, ch := range value {
+		switch {
+		case ch == '(':
+			depth++
+		case ch == ')' && depth > 0:
+			depth--
+		case depth == 0 && (ch == ',' || ch == ' ' || ch == '\t' || ch == '\n'):
+			flush()
+			continue
+		}
+		current.WriteRune(ch)
+	}
+	flush()
+
+	return tools
+}
@@ -0,0 +1,178 @@
+// Package skillscan statically inspects skill trees for content that should
+// not be installed into a developer's agent without review.
+package skillscan
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/obot-platform/obot/pkg/skillformat"
+)
+
+const (
+	// maxScannedFileBytes caps how much of any single file is inspected.
+	maxScannedFileBytes = 1024 * 1024
+	// maxScannedTreeBytes caps how much content is read across the whole skill tree.
+	maxScannedTreeBytes = 16 * 1024 * 1024
+	// maxFindingsPerScanner keeps a noisy scanner from flooding the skill status.
+	maxFindingsPerScanner = 25
+)
+
+// File is a text file from a skill tree. Path is slash-separated and relative
+// to the skill directory.
+type File struct {
+	Path    string
+	Content []byte
+}
+
+// Input is the skill tree handed to each Scanner.
+type Input struct {
+	Frontmatter skillformat.Frontmatter
+	Files       []File
+}
+
+// SkillMD returns the SKILL.md file from the input, if present.
+func (in *Input) SkillMD() (File, bool) {
+	for _, f := range in.Files {
+		if f.Path == skillformat.SkillMainFile {
+			return f, true
+		}
+	}
+	return File{}, false
+}
+
+// Scanner inspects a skill and reports findings. Implementations must not
+// modify the Input.
+type Scanner interface {
+	Name() string
+	Scan(in *Input) []types.SkillScanFinding
+}
+
+// DefaultScanners returns the built-in scanners run during skill indexing.
+func DefaultScanners() []Scanner {
+	return []Scanner{
+		secretScanner{},
+		encodedPayloadScanner{},
+		remoteExecutionScanner{},
+		promptInjectionScanner{},
+		allowedToolsScanner{},
+	}
+}
+
+// LoadInput reads the text files in skillDir. Symbolic links, binary files and
+// content beyond the size limits are skipped.
+func LoadInput(skillDir string, fm skillformat.Frontmatter) (*Input, error) {
+	in := &Input{Frontmatter: fm}
+
+	var total int
+	err := filepath.WalkDir(skillDir, func(currentPath string, entry fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if entry.Type()&fs.ModeSymlink != 0 {
+			return nil
+		}
+		if entry.IsDir() {
+			if entry.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !entry.Type().IsRegular() || total >= maxScannedTreeBytes {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(skillDir, currentPath)
+		if err != nil {
+			return fmt.Errorf("failed to determine scan path for %s: %w", currentPath, err)
+		}
+
+		content, err := readLimited(currentPath, min(maxScannedFileBytes, maxScannedTreeBytes-total))
+		if err != nil {
+			return err
+		}
+		if bytes.IndexByte(content, 0) >= 0 {
+			// Binary content is not inspected.
+			return nil
+		}
+
+		total += len(content)
+		in.Files = append(in.Files, File{
+			Path:    filepath.ToSlash(relPath),
+			Content: content,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load skill for scanning: %w", err)
+	}
+
+	return in, nil
+}
+
+func readLimited(path string, limit int) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s for scanning: %w", path, err)
+	}
+	defer f.Close()
+
+	content, err := io.ReadAll(io.LimitReader(f, int64(limit)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s for scanning: %w", path, err)
+	}
+	return content, nil
+}
+
+// Run executes scanners against the input and returns their findings in a
+// stable order.
+func Run(scanners []Scanner, in *Input) []types.SkillScanFinding {
+	var findings []types.SkillScanFinding
+	for _, scanner := range scanners {
+		results := scanner.Scan(in)
+		if len(results) > maxFindingsPerScanner {
+			results = results[:maxFindingsPerScanner]
+		}
+		for _, finding := range results {
+			finding.Scanner = scanner.Name()
+			findings = append(findings, finding)
+		}
+	}
+
+	slices.SortStableFunc(findings, func(a, b types.SkillScanFinding) int {
+		return cmp.Or(
+			cmp.Compare(severityRank(b.Severity), severityRank(a.Severity)),
+			cmp.Compare(a.Path, b.Path),
+			cmp.Compare(a.Line, b.Line),
+			cmp.Compare(a.Scanner, b.Scanner),
+		)
+	})
+	return findings
+}
+
+// Blocking reports whether any finding prevents the skill from being installed
+// without an admin override.
+func Blocking(findings []types.SkillScanFinding) bool {
+	return slices.ContainsFunc(findings, func(f types.SkillScanFinding) bool {
+		return f.Severity == types.SkillScanSeverityHigh
+	})
+}
+
+func severityRank(severity types.SkillScanSeverity) int {
+	switch severity {
+	case types.SkillScanSeverityHigh:
+		return 3
+	case types.SkillScanSeverityMedium:
+		return 2
+	case types.SkillScanSeverityLow:
+		return 1
+	}
+	return 0
+}
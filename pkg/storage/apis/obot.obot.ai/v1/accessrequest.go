@@ -0,0 +1,115 @@
+package v1
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/obot-platform/nah/pkg/fields"
+	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/obot-platform/obot/pkg/system"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var (
+	_ fields.Fields = (*AccessRequest)(nil)
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// AccessRequest is a user's request for temporary access to a resource. The
+// API records decisions in its spec; the controller keeps a grant in place
+// while the request is approved and unexpired, and removes it otherwise.
+type AccessRequest struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	Spec   AccessRequestSpec `json:"spec"`
+	Status EmptyStatus       `json:"status"`
+}
+
+type AccessRequestSpec struct {
+	Manifest        types.AccessRequestManifest `json:"manifest"`
+	RequesterID     string                      `json:"requesterID"`
+	State           types.AccessRequestState    `json:"state"`
+	DecidedBy       string                      `json:"decidedBy,omitempty"`
+	DecidedAt       metav1.Time                 `json:"decidedAt,omitzero"`
+	DecisionComment string                      `json:"decisionComment,omitempty"`
+	// ExpiresAt is set when the request is approved.
+	ExpiresAt metav1.Time                `json:"expiresAt,omitzero"`
+	History   []types.AccessRequestEvent `json:"history,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type AccessRequestList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []AccessRequest `json:"items"`
+}
+
+func (in *AccessRequest) Has(field string) (exists bool) {
+	return slices.Contains(in.FieldNames(), field)
+}
+
+func (in *AccessRequest) Get(field string) (value string) {
+	switch field {
+	case "spec.requesterID":
+		return in.Spec.RequesterID
+	case "spec.state":
+		return string(in.Spec.State)
+	}
+
+	return ""
+}
+
+func (in *AccessRequest) FieldNames() []string {
+	return []string{"spec.requesterID", "spec.state"}
+}
+
+func (in *AccessRequest) GetColumns() [][]string {
+	return [][]string{
+		{"Name", "Name"},
+		{"Requester", "Spec.RequesterID"},
+		{"Resource Type", "Spec.Manifest.ResourceType"},
+		{"Resource", "Spec.Manifest.ResourceID"},
+		{"State", "Spec.State"},
+		{"Expires", "{{ago .Spec.ExpiresAt}}"},
+	}
+}
+
+// GrantName returns the name of the access control rule, model access policy
+// or skill access rule that grants the requested access.
+func (in *AccessRequest) GrantName() string {
+	suffix := strings.TrimPrefix(in.Name, system.AccessRequestPrefix)
+	switch in.Spec.Manifest.ResourceType {
+	case types.AccessRequestResourceTypeModel:
+		return system.ModelAccessPolicyPrefix + suffix
+	case types.AccessRequestResourceTypeSkill:
+		return system.SkillAccessRulePrefix + suffix
+	default:
+		return system.AccessControlRulePrefix + suffix
+	}
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type AccessRequestSetting struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	Spec AccessRequestSettingSpec `json:"spec"`
+}
+
+type AccessRequestSettingSpec struct {
+	Manifest types.AccessRequestSetting `json:"manifest"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type AccessRequestSettingList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []AccessRequestSetting `json:"items"`
+}
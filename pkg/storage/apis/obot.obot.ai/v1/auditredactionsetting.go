@@ -0,0 +1,29 @@
+package v1
+
+import (
+	"github.com/obot-platform/obot/apiclient/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type AuditRedactionSetting struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	Spec AuditRedactionSettingSpec `json:"spec"`
+}
+
+type AuditRedactionSettingSpec struct {
+	// Manifest configures redaction of stored LLM and MCP audit payloads
+	Manifest types.AuditRedactionSetting `json:"manifest"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type AuditRedactionSettingList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []AuditRedactionSetting `json:"items"`
+}
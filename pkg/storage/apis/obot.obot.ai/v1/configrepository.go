@@ -0,0 +1,81 @@
+package v1
+
+import (
+	"slices"
+
+	"github.com/obot-platform/nah/pkg/fields"
+	"github.com/obot-platform/obot/apiclient/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var (
+	_ fields.Fields = (*ConfigRepository)(nil)
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type ConfigRepository struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	Spec   ConfigRepositorySpec   `json:"spec"`
+	Status ConfigRepositoryStatus `json:"status"`
+}
+
+type ConfigRepositorySpec struct {
+	types.ConfigRepositoryManifest `json:",inline"`
+}
+
+type ConfigRepositoryStatus struct {
+	LastSyncTime      metav1.Time                    `json:"lastSyncTime,omitzero"`
+	IsSyncing         bool                           `json:"isSyncing,omitempty"`
+	SyncError         string                         `json:"syncError,omitempty"`
+	ResolvedCommitSHA string                         `json:"resolvedCommitSHA,omitempty"`
+	Objects           []types.ConfigRepositoryObject `json:"objects,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type ConfigRepositoryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []ConfigRepository `json:"items"`
+}
+
+func (in *ConfigRepository) Has(field string) (exists bool) {
+	return slices.Contains(in.FieldNames(), field)
+}
+
+func (in *ConfigRepository) Get(field string) (value string) {
+	switch field {
+	case "spec.repoURL":
+		return in.Spec.RepoURL
+	case "spec.gitCredentialID":
+		return in.Spec.GitCredentialID
+	}
+
+	return ""
+}
+
+func (in *ConfigRepository) FieldNames() []string {
+	return []string{"spec.repoURL", "spec.gitCredentialID"}
+}
+
+func (in *ConfigRepository) GetColumns() [][]string {
+	return [][]string{
+		{"Name", "Name"},
+		{"Display Name", "Spec.DisplayName"},
+		{"Repo URL", "Spec.RepoURL"},
+		{"Ref", "Spec.Ref"},
+		{"Path", "Spec.Path"},
+		{"Error", "Status.SyncError"},
+		{"Last Synced", "{{ago .Status.LastSyncTime}}"},
+	}
+}
+
+// ManagedByConfigRepository returns the name of the config repository that
+// manages obj, or the empty string if none does.
+func ManagedByConfigRepository(obj metav1.Object) string {
+	return obj.GetLabels()[ConfigRepositoryLabel]
+}
@@ -14,6 +14,11 @@ const (
 	GitCredentialFinalizer         = "obot.obot.ai/git-credential"
 	HostedAgentInstanceFinalizer   = "obot.obot.ai/hosted-agent-instance"
 	HostedAgentPoolFinalizer       = "obot.obot.ai/hosted-agent-pool"
+	HostedAgentTriggerFinalizer    = "obot.obot.ai/hosted-agent-trigger"
+	HostedAgentSnapshotFinalizer   = "obot.obot.ai/hosted-agent-snapshot"
+	ConfigRepositoryFinalizer      = "obot.obot.ai/config-repository"
+	NotificationChannelFinalizer   = "obot.obot.ai/notification-channel"
+	AccessRequestFinalizer         = "obot.obot.ai/access-request"
 
 	ModelProviderSyncAnnotation               = "obot.ai/model-provider-sync"
 	AuthProviderSyncAnnotation                = "obot.ai/auth-provider-sync"
@@ -25,4 +30,13 @@ const (
 	MCPServerCatalogEntrySyncAnnotation       = "obot.ai/mcp-server-catalog-entry-sync"
 	SystemMCPServerCatalogEntrySyncAnnotation = "obot.ai/system-mcp-server-catalog-entry-sync"
 	ModelInfoSourceSyncAnnotation             = "obot.ai/model-info-source-sync"
+	ConfigRepositorySyncAnnotation            = "obot.ai/config-repository-sync"
+
+	// ConfigRepositoryLabel is set on objects a config repository manages, to
+	// the repository's name. The API refuses to change them.
+	ConfigRepositoryLabel = "obot.ai/config-repository"
+
+	// AccessRequestLabel is set on the rules and policies that grant the access
+	// an access request asked for, to the request's name.
+	AccessRequestLabel = "obot.ai/access-request"
 )
@@ -1,6 +1,7 @@
 package v1
 
 import (
+	"github.com/obot-platform/obot/apiclient/types"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -17,6 +18,11 @@ type GitCredential struct {
 type GitCredentialSpec struct {
 	DisplayName string `json:"displayName,omitempty"`
 	Host        string `json:"host,omitempty"`
+	// Type is the kind of credential. Empty means a token credential.
+	Type                    types.GitCredentialType `json:"type,omitempty"`
+	KnownHosts              string                  `json:"knownHosts,omitempty"`
+	GitHubAppID             string                  `json:"githubAppID,omitempty"`
+	GitHubAppInstallationID string                  `json:"githubAppInstallationID,omitempty"`
 }
 
 type GitCredentialStatus struct {
@@ -24,9 +30,10 @@ type GitCredentialStatus struct {
 }
 
 type GitCredentialReferences struct {
-	SkillRepositories []GitCredentialReference `json:"skillRepositories,omitempty"`
-	MCPCatalogs       []GitCredentialReference `json:"mcpCatalogs,omitempty"`
-	SystemMCPCatalogs []GitCredentialReference `json:"systemMcpCatalogs,omitempty"`
+	SkillRepositories  []GitCredentialReference `json:"skillRepositories,omitempty"`
+	MCPCatalogs        []GitCredentialReference `json:"mcpCatalogs,omitempty"`
+	SystemMCPCatalogs  []GitCredentialReference `json:"systemMcpCatalogs,omitempty"`
+	ConfigRepositories []GitCredentialReference `json:"configRepositories,omitempty"`
 }
 
 type GitCredentialReference struct {
@@ -44,7 +51,7 @@ type GitCredentialList struct {
 }
 
 func (r GitCredentialReferences) Len() int {
-	return len(r.SkillRepositories) + len(r.MCPCatalogs) + len(r.SystemMCPCatalogs)
+	return len(r.SkillRepositories) + len(r.MCPCatalogs) + len(r.SystemMCPCatalogs) + len(r.ConfigRepositories)
 }
 
 func (in *GitCredential) GetColumns() [][]string {
@@ -52,5 +59,6 @@ func (in *GitCredential) GetColumns() [][]string {
 		{"Name", "Name"},
 		{"Display Name", "Spec.DisplayName"},
 		{"Host", "Spec.Host"},
+		{"Type", "Spec.Type"},
 	}
 }
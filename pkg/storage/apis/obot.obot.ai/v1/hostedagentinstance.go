@@ -28,6 +28,19 @@ type HostedAgentInstanceSpec struct {
 	HostedAgentName string                            `json:"hostedAgentName,omitempty"`
 	PoolID          string                            `json:"poolID,omitempty"`
 	Manifest        types.HostedAgentInstanceManifest `json:"manifest"`
+	// DesiredState is whether the user wants the instance running. Empty means
+	// running, which is what every instance created before it existed wants.
+	DesiredState types.HostedAgentDesiredState `json:"desiredState,omitempty"`
+	// Restore asks for a snapshot to be written into the workspace. It stays
+	// in the spec after it is done; Status.RestoredID says it was.
+	Restore *HostedAgentInstanceRestore `json:"restore,omitempty"`
+}
+
+// HostedAgentInstanceRestore names a snapshot to restore. ID distinguishes one
+// request from the next, so restoring the same snapshot twice restores twice.
+type HostedAgentInstanceRestore struct {
+	SnapshotName string `json:"snapshotName,omitempty"`
+	ID           string `json:"id,omitempty"`
 }
 
 type HostedAgentInstanceStatus struct {
@@ -43,6 +56,31 @@ type HostedAgentInstanceStatus struct {
 
 	BackendID         string `json:"backendID,omitempty"`
 	BackendGeneration int64  `json:"backendGeneration,omitempty"`
+
+	// Hibernated records that the controller stopped the instance for being
+	// idle. It lives in status because the user never asked for it, and it is
+	// cleared by the next connection rather than by a spec change.
+	Hibernated bool `json:"hibernated,omitempty"`
+	// LastActivityTime is the last terminal session or HTTP request, recorded
+	// at most once per hostedagentactivity.Resolution.
+	LastActivityTime *metav1.Time `json:"lastActivityTime,omitempty"`
+
+	// RestoredID is the Spec.Restore ID that was last carried out, whether it
+	// succeeded or, with RestoreError set, failed.
+	RestoredID   string `json:"restoredID,omitempty"`
+	RestoreError string `json:"restoreError,omitempty"`
+}
+
+// Stopped reports whether the instance should be scaled to zero: because its
+// user stopped it, because it hibernated, or because a restore is rewriting its
+// workspace underneath it.
+func (in *HostedAgentInstance) Stopped() bool {
+	return in.Spec.DesiredState == types.HostedAgentDesiredStateStopped || in.Status.Hibernated || in.RestorePending()
+}
+
+// RestorePending reports whether Spec.Restore has not been carried out yet.
+func (in *HostedAgentInstance) RestorePending() bool {
+	return in.Spec.Restore != nil && in.Spec.Restore.ID != in.Status.RestoredID
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
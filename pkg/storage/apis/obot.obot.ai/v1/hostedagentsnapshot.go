@@ -0,0 +1,88 @@
+package v1
+
+import (
+	"slices"
+
+	"github.com/obot-platform/nah/pkg/fields"
+	"github.com/obot-platform/obot/apiclient/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ fields.Fields = (*HostedAgentSnapshot)(nil)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type HostedAgentSnapshot struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	Spec   HostedAgentSnapshotSpec   `json:"spec"`
+	Status HostedAgentSnapshotStatus `json:"status"`
+}
+
+type HostedAgentSnapshotSpec struct {
+	UserID                  string                            `json:"userID,omitempty"`
+	HostedAgentInstanceName string                            `json:"hostedAgentInstanceName,omitempty"`
+	HostedAgentName         string                            `json:"hostedAgentName,omitempty"`
+	PoolID                  string                            `json:"poolID,omitempty"`
+	Manifest                types.HostedAgentSnapshotManifest `json:"manifest"`
+	// InstanceManifest is the instance's manifest when the snapshot was taken,
+	// so a clone can be made after the instance is gone.
+	InstanceManifest types.HostedAgentInstanceManifest `json:"instanceManifest"`
+	PreDelete        bool                              `json:"preDelete,omitempty"`
+	ExpiresAt        *metav1.Time                      `json:"expiresAt,omitempty"`
+}
+
+type HostedAgentSnapshotStatus struct {
+	State         types.HostedAgentSnapshotState `json:"state,omitempty"`
+	SizeBytes     int64                          `json:"sizeBytes,omitempty"`
+	CompletedTime *metav1.Time                   `json:"completedTime,omitempty"`
+	Error         string                         `json:"error,omitempty"`
+	// Attempts counts captures that failed in a way a retry may fix, and
+	// NextAttemptAt is when the next one may start.
+	Attempts      int          `json:"attempts,omitempty"`
+	NextAttemptAt *metav1.Time `json:"nextAttemptAt,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type HostedAgentSnapshotList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []HostedAgentSnapshot `json:"items"`
+}
+
+func (in *HostedAgentSnapshot) Has(field string) bool {
+	return slices.Contains(in.FieldNames(), field)
+}
+
+func (in *HostedAgentSnapshot) Get(field string) string {
+	switch field {
+	case "spec.userID":
+		return in.Spec.UserID
+	case "spec.hostedAgentInstanceName":
+		return in.Spec.HostedAgentInstanceName
+	}
+	return ""
+}
+
+func (in *HostedAgentSnapshot) FieldNames() []string {
+	return []string{"spec.userID", "spec.hostedAgentInstanceName"}
+}
+
+// A snapshot has no DeleteRefs. Surviving the deletion of its instance is the
+// point of a pre-delete snapshot, and a user's own snapshots are kept until
+// they or their retention remove them.
+
+func (in *HostedAgentSnapshot) GetColumns() [][]string {
+	return [][]string{
+		{"Name", "Name"},
+		{"Display Name", "Spec.Manifest.Name"},
+		{"Instance", "Spec.HostedAgentInstanceName"},
+		{"User", "Spec.UserID"},
+		{"State", "Status.State"},
+		{"Size", "Status.SizeBytes"},
+		{"Created", "{{ago .CreationTimestamp}}"},
+	}
+}
@@ -0,0 +1,85 @@
+package v1
+
+import (
+	"slices"
+
+	"github.com/obot-platform/nah/pkg/fields"
+	"github.com/obot-platform/obot/apiclient/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var (
+	_ fields.Fields = (*HostedAgentTrigger)(nil)
+	_ DeleteRefs    = (*HostedAgentTrigger)(nil)
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type HostedAgentTrigger struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	Spec   HostedAgentTriggerSpec   `json:"spec"`
+	Status HostedAgentTriggerStatus `json:"status"`
+}
+
+type HostedAgentTriggerSpec struct {
+	UserID                  string                           `json:"userID,omitempty"`
+	HostedAgentInstanceName string                           `json:"hostedAgentInstanceName,omitempty"`
+	Manifest                types.HostedAgentTriggerManifest `json:"manifest"`
+}
+
+type HostedAgentTriggerStatus struct {
+	LastRunTime *metav1.Time `json:"lastRunTime,omitempty"`
+	NextRunTime *metav1.Time `json:"nextRunTime,omitempty"`
+
+	LastInvocationTime *metav1.Time `json:"lastInvocationTime,omitempty"`
+	LastStatusCode     int          `json:"lastStatusCode,omitempty"`
+	LastError          string       `json:"lastError,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type HostedAgentTriggerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []HostedAgentTrigger `json:"items"`
+}
+
+func (in *HostedAgentTrigger) Has(field string) bool {
+	return slices.Contains(in.FieldNames(), field)
+}
+
+func (in *HostedAgentTrigger) Get(field string) string {
+	switch field {
+	case "spec.userID":
+		return in.Spec.UserID
+	case "spec.hostedAgentInstanceName":
+		return in.Spec.HostedAgentInstanceName
+	}
+	return ""
+}
+
+func (in *HostedAgentTrigger) FieldNames() []string {
+	return []string{"spec.userID", "spec.hostedAgentInstanceName"}
+}
+
+// DeleteRefs removes a trigger with its instance. Nothing is left to invoke.
+func (in *HostedAgentTrigger) DeleteRefs() []Ref {
+	return []Ref{
+		{ObjType: &HostedAgentInstance{}, Name: in.Spec.HostedAgentInstanceName},
+	}
+}
+
+func (in *HostedAgentTrigger) GetColumns() [][]string {
+	return [][]string{
+		{"Name", "Name"},
+		{"Display Name", "Spec.Manifest.Name"},
+		{"Type", "Spec.Manifest.TriggerType"},
+		{"Instance", "Spec.HostedAgentInstanceName"},
+		{"Schedule", "Spec.Manifest.Schedule"},
+		{"Last Status", "Status.LastStatusCode"},
+		{"Created", "{{ago .CreationTimestamp}}"},
+	}
+}
@@ -0,0 +1,39 @@
+package v1
+
+import (
+	"github.com/obot-platform/obot/apiclient/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type MCPQuotaRule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	Spec   MCPQuotaRuleSpec `json:"spec"`
+	Status EmptyStatus      `json:"status"`
+}
+
+type MCPQuotaRuleSpec struct {
+	Manifest types.MCPQuotaRuleManifest `json:"manifest"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type MCPQuotaRuleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []MCPQuotaRule `json:"items"`
+}
+
+func (in *MCPQuotaRule) GetColumns() [][]string {
+	return [][]string{
+		{"Name", "Name"},
+		{"Display Name", "Spec.Manifest.DisplayName"},
+		{"Per Minute", "Spec.Manifest.CallsPerMinute"},
+		{"Per Day", "Spec.Manifest.CallsPerDay"},
+		{"Subjects", "{{len .Spec.Manifest.Subjects}}"},
+	}
+}
@@ -100,6 +100,29 @@ type MCPServerStatus struct {
 	LastRequestTime metav1.Time `json:"lastRequestTime,omitzero"`
 	// Idle indicates whether the server is currently idle.
 	Idle bool `json:"idle,omitempty"`
+	// Health records the periodic health checks of the upstream server.
+	Health *MCPServerHealth `json:"health,omitempty"`
+}
+
+type MCPServerHealth struct {
+	State               types.MCPServerHealthState `json:"state,omitempty"`
+	LastChecked         metav1.Time                `json:"lastChecked,omitzero"`
+	LastSuccess         metav1.Time                `json:"lastSuccess,omitzero"`
+	LastFailure         metav1.Time                `json:"lastFailure,omitzero"`
+	LastError           string                     `json:"lastError,omitempty"`
+	ConsecutiveFailures int                        `json:"consecutiveFailures,omitempty"`
+	// CircuitOpenUntil is set while the gateway should fail requests to the
+	// server fast. Replicas that have not observed the server themselves use it.
+	CircuitOpenUntil metav1.Time `json:"circuitOpenUntil,omitzero"`
+	// History is a bounded list of the most recent checks, oldest first.
+	History []MCPServerHealthCheck `json:"history,omitempty"`
+}
+
+type MCPServerHealthCheck struct {
+	Time          metav1.Time `json:"time"`
+	Healthy       bool        `json:"healthy,omitempty"`
+	LatencyMillis int64       `json:"latencyMillis,omitempty"`
+	Error         string      `json:"error,omitempty"`
 }
 
 type OAuthMetadata struct {
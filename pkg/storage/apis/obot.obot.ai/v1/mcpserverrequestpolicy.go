@@ -0,0 +1,38 @@
+package v1
+
+import (
+	"github.com/obot-platform/obot/apiclient/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type MCPServerRequestPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	Spec   MCPServerRequestPolicySpec `json:"spec"`
+	Status EmptyStatus                `json:"status"`
+}
+
+type MCPServerRequestPolicySpec struct {
+	Manifest types.MCPServerRequestPolicyManifest `json:"manifest"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type MCPServerRequestPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []MCPServerRequestPolicy `json:"items"`
+}
+
+func (in *MCPServerRequestPolicy) GetColumns() [][]string {
+	return [][]string{
+		{"Name", "Name"},
+		{"Display Name", "Spec.Manifest.DisplayName"},
+		{"Sampling", "Spec.Manifest.Sampling"},
+		{"Elicitation", "Spec.Manifest.Elicitation"},
+	}
+}
@@ -0,0 +1,118 @@
+package v1
+
+import (
+	"encoding/json"
+	"slices"
+
+	"github.com/obot-platform/nah/pkg/fields"
+	"github.com/obot-platform/obot/apiclient/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var (
+	_ fields.Fields = (*MCPToolCallApproval)(nil)
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type MCPToolApprovalPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	Spec   MCPToolApprovalPolicySpec `json:"spec"`
+	Status EmptyStatus               `json:"status"`
+}
+
+type MCPToolApprovalPolicySpec struct {
+	Manifest types.MCPToolApprovalPolicyManifest `json:"manifest"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type MCPToolApprovalPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []MCPToolApprovalPolicy `json:"items"`
+}
+
+func (in *MCPToolApprovalPolicy) GetColumns() [][]string {
+	return [][]string{
+		{"Name", "Name"},
+		{"Display Name", "Spec.Manifest.DisplayName"},
+		{"Tools", "Spec.Manifest.Tools"},
+		{"Approvers", "{{len .Spec.Manifest.Approvers}}"},
+	}
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// MCPToolCallApproval is a tool call the MCP gateway holds until an approver
+// decides. The API records decisions in its spec; the gateway holding the call
+// releases or rejects it, and the controller expires approvals whose gateway
+// stopped waiting without recording an outcome.
+type MCPToolCallApproval struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	Spec   MCPToolCallApprovalSpec `json:"spec"`
+	Status EmptyStatus             `json:"status"`
+}
+
+type MCPToolCallApprovalSpec struct {
+	PolicyName           string                         `json:"policyName"`
+	RequesterID          string                         `json:"requesterID"`
+	MCPID                string                         `json:"mcpID"`
+	MCPServerDisplayName string                         `json:"mcpServerDisplayName,omitempty"`
+	Method               string                         `json:"method,omitempty"`
+	ToolName             string                         `json:"toolName"`
+	Arguments            json.RawMessage                `json:"arguments,omitempty"`
+	SessionID            string                         `json:"sessionID,omitempty"`
+	RequestID            string                         `json:"requestID,omitempty"`
+	Approvers            []types.Subject                `json:"approvers,omitempty"`
+	State                types.MCPToolCallApprovalState `json:"state"`
+	ExpiresAt            metav1.Time                    `json:"expiresAt"`
+	DecidedBy            string                         `json:"decidedBy,omitempty"`
+	DecidedAt            metav1.Time                    `json:"decidedAt,omitzero"`
+	DecisionComment      string                         `json:"decisionComment,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type MCPToolCallApprovalList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []MCPToolCallApproval `json:"items"`
+}
+
+func (in *MCPToolCallApproval) Has(field string) (exists bool) {
+	return slices.Contains(in.FieldNames(), field)
+}
+
+func (in *MCPToolCallApproval) Get(field string) (value string) {
+	switch field {
+	case "spec.requesterID":
+		return in.Spec.RequesterID
+	case "spec.state":
+		return string(in.Spec.State)
+	}
+
+	return ""
+}
+
+func (in *MCPToolCallApproval) FieldNames() []string {
+	return []string{"spec.requesterID", "spec.state"}
+}
+
+func (in *MCPToolCallApproval) GetColumns() [][]string {
+	return [][]string{
+		{"Name", "Name"},
+		{"Requester", "Spec.RequesterID"},
+		{"MCP Server", "Spec.MCPID"},
+		{"Method", "Spec.Method"},
+		{"Tool", "Spec.ToolName"},
+		{"State", "Spec.State"},
+		{"Expires", "{{ago .Spec.ExpiresAt}}"},
+	}
+}
@@ -0,0 +1,151 @@
+package v1
+
+import (
+	"slices"
+
+	"github.com/obot-platform/nah/pkg/fields"
+	"github.com/obot-platform/obot/apiclient/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var (
+	_ fields.Fields = (*NotificationDelivery)(nil)
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// NotificationChannel is a destination for notifications. Its secret is kept
+// in the credential store, not in the spec.
+type NotificationChannel struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	Spec   NotificationChannelSpec `json:"spec"`
+	Status EmptyStatus             `json:"status"`
+}
+
+type NotificationChannelSpec struct {
+	// Manifest never holds the channel's secret.
+	Manifest types.NotificationChannelManifest `json:"manifest"`
+}
+
+func (in *NotificationChannel) GetColumns() [][]string {
+	return [][]string{
+		{"Name", "Name"},
+		{"Display Name", "Spec.Manifest.DisplayName"},
+		{"Type", "Spec.Manifest.ChannelType"},
+		{"Created", "{{ago .CreationTimestamp}}"},
+	}
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type NotificationChannelList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []NotificationChannel `json:"items"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// NotificationRule subscribes notification channels to event types.
+type NotificationRule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	Spec   NotificationRuleSpec `json:"spec"`
+	Status EmptyStatus          `json:"status"`
+}
+
+type NotificationRuleSpec struct {
+	Manifest types.NotificationRuleManifest `json:"manifest"`
+}
+
+func (in *NotificationRule) GetColumns() [][]string {
+	return [][]string{
+		{"Name", "Name"},
+		{"Display Name", "Spec.Manifest.DisplayName"},
+		{"Events", "Spec.Manifest.EventTypes"},
+		{"Channels", "Spec.Manifest.ChannelIDs"},
+		{"Disabled", "Spec.Manifest.Disabled"},
+	}
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type NotificationRuleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []NotificationRule `json:"items"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// NotificationDelivery is one event to be sent to one channel. The controller
+// sends it, retrying failures, and keeps it as the delivery log until it
+// expires.
+type NotificationDelivery struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	Spec   NotificationDeliverySpec   `json:"spec"`
+	Status NotificationDeliveryStatus `json:"status"`
+}
+
+type NotificationDeliverySpec struct {
+	ChannelName string                  `json:"channelName"`
+	RuleName    string                  `json:"ruleName,omitempty"`
+	Event       types.NotificationEvent `json:"event"`
+}
+
+type NotificationDeliveryStatus struct {
+	State         types.NotificationDeliveryState `json:"state,omitempty"`
+	Attempts      int                             `json:"attempts,omitempty"`
+	LastError     string                          `json:"lastError,omitempty"`
+	LastAttemptAt metav1.Time                     `json:"lastAttemptAt,omitzero"`
+	NextAttemptAt metav1.Time                     `json:"nextAttemptAt,omitzero"`
+	DeliveredAt   metav1.Time                     `json:"deliveredAt,omitzero"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type NotificationDeliveryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []NotificationDelivery `json:"items"`
+}
+
+func (in *NotificationDelivery) Has(field string) (exists bool) {
+	return slices.Contains(in.FieldNames(), field)
+}
+
+func (in *NotificationDelivery) Get(field string) (value string) {
+	switch field {
+	case "spec.channelName":
+		return in.Spec.ChannelName
+	case "spec.ruleName":
+		return in.Spec.RuleName
+	case "spec.event.type":
+		return string(in.Spec.Event.Type)
+	}
+
+	return ""
+}
+
+func (in *NotificationDelivery) FieldNames() []string {
+	return []string{"spec.channelName", "spec.ruleName", "spec.event.type"}
+}
+
+func (in *NotificationDelivery) GetColumns() [][]string {
+	return [][]string{
+		{"Name", "Name"},
+		{"Channel", "Spec.ChannelName"},
+		{"Event", "Spec.Event.Type"},
+		{"State", "Status.State"},
+		{"Attempts", "Status.Attempts"},
+		{"Created", "{{ago .CreationTimestamp}}"},
+	}
+}
@@ -71,12 +71,28 @@ func AddToSchemeWithGV(scheme *runtime.Scheme, schemeGroupVersion schema.GroupVe
 		&SkillAccessRuleList{},
 		&AgentCatalog{},
 		&AgentCatalogList{},
+		&ConfigRepository{},
+		&ConfigRepositoryList{},
+		&AccessRequest{},
+		&AccessRequestList{},
+		&AccessRequestSetting{},
+		&AccessRequestSettingList{},
+		&NotificationChannel{},
+		&NotificationChannelList{},
+		&NotificationRule{},
+		&NotificationRuleList{},
+		&NotificationDelivery{},
+		&NotificationDeliveryList{},
 		&Harness{},
 		&HarnessList{},
 		&HostedAgent{},
 		&HostedAgentList{},
 		&HostedAgentInstance{},
 		&HostedAgentInstanceList{},
+		&HostedAgentTrigger{},
+		&HostedAgentTriggerList{},
+		&HostedAgentSnapshot{},
+		&HostedAgentSnapshotList{},
 		&HostedAgentPool{},
 		&HostedAgentPoolList{},
 		&HostedAgentPoolDefaults{},
@@ -99,6 +115,10 @@ func AddToSchemeWithGV(scheme *runtime.Scheme, schemeGroupVersion schema.GroupVe
 		&PowerUserWorkspaceList{},
 		&UserDefaultRoleSetting{},
 		&UserDefaultRoleSettingList{},
+		&AuditRedactionSetting{},
+		&AuditRedactionSettingList{},
+		&ToolCallEnforcementSetting{},
+		&ToolCallEnforcementSettingList{},
 		&K8sSettings{},
 		&K8sSettingsList{},
 		&ImagePullSecret{},
@@ -119,6 +139,14 @@ func AddToSchemeWithGV(scheme *runtime.Scheme, schemeGroupVersion schema.GroupVe
 		&ModelAccessPolicyList{},
 		&MessagePolicy{},
 		&MessagePolicyList{},
+		&MCPQuotaRule{},
+		&MCPQuotaRuleList{},
+		&MCPToolApprovalPolicy{},
+		&MCPToolApprovalPolicyList{},
+		&MCPToolCallApproval{},
+		&MCPToolCallApprovalList{},
+		&MCPServerRequestPolicy{},
+		&MCPServerRequestPolicyList{},
 		&NanobotAgent{},
 		&NanobotAgentList{},
 		&Project{},
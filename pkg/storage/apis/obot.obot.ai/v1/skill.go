@@ -32,12 +32,39 @@ type SkillSpec struct {
 	CommitSHA    string `json:"commitSHA,omitempty"`
 	RelativePath string `json:"relativePath,omitempty"`
 	InstallHash  string `json:"installHash,omitempty"`
+
+	// ScanOverride is set by an admin to allow installation of a skill that
+	// was blocked by the security scan. It is preserved across re-indexing and
+	// only applies while InstallHash matches the indexed content.
+	ScanOverride *SkillScanOverride `json:"scanOverride,omitempty"`
+}
+
+type SkillScanOverride struct {
+	InstallHash  string      `json:"installHash,omitempty"`
+	OverriddenBy string      `json:"overriddenBy,omitempty"`
+	Reason       string      `json:"reason,omitempty"`
+	OverriddenAt metav1.Time `json:"overriddenAt,omitzero"`
 }
 
 type SkillStatus struct {
 	Valid           bool        `json:"valid,omitempty"`
 	ValidationError string      `json:"validationError,omitempty"`
 	LastIndexedAt   metav1.Time `json:"lastIndexedAt,omitzero"`
+
+	ScanFindings []types.SkillScanFinding `json:"scanFindings,omitempty"`
+	ScanBlocked  bool                     `json:"scanBlocked,omitempty"`
+
+	// Revisions is a bounded history of distinct indexed contents of the skill,
+	// newest first.
+	Revisions []SkillRevision `json:"revisions,omitempty"`
+}
+
+type SkillRevision struct {
+	Manifest    types.SkillManifest `json:"manifest"`
+	CommitSHA   string              `json:"commitSHA,omitempty"`
+	InstallHash string              `json:"installHash,omitempty"`
+	IndexedAt   metav1.Time         `json:"indexedAt,omitzero"`
+	ScanBlocked bool                `json:"scanBlocked,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -75,6 +102,42 @@ func (in *Skill) DeleteRefs() []Ref {
 	}}
 }
 
+// ScanOverridden reports whether an admin override applies to the skill's
+// current content.
+func (in *Skill) ScanOverridden() bool {
+	return in.Spec.ScanOverride != nil && in.Spec.ScanOverride.InstallHash != "" && in.Spec.ScanOverride.InstallHash == in.Spec.InstallHash
+}
+
+// Installable reports whether the skill can be downloaded and installed: it must
+// be valid, and either not blocked by the security scan or overridden by an admin.
+func (in *Skill) Installable() bool {
+	return in.Status.Valid && (!in.Status.ScanBlocked || in.ScanOverridden())
+}
+
+// RevisionInstallable reports whether a historical revision of the skill can be
+// installed, applying the same scan override rules as the current revision.
+// Revisions without an install hash were never fully indexed and are not
+// installable.
+func (in *Skill) RevisionInstallable(rev SkillRevision) bool {
+	if rev.InstallHash == "" {
+		return false
+	}
+	if !rev.ScanBlocked {
+		return true
+	}
+	return in.Spec.ScanOverride != nil && in.Spec.ScanOverride.InstallHash == rev.InstallHash
+}
+
+// Revision returns the indexed revision with the given commit SHA.
+func (in *Skill) Revision(commitSHA string) (SkillRevision, bool) {
+	for _, rev := range in.Status.Revisions {
+		if rev.CommitSHA == commitSHA {
+			return rev, true
+		}
+	}
+	return SkillRevision{}, false
+}
+
 func (in *Skill) GetColumns() [][]string {
 	return [][]string{
 		{"Name", "Name"},
@@ -82,6 +145,7 @@ func (in *Skill) GetColumns() [][]string {
 		{"Repository", "Spec.RepoID"},
 		{"Path", "Spec.RelativePath"},
 		{"Valid", "Status.Valid"},
+		{"Scan Blocked", "Status.ScanBlocked"},
 		{"Last Indexed", "{{ago .Status.LastIndexedAt}}"},
 	}
 }
@@ -0,0 +1,29 @@
+package v1
+
+import (
+	"github.com/obot-platform/obot/apiclient/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type ToolCallEnforcementSetting struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	Spec ToolCallEnforcementSettingSpec `json:"spec"`
+}
+
+type ToolCallEnforcementSettingSpec struct {
+	// Manifest configures enforcement of tool calls returned through the LLM gateway
+	Manifest types.ToolCallEnforcementSetting `json:"manifest"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type ToolCallEnforcementSettingList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []ToolCallEnforcementSetting `json:"items"`
+}
@@ -5,6 +5,7 @@
 package v1
 
 import (
+	"encoding/json"
 	"github.com/obot-platform/obot/apiclient/types"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -84,6 +85,164 @@ func (in *AccessControlRuleSpec) DeepCopy() *AccessControlRuleSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessRequest) DeepCopyInto(out *AccessRequest) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessRequest.
+func (in *AccessRequest) DeepCopy() *AccessRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AccessRequest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessRequestList) DeepCopyInto(out *AccessRequestList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AccessRequest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessRequestList.
+func (in *AccessRequestList) DeepCopy() *AccessRequestList {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessRequestList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AccessRequestList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessRequestSetting) DeepCopyInto(out *AccessRequestSetting) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessRequestSetting.
+func (in *AccessRequestSetting) DeepCopy() *AccessRequestSetting {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessRequestSetting)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AccessRequestSetting) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessRequestSettingList) DeepCopyInto(out *AccessRequestSettingList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AccessRequestSetting, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessRequestSettingList.
+func (in *AccessRequestSettingList) DeepCopy() *AccessRequestSettingList {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessRequestSettingList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AccessRequestSettingList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessRequestSettingSpec) DeepCopyInto(out *AccessRequestSettingSpec) {
+	*out = *in
+	in.Manifest.DeepCopyInto(&out.Manifest)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessRequestSettingSpec.
+func (in *AccessRequestSettingSpec) DeepCopy() *AccessRequestSettingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessRequestSettingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessRequestSpec) DeepCopyInto(out *AccessRequestSpec) {
+	*out = *in
+	out.Manifest = in.Manifest
+	in.DecidedAt.DeepCopyInto(&out.DecidedAt)
+	in.ExpiresAt.DeepCopyInto(&out.ExpiresAt)
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]types.AccessRequestEvent, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessRequestSpec.
+func (in *AccessRequestSpec) DeepCopy() *AccessRequestSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessRequestSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AgentCatalog) DeepCopyInto(out *AgentCatalog) {
 	*out = *in
@@ -540,6 +699,80 @@ func (in *AuditLogExportStatus) DeepCopy() *AuditLogExportStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditRedactionSetting) DeepCopyInto(out *AuditRedactionSetting) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditRedactionSetting.
+func (in *AuditRedactionSetting) DeepCopy() *AuditRedactionSetting {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditRedactionSetting)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AuditRedactionSetting) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditRedactionSettingList) DeepCopyInto(out *AuditRedactionSettingList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AuditRedactionSetting, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditRedactionSettingList.
+func (in *AuditRedactionSettingList) DeepCopy() *AuditRedactionSettingList {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditRedactionSettingList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AuditRedactionSettingList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditRedactionSettingSpec) DeepCopyInto(out *AuditRedactionSettingSpec) {
+	*out = *in
+	in.Manifest.DeepCopyInto(&out.Manifest)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditRedactionSettingSpec.
+func (in *AuditRedactionSettingSpec) DeepCopy() *AuditRedactionSettingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditRedactionSettingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AuthProvider) DeepCopyInto(out *AuthProvider) {
 	*out = *in
@@ -635,6 +868,102 @@ func (in *AuthProviderStatus) DeepCopy() *AuthProviderStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigRepository) DeepCopyInto(out *ConfigRepository) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigRepository.
+func (in *ConfigRepository) DeepCopy() *ConfigRepository {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigRepository)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ConfigRepository) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigRepositoryList) DeepCopyInto(out *ConfigRepositoryList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ConfigRepository, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigRepositoryList.
+func (in *ConfigRepositoryList) DeepCopy() *ConfigRepositoryList {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigRepositoryList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ConfigRepositoryList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigRepositorySpec) DeepCopyInto(out *ConfigRepositorySpec) {
+	*out = *in
+	out.ConfigRepositoryManifest = in.ConfigRepositoryManifest
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigRepositorySpec.
+func (in *ConfigRepositorySpec) DeepCopy() *ConfigRepositorySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigRepositorySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigRepositoryStatus) DeepCopyInto(out *ConfigRepositoryStatus) {
+	*out = *in
+	in.LastSyncTime.DeepCopyInto(&out.LastSyncTime)
+	if in.Objects != nil {
+		in, out := &in.Objects, &out.Objects
+		*out = make([]types.ConfigRepositoryObject, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigRepositoryStatus.
+func (in *ConfigRepositoryStatus) DeepCopy() *ConfigRepositoryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigRepositoryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DefaultModelAlias) DeepCopyInto(out *DefaultModelAlias) {
 	*out = *in
@@ -849,6 +1178,11 @@ func (in *GitCredentialReferences) DeepCopyInto(out *GitCredentialReferences) {
 		*out = make([]GitCredentialReference, len(*in))
 		copy(*out, *in)
 	}
+	if in.ConfigRepositories != nil {
+		in, out := &in.ConfigRepositories, &out.ConfigRepositories
+		*out = make([]GitCredentialReference, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitCredentialReferences.
@@ -1217,10 +1551,30 @@ func (in *HostedAgentInstanceList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostedAgentInstanceRestore) DeepCopyInto(out *HostedAgentInstanceRestore) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostedAgentInstanceRestore.
+func (in *HostedAgentInstanceRestore) DeepCopy() *HostedAgentInstanceRestore {
+	if in == nil {
+		return nil
+	}
+	out := new(HostedAgentInstanceRestore)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HostedAgentInstanceSpec) DeepCopyInto(out *HostedAgentInstanceSpec) {
 	*out = *in
 	in.Manifest.DeepCopyInto(&out.Manifest)
+	if in.Restore != nil {
+		in, out := &in.Restore, &out.Restore
+		*out = new(HostedAgentInstanceRestore)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostedAgentInstanceSpec.
@@ -1240,6 +1594,10 @@ func (in *HostedAgentInstanceStatus) DeepCopyInto(out *HostedAgentInstanceStatus
 		in, out := &in.LastObservedTime, &out.LastObservedTime
 		*out = (*in).DeepCopy()
 	}
+	if in.LastActivityTime != nil {
+		in, out := &in.LastActivityTime, &out.LastActivityTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostedAgentInstanceStatus.
@@ -1523,6 +1881,109 @@ func (in *HostedAgentPoolStatus) DeepCopy() *HostedAgentPoolStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostedAgentSnapshot) DeepCopyInto(out *HostedAgentSnapshot) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostedAgentSnapshot.
+func (in *HostedAgentSnapshot) DeepCopy() *HostedAgentSnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(HostedAgentSnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HostedAgentSnapshot) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostedAgentSnapshotList) DeepCopyInto(out *HostedAgentSnapshotList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]HostedAgentSnapshot, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostedAgentSnapshotList.
+func (in *HostedAgentSnapshotList) DeepCopy() *HostedAgentSnapshotList {
+	if in == nil {
+		return nil
+	}
+	out := new(HostedAgentSnapshotList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HostedAgentSnapshotList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostedAgentSnapshotSpec) DeepCopyInto(out *HostedAgentSnapshotSpec) {
+	*out = *in
+	out.Manifest = in.Manifest
+	in.InstanceManifest.DeepCopyInto(&out.InstanceManifest)
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostedAgentSnapshotSpec.
+func (in *HostedAgentSnapshotSpec) DeepCopy() *HostedAgentSnapshotSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HostedAgentSnapshotSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostedAgentSnapshotStatus) DeepCopyInto(out *HostedAgentSnapshotStatus) {
+	*out = *in
+	if in.CompletedTime != nil {
+		in, out := &in.CompletedTime, &out.CompletedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextAttemptAt != nil {
+		in, out := &in.NextAttemptAt, &out.NextAttemptAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostedAgentSnapshotStatus.
+func (in *HostedAgentSnapshotStatus) DeepCopy() *HostedAgentSnapshotStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HostedAgentSnapshotStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HostedAgentSpec) DeepCopyInto(out *HostedAgentSpec) {
 	*out = *in
@@ -1554,6 +2015,108 @@ func (in *HostedAgentStatus) DeepCopy() *HostedAgentStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostedAgentTrigger) DeepCopyInto(out *HostedAgentTrigger) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostedAgentTrigger.
+func (in *HostedAgentTrigger) DeepCopy() *HostedAgentTrigger {
+	if in == nil {
+		return nil
+	}
+	out := new(HostedAgentTrigger)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HostedAgentTrigger) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostedAgentTriggerList) DeepCopyInto(out *HostedAgentTriggerList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]HostedAgentTrigger, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostedAgentTriggerList.
+func (in *HostedAgentTriggerList) DeepCopy() *HostedAgentTriggerList {
+	if in == nil {
+		return nil
+	}
+	out := new(HostedAgentTriggerList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HostedAgentTriggerList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostedAgentTriggerSpec) DeepCopyInto(out *HostedAgentTriggerSpec) {
+	*out = *in
+	out.Manifest = in.Manifest
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostedAgentTriggerSpec.
+func (in *HostedAgentTriggerSpec) DeepCopy() *HostedAgentTriggerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HostedAgentTriggerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostedAgentTriggerStatus) DeepCopyInto(out *HostedAgentTriggerStatus) {
+	*out = *in
+	if in.LastRunTime != nil {
+		in, out := &in.LastRunTime, &out.LastRunTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextRunTime != nil {
+		in, out := &in.NextRunTime, &out.NextRunTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastInvocationTime != nil {
+		in, out := &in.LastInvocationTime, &out.LastInvocationTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostedAgentTriggerStatus.
+func (in *HostedAgentTriggerStatus) DeepCopy() *HostedAgentTriggerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HostedAgentTriggerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ImagePullSecret) DeepCopyInto(out *ImagePullSecret) {
 	*out = *in
@@ -2215,6 +2778,81 @@ func (in *MCPNetworkPolicyStatus) DeepCopy() *MCPNetworkPolicyStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPQuotaRule) DeepCopyInto(out *MCPQuotaRule) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPQuotaRule.
+func (in *MCPQuotaRule) DeepCopy() *MCPQuotaRule {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPQuotaRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MCPQuotaRule) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPQuotaRuleList) DeepCopyInto(out *MCPQuotaRuleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]MCPQuotaRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPQuotaRuleList.
+func (in *MCPQuotaRuleList) DeepCopy() *MCPQuotaRuleList {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPQuotaRuleList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MCPQuotaRuleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPQuotaRuleSpec) DeepCopyInto(out *MCPQuotaRuleSpec) {
+	*out = *in
+	in.Manifest.DeepCopyInto(&out.Manifest)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPQuotaRuleSpec.
+func (in *MCPQuotaRuleSpec) DeepCopy() *MCPQuotaRuleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPQuotaRuleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MCPServer) DeepCopyInto(out *MCPServer) {
 	*out = *in
@@ -2346,29 +2984,71 @@ func (in *MCPServerCatalogEntryStatus) DeepCopy() *MCPServerCatalogEntryStatus {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MCPServerInstance) DeepCopyInto(out *MCPServerInstance) {
+func (in *MCPServerHealth) DeepCopyInto(out *MCPServerHealth) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
+	in.LastChecked.DeepCopyInto(&out.LastChecked)
+	in.LastSuccess.DeepCopyInto(&out.LastSuccess)
+	in.LastFailure.DeepCopyInto(&out.LastFailure)
+	in.CircuitOpenUntil.DeepCopyInto(&out.CircuitOpenUntil)
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]MCPServerHealthCheck, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPServerInstance.
-func (in *MCPServerInstance) DeepCopy() *MCPServerInstance {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPServerHealth.
+func (in *MCPServerHealth) DeepCopy() *MCPServerHealth {
 	if in == nil {
 		return nil
 	}
-	out := new(MCPServerInstance)
+	out := new(MCPServerHealth)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *MCPServerInstance) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPServerHealthCheck) DeepCopyInto(out *MCPServerHealthCheck) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPServerHealthCheck.
+func (in *MCPServerHealthCheck) DeepCopy() *MCPServerHealthCheck {
+	if in == nil {
+		return nil
 	}
-	return nil
+	out := new(MCPServerHealthCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPServerInstance) DeepCopyInto(out *MCPServerInstance) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPServerInstance.
+func (in *MCPServerInstance) DeepCopy() *MCPServerInstance {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPServerInstance)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MCPServerInstance) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -2455,6 +3135,81 @@ func (in *MCPServerList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPServerRequestPolicy) DeepCopyInto(out *MCPServerRequestPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPServerRequestPolicy.
+func (in *MCPServerRequestPolicy) DeepCopy() *MCPServerRequestPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPServerRequestPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MCPServerRequestPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPServerRequestPolicyList) DeepCopyInto(out *MCPServerRequestPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]MCPServerRequestPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPServerRequestPolicyList.
+func (in *MCPServerRequestPolicyList) DeepCopy() *MCPServerRequestPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPServerRequestPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MCPServerRequestPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPServerRequestPolicySpec) DeepCopyInto(out *MCPServerRequestPolicySpec) {
+	*out = *in
+	in.Manifest.DeepCopyInto(&out.Manifest)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPServerRequestPolicySpec.
+func (in *MCPServerRequestPolicySpec) DeepCopy() *MCPServerRequestPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPServerRequestPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MCPServerSpec) DeepCopyInto(out *MCPServerSpec) {
 	*out = *in
@@ -2513,6 +3268,11 @@ func (in *MCPServerStatus) DeepCopyInto(out *MCPServerStatus) {
 	}
 	in.LastOAuthMetadataSync.DeepCopyInto(&out.LastOAuthMetadataSync)
 	in.LastRequestTime.DeepCopyInto(&out.LastRequestTime)
+	if in.Health != nil {
+		in, out := &in.Health, &out.Health
+		*out = new(MCPServerHealth)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPServerStatus.
@@ -2525,6 +3285,167 @@ func (in *MCPServerStatus) DeepCopy() *MCPServerStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPToolApprovalPolicy) DeepCopyInto(out *MCPToolApprovalPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPToolApprovalPolicy.
+func (in *MCPToolApprovalPolicy) DeepCopy() *MCPToolApprovalPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPToolApprovalPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MCPToolApprovalPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPToolApprovalPolicyList) DeepCopyInto(out *MCPToolApprovalPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]MCPToolApprovalPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPToolApprovalPolicyList.
+func (in *MCPToolApprovalPolicyList) DeepCopy() *MCPToolApprovalPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPToolApprovalPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MCPToolApprovalPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPToolApprovalPolicySpec) DeepCopyInto(out *MCPToolApprovalPolicySpec) {
+	*out = *in
+	in.Manifest.DeepCopyInto(&out.Manifest)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPToolApprovalPolicySpec.
+func (in *MCPToolApprovalPolicySpec) DeepCopy() *MCPToolApprovalPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPToolApprovalPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPToolCallApproval) DeepCopyInto(out *MCPToolCallApproval) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPToolCallApproval.
+func (in *MCPToolCallApproval) DeepCopy() *MCPToolCallApproval {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPToolCallApproval)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MCPToolCallApproval) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPToolCallApprovalList) DeepCopyInto(out *MCPToolCallApprovalList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]MCPToolCallApproval, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPToolCallApprovalList.
+func (in *MCPToolCallApprovalList) DeepCopy() *MCPToolCallApprovalList {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPToolCallApprovalList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MCPToolCallApprovalList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPToolCallApprovalSpec) DeepCopyInto(out *MCPToolCallApprovalSpec) {
+	*out = *in
+	if in.Arguments != nil {
+		in, out := &in.Arguments, &out.Arguments
+		*out = make(json.RawMessage, len(*in))
+		copy(*out, *in)
+	}
+	if in.Approvers != nil {
+		in, out := &in.Approvers, &out.Approvers
+		*out = make([]types.Subject, len(*in))
+		copy(*out, *in)
+	}
+	in.ExpiresAt.DeepCopyInto(&out.ExpiresAt)
+	in.DecidedAt.DeepCopyInto(&out.DecidedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPToolCallApprovalSpec.
+func (in *MCPToolCallApprovalSpec) DeepCopy() *MCPToolCallApprovalSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPToolCallApprovalSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MCPTunnel) DeepCopyInto(out *MCPTunnel) {
 	*out = *in
@@ -3117,13 +4038,284 @@ func (in *ModelInfoSource) DeepCopy() *ModelInfoSource {
 	if in == nil {
 		return nil
 	}
-	out := new(ModelInfoSource)
+	out := new(ModelInfoSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ModelInfoSource) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelInfoSourceList) DeepCopyInto(out *ModelInfoSourceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ModelInfoSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelInfoSourceList.
+func (in *ModelInfoSourceList) DeepCopy() *ModelInfoSourceList {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelInfoSourceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ModelInfoSourceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelInfoSourceSpec) DeepCopyInto(out *ModelInfoSourceSpec) {
+	*out = *in
+	out.Manifest = in.Manifest
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelInfoSourceSpec.
+func (in *ModelInfoSourceSpec) DeepCopy() *ModelInfoSourceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelInfoSourceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelInfoSourceStatus) DeepCopyInto(out *ModelInfoSourceStatus) {
+	*out = *in
+	in.LastSyncTime.DeepCopyInto(&out.LastSyncTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelInfoSourceStatus.
+func (in *ModelInfoSourceStatus) DeepCopy() *ModelInfoSourceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelInfoSourceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelInfoSpec) DeepCopyInto(out *ModelInfoSpec) {
+	*out = *in
+	in.Cost.DeepCopyInto(&out.Cost)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelInfoSpec.
+func (in *ModelInfoSpec) DeepCopy() *ModelInfoSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelInfoSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelList) DeepCopyInto(out *ModelList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Model, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelList.
+func (in *ModelList) DeepCopy() *ModelList {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ModelList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelProvider) DeepCopyInto(out *ModelProvider) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelProvider.
+func (in *ModelProvider) DeepCopy() *ModelProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ModelProvider) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelProviderList) DeepCopyInto(out *ModelProviderList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ModelProvider, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelProviderList.
+func (in *ModelProviderList) DeepCopy() *ModelProviderList {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelProviderList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ModelProviderList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelProviderSpec) DeepCopyInto(out *ModelProviderSpec) {
+	*out = *in
+	in.ModelProviderManifest.DeepCopyInto(&out.ModelProviderManifest)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelProviderSpec.
+func (in *ModelProviderSpec) DeepCopy() *ModelProviderSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelProviderSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelProviderStatus) DeepCopyInto(out *ModelProviderStatus) {
+	*out = *in
+	if in.MissingConfigurationParameters != nil {
+		in, out := &in.MissingConfigurationParameters, &out.MissingConfigurationParameters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ModelsBackPopulated != nil {
+		in, out := &in.ModelsBackPopulated, &out.ModelsBackPopulated
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelProviderStatus.
+func (in *ModelProviderStatus) DeepCopy() *ModelProviderStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelProviderStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelSpec) DeepCopyInto(out *ModelSpec) {
+	*out = *in
+	in.Manifest.DeepCopyInto(&out.Manifest)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelSpec.
+func (in *ModelSpec) DeepCopy() *ModelSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelStatus) DeepCopyInto(out *ModelStatus) {
+	*out = *in
+	in.Cost.DeepCopyInto(&out.Cost)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelStatus.
+func (in *ModelStatus) DeepCopy() *ModelStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NanobotAgent) DeepCopyInto(out *NanobotAgent) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NanobotAgent.
+func (in *NanobotAgent) DeepCopy() *NanobotAgent {
+	if in == nil {
+		return nil
+	}
+	out := new(NanobotAgent)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ModelInfoSource) DeepCopyObject() runtime.Object {
+func (in *NanobotAgent) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -3131,31 +4323,31 @@ func (in *ModelInfoSource) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ModelInfoSourceList) DeepCopyInto(out *ModelInfoSourceList) {
+func (in *NanobotAgentList) DeepCopyInto(out *NanobotAgentList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]ModelInfoSource, len(*in))
+		*out = make([]NanobotAgent, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelInfoSourceList.
-func (in *ModelInfoSourceList) DeepCopy() *ModelInfoSourceList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NanobotAgentList.
+func (in *NanobotAgentList) DeepCopy() *NanobotAgentList {
 	if in == nil {
 		return nil
 	}
-	out := new(ModelInfoSourceList)
+	out := new(NanobotAgentList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ModelInfoSourceList) DeepCopyObject() runtime.Object {
+func (in *NanobotAgentList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -3163,79 +4355,89 @@ func (in *ModelInfoSourceList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ModelInfoSourceSpec) DeepCopyInto(out *ModelInfoSourceSpec) {
+func (in *NanobotAgentSpec) DeepCopyInto(out *NanobotAgentSpec) {
 	*out = *in
-	out.Manifest = in.Manifest
+	out.NanobotAgentManifest = in.NanobotAgentManifest
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelInfoSourceSpec.
-func (in *ModelInfoSourceSpec) DeepCopy() *ModelInfoSourceSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NanobotAgentSpec.
+func (in *NanobotAgentSpec) DeepCopy() *NanobotAgentSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ModelInfoSourceSpec)
+	out := new(NanobotAgentSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ModelInfoSourceStatus) DeepCopyInto(out *ModelInfoSourceStatus) {
+func (in *NanobotAgentStatus) DeepCopyInto(out *NanobotAgentStatus) {
 	*out = *in
-	in.LastSyncTime.DeepCopyInto(&out.LastSyncTime)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelInfoSourceStatus.
-func (in *ModelInfoSourceStatus) DeepCopy() *ModelInfoSourceStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NanobotAgentStatus.
+func (in *NanobotAgentStatus) DeepCopy() *NanobotAgentStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ModelInfoSourceStatus)
+	out := new(NanobotAgentStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ModelInfoSpec) DeepCopyInto(out *ModelInfoSpec) {
+func (in *NotificationChannel) DeepCopyInto(out *NotificationChannel) {
 	*out = *in
-	in.Cost.DeepCopyInto(&out.Cost)
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelInfoSpec.
-func (in *ModelInfoSpec) DeepCopy() *ModelInfoSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationChannel.
+func (in *NotificationChannel) DeepCopy() *NotificationChannel {
 	if in == nil {
 		return nil
 	}
-	out := new(ModelInfoSpec)
+	out := new(NotificationChannel)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NotificationChannel) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ModelList) DeepCopyInto(out *ModelList) {
+func (in *NotificationChannelList) DeepCopyInto(out *NotificationChannelList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Model, len(*in))
+		*out = make([]NotificationChannel, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelList.
-func (in *ModelList) DeepCopy() *ModelList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationChannelList.
+func (in *NotificationChannelList) DeepCopy() *NotificationChannelList {
 	if in == nil {
 		return nil
 	}
-	out := new(ModelList)
+	out := new(NotificationChannelList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ModelList) DeepCopyObject() runtime.Object {
+func (in *NotificationChannelList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -3243,7 +4445,23 @@ func (in *ModelList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ModelProvider) DeepCopyInto(out *ModelProvider) {
+func (in *NotificationChannelSpec) DeepCopyInto(out *NotificationChannelSpec) {
+	*out = *in
+	in.Manifest.DeepCopyInto(&out.Manifest)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationChannelSpec.
+func (in *NotificationChannelSpec) DeepCopy() *NotificationChannelSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationChannelSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationDelivery) DeepCopyInto(out *NotificationDelivery) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -3251,18 +4469,18 @@ func (in *ModelProvider) DeepCopyInto(out *ModelProvider) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelProvider.
-func (in *ModelProvider) DeepCopy() *ModelProvider {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationDelivery.
+func (in *NotificationDelivery) DeepCopy() *NotificationDelivery {
 	if in == nil {
 		return nil
 	}
-	out := new(ModelProvider)
+	out := new(NotificationDelivery)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ModelProvider) DeepCopyObject() runtime.Object {
+func (in *NotificationDelivery) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -3270,31 +4488,31 @@ func (in *ModelProvider) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ModelProviderList) DeepCopyInto(out *ModelProviderList) {
+func (in *NotificationDeliveryList) DeepCopyInto(out *NotificationDeliveryList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]ModelProvider, len(*in))
+		*out = make([]NotificationDelivery, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelProviderList.
-func (in *ModelProviderList) DeepCopy() *ModelProviderList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationDeliveryList.
+func (in *NotificationDeliveryList) DeepCopy() *NotificationDeliveryList {
 	if in == nil {
 		return nil
 	}
-	out := new(ModelProviderList)
+	out := new(NotificationDeliveryList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ModelProviderList) DeepCopyObject() runtime.Object {
+func (in *NotificationDeliveryList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -3302,99 +4520,60 @@ func (in *ModelProviderList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ModelProviderSpec) DeepCopyInto(out *ModelProviderSpec) {
-	*out = *in
-	in.ModelProviderManifest.DeepCopyInto(&out.ModelProviderManifest)
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelProviderSpec.
-func (in *ModelProviderSpec) DeepCopy() *ModelProviderSpec {
-	if in == nil {
-		return nil
-	}
-	out := new(ModelProviderSpec)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ModelProviderStatus) DeepCopyInto(out *ModelProviderStatus) {
-	*out = *in
-	if in.MissingConfigurationParameters != nil {
-		in, out := &in.MissingConfigurationParameters, &out.MissingConfigurationParameters
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.ModelsBackPopulated != nil {
-		in, out := &in.ModelsBackPopulated, &out.ModelsBackPopulated
-		*out = new(bool)
-		**out = **in
-	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelProviderStatus.
-func (in *ModelProviderStatus) DeepCopy() *ModelProviderStatus {
-	if in == nil {
-		return nil
-	}
-	out := new(ModelProviderStatus)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ModelSpec) DeepCopyInto(out *ModelSpec) {
+func (in *NotificationDeliverySpec) DeepCopyInto(out *NotificationDeliverySpec) {
 	*out = *in
-	in.Manifest.DeepCopyInto(&out.Manifest)
+	in.Event.DeepCopyInto(&out.Event)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelSpec.
-func (in *ModelSpec) DeepCopy() *ModelSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationDeliverySpec.
+func (in *NotificationDeliverySpec) DeepCopy() *NotificationDeliverySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ModelSpec)
+	out := new(NotificationDeliverySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ModelStatus) DeepCopyInto(out *ModelStatus) {
+func (in *NotificationDeliveryStatus) DeepCopyInto(out *NotificationDeliveryStatus) {
 	*out = *in
-	in.Cost.DeepCopyInto(&out.Cost)
+	in.LastAttemptAt.DeepCopyInto(&out.LastAttemptAt)
+	in.NextAttemptAt.DeepCopyInto(&out.NextAttemptAt)
+	in.DeliveredAt.DeepCopyInto(&out.DeliveredAt)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelStatus.
-func (in *ModelStatus) DeepCopy() *ModelStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationDeliveryStatus.
+func (in *NotificationDeliveryStatus) DeepCopy() *NotificationDeliveryStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ModelStatus)
+	out := new(NotificationDeliveryStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NanobotAgent) DeepCopyInto(out *NanobotAgent) {
+func (in *NotificationRule) DeepCopyInto(out *NotificationRule) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	out.Status = in.Status
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NanobotAgent.
-func (in *NanobotAgent) DeepCopy() *NanobotAgent {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationRule.
+func (in *NotificationRule) DeepCopy() *NotificationRule {
 	if in == nil {
 		return nil
 	}
-	out := new(NanobotAgent)
+	out := new(NotificationRule)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *NanobotAgent) DeepCopyObject() runtime.Object {
+func (in *NotificationRule) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -3402,31 +4581,31 @@ func (in *NanobotAgent) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NanobotAgentList) DeepCopyInto(out *NanobotAgentList) {
+func (in *NotificationRuleList) DeepCopyInto(out *NotificationRuleList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]NanobotAgent, len(*in))
+		*out = make([]NotificationRule, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NanobotAgentList.
-func (in *NanobotAgentList) DeepCopy() *NanobotAgentList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationRuleList.
+func (in *NotificationRuleList) DeepCopy() *NotificationRuleList {
 	if in == nil {
 		return nil
 	}
-	out := new(NanobotAgentList)
+	out := new(NotificationRuleList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *NanobotAgentList) DeepCopyObject() runtime.Object {
+func (in *NotificationRuleList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -3434,32 +4613,17 @@ func (in *NanobotAgentList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NanobotAgentSpec) DeepCopyInto(out *NanobotAgentSpec) {
-	*out = *in
-	out.NanobotAgentManifest = in.NanobotAgentManifest
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NanobotAgentSpec.
-func (in *NanobotAgentSpec) DeepCopy() *NanobotAgentSpec {
-	if in == nil {
-		return nil
-	}
-	out := new(NanobotAgentSpec)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NanobotAgentStatus) DeepCopyInto(out *NanobotAgentStatus) {
+func (in *NotificationRuleSpec) DeepCopyInto(out *NotificationRuleSpec) {
 	*out = *in
+	in.Manifest.DeepCopyInto(&out.Manifest)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NanobotAgentStatus.
-func (in *NanobotAgentStatus) DeepCopy() *NanobotAgentStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationRuleSpec.
+func (in *NotificationRuleSpec) DeepCopy() *NotificationRuleSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(NanobotAgentStatus)
+	out := new(NotificationRuleSpec)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -4549,10 +5713,48 @@ func (in *SkillRepositoryStatus) DeepCopy() *SkillRepositoryStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SkillRevision) DeepCopyInto(out *SkillRevision) {
+	*out = *in
+	in.Manifest.DeepCopyInto(&out.Manifest)
+	in.IndexedAt.DeepCopyInto(&out.IndexedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SkillRevision.
+func (in *SkillRevision) DeepCopy() *SkillRevision {
+	if in == nil {
+		return nil
+	}
+	out := new(SkillRevision)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SkillScanOverride) DeepCopyInto(out *SkillScanOverride) {
+	*out = *in
+	in.OverriddenAt.DeepCopyInto(&out.OverriddenAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SkillScanOverride.
+func (in *SkillScanOverride) DeepCopy() *SkillScanOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(SkillScanOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SkillSpec) DeepCopyInto(out *SkillSpec) {
 	*out = *in
 	in.SkillManifest.DeepCopyInto(&out.SkillManifest)
+	if in.ScanOverride != nil {
+		in, out := &in.ScanOverride, &out.ScanOverride
+		*out = new(SkillScanOverride)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SkillSpec.
@@ -4569,6 +5771,18 @@ func (in *SkillSpec) DeepCopy() *SkillSpec {
 func (in *SkillStatus) DeepCopyInto(out *SkillStatus) {
 	*out = *in
 	in.LastIndexedAt.DeepCopyInto(&out.LastIndexedAt)
+	if in.ScanFindings != nil {
+		in, out := &in.ScanFindings, &out.ScanFindings
+		*out = make([]types.SkillScanFinding, len(*in))
+		copy(*out, *in)
+	}
+	if in.Revisions != nil {
+		in, out := &in.Revisions, &out.Revisions
+		*out = make([]SkillRevision, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SkillStatus.
@@ -4900,6 +6114,80 @@ func (in *SystemMCPServerStatus) DeepCopy() *SystemMCPServerStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ToolCallEnforcementSetting) DeepCopyInto(out *ToolCallEnforcementSetting) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ToolCallEnforcementSetting.
+func (in *ToolCallEnforcementSetting) DeepCopy() *ToolCallEnforcementSetting {
+	if in == nil {
+		return nil
+	}
+	out := new(ToolCallEnforcementSetting)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ToolCallEnforcementSetting) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ToolCallEnforcementSettingList) DeepCopyInto(out *ToolCallEnforcementSettingList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ToolCallEnforcementSetting, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ToolCallEnforcementSettingList.
+func (in *ToolCallEnforcementSettingList) DeepCopy() *ToolCallEnforcementSettingList {
+	if in == nil {
+		return nil
+	}
+	out := new(ToolCallEnforcementSettingList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ToolCallEnforcementSettingList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ToolCallEnforcementSettingSpec) DeepCopyInto(out *ToolCallEnforcementSettingSpec) {
+	*out = *in
+	in.Manifest.DeepCopyInto(&out.Manifest)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ToolCallEnforcementSettingSpec.
+func (in *ToolCallEnforcementSettingSpec) DeepCopy() *ToolCallEnforcementSettingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ToolCallEnforcementSettingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UserDefaultRoleSetting) DeepCopyInto(out *UserDefaultRoleSetting) {
 	*out = *in
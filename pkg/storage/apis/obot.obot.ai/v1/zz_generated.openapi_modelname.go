@@ -20,6 +20,36 @@ func (in AccessControlRuleSpec) OpenAPIModelName() string {
 	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.AccessControlRuleSpec"
 }
 
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in AccessRequest) OpenAPIModelName() string {
+	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.AccessRequest"
+}
+
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in AccessRequestList) OpenAPIModelName() string {
+	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.AccessRequestList"
+}
+
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in AccessRequestSetting) OpenAPIModelName() string {
+	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.AccessRequestSetting"
+}
+
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in AccessRequestSettingList) OpenAPIModelName() string {
+	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.AccessRequestSettingList"
+}
+
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in AccessRequestSettingSpec) OpenAPIModelName() string {
+	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.AccessRequestSettingSpec"
+}
+
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in AccessRequestSpec) OpenAPIModelName() string {
+	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.AccessRequestSpec"
+}
+
 // OpenAPIModelName returns the OpenAPI model name for this type.
 func (in AgentCatalog) OpenAPIModelName() string {
 	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.AgentCatalog"
@@ -115,6 +145,21 @@ func (in AuditLogExportStatus) OpenAPIModelName() string {
 	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.AuditLogExportStatus"
 }
 
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in AuditRedactionSetting) OpenAPIModelName() string {
+	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.AuditRedactionSetting"
+}
+
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in AuditRedactionSettingList) OpenAPIModelName() string {
+	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.AuditRedactionSettingList"
+}
+
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in AuditRedactionSettingSpec) OpenAPIModelName() string {
+	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.AuditRedactionSettingSpec"
+}
+
 // OpenAPIModelName returns the OpenAPI model name for this type.
 func (in AuthProvider) OpenAPIModelName() string {
 	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.AuthProvider"
@@ -135,6 +180,26 @@ func (in AuthProviderStatus) OpenAPIModelName() string {
 	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.AuthProviderStatus"
 }
 
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in ConfigRepository) OpenAPIModelName() string {
+	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.ConfigRepository"
+}
+
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in ConfigRepositoryList) OpenAPIModelName() string {
+	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.ConfigRepositoryList"
+}
+
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in ConfigRepositorySpec) OpenAPIModelName() string {
+	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.ConfigRepositorySpec"
+}
+
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in ConfigRepositoryStatus) OpenAPIModelName() string {
+	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.ConfigRepositoryStatus"
+}
+
 // OpenAPIModelName returns the OpenAPI model name for this type.
 func (in DefaultModelAlias) OpenAPIModelName() string {
 	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.DefaultModelAlias"
@@ -260,6 +325,11 @@ func (in HostedAgentInstanceList) OpenAPIModelName() string {
 	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.HostedAgentInstanceList"
 }
 
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in HostedAgentInstanceRestore) OpenAPIModelName() string {
+	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.HostedAgentInstanceRestore"
+}
+
 // OpenAPIModelName returns the OpenAPI model name for this type.
 func (in HostedAgentInstanceSpec) OpenAPIModelName() string {
 	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.HostedAgentInstanceSpec"
@@ -325,6 +395,26 @@ func (in HostedAgentPoolStatus) OpenAPIModelName() string {
 	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.HostedAgentPoolStatus"
 }
 
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in HostedAgentSnapshot) OpenAPIModelName() string {
+	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.HostedAgentSnapshot"
+}
+
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in HostedAgentSnapshotList) OpenAPIModelName() string {
+	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.HostedAgentSnapshotList"
+}
+
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in HostedAgentSnapshotSpec) OpenAPIModelName() string {
+	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.HostedAgentSnapshotSpec"
+}
+
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in HostedAgentSnapshotStatus) OpenAPIModelName() string {
+	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.HostedAgentSnapshotStatus"
+}
+
 // OpenAPIModelName returns the OpenAPI model name for this type.
 func (in HostedAgentSpec) OpenAPIModelName() string {
 	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.HostedAgentSpec"
@@ -335,6 +425,26 @@ func (in HostedAgentStatus) OpenAPIModelName() string {
 	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.HostedAgentStatus"
 }
 
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in HostedAgentTrigger) OpenAPIModelName() string {
+	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.HostedAgentTrigger"
+}
+
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in HostedAgentTriggerList) OpenAPIModelName() string {
+	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.HostedAgentTriggerList"
+}
+
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in HostedAgentTriggerSpec) OpenAPIModelName() string {
+	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.HostedAgentTriggerSpec"
+}
+
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in HostedAgentTriggerStatus) OpenAPIModelName() string {
+	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.HostedAgentTriggerStatus"
+}
+
 // OpenAPIModelName returns the OpenAPI model name for this type.
 func (in ImagePullSecret) OpenAPIModelName() string {
 	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.ImagePullSecret"
@@ -455,6 +565,21 @@ func (in MCPNetworkPolicyStatus) OpenAPIModelName() string {
 	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.MCPNetworkPolicyStatus"
 }
 
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in MCPQuotaRule) OpenAPIModelName() string {
+	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.MCPQuotaRule"
+}
+
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in MCPQuotaRuleList) OpenAPIModelName() string {
+	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.MCPQuotaRuleList"
+}
+
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in MCPQuotaRuleSpec) OpenAPIModelName() string {
+	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.MCPQuotaRuleSpec"
+}
+
 // OpenAPIModelName returns the OpenAPI model name for this type.
 func (in MCPServer) OpenAPIModelName() string {
 	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.MCPServer"
@@ -480,6 +605,16 @@ func (in MCPServerCatalogEntryStatus) OpenAPIModelName() string {
 	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.MCPServerCatalogEntryStatus"
 }
 
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in MCPServerHealth) OpenAPIModelName() string {
+	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.MCPServerHealth"
+}
+
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in MCPServerHealthCheck) OpenAPIModelName() string {
+	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.MCPServerHealthCheck"
+}
+
 // OpenAPIModelName returns the OpenAPI model name for this type.
 func (in MCPServerInstance) OpenAPIModelName() string {
 	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.MCPServerInstance"
@@ -500,6 +635,21 @@ func (in MCPServerList) OpenAPIModelName() string {
 	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.MCPServerList"
 }
 
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in MCPServerRequestPolicy) OpenAPIModelName() string {
+	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.MCPServerRequestPolicy"
+}
+
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in MCPServerRequestPolicyList) OpenAPIModelName() string {
+	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.MCPServerRequestPolicyList"
+}
+
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in MCPServerRequestPolicySpec) OpenAPIModelName() string {
+	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.MCPServerRequestPolicySpec"
+}
+
 // OpenAPIModelName returns the OpenAPI model name for this type.
 func (in MCPServerSpec) OpenAPIModelName() string {
 	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.MCPServerSpec"
@@ -510,6 +660,36 @@ func (in MCPServerStatus) OpenAPIModelName() string {
 	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.MCPServerStatus"
 }
 
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in MCPToolApprovalPolicy) OpenAPIModelName() string {
+	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.MCPToolApprovalPolicy"
+}
+
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in MCPToolApprovalPolicyList) OpenAPIModelName() string {
+	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.MCPToolApprovalPolicyList"
+}
+
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in MCPToolApprovalPolicySpec) OpenAPIModelName() string {
+	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.MCPToolApprovalPolicySpec"
+}
+
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in MCPToolCallApproval) OpenAPIModelName() string {
+	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.MCPToolCallApproval"
+}
+
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in MCPToolCallApprovalList) OpenAPIModelName() string {
+	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.MCPToolCallApprovalList"
+}
+
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in MCPToolCallApprovalSpec) OpenAPIModelName() string {
+	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.MCPToolCallApprovalSpec"
+}
+
 // OpenAPIModelName returns the OpenAPI model name for this type.
 func (in MCPTunnel) OpenAPIModelName() string {
 	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.MCPTunnel"
@@ -705,6 +885,56 @@ func (in NanobotAgentStatus) OpenAPIModelName() string {
 	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.NanobotAgentStatus"
 }
 
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in NotificationChannel) OpenAPIModelName() string {
+	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.NotificationChannel"
+}
+
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in NotificationChannelList) OpenAPIModelName() string {
+	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.NotificationChannelList"
+}
+
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in NotificationChannelSpec) OpenAPIModelName() string {
+	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.NotificationChannelSpec"
+}
+
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in NotificationDelivery) OpenAPIModelName() string {
+	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.NotificationDelivery"
+}
+
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in NotificationDeliveryList) OpenAPIModelName() string {
+	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.NotificationDeliveryList"
+}
+
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in NotificationDeliverySpec) OpenAPIModelName() string {
+	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.NotificationDeliverySpec"
+}
+
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in NotificationDeliveryStatus) OpenAPIModelName() string {
+	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.NotificationDeliveryStatus"
+}
+
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in NotificationRule) OpenAPIModelName() string {
+	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.NotificationRule"
+}
+
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in NotificationRuleList) OpenAPIModelName() string {
+	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.NotificationRuleList"
+}
+
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in NotificationRuleSpec) OpenAPIModelName() string {
+	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.NotificationRuleSpec"
+}
+
 // OpenAPIModelName returns the OpenAPI model name for this type.
 func (in OAuthAuthRequest) OpenAPIModelName() string {
 	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.OAuthAuthRequest"
@@ -935,6 +1165,16 @@ func (in SkillRepositoryStatus) OpenAPIModelName() string {
 	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.SkillRepositoryStatus"
 }
 
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in SkillRevision) OpenAPIModelName() string {
+	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.SkillRevision"
+}
+
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in SkillScanOverride) OpenAPIModelName() string {
+	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.SkillScanOverride"
+}
+
 // OpenAPIModelName returns the OpenAPI model name for this type.
 func (in SkillSpec) OpenAPIModelName() string {
 	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.SkillSpec"
@@ -1005,6 +1245,21 @@ func (in SystemMCPServerStatus) OpenAPIModelName() string {
 	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.SystemMCPServerStatus"
 }
 
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in ToolCallEnforcementSetting) OpenAPIModelName() string {
+	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.ToolCallEnforcementSetting"
+}
+
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in ToolCallEnforcementSettingList) OpenAPIModelName() string {
+	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.ToolCallEnforcementSettingList"
+}
+
+// OpenAPIModelName returns the OpenAPI model name for this type.
+func (in ToolCallEnforcementSettingSpec) OpenAPIModelName() string {
+	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.ToolCallEnforcementSettingSpec"
+}
+
 // OpenAPIModelName returns the OpenAPI model name for this type.
 func (in UserDefaultRoleSetting) OpenAPIModelName() string {
 	return "com.github.obot-platform.obot.pkg.storage.apis.obot.obot.ai.v1.UserDefaultRoleSetting"
@@ -2,13 +2,16 @@ package blob
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"log/slog"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
 	"github.com/obot-platform/obot/apiclient/types"
 )
 
@@ -56,6 +59,9 @@ func (a *AzureStore) Download(ctx context.Context, bucket, key string) (io.ReadC
 
 	resp, err := client.DownloadStream(ctx, bucket, key, &azblob.DownloadStreamOptions{})
 	if err != nil {
+		if bloberror.HasCode(err, bloberror.BlobNotFound) {
+			err = errors.Join(fs.ErrNotExist, err)
+		}
 		return nil, fmt.Errorf("failed to download from Azure Blob Storage: %w", err)
 	}
 	return resp.Body, nil
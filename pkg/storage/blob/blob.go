@@ -17,6 +17,8 @@ type BlobStore interface {
 
 	// Download retrieves the object at the given bucket and key.
 	// The caller is responsible for closing the returned ReadCloser.
+	// An object that does not exist is reported with an error wrapping
+	// fs.ErrNotExist.
 	Download(ctx context.Context, bucket, key string) (io.ReadCloser, error)
 
 	// Delete removes the object at the given bucket and key.
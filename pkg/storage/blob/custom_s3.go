@@ -2,8 +2,10 @@ package blob
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"log/slog"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -11,6 +13,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/obot-platform/obot/apiclient/types"
 )
 
@@ -64,6 +67,10 @@ func (c *CustomS3Store) Download(ctx context.Context, bucket, key string) (io.Re
 		Key:    aws.String(key),
 	})
 	if err != nil {
+		var noSuchKey *s3types.NoSuchKey
+		if errors.As(err, &noSuchKey) {
+			err = errors.Join(fs.ErrNotExist, err)
+		}
 		return nil, fmt.Errorf("failed to download from custom S3 storage: %w", err)
 	}
 	return output.Body, nil
@@ -2,8 +2,10 @@ package blob
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"log/slog"
 
 	gcsstorage "cloud.google.com/go/storage"
@@ -53,6 +55,9 @@ func (g *GCSStore) Download(ctx context.Context, bucket, key string) (io.ReadClo
 	reader, err := client.Bucket(bucket).Object(key).NewReader(ctx)
 	if err != nil {
 		client.Close()
+		if errors.Is(err, gcsstorage.ErrObjectNotExist) {
+			err = errors.Join(fs.ErrNotExist, err)
+		}
 		return nil, fmt.Errorf("failed to download from GCS: %w", err)
 	}
 	// The client must stay open while the reader is in use.
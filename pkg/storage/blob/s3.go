@@ -2,8 +2,10 @@ package blob
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"log/slog"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -11,6 +13,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/aws/aws-sdk-go-v2/service/sts"
 	"github.com/obot-platform/obot/apiclient/types"
 )
@@ -62,6 +65,10 @@ func (s *S3Store) Download(ctx context.Context, bucket, key string) (io.ReadClos
 		Key:    aws.String(key),
 	})
 	if err != nil {
+		var noSuchKey *s3types.NoSuchKey
+		if errors.As(err, &noSuchKey) {
+			return nil, fmt.Errorf("failed to download from S3: %w", errors.Join(fs.ErrNotExist, err))
+		}
 		slog.Error("S3 download failed", "bucket", bucket, "key", key, "error", err)
 		return nil, fmt.Errorf("failed to download from S3: %w", err)
 	}
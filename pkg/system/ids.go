@@ -28,13 +28,20 @@ const (
 	SkillPrefix                   = "sk1"
 	SkillAccessRulePrefix         = "sar1"
 	AgentCatalogPrefix            = "ac1"
+	ConfigRepositoryPrefix        = "cfr1"
 	HarnessPrefix                 = "hrn1"
 	HostedAgentPrefix             = "ha1"
 	HostedAgentInstancePrefix     = "hai1"
 	HostedAgentAccessRulePrefix   = "haar1"
+	HostedAgentTriggerPrefix      = "hat1"
+	HostedAgentSnapshotPrefix     = "has1"
 	OAuthClientPrefix             = "oc1"
 	OAuthAuthRequestPrefix        = "oar1"
 	AccessControlRulePrefix       = "acr1"
+	AccessRequestPrefix           = "ar1"
+	NotificationChannelPrefix     = "nc1"
+	NotificationRulePrefix        = "nr1"
+	NotificationDeliveryPrefix    = "nd1"
 	MCPWebhookValidationPrefix    = "mwv1"
 	PowerUserWorkspacePrefix      = "puw1"
 	AuditLogExportPrefix          = "ael1"
@@ -42,6 +49,10 @@ const (
 	SystemMCPServerPrefix         = "sms1"
 	ModelAccessPolicyPrefix       = "map1"
 	MessagePolicyPrefix           = "mp1"
+	MCPQuotaRulePrefix            = "mqr1"
+	MCPToolApprovalPolicyPrefix   = "mtap1"
+	MCPToolCallApprovalPrefix     = "mtca1"
+	MCPServerRequestPolicyPrefix  = "msrp1"
 	NanobotAgentPrefix            = "nba1"
 	PublishedArtifactPrefix       = "pa1"
 	OktaGroupMigrationPrefix      = "ogm1"
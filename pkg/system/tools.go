@@ -13,6 +13,11 @@ const (
 	AzureModelProvider               = "azure-model-provider"
 	AzureEntraModelProvider          = "azure-entra-model-provider"
 
+	// GenericChatCompletionsModelProvider is the built-in provider for self-hosted
+	// Chat Completions services, implemented in pkg/gateway/genericchat. It runs
+	// in-process instead of as a daemon from the provider registry.
+	GenericChatCompletionsModelProvider = "generic-chat-completions-model-provider"
+
 	// LocalAuthProvider is the built-in username/password auth provider, implemented in
 	// pkg/localauth. It runs in-process instead of as a daemon from the provider registry.
 	LocalAuthProvider = "local-auth-provider"
@@ -23,16 +28,19 @@ const (
 	OpenAIAPIKeyEnvVar    = "OPENAI_API_KEY"
 	AnthropicAPIKeyEnvVar = "ANTHROPIC_API_KEY"
 
-	DefaultNamespace       = "default"
-	DefaultCatalog         = "default"
-	DefaultSkillRepository = "default"
-	DefaultAgentCatalog    = "default"
-	DefaultModelInfoSource = "default"
-	DefaultMDMAssetSource  = "default"
-	DefaultRoleSettingName = "user-default-role-setting"
-	K8sSettingsName        = "k8s-settings"
-	AppPreferencesName     = "app-preferences"
-	AppNotificationName    = "app-notification"
+	DefaultNamespace               = "default"
+	DefaultCatalog                 = "default"
+	DefaultSkillRepository         = "default"
+	DefaultAgentCatalog            = "default"
+	DefaultModelInfoSource         = "default"
+	DefaultMDMAssetSource          = "default"
+	DefaultRoleSettingName         = "user-default-role-setting"
+	K8sSettingsName                = "k8s-settings"
+	AppPreferencesName             = "app-preferences"
+	AppNotificationName            = "app-notification"
+	AuditRedactionSettingName      = "audit-redaction-setting"
+	ToolCallEnforcementSettingName = "tool-call-enforcement-setting"
+	AccessRequestSettingName       = "access-request-setting"
 
 	ModelProviderCredential = "sys.model.provider.credential"
 
@@ -0,0 +1,263 @@
+// Package toolconstraint checks MCP tool call arguments against the tool
+// argument constraints of access control rules.
+package toolconstraint
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/obot-platform/obot/apiclient/types"
+)
+
+// patterns caches compiled patterns, since the same constraints are checked on
+// every call.
+var patterns sync.Map
+
+// ViolationError describes the first constraint a tool call violated.
+type ViolationError struct {
+	Tool    string
+	Path    string
+	Message string
+}
+
+func (e *ViolationError) Error() string {
+	if e.Path == "" {
+		return fmt.Sprintf("arguments of tool %q %s", e.Tool, e.Message)
+	}
+	return fmt.Sprintf("argument %q of tool %q %s", e.Path, e.Tool, e.Message)
+}
+
+// Check returns a *ViolationError if the arguments of a call to the tool do not
+// satisfy every constraint for that tool.
+func Check(constraints []types.ToolArgumentConstraint, tool string, arguments json.RawMessage) error {
+	var (
+		decoded any
+		parsed  bool
+	)
+	for _, constraint := range constraints {
+		if constraint.Tool != tool {
+			continue
+		}
+		if !parsed {
+			var err error
+			if decoded, err = decodeArguments(arguments); err != nil {
+				return &ViolationError{Tool: tool, Message: fmt.Sprintf("are not valid JSON: %v", err)}
+			}
+			parsed = true
+		}
+
+		if constraint.Schema != nil {
+			if path, msg := checkSchema(*constraint.Schema, decoded, ""); msg != "" {
+				return &ViolationError{Tool: tool, Path: path, Message: msg}
+			}
+		}
+		for _, rule := range constraint.Arguments {
+			if msg := checkRule(rule, decoded); msg != "" {
+				return &ViolationError{Tool: tool, Path: rule.Path, Message: msg}
+			}
+		}
+	}
+	return nil
+}
+
+func decodeArguments(arguments json.RawMessage) (any, error) {
+	if len(bytes.TrimSpace(arguments)) == 0 {
+		return map[string]any{}, nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(arguments))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	if value == nil {
+		return map[string]any{}, nil
+	}
+	return value, nil
+}
+
+func checkRule(rule types.ToolArgumentRule, arguments any) string {
+	value, ok := lookup(arguments, rule.Path)
+	if !ok {
+		if rule.Required {
+			return "is required"
+		}
+		return ""
+	}
+
+	values := []any{value}
+	if items, ok := value.([]any); ok {
+		values = items
+	}
+	for _, value := range values {
+		if rule.Pattern != "" {
+			if msg := checkPattern(rule.Pattern, value); msg != "" {
+				return msg
+			}
+		}
+		if len(rule.AllowedValues) > 0 && !slices.Contains(rule.AllowedValues, valueString(value)) {
+			return fmt.Sprintf("has a value that is not allowed: %s", valueString(value))
+		}
+	}
+	return ""
+}
+
+func lookup(value any, path string) (any, bool) {
+	for field := range strings.SplitSeq(path, ".") {
+		object, ok := value.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if value, ok = object[field]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+func checkSchema(schema types.ToolArgumentSchema, value any, path string) (string, string) {
+	if schema.Type != "" && !hasType(value, schema.Type) {
+		return path, fmt.Sprintf("must be of type %s", schema.Type)
+	}
+	if len(schema.Enum) > 0 && !slices.Contains(schema.Enum, valueString(value)) {
+		return path, fmt.Sprintf("has a value that is not allowed: %s", valueString(value))
+	}
+
+	switch value := value.(type) {
+	case map[string]any:
+		for _, name := range schema.Required {
+			if _, ok := value[name]; !ok {
+				return join(path, name), "is required"
+			}
+		}
+		for name, field := range value {
+			property, ok := schema.Properties[name]
+			if !ok {
+				if schema.AdditionalProperties != nil && !*schema.AdditionalProperties {
+					return join(path, name), "is not allowed"
+				}
+				continue
+			}
+			if fieldPath, msg := checkSchema(property, field, join(path, name)); msg != "" {
+				return fieldPath, msg
+			}
+		}
+	case []any:
+		if schema.MaxItems != nil && len(value) > *schema.MaxItems {
+			return path, fmt.Sprintf("must have at most %d items", *schema.MaxItems)
+		}
+		if schema.Items != nil {
+			for i, item := range value {
+				if itemPath, msg := checkSchema(*schema.Items, item, fmt.Sprintf("%s[%d]", path, i)); msg != "" {
+					return itemPath, msg
+				}
+			}
+		}
+	case string:
+		length := len([]rune(value))
+		if schema.MinLength != nil && length < *schema.MinLength {
+			return path, fmt.Sprintf("must be at least %d characters", *schema.MinLength)
+		}
+		if schema.MaxLength != nil && length > *schema.MaxLength {
+			return path, fmt.Sprintf("must be at most %d characters", *schema.MaxLength)
+		}
+		if schema.Pattern != "" {
+			if msg := checkPattern(schema.Pattern, value); msg != "" {
+				return path, msg
+			}
+		}
+	case json.Number:
+		number, err := value.Float64()
+		if err != nil {
+			return path, "is not a valid number"
+		}
+		if schema.Minimum != nil && number < *schema.Minimum {
+			return path, fmt.Sprintf("must be at least %v", *schema.Minimum)
+		}
+		if schema.Maximum != nil && number > *schema.Maximum {
+			return path, fmt.Sprintf("must be at most %v", *schema.Maximum)
+		}
+	}
+	return "", ""
+}
+
+func hasType(value any, typeName string) bool {
+	switch value := value.(type) {
+	case map[string]any:
+		return typeName == "object"
+	case []any:
+		return typeName == "array"
+	case string:
+		return typeName == "string"
+	case bool:
+		return typeName == "boolean"
+	case nil:
+		return typeName == "null"
+	case json.Number:
+		if typeName == "number" {
+			return true
+		}
+		if typeName != "integer" {
+			return false
+		}
+		number, err := value.Float64()
+		return err == nil && number == math.Trunc(number)
+	}
+	return false
+}
+
+func checkPattern(pattern string, value any) string {
+	s, ok := value.(string)
+	if !ok {
+		return "must be a string"
+	}
+	re, err := compile(pattern)
+	if err != nil {
+		return fmt.Sprintf("cannot be checked: invalid pattern: %v", err)
+	}
+	if !re.MatchString(s) {
+		return fmt.Sprintf("does not match pattern %s", pattern)
+	}
+	return ""
+}
+
+func compile(pattern string) (*regexp.Regexp, error) {
+	if re, ok := patterns.Load(pattern); ok {
+		return re.(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	patterns.Store(pattern, re)
+	return re, nil
+}
+
+// valueString is how a value is compared with allowed values: strings as they
+// are and anything else as JSON.
+func valueString(value any) string {
+	switch value := value.(type) {
+	case string:
+		return value
+	case json.Number:
+		return value.String()
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+func join(path, field string) string {
+	if path == "" {
+		return field
+	}
+	return path + "." + field
+}
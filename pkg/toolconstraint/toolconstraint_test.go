@@ -0,0 +1,79 @@
+package toolconstraint
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheck(t *testing.T) {
+	maxRows := 100.0
+	noExtra := false
+	constraints := []types.ToolArgumentConstraint{
+		{
+			Tool: "github_query",
+			Arguments: []types.ToolArgumentRule{
+				{Path: "repo", Required: true, Pattern: `^acme/[\w.-]+$`},
+				{Path: "options.visibility", AllowedValues: []string{"public", "internal"}},
+				{Path: "labels", AllowedValues: []string{"bug", "security"}},
+			},
+		},
+		{
+			Tool: "sql_query",
+			Schema: &types.ToolArgumentSchema{
+				Type:                 "object",
+				Required:             []string{"query"},
+				AdditionalProperties: &noExtra,
+				Properties: map[string]types.ToolArgumentSchema{
+					"query": {Type: "string", Pattern: `(?i)^\s*select\b`},
+					"limit": {Type: "integer", Maximum: &maxRows},
+				},
+			},
+		},
+	}
+
+	for _, tt := range []struct {
+		name      string
+		tool      string
+		arguments string
+		path      string
+		errorMsg  string
+	}{
+		{name: "allowed repo", tool: "github_query", arguments: `{"repo":"acme/api","labels":["bug"]}`},
+		{name: "other org", tool: "github_query", arguments: `{"repo":"evil/api"}`, path: "repo", errorMsg: "does not match pattern"},
+		{name: "missing repo", tool: "github_query", arguments: `{}`, path: "repo", errorMsg: "is required"},
+		{name: "repo not a string", tool: "github_query", arguments: `{"repo":42}`, path: "repo", errorMsg: "must be a string"},
+		{name: "nested value not allowed", tool: "github_query", arguments: `{"repo":"acme/api","options":{"visibility":"private"}}`, path: "options.visibility", errorMsg: "not allowed: private"},
+		{name: "array element not allowed", tool: "github_query", arguments: `{"repo":"acme/api","labels":["bug","wontfix"]}`, path: "labels", errorMsg: "not allowed: wontfix"},
+		{name: "select", tool: "sql_query", arguments: `{"query":" SELECT * FROM users","limit":10}`},
+		{name: "delete", tool: "sql_query", arguments: `{"query":"DELETE FROM users"}`, path: "query", errorMsg: "does not match pattern"},
+		{name: "limit too high", tool: "sql_query", arguments: `{"query":"select 1","limit":1000}`, path: "limit", errorMsg: "at most 100"},
+		{name: "fractional limit", tool: "sql_query", arguments: `{"query":"select 1","limit":1.5}`, path: "limit", errorMsg: "of type integer"},
+		{name: "extra field", tool: "sql_query", arguments: `{"query":"select 1","database":"prod"}`, path: "database", errorMsg: "is not allowed"},
+		{name: "no query", tool: "sql_query", arguments: ``, path: "query", errorMsg: "is required"},
+		{name: "unconstrained tool", tool: "list_tables", arguments: `{"anything":true}`},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Check(constraints, tt.tool, json.RawMessage(tt.arguments))
+			if tt.errorMsg == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			var violation *ViolationError
+			require.True(t, errors.As(err, &violation), "expected a violation, got %v", err)
+			assert.Equal(t, tt.tool, violation.Tool)
+			assert.Equal(t, tt.path, violation.Path)
+			assert.Contains(t, violation.Message, tt.errorMsg)
+		})
+	}
+}
+
+func TestCheckInvalidJSON(t *testing.T) {
+	err := Check([]types.ToolArgumentConstraint{{Tool: "t", Arguments: []types.ToolArgumentRule{{Path: "a", Required: true}}}}, "t", json.RawMessage(`{"a":`))
+	assert.ErrorContains(t, err, "not valid JSON")
+}
@@ -111,9 +111,22 @@ func dial(ctx context.Context, serverURL, token string) (*websocket.Conn, string
 	return nil, "", fmt.Errorf("failed to connect tunnel: %s: %s", response.Status, message)
 }
 
+// Options configures what a tunnel client serves besides its forwarded HTTP
+// requests.
+type Options struct {
+	// StdioServers are local stdio MCP servers, by name, that Obot reaches at
+	// http://<name>.stdio.obot.internal through the tunnel.
+	StdioServers map[string]StdioServer
+}
+
 // Run keeps a tunnel connected until ctx is canceled. Failed handshakes and
 // lost connections are retried with bounded exponential backoff.
 func Run(ctx context.Context, serverURL, token string) error {
+	return RunWithOptions(ctx, serverURL, token, Options{})
+}
+
+// RunWithOptions is Run for a tunnel client configured by opts.
+func RunWithOptions(ctx context.Context, serverURL, token string, opts Options) error {
 	if _, err := ConnectURL(serverURL); err != nil {
 		return err
 	}
@@ -121,6 +134,13 @@ func Run(ctx context.Context, serverURL, token string) error {
 		return errors.New("tunnel token is required")
 	}
 
+	// The client outlives individual connections so that stdio server
+	// sessions survive a reconnect.
+	client := newForwardHTTPClient()
+	if len(opts.StdioServers) > 0 {
+		client.Transport = newStdioTransport(ctx, opts.StdioServers, client.Transport)
+	}
+
 	backoff := time.Second
 	for {
 		connectionLog := slog.Default()
@@ -131,7 +151,7 @@ func Run(ctx context.Context, serverURL, token string) error {
 			}
 			connectionLog.Info("Tunnel connected")
 			backoff = time.Second
-			err = serveConnection(ctx, connection, name)
+			err = serveConnectionWithClient(ctx, connection, name, client)
 			_ = connection.Close()
 		}
 		if ctx.Err() != nil {
@@ -0,0 +1,737 @@
+package tunnel
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"net/http"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/yaml"
+)
+
+// StdioHostSuffix marks a tunneled remote MCP server as one of the tunnel
+// client's stdio servers. A request to http://github.stdio.obot.internal/mcp is
+// served by the stdio server named "github" in the client's configuration
+// instead of being sent over the network.
+const StdioHostSuffix = ".stdio.obot.internal"
+
+const (
+	stdioSessionHeader      = "Mcp-Session-Id"
+	stdioMaxMessageBytes    = 16 * 1024 * 1024
+	stdioMaxSessions        = 100
+	stdioBacklogSize        = 100
+	stdioStartTimeout       = time.Minute
+	stdioSessionIdleTimeout = 30 * time.Minute
+	// A server that crashes more than stdioMaxRestarts times in a row, each
+	// time within stdioStableAfter of starting, ends its session.
+	stdioMaxRestarts = 5
+	stdioStableAfter = time.Minute
+)
+
+var errStdioSessionClosed = errors.New("stdio MCP session closed")
+
+// StdioServer is a local MCP server that speaks MCP over stdin and stdout.
+type StdioServer struct {
+	Command string            `json:"command"`
+	Args    []string          `json:"args,omitempty"`
+	Env     map[string]string `json:"env,omitempty"`
+}
+
+// StdioConfig is the file read by obot tunnel --stdio-config.
+type StdioConfig struct {
+	Servers map[string]StdioServer `json:"servers"`
+}
+
+// LoadStdioConfig reads a YAML or JSON StdioConfig and returns its servers by
+// name.
+func LoadStdioConfig(path string) (map[string]StdioServer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read stdio server config: %w", err)
+	}
+
+	var config StdioConfig
+	if err := yaml.UnmarshalStrict(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse stdio server config: %w", err)
+	}
+	for name, server := range config.Servers {
+		if errs := validation.IsDNS1123Label(name); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid stdio server name %q: %s", name, strings.Join(errs, "; "))
+		}
+		if strings.TrimSpace(server.Command) == "" {
+			return nil, fmt.Errorf("stdio server %q: command is required", name)
+		}
+	}
+	return config.Servers, nil
+}
+
+// stdioTransport serves requests for stdio servers as MCP Streamable HTTP,
+// running one server process per MCP session. Requests for other hosts go to
+// next.
+type stdioTransport struct {
+	ctx     context.Context
+	servers map[string]StdioServer
+	next    http.RoundTripper
+
+	mu       sync.Mutex
+	sessions map[string]*stdioSession
+}
+
+func newStdioTransport(ctx context.Context, servers map[string]StdioServer, next http.RoundTripper) *stdioTransport {
+	t := &stdioTransport{
+		ctx:      ctx,
+		servers:  servers,
+		next:     next,
+		sessions: map[string]*stdioSession{},
+	}
+	go t.closeIdleSessions()
+	return t
+}
+
+func (t *stdioTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	name, ok := strings.CutSuffix(strings.ToLower(req.URL.Hostname()), StdioHostSuffix)
+	if !ok {
+		return t.next.RoundTrip(req)
+	}
+	if req.Body != nil {
+		defer req.Body.Close()
+	}
+
+	server, ok := t.servers[name]
+	if !ok {
+		return nil, fmt.Errorf("stdio MCP server %q is not configured on this tunnel", name)
+	}
+
+	sessionID := req.Header.Get(stdioSessionHeader)
+	if sessionID == "" {
+		if req.Method != http.MethodPost {
+			return stdioResponse(req, http.StatusBadRequest, "text/plain", []byte("Mcp-Session-Id header is required")), nil
+		}
+		return t.initialize(req, name, server)
+	}
+
+	t.mu.Lock()
+	session := t.sessions[sessionID]
+	t.mu.Unlock()
+	if session == nil || session.name != name {
+		// A client that receives 404 for its session starts a new one.
+		return stdioResponse(req, http.StatusNotFound, "text/plain", []byte("MCP session not found")), nil
+	}
+
+	switch req.Method {
+	case http.MethodPost:
+		messages, errResponse := readStdioMessages(req)
+		if errResponse != nil {
+			return errResponse, nil
+		}
+		return session.post(req, messages)
+	case http.MethodGet:
+		return session.listen(req), nil
+	case http.MethodDelete:
+		t.closeSession(session)
+		return stdioResponse(req, http.StatusOK, "", nil), nil
+	default:
+		return stdioResponse(req, http.StatusMethodNotAllowed, "", nil), nil
+	}
+}
+
+// initialize starts a session, and the server process behind it, for a
+// request that has no session yet. That must be the initialize request.
+func (t *stdioTransport) initialize(req *http.Request, name string, server StdioServer) (*http.Response, error) {
+	messages, errResponse := readStdioMessages(req)
+	if errResponse != nil {
+		return errResponse, nil
+	}
+	if len(messages) != 1 || messages[0].Method != "initialize" || !messages[0].isRequest() {
+		return stdioResponse(req, http.StatusBadRequest, "text/plain", []byte("an MCP session must start with an initialize request")), nil
+	}
+
+	id, err := newStdioSessionID()
+	if err != nil {
+		return nil, err
+	}
+	session := newStdioSession(t.ctx, id, name, server, messages[0])
+
+	t.mu.Lock()
+	if len(t.sessions) >= stdioMaxSessions {
+		t.mu.Unlock()
+		session.cancel()
+		return stdioResponse(req, http.StatusServiceUnavailable, "text/plain", []byte("too many MCP sessions")), nil
+	}
+	t.sessions[id] = session
+	t.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(req.Context(), stdioStartTimeout)
+	defer cancel()
+	process, result, err := session.start(ctx)
+	if err != nil {
+		t.closeSession(session)
+		return nil, err
+	}
+	slog.Info("Stdio MCP server started", "server", name)
+
+	go func() {
+		session.run(process)
+		t.closeSession(session)
+	}()
+
+	response := stdioResponse(req, http.StatusOK, "application/json", result)
+	response.Header.Set(stdioSessionHeader, id)
+	return response, nil
+}
+
+func (t *stdioTransport) closeSession(session *stdioSession) {
+	t.mu.Lock()
+	if t.sessions[session.id] == session {
+		delete(t.sessions, session.id)
+	}
+	t.mu.Unlock()
+	session.cancel()
+}
+
+// closeIdleSessions stops servers for sessions that clients have abandoned
+// without deleting them.
+func (t *stdioTransport) closeIdleSessions() {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-t.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		var idle []*stdioSession
+		t.mu.Lock()
+		for _, session := range t.sessions {
+			if session.idleSince(time.Now()) > stdioSessionIdleTimeout {
+				idle = append(idle, session)
+			}
+		}
+		t.mu.Unlock()
+		for _, session := range idle {
+			slog.Info("Closing idle stdio MCP session", "server", session.name)
+			t.closeSession(session)
+		}
+	}
+}
+
+// stdioMessage is a JSON-RPC message, compacted onto one line as the stdio
+// transport requires.
+type stdioMessage struct {
+	ID     json.RawMessage `json:"id,omitempty"`
+	Method string          `json:"method,omitempty"`
+	raw    []byte
+}
+
+func parseStdioMessage(data []byte) (stdioMessage, error) {
+	var message stdioMessage
+	if err := json.Unmarshal(data, &message); err != nil {
+		return message, err
+	}
+	var compacted bytes.Buffer
+	if err := json.Compact(&compacted, data); err != nil {
+		return message, err
+	}
+	message.raw = compacted.Bytes()
+	return message, nil
+}
+
+func (m stdioMessage) hasID() bool {
+	return len(m.ID) > 0 && string(m.ID) != "null"
+}
+
+func (m stdioMessage) isRequest() bool {
+	return m.Method != "" && m.hasID()
+}
+
+func (m stdioMessage) isResponse() bool {
+	return m.Method == "" && m.hasID()
+}
+
+func (m stdioMessage) key() string {
+	var compacted bytes.Buffer
+	if err := json.Compact(&compacted, m.ID); err != nil {
+		return string(m.ID)
+	}
+	return compacted.String()
+}
+
+// readStdioMessages reads a POST body of one JSON-RPC message or a batch. It
+// returns a response instead when the body is not acceptable.
+func readStdioMessages(req *http.Request) ([]stdioMessage, *http.Response) {
+	if req.Body == nil {
+		return nil, stdioResponse(req, http.StatusBadRequest, "text/plain", []byte("request body is required"))
+	}
+	body, err := io.ReadAll(io.LimitReader(req.Body, stdioMaxMessageBytes+1))
+	if err != nil {
+		return nil, stdioResponse(req, http.StatusBadRequest, "text/plain", []byte("failed to read request body"))
+	}
+	if len(body) > stdioMaxMessageBytes {
+		return nil, stdioResponse(req, http.StatusRequestEntityTooLarge, "", nil)
+	}
+
+	items := []json.RawMessage{body}
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(trimmed, &items); err != nil {
+			return nil, stdioResponse(req, http.StatusBadRequest, "text/plain", []byte("invalid JSON-RPC batch"))
+		}
+	}
+	messages := make([]stdioMessage, 0, len(items))
+	for _, item := range items {
+		message, err := parseStdioMessage(item)
+		if err != nil {
+			return nil, stdioResponse(req, http.StatusBadRequest, "text/plain", []byte("invalid JSON-RPC message"))
+		}
+		messages = append(messages, message)
+	}
+	if len(messages) == 0 {
+		return nil, stdioResponse(req, http.StatusBadRequest, "text/plain", []byte("empty JSON-RPC batch"))
+	}
+	return messages, nil
+}
+
+// stdioSession is one MCP session with a stdio server. The server process is
+// restarted if it exits, and the session's initialize handshake is replayed to
+// it so the client can carry on with the same session.
+type stdioSession struct {
+	id         string
+	name       string
+	server     StdioServer
+	initialize stdioMessage
+	ctx        context.Context
+	cancel     context.CancelFunc
+
+	writeMu sync.Mutex
+
+	mu          sync.Mutex
+	stdin       io.WriteCloser
+	ready       chan struct{}
+	initialized []byte
+	pending     map[string]*stdioStream
+	streams     []*stdioStream
+	backlog     []stdioMessage
+	active      int
+	lastUsed    time.Time
+}
+
+// stdioStream is an open event stream to the client. It receives the
+// responses to the requests that opened it, and the messages the server sends
+// on its own while it is the most recently opened stream.
+type stdioStream struct {
+	messages chan stdioMessage
+	done     chan struct{}
+}
+
+type stdioProcess struct {
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	stdout *bufio.Scanner
+}
+
+func newStdioSessionID() (string, error) {
+	id := make([]byte, 24)
+	if _, err := rand.Read(id); err != nil {
+		return "", fmt.Errorf("failed to generate MCP session ID: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(id), nil
+}
+
+func newStdioSession(ctx context.Context, id, name string, server StdioServer, initialize stdioMessage) *stdioSession {
+	ctx, cancel := context.WithCancel(ctx)
+	return &stdioSession{
+		id:         id,
+		name:       name,
+		server:     server,
+		initialize: initialize,
+		ctx:        ctx,
+		cancel:     cancel,
+		ready:      make(chan struct{}),
+		pending:    map[string]*stdioStream{},
+		lastUsed:   time.Now(),
+	}
+}
+
+func (s *stdioSession) idleSince(now time.Time) time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.active > 0 {
+		return 0
+	}
+	return now.Sub(s.lastUsed)
+}
+
+// start runs the server and completes the initialize handshake with it,
+// returning the server's response to the initialize request.
+func (s *stdioSession) start(ctx context.Context) (*stdioProcess, []byte, error) {
+	cmd := exec.CommandContext(s.ctx, s.server.Command, s.server.Args...)
+	cmd.Env = os.Environ()
+	for _, key := range slices.Sorted(maps.Keys(s.server.Env)) {
+		cmd.Env = append(cmd.Env, key+"="+s.server.Env[key])
+	}
+	cmd.Stderr = &stdioLogWriter{server: s.name}
+	cmd.WaitDelay = 5 * time.Second
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, nil, fmt.Errorf("failed to start stdio MCP server %q: %w", s.name, err)
+	}
+
+	process := &stdioProcess{
+		cmd:    cmd,
+		stdin:  stdin,
+		stdout: bufio.NewScanner(stdout),
+	}
+	process.stdout.Buffer(make([]byte, 64*1024), stdioMaxMessageBytes)
+
+	stop := context.AfterFunc(ctx, func() { _ = cmd.Process.Kill() })
+	defer stop()
+
+	result, err := s.handshake(process)
+	if err != nil {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+		if ctx.Err() != nil {
+			err = ctx.Err()
+		}
+		return nil, nil, fmt.Errorf("failed to initialize stdio MCP server %q: %w", s.name, err)
+	}
+
+	s.mu.Lock()
+	s.stdin = stdin
+	close(s.ready)
+	s.mu.Unlock()
+	return process, result, nil
+}
+
+func (s *stdioSession) handshake(process *stdioProcess) ([]byte, error) {
+	if _, err := process.stdin.Write(append(bytes.Clone(s.initialize.raw), '\n')); err != nil {
+		return nil, err
+	}
+	for process.stdout.Scan() {
+		message, err := parseStdioMessage(process.stdout.Bytes())
+		if err != nil {
+			continue
+		}
+		if !message.isResponse() || message.key() != s.initialize.key() {
+			s.dispatch(message)
+			continue
+		}
+
+		s.mu.Lock()
+		initialized := s.initialized
+		s.mu.Unlock()
+		if initialized != nil {
+			if _, err := process.stdin.Write(append(bytes.Clone(initialized), '\n')); err != nil {
+				return nil, err
+			}
+		}
+		return message.raw, nil
+	}
+	if err := process.stdout.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.ErrUnexpectedEOF
+}
+
+// run reads from the server until the session ends, restarting the server
+// whenever it exits.
+func (s *stdioSession) run(process *stdioProcess) {
+	backoff := time.Second
+	for failures := 0; ; {
+		if process != nil {
+			started := time.Now()
+			err := s.serve(process)
+			if s.ctx.Err() != nil {
+				return
+			}
+			if time.Since(started) > stdioStableAfter {
+				failures, backoff = 0, time.Second
+			}
+			slog.Warn("Stdio MCP server exited", "server", s.name, "error", err)
+		}
+
+		if failures++; failures > stdioMaxRestarts {
+			slog.Error("Stdio MCP server keeps exiting, ending its session", "server", s.name)
+			return
+		}
+		timer := time.NewTimer(backoff)
+		select {
+		case <-s.ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		backoff = min(backoff*2, 30*time.Second)
+
+		ctx, cancel := context.WithTimeout(s.ctx, stdioStartTimeout)
+		var err error
+		process, _, err = s.start(ctx)
+		cancel()
+		if err != nil {
+			slog.Warn("Failed to restart stdio MCP server", "server", s.name, "error", err)
+			continue
+		}
+		slog.Info("Stdio MCP server restarted", "server", s.name)
+	}
+}
+
+// serve dispatches the server's messages until it exits, then fails the
+// requests it had not answered.
+func (s *stdioSession) serve(process *stdioProcess) error {
+	for process.stdout.Scan() {
+		message, err := parseStdioMessage(process.stdout.Bytes())
+		if err != nil {
+			slog.Debug("Ignoring non-JSON-RPC output from stdio MCP server", "server", s.name)
+			continue
+		}
+		s.dispatch(message)
+	}
+	scanErr := process.stdout.Err()
+	_ = process.cmd.Process.Kill()
+	err := process.cmd.Wait()
+
+	s.mu.Lock()
+	s.stdin = nil
+	s.ready = make(chan struct{})
+	pending := s.pending
+	s.pending = map[string]*stdioStream{}
+	s.mu.Unlock()
+
+	for key, stream := range pending {
+		stream.send(stdioMessage{
+			ID:  json.RawMessage(key),
+			raw: fmt.Appendf(nil, `{"jsonrpc":"2.0","id":%s,"error":{"code":-32603,"message":"stdio MCP server exited"}}`, key),
+		})
+	}
+
+	if scanErr != nil {
+		return scanErr
+	}
+	return err
+}
+
+// dispatch routes a message from the server: a response to the stream that
+// sent its request, anything else to the most recently opened stream.
+// Messages with nowhere to go wait for the next stream.
+func (s *stdioSession) dispatch(message stdioMessage) {
+	message.raw = bytes.Clone(message.raw)
+
+	s.mu.Lock()
+	var stream *stdioStream
+	if message.isResponse() {
+		stream = s.pending[message.key()]
+		delete(s.pending, message.key())
+	} else if len(s.streams) > 0 {
+		stream = s.streams[len(s.streams)-1]
+	} else {
+		s.backlog = append(s.backlog, message)
+		if len(s.backlog) > stdioBacklogSize {
+			s.backlog = s.backlog[1:]
+		}
+	}
+	s.mu.Unlock()
+
+	if stream != nil {
+		stream.send(message)
+	}
+}
+
+func (st *stdioStream) send(message stdioMessage) {
+	select {
+	case st.messages <- message:
+	case <-st.done:
+	}
+}
+
+func (s *stdioSession) write(ctx context.Context, messages []stdioMessage) error {
+	s.mu.Lock()
+	ready := s.ready
+	s.lastUsed = time.Now()
+	s.mu.Unlock()
+
+	select {
+	case <-ready:
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-s.ctx.Done():
+		return errStdioSessionClosed
+	}
+
+	s.mu.Lock()
+	stdin := s.stdin
+	s.mu.Unlock()
+	if stdin == nil {
+		return errors.New("stdio MCP server is restarting")
+	}
+
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	for _, message := range messages {
+		if _, err := stdin.Write(append(bytes.Clone(message.raw), '\n')); err != nil {
+			return fmt.Errorf("failed to write to stdio MCP server: %w", err)
+		}
+	}
+	return nil
+}
+
+// post sends the client's messages to the server. Requests are answered on
+// an event stream that stays open until each of them has a response.
+func (s *stdioSession) post(req *http.Request, messages []stdioMessage) (*http.Response, error) {
+	var requests []string
+	for _, message := range messages {
+		if message.isRequest() {
+			requests = append(requests, message.key())
+		}
+		if message.Method == "notifications/initialized" {
+			// Replayed, after initialize, to a restarted server.
+			s.mu.Lock()
+			s.initialized = message.raw
+			s.mu.Unlock()
+		}
+	}
+
+	if len(requests) == 0 {
+		if err := s.write(req.Context(), messages); err != nil {
+			return nil, err
+		}
+		return stdioResponse(req, http.StatusAccepted, "", nil), nil
+	}
+
+	stream := s.openStream(requests)
+	if err := s.write(req.Context(), messages); err != nil {
+		s.closeStream(stream)
+		return nil, err
+	}
+	return s.streamResponse(req, stream, len(requests)), nil
+}
+
+// listen opens an event stream for messages the server sends on its own.
+func (s *stdioSession) listen(req *http.Request) *http.Response {
+	return s.streamResponse(req, s.openStream(nil), -1)
+}
+
+func (s *stdioSession) openStream(requests []string) *stdioStream {
+	stream := &stdioStream{
+		messages: make(chan stdioMessage, stdioBacklogSize),
+		done:     make(chan struct{}),
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, key := range requests {
+		s.pending[key] = stream
+	}
+	s.streams = append(s.streams, stream)
+	for _, message := range s.backlog {
+		stream.messages <- message
+	}
+	s.backlog = nil
+	s.active++
+	s.lastUsed = time.Now()
+	return stream
+}
+
+func (s *stdioSession) closeStream(stream *stdioStream) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.streams = slices.DeleteFunc(s.streams, func(other *stdioStream) bool { return other == stream })
+	maps.DeleteFunc(s.pending, func(_ string, other *stdioStream) bool { return other == stream })
+	close(stream.done)
+	s.active--
+	s.lastUsed = time.Now()
+}
+
+// streamResponse writes the stream's messages as server-sent events until
+// responses have been written, or indefinitely if responses is negative.
+func (s *stdioSession) streamResponse(req *http.Request, stream *stdioStream, responses int) *http.Response {
+	reader, writer := io.Pipe()
+	go func() {
+		defer s.closeStream(stream)
+		defer writer.Close()
+		for responses != 0 {
+			select {
+			case message := <-stream.messages:
+				if _, err := fmt.Fprintf(writer, "event: message\ndata: %s\n\n", message.raw); err != nil {
+					return
+				}
+				if message.isResponse() && responses > 0 {
+					responses--
+				}
+			case <-req.Context().Done():
+				return
+			case <-s.ctx.Done():
+				return
+			}
+		}
+	}()
+
+	response := stdioResponse(req, http.StatusOK, "text/event-stream", nil)
+	response.Header.Set("Cache-Control", "no-cache")
+	response.Body = reader
+	response.ContentLength = -1
+	return response
+}
+
+func stdioResponse(req *http.Request, status int, contentType string, body []byte) *http.Response {
+	response := &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        make(http.Header),
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+	if contentType != "" {
+		response.Header.Set("Content-Type", contentType)
+	}
+	return response
+}
+
+// stdioLogWriter logs a stdio server's stderr one line at a time.
+type stdioLogWriter struct {
+	server string
+	buf    []byte
+}
+
+func (w *stdioLogWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	for {
+		line, rest, ok := bytes.Cut(w.buf, []byte("\n"))
+		if !ok {
+			break
+		}
+		slog.Debug("Stdio MCP server output", "server", w.server, "line", string(bytes.TrimRight(line, "\r")))
+		w.buf = rest
+	}
+	if len(w.buf) > 64*1024 {
+		slog.Debug("Stdio MCP server output", "server", w.server, "line", string(w.buf))
+		w.buf = nil
+	}
+	return len(p), nil
+}
@@ -0,0 +1,278 @@
+package tunnel
+
+import (
+	"bufio"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const stdioTestURL = "http://helper" + StdioHostSuffix + "/mcp"
+
+// TestStdioHelperServer is the stdio MCP server the other tests run, as a
+// subprocess of the test binary. It does nothing when run as a test.
+func TestStdioHelperServer(*testing.T) {
+	if os.Getenv("OBOT_TUNNEL_STDIO_HELPER") != "1" {
+		return
+	}
+
+	initialized := false
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		var request struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+			Params struct {
+				Name string `json:"name"`
+			} `json:"params"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &request); err != nil || len(request.ID) == 0 {
+			continue
+		}
+		reply := func(key string, value any) {
+			data, _ := json.Marshal(map[string]any{"jsonrpc": "2.0", "id": request.ID, key: value})
+			fmt.Printf("%s\n", data)
+		}
+
+		switch {
+		case request.Method == "initialize":
+			initialized = true
+			fmt.Fprintln(os.Stderr, "initializing")
+			reply("result", map[string]any{"protocolVersion": "2025-06-18"})
+		case !initialized:
+			reply("error", map[string]any{"code": -32600, "message": "not initialized"})
+		case request.Params.Name == "crash":
+			os.Exit(1)
+		default:
+			fmt.Println(`{"jsonrpc":"2.0","method":"notifications/progress","params":{"progress":1}}`)
+			reply("result", map[string]any{"pid": os.Getpid()})
+		}
+	}
+	os.Exit(0)
+}
+
+func newStdioTestClient(t *testing.T) *http.Client {
+	t.Helper()
+	servers := map[string]StdioServer{
+		"helper": {
+			Command: os.Args[0],
+			Args:    []string{"-test.run=^TestStdioHelperServer$"},
+			Env:     map[string]string{"OBOT_TUNNEL_STDIO_HELPER": "1"},
+		},
+	}
+	next := roundTripFunc(func(*http.Request) (*http.Response, error) {
+		return nil, errors.New("unexpected network request")
+	})
+	return &http.Client{Transport: newStdioTransport(t.Context(), servers, next)}
+}
+
+func stdioRequest(t *testing.T, client *http.Client, method, sessionID, body string) *http.Response {
+	t.Helper()
+	request, err := http.NewRequestWithContext(t.Context(), method, stdioTestURL, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sessionID != "" {
+		request.Header.Set(stdioSessionHeader, sessionID)
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { response.Body.Close() })
+	return response
+}
+
+func initializeStdioSession(t *testing.T, client *http.Client) string {
+	t.Helper()
+	response := stdioRequest(t, client, http.MethodPost, "", `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{}}`)
+	body, _ := io.ReadAll(response.Body)
+	sessionID := response.Header.Get(stdioSessionHeader)
+	if response.StatusCode != http.StatusOK || sessionID == "" || !strings.Contains(string(body), `"protocolVersion"`) {
+		t.Fatalf("initialize response = %d %q, session %q", response.StatusCode, body, sessionID)
+	}
+
+	response = stdioRequest(t, client, http.MethodPost, sessionID, `{"jsonrpc":"2.0","method":"notifications/initialized"}`)
+	if response.StatusCode != http.StatusAccepted {
+		t.Fatalf("initialized notification response = %d", response.StatusCode)
+	}
+	return sessionID
+}
+
+// callStdioTool returns the events of the stream that answers a tools/call.
+func callStdioTool(t *testing.T, client *http.Client, sessionID string, id int, name string) []string {
+	t.Helper()
+	response := stdioRequest(t, client, http.MethodPost, sessionID,
+		fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"method":"tools/call","params":{"name":%q}}`, id, name))
+	if response.StatusCode != http.StatusOK || response.Header.Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("tools/call response = %d %q", response.StatusCode, response.Header.Get("Content-Type"))
+	}
+
+	var events []string
+	scanner := bufio.NewScanner(response.Body)
+	for scanner.Scan() {
+		if data, ok := strings.CutPrefix(scanner.Text(), "data: "); ok {
+			events = append(events, data)
+		}
+	}
+	return events
+}
+
+func stdioResultPID(t *testing.T, event string) int {
+	t.Helper()
+	var response struct {
+		Result struct {
+			PID int `json:"pid"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal([]byte(event), &response); err != nil || response.Result.PID == 0 {
+		t.Fatalf("event %q is not a successful result", event)
+	}
+	return response.Result.PID
+}
+
+func TestStdioTransportBridgesSessions(t *testing.T) {
+	client := newStdioTestClient(t)
+	sessionID := initializeStdioSession(t, client)
+
+	events := callStdioTool(t, client, sessionID, 2, "pid")
+	if len(events) != 2 || !strings.Contains(events[0], "notifications/progress") {
+		t.Fatalf("events = %q, want the progress notification and then the result", events)
+	}
+	stdioResultPID(t, events[1])
+
+	// A second session runs its own server.
+	otherEvents := callStdioTool(t, client, initializeStdioSession(t, client), 2, "pid")
+	if stdioResultPID(t, otherEvents[1]) == stdioResultPID(t, events[1]) {
+		t.Fatal("sessions share a server process")
+	}
+
+	if response := stdioRequest(t, client, http.MethodDelete, sessionID, ""); response.StatusCode != http.StatusOK {
+		t.Fatalf("DELETE response = %d", response.StatusCode)
+	}
+	response := stdioRequest(t, client, http.MethodPost, sessionID, `{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"pid"}}`)
+	if response.StatusCode != http.StatusNotFound {
+		t.Fatalf("response for a deleted session = %d, want 404", response.StatusCode)
+	}
+}
+
+func TestStdioTransportRestartsExitedServer(t *testing.T) {
+	client := newStdioTestClient(t)
+	sessionID := initializeStdioSession(t, client)
+	firstPID := stdioResultPID(t, callStdioTool(t, client, sessionID, 2, "pid")[1])
+
+	events := callStdioTool(t, client, sessionID, 3, "crash")
+	if len(events) != 1 || !strings.Contains(events[0], `"id":3`) || !strings.Contains(events[0], `"code":-32603`) {
+		t.Fatalf("events = %q, want an error response to the interrupted request", events)
+	}
+
+	// The session carries on with a new process, which the bridge has
+	// initialized; the helper rejects calls before initialize.
+	events = callStdioTool(t, client, sessionID, 4, "pid")
+	if secondPID := stdioResultPID(t, events[len(events)-1]); secondPID == firstPID {
+		t.Fatalf("server was not restarted: pid %d", secondPID)
+	}
+}
+
+func TestStdioTransportRejectsRequests(t *testing.T) {
+	client := newStdioTestClient(t)
+
+	response := stdioRequest(t, client, http.MethodPost, "", `{"jsonrpc":"2.0","id":1,"method":"tools/list"}`)
+	if response.StatusCode != http.StatusBadRequest {
+		t.Fatalf("response for a request before initialize = %d, want 400", response.StatusCode)
+	}
+	response = stdioRequest(t, client, http.MethodPost, "unknown", `{"jsonrpc":"2.0","id":1,"method":"tools/list"}`)
+	if response.StatusCode != http.StatusNotFound {
+		t.Fatalf("response for an unknown session = %d, want 404", response.StatusCode)
+	}
+
+	request, err := http.NewRequestWithContext(t.Context(), http.MethodPost, "http://other"+StdioHostSuffix+"/mcp", strings.NewReader("{}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Do(request); err == nil || !strings.Contains(err.Error(), "not configured") {
+		t.Fatalf("request to an unconfigured server error = %v", err)
+	}
+}
+
+func TestClientForwarderServesStdioServer(t *testing.T) {
+	clientConnection, handlerConnection := net.Pipe()
+	defer clientConnection.Close()
+	forwarder := &clientForwarder{client: newStdioTestClient(t), name: "office"}
+	done := make(chan error, 1)
+	go func() { done <- forwarder.serve(t.Context(), handlerConnection) }()
+
+	request, err := http.NewRequest(http.MethodPost, "http://obot-tunnel-forward/",
+		strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	request.Header.Set(forwardTargetHeader, base64.RawURLEncoding.EncodeToString([]byte(stdioTestURL)))
+	if err := request.Write(clientConnection); err != nil {
+		t.Fatal(err)
+	}
+	response, err := http.ReadResponse(bufio.NewReader(clientConnection), request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(response.Body)
+	response.Body.Close()
+	if response.StatusCode != http.StatusOK || response.Header.Get(stdioSessionHeader) == "" || !strings.Contains(string(body), `"protocolVersion"`) {
+		t.Fatalf("response = %d %q, headers %#v", response.StatusCode, body, response.Header)
+	}
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadStdioConfig(t *testing.T) {
+	dir := t.TempDir()
+	for name, test := range map[string]struct {
+		config  string
+		wantErr string
+	}{
+		"valid": {
+			config: "servers:\n  github:\n    command: github-mcp-server\n    args: [stdio]\n    env:\n      GITHUB_HOST: github.example.com\n",
+		},
+		"invalid name": {
+			config:  "servers:\n  GitHub_Server:\n    command: github-mcp-server\n",
+			wantErr: "invalid stdio server name",
+		},
+		"missing command": {
+			config:  "servers:\n  github:\n    args: [stdio]\n",
+			wantErr: "command is required",
+		},
+		"unknown field": {
+			config:  "servers:\n  github:\n    command: github-mcp-server\n    cwd: /tmp\n",
+			wantErr: "failed to parse",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(dir, strings.ReplaceAll(name, " ", "-")+".yaml")
+			if err := os.WriteFile(path, []byte(test.config), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			servers, err := LoadStdioConfig(path)
+			if test.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+					t.Fatalf("LoadStdioConfig() error = %v, want %q", err, test.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if server := servers["github"]; server.Command != "github-mcp-server" || server.Env["GITHUB_HOST"] != "github.example.com" {
+				t.Fatalf("servers = %#v", servers)
+			}
+		})
+	}
+}
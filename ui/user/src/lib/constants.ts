@@ -17,6 +17,7 @@ export const PAGE_SIZE = 50;
 export const CommonModelProviderIds = {
 	OLLAMA: 'ollama-model-provider',
 	GENERIC_RESPONSES: 'generic-responses-model-provider',
+	GENERIC_CHAT_COMPLETIONS: 'generic-chat-completions-model-provider',
 	GROQ: 'groq-model-provider',
 	VLLM: 'vllm-model-provider',
 	ANTHROPIC: 'anthropic-model-provider',
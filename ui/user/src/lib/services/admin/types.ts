@@ -499,13 +499,20 @@ export interface ImagePullSecretTestRequest {
 
 // Git credentials
 
+export type GitCredentialType = 'token' | 'sshKey' | 'githubApp';
+
 export interface GitCredential {
 	id: string;
 	created?: string;
 	deleted?: string;
 	displayName: string;
 	host: string;
+	type?: GitCredentialType;
+	knownHosts?: string;
+	githubAppID?: string;
+	githubAppInstallationID?: string;
 	tokenConfigured: boolean;
+	privateKeyConfigured?: boolean;
 	uses: GitCredentialUses;
 }
 
@@ -523,7 +530,12 @@ export interface GitCredentialUse {
 export interface GitCredentialManifest {
 	displayName: string;
 	host: string;
+	type?: GitCredentialType;
 	token?: string;
+	privateKey?: string;
+	knownHosts?: string;
+	githubAppID?: string;
+	githubAppInstallationID?: string;
 }
 export interface ImagePullSecretTestResponse {
 	success: boolean;
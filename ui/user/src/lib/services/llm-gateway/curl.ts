@@ -44,6 +44,27 @@ export function renderCurlExample(ctx: RenderContext): SnippetBlock {
 		return { language: 'bash', code };
 	}
 
+	if (ctx.provider.shortKey === 'generic-chat-completions') {
+		const body = JSON.stringify(
+			{
+				model,
+				messages: [{ role: 'user', content: 'hello' }]
+			},
+			null,
+			2
+		);
+		const code = [
+			`export OPENAI_BASE_URL="${ctx.baseURL}"`,
+			`export OPENAI_API_KEY="${loginSubstitution(ctx.obotURL)}"`,
+			'',
+			'curl $OPENAI_BASE_URL/v1/chat/completions \\',
+			'  -H "Authorization: Bearer $OPENAI_API_KEY" \\',
+			'  -H "Content-Type: application/json" \\',
+			`  -d '${body}'`
+		].join('\n');
+		return { language: 'bash', code };
+	}
+
 	// OpenAI-compatible Responses API
 	const body = JSON.stringify(
 		{
@@ -4,6 +4,7 @@ export type ProviderShortKey =
 	| 'openai'
 	| 'anthropic'
 	| 'generic-responses'
+	| 'generic-chat-completions'
 	| 'aws-bedrock-anthropic'
 	| 'aws-bedrock-openai'
 	| 'aws-bedrock-api-key-anthropic'
@@ -39,6 +40,12 @@ export const PROVIDER_CONNECTIONS: Record<ProviderShortKey, ProviderConnection>
 		displayName: 'Generic Responses Compatible',
 		routePath: 'generic-responses'
 	},
+	'generic-chat-completions': {
+		id: CommonModelProviderIds.GENERIC_CHAT_COMPLETIONS,
+		shortKey: 'generic-chat-completions',
+		displayName: 'Generic Chat Completions Compatible',
+		routePath: 'generic-chat-completions'
+	},
 	'aws-bedrock-anthropic': {
 		id: CommonModelProviderIds.AMAZON_BEDROCK,
 		shortKey: 'aws-bedrock-anthropic',
@@ -93,6 +100,7 @@ export const SUPPORTED_PROVIDER_IDS = new Set<string>([
 	CommonModelProviderIds.OPENAI,
 	CommonModelProviderIds.ANTHROPIC,
 	CommonModelProviderIds.GENERIC_RESPONSES,
+	CommonModelProviderIds.GENERIC_CHAT_COMPLETIONS,
 	CommonModelProviderIds.AMAZON_BEDROCK,
 	CommonModelProviderIds.AMAZON_BEDROCK_API_KEY,
 	CommonModelProviderIds.AZURE,
@@ -18,7 +18,8 @@ import {
 	type MCPSubField,
 	type OrgUser,
 	type RuntimeFormData,
-	type SystemMCPServerCatalogEntry
+	type SystemMCPServerCatalogEntry,
+	type Version
 } from '..';
 import { AiClient, MAX_CATALOG_ENTRY_SHORT_DESCRIPTION_LENGTH } from './constants';
 
@@ -196,15 +197,15 @@ export function hasMissingSecretBindingConfig(
 	return false;
 }
 
-export function isKubernetesRuntimeBackend(engine?: string | null): boolean {
-	return engine === 'kubernetes' || engine === 'k8s';
+export function secretBindingsEnabled(version?: Version | null): boolean {
+	return version?.secretBindingsEnabled === true;
 }
 
 export function getSecretBindingEngineError(
 	manifest?: SecretBindingManifest | null
 ): string | undefined {
 	if (!manifestHasSecretBindings(manifest)) return undefined;
-	return 'This MCP server uses Kubernetes Secret bindings and can only be launched when Obot is using the Kubernetes engine.';
+	return 'This MCP server uses secret bindings and can only be launched when Obot has a secret provider configured.';
 }
 
 export function requiresUserUpdate(server?: MCPCatalogServer) {
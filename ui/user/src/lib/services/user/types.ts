@@ -938,6 +938,7 @@ export interface Version {
 	messagePoliciesEnabled?: boolean;
 	agentsEnabled?: boolean;
 	hideK8sDetails?: boolean;
+	secretBindingsEnabled?: boolean;
 	disableLegacyChat?: boolean;
 }
 